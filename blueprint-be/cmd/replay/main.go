@@ -0,0 +1,179 @@
+// replay 이벤트 로그 재생 도구 (감사용)
+//
+// 분산 매칭 엔진의 마켓별 이벤트 로그(events:{market})를 재생해 주문장과 사용자별 잔액 변화를 다시 계산한다.
+//
+//	go run ./cmd/replay                        # 모든 마켓, 최신 스냅샷 이후 이벤트만 재생
+//	go run ./cmd/replay -market 12:success     # 특정 마켓 (쉼표로 여러 개)
+//	go run ./cmd/replay -from-start -verify    # 스트림 처음부터 재생하고 DB 주문 잔량과 대조
+//	go run ./cmd/replay -snapshot -compact     # 재생 결과를 스냅샷으로 저장하고 오래된 이벤트 압축
+//
+// 압축된 스트림은 처음부터 재생해도 스냅샷 이전 이벤트가 없으므로 -from-start 결과가 일부만 반영된다.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"blueprint/internal/config"
+	"blueprint/internal/database"
+	"blueprint/internal/services"
+
+	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
+)
+
+func main() {
+	marketsFlag := flag.String("market", "", "재생할 마켓 (milestoneID:optionID, 쉼표 구분, 비우면 전체)")
+	fromStart := flag.Bool("from-start", false, "스냅샷을 무시하고 스트림 처음부터 재생")
+	saveSnapshot := flag.Bool("snapshot", false, "재생 결과를 스냅샷으로 저장")
+	compact := flag.Bool("compact", false, "스냅샷에 포함되고 보존 기간이 지난 이벤트 제거")
+	verify := flag.Bool("verify", false, "재생한 주문장을 DB의 열린 주문과 대조 (불일치 시 종료 코드 1)")
+	asJSON := flag.Bool("json", false, "결과를 JSON 스냅샷 형식으로 출력")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	if err := moduleRedis.InitRedis(&cfg.Config); err != nil {
+		log.Fatalf("Failed to connect to Redis: %v", err)
+	}
+	defer moduleRedis.CloseRedis()
+
+	if *verify {
+		if err := database.Connect(cfg); err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+	}
+
+	ctx := context.Background()
+	eventSourcing := services.NewOrderEventSourcing(moduleRedis.GetClient())
+
+	markets := splitMarkets(*marketsFlag)
+	if len(markets) == 0 {
+		discovered, err := eventSourcing.MarketStreams(ctx)
+		if err != nil {
+			log.Fatalf("Failed to list event streams: %v", err)
+		}
+		markets = discovered
+	}
+
+	mismatched := 0
+	for _, marketKey := range markets {
+		replayer, err := eventSourcing.ReplayMarket(ctx, marketKey, !*fromStart)
+		if err != nil {
+			log.Fatalf("Failed to replay %s: %v", marketKey, err)
+		}
+
+		if *asJSON {
+			encoded, _ := json.MarshalIndent(replayer.Snapshot(), "", "  ")
+			fmt.Println(string(encoded))
+		} else {
+			printReport(marketKey, replayer)
+		}
+
+		if *verify {
+			mismatched += verifyAgainstDatabase(marketKey, replayer)
+		}
+
+		if *saveSnapshot {
+			if err := eventSourcing.SaveSnapshot(ctx, replayer.Snapshot()); err != nil {
+				log.Fatalf("Failed to save snapshot of %s: %v", marketKey, err)
+			}
+			log.Printf("📸 Saved snapshot of %s at event %d", marketKey, replayer.EventCount())
+		}
+
+		if *compact {
+			retention := time.Duration(cfg.Matching.EventRetentionHours) * time.Hour
+			removed, err := eventSourcing.CompactStream(ctx, marketKey, retention)
+			if err != nil {
+				log.Fatalf("Failed to compact %s: %v", marketKey, err)
+			}
+			log.Printf("🗜️ Compacted %d events from %s", removed, marketKey)
+		}
+	}
+
+	if mismatched > 0 {
+		log.Printf("❌ %d orders differ between the event log and the database", mismatched)
+		os.Exit(1)
+	}
+}
+
+// splitMarkets 쉼표로 구분된 마켓 목록
+func splitMarkets(value string) []string {
+	var markets []string
+	for _, market := range strings.Split(value, ",") {
+		if market = strings.TrimSpace(market); market != "" {
+			markets = append(markets, market)
+		}
+	}
+	return markets
+}
+
+// printReport 주문장과 잔액 변화를 표로 출력
+func printReport(marketKey string, replayer *services.OrderBookReplayer) {
+	fmt.Printf("== %s (events: %d, last event: %s, last price: %.4f)\n",
+		marketKey, replayer.EventCount(), replayer.StreamID(), replayer.LastPrice())
+
+	fmt.Println("-- open orders")
+	for _, order := range replayer.OpenOrders() {
+		fmt.Printf("%8d  %-4s  user %-6d  %.4f  remaining %d/%d\n",
+			order.ID, order.Side, order.UserID, order.Price, order.Remaining, order.Quantity)
+	}
+
+	fmt.Println("-- balances (cents)")
+	for _, balance := range replayer.Balances() {
+		fmt.Printf("user %-6d  cash %+10d  fees %8d  shares %+8d  trades %d\n",
+			balance.UserID, balance.CashDelta, balance.FeesPaid, balance.Shares, balance.Trades)
+	}
+	fmt.Println()
+}
+
+// verifyAgainstDatabase 재생한 열린 주문과 DB의 열린 주문 잔량 대조, 불일치 건수 반환
+func verifyAgainstDatabase(marketKey string, replayer *services.OrderBookReplayer) int {
+	milestonePart, optionID, _ := strings.Cut(marketKey, ":")
+	milestoneID, err := strconv.ParseUint(milestonePart, 10, 64)
+	if err != nil {
+		log.Printf("⚠️ Skipping verification of %s: invalid market key", marketKey)
+		return 0
+	}
+
+	var stored []models.Order
+	if err := database.GetDB().
+		Where("milestone_id = ? AND option_id = ? AND status IN ?", milestoneID, optionID,
+			[]models.OrderStatus{models.OrderStatusPending, models.OrderStatusPartial}).
+		Find(&stored).Error; err != nil {
+		log.Fatalf("Failed to load orders of %s: %v", marketKey, err)
+	}
+
+	replayed := make(map[uint]*models.Order)
+	for _, order := range replayer.OpenOrders() {
+		replayed[order.ID] = order
+	}
+
+	mismatched := 0
+	for _, order := range stored {
+		book, exists := replayed[order.ID]
+		switch {
+		case !exists:
+			fmt.Printf("!! %s order %d is open in DB (remaining %d) but not in the event log\n", marketKey, order.ID, order.Remaining)
+			mismatched++
+		case book.Remaining != order.Remaining:
+			fmt.Printf("!! %s order %d remaining: events %d, DB %d\n", marketKey, order.ID, book.Remaining, order.Remaining)
+			mismatched++
+		}
+		delete(replayed, order.ID)
+	}
+	for orderID, order := range replayed {
+		fmt.Printf("!! %s order %d is open in the event log (remaining %d) but not in DB\n", marketKey, orderID, order.Remaining)
+		mismatched++
+	}
+	return mismatched
+}
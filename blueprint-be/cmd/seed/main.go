@@ -0,0 +1,481 @@
+// seed 로컬 데모 환경 데이터 생성 도구
+//
+// 빈 테이블 대신 실제와 비슷한 데이터로 전체 스택을 띄울 수 있도록 데모 사용자/지갑, 마일스톤이 있는 프로젝트,
+// 호가가 쌓인 거래 가능한 마켓, 검증인/배심원/멘토 자격을 만든다. 스키마 마이그레이션을 먼저 실행하므로
+// 서버를 한 번도 띄우지 않은 DB에도 바로 쓸 수 있다.
+//
+//	go run ./cmd/seed                      # 데모 데이터 생성 (이미 있으면 건너뜀)
+//	go run ./cmd/seed -reset               # 기존 데모 데이터 삭제 후 다시 생성
+//	go run ./cmd/seed -users 20 -projects 8 -levels 8 -seed 7
+//	go run ./cmd/seed -tokens              # 데모 사용자 로그인용 액세스 토큰 출력
+//
+// 매칭 엔진은 시작할 때 DB의 열린 주문을 주문장에 적재하므로 서버를 띄우기 전에 실행한다
+// (실행 중에 시드/초기화했다면 서버를 재시작). 데모 사용자는 이메일 도메인(@demo.blueprint.local)으로 구분한다.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"time"
+
+	"blueprint/internal/config"
+	"blueprint/internal/database"
+	"blueprint/pkg/utils"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// demoEmailDomain 데모 사용자 이메일 도메인 (초기화 시 이 도메인 사용자의 데이터만 삭제)
+const demoEmailDomain = "demo.blueprint.local"
+
+const (
+	demoUSDCBalance      = 1_000_000 // 사용자별 시작 USDC (센트, $10,000)
+	demoBlueprintBalance = 100_000   // 사용자별 시작 BLUEPRINT
+	demoValidators       = 3         // 뒤쪽 사용자 중 검증인 수
+	demoJurors           = 5         // 뒤쪽 사용자 중 배심원 수
+	demoMentors          = 2         // 앞쪽 사용자 중 멘토 수
+)
+
+// demoProject 데모 프로젝트 템플릿 (마일스톤은 active → funding → proposal 순)
+type demoProject struct {
+	title       string
+	description string
+	category    models.ProjectCategory
+	milestones  [3]string
+}
+
+var demoProjects = []demoProject{
+	{"AI 이력서 코치 SaaS 출시", "이력서 첨삭을 자동화하는 구독형 서비스를 출시하고 첫 매출을 만든다",
+		models.BusinessProject, [3]string{"베타 사용자 100명 확보", "유료 전환 20건 달성", "월 매출 500만원 달성"}},
+	{"풀스택 개발자로 이직", "비전공 마케터에서 6개월 안에 풀스택 개발자로 이직한다",
+		models.CareerProject, [3]string{"포트폴리오 프로젝트 3개 배포", "기술 면접 5회 통과", "최종 오퍼 수락"}},
+	{"정보처리기사 취득", "퇴근 후 학습으로 올해 안에 정보처리기사를 취득한다",
+		models.EducationProject, [3]string{"필기 합격", "실기 모의고사 80점 이상", "최종 합격"}},
+	{"하프 마라톤 완주", "러닝 초보에서 시작해 가을 대회에서 하프 마라톤을 완주한다",
+		models.PersonalProject, [3]string{"10km 55분 이내 완주", "주 30km 8주 연속 유지", "하프 마라톤 완주"}},
+	{"독립 출판 에세이 발간", "1년간 쓴 글을 모아 독립 출판으로 에세이를 낸다",
+		models.LifeProject, [3]string{"원고 초안 완성", "크라우드펀딩 목표 달성", "독립 서점 10곳 입고"}},
+}
+
+// seedOptions 생성 규모
+type seedOptions struct {
+	users    int
+	projects int
+	levels   int // 마켓별 매수/매도 호가 단계 수
+	seed     int64
+}
+
+// seedSummary 생성 결과
+type seedSummary struct {
+	Users      int
+	Projects   int
+	Milestones int
+	Markets    int
+	Orders     int
+	Validators int
+	Jurors     int
+	Mentors    int
+}
+
+func main() {
+	opts := seedOptions{}
+	flag.IntVar(&opts.users, "users", 12, "데모 사용자 수 (최소 8)")
+	flag.IntVar(&opts.projects, "projects", len(demoProjects), "데모 프로젝트 수 (프로젝트마다 마일스톤 3개, 그중 2개가 거래 가능)")
+	flag.IntVar(&opts.levels, "levels", 5, "마켓별 매수/매도 호가 단계 수")
+	flag.Int64Var(&opts.seed, "seed", 42, "가격/수량 난수 시드")
+	reset := flag.Bool("reset", false, "기존 데모 데이터를 삭제하고 다시 생성")
+	tokens := flag.Bool("tokens", false, "데모 사용자 로그인용 액세스 토큰 출력 (24시간 유효)")
+	flag.Parse()
+
+	if opts.users < demoValidators+demoJurors {
+		log.Fatalf("-users must be at least %d", demoValidators+demoJurors)
+	}
+	if opts.projects < 1 || opts.levels < 1 {
+		log.Fatal("-projects and -levels must be positive")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	if err := database.Connect(cfg); err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := database.AutoMigrate(); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+	db := database.GetDB()
+
+	if *reset {
+		deleted, err := resetDemo(db)
+		if err != nil {
+			log.Fatalf("Failed to reset demo data: %v", err)
+		}
+		log.Printf("🧹 Removed demo data for %d users", deleted)
+	}
+
+	var existing int64
+	if err := db.Model(&models.User{}).Where("email LIKE ?", "%@"+demoEmailDomain).Count(&existing).Error; err != nil {
+		log.Fatalf("Failed to check existing demo data: %v", err)
+	}
+	if existing > 0 {
+		log.Printf("⏭️ Demo data already exists (%d users), use -reset to recreate", existing)
+	} else {
+		summary, err := seedDemo(db, opts)
+		if err != nil {
+			log.Fatalf("Failed to seed demo data: %v", err)
+		}
+		log.Printf("🌱 Seeded %d users, %d projects, %d milestones, %d markets with %d resting orders, %d validators, %d jurors, %d mentors",
+			summary.Users, summary.Projects, summary.Milestones, summary.Markets, summary.Orders,
+			summary.Validators, summary.Jurors, summary.Mentors)
+	}
+
+	if *tokens {
+		if err := printTokens(db, cfg.JWT.Secret); err != nil {
+			log.Fatalf("Failed to issue demo tokens: %v", err)
+		}
+	}
+}
+
+// seedDemo 데모 데이터 전체를 하나의 트랜잭션으로 생성
+func seedDemo(db *gorm.DB, opts seedOptions) (*seedSummary, error) {
+	summary := &seedSummary{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		s := &seeder{tx: tx, rng: rand.New(rand.NewSource(opts.seed)), summary: summary, wallets: map[uint]*models.UserWallet{}}
+		return s.run(opts)
+	})
+	return summary, err
+}
+
+// seeder 시드 실행 상태 (지갑은 주문 잠금을 모두 반영한 뒤 마지막에 저장)
+type seeder struct {
+	tx      *gorm.DB
+	rng     *rand.Rand
+	summary *seedSummary
+	users   []*models.User
+	wallets map[uint]*models.UserWallet
+}
+
+func (s *seeder) run(opts seedOptions) error {
+	// 1. 사용자와 지갑
+	for i := 1; i <= opts.users; i++ {
+		user := &models.User{
+			Email:    fmt.Sprintf("demo%02d@%s", i, demoEmailDomain),
+			Username: fmt.Sprintf("demo%02d", i),
+			IsActive: true,
+		}
+		if err := s.tx.Create(user).Error; err != nil {
+			return fmt.Errorf("user %s: %w", user.Email, err)
+		}
+		s.users = append(s.users, user)
+		s.wallets[user.ID] = &models.UserWallet{
+			UserID:           user.ID,
+			USDCBalance:      demoUSDCBalance,
+			TotalUSDCDeposit: demoUSDCBalance,
+			BlueprintBalance: demoBlueprintBalance,
+		}
+	}
+	s.summary.Users = len(s.users)
+
+	// 2. 프로젝트, 마일스톤, 마켓 (생성자는 앞쪽 사용자부터 돌아가며)
+	now := time.Now()
+	for i := 0; i < opts.projects; i++ {
+		template := demoProjects[i%len(demoProjects)]
+		title := template.title
+		if round := i / len(demoProjects); round > 0 {
+			title = fmt.Sprintf("%s #%d", title, round+1)
+		}
+		owner := s.users[i%len(s.users)]
+		if err := s.project(owner, title, template, now, opts.levels); err != nil {
+			return err
+		}
+	}
+
+	// 3. 검증인/배심원 (뒤쪽 사용자), 멘토 (앞쪽 사용자)
+	for _, user := range s.users[len(s.users)-demoValidators:] {
+		if err := s.tx.Create(&models.ValidatorQualification{
+			UserID:             user.ID,
+			StakedAmount:       10_000,
+			ReputationScore:    0.7 + 0.1*s.rng.Float64(),
+			TotalVerifications: 10 + s.rng.Intn(40),
+			AccuracyRate:       0.8 + 0.15*s.rng.Float64(),
+			ConsensusRate:      0.75 + 0.2*s.rng.Float64(),
+			LastActiveAt:       now,
+		}).Error; err != nil {
+			return fmt.Errorf("validator %d: %w", user.ID, err)
+		}
+		s.summary.Validators++
+	}
+	for _, user := range s.users[len(s.users)-demoJurors:] {
+		if err := s.tx.Create(&models.JurorQualification{
+			UserID:          user.ID,
+			MinStakeAmount:  5_000,
+			CurrentStake:    10_000,
+			ReputationScore: 0.5 + 0.3*s.rng.Float64(),
+			ExpertiseAreas:  []string{"technology", "business"},
+			LanguageSkills:  []string{"korean", "english"},
+			IsActive:        true,
+			LastActiveAt:    now,
+		}).Error; err != nil {
+			return fmt.Errorf("juror %d: %w", user.ID, err)
+		}
+		s.summary.Jurors++
+	}
+	for _, user := range s.users[:demoMentors] {
+		if err := s.tx.Create(&models.Mentor{
+			UserID:          user.ID,
+			Status:          models.MentorStatusActive,
+			Tier:            models.MentorTierSilver,
+			Expertise:       []string{"product", "career"},
+			YearsExperience: 5 + s.rng.Intn(10),
+			Bio:             "데모 멘토 프로필",
+			IsAvailable:     true,
+		}).Error; err != nil {
+			return fmt.Errorf("mentor %d: %w", user.ID, err)
+		}
+		s.summary.Mentors++
+	}
+
+	// 4. 매수 주문 잠금이 반영된 지갑 저장
+	for _, user := range s.users {
+		if err := s.tx.Create(s.wallets[user.ID]).Error; err != nil {
+			return fmt.Errorf("wallet %d: %w", user.ID, err)
+		}
+	}
+	return nil
+}
+
+// project 공개 프로젝트와 마일스톤 3개 (진행 중/펀딩 중 마일스톤은 호가가 있는 마켓)
+func (s *seeder) project(owner *models.User, title string, template demoProject, now time.Time, levels int) error {
+	target := now.AddDate(0, 6, 0)
+	project := &models.Project{
+		UserID:      owner.ID,
+		Title:       title,
+		Description: template.description,
+		Category:    template.category,
+		Status:      models.ProjectActive,
+		TargetDate:  &target,
+		IsPublic:    true,
+	}
+	if err := s.tx.Create(project).Error; err != nil {
+		return fmt.Errorf("project %q: %w", title, err)
+	}
+	s.summary.Projects++
+
+	statuses := [3]models.MilestoneStatus{models.MilestoneStatusActive, models.MilestoneStatusFunding, models.MilestoneStatusProposal}
+	for i, status := range statuses {
+		targetDate := now.AddDate(0, i+1, 0)
+		milestone := &models.Milestone{
+			ProjectID:  project.ID,
+			Title:      template.milestones[i],
+			Order:      i + 1,
+			TargetDate: &targetDate,
+			Status:     status,
+		}
+		switch status {
+		case models.MilestoneStatusActive:
+			fundingStart, fundingEnd := now.AddDate(0, 0, -10), now.AddDate(0, 0, -5)
+			milestone.FundingStartDate, milestone.FundingEndDate = &fundingStart, &fundingEnd
+		case models.MilestoneStatusFunding:
+			fundingStart, fundingEnd := now.AddDate(0, 0, -2), now.AddDate(0, 0, 3)
+			milestone.FundingStartDate, milestone.FundingEndDate = &fundingStart, &fundingEnd
+		}
+		if err := s.tx.Create(milestone).Error; err != nil {
+			return fmt.Errorf("milestone %q: %w", milestone.Title, err)
+		}
+		s.summary.Milestones++
+
+		if milestone.Status.IsTradable() {
+			if err := s.market(milestone, levels, now); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// market 성공 옵션 주문장 (중간 가격 아래 매수, 위 매도로 교차하지 않게) + 시장 데이터
+//
+// 매수 주문은 TradingService.CreateOrder처럼 주문 금액과 최대 수수료를 잠그고, 매도자에게는 매도 수량만큼 포지션을 준다.
+func (s *seeder) market(milestone *models.Milestone, levels int, now time.Time) error {
+	midCents := 30 + s.rng.Intn(41) // 0.30 ~ 0.70
+	held := map[uint]int64{}
+	var bestBid, bestAsk int
+
+	for level := 1; level <= levels; level++ {
+		for _, side := range []models.OrderSide{models.OrderSideBuy, models.OrderSideSell} {
+			priceCents := midCents - level
+			if side == models.OrderSideSell {
+				priceCents = midCents + level
+			}
+			if priceCents < 1 || priceCents > 99 {
+				continue
+			}
+			trader := s.users[s.rng.Intn(len(s.users))]
+			quantity := int64(10 + s.rng.Intn(10)*10)
+			priceTicks := models.PriceToTicks(float64(priceCents) / 100)
+
+			if side == models.OrderSideBuy {
+				reserve := models.BuyReserveCents(quantity, priceTicks)
+				wallet := s.wallets[trader.ID]
+				if wallet.USDCBalance < reserve {
+					continue
+				}
+				wallet.USDCBalance -= reserve
+				wallet.USDCLockedBalance += reserve
+				if bestBid == 0 {
+					bestBid = priceCents
+				}
+			} else {
+				held[trader.ID] += quantity
+				if bestAsk == 0 {
+					bestAsk = priceCents
+				}
+			}
+
+			// 같은 가격대 안에서도 시간 우선순위가 보이도록 접수 시각을 조금씩 다르게
+			createdAt := now.Add(-time.Duration(levels*2-level) * time.Minute)
+			if err := s.tx.Create(&models.Order{
+				ProjectID:   milestone.ProjectID,
+				MilestoneID: milestone.ID,
+				OptionID:    models.OptionSuccess,
+				UserID:      trader.ID,
+				Type:        models.OrderTypeLimit,
+				Side:        side,
+				Quantity:    quantity,
+				PriceTicks:  priceTicks,
+				Remaining:   quantity,
+				Status:      models.OrderStatusPending,
+				CreatedAt:   createdAt,
+				UpdatedAt:   createdAt,
+			}).Error; err != nil {
+				return fmt.Errorf("order on milestone %d: %w", milestone.ID, err)
+			}
+			s.summary.Orders++
+		}
+	}
+
+	// 매도자 포지션 (중간 가격 부근에서 취득한 것으로)
+	avgPrice := float64(midCents-s.rng.Intn(5)) / 100
+	for userID, quantity := range held {
+		if err := s.tx.Create(&models.Position{
+			UserID:      userID,
+			ProjectID:   milestone.ProjectID,
+			MilestoneID: milestone.ID,
+			OptionID:    models.OptionSuccess,
+			Quantity:    quantity,
+			AvgPrice:    avgPrice,
+			TotalCost:   models.NotionalCents(quantity, models.PriceToTicks(avgPrice)),
+			UpdatedAt:   now,
+		}).Error; err != nil {
+			return fmt.Errorf("position on milestone %d: %w", milestone.ID, err)
+		}
+	}
+
+	// 시장 데이터 (성공/실패 옵션 가격 합은 1)
+	mid := float64(midCents) / 100
+	for _, option := range []struct {
+		id    string
+		price float64
+	}{{models.OptionSuccess, mid}, {models.OptionFail, roundCents(1 - mid)}} {
+		data := &models.MarketData{
+			MilestoneID:   milestone.ID,
+			OptionID:      option.id,
+			CurrentPrice:  option.price,
+			PreviousPrice: option.price,
+			HighPrice24h:  option.price,
+			LowPrice24h:   option.price,
+			LastTradeTime: now,
+			UpdatedAt:     now,
+		}
+		if option.id == models.OptionSuccess && bestBid > 0 && bestAsk > 0 {
+			data.BidPrice = float64(bestBid) / 100
+			data.AskPrice = float64(bestAsk) / 100
+			data.Spread = roundCents(data.AskPrice - data.BidPrice)
+		}
+		if err := s.tx.Create(data).Error; err != nil {
+			return fmt.Errorf("market data on milestone %d: %w", milestone.ID, err)
+		}
+	}
+	s.summary.Markets++
+	return nil
+}
+
+// resetDemo 데모 사용자와 그 사용자가 만든 프로젝트/마켓 데이터 삭제 (삭제한 사용자 수 반환)
+func resetDemo(db *gorm.DB) (int, error) {
+	var userIDs []uint
+	if err := db.Unscoped().Model(&models.User{}).Where("email LIKE ?", "%@"+demoEmailDomain).Pluck("id", &userIDs).Error; err != nil {
+		return 0, err
+	}
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var projectIDs, milestoneIDs []uint
+		if err := tx.Unscoped().Model(&models.Project{}).Where("user_id IN ?", userIDs).Pluck("id", &projectIDs).Error; err != nil {
+			return err
+		}
+		if len(projectIDs) > 0 {
+			if err := tx.Unscoped().Model(&models.Milestone{}).Where("project_id IN ?", projectIDs).Pluck("id", &milestoneIDs).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("buyer_id IN ? OR seller_id IN ?", userIDs, userIDs).Delete(&models.Trade{}).Error; err != nil {
+			return fmt.Errorf("delete trades: %w", err)
+		}
+		steps := []struct {
+			model  interface{}
+			column string
+			ids    []uint
+		}{
+			{&models.Order{}, "user_id", userIDs},
+			{&models.Position{}, "user_id", userIDs},
+			{&models.MarketData{}, "milestone_id", milestoneIDs},
+			{&models.Milestone{}, "id", milestoneIDs},
+			{&models.Project{}, "id", projectIDs},
+			{&models.ValidatorQualification{}, "user_id", userIDs},
+			{&models.JurorQualification{}, "user_id", userIDs},
+			{&models.Mentor{}, "user_id", userIDs},
+			{&models.UserWallet{}, "user_id", userIDs},
+			{&models.User{}, "id", userIDs},
+		}
+		for _, step := range steps {
+			if len(step.ids) == 0 {
+				continue
+			}
+			if err := tx.Unscoped().Where(step.column+" IN ?", step.ids).Delete(step.model).Error; err != nil {
+				return fmt.Errorf("delete %T: %w", step.model, err)
+			}
+		}
+		return nil
+	})
+	return len(userIDs), err
+}
+
+// printTokens 데모 사용자별 액세스 토큰 출력 (Authorization: Bearer <token>)
+func printTokens(db *gorm.DB, secret string) error {
+	var users []models.User
+	if err := db.Where("email LIKE ?", "%@"+demoEmailDomain).Order("id").Find(&users).Error; err != nil {
+		return err
+	}
+	for i := range users {
+		token, err := utils.GenerateToken(&users[i], secret)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\t%s\n", users[i].Email, token)
+	}
+	return nil
+}
+
+// roundCents 센트 단위 반올림 (부동소수점 오차로 0.59999 같은 값이 저장되지 않게)
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSeedDemo 생성 규모대로 만들고, 매수 주문 금액은 지갑에 잠기고 매도 수량만큼 포지션이 있으며 주문장은 교차하지 않음
+func TestSeedDemo(t *testing.T) {
+	db := testkit.NewDB(t)
+
+	summary, err := seedDemo(db, seedOptions{users: 10, projects: 3, levels: 3, seed: 1})
+	require.NoError(t, err)
+	assert.Equal(t, seedSummary{
+		Users: 10, Projects: 3, Milestones: 9, Markets: 6, Orders: summary.Orders,
+		Validators: demoValidators, Jurors: demoJurors, Mentors: demoMentors,
+	}, *summary)
+	assert.Positive(t, summary.Orders)
+
+	var orders []models.Order
+	require.NoError(t, db.Find(&orders).Error)
+	assert.Len(t, orders, summary.Orders)
+
+	locked := map[uint]int64{}
+	sold := map[[2]uint]int64{}
+	bestBid, bestAsk := map[uint]int64{}, map[uint]int64{}
+	for _, order := range orders {
+		assert.Equal(t, models.OrderStatusPending, order.Status)
+		assert.Equal(t, order.Quantity, order.Remaining)
+		if order.Side == models.OrderSideBuy {
+			locked[order.UserID] += models.BuyReserveCents(order.Quantity, order.PriceTicks)
+			if order.PriceTicks > bestBid[order.MilestoneID] {
+				bestBid[order.MilestoneID] = order.PriceTicks
+			}
+		} else {
+			sold[[2]uint{order.UserID, order.MilestoneID}] += order.Quantity
+			if ask, ok := bestAsk[order.MilestoneID]; !ok || order.PriceTicks < ask {
+				bestAsk[order.MilestoneID] = order.PriceTicks
+			}
+		}
+	}
+	for milestoneID, bid := range bestBid {
+		assert.Less(t, bid, bestAsk[milestoneID], "milestone %d", milestoneID)
+	}
+
+	var wallets []models.UserWallet
+	require.NoError(t, db.Find(&wallets).Error)
+	require.Len(t, wallets, 10)
+	for _, wallet := range wallets {
+		assert.Equal(t, locked[wallet.UserID], wallet.USDCLockedBalance, "user %d", wallet.UserID)
+		assert.Equal(t, int64(demoUSDCBalance), wallet.USDCBalance+wallet.USDCLockedBalance, "user %d", wallet.UserID)
+	}
+
+	var positions []models.Position
+	require.NoError(t, db.Find(&positions).Error)
+	assert.Len(t, positions, len(sold))
+	for _, position := range positions {
+		assert.Equal(t, sold[[2]uint{position.UserID, position.MilestoneID}], position.Quantity)
+	}
+
+	var quotes int64
+	db.Model(&models.MarketData{}).Count(&quotes)
+	assert.Equal(t, int64(summary.Markets*2), quotes, "성공/실패 옵션별 시장 데이터")
+}
+
+// TestSeedDemoDeterministic 같은 시드면 같은 주문장
+func TestSeedDemoDeterministic(t *testing.T) {
+	book := func() []models.Order {
+		db := testkit.NewDB(t)
+		_, err := seedDemo(db, seedOptions{users: 8, projects: 2, levels: 2, seed: 7})
+		require.NoError(t, err)
+		var orders []models.Order
+		require.NoError(t, db.Order("id").Find(&orders).Error)
+		return orders
+	}
+
+	first, second := book(), book()
+	require.Equal(t, len(first), len(second))
+	for i := range first {
+		assert.Equal(t, first[i].UserID, second[i].UserID)
+		assert.Equal(t, first[i].Side, second[i].Side)
+		assert.Equal(t, first[i].PriceTicks, second[i].PriceTicks)
+		assert.Equal(t, first[i].Quantity, second[i].Quantity)
+	}
+}
+
+// TestResetDemo 데모 사용자의 데이터만 삭제하고 일반 사용자 데이터는 유지
+func TestResetDemo(t *testing.T) {
+	db := testkit.NewDB(t)
+	factory := testkit.NewFactory(t, db)
+	owner := factory.FundedUser(5000)
+	market := factory.Milestone(factory.Project(owner.ID).ID)
+	factory.Order(owner.ID, market, models.OrderSideBuy, 0.4, 10)
+
+	_, err := seedDemo(db, seedOptions{users: 8, projects: 2, levels: 2, seed: 1})
+	require.NoError(t, err)
+
+	deleted, err := resetDemo(db)
+	require.NoError(t, err)
+	assert.Equal(t, 8, deleted)
+
+	for _, model := range []interface{}{
+		&models.User{}, &models.UserWallet{}, &models.Project{}, &models.Order{},
+	} {
+		var count int64
+		db.Unscoped().Model(model).Count(&count)
+		assert.Equal(t, int64(1), count, "%T", model)
+	}
+	for _, model := range []interface{}{
+		&models.Position{}, &models.MarketData{}, &models.ValidatorQualification{}, &models.JurorQualification{}, &models.Mentor{},
+	} {
+		var count int64
+		db.Unscoped().Model(model).Count(&count)
+		assert.Zero(t, count, "%T", model)
+	}
+	var milestones int64
+	db.Unscoped().Model(&models.Milestone{}).Where("project_id <> ?", market.ProjectID).Count(&milestones)
+	assert.Zero(t, milestones)
+
+	// 다시 실행해도 삭제할 데모 사용자 없음
+	deleted, err = resetDemo(db)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+}
@@ -5,80 +5,31 @@ import (
 	"blueprint/internal/database"
 	"blueprint/internal/handlers"
 	"blueprint/internal/middleware"
+	"blueprint/internal/rpc"
 	"blueprint/internal/services"
+	"context"
+	"errors"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	moduleConfig "blueprint-module/pkg/config"
+	"blueprint-module/pkg/logger"
+	"blueprint-module/pkg/models"
 	moduleRedis "blueprint-module/pkg/redis"
 
 	"github.com/gin-gonic/gin"
 )
 
-// config 타입 변환 함수
-func convertToModuleConfig(cfg *config.Config) *moduleConfig.Config {
-	return &moduleConfig.Config{
-		Database: moduleConfig.DatabaseConfig{
-			Host:     cfg.Database.Host,
-			Port:     cfg.Database.Port,
-			User:     cfg.Database.User,
-			Password: cfg.Database.Password,
-			Name:     cfg.Database.Name,
-			SSLMode:  cfg.Database.SSLMode,
-		},
-		JWT: moduleConfig.JWTConfig{
-			Secret: cfg.JWT.Secret,
-		},
-		OAuth: moduleConfig.OAuthConfig{
-			Google: moduleConfig.GoogleOAuthConfig{
-				ClientID:     cfg.Google.ClientID,
-				ClientSecret: cfg.Google.ClientSecret,
-				RedirectURL:  cfg.Google.RedirectURL,
-				Scopes:       "profile email",
-			},
-			LinkedIn: moduleConfig.LinkedInOAuthConfig{
-				ClientID:     cfg.LinkedIn.ClientID,
-				ClientSecret: cfg.LinkedIn.ClientSecret,
-				RedirectURL:  cfg.LinkedIn.RedirectURL,
-				Scopes:       "r_liteprofile r_emailaddress",
-			},
-			Twitter: moduleConfig.TwitterOAuthConfig{
-				ClientID:     cfg.Twitter.ClientID,
-				ClientSecret: cfg.Twitter.ClientSecret,
-				RedirectURL:  cfg.Twitter.RedirectURL,
-				Scopes:       "tweet.read users.read",
-			},
-			GitHub: moduleConfig.GitHubOAuthConfig{
-				ClientID:     cfg.GitHub.ClientID,
-				ClientSecret: cfg.GitHub.ClientSecret,
-				RedirectURL:  cfg.GitHub.RedirectURL,
-				Scopes:       "user:email",
-			},
-		},
-		Server: moduleConfig.ServerConfig{
-			Port:        cfg.Server.Port,
-			Mode:        cfg.Server.Mode,
-			FrontendURL: cfg.Server.FrontendURL,
-		},
-		AI: moduleConfig.AIConfig{
-			Provider: cfg.AI.Provider,
-			OpenAI: moduleConfig.OpenAIConfig{
-				APIKey: cfg.AI.OpenAI.APIKey,
-				Model:  cfg.AI.OpenAI.Model,
-			},
-		},
-		Redis: moduleConfig.RedisConfig{
-			Host:     cfg.Redis.Host,
-			Port:     cfg.Redis.Port,
-			Password: cfg.Redis.Password,
-			DB:       cfg.Redis.DB,
-		},
-	}
-}
-
 func main() {
-	// 설정 로드
-	cfg := config.LoadConfig()
+	// 설정 로드 (필수 값 누락/형식 오류는 시작 단계에서 전부 보고하고 종료)
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
 
 	// Gin 모드 설정
 	gin.SetMode(cfg.Server.Mode)
@@ -93,27 +44,31 @@ func main() {
 		log.Fatal("Failed to migrate database:", err)
 	}
 
-	// Redis 연결 (blueprint-module 사용)
-	moduleCfg := &moduleConfig.Config{
-		Redis: moduleConfig.RedisConfig{
-			Host:     cfg.Redis.Host,
-			Port:     cfg.Redis.Port,
-			Password: cfg.Redis.Password,
-			DB:       cfg.Redis.DB,
-		},
-	}
+	// 📚 읽기 복제본 상태 확인 (장애/지연 복제본은 조회 대상에서 제외)
+	go database.RunReplicaHealthCheck(10 * time.Second)
 
-	if err := moduleRedis.InitRedis(moduleCfg); err != nil {
+	// Redis 연결 (blueprint-module 사용)
+	if err := moduleRedis.InitRedis(&cfg.Config); err != nil {
 		log.Fatal("Failed to connect to Redis:", err)
 	}
 	defer moduleRedis.CloseRedis()
 
+	// ⏱️ 주기 작업 레지스트리 (일정은 scheduled_jobs 테이블, 인스턴스가 여러 개여도 작업별 Redis 락으로 한 곳만 실행)
+	scheduler := services.NewSchedulerService(database.GetDB(), moduleRedis.GetClient())
+
 	// Gin 라우터 초기화
 	router := gin.Default()
 
+	// 🛡️ 신뢰할 프록시 (주문 감사 필드의 c.ClientIP()가 X-Forwarded-For 위조에 속지 않도록)
+	if err := middleware.ConfigureTrustedProxies(router, cfg); err != nil {
+		log.Fatalf("Failed to configure trusted proxies: %v", err)
+	}
+
 	// 미들웨어 설정
+	router.Use(middleware.SecurityHeaders(cfg)) // CSP/HSTS/nosniff 등 보안 헤더
 	router.Use(middleware.CORSMiddleware(cfg))
-	router.Use(middleware.ResponseWrapper()) // 응답 래핑 미들웨어 추가
+	router.Use(middleware.ResponseWrapper())                            // 응답 래핑 미들웨어 추가
+	router.Use(middleware.ActivityCapture(logger.GlobalActivityLogger)) // 📝 주요 요청 활동 로그 자동 기록
 
 	// Initialize services
 	// AI Service 초기화
@@ -124,9 +79,35 @@ func main() {
 
 	// 🆕 펀딩 검증 서비스 초기화
 	fundingVerificationService := services.NewFundingVerificationService(database.GetDB(), sseService)
+	escrowService := services.NewFundingEscrowService(database.GetDB(), fundingVerificationService)  // 🤝 직접 후원 에스크로 (예치액은 펀딩 TVL에 포함)
+	payoutService := services.NewCreatorPayoutService(database.GetDB(), cfg.APIKey.EncryptionSecret) // 💸 창작자 지급 계좌/지급 요청 (송금은 워커)
+
+	// 🛡️ 사용자 신뢰 점수 서비스 초기화 (검증 신호 가중 합산, 검증인/배심원/멘토 자격 기준)
+	trustScoreService := services.NewTrustScoreService(database.GetDB(), services.TrustScorePolicy{
+		Weights: services.TrustScoreWeights{
+			Email:        cfg.TrustScore.EmailWeight,
+			Phone:        cfg.TrustScore.PhoneWeight,
+			LinkedIn:     cfg.TrustScore.LinkedInWeight,
+			GitHub:       cfg.TrustScore.GitHubWeight,
+			Twitter:      cfg.TrustScore.TwitterWeight,
+			WorkEmail:    cfg.TrustScore.WorkEmailWeight,
+			Professional: cfg.TrustScore.ProfessionalWeight,
+			Education:    cfg.TrustScore.EducationWeight,
+			KYC:          cfg.TrustScore.KYCWeight,
+		},
+		Minimums: map[services.TrustGate]int{
+			services.TrustGateValidator: cfg.TrustScore.MinValidator,
+			services.TrustGateJuror:     cfg.TrustScore.MinJuror,
+			services.TrustGateMentor:    cfg.TrustScore.MinMentor,
+		},
+	})
+	scheduler.Register("trust_score_recalculate", time.Duration(cfg.TrustScore.RecalculateIntervalMinutes)*time.Minute, func(time.Time) (int, error) {
+		return trustScoreService.RecalculateChanged()
+	})
 
 	// 🆕 멘토 자격 증명 서비스 초기화
 	mentorQualificationService := services.NewMentorQualificationService(database.GetDB(), sseService)
+	mentorQualificationService.SetTrustScores(trustScoreService)
 
 	// 🆕 마일스톤 라이프사이클 관리 서비스 초기화 및 시작
 	lifecycleService := services.NewMilestoneLifecycleService(database.GetDB(), fundingVerificationService)
@@ -138,8 +119,53 @@ func main() {
 		}
 	}()
 
-	// 고성능 매칭 엔진 초기화 및 시작 (펀딩 + 멘토링 서비스 추가)
-	matchingEngine := services.NewMatchingEngine(database.GetDB(), sseService, fundingVerificationService, mentorQualificationService)
+	// 🚩 기능 플래그 서비스 초기화 (DB 원본 + Redis 공유 스냅샷, 다른 서버의 변경은 주기적으로 반영)
+	flagService := services.NewFeatureFlagService(database.GetDB())
+	if err := flagService.Load(); err != nil {
+		log.Printf("⚠️ Failed to load feature flags, using defaults: %v", err)
+	}
+	go flagService.RunRefresh(5 * time.Second)
+
+	// 고성능 매칭 엔진 초기화 및 시작 (펀딩 + 멘토링 서비스 추가, MATCHING_ENGINE_MODE로 단일/분산 선택)
+	// 단일 모드여도 distributed_matching 플래그가 켜져 있으면 분산 모드로 시작 (재시작 시 적용)
+	matchingMode := cfg.Matching.Mode
+	if (matchingMode == "" || matchingMode == services.MatchingModeLocal) && flagService.IsEnabled(models.FlagDistributedMatching) {
+		log.Printf("🚩 distributed_matching flag enabled, starting distributed matching engine")
+		matchingMode = services.MatchingModeDistributed
+	}
+	matchingEngine, err := services.NewMatchingEngine(matchingMode, database.GetDB(), sseService, fundingVerificationService, mentorQualificationService)
+	if err != nil {
+		log.Fatalf("Failed to initialize matching engine: %v", err)
+	}
+
+	// 📸 분산 모드 이벤트 로그 스냅샷/압축 정책
+	if distributedEngine, ok := matchingEngine.(*services.DistributedMatchingEngine); ok {
+		distributedEngine.SetSnapshotPolicy(services.SnapshotPolicy{
+			EveryEvents:        cfg.Matching.SnapshotEveryEvents,
+			Retention:          time.Duration(cfg.Matching.EventRetentionHours) * time.Hour,
+			CompactionInterval: time.Duration(cfg.Matching.CompactionIntervalMinutes) * time.Minute,
+		})
+	}
+
+	// 🧯 서킷브레이커 (급변동/상태 전환 시 거래 일시 중단, 쿨다운 후 자동 재개)
+	matchingEngine.CircuitBreaker().Configure(services.CircuitBreakerConfig{
+		MaxMovePercent: float64(cfg.CircuitBreaker.MaxMovePercent),
+		Window:         time.Duration(cfg.CircuitBreaker.WindowMinutes) * time.Minute,
+		Cooldown:       time.Duration(cfg.CircuitBreaker.CooldownMinutes) * time.Minute,
+	})
+	go matchingEngine.CircuitBreaker().RunMonitor(5 * time.Second)
+
+	// 🚧 점검 모드/마켓 거래 중단 (설정 중단 + 관리자 API 중단, 예약 점검은 시각이 되면 자동 적용/해제)
+	matchingEngine.TradingPause().Configure(services.TradingPauseConfig{
+		Global:       cfg.Maintenance.TradingPaused,
+		MilestoneIDs: cfg.Maintenance.MilestoneIDs(),
+		Reason:       cfg.Maintenance.Reason,
+	})
+	if _, _, err := matchingEngine.TradingPause().Sync(time.Now()); err != nil {
+		log.Printf("⚠️ Failed to load trading pauses: %v", err)
+	}
+	go matchingEngine.TradingPause().RunScheduler(10 * time.Second)
+	matchingEngine.SetFeatureFlags(flagService) // new_fee_tiers 메이커/테이커 수수료
 	go func() {
 		if err := matchingEngine.Start(); err != nil {
 			log.Printf("❌ CRITICAL: Failed to start matching engine: %v", err)
@@ -151,9 +177,39 @@ func main() {
 
 	// Trading Service 초기화 (매칭 엔진 주입)
 	tradingService := services.NewTradingService(database.GetDB(), sseService, matchingEngine)
+	tradingService.UseReadReplica(database.GetReadDB())          // 최근 체결/가격 캔들/마켓 목록은 읽기 복제본에서
+	fundingVerificationService.SetMatchingEngine(matchingEngine) // 펀딩 실패 환불 시 주문장에서도 제거
+
+	// 🧾 주문장/주문/체결/지갑 주기 대사 (비동기 체결 후처리 유실 감지, 설정 시 단순 불일치 자동 보정)
+	reconciliationService := services.NewReconciliationService(database.GetDB(), matchingEngine)
+	scheduler.Register("reconciliation", time.Duration(cfg.Reconciliation.IntervalMinutes)*time.Minute, func(time.Time) (int, error) {
+		report, err := reconciliationService.Run(cfg.Reconciliation.AutoCorrect)
+		if errors.Is(err, services.ErrReconciliationRunning) {
+			return 0, nil // 관리자가 즉시 실행한 대사가 진행 중
+		}
+		if err != nil {
+			return 0, err
+		}
+		return report.DiscrepancyCount, nil
+	})
+
+	// 🔔 마켓 캘린더 (목표일/설정 마감 시각에 주문 접수 종료, 미체결 주문 만료 후 결과 판정 대기 상태 알림)
+	marketCalendarService := services.NewMarketCalendarService(database.GetDB(), sseService, matchingEngine)
+	scheduler.Register("market_calendar", 30*time.Second, func(now time.Time) (int, error) {
+		closed, reopened, err := marketCalendarService.Sync(now)
+		return closed + reopened, err
+	})
+
+	// 📐 마켓 평균가 (TWAP/VWAP 1h/24h, 체결마다 queue:trades 소비자가 갱신하고 체결이 없어도 창을 밀어 재계산)
+	marketMetricsService := services.NewMarketMetricsService(database.GetDB())
+	scheduler.Register("market_metrics", time.Minute, marketMetricsService.RefreshActive)
 
-	// Market Maker 봇 초기화 및 시작
-	marketMakerBot := services.NewMarketMakerBot(database.GetDB(), tradingService)
+	// Market Maker 봇 초기화 (호가 설정은 관리자 API로 실행 중 변경)
+	marketMakerConfig := services.DefaultMarketMakerConfig
+	marketMakerConfig.UserID = cfg.MarketMaker.UserID
+	marketMakerConfig.MaxLoss = cfg.MarketMaker.MaxLoss
+	marketMakerBot := services.NewMarketMakerBot(database.GetDB(), tradingService, marketMakerConfig)
+	marketMakerBot.SetFeatureFlags(flagService) // amm_liquidity가 꺼지면 호가 공급 중단
 
 	// 🆕 워커 서비스 초기화 및 시작 (비동기 작업 처리)
 	workerService := services.NewWorkerService()
@@ -163,37 +219,332 @@ func main() {
 		}
 	}()
 
+	// 👥 프로젝트 팀원 서비스 초기화 (owner/editor/viewer 권한)
+	projectMemberService := services.NewProjectMemberService(database.GetDB())
+
+	// 🔗 마일스톤 선후 관계 서비스 초기화 (선행 완료 전 펀딩 차단, 지연 시 목표일 자동 조정)
+	milestoneDependencyService := services.NewMilestoneDependencyService(database.GetDB())
+
 	// 🔍 파일 서비스 및 검증 서비스 초기화
-	fileService := services.NewFileService("./uploads", cfg.Server.FrontendURL+"/uploads")
+	fileService := services.NewFileService(cfg.Storage.UploadPath, cfg.Storage.PublicURL, cfg.Storage.SigningSecret)
 	verificationService := services.NewVerificationService(database.GetDB(), fileService)
+	verificationService.SetTrustScores(trustScoreService)
+
+	// 🪪 본인 인증(KYC/AML) 서비스 초기화
+	kycProvider, err := services.NewKYCProvider(services.KYCProviderConfig{
+		Provider: cfg.KYC.Provider,
+		APIURL:   cfg.KYC.APIURL,
+		APIKey:   cfg.KYC.APIKey,
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize KYC provider: %v", err)
+	}
+	kycService := services.NewKYCService(database.GetDB(), kycProvider, fileService)
+	tradingService.SetKYC(kycService) // 복사 거래 주문도 같은 단계별 주문 한도 적용
+	if cfg.KYC.Provider != string(services.KYCProviderManual) {
+		scheduler.Register("kyc_provider_sync", 5*time.Minute, func(time.Time) (int, error) { // 외부 제공업체 심사 결과 동기화
+			return kycService.SyncPending()
+		})
+	}
+
+	// 🎓 전문 자격/학력 서류 심사 서비스 초기화
+	verificationReviewService := services.NewVerificationReviewService(database.GetDB(), fileService)
 	
 	// 🏛️ 분쟁 해결 서비스 초기화
 	arbitrationService := services.NewArbitrationService(database.GetDB())
+	arbitrationService.SetTrustScores(trustScoreService)
+	scheduler.Register("arbitration_deadline_reminders", 15*time.Minute, func(time.Time) (int, error) { // 투표/공개 마감 임박 알림
+		arbitrationService.SendDeadlineReminders(24 * time.Hour)
+		return 0, nil
+	})
+	scheduler.Register("arbitration_phase_timers", time.Minute, arbitrationService.AdvancePhases)       // 배심원 구성/투표/공개 마감 집행 및 자동 기각
+	arbitrationEvidenceService := services.NewArbitrationEvidenceService(database.GetDB(), fileService) // 증거 파일 (검사는 워커)
+
+	// ⏰ 검증 마감 집행 (정족수 충족 시 투표 결과대로 완료, 결론이 없으면 배심원 중재로 이관)
+	verificationTimeoutService := services.NewVerificationTimeoutService(database.GetDB(), verificationService, arbitrationService)
+	scheduler.Register("verification_timeouts", 5*time.Minute, verificationTimeoutService.ProcessExpired)
+
+	// 🕵️ 시장 감시 (자기 거래 고리/허수 주문/마감 직전 종가 관여 탐지, 운영자 검토 후 중재 이관)
+	surveillanceService := services.NewSurveillanceService(database.GetDB(), arbitrationService)
+	surveillanceService.SetSpoofingExempt(cfg.MarketMaker.UserID) // 호가를 계속 정정하는 마켓메이커 봇 제외
+	scheduler.Register("market_surveillance", 5*time.Minute, surveillanceService.Scan)
 	
 	// 💎 멘토 스테이킹 서비스 초기화
 	mentorStakingService := services.NewMentorStakingService(database.GetDB())
+	scheduler.Register("mentor_slash_reviews", 5*time.Minute, func(now time.Time) (int, error) { // 접수 1시간 지난 신고 검토 시작
+		started, err := mentorStakingService.StartPendingSlashReviews(now)
+		return int(started), err
+	})
+	mentorFeedbackService := services.NewMentorFeedbackService(database.GetDB(), mentorStakingService)
+
+	// 🤝 분쟁 조정 (증거 분쟁/멘토 신고를 당사자 협상으로 먼저 처리, 결렬 시에만 배심원 중재)
+	mediationService := services.NewMediationService(database.GetDB(), arbitrationService, mentorStakingService)
+	verificationService.SetMediation(mediationService)
+	mentorStakingService.SetMediation(mediationService)
+	scheduler.Register("mediation_deadlines", 5*time.Minute, mediationService.ProcessDeadlines) // 제안 만료, 기한 경과 조정 배심원 중재 이관
+
+	// 🌱 스테이킹 발행 보상 서비스 초기화 (에포크 적립 + 자동 갱신)
+	stakingGenesis, err := time.Parse(time.RFC3339, cfg.Staking.Genesis)
+	if err != nil {
+		log.Fatalf("Invalid STAKING_GENESIS: %v", err)
+	}
+	emissionSchedule := services.StakingEmissionSchedule{
+		Genesis:           stakingGenesis,
+		EpochDuration:     time.Duration(cfg.Staking.EpochHours) * time.Hour,
+		EpochEmission:     cfg.Staking.EpochEmission,
+		DecayPercent:      cfg.Staking.DecayPercent,
+		DecayEveryEpochs:  cfg.Staking.DecayEveryEpochs,
+		MinEpochEmission:  cfg.Staking.MinEpochEmission,
+		JurorSharePercent: cfg.Staking.JurorSharePercent,
+	}
+	if err := emissionSchedule.Validate(); err != nil {
+		log.Fatalf("Invalid staking emission schedule: %v", err)
+	}
+	stakingRewardsService := services.NewStakingRewardsService(database.GetDB(), emissionSchedule)
+	scheduler.Register("staking_epochs", 10*time.Minute, stakingRewardsService.ProcessEpochs) // 만기 스테이킹 자동 갱신 + 종료된 에포크 보상 적립
+
+	// 🔔 알림 서비스 초기화
+	notificationService := services.NewNotificationService(database.GetDB())
+
+	// 🎰 조합 베팅 서비스 초기화
+	parlayService := services.NewParlayService(database.GetDB())
+	parlayService.SetHouseAccount(cfg.Parlay.HouseUserID)
+	scheduler.Register("parlay_settlement", time.Minute, func(time.Time) (int, error) { // 마일스톤 결과 확정 시 조합 정산
+		return parlayService.SettlePendingLegs()
+	})
+
+	// 👀 프로젝트 팔로우 서비스 초기화 (이벤트 전파는 워커의 feed_queue 담당)
+	watchlistService := services.NewWatchlistService(database.GetDB())
+
+	// 🌐 공개 프로젝트 페이지 서비스 초기화 (비로그인 조회, Redis 캐시)
+	publicProjectService := services.NewPublicProjectService(database.GetDB())
+
+	// 🏆 리더보드 서비스 초기화 (트레이더 손익 / 검증 정확도 / 멘토 성과)
+	leaderboardService := services.NewLeaderboardService(database.GetDB())
+	scheduler.Register("leaderboard", time.Minute, func(time.Time) (int, error) { // 체결/판정/성과 지표로 바뀐 종류만 재계산
+		return leaderboardService.RecomputeChanged()
+	})
+	scheduler.Register("leaderboard_full", time.Hour, func(time.Time) (int, error) { // 기간 창 이동 반영 전체 재계산
+		leaderboardService.RecomputeAll()
+		return 0, nil
+	})
+
+	// 🧾 사용자 지표 서비스 초기화 (승률/거래량/확정 마켓 적중률/검증 정확도/멘토 평점 → UserStatsCache, 공개 프로필)
+	userStatsService := services.NewUserStatsService(database.GetDB())
+	scheduler.Register("user_stats", time.Minute, userStatsService.ComputeChanged)   // 체결/정산/검증으로 바뀐 사용자만 계산
+	scheduler.Register("user_stats_full", 24*time.Hour, userStatsService.ComputeAll) // 멘토 평점 등 나머지 반영 전체 재계산
+
+	// 📬 알림 요약 메일 서비스 초기화 (포지션 변동/마감 임박 마켓/검증 대기 증거/분쟁 기한 → email_queue)
+	digestService := services.NewDigestService(database.GetDB(), cfg.Digest.SigningSecret)
+	scheduler.Register("notification_digest", 15*time.Minute, digestService.SendDue) // 일간/주간 주기가 돌아온 사용자만 발송
+
+	// 💬 멘토링/분쟁 대화 서비스 초기화 (메시지, 읽음 표시, 신고 → 운영자 검토)
+	messagingService := services.NewMessagingService(database.GetDB())
+	scheduler.Register("message_retention", 24*time.Hour, func(now time.Time) (int, error) {
+		return messagingService.CleanupMessages(now.AddDate(0, 0, -cfg.Messaging.RetentionDays))
+	})
+
+	// 🌍 플랫폼 지표 서비스 초기화 (거래량/마켓/트레이더/TVL/분쟁 해결률 → GlobalStatsCache)
+	platformStatsService := services.NewPlatformStatsService(database.GetDB())
+	scheduler.Register("platform_stats", 5*time.Minute, func(now time.Time) (int, error) {
+		_, err := platformStatsService.Compute(now)
+		return 1, err
+	})
+
+	// 🔬 마일스톤 마켓 분석 서비스 초기화 (보유 집중도, 일별 거래량, 호가 잔량 스냅샷)
+	milestoneAnalyticsService := services.NewMilestoneAnalyticsService(database.GetDB())
+	scheduler.Register("orderbook_depth", 15*time.Minute, milestoneAnalyticsService.SnapshotDepth)
+	scheduler.Register("orderbook_depth_cleanup", 24*time.Hour, func(now time.Time) (int, error) {
+		return milestoneAnalyticsService.CleanupDepthSnapshots(now.Add(-services.OrderBookDepthRetention))
+	})
+
+	// 📈 마일스톤 실패 위험 점수 서비스 초기화 (마켓 가격, 증거 제출 적시성, 프로젝트 활동 → ProjectStatsCache)
+	milestoneRiskService := services.NewMilestoneRiskService(database.GetDB())
+	scheduler.Register("milestone_risk", time.Minute, func(time.Time) (int, error) { // 체결/상태 전환/활동으로 바뀐 프로젝트만 재계산
+		return milestoneRiskService.ScoreChanged()
+	})
+	scheduler.Register("milestone_risk_full", 6*time.Hour, func(time.Time) (int, error) { // 일정/비활동 신호 반영 전체 재계산
+		return milestoneRiskService.ScoreAll()
+	})
+
+	// 🤝 추천 프로그램 서비스 초기화 (적립은 매칭 엔진, 귀속은 회원가입 후속 작업에서 처리)
+	referralService := services.NewReferralService(database.GetDB())
+	scheduler.Register("referral_payouts", 24*time.Hour, func(time.Time) (int, error) { // 적립 보상 일일 지급
+		return referralService.PayoutAll()
+	})
+
+	// 📉 포트폴리오 스냅샷 서비스 초기화 (UTC 날짜마다 자산 곡선 기록, 그날 스냅샷이 없으면 저장)
+	portfolioSnapshotService := services.NewPortfolioSnapshotService(database.GetDB())
+	scheduler.Register("portfolio_snapshot", time.Hour, portfolioSnapshotService.SnapshotDue)
+
+	// 🛡️ 주문 전 리스크 한도 (미체결 금액/시장별 포지션/당일 손실) - 잔액 잠금 전에 거래 서비스에서 검사
+	riskService := services.NewPreTradeRiskService(database.GetDB(), portfolioSnapshotService, services.RiskLimits{
+		MaxOpenOrderNotional: cfg.Risk.MaxOpenOrderNotional,
+		MaxMarketPosition:    cfg.Risk.MaxMarketPosition,
+		MaxDailyLoss:         cfg.Risk.MaxDailyLoss,
+	})
+	tradingService.SetPreTradeRisk(riskService)
+
+	// 🧘 책임 있는 거래 (사용자 일일 한도/휴식/자기 배제) - 주문, 후원 예치, 조합 베팅 접수 전에 검사
+	responsibleTradingService := services.NewResponsibleTradingService(database.GetDB(), services.ResponsibleTradingDefaults{
+		DailyDeposit:       cfg.Responsible.DefaultDailyDeposit,
+		DailyOrderNotional: cfg.Responsible.DefaultDailyOrderNotional,
+		IncreaseDelay:      time.Duration(cfg.Responsible.LimitIncreaseDelayHours) * time.Hour,
+	})
+	tradingService.SetResponsibleTrading(responsibleTradingService)
+	escrowService.SetResponsibleTrading(responsibleTradingService)
+	parlayService.SetResponsibleTrading(responsibleTradingService)
+
+	// 🎁 사용자 간 포지션 이전 (받는 사람 수락 시 수량/취득 원가 이동)
+	positionTransferService := services.NewPositionTransferService(database.GetDB(), cfg.Transfer.FeeCents)
+	scheduler.Register("position_transfer_expiry", 10*time.Minute, positionTransferService.ExpireTransfers) // 응답 기한 지난 요청 만료, 수수료 반환
+
+	// 🛡️ 마일스톤 사기 보험 (카테고리별 풀, 사기 판결 확정 시 보험금 청구)
+	insuranceService := services.NewInsuranceService(database.GetDB())
+	scheduler.Register("insurance_policy_expiry", time.Hour, insuranceService.ExpirePolicies) // 청구 기한 지난 보험 만료
+
+	// 👥 복사 거래 (저장된 리더 체결을 팔로워 지정가 주문으로 복사)
+	copyTradingService := services.NewCopyTradingService(database.GetDB(), tradingService)
+	matchingEngine.AddTradeListener(copyTradingService)
+
+	// 💱 통화별 지갑 계정/환율/환전 (WALLET_CURRENCIES, 마켓별 결제 통화, BLUEPRINT↔USDC 일일 한도)
+	currencyService := services.NewCurrencyService(database.GetDB(), cfg.Wallet.CurrencyCodes(), cfg.Wallet.DailyConvertLimit)
+
+	// 📉 숏 포지션 포트폴리오 증거금 (평가 자산이 유지 증거금보다 적으면 강제 환매)
+	marginService := services.NewMarginService(database.GetDB(), tradingService)
+	scheduler.Register("short_margin_check", 5*time.Minute, marginService.CheckMargins) // 전체 숏 보유자 검사, 닫힌 환매 주문 정리
+
+	// 📉 강제 청산 워커 (체결로 가격이 움직인 마켓의 숏 보유자를 바로 검사)
+	liquidationEngine := services.NewLiquidationEngine(marginService)
+	matchingEngine.AddTradeListener(liquidationEngine)
+	if err := liquidationEngine.Start(); err != nil {
+		log.Printf("⚠️ Failed to start liquidation engine: %v", err)
+	}
+
+	// 📬 사용자별 비공개 SSE 스트림 (Redis user_events:* 구독, 이 인스턴스 연결에만 전달)
+	userStreamService := services.NewUserStreamService(database.GetDB())
+	go userStreamService.Run()
+
+	// 📤 내보내기 서비스 초기화 (파일 생성은 워커의 export_queue 담당)
+	exportService := services.NewExportService(database.GetDB(), fileService)
+	scheduler.Register("export_cleanup", time.Hour, func(time.Time) (int, error) { // 보관 기한 지난 파일 삭제
+		return exportService.CleanupExpired()
+	})
+	scheduler.Register("fee_statements", 6*time.Hour, exportService.GenerateMonthlyFeeStatements) // 지난달 수수료 명세서 자동 생성 (사용자별 한 번)
+
+	// 📋 워커 작업 진행 상태 (202 응답의 job_id 조회, 끝난 기록은 7일 보관)
+	jobService := services.NewJobService(database.GetDB())
+	scheduler.Register("job_cleanup", time.Hour, func(now time.Time) (int, error) {
+		removed, err := jobService.CleanupFinished(now.Add(-services.JobRetention))
+		return int(removed), err
+	})
+
+	// ☠️ 데드레터 큐 조회/재처리 (크기 경보는 /metrics)
+	deadLetterService := services.NewDeadLetterService(cfg.DeadLetter.AlertThreshold)
+
+	// 🔐 로그인 세션 서비스 초기화 (리프레시 토큰 회전 + 세션 폐기)
+	sessionService := services.NewSessionService(database.GetDB(), cfg.JWT.Secret)
+
+	// 🛡️ 역할 기반 접근 제어 (RBAC) 서비스 초기화
+	roleService := services.NewRoleService(database.GetDB())
+	roleService.BootstrapAdmins(cfg.Admin.BootstrapEmails)
+
+	// 🔑 API 키 서비스 초기화 (봇/마켓메이커용 HMAC 인증)
+	apiKeyService := services.NewAPIKeyService(database.GetDB(), cfg.APIKey.EncryptionSecret)
+
+	// 🐙 GitHub 연동 서비스 초기화 (저장소 웹훅 → 마일스톤 증거 자동 제출)
+	githubService := services.NewGitHubIntegrationService(database.GetDB(), verificationService, cfg.OAuth.GitHub.WebhookURL, cfg.APIKey.EncryptionSecret)
+
+	// 📮 외부 웹훅 서비스 초기화 (발송/재시도는 워커의 webhook_queue 담당)
+	webhookService := services.NewWebhookService(database.GetDB(), cfg.APIKey.EncryptionSecret)
+
+	// ✉️ 매직링크 서비스 초기화 (서명된 일회용 토큰 + 재발송 제한)
+	magicLinkService := services.NewMagicLinkService(database.GetDB(), services.MagicLinkPolicy{
+		SigningSecret:  cfg.MagicLink.SigningSecret,
+		TTL:            time.Duration(cfg.MagicLink.TTLMinutes) * time.Minute,
+		ResendInterval: time.Duration(cfg.MagicLink.ResendIntervalSeconds) * time.Second,
+		HourlyLimit:    cfg.MagicLink.HourlyLimit,
+		BindDevice:     cfg.MagicLink.BindDevice,
+	})
+
+	// 🔗 로그인 수단 연결 & 중복 계정 병합 서비스 초기화
+	accountLinkService := services.NewAccountLinkService(database.GetDB(), sessionService)
+
+	// 🗑️ 계정 삭제 서비스 초기화 (30일 유예 후 개인 데이터 삭제 및 익명화)
+	accountDeletionService := services.NewAccountDeletionService(database.GetDB(), tradingService, sessionService)
+	scheduler.Register("account_deletion_purge", time.Hour, func(time.Time) (int, error) {
+		return accountDeletionService.PurgeDue()
+	})
 
 	// Market Maker 봇 백그라운드 시작
-	go func() {
-		if err := marketMakerBot.Start(); err != nil {
-			log.Printf("Failed to start market maker bot: %v", err)
-		}
-	}()
+	if cfg.MarketMaker.AutoStart {
+		go func() {
+			if err := marketMakerBot.Start(); err != nil {
+				log.Printf("Failed to start market maker bot: %v", err)
+			}
+		}()
+	}
 
 	// Initialize handlers
 	// 핸들러 초기화
-	moduleConfig := convertToModuleConfig(cfg)
-	authHandler := handlers.NewAuthHandler(moduleConfig)
-	magicLinkHandler := handlers.NewMagicLinkHandler(moduleConfig)
-	projectHandler := handlers.NewProjectHandler(moduleConfig, aiService)
-	tradingHandler := handlers.NewTradingHandler(tradingService)
-	userSettingsHandler := handlers.NewUserSettingsHandler(moduleConfig)
-	oauthHandler := handlers.NewOAuthHandler(moduleConfig)
-	activityHandler := handlers.NewActivityHandler() // 활동 로그 핸들러 추가
-	profileHandler := handlers.NewProfileHandler()   // 프로필 핸들러 추가
-	verificationHandler := handlers.NewVerificationHandler(verificationService) // 🔍 검증 핸들러 추가
-	arbitrationHandler := handlers.NewArbitrationHandler(arbitrationService) // 🏛️ 분쟁 해결 핸들러 추가
-	mentorStakingHandler := handlers.NewMentorStakingHandler(mentorStakingService) // 💎 멘토 스테이킹 핸들러 추가
+	moduleConfig := &cfg.Config
+	authHandler := handlers.NewAuthHandler(moduleConfig, sessionService, accountLinkService)
+	magicLinkHandler := handlers.NewMagicLinkHandler(moduleConfig, sessionService, magicLinkService, accountLinkService)
+	projectHandler := handlers.NewProjectHandler(moduleConfig, aiService, projectMemberService, milestoneDependencyService)
+	tradingHandler := handlers.NewTradingHandler(tradingService, riskService, milestoneRiskService)
+	userSettingsHandler := handlers.NewUserSettingsHandler(moduleConfig, fileService, kycService)
+	oauthHandler := handlers.NewOAuthHandler(moduleConfig, githubService, accountLinkService)
+	activityHandler := handlers.NewActivityHandler()                                                     // 활동 로그 핸들러 추가
+	profileHandler := handlers.NewProfileHandler(userStatsService)                                       // 프로필 핸들러 추가
+	verificationHandler := handlers.NewVerificationHandler(verificationService)                          // 🔍 검증 핸들러 추가
+	arbitrationHandler := handlers.NewArbitrationHandler(arbitrationService)                             // 🏛️ 분쟁 해결 핸들러 추가
+	arbitrationEvidenceHandler := handlers.NewArbitrationEvidenceHandler(arbitrationEvidenceService)     // 🗂️ 분쟁 증거 핸들러 추가
+	mentorStakingHandler := handlers.NewMentorStakingHandler(mentorStakingService)                       // 💎 멘토 스테이킹 핸들러 추가
+	mentorFeedbackHandler := handlers.NewMentorFeedbackHandler(mentorFeedbackService)                    // ⭐ 멘티 세션 평가 핸들러 추가
+	stakingRewardsHandler := handlers.NewStakingRewardsHandler(stakingRewardsService)                    // 🌱 스테이킹 발행 보상 핸들러 추가
+	notificationHandler := handlers.NewNotificationHandler(notificationService, cfg.Push.VAPIDPublicKey) // 🔔 알림 핸들러 추가
+	fileHandler := handlers.NewFileHandler(fileService)                                                  // 📁 파일 다운로드 핸들러 추가
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService)                                            // 🔑 API 키 핸들러 추가
+	parlayHandler := handlers.NewParlayHandler(parlayService, kycService)                                // 🎰 조합 베팅 핸들러 추가
+	escrowHandler := handlers.NewEscrowHandler(escrowService, kycService)                                // 🤝 직접 후원 에스크로 핸들러 추가
+	payoutHandler := handlers.NewCreatorPayoutHandler(payoutService)                                     // 💸 창작자 지급 핸들러 추가
+	adminHandler := handlers.NewAdminHandler(roleService)                                                // 🛡️ 관리자(역할 관리) 핸들러 추가
+	kycHandler := handlers.NewKYCHandler(kycService)                                                     // 🪪 본인 인증 핸들러 추가
+	responsibleTradingHandler := handlers.NewResponsibleTradingHandler(responsibleTradingService)        // 🧘 책임 있는 거래 한도 핸들러 추가
+	positionTransferHandler := handlers.NewPositionTransferHandler(positionTransferService)              // 🎁 포지션 이전 핸들러 추가
+	insuranceHandler := handlers.NewInsuranceHandler(insuranceService)                                   // 🛡️ 마일스톤 보험 핸들러 추가
+	copyTradingHandler := handlers.NewCopyTradingHandler(copyTradingService)                             // 👥 복사 거래 핸들러 추가
+	marginHandler := handlers.NewMarginHandler(marginService)                                            // 📉 숏 증거금 핸들러 추가
+	walletAccountHandler := handlers.NewWalletAccountHandler(currencyService)                            // 💱 통화별 지갑 계정/환전 핸들러 추가
+	userStreamHandler := handlers.NewUserStreamHandler(userStreamService)                                // 📬 비공개 스트림 핸들러 추가
+	accountLinkHandler := handlers.NewAccountLinkHandler(accountLinkService)                             // 🔗 로그인 수단/계정 병합 핸들러 추가
+	accountDeletionHandler := handlers.NewAccountDeletionHandler(accountDeletionService, exportService)  // 🗑️ 계정 삭제/개인 데이터 내보내기 핸들러 추가
+	verificationReviewHandler := handlers.NewVerificationReviewHandler(verificationReviewService)        // 🎓 서류 심사 핸들러 추가
+	marketMakerHandler := handlers.NewMarketMakerHandler(marketMakerBot)                                 // 🤖 마켓메이커 운영 핸들러 추가
+	featureFlagHandler := handlers.NewFeatureFlagHandler(flagService)                                    // 🚩 기능 플래그 핸들러 추가
+	tradingPauseHandler := handlers.NewTradingPauseHandler(matchingEngine.TradingPause())                // 🚧 점검 모드/거래 중단 핸들러 추가
+	reconciliationHandler := handlers.NewReconciliationHandler(reconciliationService)                    // 🧾 대사 리포트 핸들러 추가
+	surveillanceHandler := handlers.NewSurveillanceHandler(surveillanceService)                          // 🕵️ 시장 감시 알림 핸들러 추가
+	marketCalendarHandler := handlers.NewMarketCalendarHandler(marketCalendarService)                    // 🔔 마켓 마감 시각 핸들러 추가
+	watchlistHandler := handlers.NewWatchlistHandler(watchlistService)                                   // 👀 프로젝트 팔로우 핸들러 추가
+	publicProjectHandler := handlers.NewPublicProjectHandler(publicProjectService)                       // 🌐 공개 프로젝트 핸들러 추가
+	projectMemberHandler := handlers.NewProjectMemberHandler(projectMemberService)                       // 👥 프로젝트 팀원 핸들러 추가
+	milestoneDependencyHandler := handlers.NewMilestoneDependencyHandler(milestoneDependencyService)     // 🔗 마일스톤 선후 관계 핸들러 추가
+	leaderboardHandler := handlers.NewLeaderboardHandler(leaderboardService)                             // 🏆 리더보드 핸들러 추가
+	platformStatsHandler := handlers.NewPlatformStatsHandler(platformStatsService)                       // 🌍 플랫폼 지표 핸들러 추가
+	milestoneAnalyticsHandler := handlers.NewMilestoneAnalyticsHandler(milestoneAnalyticsService)        // 🔬 마일스톤 분석 핸들러 추가
+	digestHandler := handlers.NewDigestHandler(digestService)                                            // 📬 알림 요약 메일 핸들러 추가
+	messagingHandler := handlers.NewMessagingHandler(messagingService)                                   // 💬 대화 핸들러 추가
+	mediationHandler := handlers.NewMediationHandler(mediationService)                                   // 🤝 분쟁 조정 핸들러 추가
+	portfolioHandler := handlers.NewPortfolioHandler(portfolioSnapshotService)                           // 📉 자산 곡선 핸들러 추가
+	exportHandler := handlers.NewExportHandler(exportService)                                            // 📤 내보내기 핸들러 추가
+	jobHandler := handlers.NewJobHandler(jobService)                                                     // 📋 작업 상태 핸들러 추가
+	deadLetterHandler := handlers.NewDeadLetterHandler(deadLetterService)                                // ☠️ 데드레터 큐 핸들러 추가
+	schedulerHandler := handlers.NewSchedulerHandler(scheduler)                                          // ⏱️ 주기 작업 핸들러 추가
+	referralHandler := handlers.NewReferralHandler(referralService)                                      // 🤝 추천 프로그램 핸들러 추가
+	githubHandler := handlers.NewGitHubIntegrationHandler(githubService)                                 // 🐙 GitHub 연동 핸들러 추가
+	webhookHandler := handlers.NewWebhookHandler(webhookService)                                         // 📮 외부 웹훅 핸들러 추가
+	fundingHandler := handlers.NewFundingHandler(fundingVerificationService, lifecycleService)           // 🏛️ 펀딩 검증 핸들러 추가
 
 	// API 라우트 그룹
 	api := router.Group("/api/v1")
@@ -209,6 +560,9 @@ func main() {
 		auth.POST("/magic-link", magicLinkHandler.CreateMagicLink)
 		auth.POST("/verify-magic-link", magicLinkHandler.VerifyMagicLink)
 
+		// 토큰 갱신 (리프레시 토큰 회전 - 액세스 토큰 만료 후에도 호출 가능)
+		auth.POST("/refresh", authHandler.RefreshToken)
+
 		// 소셜 미디어 연결 (신원 증명용)
 		auth.GET("/:provider/connect", middleware.AuthMiddleware(cfg), oauthHandler.StartOAuthConnect)
 		auth.GET("/:provider/callback", oauthHandler.OAuthCallback)
@@ -217,6 +571,14 @@ func main() {
 		auth.GET("/providers", oauthHandler.GetSupportedProviders)
 	}
 
+	// 🗑️ 삭제 예약으로 비활성화된 계정은 다시 로그인해 예약 조회/취소만 가능
+	pendingDeletion := api.Group("/")
+	pendingDeletion.Use(middleware.DeactivatedAccountAuthMiddleware(cfg))
+	{
+		pendingDeletion.GET("/users/me/deletion", accountDeletionHandler.GetAccountDeletion)       // 예약된 삭제 조회
+		pendingDeletion.DELETE("/users/me/deletion", accountDeletionHandler.CancelAccountDeletion) // 유예 기간 중 취소
+	}
+
 	// 🔐 인증이 필요한 라우터
 	protected := api.Group("/")
 	protected.Use(middleware.AuthMiddleware(cfg))
@@ -224,9 +586,28 @@ func main() {
 		// 🔐 사용자 정보
 		protected.GET("/users/me", authHandler.Me)                        // 사용자 정보 조회
 		protected.POST("/auth/logout", authHandler.Logout)                // 로그아웃
-		protected.POST("/auth/refresh", authHandler.RefreshToken)         // 토큰 갱신
 		protected.GET("/auth/token-expiry", authHandler.CheckTokenExpiry) // 토큰 만료 확인
 
+		// 🗑️ 계정 삭제 & 개인 데이터 내보내기
+		protected.DELETE("/users/me", accountDeletionHandler.DeleteAccount)    // 30일 유예 후 삭제 (모든 세션/API 키 폐기)
+		protected.GET("/users/me/export", accountDeletionHandler.ExportMyData) // 개인 데이터 전체 (JSON)
+
+		// 👤 내 역할/기능 플래그
+		protected.GET("/users/me/roles", adminHandler.GetMyRoles)               // 내 역할/권한
+		protected.GET("/users/me/feature-flags", featureFlagHandler.GetMyFlags) // 내게 적용되는 기능 플래그
+
+		// 📱 로그인 세션 (기기) 관리
+		protected.GET("/users/me/sessions", authHandler.GetSessions)
+		protected.DELETE("/users/me/sessions", authHandler.RevokeOtherSessions) // 현재 기기 제외 전체 로그아웃
+		protected.DELETE("/users/me/sessions/:id", authHandler.RevokeSession)
+
+		// 🔗 로그인 수단 연결 & 중복 계정 병합
+		protected.GET("/users/me/identities", accountLinkHandler.GetIdentities)
+		protected.DELETE("/users/me/identities/:provider", accountLinkHandler.UnlinkIdentity)
+		protected.GET("/users/me/account-merges/candidates", accountLinkHandler.GetDuplicateAccounts)
+		protected.POST("/users/me/account-merges", accountLinkHandler.StartAccountMerge)
+		protected.POST("/users/me/account-merges/:id/confirm", accountLinkHandler.ConfirmAccountMerge) // 흡수할 계정 이메일로 받은 코드
+
 		// 🧑‍💼 계정 설정 & 신원 증명
 		protected.GET("/users/me/settings", userSettingsHandler.GetMySettings)
 		protected.PUT("/users/me/profile", userSettingsHandler.UpdateProfile)
@@ -235,34 +616,162 @@ func main() {
 		protected.POST("/users/me/verify/email", userSettingsHandler.RequestVerifyEmail)
 		protected.POST("/users/me/verify/email/confirm", userSettingsHandler.VerifyEmailCode)
 		protected.POST("/users/me/verify/phone", userSettingsHandler.RequestVerifyPhone)
+		protected.POST("/users/me/verify/phone/confirm", userSettingsHandler.VerifyPhoneCode)
 		protected.POST("/users/me/connect/:provider", userSettingsHandler.ConnectProvider) // linkedin|github|twitter
 		protected.POST("/users/me/verify/work-email", userSettingsHandler.VerifyWorkEmail)
 		protected.POST("/users/me/verify/professional", userSettingsHandler.SubmitProfessionalDoc)
 		protected.POST("/users/me/verify/education", userSettingsHandler.SubmitEducationDoc)
+		protected.GET("/users/me/verify/documents/:doc_type/url", userSettingsHandler.GetVerificationDocURL)
+
+		// 🪪 본인 인증 (KYC) - 인증 단계에 따라 주문/출금 한도 적용
+		protected.GET("/users/me/kyc", kycHandler.GetMyKYC)
+		protected.POST("/users/me/kyc", kycHandler.SubmitKYC)
+
+		// 🧘 내 일일 한도/휴식/자기 배제
+		protected.GET("/users/me/limits", responsibleTradingHandler.GetLimits)
+		protected.PUT("/users/me/limits", responsibleTradingHandler.UpdateLimits)
+		protected.POST("/users/me/limits/cooling-off", responsibleTradingHandler.StartCoolingOff)
+		protected.POST("/users/me/limits/self-exclusion", responsibleTradingHandler.SelfExclude)
+
+		// 🎁 포지션 이전/선물 (JWT 세션 전용, 받는 사람 수락 필요)
+		protected.POST("/positions/transfers", positionTransferHandler.CreateTransfer)
+		protected.GET("/positions/transfers", positionTransferHandler.ListTransfers)
+		protected.POST("/positions/transfers/:id/accept", positionTransferHandler.AcceptTransfer)
+		protected.POST("/positions/transfers/:id/decline", positionTransferHandler.DeclineTransfer)
+		protected.POST("/positions/transfers/:id/cancel", positionTransferHandler.CancelTransfer)
+
+		// 🛡️ 마일스톤 보험 (보험료 납부/보험금 청구)
+		protected.GET("/insurance/quote", insuranceHandler.Quote)
+		protected.POST("/insurance/policies", insuranceHandler.Purchase)
+		protected.GET("/insurance/policies", insuranceHandler.ListPolicies)
+		protected.POST("/insurance/policies/:id/claim", insuranceHandler.Claim)
+
+		// 👥 복사 거래 (리더 모드, 팔로우/한도/일시 중지/해제)
+		protected.PUT("/copy-trading/leader", copyTradingHandler.UpdateLeader)
+		protected.POST("/copy-trading/leaders/:id/follow", copyTradingHandler.Follow)
+		protected.GET("/copy-trading/follows", copyTradingHandler.ListFollows)
+		protected.PUT("/copy-trading/follows/:id", copyTradingHandler.UpdateFollow)
+		protected.DELETE("/copy-trading/follows/:id", copyTradingHandler.Unfollow)
+		protected.GET("/copy-trading/executions", copyTradingHandler.ListExecutions)
+
+		// 📉 숏 포지션 증거금/강제 청산 내역
+		protected.GET("/margin", marginHandler.GetSummary)
+		protected.GET("/margin/liquidations", marginHandler.ListLiquidations)
+
+		// 💱 통화별 지갑 계정, 결제 통화 환율/환전
+		protected.GET("/wallet/accounts", walletAccountHandler.ListAccounts)
+		protected.GET("/wallet/rates", walletAccountHandler.ListRates)
+		protected.GET("/wallet/convert/quote", walletAccountHandler.QuoteConversion)
+		protected.POST("/wallet/convert", walletAccountHandler.Convert)
+		protected.GET("/wallet/history", walletAccountHandler.GetHistory)
+
+		// 📬 내 주문 체결/취소, 지갑 잔액, 알림 실시간 스트림 (GetMyOrders 폴링 대체)
+		protected.GET("/stream/me", userStreamHandler.StreamMe)
+
+		// 💬 멘토링 세션/분쟁 사건 대화 (새 메시지와 읽음 표시는 /stream/me로 전달)
+		protected.GET("/conversations", messagingHandler.ListConversations)
+		protected.POST("/mentoring/sessions/:id/conversation", messagingHandler.OpenMentorshipConversation) // 멘토/멘티
+		protected.POST("/arbitration/cases/:id/conversation", messagingHandler.OpenArbitrationConversation) // 신청인/피신청인
+		protected.GET("/conversations/:id/messages", messagingHandler.ListMessages)
+		protected.POST("/conversations/:id/messages", messagingHandler.SendMessage)
+		protected.POST("/conversations/:id/read", messagingHandler.MarkRead)
+		protected.POST("/messages/:id/report", messagingHandler.ReportMessage) // 운영자 검토 대기열로 신고
+
+		// 🤝 분쟁 조정 (증거 분쟁/멘토 신고 당사자 간 제안·역제안, 결렬 시 배심원 중재)
+		protected.GET("/mediations", mediationHandler.ListMediations)
+		protected.GET("/mediations/:id", mediationHandler.GetMediation)
+		protected.POST("/mediations/:id/offers", mediationHandler.MakeOffer)
+		protected.POST("/mediations/:id/offers/:offer_id/accept", mediationHandler.AcceptOffer)
+		protected.POST("/mediations/:id/offers/:offer_id/reject", mediationHandler.RejectOffer)
+		protected.POST("/mediations/:id/escalate", mediationHandler.Escalate) // 결렬 선언
+
+		// 🤝 추천 프로그램 (피추천인 거래 수수료 20%를 가입 후 90일간 적립)
+		protected.GET("/users/me/referral", referralHandler.GetMyReferral)
+		protected.GET("/users/me/referral/referees", referralHandler.GetMyReferees)
+		protected.GET("/users/me/referral/payouts", referralHandler.GetMyPayouts)
 
 		// 📝 활동 로그
 		protected.GET("/users/me/activities", activityHandler.GetUserActivities)          // 사용자 활동 로그 조회
 		protected.GET("/users/me/activities/summary", activityHandler.GetActivitySummary) // 활동 요약 (대시보드용)
 
+		// 🔔 알림
+		protected.GET("/notifications", notificationHandler.GetNotifications)                 // 내 알림 목록
+		protected.GET("/notifications/unread-count", notificationHandler.GetUnreadCount)      // 읽지 않은 알림 개수
+		protected.POST("/notifications/read-all", notificationHandler.MarkAllAsRead)          // 모든 알림 읽음 처리
+		protected.POST("/notifications/:id/read", notificationHandler.MarkAsRead)             // 알림 읽음 처리
+		protected.POST("/users/me/push-subscriptions", notificationHandler.SubscribePush)     // Web Push 구독
+		protected.DELETE("/users/me/push-subscriptions", notificationHandler.UnsubscribePush) // Web Push 구독 해제
+
+		// 🔑 API 키 관리 (JWT 세션 전용)
+		protected.GET("/users/me/api-keys", apiKeyHandler.ListAPIKeys)
+		protected.POST("/users/me/api-keys", apiKeyHandler.CreateAPIKey)
+		protected.DELETE("/users/me/api-keys/:id", apiKeyHandler.RevokeAPIKey)
+
+		// 💸 창작자 지급 (JWT 세션 전용, 관리자 승인 후 워커가 송금)
+		protected.POST("/payouts/accounts", payoutHandler.RegisterAccount)     // 지급 계좌 등록 (은행/암호화폐)
+		protected.GET("/payouts/accounts", payoutHandler.GetAccounts)          // 내 지급 계좌 (마스킹)
+		protected.DELETE("/payouts/accounts/:id", payoutHandler.DeleteAccount) // 지급 계좌 삭제
+		protected.GET("/payouts/earnings", payoutHandler.GetEarnings)          // 마일스톤별 수익/지급 가능 금액
+		protected.POST("/payouts", payoutHandler.RequestPayout)                // 지급 요청 (금액 잠금)
+		protected.GET("/payouts/my", payoutHandler.GetMyPayouts)               // 내 지급 요청 내역
+
+		// 📮 외부 웹훅 (체결/마일스톤 확정/분쟁 판결/슬래싱 이벤트)
+		protected.GET("/users/me/webhooks", webhookHandler.ListWebhooks)
+		protected.POST("/users/me/webhooks", webhookHandler.CreateWebhook)
+		protected.PATCH("/users/me/webhooks/:id", webhookHandler.UpdateWebhook)
+		protected.DELETE("/users/me/webhooks/:id", webhookHandler.DeleteWebhook)
+		protected.POST("/users/me/webhooks/:id/rotate-secret", webhookHandler.RotateWebhookSecret)
+		protected.POST("/users/me/webhooks/:id/test", webhookHandler.TestWebhook)
+		protected.GET("/users/me/webhooks/:id/deliveries", webhookHandler.GetWebhookDeliveries)
+		protected.POST("/users/me/webhook-deliveries/:id/redeliver", webhookHandler.RedeliverWebhook)
+
 		// 👤 프로필 조회 (public/private)
 		protected.GET("/users/:username/profile", profileHandler.GetUserProfile) // 사용자 프로필 조회
 
 		// 🏗️ 프로젝트 관리
-		protected.POST("/projects", projectHandler.CreateProjectWithMilestones) // 기존 메서드 사용
-		protected.GET("/projects", projectHandler.GetProjects)                  // 프로젝트 목록
-		protected.GET("/projects/:id", projectHandler.GetProject)               // 특정 프로젝트
-		protected.PUT("/projects/:id", projectHandler.UpdateProject)            // 프로젝트 수정
+		protected.POST("/projects", projectHandler.CreateProjectWithMilestones)                    // 기존 메서드 사용
+		protected.GET("/projects", projectHandler.GetProjects)                                     // 프로젝트 목록
+		protected.GET("/projects/:id", projectHandler.GetProject)                                  // 특정 프로젝트
+		protected.PUT("/projects/:id", projectHandler.UpdateProject)                               // 프로젝트 수정
 		protected.PUT("/projects/:id/with-milestones", projectHandler.UpdateProjectWithMilestones) // 프로젝트와 마일스톤 함께 수정
-		protected.DELETE("/projects/:id", projectHandler.DeleteProject)         // 프로젝트 삭제
-		protected.GET("/ai/usage", projectHandler.GetAIUsageInfo)               // AI 마일스톤 제안
-		protected.POST("/ai/milestones", projectHandler.GenerateAIMilestones)   // AI 마일스톤 제안
+		protected.DELETE("/projects/:id", projectHandler.DeleteProject)                            // 프로젝트 삭제
+		protected.POST("/projects/:id/watch", watchlistHandler.WatchProject)                       // 프로젝트 팔로우
+		protected.DELETE("/projects/:id/watch", watchlistHandler.UnwatchProject)                   // 프로젝트 팔로우 해제
+		protected.GET("/users/me/watchlist", watchlistHandler.GetMyWatchlist)                      // 내 관심 프로젝트 목록
+
+		// 👥 프로젝트 팀원 (작성자=owner, editor는 수정/증거 제출, viewer는 비공개 열람)
+		protected.GET("/projects/:id/members", projectMemberHandler.ListMembers)
+		protected.PATCH("/projects/:id/members/:user_id", projectMemberHandler.UpdateMemberRole)
+		protected.DELETE("/projects/:id/members/:user_id", projectMemberHandler.RemoveMember) // 본인이면 팀 탈퇴
+		protected.POST("/projects/:id/invites", projectMemberHandler.InviteMember)
+		protected.GET("/projects/:id/invites", projectMemberHandler.ListInvites)
+		protected.DELETE("/projects/:id/invites/:invite_id", projectMemberHandler.RevokeInvite)
+		protected.GET("/users/me/project-invites", projectMemberHandler.GetMyProjectInvites)
+		protected.POST("/project-invites/:id/accept", projectMemberHandler.AcceptProjectInvite)
+		protected.POST("/project-invites/:id/decline", projectMemberHandler.DeclineProjectInvite)
+		protected.GET("/ai/usage", projectHandler.GetAIUsageInfo)             // AI 마일스톤 제안
+		protected.POST("/ai/milestones", projectHandler.GenerateAIMilestones) // AI 마일스톤 제안
 
 		// 🔍 마일스톤 증명 및 검증 시스템
-		protected.POST("/milestones/:id/proof", verificationHandler.SubmitProof)           // 증거 제출
-		protected.GET("/milestones/:id/proofs", verificationHandler.GetMilestoneProofs)   // 마일스톤 증거 목록
-		protected.POST("/proofs/:id/validate", verificationHandler.ValidateProof)         // 증거 검증 (투표)
-		protected.POST("/proofs/:id/dispute", verificationHandler.DisputeProof)           // 증거 분쟁 제기
-		protected.GET("/proofs/:id/verification", verificationHandler.GetProofVerification) // 증거 검증 정보 조회
+		protected.POST("/milestones/:id/proof", verificationHandler.SubmitProof)                  // 증거 제출
+		protected.GET("/milestones/:id/proofs", verificationHandler.GetMilestoneProofs)           // 마일스톤 증거 목록
+		protected.GET("/milestones/:id/dependencies", milestoneDependencyHandler.GetDependencies) // 선행/후행 마일스톤
+		protected.PUT("/milestones/:id/dependencies", milestoneDependencyHandler.SetDependencies) // 선행 마일스톤 지정 (펀딩 시작 전)
+		protected.POST("/proofs/:id/validate", verificationHandler.ValidateProof)                 // 증거 검증 (투표)
+		protected.POST("/proofs/:id/dispute", verificationHandler.DisputeProof)                   // 증거 분쟁 제기
+		protected.GET("/proofs/:id/verification", verificationHandler.GetProofVerification)       // 증거 검증 정보 조회
+
+		// 🐙 GitHub 연동 (릴리스/태그/CI 이벤트로 증거 자동 제출)
+		protected.GET("/integrations/github/repos", githubHandler.ListRepositories)    // 연결된 계정의 저장소 목록
+		protected.GET("/milestones/:id/github-webhooks", githubHandler.ListWebhooks)   // 마일스톤 웹훅 구독 목록
+		protected.POST("/milestones/:id/github-webhooks", githubHandler.CreateWebhook) // 마일스톤 웹훅 등록
+		protected.DELETE("/github-webhooks/:id", githubHandler.DeleteWebhook)          // 웹훅 구독 해제
+
+		// 🏛️ 펀딩 단계 운영 (funding:manage 권한)
+		protected.POST("/milestones/:id/funding/start", middleware.RequirePermission(roleService, models.PermissionManageFunding), fundingHandler.StartFundingPhase)                    // 펀딩 단계 강제 시작
+		protected.POST("/funding/process-expired", middleware.RequirePermission(roleService, models.PermissionManageFunding), fundingHandler.ProcessExpiredFunding)                     // 만료 펀딩 강제 처리
+		protected.POST("/milestones/:id/resolve", middleware.RequirePermission(roleService, models.PermissionResolveMarkets), fundingHandler.ResolveOutcome)                            // 다중 결과 마켓 승리 옵션 확정
+		protected.POST("/milestones/:id/price-history/:option/rebuild", middleware.RequirePermission(roleService, models.PermissionResolveMarkets), tradingHandler.RebuildPriceHistory) // 가격 캔들 재집계
 		
 		// 🔍 검증인 대시보드 및 관리
 		protected.GET("/verification/dashboard", verificationHandler.GetValidatorDashboard)  // 검증인 대시보드
@@ -271,60 +780,268 @@ func main() {
 		protected.POST("/verification/upload", verificationHandler.UploadProofFile)         // 증거 파일 업로드
 
 		// 🏛️ 탈중앙화된 분쟁 해결 시스템
-		protected.POST("/arbitration/cases", arbitrationHandler.SubmitCase)                 // 분쟁 사건 제기
-		protected.GET("/arbitration/cases/:id", arbitrationHandler.GetCase)                 // 분쟁 사건 조회
-		protected.POST("/arbitration/cases/:id/vote", arbitrationHandler.CommitVote)        // 배심원 투표 제출
-		protected.POST("/arbitration/cases/:id/reveal", arbitrationHandler.RevealVote)      // 투표 공개
-		protected.POST("/arbitration/cases/:id/appeal", arbitrationHandler.AppealCase)      // 판결 이의제기
-		protected.GET("/arbitration/juror/dashboard", arbitrationHandler.GetJurorDashboard) // 배심원 대시보드
-		protected.GET("/arbitration/cases/pending", arbitrationHandler.GetPendingCases)     // 대기 중인 사건들
-		protected.GET("/arbitration/cases/my", arbitrationHandler.GetMyCases)               // 내 분쟁 사건들
-		protected.POST("/arbitration/juror/register", arbitrationHandler.BecomeJuror)       // 배심원 등록
+		protected.POST("/arbitration/cases", arbitrationHandler.SubmitCase)                                                // 분쟁 사건 제기
+		protected.GET("/arbitration/cases/:id", arbitrationHandler.GetCase)                                                // 분쟁 사건 조회
+		protected.POST("/arbitration/cases/:id/vote-nonce", arbitrationHandler.IssueVoteNonce)                             // 배심원 투표 nonce 발급
+		protected.POST("/arbitration/cases/:id/vote", arbitrationHandler.CommitVote)                                       // 배심원 투표 제출
+		protected.POST("/arbitration/cases/:id/reveal", arbitrationHandler.RevealVote)                                     // 투표 공개
+		protected.POST("/arbitration/cases/:id/appeal", arbitrationHandler.AppealCase)                                     // 판결 이의제기
+		protected.POST("/arbitration/cases/:id/evidence", arbitrationEvidenceHandler.SubmitEvidence)                       // 증거 파일 제출
+		protected.GET("/arbitration/cases/:id/evidence", arbitrationEvidenceHandler.ListEvidence)                          // 증거 목록 (당사자/배심원)
+		protected.GET("/arbitration/cases/:id/evidence/:evidenceId/download", arbitrationEvidenceHandler.DownloadEvidence) // 증거 다운로드
+		protected.GET("/arbitration/juror/dashboard", arbitrationHandler.GetJurorDashboard)                                // 배심원 대시보드
+		protected.GET("/arbitration/cases/pending", arbitrationHandler.GetPendingCases)                                    // 대기 중인 사건들
+		protected.GET("/arbitration/cases/my", arbitrationHandler.GetMyCases)                                              // 내 분쟁 사건들
+		protected.POST("/arbitration/juror/register", arbitrationHandler.BecomeJuror)                                      // 배심원 등록
+		protected.GET("/arbitration/juror/availability", arbitrationHandler.GetJurorAvailability)                          // 배심원 가용성 조회
+		protected.PUT("/arbitration/juror/availability", arbitrationHandler.UpdateJurorAvailability)                       // 동시 사건 한도/휴가 모드 설정
 		// protected.GET("/arbitration/stats", arbitrationHandler.GetArbitrationStats)         // 분쟁 해결 통계 (중복으로 주석처리)
 
 		// 💎 멘토 스테이킹 및 슬래싱 시스템
-		protected.POST("/mentors/:id/stake", mentorStakingHandler.StakeMentor)              // 멘토 스테이킹
-		protected.POST("/stakes/:id/unstake", mentorStakingHandler.UnstakeMentor)           // 스테이킹 해제
-		protected.POST("/mentors/:id/report", mentorStakingHandler.ReportMentor)            // 멘토 신고
-		protected.GET("/stakes/my", mentorStakingHandler.GetMyStakes)                       // 내 스테이킹 목록
-		protected.GET("/mentors/:id/stakes", mentorStakingHandler.GetMentorStakes)          // 멘토 스테이킹 정보
-		protected.GET("/mentors/:id/performance", mentorStakingHandler.GetMentorPerformance) // 멘토 성과 지표
-		protected.GET("/mentors/my/dashboard", mentorStakingHandler.GetMentorDashboard)     // 멘토 대시보드
-		protected.GET("/mentors/:id/slash-events", mentorStakingHandler.GetSlashEvents)     // 슬래싱 이벤트 목록
-		protected.POST("/slash-events/:id/process", mentorStakingHandler.ProcessSlashEvent) // 슬래싱 처리 (관리자)
-		protected.GET("/staking/stats", mentorStakingHandler.GetStakingStats)               // 스테이킹 통계
+		protected.POST("/mentors/:id/stake", mentorStakingHandler.StakeMentor)                                                                                           // 멘토 스테이킹
+		protected.POST("/stakes/:id/unstake", mentorStakingHandler.UnstakeMentor)                                                                                        // 스테이킹 해제
+		protected.PUT("/stakes/:id/auto-renewal", mentorStakingHandler.UpdateAutoRenewal)                                                                                // 자동 갱신 설정
+		protected.POST("/mentors/:id/report", mentorStakingHandler.ReportMentor)                                                                                         // 멘토 신고
+		protected.GET("/stakes/my", mentorStakingHandler.GetMyStakes)                                                                                                    // 내 스테이킹 목록
+		protected.GET("/mentors/:id/stakes", mentorStakingHandler.GetMentorStakes)                                                                                       // 멘토 스테이킹 정보
+		protected.GET("/mentors/:id/performance", mentorStakingHandler.GetMentorPerformance)                                                                             // 멘토 성과 지표
+		protected.GET("/mentors/my/dashboard", mentorStakingHandler.GetMentorDashboard)                                                                                  // 멘토 대시보드
+		protected.POST("/mentors/rewards/claim", mentorStakingHandler.ClaimRewards)                                                                                      // 멘토 풀 보상 청구
+		protected.GET("/mentors/:id/slash-events", mentorStakingHandler.GetSlashEvents)                                                                                  // 슬래싱 이벤트 목록
+		protected.GET("/mentors/:id/feedback", mentorFeedbackHandler.GetMentorFeedback)                                                                                  // 멘티 평가 요약/목록
+		protected.POST("/mentoring/sessions/:id/feedback", mentorFeedbackHandler.RateSession)                                                                            // 세션 평가 (멘티)
+		protected.POST("/slash-events/:id/process", middleware.RequirePermission(roleService, models.PermissionProcessSlashing), mentorStakingHandler.ProcessSlashEvent) // 슬래싱 처리 (관리자/운영자)
+		protected.GET("/staking/stats", mentorStakingHandler.GetStakingStats)                                                                                            // 스테이킹 통계
+		protected.GET("/staking/rewards", stakingRewardsHandler.GetRewards)                                                                                              // 내 스테이킹 발행 보상
+		protected.POST("/staking/rewards/claim", stakingRewardsHandler.ClaimRewards)                                                                                     // 스테이킹 발행 보상 청구
+	}
 
+	// 🤖 거래 API (JWT 세션 또는 HMAC 서명 API 키)
+	readAuth := middleware.SessionOrAPIKeyMiddleware(cfg, apiKeyService, models.APIKeyScopeRead)
+	tradeAuth := middleware.SessionOrAPIKeyMiddleware(cfg, apiKeyService, models.APIKeyScopeTrade)
+	{
 		// 💰 지갑 관리
-		protected.GET("/wallet", tradingHandler.GetUserWallet) // 사용자 지갑 조회
+		api.GET("/wallet", readAuth, tradingHandler.GetUserWallet) // 사용자 지갑 조회
 
 		// 📈 P2P 거래 시스템
-		protected.POST("/orders", tradingHandler.CreateOrder)                                  // 주문 생성
-		protected.GET("/orders/my", tradingHandler.GetMyOrders)                                // 내 주문 내역
-		protected.DELETE("/orders/:id", tradingHandler.CancelOrder)                            // 주문 취소
-		protected.GET("/trades/my", tradingHandler.GetMyTrades)                                // 내 거래 내역
-		protected.GET("/positions/my", tradingHandler.GetMyPositions)                          // 내 포지션
-		protected.GET("/milestones/:id/position/:option", tradingHandler.GetMilestonePosition) // 특정 포지션
+		api.POST("/orders", tradeAuth, tradingHandler.CreateOrder)                    // 주문 생성
+		api.GET("/orders/my", readAuth, tradingHandler.GetMyOrders)                   // 내 주문 내역
+		api.DELETE("/orders/:id", tradeAuth, tradingHandler.CancelOrder)              // 주문 취소
+		api.GET("/trades/my", readAuth, tradingHandler.GetMyTrades)                   // 내 거래 내역
+		api.GET("/positions/my", readAuth, tradingHandler.GetMyPositions)             // 내 포지션
+		api.GET("/portfolio/history", readAuth, portfolioHandler.GetPortfolioHistory) // 일별 자산 곡선
+		api.GET("/risk/limits", readAuth, tradingHandler.GetRiskLimits)               // 리스크 한도 및 사용량
+
+		// 📤 거래 내역 내보내기 (CSV/Excel, 워커에서 비동기 생성)
+		api.GET("/trades/export", readAuth, exportHandler.ExportTrades)                            // 체결 내역
+		api.GET("/orders/export", readAuth, exportHandler.ExportOrders)                            // 주문 내역
+		api.GET("/tax-reports/export", readAuth, exportHandler.ExportTaxReport)                    // 연간 실현 손익
+		api.GET("/fee-statements/export", readAuth, exportHandler.ExportFeeStatement)              // 월간 수수료 명세서 (PDF)
+		api.GET("/exports", readAuth, exportHandler.ListExports)                                   // 내보내기 요청 목록
+		api.GET("/exports/:id", readAuth, exportHandler.GetExport)                                 // 진행 상태
+		api.GET("/exports/:id/download", readAuth, exportHandler.DownloadExport)                   // 파일 다운로드
+		api.GET("/jobs/:id", jobHandler.GetJob)                                                    // 비동기 작업 상태 (job_id는 요청한 클라이언트만 앎)
+		api.GET("/milestones/:id/position/:option", readAuth, tradingHandler.GetMilestonePosition) // 특정 포지션
+
+		// 🎰 조합 베팅 (Parlay)
+		api.POST("/parlays", tradeAuth, parlayHandler.CreateParlay)                            // 조합 포지션 생성
+		api.GET("/parlays/my", readAuth, parlayHandler.GetMyParlays)                           // 내 조합 포지션
+		api.GET("/parlays/:id", readAuth, parlayHandler.GetParlay)                             // 조합 포지션 상세
+		api.POST("/projects/:id/roadmap-bundle", tradeAuth, parlayHandler.CreateRoadmapBundle) // 로드맵 전체 성공 묶음
+
+		// 🤝 마일스톤 직접 후원 (거래와 별개, 완료 시 프로젝트 소유자 지급 / 실패·취소 시 반환)
+		api.POST("/milestones/:id/escrow", tradeAuth, escrowHandler.Deposit) // 후원 예치
+		api.GET("/escrow/my", readAuth, escrowHandler.GetMyEscrows)          // 내 후원 내역
 	}
 
 	// 📊 공개 마켓 데이터 API
-	api.GET("/milestones/:id/market", tradingHandler.GetMilestoneMarket)             // 마켓 정보 조회
-	api.POST("/milestones/:id/market/init", tradingHandler.InitializeMarket)         // 마켓 초기화
-	api.GET("/milestones/:id/orderbook/:option", tradingHandler.GetOrderBook)        // 호가창 조회 (option별)
-	api.GET("/milestones/:id/trades/:option", tradingHandler.GetRecentTrades)        // 최근 거래 조회 (option별)
-	api.GET("/milestones/:id/price-history/:option", tradingHandler.GetPriceHistory) // 가격 히스토리 조회 (option별)
+	api.GET("/markets", tradingHandler.ListMarkets)                                          // 마켓 탐색 (필터/정렬)
+	api.GET("/insurance/pools", insuranceHandler.ListPools)                                  // 카테고리별 보험 풀 현황
+	api.GET("/copy-trading/leaders", copyTradingHandler.ListLeaders)                         // 팔로우할 수 있는 리더 목록
+	api.GET("/copy-trading/disclaimer", copyTradingHandler.GetDisclaimer)                    // 복사 거래 위험 고지
+	api.GET("/milestones/:id/market", tradingHandler.GetMilestoneMarket)                     // 마켓 정보 조회
+	api.POST("/milestones/:id/market/init", tradingHandler.InitializeMarket)                 // 마켓 초기화
+	api.GET("/milestones/:id/orderbook/:option", tradingHandler.GetOrderBook)                // 호가창 조회 (option별)
+	api.GET("/milestones/:id/trades/:option", tradingHandler.GetRecentTrades)                // 최근 거래 조회 (option별)
+	api.GET("/milestones/:id/price-history/:option", tradingHandler.GetPriceHistory)         // 가격 히스토리 조회 (option별)
+	api.GET("/milestones/:id/status-history", verificationHandler.GetMilestoneStatusHistory) // 상태 전환 이력
+	api.GET("/milestones/:id/funding/stats", fundingHandler.GetFundingStats)                 // 펀딩 TVL/목표/검증 단계
+	api.GET("/milestones/:id/escrow", escrowHandler.GetMilestoneEscrow)                      // 직접 후원 현황
+	api.GET("/milestones/:id/analytics", milestoneAnalyticsHandler.GetMilestoneAnalytics)    // 보유 집중도/일별 거래량/호가 잔량 추이
+	api.GET("/funding/active", fundingHandler.GetFundingMilestones)                          // 펀딩 진행 중 마일스톤 목록
+	api.GET("/funding/dashboard", fundingHandler.GetFundingDashboard)                        // 펀딩 현황 대시보드
+	api.GET("/funding/lifecycle-stats", fundingHandler.GetLifecycleStats)                    // 라이프사이클 스케줄러 상태
+	api.POST("/parlays/quote", parlayHandler.QuoteParlay)                                    // 조합 가격 견적
+	api.GET("/projects/:id/roadmap-bundle/quote", parlayHandler.QuoteRoadmapBundle)          // 로드맵 묶음 가격 견적
+	api.GET("/trading/stats", tradingHandler.GetTradingStats)                                // 거래/매칭 엔진 통계
 	
+	// 🌐 공개 프로젝트 페이지 (비로그인, 공개 설정된 프로젝트만)
+	public := api.Group("/public")
+	{
+		public.GET("/projects", publicProjectHandler.ListPublicProjects)       // 공개 프로젝트 목록 (카테고리/태그/단계/펀딩 필터)
+		public.GET("/projects/:id", publicProjectHandler.GetPublicProject)     // 프로젝트 + 마일스톤 + 마켓 가격 + TVL
+		public.GET("/project-tags", publicProjectHandler.ListProjectTags)      // 많이 쓰인 태그 (카테고리별)
+		public.GET("/project-categories", projectHandler.GetProjectCategories) // 카테고리 목록
+	}
+
 	// 🏛️ 공개 분쟁 해결 정보
 	api.GET("/arbitration/stats", arbitrationHandler.GetArbitrationStats)           // 분쟁 해결 통계 (공개)
 	
 	// 💎 공개 멘토 정보
-	api.GET("/mentors/top", mentorStakingHandler.GetTopMentors)                      // 상위 멘토 목록
+	api.GET("/mentors/top", mentorStakingHandler.GetTopMentors)       // 상위 멘토 목록
+	api.GET("/leaderboards/:kind", leaderboardHandler.GetLeaderboard) // 리더보드 (traders | validators | mentors)
+	api.GET("/stats/platform", platformStatsHandler.GetPlatformStats) // 플랫폼 전체 지표 (5분마다 집계)
 	// api.GET("/mentors/:id/stakes", mentorStakingHandler.GetMentorStakes)             // 멘토 스테이킹 정보 (공개) - 중복으로 주석처리
 	// api.GET("/mentors/:id/performance", mentorStakingHandler.GetMentorPerformance)   // 멘토 성과 지표 (공개) - 중복으로 주석처리
 	// api.GET("/staking/stats", mentorStakingHandler.GetStakingStats)                  // 스테이킹 통계 (공개) - 중복으로 주석처리
 
+	// 🔔 Web Push 공개키
+	api.GET("/push/vapid-public-key", notificationHandler.GetVAPIDPublicKey)
+
+	// 🐙 GitHub 웹훅 수신 (구독별 secret으로 서명 검증)
+	api.POST("/webhooks/github", githubHandler.ReceiveWebhook)
+
+	// 📬 알림 요약 메일 수신 거부 (메일의 서명 토큰, 로그인 불필요)
+	api.POST("/digests/unsubscribe", digestHandler.Unsubscribe)
+
+	// 📁 업로드 파일 다운로드 (민감 문서는 서명된 URL 필요)
+	api.GET("/files/:category/:key", fileHandler.DownloadFile)
+
 	// 📡 실시간 연결
 	api.GET("/milestones/:id/stream", tradingHandler.HandleSSEConnection) // SSE 연결
 
+	// 🛡️ 관리자 전용 (역할 관리)
+	admin := api.Group("/admin")
+	admin.Use(middleware.AuthMiddleware(cfg), middleware.RequirePermission(roleService, models.PermissionManageRoles))
+	{
+		admin.GET("/roles", adminHandler.GetRoleDefinitions)            // 역할별 권한 정의
+		admin.GET("/roles/:role/members", adminHandler.GetRoleMembers)  // 역할별 사용자 목록
+		admin.GET("/users/:id/roles", adminHandler.GetUserRoles)        // 사용자 역할 조회
+		admin.POST("/users/:id/roles", adminHandler.GrantRole)          // 역할 부여
+		admin.DELETE("/users/:id/roles/:role", adminHandler.RevokeRole) // 역할 회수
+	}
+
+	// 🪪 본인 인증 심사 (운영자)
+	kycReview := api.Group("/admin/kyc")
+	kycReview.Use(middleware.AuthMiddleware(cfg), middleware.RequirePermission(roleService, models.PermissionReviewKYC))
+	{
+		kycReview.GET("/pending", kycHandler.GetPendingKYC)
+		kycReview.POST("/:id/review", kycHandler.ReviewKYC)
+	}
+
+	// 🎓 전문 자격/학력 서류 심사 (운영자)
+	credentialReview := api.Group("/admin/verifications")
+	credentialReview.Use(middleware.AuthMiddleware(cfg), middleware.RequirePermission(roleService, models.PermissionReviewCredentials))
+	{
+		credentialReview.GET("/pending", verificationReviewHandler.GetPendingVerifications)                           // 심사 대기 목록
+		credentialReview.GET("/users/:user_id/:doc_type/document", verificationReviewHandler.GetVerificationDocument) // 서류 열람 URL
+		credentialReview.POST("/users/:user_id/:doc_type/review", verificationReviewHandler.ReviewVerification)       // 승인/거절
+	}
+
+	// 🤖 마켓메이커 봇 운영 (관리자)
+	marketMaker := api.Group("/admin/market-maker")
+	marketMaker.Use(middleware.AuthMiddleware(cfg), middleware.RequirePermission(roleService, models.PermissionManageMarketMaker))
+	{
+		marketMaker.GET("", marketMakerHandler.GetStatus)                                                                     // 실행 상태/설정/손익
+		marketMaker.POST("/start", middleware.RequireFeature(flagService, models.FlagAMMLiquidity), marketMakerHandler.Start) // 시작 (amm_liquidity 플래그 필요)
+		marketMaker.POST("/stop", marketMakerHandler.Stop)                                                                    // 중지 (미체결 주문 취소)
+		marketMaker.PUT("/config", marketMakerHandler.UpdateConfig)                                                           // 설정 변경
+	}
+
+	// 🚩 기능 플래그 런타임 토글 (관리자)
+	featureFlags := api.Group("/admin/feature-flags")
+	featureFlags.Use(middleware.AuthMiddleware(cfg), middleware.RequirePermission(roleService, models.PermissionManageFeatures))
+	{
+		featureFlags.GET("", featureFlagHandler.ListFlags)       // 현재 상태와 정의
+		featureFlags.PUT("/:key", featureFlagHandler.UpdateFlag) // 켜기/끄기, 롤아웃 비율, 허용 사용자
+	}
+
+	// 🚧 점검 모드/마켓 거래 중단 (관리자, 취소는 중단 중에도 허용)
+	tradingPauses := api.Group("/admin/trading-pauses")
+	tradingPauses.Use(middleware.AuthMiddleware(cfg), middleware.RequirePermission(roleService, models.PermissionManageTrading))
+	{
+		tradingPauses.GET("", tradingPauseHandler.ListPauses)       // 적용 중/예약된 중단
+		tradingPauses.POST("", tradingPauseHandler.CreatePause)     // 전체/마켓 중단, 예약 점검
+		tradingPauses.DELETE("/:id", tradingPauseHandler.LiftPause) // 해제 (예약 취소 포함)
+	}
+
+	// 🧾 주문/체결/지갑 대사 리포트 (관리자)
+	reconciliation := api.Group("/admin/reconciliation")
+	reconciliation.Use(middleware.AuthMiddleware(cfg), middleware.RequirePermission(roleService, models.PermissionManageTrading))
+	{
+		reconciliation.GET("/reports", reconciliationHandler.ListReports)    // 최근 리포트
+		reconciliation.GET("/reports/:id", reconciliationHandler.GetReport)  // 불일치 상세
+		reconciliation.POST("/run", reconciliationHandler.RunReconciliation) // 즉시 실행 (?fix=true 자동 보정)
+	}
+
+	// 🕵️ 시장 감시 알림 (운영자)
+	surveillance := api.Group("/admin/surveillance")
+	surveillance.Use(middleware.AuthMiddleware(cfg), middleware.RequirePermission(roleService, models.PermissionSurveillance))
+	{
+		surveillance.GET("/alerts", surveillanceHandler.ListAlerts)                  // 알림 목록 (?status=open&type=spoofing)
+		surveillance.GET("/alerts/:id", surveillanceHandler.GetAlert)                // 탐지 근거 상세
+		surveillance.POST("/alerts/:id/dismiss", surveillanceHandler.DismissAlert)   // 종결
+		surveillance.POST("/alerts/:id/escalate", surveillanceHandler.EscalateAlert) // 배심원 중재 이관
+		surveillance.POST("/scan", surveillanceHandler.RunScan)                      // 즉시 실행
+	}
+
+	// 🧹 메시지 신고 검토 (운영자)
+	moderation := api.Group("/admin/moderation")
+	moderation.Use(middleware.AuthMiddleware(cfg), middleware.RequirePermission(roleService, models.PermissionModerate))
+	{
+		moderation.GET("/message-reports", messagingHandler.ListReports)                // 신고 목록 (?status=open)
+		moderation.POST("/message-reports/:id/dismiss", messagingHandler.DismissReport) // 문제 없음으로 종결
+		moderation.POST("/message-reports/:id/remove", messagingHandler.RemoveMessage)  // 메시지 숨김
+	}
+
+	// 🔔 마켓 거래 마감 시각 (관리자, 기본은 마일스톤 목표일)
+	marketCalendar := api.Group("/admin/markets")
+	marketCalendar.Use(middleware.AuthMiddleware(cfg), middleware.RequirePermission(roleService, models.PermissionManageTrading))
+	{
+		marketCalendar.PUT("/:id/close-time", marketCalendarHandler.SetCloseTime)              // 마감 시각 지정/해제, 지나면 바로 마감
+		marketCalendar.PUT("/:id/quote-currency", walletAccountHandler.SetMarketQuoteCurrency) // 결제 통화 지정 (첫 주문 전까지)
+	}
+
+	// 💱 추가 결제 통화/BLUEPRINT 환율 (관리자, 1 통화 = rate USDC)
+	currencies := api.Group("/admin/currencies")
+	currencies.Use(middleware.AuthMiddleware(cfg), middleware.RequirePermission(roleService, models.PermissionManageTrading))
+	{
+		currencies.PUT("/:currency/rate", walletAccountHandler.SetRate)
+	}
+
+	// 💸 창작자 지급 승인/반려 (관리자)
+	payouts := api.Group("/admin/payouts")
+	payouts.Use(middleware.AuthMiddleware(cfg), middleware.RequirePermission(roleService, models.PermissionManagePayouts))
+	{
+		payouts.GET("", payoutHandler.ListPayouts)                // 지급 요청 목록 (?status=requested)
+		payouts.POST("/:id/approve", payoutHandler.ApprovePayout) // 승인 (scheduled_for 이후 송금)
+		payouts.POST("/:id/reject", payoutHandler.RejectPayout)   // 반려 (잠금 해제)
+	}
+
+	// ☠️ 데드레터 큐 조회/재처리/삭제 (관리자)
+	deadLetters := api.Group("/admin/dead-letters")
+	deadLetters.Use(middleware.AuthMiddleware(cfg), middleware.RequirePermission(roleService, models.PermissionManageQueues))
+	{
+		deadLetters.GET("", deadLetterHandler.ListQueues)               // 큐별 DLQ 크기
+		deadLetters.GET("/:queue", deadLetterHandler.ListDeadLetters)   // 항목 목록 (?limit=50&before=<id>)
+		deadLetters.GET("/:queue/:id", deadLetterHandler.GetDeadLetter) // payload/마지막 오류
+		deadLetters.POST("/:queue/replay", deadLetterHandler.Replay)    // 선택 항목 재발행
+		deadLetters.POST("/:queue/purge", deadLetterHandler.Purge)      // 선택 항목 또는 전체 삭제
+	}
+
+	// ⏱️ 주기 작업 일정/실행 이력 (관리자)
+	schedulerAdmin := api.Group("/admin/scheduler")
+	schedulerAdmin.Use(middleware.AuthMiddleware(cfg), middleware.RequirePermission(roleService, models.PermissionManageScheduler))
+	{
+		schedulerAdmin.GET("/jobs", schedulerHandler.ListJobs)              // 일정과 마지막 실행 결과
+		schedulerAdmin.GET("/jobs/:name/runs", schedulerHandler.ListRuns)   // 실행 이력
+		schedulerAdmin.PUT("/jobs/:name", schedulerHandler.UpdateJob)       // 주기/활성 여부 변경
+		schedulerAdmin.POST("/jobs/:name/run", schedulerHandler.TriggerJob) // 즉시 실행
+	}
+
+	// 📈 운영 지표 (Prometheus, DLQ 크기/경보)
+	router.GET("/metrics", deadLetterHandler.Metrics)
+
 	// 헬스 체크
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -333,9 +1050,56 @@ func main() {
 		})
 	})
 
+	// ⏱️ 등록된 주기 작업 실행 시작 (오래된 실행 이력도 주기 작업으로 정리)
+	scheduler.Register("scheduler_history_cleanup", 24*time.Hour, func(now time.Time) (int, error) {
+		return scheduler.CleanupRuns(now.Add(-services.SchedulerRunRetention))
+	})
+	go scheduler.Run(5 * time.Second)
+
 	// 서버 시작
-	log.Printf("Server starting on port %s", cfg.Server.Port)
-	if err := router.Run(":" + cfg.Server.Port); err != nil {
-		log.Fatal("Failed to start server:", err)
+	server := &http.Server{
+		Addr:    ":" + cfg.Server.Port,
+		Handler: router,
+	}
+	go func() {
+		log.Printf("Server starting on port %s", cfg.Server.Port)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal("Failed to start server:", err)
+		}
+	}()
+
+	// 🔌 워커/스케줄러용 내부 gRPC API (마켓 상태, 지갑 잔액, 정산 예약 + 헬스 체크, INTERNAL_GRPC_PORT 설정 시에만)
+	var internalServer *rpc.Server
+	if cfg.Internal.GRPCPort != "" {
+		listener, err := net.Listen("tcp", cfg.Internal.ListenAddress())
+		if err != nil {
+			log.Fatalf("Failed to listen for internal gRPC API: %v", err)
+		}
+		log.Printf("🔌 Internal gRPC API listening on %s", listener.Addr())
+		internalServer = rpc.NewServer(database.GetDB(), matchingEngine, cfg.Internal.Token)
+		go func() {
+			if err := internalServer.Serve(listener); err != nil {
+				log.Printf("❌ Internal gRPC API stopped: %v", err)
+			}
+		}()
+	}
+
+	// 🛑 종료 신호 시 요청 처리를 마무리하고 매칭 엔진의 미반영 주문 상태를 기록
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Println("Shutting down server...")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("⚠️ Server shutdown error: %v", err)
+	}
+	if internalServer != nil {
+		internalServer.Stop()
+	}
+	liquidationEngine.Stop()
+	if err := matchingEngine.Stop(); err != nil {
+		log.Printf("⚠️ Matching engine stop error: %v", err)
 	}
 }
@@ -0,0 +1,230 @@
+// simulator 매칭 엔진 시뮬레이션/백테스트 도구 (성능 회귀 측정용)
+//
+// 합성 주문 흐름이나 마켓의 과거 주문 흐름을 시뮬레이션 전용 in-memory DB와 프로세스 내 Redis 위의
+// 매칭 엔진에 재생해 주문 접수 지연 시간, 체결률, 불변식 위반(음수 잔액, 사라진 수량 등)을 JSON 또는
+// CSV로 출력한다. 운영 DB는 과거 주문을 읽을 때만 쓰고, 체결 후처리/이벤트는 운영 Redis에 나가지 않는다.
+//
+//	go run ./cmd/simulator                                  # 합성 흐름 10,000건, JSON 보고서
+//	go run ./cmd/simulator -orders 50000 -seed 7 -cancel-rate 0.3
+//	go run ./cmd/simulator -market 12:success -since 720h   # 최근 30일 과거 주문 재생 (운영 DB 읽기 전용)
+//	go run ./cmd/simulator -format csv -out bench.csv -append # 실행마다 한 줄씩 누적
+//	go run ./cmd/simulator -fail-on-violation               # 불변식 위반 시 종료 코드 1 (CI용)
+//
+// 지연 시간은 서버 부하와 무관하게 같은 머신에서 실행한 결과끼리만 비교한다.
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"blueprint/internal/config"
+	"blueprint/internal/database"
+	"blueprint/internal/services"
+
+	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+func main() {
+	defaults := services.DefaultSyntheticFlowConfig
+	orders := flag.Int("orders", defaults.Orders, "합성 흐름 주문 수")
+	users := flag.Int("users", defaults.Users, "합성 흐름 사용자 수")
+	seed := flag.Int64("seed", defaults.Seed, "합성 흐름 난수 시드")
+	mid := flag.Float64("mid", defaults.MidPrice, "합성 흐름 시작 중간 가격 (0-1)")
+	spread := flag.Float64("spread", defaults.Spread, "중간 가격에서 주문 가격이 벗어날 수 있는 최대 폭")
+	volatility := flag.Float64("volatility", defaults.Volatility, "주문마다 중간 가격이 움직이는 최대 폭")
+	maxQuantity := flag.Int64("max-quantity", defaults.MaxQuantity, "주문 최대 수량")
+	cancelRate := flag.Float64("cancel-rate", defaults.CancelRate, "주문 뒤 열린 주문 하나를 취소할 확률")
+	market := flag.String("market", "", "과거 주문을 재생할 마켓 (milestoneID:optionID, 비우면 합성 흐름)")
+	since := flag.Duration("since", 7*24*time.Hour, "과거 주문 재생 기간")
+	balance := flag.Int64("balance", 1_000_000, "사용자별 시작 USDC 잔액 (센트)")
+	engineMode := flag.String("engine", services.MatchingModeLocal, "매칭 엔진 모드 (local 또는 distributed)")
+	circuitBreaker := flag.Bool("circuit-breaker", false, "서킷브레이커 적용 (기본은 끄고 매칭만 측정)")
+	format := flag.String("format", "json", "출력 형식 (json 또는 csv)")
+	outPath := flag.String("out", "", "결과 파일 (비우면 표준 출력)")
+	appendOut := flag.Bool("append", false, "CSV 결과를 파일 끝에 추가 (헤더는 새 파일일 때만)")
+	failOnViolation := flag.Bool("fail-on-violation", false, "불변식 위반이 있으면 종료 코드 1")
+	verbose := flag.Bool("verbose", false, "엔진 로그 출력")
+	flag.Parse()
+
+	if *format != "json" && *format != "csv" {
+		log.Fatalf("Unknown format: %s (json or csv)", *format)
+	}
+
+	// 1. 주문 흐름과 마켓 (과거 흐름은 운영 DB에서 읽기만 함)
+	var flow *services.SimulationFlow
+	milestone := models.Milestone{Title: "simulation"}
+	if *market != "" {
+		milestoneID, optionID, err := parseMarket(*market)
+		if err != nil {
+			log.Fatal(err)
+		}
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			log.Fatalf("Invalid configuration: %v", err)
+		}
+		if err := database.Connect(cfg); err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		if err := database.GetDB().First(&milestone, milestoneID).Error; err != nil {
+			log.Fatalf("Failed to load milestone %d: %v", milestoneID, err)
+		}
+		flow, err = services.HistoricalOrderFlow(database.GetDB(), milestoneID, optionID, time.Now().Add(-*since))
+		if err != nil {
+			log.Fatalf("Failed to load historical orders: %v", err)
+		}
+	} else {
+		flow = services.SyntheticOrderFlow(1, models.OptionSuccess, services.SyntheticFlowConfig{
+			Orders:      *orders,
+			Users:       *users,
+			Seed:        *seed,
+			MidPrice:    *mid,
+			Spread:      *spread,
+			Volatility:  *volatility,
+			MinQuantity: defaults.MinQuantity,
+			MaxQuantity: *maxQuantity,
+			CancelRate:  *cancelRate,
+		})
+	}
+	milestone.ID = flow.MilestoneID
+	log.Printf("🧪 Simulating %d actions (%s, market %d:%s)", len(flow.Actions), flow.Source, flow.MilestoneID, flow.OptionID)
+
+	// 2. 시뮬레이션 전용 엔진 (체결 후처리와 분산 엔진 주문장은 in-memory DB/Redis에만 기록)
+	redisServer, err := miniredis.Run()
+	if err != nil {
+		log.Fatalf("Failed to start simulation Redis: %v", err)
+	}
+	defer redisServer.Close()
+	moduleRedis.Client = redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+	defer moduleRedis.CloseRedis()
+
+	scratch, err := scratchDatabase(milestone)
+	if err != nil {
+		log.Fatalf("Failed to prepare simulation database: %v", err)
+	}
+	engine, err := services.NewMatchingEngine(*engineMode, scratch, nil, nil, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 엔진 로그는 체결마다 찍히고 후처리 고루틴이 실행 뒤에도 이어서 남기므로 도구 출력과 분리
+	console := log.New(os.Stderr, "", log.LstdFlags)
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+	report, err := services.NewMatchingSimulator(engine, services.SimulationConfig{
+		InitialBalance: *balance,
+		CircuitBreaker: *circuitBreaker,
+	}).Run(flow)
+	if err != nil {
+		console.Fatalf("Simulation failed: %v", err)
+	}
+
+	// 3. 결과 출력
+	if err := writeReport(report, *format, *outPath, *appendOut); err != nil {
+		console.Fatalf("Failed to write report: %v", err)
+	}
+	console.Printf("✅ %d orders, %d trades, fill rate %.1f%%, p99 %.3fms, %d violations",
+		report.OrdersSubmitted, report.Trades, report.FillRate*100, report.SubmitLatency.P99, report.TotalViolations)
+
+	if *failOnViolation && report.TotalViolations > 0 {
+		os.Exit(1)
+	}
+}
+
+// parseMarket milestoneID:optionID
+func parseMarket(value string) (uint, string, error) {
+	milestonePart, optionID, found := strings.Cut(value, ":")
+	milestoneID, err := strconv.ParseUint(milestonePart, 10, 64)
+	if !found || err != nil || optionID == "" {
+		return 0, "", fmt.Errorf("invalid market %q (expected milestoneID:optionID)", value)
+	}
+	return uint(milestoneID), optionID, nil
+}
+
+// scratchDatabase 체결 후처리가 기록할 in-memory DB (연결 하나로 고정해 모든 고루틴이 같은 DB를 봄)
+//
+// 분산 엔진은 거래 중인 마일스톤의 마켓만 담당하므로 시뮬레이션 마켓을 거래 중 상태로 넣어 둔다.
+func scratchDatabase(milestone models.Milestone) (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(
+		&models.Project{}, &models.Milestone{}, &models.Order{}, &models.Trade{},
+		&models.UserWallet{}, &models.Position{}, &models.MarketData{},
+	); err != nil {
+		return nil, err
+	}
+
+	project := models.Project{Title: "simulation", Status: models.ProjectActive}
+	if err := db.Create(&project).Error; err != nil {
+		return nil, err
+	}
+	milestone.ProjectID = project.ID
+	milestone.Status = models.MilestoneStatusActive
+	milestone.TradingHaltedUntil = nil
+	if err := db.Omit(clause.Associations).Create(&milestone).Error; err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// writeReport JSON 또는 CSV로 결과 기록
+func writeReport(report *services.SimulationReport, format, path string, appendOut bool) error {
+	out := os.Stdout
+	writeHeader := true
+	if path != "" {
+		flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+		if appendOut {
+			flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+			if info, err := os.Stat(path); err == nil && info.Size() > 0 {
+				writeHeader = false
+			}
+		}
+		file, err := os.OpenFile(path, flags, 0o644)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if format == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	writer := csv.NewWriter(out)
+	if writeHeader {
+		if err := writer.Write(services.SimulationCSVHeader()); err != nil {
+			return err
+		}
+	}
+	if err := writer.Write(report.CSVRecord()); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -1,148 +1,521 @@
 package config
 
 import (
-	"log"
+	"net"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
-	"github.com/joho/godotenv"
+	moduleConfig "blueprint-module/pkg/config"
 )
 
+// Config API 서버 설정
+//
+// DB/JWT/OAuth/서버/AI/Redis는 blueprint-module 설정을 그대로 쓰고 (워커와 공유),
+// 여기서는 API 서버 전용 설정만 더한다.
 type Config struct {
-	Database DatabaseConfig
-	JWT      JWTConfig
-	Google   GoogleConfig
-	LinkedIn LinkedInConfig
-	Twitter  TwitterConfig
-	GitHub   GitHubConfig
-	Server   ServerConfig
-	AI       AIConfig
-	Redis    RedisConfig
+	moduleConfig.Config
+
+	Push           PushConfig
+	Storage        StorageConfig
+	APIKey         APIKeyConfig
+	Admin          AdminConfig
+	KYC            KYCConfig
+	Staking        StakingConfig
+	Risk           RiskConfig
+	Responsible    ResponsibleTradingConfig
+	Transfer       PositionTransferConfig
+	CircuitBreaker CircuitBreakerConfig
+	MarketMaker    MarketMakerConfig
+	Parlay         ParlayConfig
+	Matching       MatchingConfig
+	Maintenance    MaintenanceConfig
+	Reconciliation ReconciliationConfig
+	Wallet         WalletConfig
+	DeadLetter     DeadLetterConfig
+	Security       SecurityConfig
+	TrustScore     TrustScoreConfig
+	MagicLink      MagicLinkConfig
+	Digest         DigestConfig
+	Messaging      MessagingConfig
+	Internal       InternalAPIConfig
+}
+
+// SecurityConfig CORS / 프록시 / 보안 헤더 설정
+type SecurityConfig struct {
+	AllowedOrigins        []string // CORS 허용 출처 ("https://*.example.com" 형태의 하위 도메인 와일드카드 허용, 비우면 FRONTEND_URL)
+	TrustedProxies        []string // X-Forwarded-For를 믿을 프록시 IP/CIDR (비우면 신뢰하지 않고 접속 IP 사용)
+	TrustedPlatform       string   // 클라이언트 IP 헤더를 넣어주는 플랫폼 (cloudflare, google, flyio 또는 헤더 이름)
+	HSTSMaxAgeSeconds     int      // Strict-Transport-Security max-age (0이면 보내지 않음)
+	ContentSecurityPolicy string
+}
+
+// PushConfig Web Push (VAPID) 설정 - 개인키는 워커에서만 사용
+type PushConfig struct {
+	VAPIDPublicKey string
+}
+
+// StorageConfig 업로드 파일 저장 및 서명 URL 설정
+type StorageConfig struct {
+	UploadPath    string // 로컬 저장 경로
+	PublicURL     string // 파일 다운로드 기준 URL (API 서버)
+	SigningSecret string // 민감 문서 다운로드 URL 서명 키
+}
+
+// APIKeyConfig 프로그래매틱 거래용 API 키 설정
+type APIKeyConfig struct {
+	EncryptionSecret string // API secret 암호화 키
+}
+
+// AdminConfig 관리자 설정
+type AdminConfig struct {
+	BootstrapEmails []string // 서버 시작 시 admin 역할을 부여할 이메일 (최초 관리자 지정용)
+}
+
+// KYCConfig 본인 인증 제공업체 설정
+type KYCConfig struct {
+	Provider string // manual, external
+	APIURL   string // 외부 제공업체 API 주소
+	APIKey   string
+}
+
+// StakingConfig BLUEPRINT 스테이킹 발행 스케줄
+type StakingConfig struct {
+	Genesis           string // 에포크 0 시작 시각 (RFC3339)
+	EpochHours        int    // 에포크 길이 (시간)
+	EpochEmission     int64  // 초기 에포크당 발행량 (BLUEPRINT)
+	DecayPercent      int    // 감소 주기마다 발행량 감소율 (%)
+	DecayEveryEpochs  int    // 감소 주기 (에포크 수)
+	MinEpochEmission  int64  // 에포크당 최소 발행량 (꼬리 발행)
+	JurorSharePercent int    // 발행량 중 배심원 스테이킹 몫 (%), 나머지는 멘토 스테이킹
+}
+
+// RiskConfig 주문 전 리스크 한도 (사용자 공통, 0이면 제한 없음)
+type RiskConfig struct {
+	MaxOpenOrderNotional int64 // 미체결 주문 금액 합계 (센트)
+	MaxMarketPosition    int64 // 시장별 최대 포지션 수량
+	MaxDailyLoss         int64 // 당일 최대 손실 (센트)
+}
+
+// ResponsibleTradingConfig 책임 있는 거래 일일 한도 기본값 (사용자가 직접 정하지 않았을 때, 0이면 제한 없음)
+type ResponsibleTradingConfig struct {
+	DefaultDailyDeposit       int64 // 하루 후원 예치 한도 (센트)
+	DefaultDailyOrderNotional int64 // 하루 주문 금액 한도 (센트)
+	LimitIncreaseDelayHours   int   // 한도를 올리거나 해제할 때 적용까지 유예 시간
+}
+
+// PositionTransferConfig 사용자 간 포지션 이전
+type PositionTransferConfig struct {
+	FeeCents int64 // 이전 1건당 보내는 사람이 내는 수수료 (센트, 요청 시 잠그고 수락 시 차감)
+}
+
+// WalletConfig 지갑 결제 통화와 환전
+type WalletConfig struct {
+	Currencies        []string // usdc 외에 추가할 결제 통화 코드 (소문자 영숫자 2~10자, 예: krws,usdt)
+	DailyConvertLimit int64    // 사용자별 UTC 하루 환전 한도 (보내는 금액의 USDC 환산 센트 합계)
+}
+
+// CurrencyCodes 소문자로 맞춘 추가 결제 통화 코드
+func (c WalletConfig) CurrencyCodes() []string {
+	codes := make([]string, 0, len(c.Currencies))
+	for _, code := range c.Currencies {
+		codes = append(codes, strings.ToLower(code))
+	}
+	return codes
+}
+
+// validCurrencyCode 소문자 영숫자 2~10자이고 usdc/blueprint가 아닌 통화 코드
+func validCurrencyCode(code string) bool {
+	if len(code) < 2 || len(code) > 10 || code == "usdc" || code == "blueprint" {
+		return false
+	}
+	for _, r := range code {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// CircuitBreakerConfig 마켓 급변동 서킷브레이커
+type CircuitBreakerConfig struct {
+	MaxMovePercent  int // 측정 구간 안 최대 가격 변동률 (%), 0이면 가격 변동 발동 끔
+	WindowMinutes   int // 변동률 측정 구간 (분)
+	CooldownMinutes int // 발동 후 자동 재개까지 (분)
+}
+
+// MarketMakerConfig 마켓메이커 봇 기동 설정 (나머지 호가 설정은 관리자 API로 변경)
+type MarketMakerConfig struct {
+	AutoStart bool  // 서버 시작 시 봇 자동 실행
+	UserID    uint  // 봇 계정
+	MaxLoss   int64 // 실행 이후 최대 손실 (센트, 도달 시 킬 스위치)
 }
 
-type DatabaseConfig struct {
-	Host     string
-	Port     string
-	User     string
-	Password string
-	Name     string
-	SSLMode  string
+// ParlayConfig 조합 베팅 설정
+type ParlayConfig struct {
+	HouseUserID uint // 미적중 원금을 받고 적중 지급액을 내는 하우스 계정
 }
 
-type JWTConfig struct {
-	Secret string
+// MatchingConfig 매칭 엔진 실행 방식
+type MatchingConfig struct {
+	Mode string // local(단일 서버), distributed(여러 서버가 마켓을 나눠 담당)
+
+	// 분산 모드 이벤트 로그 스냅샷/압축
+	SnapshotEveryEvents       int // 마켓별 이벤트 N개마다 주문장 스냅샷 (0이면 끔)
+	EventRetentionHours       int // 스냅샷에 포함된 이벤트도 이 시간 동안은 스트림에 남김
+	CompactionIntervalMinutes int // 이벤트 스트림 압축 주기
+}
+
+// MaintenanceConfig 설정으로 거는 거래 중단 (관리자 API의 중단과 별개로 재시작 전까지 유지)
+type MaintenanceConfig struct {
+	TradingPaused    bool     // 전체 점검 모드 (신규 주문 거부, 취소는 허용)
+	PausedMilestones []string // 거래를 중단할 마일스톤 ID
+	Reason           string   // 클라이언트에 보여줄 사유
+}
+
+// MilestoneIDs 중단할 마일스톤 ID (Validate를 통과한 설정 기준)
+func (m MaintenanceConfig) MilestoneIDs() []uint {
+	ids := make([]uint, 0, len(m.PausedMilestones))
+	for _, value := range m.PausedMilestones {
+		if id, err := strconv.ParseUint(value, 10, 32); err == nil && id > 0 {
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids
 }
 
-type GoogleConfig struct {
-	ClientID     string
-	ClientSecret string
-	RedirectURL  string
+// ReconciliationConfig 주문/체결/지갑 주기 대사
+type ReconciliationConfig struct {
+	IntervalMinutes int  // 대사 주기 (분)
+	AutoCorrect     bool // 원인이 분명한 불일치 자동 보정 (끄면 리포트만)
 }
 
-type ServerConfig struct {
-	Port        string
-	Mode        string
-	FrontendURL string
+// DeadLetterConfig 데드레터 큐 모니터링
+type DeadLetterConfig struct {
+	AlertThreshold int // /metrics 에 경보(blueprint_dlq_alert=1)로 표시할 큐별 DLQ 크기
 }
 
-// OpenAIConfig OpenAI 설정
-type OpenAIConfig struct {
-	APIKey string
-	Model  string
+// TrustScoreConfig 사용자 신뢰 점수 가중치와 역할별 최소 점수
+type TrustScoreConfig struct {
+	EmailWeight        int // 이메일 인증
+	PhoneWeight        int // 휴대폰 인증
+	LinkedInWeight     int // LinkedIn 연동
+	GitHubWeight       int // GitHub 연동
+	TwitterWeight      int // Twitter 연동
+	WorkEmailWeight    int // 회사 이메일 인증
+	ProfessionalWeight int // 전문 자격 서류 승인
+	EducationWeight    int // 학력 서류 승인
+	KYCWeight          int // 본인 인증(KYC) 승인
+
+	MinValidator int // 증거 검증 참여 최소 점수 (0이면 제한 없음)
+	MinJuror     int // 배심원 등록/선정 최소 점수
+	MinMentor    int // 멘토 자격 부여 최소 점수
+
+	RecalculateIntervalMinutes int // 변경된 사용자 점수 재계산 주기
 }
 
-// AIConfig AI 전반적인 설정
-type AIConfig struct {
-	Provider string // openai, mock, claude, gemini
-	OpenAI   OpenAIConfig
+// MagicLinkConfig 매직링크 로그인 토큰 정책
+type MagicLinkConfig struct {
+	SigningSecret         string // 링크 토큰 서명 키
+	TTLMinutes            int    // 링크/코드 유효 시간 (분)
+	ResendIntervalSeconds int    // 같은 이메일로 재발송 최소 간격 (초)
+	HourlyLimit           int    // 이메일당 시간당 최대 발송 횟수
+	BindDevice            bool   // 요청한 기기(User-Agent + device_id)에서만 사용 가능
 }
 
-// RedisConfig Redis 설정
-type RedisConfig struct {
-	Host     string
-	Port     string
-	Password string
-	DB       int
+// DigestConfig 알림 요약 메일 설정
+type DigestConfig struct {
+	SigningSecret string // 수신 거부 토큰 서명 키
 }
 
-type LinkedInConfig struct {
-	ClientID     string
-	ClientSecret string
-	RedirectURL  string
+// MessagingConfig 멘토링/분쟁 대화 설정
+type MessagingConfig struct {
+	RetentionDays int // 메시지 보관 기간 (지나면 삭제, 검토 중인 신고가 있는 메시지는 보존)
 }
 
-type TwitterConfig struct {
-	ClientID     string
-	ClientSecret string
-	RedirectURL  string
+// InternalAPIConfig 워커/스케줄러용 내부 gRPC API
+type InternalAPIConfig struct {
+	GRPCPort string // 내부 gRPC 포트 (비우면 띄우지 않음, 기본값)
+	GRPCHost string // 바인딩 주소 (기본 127.0.0.1, 워커가 다른 호스트면 사설망 주소로, 외부에 노출하지 말 것)
+	Token    string // 호출 측과 공유하는 토큰 (워커의 INTERNAL_API_TOKEN과 같아야 하고 JWT_SECRET과 달라야 함)
 }
 
-type GitHubConfig struct {
-	ClientID     string
-	ClientSecret string
-	RedirectURL  string
+// ListenAddress gRPC 리스너 주소
+func (c InternalAPIConfig) ListenAddress() string {
+	return net.JoinHostPort(c.GRPCHost, c.GRPCPort)
 }
 
-// LoadConfig .env 파일을 로드하고 설정을 반환합니다 🔧
-func LoadConfig() *Config {
-	// .env 파일 로드 (파일이 없어도 오류 없이 진행)
-	if err := godotenv.Load(); err != nil {
-		log.Println("📁 .env 파일을 찾을 수 없습니다. 시스템 환경변수를 사용합니다.")
-	} else {
-		log.Println("✅ .env 파일을 성공적으로 로드했습니다.")
+// LoadConfig .env 파일과 환경변수(비밀 값은 파일/Vault 포함)를 읽고 검증한 설정을 반환합니다 🔧
+// 필수 값이 비었거나 형식이 틀리면 문제 목록 전체를 담은 오류를 돌려준다
+func LoadConfig() (*Config, error) {
+	base, err := moduleConfig.LoadConfig()
+	if err != nil {
+		return nil, err
 	}
 
-	return &Config{
-		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "5432"),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "password"),
-			Name:     getEnv("DB_NAME", "blueprint"),
-			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+	secrets := moduleConfig.NewSecretLoader()
+	cfg := &Config{
+		Config: *base,
+		Push: PushConfig{
+			VAPIDPublicKey: getEnv("VAPID_PUBLIC_KEY", ""),
+		},
+		Storage: StorageConfig{
+			UploadPath:    getEnv("UPLOAD_PATH", "./uploads"),
+			PublicURL:     getEnv("API_PUBLIC_URL", "http://localhost:8080") + "/api/v1/files",
+			SigningSecret: secrets.Get("FILE_SIGNING_SECRET", base.JWT.Secret),
+		},
+		APIKey: APIKeyConfig{
+			EncryptionSecret: secrets.Get("API_KEY_ENCRYPTION_SECRET", base.JWT.Secret),
+		},
+		Admin: AdminConfig{
+			BootstrapEmails: getEnvAsList("ADMIN_EMAILS"),
+		},
+		KYC: KYCConfig{
+			Provider: getEnv("KYC_PROVIDER", "manual"),
+			APIURL:   getEnv("KYC_API_URL", ""),
+			APIKey:   secrets.Get("KYC_API_KEY", ""),
+		},
+		Staking: StakingConfig{
+			Genesis:           getEnv("STAKING_GENESIS", "2026-01-01T00:00:00Z"),
+			EpochHours:        getEnvAsInt("STAKING_EPOCH_HOURS", 24),
+			EpochEmission:     int64(getEnvAsInt("STAKING_EPOCH_EMISSION", 100000)),
+			DecayPercent:      getEnvAsInt("STAKING_EMISSION_DECAY_PERCENT", 10),
+			DecayEveryEpochs:  getEnvAsInt("STAKING_EMISSION_DECAY_EPOCHS", 30),
+			MinEpochEmission:  int64(getEnvAsInt("STAKING_MIN_EPOCH_EMISSION", 10000)),
+			JurorSharePercent: getEnvAsInt("STAKING_JUROR_SHARE_PERCENT", 30),
+		},
+		Risk: RiskConfig{
+			MaxOpenOrderNotional: int64(getEnvAsInt("RISK_MAX_OPEN_ORDER_NOTIONAL", 1000000)), // $10,000
+			MaxMarketPosition:    int64(getEnvAsInt("RISK_MAX_MARKET_POSITION", 50000)),
+			MaxDailyLoss:         int64(getEnvAsInt("RISK_MAX_DAILY_LOSS", 500000)), // $5,000
+		},
+		Responsible: ResponsibleTradingConfig{
+			DefaultDailyDeposit:       int64(getEnvAsInt("RESPONSIBLE_DEFAULT_DAILY_DEPOSIT", 0)),
+			DefaultDailyOrderNotional: int64(getEnvAsInt("RESPONSIBLE_DEFAULT_DAILY_ORDER_NOTIONAL", 0)),
+			LimitIncreaseDelayHours:   getEnvAsInt("RESPONSIBLE_LIMIT_INCREASE_DELAY_HOURS", 24),
+		},
+		Transfer: PositionTransferConfig{
+			FeeCents: int64(getEnvAsInt("POSITION_TRANSFER_FEE", 100)), // $1
+		},
+		CircuitBreaker: CircuitBreakerConfig{
+			MaxMovePercent:  getEnvAsInt("CIRCUIT_BREAKER_MAX_MOVE_PERCENT", 20),
+			WindowMinutes:   getEnvAsInt("CIRCUIT_BREAKER_WINDOW_MINUTES", 5),
+			CooldownMinutes: getEnvAsInt("CIRCUIT_BREAKER_COOLDOWN_MINUTES", 5),
+		},
+		MarketMaker: MarketMakerConfig{
+			AutoStart: getEnv("MARKET_MAKER_AUTOSTART", "true") == "true",
+			UserID:    uint(getEnvAsInt("MARKET_MAKER_USER_ID", 1)),
+			MaxLoss:   int64(getEnvAsInt("MARKET_MAKER_MAX_LOSS", 100000)), // $1,000
+		},
+		Parlay: ParlayConfig{
+			HouseUserID: uint(getEnvAsInt("PARLAY_HOUSE_USER_ID", 1)),
+		},
+		Security: SecurityConfig{
+			AllowedOrigins:        getEnvAsList("CORS_ALLOWED_ORIGINS"),
+			TrustedProxies:        getEnvAsList("TRUSTED_PROXIES"),
+			TrustedPlatform:       getEnv("TRUSTED_PLATFORM", ""),
+			HSTSMaxAgeSeconds:     getEnvAsInt("HSTS_MAX_AGE_SECONDS", 0),
+			ContentSecurityPolicy: getEnv("CONTENT_SECURITY_POLICY", "default-src 'none'; frame-ancestors 'none'"),
 		},
-		JWT: JWTConfig{
-			Secret: getEnv("JWT_SECRET", "your-super-secret-jwt-key-change-this-in-production"),
+		Matching: MatchingConfig{
+			Mode:                      getEnv("MATCHING_ENGINE_MODE", "local"),
+			SnapshotEveryEvents:       getEnvAsInt("EVENT_SNAPSHOT_EVERY", 1000),
+			EventRetentionHours:       getEnvAsInt("EVENT_STREAM_RETENTION_HOURS", 168),
+			CompactionIntervalMinutes: getEnvAsInt("EVENT_STREAM_COMPACTION_MINUTES", 60),
 		},
-		Google: GoogleConfig{
-			ClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
-			ClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
-			RedirectURL:  getEnv("GOOGLE_REDIRECT_URL", "http://localhost:8080/api/v1/auth/google/callback"),
+		Maintenance: MaintenanceConfig{
+			TradingPaused:    getEnv("TRADING_PAUSED", "false") == "true",
+			PausedMilestones: getEnvAsList("TRADING_PAUSED_MILESTONES"),
+			Reason:           getEnv("TRADING_PAUSE_REASON", "시스템 점검"),
 		},
-		LinkedIn: LinkedInConfig{
-			ClientID:     getEnv("LINKEDIN_CLIENT_ID", ""),
-			ClientSecret: getEnv("LINKEDIN_CLIENT_SECRET", ""),
-			RedirectURL:  getEnv("LINKEDIN_REDIRECT_URL", "http://localhost:8080/api/v1/auth/linkedin/callback"),
+		Reconciliation: ReconciliationConfig{
+			IntervalMinutes: getEnvAsInt("RECONCILIATION_INTERVAL_MINUTES", 60),
+			AutoCorrect:     getEnv("RECONCILIATION_AUTO_CORRECT", "false") == "true",
 		},
-		Twitter: TwitterConfig{
-			ClientID:     getEnv("TWITTER_CLIENT_ID", ""),
-			ClientSecret: getEnv("TWITTER_CLIENT_SECRET", ""),
-			RedirectURL:  getEnv("TWITTER_REDIRECT_URL", "http://localhost:8080/api/v1/auth/twitter/callback"),
+		DeadLetter: DeadLetterConfig{
+			AlertThreshold: getEnvAsInt("DLQ_ALERT_THRESHOLD", 100),
 		},
-		GitHub: GitHubConfig{
-			ClientID:     getEnv("GITHUB_CLIENT_ID", ""),
-			ClientSecret: getEnv("GITHUB_CLIENT_SECRET", ""),
-			RedirectURL:  getEnv("GITHUB_REDIRECT_URL", "http://localhost:8080/api/v1/auth/github/callback"),
+		Wallet: WalletConfig{
+			Currencies:        getEnvAsList("WALLET_CURRENCIES"),
+			DailyConvertLimit: int64(getEnvAsInt("WALLET_DAILY_CONVERT_LIMIT", 100000)), // $1,000
 		},
-		Server: ServerConfig{
-			Port:        getEnv("PORT", "8080"),
-			Mode:        getEnv("GIN_MODE", "debug"),
-			FrontendURL: getEnv("FRONTEND_URL", "http://localhost:3000"),
+		TrustScore: TrustScoreConfig{
+			EmailWeight:                getEnvAsInt("TRUST_WEIGHT_EMAIL", 10),
+			PhoneWeight:                getEnvAsInt("TRUST_WEIGHT_PHONE", 10),
+			LinkedInWeight:             getEnvAsInt("TRUST_WEIGHT_LINKEDIN", 15),
+			GitHubWeight:               getEnvAsInt("TRUST_WEIGHT_GITHUB", 10),
+			TwitterWeight:              getEnvAsInt("TRUST_WEIGHT_TWITTER", 5),
+			WorkEmailWeight:            getEnvAsInt("TRUST_WEIGHT_WORK_EMAIL", 15),
+			ProfessionalWeight:         getEnvAsInt("TRUST_WEIGHT_PROFESSIONAL", 15),
+			EducationWeight:            getEnvAsInt("TRUST_WEIGHT_EDUCATION", 10),
+			KYCWeight:                  getEnvAsInt("TRUST_WEIGHT_KYC", 10),
+			MinValidator:               getEnvAsInt("TRUST_MIN_VALIDATOR", 30),
+			MinJuror:                   getEnvAsInt("TRUST_MIN_JUROR", 40),
+			MinMentor:                  getEnvAsInt("TRUST_MIN_MENTOR", 20),
+			RecalculateIntervalMinutes: getEnvAsInt("TRUST_RECALCULATE_MINUTES", 5),
 		},
-		AI: AIConfig{
-			Provider: getEnv("AI_PROVIDER", "mock"),
-			OpenAI: OpenAIConfig{
-				APIKey: getEnv("OPENAI_API_KEY", ""),
-				Model:  getEnv("OPENAI_MODEL", "gpt-4o-mini"),
-			},
+		MagicLink: MagicLinkConfig{
+			SigningSecret:         secrets.Get("MAGIC_LINK_SIGNING_SECRET", base.JWT.Secret),
+			TTLMinutes:            getEnvAsInt("MAGIC_LINK_TTL_MINUTES", 15),
+			ResendIntervalSeconds: getEnvAsInt("MAGIC_LINK_RESEND_SECONDS", 60),
+			HourlyLimit:           getEnvAsInt("MAGIC_LINK_HOURLY_LIMIT", 5),
+			BindDevice:            getEnv("MAGIC_LINK_BIND_DEVICE", "false") == "true",
 		},
-		Redis: RedisConfig{
-			Host:     getEnv("REDIS_HOST", "localhost"),
-			Port:     getEnv("REDIS_PORT", "6379"),
-			Password: getEnv("REDIS_PASSWORD", ""),
-			DB:       getEnvAsInt("REDIS_DB", 0),
+		Digest: DigestConfig{
+			SigningSecret: secrets.Get("DIGEST_SIGNING_SECRET", base.JWT.Secret),
 		},
+		Messaging: MessagingConfig{
+			RetentionDays: getEnvAsInt("MESSAGE_RETENTION_DAYS", 365),
+		},
+		Internal: InternalAPIConfig{
+			GRPCPort: getEnv("INTERNAL_GRPC_PORT", ""),
+			GRPCHost: getEnv("INTERNAL_GRPC_HOST", "127.0.0.1"),
+			Token:    secrets.Get("INTERNAL_API_TOKEN", ""),
+		},
+	}
+
+	if err := secrets.Err(); err != nil {
+		return nil, err
 	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate 공통 설정과 API 서버 전용 설정을 함께 검사 (문제를 모두 모아 하나의 오류로 반환)
+func (c *Config) Validate() error {
+	problems := c.Config.Problems()
+	release := c.IsRelease()
+
+	problems.Secret("FILE_SIGNING_SECRET", c.Storage.SigningSecret, release)
+	problems.Secret("API_KEY_ENCRYPTION_SECRET", c.APIKey.EncryptionSecret, release)
+	problems.Secret("MAGIC_LINK_SIGNING_SECRET", c.MagicLink.SigningSecret, release)
+	problems.Secret("DIGEST_SIGNING_SECRET", c.Digest.SigningSecret, release)
+	problems.Require("UPLOAD_PATH", c.Storage.UploadPath)
+	if c.Internal.GRPCPort != "" {
+		problems.Secret("INTERNAL_API_TOKEN", c.Internal.Token, release)
+		if c.Internal.Token != "" && c.Internal.Token == c.JWT.Secret {
+			problems.Add("INTERNAL_API_TOKEN", "JWT_SECRET과 다른 값이어야 합니다")
+		}
+		if port, err := strconv.Atoi(c.Internal.GRPCPort); err != nil || port <= 0 || port > 65535 {
+			problems.Add("INTERNAL_GRPC_PORT", "1~65535 포트여야 합니다 (%q)", c.Internal.GRPCPort)
+		}
+	}
+
+	switch c.KYC.Provider {
+	case "manual":
+	case "external":
+		problems.Require("KYC_API_URL", c.KYC.APIURL)
+		problems.Require("KYC_API_KEY", c.KYC.APIKey)
+	default:
+		problems.Add("KYC_PROVIDER", "manual, external 중 하나여야 합니다 (%q)", c.KYC.Provider)
+	}
+
+	if _, err := time.Parse(time.RFC3339, c.Staking.Genesis); err != nil {
+		problems.Add("STAKING_GENESIS", "RFC3339 시각이어야 합니다 (%q)", c.Staking.Genesis)
+	}
+	if c.Staking.EpochHours <= 0 {
+		problems.Add("STAKING_EPOCH_HOURS", "0보다 커야 합니다")
+	}
+	if c.Staking.DecayEveryEpochs <= 0 {
+		problems.Add("STAKING_EMISSION_DECAY_EPOCHS", "0보다 커야 합니다")
+	}
+	if c.Staking.DecayPercent < 0 || c.Staking.DecayPercent > 100 {
+		problems.Add("STAKING_EMISSION_DECAY_PERCENT", "0~100 사이여야 합니다 (%d)", c.Staking.DecayPercent)
+	}
+	if c.Staking.JurorSharePercent < 0 || c.Staking.JurorSharePercent > 100 {
+		problems.Add("STAKING_JUROR_SHARE_PERCENT", "0~100 사이여야 합니다 (%d)", c.Staking.JurorSharePercent)
+	}
+
+	switch c.Matching.Mode {
+	case "local", "distributed":
+	default:
+		problems.Add("MATCHING_ENGINE_MODE", "local, distributed 중 하나여야 합니다 (%q)", c.Matching.Mode)
+	}
+	if c.Matching.SnapshotEveryEvents < 0 {
+		problems.Add("EVENT_SNAPSHOT_EVERY", "0 이상이어야 합니다")
+	}
+	if c.Matching.CompactionIntervalMinutes <= 0 {
+		problems.Add("EVENT_STREAM_COMPACTION_MINUTES", "0보다 커야 합니다")
+	}
+
+	if len(c.Maintenance.MilestoneIDs()) != len(c.Maintenance.PausedMilestones) {
+		problems.Add("TRADING_PAUSED_MILESTONES", "양의 정수 마일스톤 ID 목록이어야 합니다 (%v)", c.Maintenance.PausedMilestones)
+	}
+
+	if c.Reconciliation.IntervalMinutes <= 0 {
+		problems.Add("RECONCILIATION_INTERVAL_MINUTES", "0보다 커야 합니다")
+	}
+	if c.DeadLetter.AlertThreshold <= 0 {
+		problems.Add("DLQ_ALERT_THRESHOLD", "0보다 커야 합니다")
+	}
+	seenCurrencies := make(map[string]bool)
+	for _, code := range c.Wallet.CurrencyCodes() {
+		if !validCurrencyCode(code) || seenCurrencies[code] {
+			problems.Add("WALLET_CURRENCIES", "중복 없는 영숫자 2~10자 통화 코드여야 하고 usdc/blueprint는 넣지 않습니다 (%q)", code)
+		}
+		seenCurrencies[code] = true
+	}
+	if c.Wallet.DailyConvertLimit <= 0 {
+		problems.Add("WALLET_DAILY_CONVERT_LIMIT", "0보다 커야 합니다")
+	}
+	if c.Messaging.RetentionDays <= 0 {
+		problems.Add("MESSAGE_RETENTION_DAYS", "0보다 커야 합니다")
+	}
+
+	if c.Security.HSTSMaxAgeSeconds < 0 {
+		problems.Add("HSTS_MAX_AGE_SECONDS", "0 이상이어야 합니다")
+	}
+
+	trust := c.TrustScore
+	weights := []int{trust.EmailWeight, trust.PhoneWeight, trust.LinkedInWeight, trust.GitHubWeight, trust.TwitterWeight,
+		trust.WorkEmailWeight, trust.ProfessionalWeight, trust.EducationWeight, trust.KYCWeight}
+	totalWeight := 0
+	for _, weight := range weights {
+		if weight < 0 {
+			problems.Add("TRUST_WEIGHT_*", "가중치는 0 이상이어야 합니다 (%d)", weight)
+		}
+		totalWeight += weight
+	}
+	if totalWeight <= 0 {
+		problems.Add("TRUST_WEIGHT_*", "가중치 합이 0보다 커야 합니다")
+	}
+	if trust.MinValidator < 0 || trust.MinValidator > 100 {
+		problems.Add("TRUST_MIN_VALIDATOR", "0~100 사이여야 합니다 (%d)", trust.MinValidator)
+	}
+	if trust.MinJuror < 0 || trust.MinJuror > 100 {
+		problems.Add("TRUST_MIN_JUROR", "0~100 사이여야 합니다 (%d)", trust.MinJuror)
+	}
+	if trust.MinMentor < 0 || trust.MinMentor > 100 {
+		problems.Add("TRUST_MIN_MENTOR", "0~100 사이여야 합니다 (%d)", trust.MinMentor)
+	}
+	if trust.RecalculateIntervalMinutes <= 0 {
+		problems.Add("TRUST_RECALCULATE_MINUTES", "0보다 커야 합니다")
+	}
+
+	if c.Responsible.DefaultDailyDeposit < 0 || c.Responsible.DefaultDailyOrderNotional < 0 {
+		problems.Add("RESPONSIBLE_DEFAULT_DAILY_*", "0 이상이어야 합니다")
+	}
+	if c.Responsible.LimitIncreaseDelayHours < 0 {
+		problems.Add("RESPONSIBLE_LIMIT_INCREASE_DELAY_HOURS", "0 이상이어야 합니다")
+	}
+	if c.Transfer.FeeCents < 0 {
+		problems.Add("POSITION_TRANSFER_FEE", "0 이상이어야 합니다")
+	}
+
+	if c.MagicLink.TTLMinutes <= 0 || c.MagicLink.TTLMinutes > 60 {
+		problems.Add("MAGIC_LINK_TTL_MINUTES", "1~60 사이여야 합니다 (%d)", c.MagicLink.TTLMinutes)
+	}
+	if c.MagicLink.ResendIntervalSeconds < 0 {
+		problems.Add("MAGIC_LINK_RESEND_SECONDS", "0 이상이어야 합니다")
+	}
+	if c.MagicLink.HourlyLimit <= 0 {
+		problems.Add("MAGIC_LINK_HOURLY_LIMIT", "0보다 커야 합니다")
+	}
+
+	return problems.Err()
 }
 
 // getEnv 환경변수를 가져오거나 기본값을 반환합니다
@@ -153,6 +526,17 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvAsList 쉼표로 구분된 환경변수를 목록으로 가져옵니다
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 // getEnvAsInt 환경변수를 정수로 가져오거나 기본값을 반환합니다
 func getEnvAsInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
@@ -1,7 +1,9 @@
 package database
 
 import (
-	"blueprint-module/pkg/config"
+	"log"
+	"time"
+
 	"blueprint-module/pkg/database"
 	localConfig "blueprint/internal/config"
 
@@ -15,19 +17,25 @@ func GetDB() *gorm.DB {
 
 // Connect initializes database connection using module
 func Connect(cfg *localConfig.Config) error {
-	// Convert local config to module config
-	moduleConfig := &config.Config{
-		Database: config.DatabaseConfig{
-			Host:     cfg.Database.Host,
-			User:     cfg.Database.User,
-			Password: cfg.Database.Password,
-			Name:     cfg.Database.Name,
-			Port:     cfg.Database.Port,
-			SSLMode:  cfg.Database.SSLMode,
-		},
+	if err := database.Connect(&cfg.Config); err != nil {
+		return err
+	}
+
+	// 읽기 복제본은 없어도 동작 (조회가 기본 DB로 감)
+	if _, err := database.ConnectReplicas(&cfg.Config); err != nil {
+		log.Printf("⚠️ Read replicas unavailable, reading from primary: %v", err)
 	}
+	return nil
+}
+
+// GetReadDB returns the read-only connection (replicas, or the primary when none are configured)
+func GetReadDB() *gorm.DB {
+	return database.GetReadDB()
+}
 
-	return database.Connect(moduleConfig)
+// RunReplicaHealthCheck drops lagging or unreachable replicas from the read rotation
+func RunReplicaHealthCheck(interval time.Duration) {
+	database.RunReplicaHealthCheck(interval)
 }
 
 // AutoMigrate runs database migrations
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccountDeletionHandler 계정 삭제 및 개인 데이터 내보내기 핸들러
+type AccountDeletionHandler struct {
+	deletionService *services.AccountDeletionService
+	exportService   *services.ExportService
+}
+
+// NewAccountDeletionHandler 생성자
+func NewAccountDeletionHandler(deletionService *services.AccountDeletionService, exportService *services.ExportService) *AccountDeletionHandler {
+	return &AccountDeletionHandler{
+		deletionService: deletionService,
+		exportService:   exportService,
+	}
+}
+
+// DeleteAccount 계정 삭제 예약 (30일 유예 후 삭제)
+// DELETE /api/v1/users/me
+func (h *AccountDeletionHandler) DeleteAccount(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var req models.DeleteAccountRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			middleware.BadRequest(c, err.Error())
+			return
+		}
+	}
+
+	deletion, err := h.deletionService.RequestDeletion(userID, req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrDeletionAlreadyRequested), errors.Is(err, services.ErrDeletionBlocked):
+			middleware.Conflict(c, err.Error())
+		default:
+			middleware.InternalServerError(c, err.Error())
+		}
+		return
+	}
+
+	middleware.SuccessWithStatus(c, http.StatusAccepted, gin.H{"deletion": deletion}, "계정 삭제가 예약되었습니다. 30일 안에 다시 로그인해 취소할 수 있습니다")
+}
+
+// GetAccountDeletion 예약된 계정 삭제 조회
+// GET /api/v1/users/me/deletion
+func (h *AccountDeletionHandler) GetAccountDeletion(c *gin.Context) {
+	deletion, err := h.deletionService.GetPending(c.GetUint("user_id"))
+	if err != nil {
+		if errors.Is(err, services.ErrDeletionNotRequested) {
+			middleware.NotFound(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{"deletion": deletion}, "계정 삭제 예약 조회 성공")
+}
+
+// CancelAccountDeletion 유예 기간 중 계정 삭제 취소
+// DELETE /api/v1/users/me/deletion
+func (h *AccountDeletionHandler) CancelAccountDeletion(c *gin.Context) {
+	deletion, err := h.deletionService.CancelDeletion(c.GetUint("user_id"))
+	if err != nil {
+		if errors.Is(err, services.ErrDeletionNotRequested) {
+			middleware.NotFound(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{"deletion": deletion}, "계정 삭제가 취소되었습니다")
+}
+
+// ExportMyData 개인 데이터 전체 내보내기 요청 (워커가 JSON 파일 생성, /exports/:id/download로 다운로드)
+// GET /api/v1/users/me/export
+func (h *AccountDeletionHandler) ExportMyData(c *gin.Context) {
+	export, err := h.exportService.RequestPersonalDataExport(c.GetUint("user_id"))
+	if err != nil {
+		if errors.Is(err, services.ErrExportLimitExceeded) {
+			middleware.Error(c, http.StatusTooManyRequests, err.Error(), "요청 한도를 초과했습니다")
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.SuccessWithStatus(c, http.StatusAccepted, export, "개인 데이터 파일을 생성 중입니다. 완료되면 알림으로 알려드립니다")
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/queue"
+	"blueprint-module/pkg/redis"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccountLinkHandler 로그인 수단 연결/해제 및 중복 계정 병합 핸들러
+type AccountLinkHandler struct {
+	linkService *services.AccountLinkService
+}
+
+// NewAccountLinkHandler 생성자
+func NewAccountLinkHandler(linkService *services.AccountLinkService) *AccountLinkHandler {
+	return &AccountLinkHandler{
+		linkService: linkService,
+	}
+}
+
+// GetIdentities 연결된 로그인 수단 목록
+// GET /api/v1/users/me/identities
+func (h *AccountLinkHandler) GetIdentities(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	identities, err := h.linkService.ListIdentities(userID)
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{"identities": identities}, "로그인 수단 조회 성공")
+}
+
+// UnlinkIdentity 로그인 수단 연결 해제
+// DELETE /api/v1/users/me/identities/:provider
+func (h *AccountLinkHandler) UnlinkIdentity(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	if err := h.linkService.Unlink(userID, c.Param("provider")); err != nil {
+		switch {
+		case errors.Is(err, services.ErrIdentityNotLinked):
+			middleware.NotFound(c, err.Error())
+		case errors.Is(err, services.ErrIdentityRequired), errors.Is(err, services.ErrIdentityInUse):
+			middleware.Conflict(c, err.Error())
+		default:
+			middleware.InternalServerError(c, err.Error())
+		}
+		return
+	}
+
+	middleware.Success(c, gin.H{"provider": c.Param("provider")}, "로그인 수단 연결 해제")
+}
+
+// GetDuplicateAccounts 같은 인증 이메일을 쓰는 다른 계정 (병합 후보)
+// GET /api/v1/users/me/account-merges/candidates
+func (h *AccountLinkHandler) GetDuplicateAccounts(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	duplicates, err := h.linkService.FindDuplicates(userID)
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{"accounts": duplicates}, "중복 계정 조회 성공")
+}
+
+// StartAccountMerge 병합 요청 (흡수할 계정 이메일로 확인 코드 발송)
+// POST /api/v1/users/me/account-merges
+func (h *AccountLinkHandler) StartAccountMerge(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var req models.StartAccountMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, err.Error())
+		return
+	}
+
+	// 다른 사람 이메일로 코드를 반복 발송하지 못하도록 제한
+	if allowed, err := redis.CheckRateLimit(userID, "account_merge_request", 5, time.Hour); err == nil && !allowed {
+		middleware.Error(c, http.StatusTooManyRequests, "Too many merge requests", "잠시 후 다시 시도해주세요")
+		return
+	}
+
+	merge, source, code, err := h.linkService.StartMerge(userID, req.SourceEmail)
+	if err != nil {
+		h.respondMergeError(c, err)
+		return
+	}
+
+	jobID, err := queue.PublishTrackedJob("email_queue", map[string]interface{}{
+		"type":     "send_email",
+		"to":       source.Email,
+		"template": "email_verification",
+		"data": map[string]interface{}{
+			"username": source.Username,
+			"code":     code,
+		},
+		"user_id":   source.ID,
+		"timestamp": time.Now().Unix(),
+	})
+	if err != nil {
+		log.Printf("❌ Failed to queue account merge code for user %d: %v", source.ID, err)
+		middleware.InternalServerError(c, "Failed to send merge confirmation code")
+		return
+	}
+
+	middleware.SuccessWithStatus(c, http.StatusAccepted, gin.H{
+		"merge":      merge,
+		"expires_in": int(time.Until(merge.ExpiresAt).Seconds()),
+		"job_id":     jobID,
+	}, "병합 확인 코드를 병합할 계정 이메일로 보냈습니다")
+}
+
+// ConfirmAccountMerge 확인 코드로 병합 실행
+// POST /api/v1/users/me/account-merges/:id/confirm
+func (h *AccountLinkHandler) ConfirmAccountMerge(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	mergeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid merge ID")
+		return
+	}
+
+	var req models.ConfirmAccountMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, err.Error())
+		return
+	}
+
+	merge, err := h.linkService.ConfirmMerge(userID, uint(mergeID), req.Code)
+	if err != nil {
+		h.respondMergeError(c, err)
+		return
+	}
+
+	middleware.Success(c, gin.H{"merge": merge}, "계정 병합 완료")
+}
+
+// respondMergeError 병합 오류를 HTTP 응답으로 변환
+func (h *AccountLinkHandler) respondMergeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrMergeSourceNotFound), errors.Is(err, services.ErrMergeNotFound):
+		middleware.NotFound(c, err.Error())
+	case errors.Is(err, services.ErrMergeSelf):
+		middleware.BadRequest(c, err.Error())
+	case errors.Is(err, services.ErrMergeExpired), errors.Is(err, services.ErrMergeInvalidCode):
+		middleware.Unauthorized(c, err.Error())
+	case errors.Is(err, services.ErrMergeBlocked):
+		middleware.Conflict(c, err.Error())
+	default:
+		middleware.InternalServerError(c, err.Error())
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"blueprint-module/pkg/models"
 	"blueprint/internal/database"
 	"blueprint/internal/middleware"
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // ActivityHandler 활동 로그 핸들러
@@ -29,7 +32,8 @@ func (h *ActivityHandler) GetUserActivities(c *gin.Context) {
 	// 쿼리 파라미터 파싱
 	limitStr := c.DefaultQuery("limit", "20")
 	offsetStr := c.DefaultQuery("offset", "0")
-	activityTypes := c.QueryArray("types") // ?types=project&types=trade
+	activityTypes := splitQueryList(c.QueryArray("types")) // ?types=project&types=trade 또는 ?types=auth,trading
+	events := splitQueryList(c.QueryArray("events"))       // ?events=trading.order_placed,auth.login
 
 	limit, err := strconv.Atoi(limitStr)
 	if err != nil || limit <= 0 || limit > 100 {
@@ -41,19 +45,35 @@ func (h *ActivityHandler) GetUserActivities(c *gin.Context) {
 		offset = 0
 	}
 
-	// 날짜 범위 파라미터
-	var startDate, endDate *time.Time
-	if startStr := c.Query("start_date"); startStr != "" {
-		if parsed, err := time.Parse("2006-01-02", startStr); err == nil {
-			startDate = &parsed
+	for _, activityType := range activityTypes {
+		if !models.IsValidActivityType(activityType) {
+			middleware.BadRequest(c, fmt.Sprintf("unknown activity type: %s", activityType))
+			return
 		}
 	}
-	if endStr := c.Query("end_date"); endStr != "" {
-		if parsed, err := time.Parse("2006-01-02", endStr); err == nil {
-			endDate = &parsed
+	for _, event := range events {
+		if !models.IsValidActivityType(models.ActivityEvent(event).Type()) || models.ActivityEvent(event).Action() == "" {
+			middleware.BadRequest(c, fmt.Sprintf("invalid activity event: %s (expected type.action)", event))
+			return
 		}
 	}
 
+	// 날짜 범위 파라미터 (YYYY-MM-DD 또는 RFC3339)
+	startDate, err := parseActivityDate(c.Query("start_date"), false)
+	if err != nil {
+		middleware.BadRequest(c, "invalid start_date: "+err.Error())
+		return
+	}
+	endDate, err := parseActivityDate(c.Query("end_date"), true)
+	if err != nil {
+		middleware.BadRequest(c, "invalid end_date: "+err.Error())
+		return
+	}
+	if startDate != nil && endDate != nil && endDate.Before(*startDate) {
+		middleware.BadRequest(c, "end_date must not be before start_date")
+		return
+	}
+
 	// 데이터베이스 쿼리 구성
 	db := database.GetDB()
 	query := db.Model(&models.ActivityLog{}).
@@ -67,12 +87,26 @@ func (h *ActivityHandler) GetUserActivities(c *gin.Context) {
 		query = query.Where("activity_type IN ?", activityTypes)
 	}
 
+	// 활동 이벤트 필터 (분류 + 액션)
+	if len(events) > 0 {
+		var conditions *gorm.DB
+		for _, event := range events {
+			e := models.ActivityEvent(event)
+			if conditions == nil {
+				conditions = db.Where("activity_type = ? AND action = ?", e.Type(), e.Action())
+				continue
+			}
+			conditions = conditions.Or("activity_type = ? AND action = ?", e.Type(), e.Action())
+		}
+		query = query.Where(conditions)
+	}
+
 	// 날짜 범위 필터
 	if startDate != nil {
 		query = query.Where("created_at >= ?", startDate)
 	}
 	if endDate != nil {
-		query = query.Where("created_at <= ?", endDate.Add(24*time.Hour-time.Second))
+		query = query.Where("created_at <= ?", endDate)
 	}
 
 	// 총 개수 조회
@@ -103,6 +137,37 @@ func (h *ActivityHandler) GetUserActivities(c *gin.Context) {
 	middleware.Success(c, response, "Activities retrieved successfully")
 }
 
+// splitQueryList 반복 파라미터와 쉼표 구분 값을 모두 허용
+func splitQueryList(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
+
+// parseActivityDate 날짜 파라미터 파싱 - 날짜만 주어진 종료일은 그날의 마지막 시각으로 처리
+func parseActivityDate(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return &parsed, nil
+	}
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("expected YYYY-MM-DD or RFC3339, got %q", value)
+	}
+	if endOfDay {
+		parsed = parsed.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &parsed, nil
+}
+
 // GetActivitySummary 사용자의 활동 요약 정보 조회 (대시보드용)
 func (h *ActivityHandler) GetActivitySummary(c *gin.Context) {
 	userID, exists := c.Get("user_id")
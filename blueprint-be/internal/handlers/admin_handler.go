@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHandler 관리자 전용 핸들러 (역할 관리)
+type AdminHandler struct {
+	roleService *services.RoleService
+}
+
+// NewAdminHandler 생성자
+func NewAdminHandler(roleService *services.RoleService) *AdminHandler {
+	return &AdminHandler{
+		roleService: roleService,
+	}
+}
+
+// GetMyRoles 내 역할/권한 조회
+// GET /api/v1/users/me/roles
+func (h *AdminHandler) GetMyRoles(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	response, err := h.roleService.GetUserRolesResponse(userID.(uint))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, response, "역할 조회 성공")
+}
+
+// GetRoleDefinitions 역할별 권한 정의 조회
+// GET /api/v1/admin/roles
+func (h *AdminHandler) GetRoleDefinitions(c *gin.Context) {
+	definitions := make(map[models.Role][]models.Permission, len(models.ValidRoles))
+	for _, role := range models.ValidRoles {
+		definitions[role] = models.RolePermissions[role]
+	}
+	definitions[models.RoleAdmin] = models.AllPermissions
+
+	middleware.Success(c, gin.H{
+		"roles":       models.ValidRoles,
+		"permissions": definitions,
+	}, "역할 정의 조회 성공")
+}
+
+// GetRoleMembers 역할별 사용자 목록
+// GET /api/v1/admin/roles/:role/members
+func (h *AdminHandler) GetRoleMembers(c *gin.Context) {
+	members, err := h.roleService.ListRoleMembers(models.Role(c.Param("role")))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRole) {
+			middleware.BadRequest(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"members": members,
+		"count":   len(members),
+	}, "역할 사용자 조회 성공")
+}
+
+// GetUserRoles 특정 사용자 역할 조회
+// GET /api/v1/admin/users/:id/roles
+func (h *AdminHandler) GetUserRoles(c *gin.Context) {
+	targetID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	response, err := h.roleService.GetUserRolesResponse(uint(targetID))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, response, "역할 조회 성공")
+}
+
+// GrantRole 역할 부여
+// POST /api/v1/admin/users/:id/roles
+func (h *AdminHandler) GrantRole(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	targetID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	var req models.GrantRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	grantedBy := adminID.(uint)
+	userRole, err := h.roleService.GrantRole(uint(targetID), req.Role, &grantedBy, req.Reason)
+	if err != nil {
+		middleware.BadRequest(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, userRole, "역할이 부여되었습니다")
+}
+
+// RevokeRole 역할 회수
+// DELETE /api/v1/admin/users/:id/roles/:role
+func (h *AdminHandler) RevokeRole(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	targetID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	if err := h.roleService.RevokeRole(uint(targetID), models.Role(c.Param("role")), adminID.(uint)); err != nil {
+		if errors.Is(err, services.ErrRoleNotAssigned) {
+			middleware.NotFound(c, err.Error())
+			return
+		}
+		middleware.BadRequest(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, nil, "역할이 회수되었습니다")
+}
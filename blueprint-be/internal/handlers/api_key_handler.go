@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHandler API 키 관리 핸들러
+type APIKeyHandler struct {
+	apiKeyService *services.APIKeyService
+}
+
+// NewAPIKeyHandler 생성자
+func NewAPIKeyHandler(apiKeyService *services.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// CreateAPIKey API 키 발급
+// POST /api/v1/users/me/api-keys
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req models.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	result, err := h.apiKeyService.CreateKey(userID.(uint), &req)
+	if err != nil {
+		middleware.BadRequest(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, result, "API key created. Store the secret now, it will not be shown again")
+}
+
+// ListAPIKeys 내 API 키 목록
+// GET /api/v1/users/me/api-keys
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	keys, err := h.apiKeyService.ListKeys(userID.(uint))
+	if err != nil {
+		middleware.InternalServerError(c, "Failed to retrieve API keys")
+		return
+	}
+
+	middleware.Success(c, gin.H{"api_keys": keys}, "API keys retrieved successfully")
+}
+
+// RevokeAPIKey API 키 폐기
+// DELETE /api/v1/users/me/api-keys/:id
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	keyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid API key ID")
+		return
+	}
+
+	if err := h.apiKeyService.RevokeKey(userID.(uint), uint(keyID)); err != nil {
+		middleware.NotFound(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, nil, "API key revoked")
+}
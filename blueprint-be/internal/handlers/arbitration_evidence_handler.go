@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"strconv"
+
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ArbitrationEvidenceHandler 분쟁 증거 파일 핸들러
+type ArbitrationEvidenceHandler struct {
+	evidenceService *services.ArbitrationEvidenceService
+}
+
+// NewArbitrationEvidenceHandler 생성자
+func NewArbitrationEvidenceHandler(evidenceService *services.ArbitrationEvidenceService) *ArbitrationEvidenceHandler {
+	return &ArbitrationEvidenceHandler{
+		evidenceService: evidenceService,
+	}
+}
+
+// SubmitEvidence 증거 파일 제출 (multipart: files 여러 개 + description)
+// POST /api/v1/arbitration/cases/:id/evidence
+func (h *ArbitrationEvidenceHandler) SubmitEvidence(c *gin.Context) {
+	caseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 사건 ID입니다"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "로그인이 필요합니다"})
+		return
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "multipart 형식으로 파일을 첨부해주세요"})
+		return
+	}
+
+	uploads := make([]services.EvidenceUpload, 0, len(form.File["files"]))
+	for _, header := range form.File["files"] {
+		file, err := header.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "파일을 읽을 수 없습니다: " + header.Filename})
+			return
+		}
+		defer file.Close()
+		uploads = append(uploads, services.EvidenceUpload{File: file, Header: header})
+	}
+
+	evidences, err := h.evidenceService.SubmitEvidence(uint(caseID), userID.(uint), c.PostForm("description"), uploads)
+	if err != nil {
+		if errors.Is(err, services.ErrEvidenceCaseNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":   "증거가 제출되었습니다. 악성코드 검사 후 열람할 수 있습니다",
+		"evidences": evidences,
+	})
+}
+
+// ListEvidence 증거 목록 (항소심은 이전 심급 증거 포함)
+// GET /api/v1/arbitration/cases/:id/evidence
+func (h *ArbitrationEvidenceHandler) ListEvidence(c *gin.Context) {
+	caseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 사건 ID입니다"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "로그인이 필요합니다"})
+		return
+	}
+
+	evidences, err := h.evidenceService.ListEvidence(uint(caseID), userID.(uint))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"evidences": evidences,
+		"total":     len(evidences),
+	})
+}
+
+// DownloadEvidence 증거 파일 다운로드 (검사 통과 파일만)
+// GET /api/v1/arbitration/cases/:id/evidence/:evidenceId/download
+func (h *ArbitrationEvidenceHandler) DownloadEvidence(c *gin.Context) {
+	caseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 사건 ID입니다"})
+		return
+	}
+	evidenceID, err := strconv.ParseUint(c.Param("evidenceId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 증거 ID입니다"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "로그인이 필요합니다"})
+		return
+	}
+
+	file, stored, evidence, err := h.evidenceService.OpenEvidence(uint(caseID), uint(evidenceID), userID.(uint))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	defer file.Close()
+
+	c.Header("Content-Type", stored.ContentType)
+	c.Header("Content-Disposition", contentDisposition("attachment", stored.OriginalName))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("X-Evidence-SHA256", evidence.SHA256)
+
+	http.ServeContent(c.Writer, c.Request, "", stored.UploadedAt, file)
+}
+
+func (h *ArbitrationEvidenceHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrEvidenceCaseNotFound), errors.Is(err, services.ErrEvidenceNotFound), errors.Is(err, os.ErrNotExist):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrEvidenceForbidden), errors.Is(err, services.ErrEvidenceBlocked):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrEvidenceNotScanned):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "증거 파일 처리 중 오류가 발생했습니다"})
+	}
+}
@@ -81,6 +81,35 @@ func (h *ArbitrationHandler) GetCase(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// IssueVoteNonce 배심원 투표 nonce 발급 (커밋 해시 계산에 사용)
+// POST /api/v1/arbitration/cases/:id/vote-nonce
+func (h *ArbitrationHandler) IssueVoteNonce(c *gin.Context) {
+	caseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 사건 ID입니다"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "로그인이 필요합니다"})
+		return
+	}
+
+	nonce, err := h.arbitrationService.IssueVoteNonce(uint(caseID), userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"case_id":  caseID,
+		"juror_id": userID,
+		"nonce":    nonce,
+		"scheme":   "sha256(case_id:juror_id:vote:salt:nonce)",
+	})
+}
+
 // CommitVote 배심원 투표 제출 (Commit phase)
 // POST /api/v1/arbitration/cases/:id/vote
 func (h *ArbitrationHandler) CommitVote(c *gin.Context) {
@@ -288,6 +317,58 @@ func (h *ArbitrationHandler) BecomeJuror(c *gin.Context) {
 	})
 }
 
+// GetJurorAvailability 배심원 가용성 조회
+// GET /api/v1/arbitration/juror/availability
+func (h *ArbitrationHandler) GetJurorAvailability(c *gin.Context) {
+	// 1. 사용자 ID 추출
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "로그인이 필요합니다"})
+		return
+	}
+
+	// 2. 가용성 조회
+	availability, err := h.arbitrationService.GetJurorAvailability(userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// 3. 성공 응답
+	c.JSON(http.StatusOK, availability)
+}
+
+// UpdateJurorAvailability 배심원 동시 사건 한도/휴가 모드 변경
+// PUT /api/v1/arbitration/juror/availability
+func (h *ArbitrationHandler) UpdateJurorAvailability(c *gin.Context) {
+	// 1. 요청 바디 파싱
+	var req models.UpdateJurorAvailabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 요청 데이터입니다: " + err.Error()})
+		return
+	}
+
+	// 2. 사용자 ID 추출
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "로그인이 필요합니다"})
+		return
+	}
+
+	// 3. 가용성 변경
+	availability, err := h.arbitrationService.UpdateJurorAvailability(userID.(uint), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// 4. 성공 응답
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "배심원 가용성이 변경되었습니다",
+		"availability": availability,
+	})
+}
+
 // GetArbitrationStats 분쟁 해결 통계 조회
 // GET /api/v1/arbitration/stats
 func (h *ArbitrationHandler) GetArbitrationStats(c *gin.Context) {
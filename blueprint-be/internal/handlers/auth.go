@@ -6,15 +6,18 @@ import (
 	"blueprint-module/pkg/queue"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
 	"time"
 
 	"blueprint/internal/database"
 	"blueprint/internal/middleware"
+	"blueprint/internal/services"
 	"blueprint/pkg/utils"
 
 	"github.com/gin-gonic/gin"
@@ -83,11 +86,13 @@ func generateUsernameFromGoogleName(name string, googleID string) string {
 }
 
 type AuthHandler struct {
-	cfg         *config.Config
-	googleOAuth *oauth2.Config
+	cfg            *config.Config
+	googleOAuth    *oauth2.Config
+	sessionService *services.SessionService
+	linkService    *services.AccountLinkService
 }
 
-func NewAuthHandler(cfg *config.Config) *AuthHandler {
+func NewAuthHandler(cfg *config.Config, sessionService *services.SessionService, linkService *services.AccountLinkService) *AuthHandler {
 	googleConfig := &oauth2.Config{
 		ClientID:     cfg.OAuth.Google.ClientID,
 		ClientSecret: cfg.OAuth.Google.ClientSecret,
@@ -97,14 +102,32 @@ func NewAuthHandler(cfg *config.Config) *AuthHandler {
 	}
 
 	return &AuthHandler{
-		cfg:         cfg,
-		googleOAuth: googleConfig,
+		cfg:            cfg,
+		googleOAuth:    googleConfig,
+		sessionService: sessionService,
+		linkService:    linkService,
 	}
 }
 
+// referralStatePrefix Google OAuth state에 추천 코드를 싣기 위한 접두사
+const referralStatePrefix = "ref:"
+
+// referralCodeFromState OAuth state에서 추천 코드 추출 (없으면 빈 문자열)
+func referralCodeFromState(state string) string {
+	if !strings.HasPrefix(state, referralStatePrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(state, referralStatePrefix)
+}
+
 // Google OAuth 로그인 시작
 func (h *AuthHandler) GoogleLogin(c *gin.Context) {
-	url := h.googleOAuth.AuthCodeURL("state", oauth2.AccessTypeOffline)
+	// 추천 코드는 OAuth state로 콜백까지 전달 (신규 가입 시에만 사용)
+	state := "state"
+	if ref := strings.TrimSpace(c.Query("ref")); ref != "" && len(ref) <= 16 {
+		state = referralStatePrefix + ref
+	}
+	url := h.googleOAuth.AuthCodeURL(state, oauth2.AccessTypeOffline)
 	middleware.Success(c, gin.H{"auth_url": url}, "Google auth URL generated successfully")
 }
 
@@ -138,9 +161,12 @@ func (h *AuthHandler) GoogleCallback(c *gin.Context) {
 		return
 	}
 
-	// 기존 사용자 확인
+	// 기존 사용자 확인 (Google 계정 → 인증된 이메일 순, 미인증 이메일로는 기존 계정에 연결하지 않음)
 	var user models.User
-	err = database.GetDB().Where("google_id = ? OR email = ?", userinfo.ID, userinfo.Email).First(&user).Error
+	err = database.GetDB().Where("google_id = ?", userinfo.ID).First(&user).Error
+	if err == gorm.ErrRecordNotFound && userinfo.VerifiedEmail {
+		err = database.GetDB().Where("email = ?", userinfo.Email).First(&user).Error
+	}
 
 	if err == gorm.ErrRecordNotFound {
 		// 새 사용자 생성
@@ -171,10 +197,11 @@ func (h *AuthHandler) GoogleCallback(c *gin.Context) {
 		// 🆕 Google 회원가입 후속 작업들을 큐로 비동기 처리
 		publisher := queue.NewPublisher()
 		err = publisher.EnqueueUserCreated(queue.UserCreatedEventData{
-			UserID:   user.ID,
-			Email:    user.Email,
-			Username: user.Username,
-			Provider: "google",
+			UserID:       user.ID,
+			Email:        user.Email,
+			Username:     user.Username,
+			Provider:     "google",
+			ReferralCode: referralCodeFromState(c.Query("state")),
 		})
 		if err != nil {
 			log.Printf("❌ Failed to enqueue Google user created tasks: %v", err)
@@ -195,15 +222,35 @@ func (h *AuthHandler) GoogleCallback(c *gin.Context) {
 		}
 	}
 
-	// JWT 토큰 생성
-	jwtToken, err := utils.GenerateToken(&user, h.cfg.JWT.Secret)
+	// 병합된 계정이면 병합 대상으로 로그인하고 Google 신원 기록
+	resolved, err := h.linkService.ResolveMerged(&user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		return
+	}
+	user = *resolved
+	if _, err := h.linkService.LinkIdentity(user.ID, models.UserIdentity{
+		Provider:       models.IdentityProviderGoogle,
+		ProviderUserID: userinfo.ID,
+		Email:          userinfo.Email,
+		EmailVerified:  userinfo.VerifiedEmail,
+		DisplayName:    userinfo.Name,
+	}); err != nil {
+		log.Printf("⚠️ Failed to record Google identity for user %d: %v", user.ID, err)
+	}
+
+	// 로그인 세션 생성 (액세스 토큰 + 리프레시 토큰)
+	tokens, err := h.sessionService.CreateSession(&user, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
 	}
 
 	// 프론트엔드로 JWT 토큰과 함께 리다이렉트
-	frontendURL := fmt.Sprintf("http://localhost:3000?token=%s&user_id=%d", jwtToken, user.ID)
+	frontendURL := fmt.Sprintf("http://localhost:3000?token=%s&user_id=%d", tokens.AccessToken, user.ID)
+	if tokens.RefreshToken != "" {
+		frontendURL += "&refresh_token=" + url.QueryEscape(tokens.RefreshToken)
+	}
 	c.Redirect(http.StatusFound, frontendURL)
 }
 
@@ -224,7 +271,7 @@ func (h *AuthHandler) Me(c *gin.Context) {
 	middleware.Success(c, user, "User information retrieved successfully")
 }
 
-// Logout 로그아웃 처리 🚪
+// Logout 로그아웃 처리 🚪 (현재 세션 폐기)
 func (h *AuthHandler) Logout(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -232,44 +279,107 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 		return
 	}
 
-	// 현재 JWT 기반이므로 클라이언트에서 토큰 삭제하도록 안내
-	// 향후 Redis 기반 블랙리스트나 세션 관리로 확장 가능
+	// 세션 없이 발급된 토큰은 폐기할 수 없으므로 클라이언트에서 삭제
+	if sessionID := c.GetString("session_id"); sessionID != "" {
+		if err := h.sessionService.RevokeSession(userID.(uint), sessionID); err != nil && !errors.Is(err, services.ErrSessionNotFound) {
+			middleware.InternalServerError(c, "로그아웃 처리에 실패했습니다")
+			return
+		}
+	}
+
 	middleware.Success(c, gin.H{
-		"message":      "로그아웃이 완료되었습니다",
-		"user_id":      userID,
-		"logout_time":  time.Now(),
-		"instructions": "클라이언트에서 토큰을 삭제해주세요",
+		"message":     "로그아웃이 완료되었습니다",
+		"user_id":     userID,
+		"logout_time": time.Now(),
 	}, "로그아웃이 성공적으로 처리되었습니다")
 }
 
-// RefreshToken JWT 토큰 갱신 🔄
+// RefreshToken 리프레시 토큰으로 액세스 토큰 재발급 🔄 (리프레시 토큰도 함께 회전)
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
+	var req models.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "refresh_token이 필요합니다")
+		return
+	}
+
+	tokens, err := h.sessionService.Refresh(req.RefreshToken, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		if errors.Is(err, services.ErrSessionInvalid) || errors.Is(err, services.ErrRefreshTokenReused) {
+			middleware.Unauthorized(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, "토큰 갱신에 실패했습니다")
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"token":              tokens.AccessToken,
+		"refresh_token":      tokens.RefreshToken,
+		"expires_in":         tokens.ExpiresIn,
+		"refresh_expires_in": tokens.RefreshExpiresIn,
+		"session_id":         tokens.SessionID,
+		"refresh_time":       time.Now(),
+	}, "토큰이 성공적으로 갱신되었습니다")
+}
+
+// GetSessions 내 로그인 세션(기기) 목록 📱
+// GET /api/v1/users/me/sessions
+func (h *AuthHandler) GetSessions(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		middleware.Unauthorized(c, "User not authenticated")
 		return
 	}
 
-	// 사용자 정보 조회
-	var user models.User
-	if err := database.GetDB().First(&user, userID).Error; err != nil {
-		middleware.NotFound(c, "사용자를 찾을 수 없습니다")
+	sessions, err := h.sessionService.ListSessions(userID.(uint), c.GetString("session_id"))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"sessions": sessions,
+		"count":    len(sessions),
+	}, "세션 목록 조회 성공")
+}
+
+// RevokeSession 특정 세션 로그아웃
+// DELETE /api/v1/users/me/sessions/:id
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	if err := h.sessionService.RevokeSession(userID.(uint), c.Param("id")); err != nil {
+		if errors.Is(err, services.ErrSessionNotFound) {
+			middleware.NotFound(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, nil, "세션이 종료되었습니다")
+}
+
+// RevokeOtherSessions 현재 기기를 제외한 모든 세션 로그아웃
+// DELETE /api/v1/users/me/sessions
+func (h *AuthHandler) RevokeOtherSessions(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
 		return
 	}
 
-	// 새로운 토큰 생성
-	token, err := utils.GenerateToken(&user, h.cfg.JWT.Secret)
+	revoked, err := h.sessionService.RevokeOtherSessions(userID.(uint), c.GetString("session_id"))
 	if err != nil {
-		middleware.InternalServerError(c, "토큰 생성에 실패했습니다")
+		middleware.InternalServerError(c, err.Error())
 		return
 	}
 
-	middleware.Success(c, gin.H{
-		"token":        token,
-		"user":         user,
-		"expires_in":   24 * 60 * 60, // 24시간 (초 단위)
-		"refresh_time": time.Now(),
-	}, "토큰이 성공적으로 갱신되었습니다")
+	middleware.Success(c, gin.H{"revoked": revoked}, "다른 기기의 세션이 모두 종료되었습니다")
 }
 
 // CheckTokenExpiry 토큰 만료 확인 ⏰
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CopyTradingHandler 복사 거래 핸들러
+type CopyTradingHandler struct {
+	copyTradingService *services.CopyTradingService
+}
+
+// NewCopyTradingHandler 생성자
+func NewCopyTradingHandler(copyTradingService *services.CopyTradingService) *CopyTradingHandler {
+	return &CopyTradingHandler{copyTradingService: copyTradingService}
+}
+
+// GetDisclaimer 팔로우 전에 보여줄 위험 고지 (동의 시 버전을 함께 보냄)
+// GET /api/v1/copy-trading/disclaimer
+func (h *CopyTradingHandler) GetDisclaimer(c *gin.Context) {
+	middleware.Success(c, gin.H{
+		"version":    models.CopyTradingDisclaimerVersion,
+		"disclaimer": models.CopyTradingDisclaimer,
+	}, "복사 거래 위험 고지 조회 성공")
+}
+
+// ListLeaders 팔로우할 수 있는 리더 목록
+// GET /api/v1/copy-trading/leaders
+func (h *CopyTradingHandler) ListLeaders(c *gin.Context) {
+	leaders, err := h.copyTradingService.ListLeaders()
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{"leaders": leaders, "count": len(leaders)}, "리더 목록 조회 성공")
+}
+
+// UpdateLeader 내 리더 모드 켜기/끄기
+// PUT /api/v1/copy-trading/leader
+func (h *CopyTradingHandler) UpdateLeader(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req models.UpdateCopyLeaderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	leader, err := h.copyTradingService.SetLeader(userID.(uint), *req.Enabled)
+	if err != nil {
+		respondCopyTradingError(c, err)
+		return
+	}
+
+	middleware.Success(c, leader, "리더 설정이 변경되었습니다")
+}
+
+// Follow 리더 팔로우 (위험 고지 동의 필수)
+// POST /api/v1/copy-trading/leaders/:id/follow
+func (h *CopyTradingHandler) Follow(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	leaderID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid leader ID")
+		return
+	}
+
+	var req models.FollowTraderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	follow, err := h.copyTradingService.Follow(userID.(uint), uint(leaderID), req)
+	if err != nil {
+		respondCopyTradingError(c, err)
+		return
+	}
+
+	middleware.Success(c, follow, "리더를 팔로우했습니다")
+}
+
+// ListFollows 내 팔로우 목록
+// GET /api/v1/copy-trading/follows
+func (h *CopyTradingHandler) ListFollows(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	follows, err := h.copyTradingService.ListFollows(userID.(uint))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{"follows": follows, "count": len(follows)}, "팔로우 목록 조회 성공")
+}
+
+// UpdateFollow 팔로우 한도 변경/일시 중지/재개
+// PUT /api/v1/copy-trading/follows/:id
+func (h *CopyTradingHandler) UpdateFollow(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	followID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid follow ID")
+		return
+	}
+
+	var req models.UpdateCopyFollowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	follow, err := h.copyTradingService.UpdateFollow(uint(followID), userID.(uint), req)
+	if err != nil {
+		respondCopyTradingError(c, err)
+		return
+	}
+
+	middleware.Success(c, follow, "팔로우 설정이 변경되었습니다")
+}
+
+// Unfollow 팔로우 해제
+// DELETE /api/v1/copy-trading/follows/:id
+func (h *CopyTradingHandler) Unfollow(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	followID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid follow ID")
+		return
+	}
+
+	if err := h.copyTradingService.Unfollow(uint(followID), userID.(uint)); err != nil {
+		respondCopyTradingError(c, err)
+		return
+	}
+
+	middleware.Success(c, nil, "팔로우를 해제했습니다")
+}
+
+// ListExecutions 내 복사 주문 내역
+// GET /api/v1/copy-trading/executions
+func (h *CopyTradingHandler) ListExecutions(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	executions, err := h.copyTradingService.ListExecutions(userID.(uint))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{"executions": executions, "count": len(executions)}, "복사 주문 내역 조회 성공")
+}
+
+func respondCopyTradingError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrCopyLeaderNotFound),
+		errors.Is(err, services.ErrCopyFollowNotFound):
+		middleware.NotFound(c, err.Error())
+	case errors.Is(err, services.ErrCopyAlreadyFollowing),
+		errors.Is(err, services.ErrCopyChainNotAllowed),
+		errors.Is(err, services.ErrCopyLeaderNotPublic):
+		middleware.Conflict(c, err.Error())
+	case errors.Is(err, services.ErrCopyFollowSelf),
+		errors.Is(err, services.ErrCopyDisclaimerRequired):
+		middleware.BadRequest(c, err.Error())
+	default:
+		middleware.InternalServerError(c, err.Error())
+	}
+}
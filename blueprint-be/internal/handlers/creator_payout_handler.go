@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CreatorPayoutHandler 창작자 지급 계좌/지급 요청 핸들러
+type CreatorPayoutHandler struct {
+	payoutService *services.CreatorPayoutService
+}
+
+// NewCreatorPayoutHandler 생성자
+func NewCreatorPayoutHandler(payoutService *services.CreatorPayoutService) *CreatorPayoutHandler {
+	return &CreatorPayoutHandler{payoutService: payoutService}
+}
+
+// RegisterAccount 지급 계좌 등록 (은행 계좌 또는 암호화폐 주소)
+// POST /api/v1/payouts/accounts
+func (h *CreatorPayoutHandler) RegisterAccount(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req models.RegisterPayoutAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	account, err := h.payoutService.RegisterAccount(userID.(uint), req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidPayoutAccount) {
+			middleware.BadRequest(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, account, "지급 계좌가 등록되었습니다")
+}
+
+// GetAccounts 내 지급 계좌 (마스킹된 정보만)
+// GET /api/v1/payouts/accounts
+func (h *CreatorPayoutHandler) GetAccounts(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	accounts, err := h.payoutService.GetAccounts(userID.(uint))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, accounts, "지급 계좌 조회 성공")
+}
+
+// DeleteAccount 지급 계좌 삭제
+// DELETE /api/v1/payouts/accounts/:id
+func (h *CreatorPayoutHandler) DeleteAccount(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	accountID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid account ID")
+		return
+	}
+
+	if err := h.payoutService.DeleteAccount(userID.(uint), uint(accountID)); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			middleware.NotFound(c, "Payout account not found")
+		case errors.Is(err, services.ErrPayoutAccountInUse):
+			middleware.BadRequest(c, err.Error())
+		default:
+			middleware.InternalServerError(c, err.Error())
+		}
+		return
+	}
+
+	middleware.Success(c, nil, "지급 계좌가 삭제되었습니다")
+}
+
+// GetEarnings 마일스톤별 창작자 수익과 지급 가능 금액
+// GET /api/v1/payouts/earnings
+func (h *CreatorPayoutHandler) GetEarnings(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	earnings, err := h.payoutService.GetEarnings(userID.(uint))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, earnings, "창작자 수익 조회 성공")
+}
+
+// RequestPayout 지급 요청 (금액을 잠그고 관리자 승인 대기)
+// POST /api/v1/payouts
+func (h *CreatorPayoutHandler) RequestPayout(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req models.CreateCreatorPayoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	payout, err := h.payoutService.RequestPayout(userID.(uint), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrPayoutAccountRequired), errors.Is(err, services.ErrPayoutExceedsEarnings):
+			middleware.BadRequest(c, err.Error())
+		default:
+			middleware.InternalServerError(c, err.Error())
+		}
+		return
+	}
+
+	middleware.Success(c, payout, "지급 요청이 접수되었습니다")
+}
+
+// GetMyPayouts 내 지급 요청 내역
+// GET /api/v1/payouts/my?limit=50&offset=0
+func (h *CreatorPayoutHandler) GetMyPayouts(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	payouts, err := h.payoutService.GetMyPayouts(userID.(uint), limit, offset)
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"payouts": payouts,
+		"count":   len(payouts),
+	}, "지급 내역 조회 성공")
+}
+
+// ListPayouts 지급 요청 목록 (관리자)
+// GET /api/v1/admin/payouts?status=requested&limit=50&offset=0
+func (h *CreatorPayoutHandler) ListPayouts(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	payouts, err := h.payoutService.ListPayouts(models.CreatorPayoutStatus(c.Query("status")), limit, offset)
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"payouts": payouts,
+		"count":   len(payouts),
+	}, "지급 요청 조회 성공")
+}
+
+// ApprovePayout 지급 승인 (scheduled_for 이후 워커가 송금)
+// POST /api/v1/admin/payouts/:id/approve
+func (h *CreatorPayoutHandler) ApprovePayout(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	payoutID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid payout ID")
+		return
+	}
+
+	var req models.ApproveCreatorPayoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			middleware.BadRequest(c, "Invalid request: "+err.Error())
+			return
+		}
+	}
+
+	payout, err := h.payoutService.ApprovePayout(adminID.(uint), uint(payoutID), req.ScheduledFor)
+	if err != nil {
+		h.respondReviewError(c, err)
+		return
+	}
+
+	middleware.Success(c, payout, "지급 요청이 승인되었습니다")
+}
+
+// RejectPayout 지급 반려 (잠금 해제)
+// POST /api/v1/admin/payouts/:id/reject
+func (h *CreatorPayoutHandler) RejectPayout(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	payoutID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid payout ID")
+		return
+	}
+
+	var req models.RejectCreatorPayoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	payout, err := h.payoutService.RejectPayout(adminID.(uint), uint(payoutID), req.Reason)
+	if err != nil {
+		h.respondReviewError(c, err)
+		return
+	}
+
+	middleware.Success(c, payout, "지급 요청이 반려되었습니다")
+}
+
+func (h *CreatorPayoutHandler) respondReviewError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		middleware.NotFound(c, "Payout not found")
+	case errors.Is(err, services.ErrPayoutNotReviewable):
+		middleware.BadRequest(c, err.Error())
+	default:
+		middleware.InternalServerError(c, err.Error())
+	}
+}
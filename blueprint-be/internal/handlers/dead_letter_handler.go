@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"blueprint-module/pkg/queue"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeadLetterHandler 데드레터 큐 운영 핸들러
+type DeadLetterHandler struct {
+	deadLetterService *services.DeadLetterService
+}
+
+// NewDeadLetterHandler 생성자
+func NewDeadLetterHandler(deadLetterService *services.DeadLetterService) *DeadLetterHandler {
+	return &DeadLetterHandler{deadLetterService: deadLetterService}
+}
+
+// DeadLetterSelectionRequest 재처리/삭제할 DLQ 메시지 ID
+type DeadLetterSelectionRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// ListQueues DLQ가 쌓인 큐와 크기
+// GET /api/v1/admin/dead-letters
+func (h *DeadLetterHandler) ListQueues(c *gin.Context) {
+	queues, err := h.deadLetterService.ListQueues()
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"queues": queues,
+		"count":  len(queues),
+	}, "데드레터 큐 조회 성공")
+}
+
+// ListDeadLetters 큐의 DLQ 항목 (최신순, next_before로 다음 페이지)
+// GET /api/v1/admin/dead-letters/:queue?limit=50&before=1700000000000-0
+func (h *DeadLetterHandler) ListDeadLetters(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(services.DefaultDeadLetterLimit)))
+
+	letters, err := h.deadLetterService.List(c.Param("queue"), limit, c.Query("before"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	response := gin.H{
+		"items": letters,
+		"count": len(letters),
+	}
+	if len(letters) > 0 {
+		response["next_before"] = letters[len(letters)-1].ID
+	}
+	middleware.Success(c, response, "데드레터 조회 성공")
+}
+
+// GetDeadLetter DLQ 항목 상세 (원본 payload, 마지막 오류)
+// GET /api/v1/admin/dead-letters/:queue/:id
+func (h *DeadLetterHandler) GetDeadLetter(c *gin.Context) {
+	letter, err := h.deadLetterService.Get(c.Param("queue"), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	middleware.Success(c, letter, "데드레터 조회 성공")
+}
+
+// Replay 선택한 항목을 원래 큐로 재발행 (재시도 횟수 초기화)
+// POST /api/v1/admin/dead-letters/:queue/replay
+func (h *DeadLetterHandler) Replay(c *gin.Context) {
+	var req DeadLetterSelectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil || len(req.IDs) == 0 {
+		middleware.BadRequest(c, "재처리할 항목 ids를 지정해야 합니다")
+		return
+	}
+
+	result, err := h.deadLetterService.Replay(c.Param("queue"), req.IDs)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	middleware.Success(c, result, "데드레터 재처리 완료")
+}
+
+// Purge 선택한 항목 삭제 (ids를 생략하면 큐의 DLQ 전체)
+// POST /api/v1/admin/dead-letters/:queue/purge
+func (h *DeadLetterHandler) Purge(c *gin.Context) {
+	var req DeadLetterSelectionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			middleware.BadRequest(c, "Invalid request: "+err.Error())
+			return
+		}
+	}
+
+	removed, err := h.deadLetterService.Purge(c.Param("queue"), req.IDs)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	middleware.Success(c, gin.H{"removed": removed}, "데드레터 삭제 완료")
+}
+
+// Metrics 큐별 DLQ 크기와 경보 여부 (Prometheus 텍스트 형식)
+// GET /metrics
+func (h *DeadLetterHandler) Metrics(c *gin.Context) {
+	var body bytes.Buffer
+	if err := h.deadLetterService.WriteMetrics(&body); err != nil {
+		c.String(http.StatusServiceUnavailable, "# metrics unavailable: %v\n", err)
+		return
+	}
+
+	c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", body.Bytes())
+}
+
+func (h *DeadLetterHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, queue.ErrDeadLetterNotFound):
+		middleware.NotFound(c, err.Error())
+	case errors.Is(err, services.ErrInvalidDeadLetterQueue):
+		middleware.BadRequest(c, err.Error())
+	default:
+		middleware.InternalServerError(c, err.Error())
+	}
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"errors"
+
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DigestHandler 알림 요약 메일 수신 거부 핸들러
+type DigestHandler struct {
+	digestService *services.DigestService
+}
+
+// NewDigestHandler 생성자
+func NewDigestHandler(digestService *services.DigestService) *DigestHandler {
+	return &DigestHandler{digestService: digestService}
+}
+
+// Unsubscribe 메일의 수신 거부 토큰으로 요약 메일 끄기 (로그인 불필요)
+// POST /api/v1/digests/unsubscribe?token=...  (본문 {"token": "..."}도 허용)
+func (h *DigestHandler) Unsubscribe(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		var req struct {
+			Token string `json:"token" form:"token"`
+		}
+		_ = c.ShouldBind(&req)
+		token = req.Token
+	}
+	if token == "" {
+		middleware.BadRequest(c, "token is required")
+		return
+	}
+
+	if _, err := h.digestService.Unsubscribe(token); err != nil {
+		if errors.Is(err, services.ErrInvalidUnsubscribeToken) {
+			middleware.BadRequest(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{"digest_frequency": "off"}, "알림 요약 메일 수신 거부 완료")
+}
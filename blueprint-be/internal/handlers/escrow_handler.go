@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// EscrowHandler 마일스톤 직접 후원 에스크로 핸들러
+type EscrowHandler struct {
+	escrowService *services.FundingEscrowService
+	kycService    *services.KYCService
+}
+
+// NewEscrowHandler 생성자
+func NewEscrowHandler(escrowService *services.FundingEscrowService, kycService *services.KYCService) *EscrowHandler {
+	return &EscrowHandler{
+		escrowService: escrowService,
+		kycService:    kycService,
+	}
+}
+
+// Deposit 마일스톤 후원 예치 (완료 확정 시 프로젝트 소유자에게 지급, 실패/취소 시 반환)
+// POST /api/v1/milestones/:id/escrow
+func (h *EscrowHandler) Deposit(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	milestoneID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid milestone ID")
+		return
+	}
+
+	var req models.CreateFundingEscrowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	// 🪪 본인 인증 단계별 한도 (주문과 같은 기준)
+	if err := h.kycService.CheckOrderLimit(userID.(uint), req.Amount); err != nil {
+		middleware.Forbidden(c, err.Error())
+		return
+	}
+
+	escrow, err := h.escrowService.Deposit(userID.(uint), uint(milestoneID), req.Amount)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			middleware.NotFound(c, "Milestone not found")
+		case errors.Is(err, services.ErrTradingRestricted), errors.Is(err, services.ErrDailyLimitExceeded):
+			middleware.Forbidden(c, err.Error())
+		case errors.Is(err, services.ErrEscrowNotAccepting), errors.Is(err, services.ErrEscrowSelfBacking), errors.Is(err, services.ErrInsufficientBalance):
+			middleware.BadRequest(c, err.Error())
+		default:
+			middleware.InternalServerError(c, err.Error())
+		}
+		return
+	}
+
+	middleware.Success(c, escrow, "후원금이 예치되었습니다")
+}
+
+// GetMilestoneEscrow 마일스톤 후원 현황 (공개)
+// GET /api/v1/milestones/:id/escrow
+func (h *EscrowHandler) GetMilestoneEscrow(c *gin.Context) {
+	milestoneID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid milestone ID")
+		return
+	}
+
+	summary, err := h.escrowService.GetMilestoneEscrow(uint(milestoneID))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, summary, "후원 현황 조회 성공")
+}
+
+// GetMyEscrows 내 후원 내역
+// GET /api/v1/escrow/my?limit=50&offset=0
+func (h *EscrowHandler) GetMyEscrows(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	escrows, err := h.escrowService.GetMyEscrows(userID.(uint), limit, offset)
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"escrows": escrows,
+		"count":   len(escrows),
+	}, "후원 내역 조회 성공")
+}
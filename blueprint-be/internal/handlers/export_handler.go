@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportHandler 거래 내역 내보내기 핸들러
+type ExportHandler struct {
+	exportService *services.ExportService
+}
+
+// NewExportHandler 생성자
+func NewExportHandler(exportService *services.ExportService) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+	}
+}
+
+// ExportTrades 체결 내역 내보내기 요청
+// GET /api/v1/trades/export?format=csv&year=2024
+func (h *ExportHandler) ExportTrades(c *gin.Context) {
+	h.requestExport(c, models.DataExportTrades)
+}
+
+// ExportOrders 주문 내역 내보내기 요청
+// GET /api/v1/orders/export?format=csv&year=2024
+func (h *ExportHandler) ExportOrders(c *gin.Context) {
+	h.requestExport(c, models.DataExportOrders)
+}
+
+// ExportTaxReport 연간 실현 손익 리포트 내보내기 요청
+// GET /api/v1/tax-reports/export?format=xlsx&year=2024
+func (h *ExportHandler) ExportTaxReport(c *gin.Context) {
+	h.requestExport(c, models.DataExportTaxReport)
+}
+
+// ExportFeeStatement 월간 수수료 명세서(PDF) 요청 (기본은 지난달)
+// GET /api/v1/fee-statements/export?year=2026&month=9
+func (h *ExportHandler) ExportFeeStatement(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	lastMonth := time.Now().UTC().AddDate(0, -1, 0)
+	year, err := strconv.Atoi(c.DefaultQuery("year", strconv.Itoa(lastMonth.Year())))
+	if err != nil {
+		middleware.BadRequest(c, "Invalid year")
+		return
+	}
+	month, err := strconv.Atoi(c.DefaultQuery("month", strconv.Itoa(int(lastMonth.Month()))))
+	if err != nil {
+		middleware.BadRequest(c, "Invalid month")
+		return
+	}
+
+	export, err := h.exportService.RequestFeeStatement(userID.(uint), year, month)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrExportLimitExceeded):
+			middleware.Error(c, http.StatusTooManyRequests, err.Error(), "요청 한도를 초과했습니다")
+		case errors.Is(err, services.ErrExportInvalidPeriod):
+			middleware.BadRequest(c, err.Error())
+		default:
+			middleware.InternalServerError(c, err.Error())
+		}
+		return
+	}
+
+	middleware.SuccessWithStatus(c, http.StatusAccepted, export, "수수료 명세서를 생성 중입니다. 완료되면 알림으로 알려드립니다")
+}
+
+func (h *ExportHandler) requestExport(c *gin.Context, kind models.DataExportKind) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	format := models.DataExportFormat(c.DefaultQuery("format", string(models.DataExportCSV)))
+	year, err := strconv.Atoi(c.DefaultQuery("year", strconv.Itoa(time.Now().UTC().Year())))
+	if err != nil {
+		middleware.BadRequest(c, "Invalid year")
+		return
+	}
+
+	export, err := h.exportService.RequestExport(userID.(uint), kind, format, year)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrExportLimitExceeded):
+			middleware.Error(c, http.StatusTooManyRequests, err.Error(), "요청 한도를 초과했습니다")
+		case errors.Is(err, services.ErrExportInvalidKind), errors.Is(err, services.ErrExportInvalidFormat), errors.Is(err, services.ErrExportInvalidYear):
+			middleware.BadRequest(c, err.Error())
+		default:
+			middleware.InternalServerError(c, err.Error())
+		}
+		return
+	}
+
+	middleware.SuccessWithStatus(c, http.StatusAccepted, export, "내보내기 파일을 생성 중입니다. 완료되면 알림으로 알려드립니다")
+}
+
+// ListExports 내 내보내기 요청 목록
+// GET /api/v1/exports
+func (h *ExportHandler) ListExports(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	limit, offset := limitOffsetPagination(c)
+	exports, total, err := h.exportService.ListExports(userID.(uint), limit, offset)
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"exports": exports,
+		"total":   total,
+		"limit":   limit,
+		"offset":  offset,
+	}, "내보내기 목록 조회 성공")
+}
+
+// GetExport 내보내기 요청 상태 조회
+// GET /api/v1/exports/:id
+func (h *ExportHandler) GetExport(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	exportID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid export ID")
+		return
+	}
+
+	export, err := h.exportService.GetExport(userID.(uint), uint(exportID))
+	if err != nil {
+		middleware.NotFound(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, export, "내보내기 상태 조회 성공")
+}
+
+// DownloadExport 완료된 내보내기 파일 다운로드 (본인만)
+// GET /api/v1/exports/:id/download
+func (h *ExportHandler) DownloadExport(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	exportID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid export ID")
+		return
+	}
+
+	file, stored, err := h.exportService.OpenExport(userID.(uint), uint(exportID))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrExportNotReady):
+			middleware.Conflict(c, err.Error())
+		case errors.Is(err, services.ErrExportNotFound), errors.Is(err, os.ErrNotExist):
+			middleware.NotFound(c, "Export file not found")
+		default:
+			middleware.InternalServerError(c, "Failed to open export file")
+		}
+		return
+	}
+	defer file.Close()
+
+	c.Header("Content-Type", stored.ContentType)
+	c.Header("Content-Disposition", contentDisposition("attachment", stored.OriginalName))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Cache-Control", "private, no-store")
+
+	http.ServeContent(c.Writer, c.Request, "", stored.UploadedAt, file)
+}
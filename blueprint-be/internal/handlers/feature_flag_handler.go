@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"errors"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeatureFlagHandler 기능 플래그 핸들러
+type FeatureFlagHandler struct {
+	flagService *services.FeatureFlagService
+}
+
+// NewFeatureFlagHandler 생성자
+func NewFeatureFlagHandler(flagService *services.FeatureFlagService) *FeatureFlagHandler {
+	return &FeatureFlagHandler{
+		flagService: flagService,
+	}
+}
+
+// ListFlags 기능 플래그 현재 상태와 정의 조회
+// GET /api/v1/admin/feature-flags
+func (h *FeatureFlagHandler) ListFlags(c *gin.Context) {
+	middleware.Success(c, gin.H{
+		"flags":       h.flagService.List(),
+		"definitions": models.FeatureFlagDefinitions,
+	}, "기능 플래그 조회 성공")
+}
+
+// UpdateFlag 기능 플래그 변경 (켜기/끄기, 롤아웃 비율, 허용 사용자)
+// PUT /api/v1/admin/feature-flags/:key
+func (h *FeatureFlagHandler) UpdateFlag(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req models.UpdateFeatureFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	flag, err := h.flagService.Update(models.FeatureFlagKey(c.Param("key")), req, adminID.(uint))
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownFeatureFlag) {
+			middleware.BadRequest(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, flag, "기능 플래그 변경 완료")
+}
+
+// GetMyFlags 내게 적용되는 기능 플래그
+// GET /api/v1/users/me/feature-flags
+func (h *FeatureFlagHandler) GetMyFlags(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	middleware.Success(c, h.flagService.EvaluateFor(userID.(uint)), "기능 플래그 조회 성공")
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FileHandler 업로드 파일 다운로드 핸들러
+type FileHandler struct {
+	fileService *services.FileService
+}
+
+// NewFileHandler 생성자
+func NewFileHandler(fileService *services.FileService) *FileHandler {
+	return &FileHandler{
+		fileService: fileService,
+	}
+}
+
+// DownloadFile 업로드 파일 다운로드 (민감 문서는 서명된 URL 필요)
+// GET /api/v1/files/:category/:key?expires=...&signature=...
+func (h *FileHandler) DownloadFile(c *gin.Context) {
+	category := c.Param("category")
+	key := c.Param("key")
+
+	sensitive := h.fileService.IsSensitiveCategory(category)
+	if sensitive {
+		err := h.fileService.VerifySignature(category, key, c.Query("expires"), c.Query("signature"))
+		if err != nil {
+			middleware.Forbidden(c, err.Error())
+			return
+		}
+	}
+
+	file, stored, err := h.fileService.OpenFile(category, key)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidFileKey) || errors.Is(err, os.ErrNotExist) {
+			middleware.NotFound(c, "File not found")
+			return
+		}
+		middleware.InternalServerError(c, "Failed to open file")
+		return
+	}
+	defer file.Close()
+
+	// 콘텐츠 스니핑 방지: 감지된 안전한 형식만 그대로 제공, 나머지는 바이너리 첨부파일
+	contentType := "application/octet-stream"
+	disposition := "attachment"
+	if stored.Inline() {
+		contentType = stored.ContentType
+		if !sensitive {
+			disposition = "inline"
+		}
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", contentDisposition(disposition, stored.OriginalName))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Content-Security-Policy", "default-src 'none'; img-src 'self'; media-src 'self'; sandbox")
+	c.Header("Cross-Origin-Resource-Policy", "same-site")
+	if sensitive {
+		c.Header("Cache-Control", "private, no-store")
+		c.Header("Referrer-Policy", "no-referrer")
+	} else {
+		c.Header("Cache-Control", "public, max-age=86400")
+	}
+
+	http.ServeContent(c.Writer, c.Request, "", stored.UploadedAt, file)
+}
+
+// contentDisposition 원본 파일명을 안전하게 인코딩한 Content-Disposition 값
+func contentDisposition(disposition, filename string) string {
+	// 헤더 인젝션 방지: 제어문자/따옴표/경로 구분자 제거
+	cleaned := strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' || r == '\\' || r == '/' {
+			return -1
+		}
+		return r
+	}, filename)
+	if cleaned == "" {
+		cleaned = "download"
+	}
+
+	// ASCII 대체 파일명 + RFC 5987 UTF-8 파일명
+	ascii := strings.Map(func(r rune) rune {
+		if r > 0x7e {
+			return '_'
+		}
+		return r
+	}, cleaned)
+
+	if value := mime.FormatMediaType(disposition, map[string]string{"filename": ascii}); value != "" {
+		return fmt.Sprintf("%s; filename*=UTF-8''%s", value, url.PathEscape(cleaned))
+	}
+	return disposition
+}
@@ -1,12 +1,15 @@
 package handlers
 
 import (
+	"blueprint-module/pkg/models"
 	"blueprint/internal/middleware"
 	"blueprint/internal/services"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // FundingHandler 펀딩 관련 API 핸들러
@@ -45,16 +48,9 @@ func (h *FundingHandler) GetFundingStats(c *gin.Context) {
 	})
 }
 
-// StartFundingPhase 펀딩 단계 강제 시작 (관리자용)
+// StartFundingPhase 펀딩 단계 강제 시작 (funding:manage 권한)
 // POST /api/v1/milestones/:id/funding/start
 func (h *FundingHandler) StartFundingPhase(c *gin.Context) {
-	// 관리자 권한 확인 (추후 구현)
-	// userID, exists := c.Get("user_id")
-	// if !exists {
-	//     middleware.Unauthorized(c, "User not authenticated")
-	//     return
-	// }
-
 	milestoneIDStr := c.Param("id")
 	milestoneID, err := strconv.ParseUint(milestoneIDStr, 10, 32)
 	if err != nil {
@@ -63,6 +59,10 @@ func (h *FundingHandler) StartFundingPhase(c *gin.Context) {
 	}
 
 	if err := h.lifecycleService.ForceStartFunding(uint(milestoneID)); err != nil {
+		if errors.Is(err, services.ErrMilestoneDependenciesUnmet) {
+			middleware.Conflict(c, err.Error())
+			return
+		}
 		middleware.InternalServerError(c, "Failed to start funding phase: "+err.Error())
 		return
 	}
@@ -73,7 +73,68 @@ func (h *FundingHandler) StartFundingPhase(c *gin.Context) {
 	})
 }
 
-// ProcessExpiredFunding 만료된 펀딩들 강제 처리 (관리자용)
+// ResolveOutcomeRequest 마켓 판정 요청 (다중 결과 마켓은 option_id, 수치 마켓은 value)
+type ResolveOutcomeRequest struct {
+	OptionID string   `json:"option_id" binding:"max=50"`
+	Value    *float64 `json:"value"`
+}
+
+// ResolveOutcome 다중 결과 마켓의 승리 옵션 또는 수치 마켓의 판정 값 확정 (markets:resolve 권한)
+// POST /api/v1/milestones/:id/resolve
+func (h *FundingHandler) ResolveOutcome(c *gin.Context) {
+	milestoneID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid milestone ID")
+		return
+	}
+
+	var req ResolveOutcomeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request format: "+err.Error())
+		return
+	}
+
+	if (req.OptionID == "") == (req.Value == nil) {
+		middleware.BadRequest(c, "option_id 또는 value 중 하나만 지정해야 합니다")
+		return
+	}
+
+	actorID := c.MustGet("user_id").(uint)
+	var milestone *models.Milestone
+	if req.Value != nil {
+		milestone, err = h.lifecycleService.ResolveScalar(uint(milestoneID), *req.Value, actorID)
+	} else {
+		milestone, err = h.lifecycleService.ResolveOutcome(uint(milestoneID), req.OptionID, actorID)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			middleware.NotFound(c, "Milestone not found")
+		case errors.Is(err, services.ErrNotCategoricalMarket), errors.Is(err, services.ErrNotScalarMarket),
+			errors.Is(err, services.ErrUnknownOption), errors.Is(err, services.ErrInvalidResolvedValue),
+			errors.Is(err, services.ErrInvalidMilestoneTransition):
+			middleware.BadRequest(c, err.Error())
+		case errors.Is(err, services.ErrMilestoneTransitionConflict):
+			middleware.Conflict(c, err.Error())
+		default:
+			middleware.InternalServerError(c, "Failed to resolve outcome: "+err.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Outcome resolved successfully",
+		"data": gin.H{
+			"milestone_id":      milestone.ID,
+			"status":            milestone.Status,
+			"winning_option_id": milestone.WinningOptionID,
+			"resolved_value":    milestone.ResolvedValue,
+		},
+	})
+}
+
+// ProcessExpiredFunding 만료된 펀딩들 강제 처리 (funding:manage 권한)
 // POST /api/v1/funding/process-expired
 func (h *FundingHandler) ProcessExpiredFunding(c *gin.Context) {
 	if err := h.lifecycleService.ForceProcessExpired(); err != nil {
@@ -103,12 +164,13 @@ func (h *FundingHandler) GetLifecycleStats(c *gin.Context) {
 }
 
 // GetFundingMilestones 펀딩 중인 마일스톤 목록 조회
-// GET /api/v1/funding/active
+// GET /api/v1/funding/active?category=&sort=ending_soon|progress|tvl
 func (h *FundingHandler) GetFundingMilestones(c *gin.Context) {
 	// 쿼리 파라미터
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	category := c.Query("category")
+	sort := c.DefaultQuery("sort", "ending_soon")
 
 	if page < 1 {
 		page = 1
@@ -116,20 +178,45 @@ func (h *FundingHandler) GetFundingMilestones(c *gin.Context) {
 	if limit < 1 || limit > 50 {
 		limit = 10
 	}
+	switch sort {
+	case "ending_soon", "progress", "tvl":
+	default:
+		middleware.BadRequest(c, "Invalid sort (ending_soon, progress, tvl)")
+		return
+	}
 
 	offset := (page - 1) * limit
 
-	// 데이터베이스 쿼리 (기본 구현)
-	// TODO: 실제 서비스 메서드로 분리
+	milestones, total, err := h.fundingService.GetFundingMilestones(category, sort, limit, offset)
+	if err != nil {
+		middleware.InternalServerError(c, "Failed to get funding milestones")
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "펀딩 중인 마일스톤 목록",
 		"data": gin.H{
 			"page":       page,
 			"limit":      limit,
+			"total":      total,
 			"category":   category,
-			"offset":     offset,
-			"milestones": []gin.H{}, // 실제 데이터는 추후 구현
+			"sort":       sort,
+			"milestones": milestones,
 		},
 	})
 }
+
+// GetFundingDashboard 펀딩 현황 대시보드 (전체 TVL, 카테고리별 집계)
+// GET /api/v1/funding/dashboard
+func (h *FundingHandler) GetFundingDashboard(c *gin.Context) {
+	dashboard, err := h.fundingService.GetFundingDashboard()
+	if err != nil {
+		middleware.InternalServerError(c, "Failed to get funding dashboard")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    dashboard,
+	})
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GitHubIntegrationHandler GitHub 저장소 연동 핸들러
+type GitHubIntegrationHandler struct {
+	githubService *services.GitHubIntegrationService
+}
+
+// NewGitHubIntegrationHandler 생성자
+func NewGitHubIntegrationHandler(githubService *services.GitHubIntegrationService) *GitHubIntegrationHandler {
+	return &GitHubIntegrationHandler{
+		githubService: githubService,
+	}
+}
+
+// ListRepositories 연결된 GitHub 계정의 저장소 목록
+// GET /api/v1/integrations/github/repos
+func (h *GitHubIntegrationHandler) ListRepositories(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 20*time.Second)
+	defer cancel()
+
+	repos, err := h.githubService.ListRepositories(ctx, userID.(uint))
+	if err != nil {
+		if errors.Is(err, services.ErrGitHubNotConnected) {
+			middleware.BadRequest(c, err.Error())
+			return
+		}
+		middleware.Error(c, http.StatusBadGateway, err.Error(), "GitHub 저장소 조회에 실패했습니다")
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"repositories": repos,
+		"count":        len(repos),
+	}, "GitHub 저장소 목록을 조회했습니다")
+}
+
+// CreateWebhook 마일스톤에 저장소 웹훅 등록
+// POST /api/v1/milestones/:id/github-webhooks
+func (h *GitHubIntegrationHandler) CreateWebhook(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	milestoneID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid milestone ID")
+		return
+	}
+
+	var req models.CreateGitHubWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	subscription, err := h.githubService.CreateSubscription(ctx, userID.(uint), uint(milestoneID), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrGitHubNotProjectOwner), errors.Is(err, services.ErrGitHubRepoNotAdmin):
+			middleware.Forbidden(c, err.Error())
+		case errors.Is(err, services.ErrGitHubSubscriptionExists):
+			middleware.Conflict(c, err.Error())
+		default:
+			middleware.BadRequest(c, err.Error())
+		}
+		return
+	}
+
+	middleware.SuccessWithStatus(c, http.StatusCreated, subscription, "GitHub 웹훅이 등록되었습니다")
+}
+
+// ListWebhooks 마일스톤의 웹훅 구독 목록
+// GET /api/v1/milestones/:id/github-webhooks
+func (h *GitHubIntegrationHandler) ListWebhooks(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	milestoneID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid milestone ID")
+		return
+	}
+
+	subscriptions, err := h.githubService.ListSubscriptions(userID.(uint), uint(milestoneID))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"webhooks": subscriptions,
+		"count":    len(subscriptions),
+	}, "GitHub 웹훅 목록을 조회했습니다")
+}
+
+// DeleteWebhook 웹훅 구독 해제
+// DELETE /api/v1/github-webhooks/:id
+func (h *GitHubIntegrationHandler) DeleteWebhook(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	subscriptionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 20*time.Second)
+	defer cancel()
+
+	if err := h.githubService.DeleteSubscription(ctx, userID.(uint), uint(subscriptionID)); err != nil {
+		if errors.Is(err, services.ErrGitHubSubscriptionMissing) {
+			middleware.NotFound(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, nil, "GitHub 웹훅이 해제되었습니다")
+}
+
+// ReceiveWebhook GitHub 웹훅 수신 (서명 검증은 서비스에서 구독별 secret으로 처리)
+// POST /api/v1/webhooks/github
+func (h *GitHubIntegrationHandler) ReceiveWebhook(c *gin.Context) {
+	hookID, err := strconv.ParseInt(c.GetHeader("X-GitHub-Hook-ID"), 10, 64)
+	if err != nil {
+		middleware.BadRequest(c, "Missing X-GitHub-Hook-ID header")
+		return
+	}
+	deliveryID := c.GetHeader("X-GitHub-Delivery")
+	if deliveryID == "" {
+		middleware.BadRequest(c, "Missing X-GitHub-Delivery header")
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		middleware.BadRequest(c, "Failed to read body")
+		return
+	}
+
+	result, err := h.githubService.HandleWebhook(hookID, deliveryID, c.GetHeader("X-GitHub-Event"), c.GetHeader("X-Hub-Signature-256"), body)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrGitHubSubscriptionMissing):
+			middleware.NotFound(c, err.Error())
+		case errors.Is(err, services.ErrGitHubWebhookSignature):
+			middleware.Unauthorized(c, err.Error())
+		default:
+			middleware.InternalServerError(c, err.Error())
+		}
+		return
+	}
+
+	// 증거 제출이 거절되어도 GitHub 재전송을 막기 위해 2xx로 응답
+	middleware.SuccessWithStatus(c, http.StatusAccepted, result, "Webhook processed")
+}
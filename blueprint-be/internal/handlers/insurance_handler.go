@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InsuranceHandler 마일스톤 보험 핸들러
+type InsuranceHandler struct {
+	insuranceService *services.InsuranceService
+}
+
+// NewInsuranceHandler 생성자
+func NewInsuranceHandler(insuranceService *services.InsuranceService) *InsuranceHandler {
+	return &InsuranceHandler{insuranceService: insuranceService}
+}
+
+// ListPools 카테고리별 보험 풀 현황
+// GET /api/v1/insurance/pools
+func (h *InsuranceHandler) ListPools(c *gin.Context) {
+	pools, err := h.insuranceService.ListPools()
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{"pools": pools, "count": len(pools)}, "보험 풀 조회 성공")
+}
+
+// Quote 보험료 견적
+// GET /api/v1/insurance/quote?milestone_id=1&option_id=yes&coverage=10000
+func (h *InsuranceHandler) Quote(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req models.InsuranceQuoteRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	quote, err := h.insuranceService.Quote(userID.(uint), req)
+	if err != nil {
+		respondInsuranceError(c, err)
+		return
+	}
+
+	middleware.Success(c, quote, "보험료 견적 조회 성공")
+}
+
+// Purchase 보험 가입 (보험료 납부)
+// POST /api/v1/insurance/policies
+func (h *InsuranceHandler) Purchase(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req models.InsuranceQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	policy, err := h.insuranceService.Purchase(userID.(uint), req)
+	if err != nil {
+		respondInsuranceError(c, err)
+		return
+	}
+
+	middleware.Success(c, policy, "마일스톤 보험에 가입했습니다")
+}
+
+// ListPolicies 내 보험 목록
+// GET /api/v1/insurance/policies?status=active
+func (h *InsuranceHandler) ListPolicies(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	policies, err := h.insuranceService.ListPolicies(userID.(uint), models.InsurancePolicyStatus(c.Query("status")))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{"policies": policies, "count": len(policies)}, "보험 목록 조회 성공")
+}
+
+// Claim 보험금 청구 (확정된 사기 판결 필요)
+// POST /api/v1/insurance/policies/:id/claim
+func (h *InsuranceHandler) Claim(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	policyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid policy ID")
+		return
+	}
+
+	claim, err := h.insuranceService.Claim(uint(policyID), userID.(uint))
+	if err != nil {
+		respondInsuranceError(c, err)
+		return
+	}
+
+	middleware.Success(c, claim, "보험금이 지급되었습니다")
+}
+
+func respondInsuranceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInsurancePolicyNotFound):
+		middleware.NotFound(c, err.Error())
+	case errors.Is(err, services.ErrInsurancePolicyNotActive),
+		errors.Is(err, services.ErrInsuranceNoFraudRuling),
+		errors.Is(err, services.ErrInsurancePoolEmpty):
+		middleware.Conflict(c, err.Error())
+	case errors.Is(err, services.ErrInsuranceCoverageExceeded),
+		errors.Is(err, services.ErrInsufficientBalance),
+		errors.Is(err, services.ErrMarketFrozen),
+		errors.Is(err, services.ErrMarketClosed),
+		errors.Is(err, services.ErrUnknownOption):
+		middleware.BadRequest(c, err.Error())
+	default:
+		middleware.InternalServerError(c, err.Error())
+	}
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"errors"
+
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobHandler 비동기 워커 작업 상태 조회 핸들러
+type JobHandler struct {
+	jobService *services.JobService
+}
+
+// NewJobHandler 생성자
+func NewJobHandler(jobService *services.JobService) *JobHandler {
+	return &JobHandler{jobService: jobService}
+}
+
+// GetJob 202 응답으로 받은 job_id의 진행 상태 (queued, processing, succeeded, failed)
+// GET /api/v1/jobs/:id
+func (h *JobHandler) GetJob(c *gin.Context) {
+	job, err := h.jobService.GetJob(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrJobNotFound) {
+			middleware.NotFound(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, job, "작업 상태 조회 성공")
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// KYCHandler 본인 인증(KYC) 핸들러
+type KYCHandler struct {
+	kycService *services.KYCService
+}
+
+// NewKYCHandler 생성자
+func NewKYCHandler(kycService *services.KYCService) *KYCHandler {
+	return &KYCHandler{
+		kycService: kycService,
+	}
+}
+
+// GetMyKYC 내 본인 인증 상태 및 한도
+// GET /api/v1/users/me/kyc
+func (h *KYCHandler) GetMyKYC(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	status, err := h.kycService.GetStatus(userID.(uint))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, status, "본인 인증 상태 조회 성공")
+}
+
+// SubmitKYC 본인 인증 신청 (multipart: 신청 정보 + document 파일)
+// POST /api/v1/users/me/kyc
+func (h *KYCHandler) SubmitKYC(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req models.SubmitKYCRequest
+	if err := c.ShouldBind(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	file, header, err := c.Request.FormFile("document")
+	if err != nil && !errors.Is(err, http.ErrMissingFile) && !errors.Is(err, http.ErrNotMultipart) {
+		middleware.BadRequest(c, "Failed to read document")
+		return
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	record, err := h.kycService.Submit(userID.(uint), &req, file, header)
+	if err != nil {
+		middleware.BadRequest(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, record, "본인 인증 신청이 접수되었습니다")
+}
+
+// GetPendingKYC 심사 대기 목록 (운영자)
+// GET /api/v1/admin/kyc/pending
+func (h *KYCHandler) GetPendingKYC(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	records, total, err := h.kycService.ListPending(limit, offset)
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"verifications": records,
+		"total":         total,
+		"limit":         limit,
+		"offset":        offset,
+	}, "본인 인증 심사 대기 목록 조회 성공")
+}
+
+// ReviewKYC 본인 인증 수동 심사 (운영자)
+// POST /api/v1/admin/kyc/:id/review
+func (h *KYCHandler) ReviewKYC(c *gin.Context) {
+	reviewerID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	verificationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid verification ID")
+		return
+	}
+
+	var req models.ReviewKYCRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	record, err := h.kycService.Review(reviewerID.(uint), uint(verificationID), &req)
+	if err != nil {
+		middleware.BadRequest(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, record, "본인 인증 심사가 완료되었습니다")
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LeaderboardHandler 공개 리더보드 핸들러
+type LeaderboardHandler struct {
+	leaderboardService *services.LeaderboardService
+}
+
+// NewLeaderboardHandler 생성자
+func NewLeaderboardHandler(leaderboardService *services.LeaderboardService) *LeaderboardHandler {
+	return &LeaderboardHandler{
+		leaderboardService: leaderboardService,
+	}
+}
+
+// GetLeaderboard 리더보드 조회 (traders | validators | mentors)
+// GET /api/v1/leaderboards/:kind?period=weekly|monthly|all_time&limit=50&offset=0
+func (h *LeaderboardHandler) GetLeaderboard(c *gin.Context) {
+	kind := models.LeaderboardKind(c.Param("kind"))
+	if !kind.IsValid() {
+		middleware.BadRequest(c, "Invalid leaderboard kind (traders, validators, mentors)")
+		return
+	}
+
+	period, ok := models.ParseLeaderboardPeriod(c.DefaultQuery("period", string(models.LeaderboardWeekly)))
+	if !ok {
+		middleware.BadRequest(c, "Invalid period (weekly, monthly, all_time)")
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	leaderboard, err := h.leaderboardService.GetLeaderboard(kind, period, limit, offset)
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, leaderboard, "리더보드 조회 성공")
+}
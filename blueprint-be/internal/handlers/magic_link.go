@@ -4,16 +4,15 @@ import (
 	"blueprint-module/pkg/config"
 	"blueprint-module/pkg/models"
 	"blueprint-module/pkg/queue"
-	"crypto/rand"
+	"errors"
 	"fmt"
 	"log"
-	"math/big"
+	"net/http"
 	"strings"
-	"time"
 
 	"blueprint/internal/database"
 	"blueprint/internal/middleware"
-	"blueprint/pkg/utils"
+	"blueprint/internal/services"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -21,28 +20,21 @@ import (
 
 // MagicLinkHandler 매직링크 전용 핸들러
 type MagicLinkHandler struct {
-	cfg *config.Config
+	cfg              *config.Config
+	sessionService   *services.SessionService
+	magicLinkService *services.MagicLinkService
+	linkService      *services.AccountLinkService
 }
 
-func NewMagicLinkHandler(cfg *config.Config) *MagicLinkHandler {
+func NewMagicLinkHandler(cfg *config.Config, sessionService *services.SessionService, magicLinkService *services.MagicLinkService, linkService *services.AccountLinkService) *MagicLinkHandler {
 	return &MagicLinkHandler{
-		cfg: cfg,
+		cfg:              cfg,
+		sessionService:   sessionService,
+		magicLinkService: magicLinkService,
+		linkService:      linkService,
 	}
 }
 
-// generateRandomCode 6자리 랜덤 숫자 코드 생성
-func generateRandomCode() (string, error) {
-	max := big.NewInt(999999)
-	min := big.NewInt(100000)
-
-	n, err := rand.Int(rand.Reader, max.Sub(max, min).Add(max, big.NewInt(1)))
-	if err != nil {
-		return "", err
-	}
-
-	return fmt.Sprintf("%06d", n.Add(n, min).Int64()), nil
-}
-
 // CreateMagicLink 매직링크 생성 (이메일 발송)
 func (h *MagicLinkHandler) CreateMagicLink(c *gin.Context) {
 	var req models.CreateMagicLinkRequest
@@ -51,45 +43,37 @@ func (h *MagicLinkHandler) CreateMagicLink(c *gin.Context) {
 		return
 	}
 
-	// 6자리 랜덤 코드 생성
-	code, err := generateRandomCode()
+	// 일회용 링크 토큰 + 6자리 코드 발급 (기존 미사용 링크는 무효화)
+	issued, err := h.magicLinkService.Issue(req.Email, req.ReferralCode, magicLinkClient(c, req.DeviceID))
 	if err != nil {
-		middleware.InternalServerError(c, "Failed to generate verification code")
+		h.respondMagicLinkError(c, err, "Failed to create magic link")
 		return
 	}
 
-	// 기존 미사용 매직링크 삭제 (동일 이메일)
-	database.GetDB().Where("email = ? AND is_used = false", req.Email).Delete(&models.MagicLink{})
-
-	// 새 매직링크 생성
-	magicLink := models.MagicLink{
-		Email:     req.Email,
-		Code:      code,
-		ExpiresAt: time.Now().Add(15 * time.Minute), // 15분 후 만료
-		IsUsed:    false,
-	}
-
-	if err := database.GetDB().Create(&magicLink).Error; err != nil {
-		middleware.InternalServerError(c, "Failed to create magic link")
-		return
-	}
+	ttl := h.magicLinkService.TTL()
 
 	// 이메일 발송 (백그라운드)
 	err = queue.PublishJob("email_queue", map[string]interface{}{
-		"type":       "magic_link",
-		"email":      req.Email,
-		"code":       code,
-		"expires_at": magicLink.ExpiresAt,
+		"type":            "magic_link",
+		"email":           req.Email,
+		"code":            issued.Code,
+		"token":           issued.Token,
+		"expires_at":      issued.Link.ExpiresAt,
+		"expires_minutes": int(ttl.Minutes()),
+		"locale":          requestLocale(c),
 	})
 	if err != nil {
 		log.Printf("❌ Failed to queue magic link email: %v", err)
 	}
 
-	middleware.Success(c, gin.H{
+	response := gin.H{
 		"message":    "Magic link sent",
-		"code":       code, // 개발/테스트용 - 프로덕션에서는 제거
-		"expires_in": 900,  // 15분 (초)
-	}, "Magic link created successfully")
+		"expires_in": int(ttl.Seconds()),
+	}
+	if !h.cfg.IsRelease() {
+		response["code"] = issued.Code // 개발/테스트용 - release 모드에서는 내려주지 않음
+	}
+	middleware.Success(c, response, "Magic link created successfully")
 }
 
 // VerifyMagicLink 매직링크 인증 및 로그인
@@ -100,30 +84,23 @@ func (h *MagicLinkHandler) VerifyMagicLink(c *gin.Context) {
 		return
 	}
 
-	// 매직링크 조회
-	var magicLink models.MagicLink
-	if err := database.GetDB().Where("code = ? AND is_used = false", req.Code).First(&magicLink).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			middleware.Unauthorized(c, "Invalid or expired verification code")
-			return
-		}
-		middleware.InternalServerError(c, "Database error")
-		return
+	// 매직링크 소비 (링크 토큰 또는 코드, 한 번만 성공)
+	client := magicLinkClient(c, req.DeviceID)
+	var magicLink *models.MagicLink
+	var err error
+	if req.Token != "" {
+		magicLink, err = h.magicLinkService.ConsumeToken(req.Token, client)
+	} else {
+		magicLink, err = h.magicLinkService.ConsumeCode(req.Email, req.Code, client)
 	}
-
-	// 만료 확인
-	if time.Now().After(magicLink.ExpiresAt) {
-		middleware.Unauthorized(c, "Verification code has expired")
+	if err != nil {
+		h.respondMagicLinkError(c, err, "Failed to verify magic link")
 		return
 	}
 
-	// 매직링크 사용 처리
-	magicLink.IsUsed = true
-	database.GetDB().Save(&magicLink)
-
 	// 사용자 조회 또는 생성
 	var user models.User
-	err := database.GetDB().Where("email = ?", magicLink.Email).First(&user).Error
+	err = database.GetDB().Where("email = ?", magicLink.Email).First(&user).Error
 
 	if err == gorm.ErrRecordNotFound {
 		// 새 사용자 생성 (매직링크 방식)
@@ -163,10 +140,11 @@ func (h *MagicLinkHandler) VerifyMagicLink(c *gin.Context) {
 		// 후속 작업들을 큐로 비동기 처리
 		publisher := queue.NewPublisher()
 		err = publisher.EnqueueUserCreated(queue.UserCreatedEventData{
-			UserID:   user.ID,
-			Email:    user.Email,
-			Username: user.Username,
-			Provider: "magic_link",
+			UserID:       user.ID,
+			Email:        user.Email,
+			Username:     user.Username,
+			Provider:     "magic_link",
+			ReferralCode: magicLink.ReferralCode,
 		})
 		if err != nil {
 			log.Printf("❌ Failed to enqueue magic link user created tasks: %v", err)
@@ -176,19 +154,74 @@ func (h *MagicLinkHandler) VerifyMagicLink(c *gin.Context) {
 		return
 	}
 
+	// 병합된 계정이면 병합 대상으로 로그인
+	resolved, err := h.linkService.ResolveMerged(&user)
+	if err != nil {
+		middleware.InternalServerError(c, "Database error")
+		return
+	}
+	user = *resolved
+
+	// 이메일 소유가 확인되었으므로 이메일 로그인 수단으로 기록
+	if _, err := h.linkService.LinkIdentity(user.ID, models.UserIdentity{
+		Provider:       models.IdentityProviderEmail,
+		ProviderUserID: strings.ToLower(magicLink.Email),
+		Email:          magicLink.Email,
+		EmailVerified:  true,
+	}); err != nil {
+		log.Printf("⚠️ Failed to record email identity for user %d: %v", user.ID, err)
+	}
+
 	// 매직링크와 사용자 연결
-	magicLink.UserID = &user.ID
-	database.GetDB().Save(&magicLink)
+	if err := h.magicLinkService.AttachUser(magicLink, user.ID); err != nil {
+		log.Printf("⚠️ Failed to attach user %d to magic link %d: %v", user.ID, magicLink.ID, err)
+	}
 
-	// JWT 토큰 생성
-	token, err := utils.GenerateToken(&user, h.cfg.JWT.Secret)
+	// 로그인 세션 생성 (액세스 토큰 + 리프레시 토큰)
+	tokens, err := h.sessionService.CreateSession(&user, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
 		middleware.InternalServerError(c, "Failed to generate token")
 		return
 	}
 
 	middleware.Success(c, gin.H{
-		"token": token,
-		"user":  user,
+		"token":              tokens.AccessToken,
+		"refresh_token":      tokens.RefreshToken,
+		"expires_in":         tokens.ExpiresIn,
+		"refresh_expires_in": tokens.RefreshExpiresIn,
+		"session_id":         tokens.SessionID,
+		"user":               user,
 	}, "Magic link verification successful")
 }
+
+// respondMagicLinkError 매직링크 서비스 오류를 HTTP 응답으로 변환
+func (h *MagicLinkHandler) respondMagicLinkError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrMagicLinkThrottled):
+		middleware.Error(c, http.StatusTooManyRequests, err.Error(), "Too many magic link requests")
+	case errors.Is(err, services.ErrMagicLinkInvalid), errors.Is(err, services.ErrMagicLinkDeviceMismatch):
+		middleware.Unauthorized(c, err.Error())
+	case errors.Is(err, services.ErrMagicLinkUnavailable):
+		middleware.Error(c, http.StatusServiceUnavailable, err.Error(), "Magic link login is temporarily unavailable")
+	default:
+		log.Printf("❌ Magic link error: %v", err)
+		middleware.InternalServerError(c, fallback)
+	}
+}
+
+// magicLinkClient 요청 기기 정보 (기기 바인딩/감사 기록용)
+func magicLinkClient(c *gin.Context, deviceID string) services.MagicLinkClient {
+	return services.MagicLinkClient{
+		UserAgent: c.Request.UserAgent(),
+		DeviceID:  deviceID,
+		IPAddress: c.ClientIP(),
+	}
+}
+
+// requestLocale Accept-Language 헤더 기반 이메일 언어 선택 (ko, en)
+func requestLocale(c *gin.Context) string {
+	if strings.HasPrefix(strings.ToLower(c.GetHeader("Accept-Language")), "en") {
+		return "en"
+	}
+	return "ko"
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"errors"
+
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MarginHandler 숏 포지션 증거금 핸들러
+type MarginHandler struct {
+	marginService *services.MarginService
+}
+
+// NewMarginHandler 생성자
+func NewMarginHandler(marginService *services.MarginService) *MarginHandler {
+	return &MarginHandler{marginService: marginService}
+}
+
+// GetSummary 내 포트폴리오 증거금 (평가 자산, 숏 환매 비용, 포지션별 담보)
+// GET /api/v1/margin
+func (h *MarginHandler) GetSummary(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	summary, err := h.marginService.GetSummary(userID.(uint))
+	if err != nil {
+		if errors.Is(err, services.ErrWalletNotFound) {
+			middleware.NotFound(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, summary, "증거금 조회 성공")
+}
+
+// ListLiquidations 내 강제 청산 내역
+// GET /api/v1/margin/liquidations
+func (h *MarginHandler) ListLiquidations(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	liquidations, err := h.marginService.ListLiquidations(userID.(uint))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{"liquidations": liquidations, "count": len(liquidations)}, "강제 청산 내역 조회 성공")
+}
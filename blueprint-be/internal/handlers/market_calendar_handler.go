@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// MarketCalendarHandler 마켓 거래 마감 시각 핸들러
+type MarketCalendarHandler struct {
+	calendarService *services.MarketCalendarService
+}
+
+// NewMarketCalendarHandler 생성자
+func NewMarketCalendarHandler(calendarService *services.MarketCalendarService) *MarketCalendarHandler {
+	return &MarketCalendarHandler{
+		calendarService: calendarService,
+	}
+}
+
+// SetCloseTime 마켓 마감 시각 지정 (closes_at이 없으면 목표일 기준, 과거 시각이면 바로 마감)
+// PUT /api/v1/admin/markets/:id/close-time
+func (h *MarketCalendarHandler) SetCloseTime(c *gin.Context) {
+	milestoneID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid milestone ID")
+		return
+	}
+
+	var req models.SetMarketCloseTimeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	milestone, err := h.calendarService.SetCloseTime(uint(milestoneID), req.ClosesAt)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			middleware.NotFound(c, "Milestone not found")
+			return
+		}
+		if errors.Is(err, services.ErrInvalidMarketCloseTime) {
+			middleware.BadRequest(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"milestone_id":      milestone.ID,
+		"trading_state":     milestone.TradingState(time.Now()),
+		"closes_at":         milestone.TradingCloseTime(),
+		"trading_closed_at": milestone.TradingClosedAt,
+	}, "마감 시각 변경 완료")
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"errors"
+
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MarketMakerHandler 마켓메이커 봇 운영 핸들러 (관리자)
+type MarketMakerHandler struct {
+	bot *services.MarketMakerBot
+}
+
+// NewMarketMakerHandler 생성자
+func NewMarketMakerHandler(bot *services.MarketMakerBot) *MarketMakerHandler {
+	return &MarketMakerHandler{
+		bot: bot,
+	}
+}
+
+// GetStatus 실행 상태, 설정, 손익 통계, 활성 마켓 조회
+// GET /api/v1/admin/market-maker
+func (h *MarketMakerHandler) GetStatus(c *gin.Context) {
+	middleware.Success(c, gin.H{
+		"running": h.bot.IsRunning(),
+		"config":  h.bot.GetConfig(),
+		"stats":   h.bot.GetStats(),
+		"markets": h.bot.GetActiveMarkets(),
+	}, "마켓메이커 상태 조회 성공")
+}
+
+// Start 봇 시작 (킬 스위치 발동 후 재시작 포함)
+// POST /api/v1/admin/market-maker/start
+func (h *MarketMakerHandler) Start(c *gin.Context) {
+	if err := h.bot.Start(); err != nil {
+		if errors.Is(err, services.ErrMarketMakerRunning) {
+			middleware.Conflict(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{"running": true}, "마켓메이커를 시작했습니다")
+}
+
+// Stop 봇 중지 (미체결 주문 전체 취소)
+// POST /api/v1/admin/market-maker/stop
+func (h *MarketMakerHandler) Stop(c *gin.Context) {
+	if err := h.bot.Stop(); err != nil {
+		if errors.Is(err, services.ErrMarketMakerStopped) {
+			middleware.Conflict(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{"running": false}, "마켓메이커를 중지했습니다")
+}
+
+// UpdateConfig 설정 변경 (요청에 없는 항목은 현재 값 유지)
+// PUT /api/v1/admin/market-maker/config
+func (h *MarketMakerHandler) UpdateConfig(c *gin.Context) {
+	config := h.bot.GetConfig()
+	if err := c.ShouldBindJSON(&config); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	if err := h.bot.UpdateConfig(config); err != nil {
+		if errors.Is(err, services.ErrInvalidMarketMakerConfig) {
+			middleware.BadRequest(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, h.bot.GetConfig(), "마켓메이커 설정을 변경했습니다")
+}
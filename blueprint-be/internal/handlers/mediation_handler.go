@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MediationHandler 분쟁 조정 핸들러
+type MediationHandler struct {
+	mediationService *services.MediationService
+}
+
+// NewMediationHandler 생성자
+func NewMediationHandler(mediationService *services.MediationService) *MediationHandler {
+	return &MediationHandler{mediationService: mediationService}
+}
+
+// ListMediations 내가 당사자인 조정 목록
+// GET /api/v1/mediations?status=open
+func (h *MediationHandler) ListMediations(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	mediations, err := h.mediationService.ListMediations(userID.(uint), models.MediationStatus(c.Query("status")))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"mediations": mediations,
+		"count":      len(mediations),
+	}, "조정 목록 조회 성공")
+}
+
+// GetMediation 조정 상세 (제안 이력 포함)
+// GET /api/v1/mediations/:id
+func (h *MediationHandler) GetMediation(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+	mediationID, ok := mediationIDParam(c)
+	if !ok {
+		return
+	}
+
+	mediation, err := h.mediationService.GetMediation(mediationID, userID.(uint))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	middleware.Success(c, mediation, "조정 조회 성공")
+}
+
+// MakeOffer 합의안 제안/역제안
+// POST /api/v1/mediations/:id/offers
+func (h *MediationHandler) MakeOffer(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+	mediationID, ok := mediationIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.MediationOfferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	offer, err := h.mediationService.MakeOffer(mediationID, userID.(uint), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	middleware.SuccessWithStatus(c, http.StatusCreated, offer, "조정 제안 완료")
+}
+
+// AcceptOffer 상대 제안 수락 (합의 성립)
+// POST /api/v1/mediations/:id/offers/:offer_id/accept
+func (h *MediationHandler) AcceptOffer(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+	mediationID, offerID, ok := mediationOfferParams(c)
+	if !ok {
+		return
+	}
+
+	mediation, err := h.mediationService.AcceptOffer(mediationID, offerID, userID.(uint))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	middleware.Success(c, mediation, "합의가 성립되었습니다")
+}
+
+// RejectOffer 상대 제안 거절
+// POST /api/v1/mediations/:id/offers/:offer_id/reject
+func (h *MediationHandler) RejectOffer(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+	mediationID, offerID, ok := mediationOfferParams(c)
+	if !ok {
+		return
+	}
+
+	offer, err := h.mediationService.RejectOffer(mediationID, offerID, userID.(uint))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	middleware.Success(c, offer, "제안을 거절했습니다")
+}
+
+// Escalate 결렬 선언 (배심원 중재 이관)
+// POST /api/v1/mediations/:id/escalate
+func (h *MediationHandler) Escalate(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+	mediationID, ok := mediationIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.EscalateMediationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			middleware.BadRequest(c, "Invalid request: "+err.Error())
+			return
+		}
+	}
+
+	mediation, err := h.mediationService.Escalate(mediationID, userID.(uint), req.Reason)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	middleware.Success(c, mediation, "배심원 중재로 이관되었습니다")
+}
+
+func (h *MediationHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrMediationNotFound),
+		errors.Is(err, services.ErrMediationOfferNotFound):
+		middleware.NotFound(c, err.Error())
+	case errors.Is(err, services.ErrMediationForbidden):
+		middleware.Forbidden(c, err.Error())
+	case errors.Is(err, services.ErrMediationClosed),
+		errors.Is(err, services.ErrMediationOfferNotPending):
+		middleware.Conflict(c, err.Error())
+	case errors.Is(err, services.ErrMediationOutcome),
+		errors.Is(err, services.ErrMediationOfferLimit),
+		errors.Is(err, services.ErrMediationOwnOffer),
+		errors.Is(err, services.ErrMediationInsufficientBalance):
+		middleware.BadRequest(c, err.Error())
+	default:
+		middleware.InternalServerError(c, err.Error())
+	}
+}
+
+func mediationIDParam(c *gin.Context) (uint, bool) {
+	mediationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid mediation ID")
+		return 0, false
+	}
+	return uint(mediationID), true
+}
+
+func mediationOfferParams(c *gin.Context) (uint, uint, bool) {
+	mediationID, ok := mediationIDParam(c)
+	if !ok {
+		return 0, 0, false
+	}
+	offerID, err := strconv.ParseUint(c.Param("offer_id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid offer ID")
+		return 0, 0, false
+	}
+	return mediationID, uint(offerID), true
+}
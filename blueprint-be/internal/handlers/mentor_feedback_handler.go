@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MentorFeedbackHandler 멘티 세션 평가 핸들러
+type MentorFeedbackHandler struct {
+	feedbackService *services.MentorFeedbackService
+}
+
+// NewMentorFeedbackHandler 생성자
+func NewMentorFeedbackHandler(feedbackService *services.MentorFeedbackService) *MentorFeedbackHandler {
+	return &MentorFeedbackHandler{
+		feedbackService: feedbackService,
+	}
+}
+
+// RateSession 멘토링 세션 평가 (1-5점 + 후기, 불참 신고 포함)
+// POST /api/v1/mentoring/sessions/:id/feedback
+func (h *MentorFeedbackHandler) RateSession(c *gin.Context) {
+	sessionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 세션 ID입니다"})
+		return
+	}
+
+	var req models.RateSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 요청 데이터입니다: " + err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "로그인이 필요합니다"})
+		return
+	}
+
+	feedback, err := h.feedbackService.RateSession(uint(sessionID), userID.(uint), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrMentoringSessionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrFeedbackForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrFeedbackExists):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "세션 평가가 등록되었습니다",
+		"feedback": feedback,
+	})
+}
+
+// GetMentorFeedback 멘토 평가 요약 및 목록
+// GET /api/v1/mentors/:id/feedback
+func (h *MentorFeedbackHandler) GetMentorFeedback(c *gin.Context) {
+	mentorID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 멘토 ID입니다"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	summary, err := h.feedbackService.GetMentorFeedback(uint(mentorID), limit, (page-1)*limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
@@ -95,6 +95,39 @@ func (h *MentorStakingHandler) UnstakeMentor(c *gin.Context) {
 	})
 }
 
+// UpdateAutoRenewal 스테이킹 자동 갱신 설정
+// PUT /api/v1/stakes/:id/auto-renewal
+func (h *MentorStakingHandler) UpdateAutoRenewal(c *gin.Context) {
+	stakeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 스테이킹 ID입니다"})
+		return
+	}
+
+	var req models.UpdateAutoRenewalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 요청 데이터입니다: " + err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "로그인이 필요합니다"})
+		return
+	}
+
+	stake, err := h.mentorStakingService.SetAutoRenewal(uint(stakeID), userID.(uint), req.IsAutoRenewal)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "자동 갱신 설정이 변경되었습니다",
+		"stake":   stake,
+	})
+}
+
 // ReportMentor 멘토 신고
 // POST /api/v1/mentors/:id/report
 func (h *MentorStakingHandler) ReportMentor(c *gin.Context) {
@@ -262,6 +295,27 @@ func (h *MentorStakingHandler) GetMentorDashboard(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// ClaimRewards 멘토 보상 청구 (청구 대기 보상 전체를 USDC 잔액으로 입금)
+// POST /api/v1/mentors/rewards/claim
+func (h *MentorStakingHandler) ClaimRewards(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "로그인이 필요합니다"})
+		return
+	}
+
+	response, err := h.mentorStakingService.ClaimRewards(userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": fmt.Sprintf("멘토 보상 %d건이 지급되었습니다", response.ClaimedCount),
+		"claim":   response,
+	})
+}
+
 // GetSlashEvents 슬래싱 이벤트 목록 조회
 // GET /api/v1/mentors/:id/slash-events
 func (h *MentorStakingHandler) GetSlashEvents(c *gin.Context) {
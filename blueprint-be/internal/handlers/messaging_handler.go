@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MessagingHandler 멘토링/분쟁 대화 핸들러
+type MessagingHandler struct {
+	messagingService *services.MessagingService
+}
+
+// NewMessagingHandler 생성자
+func NewMessagingHandler(messagingService *services.MessagingService) *MessagingHandler {
+	return &MessagingHandler{messagingService: messagingService}
+}
+
+// OpenMentorshipConversation 멘토링 세션 대화 열기 (멘토/멘티)
+// POST /api/v1/mentoring/sessions/:id/conversation
+func (h *MessagingHandler) OpenMentorshipConversation(c *gin.Context) {
+	h.open(c, models.ConversationMentorship)
+}
+
+// OpenArbitrationConversation 분쟁 사건 대화 열기 (신청인/피신청인)
+// POST /api/v1/arbitration/cases/:id/conversation
+func (h *MessagingHandler) OpenArbitrationConversation(c *gin.Context) {
+	h.open(c, models.ConversationArbitration)
+}
+
+func (h *MessagingHandler) open(c *gin.Context, scope models.ConversationScope) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+	scopeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid ID")
+		return
+	}
+
+	conversation, err := h.messagingService.OpenConversation(scope, uint(scopeID), userID.(uint))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	middleware.Success(c, conversation, "대화 열기 성공")
+}
+
+// ListConversations 내 대화 목록 (안 읽은 메시지 수 포함)
+// GET /api/v1/conversations
+func (h *MessagingHandler) ListConversations(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	conversations, err := h.messagingService.ListConversations(userID.(uint))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"conversations": conversations,
+		"count":         len(conversations),
+	}, "대화 목록 조회 성공")
+}
+
+// ListMessages 대화 메시지 (최신순)
+// GET /api/v1/conversations/:id/messages?before_id=120&limit=50
+func (h *MessagingHandler) ListMessages(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+	conversationID, ok := conversationIDParam(c)
+	if !ok {
+		return
+	}
+	beforeID, _ := strconv.ParseUint(c.Query("before_id"), 10, 32)
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+
+	messages, err := h.messagingService.ListMessages(conversationID, userID.(uint), uint(beforeID), limit)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"messages": messages,
+		"count":    len(messages),
+	}, "메시지 조회 성공")
+}
+
+// SendMessage 메시지 보내기
+// POST /api/v1/conversations/:id/messages
+func (h *MessagingHandler) SendMessage(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+	conversationID, ok := conversationIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.SendMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	message, err := h.messagingService.SendMessage(conversationID, userID.(uint), req.Body)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	middleware.Success(c, message, "메시지 전송 완료")
+}
+
+// MarkRead 읽음 표시
+// POST /api/v1/conversations/:id/read
+func (h *MessagingHandler) MarkRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+	conversationID, ok := conversationIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.MarkConversationReadRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			middleware.BadRequest(c, "Invalid request: "+err.Error())
+			return
+		}
+	}
+
+	participant, err := h.messagingService.MarkRead(conversationID, userID.(uint), req.MessageID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	middleware.Success(c, participant, "읽음 표시 완료")
+}
+
+// ReportMessage 메시지 신고 (운영자 검토)
+// POST /api/v1/messages/:id/report
+func (h *MessagingHandler) ReportMessage(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+	messageID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid message ID")
+		return
+	}
+
+	var req models.ReportMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	report, err := h.messagingService.ReportMessage(uint(messageID), userID.(uint), req.Reason)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	middleware.Success(c, report, "신고가 접수되었습니다")
+}
+
+// ListReports 메시지 신고 목록 (운영자)
+// GET /api/v1/admin/moderation/message-reports?status=open&limit=50&offset=0
+func (h *MessagingHandler) ListReports(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	reports, total, err := h.messagingService.ListReports(models.MessageReportStatus(c.Query("status")), limit, offset)
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"reports": reports,
+		"count":   len(reports),
+		"total":   total,
+	}, "메시지 신고 조회 성공")
+}
+
+// DismissReport 문제 없음으로 종결
+// POST /api/v1/admin/moderation/message-reports/:id/dismiss
+func (h *MessagingHandler) DismissReport(c *gin.Context) {
+	h.review(c, h.messagingService.DismissReport, "신고가 종결되었습니다")
+}
+
+// RemoveMessage 신고된 메시지 숨김
+// POST /api/v1/admin/moderation/message-reports/:id/remove
+func (h *MessagingHandler) RemoveMessage(c *gin.Context) {
+	h.review(c, h.messagingService.RemoveMessage, "메시지가 숨김 처리되었습니다")
+}
+
+func (h *MessagingHandler) review(c *gin.Context, action func(reportID, reviewerID uint, note string) (*models.MessageReport, error), message string) {
+	reviewerID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+	reportID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid report ID")
+		return
+	}
+
+	var req models.ReviewMessageReportRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			middleware.BadRequest(c, "Invalid request: "+err.Error())
+			return
+		}
+	}
+
+	report, err := action(uint(reportID), reviewerID.(uint), req.Note)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	middleware.Success(c, report, message)
+}
+
+func (h *MessagingHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrConversationNotFound),
+		errors.Is(err, services.ErrMessageNotFound),
+		errors.Is(err, services.ErrMessageReportNotFound),
+		errors.Is(err, services.ErrMentoringSessionNotFound),
+		errors.Is(err, services.ErrArbitrationCaseNotFound):
+		middleware.NotFound(c, err.Error())
+	case errors.Is(err, services.ErrConversationForbidden):
+		middleware.Forbidden(c, err.Error())
+	case errors.Is(err, services.ErrMessageAlreadyReported):
+		middleware.Conflict(c, err.Error())
+	case errors.Is(err, services.ErrConversationClosed),
+		errors.Is(err, services.ErrConversationScope),
+		errors.Is(err, services.ErrEmptyMessage),
+		errors.Is(err, services.ErrMessageReportOwn),
+		errors.Is(err, services.ErrMessageReportNotReviewed):
+		middleware.BadRequest(c, err.Error())
+	default:
+		middleware.InternalServerError(c, err.Error())
+	}
+}
+
+func conversationIDParam(c *gin.Context) (uint, bool) {
+	conversationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid conversation ID")
+		return 0, false
+	}
+	return uint(conversationID), true
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MilestoneAnalyticsHandler 마일스톤 마켓 투명성 지표 핸들러
+type MilestoneAnalyticsHandler struct {
+	analyticsService *services.MilestoneAnalyticsService
+}
+
+// NewMilestoneAnalyticsHandler 생성자
+func NewMilestoneAnalyticsHandler(analyticsService *services.MilestoneAnalyticsService) *MilestoneAnalyticsHandler {
+	return &MilestoneAnalyticsHandler{
+		analyticsService: analyticsService,
+	}
+}
+
+// GetMilestoneAnalytics 옵션별 보유자 수/집중도, 일별 거래량, 호가 잔량 추이
+// GET /api/v1/milestones/:id/analytics?days=30
+func (h *MilestoneAnalyticsHandler) GetMilestoneAnalytics(c *gin.Context) {
+	milestoneID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid milestone ID")
+		return
+	}
+	days, err := strconv.Atoi(c.DefaultQuery("days", strconv.Itoa(services.DefaultMilestoneAnalyticsDays)))
+	if err != nil {
+		middleware.BadRequest(c, services.ErrInvalidAnalyticsDays.Error())
+		return
+	}
+
+	analytics, err := h.analyticsService.GetAnalytics(uint(milestoneID), days)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidAnalyticsDays):
+			middleware.BadRequest(c, err.Error())
+		case errors.Is(err, services.ErrMilestoneNotFound):
+			middleware.NotFound(c, err.Error())
+		default:
+			middleware.InternalServerError(c, err.Error())
+		}
+		return
+	}
+
+	middleware.Success(c, analytics, "마일스톤 분석 조회 성공")
+}
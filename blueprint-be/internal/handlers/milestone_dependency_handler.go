@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MilestoneDependencyHandler 마일스톤 선후 관계 핸들러
+type MilestoneDependencyHandler struct {
+	dependencyService *services.MilestoneDependencyService
+}
+
+// NewMilestoneDependencyHandler 생성자
+func NewMilestoneDependencyHandler(dependencyService *services.MilestoneDependencyService) *MilestoneDependencyHandler {
+	return &MilestoneDependencyHandler{
+		dependencyService: dependencyService,
+	}
+}
+
+// GetDependencies 선행/후행 마일스톤과 펀딩 차단 여부
+// GET /api/v1/milestones/:id/dependencies
+func (h *MilestoneDependencyHandler) GetDependencies(c *gin.Context) {
+	userID, milestoneID, ok := milestoneDependencyParams(c)
+	if !ok {
+		return
+	}
+
+	dependencies, err := h.dependencyService.GetDependencies(milestoneID, userID)
+	if err != nil {
+		respondMilestoneDependencyError(c, err)
+		return
+	}
+
+	middleware.Success(c, dependencies, "마일스톤 선후 관계 조회 성공")
+}
+
+// SetDependencies 선행 마일스톤 목록 교체 (작성자/editor, 펀딩 시작 전)
+// PUT /api/v1/milestones/:id/dependencies
+func (h *MilestoneDependencyHandler) SetDependencies(c *gin.Context) {
+	userID, milestoneID, ok := milestoneDependencyParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.SetMilestoneDependenciesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, err.Error())
+		return
+	}
+
+	dependencies, err := h.dependencyService.SetDependencies(milestoneID, userID, req.DependsOn)
+	if err != nil {
+		respondMilestoneDependencyError(c, err)
+		return
+	}
+
+	middleware.Success(c, dependencies, "선행 마일스톤을 지정했습니다")
+}
+
+func milestoneDependencyParams(c *gin.Context) (uint, uint, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return 0, 0, false
+	}
+	milestoneID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid milestone ID")
+		return 0, 0, false
+	}
+	return userID.(uint), uint(milestoneID), true
+}
+
+func respondMilestoneDependencyError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrMilestoneNotFound), errors.Is(err, services.ErrProjectNotFound):
+		middleware.NotFound(c, err.Error())
+	case errors.Is(err, services.ErrProjectForbidden):
+		middleware.Forbidden(c, err.Error())
+	case errors.Is(err, services.ErrInvalidMilestoneDependency), errors.Is(err, services.ErrMilestoneDependencyCycle):
+		middleware.BadRequest(c, err.Error())
+	case errors.Is(err, services.ErrMilestoneDependencyStarted):
+		middleware.Conflict(c, err.Error())
+	default:
+		middleware.InternalServerError(c, err.Error())
+	}
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationHandler 알림 핸들러
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+	vapidPublicKey      string
+}
+
+// NewNotificationHandler 생성자
+func NewNotificationHandler(notificationService *services.NotificationService, vapidPublicKey string) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+		vapidPublicKey:      vapidPublicKey,
+	}
+}
+
+// GetNotifications 내 알림 목록 조회
+// GET /api/v1/notifications?unread=true&limit=20&offset=0
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	unreadOnly := c.Query("unread") == "true"
+
+	result, err := h.notificationService.GetUserNotifications(userID.(uint), unreadOnly, limit, offset)
+	if err != nil {
+		middleware.InternalServerError(c, "Failed to retrieve notifications")
+		return
+	}
+
+	middleware.Success(c, result, "Notifications retrieved successfully")
+}
+
+// GetUnreadCount 읽지 않은 알림 개수
+// GET /api/v1/notifications/unread-count
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	count, err := h.notificationService.GetUnreadCount(userID.(uint))
+	if err != nil {
+		middleware.InternalServerError(c, "Failed to count notifications")
+		return
+	}
+
+	middleware.Success(c, gin.H{"unread_count": count}, "Unread count retrieved successfully")
+}
+
+// MarkAsRead 알림 읽음 처리
+// POST /api/v1/notifications/:id/read
+func (h *NotificationHandler) MarkAsRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	notificationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid notification ID")
+		return
+	}
+
+	if err := h.notificationService.MarkAsRead(userID.(uint), uint(notificationID)); err != nil {
+		middleware.NotFound(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, nil, "Notification marked as read")
+}
+
+// MarkAllAsRead 모든 알림 읽음 처리
+// POST /api/v1/notifications/read-all
+func (h *NotificationHandler) MarkAllAsRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	updated, err := h.notificationService.MarkAllAsRead(userID.(uint))
+	if err != nil {
+		middleware.InternalServerError(c, "Failed to mark notifications as read")
+		return
+	}
+
+	middleware.Success(c, gin.H{"updated": updated}, "All notifications marked as read")
+}
+
+// GetVAPIDPublicKey Web Push 구독용 VAPID 공개키
+// GET /api/v1/push/vapid-public-key
+func (h *NotificationHandler) GetVAPIDPublicKey(c *gin.Context) {
+	if h.vapidPublicKey == "" {
+		middleware.NotFound(c, "Web push is not configured")
+		return
+	}
+
+	middleware.Success(c, gin.H{"public_key": h.vapidPublicKey}, "VAPID public key retrieved successfully")
+}
+
+// SubscribePush Web Push 구독 등록
+// POST /api/v1/users/me/push-subscriptions
+func (h *NotificationHandler) SubscribePush(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req models.SubscribePushRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid push subscription: "+err.Error())
+		return
+	}
+
+	subscription, err := h.notificationService.Subscribe(userID.(uint), &req, c.GetHeader("User-Agent"))
+	switch {
+	case errors.Is(err, services.ErrPushEndpointInvalid):
+		middleware.BadRequest(c, err.Error())
+		return
+	case errors.Is(err, services.ErrPushEndpointInUse):
+		middleware.Conflict(c, err.Error())
+		return
+	case err != nil:
+		middleware.InternalServerError(c, "Failed to save push subscription")
+		return
+	}
+
+	middleware.Success(c, subscription, "Push subscription saved")
+}
+
+// UnsubscribePush Web Push 구독 해제
+// DELETE /api/v1/users/me/push-subscriptions
+func (h *NotificationHandler) UnsubscribePush(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req models.UnsubscribePushRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Endpoint is required")
+		return
+	}
+
+	if err := h.notificationService.Unsubscribe(userID.(uint), req.Endpoint); err != nil {
+		middleware.NotFound(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, nil, "Push subscription removed")
+}
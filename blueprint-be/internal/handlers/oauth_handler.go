@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -11,21 +12,26 @@ import (
 	"blueprint-module/pkg/oauth"
 	"blueprint/internal/database"
 	"blueprint/internal/middleware"
+	"blueprint/internal/services"
 
 	"github.com/gin-gonic/gin"
 )
 
 // OAuthHandler OAuth 관련 핸들러
 type OAuthHandler struct {
-	oauthService *oauth.OAuthService
-	config       *config.Config
+	oauthService  *oauth.OAuthService
+	githubService *services.GitHubIntegrationService
+	linkService   *services.AccountLinkService
+	config        *config.Config
 }
 
 // NewOAuthHandler OAuth 핸들러 생성
-func NewOAuthHandler(cfg *config.Config) *OAuthHandler {
+func NewOAuthHandler(cfg *config.Config, githubService *services.GitHubIntegrationService, linkService *services.AccountLinkService) *OAuthHandler {
 	return &OAuthHandler{
-		oauthService: oauth.NewOAuthService(cfg.OAuth),
-		config:       cfg,
+		oauthService:  oauth.NewOAuthService(cfg.OAuth),
+		githubService: githubService,
+		linkService:   linkService,
+		config:        cfg,
 	}
 }
 
@@ -104,6 +110,13 @@ func (h *OAuthHandler) OAuthCallback(c *gin.Context) {
 	// 연결 액션 처리
 	if result.Action == "connect" {
 		err = h.handleSocialConnection(result)
+		if errors.Is(err, services.ErrIdentityLinkedElsewhere) {
+			// 이미 다른 계정에 연결된 소셜 계정 - 병합 흐름으로 안내
+			redirectURL := fmt.Sprintf("%s/settings?error=identity_in_use&provider=%s",
+				h.config.Server.FrontendURL, provider)
+			c.Redirect(http.StatusFound, redirectURL)
+			return
+		}
 		if err != nil {
 			redirectURL := fmt.Sprintf("%s/settings?error=connection_failed&provider=%s",
 				h.config.Server.FrontendURL, provider)
@@ -133,6 +146,16 @@ func (h *OAuthHandler) handleSocialConnection(result *oauth.CallbackResult) erro
 		return fmt.Errorf("user not found: %w", err)
 	}
 
+	// 로그인 수단으로 기록 (다른 계정이 이미 쓰고 있으면 연결 거부)
+	if _, err := h.linkService.LinkIdentity(result.UserID, models.UserIdentity{
+		Provider:       result.Provider,
+		ProviderUserID: result.Profile.ID,
+		Email:          result.Profile.Email,
+		DisplayName:    result.Profile.DisplayName,
+	}); err != nil {
+		return err
+	}
+
 	// 기존 연결 여부 확인
 	var verification models.UserVerification
 	err := db.Where("user_id = ?", result.UserID).First(&verification).Error
@@ -151,9 +174,14 @@ func (h *OAuthHandler) handleSocialConnection(result *oauth.CallbackResult) erro
 		verification.LinkedInProfileURL = &result.Profile.ProfileURL
 		verification.LinkedInVerifiedAt = &[]time.Time{time.Now()}[0]
 	case "github":
+		// 저장소 웹훅 연동을 위해 토큰 보관
+		connection, err := h.githubService.SaveConnection(result.UserID, result.Profile, result.TokenInfo)
+		if err != nil {
+			return err
+		}
 		verification.GitHubConnected = true
 		verification.GitHubProfileID = &result.Profile.ID
-		verification.GitHubUsername = &result.Profile.DisplayName
+		verification.GitHubUsername = &connection.Login
 		verification.GitHubVerifiedAt = &[]time.Time{time.Now()}[0]
 	case "twitter":
 		verification.TwitterConnected = true
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ParlayHandler 조합 베팅 핸들러
+type ParlayHandler struct {
+	parlayService *services.ParlayService
+	kycService    *services.KYCService
+}
+
+// NewParlayHandler 생성자
+func NewParlayHandler(parlayService *services.ParlayService, kycService *services.KYCService) *ParlayHandler {
+	return &ParlayHandler{
+		parlayService: parlayService,
+		kycService:    kycService,
+	}
+}
+
+// QuoteParlay 조합 가격 견적 (공개)
+// POST /api/v1/parlays/quote
+func (h *ParlayHandler) QuoteParlay(c *gin.Context) {
+	var req models.ParlayQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	quote, err := h.parlayService.Quote(req.Legs, req.Stake)
+	if err != nil {
+		middleware.BadRequest(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, quote, "조합 가격 조회 성공")
+}
+
+// CreateParlay 조합 포지션 생성
+// POST /api/v1/parlays
+func (h *ParlayHandler) CreateParlay(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req models.CreateParlayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	// 🪪 본인 인증 단계별 주문 한도 (원금 기준)
+	if err := h.kycService.CheckOrderLimit(userID.(uint), req.Stake); err != nil {
+		middleware.Forbidden(c, err.Error())
+		return
+	}
+
+	parlay, err := h.parlayService.PlaceParlay(userID.(uint), &req)
+	if err != nil {
+		if errors.Is(err, services.ErrParlayLimitExceeded) || errors.Is(err, services.ErrTradingRestricted) || errors.Is(err, services.ErrDailyLimitExceeded) {
+			middleware.Forbidden(c, err.Error())
+			return
+		}
+		if errors.Is(err, services.ErrParlayHouseNotConfigured) {
+			middleware.Error(c, http.StatusServiceUnavailable, err.Error(), "조합 베팅을 접수할 수 없습니다")
+			return
+		}
+		middleware.BadRequest(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, parlay, "조합 베팅이 접수되었습니다")
+}
+
+// QuoteRoadmapBundle 프로젝트 로드맵 묶음(남은 마일스톤 전체 성공) 가격 견적 (공개)
+// GET /api/v1/projects/:id/roadmap-bundle/quote?stake=
+func (h *ParlayHandler) QuoteRoadmapBundle(c *gin.Context) {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid project ID")
+		return
+	}
+	stake, err := strconv.ParseInt(c.DefaultQuery("stake", "0"), 10, 64)
+	if err != nil || stake < 0 {
+		middleware.BadRequest(c, "Invalid stake")
+		return
+	}
+
+	quote, err := h.parlayService.QuoteRoadmap(uint(projectID), stake)
+	if err != nil {
+		middleware.BadRequest(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, quote, "로드맵 묶음 가격 조회 성공")
+}
+
+// CreateRoadmapBundle 프로젝트 로드맵 묶음 포지션 생성
+// POST /api/v1/projects/:id/roadmap-bundle
+func (h *ParlayHandler) CreateRoadmapBundle(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid project ID")
+		return
+	}
+
+	var req models.CreateRoadmapBundleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	// 🪪 본인 인증 단계별 주문 한도 (원금 기준)
+	if err := h.kycService.CheckOrderLimit(userID.(uint), req.Stake); err != nil {
+		middleware.Forbidden(c, err.Error())
+		return
+	}
+
+	parlay, err := h.parlayService.PlaceRoadmapBundle(userID.(uint), uint(projectID), &req)
+	if err != nil {
+		if errors.Is(err, services.ErrParlayLimitExceeded) || errors.Is(err, services.ErrTradingRestricted) || errors.Is(err, services.ErrDailyLimitExceeded) {
+			middleware.Forbidden(c, err.Error())
+			return
+		}
+		if errors.Is(err, services.ErrParlayHouseNotConfigured) {
+			middleware.Error(c, http.StatusServiceUnavailable, err.Error(), "조합 베팅을 접수할 수 없습니다")
+			return
+		}
+		middleware.BadRequest(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, parlay, "로드맵 묶음이 접수되었습니다")
+}
+
+// GetMyParlays 내 조합 포지션 목록
+// GET /api/v1/parlays/my?status=open
+func (h *ParlayHandler) GetMyParlays(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	parlays, err := h.parlayService.GetUserParlays(userID.(uint), c.Query("status"))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"parlays": parlays,
+		"count":   len(parlays),
+	}, "조합 포지션 조회 성공")
+}
+
+// GetParlay 조합 포지션 상세
+// GET /api/v1/parlays/:id
+func (h *ParlayHandler) GetParlay(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	parlayID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid parlay ID")
+		return
+	}
+
+	parlay, err := h.parlayService.GetParlay(userID.(uint), uint(parlayID))
+	if err != nil {
+		middleware.NotFound(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, parlay, "조합 포지션 조회 성공")
+}
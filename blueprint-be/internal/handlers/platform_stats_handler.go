@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PlatformStatsHandler 공개 플랫폼 지표 핸들러
+type PlatformStatsHandler struct {
+	platformStatsService *services.PlatformStatsService
+}
+
+// NewPlatformStatsHandler 생성자
+func NewPlatformStatsHandler(platformStatsService *services.PlatformStatsService) *PlatformStatsHandler {
+	return &PlatformStatsHandler{
+		platformStatsService: platformStatsService,
+	}
+}
+
+// GetPlatformStats 플랫폼 전체 지표 (누적/24시간 거래량, 열린 마켓, 활동 트레이더, 확정 마일스톤, TVL, 분쟁 해결률)
+// GET /api/v1/stats/platform
+func (h *PlatformStatsHandler) GetPlatformStats(c *gin.Context) {
+	stats, err := h.platformStatsService.Get()
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, stats, "플랫폼 지표 조회 성공")
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"strconv"
+
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PortfolioHandler 포트폴리오 자산 곡선 핸들러
+type PortfolioHandler struct {
+	snapshotService *services.PortfolioSnapshotService
+}
+
+// NewPortfolioHandler 생성자
+func NewPortfolioHandler(snapshotService *services.PortfolioSnapshotService) *PortfolioHandler {
+	return &PortfolioHandler{
+		snapshotService: snapshotService,
+	}
+}
+
+// GetPortfolioHistory 일별 포트폴리오 평가액 (자산 곡선)
+// GET /api/v1/portfolio/history?days=30
+func (h *PortfolioHandler) GetPortfolioHistory(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days <= 0 {
+		middleware.BadRequest(c, "Invalid days")
+		return
+	}
+
+	history, err := h.snapshotService.GetHistory(userID.(uint), days)
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, history, "포트폴리오 히스토리 조회 성공")
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PositionTransferHandler 사용자 간 포지션 이전 핸들러
+type PositionTransferHandler struct {
+	transferService *services.PositionTransferService
+}
+
+// NewPositionTransferHandler 생성자
+func NewPositionTransferHandler(transferService *services.PositionTransferService) *PositionTransferHandler {
+	return &PositionTransferHandler{transferService: transferService}
+}
+
+// CreateTransfer 포지션 이전 요청 (수수료 잠금, 받는 사람 수락 대기)
+// POST /api/v1/positions/transfers
+func (h *PositionTransferHandler) CreateTransfer(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req models.CreatePositionTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	transfer, err := h.transferService.RequestTransfer(userID.(uint), req)
+	if err != nil {
+		respondTransferError(c, err)
+		return
+	}
+
+	middleware.Success(c, transfer, "포지션 이전을 요청했습니다")
+}
+
+// ListTransfers 내가 보내거나 받은 이전 요청
+// GET /api/v1/positions/transfers?direction=sent|received&status=pending&limit=50&offset=0
+func (h *PositionTransferHandler) ListTransfers(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	transfers, err := h.transferService.ListTransfers(userID.(uint), c.Query("direction"),
+		models.PositionTransferStatus(c.Query("status")), limit, offset)
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{"transfers": transfers, "count": len(transfers)}, "포지션 이전 목록 조회 성공")
+}
+
+// AcceptTransfer 받은 이전 요청 수락
+// POST /api/v1/positions/transfers/:id/accept
+func (h *PositionTransferHandler) AcceptTransfer(c *gin.Context) {
+	h.respond(c, h.transferService.AcceptTransfer, "포지션을 받았습니다")
+}
+
+// DeclineTransfer 받은 이전 요청 거절
+// POST /api/v1/positions/transfers/:id/decline
+func (h *PositionTransferHandler) DeclineTransfer(c *gin.Context) {
+	h.respond(c, h.transferService.DeclineTransfer, "포지션 이전을 거절했습니다")
+}
+
+// CancelTransfer 보낸 이전 요청 취소
+// POST /api/v1/positions/transfers/:id/cancel
+func (h *PositionTransferHandler) CancelTransfer(c *gin.Context) {
+	h.respond(c, h.transferService.CancelTransfer, "포지션 이전 요청을 취소했습니다")
+}
+
+func (h *PositionTransferHandler) respond(c *gin.Context, action func(transferID, userID uint) (*models.PositionTransfer, error), message string) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	transferID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid transfer ID")
+		return
+	}
+
+	transfer, err := action(uint(transferID), userID.(uint))
+	if err != nil {
+		respondTransferError(c, err)
+		return
+	}
+
+	middleware.Success(c, transfer, message)
+}
+
+func respondTransferError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrTransferNotFound),
+		errors.Is(err, services.ErrTransferRecipientNotFound):
+		middleware.NotFound(c, err.Error())
+	case errors.Is(err, services.ErrTransferToSelf),
+		errors.Is(err, services.ErrTransferQuantityUnavailable),
+		errors.Is(err, services.ErrTransferOppositePosition),
+		errors.Is(err, services.ErrInsufficientBalance),
+		errors.Is(err, services.ErrMarketFrozen),
+		errors.Is(err, services.ErrMarketClosed),
+		errors.Is(err, services.ErrUnknownOption):
+		middleware.BadRequest(c, err.Error())
+	default:
+		middleware.InternalServerError(c, err.Error())
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"blueprint-module/pkg/models"
 	"blueprint/internal/database"
 	"blueprint/internal/middleware"
+	"blueprint/internal/services"
 	"fmt"
 	"time"
 
@@ -12,11 +13,15 @@ import (
 )
 
 // ProfileHandler 프로필 관련 핸들러
-type ProfileHandler struct{}
+type ProfileHandler struct {
+	userStatsService *services.UserStatsService
+}
 
 // NewProfileHandler ProfileHandler 인스턴스 생성
-func NewProfileHandler() *ProfileHandler {
-	return &ProfileHandler{}
+func NewProfileHandler(userStatsService *services.UserStatsService) *ProfileHandler {
+	return &ProfileHandler{
+		userStatsService: userStatsService,
+	}
 }
 
 // ProfileStats 프로필 통계 데이터
@@ -27,6 +32,19 @@ type ProfileStats struct {
 	SbtCount             int     `json:"sbtCount"`             // SBT 개수
 }
 
+// TradingStats 거래/검증/멘토 지표 (주기 작업이 계산한 값, 투자 정보 공개를 켠 사용자만 노출)
+type TradingStats struct {
+	WinRate           float64 `json:"winRate"`           // 정산된 포지션 중 수익 비율 (0~1)
+	TotalVolume       int64   `json:"totalVolume"`       // 체결 금액 합계 (USDC cents)
+	TotalTrades       int     `json:"totalTrades"`       // 체결 건수
+	ResolvedMarkets   int     `json:"resolvedMarkets"`   // 참여한 결과 확정 마켓 수
+	MarketAccuracy    float64 `json:"marketAccuracy"`    // 확정 마켓 적중률 (0~1)
+	ValidatorVotes    int     `json:"validatorVotes"`    // 검증 참여 수
+	ValidatorAccuracy float64 `json:"validatorAccuracy"` // 검증 정확도 (0~1)
+	MentorRating      float64 `json:"mentorRating"`      // 멘토 평균 평점 (멘토가 아니면 0)
+	ComputedAt        string  `json:"computedAt"`        // 마지막 계산 시각 (RFC3339)
+}
+
 // CurrentProject 현재 진행 프로젝트
 type CurrentProject struct {
 	ID       uint   `json:"id"`
@@ -61,7 +79,9 @@ type ProfileResponse struct {
 	Bio              string            `json:"bio"`
 	Avatar           string            `json:"avatar"`
 	JoinedDate       string            `json:"joinedDate"`
+	TrustScore       int               `json:"trustScore"` // 검증 신호 기반 신뢰 점수 (0~100)
 	Stats            ProfileStats      `json:"stats"`
+	TradingStats     *TradingStats     `json:"tradingStats,omitempty"` // 투자 정보 공개(또는 본인)일 때만
 	CurrentProjects  []CurrentProject  `json:"currentProjects"`
 	FeaturedProjects []FeaturedProject `json:"featuredProjects"`
 	RecentActivities []RecentActivity  `json:"recentActivities"`
@@ -115,6 +135,7 @@ func (h *ProfileHandler) GetUserProfile(c *gin.Context) {
 		Bio:              "",
 		Avatar:           avatar,
 		JoinedDate:       user.CreatedAt.Format("2006-01-02"),
+		TrustScore:       user.TrustScore,
 		Stats:            stats,
 		CurrentProjects:  currentProjects,
 		FeaturedProjects: featuredProjects,
@@ -126,6 +147,12 @@ func (h *ProfileHandler) GetUserProfile(c *gin.Context) {
 		response.Bio = user.Profile.Bio
 	}
 
+	// 거래 지표는 투자 정보 공개를 켠 사용자(또는 본인 조회)만
+	viewerID, _ := c.Get("user_id")
+	if (user.Profile != nil && user.Profile.InvestmentPublic) || viewerID == user.ID {
+		response.TradingStats = h.getTradingStats(user.ID)
+	}
+
 	middleware.Success(c, response, "Profile retrieved successfully")
 }
 
@@ -162,6 +189,28 @@ func (h *ProfileHandler) calculateProfileStats(userID uint) ProfileStats {
 	}
 }
 
+// getTradingStats 주기 작업이 계산해 둔 거래 지표 (아직 없으면 nil)
+func (h *ProfileHandler) getTradingStats(userID uint) *TradingStats {
+	if h.userStatsService == nil {
+		return nil
+	}
+	stats, err := h.userStatsService.Get(userID)
+	if err != nil {
+		return nil
+	}
+	return &TradingStats{
+		WinRate:           stats.WinRate,
+		TotalVolume:       stats.TotalVolume,
+		TotalTrades:       stats.TotalTrades,
+		ResolvedMarkets:   stats.ResolvedMarkets,
+		MarketAccuracy:    stats.MarketAccuracy,
+		ValidatorVotes:    stats.ValidatorVotes,
+		ValidatorAccuracy: stats.ValidatorAccuracy,
+		MentorRating:      stats.MentorRating,
+		ComputedAt:        stats.ComputedAt.Format(time.RFC3339),
+	}
+}
+
 // getCurrentProjects 현재 진행 중인 프로젝트 조회
 func (h *ProfileHandler) getCurrentProjects(userID uint) []CurrentProject {
 	db := database.GetDB()
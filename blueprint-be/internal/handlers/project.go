@@ -7,9 +7,11 @@ import (
 	"blueprint-module/pkg/queue"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
 	internalModels "blueprint-module/pkg/models"
 	"blueprint/internal/database"
@@ -22,14 +24,18 @@ import (
 
 // ProjectHandler 프로젝트 관련 핸들러
 type ProjectHandler struct {
-	cfg       *config.Config
-	aiService services.AIServiceInterface
+	cfg               *config.Config
+	aiService         services.AIServiceInterface
+	memberService     *services.ProjectMemberService
+	dependencyService *services.MilestoneDependencyService
 }
 
-func NewProjectHandler(cfg *config.Config, aiService services.AIServiceInterface) *ProjectHandler {
+func NewProjectHandler(cfg *config.Config, aiService services.AIServiceInterface, memberService *services.ProjectMemberService, dependencyService *services.MilestoneDependencyService) *ProjectHandler {
 	return &ProjectHandler{
-		cfg:       cfg,
-		aiService: aiService,
+		cfg:               cfg,
+		aiService:         aiService,
+		memberService:     memberService,
+		dependencyService: dependencyService,
 	}
 }
 
@@ -53,6 +59,52 @@ func (h *ProjectHandler) CreateProjectWithMilestones(c *gin.Context) {
 		return
 	}
 
+	// 카테고리/태그 검증 (태그는 정규화해서 저장)
+	tags, err := validateProjectTaxonomy(req.Category, req.Tags)
+	if err != nil {
+		middleware.BadRequest(c, err.Error())
+		return
+	}
+
+	// 다중 결과/수치 마켓 옵션과 초기 확률(합계 1) 검증
+	probabilityValidator := services.NewProbabilityValidator()
+	initialPrices := make([]map[string]float64, len(req.Milestones))
+	for i, milestoneReq := range req.Milestones {
+		if milestoneReq.Scalar != nil {
+			if len(milestoneReq.Outcomes) > 0 {
+				middleware.BadRequest(c, "outcomes와 scalar는 함께 지정할 수 없습니다")
+				return
+			}
+			if err := milestoneReq.Scalar.Validate(); err != nil {
+				middleware.BadRequest(c, err.Error())
+				return
+			}
+			prices, err := probabilityValidator.InitialScalarPrices(scalarMilestone(milestoneReq.Scalar), milestoneReq.Scalar.InitialValue)
+			if err != nil {
+				middleware.BadRequest(c, "초기 확률이 올바르지 않습니다: "+err.Error())
+				return
+			}
+			initialPrices[i] = prices
+			continue
+		}
+		if len(milestoneReq.Outcomes) == 0 {
+			continue
+		}
+		if err := models.ValidateOutcomes(milestoneReq.Outcomes); err != nil {
+			middleware.BadRequest(c, err.Error())
+			return
+		}
+		prices, err := probabilityValidator.InitialOutcomePrices(&models.Milestone{
+			MarketType:    models.MarketTypeCategorical,
+			OutcomesArray: milestoneReq.Outcomes,
+		})
+		if err != nil {
+			middleware.BadRequest(c, "초기 확률이 올바르지 않습니다: "+err.Error())
+			return
+		}
+		initialPrices[i] = prices
+	}
+
 	// 트랜잭션으로 처리
 	tx := database.GetDB().Begin()
 	defer func() {
@@ -63,8 +115,8 @@ func (h *ProjectHandler) CreateProjectWithMilestones(c *gin.Context) {
 
 	// Tags JSON 변환
 	tagsJSON := ""
-	if len(req.Tags) > 0 {
-		if tagsBytes, err := json.Marshal(req.Tags); err == nil {
+	if len(tags) > 0 {
+		if tagsBytes, err := json.Marshal(tags); err == nil {
 			tagsJSON = string(tagsBytes)
 		}
 	}
@@ -89,10 +141,16 @@ func (h *ProjectHandler) CreateProjectWithMilestones(c *gin.Context) {
 		middleware.InternalServerError(c, "프로젝트 생성에 실패했습니다")
 		return
 	}
+	if err := services.SyncProjectTags(tx, project.ID, tags); err != nil {
+		tx.Rollback()
+		middleware.InternalServerError(c, "프로젝트 태그 저장에 실패했습니다")
+		return
+	}
 
 	// 마일스톤들 생성
 	var milestones []models.Milestone
-	for _, milestoneReq := range req.Milestones {
+	marketPrices := make(map[uint]map[string]float64)
+	for i, milestoneReq := range req.Milestones {
 
 		// 🔍 인증 관련 필드 기본값 설정
 		requiresProof := true
@@ -136,6 +194,17 @@ func (h *ProjectHandler) CreateProjectWithMilestones(c *gin.Context) {
 			MinValidators:            minValidators,
 			MinApprovalRate:          minApprovalRate,
 			VerificationDeadlineDays: verificationDeadlineDays,
+
+			MarketType: models.MarketTypeBinary,
+		}
+		if len(milestoneReq.Outcomes) > 0 {
+			milestone.MarketType = models.MarketTypeCategorical
+			milestone.OutcomesArray = milestoneReq.Outcomes // BeforeSave에서 JSON으로 변환됨
+		}
+		if milestoneReq.Scalar != nil {
+			scalar := scalarMilestone(milestoneReq.Scalar)
+			milestone.MarketType = scalar.MarketType
+			milestone.ScalarLower, milestone.ScalarUpper, milestone.ScalarUnit = scalar.ScalarLower, scalar.ScalarUpper, scalar.ScalarUnit
 		}
 
 		if err := tx.Create(&milestone).Error; err != nil {
@@ -143,6 +212,7 @@ func (h *ProjectHandler) CreateProjectWithMilestones(c *gin.Context) {
 			middleware.InternalServerError(c, "마일스톤 생성에 실패했습니다")
 			return
 		}
+		marketPrices[milestone.ID] = initialPrices[i]
 
 		milestones = append(milestones, milestone)
 	}
@@ -156,16 +226,17 @@ func (h *ProjectHandler) CreateProjectWithMilestones(c *gin.Context) {
 	// 각 마일스톤에 대한 마켓 초기화 🎯
 	publisher := queue.NewPublisher()
 	for _, milestone := range milestones {
-		// 🚀 마켓 초기화 이벤트를 큐에 발행 (항상 성공/실패 두 옵션)
+		// 🚀 마켓 초기화 이벤트를 큐에 발행 (결과 옵션마다 주문장, 이진 마켓은 success/fail)
 		err := publisher.EnqueueMarketInit(queue.MarketInitEventData{
-			ProjectID:   project.ID,
-			MilestoneID: milestone.ID,
-			Options:     []string{"success", "fail"}, // 고정된 두 옵션
+			ProjectID:     project.ID,
+			MilestoneID:   milestone.ID,
+			Options:       milestone.OptionIDs(),
+			InitialPrices: marketPrices[milestone.ID],
 		})
 		if err != nil {
 			log.Printf("❌ Failed to enqueue market init for milestone %d: %v", milestone.ID, err)
 		} else {
-			log.Printf("✅ Market init queued for milestone %d with success/fail options", milestone.ID)
+			log.Printf("✅ Market init queued for milestone %d with options %v", milestone.ID, milestone.OptionIDs())
 		}
 	}
 
@@ -184,9 +255,9 @@ func (h *ProjectHandler) CreateProjectWithMilestones(c *gin.Context) {
 	middleware.SuccessWithStatus(c, 201, project, "프로젝트와 마일스톤이 성공적으로 등록되었습니다! 투자 시장도 열렸어요! 🎯✨")
 }
 
-// GetProjects 목표 목록 조회 (카테고리별 필터링, 페이지네이션 지원)
+// GetProjects 목표 목록 조회 (카테고리/태그/진행 단계/펀딩 현황 필터링, 페이지네이션 지원)
 func (h *ProjectHandler) GetProjects(c *gin.Context) {
-	_, exists := c.Get("user_id")
+	userID, exists := c.Get("user_id")
 	if !exists {
 		middleware.Unauthorized(c, "User not authenticated")
 		return
@@ -195,7 +266,7 @@ func (h *ProjectHandler) GetProjects(c *gin.Context) {
 	// 쿼리 파라미터 파싱
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	category := c.Query("category")
+	taxonomy := projectTaxonomyFilter(c)
 	status := c.Query("status")
 	sortBy := c.DefaultQuery("sort", "created_at")
 	order := c.DefaultQuery("order", "desc")
@@ -209,15 +280,18 @@ func (h *ProjectHandler) GetProjects(c *gin.Context) {
 
 	offset := (page - 1) * limit
 
-	// 쿼리 빌드 (모든 공개 프로젝트 조회 - 투자 기능을 위해)
-	query := database.GetDB().Model(&models.Project{})
-
-	if category != "" {
-		query = query.Where("category = ?", category)
+	if err := taxonomy.Normalize(); err != nil {
+		middleware.BadRequest(c, err.Error())
+		return
 	}
 
+	// 쿼리 빌드 (공개 프로젝트 + 내가 작성했거나 팀원인 비공개 프로젝트)
+	query := taxonomy.Apply(database.GetDB().Model(&models.Project{})).
+		Where("projects.is_public = ? OR projects.user_id = ? OR projects.id IN (?)",
+			true, userID, h.memberService.MemberProjectIDs(userID.(uint)))
+
 	if status != "" {
-		query = query.Where("status = ?", status)
+		query = query.Where("projects.status = ?", status)
 	}
 
 	// 정렬
@@ -262,9 +336,17 @@ func (h *ProjectHandler) GetProjects(c *gin.Context) {
 	middleware.Success(c, result, "Projects retrieved successfully")
 }
 
+// validateProjectTaxonomy 카테고리(지정된 경우) 검증 및 태그 정규화
+func validateProjectTaxonomy(category models.ProjectCategory, tags []string) ([]string, error) {
+	if category != "" && !category.IsValid() {
+		return nil, fmt.Errorf("%w: %s", services.ErrInvalidProjectCategory, category)
+	}
+	return models.NormalizeProjectTags(tags)
+}
+
 // GetProject 단일 목표 조회
 func (h *ProjectHandler) GetProject(c *gin.Context) {
-	_, exists := c.Get("user_id")
+	userID, exists := c.Get("user_id")
 	if !exists {
 		middleware.Unauthorized(c, "User not authenticated")
 		return
@@ -291,6 +373,17 @@ func (h *ProjectHandler) GetProject(c *gin.Context) {
 		return
 	}
 
+	// 비공개 프로젝트는 작성자와 팀원만 열람
+	canView, err := h.memberService.CanView(&project, userID.(uint))
+	if err != nil {
+		middleware.InternalServerError(c, "Failed to fetch project")
+		return
+	}
+	if !canView {
+		middleware.NotFound(c, "Project not found")
+		return
+	}
+
 	middleware.Success(c, project, "Project retrieved successfully")
 }
 
@@ -314,18 +407,16 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 		return
 	}
 
-	// 기존 목표 조회
-	var project models.Project
-	err := database.GetDB().
-		Where("id = ? AND user_id = ?", projectID, userID).
-		First(&project).Error
+	// 기존 목표 조회 (작성자/editor만 수정 가능)
+	project, ok := h.authorizeProject(c, projectID, userID.(uint), models.ProjectRole.CanEdit)
+	if !ok {
+		return
+	}
 
+	// 카테고리/태그 검증 (태그는 정규화해서 저장)
+	tags, err := validateProjectTaxonomy(req.Category, req.Tags)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			middleware.NotFound(c, "Project not found")
-			return
-		}
-		middleware.InternalServerError(c, "Failed to fetch project")
+		middleware.BadRequest(c, err.Error())
 		return
 	}
 
@@ -357,7 +448,7 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 
 	// Tags 처리
 	if len(req.Tags) > 0 {
-		if tagsBytes, err := json.Marshal(req.Tags); err == nil {
+		if tagsBytes, err := json.Marshal(tags); err == nil {
 			updates["tags"] = string(tagsBytes)
 		}
 	}
@@ -367,13 +458,21 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 	}
 
 	// 업데이트 실행
-	if err := database.GetDB().Model(&project).Updates(updates).Error; err != nil {
+	if err := database.GetDB().Model(project).Updates(updates).Error; err != nil {
 		middleware.InternalServerError(c, "Failed to update project")
 		return
 	}
+	if len(req.Tags) > 0 {
+		if err := services.SyncProjectTags(database.GetDB(), project.ID, tags); err != nil {
+			middleware.InternalServerError(c, "Failed to update project tags")
+			return
+		}
+	}
+
+	services.InvalidatePublicProject(project.ID)
 
 	// 업데이트된 목표 다시 조회
-	database.GetDB().Where("id = ?", projectID).First(&project)
+	database.GetDB().Where("id = ?", projectID).First(project)
 
 	middleware.Success(c, project, "Project updated successfully")
 }
@@ -398,6 +497,11 @@ func (h *ProjectHandler) UpdateProjectWithMilestones(c *gin.Context) {
 		return
 	}
 
+	// 작성자/editor만 수정 가능
+	if _, ok := h.authorizeProject(c, projectID, userID.(uint), models.ProjectRole.CanEdit); !ok {
+		return
+	}
+
 	// 트랜잭션으로 처리
 	tx := database.GetDB().Begin()
 	defer func() {
@@ -408,7 +512,7 @@ func (h *ProjectHandler) UpdateProjectWithMilestones(c *gin.Context) {
 
 	// 기존 프로젝트 조회
 	var project models.Project
-	err := tx.Where("id = ? AND user_id = ?", projectID, userID).First(&project).Error
+	err := tx.Where("id = ?", projectID).First(&project).Error
 	if err != nil {
 		tx.Rollback()
 		if err == gorm.ErrRecordNotFound {
@@ -419,6 +523,14 @@ func (h *ProjectHandler) UpdateProjectWithMilestones(c *gin.Context) {
 		return
 	}
 
+	// 카테고리/태그 검증 (태그는 정규화해서 저장)
+	tags, err := validateProjectTaxonomy(req.Category, req.Tags)
+	if err != nil {
+		tx.Rollback()
+		middleware.BadRequest(c, err.Error())
+		return
+	}
+
 	// 프로젝트 정보 업데이트
 	updates := map[string]interface{}{}
 	if req.Title != "" {
@@ -446,7 +558,7 @@ func (h *ProjectHandler) UpdateProjectWithMilestones(c *gin.Context) {
 
 	// Tags 처리
 	if len(req.Tags) > 0 {
-		if tagsBytes, err := json.Marshal(req.Tags); err == nil {
+		if tagsBytes, err := json.Marshal(tags); err == nil {
 			updates["tags"] = string(tagsBytes)
 		}
 	}
@@ -461,6 +573,13 @@ func (h *ProjectHandler) UpdateProjectWithMilestones(c *gin.Context) {
 		middleware.InternalServerError(c, "Failed to update project")
 		return
 	}
+	if len(req.Tags) > 0 {
+		if err := services.SyncProjectTags(tx, project.ID, tags); err != nil {
+			tx.Rollback()
+			middleware.InternalServerError(c, "Failed to update project tags")
+			return
+		}
+	}
 
 	// 마일스톤들 업데이트
 	for _, milestoneReq := range req.Milestones {
@@ -593,6 +712,12 @@ func (h *ProjectHandler) UpdateProjectWithMilestones(c *gin.Context) {
 		middleware.InternalServerError(c, "Failed to save changes")
 		return
 	}
+	services.InvalidatePublicProject(project.ID)
+
+	// 선행 마일스톤 목표일이 늦춰졌으면 후행 마일스톤 목표일도 조정
+	if _, err := h.dependencyService.RescheduleProject(project.ID); err != nil {
+		log.Printf("⚠️ Failed to reschedule milestones of project %d: %v", project.ID, err)
+	}
 
 	// 업데이트된 프로젝트와 마일스톤들을 함께 반환
 	database.GetDB().Where("id = ?", projectID).Preload("Milestones").First(&project)
@@ -614,26 +739,18 @@ func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 		return
 	}
 
-	// 목표 존재 확인
-	var project models.Project
-	err := database.GetDB().
-		Where("id = ? AND user_id = ?", projectID, userID).
-		First(&project).Error
-
-	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			middleware.NotFound(c, "Project not found")
-			return
-		}
-		middleware.InternalServerError(c, "Failed to fetch project")
+	// 목표 존재 확인 (작성자만 삭제 가능)
+	project, ok := h.authorizeProject(c, projectID, userID.(uint), models.ProjectRole.CanManage)
+	if !ok {
 		return
 	}
 
 	// 소프트 삭제
-	if err := database.GetDB().Delete(&project).Error; err != nil {
+	if err := database.GetDB().Delete(project).Error; err != nil {
 		middleware.InternalServerError(c, "Failed to delete project")
 		return
 	}
+	services.InvalidatePublicProject(project.ID)
 
 	middleware.Success(c, nil, "Project deleted successfully")
 }
@@ -661,25 +778,44 @@ func (h *ProjectHandler) UpdateProjectStatus(c *gin.Context) {
 		return
 	}
 
-	// 목표 존재 확인 및 상태 업데이트
-	result := database.GetDB().
-		Model(&models.Project{}).
-		Where("id = ? AND user_id = ?", projectID, userID).
-		Update("status", req.Status)
-
-	if result.Error != nil {
-		middleware.InternalServerError(c, "Failed to update project status")
+	// 목표 존재 확인 (작성자/editor만 변경 가능) 및 상태 업데이트
+	project, ok := h.authorizeProject(c, projectID, userID.(uint), models.ProjectRole.CanEdit)
+	if !ok {
 		return
 	}
 
-	if result.RowsAffected == 0 {
-		middleware.NotFound(c, "Project not found")
+	if err := database.GetDB().Model(project).Update("status", req.Status).Error; err != nil {
+		middleware.InternalServerError(c, "Failed to update project status")
 		return
 	}
+	services.InvalidatePublicProject(project.ID)
 
 	middleware.Success(c, gin.H{"status": req.Status}, "Project status updated successfully")
 }
 
+// authorizeProject 팀 역할 권한 확인 - 실패 시 응답을 쓰고 false 반환
+func (h *ProjectHandler) authorizeProject(c *gin.Context, projectID string, userID uint, allowed func(models.ProjectRole) bool) (*models.Project, bool) {
+	id, err := strconv.ParseUint(projectID, 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid project ID")
+		return nil, false
+	}
+
+	project, err := h.memberService.Authorize(uint(id), userID, allowed)
+	switch {
+	case errors.Is(err, services.ErrProjectNotFound):
+		middleware.NotFound(c, "Project not found")
+		return nil, false
+	case errors.Is(err, services.ErrProjectForbidden):
+		middleware.Forbidden(c, err.Error())
+		return nil, false
+	case err != nil:
+		middleware.InternalServerError(c, "Failed to fetch project")
+		return nil, false
+	}
+	return project, true
+}
+
 // GetProjectCategories 꿈 카테고리 목록 조회 ✨
 func (h *ProjectHandler) GetProjectCategories(c *gin.Context) {
 	categories := []gin.H{
@@ -707,6 +843,7 @@ func (h *ProjectHandler) GetProjectStatuses(c *gin.Context) {
 }
 
 // GenerateAIMilestones AI를 사용해서 마일스톤을 제안합니다 🤖
+// POST /api/v1/ai/milestones (?stream=true 또는 Accept: text/event-stream이면 SSE로 스트리밍)
 func (h *ProjectHandler) GenerateAIMilestones(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -726,61 +863,94 @@ func (h *ProjectHandler) GenerateAIMilestones(c *gin.Context) {
 		return
 	}
 
-	// AI 사용 횟수 제한 체크 🚫
-	canUse, remaining, err := h.aiService.CheckAIUsageLimit(userID.(uint))
+	// AI 사용 횟수 제한 체크 🚫 (실제 차감은 생성 시 원자적으로 처리)
+	canUse, _, err := h.aiService.CheckAIUsageLimit(userID.(uint))
 	if err != nil {
 		middleware.InternalServerError(c, "사용자 정보 확인에 실패했습니다")
 		return
 	}
 
 	if !canUse {
-		middleware.BadRequest(c, "AI 사용 횟수를 초과했습니다 (최대 5회)")
+		middleware.BadRequest(c, services.ErrAIQuotaExceeded.Error())
 		return
 	}
 
 	// 모듈 models를 내부 models로 변환
-	convertToInternalRequest := func(req models.CreateProjectWithMilestonesRequest) internalModels.CreateProjectRequest {
-		return internalModels.CreateProjectRequest{
-			Title:       req.Title,
-			Description: req.Description,
-			Category:    internalModels.ProjectCategory(req.Category),
-			TargetDate:  req.TargetDate,
-			Budget:      req.Budget,
-			Priority:    req.Priority,
-			IsPublic:    req.IsPublic,
-			Tags:        req.Tags,
-			Metrics:     req.Metrics,
-		}
+	project := internalModels.CreateProjectRequest{
+		Title:       req.Title,
+		Description: req.Description,
+		Category:    internalModels.ProjectCategory(req.Category),
+		TargetDate:  req.TargetDate,
+		Budget:      req.Budget,
+		Priority:    req.Priority,
+		IsPublic:    req.IsPublic,
+		Tags:        req.Tags,
+		Metrics:     req.Metrics,
+	}
+
+	if c.Query("stream") == "true" || c.GetHeader("Accept") == "text/event-stream" {
+		h.streamAIMilestones(c, userID.(uint), project)
+		return
 	}
 
 	// AI 마일스톤 생성
-	aiResponse, err := h.aiService.GenerateMilestones(convertToInternalRequest(req))
+	aiResponse, err := h.aiService.GenerateMilestones(userID.(uint), project)
 	if err != nil {
+		if errors.Is(err, services.ErrAIQuotaExceeded) {
+			middleware.BadRequest(c, err.Error())
+			return
+		}
 		middleware.InternalServerError(c, "AI 마일스톤 생성에 실패했습니다: "+err.Error())
 		return
 	}
 
-	// 사용 횟수 업데이트 📈
-	if err := h.aiService.IncrementAIUsage(userID.(uint)); err != nil {
-		// 로그만 남기고 응답은 정상적으로 반환 (이미 AI 호출은 성공)
-		middleware.InternalServerError(c, "AI 사용 횟수 업데이트에 실패했습니다")
+	middleware.Success(c, h.aiMilestonePayload(userID.(uint), aiResponse), "🤖 AI 마일스톤 제안이 완성되었습니다!")
+}
+
+// streamAIMilestones 생성 중인 텍스트를 SSE로 전달 (delta → done | error)
+func (h *ProjectHandler) streamAIMilestones(c *gin.Context, userID uint, project internalModels.CreateProjectRequest) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	aiResponse, err := h.aiService.StreamMilestones(c.Request.Context(), userID, project, func(chunk string) {
+		c.SSEvent("delta", gin.H{"text": chunk})
+		c.Writer.Flush()
+	})
+	if err != nil {
+		c.SSEvent("error", gin.H{"message": err.Error()})
+		c.Writer.Flush()
 		return
 	}
 
-	middleware.Success(c, gin.H{
+	c.SSEvent("done", h.aiMilestonePayload(userID, aiResponse))
+	c.Writer.Flush()
+}
+
+// aiMilestonePayload 마일스톤 제안 응답 본문
+func (h *ProjectHandler) aiMilestonePayload(userID uint, aiResponse *services.AIMilestoneResponse) gin.H {
+	usage := gin.H{}
+	if info, err := h.aiService.GetAIUsageInfo(userID); err == nil {
+		usage = gin.H{
+			"remaining": info.Remaining,
+			"total":     info.Limit,
+		}
+	}
+
+	return gin.H{
 		"milestones": aiResponse.Milestones,
 		"tips":       aiResponse.Tips,
 		"warnings":   aiResponse.Warnings,
-		"usage": gin.H{
-			"remaining": remaining - 1, // 방금 사용했으므로 -1
-			"total":     5,
-		},
+		"usage":      usage,
 		"meta": gin.H{
-			"model":        "GPT-4o-mini",
-			"generated_at": "now",
+			"provider":     aiResponse.Provider,
+			"model":        aiResponse.Model,
+			"cached":       aiResponse.Cached,
+			"generated_at": time.Now().Format(time.RFC3339),
 			"user_id":      userID,
 		},
-	}, "🤖 AI 마일스톤 제안이 완성되었습니다!")
+	}
 }
 
 // GetAIUsageInfo 사용자의 AI 사용 정보를 반환합니다 📊
@@ -799,3 +969,14 @@ func (h *ProjectHandler) GetAIUsageInfo(c *gin.Context) {
 
 	middleware.Success(c, usageInfo, "AI 사용 정보를 성공적으로 가져왔습니다")
 }
+
+// scalarMilestone 수치 마켓 범위 요청을 마일스톤 필드로 변환
+func scalarMilestone(r *models.ScalarRange) *models.Milestone {
+	lower, upper := r.Lower, r.Upper
+	return &models.Milestone{
+		MarketType:  models.MarketTypeScalar,
+		ScalarLower: &lower,
+		ScalarUpper: &upper,
+		ScalarUnit:  r.Unit,
+	}
+}
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProjectMemberHandler 프로젝트 팀원/초대 핸들러
+type ProjectMemberHandler struct {
+	memberService *services.ProjectMemberService
+}
+
+// NewProjectMemberHandler 생성자
+func NewProjectMemberHandler(memberService *services.ProjectMemberService) *ProjectMemberHandler {
+	return &ProjectMemberHandler{
+		memberService: memberService,
+	}
+}
+
+// ListMembers 작성자와 팀원 목록
+// GET /api/v1/projects/:id/members
+func (h *ProjectMemberHandler) ListMembers(c *gin.Context) {
+	userID, projectID, ok := projectMemberParams(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.memberService.ListMembers(projectID, userID)
+	if err != nil {
+		respondProjectMemberError(c, err)
+		return
+	}
+
+	middleware.Success(c, gin.H{"members": members}, "팀원 목록 조회 성공")
+}
+
+// InviteMember 이메일로 팀원 초대 (작성자만)
+// POST /api/v1/projects/:id/invites
+func (h *ProjectMemberHandler) InviteMember(c *gin.Context) {
+	userID, projectID, ok := projectMemberParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.InviteProjectMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, err.Error())
+		return
+	}
+
+	invite, err := h.memberService.Invite(projectID, userID, req.Email, req.Role)
+	if err != nil {
+		respondProjectMemberError(c, err)
+		return
+	}
+
+	middleware.SuccessWithStatus(c, 201, invite, "팀원 초대를 보냈습니다")
+}
+
+// ListInvites 프로젝트의 대기 중 초대 목록 (작성자만)
+// GET /api/v1/projects/:id/invites
+func (h *ProjectMemberHandler) ListInvites(c *gin.Context) {
+	userID, projectID, ok := projectMemberParams(c)
+	if !ok {
+		return
+	}
+
+	invites, err := h.memberService.ListInvites(projectID, userID)
+	if err != nil {
+		respondProjectMemberError(c, err)
+		return
+	}
+
+	middleware.Success(c, gin.H{"invites": invites}, "초대 목록 조회 성공")
+}
+
+// RevokeInvite 초대 취소 (작성자만)
+// DELETE /api/v1/projects/:id/invites/:invite_id
+func (h *ProjectMemberHandler) RevokeInvite(c *gin.Context) {
+	userID, projectID, ok := projectMemberParams(c)
+	if !ok {
+		return
+	}
+	inviteID, err := strconv.ParseUint(c.Param("invite_id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid invite ID")
+		return
+	}
+
+	if err := h.memberService.RevokeInvite(projectID, uint(inviteID), userID); err != nil {
+		respondProjectMemberError(c, err)
+		return
+	}
+
+	middleware.Success(c, nil, "초대를 취소했습니다")
+}
+
+// UpdateMemberRole 팀원 역할 변경 (작성자만)
+// PATCH /api/v1/projects/:id/members/:user_id
+func (h *ProjectMemberHandler) UpdateMemberRole(c *gin.Context) {
+	userID, projectID, ok := projectMemberParams(c)
+	if !ok {
+		return
+	}
+	memberUserID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	var req models.UpdateProjectMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, err.Error())
+		return
+	}
+
+	member, err := h.memberService.UpdateRole(projectID, userID, uint(memberUserID), req.Role)
+	if err != nil {
+		respondProjectMemberError(c, err)
+		return
+	}
+
+	middleware.Success(c, member, "팀원 역할을 변경했습니다")
+}
+
+// RemoveMember 팀원 제거 (작성자) 또는 팀 탈퇴 (본인)
+// DELETE /api/v1/projects/:id/members/:user_id
+func (h *ProjectMemberHandler) RemoveMember(c *gin.Context) {
+	userID, projectID, ok := projectMemberParams(c)
+	if !ok {
+		return
+	}
+	memberUserID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	if err := h.memberService.RemoveMember(projectID, userID, uint(memberUserID)); err != nil {
+		respondProjectMemberError(c, err)
+		return
+	}
+
+	middleware.Success(c, nil, "팀원을 제거했습니다")
+}
+
+// GetMyProjectInvites 내게 온 대기 중 초대
+// GET /api/v1/users/me/project-invites
+func (h *ProjectMemberHandler) GetMyProjectInvites(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	invites, err := h.memberService.MyInvites(userID.(uint))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{"invites": invites}, "받은 초대 목록 조회 성공")
+}
+
+// AcceptProjectInvite 초대 수락
+// POST /api/v1/project-invites/:id/accept
+func (h *ProjectMemberHandler) AcceptProjectInvite(c *gin.Context) {
+	userID, inviteID, ok := projectMemberParams(c)
+	if !ok {
+		return
+	}
+
+	member, err := h.memberService.AcceptInvite(inviteID, userID)
+	if err != nil {
+		respondProjectMemberError(c, err)
+		return
+	}
+
+	middleware.Success(c, member, "프로젝트 팀에 합류했습니다")
+}
+
+// DeclineProjectInvite 초대 거절
+// POST /api/v1/project-invites/:id/decline
+func (h *ProjectMemberHandler) DeclineProjectInvite(c *gin.Context) {
+	userID, inviteID, ok := projectMemberParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.memberService.DeclineInvite(inviteID, userID); err != nil {
+		respondProjectMemberError(c, err)
+		return
+	}
+
+	middleware.Success(c, nil, "초대를 거절했습니다")
+}
+
+// projectMemberParams 로그인 사용자와 :id 파라미터
+func projectMemberParams(c *gin.Context) (uint, uint, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return 0, 0, false
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid ID")
+		return 0, 0, false
+	}
+	return userID.(uint), uint(id), true
+}
+
+func respondProjectMemberError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrProjectNotFound),
+		errors.Is(err, services.ErrProjectInviteNotFound),
+		errors.Is(err, services.ErrProjectMemberNotFound):
+		middleware.NotFound(c, err.Error())
+	case errors.Is(err, services.ErrProjectForbidden):
+		middleware.Forbidden(c, err.Error())
+	case errors.Is(err, services.ErrInvalidProjectRole):
+		middleware.BadRequest(c, err.Error())
+	case errors.Is(err, services.ErrAlreadyProjectMember):
+		middleware.Conflict(c, err.Error())
+	default:
+		middleware.InternalServerError(c, err.Error())
+	}
+}
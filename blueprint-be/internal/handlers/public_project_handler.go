@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PublicProjectHandler 비로그인 공개 프로젝트 페이지 핸들러
+type PublicProjectHandler struct {
+	publicProjectService *services.PublicProjectService
+}
+
+// NewPublicProjectHandler 생성자
+func NewPublicProjectHandler(publicProjectService *services.PublicProjectService) *PublicProjectHandler {
+	return &PublicProjectHandler{
+		publicProjectService: publicProjectService,
+	}
+}
+
+// ListPublicProjects 공개 프로젝트 목록
+// GET /api/v1/public/projects?category=&tag=&stage=&funding=&sort=newest|tvl|trust&limit=&offset=
+func (h *PublicProjectHandler) ListPublicProjects(c *gin.Context) {
+	limit, offset := limitOffsetPagination(c)
+	filter := services.PublicProjectFilter{
+		ProjectTaxonomyFilter: projectTaxonomyFilter(c),
+		Sort:                  c.DefaultQuery("sort", services.PublicProjectSortNewest),
+		Limit:                 limit,
+		Offset:                offset,
+	}
+
+	projects, total, err := h.publicProjectService.ListProjects(filter)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidPublicSort) || isTaxonomyFilterError(err) {
+			middleware.BadRequest(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"projects": projects,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+	}, "공개 프로젝트 목록 조회 성공")
+}
+
+// GetPublicProject 공개 프로젝트 상세 (마일스톤 상태, 마켓 가격, TVL, 작성자 신뢰 점수)
+// GET /api/v1/public/projects/:id
+func (h *PublicProjectHandler) GetPublicProject(c *gin.Context) {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid project ID")
+		return
+	}
+
+	project, err := h.publicProjectService.GetProject(uint(projectID))
+	if err != nil {
+		if errors.Is(err, services.ErrPublicProjectNotFound) {
+			middleware.NotFound(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, project, "공개 프로젝트 조회 성공")
+}
+
+// ListProjectTags 공개 프로젝트에서 많이 쓰인 태그
+// GET /api/v1/public/project-tags?category=&limit=
+func (h *PublicProjectHandler) ListProjectTags(c *gin.Context) {
+	limit, _ := limitOffsetPagination(c)
+
+	tags, err := h.publicProjectService.PopularTags(models.ProjectCategory(c.Query("category")), limit)
+	if err != nil {
+		if isTaxonomyFilterError(err) {
+			middleware.BadRequest(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{"tags": tags}, "태그 목록 조회 성공")
+}
+
+// projectTaxonomyFilter 카테고리/태그/진행 단계/펀딩 현황 쿼리 파라미터
+func projectTaxonomyFilter(c *gin.Context) services.ProjectTaxonomyFilter {
+	return services.ProjectTaxonomyFilter{
+		Category: models.ProjectCategory(c.Query("category")),
+		Tag:      c.Query("tag"),
+		Stage:    c.Query("stage"),
+		Funding:  c.Query("funding"),
+	}
+}
+
+func isTaxonomyFilterError(err error) bool {
+	return errors.Is(err, services.ErrInvalidProjectCategory) ||
+		errors.Is(err, services.ErrInvalidProjectStage) ||
+		errors.Is(err, services.ErrInvalidFundingStatus) ||
+		errors.Is(err, models.ErrInvalidProjectTag)
+}
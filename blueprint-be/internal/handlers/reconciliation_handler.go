@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReconciliationHandler 주문/체결/지갑 대사 핸들러
+type ReconciliationHandler struct {
+	reconciliationService *services.ReconciliationService
+}
+
+// NewReconciliationHandler 생성자
+func NewReconciliationHandler(reconciliationService *services.ReconciliationService) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		reconciliationService: reconciliationService,
+	}
+}
+
+// ListReports 최근 대사 리포트 (불일치 목록은 상세 조회에서)
+// GET /api/v1/admin/reconciliation/reports?limit=20
+func (h *ReconciliationHandler) ListReports(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	reports, err := h.reconciliationService.ListReports(limit)
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"reports": reports,
+		"count":   len(reports),
+	}, "대사 리포트 조회 성공")
+}
+
+// GetReport 대사 리포트 상세
+// GET /api/v1/admin/reconciliation/reports/:id
+func (h *ReconciliationHandler) GetReport(c *gin.Context) {
+	reportID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid report ID")
+		return
+	}
+
+	report, err := h.reconciliationService.GetReport(uint(reportID))
+	if err != nil {
+		if errors.Is(err, services.ErrReconciliationReportNotFound) {
+			middleware.NotFound(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, report, "대사 리포트 조회 성공")
+}
+
+// RunReconciliation 대사 즉시 실행 (fix=true면 원인이 분명한 불일치 자동 보정)
+// POST /api/v1/admin/reconciliation/run?fix=true
+func (h *ReconciliationHandler) RunReconciliation(c *gin.Context) {
+	report, err := h.reconciliationService.Run(c.Query("fix") == "true")
+	if err != nil {
+		if errors.Is(err, services.ErrReconciliationRunning) {
+			middleware.BadRequest(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, report, "대사 완료")
+}
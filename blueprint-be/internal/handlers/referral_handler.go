@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"strconv"
+
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReferralHandler 추천 프로그램 핸들러
+type ReferralHandler struct {
+	referralService *services.ReferralService
+}
+
+// NewReferralHandler 생성자
+func NewReferralHandler(referralService *services.ReferralService) *ReferralHandler {
+	return &ReferralHandler{
+		referralService: referralService,
+	}
+}
+
+// GetMyReferral 내 추천 코드 및 적립 현황 (코드가 없으면 발급)
+// GET /api/v1/users/me/referral
+func (h *ReferralHandler) GetMyReferral(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	stats, err := h.referralService.GetStats(userID.(uint))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, stats, "추천 현황 조회 성공")
+}
+
+// GetMyReferees 내가 추천한 사용자 목록
+// GET /api/v1/users/me/referral/referees
+func (h *ReferralHandler) GetMyReferees(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	limit, offset := limitOffsetPagination(c)
+	referrals, total, err := h.referralService.GetReferees(userID.(uint), limit, offset)
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"referrals": referrals,
+		"total":     total,
+		"limit":     limit,
+		"offset":    offset,
+	}, "추천 사용자 목록 조회 성공")
+}
+
+// GetMyPayouts 추천 보상 지급 내역
+// GET /api/v1/users/me/referral/payouts
+func (h *ReferralHandler) GetMyPayouts(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	limit, offset := limitOffsetPagination(c)
+	payouts, total, err := h.referralService.GetPayouts(userID.(uint), limit, offset)
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"payouts": payouts,
+		"total":   total,
+		"limit":   limit,
+		"offset":  offset,
+	}, "추천 보상 지급 내역 조회 성공")
+}
+
+// limitOffsetPagination limit/offset 쿼리 파싱 (기본 20, 최대 100)
+func limitOffsetPagination(c *gin.Context) (int, int) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
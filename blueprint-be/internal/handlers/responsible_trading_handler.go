@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResponsibleTradingHandler 내 일일 한도, 휴식, 자기 배제 핸들러
+type ResponsibleTradingHandler struct {
+	responsibleService *services.ResponsibleTradingService
+}
+
+// NewResponsibleTradingHandler 생성자
+func NewResponsibleTradingHandler(responsibleService *services.ResponsibleTradingService) *ResponsibleTradingHandler {
+	return &ResponsibleTradingHandler{responsibleService: responsibleService}
+}
+
+// GetLimits 적용 중인 한도, 오늘 사용량, 거래 제한 상태
+// GET /api/v1/users/me/limits
+func (h *ResponsibleTradingHandler) GetLimits(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	status, err := h.responsibleService.GetStatus(userID.(uint))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, status, "거래 한도 조회 성공")
+}
+
+// UpdateLimits 일일 한도 변경 (낮추면 즉시, 올리거나 해제하면 유예 후 적용)
+// PUT /api/v1/users/me/limits
+func (h *ResponsibleTradingHandler) UpdateLimits(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req models.UpdateTradingLimitsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	status, err := h.responsibleService.UpdateLimits(userID.(uint), req)
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, status, "거래 한도가 변경되었습니다")
+}
+
+// StartCoolingOff 휴식 기간 시작
+// POST /api/v1/users/me/limits/cooling-off
+func (h *ResponsibleTradingHandler) StartCoolingOff(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req models.CoolingOffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	status, err := h.responsibleService.StartCoolingOff(userID.(uint), req.Hours)
+	if err != nil {
+		h.respondRestrictionError(c, err)
+		return
+	}
+
+	middleware.Success(c, status, "휴식 기간이 시작되었습니다")
+}
+
+// SelfExclude 자기 배제 시작
+// POST /api/v1/users/me/limits/self-exclusion
+func (h *ResponsibleTradingHandler) SelfExclude(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req models.SelfExclusionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	status, err := h.responsibleService.SelfExclude(userID.(uint), req.Days)
+	if err != nil {
+		h.respondRestrictionError(c, err)
+		return
+	}
+
+	middleware.Success(c, status, "자기 배제가 시작되었습니다")
+}
+
+func (h *ResponsibleTradingHandler) respondRestrictionError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrRestrictionShortened) {
+		middleware.BadRequest(c, err.Error())
+		return
+	}
+	middleware.InternalServerError(c, err.Error())
+}
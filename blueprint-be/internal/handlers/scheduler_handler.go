@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SchedulerHandler 주기 작업 운영 핸들러
+type SchedulerHandler struct {
+	schedulerService *services.SchedulerService
+}
+
+// NewSchedulerHandler 생성자
+func NewSchedulerHandler(schedulerService *services.SchedulerService) *SchedulerHandler {
+	return &SchedulerHandler{schedulerService: schedulerService}
+}
+
+// ListJobs 등록된 주기 작업의 일정과 마지막 실행 결과
+// GET /api/v1/admin/scheduler/jobs
+func (h *SchedulerHandler) ListJobs(c *gin.Context) {
+	jobs, err := h.schedulerService.ListJobs()
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"jobs":  jobs,
+		"count": len(jobs),
+	}, "주기 작업 조회 성공")
+}
+
+// ListRuns 작업 실행 이력 (최신순)
+// GET /api/v1/admin/scheduler/jobs/:name/runs?limit=50
+func (h *SchedulerHandler) ListRuns(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+
+	runs, err := h.schedulerService.ListRuns(c.Param("name"), limit)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"runs":  runs,
+		"count": len(runs),
+	}, "실행 이력 조회 성공")
+}
+
+// UpdateJob 주기/활성 여부 변경
+// PUT /api/v1/admin/scheduler/jobs/:name
+func (h *SchedulerHandler) UpdateJob(c *gin.Context) {
+	var req models.UpdateScheduledJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	job, err := h.schedulerService.UpdateSchedule(c.Param("name"), req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	middleware.Success(c, job, "주기 작업 일정 변경 완료")
+}
+
+// TriggerJob 즉시 실행 (백그라운드 실행, 결과는 실행 이력에서 확인)
+// POST /api/v1/admin/scheduler/jobs/:name/run
+func (h *SchedulerHandler) TriggerJob(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	run, err := h.schedulerService.Trigger(c.Param("name"), userID.(uint))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	middleware.SuccessWithStatus(c, http.StatusAccepted, run, "주기 작업 실행 시작")
+}
+
+func (h *SchedulerHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrScheduledJobNotFound):
+		middleware.NotFound(c, err.Error())
+	case errors.Is(err, services.ErrScheduledJobRunning), errors.Is(err, services.ErrInvalidSchedule):
+		middleware.BadRequest(c, err.Error())
+	default:
+		middleware.InternalServerError(c, err.Error())
+	}
+}
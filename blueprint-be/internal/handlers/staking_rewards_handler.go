@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StakingRewardsHandler 스테이킹 발행 보상 핸들러
+type StakingRewardsHandler struct {
+	stakingRewardsService *services.StakingRewardsService
+}
+
+// NewStakingRewardsHandler 생성자
+func NewStakingRewardsHandler(stakingRewardsService *services.StakingRewardsService) *StakingRewardsHandler {
+	return &StakingRewardsHandler{
+		stakingRewardsService: stakingRewardsService,
+	}
+}
+
+// GetRewards 내 스테이킹 보상 현황 (청구 대기/누적 청구액, 현재 에포크 발행량, APY)
+// GET /api/v1/staking/rewards
+func (h *StakingRewardsHandler) GetRewards(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "로그인이 필요합니다"})
+		return
+	}
+
+	summary, err := h.stakingRewardsService.GetSummary(userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// ClaimRewards 청구 대기 중인 스테이킹 보상을 BLUEPRINT 잔액으로 입금
+// POST /api/v1/staking/rewards/claim
+func (h *StakingRewardsHandler) ClaimRewards(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "로그인이 필요합니다"})
+		return
+	}
+
+	response, err := h.stakingRewardsService.ClaimRewards(userID.(uint))
+	if err != nil {
+		if errors.Is(err, services.ErrNoStakingRewards) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": fmt.Sprintf("스테이킹 보상 %d BLUEPRINT가 지급되었습니다", response.TotalAmount),
+		"claim":   response,
+	})
+}
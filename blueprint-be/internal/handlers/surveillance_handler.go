@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SurveillanceHandler 시장 감시 알림 핸들러 (운영자)
+type SurveillanceHandler struct {
+	surveillanceService *services.SurveillanceService
+}
+
+// NewSurveillanceHandler 생성자
+func NewSurveillanceHandler(surveillanceService *services.SurveillanceService) *SurveillanceHandler {
+	return &SurveillanceHandler{surveillanceService: surveillanceService}
+}
+
+// ListAlerts 알림 목록
+// GET /api/v1/admin/surveillance/alerts?status=open&type=spoofing&milestone_id=1&limit=50&offset=0
+func (h *SurveillanceHandler) ListAlerts(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	milestoneID, _ := strconv.ParseUint(c.Query("milestone_id"), 10, 32)
+
+	alerts, total, err := h.surveillanceService.ListAlerts(
+		models.SurveillanceAlertStatus(c.Query("status")),
+		models.SurveillanceAlertType(c.Query("type")),
+		uint(milestoneID), limit, offset)
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"alerts": alerts,
+		"count":  len(alerts),
+		"total":  total,
+	}, "시장 감시 알림 조회 성공")
+}
+
+// GetAlert 알림 상세
+// GET /api/v1/admin/surveillance/alerts/:id
+func (h *SurveillanceHandler) GetAlert(c *gin.Context) {
+	alertID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid alert ID")
+		return
+	}
+
+	alert, err := h.surveillanceService.GetAlert(uint(alertID))
+	if err != nil {
+		h.respondReviewError(c, err)
+		return
+	}
+
+	middleware.Success(c, alert, "시장 감시 알림 조회 성공")
+}
+
+// DismissAlert 문제 없음으로 종결
+// POST /api/v1/admin/surveillance/alerts/:id/dismiss
+func (h *SurveillanceHandler) DismissAlert(c *gin.Context) {
+	h.review(c, h.surveillanceService.DismissAlert, "알림이 종결되었습니다")
+}
+
+// EscalateAlert 배심원 중재 사건으로 이관
+// POST /api/v1/admin/surveillance/alerts/:id/escalate
+func (h *SurveillanceHandler) EscalateAlert(c *gin.Context) {
+	h.review(c, h.surveillanceService.EscalateAlert, "알림이 중재 사건으로 이관되었습니다")
+}
+
+// RunScan 감시 즉시 실행
+// POST /api/v1/admin/surveillance/scan
+func (h *SurveillanceHandler) RunScan(c *gin.Context) {
+	raised, err := h.surveillanceService.Scan(time.Now())
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{"raised": raised}, "시장 감시 완료")
+}
+
+func (h *SurveillanceHandler) review(c *gin.Context, action func(alertID, reviewerID uint, note string) (*models.SurveillanceAlert, error), message string) {
+	reviewerID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	alertID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid alert ID")
+		return
+	}
+
+	var req models.ReviewSurveillanceAlertRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			middleware.BadRequest(c, "Invalid request: "+err.Error())
+			return
+		}
+	}
+
+	alert, err := action(uint(alertID), reviewerID.(uint), req.Note)
+	if err != nil {
+		h.respondReviewError(c, err)
+		return
+	}
+
+	middleware.Success(c, alert, message)
+}
+
+func (h *SurveillanceHandler) respondReviewError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrSurveillanceAlertNotFound):
+		middleware.NotFound(c, err.Error())
+	case errors.Is(err, services.ErrSurveillanceAlertReviewed):
+		middleware.BadRequest(c, err.Error())
+	default:
+		middleware.InternalServerError(c, err.Error())
+	}
+}
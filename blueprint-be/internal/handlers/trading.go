@@ -4,9 +4,12 @@ import (
 	"blueprint-module/pkg/models"
 	"blueprint/internal/middleware"
 	"blueprint/internal/services"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -19,13 +22,17 @@ import (
 // TradingHandler P2P 거래 핸들러 (폴리마켓 스타일)
 type TradingHandler struct {
 	tradingService       *services.TradingService
+	riskService          *services.PreTradeRiskService
+	milestoneRisk        *services.MilestoneRiskService
 	probabilityValidator *services.ProbabilityValidator
 }
 
 // NewTradingHandler 거래 핸들러 생성자
-func NewTradingHandler(tradingService *services.TradingService) *TradingHandler {
+func NewTradingHandler(tradingService *services.TradingService, riskService *services.PreTradeRiskService, milestoneRisk *services.MilestoneRiskService) *TradingHandler {
 	return &TradingHandler{
 		tradingService:       tradingService,
+		riskService:          riskService,
+		milestoneRisk:        milestoneRisk,
 		probabilityValidator: services.NewProbabilityValidator(),
 	}
 }
@@ -53,7 +60,7 @@ func (h *TradingHandler) CreateOrder(c *gin.Context) {
 
 	// 💰 USDC 잔액 검증 (매수 주문만) - TradingService를 통해 검증
 	if req.Side == models.OrderSideBuy {
-		requiredUSDC := int64(float64(req.Quantity) * req.Price * 100) // 확률을 센트로 변환
+		requiredUSDC := models.ReserveCents(req.Quantity, models.PriceToTicks(req.Price))
 		hasBalance, err := h.tradingService.ValidateUserBalance(userID.(uint), requiredUSDC)
 		if err != nil {
 			middleware.InternalServerError(c, "잔액 검증 중 오류 발생")
@@ -77,6 +84,17 @@ func (h *TradingHandler) CreateOrder(c *gin.Context) {
 		userAgent,
 	)
 	if err != nil {
+		if errors.Is(err, services.ErrTradingRestricted) || errors.Is(err, services.ErrDailyLimitExceeded) ||
+			errors.Is(err, services.ErrKYCOrderLimitExceeded) || errors.Is(err, services.ErrRiskLimitExceeded) {
+			middleware.Forbidden(c, err.Error())
+			return
+		}
+		if errors.Is(err, services.ErrMarketFrozen) || errors.Is(err, services.ErrMarketClosed) || errors.Is(err, services.ErrMarketHalted) || errors.Is(err, services.ErrTradingPaused) || errors.Is(err, services.ErrUnknownOption) ||
+			errors.Is(err, models.ErrPriceOutOfRange) || errors.Is(err, models.ErrPriceOffTick) || errors.Is(err, services.ErrInsufficientBalance) ||
+			errors.Is(err, services.ErrInsufficientCollateral) || errors.Is(err, services.ErrShortSellingUnavailable) {
+			middleware.BadRequest(c, err.Error())
+			return
+		}
 		middleware.InternalServerError(c, err.Error())
 		return
 	}
@@ -84,8 +102,26 @@ func (h *TradingHandler) CreateOrder(c *gin.Context) {
 	middleware.Success(c, response, "주문이 성공적으로 생성되었습니다")
 }
 
+// GetRiskLimits 내 리스크 한도 및 현재 사용량
+// GET /api/v1/risk/limits
+func (h *TradingHandler) GetRiskLimits(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	status, err := h.riskService.GetStatus(userID.(uint))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, status, "리스크 한도 조회 성공")
+}
+
 // GetOrderBook 호가창 조회
-// GET /api/v1/milestones/:id/orderbook/:option
+// GET /api/v1/milestones/:id/orderbook/:option?depth=10&agg=0.01
 func (h *TradingHandler) GetOrderBook(c *gin.Context) {
 	milestoneIDStr := c.Param("id")
 	milestoneID, err := strconv.ParseUint(milestoneIDStr, 10, 32)
@@ -100,8 +136,23 @@ func (h *TradingHandler) GetOrderBook(c *gin.Context) {
 		return
 	}
 
-	orderBook, err := h.tradingService.GetOrderBook(uint(milestoneID), optionID)
+	depth, err := strconv.Atoi(c.DefaultQuery("depth", strconv.Itoa(services.DefaultOrderBookDepth)))
+	if err != nil {
+		middleware.BadRequest(c, "Invalid depth")
+		return
+	}
+	aggregation, err := strconv.ParseFloat(c.DefaultQuery("agg", "0"), 64)
 	if err != nil {
+		middleware.BadRequest(c, "Invalid agg")
+		return
+	}
+
+	orderBook, err := h.tradingService.GetOrderBook(uint(milestoneID), optionID, depth, aggregation)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidOrderBookDepth) || errors.Is(err, services.ErrInvalidOrderBookAggregation) {
+			middleware.BadRequest(c, err.Error())
+			return
+		}
 		middleware.InternalServerError(c, err.Error())
 		return
 	}
@@ -279,27 +330,33 @@ func (h *TradingHandler) CancelOrder(c *gin.Context) {
 		return
 	}
 
-	// 주문 조회 및 권한 확인
-	var order models.Order
-	if err := h.tradingService.GetDB().Where("id = ? AND user_id = ?", uint(orderID), userID).First(&order).Error; err != nil {
-		middleware.NotFound(c, "주문을 찾을 수 없습니다")
-		return
-	}
-
-	// 취소 가능한 상태 확인
-	if order.Status == models.OrderStatusFilled || order.Status == models.OrderStatusCancelled {
-		middleware.BadRequest(c, "취소할 수 없는 주문입니다")
-		return
-	}
-
-	// 주문 취소
-	order.Status = models.OrderStatusCancelled
-	if err := h.tradingService.GetDB().Save(&order).Error; err != nil {
+	// 주문 취소 (매칭 엔진 우선 취소 경로로 주문장에서 먼저 제거, 미체결분 매수 잠금 해제)
+	response, err := h.tradingService.CancelOrder(userID.(uint), uint(orderID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			middleware.NotFound(c, "주문을 찾을 수 없습니다")
+			return
+		}
+		if errors.Is(err, services.ErrOrderNotOpen) {
+			middleware.BadRequest(c, "취소할 수 없는 주문입니다")
+			return
+		}
+		if errors.Is(err, services.ErrCancelNotConfirmed) {
+			// 주문은 주문장에 남아 있고 잠금도 그대로이므로 다시 시도하면 됨
+			middleware.Error(c, http.StatusServiceUnavailable, err.Error(), "주문 취소를 처리하지 못했습니다. 잠시 후 다시 시도하세요")
+			return
+		}
 		middleware.InternalServerError(c, "주문 취소 중 오류가 발생했습니다")
 		return
 	}
 
-	middleware.Success(c, order, "주문이 성공적으로 취소되었습니다")
+	middleware.Success(c, response, "주문이 성공적으로 취소되었습니다")
+}
+
+// GetTradingStats 거래/매칭 엔진 통계 (취소 SLA 지표 포함)
+// GET /api/v1/trading/stats
+func (h *TradingHandler) GetTradingStats(c *gin.Context) {
+	middleware.Success(c, h.tradingService.GetStats(), "거래 통계 조회 성공")
 }
 
 // GetRecentTrades 최근 거래 내역 조회 (공개)
@@ -324,8 +381,12 @@ func (h *TradingHandler) GetRecentTrades(c *gin.Context) {
 		limitInt = 50
 	}
 
-	// TradingService 메서드 사용
-	trades, err := h.tradingService.GetRecentTrades(uint(milestoneID), optionID, limitInt)
+	// 읽기 복제본에서 조회
+	trades, err := h.tradingService.Queries().GetRecentTrades(&services.RecentTradesQuery{
+		MilestoneID: uint(milestoneID),
+		OptionID:    optionID,
+		Limit:       limitInt,
+	})
 	if err != nil {
 		middleware.InternalServerError(c, err.Error())
 		return
@@ -372,6 +433,44 @@ func (h *TradingHandler) GetUserWallet(c *gin.Context) {
 	middleware.Success(c, wallet, "지갑 조회 성공")
 }
 
+// ListMarkets 마켓 탐색 (카테고리/상태/마감 임박/거래량/변동률)
+// GET /api/v1/markets?category=&status=&sort=volume|closing_soon|movers|newest&closing_within_hours=
+func (h *TradingHandler) ListMarkets(c *gin.Context) {
+	limit, offset := limitOffsetPagination(c)
+	filter := services.MarketListFilter{
+		Category: models.ProjectCategory(c.Query("category")),
+		Status:   c.Query("status"),
+		Sort:     c.DefaultQuery("sort", services.MarketSortVolume),
+		Limit:    limit,
+		Offset:   offset,
+	}
+	if hours := c.Query("closing_within_hours"); hours != "" {
+		n, err := strconv.Atoi(hours)
+		if err != nil || n <= 0 {
+			middleware.BadRequest(c, "Invalid closing_within_hours")
+			return
+		}
+		filter.ClosingWithin = time.Duration(n) * time.Hour
+	}
+
+	markets, total, err := h.tradingService.ListMarkets(filter)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidMarketSort) {
+			middleware.BadRequest(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"markets": markets,
+		"total":   total,
+		"limit":   limit,
+		"offset":  offset,
+	}, "마켓 목록 조회 성공")
+}
+
 // GetMilestoneMarket 마일스톤 마켓 정보 조회
 // GET /api/v1/milestones/:id/market
 func (h *TradingHandler) GetMilestoneMarket(c *gin.Context) {
@@ -381,30 +480,79 @@ func (h *TradingHandler) GetMilestoneMarket(c *gin.Context) {
 		return
 	}
 
-	// 마일스톤 존재 확인
-	var milestone models.Milestone
-	if err := h.tradingService.GetDB().First(&milestone, milestoneID).Error; err != nil {
-		middleware.NotFound(c, "Milestone not found")
+	// 마켓 스냅샷 조회 (시퀀스 기반 캐시)
+	view, err := h.tradingService.GetMarketView(uint(milestoneID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			middleware.NotFound(c, "Milestone not found")
+			return
+		}
+		middleware.InternalServerError(c, "마켓 데이터 조회 실패")
 		return
 	}
 
-	// 마켓 데이터 조회
-	var marketData []models.MarketData
-	if err := h.tradingService.GetDB().Where("milestone_id = ?", milestoneID).Find(&marketData).Error; err != nil {
-		middleware.InternalServerError(c, "마켓 데이터 조회 실패")
+	// 모델 기반 실패 위험 점수 (스케줄러가 계산해 둔 값, 아직 없으면 생략)
+	risk, err := h.milestoneRisk.GetMilestoneRisk(uint(milestoneID))
+	if err != nil && !errors.Is(err, services.ErrMilestoneRiskNotComputed) {
+		log.Printf("⚠️ Failed to load milestone risk %d: %v", milestoneID, err)
+	}
+
+	// ETag / If-None-Match 조건부 응답 (위험 점수가 갱신되어도 바뀜)
+	etag := fmt.Sprintf(`"%s"`, view.Version)
+	if risk != nil {
+		etag = fmt.Sprintf(`"%s-r%d"`, view.Version, risk.ComputedAt.Unix())
+	}
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "no-cache")
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
 		return
 	}
 
+	// ?since=<version> 델타 응답 (기준 스냅샷이 만료되었으면 전체 응답)
+	if since := c.Query("since"); since != "" {
+		if delta, ok := h.tradingService.GetMarketDelta(uint(milestoneID), since, view); ok {
+			middleware.Success(c, delta, "마켓 변경분 조회 성공")
+			return
+		}
+	}
+
 	result := gin.H{
-		"milestone":    milestone,
-		"market_data":  marketData,
-		"total_volume": 0, // TODO: 실제 볼륨 계산
+		"milestone":     view.Milestone,
+		"market_data":   view.MarketData,
+		"total_volume":  view.TotalVolume,
+		"trading_state": view.TradingState,
+		"version":       view.Version,
+		"delta":         false,
+	}
+	if view.ClosesAt != nil {
+		result["closes_at"] = view.ClosesAt
+	}
+	if view.ImpliedValue != nil {
+		result["implied_value"] = *view.ImpliedValue
+	}
+	if risk != nil {
+		result["risk"] = risk
 	}
 
 	middleware.Success(c, result, "마켓 정보 조회 성공")
 }
 
-// GetPriceHistory 가격 히스토리 조회 (새로 추가)
+// etagMatches If-None-Match 헤더와 ETag 비교 (여러 값, weak 비교 지원)
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPriceHistory 가격 히스토리 조회 (체결로 미리 집계한 캔들, 읽기 복제본)
 // GET /api/v1/milestones/:id/price-history/:option
 func (h *TradingHandler) GetPriceHistory(c *gin.Context) {
 	milestoneID, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -420,125 +568,86 @@ func (h *TradingHandler) GetPriceHistory(c *gin.Context) {
 	}
 
 	// 쿼리 파라미터
-	interval := c.DefaultQuery("interval", "1h") // 1m, 5m, 15m, 1h, 1d
-	limit := c.DefaultQuery("limit", "100")
-
-	limitInt, err := strconv.Atoi(limit)
+	interval := models.CandleInterval(c.DefaultQuery("interval", "1h")) // 1m, 5m, 15m, 1h, 1d
+	limitInt, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
 	if err != nil || limitInt <= 0 {
 		limitInt = 100
 	}
 
-	// 일반 DB에서 fallback 데이터 생성 (TimescaleDB 대신)
-	log.Printf("🔍 Generating fallback price history for milestone %d, option %s", milestoneID, optionID)
-
-	// 1. 마켓 데이터에서 현재 가격 조회
-	var marketData models.MarketData
-	if err := h.tradingService.GetDB().Where("milestone_id = ? AND option_id = ?", milestoneID, optionID).First(&marketData).Error; err != nil {
-		middleware.InternalServerError(c, "마켓 데이터를 찾을 수 없습니다")
-		return
-	}
-
-	// 2. 최근 거래에서 가격 변동 히스토리 생성
-	trades, err := h.tradingService.GetRecentTrades(uint(milestoneID), optionID, limitInt)
+	candles, err := h.tradingService.Queries().GetPriceCandles(&services.PriceCandlesQuery{
+		MilestoneID: uint(milestoneID),
+		OptionID:    optionID,
+		Interval:    interval,
+		Limit:       limitInt,
+	})
 	if err != nil {
-		log.Printf("❌ Error getting recent trades: %v", err)
-	}
-
-	// 3. 가격 히스토리 데이터 생성
-	var priceHistory []map[string]interface{}
-
-	if len(trades) > 0 {
-		// 거래 데이터가 있으면 시간별로 그룹화
-		timeGroups := make(map[string][]models.Trade)
-		for _, trade := range trades {
-			var bucket string
-			switch interval {
-			case "1m":
-				bucket = trade.CreatedAt.Truncate(time.Minute).Format(time.RFC3339)
-			case "5m":
-				bucket = trade.CreatedAt.Truncate(5 * time.Minute).Format(time.RFC3339)
-			case "15m":
-				bucket = trade.CreatedAt.Truncate(15 * time.Minute).Format(time.RFC3339)
-			case "1d":
-				bucket = trade.CreatedAt.Truncate(24 * time.Hour).Format(time.RFC3339)
-			default: // 1h
-				bucket = trade.CreatedAt.Truncate(time.Hour).Format(time.RFC3339)
-			}
-			timeGroups[bucket] = append(timeGroups[bucket], trade)
+		if errors.Is(err, services.ErrInvalidCandleInterval) {
+			middleware.BadRequest(c, err.Error())
+			return
 		}
+		middleware.InternalServerError(c, "가격 히스토리 조회 실패")
+		return
+	}
 
-		// 각 시간 그룹별로 OHLC 데이터 생성
-		for bucket, groupTrades := range timeGroups {
-			if len(groupTrades) == 0 {
-				continue
-			}
-
-			open := groupTrades[len(groupTrades)-1].Price // 가장 오래된 거래
-			close := groupTrades[0].Price                 // 가장 최근 거래
-			high := groupTrades[0].Price
-			low := groupTrades[0].Price
-			volume := int64(0)
-
-			for _, trade := range groupTrades {
-				if trade.Price > high {
-					high = trade.Price
-				}
-				if trade.Price < low {
-					low = trade.Price
-				}
-				volume += trade.TotalAmount
-			}
+	var marketData models.MarketData
+	marketDataErr := h.tradingService.ReadDB().Where("milestone_id = ? AND option_id = ?", milestoneID, optionID).First(&marketData).Error
 
-			priceHistory = append(priceHistory, map[string]interface{}{
-				"bucket": bucket,
-				"open":   open,
-				"high":   high,
-				"low":    low,
-				"close":  close,
-				"volume": volume,
-				"trades": len(groupTrades),
-			})
+	if len(candles) == 0 {
+		// 체결이 없으면 현재 마켓 데이터로 기본 포인트 생성
+		if marketDataErr != nil {
+			middleware.InternalServerError(c, "마켓 데이터를 찾을 수 없습니다")
+			return
 		}
-	} else {
-		// 거래 데이터가 없으면 현재 마켓 데이터로 기본 포인트 생성
+
 		now := time.Now()
 		for i := limitInt - 1; i >= 0; i-- {
-			var bucket time.Time
-			switch interval {
-			case "1m":
-				bucket = now.Add(-time.Duration(i) * time.Minute).Truncate(time.Minute)
-			case "5m":
-				bucket = now.Add(-time.Duration(i) * 5 * time.Minute).Truncate(5 * time.Minute)
-			case "15m":
-				bucket = now.Add(-time.Duration(i) * 15 * time.Minute).Truncate(15 * time.Minute)
-			case "1d":
-				bucket = now.Add(-time.Duration(i) * 24 * time.Hour).Truncate(24 * time.Hour)
-			default: // 1h
-				bucket = now.Add(-time.Duration(i) * time.Hour).Truncate(time.Hour)
-			}
-
-			priceHistory = append(priceHistory, map[string]interface{}{
-				"bucket": bucket.Format(time.RFC3339),
-				"open":   marketData.CurrentPrice,
-				"high":   marketData.CurrentPrice,
-				"low":    marketData.CurrentPrice,
-				"close":  marketData.CurrentPrice,
-				"volume": marketData.Volume24h / int64(limitInt), // 균등 분배
-				"trades": 0,
+			bucket := now.Add(-time.Duration(i) * interval.Duration()).Truncate(interval.Duration())
+			candles = append(candles, services.PriceCandleView{
+				Bucket: bucket.Format(time.RFC3339),
+				Open:   marketData.CurrentPrice,
+				High:   marketData.CurrentPrice,
+				Low:    marketData.CurrentPrice,
+				Close:  marketData.CurrentPrice,
+				VWAP:   marketData.CurrentPrice,
+				Volume: marketData.Volume24h / int64(limitInt), // 균등 분배
 			})
 		}
 	}
 
-	// 시간순 정렬 (오래된 것부터)
-	for i, j := 0, len(priceHistory)-1; i < j; i, j = i+1, j-1 {
-		priceHistory[i], priceHistory[j] = priceHistory[j], priceHistory[i]
+	result := gin.H{
+		"data":     candles,
+		"interval": interval,
+		"count":    len(candles),
+	}
+	if marketDataErr == nil {
+		// 직전 체결가보다 조작이 어려운 기간 평균가
+		result["averages"] = services.AveragePrices{
+			TWAP1h:  marketData.TWAP1h,
+			TWAP24h: marketData.TWAP24h,
+			VWAP1h:  marketData.VWAP1h,
+			VWAP24h: marketData.VWAP24h,
+		}
 	}
 
-	middleware.Success(c, gin.H{
-		"data":     priceHistory,
-		"interval": interval,
-		"count":    len(priceHistory),
-	}, "가격 히스토리 조회 성공")
+	middleware.Success(c, result, "가격 히스토리 조회 성공")
+}
+
+// RebuildPriceHistory 가격 캔들 재집계 (관리자, 배포 직후 백필/불일치 복구)
+// POST /api/v1/milestones/:id/price-history/:option/rebuild
+func (h *TradingHandler) RebuildPriceHistory(c *gin.Context) {
+	milestoneID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid milestone ID")
+		return
+	}
+
+	applied, err := h.tradingService.RebuildPriceCandles(uint(milestoneID), c.Param("option"))
+	if err != nil {
+		middleware.InternalServerError(c, "가격 캔들 재집계 실패")
+		return
+	}
+
+	middleware.Success(c, gin.H{"trades": applied}, "가격 캔들 재집계 완료")
 }
 
 // InitializeMarket 마켓 초기화
@@ -566,10 +675,16 @@ func (h *TradingHandler) InitializeMarket(c *gin.Context) {
 		return
 	}
 
-	// 옵션이 없으면 기본 성공/실패 옵션 사용
+	// 옵션이 없으면 마일스톤의 결과 옵션 전체 (이진 마켓은 success/fail)
 	options := req.Options
 	if len(options) == 0 {
-		options = []string{"success", "fail"}
+		options = milestone.OptionIDs()
+	}
+	for _, option := range options {
+		if !milestone.HasOption(option) {
+			middleware.BadRequest(c, "Unknown market option: "+option)
+			return
+		}
 	}
 
 	// 마켓 초기화는 매칭 엔진에서 동적으로 처리됩니다
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TradingPauseHandler 점검 모드/거래 중단 핸들러
+type TradingPauseHandler struct {
+	pauseService *services.TradingPauseService
+}
+
+// NewTradingPauseHandler 생성자
+func NewTradingPauseHandler(pauseService *services.TradingPauseService) *TradingPauseHandler {
+	return &TradingPauseHandler{
+		pauseService: pauseService,
+	}
+}
+
+// ListPauses 적용 중이거나 예약된 거래 중단 (설정으로 건 중단은 id 0)
+// GET /api/v1/admin/trading-pauses
+func (h *TradingPauseHandler) ListPauses(c *gin.Context) {
+	pauses := h.pauseService.List()
+	middleware.Success(c, gin.H{
+		"pauses": pauses,
+		"count":  len(pauses),
+	}, "거래 중단 조회 성공")
+}
+
+// CreatePause 전체(milestone_id 없음) 또는 마켓 거래 중단, starts_at이 미래면 예약 점검
+// POST /api/v1/admin/trading-pauses
+func (h *TradingPauseHandler) CreatePause(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req models.CreateTradingPauseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	pause, err := h.pauseService.Pause(req, adminID.(uint))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidTradingPause) {
+			middleware.BadRequest(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, pause, "거래 중단 등록 완료")
+}
+
+// LiftPause 거래 중단 해제 (예약 점검 취소 포함)
+// DELETE /api/v1/admin/trading-pauses/:id
+func (h *TradingPauseHandler) LiftPause(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	pauseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid pause ID")
+		return
+	}
+
+	if err := h.pauseService.Lift(uint(pauseID), adminID.(uint)); err != nil {
+		if errors.Is(err, services.ErrTradingPauseNotFound) {
+			middleware.NotFound(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, nil, "거래 중단 해제 완료")
+}
@@ -4,13 +4,21 @@ import (
 	"blueprint-module/pkg/config"
 	"blueprint-module/pkg/models"
 	"blueprint-module/pkg/queue"
+	"blueprint-module/pkg/redis"
+	"crypto/rand"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
+	"math/big"
+	"mime/multipart"
 	"net/http"
+	"regexp"
+	"strings"
 	"time"
 
 	"blueprint/internal/database"
 	"blueprint/internal/middleware"
+	"blueprint/internal/services"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -18,12 +26,16 @@ import (
 
 // UserSettingsHandler 사용자 설정 핸들러
 type UserSettingsHandler struct {
-	cfg *config.Config
+	cfg         *config.Config
+	fileService *services.FileService
+	kycService  *services.KYCService
 }
 
-func NewUserSettingsHandler(cfg *config.Config) *UserSettingsHandler {
+func NewUserSettingsHandler(cfg *config.Config, fileService *services.FileService, kycService *services.KYCService) *UserSettingsHandler {
 	return &UserSettingsHandler{
-		cfg: cfg,
+		cfg:         cfg,
+		fileService: fileService,
+		kycService:  kycService,
 	}
 }
 
@@ -83,14 +95,22 @@ func (h *UserSettingsHandler) GetMySettings(c *gin.Context) {
 		user.Verification = verification
 	}
 
+	kycStatus, err := h.kycService.GetStatus(user.ID)
+	if err != nil {
+		middleware.InternalServerError(c, "Failed to fetch KYC status")
+		return
+	}
+
 	middleware.Success(c, gin.H{
 		"user": gin.H{
-			"id":       user.ID,
-			"email":    user.Email,
-			"username": user.Username,
+			"id":          user.ID,
+			"email":       user.Email,
+			"username":    user.Username,
+			"trust_score": user.TrustScore,
 		},
 		"profile":      user.Profile,
 		"verification": user.Verification,
+		"kyc":          kycStatus,
 	}, "User settings fetched")
 }
 
@@ -132,6 +152,7 @@ func (h *UserSettingsHandler) UpdateProfile(c *gin.Context) {
 				MarketingNotifications: false,
 				ProfilePublic:          true,
 				InvestmentPublic:       false,
+				Locale:                 "ko",
 			}
 		} else {
 			middleware.InternalServerError(c, "Failed to query profile")
@@ -223,6 +244,12 @@ func (h *UserSettingsHandler) UpdatePreferences(c *gin.Context) {
 	if req.InvestmentPublic != nil {
 		profile.InvestmentPublic = *req.InvestmentPublic
 	}
+	if req.Locale != nil {
+		profile.Locale = *req.Locale
+	}
+	if req.DigestFrequency != nil {
+		profile.DigestFrequency = models.DigestFrequency(*req.DigestFrequency)
+	}
 
 	// 데이터베이스 저장
 	if profile.ID == 0 {
@@ -290,7 +317,8 @@ func (h *UserSettingsHandler) RequestVerifyEmail(c *gin.Context) {
 		"timestamp": time.Now().Unix(),
 	}
 
-	if err := queue.PublishJob("email_queue", emailJob); err != nil {
+	jobID, err := queue.PublishTrackedJob("email_queue", emailJob)
+	if err != nil {
 		middleware.InternalServerError(c, "Failed to queue email job")
 		return
 	}
@@ -298,6 +326,7 @@ func (h *UserSettingsHandler) RequestVerifyEmail(c *gin.Context) {
 	middleware.SuccessWithStatus(c, http.StatusAccepted, gin.H{
 		"message":    "Verification email sent",
 		"expires_in": 900, // 15분
+		"job_id":     jobID,
 	}, "Email verification requested")
 }
 
@@ -378,8 +407,12 @@ func (h *UserSettingsHandler) RequestVerifyPhone(c *gin.Context) {
 		return
 	}
 
-	// 휴대폰 번호 유효성 검사 (한국 번호 형식)
-	// TODO: 더 정교한 유효성 검사 구현
+	// 국제 형식(E.164)으로 정규화 (국내 번호 010-1234-5678 → +821012345678)
+	phoneNumber, ok := normalizePhoneNumber(req.PhoneNumber)
+	if !ok {
+		middleware.BadRequest(c, "Invalid phone number")
+		return
+	}
 
 	// 이미 인증된 경우 체크
 	var verification models.UserVerification
@@ -390,36 +423,138 @@ func (h *UserSettingsHandler) RequestVerifyPhone(c *gin.Context) {
 		}
 	}
 
+	// 재발송 제한 (1분에 1회, 하루 5회)
+	if allowed, err := redis.CheckRateLimit(userID.(uint), "verify_phone_resend", 1, phoneCodeResendInterval); err != nil || !allowed {
+		middleware.Error(c, http.StatusTooManyRequests, "Please wait before requesting another code", "인증번호 재발송 대기 중입니다")
+		return
+	}
+	if allowed, err := redis.CheckRateLimit(userID.(uint), "verify_phone_daily", phoneCodeDailyLimit, 24*time.Hour); err != nil || !allowed {
+		middleware.Error(c, http.StatusTooManyRequests, "Daily verification SMS limit reached", "오늘 인증번호 발송 한도를 초과했습니다")
+		return
+	}
+
 	// 인증 코드 생성
-	verificationCode := fmt.Sprintf("%06d", time.Now().UnixNano()%1000000)
+	verificationCode, err := generateNumericCode(6)
+	if err != nil {
+		middleware.InternalServerError(c, "Failed to generate verification code")
+		return
+	}
 
-	// Redis에 인증 코드 저장 (5분 만료)
+	// Redis에 인증 코드와 번호 저장 (5분 만료, 새 코드 발급 시 확인 시도 횟수 초기화)
+	pending, _ := json.Marshal(pendingPhoneVerification{Code: verificationCode, PhoneNumber: phoneNumber})
 	redisKey := fmt.Sprintf("phone_verification:%d", userID)
-	if err := queue.SetWithExpiry(redisKey, verificationCode, 5*time.Minute); err != nil {
+	if err := queue.SetWithExpiry(redisKey, string(pending), phoneCodeTTL); err != nil {
 		middleware.InternalServerError(c, "Failed to store verification code")
 		return
 	}
+	queue.Delete(fmt.Sprintf("rate_limit:%d:verify_phone_confirm", userID))
 
 	// 워커 큐에 SMS 전송 작업 추가
 	smsJob := map[string]interface{}{
 		"type":      "send_sms",
-		"to":        req.PhoneNumber,
+		"to":        phoneNumber,
 		"message":   fmt.Sprintf("[Blueprint] 인증번호: %s (5분간 유효)", verificationCode),
 		"user_id":   userID,
 		"timestamp": time.Now().Unix(),
 	}
 
-	if err := queue.PublishJob("sms_queue", smsJob); err != nil {
+	jobID, err := queue.PublishTrackedJob("sms_queue", smsJob)
+	if err != nil {
 		middleware.InternalServerError(c, "Failed to queue SMS job")
 		return
 	}
 
 	middleware.SuccessWithStatus(c, http.StatusAccepted, gin.H{
 		"message":    "Verification SMS sent",
-		"expires_in": 300, // 5분
+		"expires_in": int(phoneCodeTTL.Seconds()),
+		"resend_in":  int(phoneCodeResendInterval.Seconds()),
+		"job_id":     jobID,
 	}, "Phone verification requested")
 }
 
+// VerifyPhoneCode 휴대폰 인증 코드 확인 (코드당 5회까지 시도)
+// POST /api/v1/users/me/verify/phone/confirm
+func (h *UserSettingsHandler) VerifyPhoneCode(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req struct {
+		Code string `json:"code" binding:"required,len=6"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid verification code format")
+		return
+	}
+
+	redisKey := fmt.Sprintf("phone_verification:%d", userID)
+	stored, err := queue.Get(redisKey)
+	var pending pendingPhoneVerification
+	if err != nil || json.Unmarshal([]byte(stored), &pending) != nil {
+		middleware.BadRequest(c, "Invalid or expired verification code")
+		return
+	}
+
+	// 시도 횟수를 넘기면 코드를 폐기하고 재발급을 요구
+	if allowed, err := redis.CheckRateLimit(userID.(uint), "verify_phone_confirm", phoneCodeMaxAttempts, phoneCodeTTL); err != nil || !allowed {
+		queue.Delete(redisKey)
+		middleware.Error(c, http.StatusTooManyRequests, "Too many attempts, request a new code", "인증 시도 횟수를 초과했습니다")
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(pending.Code), []byte(req.Code)) != 1 {
+		middleware.BadRequest(c, "Invalid or expired verification code")
+		return
+	}
+
+	db := database.GetDB()
+
+	// 같은 번호로 다른 계정이 이미 인증한 경우 거부 (다중 계정 방지)
+	var taken int64
+	db.Model(&models.UserVerification{}).
+		Where("phone_number = ? AND phone_verified = ? AND user_id <> ?", pending.PhoneNumber, true, userID).
+		Count(&taken)
+	if taken > 0 {
+		queue.Delete(redisKey)
+		middleware.Conflict(c, "Phone number is already verified by another account")
+		return
+	}
+
+	// 인증 상태 업데이트
+	now := time.Now()
+	var verification models.UserVerification
+	if err := db.Where("user_id = ?", userID).First(&verification).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			verification = models.UserVerification{UserID: userID.(uint)}
+		} else {
+			middleware.InternalServerError(c, "Failed to query verification")
+			return
+		}
+	}
+
+	verification.PhoneVerified = true
+	verification.PhoneNumber = pending.PhoneNumber
+	verification.PhoneVerifiedAt = &now
+
+	if verification.ID == 0 {
+		if err := db.Create(&verification).Error; err != nil {
+			middleware.InternalServerError(c, "Failed to create verification record")
+			return
+		}
+	} else {
+		if err := db.Save(&verification).Error; err != nil {
+			middleware.InternalServerError(c, "Failed to update verification")
+			return
+		}
+	}
+
+	// Redis에서 인증 코드 삭제
+	queue.Delete(redisKey)
+
+	middleware.Success(c, verification, "Phone verified successfully")
+}
+
 // ConnectProvider 소셜 미디어 연결
 // POST /api/v1/users/me/connect/:provider (linkedin|github|twitter)
 func (h *UserSettingsHandler) ConnectProvider(c *gin.Context) {
@@ -454,7 +589,8 @@ func (h *UserSettingsHandler) ConnectProvider(c *gin.Context) {
 		"timestamp":    time.Now().Unix(),
 	}
 
-	if err := queue.PublishJob("verification_queue", verificationJob); err != nil {
+	jobID, err := queue.PublishTrackedJob("verification_queue", verificationJob)
+	if err != nil {
 		middleware.InternalServerError(c, "Failed to queue verification job")
 		return
 	}
@@ -462,6 +598,7 @@ func (h *UserSettingsHandler) ConnectProvider(c *gin.Context) {
 	middleware.SuccessWithStatus(c, http.StatusAccepted, gin.H{
 		"message": fmt.Sprintf("%s connection verification started", provider),
 		"status":  "pending",
+		"job_id":  jobID,
 	}, "Provider connection requested")
 }
 
@@ -516,7 +653,8 @@ func (h *UserSettingsHandler) VerifyWorkEmail(c *gin.Context) {
 		"timestamp": time.Now().Unix(),
 	}
 
-	if err := queue.PublishJob("email_queue", emailJob); err != nil {
+	jobID, err := queue.PublishTrackedJob("email_queue", emailJob)
+	if err != nil {
 		middleware.InternalServerError(c, "Failed to queue email job")
 		return
 	}
@@ -524,6 +662,7 @@ func (h *UserSettingsHandler) VerifyWorkEmail(c *gin.Context) {
 	middleware.SuccessWithStatus(c, http.StatusAccepted, gin.H{
 		"message":    "Work email verification sent",
 		"expires_in": 900, // 15분
+		"job_id":     jobID,
 	}, "Work email verification requested")
 }
 
@@ -569,20 +708,9 @@ func (h *UserSettingsHandler) SubmitProfessionalDoc(c *gin.Context) {
 		return
 	}
 
-	// 파일 업로드 작업을 워커에 전달
-	fileUploadJob := map[string]interface{}{
-		"type":         "upload_verification_doc",
-		"doc_type":     "professional",
-		"user_id":      userID,
-		"title":        professionalTitle,
-		"filename":     header.Filename,
-		"content_type": contentType,
-		"size":         header.Size,
-		"timestamp":    time.Now().Unix(),
-	}
-
-	if err := queue.PublishJob("file_processing_queue", fileUploadJob); err != nil {
-		middleware.InternalServerError(c, "Failed to queue file processing job")
+	// 서류 저장 (무작위 저장 키, 서명된 URL로만 열람 가능)
+	stored, ok := h.storeVerificationDoc(c, file, header, allowedTypes)
+	if !ok {
 		return
 	}
 
@@ -601,6 +729,12 @@ func (h *UserSettingsHandler) SubmitProfessionalDoc(c *gin.Context) {
 
 	verification.ProfessionalStatus = models.VerificationPending
 	verification.ProfessionalTitle = professionalTitle
+	verification.ProfessionalDocPath = stored.Path()
+	verification.ProfessionalDocSHA256 = stored.SHA256
+	verification.ProfessionalDocScanStatus = models.DocumentScanPending
+	verification.ProfessionalDocScanDetail = ""
+	verification.ProfessionalDocScannedAt = nil
+	verification.ProfessionalRejectionReason = ""
 
 	if verification.ID == 0 {
 		if err := db.Create(&verification).Error; err != nil {
@@ -614,9 +748,17 @@ func (h *UserSettingsHandler) SubmitProfessionalDoc(c *gin.Context) {
 		}
 	}
 
+	// 레코드 저장 후 검사 작업 전달 (워커가 저장 키와 해시를 레코드와 대조)
+	jobID, ok := h.queueVerificationDocScan(c, &verification, "professional", stored, map[string]interface{}{"title": professionalTitle})
+	if !ok {
+		return
+	}
+
 	middleware.SuccessWithStatus(c, http.StatusAccepted, gin.H{
-		"status":  "pending",
-		"message": "Professional document submitted for review",
+		"status":      "pending",
+		"scan_status": models.DocumentScanPending,
+		"message":     "Professional document submitted for review",
+		"job_id":      jobID,
 	}, "Professional document submitted")
 }
 
@@ -662,20 +804,9 @@ func (h *UserSettingsHandler) SubmitEducationDoc(c *gin.Context) {
 		return
 	}
 
-	// 파일 업로드 작업을 워커에 전달
-	fileUploadJob := map[string]interface{}{
-		"type":         "upload_verification_doc",
-		"doc_type":     "education",
-		"user_id":      userID,
-		"degree":       educationDegree,
-		"filename":     header.Filename,
-		"content_type": contentType,
-		"size":         header.Size,
-		"timestamp":    time.Now().Unix(),
-	}
-
-	if err := queue.PublishJob("file_processing_queue", fileUploadJob); err != nil {
-		middleware.InternalServerError(c, "Failed to queue file processing job")
+	// 서류 저장 (무작위 저장 키, 서명된 URL로만 열람 가능)
+	stored, ok := h.storeVerificationDoc(c, file, header, allowedTypes)
+	if !ok {
 		return
 	}
 
@@ -694,6 +825,12 @@ func (h *UserSettingsHandler) SubmitEducationDoc(c *gin.Context) {
 
 	verification.EducationStatus = models.VerificationPending
 	verification.EducationDegree = educationDegree
+	verification.EducationDocPath = stored.Path()
+	verification.EducationDocSHA256 = stored.SHA256
+	verification.EducationDocScanStatus = models.DocumentScanPending
+	verification.EducationDocScanDetail = ""
+	verification.EducationDocScannedAt = nil
+	verification.EducationRejectionReason = ""
 
 	if verification.ID == 0 {
 		if err := db.Create(&verification).Error; err != nil {
@@ -707,8 +844,162 @@ func (h *UserSettingsHandler) SubmitEducationDoc(c *gin.Context) {
 		}
 	}
 
+	// 레코드 저장 후 검사 작업 전달 (워커가 저장 키와 해시를 레코드와 대조)
+	jobID, ok := h.queueVerificationDocScan(c, &verification, "education", stored, map[string]interface{}{"degree": educationDegree})
+	if !ok {
+		return
+	}
+
 	middleware.SuccessWithStatus(c, http.StatusAccepted, gin.H{
-		"status":  "pending",
-		"message": "Education document submitted for review",
+		"status":      "pending",
+		"scan_status": models.DocumentScanPending,
+		"message":     "Education document submitted for review",
+		"job_id":      jobID,
 	}, "Education document submitted")
 }
+
+// storeVerificationDoc 검증 서류 저장 (업로드 내용으로 형식 재확인)
+func (h *UserSettingsHandler) storeVerificationDoc(c *gin.Context, file multipart.File, header *multipart.FileHeader, allowedTypes map[string]bool) (*services.StoredFile, bool) {
+	stored, err := h.fileService.StoreFile(file, header, "verification_docs")
+	if err != nil {
+		middleware.InternalServerError(c, "Failed to store document")
+		return nil, false
+	}
+
+	// 클라이언트가 보낸 Content-Type과 무관하게 실제 내용 기준으로 검사
+	if !allowedTypes[stored.ContentType] {
+		h.fileService.RemoveStoredFile(stored)
+		middleware.BadRequest(c, "Invalid file type. Only JPEG, PNG, PDF allowed")
+		return nil, false
+	}
+
+	return stored, true
+}
+
+// queueVerificationDocScan 저장된 검증 서류의 무결성/악성코드 검사 작업을 워커에 전달 (작업 ID 반환)
+// 작업 등록에 실패하면 검사 상태를 failed로 남기고 500 응답
+func (h *UserSettingsHandler) queueVerificationDocScan(c *gin.Context, verification *models.UserVerification, docType string, stored *services.StoredFile, extra map[string]interface{}) (string, bool) {
+	fileUploadJob := map[string]interface{}{
+		"type":         "upload_verification_doc",
+		"doc_type":     docType,
+		"user_id":      verification.UserID,
+		"filename":     stored.OriginalName,
+		"storage_key":  stored.Path(),
+		"content_type": stored.ContentType,
+		"size":         stored.Size,
+		"sha256":       stored.SHA256,
+		"timestamp":    time.Now().Unix(),
+	}
+	for key, value := range extra {
+		fileUploadJob[key] = value
+	}
+
+	jobID, err := queue.PublishTrackedJob("file_processing_queue", fileUploadJob)
+	if err != nil {
+		database.GetDB().Model(verification).Updates(map[string]interface{}{
+			docType + "_doc_scan_status": models.DocumentScanFailed,
+			docType + "_doc_scan_detail": "검사 작업 등록 실패, 다시 제출해 주세요",
+		})
+		middleware.InternalServerError(c, "Failed to queue file processing job")
+		return "", false
+	}
+	return jobID, true
+}
+
+// GetVerificationDocURL 본인이 제출한 검증 서류 열람용 서명 URL 발급 (10분 유효)
+// GET /api/v1/users/me/verify/documents/:doc_type/url
+func (h *UserSettingsHandler) GetVerificationDocURL(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	db := database.GetDB()
+	var verification models.UserVerification
+	if err := db.Where("user_id = ?", userID).First(&verification).Error; err != nil {
+		middleware.NotFound(c, "Verification record not found")
+		return
+	}
+
+	var docPath string
+	var scanStatus models.DocumentScanStatus
+	switch c.Param("doc_type") {
+	case "professional":
+		docPath, scanStatus = verification.ProfessionalDocPath, verification.ProfessionalDocScanStatus
+	case "education":
+		docPath, scanStatus = verification.EducationDocPath, verification.EducationDocScanStatus
+	default:
+		middleware.BadRequest(c, "Invalid document type")
+		return
+	}
+	if docPath == "" {
+		middleware.NotFound(c, "Document not submitted")
+		return
+	}
+	// 악성코드가 감지되었거나 무결성 확인에 실패한 서류는 열람 차단
+	if scanStatus == models.DocumentScanInfected || scanStatus == models.DocumentScanFailed {
+		middleware.Forbidden(c, "Document failed security scan")
+		return
+	}
+
+	const ttl = 10 * time.Minute
+	signedURL, err := h.fileService.SignedURL(docPath, ttl)
+	if err != nil {
+		middleware.NotFound(c, "Document not available")
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"url":        signedURL,
+		"expires_in": int(ttl.Seconds()),
+	}, "Document URL issued")
+}
+
+const (
+	phoneCodeTTL            = 5 * time.Minute
+	phoneCodeResendInterval = time.Minute
+	phoneCodeDailyLimit     = 5
+	phoneCodeMaxAttempts    = 5
+)
+
+// pendingPhoneVerification 발송한 휴대폰 인증 코드와 대상 번호
+type pendingPhoneVerification struct {
+	Code        string `json:"code"`
+	PhoneNumber string `json:"phone_number"`
+}
+
+// e164Pattern 국제 전화번호 형식 (+국가번호 포함 8~15자리)
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+
+// normalizePhoneNumber 공백/하이픈/괄호를 제거하고 E.164로 변환 (0으로 시작하는 번호는 국내 번호로 간주)
+func normalizePhoneNumber(raw string) (string, bool) {
+	cleaned := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '(', ')', '.':
+			return -1
+		}
+		return r
+	}, raw)
+
+	if strings.HasPrefix(cleaned, "0") {
+		if len(cleaned) < 10 || len(cleaned) > 11 || !strings.HasPrefix(cleaned, "01") {
+			return "", false
+		}
+		cleaned = "+82" + cleaned[1:]
+	}
+	return cleaned, e164Pattern.MatchString(cleaned)
+}
+
+// generateNumericCode crypto/rand 기반 n자리 숫자 코드
+func generateNumericCode(digits int) (string, error) {
+	limit := big.NewInt(1)
+	for i := 0; i < digits; i++ {
+		limit.Mul(limit, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", digits, n), nil
+}
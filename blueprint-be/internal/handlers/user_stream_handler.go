@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"io"
+	"log"
+	"time"
+
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// userStreamKeepAlive 프록시 유휴 타임아웃을 피하기 위한 주석 프레임 간격
+const userStreamKeepAlive = 25 * time.Second
+
+// UserStreamHandler 로그인 사용자 비공개 SSE 스트림 핸들러
+type UserStreamHandler struct {
+	userStreamService *services.UserStreamService
+}
+
+// NewUserStreamHandler 생성자
+func NewUserStreamHandler(userStreamService *services.UserStreamService) *UserStreamHandler {
+	return &UserStreamHandler{userStreamService: userStreamService}
+}
+
+// StreamMe 내 주문 체결/취소, 지갑 잔액, 알림(검증 결과, 분쟁 진행) 실시간 스트림
+// GET /api/v1/stream/me
+func (h *UserStreamHandler) StreamMe(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+	uid := userID.(uint)
+
+	events, unsubscribe := h.userStreamService.Subscribe(uid)
+	defer unsubscribe()
+
+	// SSE 헤더 설정
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	log.Printf("🔗 User stream connected for user %d", uid)
+	defer log.Printf("🔌 User stream disconnected for user %d", uid)
+
+	c.Writer.Write(services.FormatUserEvent("connection", gin.H{"status": "connected", "user_id": uid}))
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(userStreamKeepAlive)
+	defer ticker.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case frame := <-events:
+			_, err := w.Write(frame)
+			return err == nil
+		case <-ticker.C:
+			_, err := w.Write([]byte(": ping\n\n"))
+			return err == nil
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -53,6 +54,10 @@ func (h *VerificationHandler) SubmitProof(c *gin.Context) {
 	// 4. 증거 제출 처리
 	proof, err := h.verificationService.SubmitProof(&req, userID.(uint))
 	if err != nil {
+		if errors.Is(err, services.ErrProjectForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -329,6 +334,27 @@ func (h *VerificationHandler) GetMilestoneProofs(c *gin.Context) {
 	})
 }
 
+// GetMilestoneStatusHistory 마일스톤 상태 전환 이력 조회
+// GET /api/v1/milestones/:id/status-history
+func (h *VerificationHandler) GetMilestoneStatusHistory(c *gin.Context) {
+	milestoneID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 마일스톤 ID입니다"})
+		return
+	}
+
+	history, err := h.verificationService.GetMilestoneStatusHistory(uint(milestoneID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"milestone_id": milestoneID,
+		"history":      history,
+	})
+}
+
 // GetVerificationStats 검증 통계 조회
 // GET /api/v1/verification/stats
 func (h *VerificationHandler) GetVerificationStats(c *gin.Context) {
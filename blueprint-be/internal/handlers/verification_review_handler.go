@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VerificationReviewHandler 전문 자격/학력 서류 심사 핸들러 (운영자)
+type VerificationReviewHandler struct {
+	reviewService *services.VerificationReviewService
+}
+
+// NewVerificationReviewHandler 생성자
+func NewVerificationReviewHandler(reviewService *services.VerificationReviewService) *VerificationReviewHandler {
+	return &VerificationReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+// GetPendingVerifications 심사 대기 서류 목록
+// GET /api/v1/admin/verifications/pending?doc_type=professional|education
+func (h *VerificationReviewHandler) GetPendingVerifications(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	docs, total, err := h.reviewService.ListPending(c.DefaultQuery("doc_type", "professional"), limit, offset)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCredentialType) {
+			middleware.BadRequest(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"verifications": docs,
+		"total":         total,
+		"limit":         limit,
+		"offset":        offset,
+	}, "서류 심사 대기 목록 조회 성공")
+}
+
+// GetVerificationDocument 심사 대상 서류 열람 URL
+// GET /api/v1/admin/verifications/users/:user_id/:doc_type/document
+func (h *VerificationReviewHandler) GetVerificationDocument(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	url, err := h.reviewService.DocumentURL(uint(userID), c.Param("doc_type"))
+	if err != nil {
+		if errors.Is(err, services.ErrCredentialNotScanned) {
+			middleware.Forbidden(c, err.Error())
+			return
+		}
+		middleware.BadRequest(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{"url": url}, "서류 열람 URL 발급")
+}
+
+// ReviewVerification 서류 승인/거절
+// POST /api/v1/admin/verifications/users/:user_id/:doc_type/review
+func (h *VerificationReviewHandler) ReviewVerification(c *gin.Context) {
+	reviewerID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	var req models.ReviewVerificationDocRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	record, err := h.reviewService.Review(reviewerID.(uint), uint(userID), c.Param("doc_type"), &req)
+	if err != nil {
+		if errors.Is(err, services.ErrCredentialNotPending) {
+			middleware.Conflict(c, err.Error())
+			return
+		}
+		middleware.BadRequest(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, record, "서류 심사가 완료되었습니다")
+}
@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// WalletAccountHandler 통화별 지갑 계정/환율/환전 핸들러
+type WalletAccountHandler struct {
+	currencyService *services.CurrencyService
+}
+
+// NewWalletAccountHandler 생성자
+func NewWalletAccountHandler(currencyService *services.CurrencyService) *WalletAccountHandler {
+	return &WalletAccountHandler{currencyService: currencyService}
+}
+
+// ListAccounts 내 통화별 잔액 (usdc, blueprint, 설정으로 추가한 결제 통화)
+// GET /api/v1/wallet/accounts
+func (h *WalletAccountHandler) ListAccounts(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	accounts, err := h.currencyService.ListAccounts(userID.(uint))
+	if err != nil {
+		if errors.Is(err, services.ErrWalletNotFound) {
+			middleware.NotFound(c, err.Error())
+			return
+		}
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{"accounts": accounts}, "지갑 계정 조회 성공")
+}
+
+// ListRates 결제 통화 목록과 환율 (1 통화 = rate USDC)
+// GET /api/v1/wallet/rates
+func (h *WalletAccountHandler) ListRates(c *gin.Context) {
+	rates, err := h.currencyService.ListRates()
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"quote_currencies": h.currencyService.QuoteCurrencies(),
+		"rates":            rates,
+	}, "환율 조회 성공")
+}
+
+// QuoteConversion 환전 견적
+// GET /api/v1/wallet/convert/quote?from=usdc&to=krws&amount=10000
+func (h *WalletAccountHandler) QuoteConversion(c *gin.Context) {
+	amount, err := strconv.ParseInt(c.Query("amount"), 10, 64)
+	if err != nil || amount <= 0 {
+		middleware.BadRequest(c, "Invalid amount")
+		return
+	}
+
+	from := models.LedgerCurrency(strings.ToLower(c.Query("from")))
+	to := models.LedgerCurrency(strings.ToLower(c.Query("to")))
+	quote, err := h.currencyService.Quote(from, to, amount)
+	if err != nil {
+		h.respondCurrencyError(c, err)
+		return
+	}
+
+	middleware.Success(c, quote, "환전 견적 조회 성공")
+}
+
+// Convert 결제 통화 계정 간, BLUEPRINT와 USDC 간 환전 (일일 한도)
+// POST /api/v1/wallet/convert
+func (h *WalletAccountHandler) Convert(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req models.ConvertCurrencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+	req.From = models.LedgerCurrency(strings.ToLower(string(req.From)))
+	req.To = models.LedgerCurrency(strings.ToLower(string(req.To)))
+
+	conversion, err := h.currencyService.Convert(userID.(uint), req)
+	if err != nil {
+		h.respondCurrencyError(c, err)
+		return
+	}
+
+	middleware.Success(c, conversion, "환전 완료")
+}
+
+// GetHistory 내 지갑 원장 내역 (환전, 체결 외 잔액 변동)
+// GET /api/v1/wallet/history?currency=blueprint&entry_type=currency_convert_in&before=120&limit=50
+func (h *WalletAccountHandler) GetHistory(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var query models.WalletHistoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.BadRequest(c, "Invalid query: "+err.Error())
+		return
+	}
+	query.Currency = models.LedgerCurrency(strings.ToLower(string(query.Currency)))
+
+	entries, err := h.currencyService.History(userID.(uint), query)
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{"entries": entries, "count": len(entries)}, "지갑 내역 조회 성공")
+}
+
+// SetRate 추가 결제 통화/BLUEPRINT 환율 지정 (관리자)
+// PUT /api/v1/admin/currencies/:currency/rate
+func (h *WalletAccountHandler) SetRate(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req models.SetExchangeRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	currency := models.LedgerCurrency(strings.ToLower(c.Param("currency")))
+	rate, err := h.currencyService.SetRate(currency, req.Rate, adminID.(uint))
+	if err != nil {
+		h.respondCurrencyError(c, err)
+		return
+	}
+
+	middleware.Success(c, rate, "환율 변경 완료")
+}
+
+// SetMarketQuoteCurrency 마켓 결제 통화 지정 (관리자, 첫 주문 전까지)
+// PUT /api/v1/admin/markets/:id/quote-currency
+func (h *WalletAccountHandler) SetMarketQuoteCurrency(c *gin.Context) {
+	milestoneID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid milestone ID")
+		return
+	}
+
+	var req models.SetQuoteCurrencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	milestone, err := h.currencyService.SetMarketQuoteCurrency(uint(milestoneID), models.LedgerCurrency(strings.ToLower(string(req.Currency))))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			middleware.NotFound(c, "Milestone not found")
+			return
+		}
+		if errors.Is(err, services.ErrQuoteCurrencyLocked) {
+			middleware.Conflict(c, err.Error())
+			return
+		}
+		h.respondCurrencyError(c, err)
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"milestone_id":   milestone.ID,
+		"quote_currency": milestone.QuoteCurrency,
+	}, "마켓 결제 통화 변경 완료")
+}
+
+// respondCurrencyError 통화/환전 오류를 응답 코드로 변환
+func (h *WalletAccountHandler) respondCurrencyError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrUnsupportedCurrency), errors.Is(err, services.ErrSameCurrency),
+		errors.Is(err, services.ErrInsufficientBalance):
+		middleware.BadRequest(c, err.Error())
+	case errors.Is(err, services.ErrExchangeRateNotFound):
+		middleware.Conflict(c, err.Error())
+	case errors.Is(err, services.ErrDailyConvertLimit):
+		middleware.Forbidden(c, err.Error())
+	case errors.Is(err, services.ErrWalletNotFound):
+		middleware.NotFound(c, err.Error())
+	default:
+		middleware.InternalServerError(c, err.Error())
+	}
+}
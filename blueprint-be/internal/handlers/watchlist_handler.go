@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WatchlistHandler 프로젝트 팔로우(관심 목록) 핸들러
+type WatchlistHandler struct {
+	watchlistService *services.WatchlistService
+}
+
+// NewWatchlistHandler 생성자
+func NewWatchlistHandler(watchlistService *services.WatchlistService) *WatchlistHandler {
+	return &WatchlistHandler{
+		watchlistService: watchlistService,
+	}
+}
+
+// WatchProject 프로젝트 팔로우
+// POST /api/v1/projects/:id/watch
+func (h *WatchlistHandler) WatchProject(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid project ID")
+		return
+	}
+
+	status, err := h.watchlistService.Watch(userID.(uint), uint(projectID))
+	if err != nil {
+		if errors.Is(err, services.ErrProjectNotWatchable) {
+			middleware.Forbidden(c, err.Error())
+			return
+		}
+		middleware.BadRequest(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, status, "프로젝트를 팔로우했습니다")
+}
+
+// UnwatchProject 프로젝트 팔로우 해제
+// DELETE /api/v1/projects/:id/watch
+func (h *WatchlistHandler) UnwatchProject(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid project ID")
+		return
+	}
+
+	status, err := h.watchlistService.Unwatch(userID.(uint), uint(projectID))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, status, "프로젝트 팔로우를 해제했습니다")
+}
+
+// GetMyWatchlist 내가 팔로우한 프로젝트 목록
+// GET /api/v1/users/me/watchlist
+func (h *WatchlistHandler) GetMyWatchlist(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	watchlist, err := h.watchlistService.GetWatchlist(userID.(uint))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, watchlist, "관심 프로젝트 목록 조회 성공")
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler 외부 웹훅 구독 핸들러
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+}
+
+// NewWebhookHandler 생성자
+func NewWebhookHandler(webhookService *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// CreateWebhook 웹훅 등록
+// POST /api/v1/users/me/webhooks
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req models.CreateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	result, err := h.webhookService.CreateEndpoint(userID.(uint), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	middleware.SuccessWithStatus(c, http.StatusCreated, result, "웹훅이 등록되었습니다. secret은 다시 조회할 수 없으니 안전하게 보관하세요")
+}
+
+// ListWebhooks 내 웹훅 목록
+// GET /api/v1/users/me/webhooks
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	endpoints, err := h.webhookService.ListEndpoints(userID.(uint))
+	if err != nil {
+		middleware.InternalServerError(c, err.Error())
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"webhooks": endpoints,
+		"events":   models.ValidWebhookEventTypes,
+		"count":    len(endpoints),
+	}, "웹훅 목록 조회 성공")
+}
+
+// UpdateWebhook 웹훅 수정
+// PATCH /api/v1/users/me/webhooks/:id
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	endpointID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+
+	var req models.UpdateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	endpoint, err := h.webhookService.UpdateEndpoint(userID.(uint), uint(endpointID), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	middleware.Success(c, endpoint, "웹훅이 수정되었습니다")
+}
+
+// DeleteWebhook 웹훅 삭제
+// DELETE /api/v1/users/me/webhooks/:id
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	endpointID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+
+	if err := h.webhookService.DeleteEndpoint(userID.(uint), uint(endpointID)); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	middleware.Success(c, nil, "웹훅이 삭제되었습니다")
+}
+
+// RotateWebhookSecret 서명 secret 재발급
+// POST /api/v1/users/me/webhooks/:id/rotate-secret
+func (h *WebhookHandler) RotateWebhookSecret(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	endpointID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+
+	result, err := h.webhookService.RotateSecret(userID.(uint), uint(endpointID))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	middleware.Success(c, result, "secret이 재발급되었습니다")
+}
+
+// TestWebhook ping 이벤트 발송
+// POST /api/v1/users/me/webhooks/:id/test
+func (h *WebhookHandler) TestWebhook(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	endpointID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+
+	delivery, err := h.webhookService.SendTestEvent(userID.(uint), uint(endpointID))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	middleware.SuccessWithStatus(c, http.StatusAccepted, delivery, "테스트 이벤트 발송을 요청했습니다")
+}
+
+// GetWebhookDeliveries 웹훅 발송 기록
+// GET /api/v1/users/me/webhooks/:id/deliveries
+func (h *WebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	endpointID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+
+	limit, offset := limitOffsetPagination(c)
+	deliveries, total, err := h.webhookService.GetDeliveries(userID.(uint), uint(endpointID), limit, offset)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	middleware.Success(c, gin.H{
+		"deliveries": deliveries,
+		"total":      total,
+		"limit":      limit,
+		"offset":     offset,
+	}, "웹훅 발송 기록 조회 성공")
+}
+
+// RedeliverWebhook 발송 기록 재전송
+// POST /api/v1/users/me/webhook-deliveries/:id/redeliver
+func (h *WebhookHandler) RedeliverWebhook(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	deliveryID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		middleware.BadRequest(c, "Invalid delivery ID")
+		return
+	}
+
+	delivery, err := h.webhookService.Redeliver(userID.(uint), uint(deliveryID))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	middleware.SuccessWithStatus(c, http.StatusAccepted, delivery, "재전송을 요청했습니다")
+}
+
+func (h *WebhookHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrWebhookEndpointNotFound):
+		middleware.NotFound(c, err.Error())
+	case errors.Is(err, services.ErrWebhookNotProjectOwner):
+		middleware.Forbidden(c, err.Error())
+	default:
+		middleware.BadRequest(c, err.Error())
+	}
+}
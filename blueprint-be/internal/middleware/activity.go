@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"context"
+	"strconv"
+
+	"blueprint-module/pkg/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActivityRecorder 활동 로그 큐 발행기 (logger.ActivityLogger)
+type ActivityRecorder interface {
+	LogActivity(ctx context.Context, req models.CreateActivityLogRequest) error
+}
+
+// activityRef 경로의 :id가 가리키는 대상 (ActivityLog의 연관 컬럼)
+type activityRef int
+
+const (
+	refNone activityRef = iota
+	refOrder
+	refProject
+	refMilestone
+)
+
+// activityRoute 자동 기록할 라우트
+type activityRoute struct {
+	event       models.ActivityEvent
+	description string
+	ref         activityRef
+}
+
+// activityRoutes "METHOD 라우트 경로" → 기록할 활동 (성공한 인증 요청만 기록)
+var activityRoutes = map[string]activityRoute{
+	// 인증/계정 보안
+	"POST /api/v1/auth/logout":                         {models.ActivityAuthLogout, "로그아웃", refNone},
+	"DELETE /api/v1/users/me/sessions":                 {models.ActivityAuthSessionRevoked, "다른 기기 세션 모두 종료", refNone},
+	"DELETE /api/v1/users/me/sessions/:id":             {models.ActivityAuthSessionRevoked, "세션 종료", refNone},
+	"POST /api/v1/users/me/api-keys":                   {models.ActivityAuthAPIKeyCreated, "API 키 발급", refNone},
+	"DELETE /api/v1/users/me/api-keys/:id":             {models.ActivityAuthAPIKeyRevoked, "API 키 폐기", refNone},
+	"DELETE /api/v1/users/me/identities/:provider":     {models.ActivityAuthIdentityUnlinked, "로그인 수단 해제", refNone},
+	"POST /api/v1/users/me/account-merges/:id/confirm": {models.ActivityAuthAccountMerged, "계정 병합", refNone},
+	"DELETE /api/v1/users/me":                          {models.ActivityAuthDeletionRequested, "계정 삭제 요청", refNone},
+	"DELETE /api/v1/users/me/deletion":                 {models.ActivityAuthDeletionCancelled, "계정 삭제 취소", refNone},
+
+	// 거래
+	"POST /api/v1/orders":                      {models.ActivityTradingOrderPlaced, "주문 생성", refNone},
+	"DELETE /api/v1/orders/:id":                {models.ActivityTradingOrderCancelled, "주문 취소", refOrder},
+	"POST /api/v1/parlays":                     {models.ActivityTradingParlayPlaced, "조합 베팅", refNone},
+	"POST /api/v1/projects/:id/roadmap-bundle": {models.ActivityTradingParlayPlaced, "로드맵 묶음 베팅", refProject},
+
+	// 검증
+	"POST /api/v1/users/me/verify/email/confirm": {models.ActivityVerificationContactVerified, "이메일 인증", refNone},
+	"POST /api/v1/users/me/verify/phone/confirm": {models.ActivityVerificationContactVerified, "휴대폰 인증", refNone},
+	"POST /api/v1/users/me/verify/work-email":    {models.ActivityVerificationDocSubmitted, "회사 이메일 인증 요청", refNone},
+	"POST /api/v1/users/me/verify/professional":  {models.ActivityVerificationDocSubmitted, "경력 증빙 제출", refNone},
+	"POST /api/v1/users/me/verify/education":     {models.ActivityVerificationDocSubmitted, "학력 증빙 제출", refNone},
+	"POST /api/v1/users/me/connect/:provider":    {models.ActivityVerificationProviderConnected, "소셜 계정 연결", refNone},
+	"POST /api/v1/users/me/kyc":                  {models.ActivityVerificationKYCSubmitted, "본인 인증 신청", refNone},
+	"POST /api/v1/milestones/:id/proof":          {models.ActivityVerificationProofSubmitted, "마일스톤 증거 제출", refMilestone},
+	"POST /api/v1/proofs/:id/validate":           {models.ActivityVerificationProofValidated, "증거 검증 투표", refNone},
+	"POST /api/v1/proofs/:id/dispute":            {models.ActivityVerificationProofDisputed, "증거 분쟁 제기", refNone},
+
+	// 분쟁 해결
+	"POST /api/v1/arbitration/cases":              {models.ActivityArbitrationCaseOpened, "분쟁 제기", refNone},
+	"POST /api/v1/arbitration/cases/:id/vote":     {models.ActivityArbitrationVoteCommitted, "배심원 투표 제출", refNone},
+	"POST /api/v1/arbitration/cases/:id/reveal":   {models.ActivityArbitrationVoteRevealed, "배심원 투표 공개", refNone},
+	"POST /api/v1/arbitration/cases/:id/appeal":   {models.ActivityArbitrationAppealed, "판결 이의제기", refNone},
+	"POST /api/v1/arbitration/cases/:id/evidence": {models.ActivityArbitrationEvidenceAdded, "분쟁 증거 제출", refNone},
+	"POST /api/v1/arbitration/juror/register":     {models.ActivityArbitrationJurorRegistered, "배심원 등록", refNone},
+
+	// 스테이킹
+	"POST /api/v1/mentors/:id/stake":     {models.ActivityStakingMentorStaked, "멘토 스테이킹", refNone},
+	"POST /api/v1/stakes/:id/unstake":    {models.ActivityStakingUnstaked, "스테이킹 해제", refNone},
+	"POST /api/v1/mentors/rewards/claim": {models.ActivityStakingRewardsClaimed, "멘토 풀 보상 청구", refNone},
+	"POST /api/v1/staking/rewards/claim": {models.ActivityStakingRewardsClaimed, "스테이킹 보상 청구", refNone},
+}
+
+// ActivityCapture 성공한 인증 요청 중 activityRoutes에 등록된 라우트를 활동 로그 큐로 기록
+func ActivityCapture(recorder ActivityRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		route, ok := activityRoutes[c.Request.Method+" "+c.FullPath()]
+		if !ok || c.Writer.Status() >= 400 {
+			return
+		}
+		userID := c.GetUint("user_id")
+		if userID == 0 {
+			return
+		}
+
+		req := route.event.Request(userID, route.description)
+		req.Metadata.IPAddress = c.ClientIP()
+		req.Metadata.UserAgent = c.Request.UserAgent()
+		req.Metadata.AuthMethod = c.GetString("auth_method")
+		if req.Metadata.AuthMethod == "" {
+			req.Metadata.AuthMethod = "session"
+		}
+
+		resourceID := c.Param("id")
+		if resourceID == "" {
+			resourceID = c.Param("provider")
+		}
+		req.Metadata.ResourceID = resourceID
+		if id, err := strconv.ParseUint(resourceID, 10, 32); err == nil {
+			ref := uint(id)
+			switch route.ref {
+			case refOrder:
+				req.OrderID = &ref
+			case refProject:
+				req.ProjectID = &ref
+			case refMilestone:
+				req.MilestoneID = &ref
+			}
+		}
+
+		// 기록 실패는 응답에 영향을 주지 않음 (LogActivity가 로그를 남김)
+		_ = recorder.LogActivity(context.Background(), req)
+	}
+}
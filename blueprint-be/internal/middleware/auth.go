@@ -1,15 +1,32 @@
 package middleware
 
 import (
+	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
 	"blueprint/internal/config"
+	"blueprint/internal/database"
 	"blueprint/pkg/utils"
+	"bytes"
+	"errors"
+	"io"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
+// AuthMiddleware JWT 세션 인증 (비활성화된 계정은 거부)
 func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
+	return authMiddleware(cfg, false)
+}
+
+// DeactivatedAccountAuthMiddleware 삭제 예약으로 비활성화된 계정도 허용하는 JWT 세션 인증 (예약 조회/취소 라우트 전용)
+func DeactivatedAccountAuthMiddleware(cfg *config.Config) gin.HandlerFunc {
+	return authMiddleware(cfg, true)
+}
+
+func authMiddleware(cfg *config.Config, allowDeactivated bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -34,10 +51,21 @@ func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
 			return
 		}
 
+		// 로그아웃/폐기된 세션의 토큰 차단
+		if moduleRedis.IsSessionRevoked(claims.SessionID) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Session has been revoked"})
+			c.Abort()
+			return
+		}
+		if !checkAccount(c, claims.UserID, allowDeactivated) {
+			return
+		}
+
 		// 사용자 정보를 context에 저장
 		c.Set("user_id", claims.UserID)
 		c.Set("user_email", claims.Email)
 		c.Set("username", claims.Username)
+		c.Set("session_id", claims.SessionID)
 
 		c.Next()
 	}
@@ -59,12 +87,113 @@ func OptionalAuthMiddleware(cfg *config.Config) gin.HandlerFunc {
 		}
 
 		claims, err := utils.ValidateToken(tokenString, cfg.JWT.Secret)
-		if err == nil && claims != nil {
+		if err == nil && claims != nil && !moduleRedis.IsSessionRevoked(claims.SessionID) {
 			c.Set("user_id", claims.UserID)
 			c.Set("user_email", claims.Email)
 			c.Set("username", claims.Username)
+			c.Set("session_id", claims.SessionID)
+		}
+
+		c.Next()
+	}
+}
+
+// API 키 인증 헤더
+const (
+	HeaderAPIKey       = "X-BP-API-KEY"
+	HeaderAPITimestamp = "X-BP-TIMESTAMP" // 유닉스 밀리초
+	HeaderAPISignature = "X-BP-SIGNATURE" // hex(HMAC-SHA256(secret, timestamp + METHOD + requestURI + body))
+)
+
+// 서명 대상 요청 본문 최대 크기
+const maxSignedBodySize = 1 << 20
+
+// APIKeyAuthenticator API 키 서명 검증기 (services.APIKeyService)
+type APIKeyAuthenticator interface {
+	Authenticate(keyID, timestamp, signature, method, requestURI string, body []byte, clientIP string) (*models.APIKey, error)
+}
+
+// SessionOrAPIKeyMiddleware JWT 세션 또는 HMAC 서명 API 키 인증
+// API 키 요청은 해당 라우트에 필요한 scope를 보유해야 한다.
+func SessionOrAPIKeyMiddleware(cfg *config.Config, authenticator APIKeyAuthenticator, scope models.APIKeyScope) gin.HandlerFunc {
+	sessionAuth := AuthMiddleware(cfg)
+
+	return func(c *gin.Context) {
+		keyID := c.GetHeader(HeaderAPIKey)
+		if keyID == "" {
+			sessionAuth(c)
+			return
+		}
+
+		// 한도를 1바이트 넘게 읽어 잘린 본문으로 서명을 검증하지 않도록 초과 여부 확인
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxSignedBodySize+1))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+			c.Abort()
+			return
+		}
+		if len(body) > maxSignedBodySize {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Request body too large for API key signature"})
+			c.Abort()
+			return
 		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		apiKey, err := authenticator.Authenticate(
+			keyID,
+			c.GetHeader(HeaderAPITimestamp),
+			c.GetHeader(HeaderAPISignature),
+			c.Request.Method,
+			c.Request.URL.RequestURI(),
+			body,
+			c.ClientIP(),
+		)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+
+		if !checkAccount(c, apiKey.UserID, false) {
+			return
+		}
+
+		if !apiKey.HasScope(scope) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "API key does not have the required scope: " + string(scope)})
+			c.Abort()
+			return
+		}
+
+		// 사용자 정보를 context에 저장 (API 키 인증 표시)
+		c.Set("user_id", apiKey.UserID)
+		c.Set("api_key_id", apiKey.ID)
+		c.Set("auth_method", "api_key")
 
 		c.Next()
 	}
 }
+
+// checkAccount 삭제 예약으로 비활성화되었거나 다른 계정에 병합/삭제된 계정의 토큰/API 키 차단 (거부하면 응답 후 false)
+func checkAccount(c *gin.Context, userID uint, allowDeactivated bool) bool {
+	db := database.GetDB()
+	if db == nil {
+		return true
+	}
+
+	var user models.User
+	err := db.Select("id", "is_active", "merged_into_id").First(&user, userID).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Account not found"})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check account status"})
+	case user.MergedIntoID != nil:
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Account has been merged into another account"})
+	case !user.IsActive && !allowDeactivated:
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Account is deactivated"})
+	default:
+		return true
+	}
+	c.Abort()
+	return false
+}
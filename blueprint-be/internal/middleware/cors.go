@@ -1,23 +1,63 @@
 package middleware
 
 import (
+	"net/http"
+	"strings"
+
 	"blueprint/internal/config"
 
 	"github.com/gin-gonic/gin"
 )
 
+// CORSMiddleware 허용된 출처(CORS_ALLOWED_ORIGINS, 기본 FRONTEND_URL)에만 CORS 응답 헤더를 붙임
+// 허용되지 않은 출처의 preflight는 403으로 거절한다
 func CORSMiddleware(cfg *config.Config) gin.HandlerFunc {
+	origins := cfg.Security.AllowedOrigins
+	if len(origins) == 0 {
+		origins = []string{cfg.Server.FrontendURL}
+	}
+
 	return func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", cfg.Server.FrontendURL)
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
+		origin := c.GetHeader("Origin")
+		c.Writer.Header().Add("Vary", "Origin")
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
+		if origin != "" && originAllowed(origins, origin) {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+			c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, If-None-Match")
+			c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
+			c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag")
+		} else if origin != "" && c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusNoContent)
 			return
 		}
 
 		c.Next()
 	}
 }
+
+// originAllowed 정확히 일치하거나 "scheme://*.domain" 패턴의 하위 도메인이면 허용
+func originAllowed(allowed []string, origin string) bool {
+	for _, pattern := range allowed {
+		pattern = strings.TrimSuffix(pattern, "/")
+		if pattern == origin {
+			return true
+		}
+
+		scheme, host, found := strings.Cut(pattern, "://*.")
+		if !found {
+			continue
+		}
+		prefix := scheme + "://"
+		if strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, "."+host) &&
+			len(origin) > len(prefix)+len(host)+1 {
+			return true
+		}
+	}
+	return false
+}
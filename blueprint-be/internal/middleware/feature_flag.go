@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"blueprint-module/pkg/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeatureGate 기능 플래그 확인기 (services.FeatureFlagService)
+type FeatureGate interface {
+	IsEnabled(key models.FeatureFlagKey) bool
+	IsEnabledFor(key models.FeatureFlagKey, userID uint) bool
+}
+
+// RequireFeature 기능 플래그가 켜진 경우에만 허용 (인증된 요청이면 사용자별 롤아웃 적용)
+func RequireFeature(gate FeatureGate, key models.FeatureFlagKey) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		enabled := gate.IsEnabled(key)
+		if userID, exists := c.Get("user_id"); exists {
+			enabled = gate.IsEnabledFor(key, userID.(uint))
+		}
+		if !enabled {
+			Forbidden(c, "이 기능은 현재 사용할 수 없습니다 ("+string(key)+")")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"blueprint-module/pkg/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoleAuthorizer 역할/권한 확인기 (services.RoleService)
+type RoleAuthorizer interface {
+	HasRole(userID uint, roles ...models.Role) (bool, error)
+	HasPermission(userID uint, permission models.Permission) (bool, error)
+}
+
+// RequireRole 지정한 역할 중 하나를 보유한 사용자만 허용 (AuthMiddleware 이후에 사용)
+func RequireRole(authorizer RoleAuthorizer, roles ...models.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := authenticatedUserID(c)
+		if !ok {
+			return
+		}
+
+		allowed, err := authorizer.HasRole(userID, roles...)
+		if err != nil {
+			InternalServerError(c, "권한 확인에 실패했습니다")
+			c.Abort()
+			return
+		}
+		if !allowed {
+			Forbidden(c, "이 작업을 수행할 권한이 없습니다")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequirePermission 지정한 권한을 보유한 사용자만 허용 (AuthMiddleware 이후에 사용)
+func RequirePermission(authorizer RoleAuthorizer, permission models.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := authenticatedUserID(c)
+		if !ok {
+			return
+		}
+
+		allowed, err := authorizer.HasPermission(userID, permission)
+		if err != nil {
+			InternalServerError(c, "권한 확인에 실패했습니다")
+			c.Abort()
+			return
+		}
+		if !allowed {
+			Forbidden(c, "이 작업을 수행할 권한이 없습니다 ("+string(permission)+")")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func authenticatedUserID(c *gin.Context) (uint, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		Unauthorized(c, "User not authenticated")
+		c.Abort()
+		return 0, false
+	}
+	return userID.(uint), true
+}
@@ -69,6 +69,10 @@ func Unauthorized(c *gin.Context, error string) {
 	Error(c, http.StatusUnauthorized, error, "Unauthorized")
 }
 
+func Forbidden(c *gin.Context, error string) {
+	Error(c, http.StatusForbidden, error, "Forbidden")
+}
+
 func InternalServerError(c *gin.Context, error string) {
 	Error(c, http.StatusInternalServerError, error, "Internal Server Error")
 }
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+
+	"blueprint/internal/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SecurityHeaders 모든 응답에 기본 보안 헤더 추가 (CSP, HSTS, 스니핑/프레임 차단)
+func SecurityHeaders(cfg *config.Config) gin.HandlerFunc {
+	hsts := ""
+	if cfg.Security.HSTSMaxAgeSeconds > 0 {
+		hsts = fmt.Sprintf("max-age=%d; includeSubDomains", cfg.Security.HSTSMaxAgeSeconds)
+	}
+
+	return func(c *gin.Context) {
+		header := c.Writer.Header()
+		header.Set("X-Content-Type-Options", "nosniff")
+		header.Set("X-Frame-Options", "DENY")
+		header.Set("Referrer-Policy", "strict-origin-when-cross-origin")
+		header.Set("Permissions-Policy", "camera=(), microphone=(), geolocation=()")
+		header.Set("Cross-Origin-Opener-Policy", "same-origin")
+		if cfg.Security.ContentSecurityPolicy != "" {
+			header.Set("Content-Security-Policy", cfg.Security.ContentSecurityPolicy)
+		}
+		if hsts != "" {
+			header.Set("Strict-Transport-Security", hsts)
+		}
+
+		c.Next()
+	}
+}
+
+// ConfigureTrustedProxies c.ClientIP()가 믿을 프록시 설정 (주문 감사 필드의 IP가 위조되지 않도록)
+//
+// TRUSTED_PROXIES가 비어 있으면 X-Forwarded-For를 무시하고 접속 IP를 그대로 쓴다.
+// TRUSTED_PLATFORM은 CDN/플랫폼이 넣어주는 클라이언트 IP 헤더를 우선 사용한다.
+func ConfigureTrustedProxies(router *gin.Engine, cfg *config.Config) error {
+	if err := router.SetTrustedProxies(cfg.Security.TrustedProxies); err != nil {
+		return fmt.Errorf("TRUSTED_PROXIES 형식 오류: %w", err)
+	}
+
+	switch strings.ToLower(cfg.Security.TrustedPlatform) {
+	case "":
+	case "cloudflare":
+		router.TrustedPlatform = gin.PlatformCloudflare
+	case "google":
+		router.TrustedPlatform = gin.PlatformGoogleAppEngine
+	case "flyio":
+		router.TrustedPlatform = "Fly-Client-IP"
+	default:
+		router.TrustedPlatform = cfg.Security.TrustedPlatform
+	}
+	return nil
+}
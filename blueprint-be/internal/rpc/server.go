@@ -0,0 +1,154 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"time"
+
+	"blueprint-module/pkg/internalapi"
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// Server 워커/스케줄러가 호출하는 내부 gRPC API (internalapi.proto)
+//
+// 공유 DB와 Redis 큐로 충분하지 않은 동기 요청만 다룬다. 마켓 상태 판단은 주문 접수와 같은 규칙
+// (마일스톤 상태, 거래 마감, 거래 중단, 서킷브레이커)을 쓰고, 정산 예약은 API 서버에서 실행한다.
+type Server struct {
+	internalapi.UnimplementedInternalServiceServer
+
+	db             *gorm.DB
+	matchingEngine services.MatchingEngine
+	settlement     *services.MarketSettlementService
+
+	grpcServer *grpc.Server
+	health     *health.Server
+}
+
+// NewServer 생성자 (token은 호출 측과 공유하는 INTERNAL_API_TOKEN)
+func NewServer(db *gorm.DB, matchingEngine services.MatchingEngine, token string) *Server {
+	s := &Server{
+		db:             db,
+		matchingEngine: matchingEngine,
+		settlement:     services.NewMarketSettlementService(db),
+		health:         health.NewServer(),
+	}
+	s.grpcServer = grpc.NewServer(grpc.UnaryInterceptor(internalapi.TokenInterceptor(token)))
+	internalapi.RegisterInternalServiceServer(s.grpcServer, s)
+	healthpb.RegisterHealthServer(s.grpcServer, s.health)
+	return s
+}
+
+// Serve 리스너에서 요청 처리 (Stop 전까지 블록)
+func (s *Server) Serve(listener net.Listener) error {
+	s.health.SetServingStatus(internalapi.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	log.Printf("🔌 Internal gRPC API listening on %s", listener.Addr())
+	return s.grpcServer.Serve(listener)
+}
+
+// Stop 헬스 체크를 NOT_SERVING으로 바꿔 새 요청을 막고 진행 중인 요청을 마무리
+func (s *Server) Stop() {
+	s.health.Shutdown()
+	s.grpcServer.GracefulStop()
+}
+
+// GetMarketStatus 마켓이 지금 주문을 받을 수 있는지
+func (s *Server) GetMarketStatus(ctx context.Context, req *internalapi.GetMarketStatusRequest) (*internalapi.MarketStatus, error) {
+	milestone, err := s.loadMilestone(ctx, req.GetMilestoneId())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &internalapi.MarketStatus{
+		MilestoneId:     uint32(milestone.ID),
+		Status:          string(milestone.Status),
+		WinningOptionId: milestone.WinningOptionID,
+	}
+	if closesAt := milestone.TradingCloseTime(); closesAt != nil {
+		resp.TradingClosesAt = closesAt.Unix()
+	}
+
+	switch {
+	case !milestone.Status.IsTradable():
+		resp.Reason = "frozen"
+	case milestone.IsTradingClosed(time.Now()):
+		resp.Reason = "closed"
+	case s.matchingEngine.TradingPause().Check(milestone.ID) != nil,
+		s.matchingEngine.CircuitBreaker().Check(milestone) != nil:
+		resp.Reason = "halted"
+	default:
+		resp.TradingOpen = true
+	}
+	return resp, nil
+}
+
+// GetWalletBalance 사용자 지갑 잔액 (센트)
+func (s *Server) GetWalletBalance(ctx context.Context, req *internalapi.GetWalletBalanceRequest) (*internalapi.WalletBalance, error) {
+	var wallet models.UserWallet
+	if err := s.db.WithContext(ctx).Where("user_id = ?", req.GetUserId()).First(&wallet).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "wallet of user %d not found", req.GetUserId())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &internalapi.WalletBalance{
+		UserId:                 uint32(wallet.UserID),
+		UsdcBalance:            wallet.USDCBalance,
+		UsdcLockedBalance:      wallet.USDCLockedBalance,
+		BlueprintBalance:       wallet.BlueprintBalance,
+		BlueprintLockedBalance: wallet.BlueprintLockedBalance,
+	}, nil
+}
+
+// EnqueueResolution 결과가 확정된 마켓의 포지션 정산 예약 (정산은 settled_at 선점으로 중복 실행되지 않음)
+func (s *Server) EnqueueResolution(ctx context.Context, req *internalapi.EnqueueResolutionRequest) (*internalapi.EnqueueResolutionResponse, error) {
+	milestone, err := s.loadMilestone(ctx, req.GetMilestoneId())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &internalapi.EnqueueResolutionResponse{MilestoneId: uint32(milestone.ID)}
+	if milestone.SettledAt != nil {
+		resp.AlreadySettled = true
+		return resp, nil
+	}
+	if milestone.Status != models.MilestoneStatusCompleted && milestone.Status != models.MilestoneStatusFailed {
+		return nil, status.Errorf(codes.FailedPrecondition, "milestone %d is %s, not resolved", milestone.ID, milestone.Status)
+	}
+	if _, ok := milestone.SettlementTicks(); !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "milestone %d has no resolution", milestone.ID)
+	}
+
+	go func(milestoneID uint, requestedBy string) {
+		settled, err := s.settlement.SettleMarket(milestoneID)
+		if err != nil {
+			log.Printf("❌ Resolution requested by %s failed for milestone %d: %v", requestedBy, milestoneID, err)
+			return
+		}
+		log.Printf("⚖️ Resolution requested by %s settled %d positions for milestone %d", requestedBy, settled, milestoneID)
+	}(milestone.ID, req.GetRequestedBy())
+
+	resp.Accepted = true
+	return resp, nil
+}
+
+func (s *Server) loadMilestone(ctx context.Context, milestoneID uint32) (*models.Milestone, error) {
+	var milestone models.Milestone
+	if err := s.db.WithContext(ctx).First(&milestone, milestoneID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "milestone %d not found", milestoneID)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &milestone, nil
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// AccountDeletionGracePeriod 삭제 요청 후 실제 삭제까지의 유예 기간 (이 동안은 취소 가능)
+const AccountDeletionGracePeriod = 30 * 24 * time.Hour
+
+const accountDeletionPurgeBatch = 50 // 주기당 최대 처리 건수
+
+var (
+	ErrDeletionAlreadyRequested = errors.New("이미 계정 삭제가 예약되어 있습니다")
+	ErrDeletionNotRequested     = errors.New("예약된 계정 삭제 요청이 없습니다")
+	ErrDeletionBlocked          = errors.New("지금은 계정을 삭제할 수 없습니다")
+)
+
+// activeMentorStakeStatuses 인출이 끝나지 않은 멘토 스테이킹 상태
+var activeMentorStakeStatuses = []models.MentorStakeStatus{
+	models.MentorStakeStatusActive,
+	models.MentorStakeStatusUnlocking,
+	models.MentorStakeStatusFrozen,
+}
+
+// AccountDeletionService 계정 삭제 요청 (유예 기간 후 개인 데이터 삭제 및 익명화)
+//
+// 주문/체결/원장 기록은 회계 및 분쟁 대응을 위해 익명화된 사용자 ID로 남긴다.
+type AccountDeletionService struct {
+	db             *gorm.DB
+	tradingService *TradingService
+	sessionService *SessionService
+}
+
+// NewAccountDeletionService 생성자
+func NewAccountDeletionService(db *gorm.DB, tradingService *TradingService, sessionService *SessionService) *AccountDeletionService {
+	return &AccountDeletionService{
+		db:             db,
+		tradingService: tradingService,
+		sessionService: sessionService,
+	}
+}
+
+// RequestDeletion 계정 삭제 예약 - 미체결 주문은 취소하고 모든 세션과 API 키를 폐기, 스테이킹/분쟁이 남아 있으면 거부
+//
+// 유예 기간 중에는 다시 로그인해 예약 조회/취소만 할 수 있다 (DeactivatedAccountAuthMiddleware).
+func (s *AccountDeletionService) RequestDeletion(userID uint, reason string) (*models.AccountDeletion, error) {
+	if _, err := s.GetPending(userID); err == nil {
+		return nil, ErrDeletionAlreadyRequested
+	}
+	if err := checkDeletionBlockers(s.db, userID); err != nil {
+		return nil, err
+	}
+
+	cancelled, err := s.cancelOpenOrders(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	deletion := models.AccountDeletion{
+		UserID:          userID,
+		Status:          models.AccountDeletionPending,
+		Reason:          strings.TrimSpace(reason),
+		CancelledOrders: cancelled,
+		ScheduledFor:    time.Now().Add(AccountDeletionGracePeriod),
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&deletion).Error; err != nil {
+			return err
+		}
+		if err := revokeAPIKeys(tx, userID); err != nil {
+			return err
+		}
+		// 유예 기간 동안 계정 비활성화 (프로필/리더보드 등에서 제외, 인증 미들웨어가 토큰 거부)
+		return tx.Model(&models.User{}).Where("id = ?", userID).Update("is_active", false).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("계정 삭제 요청 저장 실패: %w", err)
+	}
+	revokeAllSessions(s.sessionService, userID)
+
+	log.Printf("🗑️ Account deletion scheduled for user %d at %s (%d orders cancelled)", userID, deletion.ScheduledFor.Format(time.RFC3339), cancelled)
+	return &deletion, nil
+}
+
+// GetPending 유예 중인 삭제 요청 조회
+func (s *AccountDeletionService) GetPending(userID uint) (*models.AccountDeletion, error) {
+	var deletion models.AccountDeletion
+	err := s.db.Where("user_id = ? AND status = ?", userID, models.AccountDeletionPending).First(&deletion).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrDeletionNotRequested
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &deletion, nil
+}
+
+// CancelDeletion 유예 기간 중 삭제 취소 (계정 다시 활성화)
+func (s *AccountDeletionService) CancelDeletion(userID uint) (*models.AccountDeletion, error) {
+	deletion, err := s.GetPending(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.AccountDeletion{}).
+			Where("id = ? AND status = ?", deletion.ID, models.AccountDeletionPending).
+			Updates(map[string]interface{}{"status": models.AccountDeletionCancelled, "cancelled_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrDeletionNotRequested
+		}
+		return tx.Model(&models.User{}).Where("id = ?", userID).Update("is_active", true).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deletion.Status = models.AccountDeletionCancelled
+	deletion.CancelledAt = &now
+	return deletion, nil
+}
+
+// cancelOpenOrders 미체결 주문 취소 (잠긴 잔액 반환)
+func (s *AccountDeletionService) cancelOpenOrders(userID uint) (int, error) {
+	var orders []models.Order
+	if err := s.db.Select("id").Where("user_id = ? AND status IN ?", userID, openOrderStatuses).Find(&orders).Error; err != nil {
+		return 0, err
+	}
+	for _, order := range orders {
+		if _, err := s.tradingService.CancelOrder(userID, order.ID); err != nil {
+			if errors.Is(err, ErrOrderNotOpen) {
+				continue // 그 사이 전량 체결
+			}
+			return 0, fmt.Errorf("미체결 주문 %d 취소 실패: %w", order.ID, err)
+		}
+	}
+	return len(orders), nil
+}
+
+// checkDeletionBlockers 삭제 전에 사용자가 직접 정리해야 하는 상태 (스테이킹, 미정산 조합 베팅, 대기 중 포지션 이전, 진행 중인 분쟁)
+func checkDeletionBlockers(db *gorm.DB, userID uint) error {
+	checks := []struct {
+		model   interface{}
+		where   string
+		args    []interface{}
+		message string
+	}{
+		{&models.MentorStake{}, "user_id = ? AND status IN ?", []interface{}{userID, activeMentorStakeStatuses}, "멘토 스테이킹을 먼저 인출해주세요"},
+		{&models.StakingPool{}, "user_id = ? AND status = ?", []interface{}{userID, "active"}, "BLUEPRINT 스테이킹을 먼저 해제해주세요"},
+		{&models.JurorQualification{}, "user_id = ? AND current_stake > 0", []interface{}{userID}, "배심원 스테이킹을 먼저 해제해주세요"},
+		{&models.Parlay{}, "user_id = ? AND status = ?", []interface{}{userID, models.ParlayStatusOpen}, "정산되지 않은 조합 베팅이 있습니다"},
+		{&models.PositionTransfer{}, "status = ? AND (sender_id = ? OR recipient_id = ?)", []interface{}{models.PositionTransferPending, userID, userID}, "응답 대기 중인 포지션 이전 요청이 있습니다"},
+		{&models.ArbitrationCase{}, "status IN ? AND (plaintiff_id = ? OR defendant_id = ?)", []interface{}{openArbitrationStatuses, userID, userID}, "진행 중인 분쟁의 당사자입니다"},
+	}
+	for _, check := range checks {
+		var count int64
+		if err := db.Model(check.model).Where(check.where, check.args...).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: %s", ErrDeletionBlocked, check.message)
+		}
+	}
+
+	var jurorCases int64
+	if err := db.Model(&models.ArbitrationVote{}).
+		Joins("JOIN arbitration_cases ON arbitration_cases.id = arbitration_votes.case_id").
+		Where("arbitration_votes.juror_id = ? AND arbitration_cases.status IN ?", userID, openArbitrationStatuses).
+		Count(&jurorCases).Error; err != nil {
+		return err
+	}
+	if jurorCases > 0 {
+		return fmt.Errorf("%w: 배심원으로 배정된 분쟁이 진행 중입니다", ErrDeletionBlocked)
+	}
+	return nil
+}
+
+// checkWalletEmpty 남은 지갑 잔액 (잠금 포함, 추가 통화 포함)이 있으면 거부 - 익명화된 계정에 돈이 묶이지 않도록
+func checkWalletEmpty(db *gorm.DB, userID uint) error {
+	var wallets, accounts int64
+	if err := db.Model(&models.UserWallet{}).
+		Where("user_id = ? AND (usdc_balance <> 0 OR usdc_locked_balance <> 0 OR blueprint_balance <> 0 OR blueprint_locked_balance <> 0)", userID).
+		Count(&wallets).Error; err != nil {
+		return err
+	}
+	if err := db.Model(&models.WalletAccount{}).Where("user_id = ? AND (available <> 0 OR locked <> 0)", userID).Count(&accounts).Error; err != nil {
+		return err
+	}
+	if wallets > 0 || accounts > 0 {
+		return fmt.Errorf("%w: 지갑 잔액이 남아 있습니다. 삭제를 취소하고 출금한 뒤 다시 요청해주세요", ErrDeletionBlocked)
+	}
+	return nil
+}
+
+// PurgeDue 유예 기간이 지난 계정의 개인 데이터 삭제 및 익명화
+func (s *AccountDeletionService) PurgeDue() (int, error) {
+	var deletions []models.AccountDeletion
+	if err := s.db.Where("status = ? AND scheduled_for <= ?", models.AccountDeletionPending, time.Now()).
+		Order("scheduled_for ASC").
+		Limit(accountDeletionPurgeBatch).
+		Find(&deletions).Error; err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for i := range deletions {
+		if err := s.purge(&deletions[i]); err != nil {
+			if errors.Is(err, ErrDeletionBlocked) {
+				log.Printf("⏸️ Purge of user %d held: %v", deletions[i].UserID, err)
+				continue
+			}
+			log.Printf("❌ Failed to purge user %d: %v", deletions[i].UserID, err)
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// purge 로그인 수단/프로필/연동 정보 삭제 후 사용자 익명화 및 soft delete
+// 유예 기간 중 새로 생긴 차단 사유(정산 지급 등)나 남은 지갑 잔액이 있으면 삭제하지 않고 예약을 유지한다.
+func (s *AccountDeletionService) purge(deletion *models.AccountDeletion) error {
+	userID := deletion.UserID
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return err
+	}
+	// 유예 기간 중 다시 낸 주문도 정리
+	if _, err := s.cancelOpenOrders(userID); err != nil {
+		return err
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkDeletionBlockers(tx, userID); err != nil {
+			return err
+		}
+		if err := checkWalletEmpty(tx, userID); err != nil {
+			return err
+		}
+
+		personal := []interface{}{
+			&models.UserProfile{},
+			&models.UserVerification{},
+			&models.UserIdentity{},
+			&models.PushSubscription{},
+			&models.APIKey{},
+			&models.WebhookEndpoint{},
+			&models.GitHubConnection{},
+			&models.ProjectWatch{},
+			&models.Notification{},
+			&models.ActivityLog{},
+			&models.UserRole{},
+		}
+		for _, model := range personal {
+			if err := tx.Unscoped().Where("user_id = ?", userID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&models.GitHubWebhookSubscription{}).Where("user_id = ?", userID).Update("active", false).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("email = ?", user.Email).Delete(&models.MagicLink{}).Error; err != nil {
+			return err
+		}
+		// 생성된 내보내기 파일은 다음 정리 주기에 삭제
+		if err := tx.Model(&models.DataExport{}).
+			Where("user_id = ? AND status = ?", userID, models.DataExportCompleted).
+			Update("expires_at", time.Now()).Error; err != nil {
+			return err
+		}
+
+		// 이메일/사용자명은 재가입할 수 있도록 익명 값으로 교체
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"email":     fmt.Sprintf("deleted-%d@deleted.invalid", userID),
+			"username":  fmt.Sprintf("deleted_%d", userID),
+			"google_id": nil,
+			"is_active": false,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.User{}, userID).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.AccountDeletion{}).Where("id = ?", deletion.ID).
+			Updates(map[string]interface{}{"status": models.AccountDeletionCompleted, "completed_at": time.Now()}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	revokeAllSessions(s.sessionService, userID)
+	return nil
+}
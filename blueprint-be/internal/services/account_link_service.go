@@ -0,0 +1,779 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	accountMergeCodeTTL      = 15 * time.Minute
+	accountMergeMaxAttempts  = 5
+	maxMergeRedirects        = 5 // 병합된 계정을 따라가는 최대 단계 (순환 방지)
+	accountMergeReferenceKey = "account_merge"
+)
+
+var (
+	ErrIdentityLinkedElsewhere = errors.New("이미 다른 계정에 연결된 로그인 수단입니다. 계정 병합을 이용해주세요")
+	ErrIdentityNotLinked       = errors.New("연결되지 않은 로그인 수단입니다")
+	ErrIdentityRequired        = errors.New("이메일 로그인은 해제할 수 없습니다")
+	ErrIdentityInUse           = errors.New("연결된 기능을 먼저 정리해야 해제할 수 있습니다")
+
+	ErrMergeSourceNotFound = errors.New("병합할 계정을 찾을 수 없습니다")
+	ErrMergeSelf           = errors.New("같은 계정은 병합할 수 없습니다")
+	ErrMergeNotFound       = errors.New("진행 중인 병합 요청이 없습니다")
+	ErrMergeExpired        = errors.New("병합 확인 코드가 만료되었습니다")
+	ErrMergeInvalidCode    = errors.New("병합 확인 코드가 올바르지 않습니다")
+	ErrMergeBlocked        = errors.New("지금은 계정을 병합할 수 없습니다")
+)
+
+// openArbitrationStatuses 아직 결론이 나지 않은 분쟁 단계 (병합 시 당사자/배심원이 바뀌면 안 됨)
+var openArbitrationStatuses = []models.ArbitrationStatus{
+	models.ArbitrationStatusSubmitted,
+	models.ArbitrationStatusUnderReview,
+	models.ArbitrationStatusJurySelection,
+	models.ArbitrationStatusEvidence,
+	models.ArbitrationStatusVoting,
+	models.ArbitrationStatusReveal,
+	models.ArbitrationStatusAppealed,
+}
+
+// AccountLinkService 로그인 수단 연결/해제와 중복 계정 병합
+//
+// 제공업체 계정(provider + provider_user_id)은 한 사용자에게만 연결된다.
+// 다른 계정에 이미 연결된 경우 조용히 옮기지 않고, 흡수할 계정의 이메일로 받은 코드로
+// 병합을 확인해야 지갑/포지션/스테이킹이 이전된다.
+type AccountLinkService struct {
+	db             *gorm.DB
+	sessionService *SessionService
+}
+
+// NewAccountLinkService 생성자
+func NewAccountLinkService(db *gorm.DB, sessionService *SessionService) *AccountLinkService {
+	return &AccountLinkService{
+		db:             db,
+		sessionService: sessionService,
+	}
+}
+
+// LinkIdentity 로그인/연결에 사용된 외부 신원 기록 (같은 제공업체의 다른 계정은 새 계정으로 대체)
+func (s *AccountLinkService) LinkIdentity(userID uint, identity models.UserIdentity) (*models.UserIdentity, error) {
+	identity.Email = strings.ToLower(strings.TrimSpace(identity.Email))
+	now := time.Now()
+
+	var existing models.UserIdentity
+	err := s.db.Where("provider = ? AND provider_user_id = ?", identity.Provider, identity.ProviderUserID).First(&existing).Error
+	if err == nil {
+		if existing.UserID != userID {
+			return &existing, ErrIdentityLinkedElsewhere
+		}
+		existing.LastUsedAt = &now
+		if identity.Email != "" {
+			existing.Email = identity.Email
+			existing.EmailVerified = identity.EmailVerified
+		}
+		if identity.DisplayName != "" {
+			existing.DisplayName = identity.DisplayName
+		}
+		if err := s.db.Save(&existing).Error; err != nil {
+			return nil, err
+		}
+		return &existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	identity.ID = 0
+	identity.UserID = userID
+	identity.LastUsedAt = &now
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND provider = ?", userID, identity.Provider).Delete(&models.UserIdentity{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&identity).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("로그인 수단 연결 실패: %w", err)
+	}
+	return &identity, nil
+}
+
+// ResolveMerged 병합되어 비활성화된 계정이면 병합 대상 계정을 반환
+func (s *AccountLinkService) ResolveMerged(user *models.User) (*models.User, error) {
+	for i := 0; user.MergedIntoID != nil && i < maxMergeRedirects; i++ {
+		var next models.User
+		if err := s.db.First(&next, *user.MergedIntoID).Error; err != nil {
+			return nil, err
+		}
+		user = &next
+	}
+	return user, nil
+}
+
+// ListIdentities 연결된 로그인 수단 목록 (이전 방식으로 연결된 계정도 함께 기록)
+func (s *AccountLinkService) ListIdentities(userID uint) ([]models.UserIdentity, error) {
+	s.backfillIdentities(userID)
+
+	var identities []models.UserIdentity
+	err := s.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&identities).Error
+	return identities, err
+}
+
+// Unlink 로그인 수단 연결 해제 (이메일 로그인은 항상 남김)
+func (s *AccountLinkService) Unlink(userID uint, provider string) error {
+	if provider == models.IdentityProviderEmail {
+		return ErrIdentityRequired
+	}
+	s.backfillIdentities(userID)
+
+	var identity models.UserIdentity
+	if err := s.db.Where("user_id = ? AND provider = ?", userID, provider).First(&identity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrIdentityNotLinked
+		}
+		return err
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		switch provider {
+		case models.IdentityProviderGoogle:
+			if err := tx.Model(&models.User{}).Where("id = ?", userID).Update("google_id", nil).Error; err != nil {
+				return err
+			}
+		case models.IdentityProviderGitHub:
+			var hooks int64
+			if err := tx.Model(&models.GitHubWebhookSubscription{}).Where("user_id = ? AND active = ?", userID, true).Count(&hooks).Error; err != nil {
+				return err
+			}
+			if hooks > 0 {
+				return fmt.Errorf("%w: 등록된 저장소 웹훅 %d개를 먼저 삭제해주세요", ErrIdentityInUse, hooks)
+			}
+			if err := tx.Where("user_id = ?", userID).Delete(&models.GitHubConnection{}).Error; err != nil {
+				return err
+			}
+		}
+		if columns := socialVerificationColumns(provider); columns != nil {
+			if err := tx.Model(&models.UserVerification{}).Where("user_id = ?", userID).Updates(clearedColumns(columns)).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&identity).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	MarkTrustScoreStale(userID)
+	return nil
+}
+
+// FindDuplicates 내 인증된 이메일과 같은 이메일을 쓰는 다른 활성 계정
+func (s *AccountLinkService) FindDuplicates(userID uint) ([]models.DuplicateAccount, error) {
+	s.backfillIdentities(userID)
+
+	emails, err := s.verifiedEmails(userID)
+	if err != nil || len(emails) == 0 {
+		return []models.DuplicateAccount{}, err
+	}
+	emailList := make([]string, 0, len(emails))
+	for email := range emails {
+		emailList = append(emailList, email)
+	}
+
+	identityOwners := s.db.Model(&models.UserIdentity{}).Select("user_id").
+		Where("email_verified = ? AND email IN ?", true, emailList)
+
+	var users []models.User
+	err = s.db.Where("id <> ? AND is_active = ? AND merged_into_id IS NULL", userID, true).
+		Where(s.db.Where("LOWER(email) IN ?", emailList).Or("id IN (?)", identityOwners)).
+		Order("created_at ASC").
+		Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates := make([]models.DuplicateAccount, 0, len(users))
+	for _, user := range users {
+		var identities []models.UserIdentity
+		s.db.Where("user_id = ?", user.ID).Find(&identities)
+
+		duplicate := models.DuplicateAccount{
+			UserID:    user.ID,
+			Username:  user.Username,
+			Providers: []string{},
+			CreatedAt: user.CreatedAt,
+		}
+		if emails[strings.ToLower(user.Email)] {
+			duplicate.MatchedEmail = user.Email
+		}
+		for _, identity := range identities {
+			duplicate.Providers = append(duplicate.Providers, identity.Provider)
+			if duplicate.MatchedEmail == "" && identity.EmailVerified && emails[identity.Email] {
+				duplicate.MatchedEmail = identity.Email
+			}
+		}
+		duplicates = append(duplicates, duplicate)
+	}
+	return duplicates, nil
+}
+
+// StartMerge 병합 요청 생성 - 반환된 코드를 흡수할 계정(source)의 이메일로 보내야 한다
+func (s *AccountLinkService) StartMerge(targetUserID uint, sourceEmail string) (*models.AccountMerge, *models.User, string, error) {
+	var source models.User
+	err := s.db.Where("LOWER(email) = ?", strings.ToLower(strings.TrimSpace(sourceEmail))).First(&source).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && (!source.IsActive || source.MergedIntoID != nil)) {
+		return nil, nil, "", ErrMergeSourceNotFound
+	}
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if source.ID == targetUserID {
+		return nil, nil, "", ErrMergeSelf
+	}
+	if err := checkMergeBlockers(s.db, source.ID, targetUserID); err != nil {
+		return nil, nil, "", err
+	}
+
+	code, err := generateMagicLinkCode()
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	merge := models.AccountMerge{
+		TargetUserID: targetUserID,
+		SourceUserID: source.ID,
+		Status:       models.AccountMergePending,
+		CodeHash:     hashAccountMergeCode(code),
+		ExpiresAt:    time.Now().Add(accountMergeCodeTTL),
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// 이전 요청은 마지막 코드만 유효하도록 취소
+		if err := tx.Model(&models.AccountMerge{}).
+			Where("target_user_id = ? AND status = ?", targetUserID, models.AccountMergePending).
+			Update("status", models.AccountMergeCancelled).Error; err != nil {
+			return err
+		}
+		return tx.Create(&merge).Error
+	})
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("병합 요청 저장 실패: %w", err)
+	}
+	return &merge, &source, code, nil
+}
+
+// ConfirmMerge 확인 코드 검증 후 병합 실행 (source의 자산을 target으로 이전하고 source 비활성화)
+func (s *AccountLinkService) ConfirmMerge(targetUserID, mergeID uint, code string) (*models.AccountMerge, error) {
+	var merge models.AccountMerge
+	err := s.db.Where("id = ? AND target_user_id = ? AND status = ?", mergeID, targetUserID, models.AccountMergePending).First(&merge).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrMergeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().After(merge.ExpiresAt) {
+		return nil, ErrMergeExpired
+	}
+	if !hashEqual(merge.CodeHash, hashAccountMergeCode(code)) {
+		updates := map[string]interface{}{"attempts": gorm.Expr("attempts + 1")}
+		if merge.Attempts+1 >= accountMergeMaxAttempts {
+			updates["status"] = models.AccountMergeCancelled
+		}
+		s.db.Model(&merge).Updates(updates)
+		return nil, ErrMergeInvalidCode
+	}
+
+	summary := &models.AccountMergeSummary{}
+	now := time.Now()
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// 동시에 두 번 확인해도 한 번만 실행되도록 상태를 먼저 선점
+		claim := tx.Model(&models.AccountMerge{}).
+			Where("id = ? AND status = ?", merge.ID, models.AccountMergePending).
+			Updates(map[string]interface{}{"status": models.AccountMergeCompleted, "completed_at": now})
+		if claim.Error != nil {
+			return claim.Error
+		}
+		if claim.RowsAffected == 0 {
+			return ErrMergeNotFound
+		}
+
+		var source, target models.User
+		if err := tx.First(&source, merge.SourceUserID).Error; err != nil {
+			return err
+		}
+		if err := tx.First(&target, merge.TargetUserID).Error; err != nil {
+			return err
+		}
+		if !source.IsActive || source.MergedIntoID != nil {
+			return ErrMergeSourceNotFound
+		}
+		if err := checkMergeBlockers(tx, source.ID, target.ID); err != nil {
+			return err
+		}
+
+		steps := []func(*gorm.DB, *models.AccountMerge, *models.AccountMergeSummary) error{
+			mergeWallet,
+			mergePositions,
+			mergeStakes,
+			mergeOwnership,
+		}
+		for _, step := range steps {
+			if err := step(tx, &merge, summary); err != nil {
+				return err
+			}
+		}
+		if err := mergeIdentities(tx, &source, &target, summary); err != nil {
+			return err
+		}
+
+		// 흡수된 계정은 비활성화하고 API 키 폐기 (이후 로그인은 병합된 계정으로 연결)
+		if err := tx.Model(&models.User{}).Where("id = ?", source.ID).
+			Updates(map[string]interface{}{"is_active": false, "merged_into_id": target.ID}).Error; err != nil {
+			return err
+		}
+		if err := revokeAPIKeys(tx, source.ID); err != nil {
+			return err
+		}
+		return tx.Model(&models.AccountMerge{}).Where("id = ?", merge.ID).
+			Select("summary").Updates(&models.AccountMerge{Summary: summary}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	revokeAllSessions(s.sessionService, merge.SourceUserID)
+	MarkTrustScoreStale(merge.TargetUserID)
+
+	merge.Status = models.AccountMergeCompleted
+	merge.CompletedAt = &now
+	merge.Summary = summary
+	return &merge, nil
+}
+
+// checkMergeBlockers 병합하면 정합성이 깨지는 상태 (미체결 주문, 진행 중인 분쟁, 반대 방향 포지션)
+// 미체결 주문은 결제 통화와 무관하게 막는다 (주문의 잠금/체결이 source 계정 기준으로 남으므로)
+func checkMergeBlockers(tx *gorm.DB, sourceID, targetID uint) error {
+	var openOrders []struct {
+		QuoteCurrency models.LedgerCurrency
+		Count         int64
+	}
+	if err := tx.Model(&models.Order{}).
+		Select("quote_currency, COUNT(*) AS count").
+		Where("user_id = ? AND status IN ?", sourceID, openOrderStatuses).
+		Group("quote_currency").
+		Scan(&openOrders).Error; err != nil {
+		return err
+	}
+	if len(openOrders) > 0 {
+		counts := make([]string, 0, len(openOrders))
+		for _, open := range openOrders {
+			counts = append(counts, fmt.Sprintf("%s %d건", strings.ToUpper(string(open.QuoteCurrency.OrUSDC())), open.Count))
+		}
+		return fmt.Errorf("%w: 병합할 계정의 미체결 주문(%s)을 먼저 취소해주세요", ErrMergeBlocked, strings.Join(counts, ", "))
+	}
+
+	var openCases int64
+	if err := tx.Model(&models.ArbitrationCase{}).
+		Where("status IN ? AND (plaintiff_id = ? OR defendant_id = ?)", openArbitrationStatuses, sourceID, sourceID).
+		Count(&openCases).Error; err != nil {
+		return err
+	}
+	if openCases > 0 {
+		return fmt.Errorf("%w: 병합할 계정이 진행 중인 분쟁의 당사자입니다", ErrMergeBlocked)
+	}
+
+	var jurorCases int64
+	if err := tx.Model(&models.ArbitrationVote{}).
+		Joins("JOIN arbitration_cases ON arbitration_cases.id = arbitration_votes.case_id").
+		Where("arbitration_votes.juror_id = ? AND arbitration_cases.status IN ?", sourceID, openArbitrationStatuses).
+		Count(&jurorCases).Error; err != nil {
+		return err
+	}
+	if jurorCases > 0 {
+		return fmt.Errorf("%w: 병합할 계정이 배심원으로 배정된 분쟁이 진행 중입니다", ErrMergeBlocked)
+	}
+
+	var conflicts int64
+	if err := tx.Table("positions AS s").
+		Joins("JOIN positions AS t ON t.milestone_id = s.milestone_id AND t.option_id = s.option_id").
+		Where("s.user_id = ? AND t.user_id = ? AND s.quantity * t.quantity < 0", sourceID, targetID).
+		Count(&conflicts).Error; err != nil {
+		return err
+	}
+	if conflicts > 0 {
+		return fmt.Errorf("%w: 같은 옵션에 반대 방향 포지션 %d개가 있어 먼저 정리해야 합니다", ErrMergeBlocked, conflicts)
+	}
+	return nil
+}
+
+// mergeWallet 통화별 사용 가능/잠긴 잔액을 원장 기록과 함께 이전 (usdc/blueprint는 UserWallet, 그 외 결제 통화는 WalletAccount)
+func mergeWallet(tx *gorm.DB, merge *models.AccountMerge, summary *models.AccountMergeSummary) error {
+	var source models.UserWallet
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", merge.SourceUserID).First(&source).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	var accounts []models.WalletAccount
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND (available <> 0 OR locked <> 0)", merge.SourceUserID).
+		Order("currency ASC").
+		Find(&accounts).Error; err != nil {
+		return err
+	}
+
+	var target models.UserWallet
+	if err := tx.Where(models.UserWallet{UserID: merge.TargetUserID}).FirstOrCreate(&target).Error; err != nil {
+		return fmt.Errorf("지갑 준비 실패: %w", err)
+	}
+
+	if err := transferMergeBalance(tx, merge, models.LedgerCurrencyUSDC, source.USDCBalance, source.USDCLockedBalance); err != nil {
+		return err
+	}
+	summary.USDC, summary.USDCLocked = source.USDCBalance, source.USDCLockedBalance
+
+	if err := transferMergeBalance(tx, merge, models.LedgerCurrencyBlueprint, source.BlueprintBalance, source.BlueprintLockedBalance); err != nil {
+		return err
+	}
+	summary.Blueprint, summary.BlueprintLocked = source.BlueprintBalance, source.BlueprintLockedBalance
+
+	for _, account := range accounts {
+		if err := transferMergeBalance(tx, merge, account.Currency, account.Available, account.Locked); err != nil {
+			return err
+		}
+		summary.Accounts = append(summary.Accounts, models.WalletBalance{
+			Currency:  account.Currency,
+			Available: account.Available,
+			Locked:    account.Locked,
+			Tradable:  true,
+		})
+	}
+	return nil
+}
+
+// transferMergeBalance 한 통화의 잔액을 source에서 target으로 옮기는 원장 기록 한 쌍
+func transferMergeBalance(tx *gorm.DB, merge *models.AccountMerge, currency models.LedgerCurrency, amount, locked int64) error {
+	if amount == 0 && locked == 0 {
+		return nil
+	}
+	entries := []models.WalletLedgerEntry{
+		{UserID: merge.SourceUserID, Amount: -amount, LockedAmount: -locked,
+			Memo: fmt.Sprintf("계정 병합: 사용자 %d로 이전", merge.TargetUserID)},
+		{UserID: merge.TargetUserID, Amount: amount, LockedAmount: locked,
+			Memo: fmt.Sprintf("계정 병합: 사용자 %d에서 이전", merge.SourceUserID)},
+	}
+	for i := range entries {
+		entries[i].Currency = currency
+		entries[i].EntryType = models.LedgerAccountMerge
+		entries[i].ReferenceType = accountMergeReferenceKey
+		entries[i].ReferenceID = merge.ID
+		if err := postLedgerEntry(tx, &entries[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergePositions 포지션 이전 (같은 옵션을 이미 보유하면 수량/원가를 합산)
+func mergePositions(tx *gorm.DB, merge *models.AccountMerge, summary *models.AccountMergeSummary) error {
+	var positions []models.Position
+	if err := tx.Where("user_id = ?", merge.SourceUserID).Find(&positions).Error; err != nil {
+		return err
+	}
+
+	for _, position := range positions {
+		var existing models.Position
+		err := tx.Where("user_id = ? AND milestone_id = ? AND option_id = ?", merge.TargetUserID, position.MilestoneID, position.OptionID).
+			First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if err := tx.Model(&models.Position{}).Where("id = ?", position.ID).Update("user_id", merge.TargetUserID).Error; err != nil {
+				return err
+			}
+			summary.Positions++
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		combinePositions(&existing, &position)
+		if err := tx.Save(&existing).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.Position{}, position.ID).Error; err != nil {
+			return err
+		}
+		summary.PositionsCombined++
+	}
+	return nil
+}
+
+// combinePositions 같은 방향 포지션 합산 (평균가는 수량 가중)
+func combinePositions(into, from *models.Position) {
+	intoSize, fromSize := abs64(into.Quantity), abs64(from.Quantity)
+	if intoSize+fromSize > 0 {
+		into.AvgPrice = (into.AvgPrice*float64(intoSize) + from.AvgPrice*float64(fromSize)) / float64(intoSize+fromSize)
+	}
+	into.Quantity += from.Quantity
+	into.TotalCost += from.TotalCost
+	into.Realized += from.Realized
+	into.Unrealized += from.Unrealized
+}
+
+// mergeStakes 조합 베팅, 멘토/BLUEPRINT 스테이킹, 미청구 보상, 배심원 스테이킹 이전
+func mergeStakes(tx *gorm.DB, merge *models.AccountMerge, summary *models.AccountMergeSummary) error {
+	reassign := []struct {
+		model interface{}
+		where string
+		count *int
+	}{
+		{&models.Parlay{}, "user_id = ?", &summary.Parlays},
+		{&models.MentorStake{}, "user_id = ?", &summary.MentorStakes},
+		{&models.StakingPool{}, "user_id = ?", &summary.StakingPools},
+		{&models.StakingEpochReward{}, "user_id = ? AND status = '" + string(models.StakingEpochRewardPending) + "'", &summary.StakingRewards},
+	}
+	for _, r := range reassign {
+		result := tx.Model(r.model).Where(r.where, merge.SourceUserID).Update("user_id", merge.TargetUserID)
+		if result.Error != nil {
+			return result.Error
+		}
+		*r.count = int(result.RowsAffected)
+	}
+
+	var sourceJuror models.JurorQualification
+	err := tx.Where("user_id = ?", merge.SourceUserID).First(&sourceJuror).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var targetJuror models.JurorQualification
+	err = tx.Where("user_id = ?", merge.TargetUserID).First(&targetJuror).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		err = tx.Model(&sourceJuror).Update("user_id", merge.TargetUserID).Error
+	case err == nil:
+		// 배심원 자격은 사용자당 하나 - 스테이킹만 합치고 흡수된 자격은 비활성화
+		err = tx.Model(&targetJuror).Update("current_stake", gorm.Expr("current_stake + ?", sourceJuror.CurrentStake)).Error
+		if err == nil {
+			err = tx.Model(&sourceJuror).Updates(map[string]interface{}{"current_stake": 0, "is_active": false}).Error
+		}
+	}
+	if err != nil {
+		return err
+	}
+	summary.JurorStakeMerged = true
+	return nil
+}
+
+// mergeOwnership 프로젝트 소유권 이전
+func mergeOwnership(tx *gorm.DB, merge *models.AccountMerge, summary *models.AccountMergeSummary) error {
+	result := tx.Model(&models.Project{}).Where("user_id = ?", merge.SourceUserID).Update("user_id", merge.TargetUserID)
+	if result.Error != nil {
+		return result.Error
+	}
+	summary.Projects = int(result.RowsAffected)
+	return nil
+}
+
+// mergeIdentities 로그인 수단과 소셜 인증 이전 (target에 같은 제공업체가 이미 있으면 target 것을 유지)
+func mergeIdentities(tx *gorm.DB, source, target *models.User, summary *models.AccountMergeSummary) error {
+	backfillIdentities(tx, source.ID)
+	backfillIdentities(tx, target.ID)
+
+	var identities []models.UserIdentity
+	if err := tx.Where("user_id = ?", source.ID).Find(&identities).Error; err != nil {
+		return err
+	}
+
+	var sourceVerification, targetVerification models.UserVerification
+	tx.Where("user_id = ?", source.ID).First(&sourceVerification)
+	if err := tx.Where(models.UserVerification{UserID: target.ID}).FirstOrCreate(&targetVerification).Error; err != nil {
+		return err
+	}
+
+	for _, identity := range identities {
+		var taken int64
+		if err := tx.Model(&models.UserIdentity{}).Where("user_id = ? AND provider = ?", target.ID, identity.Provider).Count(&taken).Error; err != nil {
+			return err
+		}
+		// 이메일 신원은 계정마다 고유 - 흡수된 계정 이메일로 로그인하면 병합 대상으로 연결된다
+		if taken > 0 || identity.Provider == models.IdentityProviderEmail {
+			continue
+		}
+
+		if err := tx.Model(&identity).Update("user_id", target.ID).Error; err != nil {
+			return err
+		}
+		summary.Identities++
+
+		switch identity.Provider {
+		case models.IdentityProviderGoogle:
+			if target.GoogleID == nil && source.GoogleID != nil {
+				if err := tx.Model(&models.User{}).Where("id = ?", source.ID).Update("google_id", nil).Error; err != nil {
+					return err
+				}
+				if err := tx.Model(&models.User{}).Where("id = ?", target.ID).Update("google_id", *source.GoogleID).Error; err != nil {
+					return err
+				}
+			}
+		case models.IdentityProviderGitHub:
+			var connections int64
+			tx.Model(&models.GitHubConnection{}).Where("user_id = ?", target.ID).Count(&connections)
+			if connections == 0 {
+				for _, model := range []interface{}{&models.GitHubConnection{}, &models.GitHubWebhookSubscription{}} {
+					if err := tx.Model(model).Where("user_id = ?", source.ID).Update("user_id", target.ID).Error; err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		if columns := socialVerificationColumns(identity.Provider); columns != nil && sourceVerification.ID != 0 {
+			copySocialVerification(identity.Provider, &sourceVerification, &targetVerification)
+			if err := tx.Model(&targetVerification).Select(columns).Updates(&targetVerification).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&sourceVerification).Updates(clearedColumns(columns)).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// verifiedEmails 사용자가 소유를 증명한 이메일 (로그인 이메일 + 제공업체가 확인한 이메일)
+func (s *AccountLinkService) verifiedEmails(userID uint) (map[string]bool, error) {
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+
+	emails := map[string]bool{}
+	var verification models.UserVerification
+	s.db.Where("user_id = ?", userID).First(&verification)
+	if verification.EmailVerified || user.Provider == "google" || user.Provider == "magic_link" {
+		emails[strings.ToLower(user.Email)] = true
+	}
+
+	var identities []models.UserIdentity
+	if err := s.db.Where("user_id = ? AND email_verified = ? AND email <> ''", userID, true).Find(&identities).Error; err != nil {
+		return nil, err
+	}
+	for _, identity := range identities {
+		emails[identity.Email] = true
+	}
+	return emails, nil
+}
+
+func (s *AccountLinkService) backfillIdentities(userID uint) {
+	backfillIdentities(s.db, userID)
+}
+
+// backfillIdentities 신원 기록 도입 전에 연결된 Google/소셜 계정을 UserIdentity로 기록
+func backfillIdentities(tx *gorm.DB, userID uint) {
+	var user models.User
+	if err := tx.First(&user, userID).Error; err != nil {
+		return
+	}
+	var verification models.UserVerification
+	tx.Where("user_id = ?", userID).First(&verification)
+
+	legacy := []models.UserIdentity{}
+	if user.GoogleID != nil {
+		legacy = append(legacy, models.UserIdentity{Provider: models.IdentityProviderGoogle, ProviderUserID: *user.GoogleID,
+			Email: strings.ToLower(user.Email), EmailVerified: true})
+	}
+	if user.Provider == "magic_link" {
+		legacy = append(legacy, models.UserIdentity{Provider: models.IdentityProviderEmail, ProviderUserID: strings.ToLower(user.Email),
+			Email: strings.ToLower(user.Email), EmailVerified: true})
+	}
+	if verification.GitHubConnected && verification.GitHubProfileID != nil {
+		legacy = append(legacy, models.UserIdentity{Provider: models.IdentityProviderGitHub, ProviderUserID: *verification.GitHubProfileID,
+			DisplayName: stringValue(verification.GitHubUsername)})
+	}
+	if verification.LinkedInConnected && verification.LinkedInProfileID != nil {
+		legacy = append(legacy, models.UserIdentity{Provider: models.IdentityProviderLinkedIn, ProviderUserID: *verification.LinkedInProfileID})
+	}
+	if verification.TwitterConnected && verification.TwitterProfileID != nil {
+		legacy = append(legacy, models.UserIdentity{Provider: models.IdentityProviderTwitter, ProviderUserID: *verification.TwitterProfileID,
+			DisplayName: stringValue(verification.TwitterUsername)})
+	}
+
+	for _, identity := range legacy {
+		var exists int64
+		tx.Model(&models.UserIdentity{}).
+			Where("(user_id = ? AND provider = ?) OR (provider = ? AND provider_user_id = ?)",
+				userID, identity.Provider, identity.Provider, identity.ProviderUserID).
+			Count(&exists)
+		if exists > 0 {
+			continue
+		}
+		identity.UserID = userID
+		tx.Create(&identity)
+	}
+}
+
+// socialVerificationColumns 제공업체별 user_verifications 연결 컬럼
+func socialVerificationColumns(provider string) []string {
+	switch provider {
+	case models.IdentityProviderGitHub:
+		return []string{"git_hub_connected", "git_hub_profile_id", "git_hub_username", "git_hub_verified_at"}
+	case models.IdentityProviderLinkedIn:
+		return []string{"linked_in_connected", "linked_in_profile_id", "linked_in_profile_url", "linked_in_verified_at"}
+	case models.IdentityProviderTwitter:
+		return []string{"twitter_connected", "twitter_profile_id", "twitter_username", "twitter_verified_at"}
+	}
+	return nil
+}
+
+// copySocialVerification 소셜 연결 인증 정보 복사
+func copySocialVerification(provider string, from, to *models.UserVerification) {
+	switch provider {
+	case models.IdentityProviderGitHub:
+		to.GitHubConnected, to.GitHubProfileID = from.GitHubConnected, from.GitHubProfileID
+		to.GitHubUsername, to.GitHubVerifiedAt = from.GitHubUsername, from.GitHubVerifiedAt
+	case models.IdentityProviderLinkedIn:
+		to.LinkedInConnected, to.LinkedInProfileID = from.LinkedInConnected, from.LinkedInProfileID
+		to.LinkedInProfileURL, to.LinkedInVerifiedAt = from.LinkedInProfileURL, from.LinkedInVerifiedAt
+	case models.IdentityProviderTwitter:
+		to.TwitterConnected, to.TwitterProfileID = from.TwitterConnected, from.TwitterProfileID
+		to.TwitterUsername, to.TwitterVerifiedAt = from.TwitterUsername, from.TwitterVerifiedAt
+	}
+}
+
+// clearedColumns 연결 해제 값 (*_connected는 false, 나머지는 NULL)
+func clearedColumns(columns []string) map[string]interface{} {
+	values := make(map[string]interface{}, len(columns))
+	for _, column := range columns {
+		if strings.HasSuffix(column, "_connected") {
+			values[column] = false
+		} else {
+			values[column] = nil
+		}
+	}
+	return values
+}
+
+func hashAccountMergeCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
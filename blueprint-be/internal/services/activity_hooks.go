@@ -0,0 +1,17 @@
+package services
+
+import (
+	"context"
+
+	"blueprint-module/pkg/logger"
+	"blueprint-module/pkg/models"
+)
+
+// recordActivity 서비스 내부에서 발생한 활동을 activity_logs 큐로 기록 (실패해도 처리 흐름은 계속)
+//
+// 사용자 요청으로 일어나는 활동은 middleware.ActivityCapture가 라우트 단위로 기록한다.
+func recordActivity(event models.ActivityEvent, userID uint, description string, metadata models.ActivityMetadata) {
+	req := event.Request(userID, description)
+	req.Metadata = metadata
+	_ = logger.GlobalActivityLogger.LogActivity(context.Background(), req)
+}
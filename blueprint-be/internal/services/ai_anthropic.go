@@ -0,0 +1,255 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	anthropicAPIURL     = "https://api.anthropic.com/v1/messages"
+	anthropicAPIVersion = "2023-06-01"
+)
+
+// AnthropicModel Anthropic Messages API 구현체 (Claude)
+type AnthropicModel struct {
+	httpClient *http.Client
+	config     AnthropicConfig
+}
+
+// AnthropicConfig Anthropic 설정
+type AnthropicConfig struct {
+	APIKey string
+	Model  string
+}
+
+// NewAnthropicModel Anthropic 모델 생성자
+func NewAnthropicModel(config AnthropicConfig) *AnthropicModel {
+	return &AnthropicModel{
+		httpClient: &http.Client{Timeout: 2 * time.Minute},
+		config:     config,
+	}
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicRequest struct {
+	Model       string             `json:"model"`
+	System      string             `json:"system,omitempty"`
+	Messages    []anthropicMessage `json:"messages"`
+	MaxTokens   int                `json:"max_tokens"`
+	Temperature float64            `json:"temperature,omitempty"`
+	Stream      bool               `json:"stream,omitempty"`
+}
+
+type anthropicUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+type anthropicResponse struct {
+	ID      string `json:"id"`
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	Usage anthropicUsage `json:"usage"`
+}
+
+// anthropicStreamEvent 스트리밍 이벤트 (필요한 필드만)
+type anthropicStreamEvent struct {
+	Type    string `json:"type"`
+	Message struct {
+		ID    string         `json:"id"`
+		Usage anthropicUsage `json:"usage"`
+	} `json:"message"`
+	Delta struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+	Usage anthropicUsage `json:"usage"`
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// GenerateMilestones Claude를 사용하여 마일스톤 생성
+func (m *AnthropicModel) GenerateMilestones(ctx context.Context, request AIRequest) (*AIResponse, error) {
+	startTime := time.Now()
+
+	resp, err := m.send(ctx, m.buildRequest(request, 2000, false))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result anthropicResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("Anthropic 응답 디코딩 실패: %w", err)
+	}
+
+	var content strings.Builder
+	for _, block := range result.Content {
+		if block.Type == "text" {
+			content.WriteString(block.Text)
+		}
+	}
+
+	return m.toResponse(content.String(), result.Usage.InputTokens+result.Usage.OutputTokens, result.ID, startTime)
+}
+
+// StreamMilestones Claude 스트리밍 응답으로 마일스톤 생성 (SSE content_block_delta마다 onDelta 호출)
+func (m *AnthropicModel) StreamMilestones(ctx context.Context, request AIRequest, onDelta func(chunk string)) (*AIResponse, error) {
+	startTime := time.Now()
+
+	resp, err := m.send(ctx, m.buildRequest(request, 2000, true))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var content strings.Builder
+	var requestID string
+	var usage anthropicUsage
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+
+		var event anthropicStreamEvent
+		if err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(line, "data:"))), &event); err != nil {
+			continue
+		}
+
+		switch event.Type {
+		case "message_start":
+			requestID = event.Message.ID
+			usage.InputTokens = event.Message.Usage.InputTokens
+		case "content_block_delta":
+			if event.Delta.Type != "text_delta" || event.Delta.Text == "" {
+				continue
+			}
+			content.WriteString(event.Delta.Text)
+			if onDelta != nil {
+				onDelta(event.Delta.Text)
+			}
+		case "message_delta":
+			usage.OutputTokens = event.Usage.OutputTokens
+		case "error":
+			return nil, fmt.Errorf("Anthropic 스트리밍 실패: %s", event.Error.Message)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Anthropic 스트리밍 실패: %w", err)
+	}
+
+	return m.toResponse(content.String(), usage.InputTokens+usage.OutputTokens, requestID, startTime)
+}
+
+// ValidateConnection Anthropic API 연결 상태 확인
+func (m *AnthropicModel) ValidateConnection(ctx context.Context) error {
+	req := anthropicRequest{
+		Model:     m.config.Model,
+		Messages:  []anthropicMessage{{Role: "user", Content: "테스트"}},
+		MaxTokens: 10,
+	}
+
+	resp, err := m.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// GetProviderInfo Anthropic 제공업체 정보 반환
+func (m *AnthropicModel) GetProviderInfo() AIProviderInfo {
+	return AIProviderInfo{
+		Name:        "Anthropic",
+		Provider:    ProviderClaude,
+		Model:       m.config.Model,
+		Description: "Anthropic의 Claude 모델을 사용한 AI 마일스톤 생성",
+		Features: []string{
+			"자연어 처리",
+			"긴 문맥 이해",
+			"단계별 마일스톤",
+			"스트리밍 응답",
+		},
+		Limits: AILimits{
+			MaxTokens:            2000,
+			MaxRequestsPerMinute: 50,
+			MaxRequestsPerDay:    1000,
+		},
+	}
+}
+
+func (m *AnthropicModel) buildRequest(request AIRequest, maxTokens int, stream bool) anthropicRequest {
+	return anthropicRequest{
+		Model:       m.config.Model,
+		System:      milestoneSystemPrompt(),
+		Messages:    []anthropicMessage{{Role: "user", Content: buildMilestonePrompt(request)}},
+		MaxTokens:   maxTokens,
+		Temperature: 0.7,
+		Stream:      stream,
+	}
+}
+
+// send Messages API 호출 (2xx가 아니면 에러, 성공 시 호출자가 Body를 닫아야 함)
+func (m *AnthropicModel) send(ctx context.Context, body anthropicRequest) (*http.Response, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, anthropicAPIURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-api-key", m.config.APIKey)
+	httpReq.Header.Set("anthropic-version", anthropicAPIVersion)
+
+	resp, err := m.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("Anthropic API 호출 실패: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("Anthropic API 오류 (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return resp, nil
+}
+
+func (m *AnthropicModel) toResponse(content string, tokensUsed int, requestID string, startTime time.Time) (*AIResponse, error) {
+	parsed, err := parseMilestoneContent(content)
+	if err != nil {
+		return nil, fmt.Errorf("Anthropic 응답 파싱 실패: %w", err)
+	}
+
+	return &AIResponse{
+		Milestones: parsed.Milestones,
+		Tips:       parsed.Tips,
+		Warnings:   parsed.Warnings,
+		Metadata: AIMetadata{
+			Provider:     ProviderClaude,
+			Model:        m.config.Model,
+			ResponseTime: time.Since(startTime).Milliseconds(),
+			TokensUsed:   tokensUsed,
+			RequestID:    requestID,
+			GeneratedAt:  time.Now().Format(time.RFC3339),
+		},
+	}, nil
+}
@@ -2,16 +2,34 @@ package services
 
 import (
 	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/redis"
 	"blueprint/internal/config"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"sync"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+const (
+	// AIResponseCacheTTL 동일 프롬프트 응답 캐시 기간 (캐시 응답은 사용 횟수를 차감하지 않음)
+	AIResponseCacheTTL = 24 * time.Hour
+
+	aiGenerateTimeout = 30 * time.Second
+	aiStreamTimeout   = 2 * time.Minute
+)
+
+var ErrAIQuotaExceeded = errors.New("AI 사용 횟수를 초과했습니다")
+
 // BridgeAIService 브릿지 패턴을 적용한 AI 서비스
 type BridgeAIService struct {
+	mu       sync.RWMutex
 	aiModel  AIModelInterface
 	factory  AIModelFactory
 	provider AIProvider
@@ -21,63 +39,66 @@ type BridgeAIService struct {
 
 // NewBridgeAIService 새로운 브릿지 AI 서비스 생성
 func NewBridgeAIService(cfg *config.Config, db *gorm.DB) *BridgeAIService {
-	factory := NewAIModelFactory()
-
-	// 환경변수에서 설정된 AI 제공업체 사용
-	provider := AIProvider(cfg.AI.Provider)
-	var modelConfig map[string]string
-
-	switch provider {
-	case ProviderOpenAI:
-		if cfg.AI.OpenAI.APIKey != "" && cfg.AI.OpenAI.APIKey != "your-openai-api-key" {
-			modelConfig = CreateOpenAIConfig(cfg.AI.OpenAI.APIKey, cfg.AI.OpenAI.Model)
-		} else {
-			// API 키가 없으면 Mock으로 폴백
-			provider = ProviderMock
-			modelConfig = CreateMockConfig(100, 0.0)
-		}
-	case ProviderMock:
-		modelConfig = CreateMockConfig(100, 0.0) // 100ms 지연, 실패율 0%
-	default:
-		// 지원되지 않는 제공업체는 Mock으로 폴백
-		provider = ProviderMock
-		modelConfig = CreateMockConfig(100, 0.0)
+	s := &BridgeAIService{
+		factory: NewAIModelFactory(),
+		config:  cfg,
+		db:      db,
 	}
 
-	aiModel, err := factory.CreateModel(provider, modelConfig)
+	// 환경변수에서 설정된 AI 제공업체 사용 (설정이 없거나 생성 실패 시 Mock으로 폴백)
+	provider := ParseAIProvider(cfg.AI.Provider)
+	aiModel, err := s.createModel(provider)
 	if err != nil {
-		// OpenAI 실패 시 Mock으로 폴백
+		log.Printf("⚠️ AI 제공업체 %s 사용 불가, Mock으로 폴백: %v", provider, err)
 		provider = ProviderMock
-		modelConfig = CreateMockConfig(100, 0.0)
-		aiModel, _ = factory.CreateModel(provider, modelConfig)
+		aiModel, _ = s.createModel(provider)
 	}
 
-	return &BridgeAIService{
-		aiModel:  aiModel,
-		factory:  factory,
-		provider: provider,
-		config:   cfg,
-		db:       db,
-	}
+	s.aiModel = aiModel
+	s.provider = provider
+	return s
 }
 
-// SwitchProvider AI 제공업체 변경
-func (s *BridgeAIService) SwitchProvider(provider AIProvider) error {
-	var modelConfig map[string]string
-
+// modelConfigFor 제공업체별 모델 설정
+func (s *BridgeAIService) modelConfigFor(provider AIProvider) (map[string]string, error) {
 	switch provider {
 	case ProviderOpenAI:
 		if s.config.AI.OpenAI.APIKey == "" || s.config.AI.OpenAI.APIKey == "your-openai-api-key" {
-			return fmt.Errorf("OpenAI API 키가 설정되지 않았습니다")
+			return nil, fmt.Errorf("OpenAI API 키가 설정되지 않았습니다")
+		}
+		return CreateOpenAIConfig(s.config.AI.OpenAI.APIKey, s.config.AI.OpenAI.Model), nil
+	case ProviderClaude:
+		if s.config.AI.Anthropic.APIKey == "" {
+			return nil, fmt.Errorf("Anthropic API 키가 설정되지 않았습니다")
 		}
-		modelConfig = CreateOpenAIConfig(s.config.AI.OpenAI.APIKey, s.config.AI.OpenAI.Model)
+		return CreateAnthropicConfig(s.config.AI.Anthropic.APIKey, s.config.AI.Anthropic.Model), nil
+	case ProviderOllama:
+		return CreateOllamaConfig(s.config.AI.Ollama.BaseURL, s.config.AI.Ollama.Model), nil
 	case ProviderMock:
-		modelConfig = CreateMockConfig(100, 0.0)
+		return CreateMockConfig(100, 0.0), nil // 100ms 지연, 실패율 0%
 	default:
-		return fmt.Errorf("지원되지 않는 제공업체입니다: %s", provider)
+		return nil, fmt.Errorf("지원되지 않는 제공업체입니다: %s", provider)
 	}
+}
 
-	aiModel, err := s.factory.CreateModel(provider, modelConfig)
+func (s *BridgeAIService) createModel(provider AIProvider) (AIModelInterface, error) {
+	modelConfig, err := s.modelConfigFor(provider)
+	if err != nil {
+		return nil, err
+	}
+	return s.factory.CreateModel(provider, modelConfig)
+}
+
+// currentModel 현재 모델과 제공업체 (SwitchProvider와 동시 호출 안전)
+func (s *BridgeAIService) currentModel() (AIModelInterface, AIProvider) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.aiModel, s.provider
+}
+
+// SwitchProvider AI 제공업체 변경
+func (s *BridgeAIService) SwitchProvider(provider AIProvider) error {
+	aiModel, err := s.createModel(provider)
 	if err != nil {
 		return fmt.Errorf("AI 모델 생성 실패: %w", err)
 	}
@@ -90,8 +111,10 @@ func (s *BridgeAIService) SwitchProvider(provider AIProvider) error {
 		return fmt.Errorf("AI 모델 연결 실패: %w", err)
 	}
 
+	s.mu.Lock()
 	s.aiModel = aiModel
 	s.provider = provider
+	s.mu.Unlock()
 
 	fmt.Printf("✅ AI 제공업체를 %s로 변경했습니다\n", provider)
 	return nil
@@ -99,12 +122,14 @@ func (s *BridgeAIService) SwitchProvider(provider AIProvider) error {
 
 // GetCurrentProvider 현재 사용 중인 제공업체 반환
 func (s *BridgeAIService) GetCurrentProvider() AIProvider {
-	return s.provider
+	_, provider := s.currentModel()
+	return provider
 }
 
 // GetProviderInfo 현재 제공업체 정보 반환
 func (s *BridgeAIService) GetProviderInfo() AIProviderInfo {
-	return s.aiModel.GetProviderInfo()
+	aiModel, _ := s.currentModel()
+	return aiModel.GetProviderInfo()
 }
 
 // GetSupportedProviders 지원되는 제공업체 목록 반환
@@ -113,35 +138,178 @@ func (s *BridgeAIService) GetSupportedProviders() []AIProvider {
 }
 
 // GenerateMilestones AI를 사용해서 마일스톤을 생성합니다 🤖
-func (s *BridgeAIService) GenerateMilestones(project models.CreateProjectRequest) (*AIMilestoneResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+func (s *BridgeAIService) GenerateMilestones(userID uint, project models.CreateProjectRequest) (*AIMilestoneResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), aiGenerateTimeout)
 	defer cancel()
 
-	// CreateProjectRequest를 AIRequest로 변환
-	aiRequest := s.convertToAIRequest(project)
+	return s.generate(ctx, userID, project, nil)
+}
 
-	// AI 모델을 통해 마일스톤 생성
-	aiResponse, err := s.aiModel.GenerateMilestones(ctx, aiRequest)
-	if err != nil {
-		// OpenAI 실패 시 자동으로 Mock으로 전환
-		if s.provider == ProviderOpenAI {
-			fmt.Printf("⚠️ OpenAI 실패, Mock 모델로 자동 전환: %v\n", err)
-			if switchErr := s.SwitchProvider(ProviderMock); switchErr == nil {
-				aiResponse, err = s.aiModel.GenerateMilestones(ctx, aiRequest)
+// StreamMilestones 마일스톤 생성 과정을 onDelta로 스트리밍 (캐시 적중 시 전체 응답을 한 번에 전달)
+func (s *BridgeAIService) StreamMilestones(ctx context.Context, userID uint, project models.CreateProjectRequest, onDelta func(chunk string)) (*AIMilestoneResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, aiStreamTimeout)
+	defer cancel()
+
+	if onDelta == nil {
+		onDelta = func(string) {}
+	}
+	return s.generate(ctx, userID, project, onDelta)
+}
+
+// generate 캐시 확인 → 사용 횟수 차감 → 모델 호출 → 사용 기록/캐시 저장
+func (s *BridgeAIService) generate(ctx context.Context, userID uint, project models.CreateProjectRequest, onDelta func(chunk string)) (*AIMilestoneResponse, error) {
+	aiModel, provider := s.currentModel()
+	model := aiModel.GetProviderInfo().Model
+
+	// CreateProjectRequest를 AIRequest로 변환
+	aiRequest := s.convertToAIRequest(project, provider, model)
+	promptHash := hashAIRequest(provider, model, aiRequest)
+
+	// 1. 동일 프롬프트 캐시
+	if cached := s.loadCachedResponse(promptHash); cached != nil {
+		if onDelta != nil {
+			if content, err := json.Marshal(cached); err == nil {
+				onDelta(string(content))
 			}
 		}
+		cached.Cached = true
+		s.recordUsage(userID, provider, model, promptHash, 0, true, onDelta != nil)
+		return cached, nil
+	}
+
+	// 2. 사용 횟수 선차감 (동시 요청으로 한도를 넘지 않도록)
+	if err := s.reserveUsage(userID); err != nil {
+		return nil, err
+	}
+
+	// 3. AI 모델을 통해 마일스톤 생성
+	streamed := false
+	var aiResponse *AIResponse
+	var err error
+	if onDelta != nil {
+		aiResponse, err = aiModel.StreamMilestones(ctx, aiRequest, func(chunk string) {
+			streamed = true
+			onDelta(chunk)
+		})
+	} else {
+		aiResponse, err = aiModel.GenerateMilestones(ctx, aiRequest)
+	}
 
-		if err != nil {
-			return nil, fmt.Errorf("AI 마일스톤 생성 실패: %w", err)
+	// 외부 제공업체 실패 시 이번 요청만 Mock으로 대체 (이미 일부를 스트리밍했다면 대체하지 않음)
+	if err != nil && provider != ProviderMock && !streamed {
+		log.Printf("⚠️ %s 실패, Mock 모델로 대체: %v", provider, err)
+		if mock, mockErr := s.createModel(ProviderMock); mockErr == nil {
+			if onDelta != nil {
+				aiResponse, err = mock.StreamMilestones(ctx, aiRequest, onDelta)
+			} else {
+				aiResponse, err = mock.GenerateMilestones(ctx, aiRequest)
+			}
 		}
 	}
 
+	if err != nil {
+		s.releaseUsage(userID)
+		return nil, fmt.Errorf("AI 마일스톤 생성 실패: %w", err)
+	}
+
 	// AIResponse를 기존 AIMilestoneResponse 형태로 변환 (하위 호환성)
-	return s.convertToLegacyResponse(aiResponse), nil
+	response := s.convertToLegacyResponse(aiResponse)
+	s.recordUsage(userID, aiResponse.Metadata.Provider, aiResponse.Metadata.Model, promptHash,
+		aiResponse.Metadata.TokensUsed, false, onDelta != nil)
+	if aiResponse.Metadata.Provider == provider {
+		s.storeCachedResponse(promptHash, response)
+	}
+
+	return response, nil
+}
+
+// hashAIRequest 제공업체/모델/프롬프트 입력의 해시 (캐시 키)
+func hashAIRequest(provider AIProvider, model string, request AIRequest) string {
+	request.Context = nil
+	payload, _ := json.Marshal(request)
+
+	sum := sha256.Sum256(append([]byte(string(provider)+"|"+model+"|"), payload...))
+	return hex.EncodeToString(sum[:])
+}
+
+func aiCacheKey(promptHash string) string {
+	return "ai_milestones:" + promptHash
+}
+
+// loadCachedResponse 캐시된 응답 조회 (Redis 미연결 시 nil)
+func (s *BridgeAIService) loadCachedResponse(promptHash string) *AIMilestoneResponse {
+	client := redis.GetClient()
+	if client == nil {
+		return nil
+	}
+
+	data, err := client.Get(context.Background(), aiCacheKey(promptHash)).Bytes()
+	if err != nil {
+		return nil
+	}
+
+	var cached AIMilestoneResponse
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil
+	}
+	return &cached
+}
+
+func (s *BridgeAIService) storeCachedResponse(promptHash string, response *AIMilestoneResponse) {
+	client := redis.GetClient()
+	if client == nil {
+		return
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return
+	}
+	if err := client.Set(context.Background(), aiCacheKey(promptHash), data, AIResponseCacheTTL).Err(); err != nil {
+		log.Printf("⚠️ AI 응답 캐시 저장 실패: %v", err)
+	}
+}
+
+// reserveUsage 사용 횟수 1회 차감 (한도 초과 시 ErrAIQuotaExceeded)
+func (s *BridgeAIService) reserveUsage(userID uint) error {
+	result := s.db.Model(&models.User{}).
+		Where("id = ? AND ai_usage_count < ai_usage_limit", userID).
+		Update("ai_usage_count", gorm.Expr("ai_usage_count + 1"))
+	if result.Error != nil {
+		return fmt.Errorf("AI 사용 횟수 업데이트 실패: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrAIQuotaExceeded
+	}
+	return nil
+}
+
+// releaseUsage 생성 실패 시 차감한 사용 횟수 복구
+func (s *BridgeAIService) releaseUsage(userID uint) {
+	if err := s.db.Model(&models.User{}).
+		Where("id = ? AND ai_usage_count > 0", userID).
+		Update("ai_usage_count", gorm.Expr("ai_usage_count - 1")).Error; err != nil {
+		log.Printf("⚠️ AI 사용 횟수 복구 실패 (user %d): %v", userID, err)
+	}
+}
+
+func (s *BridgeAIService) recordUsage(userID uint, provider AIProvider, model, promptHash string, tokens int, cached, streamed bool) {
+	usage := &models.AIUsageLog{
+		UserID:     userID,
+		Provider:   string(provider),
+		Model:      model,
+		PromptHash: promptHash,
+		TokensUsed: tokens,
+		Cached:     cached,
+		Streamed:   streamed,
+	}
+	if err := s.db.Create(usage).Error; err != nil {
+		log.Printf("⚠️ AI 사용 기록 저장 실패 (user %d): %v", userID, err)
+	}
 }
 
 // convertToAIRequest CreateProjectRequest를 AIRequest로 변환
-func (s *BridgeAIService) convertToAIRequest(project models.CreateProjectRequest) AIRequest {
+func (s *BridgeAIService) convertToAIRequest(project models.CreateProjectRequest, provider AIProvider, model string) AIRequest {
 	var targetDateStr string
 	if project.TargetDate != nil {
 		targetDateStr = project.TargetDate.Format(time.RFC3339)
@@ -156,8 +324,8 @@ func (s *BridgeAIService) convertToAIRequest(project models.CreateProjectRequest
 		Priority:    project.Priority,
 		Tags:        project.Tags,
 		Context: map[string]string{
-			"provider": string(s.provider),
-			"model":    s.aiModel.GetProviderInfo().Model,
+			"provider": string(provider),
+			"model":    model,
 		},
 	}
 }
@@ -168,6 +336,8 @@ func (s *BridgeAIService) convertToLegacyResponse(response *AIResponse) *AIMiles
 		Milestones: response.Milestones,
 		Tips:       response.Tips,
 		Warnings:   response.Warnings,
+		Provider:   string(response.Metadata.Provider),
+		Model:      response.Metadata.Model,
 	}
 }
 
@@ -176,7 +346,8 @@ func (s *BridgeAIService) ValidateAPIKey() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	return s.aiModel.ValidateConnection(ctx)
+	aiModel, _ := s.currentModel()
+	return aiModel.ValidateConnection(ctx)
 }
 
 // 기존 AIService 메서드들과의 호환성을 위한 메서드들
@@ -22,7 +22,9 @@ func (f *DefaultAIModelFactory) CreateModel(provider AIProvider, config map[stri
 	case ProviderMock:
 		return f.createMockModel(config)
 	case ProviderClaude:
-		return nil, fmt.Errorf("Claude 모델은 아직 구현되지 않았습니다")
+		return f.createAnthropicModel(config)
+	case ProviderOllama:
+		return f.createOllamaModel(config)
 	case ProviderGemini:
 		return nil, fmt.Errorf("Gemini 모델은 아직 구현되지 않았습니다")
 	default:
@@ -34,8 +36,9 @@ func (f *DefaultAIModelFactory) CreateModel(provider AIProvider, config map[stri
 func (f *DefaultAIModelFactory) GetSupportedProviders() []AIProvider {
 	return []AIProvider{
 		ProviderOpenAI,
+		ProviderClaude,
+		ProviderOllama,
 		ProviderMock,
-		// ProviderClaude,  // 향후 구현 예정
 		// ProviderGemini,  // 향후 구현 예정
 	}
 }
@@ -60,6 +63,42 @@ func (f *DefaultAIModelFactory) createOpenAIModel(config map[string]string) (AIM
 	return NewOpenAIModel(openaiConfig), nil
 }
 
+// createAnthropicModel Anthropic(Claude) 모델 생성
+func (f *DefaultAIModelFactory) createAnthropicModel(config map[string]string) (AIModelInterface, error) {
+	apiKey := config["api_key"]
+	if apiKey == "" {
+		return nil, fmt.Errorf("Anthropic API 키가 필요합니다")
+	}
+
+	model := config["model"]
+	if model == "" {
+		model = "claude-3-5-haiku-latest" // 기본 모델
+	}
+
+	return NewAnthropicModel(AnthropicConfig{
+		APIKey: apiKey,
+		Model:  model,
+	}), nil
+}
+
+// createOllamaModel 로컬 Ollama 모델 생성
+func (f *DefaultAIModelFactory) createOllamaModel(config map[string]string) (AIModelInterface, error) {
+	baseURL := config["base_url"]
+	if baseURL == "" {
+		baseURL = "http://localhost:11434"
+	}
+
+	model := config["model"]
+	if model == "" {
+		return nil, fmt.Errorf("Ollama 모델 이름이 필요합니다")
+	}
+
+	return NewOllamaModel(OllamaConfig{
+		BaseURL: baseURL,
+		Model:   model,
+	}), nil
+}
+
 // createMockModel Mock 모델 생성
 func (f *DefaultAIModelFactory) createMockModel(config map[string]string) (AIModelInterface, error) {
 	mockConfig := MockConfig{
@@ -97,6 +136,19 @@ func CreateOpenAIConfig(apiKey, model string) map[string]string {
 	return config
 }
 
+// CreateAnthropicConfig Anthropic 설정 생성
+func CreateAnthropicConfig(apiKey, model string) map[string]string {
+	return CreateOpenAIConfig(apiKey, model) // 키/모델 구성이 동일
+}
+
+// CreateOllamaConfig Ollama 설정 생성
+func CreateOllamaConfig(baseURL, model string) map[string]string {
+	return map[string]string{
+		"base_url": baseURL,
+		"model":    model,
+	}
+}
+
 // CreateMockConfig Mock 설정 생성
 func CreateMockConfig(responseDelayMs int, failRate float64) map[string]string {
 	return map[string]string{
@@ -110,6 +162,8 @@ func CreateConfigFromEnv(provider AIProvider, apiKey, model string) map[string]s
 	switch provider {
 	case ProviderOpenAI:
 		return CreateOpenAIConfig(apiKey, model)
+	case ProviderClaude:
+		return CreateAnthropicConfig(apiKey, model)
 	case ProviderMock:
 		return CreateMockConfig(0, 0.0) // 기본값
 	default:
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"strings"
 )
 
 // AIProvider AI 제공업체 타입
@@ -9,16 +10,29 @@ type AIProvider string
 
 const (
 	ProviderOpenAI AIProvider = "openai"
-	ProviderClaude AIProvider = "claude"
+	ProviderClaude AIProvider = "claude" // Anthropic
 	ProviderGemini AIProvider = "gemini"
-	ProviderMock   AIProvider = "mock" // 개발/테스트용
+	ProviderOllama AIProvider = "ollama" // 로컬 모델
+	ProviderMock   AIProvider = "mock"   // 개발/테스트용
 )
 
+// ParseAIProvider 설정값을 제공업체로 변환 ("anthropic"은 claude의 별칭)
+func ParseAIProvider(value string) AIProvider {
+	provider := AIProvider(strings.ToLower(strings.TrimSpace(value)))
+	if provider == "anthropic" {
+		return ProviderClaude
+	}
+	return provider
+}
+
 // AIModelInterface 모든 AI 모델이 구현해야 하는 인터페이스
 type AIModelInterface interface {
 	// GenerateMilestones 마일스톤 생성
 	GenerateMilestones(ctx context.Context, request AIRequest) (*AIResponse, error)
 
+	// StreamMilestones 마일스톤 생성 (생성되는 텍스트 조각마다 onDelta 호출, 완료 후 전체 응답 반환)
+	StreamMilestones(ctx context.Context, request AIRequest, onDelta func(chunk string)) (*AIResponse, error)
+
 	// ValidateConnection API 연결 상태 확인
 	ValidateConnection(ctx context.Context) error
 
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -57,6 +58,42 @@ func (m *MockModel) GenerateMilestones(ctx context.Context, request AIRequest) (
 	return response, nil
 }
 
+// StreamMilestones Mock 스트리밍 (완성된 JSON을 조각으로 나눠 전달)
+func (m *MockModel) StreamMilestones(ctx context.Context, request AIRequest, onDelta func(chunk string)) (*AIResponse, error) {
+	response, err := m.GenerateMilestones(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if onDelta == nil {
+		return response, nil
+	}
+
+	content, err := json.Marshal(AIMilestoneResponse{
+		Milestones: response.Milestones,
+		Tips:       response.Tips,
+		Warnings:   response.Warnings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 한글이 잘리지 않도록 rune 단위로 분할
+	runes := []rune(string(content))
+	const chunkSize = 24
+	for start := 0; start < len(runes); start += chunkSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		end := start + chunkSize
+		if end > len(runes) {
+			end = len(runes)
+		}
+		onDelta(string(runes[start:end]))
+	}
+
+	return response, nil
+}
+
 // ValidateConnection Mock API 연결 확인 (항상 성공)
 func (m *MockModel) ValidateConnection(ctx context.Context) error {
 	// Mock은 항상 연결 성공
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OllamaModel 로컬 Ollama 서버 구현체 (/api/chat)
+type OllamaModel struct {
+	httpClient *http.Client
+	config     OllamaConfig
+}
+
+// OllamaConfig Ollama 설정
+type OllamaConfig struct {
+	BaseURL string
+	Model   string
+}
+
+// NewOllamaModel Ollama 모델 생성자
+func NewOllamaModel(config OllamaConfig) *OllamaModel {
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+	return &OllamaModel{
+		httpClient: &http.Client{Timeout: 5 * time.Minute}, // 로컬 모델은 첫 로딩이 느림
+		config:     config,
+	}
+}
+
+type ollamaMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type ollamaChatRequest struct {
+	Model    string          `json:"model"`
+	Messages []ollamaMessage `json:"messages"`
+	Stream   bool            `json:"stream"`
+	Format   string          `json:"format,omitempty"`
+}
+
+// ollamaChatChunk 응답 한 줄 (스트리밍 시 NDJSON, 마지막 줄에 done=true와 토큰 수)
+type ollamaChatChunk struct {
+	Message         ollamaMessage `json:"message"`
+	Done            bool          `json:"done"`
+	PromptEvalCount int           `json:"prompt_eval_count"`
+	EvalCount       int           `json:"eval_count"`
+	Error           string        `json:"error"`
+}
+
+// GenerateMilestones Ollama 로컬 모델로 마일스톤 생성
+func (m *OllamaModel) GenerateMilestones(ctx context.Context, request AIRequest) (*AIResponse, error) {
+	return m.StreamMilestones(ctx, request, nil)
+}
+
+// StreamMilestones Ollama 스트리밍 응답으로 마일스톤 생성
+func (m *OllamaModel) StreamMilestones(ctx context.Context, request AIRequest, onDelta func(chunk string)) (*AIResponse, error) {
+	startTime := time.Now()
+
+	resp, err := m.send(ctx, ollamaChatRequest{
+		Model: m.config.Model,
+		Messages: []ollamaMessage{
+			{Role: "system", Content: milestoneSystemPrompt()},
+			{Role: "user", Content: buildMilestonePrompt(request)},
+		},
+		Stream: true,
+		Format: "json",
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var content strings.Builder
+	var tokensUsed int
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var chunk ollamaChatChunk
+		if err := json.Unmarshal(scanner.Bytes(), &chunk); err != nil {
+			continue
+		}
+		if chunk.Error != "" {
+			return nil, fmt.Errorf("Ollama 스트리밍 실패: %s", chunk.Error)
+		}
+		if chunk.Message.Content != "" {
+			content.WriteString(chunk.Message.Content)
+			if onDelta != nil {
+				onDelta(chunk.Message.Content)
+			}
+		}
+		if chunk.Done {
+			tokensUsed = chunk.PromptEvalCount + chunk.EvalCount
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Ollama 스트리밍 실패: %w", err)
+	}
+
+	parsed, err := parseMilestoneContent(content.String())
+	if err != nil {
+		return nil, fmt.Errorf("Ollama 응답 파싱 실패: %w", err)
+	}
+
+	return &AIResponse{
+		Milestones: parsed.Milestones,
+		Tips:       parsed.Tips,
+		Warnings:   parsed.Warnings,
+		Metadata: AIMetadata{
+			Provider:     ProviderOllama,
+			Model:        m.config.Model,
+			ResponseTime: time.Since(startTime).Milliseconds(),
+			TokensUsed:   tokensUsed,
+			RequestID:    fmt.Sprintf("ollama-%d", startTime.UnixNano()),
+			GeneratedAt:  time.Now().Format(time.RFC3339),
+		},
+	}, nil
+}
+
+// ValidateConnection Ollama 서버 및 모델 존재 확인
+func (m *OllamaModel) ValidateConnection(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.config.BaseURL+"/api/tags", nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Ollama 서버 연결 실패: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var tags struct {
+		Models []struct {
+			Name string `json:"name"`
+		} `json:"models"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
+		return fmt.Errorf("Ollama 응답 디코딩 실패: %w", err)
+	}
+	for _, model := range tags.Models {
+		if model.Name == m.config.Model || strings.TrimSuffix(model.Name, ":latest") == m.config.Model {
+			return nil
+		}
+	}
+	return fmt.Errorf("Ollama에 %s 모델이 없습니다 (ollama pull %s)", m.config.Model, m.config.Model)
+}
+
+// GetProviderInfo Ollama 제공업체 정보 반환
+func (m *OllamaModel) GetProviderInfo() AIProviderInfo {
+	return AIProviderInfo{
+		Name:        "Ollama",
+		Provider:    ProviderOllama,
+		Model:       m.config.Model,
+		Description: "로컬 Ollama 서버의 오픈소스 모델을 사용한 AI 마일스톤 생성",
+		Features: []string{
+			"로컬 실행",
+			"외부 API 비용 없음",
+			"스트리밍 응답",
+		},
+		Limits: AILimits{
+			MaxTokens:            4096,
+			MaxRequestsPerMinute: 10,
+		},
+	}
+}
+
+func (m *OllamaModel) send(ctx context.Context, body ollamaChatRequest) (*http.Response, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.config.BaseURL+"/api/chat", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Ollama API 호출 실패: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("Ollama API 오류 (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return resp, nil
+}
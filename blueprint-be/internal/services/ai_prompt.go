@@ -0,0 +1,111 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// buildMilestonePrompt 요청을 바탕으로 프롬프트 생성 (모든 제공업체 공통)
+func buildMilestonePrompt(request AIRequest) string {
+	categoryNames := map[string]string{
+		"career":    "커리어 성장",
+		"business":  "창업/사업",
+		"education": "교육/학습",
+		"personal":  "개인 발전",
+		"life":      "라이프스타일",
+	}
+
+	categoryName := categoryNames[request.Category]
+	if categoryName == "" {
+		categoryName = request.Category
+	}
+
+	prompt := fmt.Sprintf(`꿈 분석 요청:
+
+제목: %s
+설명: %s
+카테고리: %s
+예산: %d만원
+우선순위: %d/5`,
+		request.Title,
+		request.Description,
+		categoryName,
+		request.Budget,
+		request.Priority,
+	)
+
+	// 목표 날짜가 있는 경우 추가
+	if request.TargetDate != "" {
+		if parsedDate, err := time.Parse(time.RFC3339, request.TargetDate); err == nil {
+			prompt += fmt.Sprintf("\n목표 날짜: %s", parsedDate.Format("2006년 1월 2일"))
+		}
+	}
+
+	// 태그가 있는 경우 추가
+	if len(request.Tags) > 0 {
+		tagsStr := ""
+		for i, tag := range request.Tags {
+			if i > 0 {
+				tagsStr += ", "
+			}
+			tagsStr += tag
+		}
+		prompt += fmt.Sprintf("\n관심 분야: %s", tagsStr)
+	}
+
+	prompt += "\n\n위 꿈을 실현하기 위한 구체적이고 실행 가능한 마일스톤을 제안해주세요."
+
+	return prompt
+}
+
+// milestoneSystemPrompt 시스템 프롬프트 반환 (모든 제공업체 공통)
+func milestoneSystemPrompt() string {
+	return `당신은 한국의 전문 라이프 코치이자 목표 달성 전문가입니다.
+사용자의 꿈을 분석하여 실현 가능하고 구체적인 마일스톤을 제안해주세요.
+
+응답 규칙:
+1. 반드시 JSON 형식으로 응답하세요
+2. 마일스톤은 3-5개, 논리적 순서로 배열
+3. 각 마일스톤은 구체적인 액션 아이템이어야 함
+4. 한국 상황에 맞는 현실적인 제안
+5. 예상 기간은 정확하고 실현 가능해야 함
+
+JSON 구조:
+{
+  "milestones": [
+    {
+      "title": "구체적인 마일스톤 제목",
+      "description": "상세한 실행 방법과 팁",
+      "duration": "예상 소요 기간",
+      "difficulty": "쉬움|보통|어려움",
+      "category": "준비|실행|완성"
+    }
+  ],
+  "tips": ["성공을 위한 추가 팁들"],
+  "warnings": ["주의해야 할 점들"]
+}`
+}
+
+// parseMilestoneContent 모델 출력 텍스트에서 마일스톤 JSON 추출
+//
+// 일부 모델은 JSON을 코드 블록이나 설명 문장으로 감싸므로 첫 '{'부터 마지막 '}'까지만 파싱한다.
+func parseMilestoneContent(content string) (*AIMilestoneResponse, error) {
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("응답에서 JSON을 찾을 수 없습니다")
+	}
+
+	var parsed AIMilestoneResponse
+	if err := json.Unmarshal([]byte(content[start:end+1]), &parsed); err != nil {
+		return nil, err
+	}
+
+	// 마일스톤 순서 정렬
+	for i := range parsed.Milestones {
+		parsed.Milestones[i].Order = i + 1
+	}
+	return &parsed, nil
+}
@@ -32,6 +32,9 @@ type AIMilestoneResponse struct {
 	Milestones []AIMilestone `json:"milestones"`
 	Tips       []string      `json:"tips"`     // 추가 팁
 	Warnings   []string      `json:"warnings"` // 주의사항
+	Provider   string        `json:"provider,omitempty"`
+	Model      string        `json:"model,omitempty"`
+	Cached     bool          `json:"cached,omitempty"` // 동일 프롬프트 캐시 응답
 }
 
 type AIMilestone struct {
@@ -1,11 +1,18 @@
 package services
 
-import "blueprint-module/pkg/models"
+import (
+	"context"
+
+	"blueprint-module/pkg/models"
+)
 
 // AIServiceInterface AI 서비스의 공통 인터페이스
 type AIServiceInterface interface {
-	// GenerateMilestones AI를 사용해서 마일스톤을 생성합니다
-	GenerateMilestones(project models.CreateProjectRequest) (*AIMilestoneResponse, error)
+	// GenerateMilestones AI를 사용해서 마일스톤을 생성합니다 (사용 횟수 차감 포함)
+	GenerateMilestones(userID uint, project models.CreateProjectRequest) (*AIMilestoneResponse, error)
+
+	// StreamMilestones 생성 중인 응답을 onDelta로 스트리밍합니다 (사용 횟수 차감 포함)
+	StreamMilestones(ctx context.Context, userID uint, project models.CreateProjectRequest, onDelta func(chunk string)) (*AIMilestoneResponse, error)
 
 	// CheckAIUsageLimit 사용자의 AI 사용 횟수를 체크합니다
 	CheckAIUsageLimit(userID uint) (bool, int, error)
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/redis"
+	"blueprint-module/pkg/secrets"
+
+	"gorm.io/gorm"
+)
+
+const (
+	maxActiveAPIKeys     = 10               // 사용자당 활성 키 최대 개수
+	apiKeySignatureSkew  = 30 * time.Second // 허용 시각 오차
+	apiKeyReplayCacheTTL = 2 * apiKeySignatureSkew
+)
+
+var (
+	ErrAPIKeyInvalid          = errors.New("유효하지 않은 API 키입니다")
+	ErrAPIKeySignatureInvalid = errors.New("API 서명이 올바르지 않습니다")
+	ErrAPIKeyTimestampInvalid = errors.New("요청 시각이 허용 범위를 벗어났습니다")
+	ErrAPIKeyReplayed         = errors.New("이미 사용된 서명입니다")
+)
+
+// APIKeyService API 키 발급/폐기 및 HMAC 서명 검증 서비스
+type APIKeyService struct {
+	db            *gorm.DB
+	encryptionKey []byte // secret 암호화용 (AES-256-GCM)
+}
+
+// NewAPIKeyService 생성자
+func NewAPIKeyService(db *gorm.DB, encryptionSecret string) *APIKeyService {
+	return &APIKeyService{
+		db:            db,
+		encryptionKey: secrets.DeriveKey(encryptionSecret),
+	}
+}
+
+// CreateKey API 키 발급 (secret은 응답으로 한 번만 반환)
+func (s *APIKeyService) CreateKey(userID uint, req *models.CreateAPIKeyRequest) (*models.CreateAPIKeyResponse, error) {
+	var activeCount int64
+	s.db.Model(&models.APIKey{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Count(&activeCount)
+	if activeCount >= maxActiveAPIKeys {
+		return nil, fmt.Errorf("API 키는 최대 %d개까지 발급할 수 있습니다", maxActiveAPIKeys)
+	}
+
+	keyID, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := s.encrypt(secret)
+	if err != nil {
+		return nil, fmt.Errorf("API 키 암호화 실패: %w", err)
+	}
+
+	apiKey := &models.APIKey{
+		UserID:          userID,
+		Name:            req.Name,
+		KeyID:           "bpk_" + keyID,
+		Scopes:          normalizeScopes(req.Scopes),
+		EncryptedSecret: encrypted,
+	}
+	if req.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, req.ExpiresInDays)
+		apiKey.ExpiresAt = &expiresAt
+	}
+
+	if err := s.db.Create(apiKey).Error; err != nil {
+		return nil, fmt.Errorf("API 키 저장 실패: %w", err)
+	}
+
+	return &models.CreateAPIKeyResponse{
+		APIKey: apiKey,
+		Secret: "bps_" + secret,
+	}, nil
+}
+
+// ListKeys 사용자 API 키 목록 (폐기된 키 포함)
+func (s *APIKeyService) ListKeys(userID uint) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	if err := s.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&keys).Error; err != nil {
+		return nil, fmt.Errorf("API 키 목록 조회 실패: %w", err)
+	}
+	return keys, nil
+}
+
+// RevokeKey API 키 폐기
+func (s *APIKeyService) RevokeKey(userID, keyID uint) error {
+	now := time.Now()
+	result := s.db.Model(&models.APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", keyID, userID).
+		Update("revoked_at", &now)
+	if result.Error != nil {
+		return fmt.Errorf("API 키 폐기 실패: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("API 키를 찾을 수 없습니다")
+	}
+	return nil
+}
+
+// revokeAPIKeys 사용자의 폐기되지 않은 API 키 모두 폐기 (계정 삭제 요청/병합 시)
+func revokeAPIKeys(tx *gorm.DB, userID uint) error {
+	return tx.Model(&models.APIKey{}).Where("user_id = ? AND revoked_at IS NULL", userID).Update("revoked_at", time.Now()).Error
+}
+
+// Authenticate HMAC 서명 검증
+//
+// signature = hex(HMAC-SHA256(secret, timestamp + METHOD + requestURI + body))
+// timestamp는 유닉스 밀리초, 서버 시각 기준 ±30초 이내여야 한다.
+func (s *APIKeyService) Authenticate(keyID, timestamp, signature, method, requestURI string, body []byte, clientIP string) (*models.APIKey, error) {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, ErrAPIKeyTimestampInvalid
+	}
+	skew := time.Since(time.UnixMilli(ts))
+	if skew > apiKeySignatureSkew || skew < -apiKeySignatureSkew {
+		return nil, ErrAPIKeyTimestampInvalid
+	}
+
+	var apiKey models.APIKey
+	if err := s.db.Where("key_id = ?", keyID).First(&apiKey).Error; err != nil {
+		return nil, ErrAPIKeyInvalid
+	}
+	if !apiKey.IsActive() {
+		return nil, ErrAPIKeyInvalid
+	}
+
+	secret, err := s.decrypt(apiKey.EncryptedSecret)
+	if err != nil {
+		return nil, ErrAPIKeyInvalid
+	}
+
+	mac := hmac.New(sha256.New, []byte("bps_"+secret))
+	mac.Write([]byte(timestamp + strings.ToUpper(method) + requestURI))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(signature))) {
+		return nil, ErrAPIKeySignatureInvalid
+	}
+
+	// 재전송 공격 방지 (허용 시각 범위 동안 동일 서명 재사용 차단)
+	if client := redis.GetClient(); client != nil {
+		replayKey := fmt.Sprintf("api_key_sig:%s:%s", apiKey.KeyID, expected)
+		ok, err := client.SetNX(context.Background(), replayKey, 1, apiKeyReplayCacheTTL).Result()
+		if err == nil && !ok {
+			return nil, ErrAPIKeyReplayed
+		}
+	}
+
+	now := time.Now()
+	s.db.Model(&apiKey).Updates(map[string]interface{}{
+		"last_used_at": &now,
+		"last_used_ip": clientIP,
+	})
+
+	return &apiKey, nil
+}
+
+func (s *APIKeyService) encrypt(plaintext string) (string, error) {
+	return secrets.Encrypt(s.encryptionKey, plaintext)
+}
+
+func (s *APIKeyService) decrypt(ciphertext string) (string, error) {
+	return secrets.Decrypt(s.encryptionKey, ciphertext)
+}
+
+// normalizeScopes 중복 제거 후 정해진 순서로 정렬
+func normalizeScopes(scopes []string) string {
+	requested := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		requested[strings.TrimSpace(scope)] = true
+	}
+
+	var result []string
+	for _, scope := range models.ValidAPIKeyScopes {
+		if requested[string(scope)] {
+			result = append(result, string(scope))
+		}
+	}
+	return strings.Join(result, ",")
+}
+
+// randomToken URL-safe 무작위 토큰
+func randomToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("토큰 생성 실패: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,258 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/queue"
+
+	"gorm.io/gorm"
+)
+
+const (
+	arbitrationEvidenceCategory = "arbitration_evidence"
+	evidenceFileSizeLimit       = 10 << 20 // 파일당 10MB
+	maxEvidenceFilesPerCase     = 20       // 사건(심급)당 최대 증거 파일 수
+	maxEvidenceFilesPerUpload   = 5
+
+	// evidenceVotingCutoff 투표 마감 전 이 시간 안에는 새 증거를 받지 않음 (배심원 검토 시간 확보)
+	evidenceVotingCutoff = 24 * time.Hour
+)
+
+var (
+	ErrEvidenceCaseNotFound = errors.New("사건을 찾을 수 없습니다")
+	ErrEvidenceForbidden    = errors.New("사건 당사자 또는 배심원만 증거를 열람할 수 있습니다")
+	ErrEvidenceNotFound     = errors.New("증거 파일을 찾을 수 없습니다")
+	ErrEvidenceNotScanned   = errors.New("악성코드 검사가 끝나지 않은 파일입니다")
+	ErrEvidenceBlocked      = errors.New("악성코드 검사를 통과하지 못한 파일입니다")
+	ErrEvidenceClosed       = errors.New("현재 단계에서는 증거를 제출할 수 없습니다")
+)
+
+// ArbitrationEvidenceService 분쟁 증거 파일 제출/열람 (악성코드 검사는 워커 담당)
+type ArbitrationEvidenceService struct {
+	db          *gorm.DB
+	fileService *FileService
+}
+
+// NewArbitrationEvidenceService 생성자
+func NewArbitrationEvidenceService(db *gorm.DB, fileService *FileService) *ArbitrationEvidenceService {
+	return &ArbitrationEvidenceService{
+		db:          db,
+		fileService: fileService,
+	}
+}
+
+// EvidenceUpload 업로드 파일 1건
+type EvidenceUpload struct {
+	File   multipart.File
+	Header *multipart.FileHeader
+}
+
+// SubmitEvidence 당사자 증거 제출 (단계별 제출 기한 적용, 저장 후 검사 작업 등록)
+func (s *ArbitrationEvidenceService) SubmitEvidence(caseID, userID uint, description string, uploads []EvidenceUpload) ([]models.ArbitrationEvidence, error) {
+	var arbitrationCase models.ArbitrationCase
+	if err := s.db.First(&arbitrationCase, caseID).Error; err != nil {
+		return nil, ErrEvidenceCaseNotFound
+	}
+	if !isArbitrationParty(&arbitrationCase, userID) {
+		return nil, errors.New("사건 당사자만 증거를 제출할 수 있습니다")
+	}
+	if err := checkEvidenceWindow(&arbitrationCase, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if len(uploads) == 0 {
+		return nil, errors.New("증거 파일을 첨부해주세요")
+	}
+	if len(uploads) > maxEvidenceFilesPerUpload {
+		return nil, fmt.Errorf("한 번에 최대 %d개 파일까지 제출할 수 있습니다", maxEvidenceFilesPerUpload)
+	}
+	var existing int64
+	s.db.Model(&models.ArbitrationEvidence{}).Where("case_id = ?", caseID).Count(&existing)
+	if existing+int64(len(uploads)) > maxEvidenceFilesPerCase {
+		return nil, fmt.Errorf("사건당 증거 파일은 최대 %d개입니다", maxEvidenceFilesPerCase)
+	}
+	for _, upload := range uploads {
+		if upload.Header.Size > evidenceFileSizeLimit {
+			return nil, fmt.Errorf("%s: 증거 파일은 10MB 이하만 업로드할 수 있습니다", upload.Header.Filename)
+		}
+	}
+
+	evidences := make([]models.ArbitrationEvidence, 0, len(uploads))
+	stored := make([]*StoredFile, 0, len(uploads))
+	cleanup := func() {
+		for _, file := range stored {
+			s.fileService.RemoveStoredFile(file)
+		}
+	}
+
+	for _, upload := range uploads {
+		hash := sha256.New()
+		if _, err := io.Copy(hash, upload.File); err != nil {
+			cleanup()
+			return nil, fmt.Errorf("파일 읽기 실패: %w", err)
+		}
+		if _, err := upload.File.Seek(0, io.SeekStart); err != nil {
+			cleanup()
+			return nil, fmt.Errorf("파일 읽기 실패: %w", err)
+		}
+
+		file, err := s.fileService.StoreFile(upload.File, upload.Header, arbitrationEvidenceCategory)
+		if err != nil {
+			cleanup()
+			return nil, err
+		}
+		stored = append(stored, file)
+
+		evidences = append(evidences, models.ArbitrationEvidence{
+			CaseID:       caseID,
+			SubmitterID:  userID,
+			Phase:        arbitrationCase.Status,
+			FilePath:     file.Path(),
+			OriginalName: file.OriginalName,
+			ContentType:  file.ContentType,
+			Size:         file.Size,
+			SHA256:       hex.EncodeToString(hash.Sum(nil)),
+			Description:  description,
+			ScanStatus:   models.EvidenceScanPending,
+		})
+	}
+
+	if err := s.db.Create(&evidences).Error; err != nil {
+		cleanup()
+		return nil, fmt.Errorf("증거 저장 실패: %w", err)
+	}
+
+	for _, evidence := range evidences {
+		job := map[string]interface{}{
+			"type":        "scan_arbitration_evidence",
+			"evidence_id": evidence.ID,
+			"file_path":   evidence.FilePath,
+			"timestamp":   time.Now().Unix(),
+		}
+		if err := queue.PublishJob("file_processing_queue", job); err != nil {
+			// 검사 대기 상태로 남겨 열람을 막는다 (재등록은 운영자 처리)
+			s.db.Model(&models.ArbitrationEvidence{}).Where("id = ?", evidence.ID).
+				Update("scan_status", models.EvidenceScanFailed)
+		}
+	}
+
+	return evidences, nil
+}
+
+// ListEvidence 증거 목록 (항소심은 이전 심급 증거 포함, 오래된 순)
+func (s *ArbitrationEvidenceService) ListEvidence(caseID, userID uint) ([]models.ArbitrationEvidence, error) {
+	arbitrationCase, err := s.authorize(caseID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	caseIDs, err := s.caseLineage(arbitrationCase)
+	if err != nil {
+		return nil, err
+	}
+
+	var evidences []models.ArbitrationEvidence
+	if err := s.db.Where("case_id IN ?", caseIDs).Order("created_at ASC, id ASC").Find(&evidences).Error; err != nil {
+		return nil, fmt.Errorf("증거 목록 조회 실패: %w", err)
+	}
+	return evidences, nil
+}
+
+// OpenEvidence 검사를 통과한 증거 파일 열기
+func (s *ArbitrationEvidenceService) OpenEvidence(caseID, evidenceID, userID uint) (*os.File, *StoredFile, *models.ArbitrationEvidence, error) {
+	arbitrationCase, err := s.authorize(caseID, userID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	caseIDs, err := s.caseLineage(arbitrationCase)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var evidence models.ArbitrationEvidence
+	if err := s.db.Where("id = ? AND case_id IN ?", evidenceID, caseIDs).First(&evidence).Error; err != nil {
+		return nil, nil, nil, ErrEvidenceNotFound
+	}
+	switch evidence.ScanStatus {
+	case models.EvidenceScanClean:
+	case models.EvidenceScanPending:
+		return nil, nil, nil, ErrEvidenceNotScanned
+	default:
+		return nil, nil, nil, ErrEvidenceBlocked
+	}
+
+	category, key, err := splitStoragePath(evidence.FilePath)
+	if err != nil || category != arbitrationEvidenceCategory {
+		return nil, nil, nil, ErrEvidenceNotFound
+	}
+	file, stored, err := s.fileService.OpenFile(category, key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return file, stored, &evidence, nil
+}
+
+// authorize 당사자(항소인 포함) 또는 선정된 배심원만 허용
+func (s *ArbitrationEvidenceService) authorize(caseID, userID uint) (*models.ArbitrationCase, error) {
+	var arbitrationCase models.ArbitrationCase
+	if err := s.db.First(&arbitrationCase, caseID).Error; err != nil {
+		return nil, ErrEvidenceCaseNotFound
+	}
+	if isArbitrationParty(&arbitrationCase, userID) {
+		return &arbitrationCase, nil
+	}
+	for _, jurorID := range arbitrationCase.SelectedJurors {
+		if jurorID == userID {
+			return &arbitrationCase, nil
+		}
+	}
+	return nil, ErrEvidenceForbidden
+}
+
+// caseLineage 현재 사건과 이전 심급 사건 ID 목록
+func (s *ArbitrationEvidenceService) caseLineage(arbitrationCase *models.ArbitrationCase) ([]uint, error) {
+	ids := []uint{arbitrationCase.ID}
+	for parentID := arbitrationCase.ParentCaseID; parentID != nil; {
+		var parent models.ArbitrationCase
+		if err := s.db.Select("id", "parent_case_id").First(&parent, *parentID).Error; err != nil {
+			return nil, fmt.Errorf("이전 심급 사건 조회 실패: %w", err)
+		}
+		ids = append(ids, parent.ID)
+		parentID = parent.ParentCaseID
+	}
+	return ids, nil
+}
+
+// checkEvidenceWindow 단계별 증거 제출 가능 여부
+// 배심원 선정 전후와 증거 기간에는 자유롭게, 투표 중에는 마감 24시간 전까지, 공개 단계 이후는 불가 (새 증거는 항소로 제출)
+func checkEvidenceWindow(arbitrationCase *models.ArbitrationCase, now time.Time) error {
+	switch arbitrationCase.Status {
+	case models.ArbitrationStatusSubmitted,
+		models.ArbitrationStatusUnderReview,
+		models.ArbitrationStatusJurySelection,
+		models.ArbitrationStatusEvidence:
+		return nil
+	case models.ArbitrationStatusVoting:
+		if arbitrationCase.VotingDeadline != nil && now.After(arbitrationCase.VotingDeadline.Add(-evidenceVotingCutoff)) {
+			return errors.New("투표 마감 24시간 전부터는 새 증거를 제출할 수 없습니다")
+		}
+		return nil
+	default:
+		return ErrEvidenceClosed
+	}
+}
+
+func isArbitrationParty(arbitrationCase *models.ArbitrationCase, userID uint) bool {
+	if arbitrationCase.PlaintiffID == userID || arbitrationCase.DefendantID == userID {
+		return true
+	}
+	return arbitrationCase.AppellantID != nil && *arbitrationCase.AppellantID == userID
+}
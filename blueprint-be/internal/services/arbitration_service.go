@@ -1,30 +1,65 @@
 package services
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"math"
+	"math/big"
+	"strings"
 	"time"
 
 	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/redis"
 	"gorm.io/gorm"
 	"github.com/gin-gonic/gin"
 )
 
+// 분쟁 단계별 기간 (배심원단 구성 기한은 SubmitCase에서 48시간으로 설정)
+const (
+	arbitrationVotingPeriod = 72 * time.Hour // 투표(commit) 기간
+	arbitrationRevealPeriod = 24 * time.Hour // 투표 공개(reveal) 기간
+
+	arbitrationFeeRate = 0.10 // 승소/기각 시에도 신청인 스테이킹에서 배심원 보상 풀로 가는 비율
+	jurorSlashRate     = 0.10 // 소수 의견/미참여 배심원 스테이킹 차감 비율
+	jurorNoRevealRate  = 0.20 // 커밋 후 공개하지 않은 배심원 스테이킹 차감 비율 (표를 숨긴 것으로 간주)
+	jurorWeightScale   = 1_000_000
+
+	arbitrationAppealWindow = 72 * time.Hour // 판결 후 항소 가능 기간
+	maxAppealRounds         = 3              // 최대 항소 횟수 (마지막 항소심 판결은 최종 확정)
+
+	mediatedJurorReduction = 2 // 조정을 거쳐 쟁점이 좁혀진 사건의 배심원 감축 수
+	minMediatedJurors      = 3
+)
+
 // ArbitrationService 탈중앙화된 분쟁 해결 서비스
 type ArbitrationService struct {
-	db *gorm.DB
+	db                  *gorm.DB
+	notificationService *NotificationService
+	webhookPublisher    *WebhookPublisher
+	trustScores         *TrustScoreService // 배심원 신뢰 점수 확인 (nil이면 제한 없음)
 }
 
 // NewArbitrationService 생성자
 func NewArbitrationService(db *gorm.DB) *ArbitrationService {
 	return &ArbitrationService{
-		db: db,
+		db:                  db,
+		notificationService: NewNotificationService(db),
+		webhookPublisher:    NewWebhookPublisher(),
 	}
 }
 
+// SetTrustScores 배심원 등록/선정에 신뢰 점수 최소 기준 적용
+func (s *ArbitrationService) SetTrustScores(trustScores *TrustScoreService) {
+	s.trustScores = trustScores
+}
+
 // SubmitCase 분쟁 사건 제기
 func (s *ArbitrationService) SubmitCase(req *models.SubmitArbitrationRequest, plaintiffID uint) (*models.ArbitrationCase, error) {
 	// 1. 사용자 지갑 확인
@@ -44,7 +79,7 @@ func (s *ArbitrationService) SubmitCase(req *models.SubmitArbitrationRequest, pl
 	}
 
 	// 4. 사건 번호 생성
-	caseNumber, err := s.generateCaseNumber()
+	caseNumber, err := s.generateCaseNumber(s.db)
 	if err != nil {
 		return nil, fmt.Errorf("사건 번호 생성 실패: %w", err)
 	}
@@ -104,49 +139,213 @@ func (s *ArbitrationService) SubmitCase(req *models.SubmitArbitrationRequest, pl
 	return arbitrationCase, nil
 }
 
-// StartJurySelection 배심원단 선정 프로세스
-func (s *ArbitrationService) startJurySelection(caseID uint) {
+// OpenVerificationEscalation 검증 기간 내 결론이 나지 않은 마일스톤을 배심원 중재로 이관 (스테이킹 없는 시스템 사건)
+//
+// 증거 제출자를 신청인, 프로젝트 소유자를 피신청인으로 한다. 배심원단 구성은 커밋 후 startJurySelection으로 시작한다.
+func (s *ArbitrationService) OpenVerificationEscalation(tx *gorm.DB, milestone *models.Milestone, proof *models.MilestoneProof, ownerID uint, reason string) (*models.ArbitrationCase, error) {
+	caseNumber, err := s.generateCaseNumber(tx)
+	if err != nil {
+		return nil, fmt.Errorf("사건 번호 생성 실패: %w", err)
+	}
+
+	milestoneID := milestone.ID
+	arbitrationCase := &models.ArbitrationCase{
+		CaseNumber:            caseNumber,
+		PlaintiffID:           proof.UserID,
+		DefendantID:           ownerID,
+		DisputeType:           models.DisputeTypeMilestoneCompletion,
+		MilestoneID:           &milestoneID,
+		Title:                 fmt.Sprintf("'%s' 마일스톤 완료 여부 판정", milestone.Title),
+		Description:           reason,
+		Evidence:              fmt.Sprintf(`{"proof_id":%d,"approval_votes":%d,"rejection_votes":%d}`, proof.ID, milestone.ApprovalVotes, milestone.RejectionVotes),
+		Status:                models.ArbitrationStatusSubmitted,
+		Priority:              s.calculatePriority(models.DisputeTypeMilestoneCompletion, 0),
+		RequiredJurors:        s.calculateRequiredJurors(models.DisputeTypeMilestoneCompletion, 0),
+		JuryFormationDeadline: time.Now().Add(48 * time.Hour),
+	}
+	if err := tx.Create(arbitrationCase).Error; err != nil {
+		return nil, fmt.Errorf("분쟁 사건 생성 실패: %w", err)
+	}
+	return arbitrationCase, nil
+}
+
+// OpenSurveillanceEscalation 시장 감시 알림을 시세 조종 사건으로 이관 (스테이킹 없는 시스템 사건)
+//
+// 이관한 운영자를 신청인, 알림의 주 대상 계정을 피신청인으로 하고 탐지 근거를 증거로 남긴다.
+// 배심원단 구성은 커밋 후 startJurySelection으로 시작한다.
+func (s *ArbitrationService) OpenSurveillanceEscalation(tx *gorm.DB, alert *models.SurveillanceAlert, reviewerID uint, note string) (*models.ArbitrationCase, error) {
+	if len(alert.UserIDs) == 0 {
+		return nil, errors.New("알림에 대상 계정이 없습니다")
+	}
+	caseNumber, err := s.generateCaseNumber(tx)
+	if err != nil {
+		return nil, fmt.Errorf("사건 번호 생성 실패: %w", err)
+	}
+
+	evidence, err := json.Marshal(map[string]interface{}{
+		"surveillance_alert_id": alert.ID,
+		"type":                  alert.Type,
+		"user_ids":              alert.UserIDs,
+		"evidence":              alert.Evidence,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("증거 직렬화 실패: %w", err)
+	}
+
+	description := alert.Summary
+	if note != "" {
+		description += "\n\n운영자 의견: " + note
+	}
+	milestoneID := alert.MilestoneID
+	arbitrationCase := &models.ArbitrationCase{
+		CaseNumber:            caseNumber,
+		PlaintiffID:           reviewerID,
+		DefendantID:           alert.UserIDs[0],
+		DisputeType:           models.DisputeTypeMarketManipulation,
+		MilestoneID:           &milestoneID,
+		Title:                 fmt.Sprintf("시장 감시 알림 #%d (%s)", alert.ID, alert.Type),
+		Description:           description,
+		Evidence:              string(evidence),
+		Status:                models.ArbitrationStatusSubmitted,
+		Priority:              s.calculatePriority(models.DisputeTypeMarketManipulation, 0),
+		RequiredJurors:        s.calculateRequiredJurors(models.DisputeTypeMarketManipulation, 0),
+		JuryFormationDeadline: time.Now().Add(48 * time.Hour),
+	}
+	if len(alert.Evidence.TradeIDs) > 0 {
+		tradeID := alert.Evidence.TradeIDs[0]
+		arbitrationCase.TradeID = &tradeID
+	}
+	if err := tx.Create(arbitrationCase).Error; err != nil {
+		return nil, fmt.Errorf("분쟁 사건 생성 실패: %w", err)
+	}
+	return arbitrationCase, nil
+}
+
+// OpenMediationEscalation 결렬된 조정을 배심원 중재로 이관
+//
+// 조정 신청인/피신청인이 그대로 당사자가 되고, 분쟁 제기 때 잠근 스테이킹을 사건 스테이킹으로 승계해 추가 스테이킹이 없다.
+// 청구 금액은 양측 마지막 제안의 차이이고, 조정 기록으로 쟁점이 좁혀졌으므로 배심원 수를 줄인다.
+// 배심원단 구성은 커밋 후 startJurySelection으로 시작한다.
+func (s *ArbitrationService) OpenMediationEscalation(tx *gorm.DB, mediation *models.Mediation, offers []models.MediationOffer, reason string) (*models.ArbitrationCase, error) {
+	caseNumber, err := s.generateCaseNumber(tx)
+	if err != nil {
+		return nil, fmt.Errorf("사건 번호 생성 실패: %w", err)
+	}
+
+	disputeType := models.DisputeTypeMilestoneCompletion
+	if mediation.SourceType == models.MediationSourceMentorReport {
+		disputeType = models.DisputeTypeMentorMalpractice
+	}
+
+	// 신청인의 마지막 요구액과 피신청인의 마지막 제안액 차이 (offers는 오래된 순)
+	var claimantAsk, respondentOffer int64
+	for _, offer := range offers {
+		if offer.ProposerID == mediation.ClaimantID {
+			claimantAsk = offer.Compensation
+		} else {
+			respondentOffer = offer.Compensation
+		}
+	}
+	claimed := claimantAsk - respondentOffer
+	if claimed < 0 {
+		claimed = 0
+	}
+
+	evidence, err := json.Marshal(map[string]interface{}{
+		"mediation_id": mediation.ID,
+		"source_type":  mediation.SourceType,
+		"source_id":    mediation.SourceID,
+		"offers":       offers,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("증거 직렬화 실패: %w", err)
+	}
+
+	description := fmt.Sprintf("조정 #%d 결렬 (제안 %d건)", mediation.ID, len(offers))
+	if reason != "" {
+		description += "\n\n" + reason
+	}
+	requiredJurors := s.calculateRequiredJurors(disputeType, claimed) - mediatedJurorReduction
+	if requiredJurors < minMediatedJurors {
+		requiredJurors = minMediatedJurors
+	}
+	arbitrationCase := &models.ArbitrationCase{
+		CaseNumber:            caseNumber,
+		PlaintiffID:           mediation.ClaimantID,
+		DefendantID:           mediation.RespondentID,
+		DisputeType:           disputeType,
+		MilestoneID:           mediation.MilestoneID,
+		MentorshipID:          mediation.MentorshipID,
+		Title:                 mediation.Title,
+		Description:           description,
+		Evidence:              string(evidence),
+		ClaimedAmount:         claimed,
+		Status:                models.ArbitrationStatusSubmitted,
+		Priority:              s.calculatePriority(disputeType, claimed),
+		StakeAmount:           mediation.ClaimantStake,
+		RequiredJurors:        requiredJurors,
+		JuryFormationDeadline: time.Now().Add(48 * time.Hour),
+	}
+	if err := tx.Create(arbitrationCase).Error; err != nil {
+		return nil, fmt.Errorf("분쟁 사건 생성 실패: %w", err)
+	}
+	return arbitrationCase, nil
+}
+
+// StartJurySelection 배심원단 선정 프로세스 (선정 후 투표 단계로 전환되면 true)
+func (s *ArbitrationService) startJurySelection(caseID uint) bool {
 	// 1. 사건 정보 조회
 	var arbitrationCase models.ArbitrationCase
 	if err := s.db.First(&arbitrationCase, caseID).Error; err != nil {
-		return
+		return false
 	}
 
 	// 2. 자격을 갖춘 배심원 후보 조회
-	candidates, err := s.getEligibleJurors(arbitrationCase.DisputeType, arbitrationCase.PlaintiffID, arbitrationCase.DefendantID)
+	candidates, loads, err := s.getEligibleJurors(arbitrationCase.DisputeType, arbitrationCase.PlaintiffID, arbitrationCase.DefendantID)
 	if err != nil {
-		return
+		return false
 	}
 
 	// 3. 무작위로 배심원 선정
-	selectedJurors, err := s.selectJurors(candidates, arbitrationCase.RequiredJurors)
+	selectedJurors, err := s.selectJurors(candidates, loads, arbitrationCase.RequiredJurors)
 	if err != nil {
-		return
+		return false
 	}
 
-	// 4. 선정된 배심원들에게 알림 및 스테이킹 요구
-	s.db.Transaction(func(tx *gorm.DB) error {
-		// 배심원 목록 업데이트
-		arbitrationCase.SelectedJurors = selectedJurors
-		arbitrationCase.Status = models.ArbitrationStatusJurySelection
-		tx.Save(&arbitrationCase)
-
-		// 배심원들에게 알림 발송 및 스테이킹 요구
-		for _, jurorID := range selectedJurors {
-			s.notifyJurorSelection(jurorID, caseID)
-		}
+	// 4. 배심원 목록 저장 후 바로 투표 단계 시작 (이미 다른 곳에서 선정했으면 건너뜀)
+	votingDeadline := time.Now().Add(arbitrationVotingPeriod)
+	result := s.db.Model(&models.ArbitrationCase{}).
+		Where("id = ? AND status = ?", caseID, arbitrationCase.Status).
+		Updates(&models.ArbitrationCase{
+			SelectedJurors: selectedJurors,
+			Status:         models.ArbitrationStatusVoting,
+			VotingStarted:  true,
+			VotingDeadline: &votingDeadline,
+		})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false
+	}
 
-		return nil
-	})
+	// 5. 선정된 배심원들에게 알림
+	for _, jurorID := range selectedJurors {
+		s.notifyJurorSelection(jurorID, caseID)
+	}
+	return true
 }
 
-// GetEligibleJurors 자격을 갖춘 배심원 후보 조회
-func (s *ArbitrationService) getEligibleJurors(disputeType models.ArbitrationDisputeType, plaintiffID, defendantID uint) ([]models.JurorQualification, error) {
+// GetEligibleJurors 자격을 갖춘 배심원 후보 조회 (휴가 중이거나 동시 사건 한도를 채운 배심원 제외, 후보별 진행 중 사건 수 함께 반환)
+func (s *ArbitrationService) getEligibleJurors(disputeType models.ArbitrationDisputeType, plaintiffID, defendantID uint) ([]models.JurorQualification, map[uint]int, error) {
 	var candidates []models.JurorQualification
 
 	// 기본 자격 요건: 충분한 스테이킹, 활성 상태, 이해충돌 없음
 	query := s.db.Where("is_active = ? AND is_suspended = ? AND current_stake >= min_stake_amount", true, false).
-		Where("user_id != ? AND user_id != ?", plaintiffID, defendantID) // 이해충돌 방지
+		Where("user_id != ? AND user_id != ?", plaintiffID, defendantID). // 이해충돌 방지
+		Where("vacation_until IS NULL OR vacation_until <= ?", time.Now())
+
+	// 신뢰 점수 미달 사용자는 선정 대상에서 제외
+	if minimum := s.trustScores.MinimumScore(TrustGateJuror); minimum > 0 {
+		query = query.Where("user_id IN (?)", s.db.Model(&models.User{}).Select("id").Where("trust_score >= ?", minimum))
+	}
 
 	// 분쟁 유형별 전문성 고려
 	switch disputeType {
@@ -162,66 +361,63 @@ func (s *ArbitrationService) getEligibleJurors(disputeType models.ArbitrationDis
 	query = query.Order("reputation_score DESC, accuracy_rate DESC")
 
 	if err := query.Find(&candidates).Error; err != nil {
-		return nil, fmt.Errorf("배심원 후보 조회 실패: %w", err)
+		return nil, nil, fmt.Errorf("배심원 후보 조회 실패: %w", err)
 	}
 
-	return candidates, nil
+	loads, err := s.jurorCaseLoads()
+	if err != nil {
+		return nil, nil, err
+	}
+	available := candidates[:0]
+	for _, candidate := range candidates {
+		if loads[candidate.UserID] < jurorCaseLimit(candidate) {
+			available = append(available, candidate)
+		}
+	}
+
+	return available, loads, nil
 }
 
-// SelectJurors 무작위 배심원 선정 (가중 확률)
-func (s *ArbitrationService) selectJurors(candidates []models.JurorQualification, requiredCount int) ([]uint, error) {
+// SelectJurors 무작위 배심원 선정 (가중 확률, 비복원 추출)
+// crypto/rand로 [0, 남은 가중치 합) 범위의 균등 난수를 뽑아 누적 가중치 구간으로 선택하므로 모듈로 편향이 없다.
+// 진행 중 사건이 많은 배심원일수록 가중치를 (사건 수 + 1)로 나눠 부담을 고르게 나눈다.
+func (s *ArbitrationService) selectJurors(candidates []models.JurorQualification, loads map[uint]int, requiredCount int) ([]uint, error) {
 	if len(candidates) < requiredCount {
 		return nil, errors.New("충분한 배심원 후보가 없습니다")
 	}
 
-	// 가중 확률 계산 (평판 점수 + 정확도 기반)
 	type weightedCandidate struct {
 		UserID uint
-		Weight float64
+		Weight int64
 	}
 
-	var weightedCandidates []weightedCandidate
-	totalWeight := 0.0
-
+	weightedCandidates := make([]weightedCandidate, 0, len(candidates))
+	var totalWeight int64
 	for _, candidate := range candidates {
-		// 가중치 = 평판점수 * 정확도 * 스테이킹비율
-		stakeRatio := math.Min(float64(candidate.CurrentStake)/float64(candidate.MinStakeAmount), 2.0) // 최대 2배
-		weight := candidate.ReputationScore * candidate.AccuracyRate * stakeRatio
-		
-		weightedCandidates = append(weightedCandidates, weightedCandidate{
-			UserID: candidate.UserID,
-			Weight: weight,
-		})
+		weight := jurorSelectionWeight(candidate) / int64(1+loads[candidate.UserID])
+		if weight < 1 {
+			weight = 1
+		}
+		weightedCandidates = append(weightedCandidates, weightedCandidate{UserID: candidate.UserID, Weight: weight})
 		totalWeight += weight
 	}
 
-	// 가중 무작위 선정
 	selected := make([]uint, 0, requiredCount)
-	used := make(map[uint]bool)
-
 	for len(selected) < requiredCount {
-		// 무작위 숫자 생성
-		randBytes := make([]byte, 8)
-		rand.Read(randBytes)
-		randFloat := float64(randBytes[0]) / 255.0 * totalWeight
-
-		// 가중 선택
-		currentWeight := 0.0
-		for _, candidate := range weightedCandidates {
-			if used[candidate.UserID] {
-				continue
-			}
-			
-			currentWeight += candidate.Weight
-			if currentWeight >= randFloat {
-				selected = append(selected, candidate.UserID)
-				used[candidate.UserID] = true
-				break
-			}
+		n, err := rand.Int(rand.Reader, big.NewInt(totalWeight))
+		if err != nil {
+			return nil, fmt.Errorf("난수 생성 실패: %w", err)
 		}
+		target := n.Int64()
 
-		// 무한 루프 방지
-		if len(used) >= len(weightedCandidates) {
+		for i, candidate := range weightedCandidates {
+			if target >= candidate.Weight {
+				target -= candidate.Weight
+				continue
+			}
+			selected = append(selected, candidate.UserID)
+			totalWeight -= candidate.Weight
+			weightedCandidates = append(weightedCandidates[:i], weightedCandidates[i+1:]...)
 			break
 		}
 	}
@@ -229,6 +425,58 @@ func (s *ArbitrationService) selectJurors(candidates []models.JurorQualification
 	return selected, nil
 }
 
+// jurorSelectionWeight 선정 가중치 = 평판 × 정확도 × 스테이킹 비율(최대 2배), 정수 단위(최소 1)
+// 참여 이력이 없는 배심원은 정확도 0.5로 간주해 신규 배심원도 선정될 수 있게 한다.
+func jurorSelectionWeight(candidate models.JurorQualification) int64 {
+	accuracy := candidate.AccuracyRate
+	if candidate.TotalCases == 0 {
+		accuracy = 0.5
+	}
+	stakeRatio := 1.0
+	if candidate.MinStakeAmount > 0 {
+		stakeRatio = math.Min(float64(candidate.CurrentStake)/float64(candidate.MinStakeAmount), 2.0)
+	}
+
+	weight := int64(candidate.ReputationScore * accuracy * stakeRatio * jurorWeightScale)
+	if weight < 1 {
+		weight = 1
+	}
+	return weight
+}
+
+// IssueVoteNonce 배심원별 투표 nonce 발급 (같은 사건에서는 항상 같은 값)
+func (s *ArbitrationService) IssueVoteNonce(caseID, jurorID uint) (string, error) {
+	var arbitrationCase models.ArbitrationCase
+	if err := s.db.First(&arbitrationCase, caseID).Error; err != nil {
+		return "", fmt.Errorf("사건을 찾을 수 없습니다: %w", err)
+	}
+	if arbitrationCase.Status != models.ArbitrationStatusVoting {
+		return "", errors.New("현재 투표 기간이 아닙니다")
+	}
+	if !isSelectedJuror(&arbitrationCase, jurorID) {
+		return "", errors.New("이 사건의 배심원이 아닙니다")
+	}
+
+	var existing models.ArbitrationVoteNonce
+	if err := s.db.Where("case_id = ? AND juror_id = ?", caseID, jurorID).First(&existing).Error; err == nil {
+		return existing.Nonce, nil
+	}
+
+	nonceBytes := make([]byte, 32)
+	if _, err := rand.Read(nonceBytes); err != nil {
+		return "", fmt.Errorf("nonce 생성 실패: %w", err)
+	}
+	nonce := &models.ArbitrationVoteNonce{CaseID: caseID, JurorID: jurorID, Nonce: hex.EncodeToString(nonceBytes)}
+	if err := s.db.Create(nonce).Error; err != nil {
+		// 동시 요청으로 먼저 발급된 nonce가 있으면 그 값을 사용
+		if err := s.db.Where("case_id = ? AND juror_id = ?", caseID, jurorID).First(&existing).Error; err == nil {
+			return existing.Nonce, nil
+		}
+		return "", fmt.Errorf("nonce 저장 실패: %w", err)
+	}
+	return nonce.Nonce, nil
+}
+
 // CommitVote 배심원 투표 제출 (Commit phase)
 func (s *ArbitrationService) CommitVote(req *models.JurorVoteRequest, jurorID uint) (*models.ArbitrationVote, error) {
 	// 1. 사건 조회 및 상태 확인
@@ -240,18 +488,18 @@ func (s *ArbitrationService) CommitVote(req *models.JurorVoteRequest, jurorID ui
 	if arbitrationCase.Status != models.ArbitrationStatusVoting {
 		return nil, errors.New("현재 투표 기간이 아닙니다")
 	}
-
-	// 2. 배심원 자격 확인
-	isEligible := false
-	for _, selectedJurorID := range arbitrationCase.SelectedJurors {
-		if selectedJurorID == jurorID {
-			isEligible = true
-			break
-		}
+	if arbitrationCase.VotingDeadline != nil && time.Now().After(*arbitrationCase.VotingDeadline) {
+		return nil, errors.New("투표 마감 시간이 지났습니다")
 	}
-	if !isEligible {
+
+	// 2. 배심원 자격 확인 (nonce를 발급받은 배심원만 커밋 가능)
+	if !isSelectedJuror(&arbitrationCase, jurorID) {
 		return nil, errors.New("이 사건의 배심원이 아닙니다")
 	}
+	var nonce models.ArbitrationVoteNonce
+	if err := s.db.Where("case_id = ? AND juror_id = ?", req.CaseID, jurorID).First(&nonce).Error; err != nil {
+		return nil, errors.New("투표 nonce를 먼저 발급받아야 합니다")
+	}
 
 	// 3. 이미 투표했는지 확인
 	var existingVote models.ArbitrationVote
@@ -269,7 +517,7 @@ func (s *ArbitrationService) CommitVote(req *models.JurorVoteRequest, jurorID ui
 	vote := &models.ArbitrationVote{
 		CaseID:             req.CaseID,
 		JurorID:            jurorID,
-		CommitHash:         req.CommitHash,
+		CommitHash:         strings.ToLower(req.CommitHash),
 		JurorStake:         jurorQualification.CurrentStake,
 		QualificationScore: jurorQualification.ReputationScore,
 		CommittedAt:        &[]time.Time{time.Now()}[0],
@@ -302,10 +550,23 @@ func (s *ArbitrationService) RevealVote(req *models.RevealVoteRequest, jurorID u
 	if arbitrationCase.Status != models.ArbitrationStatusReveal {
 		return errors.New("현재 투표 공개 기간이 아닙니다")
 	}
+	if arbitrationCase.RevealDeadline != nil && time.Now().After(*arbitrationCase.RevealDeadline) {
+		return errors.New("투표 공개 마감 시간이 지났습니다")
+	}
+	if vote.RevealedAt != nil {
+		return errors.New("이미 공개된 투표입니다")
+	}
+	if !isValidDecision(req.Vote) {
+		return errors.New("잘못된 투표 값입니다")
+	}
 
-	// 3. 해시 검증
-	expectedHash := s.generateCommitHash(string(req.Vote), req.Salt)
-	if vote.CommitHash != expectedHash {
+	// 3. 해시 검증 (서버 발급 nonce 포함)
+	var nonce models.ArbitrationVoteNonce
+	if err := s.db.Where("case_id = ? AND juror_id = ?", req.CaseID, jurorID).First(&nonce).Error; err != nil {
+		return errors.New("투표 nonce를 찾을 수 없습니다")
+	}
+	expectedHash := s.generateCommitHash(req.CaseID, jurorID, string(req.Vote), req.Salt, nonce.Nonce)
+	if subtle.ConstantTimeCompare([]byte(vote.CommitHash), []byte(expectedHash)) != 1 {
 		return errors.New("투표 해시가 일치하지 않습니다")
 	}
 
@@ -327,15 +588,22 @@ func (s *ArbitrationService) RevealVote(req *models.RevealVoteRequest, jurorID u
 
 // FinalizeCase 사건 최종 판결
 func (s *ArbitrationService) FinalizeCase(caseID uint) error {
-	return s.db.Transaction(func(tx *gorm.DB) error {
+	var decided *models.ArbitrationCase
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
 		// 1. 사건 및 투표 조회
 		var arbitrationCase models.ArbitrationCase
 		if err := tx.Preload("Votes").First(&arbitrationCase, caseID).Error; err != nil {
 			return fmt.Errorf("사건 조회 실패: %w", err)
 		}
+		if arbitrationCase.Status == models.ArbitrationStatusDecided ||
+			arbitrationCase.Status == models.ArbitrationStatusClosed ||
+			arbitrationCase.Status == models.ArbitrationStatusRejected {
+			return errors.New("이미 종결된 사건입니다")
+		}
 
 		// 2. 투표 집계 및 결과 결정
-		decision, confidence := s.calculateDecision(arbitrationCase.Votes)
+		decision, _ := s.calculateDecision(arbitrationCase.Votes)
 		
 		// 3. 사건 결과 업데이트
 		now := time.Now()
@@ -351,32 +619,61 @@ func (s *ArbitrationService) FinalizeCase(caseID uint) error {
 			arbitrationCase.AwardAmount = arbitrationCase.ClaimedAmount / 2 // 50% 배상
 		}
 
+		// 5. 항소 기한 설정 (최종심이면 바로 확정)
+		if arbitrationCase.AppealRound >= maxAppealRounds {
+			arbitrationCase.IsFinal = true
+		} else {
+			appealDeadline := now.Add(arbitrationAppealWindow)
+			arbitrationCase.AppealDeadline = &appealDeadline
+		}
+
 		if err := tx.Save(&arbitrationCase).Error; err != nil {
 			return fmt.Errorf("사건 업데이트 실패: %w", err)
 		}
 
-		// 5. 배심원 보상 지급
-		if err := s.distributeJurorRewards(tx, caseID, decision, confidence); err != nil {
+		// 6. 신청인(항소심은 항소인) 스테이킹 정산 (배심원 보상 풀 적립분 반환)
+		rewardPool, err := s.processSettlement(tx, &arbitrationCase)
+		if err != nil {
+			return fmt.Errorf("스테이킹 정산 실패: %w", err)
+		}
+
+		// 7. 배심원 보상 지급 및 소수 의견 배심원 스테이킹 차감
+		if err := s.distributeJurorRewards(tx, &arbitrationCase, rewardPool); err != nil {
 			return fmt.Errorf("배심원 보상 지급 실패: %w", err)
 		}
 
-		// 6. 당사자들에게 배상/환급 처리
-		if err := s.processSettlement(tx, &arbitrationCase); err != nil {
-			return fmt.Errorf("배상 처리 실패: %w", err)
+		// 8. 최종심은 항소 기한 없이 바로 확정 (당사자 간 배상)
+		if arbitrationCase.IsFinal {
+			if err := s.closeCaseTx(tx, &arbitrationCase); err != nil {
+				return err
+			}
 		}
 
+		decided = &arbitrationCase
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	// 9. 커밋 이후 외부 웹훅 발행
+	var projectID uint
+	if decided.MilestoneID != nil {
+		s.db.Model(&models.Milestone{}).Where("id = ?", *decided.MilestoneID).Pluck("project_id", &projectID)
+	}
+	s.webhookPublisher.PublishArbitrationDecided(decided, projectID)
+
+	return nil
 }
 
 // Helper functions
 
-func (s *ArbitrationService) generateCaseNumber() (string, error) {
+func (s *ArbitrationService) generateCaseNumber(db *gorm.DB) (string, error) {
 	year := time.Now().Year()
 	
 	// 해당 연도의 사건 수 조회
 	var count int64
-	s.db.Model(&models.ArbitrationCase{}).
+	db.Model(&models.ArbitrationCase{}).
 		Where("EXTRACT(YEAR FROM created_at) = ?", year).
 		Count(&count)
 
@@ -412,7 +709,7 @@ func (s *ArbitrationService) calculatePriority(disputeType models.ArbitrationDis
 	}
 	
 	switch disputeType {
-	case models.DisputeTypeProjectFraud:
+	case models.DisputeTypeProjectFraud, models.DisputeTypeMarketManipulation:
 		return models.ArbitrationPriorityHigh
 	case models.DisputeTypeMentorMalpractice:
 		return models.ArbitrationPriorityNormal
@@ -421,9 +718,30 @@ func (s *ArbitrationService) calculatePriority(disputeType models.ArbitrationDis
 	}
 }
 
-func (s *ArbitrationService) generateCommitHash(vote, salt string) string {
-	hash := sha256.Sum256([]byte(vote + salt))
-	return fmt.Sprintf("%x", hash)
+// generateCommitHash hex(SHA256("case_id:juror_id:vote:salt:nonce")) - 사건/배심원에 묶여 다른 배심원이 커밋을 복제할 수 없음
+func (s *ArbitrationService) generateCommitHash(caseID, jurorID uint, vote, salt, nonce string) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%d:%d:%s:%s:%s", caseID, jurorID, vote, salt, nonce)))
+	return hex.EncodeToString(hash[:])
+}
+
+func isSelectedJuror(arbitrationCase *models.ArbitrationCase, jurorID uint) bool {
+	for _, selectedJurorID := range arbitrationCase.SelectedJurors {
+		if selectedJurorID == jurorID {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidDecision(decision models.ArbitrationDecision) bool {
+	switch decision {
+	case models.ArbitrationDecisionPlaintiffWins,
+		models.ArbitrationDecisionDefendantWins,
+		models.ArbitrationDecisionPartialWin,
+		models.ArbitrationDecisionDismissed:
+		return true
+	}
+	return false
 }
 
 func (s *ArbitrationService) calculateDecision(votes []models.ArbitrationVote) (models.ArbitrationDecision, float64) {
@@ -437,7 +755,7 @@ func (s *ArbitrationService) calculateDecision(votes []models.ArbitrationVote) (
 
 	for _, vote := range votes {
 		if vote.RevealedVote != nil && vote.IsValid {
-			weight := vote.QualificationScore * (1.0 + float64(vote.JurorStake)/10000.0) // 스테이킹 가중치
+			weight := jurorVoteWeight(vote) // 스테이킹 가중치
 			voteCount[*vote.RevealedVote] += weight
 			totalWeight += weight
 		}
@@ -486,27 +804,655 @@ func (s *ArbitrationService) startInitialReview(tx *gorm.DB, caseID uint) error
 }
 
 func (s *ArbitrationService) notifyJurorSelection(jurorID uint, caseID uint) {
-	// Implementation for notifying selected jurors
+	var arbitrationCase models.ArbitrationCase
+	if err := s.db.First(&arbitrationCase, caseID).Error; err != nil {
+		return
+	}
+
+	s.notificationService.Notify(models.CreateNotificationRequest{
+		UserID:   jurorID,
+		Type:     models.NotificationTypeJurorSelected,
+		Priority: models.NotificationPriorityHigh,
+		Title:    "배심원으로 선정되었습니다",
+		Message:  fmt.Sprintf("분쟁 사건 %s (%s)의 배심원으로 선정되었습니다. 투표 기한 내에 참여해주세요.", arbitrationCase.CaseNumber, arbitrationCase.Title),
+		Link:     fmt.Sprintf("/arbitration/cases/%d", caseID),
+		Data: map[string]interface{}{
+			"case_id":     caseID,
+			"case_number": arbitrationCase.CaseNumber,
+		},
+	})
 }
 
+// SendDeadlineReminders 투표/공개 마감이 window 이내로 남은 미참여 배심원에게 알림
+func (s *ArbitrationService) SendDeadlineReminders(window time.Duration) {
+	client := redis.GetClient()
+	if client == nil {
+		return // 중복 발송 방지 키를 저장할 수 없으면 건너뜀
+	}
+
+	now := time.Now()
+	until := now.Add(window)
+
+	var cases []models.ArbitrationCase
+	if err := s.db.Where("(status = ? AND voting_deadline BETWEEN ? AND ?) OR (status = ? AND reveal_deadline BETWEEN ? AND ?)",
+		models.ArbitrationStatusVoting, now, until,
+		models.ArbitrationStatusReveal, now, until).
+		Find(&cases).Error; err != nil {
+		return
+	}
+
+	for _, arbitrationCase := range cases {
+		phase, deadline := "투표", arbitrationCase.VotingDeadline
+		if arbitrationCase.Status == models.ArbitrationStatusReveal {
+			phase, deadline = "투표 공개", arbitrationCase.RevealDeadline
+		}
+
+		var votes []models.ArbitrationVote
+		s.db.Where("case_id = ?", arbitrationCase.ID).Find(&votes)
+		done := make(map[uint]bool)
+		for _, vote := range votes {
+			if arbitrationCase.Status == models.ArbitrationStatusVoting && vote.CommittedAt != nil {
+				done[vote.JurorID] = true
+			}
+			if arbitrationCase.Status == models.ArbitrationStatusReveal && vote.RevealedAt != nil {
+				done[vote.JurorID] = true
+			}
+		}
+
+		for _, jurorID := range arbitrationCase.SelectedJurors {
+			if done[jurorID] {
+				continue
+			}
+
+			key := fmt.Sprintf("arbitration_reminder:%d:%s:%d", arbitrationCase.ID, arbitrationCase.Status, jurorID)
+			if ok, err := client.SetNX(context.Background(), key, 1, window).Result(); err != nil || !ok {
+				continue
+			}
+
+			s.notificationService.Notify(models.CreateNotificationRequest{
+				UserID:   jurorID,
+				Type:     models.NotificationTypeArbitration,
+				Priority: models.NotificationPriorityHigh,
+				Title:    fmt.Sprintf("분쟁 %s 마감 임박", phase),
+				Message:  fmt.Sprintf("분쟁 사건 %s의 %s 마감이 %s입니다. 기한 내 참여하지 않으면 스테이킹이 감소할 수 있습니다.", arbitrationCase.CaseNumber, phase, deadline.Format("2006-01-02 15:04")),
+				Link:     fmt.Sprintf("/arbitration/cases/%d", arbitrationCase.ID),
+				Data: map[string]interface{}{
+					"case_id":  arbitrationCase.ID,
+					"phase":    arbitrationCase.Status,
+					"deadline": deadline,
+				},
+			})
+		}
+	}
+}
+
+// checkVotingCompletion 모든 배심원이 투표했으면 마감 전이라도 공개 단계로 전환
 func (s *ArbitrationService) checkVotingCompletion(caseID uint) {
-	// Implementation for checking if all jurors have voted
+	s.advanceCaseByID(caseID)
 }
 
+// checkRevealCompletion 모든 투표가 공개되었으면 마감 전이라도 판결
 func (s *ArbitrationService) checkRevealCompletion(caseID uint) {
-	// Implementation for checking if all votes are revealed
+	s.advanceCaseByID(caseID)
+}
+
+func (s *ArbitrationService) advanceCaseByID(caseID uint) {
+	var arbitrationCase models.ArbitrationCase
+	if err := s.db.First(&arbitrationCase, caseID).Error; err != nil {
+		return
+	}
+	if _, err := s.advanceCase(&arbitrationCase, time.Now()); err != nil {
+		log.Printf("⚠️ Failed to advance arbitration case %d: %v", caseID, err)
+	}
+}
+
+// AdvancePhases 진행 중인 사건들의 마감을 확인해 다음 단계로 전환 (전환된 사건 수 반환)
+func (s *ArbitrationService) AdvancePhases(now time.Time) (int, error) {
+	var cases []models.ArbitrationCase
+	if err := s.db.Where("status IN ?", []models.ArbitrationStatus{
+		models.ArbitrationStatusSubmitted,
+		models.ArbitrationStatusUnderReview,
+		models.ArbitrationStatusJurySelection,
+		models.ArbitrationStatusVoting,
+		models.ArbitrationStatusReveal,
+		models.ArbitrationStatusDecided,
+	}).Order("id ASC").Find(&cases).Error; err != nil {
+		return 0, fmt.Errorf("진행 중인 사건 조회 실패: %w", err)
+	}
+
+	advanced := 0
+	for i := range cases {
+		ok, err := s.advanceCase(&cases[i], now)
+		if err != nil {
+			log.Printf("⚠️ Failed to advance arbitration case %d: %v", cases[i].ID, err)
+			continue
+		}
+		if ok {
+			advanced++
+		}
+	}
+	return advanced, nil
+}
+
+// advanceCase 사건 1건의 단계 전환 (전환 조건을 만족하지 않으면 false)
+func (s *ArbitrationService) advanceCase(arbitrationCase *models.ArbitrationCase, now time.Time) (bool, error) {
+	switch arbitrationCase.Status {
+	case models.ArbitrationStatusSubmitted, models.ArbitrationStatusUnderReview:
+		if now.After(arbitrationCase.JuryFormationDeadline) {
+			return s.dismissCase(arbitrationCase, "배심원단 구성 기한 내에 배심원을 선정하지 못해 기각되었습니다.")
+		}
+		// 후보가 부족했던 사건은 기한 전까지 다시 선정 시도
+		return s.startJurySelection(arbitrationCase.ID), nil
+
+	case models.ArbitrationStatusJurySelection:
+		return s.openVoting(arbitrationCase.ID, arbitrationCase.Status, now)
+
+	case models.ArbitrationStatusVoting:
+		committed, _, err := s.countVotes(arbitrationCase.ID)
+		if err != nil {
+			return false, err
+		}
+		allCommitted := len(arbitrationCase.SelectedJurors) > 0 && committed >= int64(len(arbitrationCase.SelectedJurors))
+		deadlinePassed := arbitrationCase.VotingDeadline != nil && now.After(*arbitrationCase.VotingDeadline)
+		if !allCommitted && !deadlinePassed {
+			return false, nil
+		}
+		// 커밋하지 않은 배심원은 한 번에 한해 교체하고 투표 기한 연장
+		if !allCommitted && len(arbitrationCase.ReplacedJurors) == 0 {
+			replaced, err := s.replaceMissingJurors(arbitrationCase, now)
+			if err != nil {
+				return false, err
+			}
+			if replaced {
+				return true, nil
+			}
+		}
+		if committed == 0 {
+			return s.dismissCase(arbitrationCase, "투표 기한 내에 투표한 배심원이 없어 기각되었습니다.")
+		}
+		revealDeadline := now.Add(arbitrationRevealPeriod)
+		return s.transitionCase(arbitrationCase.ID, models.ArbitrationStatusVoting, map[string]interface{}{
+			"status":          models.ArbitrationStatusReveal,
+			"reveal_deadline": revealDeadline,
+		})
+
+	case models.ArbitrationStatusReveal:
+		committed, revealed, err := s.countVotes(arbitrationCase.ID)
+		if err != nil {
+			return false, err
+		}
+		allRevealed := committed > 0 && revealed >= committed
+		deadlinePassed := arbitrationCase.RevealDeadline != nil && now.After(*arbitrationCase.RevealDeadline)
+		if !allRevealed && !deadlinePassed {
+			return false, nil
+		}
+		if revealed == 0 {
+			return s.dismissCase(arbitrationCase, "공개 기한 내에 공개된 투표가 없어 기각되었습니다.")
+		}
+		if err := s.FinalizeCase(arbitrationCase.ID); err != nil {
+			return false, err
+		}
+		return true, nil
+
+	case models.ArbitrationStatusDecided:
+		if arbitrationCase.AppealDeadline == nil || !now.After(*arbitrationCase.AppealDeadline) {
+			return false, nil
+		}
+		return s.closeCase(arbitrationCase)
+	}
+	return false, nil
+}
+
+// openVoting 투표 단계 시작 (투표 마감 설정)
+func (s *ArbitrationService) openVoting(caseID uint, from models.ArbitrationStatus, now time.Time) (bool, error) {
+	return s.transitionCase(caseID, from, map[string]interface{}{
+		"status":          models.ArbitrationStatusVoting,
+		"voting_started":  true,
+		"voting_deadline": now.Add(arbitrationVotingPeriod),
+	})
 }
 
-func (s *ArbitrationService) distributeJurorRewards(tx *gorm.DB, caseID uint, decision models.ArbitrationDecision, confidence float64) error {
-	// Implementation for distributing rewards to jurors
+// transitionCase 현재 상태가 from일 때만 갱신 (여러 인스턴스가 동시에 실행해도 한 번만 전환)
+func (s *ArbitrationService) transitionCase(caseID uint, from models.ArbitrationStatus, updates map[string]interface{}) (bool, error) {
+	result := s.db.Model(&models.ArbitrationCase{}).
+		Where("id = ? AND status = ?", caseID, from).
+		Updates(updates)
+	if result.Error != nil {
+		return false, fmt.Errorf("사건 상태 전환 실패: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// countVotes 커밋된 투표 수와 공개된 투표 수
+func (s *ArbitrationService) countVotes(caseID uint) (committed, revealed int64, err error) {
+	if err = s.db.Model(&models.ArbitrationVote{}).
+		Where("case_id = ? AND committed_at IS NOT NULL", caseID).
+		Count(&committed).Error; err != nil {
+		return 0, 0, fmt.Errorf("투표 수 조회 실패: %w", err)
+	}
+	if err = s.db.Model(&models.ArbitrationVote{}).
+		Where("case_id = ? AND revealed_at IS NOT NULL", caseID).
+		Count(&revealed).Error; err != nil {
+		return 0, 0, fmt.Errorf("공개 투표 수 조회 실패: %w", err)
+	}
+	return committed, revealed, nil
+}
+
+// dismissCase 진행이 불가능한 사건 자동 기각 (신청인 스테이킹 반환 후 양 당사자에게 알림)
+func (s *ArbitrationService) dismissCase(arbitrationCase *models.ArbitrationCase, reason string) (bool, error) {
+	from := arbitrationCase.Status
+	dismissed := false
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.ArbitrationCase{}).
+			Where("id = ? AND status = ?", arbitrationCase.ID, from).
+			Updates(map[string]interface{}{
+				"status":          models.ArbitrationStatusRejected,
+				"decision":        models.ArbitrationDecisionDismissed,
+				"decision_reason": reason,
+				"decided_at":      now,
+				"stake_returned":  true,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil // 다른 인스턴스가 이미 처리함
+		}
+
+		// 배심원단 미구성/미참여는 신청인(항소인) 귀책이 아니므로 스테이킹 전액 반환
+		stakerID := arbitrationCase.PlaintiffID
+		if arbitrationCase.AppellantID != nil {
+			stakerID = *arbitrationCase.AppellantID
+		}
+		if arbitrationCase.StakeAmount > 0 && !arbitrationCase.StakeReturned {
+			if err := postLedgerEntry(tx, arbitrationLedgerEntry(arbitrationCase, models.WalletLedgerEntry{
+				UserID:       stakerID,
+				EntryType:    models.LedgerArbitrationStakeReturn,
+				Amount:       arbitrationCase.StakeAmount,
+				LockedAmount: -arbitrationCase.StakeAmount,
+				Memo:         fmt.Sprintf("분쟁 사건 %s 기각에 따른 스테이킹 반환", arbitrationCase.CaseNumber),
+			})); err != nil {
+				return fmt.Errorf("스테이킹 반환 실패: %w", err)
+			}
+		}
+
+		// 항소심이 기각되면 이전 판결이 그대로 확정 (다음 주기에 종료 처리)
+		if arbitrationCase.ParentCaseID != nil {
+			if err := tx.Model(&models.ArbitrationCase{}).
+				Where("id = ? AND status = ?", *arbitrationCase.ParentCaseID, models.ArbitrationStatusAppealed).
+				Updates(map[string]interface{}{
+					"status":          models.ArbitrationStatusDecided,
+					"appeal_deadline": now,
+				}).Error; err != nil {
+				return fmt.Errorf("이전 판결 복원 실패: %w", err)
+			}
+		}
+
+		dismissed = true
+		return nil
+	})
+	if err != nil || !dismissed {
+		return false, err
+	}
+
+	for _, userID := range []uint{arbitrationCase.PlaintiffID, arbitrationCase.DefendantID} {
+		s.notificationService.Notify(models.CreateNotificationRequest{
+			UserID:   userID,
+			Type:     models.NotificationTypeArbitration,
+			Priority: models.NotificationPriorityNormal,
+			Title:    "분쟁 사건이 기각되었습니다",
+			Message:  fmt.Sprintf("분쟁 사건 %s: %s", arbitrationCase.CaseNumber, reason),
+			Link:     fmt.Sprintf("/arbitration/cases/%d", arbitrationCase.ID),
+			Data: map[string]interface{}{
+				"case_id":  arbitrationCase.ID,
+				"decision": models.ArbitrationDecisionDismissed,
+			},
+		})
+	}
+	return true, nil
+}
+
+// distributeJurorRewards 판결과 같은 쪽에 투표한 배심원이 보상 풀을 가중치대로 나누고,
+// 다른 쪽에 투표했거나 기한 내 공개하지 않은 배심원은 스테이킹 일부를 차감 (차감분도 풀에 합산)
+func (s *ArbitrationService) distributeJurorRewards(tx *gorm.DB, arbitrationCase *models.ArbitrationCase, rewardPool int64) error {
+	decision := arbitrationCase.Decision
+	votes := make(map[uint]models.ArbitrationVote, len(arbitrationCase.Votes))
+	for _, vote := range arbitrationCase.Votes {
+		votes[vote.JurorID] = vote
+	}
+
+	// 교체된 배심원도 미참여로 차감
+	jurorIDs := append(append([]uint{}, arbitrationCase.SelectedJurors...), arbitrationCase.ReplacedJurors...)
+	var qualifications []models.JurorQualification
+	if err := tx.Where("user_id IN ?", jurorIDs).Find(&qualifications).Error; err != nil {
+		return fmt.Errorf("배심원 자격 조회 실패: %w", err)
+	}
+
+	now := time.Now()
+	var coherent []models.ArbitrationVote
+	totalWeight := 0.0
+
+	// 1. 소수 의견/미참여 배심원 스테이킹 차감
+	for i := range qualifications {
+		qualification := &qualifications[i]
+		vote, voted := votes[qualification.UserID]
+		revealed := voted && vote.RevealedVote != nil && vote.IsValid
+		isCoherent := revealed && *vote.RevealedVote == decision
+
+		if isCoherent {
+			coherent = append(coherent, vote)
+			totalWeight += jurorVoteWeight(vote)
+		} else {
+			// 커밋만 하고 공개하지 않은 배심원은 더 무겁게 차감하고 투표를 무효 처리
+			rate := jurorSlashRate
+			committedOnly := voted && vote.CommittedAt != nil && vote.RevealedAt == nil
+			if committedOnly {
+				rate = jurorNoRevealRate
+			}
+			slashed, err := s.slashJuror(tx, arbitrationCase, qualification, rate, now)
+			if err != nil {
+				return err
+			}
+			rewardPool += slashed
+
+			if voted {
+				if err := tx.Model(&models.ArbitrationVote{}).Where("id = ?", vote.ID).Updates(map[string]interface{}{
+					"penalty_amount": slashed,
+					"is_valid":       !committedOnly && vote.IsValid,
+				}).Error; err != nil {
+					return fmt.Errorf("투표 페널티 기록 실패: %w", err)
+				}
+			}
+		}
+
+		s.updateJurorRecord(qualification, revealed, isCoherent)
+		if err := tx.Save(qualification).Error; err != nil {
+			return fmt.Errorf("배심원 기록 갱신 실패: %w", err)
+		}
+	}
+
+	if len(coherent) == 0 || rewardPool <= 0 {
+		return nil // 나눌 배심원이 없으면 풀은 플랫폼에 귀속
+	}
+
+	// 2. 다수 의견 배심원 보상 (나머지는 가중치가 가장 큰 배심원에게)
+	shares := make([]int64, len(coherent))
+	distributed, top := int64(0), 0
+	for i, vote := range coherent {
+		shares[i] = int64(float64(rewardPool) * jurorVoteWeight(vote) / totalWeight)
+		distributed += shares[i]
+		if jurorVoteWeight(vote) > jurorVoteWeight(coherent[top]) {
+			top = i
+		}
+	}
+	shares[top] += rewardPool - distributed
+
+	for i, vote := range coherent {
+		reward := &models.ArbitrationReward{
+			CaseID:            arbitrationCase.ID,
+			JurorID:           vote.JurorID,
+			BaseReward:        shares[i],
+			TotalReward:       shares[i],
+			VotedWithMajority: true,
+			ResponseTime:      jurorResponseHours(arbitrationCase, vote),
+			QualityScore:      vote.QualificationScore,
+			Status:            "distributed",
+			DistributedAt:     &now,
+		}
+		if err := tx.Create(reward).Error; err != nil {
+			return fmt.Errorf("배심원 보상 기록 실패: %w", err)
+		}
+		if err := tx.Model(&models.ArbitrationVote{}).Where("id = ?", vote.ID).
+			Update("reward_amount", shares[i]).Error; err != nil {
+			return fmt.Errorf("투표 보상 기록 실패: %w", err)
+		}
+		if shares[i] == 0 {
+			continue
+		}
+		if err := postLedgerEntry(tx, &models.WalletLedgerEntry{
+			UserID:        vote.JurorID,
+			Currency:      models.LedgerCurrencyBlueprint,
+			EntryType:     models.LedgerJurorReward,
+			Amount:        shares[i],
+			ReferenceType: "arbitration_case",
+			ReferenceID:   arbitrationCase.ID,
+			Memo:          fmt.Sprintf("분쟁 사건 %s 배심원 보상", arbitrationCase.CaseNumber),
+		}); err != nil {
+			return err
+		}
+		if err := tx.Model(&models.UserWallet{}).Where("user_id = ?", vote.JurorID).
+			Update("total_blueprint_earned", gorm.Expr("total_blueprint_earned + ?", shares[i])).Error; err != nil {
+			return fmt.Errorf("보상 통계 갱신 실패: %w", err)
+		}
+	}
 	return nil
 }
 
-func (s *ArbitrationService) processSettlement(tx *gorm.DB, arbitrationCase *models.ArbitrationCase) error {
-	// Implementation for processing settlement between parties
+// slashJuror 배심원 스테이킹을 rate만큼 차감 (지갑 잔액을 넘지 않음), 차감액 반환
+func (s *ArbitrationService) slashJuror(tx *gorm.DB, arbitrationCase *models.ArbitrationCase, qualification *models.JurorQualification, rate float64, now time.Time) (int64, error) {
+	amount := int64(float64(qualification.CurrentStake) * rate)
+
+	var wallet models.UserWallet
+	if err := tx.Where("user_id = ?", qualification.UserID).Limit(1).Find(&wallet).Error; err != nil {
+		return 0, fmt.Errorf("배심원 지갑 조회 실패: %w", err)
+	}
+	if amount > wallet.BlueprintBalance {
+		amount = wallet.BlueprintBalance
+	}
+	if amount < 0 {
+		amount = 0
+	}
+
+	reward := &models.ArbitrationReward{
+		CaseID:        arbitrationCase.ID,
+		JurorID:       qualification.UserID,
+		SlashedAmount: amount,
+		Status:        "forfeited",
+		DistributedAt: &now,
+	}
+	if err := tx.Create(reward).Error; err != nil {
+		return 0, fmt.Errorf("배심원 차감 기록 실패: %w", err)
+	}
+	if amount == 0 {
+		return 0, nil
+	}
+
+	qualification.CurrentStake -= amount
+	if err := postLedgerEntry(tx, &models.WalletLedgerEntry{
+		UserID:        qualification.UserID,
+		Currency:      models.LedgerCurrencyBlueprint,
+		EntryType:     models.LedgerJurorSlash,
+		Amount:        -amount,
+		ReferenceType: "arbitration_case",
+		ReferenceID:   arbitrationCase.ID,
+		Memo:          fmt.Sprintf("분쟁 사건 %s 배심원 스테이킹 차감", arbitrationCase.CaseNumber),
+	}); err != nil {
+		return 0, err
+	}
+	return amount, nil
+}
+
+// updateJurorRecord 참여율/정확도/평판 누적 갱신
+func (s *ArbitrationService) updateJurorRecord(qualification *models.JurorQualification, participated, coherent bool) {
+	prev := float64(qualification.TotalCases)
+	qualification.TotalCases++
+	total := float64(qualification.TotalCases)
+
+	participation, accuracy := 0.0, 0.0
+	if participated {
+		participation = 1
+	}
+	if coherent {
+		accuracy = 1
+	}
+	qualification.ParticipationRate = (qualification.ParticipationRate*prev + participation) / total
+	qualification.AccuracyRate = (qualification.AccuracyRate*prev + accuracy) / total
+
+	if coherent {
+		qualification.ReputationScore = math.Min(1, qualification.ReputationScore+0.02)
+	} else {
+		qualification.ReputationScore = math.Max(0, qualification.ReputationScore-0.05)
+	}
+	qualification.LastActiveAt = time.Now()
+}
+
+// processSettlement 판결에 따라 사건 스테이킹 정산 (배심원 보상 풀 적립액 반환)
+// 최초 심리는 신청인이 패소하면, 항소심은 이전 판결이 유지되면 스테이킹 전액이 풀로 가고
+// 그 외에는 중재 수수료만 떼고 반환한다. 당사자 간 배상은 판결 확정 시 closeCaseTx에서 처리한다.
+func (s *ArbitrationService) processSettlement(tx *gorm.DB, arbitrationCase *models.ArbitrationCase) (int64, error) {
+	if arbitrationCase.StakeReturned || arbitrationCase.StakeAmount <= 0 {
+		return 0, nil
+	}
+
+	stakerID := arbitrationCase.PlaintiffID
+	forfeited := arbitrationCase.Decision == models.ArbitrationDecisionDefendantWins
+	if arbitrationCase.ParentCaseID != nil {
+		var parent models.ArbitrationCase
+		if err := tx.Select("id", "decision").First(&parent, *arbitrationCase.ParentCaseID).Error; err != nil {
+			return 0, fmt.Errorf("이전 심급 사건 조회 실패: %w", err)
+		}
+		if arbitrationCase.AppellantID != nil {
+			stakerID = *arbitrationCase.AppellantID
+		}
+		forfeited = arbitrationCase.Decision == parent.Decision
+	}
+
+	fee := int64(float64(arbitrationCase.StakeAmount) * arbitrationFeeRate)
+	if forfeited {
+		fee = arbitrationCase.StakeAmount
+	}
+	refund := arbitrationCase.StakeAmount - fee
+
+	if refund > 0 {
+		if err := postLedgerEntry(tx, arbitrationLedgerEntry(arbitrationCase, models.WalletLedgerEntry{
+			UserID:       stakerID,
+			EntryType:    models.LedgerArbitrationStakeReturn,
+			Amount:       refund,
+			LockedAmount: -refund,
+			Memo:         fmt.Sprintf("분쟁 사건 %s 스테이킹 반환", arbitrationCase.CaseNumber),
+		})); err != nil {
+			return 0, err
+		}
+	}
+	if fee > 0 {
+		if err := postLedgerEntry(tx, arbitrationLedgerEntry(arbitrationCase, models.WalletLedgerEntry{
+			UserID:       stakerID,
+			EntryType:    models.LedgerArbitrationFee,
+			LockedAmount: -fee,
+			Memo:         fmt.Sprintf("분쟁 사건 %s 중재 수수료", arbitrationCase.CaseNumber),
+		})); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Model(&models.ArbitrationCase{}).Where("id = ?", arbitrationCase.ID).
+		Update("stake_returned", true).Error; err != nil {
+		return 0, fmt.Errorf("정산 결과 저장 실패: %w", err)
+	}
+	arbitrationCase.StakeReturned = true
+
+	return fee, nil
+}
+
+// closeCaseTx 판결 확정: 피신청인 → 신청인 배상 (사용 가능 잔액 한도), 이전 심급 사건도 함께 종료
+func (s *ArbitrationService) closeCaseTx(tx *gorm.DB, arbitrationCase *models.ArbitrationCase) error {
+	result := tx.Model(&models.ArbitrationCase{}).
+		Where("id = ? AND status = ?", arbitrationCase.ID, models.ArbitrationStatusDecided).
+		Update("status", models.ArbitrationStatusClosed)
+	if result.Error != nil {
+		return fmt.Errorf("사건 확정 실패: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil // 다른 인스턴스가 이미 확정함
+	}
+	arbitrationCase.Status = models.ArbitrationStatusClosed
+
+	award := arbitrationCase.AwardAmount
+	if award > 0 {
+		var defendantWallet models.UserWallet
+		if err := tx.Where("user_id = ?", arbitrationCase.DefendantID).Limit(1).Find(&defendantWallet).Error; err != nil {
+			return fmt.Errorf("피신청인 지갑 조회 실패: %w", err)
+		}
+		if award > defendantWallet.BlueprintBalance {
+			award = defendantWallet.BlueprintBalance
+		}
+	}
+	if award > 0 {
+		memo := fmt.Sprintf("분쟁 사건 %s 판결 배상", arbitrationCase.CaseNumber)
+		if err := postLedgerEntry(tx, arbitrationLedgerEntry(arbitrationCase, models.WalletLedgerEntry{
+			UserID:    arbitrationCase.DefendantID,
+			EntryType: models.LedgerArbitrationAward,
+			Amount:    -award,
+			Memo:      memo,
+		})); err != nil {
+			return err
+		}
+		if err := postLedgerEntry(tx, arbitrationLedgerEntry(arbitrationCase, models.WalletLedgerEntry{
+			UserID:    arbitrationCase.PlaintiffID,
+			EntryType: models.LedgerArbitrationAward,
+			Amount:    award,
+			Memo:      memo,
+		})); err != nil {
+			return err
+		}
+	}
+	if award != arbitrationCase.AwardAmount {
+		if err := tx.Model(&models.ArbitrationCase{}).Where("id = ?", arbitrationCase.ID).
+			Update("award_amount", award).Error; err != nil {
+			return fmt.Errorf("배상액 저장 실패: %w", err)
+		}
+		arbitrationCase.AwardAmount = award
+	}
+
+	// 항소로 대체된 이전 심급 사건 종료
+	for parentID := arbitrationCase.ParentCaseID; parentID != nil; {
+		var parent models.ArbitrationCase
+		if err := tx.Select("id", "parent_case_id").First(&parent, *parentID).Error; err != nil {
+			return fmt.Errorf("이전 심급 사건 조회 실패: %w", err)
+		}
+		if err := tx.Model(&parent).Update("status", models.ArbitrationStatusClosed).Error; err != nil {
+			return fmt.Errorf("이전 심급 사건 종료 실패: %w", err)
+		}
+		parentID = parent.ParentCaseID
+	}
 	return nil
 }
 
+// closeCase 항소 기한이 지난 판결 확정
+func (s *ArbitrationService) closeCase(arbitrationCase *models.ArbitrationCase) (bool, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		return s.closeCaseTx(tx, arbitrationCase)
+	})
+	if err != nil {
+		return false, err
+	}
+	return arbitrationCase.Status == models.ArbitrationStatusClosed, nil
+}
+
+// arbitrationLedgerEntry 사건 참조가 붙은 BLUEPRINT 원장 항목
+func arbitrationLedgerEntry(arbitrationCase *models.ArbitrationCase, entry models.WalletLedgerEntry) *models.WalletLedgerEntry {
+	entry.Currency = models.LedgerCurrencyBlueprint
+	entry.ReferenceType = "arbitration_case"
+	entry.ReferenceID = arbitrationCase.ID
+	return &entry
+}
+
+// jurorVoteWeight 투표 가중치 (평판 × 스테이킹), 판결 집계와 보상 분배에 같은 기준 사용
+func jurorVoteWeight(vote models.ArbitrationVote) float64 {
+	return vote.QualificationScore * (1.0 + float64(vote.JurorStake)/10000.0)
+}
+
+// jurorResponseHours 투표 시작부터 커밋까지 걸린 시간
+func jurorResponseHours(arbitrationCase *models.ArbitrationCase, vote models.ArbitrationVote) int {
+	if arbitrationCase.VotingDeadline == nil || vote.CommittedAt == nil {
+		return 0
+	}
+	started := arbitrationCase.VotingDeadline.Add(-arbitrationVotingPeriod)
+	return int(vote.CommittedAt.Sub(started).Hours())
+}
+
 // GetCaseDetails 분쟁 사건 상세 정보 조회
 func (s *ArbitrationService) GetCaseDetails(caseID uint, userID uint) (*models.ArbitrationCaseResponse, error) {
 	var arbitrationCase models.ArbitrationCase
@@ -575,6 +1521,17 @@ func (s *ArbitrationService) GetJurorDashboard(userID uint) (*models.JurorDashbo
 		Where("juror_id = ? AND status = ?", userID, "distributed").
 		Select("COALESCE(SUM(total_reward), 0)").Scan(&totalRewards)
 
+	var totalSlashed int64
+	s.db.Model(&models.ArbitrationReward{}).
+		Where("juror_id = ?", userID).
+		Select("COALESCE(SUM(slashed_amount), 0)").Scan(&totalSlashed)
+
+	var recentRewards []models.ArbitrationReward
+	s.db.Where("juror_id = ?", userID).
+		Order("created_at DESC").
+		Limit(20).
+		Find(&recentRewards)
+
 	statistics := models.JurorStatistics{
 		TotalCases:        qualification.TotalCases,
 		AccuracyRate:      qualification.AccuracyRate,
@@ -582,6 +1539,7 @@ func (s *ArbitrationService) GetJurorDashboard(userID uint) (*models.JurorDashbo
 		AverageResponseTime: qualification.AverageResponseTime,
 		Rank:              1, // TODO: 실제 순위 계산
 		TotalEarnings:     totalRewards,
+		TotalSlashed:      totalSlashed,
 	}
 
 	return &models.JurorDashboardResponse{
@@ -590,6 +1548,7 @@ func (s *ArbitrationService) GetJurorDashboard(userID uint) (*models.JurorDashbo
 		ActiveCases:     activeCases,
 		CompletedCases:  completedCases,
 		TotalRewards:    totalRewards,
+		RecentRewards:   recentRewards,
 		Statistics:      statistics,
 	}, nil
 }
@@ -677,6 +1636,10 @@ func (s *ArbitrationService) RegisterJuror(userID uint, req interface{}) (*model
 		LegalBackground bool     `json:"legal_background"`
 	})
 
+	if err := s.trustScores.CheckEligibility(userID, TrustGateJuror); err != nil {
+		return nil, err
+	}
+
 	var userWallet models.UserWallet
 	if err := s.db.Where("user_id = ?", userID).First(&userWallet).Error; err != nil {
 		return nil, errors.New("지갑을 찾을 수 없습니다")
@@ -761,43 +1724,108 @@ func (s *ArbitrationService) GetArbitrationStats(period string) (interface{}, er
 
 // AppealCase 판결 이의제기
 func (s *ArbitrationService) AppealCase(caseID uint, userID uint, reason, evidence string, stakeAmount int64) (interface{}, error) {
-	var arbitrationCase models.ArbitrationCase
-	if err := s.db.First(&arbitrationCase, caseID).Error; err != nil {
+	var parent models.ArbitrationCase
+	if err := s.db.First(&parent, caseID).Error; err != nil {
 		return nil, fmt.Errorf("사건을 찾을 수 없습니다: %w", err)
 	}
 
-	if arbitrationCase.Status != models.ArbitrationStatusDecided {
-		return nil, errors.New("아직 판결이 나지 않은 사건입니다")
+	if parent.Status != models.ArbitrationStatusDecided {
+		return nil, errors.New("판결이 확정되지 않은 진행 중 사건이거나 이미 종결된 사건입니다")
 	}
-
-	if arbitrationCase.PlaintiffID != userID && arbitrationCase.DefendantID != userID {
+	if parent.IsFinal || parent.AppealRound >= maxAppealRounds {
+		return nil, errors.New("최종심 판결은 더 이상 항소할 수 없습니다")
+	}
+	if parent.AppealDeadline == nil || time.Now().After(*parent.AppealDeadline) {
+		return nil, errors.New("항소 기한이 지났습니다")
+	}
+	if parent.PlaintiffID != userID && parent.DefendantID != userID {
 		return nil, errors.New("해당 사건의 당사자가 아닙니다")
 	}
 
-	appealCase := &models.ArbitrationCase{
-		CaseNumber:    arbitrationCase.CaseNumber + "-APPEAL",
-		PlaintiffID:   userID,
-		DefendantID:   arbitrationCase.DefendantID,
-		DisputeType:   arbitrationCase.DisputeType,
-		Title:         "항소: " + arbitrationCase.Title,
-		Description:   reason,
-		Evidence:      evidence,
-		ClaimedAmount: arbitrationCase.ClaimedAmount,
-		StakeAmount:   stakeAmount,
-		Status:        models.ArbitrationStatusSubmitted,
-		Priority:      models.ArbitrationPriorityHigh,
+	// 심급마다 스테이킹과 배심원 수가 두 배 (배심원은 동수 방지를 위해 +1)
+	minStake := parent.StakeAmount * 2
+	if stakeAmount < minStake {
+		return nil, fmt.Errorf("%d심 항소에는 최소 %d BLUEPRINT 스테이킹이 필요합니다", parent.AppealRound+2, minStake)
 	}
 
-	if arbitrationCase.DefendantID == userID {
-		appealCase.DefendantID = arbitrationCase.PlaintiffID
+	rootCaseNumber := parent.CaseNumber
+	if idx := strings.Index(rootCaseNumber, "-A"); idx >= 0 {
+		rootCaseNumber = rootCaseNumber[:idx]
 	}
 
-	if err := s.db.Create(appealCase).Error; err != nil {
-		return nil, fmt.Errorf("이의제기 사건 생성 실패: %w", err)
+	// 이전 심급 증거를 이어받고 항소 증거를 덧붙임
+	carriedEvidence := parent.Evidence
+	if evidence != "" {
+		if carriedEvidence != "" {
+			carriedEvidence += "\n\n"
+		}
+		carriedEvidence += fmt.Sprintf("[%d심 항소 증거]\n%s", parent.AppealRound+2, evidence)
+	}
+
+	now := time.Now()
+	var appealCase *models.ArbitrationCase
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// 1. 이전 판결을 항소 중으로 전환 (동시 항소는 한 건만 성공)
+		result := tx.Model(&models.ArbitrationCase{}).
+			Where("id = ? AND status = ?", parent.ID, models.ArbitrationStatusDecided).
+			Update("status", models.ArbitrationStatusAppealed)
+		if result.Error != nil {
+			return fmt.Errorf("항소 처리 실패: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("이미 항소된 사건입니다")
+		}
+
+		// 2. 항소인 BLUEPRINT 잠금
+		var wallet models.UserWallet
+		if err := tx.Where("user_id = ?", userID).First(&wallet).Error; err != nil {
+			return errors.New("지갑을 찾을 수 없습니다")
+		}
+		if wallet.BlueprintBalance < stakeAmount {
+			return errors.New("항소에 필요한 BLUEPRINT 잔액이 부족합니다")
+		}
+
+		// 3. 항소심 사건 생성 (당사자 역할은 유지해 판결 방향이 심급 간 일관되도록 함)
+		appellantID := userID
+		appealCase = &models.ArbitrationCase{
+			CaseNumber:            fmt.Sprintf("%s-A%d", rootCaseNumber, parent.AppealRound+1),
+			PlaintiffID:           parent.PlaintiffID,
+			DefendantID:           parent.DefendantID,
+			DisputeType:           parent.DisputeType,
+			MilestoneID:           parent.MilestoneID,
+			MentorshipID:          parent.MentorshipID,
+			TradeID:               parent.TradeID,
+			Title:                 fmt.Sprintf("[%d심] %s", parent.AppealRound+2, strings.TrimPrefix(parent.Title, fmt.Sprintf("[%d심] ", parent.AppealRound+1))),
+			Description:           reason,
+			Evidence:              carriedEvidence,
+			ClaimedAmount:         parent.ClaimedAmount,
+			Status:                models.ArbitrationStatusSubmitted,
+			Priority:              models.ArbitrationPriorityHigh,
+			StakeAmount:           stakeAmount,
+			RequiredJurors:        parent.RequiredJurors*2 + 1,
+			JuryFormationDeadline: now.Add(48 * time.Hour),
+			ParentCaseID:          &parent.ID,
+			AppealRound:           parent.AppealRound + 1,
+			AppellantID:           &appellantID,
+		}
+		if err := tx.Create(appealCase).Error; err != nil {
+			return fmt.Errorf("항소심 사건 생성 실패: %w", err)
+		}
+
+		return postLedgerEntry(tx, arbitrationLedgerEntry(appealCase, models.WalletLedgerEntry{
+			UserID:       userID,
+			EntryType:    models.LedgerArbitrationStakeLock,
+			Amount:       -stakeAmount,
+			LockedAmount: stakeAmount,
+			Memo:         fmt.Sprintf("분쟁 사건 %s 항소 스테이킹", appealCase.CaseNumber),
+		}))
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	arbitrationCase.Status = models.ArbitrationStatusAppealed
-	s.db.Save(&arbitrationCase)
+	// 4. 확대된 배심원단 선정 (후보가 부족하면 arbitration_phase_timers 주기 작업이 기한 내 재시도)
+	go s.startJurySelection(appealCase.ID)
 
 	return appealCase, nil
 }
@@ -0,0 +1,291 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrMarketHalted 서킷브레이커로 일시 중단된 마켓에 대한 주문
+var ErrMarketHalted = errors.New("서킷브레이커로 거래가 일시 중단된 마켓입니다")
+
+// StatusChangeHaltDuration 펀딩/검증 결과로 거래 가능 상태에 (재)진입한 뒤 주문을 받지 않는 시간
+const StatusChangeHaltDuration = 10 * time.Minute
+
+// CircuitBreakerConfig 가격 변동 서킷브레이커 설정
+type CircuitBreakerConfig struct {
+	MaxMovePercent float64       // Window 안에서 허용하는 최대 가격 변동률 (%)
+	Window         time.Duration // 변동률 측정 구간
+	Cooldown       time.Duration // 발동 후 자동 재개까지 시간
+}
+
+// DefaultCircuitBreakerConfig 기본값: 5분 안에 20% 넘게 움직이면 5분간 중단
+var DefaultCircuitBreakerConfig = CircuitBreakerConfig{
+	MaxMovePercent: 20,
+	Window:         5 * time.Minute,
+	Cooldown:       5 * time.Minute,
+}
+
+// TradingHaltEvent SSE market_halted / market_resumed 이벤트
+type TradingHaltEvent struct {
+	MilestoneID uint                     `json:"milestone_id"`
+	Halted      bool                     `json:"halted"`
+	Reason      models.TradingHaltReason `json:"reason,omitempty"`
+	OptionID    string                   `json:"option_id,omitempty"`    // 가격 변동 발동 옵션
+	MovePercent float64                  `json:"move_percent,omitempty"` // 발동 시점 변동률
+	ResumesAt   *time.Time               `json:"resumes_at,omitempty"`
+}
+
+type tradePricePoint struct {
+	at    time.Time
+	ticks int64
+}
+
+// CircuitBreakerService 마켓(마일스톤) 단위 거래 일시 중단
+//
+// 중단 상태는 milestones.trading_halted_until에 저장되어 모든 서버 인스턴스가 공유한다.
+// 가격 변동 발동은 매칭 엔진이 체결마다 RecordTrades로 알리고, 상태 전환 발동은
+// MilestoneStateMachine.Transition이 직접 기록한다. RunMonitor가 중단/재개를 SSE로 알리고
+// 쿨다운이 끝난 중단을 해제한다.
+type CircuitBreakerService struct {
+	db         *gorm.DB
+	sseService *SSEService
+
+	mutex  sync.Mutex
+	config CircuitBreakerConfig
+	prices map[string][]tradePricePoint // milestoneID:optionID -> 측정 구간 내 체결가
+	halts  map[uint]time.Time           // 알림을 보낸 중단 (milestoneID -> 재개 시각)
+}
+
+// NewCircuitBreakerService 생성자
+func NewCircuitBreakerService(db *gorm.DB, sseService *SSEService, config CircuitBreakerConfig) *CircuitBreakerService {
+	return &CircuitBreakerService{
+		db:         db,
+		sseService: sseService,
+		config:     config,
+		prices:     make(map[string][]tradePricePoint),
+		halts:      make(map[uint]time.Time),
+	}
+}
+
+// Configure 발동 기준 변경 (서버 설정 반영용)
+func (s *CircuitBreakerService) Configure(config CircuitBreakerConfig) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.config = config
+}
+
+// Check 주문 접수 가능 여부 (마일스톤은 trading_halted_until을 포함해 조회된 상태여야 함)
+func (s *CircuitBreakerService) Check(milestone *models.Milestone) error {
+	until, halted := s.HaltedUntil(milestone.ID)
+	if milestone.TradingHaltedUntil != nil && milestone.TradingHaltedUntil.After(until) {
+		until, halted = *milestone.TradingHaltedUntil, true
+	}
+	if !halted || !time.Now().Before(until) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s 이후 재개", ErrMarketHalted, until.UTC().Format(time.RFC3339))
+}
+
+// HaltedUntil 이 인스턴스가 알고 있는 중단 재개 시각
+func (s *CircuitBreakerService) HaltedUntil(milestoneID uint) (time.Time, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	until, ok := s.halts[milestoneID]
+	if !ok || !time.Now().Before(until) {
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// RecordTrades 체결가를 측정 구간에 넣고 변동률이 기준을 넘으면 마켓 중단 (시장 액터 고루틴에서 호출)
+func (s *CircuitBreakerService) RecordTrades(milestoneID uint, optionID string, trades []models.Trade) {
+	if len(trades) == 0 {
+		return
+	}
+
+	s.mutex.Lock()
+	config := s.config
+	if config.MaxMovePercent <= 0 || config.Window <= 0 {
+		s.mutex.Unlock()
+		return
+	}
+
+	key := fmt.Sprintf("%d:%s", milestoneID, optionID)
+	now := time.Now()
+	window := pruneTradePrices(s.prices[key], now.Add(-config.Window))
+
+	var move float64
+	for _, trade := range trades {
+		if m := priceMovePercent(window, trade.PriceTicks); m > move {
+			move = m
+		}
+		window = append(window, tradePricePoint{at: now, ticks: trade.PriceTicks})
+	}
+	s.prices[key] = window
+
+	_, alreadyHalted := s.halts[milestoneID]
+	s.mutex.Unlock()
+
+	if move > config.MaxMovePercent && !alreadyHalted {
+		s.trip(milestoneID, optionID, move, now.Add(config.Cooldown))
+	}
+}
+
+// trip 가격 변동 중단 발동 (메모리 즉시 반영, DB 기록과 알림은 백그라운드)
+func (s *CircuitBreakerService) trip(milestoneID uint, optionID string, move float64, until time.Time) {
+	s.mutex.Lock()
+	s.halts[milestoneID] = until
+	s.mutex.Unlock()
+
+	log.Printf("🧯 Circuit breaker tripped for milestone %d (%s moved %.1f%%), halted until %s",
+		milestoneID, optionID, move, until.Format(time.RFC3339))
+
+	go func() {
+		if err := s.db.Model(&models.Milestone{}).Where("id = ?", milestoneID).UpdateColumns(map[string]interface{}{
+			"trading_halted_until": until,
+			"trading_halt_reason":  models.TradingHaltPriceMove,
+		}).Error; err != nil {
+			log.Printf("❌ Failed to persist trading halt for milestone %d: %v", milestoneID, err)
+		}
+	}()
+
+	s.broadcast(TradingHaltEvent{
+		MilestoneID: milestoneID,
+		Halted:      true,
+		Reason:      models.TradingHaltPriceMove,
+		OptionID:    optionID,
+		MovePercent: move,
+		ResumesAt:   &until,
+	})
+}
+
+// RunMonitor 주기적으로 중단/재개 상태 동기화
+func (s *CircuitBreakerService) RunMonitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, _, err := s.SyncHalts(time.Now()); err != nil {
+			log.Printf("❌ Circuit breaker sync failed: %v", err)
+		}
+	}
+}
+
+// SyncHalts DB에 기록된 중단 중 새로 알게 된 것은 알리고, 쿨다운이 끝난 것은 해제 후 재개 알림
+func (s *CircuitBreakerService) SyncHalts(now time.Time) (halted, resumed int, err error) {
+	var milestones []models.Milestone
+	if err := s.db.Select("id", "trading_halted_until", "trading_halt_reason").
+		Where("trading_halted_until IS NOT NULL").
+		Find(&milestones).Error; err != nil {
+		return 0, 0, fmt.Errorf("중단 마켓 조회 실패: %w", err)
+	}
+
+	seen := make(map[uint]bool, len(milestones))
+	for _, milestone := range milestones {
+		seen[milestone.ID] = true
+		until := *milestone.TradingHaltedUntil
+
+		if now.Before(until) {
+			s.mutex.Lock()
+			known, ok := s.halts[milestone.ID]
+			if !ok || !known.Equal(until) {
+				s.halts[milestone.ID] = until
+			}
+			s.mutex.Unlock()
+
+			if !ok {
+				s.broadcast(TradingHaltEvent{MilestoneID: milestone.ID, Halted: true, Reason: milestone.TradingHaltReason, ResumesAt: &until})
+				halted++
+			}
+			continue
+		}
+
+		// 다른 요청이 중단을 연장했을 수 있으므로 읽은 시각 그대로일 때만 해제
+		if err := s.db.Model(&models.Milestone{}).
+			Where("id = ? AND trading_halted_until = ?", milestone.ID, until).
+			UpdateColumns(map[string]interface{}{"trading_halted_until": nil, "trading_halt_reason": ""}).Error; err != nil {
+			log.Printf("❌ Failed to clear trading halt for milestone %d: %v", milestone.ID, err)
+			continue
+		}
+		s.resume(milestone.ID)
+		resumed++
+	}
+
+	// DB 기록에 실패했던 메모리 중단도 쿨다운이 끝나면 해제
+	s.mutex.Lock()
+	var expired []uint
+	for milestoneID, until := range s.halts {
+		if !seen[milestoneID] && !now.Before(until) {
+			expired = append(expired, milestoneID)
+		}
+	}
+	s.mutex.Unlock()
+	for _, milestoneID := range expired {
+		s.resume(milestoneID)
+		resumed++
+	}
+
+	return halted, resumed, nil
+}
+
+// resume 중단 해제 (측정 구간을 비워 재개 직후 재발동 방지)
+func (s *CircuitBreakerService) resume(milestoneID uint) {
+	s.mutex.Lock()
+	delete(s.halts, milestoneID)
+	prefix := fmt.Sprintf("%d:", milestoneID)
+	for key := range s.prices {
+		if strings.HasPrefix(key, prefix) {
+			delete(s.prices, key)
+		}
+	}
+	s.mutex.Unlock()
+
+	log.Printf("▶️ Trading resumed for milestone %d", milestoneID)
+	s.broadcast(TradingHaltEvent{MilestoneID: milestoneID, Halted: false})
+}
+
+func (s *CircuitBreakerService) broadcast(event TradingHaltEvent) {
+	if s.sseService != nil {
+		s.sseService.BroadcastTradingHalt(event)
+	}
+}
+
+// pruneTradePrices 측정 구간 밖의 체결가 제거
+func pruneTradePrices(points []tradePricePoint, since time.Time) []tradePricePoint {
+	i := 0
+	for i < len(points) && points[i].at.Before(since) {
+		i++
+	}
+	return points[i:]
+}
+
+// priceMovePercent 측정 구간 최저가 대비 상승률과 최고가 대비 하락률 중 큰 값 (%)
+func priceMovePercent(window []tradePricePoint, ticks int64) float64 {
+	if len(window) == 0 || ticks <= 0 {
+		return 0
+	}
+
+	low, high := window[0].ticks, window[0].ticks
+	for _, point := range window[1:] {
+		low = min(low, point.ticks)
+		high = max(high, point.ticks)
+	}
+
+	var move float64
+	if low > 0 && ticks > low {
+		move = float64(ticks-low) / float64(low) * 100
+	}
+	if high > 0 && ticks < high {
+		move = max(move, float64(high-ticks)/float64(high)*100)
+	}
+	return move
+}
@@ -0,0 +1,464 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	copyTradingUserAgent      = "copy-trading" // 복사 주문의 UserAgent (주문 내역에서 구분)
+	copyTradingLeaderLimit    = 50
+	copyTradingExecutionLimit = 100
+)
+
+var (
+	// ErrCopyLeaderNotFound 리더 모드를 켜지 않은 사용자
+	ErrCopyLeaderNotFound = errors.New("팔로우할 수 있는 리더가 아닙니다")
+	// ErrCopyFollowNotFound 없거나 본인 것이 아닌(또는 해제된) 팔로우
+	ErrCopyFollowNotFound = errors.New("팔로우를 찾을 수 없습니다")
+	// ErrCopyFollowSelf 본인 팔로우
+	ErrCopyFollowSelf = errors.New("자기 자신은 팔로우할 수 없습니다")
+	// ErrCopyAlreadyFollowing 이미 팔로우 중 (한도 변경은 PUT)
+	ErrCopyAlreadyFollowing = errors.New("이미 팔로우 중인 리더입니다")
+	// ErrCopyDisclaimerRequired 현재 버전 위험 고지에 동의하지 않음
+	ErrCopyDisclaimerRequired = errors.New("복사 거래 위험 고지에 동의해야 합니다")
+	// ErrCopyLeaderNotPublic 투자 내역 비공개 사용자는 리더가 될 수 없음
+	ErrCopyLeaderNotPublic = errors.New("리더가 되려면 투자 내역을 공개해야 합니다")
+	// ErrCopyChainNotAllowed 리더는 팔로우할 수 없고 팔로우 중인 사용자는 리더가 될 수 없음 (복사의 복사 방지)
+	ErrCopyChainNotAllowed = errors.New("리더와 팔로워를 동시에 할 수 없습니다")
+)
+
+// CopyTradingService 리더 체결을 팔로워 주문으로 따라 하는 복사 거래
+//
+// 리더가 직접 리더 모드를 켜야(투자 내역 공개 필요) 팔로우할 수 있고, 팔로워는 현재 버전 위험 고지에 동의하고
+// 수량 배율, 주문당/일일 매수 한도를 정한다. 매칭 엔진이 체결을 저장하면 리더 쪽 체결마다
+// 배율을 적용한 수량을 같은 가격의 지정가 주문으로 넣는다. 복사 주문도 일반 주문 경로(TradingService.CreateOrder)를
+// 그대로 타므로 잔액 잠금, 책임 거래 한도, 거래 중단/서킷브레이커 검사가 모두 적용된다.
+// 매도는 팔로워가 실제로 팔 수 있는 수량까지만 따라 하고, 잔액 부족이면 팔로우를 자동으로 일시 중지한다.
+// 리더는 팔로우할 수 없고 팔로워는 리더가 될 수 없어 복사 주문이 다시 복사되지 않는다.
+type CopyTradingService struct {
+	db                  *gorm.DB
+	tradingService      *TradingService
+	notificationService *NotificationService
+}
+
+// NewCopyTradingService 생성자
+func NewCopyTradingService(db *gorm.DB, tradingService *TradingService) *CopyTradingService {
+	return &CopyTradingService{
+		db:                  db,
+		tradingService:      tradingService,
+		notificationService: NewNotificationService(db),
+	}
+}
+
+var _ TradeListener = (*CopyTradingService)(nil)
+
+// SetLeader 리더 모드 켜기/끄기 (끄면 모든 팔로우 해제 후 팔로워에게 알림)
+func (s *CopyTradingService) SetLeader(userID uint, enabled bool) (*models.CopyLeader, error) {
+	if enabled {
+		var profile models.UserProfile
+		err := s.db.Select("investment_public").Where("user_id = ?", userID).First(&profile).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if !profile.InvestmentPublic {
+			return nil, ErrCopyLeaderNotPublic
+		}
+	}
+
+	leader := models.CopyLeader{UserID: userID}
+	var stopped []models.CopyFollow
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if enabled {
+			var following int64
+			if err := tx.Model(&models.CopyFollow{}).
+				Where("follower_id = ? AND status <> ?", userID, models.CopyFollowStopped).
+				Count(&following).Error; err != nil {
+				return err
+			}
+			if following > 0 {
+				return ErrCopyChainNotAllowed
+			}
+		} else {
+			if err := tx.Where("leader_id = ? AND status <> ?", userID, models.CopyFollowStopped).Find(&stopped).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&models.CopyFollow{}).
+				Where("leader_id = ? AND status <> ?", userID, models.CopyFollowStopped).
+				Updates(map[string]interface{}{"status": models.CopyFollowStopped, "paused_reason": "리더가 복사 거래를 종료했습니다"}).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"enabled": enabled, "follower_count": 0, "updated_at": time.Now()}),
+		}).Create(&models.CopyLeader{UserID: userID, Enabled: enabled}).Error; err != nil {
+			return err
+		}
+		if enabled {
+			if err := refreshFollowerCount(tx, userID); err != nil {
+				return err
+			}
+		}
+		return tx.First(&leader, "user_id = ?", userID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range stopped {
+		stopped[i].Status = models.CopyFollowStopped
+		s.notify(stopped[i].FollowerID, "복사 거래 종료", "팔로우하던 리더가 복사 거래를 종료해 자동 주문이 중단되었습니다.", &stopped[i])
+	}
+	return &leader, nil
+}
+
+// ListLeaders 팔로우할 수 있는 리더 목록 (투자 내역 공개 사용자만, 거래 금액순)
+func (s *CopyTradingService) ListLeaders() ([]models.CopyLeaderSummary, error) {
+	leaders := []models.CopyLeaderSummary{}
+	err := s.db.Table("copy_leaders").
+		Select("copy_leaders.user_id, users.username, user_profiles.display_name, copy_leaders.follower_count, "+
+			"COALESCE(user_stats_caches.total_volume, 0) AS total_volume, COALESCE(user_stats_caches.total_trades, 0) AS total_trades, "+
+			"COALESCE(user_stats_caches.win_rate, 0) AS win_rate, COALESCE(user_stats_caches.market_accuracy, 0) AS market_accuracy").
+		Joins("JOIN users ON users.id = copy_leaders.user_id AND users.deleted_at IS NULL").
+		Joins("JOIN user_profiles ON user_profiles.user_id = copy_leaders.user_id").
+		Joins("LEFT JOIN user_stats_caches ON user_stats_caches.user_id = copy_leaders.user_id").
+		Where("copy_leaders.enabled = ? AND user_profiles.investment_public = ?", true, true).
+		Order("total_volume DESC").
+		Limit(copyTradingLeaderLimit).
+		Scan(&leaders).Error
+	return leaders, err
+}
+
+// Follow 리더 팔로우 (해제했던 팔로우는 새 한도로 다시 활성화)
+func (s *CopyTradingService) Follow(followerID, leaderID uint, req models.FollowTraderRequest) (*models.CopyFollow, error) {
+	if followerID == leaderID {
+		return nil, ErrCopyFollowSelf
+	}
+	if !req.AcceptDisclaimer || req.DisclaimerVersion != models.CopyTradingDisclaimerVersion {
+		return nil, ErrCopyDisclaimerRequired
+	}
+
+	var follow models.CopyFollow
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var leader models.CopyLeader
+		if err := tx.Where("user_id = ? AND enabled = ?", leaderID, true).First(&leader).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCopyLeaderNotFound
+			}
+			return err
+		}
+		var leading int64
+		if err := tx.Model(&models.CopyLeader{}).Where("user_id = ? AND enabled = ?", followerID, true).Count(&leading).Error; err != nil {
+			return err
+		}
+		if leading > 0 {
+			return ErrCopyChainNotAllowed
+		}
+
+		now := time.Now()
+		err := tx.Where("follower_id = ? AND leader_id = ?", followerID, leaderID).First(&follow).Error
+		switch {
+		case err == nil && follow.Status != models.CopyFollowStopped:
+			return ErrCopyAlreadyFollowing
+		case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		follow.FollowerID = followerID
+		follow.LeaderID = leaderID
+		follow.SizeScale = req.SizeScale
+		follow.MaxOrderNotional = req.MaxOrderNotional
+		follow.MaxDailyNotional = req.MaxDailyNotional
+		follow.Status = models.CopyFollowActive
+		follow.PausedReason = ""
+		follow.DisclaimerVersion = req.DisclaimerVersion
+		follow.DisclaimerAcceptedAt = now
+		if err := tx.Save(&follow).Error; err != nil {
+			return err
+		}
+		return refreshFollowerCount(tx, leaderID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &follow, nil
+}
+
+// UpdateFollow 팔로우 한도 변경/일시 중지/재개
+func (s *CopyTradingService) UpdateFollow(followID, followerID uint, req models.UpdateCopyFollowRequest) (*models.CopyFollow, error) {
+	var follow models.CopyFollow
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND follower_id = ? AND status <> ?", followID, followerID, models.CopyFollowStopped).
+			First(&follow).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCopyFollowNotFound
+			}
+			return err
+		}
+
+		if req.SizeScale != nil {
+			follow.SizeScale = *req.SizeScale
+		}
+		if req.MaxOrderNotional != nil {
+			follow.MaxOrderNotional = *req.MaxOrderNotional
+		}
+		if req.MaxDailyNotional != nil {
+			follow.MaxDailyNotional = *req.MaxDailyNotional
+		}
+		if req.Status != nil && *req.Status != follow.Status {
+			follow.Status = *req.Status
+			follow.PausedReason = ""
+			if follow.Status == models.CopyFollowPaused {
+				follow.PausedReason = "팔로워가 일시 중지했습니다"
+			}
+		}
+		return tx.Save(&follow).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &follow, nil
+}
+
+// Unfollow 팔로우 해제 (이미 넣은 복사 주문은 그대로 두고, 이후 체결은 따라 하지 않음)
+func (s *CopyTradingService) Unfollow(followID, followerID uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var follow models.CopyFollow
+		if err := tx.Where("id = ? AND follower_id = ? AND status <> ?", followID, followerID, models.CopyFollowStopped).
+			First(&follow).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCopyFollowNotFound
+			}
+			return err
+		}
+		if err := tx.Model(&follow).Updates(map[string]interface{}{
+			"status":        models.CopyFollowStopped,
+			"paused_reason": "팔로워가 해제했습니다",
+		}).Error; err != nil {
+			return err
+		}
+		return refreshFollowerCount(tx, follow.LeaderID)
+	})
+}
+
+// ListFollows 내 팔로우 목록 (해제한 팔로우 제외)
+func (s *CopyTradingService) ListFollows(followerID uint) ([]models.CopyFollow, error) {
+	follows := []models.CopyFollow{}
+	err := s.db.Preload("Leader").
+		Where("follower_id = ? AND status <> ?", followerID, models.CopyFollowStopped).
+		Order("created_at DESC").
+		Find(&follows).Error
+	return follows, err
+}
+
+// ListExecutions 내 복사 주문 내역 (최신순)
+func (s *CopyTradingService) ListExecutions(followerID uint) ([]models.CopyTradeExecution, error) {
+	executions := []models.CopyTradeExecution{}
+	err := s.db.Where("follower_id = ?", followerID).
+		Order("created_at DESC, id DESC").
+		Limit(copyTradingExecutionLimit).
+		Find(&executions).Error
+	return executions, err
+}
+
+// OnTradesPersisted 저장된 체결 중 리더 쪽 체결을 활성 팔로워 주문으로 복사 (TradeListener)
+func (s *CopyTradingService) OnTradesPersisted(trades []models.Trade) {
+	participants := make([]uint, 0, len(trades)*2)
+	for i := range trades {
+		participants = append(participants, trades[i].BuyerID, trades[i].SellerID)
+	}
+	var leaderIDs []uint
+	if err := s.db.Model(&models.CopyLeader{}).
+		Where("user_id IN ? AND enabled = ? AND follower_count > 0", participants, true).
+		Pluck("user_id", &leaderIDs).Error; err != nil {
+		log.Printf("⚠️ Failed to load copy leaders: %v", err)
+		return
+	}
+	if len(leaderIDs) == 0 {
+		return
+	}
+	leaders := make(map[uint]bool, len(leaderIDs))
+	for _, id := range leaderIDs {
+		leaders[id] = true
+	}
+
+	for i := range trades {
+		trade := &trades[i]
+		if trade.BuyerID == trade.SellerID {
+			continue
+		}
+		if leaders[trade.BuyerID] {
+			s.mirrorTrade(trade, trade.BuyerID, models.OrderSideBuy)
+		}
+		if leaders[trade.SellerID] {
+			s.mirrorTrade(trade, trade.SellerID, models.OrderSideSell)
+		}
+	}
+}
+
+// mirrorTrade 리더 체결 1건을 활성 팔로워 전원에게 복사
+func (s *CopyTradingService) mirrorTrade(trade *models.Trade, leaderID uint, side models.OrderSide) {
+	var follows []models.CopyFollow
+	if err := s.db.Where("leader_id = ? AND status = ?", leaderID, models.CopyFollowActive).Find(&follows).Error; err != nil {
+		log.Printf("⚠️ Failed to load copy followers of %d: %v", leaderID, err)
+		return
+	}
+	for i := range follows {
+		if follows[i].FollowerID == trade.BuyerID || follows[i].FollowerID == trade.SellerID {
+			continue // 리더와 직접 체결한 팔로워는 같은 체결을 다시 따라 하지 않음
+		}
+		if err := s.mirrorForFollower(&follows[i], trade, side); err != nil {
+			log.Printf("⚠️ Failed to copy trade %d for follow %d: %v", trade.ID, follows[i].ID, err)
+		}
+	}
+}
+
+// mirrorForFollower 배율과 한도를 적용해 복사 주문을 넣고 결과를 기록 (같은 체결은 팔로우당 한 번만)
+func (s *CopyTradingService) mirrorForFollower(follow *models.CopyFollow, trade *models.Trade, side models.OrderSide) error {
+	execution := models.CopyTradeExecution{
+		FollowID:       follow.ID,
+		SourceTradeID:  trade.ID,
+		FollowerID:     follow.FollowerID,
+		LeaderID:       follow.LeaderID,
+		MilestoneID:    trade.MilestoneID,
+		OptionID:       trade.OptionID,
+		Side:           side,
+		Price:          models.TicksToPrice(trade.PriceTicks),
+		LeaderQuantity: trade.Quantity,
+		Status:         models.CopyTradePlaced,
+	}
+
+	quantity, reason, err := s.copyQuantity(follow, trade, side)
+	if err != nil {
+		return err
+	}
+	if quantity < 1 {
+		execution.Status = models.CopyTradeSkipped
+		execution.Reason = reason
+	} else {
+		execution.Quantity = quantity
+		if side == models.OrderSideBuy {
+			execution.Notional = models.ReserveCents(quantity, trade.PriceTicks)
+		}
+	}
+
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&execution)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 || execution.Status != models.CopyTradePlaced {
+		return nil
+	}
+
+	response, err := s.tradingService.CreateOrder(follow.FollowerID, models.CreateOrderRequest{
+		ProjectID:   trade.ProjectID,
+		MilestoneID: trade.MilestoneID,
+		OptionID:    trade.OptionID,
+		Type:        models.OrderTypeLimit,
+		Side:        side,
+		Quantity:    quantity,
+		Price:       execution.Price,
+	}, "", copyTradingUserAgent)
+	if err != nil {
+		if updateErr := s.db.Model(&execution).Updates(map[string]interface{}{
+			"status": models.CopyTradeFailed, "reason": err.Error(), "notional": 0,
+		}).Error; updateErr != nil {
+			return updateErr
+		}
+		if errors.Is(err, ErrInsufficientBalance) {
+			return s.pauseFollow(follow, "잔액이 부족해 복사 거래가 일시 중지되었습니다")
+		}
+		return nil
+	}
+	return s.db.Model(&execution).Update("order_id", response.Order.ID).Error
+}
+
+// copyQuantity 배율 적용 수량을 주문당/일일 매수 한도 또는 매도 가능 수량으로 자름 (0이면 건너뛴 이유 반환)
+func (s *CopyTradingService) copyQuantity(follow *models.CopyFollow, trade *models.Trade, side models.OrderSide) (int64, string, error) {
+	quantity := int64(math.Floor(float64(trade.Quantity) * follow.SizeScale))
+	if quantity < 1 {
+		return 0, "배율 적용 후 수량이 1 미만입니다", nil
+	}
+
+	if side == models.OrderSideSell {
+		available, err := transferableQuantity(s.db, follow.FollowerID, trade.MilestoneID, trade.OptionID, 0)
+		if err != nil {
+			return 0, "", err
+		}
+		if available < 1 {
+			return 0, "매도할 보유 수량이 없습니다", nil
+		}
+		if quantity > available {
+			quantity = available
+		}
+		return quantity, "", nil
+	}
+
+	dayStart := time.Now().UTC().Truncate(24 * time.Hour)
+	var used struct{ Total int64 }
+	if err := s.db.Model(&models.CopyTradeExecution{}).
+		Select("COALESCE(SUM(notional), 0) AS total").
+		Where("follow_id = ? AND status = ? AND created_at >= ?", follow.ID, models.CopyTradePlaced, dayStart).
+		First(&used).Error; err != nil {
+		return 0, "", err
+	}
+	budget := follow.MaxOrderNotional
+	if remaining := follow.MaxDailyNotional - used.Total; remaining < budget {
+		budget = remaining
+	}
+	if limit := models.MaxReserveQuantity(budget, trade.PriceTicks); quantity > limit {
+		quantity = limit
+	}
+	if quantity < 1 {
+		return 0, "주문당/일일 복사 한도를 초과했습니다", nil
+	}
+	return quantity, "", nil
+}
+
+// pauseFollow 팔로우 자동 일시 중지 후 팔로워에게 알림
+func (s *CopyTradingService) pauseFollow(follow *models.CopyFollow, reason string) error {
+	if err := s.db.Model(follow).Updates(map[string]interface{}{
+		"status": models.CopyFollowPaused, "paused_reason": reason,
+	}).Error; err != nil {
+		return err
+	}
+	follow.Status = models.CopyFollowPaused
+	follow.PausedReason = reason
+	s.notify(follow.FollowerID, "복사 거래 일시 중지", reason, follow)
+	return nil
+}
+
+func (s *CopyTradingService) notify(userID uint, title, message string, follow *models.CopyFollow) {
+	if _, err := s.notificationService.Notify(models.CreateNotificationRequest{
+		UserID:   userID,
+		Type:     models.NotificationTypeCopyTrading,
+		Priority: models.NotificationPriorityNormal,
+		Title:    title,
+		Message:  message,
+		Link:     "/portfolio/copy-trading",
+		Data:     map[string]interface{}{"follow_id": follow.ID, "leader_id": follow.LeaderID, "status": follow.Status},
+	}); err != nil {
+		log.Printf("⚠️ Failed to notify copy follow %d: %v", follow.ID, err)
+	}
+}
+
+// refreshFollowerCount 리더의 팔로워 수(해제 제외) 재계산
+func refreshFollowerCount(tx *gorm.DB, leaderID uint) error {
+	var count int64
+	if err := tx.Model(&models.CopyFollow{}).
+		Where("leader_id = ? AND status <> ?", leaderID, models.CopyFollowStopped).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("팔로워 수 계산 실패: %w", err)
+	}
+	return tx.Model(&models.CopyLeader{}).Where("user_id = ?", leaderID).Update("follower_count", count).Error
+}
@@ -0,0 +1,486 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/secrets"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrInvalidPayoutAccount 계좌 종류에 필요한 정보 누락 또는 형식 오류
+	ErrInvalidPayoutAccount = errors.New("지급 계좌 정보가 올바르지 않습니다")
+	// ErrPayoutAccountRequired 지급 계좌 미등록 또는 다른 사용자의 계좌
+	ErrPayoutAccountRequired = errors.New("등록된 지급 계좌가 필요합니다")
+	// ErrPayoutAccountInUse 진행 중 지급 요청이 있는 계좌 삭제
+	ErrPayoutAccountInUse = errors.New("진행 중인 지급 요청이 있는 계좌입니다")
+	// ErrPayoutExceedsEarnings 지급 가능 금액 초과
+	ErrPayoutExceedsEarnings = errors.New("지급 가능 금액을 초과했습니다")
+	// ErrPayoutNotReviewable 이미 처리된 지급 요청 승인/반려
+	ErrPayoutNotReviewable = errors.New("승인/반려할 수 없는 지급 요청입니다")
+)
+
+var (
+	bankAccountNumberPattern = regexp.MustCompile(`^[0-9]{6,20}$`)
+	cryptoAddressPattern     = regexp.MustCompile(`^[0-9A-Za-z]{26,128}$`)
+)
+
+// CreatorMilestoneEarning 마일스톤별 창작자 수익 (센트)
+type CreatorMilestoneEarning struct {
+	MilestoneID     uint  `json:"milestone_id"`
+	ProjectID       uint  `json:"project_id"`
+	EscrowAmount    int64 `json:"escrow_amount"`     // 완료 확정으로 받은 직접 후원금
+	MentorPoolShare int64 `json:"mentor_pool_share"` // 분배되지 않은 멘토 풀 몫
+	Total           int64 `json:"total"`
+}
+
+// CreatorEarnings 창작자 수익과 지급 가능 금액 (센트)
+type CreatorEarnings struct {
+	Milestones    []CreatorMilestoneEarning `json:"milestones"`
+	TotalEarned   int64                     `json:"total_earned"`
+	PaidOut       int64                     `json:"paid_out"`
+	Pending       int64                     `json:"pending"`        // 승인 대기/송금 예정/송금 중
+	WalletBalance int64                     `json:"wallet_balance"` // 사용 가능 USDC
+	Payable       int64                     `json:"payable"`        // min(수익 - 지급 - 진행 중, 사용 가능 잔액)
+}
+
+// openPayoutStatuses 지급 가능 금액에서 빼는 진행 중 상태
+var openPayoutStatuses = []models.CreatorPayoutStatus{
+	models.CreatorPayoutRequested, models.CreatorPayoutApproved, models.CreatorPayoutProcessing,
+}
+
+// CreatorPayoutService 창작자 지급 계좌와 지급 요청
+//
+// 마일스톤 완료 시 프로젝트 소유자 지갑에 들어온 직접 후원금(escrow_payout)과 멘토 풀 소유자 몫
+// (creator_pool_share)이 지급 대상 수익이다. 요청하면 그 금액을 지갑에서 잠그고(payout_hold), 관리자가
+// 승인하면 워커가 예정 시각에 지급 계좌로 송금해 잠금을 차감(payout_disbursed)한다. 반려/실패는 잠금을
+// 풀어 준다(payout_release).
+type CreatorPayoutService struct {
+	db            *gorm.DB
+	encryptionKey []byte
+}
+
+// NewCreatorPayoutService 생성자 (계좌 정보는 API 키 secret과 같은 키로 암호화)
+func NewCreatorPayoutService(db *gorm.DB, encryptionSecret string) *CreatorPayoutService {
+	return &CreatorPayoutService{
+		db:            db,
+		encryptionKey: secrets.DeriveKey(encryptionSecret),
+	}
+}
+
+// RegisterAccount 지급 계좌 등록 (첫 계좌 또는 is_default면 기본 계좌)
+//
+// 실제 은행/체인 확인은 아직 연동하지 않아 형식 검증을 통과하면 확인된 계좌로 본다.
+func (s *CreatorPayoutService) RegisterAccount(userID uint, req models.RegisterPayoutAccountRequest) (*models.PayoutAccount, error) {
+	account := &models.PayoutAccount{
+		UserID: userID,
+		Type:   req.Type,
+		Label:  strings.TrimSpace(req.Label),
+	}
+
+	var details string
+	switch req.Type {
+	case models.PayoutAccountBank:
+		details = strings.NewReplacer("-", "", " ", "").Replace(req.AccountNumber)
+		if req.BankName == "" || req.AccountHolder == "" || !bankAccountNumberPattern.MatchString(details) {
+			return nil, fmt.Errorf("%w: 은행명, 예금주, 계좌번호(숫자 6~20자리)가 필요합니다", ErrInvalidPayoutAccount)
+		}
+		account.BankName = strings.TrimSpace(req.BankName)
+		account.AccountHolder = strings.TrimSpace(req.AccountHolder)
+		account.MaskedDetails = "****" + details[len(details)-4:]
+	case models.PayoutAccountCrypto:
+		details = strings.TrimSpace(req.CryptoAddress)
+		if req.CryptoNetwork == "" || !cryptoAddressPattern.MatchString(strings.TrimPrefix(details, "0x")) {
+			return nil, fmt.Errorf("%w: 네트워크와 지갑 주소가 필요합니다", ErrInvalidPayoutAccount)
+		}
+		account.CryptoNetwork = strings.ToLower(strings.TrimSpace(req.CryptoNetwork))
+		account.MaskedDetails = details[:6] + "…" + details[len(details)-4:]
+	default:
+		return nil, fmt.Errorf("%w: 지원하지 않는 계좌 종류 %s", ErrInvalidPayoutAccount, req.Type)
+	}
+
+	encrypted, err := secrets.Encrypt(s.encryptionKey, details)
+	if err != nil {
+		return nil, fmt.Errorf("계좌 정보 암호화 실패: %w", err)
+	}
+	account.DetailsEncrypted = encrypted
+	now := time.Now()
+	account.VerifiedAt = &now
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.PayoutAccount{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+			return err
+		}
+		account.IsDefault = req.IsDefault || count == 0
+		if account.IsDefault {
+			if err := tx.Model(&models.PayoutAccount{}).Where("user_id = ? AND is_default = ?", userID, true).
+				Update("is_default", false).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(account).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("지급 계좌 저장 실패: %w", err)
+	}
+
+	log.Printf("💸 User %d registered %s payout account %d (%s)", userID, account.Type, account.ID, account.MaskedDetails)
+	return account, nil
+}
+
+// GetAccounts 내 지급 계좌 (기본 계좌 먼저)
+func (s *CreatorPayoutService) GetAccounts(userID uint) ([]models.PayoutAccount, error) {
+	var accounts []models.PayoutAccount
+	if err := s.db.Where("user_id = ?", userID).
+		Order("is_default DESC, id ASC").
+		Find(&accounts).Error; err != nil {
+		return nil, fmt.Errorf("지급 계좌 조회 실패: %w", err)
+	}
+	return accounts, nil
+}
+
+// DeleteAccount 지급 계좌 삭제 (진행 중 지급 요청이 있으면 거부)
+func (s *CreatorPayoutService) DeleteAccount(userID, accountID uint) error {
+	var account models.PayoutAccount
+	if err := s.db.Where("id = ? AND user_id = ?", accountID, userID).First(&account).Error; err != nil {
+		return err
+	}
+
+	var open int64
+	if err := s.db.Model(&models.CreatorPayout{}).
+		Where("payout_account_id = ? AND status IN ?", accountID, openPayoutStatuses).
+		Count(&open).Error; err != nil {
+		return fmt.Errorf("지급 요청 조회 실패: %w", err)
+	}
+	if open > 0 {
+		return ErrPayoutAccountInUse
+	}
+	return s.db.Delete(&account).Error
+}
+
+// GetEarnings 마일스톤별 수익과 지급 가능 금액
+func (s *CreatorPayoutService) GetEarnings(userID uint) (*CreatorEarnings, error) {
+	return s.earnings(s.db, userID)
+}
+
+func (s *CreatorPayoutService) earnings(tx *gorm.DB, userID uint) (*CreatorEarnings, error) {
+	byMilestone := make(map[uint]*CreatorMilestoneEarning)
+	entry := func(milestoneID, projectID uint) *CreatorMilestoneEarning {
+		if e, ok := byMilestone[milestoneID]; ok {
+			return e
+		}
+		e := &CreatorMilestoneEarning{MilestoneID: milestoneID, ProjectID: projectID}
+		byMilestone[milestoneID] = e
+		return e
+	}
+
+	var escrows []struct {
+		MilestoneID uint
+		ProjectID   uint
+		Total       int64
+	}
+	if err := tx.Model(&models.FundingEscrow{}).
+		Select("milestone_id, project_id, COALESCE(SUM(amount), 0) AS total").
+		Where("recipient_id = ? AND status = ?", userID, models.FundingEscrowReleased).
+		Group("milestone_id, project_id").
+		Scan(&escrows).Error; err != nil {
+		return nil, fmt.Errorf("후원 수익 집계 실패: %w", err)
+	}
+	for _, row := range escrows {
+		entry(row.MilestoneID, row.ProjectID).EscrowAmount += row.Total
+	}
+
+	var pools []models.MentorPool
+	if err := tx.Select("mentor_pools.milestone_id", "mentor_pools.project_id", "mentor_pools.creator_share_amount").
+		Joins("JOIN projects ON projects.id = mentor_pools.project_id").
+		Where("projects.user_id = ? AND mentor_pools.creator_share_amount > 0", userID).
+		Find(&pools).Error; err != nil {
+		return nil, fmt.Errorf("멘토 풀 몫 집계 실패: %w", err)
+	}
+	for _, pool := range pools {
+		entry(pool.MilestoneID, pool.ProjectID).MentorPoolShare += pool.CreatorShareAmount
+	}
+
+	result := &CreatorEarnings{Milestones: make([]CreatorMilestoneEarning, 0, len(byMilestone))}
+	for _, e := range byMilestone {
+		e.Total = e.EscrowAmount + e.MentorPoolShare
+		result.TotalEarned += e.Total
+		result.Milestones = append(result.Milestones, *e)
+	}
+	sort.Slice(result.Milestones, func(i, j int) bool {
+		return result.Milestones[i].MilestoneID < result.Milestones[j].MilestoneID
+	})
+
+	var payouts []struct {
+		Status models.CreatorPayoutStatus
+		Total  int64
+	}
+	if err := tx.Model(&models.CreatorPayout{}).
+		Select("status, COALESCE(SUM(amount), 0) AS total").
+		Where("user_id = ?", userID).
+		Group("status").
+		Scan(&payouts).Error; err != nil {
+		return nil, fmt.Errorf("지급 내역 집계 실패: %w", err)
+	}
+	for _, row := range payouts {
+		switch {
+		case row.Status == models.CreatorPayoutPaid:
+			result.PaidOut += row.Total
+		case row.Status.IsOpen():
+			result.Pending += row.Total
+		}
+	}
+
+	var wallet models.UserWallet
+	if err := tx.Select("usdc_balance").Where("user_id = ?", userID).First(&wallet).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("지갑 조회 실패: %w", err)
+	}
+	result.WalletBalance = wallet.USDCBalance
+
+	result.Payable = result.TotalEarned - result.PaidOut - result.Pending
+	if result.Payable > result.WalletBalance {
+		result.Payable = result.WalletBalance
+	}
+	if result.Payable < 0 {
+		result.Payable = 0
+	}
+	return result, nil
+}
+
+// RequestPayout 지급 요청 (지급 가능 금액 안에서 지갑 잠금, 관리자 승인 대기)
+func (s *CreatorPayoutService) RequestPayout(userID uint, req models.CreateCreatorPayoutRequest) (*models.CreatorPayout, error) {
+	var payout *models.CreatorPayout
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var account models.PayoutAccount
+		query := tx.Where("user_id = ?", userID)
+		if req.PayoutAccountID != 0 {
+			query = query.Where("id = ?", req.PayoutAccountID)
+		} else {
+			query = query.Where("is_default = ?", true)
+		}
+		if err := query.First(&account).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrPayoutAccountRequired
+			}
+			return fmt.Errorf("지급 계좌 조회 실패: %w", err)
+		}
+
+		// 같은 사용자의 동시 요청이 같은 수익을 두 번 잡지 않도록 지갑 행을 먼저 잠금
+		var wallet models.UserWallet
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", userID).First(&wallet).Error; err != nil {
+			return fmt.Errorf("지갑 조회 실패: %w", err)
+		}
+
+		earnings, err := s.earnings(tx, userID)
+		if err != nil {
+			return err
+		}
+		if req.Amount > earnings.Payable {
+			return fmt.Errorf("%w: 지급 가능 $%.2f", ErrPayoutExceedsEarnings, float64(earnings.Payable)/100)
+		}
+
+		payout = &models.CreatorPayout{
+			UserID:          userID,
+			PayoutAccountID: account.ID,
+			Amount:          req.Amount,
+			Status:          models.CreatorPayoutRequested,
+		}
+		if err := tx.Create(payout).Error; err != nil {
+			return fmt.Errorf("지급 요청 저장 실패: %w", err)
+		}
+		payout.PayoutAccount = &account
+
+		return postLedgerEntry(tx, payoutLedgerEntry(payout, models.WalletLedgerEntry{
+			UserID:       userID,
+			EntryType:    models.LedgerPayoutHold,
+			Amount:       -req.Amount,
+			LockedAmount: req.Amount,
+			Memo:         fmt.Sprintf("창작자 지급 요청 (%s)", account.MaskedDetails),
+		}))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("💸 User %d requested payout %d: $%.2f", userID, payout.ID, float64(payout.Amount)/100)
+	return payout, nil
+}
+
+// ApprovePayout 지급 승인 (예정 시각이 없으면 즉시 송금 대상)
+func (s *CreatorPayoutService) ApprovePayout(reviewerID, payoutID uint, scheduledFor *time.Time) (*models.CreatorPayout, error) {
+	now := time.Now()
+	if scheduledFor == nil || scheduledFor.Before(now) {
+		scheduledFor = &now
+	}
+
+	if _, err := s.review(payoutID, []models.CreatorPayoutStatus{models.CreatorPayoutRequested}, map[string]interface{}{
+		"status":        models.CreatorPayoutApproved,
+		"reviewed_by":   reviewerID,
+		"reviewed_at":   now,
+		"scheduled_for": *scheduledFor,
+	}, nil); err != nil {
+		return nil, err
+	}
+
+	log.Printf("💸 Payout %d approved by %d, scheduled for %v", payoutID, reviewerID, scheduledFor.Format(time.RFC3339))
+	return s.getPayout(payoutID)
+}
+
+// RejectPayout 지급 반려 (승인 후 송금 전까지 가능), 잠금 해제
+func (s *CreatorPayoutService) RejectPayout(reviewerID, payoutID uint, reason string) (*models.CreatorPayout, error) {
+	if _, err := s.review(payoutID, []models.CreatorPayoutStatus{models.CreatorPayoutRequested, models.CreatorPayoutApproved}, map[string]interface{}{
+		"status":        models.CreatorPayoutRejected,
+		"reviewed_by":   reviewerID,
+		"reviewed_at":   time.Now(),
+		"reject_reason": reason,
+	}, func(tx *gorm.DB, payout *models.CreatorPayout) error {
+		return postLedgerEntry(tx, payoutLedgerEntry(payout, models.WalletLedgerEntry{
+			UserID:       payout.UserID,
+			EntryType:    models.LedgerPayoutRelease,
+			Amount:       payout.Amount,
+			LockedAmount: -payout.Amount,
+			Memo:         "창작자 지급 반려: " + reason,
+		}))
+	}); err != nil {
+		return nil, err
+	}
+
+	log.Printf("💸 Payout %d rejected by %d: %s", payoutID, reviewerID, reason)
+	return s.getPayout(payoutID)
+}
+
+// review 상태를 선점해 승인/반려 (워커가 송금을 시작한 요청은 바꾸지 않음)
+func (s *CreatorPayoutService) review(payoutID uint, from []models.CreatorPayoutStatus, updates map[string]interface{}, settle func(tx *gorm.DB, payout *models.CreatorPayout) error) (*models.CreatorPayout, error) {
+	var payout models.CreatorPayout
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&payout, payoutID).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&models.CreatorPayout{}).
+			Where("id = ? AND status IN ?", payoutID, from).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: %s", ErrPayoutNotReviewable, payout.Status)
+		}
+		if settle != nil {
+			return settle(tx, &payout)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &payout, nil
+}
+
+// GetMyPayouts 내 지급 요청 (최신순)
+func (s *CreatorPayoutService) GetMyPayouts(userID uint, limit, offset int) ([]models.CreatorPayout, error) {
+	var payouts []models.CreatorPayout
+	if err := s.db.Preload("PayoutAccount").
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&payouts).Error; err != nil {
+		return nil, fmt.Errorf("지급 내역 조회 실패: %w", err)
+	}
+	return payouts, nil
+}
+
+// ListPayouts 관리자 지급 요청 목록 (상태 미지정 시 전체, 오래된 순)
+func (s *CreatorPayoutService) ListPayouts(status models.CreatorPayoutStatus, limit, offset int) ([]models.CreatorPayout, error) {
+	query := s.db.Preload("PayoutAccount")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var payouts []models.CreatorPayout
+	if err := query.Order("created_at ASC, id ASC").
+		Limit(limit).Offset(offset).
+		Find(&payouts).Error; err != nil {
+		return nil, fmt.Errorf("지급 요청 조회 실패: %w", err)
+	}
+	return payouts, nil
+}
+
+func (s *CreatorPayoutService) getPayout(payoutID uint) (*models.CreatorPayout, error) {
+	var payout models.CreatorPayout
+	if err := s.db.Preload("PayoutAccount").First(&payout, payoutID).Error; err != nil {
+		return nil, err
+	}
+	return &payout, nil
+}
+
+// CreditMentorPoolShare 완료된 마일스톤에서 멘토에게 분배되지 않은 멘토 풀을 프로젝트 소유자에게 지급
+//
+// 자격 있는 멘토가 없어 풀이 그대로 남은 경우다. 멘토 보상 분배 훅 다음에 실행되며, 풀마다 한 번만 지급한다.
+func (s *CreatorPayoutService) CreditMentorPoolShare(milestoneID uint) (int64, error) {
+	var pool models.MentorPool
+	if err := s.db.Where("milestone_id = ?", milestoneID).First(&pool).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("멘토 풀 조회 실패: %w", err)
+	}
+	share := pool.TotalPoolAmount - pool.DistributedAmount
+	if !pool.IsDistributed || pool.CreatorShareAmount > 0 || share <= 0 {
+		return 0, nil
+	}
+
+	var ownerID uint
+	if err := s.db.Model(&models.Project{}).Where("id = ?", pool.ProjectID).Pluck("user_id", &ownerID).Error; err != nil {
+		return 0, fmt.Errorf("프로젝트 소유자 조회 실패: %w", err)
+	}
+	if ownerID == 0 {
+		return 0, fmt.Errorf("프로젝트 %d의 소유자를 찾을 수 없습니다", pool.ProjectID)
+	}
+
+	credited := false
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.MentorPool{}).
+			Where("id = ? AND creator_share_amount = 0", pool.ID).
+			Update("creator_share_amount", share)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		credited = true
+		return postLedgerEntry(tx, &models.WalletLedgerEntry{
+			UserID:        ownerID,
+			Currency:      models.LedgerCurrencyUSDC,
+			EntryType:     models.LedgerCreatorPoolShare,
+			Amount:        share,
+			ReferenceType: "mentor_pool",
+			ReferenceID:   pool.ID,
+			Memo:          fmt.Sprintf("마일스톤 %d 멘토 풀 미분배분 수령", milestoneID),
+		})
+	})
+	if err != nil || !credited {
+		return 0, err
+	}
+
+	log.Printf("💸 Credited $%.2f undistributed mentor pool of milestone %d to creator %d", float64(share)/100, milestoneID, ownerID)
+	return share, nil
+}
+
+// payoutLedgerEntry 지급 요청을 참조하는 USDC 원장 항목
+func payoutLedgerEntry(payout *models.CreatorPayout, entry models.WalletLedgerEntry) *models.WalletLedgerEntry {
+	entry.Currency = models.LedgerCurrencyUSDC
+	entry.ReferenceType = "creator_payout"
+	entry.ReferenceID = payout.ID
+	return &entry
+}
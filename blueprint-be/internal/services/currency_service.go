@@ -0,0 +1,375 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrUnsupportedCurrency 설정에 없는 결제 통화 (blueprint는 환전/마켓 결제 불가)
+	ErrUnsupportedCurrency = errors.New("지원하지 않는 결제 통화입니다")
+	// ErrExchangeRateNotFound 관리자가 아직 환율을 지정하지 않은 통화
+	ErrExchangeRateNotFound = errors.New("환율이 지정되지 않은 통화입니다")
+	// ErrSameCurrency 같은 통화끼리 환전
+	ErrSameCurrency = errors.New("같은 통화로는 환전할 수 없습니다")
+	// ErrQuoteCurrencyLocked 주문이 들어온 마켓의 결제 통화 변경
+	ErrQuoteCurrencyLocked = errors.New("주문이 들어온 마켓은 결제 통화를 바꿀 수 없습니다")
+	// ErrDailyConvertLimit 오늘 환전 합계(USDC 환산)가 일일 한도 초과
+	ErrDailyConvertLimit = errors.New("오늘 환전 한도를 초과했습니다")
+)
+
+// 환전 내역 조회 기본/최대 건수
+const (
+	walletHistoryDefaultLimit = 50
+	walletHistoryMaxLimit     = 200
+)
+
+// CurrencyService 결제 통화 목록, 통화별 지갑 계정, 환율과 환전
+//
+// USDC는 기준 통화(환율 1)이고, WALLET_CURRENCIES로 추가한 통화와 BLUEPRINT는 관리자가 지정한 환율(1 통화 = rate USDC)로 환산한다.
+// 마켓은 USDC 또는 추가 통화 중 하나를 결제 통화로 쓰며, 주문 잠금/체결/정산이 모두 그 통화 계정에서 일어난다.
+// 멘토 풀 수수료, 추천 보상, 펀딩 TVL처럼 USDC로 쌓는 값은 체결 시점 환율로 환산한다.
+//
+// 환전은 결제 통화끼리, 그리고 BLUEPRINT(스테이킹)와 USDC(거래) 사이에서 할 수 있다. 보내는 금액의 USDC 환산액을
+// 사용자별 UTC 하루 한도로 묶고, 두 통화 원장 항목은 환전 기록(CurrencySwap)을 참조한다.
+type CurrencyService struct {
+	db                *gorm.DB
+	currencies        []models.LedgerCurrency // 추가 결제 통화 (usdc 제외)
+	dailyConvertLimit int64                   // 사용자별 하루 환전 한도 (USDC 센트, 0이면 검사 안 함)
+}
+
+// NewCurrencyService 생성자 (currencies: 설정의 추가 결제 통화 코드, dailyConvertLimit: 하루 환전 한도 센트)
+func NewCurrencyService(db *gorm.DB, currencies []string, dailyConvertLimit int64) *CurrencyService {
+	s := &CurrencyService{db: db, dailyConvertLimit: dailyConvertLimit}
+	for _, code := range currencies {
+		s.currencies = append(s.currencies, models.LedgerCurrency(strings.ToLower(code)))
+	}
+	return s
+}
+
+// QuoteCurrencies 마켓 결제 통화로 쓸 수 있는 통화 (usdc + 추가 통화)
+func (s *CurrencyService) QuoteCurrencies() []models.LedgerCurrency {
+	return append([]models.LedgerCurrency{models.LedgerCurrencyUSDC}, s.currencies...)
+}
+
+// IsQuoteCurrency 마켓 결제 통화로 쓸 수 있는지
+func (s *CurrencyService) IsQuoteCurrency(currency models.LedgerCurrency) bool {
+	for _, quote := range s.QuoteCurrencies() {
+		if quote == currency {
+			return true
+		}
+	}
+	return false
+}
+
+// ListAccounts 통화별 잔액 (USDC/BLUEPRINT는 지갑 컬럼, 추가 통화는 계정이 없으면 0)
+func (s *CurrencyService) ListAccounts(userID uint) ([]models.WalletBalance, error) {
+	var wallet models.UserWallet
+	if err := s.db.Where("user_id = ?", userID).First(&wallet).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, err
+	}
+
+	var accounts []models.WalletAccount
+	if err := s.db.Where("user_id = ?", userID).Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+	byCurrency := make(map[models.LedgerCurrency]models.WalletAccount, len(accounts))
+	for _, account := range accounts {
+		byCurrency[account.Currency] = account
+	}
+
+	balances := []models.WalletBalance{
+		{Currency: models.LedgerCurrencyUSDC, Available: wallet.USDCBalance, Locked: wallet.USDCLockedBalance, Tradable: true},
+		{Currency: models.LedgerCurrencyBlueprint, Available: wallet.BlueprintBalance, Locked: wallet.BlueprintLockedBalance},
+	}
+	for _, currency := range s.currencies {
+		account := byCurrency[currency]
+		balances = append(balances, models.WalletBalance{
+			Currency: currency, Available: account.Available, Locked: account.Locked, Tradable: true,
+		})
+	}
+	return balances, nil
+}
+
+// ListRates 추가 통화와 BLUEPRINT 환율 (지정된 것만)
+func (s *CurrencyService) ListRates() ([]models.ExchangeRate, error) {
+	rates := []models.ExchangeRate{}
+	currencies := append([]models.LedgerCurrency{models.LedgerCurrencyBlueprint}, s.currencies...)
+	err := s.db.Where("currency IN ?", currencies).Order("currency").Find(&rates).Error
+	return rates, err
+}
+
+// SetRate 추가 통화/BLUEPRINT 환율 지정 (1 통화 = rate USDC)
+func (s *CurrencyService) SetRate(currency models.LedgerCurrency, rate float64, adminID uint) (*models.ExchangeRate, error) {
+	if currency == models.LedgerCurrencyUSDC || !s.isConvertible(currency) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+	}
+	if rate <= 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+		return nil, fmt.Errorf("환율은 0보다 커야 합니다")
+	}
+
+	exchangeRate := models.ExchangeRate{Currency: currency, Rate: rate, UpdatedBy: adminID}
+	if err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "currency"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_by", "updated_at"}),
+	}).Create(&exchangeRate).Error; err != nil {
+		return nil, fmt.Errorf("환율 저장 실패: %w", err)
+	}
+	log.Printf("💱 Exchange rate for %s set to %.6f USDC by admin %d", currency, rate, adminID)
+	return &exchangeRate, nil
+}
+
+// Quote 환전 견적 (받는 금액은 내림, BLUEPRINT는 USDC와만)
+func (s *CurrencyService) Quote(from, to models.LedgerCurrency, amount int64) (*models.CurrencyConversion, error) {
+	if from == to {
+		return nil, ErrSameCurrency
+	}
+	for _, currency := range []models.LedgerCurrency{from, to} {
+		if !s.isConvertible(currency) {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+		}
+	}
+	if (from == models.LedgerCurrencyBlueprint || to == models.LedgerCurrencyBlueprint) &&
+		from != models.LedgerCurrencyUSDC && to != models.LedgerCurrencyUSDC {
+		return nil, fmt.Errorf("%w: BLUEPRINT는 USDC와만 환전할 수 있습니다", ErrUnsupportedCurrency)
+	}
+	fromRate, err := s.usdcRate(from)
+	if err != nil {
+		return nil, err
+	}
+	toRate, err := s.usdcRate(to)
+	if err != nil {
+		return nil, err
+	}
+
+	// 잔액 단위 기준 환율 (BLUEPRINT는 토큰 1개, 나머지는 1/100 단위)
+	rate := fromRate / toRate * float64(currencyScale(to)) / float64(currencyScale(from))
+	return &models.CurrencyConversion{
+		From:      from,
+		To:        to,
+		Amount:    amount,
+		Converted: int64(math.Floor(float64(amount) * rate)),
+		Rate:      rate,
+		USDCValue: int64(math.Floor(float64(amount) * fromRate * 100 / float64(currencyScale(from)))),
+	}, nil
+}
+
+// Convert 사용 가능 잔액을 다른 통화로 환전 (일일 한도 확인, 환전 기록과 원장 2건을 같은 트랜잭션에서)
+func (s *CurrencyService) Convert(userID uint, req models.ConvertCurrencyRequest) (*models.CurrencyConversion, error) {
+	conversion, err := s.Quote(req.From, req.To, req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if conversion.Converted <= 0 {
+		return nil, fmt.Errorf("%w: 환전 금액이 너무 적습니다", ErrInsufficientBalance)
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// 같은 사용자의 동시 환전이 한도를 함께 넘지 않도록 지갑 행을 먼저 잠금
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", userID).First(&models.UserWallet{}).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrWalletNotFound
+			}
+			return err
+		}
+		if s.dailyConvertLimit > 0 {
+			used, err := s.convertedToday(tx, userID)
+			if err != nil {
+				return err
+			}
+			if used+conversion.USDCValue > s.dailyConvertLimit {
+				return fmt.Errorf("%w: 한도 $%.2f, 오늘 사용 $%.2f", ErrDailyConvertLimit,
+					float64(s.dailyConvertLimit)/100, float64(used)/100)
+			}
+			conversion.DailyRemaining = s.dailyConvertLimit - used - conversion.USDCValue
+		}
+
+		// 보내는 통화 잔액이 충분할 때만 차감 (잠금 없이 바로 빠져나감)
+		scope, balanceColumn, _ := walletBalance(tx, userID, req.From)
+		result := scope.Where(balanceColumn+" >= ?", req.Amount).
+			UpdateColumn(balanceColumn, gorm.Expr(balanceColumn+" - ?", req.Amount))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: 필요 %s", ErrInsufficientBalance, formatCurrencyAmount(req.From, req.Amount))
+		}
+
+		swap := models.CurrencySwap{
+			UserID:    userID,
+			From:      req.From,
+			To:        req.To,
+			Amount:    req.Amount,
+			Converted: conversion.Converted,
+			Rate:      conversion.Rate,
+			USDCValue: conversion.USDCValue,
+		}
+		if err := tx.Create(&swap).Error; err != nil {
+			return fmt.Errorf("환전 기록 실패: %w", err)
+		}
+		conversion.SwapID = swap.ID
+
+		memo := fmt.Sprintf("%s → %s 환전 (환율 %.6f)", req.From, req.To, conversion.Rate)
+		if err := tx.Create(swapLedgerEntry(&swap, models.WalletLedgerEntry{
+			Currency:  req.From,
+			EntryType: models.LedgerCurrencyConvertOut,
+			Amount:    -req.Amount,
+			Memo:      memo,
+		})).Error; err != nil {
+			return fmt.Errorf("원장 기록 실패: %w", err)
+		}
+		return postLedgerEntry(tx, swapLedgerEntry(&swap, models.WalletLedgerEntry{
+			Currency:  req.To,
+			EntryType: models.LedgerCurrencyConvertIn,
+			Amount:    conversion.Converted,
+			Memo:      memo,
+		}))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("💱 User %d converted %s to %s", userID, formatCurrencyAmount(req.From, req.Amount), formatCurrencyAmount(req.To, conversion.Converted))
+	return conversion, nil
+}
+
+// History 지갑 원장 내역 (최신순, before ID 이전 페이지)
+func (s *CurrencyService) History(userID uint, query models.WalletHistoryQuery) ([]models.WalletLedgerEntry, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = walletHistoryDefaultLimit
+	}
+	if limit > walletHistoryMaxLimit {
+		limit = walletHistoryMaxLimit
+	}
+
+	db := s.db.Where("user_id = ?", userID)
+	if query.Currency != "" {
+		db = db.Where("currency = ?", query.Currency)
+	}
+	if query.EntryType != "" {
+		db = db.Where("entry_type = ?", query.EntryType)
+	}
+	if query.Before > 0 {
+		db = db.Where("id < ?", query.Before)
+	}
+
+	entries := []models.WalletLedgerEntry{}
+	if err := db.Order("id DESC").Limit(limit).Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("지갑 내역 조회 실패: %w", err)
+	}
+	return entries, nil
+}
+
+// convertedToday 오늘(UTC) 환전한 금액의 USDC 환산 합계
+func (s *CurrencyService) convertedToday(tx *gorm.DB, userID uint) (int64, error) {
+	var used int64
+	err := tx.Model(&models.CurrencySwap{}).
+		Where("user_id = ? AND created_at >= ?", userID, time.Now().UTC().Truncate(24*time.Hour)).
+		Select("COALESCE(SUM(usdc_value), 0)").
+		Scan(&used).Error
+	return used, err
+}
+
+// swapLedgerEntry 환전 기록을 참조하는 원장 항목
+func swapLedgerEntry(swap *models.CurrencySwap, entry models.WalletLedgerEntry) *models.WalletLedgerEntry {
+	entry.UserID = swap.UserID
+	entry.ReferenceType = "currency_swap"
+	entry.ReferenceID = swap.ID
+	return &entry
+}
+
+// SetMarketQuoteCurrency 마켓 결제 통화 지정 (주문이 한 건이라도 들어오면 변경 불가)
+func (s *CurrencyService) SetMarketQuoteCurrency(milestoneID uint, currency models.LedgerCurrency) (*models.Milestone, error) {
+	if !s.IsQuoteCurrency(currency) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+	}
+
+	var milestone models.Milestone
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&milestone, milestoneID).Error; err != nil {
+			return err
+		}
+		var orders int64
+		if err := tx.Model(&models.Order{}).Where("milestone_id = ?", milestoneID).Count(&orders).Error; err != nil {
+			return err
+		}
+		if orders > 0 {
+			return fmt.Errorf("%w: 주문 %d건", ErrQuoteCurrencyLocked, orders)
+		}
+		milestone.QuoteCurrency = currency
+		return tx.Model(&models.Milestone{}).Where("id = ?", milestoneID).UpdateColumn("quote_currency", currency).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	BumpMarketSequence(milestoneID)
+	return &milestone, nil
+}
+
+// ToUSDC 결제 통화 금액의 USDC 환산액 (USDC로 쌓는 수수료/TVL용, 환율이 없으면 0)
+func (s *CurrencyService) ToUSDC(currency models.LedgerCurrency, amount int64) int64 {
+	currency = currency.OrUSDC()
+	if currency == models.LedgerCurrencyUSDC || amount == 0 {
+		return amount
+	}
+	rate, err := s.usdcRate(currency)
+	if err != nil {
+		log.Printf("⚠️ Cannot convert %d %s to USDC: %v", amount, currency, err)
+		return 0
+	}
+	return int64(math.Floor(float64(amount) * rate))
+}
+
+// usdcRate 1 통화의 USDC 가치
+func (s *CurrencyService) usdcRate(currency models.LedgerCurrency) (float64, error) {
+	if currency == models.LedgerCurrencyUSDC {
+		return 1, nil
+	}
+
+	var rate models.ExchangeRate
+	if err := s.db.Where("currency = ?", currency).First(&rate).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, fmt.Errorf("%w: %s", ErrExchangeRateNotFound, currency)
+		}
+		return 0, err
+	}
+	return rate.Rate, nil
+}
+
+// isConvertible 환전/환율 지정 대상 (결제 통화와 BLUEPRINT)
+func (s *CurrencyService) isConvertible(currency models.LedgerCurrency) bool {
+	return currency == models.LedgerCurrencyBlueprint || s.IsQuoteCurrency(currency)
+}
+
+// currencyScale 통화 1개당 잔액 단위 수 (BLUEPRINT는 토큰 단위, 나머지는 1/100 단위)
+func currencyScale(currency models.LedgerCurrency) int64 {
+	if currency == models.LedgerCurrencyBlueprint {
+		return 1
+	}
+	return 100
+}
+
+// formatCurrencyAmount 잔액 단위 금액 표시 (예: $12.34, 12.34 KRWS, 50 BLUEPRINT)
+func formatCurrencyAmount(currency models.LedgerCurrency, amount int64) string {
+	switch currency {
+	case models.LedgerCurrencyUSDC:
+		return fmt.Sprintf("$%.2f", float64(amount)/100)
+	case models.LedgerCurrencyBlueprint:
+		return fmt.Sprintf("%d BLUEPRINT", amount)
+	default:
+		return fmt.Sprintf("%.2f %s", float64(amount)/100, strings.ToUpper(string(currency)))
+	}
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"blueprint-module/pkg/queue"
+)
+
+// 데드레터 조회 기본/최대 개수
+const (
+	DefaultDeadLetterLimit = 50
+	MaxDeadLetterLimit     = 500
+)
+
+// ErrInvalidDeadLetterQueue DLQ 스트림 이름(":dlq")을 그대로 넘긴 경우
+var ErrInvalidDeadLetterQueue = errors.New("원래 큐 이름을 지정해야 합니다")
+
+// DeadLetterService 재시도를 모두 소진한 작업/이벤트(<queue>:dlq) 조회, 재처리, 삭제 및 크기 경보
+type DeadLetterService struct {
+	alertThreshold int64 // 큐별 DLQ 크기가 이 값 이상이면 경보
+}
+
+// NewDeadLetterService 생성자
+func NewDeadLetterService(alertThreshold int) *DeadLetterService {
+	return &DeadLetterService{alertThreshold: int64(alertThreshold)}
+}
+
+// DeadLetterReplayResult 선택 재처리 결과
+type DeadLetterReplayResult struct {
+	Replayed []string          `json:"replayed"`
+	Failed   map[string]string `json:"failed,omitempty"` // 메시지 ID별 실패 사유
+}
+
+// ListQueues DLQ가 쌓인 큐와 크기
+func (s *DeadLetterService) ListQueues() ([]queue.DeadLetterQueueInfo, error) {
+	return queue.DeadLetterQueues()
+}
+
+// List 큐의 DLQ 항목 (최신순, before 커서로 다음 페이지)
+func (s *DeadLetterService) List(queueName string, limit int, before string) ([]queue.DeadLetter, error) {
+	if err := validateQueueName(queueName); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = DefaultDeadLetterLimit
+	}
+	if limit > MaxDeadLetterLimit {
+		limit = MaxDeadLetterLimit
+	}
+	return queue.ListDeadLetters(queueName, int64(limit), before)
+}
+
+// Get DLQ 항목 1건 (원본 payload 포함)
+func (s *DeadLetterService) Get(queueName, id string) (*queue.DeadLetter, error) {
+	if err := validateQueueName(queueName); err != nil {
+		return nil, err
+	}
+	return queue.GetDeadLetter(queueName, id)
+}
+
+// Replay 선택한 항목을 원래 큐로 다시 발행 (실패한 항목은 DLQ에 남음)
+func (s *DeadLetterService) Replay(queueName string, ids []string) (*DeadLetterReplayResult, error) {
+	if err := validateQueueName(queueName); err != nil {
+		return nil, err
+	}
+
+	result := &DeadLetterReplayResult{Replayed: []string{}}
+	for _, id := range ids {
+		if err := queue.ReplayDeadLetter(queueName, id); err != nil {
+			if result.Failed == nil {
+				result.Failed = map[string]string{}
+			}
+			result.Failed[id] = err.Error()
+			continue
+		}
+		result.Replayed = append(result.Replayed, id)
+	}
+	return result, nil
+}
+
+// Purge 선택한 항목 삭제 (ids가 비면 큐의 DLQ 전체)
+func (s *DeadLetterService) Purge(queueName string, ids []string) (int64, error) {
+	if err := validateQueueName(queueName); err != nil {
+		return 0, err
+	}
+	return queue.PurgeDeadLetters(queueName, ids)
+}
+
+// WriteMetrics 큐별 DLQ 크기와 경보 여부를 Prometheus 텍스트 형식으로 기록
+func (s *DeadLetterService) WriteMetrics(w io.Writer) error {
+	infos, err := queue.DeadLetterQueues()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "# HELP blueprint_dlq_messages Messages waiting in the dead-letter queue.")
+	fmt.Fprintln(w, "# TYPE blueprint_dlq_messages gauge")
+	for _, info := range infos {
+		fmt.Fprintf(w, "blueprint_dlq_messages{queue=%s} %d\n", strconv.Quote(info.Queue), info.Size)
+	}
+	fmt.Fprintln(w, "# HELP blueprint_dlq_alert_threshold Dead-letter queue size that raises an alert.")
+	fmt.Fprintln(w, "# TYPE blueprint_dlq_alert_threshold gauge")
+	fmt.Fprintf(w, "blueprint_dlq_alert_threshold %d\n", s.alertThreshold)
+	fmt.Fprintln(w, "# HELP blueprint_dlq_alert 1 when the dead-letter queue reached the alert threshold.")
+	fmt.Fprintln(w, "# TYPE blueprint_dlq_alert gauge")
+	for _, info := range infos {
+		alert := 0
+		if info.Size >= s.alertThreshold {
+			alert = 1
+		}
+		fmt.Fprintf(w, "blueprint_dlq_alert{queue=%s} %d\n", strconv.Quote(info.Queue), alert)
+	}
+	return nil
+}
+
+func validateQueueName(queueName string) error {
+	if queueName == "" || strings.HasSuffix(queueName, ":dlq") {
+		return fmt.Errorf("%w (%q)", ErrInvalidDeadLetterQueue, queueName)
+	}
+	return nil
+}
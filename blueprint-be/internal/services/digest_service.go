@@ -0,0 +1,443 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/queue"
+
+	"gorm.io/gorm"
+)
+
+const (
+	digestBatchSize      = 500 // 한 번의 실행에서 보낼 최대 요약 수 (나머지는 다음 주기에)
+	digestSectionLimit   = 10  // 섹션별 최대 항목 수
+	digestPriceMoveTicks = 500 // 요약에 넣을 최소 가격 변화 (5%p)
+
+	// digestSlack 작업 주기 때문에 발송 시각이 조금씩 밀리지 않도록 주기보다 이만큼 일찍 대상으로 본다
+	digestSlack = time.Hour
+)
+
+// ErrInvalidUnsubscribeToken 서명이 맞지 않는 수신 거부 토큰
+var ErrInvalidUnsubscribeToken = errors.New("유효하지 않은 수신 거부 링크입니다")
+
+// DigestService 사용자별 알림 요약 메일 (일간/주간)
+//
+// notification_digest 주기 작업이 주기가 돌아온 사용자의 요약(포지션 가격 변동, 마감 임박 마켓, 검증 대기 증거,
+// 분쟁 기한)을 만들어 email_queue로 보낸다. 빈 요약은 보내지 않고 기간만 넘긴다.
+// 메일의 수신 거부 링크는 사용자 ID에 서명한 토큰이라 로그인 없이 한 번에 끌 수 있다.
+type DigestService struct {
+	db            *gorm.DB
+	signingSecret string
+}
+
+// NewDigestService 생성자
+func NewDigestService(db *gorm.DB, signingSecret string) *DigestService {
+	return &DigestService{db: db, signingSecret: signingSecret}
+}
+
+// SendDue 주기가 돌아온 사용자에게 요약 메일 발송 (보낸 메일 수 반환)
+func (s *DigestService) SendDue(now time.Time) (int, error) {
+	var profiles []models.UserProfile
+	if err := s.db.Preload("User").
+		Where("email_notifications = ?", true).
+		Where("(digest_frequency = ? AND (digest_sent_at IS NULL OR digest_sent_at <= ?)) OR (digest_frequency = ? AND (digest_sent_at IS NULL OR digest_sent_at <= ?))",
+			models.DigestDaily, now.Add(digestSlack-models.DigestDaily.Period()),
+			models.DigestWeekly, now.Add(digestSlack-models.DigestWeekly.Period())).
+		Order("user_id").
+		Limit(digestBatchSize).
+		Find(&profiles).Error; err != nil {
+		return 0, fmt.Errorf("요약 대상 사용자 조회 실패: %w", err)
+	}
+	if len(profiles) == 0 {
+		return 0, nil
+	}
+
+	cases, err := s.openArbitrationCases()
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for i := range profiles {
+		profile := &profiles[i]
+		since := now.Add(-profile.DigestFrequency.Period())
+		if profile.DigestSentAt != nil && profile.DigestSentAt.After(since) {
+			since = *profile.DigestSentAt
+		}
+
+		digest, err := s.build(profile.UserID, profile.DigestFrequency, since, now, cases)
+		if err != nil {
+			log.Printf("⚠️ Failed to build digest for user %d: %v", profile.UserID, err)
+			continue
+		}
+		if !digest.IsEmpty() && profile.User.Email != "" {
+			if err := s.publish(profile, digest); err != nil {
+				log.Printf("⚠️ Failed to queue digest for user %d: %v", profile.UserID, err)
+				continue
+			}
+			sent++
+		}
+
+		if err := s.db.Model(&models.UserProfile{}).Where("id = ?", profile.ID).
+			UpdateColumn("digest_sent_at", now).Error; err != nil {
+			log.Printf("⚠️ Failed to record digest for user %d: %v", profile.UserID, err)
+		}
+	}
+	return sent, nil
+}
+
+// Build 사용자 한 명의 since~now 요약 (마감/기한 섹션은 now 이후 한 주기 안의 항목)
+func (s *DigestService) Build(userID uint, frequency models.DigestFrequency, since, now time.Time) (*models.NotificationDigest, error) {
+	cases, err := s.openArbitrationCases()
+	if err != nil {
+		return nil, err
+	}
+	return s.build(userID, frequency, since, now, cases)
+}
+
+func (s *DigestService) build(userID uint, frequency models.DigestFrequency, since, now time.Time, cases []models.ArbitrationCase) (*models.NotificationDigest, error) {
+	digest := &models.NotificationDigest{
+		UserID:      userID,
+		Frequency:   frequency,
+		PeriodStart: since,
+		PeriodEnd:   now,
+	}
+	horizon := now.Add(frequency.Period())
+
+	var err error
+	if digest.Positions, err = s.movedPositions(userID, since, now); err != nil {
+		return nil, err
+	}
+	if digest.Closing, err = s.closingMarkets(userID, now, horizon); err != nil {
+		return nil, err
+	}
+	if digest.Proofs, err = s.pendingProofs(userID, now); err != nil {
+		return nil, err
+	}
+	if digest.Arbitration, err = s.arbitrationDeadlines(userID, now, horizon, cases); err != nil {
+		return nil, err
+	}
+	return digest, nil
+}
+
+// movedPositions 보유 포지션 중 기간 동안 체결가가 digestPriceMoveTicks 이상 움직인 것 (변화 큰 순)
+func (s *DigestService) movedPositions(userID uint, since, now time.Time) ([]models.DigestPosition, error) {
+	var positions []models.Position
+	if err := s.db.Preload("Milestone").
+		Where("user_id = ? AND quantity <> 0", userID).
+		Find(&positions).Error; err != nil {
+		return nil, fmt.Errorf("포지션 조회 실패: %w", err)
+	}
+
+	moved := make([]models.DigestPosition, 0)
+	for _, position := range positions {
+		from, err := s.lastTradeTicks(position.MilestoneID, position.OptionID, since)
+		if err != nil {
+			return nil, err
+		}
+		to, err := s.lastTradeTicks(position.MilestoneID, position.OptionID, now)
+		if err != nil {
+			return nil, err
+		}
+		if from == 0 || to == 0 || abs64(to-from) < digestPriceMoveTicks {
+			continue
+		}
+		moved = append(moved, models.DigestPosition{
+			MilestoneID:    position.MilestoneID,
+			MilestoneTitle: position.Milestone.Title,
+			OptionID:       position.OptionID,
+			Quantity:       position.Quantity,
+			FromPrice:      models.TicksToPrice(from),
+			ToPrice:        models.TicksToPrice(to),
+			ChangePercent:  math.Round(float64(to-from)*1000/float64(models.PriceScale)) / 10,
+		})
+	}
+
+	sort.SliceStable(moved, func(i, j int) bool {
+		return math.Abs(moved[i].ChangePercent) > math.Abs(moved[j].ChangePercent)
+	})
+	if len(moved) > digestSectionLimit {
+		moved = moved[:digestSectionLimit]
+	}
+	return moved, nil
+}
+
+// lastTradeTicks at 시점까지의 마지막 체결가 (체결이 없으면 0)
+func (s *DigestService) lastTradeTicks(milestoneID uint, optionID string, at time.Time) (int64, error) {
+	var ticks []int64
+	if err := s.db.Model(&models.Trade{}).
+		Where("milestone_id = ? AND option_id = ? AND created_at <= ?", milestoneID, optionID, at).
+		Order("created_at DESC, id DESC").
+		Limit(1).
+		Pluck("price_ticks", &ticks).Error; err != nil {
+		return 0, fmt.Errorf("체결가 조회 실패: %w", err)
+	}
+	if len(ticks) == 0 {
+		return 0, nil
+	}
+	return ticks[0], nil
+}
+
+// closingMarkets 보유 포지션이 있거나 관심 프로젝트의 마켓 중 horizon 전에 거래가 마감되는 것
+func (s *DigestService) closingMarkets(userID uint, now, horizon time.Time) ([]models.DigestMarket, error) {
+	held := s.db.Model(&models.Position{}).Select("milestone_id").Where("user_id = ? AND quantity <> 0", userID)
+	watched := s.db.Model(&models.ProjectWatch{}).Select("project_id").Where("user_id = ?", userID)
+
+	var milestones []models.Milestone
+	if err := s.db.
+		Where("trading_closed_at IS NULL AND COALESCE(trading_closes_at, target_date) > ? AND COALESCE(trading_closes_at, target_date) <= ?", now, horizon).
+		Where("id IN (?) OR project_id IN (?)", held, watched).
+		Order("COALESCE(trading_closes_at, target_date) ASC").
+		Limit(digestSectionLimit).
+		Find(&milestones).Error; err != nil {
+		return nil, fmt.Errorf("마감 임박 마켓 조회 실패: %w", err)
+	}
+
+	closing := make([]models.DigestMarket, 0, len(milestones))
+	for _, milestone := range milestones {
+		if !milestone.Status.IsTradable() {
+			continue
+		}
+		closesAt := milestone.TradingClosesAt
+		if closesAt == nil {
+			closesAt = milestone.TargetDate
+		}
+		closing = append(closing, models.DigestMarket{
+			MilestoneID:    milestone.ID,
+			MilestoneTitle: milestone.Title,
+			ClosesAt:       *closesAt,
+		})
+	}
+	return closing, nil
+}
+
+// pendingProofs 검증인 자격이 있는 사용자가 아직 투표하지 않은 검증 대기 증거 (마감 임박 순)
+func (s *DigestService) pendingProofs(userID uint, now time.Time) ([]models.DigestProof, error) {
+	var qualification models.ValidatorQualification
+	if err := s.db.Where("user_id = ?", userID).First(&qualification).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return []models.DigestProof{}, nil
+		}
+		return nil, fmt.Errorf("검증인 자격 조회 실패: %w", err)
+	}
+	if qualification.IsSuspended {
+		return []models.DigestProof{}, nil
+	}
+
+	voted := s.db.Model(&models.ProofValidator{}).Select("proof_id").Where("user_id = ?", userID)
+
+	var proofs []models.MilestoneProof
+	if err := s.db.Preload("Milestone").
+		Where("status = ? AND review_deadline > ? AND user_id <> ?", models.ProofStatusUnderReview, now, userID).
+		Where("id NOT IN (?)", voted).
+		Order("review_deadline ASC").
+		Limit(digestSectionLimit).
+		Find(&proofs).Error; err != nil {
+		return nil, fmt.Errorf("검증 대기 증거 조회 실패: %w", err)
+	}
+
+	pending := make([]models.DigestProof, 0, len(proofs))
+	for _, proof := range proofs {
+		pending = append(pending, models.DigestProof{
+			ProofID:        proof.ID,
+			MilestoneTitle: proof.Milestone.Title,
+			ReviewDeadline: proof.ReviewDeadline,
+		})
+	}
+	return pending, nil
+}
+
+// openArbitrationCases 기한이 남아 있을 수 있는 분쟁 (투표/공개/항소 대기)
+func (s *DigestService) openArbitrationCases() ([]models.ArbitrationCase, error) {
+	var cases []models.ArbitrationCase
+	if err := s.db.Where("status IN ? AND is_final = ?", []models.ArbitrationStatus{
+		models.ArbitrationStatusVoting,
+		models.ArbitrationStatusReveal,
+		models.ArbitrationStatusDecided,
+	}, false).Find(&cases).Error; err != nil {
+		return nil, fmt.Errorf("분쟁 조회 실패: %w", err)
+	}
+	return cases, nil
+}
+
+// arbitrationDeadlines horizon 전에 돌아오는 사용자의 분쟁 기한
+//
+// 배심원은 아직 투표(공개)하지 않은 투표/공개 기한만, 당사자는 투표/공개/항소 기한을 모두 받는다.
+func (s *DigestService) arbitrationDeadlines(userID uint, now, horizon time.Time, cases []models.ArbitrationCase) ([]models.DigestArbitration, error) {
+	deadlines := make([]models.DigestArbitration, 0)
+	for _, arbitrationCase := range cases {
+		var deadline *time.Time
+		switch arbitrationCase.Status {
+		case models.ArbitrationStatusVoting:
+			deadline = arbitrationCase.VotingDeadline
+		case models.ArbitrationStatusReveal:
+			deadline = arbitrationCase.RevealDeadline
+		case models.ArbitrationStatusDecided:
+			deadline = arbitrationCase.AppealDeadline
+		}
+		if deadline == nil || !deadline.After(now) || deadline.After(horizon) {
+			continue
+		}
+
+		role := ""
+		if arbitrationCase.PlaintiffID == userID || arbitrationCase.DefendantID == userID {
+			role = "party"
+		} else if arbitrationCase.Status != models.ArbitrationStatusDecided && isSelectedJuror(&arbitrationCase, userID) {
+			done, err := s.jurorActed(arbitrationCase.ID, userID, arbitrationCase.Status)
+			if err != nil {
+				return nil, err
+			}
+			if !done {
+				role = "juror"
+			}
+		}
+		if role == "" {
+			continue
+		}
+
+		deadlines = append(deadlines, models.DigestArbitration{
+			CaseID:     arbitrationCase.ID,
+			CaseNumber: arbitrationCase.CaseNumber,
+			Title:      arbitrationCase.Title,
+			Role:       role,
+			Phase:      arbitrationCase.Status,
+			Deadline:   *deadline,
+		})
+	}
+
+	sort.SliceStable(deadlines, func(i, j int) bool {
+		return deadlines[i].Deadline.Before(deadlines[j].Deadline)
+	})
+	if len(deadlines) > digestSectionLimit {
+		deadlines = deadlines[:digestSectionLimit]
+	}
+	return deadlines, nil
+}
+
+// jurorActed 배심원이 현재 단계에서 할 일(투표 제출/공개)을 마쳤는지
+func (s *DigestService) jurorActed(caseID, jurorID uint, phase models.ArbitrationStatus) (bool, error) {
+	column := "committed_at"
+	if phase == models.ArbitrationStatusReveal {
+		column = "revealed_at"
+	}
+	var count int64
+	if err := s.db.Model(&models.ArbitrationVote{}).
+		Where("case_id = ? AND juror_id = ? AND "+column+" IS NOT NULL", caseID, jurorID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("배심원 투표 조회 실패: %w", err)
+	}
+	return count > 0, nil
+}
+
+// publish 요약을 digest 템플릿 이메일 작업으로 발행 (같은 사용자/기간은 한 번만 발송)
+func (s *DigestService) publish(profile *models.UserProfile, digest *models.NotificationDigest) error {
+	positions := make([]map[string]interface{}, 0, len(digest.Positions))
+	for _, position := range digest.Positions {
+		positions = append(positions, map[string]interface{}{
+			"milestone_id": position.MilestoneID,
+			"title":        position.MilestoneTitle,
+			"option":       position.OptionID,
+			"quantity":     position.Quantity,
+			"from":         formatDigestPrice(position.FromPrice),
+			"to":           formatDigestPrice(position.ToPrice),
+			"change":       fmt.Sprintf("%+.1f%%p", position.ChangePercent),
+		})
+	}
+	closing := make([]map[string]interface{}, 0, len(digest.Closing))
+	for _, market := range digest.Closing {
+		closing = append(closing, map[string]interface{}{
+			"milestone_id": market.MilestoneID,
+			"title":        market.MilestoneTitle,
+			"closes_at":    formatDigestTime(market.ClosesAt),
+		})
+	}
+	proofs := make([]map[string]interface{}, 0, len(digest.Proofs))
+	for _, proof := range digest.Proofs {
+		proofs = append(proofs, map[string]interface{}{
+			"proof_id": proof.ProofID,
+			"title":    proof.MilestoneTitle,
+			"deadline": formatDigestTime(proof.ReviewDeadline),
+		})
+	}
+	arbitration := make([]map[string]interface{}, 0, len(digest.Arbitration))
+	for _, item := range digest.Arbitration {
+		arbitration = append(arbitration, map[string]interface{}{
+			"case_id":     item.CaseID,
+			"case_number": item.CaseNumber,
+			"title":       item.Title,
+			"role":        item.Role,
+			"phase":       string(item.Phase),
+			"deadline":    formatDigestTime(item.Deadline),
+		})
+	}
+
+	return queue.PublishJob("email_queue", map[string]interface{}{
+		"type":     "send_email",
+		"to":       profile.User.Email,
+		"template": "digest",
+		"data": map[string]interface{}{
+			"username":          profile.User.Username,
+			"frequency":         string(digest.Frequency),
+			"positions":         positions,
+			"closing":           closing,
+			"proofs":            proofs,
+			"arbitration":       arbitration,
+			"unsubscribe_token": s.UnsubscribeToken(profile.UserID),
+		},
+		"user_id":                 profile.UserID,
+		"locale":                  profile.Locale,
+		"timestamp":               time.Now().Unix(),
+		queue.IdempotencyKeyField: fmt.Sprintf("digest:%d:%d", profile.UserID, digest.PeriodEnd.Truncate(time.Hour).Unix()),
+	})
+}
+
+// UnsubscribeToken 요약 메일 수신 거부 토큰 ("<user_id>.<서명>", 만료 없음)
+func (s *DigestService) UnsubscribeToken(userID uint) string {
+	payload := strconv.FormatUint(uint64(userID), 10)
+	return payload + "." + s.sign(payload)
+}
+
+// Unsubscribe 토큰의 사용자 요약 메일을 끈다 (대상 사용자 ID 반환, 이미 꺼져 있어도 성공)
+func (s *DigestService) Unsubscribe(token string) (uint, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok || payload == "" || !hmac.Equal([]byte(signature), []byte(s.sign(payload))) {
+		return 0, ErrInvalidUnsubscribeToken
+	}
+	userID, err := strconv.ParseUint(payload, 10, 32)
+	if err != nil {
+		return 0, ErrInvalidUnsubscribeToken
+	}
+
+	if err := s.db.Model(&models.UserProfile{}).Where("user_id = ?", userID).
+		Update("digest_frequency", models.DigestOff).Error; err != nil {
+		return 0, fmt.Errorf("수신 거부 저장 실패: %w", err)
+	}
+	return uint(userID), nil
+}
+
+func (s *DigestService) sign(payload string) string {
+	mac := hmac.New(sha256.New, []byte(s.signingSecret))
+	mac.Write([]byte("digest_unsubscribe:" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// formatDigestPrice 표시용 가격 (센트)
+func formatDigestPrice(price float64) string {
+	return fmt.Sprintf("%.0f¢", price*100)
+}
+
+// formatDigestTime 메일 표시용 시각 (UTC)
+func formatDigestTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04") + " UTC"
+}
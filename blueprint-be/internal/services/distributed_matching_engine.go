@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	redisClient "github.com/redis/go-redis/v9"
@@ -20,9 +21,9 @@ import (
 // Redis Streams + Distributed Locks + Event Sourcing
 
 type DistributedMatchingEngine struct {
-	db          *gorm.DB
+	*tradePipeline // 체결 후처리 (단일 노드 엔진과 같은 수수료/지갑/포지션/멘토 풀 처리)
+
 	redisClient *redisClient.Client
-	sseService  *SSEService
 	instanceID  string // 서버 인스턴스 고유 ID
 
 	// 분산 락 및 상태 관리
@@ -36,6 +37,30 @@ type DistributedMatchingEngine struct {
 	// 로컬 캐시 (성능 최적화용)
 	localCache *LocalOrderBookCache
 
+	// 마켓 담당 배정 (일관된 해싱 + Redis 리스), 담당 마켓만 스트림 처리
+	ownership     *MarketOwnershipManager
+	streamCancels map[string]context.CancelFunc
+	streamMutex   sync.Mutex
+	marketMutexes sync.Map // 마켓별 로컬 직렬화 (같은 인스턴스 안의 동시 주문이 분산 락에서 튕기지 않도록)
+
+	// 이벤트 스냅샷/압축 (마켓별 N개마다 스냅샷)
+	snapshotPolicy  SnapshotPolicy
+	eventCounts     map[string]int
+	eventCountMutex sync.Mutex
+	snapshotMutex   sync.Mutex
+
+	// 통계 (GetStats)
+	startTime        time.Time
+	ordersProcessed  atomic.Int64
+	totalMatches     atomic.Int64
+	totalVolume      atomic.Int64
+	matchNanos       atomic.Int64
+	lastMatch        atomic.Int64 // UnixNano
+	cancelsProcessed atomic.Int64
+	cancelNanos      atomic.Int64
+	maxCancelNanos   atomic.Int64
+	cancelBreaches   atomic.Int64
+
 	// 컨트롤 채널
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -109,6 +134,23 @@ func (dlm *DistributedLockManager) ReleaseLock(ctx context.Context, key string,
 	return err
 }
 
+// RenewLock 보유 중인 락의 만료 시간 연장 (다른 인스턴스가 가져갔으면 false)
+func (dlm *DistributedLockManager) RenewLock(ctx context.Context, key string, ttl time.Duration, instanceID string) (bool, error) {
+	script := `
+		if redis.call("GET", KEYS[1]) == ARGV[1] then
+			return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+		end
+		return 0
+	`
+
+	result, err := dlm.redisClient.Eval(ctx, script, []string{fmt.Sprintf("lock:%s", key)}, instanceID, ttl.Milliseconds()).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return result.(int64) == 1, nil
+}
+
 // 📊 이벤트 소싱 기반 주문 관리
 type OrderEventSourcing struct {
 	redisClient *redisClient.Client
@@ -134,7 +176,7 @@ func (oes *OrderEventSourcing) AppendEvent(ctx context.Context, marketKey string
 		Stream: streamKey,
 		Values: map[string]interface{}{
 			"event_id":   event.EventID,
-			"event_type": event.EventType,
+			"event_type": string(event.EventType),
 			"order_id":   event.OrderID,
 			"payload":    string(eventJSON),
 			"timestamp":  event.Timestamp,
@@ -152,10 +194,13 @@ func (oes *OrderEventSourcing) ReadEvents(ctx context.Context, marketKey string,
 	result, err := oes.redisClient.XRead(ctx, &redisClient.XReadArgs{
 		Streams: []string{streamKey, fromID},
 		Count:   100,
-		Block:   0,
+		Block:   -1, // 새 이벤트를 기다리지 않음
 	}).Result()
 
 	if err != nil {
+		if err == redisClient.Nil {
+			return nil, nil
+		}
 		return nil, err
 	}
 
@@ -174,6 +219,36 @@ func (oes *OrderEventSourcing) ReadEvents(ctx context.Context, marketKey string,
 	return events, nil
 }
 
+// ReplayEvents afterID 이후(빈 값이면 처음부터) 이벤트를 오래된 순으로 재생 (주문장 재구성/감사용)
+func (oes *OrderEventSourcing) ReplayEvents(ctx context.Context, marketKey string, afterID string, apply func(streamID string, event *OrderEvent)) (int, error) {
+	streamKey := fmt.Sprintf("events:%s", marketKey)
+	start := "-"
+	if afterID != "" {
+		start = "(" + afterID
+	}
+	replayed := 0
+
+	for {
+		messages, err := oes.redisClient.XRangeN(ctx, streamKey, start, "+", 500).Result()
+		if err != nil {
+			return replayed, err
+		}
+		for _, message := range messages {
+			var event OrderEvent
+			if payloadStr, ok := message.Values["payload"].(string); ok {
+				if err := json.Unmarshal([]byte(payloadStr), &event); err == nil {
+					apply(message.ID, &event)
+					replayed++
+				}
+			}
+		}
+		if len(messages) < 500 {
+			return replayed, nil
+		}
+		start = "(" + messages[len(messages)-1].ID
+	}
+}
+
 // 🌊 Redis Streams 기반 실시간 주문 처리
 type RedisStreamManager struct {
 	redisClient *redisClient.Client
@@ -334,38 +409,70 @@ func NewDistributedMatchingEngineWithRedis(db *gorm.DB, sseService *SSEService,
 		redisClient = redis.GetClient()
 	}
 
-	return &DistributedMatchingEngine{
-		db:            db,
-		redisClient:   redisClient,
-		sseService:    sseService,
-		instanceID:    instanceID,
-		ctx:           ctx,
-		cancel:        cancel,
-		lockManager:   NewDistributedLockManager(redisClient),
-		eventSourcing: NewOrderEventSourcing(redisClient),
-		orderStreams:  NewRedisStreamManager(redisClient, instanceID),
-		priceOracle:   NewDistributedPriceOracle(redisClient),
-		localCache:    NewLocalOrderBookCache(),
-	}
+	dme := &DistributedMatchingEngine{
+		tradePipeline:  newTradePipeline(db, sseService, nil, nil),
+		redisClient:    redisClient,
+		instanceID:     instanceID,
+		ctx:            ctx,
+		cancel:         cancel,
+		lockManager:    NewDistributedLockManager(redisClient),
+		eventSourcing:  NewOrderEventSourcing(redisClient),
+		orderStreams:   NewRedisStreamManager(redisClient, instanceID),
+		priceOracle:    NewDistributedPriceOracle(redisClient),
+		localCache:     NewLocalOrderBookCache(),
+		streamCancels:  make(map[string]context.CancelFunc),
+		snapshotPolicy: DefaultSnapshotPolicy,
+		eventCounts:    make(map[string]int),
+		startTime:      time.Now(),
+	}
+	dme.quote = dme.bookQuote
+	dme.ownership = NewMarketOwnershipManager(redisClient, instanceID, DefaultMarketOwnershipConfig,
+		dme.getActiveMarkets, dme.takeOverMarket, dme.releaseMarket)
+	return dme
+}
+
+// InstanceID 이 엔진 인스턴스의 ID
+func (dme *DistributedMatchingEngine) InstanceID() string {
+	return dme.instanceID
+}
+
+// Ownership 마켓 담당 배정 관리자
+func (dme *DistributedMatchingEngine) Ownership() *MarketOwnershipManager {
+	return dme.ownership
 }
 
 // Start 분산 매칭 엔진 시작
 func (dme *DistributedMatchingEngine) Start() error {
 	log.Printf("🌐 Starting Distributed Matching Engine: %s", dme.instanceID)
 
-	// 주요 마켓 스트림 처리 시작
-	markets, err := dme.getActiveMarkets()
-	if err != nil {
-		return err
+	// Stop 후 다시 시작하면 새 컨텍스트로 (Stop이 모든 고루틴 종료를 기다렸으므로 교체해도 안전)
+	if dme.ctx.Err() != nil {
+		dme.ctx, dme.cancel = context.WithCancel(context.Background())
 	}
 
-	for _, marketKey := range markets {
-		dme.wg.Add(1)
-		go func(market string) {
-			defer dme.wg.Done()
-			dme.orderStreams.ProcessOrderStream(dme.ctx, market, dme.processOrderEvent)
-		}(marketKey)
+	// 해시 링으로 배정된 마켓만 리스를 잡고 스트림 처리 (인수 시 주문장 재구성)
+	if err := dme.ownership.Rebalance(dme.ctx); err != nil {
+		return err
 	}
+	dme.wg.Add(1)
+	go func() {
+		defer dme.wg.Done()
+		dme.ownership.Run(dme.ctx)
+	}()
+
+	// 주문 상태 DB 반영 워커
+	dme.wg.Add(1)
+	go func() {
+		defer dme.wg.Done()
+		dme.persistWorker()
+	}()
+
+	// 담당 마켓 이벤트 스트림 압축
+	dme.wg.Add(1)
+	go func() {
+		defer dme.wg.Done()
+		dme.runStreamCompactor()
+	}()
 
 	// 가격 오라클 업데이터 시작
 	dme.wg.Add(1)
@@ -374,15 +481,86 @@ func (dme *DistributedMatchingEngine) Start() error {
 		dme.runPriceOracleUpdater()
 	}()
 
-	log.Printf("✅ Distributed Matching Engine started with %d market streams", len(markets))
+	log.Printf("✅ Distributed Matching Engine started with %d owned market streams", len(dme.ownership.OwnedMarkets()))
+	return nil
+}
+
+// takeOverMarket 마켓 인수: 이벤트 로그로 주문장을 재구성한 뒤 주문 스트림 처리 시작
+func (dme *DistributedMatchingEngine) takeOverMarket(marketKey string) error {
+	if _, err := dme.RebuildOrderBook(marketKey); err != nil {
+		return fmt.Errorf("주문장 재구성 실패: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(dme.ctx)
+	dme.streamMutex.Lock()
+	if previous, exists := dme.streamCancels[marketKey]; exists {
+		previous()
+	}
+	dme.streamCancels[marketKey] = cancel
+	dme.streamMutex.Unlock()
+
+	dme.wg.Add(1)
+	go func() {
+		defer dme.wg.Done()
+		dme.orderStreams.ProcessOrderStream(ctx, marketKey, dme.processOrderEvent)
+	}()
 	return nil
 }
 
+// releaseMarket 담당 해제된 마켓의 스트림 처리 중단
+func (dme *DistributedMatchingEngine) releaseMarket(marketKey string) {
+	dme.streamMutex.Lock()
+	defer dme.streamMutex.Unlock()
+
+	if cancel, exists := dme.streamCancels[marketKey]; exists {
+		cancel()
+		delete(dme.streamCancels, marketKey)
+	}
+}
+
+// RebuildOrderBook 최신 스냅샷과 그 이후 이벤트(events:{market})를 재생해 주문장을 다시 만들고 저장
+func (dme *DistributedMatchingEngine) RebuildOrderBook(marketKey string) (*DistributedOrderBook, error) {
+	replayer, err := dme.eventSourcing.ReplayMarket(dme.ctx, marketKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	orderBook := &DistributedOrderBook{
+		MarketKey: marketKey,
+		Bids:      []*models.Order{},
+		Asks:      []*models.Order{},
+		LastPrice: replayer.LastPrice(),
+	}
+	for _, order := range replayer.OpenOrders() {
+		dme.addOrderToBook(orderBook, order)
+	}
+	if err := dme.saveOrderBook(marketKey, orderBook); err != nil {
+		return nil, err
+	}
+
+	log.Printf("♻️ Rebuilt order book %s from %d events (%d bids, %d asks)", marketKey, replayer.EventCount(), len(orderBook.Bids), len(orderBook.Asks))
+	return orderBook, nil
+}
+
+// decodeEventPayload JSON으로 역직렬화된 이벤트 페이로드를 구조체로 변환
+func decodeEventPayload(value interface{}, target interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
 // Stop 분산 매칭 엔진 정지
 func (dme *DistributedMatchingEngine) Stop() error {
 	log.Printf("🛑 Stopping Distributed Matching Engine: %s", dme.instanceID)
 	dme.cancel()
 	dme.wg.Wait()
+
+	// 아직 반영되지 않은 주문 상태 기록
+	if persisted := dme.FlushOrderStates(); persisted > 0 {
+		log.Printf("💾 Persisted %d pending order states on shutdown", persisted)
+	}
 	log.Printf("✅ Distributed Matching Engine stopped")
 	return nil
 }
@@ -391,16 +569,28 @@ func (dme *DistributedMatchingEngine) Stop() error {
 func (dme *DistributedMatchingEngine) SubmitOrder(order *models.Order) (*MatchingResult, error) {
 	marketKey := dme.getMarketKey(order.MilestoneID, order.OptionID)
 
-	// 1. 분산 락 획득 (매칭 원자성 보장)
-	lockKey := fmt.Sprintf("match:%s", marketKey)
-	locked, err := dme.lockManager.AcquireLock(dme.ctx, lockKey, 5*time.Second, dme.instanceID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to acquire lock: %v", err)
+	// 0. 배정이 동작 중이면 담당 인스턴스만 매칭 (호출자는 Owner로 라우팅하거나 재시도)
+	if dme.ownership.Running() && !dme.ownership.Owns(marketKey) {
+		return nil, fmt.Errorf("%w: %s (owner: %s)", ErrMarketNotOwned, marketKey, dme.ownership.Owner(marketKey))
 	}
-	if !locked {
-		return nil, fmt.Errorf("market is locked by another instance")
+
+	// 점검/서킷브레이커 중단 중에는 매칭하지 않음 (취소는 CancelOrder로 계속 처리)
+	if err := dme.tradingPause.Check(order.MilestoneID); err != nil {
+		return nil, err
+	}
+	if until, halted := dme.circuitBreaker.HaltedUntil(order.MilestoneID); halted {
+		return nil, fmt.Errorf("%w: %s 이후 재개", ErrMarketHalted, until.UTC().Format(time.RFC3339))
 	}
-	defer dme.lockManager.ReleaseLock(dme.ctx, lockKey, dme.instanceID)
+
+	// 1. 마켓 락 획득 (매칭 원자성 보장)
+	unlock, err := dme.lockMarket(marketKey, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// 저장 전 주문이 들어와도 틱 가격/잔량 기준으로 매칭되도록
+	prepareOrder(order)
 
 	// 2. 주문 이벤트 생성
 	event := &OrderEvent{
@@ -418,7 +608,7 @@ func (dme *DistributedMatchingEngine) SubmitOrder(order *models.Order) (*Matchin
 	}
 
 	// 3. 이벤트 소싱에 기록
-	if err := dme.eventSourcing.AppendEvent(dme.ctx, marketKey, event); err != nil {
+	if err := dme.appendEvent(marketKey, event); err != nil {
 		return nil, fmt.Errorf("failed to append event: %v", err)
 	}
 
@@ -426,91 +616,88 @@ func (dme *DistributedMatchingEngine) SubmitOrder(order *models.Order) (*Matchin
 	return dme.executeMatching(marketKey, order)
 }
 
-// 핵심 매칭 로직들...
+// lockMarket 마켓별 로컬 뮤텍스와 분산 락을 잡고 해제 함수 반환 (wait 동안 분산 락 재시도)
+func (dme *DistributedMatchingEngine) lockMarket(marketKey string, wait time.Duration) (func(), error) {
+	marketMutex, _ := dme.marketMutexes.LoadOrStore(marketKey, &sync.Mutex{})
+	mutex := marketMutex.(*sync.Mutex)
+	mutex.Lock()
+
+	lockKey := fmt.Sprintf("match:%s", marketKey)
+	deadline := time.Now().Add(wait)
+	for {
+		locked, err := dme.lockManager.AcquireLock(dme.ctx, lockKey, 5*time.Second, dme.instanceID)
+		if err != nil {
+			mutex.Unlock()
+			return nil, fmt.Errorf("failed to acquire lock: %v", err)
+		}
+		if locked {
+			break
+		}
+		if time.Now().After(deadline) {
+			mutex.Unlock()
+			return nil, fmt.Errorf("market is locked by another instance")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return func() {
+		dme.lockManager.ReleaseLock(dme.ctx, lockKey, dme.instanceID)
+		mutex.Unlock()
+	}, nil
+}
+
+// prepareOrder 틱 가격과 잔량이 비어 있으면 채움 (표시용 가격은 틱에서 다시 계산)
+func prepareOrder(order *models.Order) {
+	if order.PriceTicks == 0 {
+		order.PriceTicks = models.PriceToTicks(order.Price)
+	}
+	order.Price = models.TicksToPrice(order.PriceTicks)
+	if order.Remaining == 0 && order.Filled == 0 {
+		order.Remaining = order.Quantity
+	}
+}
+
+// executeMatching 가격-시간 우선 지정가 매칭 (마켓 락을 잡은 상태에서 호출)
 func (dme *DistributedMatchingEngine) executeMatching(marketKey string, order *models.Order) (*MatchingResult, error) {
+	started := time.Now()
+
 	// 1. Redis에서 현재 주문장 상태 로드
 	orderBook, err := dme.loadOrderBook(marketKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load order book: %v", err)
 	}
 
-	// 2. 매칭 엔진 실행
-	trades := []models.Trade{}
-	remainingQuantity := order.Quantity
-
-	if order.Side == "buy" {
-		// Buy 주문: 가장 낮은 Ask부터 매칭
-		for len(orderBook.Asks) > 0 && remainingQuantity > 0 {
-			askOrder := orderBook.Asks[0]
-			if askOrder.Price > order.Price {
-				break // 가격이 맞지 않음
-			}
-
-			// 거래 체결
-			tradeQuantity := minInt64(remainingQuantity, askOrder.Quantity)
-			trade := models.Trade{
-				BuyOrderID:  order.ID,
-				SellOrderID: askOrder.ID,
-				MilestoneID: order.MilestoneID,
-				OptionID:    order.OptionID,
-				BuyerID:     order.UserID,
-				SellerID:    askOrder.UserID,
-				Quantity:    tradeQuantity,
-				Price:       askOrder.Price,
-				CreatedAt:   time.Now(),
-			}
-
-			trades = append(trades, trade)
-			remainingQuantity -= tradeQuantity
-
-			// Ask 주문 업데이트
-			askOrder.Quantity -= tradeQuantity
-			if askOrder.Quantity == 0 {
-				orderBook.Asks = orderBook.Asks[1:]
-			}
+	// 2. 반대편 최우선 호가부터 체결 (가격은 메이커 가격)
+	var trades []models.Trade
+	var makers []*models.Order
+	opposite := &orderBook.Asks
+	crosses := func(maker *models.Order) bool { return maker.PriceTicks <= order.PriceTicks }
+	if order.Side != models.OrderSideBuy {
+		opposite = &orderBook.Bids
+		crosses = func(maker *models.Order) bool { return maker.PriceTicks >= order.PriceTicks }
+	}
 
-			// 거래 이벤트 발생
-			dme.emitTradeEvent(marketKey, &trade)
+	buyerFee, sellerFee := dme.feeBasisPoints(order)
+	for order.Remaining > 0 && len(*opposite) > 0 {
+		maker := (*opposite)[0]
+		if !crosses(maker) {
+			break // 가격이 맞지 않음
 		}
-	} else {
-		// Sell 주문: 가장 높은 Bid부터 매칭
-		for len(orderBook.Bids) > 0 && remainingQuantity > 0 {
-			bidOrder := orderBook.Bids[0]
-			if bidOrder.Price < order.Price {
-				break // 가격이 맞지 않음
-			}
-
-			// 거래 체결
-			tradeQuantity := minInt64(remainingQuantity, bidOrder.Quantity)
-			trade := models.Trade{
-				BuyOrderID:  bidOrder.ID,
-				SellOrderID: order.ID,
-				MilestoneID: order.MilestoneID,
-				OptionID:    order.OptionID,
-				BuyerID:     bidOrder.UserID,
-				SellerID:    order.UserID,
-				Quantity:    tradeQuantity,
-				Price:       bidOrder.Price,
-				CreatedAt:   time.Now(),
-			}
-
-			trades = append(trades, trade)
-			remainingQuantity -= tradeQuantity
-
-			// Bid 주문 업데이트
-			bidOrder.Quantity -= tradeQuantity
-			if bidOrder.Quantity == 0 {
-				orderBook.Bids = orderBook.Bids[1:]
-			}
 
-			// 거래 이벤트 발생
-			dme.emitTradeEvent(marketKey, &trade)
+		trade := fillOrders(order, maker, min(order.Remaining, maker.Remaining), buyerFee, sellerFee)
+		trades = append(trades, trade)
+		makers = append(makers, maker)
+		if maker.Remaining <= 0 {
+			*opposite = (*opposite)[1:]
 		}
+		orderBook.LastPrice = trade.Price
+
+		// 거래 이벤트 발생 (인수 시 재구성에 사용)
+		dme.emitTradeEvent(marketKey, &trade)
 	}
 
 	// 3. 남은 수량이 있으면 주문장에 추가
-	if remainingQuantity > 0 {
-		order.Quantity = remainingQuantity
+	if order.Remaining > 0 {
 		dme.addOrderToBook(orderBook, order)
 	}
 
@@ -519,26 +706,85 @@ func (dme *DistributedMatchingEngine) executeMatching(marketKey string, order *m
 		return nil, fmt.Errorf("failed to save order book: %v", err)
 	}
 
-	// 5. 가격 오라클 업데이트
+	// 5. 바뀐 주문 상태는 write-behind로 DB 반영 (단일 노드 엔진과 같은 방식)
+	for _, changed := range append(makers, order) {
+		if changed.ID != 0 && changed.Filled > 0 {
+			dme.markOrderDirty(changed)
+		}
+	}
+
+	// 6. 가격 오라클 업데이트 및 체결 후처리 (수수료/지갑/포지션/멘토 풀/시장 데이터)
 	if len(trades) > 0 {
-		lastTrade := trades[len(trades)-1]
 		totalVolume := int64(0)
 		for _, trade := range trades {
-			totalVolume += int64(trade.Quantity)
+			totalVolume += trade.Quantity
 		}
-		dme.priceOracle.UpdatePrice(dme.ctx, marketKey, lastTrade.Price, totalVolume)
+		dme.priceOracle.UpdatePrice(dme.ctx, marketKey, orderBook.LastPrice, totalVolume)
+
+		dme.totalMatches.Add(int64(len(trades)))
+		dme.totalVolume.Add(totalVolume)
+		dme.lastMatch.Store(time.Now().UnixNano())
+		dme.settleTrades(order.MilestoneID, order.OptionID, trades)
 	}
 
-	// 6. SSE로 실시간 업데이트 전송
+	// 7. SSE로 실시간 업데이트 전송
 	dme.broadcastMarketUpdate(marketKey, orderBook, trades)
 
+	dme.ordersProcessed.Add(1)
+	dme.matchNanos.Add(int64(time.Since(started)))
+
 	return &MatchingResult{
-		Trades:   trades,
-		Error:    nil,
-		Executed: len(trades) > 0,
+		Trades:    trades,
+		Error:     nil,
+		Executed:  len(trades) > 0,
+		Filled:    order.Filled,
+		Remaining: order.Remaining,
+		Status:    order.Status,
 	}, nil
 }
 
+// fillOrders 테이커와 메이커를 quantity만큼 체결 (단일 노드 엔진과 같은 수수료/매수 잠금 해제 계산)
+func fillOrders(taker, maker *models.Order, quantity, buyerFee, sellerFee int64) models.Trade {
+	buy, sell := taker, maker
+	if taker.Side != models.OrderSideBuy {
+		buy, sell = maker, taker
+	}
+
+	// 메이커 가격으로 체결, 매수 지정가와의 차액은 매수자 잠금에서 해제
+	settlement := models.SettleFillWithFees(quantity, maker.PriceTicks, buy.PriceTicks, buy.Filled, buyerFee, sellerFee)
+	trade := models.Trade{
+		ProjectID:     taker.ProjectID,
+		MilestoneID:   taker.MilestoneID,
+		OptionID:      taker.OptionID,
+		BuyOrderID:    buy.ID,
+		SellOrderID:   sell.ID,
+		BuyerID:       buy.UserID,
+		SellerID:      sell.UserID,
+		Quantity:      quantity,
+		Price:         maker.Price,
+		PriceTicks:    maker.PriceTicks,
+		TotalAmount:   settlement.Notional,
+		BuyerFee:      settlement.BuyerFee,
+		SellerFee:     settlement.SellerFee,
+		BuyerRelease:  settlement.BuyerRelease,
+		QuoteCurrency: taker.QuoteCurrency.OrUSDC(),
+		CreatedAt:     time.Now(),
+
+		SellerCollateral: sell.ShortCollateralFill(sell.Filled, quantity),
+	}
+
+	for _, order := range []*models.Order{taker, maker} {
+		order.Filled += quantity
+		order.Remaining -= quantity
+		if order.Remaining <= 0 {
+			order.Status = models.OrderStatusFilled
+		} else {
+			order.Status = models.OrderStatusPartial
+		}
+	}
+	return trade
+}
+
 func (dme *DistributedMatchingEngine) processOrderEvent(event *OrderEvent) error {
 	marketKey := dme.getMarketKey(event.MilestoneID, event.OptionID)
 
@@ -580,9 +826,10 @@ func (dme *DistributedMatchingEngine) getActiveMarkets() ([]string, error) {
 
 	var markets []string
 	for _, milestone := range milestones {
-		// 각 마일스톤에 대해 success/fail 마켓 생성
-		markets = append(markets, fmt.Sprintf("%d:success", milestone.ID))
-		markets = append(markets, fmt.Sprintf("%d:fail", milestone.ID))
+		// 결과 옵션마다 별도 마켓 (이진 마켓은 success/fail)
+		for _, option := range milestone.OptionIDs() {
+			markets = append(markets, fmt.Sprintf("%d:%s", milestone.ID, option))
+		}
 	}
 
 	log.Printf("🎯 Found %d active markets from %d milestones", len(markets), len(milestones))
@@ -708,11 +955,11 @@ func (dme *DistributedMatchingEngine) saveOrderBook(marketKey string, orderBook
 
 // addOrderToBook 주문장에 주문 추가
 func (dme *DistributedMatchingEngine) addOrderToBook(orderBook *DistributedOrderBook, order *models.Order) {
-	if order.Side == "buy" {
+	if order.Side == models.OrderSideBuy {
 		// Bid 추가 (가격 높은 순으로 정렬)
 		inserted := false
 		for i, existingOrder := range orderBook.Bids {
-			if order.Price > existingOrder.Price {
+			if order.PriceTicks > existingOrder.PriceTicks {
 				orderBook.Bids = append(orderBook.Bids[:i], append([]*models.Order{order}, orderBook.Bids[i:]...)...)
 				inserted = true
 				break
@@ -725,7 +972,7 @@ func (dme *DistributedMatchingEngine) addOrderToBook(orderBook *DistributedOrder
 		// Ask 추가 (가격 낮은 순으로 정렬)
 		inserted := false
 		for i, existingOrder := range orderBook.Asks {
-			if order.Price < existingOrder.Price {
+			if order.PriceTicks < existingOrder.PriceTicks {
 				orderBook.Asks = append(orderBook.Asks[:i], append([]*models.Order{order}, orderBook.Asks[i:]...)...)
 				inserted = true
 				break
@@ -752,7 +999,7 @@ func (dme *DistributedMatchingEngine) emitTradeEvent(marketKey string, trade *mo
 		Version:   1,
 	}
 
-	dme.eventSourcing.AppendEvent(dme.ctx, marketKey, event)
+	dme.appendEvent(marketKey, event)
 }
 
 // broadcastMarketUpdate SSE로 마켓 업데이트 브로드캐스트
@@ -787,12 +1034,12 @@ func (dme *DistributedMatchingEngine) broadcastMarketUpdate(marketKey string, or
 
 // handleOrderCancellation 주문 취소 처리
 func (dme *DistributedMatchingEngine) handleOrderCancellation(marketKey string, orderID uint) error {
-	lockKey := fmt.Sprintf("cancel:%s:%d", marketKey, orderID)
-	locked, err := dme.lockManager.AcquireLock(dme.ctx, lockKey, 5*time.Second, dme.instanceID)
-	if err != nil || !locked {
-		return fmt.Errorf("failed to acquire cancellation lock")
+	// 매칭과 같은 마켓 락 (진행 중인 매칭이 끝날 때까지 대기)
+	unlock, err := dme.lockMarket(marketKey, cancelWaitTimeout)
+	if err != nil {
+		return err
 	}
-	defer dme.lockManager.ReleaseLock(dme.ctx, lockKey, dme.instanceID)
+	defer unlock()
 
 	orderBook, err := dme.loadOrderBook(marketKey)
 	if err != nil {
@@ -800,9 +1047,11 @@ func (dme *DistributedMatchingEngine) handleOrderCancellation(marketKey string,
 	}
 
 	// Bids에서 주문 제거
+	removed := false
 	for i, order := range orderBook.Bids {
 		if order.ID == orderID {
 			orderBook.Bids = append(orderBook.Bids[:i], orderBook.Bids[i+1:]...)
+			removed = true
 			break
 		}
 	}
@@ -811,11 +1060,23 @@ func (dme *DistributedMatchingEngine) handleOrderCancellation(marketKey string,
 	for i, order := range orderBook.Asks {
 		if order.ID == orderID {
 			orderBook.Asks = append(orderBook.Asks[:i], orderBook.Asks[i+1:]...)
+			removed = true
 			break
 		}
 	}
 
-	return dme.saveOrderBook(marketKey, orderBook)
+	if err := dme.saveOrderBook(marketKey, orderBook); err != nil {
+		return err
+	}
+	if err := dme.recordRemoval(marketKey, orderID, EventOrderCancelled); err != nil {
+		return err
+	}
+
+	// 주문장이 바뀌었으면 구독자에게 알림
+	if removed {
+		dme.broadcastMarketUpdate(marketKey, orderBook, nil)
+	}
+	return nil
 }
 
 // handleOrderExpiry 주문 만료 처리
@@ -823,12 +1084,157 @@ func (dme *DistributedMatchingEngine) handleOrderExpiry(marketKey string, orderI
 	return dme.handleOrderCancellation(marketKey, orderID)
 }
 
-// minInt64 utility function
-func minInt64(a, b int64) int64 {
-	if a < b {
-		return a
+// recordRemoval 주문 제거를 이벤트 로그에 남김 (인수 시 재구성에 반영)
+func (dme *DistributedMatchingEngine) recordRemoval(marketKey string, orderID uint, eventType OrderEventType) error {
+	milestoneID, optionID := dme.parseMarketKey(marketKey)
+	return dme.appendEvent(marketKey, &OrderEvent{
+		EventID:     fmt.Sprintf("removed-%s-%d", dme.instanceID, time.Now().UnixNano()),
+		EventType:   eventType,
+		OrderID:     orderID,
+		MilestoneID: milestoneID,
+		OptionID:    optionID,
+		Timestamp:   time.Now().UnixMilli(),
+		ServerID:    dme.instanceID,
+		Version:     1,
+	})
+}
+
+// persistWorker 주기적으로 대기 중인 주문 상태를 DB에 반영 (write-behind)
+func (dme *DistributedMatchingEngine) persistWorker() {
+	ticker := time.NewTicker(orderPersistInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-dme.ctx.Done():
+			return
+		case <-ticker.C:
+			dme.FlushOrderStates()
+		}
+	}
+}
+
+// CancelOrder 주문장에서 주문 제거 (어느 인스턴스에서 호출해도 마켓 락을 잡고 Redis 주문장에서 제거)
+func (dme *DistributedMatchingEngine) CancelOrder(order *models.Order) error {
+	started := time.Now()
+	marketKey := dme.getMarketKey(order.MilestoneID, order.OptionID)
+	if err := dme.handleOrderCancellation(marketKey, order.ID); err != nil {
+		log.Printf("⚠️ Failed to remove order %d from %s: %v", order.ID, marketKey, err)
+		return fmt.Errorf("%w: %v", ErrCancelNotConfirmed, err)
+	}
+
+	latency := time.Since(started)
+	dme.cancelsProcessed.Add(1)
+	dme.cancelNanos.Add(int64(latency))
+	for {
+		current := dme.maxCancelNanos.Load()
+		if int64(latency) <= current || dme.maxCancelNanos.CompareAndSwap(current, int64(latency)) {
+			break
+		}
+	}
+	if latency > CancelSLA {
+		dme.cancelBreaches.Add(1)
+	}
+	return nil
+}
+
+// RestingOrders 담당 중인 활성 마켓의 Redis 주문장에 남은 주문
+func (dme *DistributedMatchingEngine) RestingOrders() []RestingOrder {
+	markets, err := dme.getActiveMarkets()
+	if err != nil {
+		log.Printf("⚠️ Failed to list markets for resting orders: %v", err)
+		return nil
+	}
+
+	var orders []RestingOrder
+	for _, marketKey := range markets {
+		milestoneID, optionID := dme.parseMarketKey(marketKey)
+		if !dme.ManagesMarket(milestoneID, optionID) {
+			continue
+		}
+		orderBook, err := dme.loadOrderBook(marketKey)
+		if err != nil {
+			log.Printf("⚠️ Failed to load order book %s: %v", marketKey, err)
+			continue
+		}
+		for _, side := range [][]*models.Order{orderBook.Bids, orderBook.Asks} {
+			for _, order := range side {
+				orders = append(orders, newRestingOrder(order))
+			}
+		}
 	}
-	return b
+	return orders
+}
+
+// ManagesMarket 배정이 동작 중이면 담당 마켓만, 아니면 모든 마켓
+func (dme *DistributedMatchingEngine) ManagesMarket(milestoneID uint, optionID string) bool {
+	return !dme.ownership.Running() || dme.ownership.Owns(dme.getMarketKey(milestoneID, optionID))
+}
+
+// GetOrderBook Redis 주문장을 가격대별로 집계해 조회
+func (dme *DistributedMatchingEngine) GetOrderBook(milestoneID uint, optionID string, depth int, aggregation float64) *models.OrderBook {
+	book := &models.OrderBook{
+		MilestoneID: milestoneID,
+		OptionID:    optionID,
+		Bids:        []models.OrderBookLevel{},
+		Asks:        []models.OrderBookLevel{},
+		Depth:       depth,
+		Aggregation: aggregation,
+		LastUpdate:  time.Now(),
+	}
+
+	orderBook, err := dme.loadOrderBook(dme.getMarketKey(milestoneID, optionID))
+	if err != nil {
+		log.Printf("⚠️ Failed to load order book %d:%s: %v", milestoneID, optionID, err)
+		return book
+	}
+	book.Bids = aggregateLevels(orderBook.Bids, depth, aggregation, true)
+	book.Asks = aggregateLevels(orderBook.Asks, depth, aggregation, false)
+	if len(book.Bids) > 0 && len(book.Asks) > 0 {
+		book.Spread = tickToPrice(priceToTick(book.Asks[0].Price) - priceToTick(book.Bids[0].Price))
+	}
+	return book
+}
+
+// GetStats 이 인스턴스의 매칭 통계 (ActiveOrderBooks는 담당 중인 마켓 수)
+func (dme *DistributedMatchingEngine) GetStats() MatchingStats {
+	stats := MatchingStats{
+		TotalMatches:      dme.totalMatches.Load(),
+		TotalVolume:       dme.totalVolume.Load(),
+		OrdersProcessed:   dme.ordersProcessed.Load(),
+		ActiveOrderBooks:  len(dme.ownership.OwnedMarkets()),
+		StartTime:         dme.startTime,
+		CancelsProcessed:  dme.cancelsProcessed.Load(),
+		MaxCancelLatency:  float64(dme.maxCancelNanos.Load()) / float64(time.Millisecond),
+		CancelSLABreaches: dme.cancelBreaches.Load(),
+	}
+	if lastMatch := dme.lastMatch.Load(); lastMatch > 0 {
+		stats.LastMatchTime = time.Unix(0, lastMatch)
+	}
+	if stats.OrdersProcessed > 0 {
+		stats.AvgMatchTime = float64(dme.matchNanos.Load()) / float64(stats.OrdersProcessed) / float64(time.Millisecond)
+	}
+	if stats.CancelsProcessed > 0 {
+		stats.AvgCancelLatency = float64(dme.cancelNanos.Load()) / float64(stats.CancelsProcessed) / float64(time.Millisecond)
+	}
+	return stats
+}
+
+// bookQuote Redis 주문장의 최우선 호가와 마지막 체결가 (체결 후처리의 시장 데이터/미실현 손익 계산용)
+func (dme *DistributedMatchingEngine) bookQuote(milestoneID uint, optionID string) BookQuote {
+	orderBook, err := dme.loadOrderBook(dme.getMarketKey(milestoneID, optionID))
+	if err != nil {
+		return BookQuote{}
+	}
+
+	quote := BookQuote{Last: orderBook.LastPrice}
+	if len(orderBook.Bids) > 0 {
+		quote.Bid = orderBook.Bids[0].Price
+	}
+	if len(orderBook.Asks) > 0 {
+		quote.Ask = orderBook.Asks[0].Price
+	}
+	return quote
 }
 
 // 🔄 CQRS Pattern: Command Query Responsibility Segregation
@@ -884,29 +1290,15 @@ func (tch *TradingCommandHandler) HandleCreateOrder(cmd *CreateOrderCommand) (*M
 	return tch.matchingEngine.SubmitOrder(order)
 }
 
-// HandleCancelOrder 주문 취소 명령 처리
-func (tch *TradingCommandHandler) HandleCancelOrder(cmd *CancelOrderCommand) error {
+// HandleCancelOrder 주문 취소 명령 처리 (Redis 주문장에서 제거 후 DB 취소와 매수 잠금 해제)
+func (tch *TradingCommandHandler) HandleCancelOrder(cmd *CancelOrderCommand) (*models.CancelOrderResponse, error) {
 	// 1. 명령 검증
 	if cmd.OrderID == 0 || cmd.UserID == 0 {
-		return fmt.Errorf("invalid cancel order command")
+		return nil, fmt.Errorf("invalid cancel order command")
 	}
 
-	// 2. 주문 취소 이벤트 생성
-	marketKey := "unknown" // 실제로는 주문 조회 후 결정
-	event := &OrderEvent{
-		EventID:   fmt.Sprintf("cancel-%s-%d", tch.matchingEngine.instanceID, time.Now().UnixNano()),
-		EventType: EventOrderCancelled,
-		OrderID:   cmd.OrderID,
-		Payload: map[string]interface{}{
-			"user_id": cmd.UserID,
-		},
-		Timestamp: time.Now().UnixMilli(),
-		ServerID:  tch.matchingEngine.instanceID,
-		Version:   1,
-	}
-
-	// 3. 이벤트 소싱에 기록
-	return tch.matchingEngine.eventSourcing.AppendEvent(tch.matchingEngine.ctx, marketKey, event)
+	// 2. 주문장 제거(취소 이벤트 기록 포함) → 주문 상태 변경 → 잠금 해제
+	return cancelOpenOrder(tch.matchingEngine.db, tch.matchingEngine, cmd.UserID, cmd.OrderID)
 }
 
 func (tch *TradingCommandHandler) validateCreateOrderCommand(cmd *CreateOrderCommand) error {
@@ -93,19 +93,8 @@ func (dts *DistributedTradingService) CreateOrder(userID uint, milestoneID uint,
 	return dts.commandHandler.HandleCreateOrder(cmd)
 }
 
-// CancelOrder 주문 취소 - CQRS Command 패턴 사용
-func (dts *DistributedTradingService) CancelOrder(userID uint, orderID uint) error {
-	// 1. 주문 소유권 검증
-	var order models.Order
-	err := dts.db.Where("id = ? AND user_id = ? AND status = ?", orderID, userID, "open").First(&order).Error
-	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return fmt.Errorf("order not found or not owned by user")
-		}
-		return err
-	}
-
-	// 2. 주문 취소 명령 실행
+// CancelOrder 주문 취소 - CQRS Command 패턴 사용 (해제된 매수 잠금액 반환)
+func (dts *DistributedTradingService) CancelOrder(userID uint, orderID uint) (*models.CancelOrderResponse, error) {
 	cmd := &CancelOrderCommand{
 		UserID:  userID,
 		OrderID: orderID,
@@ -125,7 +114,7 @@ func (dts *DistributedTradingService) ValidateUserBalance(userID uint, orderType
 		return err
 	}
 
-	requiredAmount := int64(float64(quantity) * price * 100) // Convert to cents
+	requiredAmount := models.BuyReserveCents(quantity, models.PriceToTicks(price))
 
 	if orderType == "buy" {
 		if wallet.USDCBalance < requiredAmount {
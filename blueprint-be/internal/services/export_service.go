@@ -0,0 +1,289 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/queue"
+
+	"gorm.io/gorm"
+)
+
+// ExportQueue 내보내기 파일 생성 작업 큐 (blueprint-worker 소비)
+const ExportQueue = "export_queue"
+
+const (
+	exportFileCategory  = "exports"
+	maxExportsPerDay    = 20 // 사용자당 하루 최대 요청 수
+	exportMinYear       = 2020
+	exportCleanupWindow = 100 // 정리 주기당 최대 삭제 파일 수
+)
+
+var (
+	ErrExportNotFound      = errors.New("내보내기 요청을 찾을 수 없습니다")
+	ErrExportNotReady      = errors.New("내보내기 파일이 아직 준비되지 않았습니다")
+	ErrExportInvalidKind   = errors.New("지원하지 않는 내보내기 종류입니다")
+	ErrExportInvalidFormat = errors.New("지원하지 않는 파일 형식입니다 (csv, xlsx)")
+	ErrExportInvalidYear   = errors.New("잘못된 연도입니다")
+	ErrExportInvalidPeriod = errors.New("명세서는 이번 달까지의 월만 요청할 수 있습니다")
+	ErrExportLimitExceeded = fmt.Errorf("내보내기는 하루 %d회까지 요청할 수 있습니다", maxExportsPerDay)
+)
+
+// ExportService 거래/주문/세무 리포트, 월간 수수료 명세서, 개인 데이터 내보내기 요청 관리 (파일 생성은 워커 담당)
+type ExportService struct {
+	db          *gorm.DB
+	fileService *FileService
+}
+
+// NewExportService 생성자
+func NewExportService(db *gorm.DB, fileService *FileService) *ExportService {
+	return &ExportService{
+		db:          db,
+		fileService: fileService,
+	}
+}
+
+// RequestExport 내보내기 요청 (같은 조건의 요청이 진행 중이면 그대로 반환)
+func (s *ExportService) RequestExport(userID uint, kind models.DataExportKind, format models.DataExportFormat, year int) (*models.DataExport, error) {
+	switch kind {
+	case models.DataExportTrades, models.DataExportOrders, models.DataExportTaxReport:
+	default:
+		return nil, ErrExportInvalidKind
+	}
+	if !format.IsValid() {
+		return nil, ErrExportInvalidFormat
+	}
+	if year < exportMinYear || year > time.Now().UTC().Year() {
+		return nil, ErrExportInvalidYear
+	}
+
+	var inFlight models.DataExport
+	err := s.db.Where("user_id = ? AND kind = ? AND format = ? AND year = ? AND status IN ?",
+		userID, kind, format, year, []models.DataExportStatus{models.DataExportPending, models.DataExportProcessing}).
+		First(&inFlight).Error
+	if err == nil {
+		return &inFlight, nil
+	}
+
+	return s.enqueue(&models.DataExport{
+		UserID: userID,
+		Kind:   kind,
+		Format: format,
+		Year:   year,
+		Status: models.DataExportPending,
+	})
+}
+
+// RequestPersonalDataExport 계정에 저장된 개인 데이터 전체 내보내기 요청 (JSON, 진행 중인 요청이 있으면 그대로 반환)
+func (s *ExportService) RequestPersonalDataExport(userID uint) (*models.DataExport, error) {
+	var inFlight models.DataExport
+	err := s.db.Where("user_id = ? AND kind = ? AND status IN ?",
+		userID, models.DataExportPersonalData, []models.DataExportStatus{models.DataExportPending, models.DataExportProcessing}).
+		First(&inFlight).Error
+	if err == nil {
+		return &inFlight, nil
+	}
+
+	return s.enqueue(&models.DataExport{
+		UserID: userID,
+		Kind:   models.DataExportPersonalData,
+		Format: models.DataExportJSON,
+		Year:   time.Now().UTC().Year(),
+		Status: models.DataExportPending,
+	})
+}
+
+// RequestFeeStatement 월간 수수료 명세서 요청 (PDF, 같은 달 요청이 진행 중이면 그대로 반환)
+// 진행 중인 달도 요청할 수 있고, 그때까지의 내역으로 새 명세서를 만든다.
+func (s *ExportService) RequestFeeStatement(userID uint, year, month int) (*models.DataExport, error) {
+	now := time.Now().UTC()
+	if year < exportMinYear || month < 1 || month > 12 ||
+		time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC).After(now) {
+		return nil, ErrExportInvalidPeriod
+	}
+
+	var inFlight models.DataExport
+	err := s.db.Where("user_id = ? AND kind = ? AND year = ? AND month = ? AND status IN ?",
+		userID, models.DataExportFeeStatement, year, month, []models.DataExportStatus{models.DataExportPending, models.DataExportProcessing}).
+		First(&inFlight).Error
+	if err == nil {
+		return &inFlight, nil
+	}
+
+	return s.enqueue(feeStatementExport(userID, year, month))
+}
+
+// GenerateMonthlyFeeStatements 지난달 수수료/분쟁 스테이킹 내역이 있는 사용자의 명세서를 자동 생성 (이미 있으면 건너뜀)
+func (s *ExportService) GenerateMonthlyFeeStatements(now time.Time) (int, error) {
+	to := time.Date(now.UTC().Year(), now.UTC().Month(), 1, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, -1, 0)
+	year, month := from.Year(), int(from.Month())
+
+	var userIDs []uint
+	collect := func(db *gorm.DB, column string) error {
+		var ids []uint
+		if err := db.Distinct().Pluck(column, &ids).Error; err != nil {
+			return err
+		}
+		userIDs = append(userIDs, ids...)
+		return nil
+	}
+	if err := collect(s.db.Model(&models.Trade{}).Where("buyer_fee > 0 AND created_at >= ? AND created_at < ?", from, to), "buyer_id"); err != nil {
+		return 0, err
+	}
+	if err := collect(s.db.Model(&models.Trade{}).Where("seller_fee > 0 AND created_at >= ? AND created_at < ?", from, to), "seller_id"); err != nil {
+		return 0, err
+	}
+	if err := collect(s.db.Model(&models.WalletLedgerEntry{}).Where("entry_type IN ? AND created_at >= ? AND created_at < ?",
+		feeStatementStakeEntries, from, to), "user_id"); err != nil {
+		return 0, err
+	}
+
+	var existing []uint
+	if err := s.db.Model(&models.DataExport{}).
+		Where("kind = ? AND year = ? AND month = ? AND status != ?", models.DataExportFeeStatement, year, month, models.DataExportFailed).
+		Pluck("user_id", &existing).Error; err != nil {
+		return 0, err
+	}
+	done := make(map[uint]bool, len(existing))
+	for _, userID := range existing {
+		done[userID] = true
+	}
+
+	created := 0
+	for _, userID := range userIDs {
+		if done[userID] {
+			continue
+		}
+		done[userID] = true
+		if _, err := s.publish(feeStatementExport(userID, year, month)); err != nil {
+			log.Printf("❌ Fee statement %d-%02d for user %d failed: %v", year, month, userID, err)
+			continue
+		}
+		created++
+	}
+	if created > 0 {
+		log.Printf("🧾 Queued %d fee statements for %d-%02d", created, year, month)
+	}
+	return created, nil
+}
+
+// feeStatementStakeEntries 수수료 명세서에 넣는 분쟁 스테이킹 원장 종류
+var feeStatementStakeEntries = []models.LedgerEntryType{
+	models.LedgerArbitrationStakeLock,
+	models.LedgerArbitrationStakeReturn,
+	models.LedgerMediationStakeReturn,
+	models.LedgerArbitrationFee,
+	models.LedgerJurorSlash,
+}
+
+func feeStatementExport(userID uint, year, month int) *models.DataExport {
+	return &models.DataExport{
+		UserID: userID,
+		Kind:   models.DataExportFeeStatement,
+		Format: models.DataExportPDF,
+		Year:   year,
+		Month:  month,
+		Status: models.DataExportPending,
+	}
+}
+
+// enqueue 하루 요청 한도 확인 후 요청 저장 및 워커 작업 등록
+func (s *ExportService) enqueue(export *models.DataExport) (*models.DataExport, error) {
+	var today int64
+	s.db.Model(&models.DataExport{}).
+		Where("user_id = ? AND created_at >= ?", export.UserID, time.Now().Add(-24*time.Hour)).
+		Count(&today)
+	if today >= maxExportsPerDay {
+		return nil, ErrExportLimitExceeded
+	}
+	return s.publish(export)
+}
+
+// publish 요청 저장 및 워커 작업 등록
+func (s *ExportService) publish(export *models.DataExport) (*models.DataExport, error) {
+	if err := s.db.Create(export).Error; err != nil {
+		return nil, fmt.Errorf("내보내기 요청 저장 실패: %w", err)
+	}
+
+	job := map[string]interface{}{
+		"type":      "generate_export",
+		"export_id": export.ID,
+		"timestamp": time.Now().Unix(),
+	}
+	if err := queue.PublishJob(ExportQueue, job); err != nil {
+		s.db.Model(export).Updates(map[string]interface{}{
+			"status": models.DataExportFailed,
+			"error":  "작업 큐에 등록하지 못했습니다",
+		})
+		return nil, fmt.Errorf("내보내기 작업 등록 실패: %w", err)
+	}
+
+	return export, nil
+}
+
+// ListExports 내 내보내기 요청 목록 (최신순)
+func (s *ExportService) ListExports(userID uint, limit, offset int) ([]models.DataExport, int64, error) {
+	query := s.db.Model(&models.DataExport{}).Where("user_id = ?", userID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var exports []models.DataExport
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&exports).Error; err != nil {
+		return nil, 0, fmt.Errorf("내보내기 목록 조회 실패: %w", err)
+	}
+	return exports, total, nil
+}
+
+// GetExport 내보내기 요청 조회 (본인 것만)
+func (s *ExportService) GetExport(userID, exportID uint) (*models.DataExport, error) {
+	var export models.DataExport
+	if err := s.db.Where("id = ? AND user_id = ?", exportID, userID).First(&export).Error; err != nil {
+		return nil, ErrExportNotFound
+	}
+	return &export, nil
+}
+
+// OpenExport 완료된 내보내기 파일 열기
+func (s *ExportService) OpenExport(userID, exportID uint) (*os.File, *StoredFile, error) {
+	export, err := s.GetExport(userID, exportID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if export.Status != models.DataExportCompleted {
+		return nil, nil, ErrExportNotReady
+	}
+
+	category, key, err := splitStoragePath(export.FilePath)
+	if err != nil || category != exportFileCategory {
+		return nil, nil, ErrExportNotFound
+	}
+	return s.fileService.OpenFile(category, key)
+}
+
+// CleanupExpired 보관 기한이 지난 파일 삭제 후 expired 처리
+func (s *ExportService) CleanupExpired() (int, error) {
+	var exports []models.DataExport
+	if err := s.db.Where("status = ? AND expires_at < ?", models.DataExportCompleted, time.Now()).
+		Limit(exportCleanupWindow).
+		Find(&exports).Error; err != nil {
+		return 0, err
+	}
+
+	for _, export := range exports {
+		if category, key, err := splitStoragePath(export.FilePath); err == nil {
+			if err := s.fileService.RemoveStoredFile(&StoredFile{Category: category, Key: key}); err != nil && !os.IsNotExist(err) {
+				log.Printf("⚠️ Failed to remove export file %s: %v", export.FilePath, err)
+			}
+		}
+		s.db.Model(&export).Update("status", models.DataExportExpired)
+	}
+	return len(exports), nil
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrUnknownFeatureFlag = errors.New("정의되지 않은 기능 플래그입니다")
+	ErrFeatureDisabled    = errors.New("비활성화된 기능입니다")
+)
+
+// FeatureChecker 기능 플래그 확인 (매칭 엔진/마켓메이커/미들웨어는 이 인터페이스만 의존)
+type FeatureChecker interface {
+	// IsEnabled 사용자와 무관한 전역 확인 (켜짐 여부만 봄)
+	IsEnabled(key models.FeatureFlagKey) bool
+	// IsEnabledFor 사용자별 확인 (허용 목록과 롤아웃 비율 적용)
+	IsEnabledFor(key models.FeatureFlagKey, userID uint) bool
+}
+
+// FeatureFlagService 런타임 기능 플래그 서비스
+//
+// DB가 원본이고 Redis에 전체 스냅샷을 두어 여러 서버가 같은 상태를 본다. 확인은 메모리 스냅샷만 읽으므로
+// 매칭 경로에서도 네트워크를 타지 않고, 다른 서버에서 바꾼 값은 RunRefresh 주기 안에 반영된다.
+type FeatureFlagService struct {
+	db *gorm.DB
+
+	mutex sync.RWMutex
+	flags map[models.FeatureFlagKey]models.FeatureFlag
+}
+
+var _ FeatureChecker = (*FeatureFlagService)(nil)
+
+// NewFeatureFlagService 생성자 (Load 전에는 정의된 기본값으로 동작)
+func NewFeatureFlagService(db *gorm.DB) *FeatureFlagService {
+	s := &FeatureFlagService{db: db}
+	s.apply(nil)
+	return s
+}
+
+// Load DB에서 읽어 메모리 스냅샷과 Redis 공유 스냅샷 갱신 (서버 시작 시, 변경 직후)
+func (s *FeatureFlagService) Load() error {
+	var stored []models.FeatureFlag
+	if err := s.db.Find(&stored).Error; err != nil {
+		return fmt.Errorf("기능 플래그 조회 실패: %w", err)
+	}
+	s.apply(stored)
+
+	if moduleRedis.GetClient() != nil {
+		if err := moduleRedis.SetFeatureFlags(stored); err != nil {
+			log.Printf("⚠️ Failed to share feature flags: %v", err)
+		}
+	}
+	return nil
+}
+
+// Refresh Redis 공유 스냅샷으로 메모리 스냅샷 갱신 (공유 스냅샷이 없거나 Redis 장애면 DB에서)
+func (s *FeatureFlagService) Refresh() error {
+	if moduleRedis.GetClient() != nil {
+		var shared []models.FeatureFlag
+		if err := moduleRedis.GetFeatureFlags(&shared); err == nil {
+			s.apply(shared)
+			return nil
+		}
+	}
+	return s.Load()
+}
+
+// RunRefresh 주기적으로 다른 서버의 변경을 반영 (서버 종료 시까지 실행)
+func (s *FeatureFlagService) RunRefresh(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := s.Refresh(); err != nil {
+			log.Printf("❌ Feature flag refresh failed: %v", err)
+		}
+	}
+}
+
+// apply 정의된 플래그 전체를 기본값으로 채운 뒤 저장된 상태로 덮어씀 (정의에서 빠진 키는 무시)
+func (s *FeatureFlagService) apply(stored []models.FeatureFlag) {
+	flags := make(map[models.FeatureFlagKey]models.FeatureFlag, len(models.FeatureFlagDefinitions))
+	for _, definition := range models.FeatureFlagDefinitions {
+		flags[definition.Key] = models.FeatureFlag{Key: definition.Key, Enabled: definition.Default, RolloutPercent: 100}
+	}
+	for _, flag := range stored {
+		if flag.Key.IsValid() {
+			flags[flag.Key] = flag
+		}
+	}
+
+	s.mutex.Lock()
+	s.flags = flags
+	s.mutex.Unlock()
+}
+
+func (s *FeatureFlagService) get(key models.FeatureFlagKey) (models.FeatureFlag, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	flag, ok := s.flags[key]
+	return flag, ok
+}
+
+// IsEnabled 전역 확인 (정의되지 않은 키는 꺼진 것으로 간주)
+func (s *FeatureFlagService) IsEnabled(key models.FeatureFlagKey) bool {
+	flag, ok := s.get(key)
+	return ok && flag.Enabled
+}
+
+// IsEnabledFor 사용자별 확인
+func (s *FeatureFlagService) IsEnabledFor(key models.FeatureFlagKey, userID uint) bool {
+	flag, ok := s.get(key)
+	return ok && flag.EnabledFor(userID)
+}
+
+// List 정의된 모든 플래그의 현재 상태 (정의 순서)
+func (s *FeatureFlagService) List() []models.FeatureFlag {
+	flags := make([]models.FeatureFlag, 0, len(models.FeatureFlagDefinitions))
+	for _, definition := range models.FeatureFlagDefinitions {
+		flag, _ := s.get(definition.Key)
+		flags = append(flags, flag)
+	}
+	return flags
+}
+
+// EvaluateFor 사용자에게 적용되는 플래그 (클라이언트 화면 분기용)
+func (s *FeatureFlagService) EvaluateFor(userID uint) map[models.FeatureFlagKey]bool {
+	evaluated := make(map[models.FeatureFlagKey]bool, len(models.FeatureFlagDefinitions))
+	for _, definition := range models.FeatureFlagDefinitions {
+		evaluated[definition.Key] = s.IsEnabledFor(definition.Key, userID)
+	}
+	return evaluated
+}
+
+// Update 플래그 변경 (비운 필드는 현재 값 유지, 다른 서버에는 Redis 스냅샷으로 전파)
+func (s *FeatureFlagService) Update(key models.FeatureFlagKey, req models.UpdateFeatureFlagRequest, adminID uint) (*models.FeatureFlag, error) {
+	if !key.IsValid() {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFeatureFlag, key)
+	}
+
+	flag, _ := s.get(key)
+	if req.Enabled != nil {
+		flag.Enabled = *req.Enabled
+	}
+	if req.RolloutPercent != nil {
+		if *req.RolloutPercent < 0 || *req.RolloutPercent > 100 {
+			return nil, errors.New("롤아웃 비율은 0-100 사이여야 합니다")
+		}
+		flag.RolloutPercent = *req.RolloutPercent
+	}
+	if req.AllowUserIDs != nil {
+		flag.AllowUserIDs = req.AllowUserIDs
+	}
+	flag.UpdatedBy = &adminID
+	flag.Reason = req.Reason
+
+	if err := s.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&flag).Error; err != nil {
+		return nil, fmt.Errorf("기능 플래그 저장 실패: %w", err)
+	}
+	if err := s.Load(); err != nil {
+		return nil, err
+	}
+
+	log.Printf("🚩 Feature flag %s set to enabled=%t rollout=%d%% allow=%v by admin %d",
+		key, flag.Enabled, flag.RolloutPercent, flag.AllowUserIDs, adminID)
+	return &flag, nil
+}
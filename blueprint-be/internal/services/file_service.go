@@ -1,64 +1,277 @@
 package services
 
 import (
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 )
 
+var (
+	ErrInvalidFileKey       = errors.New("잘못된 파일 경로입니다")
+	ErrFileSignatureInvalid = errors.New("다운로드 링크가 유효하지 않습니다")
+	ErrFileSignatureExpired = errors.New("다운로드 링크가 만료되었습니다")
+)
+
+// 저장 키/카테고리 형식 (경로 조작 방지)
+var (
+	storageKeyPattern = regexp.MustCompile(`^[a-f0-9]{32}$`)
+	categoryPattern   = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+)
+
+// sensitiveFileCategories 서명된 URL로만 다운로드 가능한 카테고리 (신원/자격 증빙 서류)
+var sensitiveFileCategories = map[string]bool{
+	"verification_docs":    true,
+	"exports":              true, // 거래 내역 내보내기 (소유자 전용 다운로드 API로만 제공)
+	"arbitration_evidence": true, // 분쟁 증거 (당사자/배심원 전용 다운로드 API로만 제공)
+}
+
+// inlineContentTypes 브라우저에서 바로 열어도 안전한 형식 (그 외는 첨부파일로 강제 다운로드)
+var inlineContentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+	"video/mp4":       true,
+}
+
+// StoredFile 저장된 파일 메타데이터 (원본 파일명은 저장 키와 분리하여 보관)
+type StoredFile struct {
+	Category     string    `json:"category"`
+	Key          string    `json:"key"`
+	OriginalName string    `json:"original_name"`
+	ContentType  string    `json:"content_type"` // 업로드 내용에서 감지한 형식 (클라이언트 헤더 무시)
+	Size         int64     `json:"size"`
+	SHA256       string    `json:"sha256,omitempty"` // 저장한 내용의 해시 (워커 무결성 확인용)
+	UploadedAt   time.Time `json:"uploaded_at"`
+}
+
+// Path 카테고리/키 형태의 저장 경로 (DB 저장용)
+func (f *StoredFile) Path() string {
+	return f.Category + "/" + f.Key
+}
+
+// Inline 브라우저 인라인 표시 허용 여부
+func (f *StoredFile) Inline() bool {
+	return inlineContentTypes[f.ContentType]
+}
+
 // FileService 파일 업로드 및 관리 서비스
 type FileService struct {
-	uploadPath string
-	baseURL    string
+	uploadPath    string
+	baseURL       string
+	signingSecret []byte
 }
 
 // NewFileService 생성자
-func NewFileService(uploadPath, baseURL string) *FileService {
+func NewFileService(uploadPath, baseURL, signingSecret string) *FileService {
 	// 업로드 디렉토리 생성
 	os.MkdirAll(uploadPath, 0755)
-	
+
 	return &FileService{
-		uploadPath: uploadPath,
-		baseURL:    baseURL,
+		uploadPath:    uploadPath,
+		baseURL:       baseURL,
+		signingSecret: []byte(signingSecret),
 	}
 }
 
-// UploadFile 파일 업로드
+// IsSensitiveCategory 서명된 URL이 필요한 카테고리인지 확인
+func (s *FileService) IsSensitiveCategory(category string) bool {
+	return sensitiveFileCategories[category]
+}
+
+// UploadFile 파일 업로드 후 다운로드 URL 반환 (민감 카테고리는 만료되지 않는 URL을 발급하지 않음)
 func (s *FileService) UploadFile(file multipart.File, header *multipart.FileHeader, category string) (string, error) {
-	// 파일 확장자 추출
-	ext := filepath.Ext(header.Filename)
-	
-	// 고유한 파일명 생성
+	stored, err := s.StoreFile(file, header, category)
+	if err != nil {
+		return "", err
+	}
+	if s.IsSensitiveCategory(category) {
+		return stored.Path(), nil
+	}
+	return s.FileURL(stored.Category, stored.Key), nil
+}
+
+// StoreFile 파일 저장 (무작위 저장 키 + 내용 기반 형식 감지)
+func (s *FileService) StoreFile(file multipart.File, header *multipart.FileHeader, category string) (*StoredFile, error) {
+	if !categoryPattern.MatchString(category) {
+		return nil, ErrInvalidFileKey
+	}
+
+	// 고유한 저장 키 생성 (원본 파일명/확장자와 무관)
 	randBytes := make([]byte, 16)
-	rand.Read(randBytes)
-	filename := fmt.Sprintf("%x_%d%s", randBytes, time.Now().Unix(), ext)
-	
+	if _, err := rand.Read(randBytes); err != nil {
+		return nil, fmt.Errorf("저장 키 생성 실패: %w", err)
+	}
+	key := hex.EncodeToString(randBytes)
+
+	// 내용 기반 형식 감지 (클라이언트가 보낸 Content-Type은 신뢰하지 않음)
+	sniff := make([]byte, 512)
+	n, err := io.ReadFull(file, sniff)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, fmt.Errorf("파일 읽기 실패: %w", err)
+	}
+	contentType := http.DetectContentType(sniff[:n])
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("파일 읽기 실패: %w", err)
+	}
+
 	// 카테고리별 디렉토리 생성
 	categoryPath := filepath.Join(s.uploadPath, category)
-	os.MkdirAll(categoryPath, 0755)
-	
-	// 파일 경로
-	filePath := filepath.Join(categoryPath, filename)
-	
+	if err := os.MkdirAll(categoryPath, 0755); err != nil {
+		return nil, fmt.Errorf("디렉토리 생성 실패: %w", err)
+	}
+
 	// 파일 저장
-	dst, err := os.Create(filePath)
+	dst, err := os.OpenFile(filepath.Join(categoryPath, key), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0640)
 	if err != nil {
-		return "", fmt.Errorf("파일 생성 실패: %w", err)
+		return nil, fmt.Errorf("파일 생성 실패: %w", err)
 	}
 	defer dst.Close()
-	
-	// 파일 내용 복사
-	if _, err := io.Copy(dst, file); err != nil {
-		return "", fmt.Errorf("파일 저장 실패: %w", err)
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(dst, hash), file)
+	if err != nil {
+		return nil, fmt.Errorf("파일 저장 실패: %w", err)
+	}
+
+	stored := &StoredFile{
+		Category:     category,
+		Key:          key,
+		OriginalName: filepath.Base(header.Filename),
+		ContentType:  contentType,
+		Size:         size,
+		SHA256:       hex.EncodeToString(hash.Sum(nil)),
+		UploadedAt:   time.Now(),
+	}
+
+	// 메타데이터 저장 (다운로드 시 Content-Type/Content-Disposition 결정)
+	meta, err := json.Marshal(stored)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(categoryPath, key+".json"), meta, 0640); err != nil {
+		return nil, fmt.Errorf("파일 메타데이터 저장 실패: %w", err)
+	}
+
+	return stored, nil
+}
+
+// OpenFile 저장된 파일과 메타데이터 조회
+func (s *FileService) OpenFile(category, key string) (*os.File, *StoredFile, error) {
+	if !categoryPattern.MatchString(category) || !storageKeyPattern.MatchString(key) {
+		return nil, nil, ErrInvalidFileKey
+	}
+
+	filePath := filepath.Join(s.uploadPath, category, key)
+
+	meta, err := os.ReadFile(filePath + ".json")
+	if err != nil {
+		return nil, nil, err
 	}
-	
-	// 접근 가능한 URL 반환
-	fileURL := fmt.Sprintf("%s/%s/%s", s.baseURL, category, filename)
-	return fileURL, nil
+	var stored StoredFile
+	if err := json.Unmarshal(meta, &stored); err != nil {
+		return nil, nil, fmt.Errorf("파일 메타데이터 손상: %w", err)
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, &stored, nil
+}
+
+// FileURL 공개 다운로드 URL
+func (s *FileService) FileURL(category, key string) string {
+	return fmt.Sprintf("%s/%s/%s", s.baseURL, category, key)
+}
+
+// LookupFileURL FileURL로 발급한 공개 다운로드 URL을 저장된 파일 메타데이터로 되돌림
+// (다른 서버의 URL이나 존재하지 않는 파일은 ErrInvalidFileKey)
+func (s *FileService) LookupFileURL(fileURL string) (*StoredFile, error) {
+	rest, ok := strings.CutPrefix(fileURL, s.baseURL+"/")
+	if !ok {
+		return nil, ErrInvalidFileKey
+	}
+	category, key, err := splitStoragePath(rest)
+	if err != nil {
+		return nil, err
+	}
+
+	file, stored, err := s.OpenFile(category, key)
+	if err != nil {
+		return nil, ErrInvalidFileKey
+	}
+	file.Close()
+	return stored, nil
+}
+
+// SignedURL 만료 시간이 있는 서명된 다운로드 URL 생성
+func (s *FileService) SignedURL(path string, ttl time.Duration) (string, error) {
+	category, key, err := splitStoragePath(path)
+	if err != nil {
+		return "", err
+	}
+
+	expires := time.Now().Add(ttl).Unix()
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.sign(category, key, expires))
+
+	return s.FileURL(category, key) + "?" + query.Encode(), nil
+}
+
+// VerifySignature 서명된 다운로드 URL 검증
+func (s *FileService) VerifySignature(category, key, expiresParam, signature string) error {
+	expires, err := strconv.ParseInt(expiresParam, 10, 64)
+	if err != nil || signature == "" {
+		return ErrFileSignatureInvalid
+	}
+
+	expected := s.sign(category, key, expires)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrFileSignatureInvalid
+	}
+	if time.Now().Unix() > expires {
+		return ErrFileSignatureExpired
+	}
+	return nil
+}
+
+func (s *FileService) sign(category, key string, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingSecret)
+	fmt.Fprintf(mac, "%s/%s:%d", category, key, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// splitStoragePath "category/key" 경로 분리 및 검증
+func splitStoragePath(path string) (string, string, error) {
+	category, key, _ := strings.Cut(path, "/")
+	if !categoryPattern.MatchString(category) || !storageKeyPattern.MatchString(key) {
+		return "", "", ErrInvalidFileKey
+	}
+	return category, key, nil
+}
+
+// RemoveStoredFile 저장된 파일과 메타데이터 삭제
+func (s *FileService) RemoveStoredFile(stored *StoredFile) error {
+	filePath := filepath.Join(s.uploadPath, stored.Category, stored.Key)
+	os.Remove(filePath + ".json")
+	return os.Remove(filePath)
 }
 
 // DeleteFile 파일 삭제
@@ -69,4 +282,4 @@ func (s *FileService) DeleteFile(filePath string) error {
 // GetFileInfo 파일 정보 조회
 func (s *FileService) GetFileInfo(filePath string) (os.FileInfo, error) {
 	return os.Stat(filePath)
-}
\ No newline at end of file
+}
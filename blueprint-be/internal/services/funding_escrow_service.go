@@ -0,0 +1,261 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrEscrowNotAccepting 펀딩/진행 중이 아닌 마일스톤에 대한 후원
+	ErrEscrowNotAccepting = errors.New("후원을 받을 수 없는 마일스톤입니다")
+	// ErrEscrowSelfBacking 프로젝트 소유자가 자기 마일스톤을 후원
+	ErrEscrowSelfBacking = errors.New("자신의 프로젝트 마일스톤은 후원할 수 없습니다")
+)
+
+// MilestoneEscrowSummary 마일스톤 후원 에스크로 현황 (센트)
+type MilestoneEscrowSummary struct {
+	MilestoneID    uint  `json:"milestone_id"`
+	LockedAmount   int64 `json:"locked_amount"`
+	ReleasedAmount int64 `json:"released_amount"`
+	RefundedAmount int64 `json:"refunded_amount"`
+	BackerCount    int64 `json:"backer_count"`
+}
+
+// FundingEscrowService 마일스톤 직접 후원 에스크로
+//
+// 거래와 별개로 후원자가 마일스톤에 USDC를 잠근다. 상태 머신 훅이 완료 확정 시 프로젝트 소유자에게
+// 지급(ReleaseMilestone)하고, 펀딩 실패/마일스톤 실패/취소 시 후원자에게 반환(RefundMilestone)한다.
+// 잔액 변경은 모두 원장(escrow_*)에 남고, 예치액은 펀딩 검증 TVL에 더해진다.
+type FundingEscrowService struct {
+	db             *gorm.DB
+	fundingService *FundingVerificationService // 예치액 TVL 반영 (nil이면 생략)
+	responsible    *ResponsibleTradingService  // 사용자 일일 예치 한도/휴식/자기 배제 (nil이면 검사 생략)
+}
+
+// NewFundingEscrowService 생성자
+func NewFundingEscrowService(db *gorm.DB, fundingService *FundingVerificationService) *FundingEscrowService {
+	return &FundingEscrowService{
+		db:             db,
+		fundingService: fundingService,
+	}
+}
+
+// SetResponsibleTrading 예치 전에 사용자 일일 예치 한도와 휴식/자기 배제 검사
+func (s *FundingEscrowService) SetResponsibleTrading(responsible *ResponsibleTradingService) {
+	s.responsible = responsible
+}
+
+// Deposit 마일스톤 후원 예치 (사용 가능 잔액 → 잠금)
+func (s *FundingEscrowService) Deposit(userID, milestoneID uint, amount int64) (*models.FundingEscrow, error) {
+	if s.responsible != nil {
+		if err := s.responsible.CheckDeposit(userID, amount); err != nil {
+			return nil, err
+		}
+	}
+
+	var escrow *models.FundingEscrow
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var milestone models.Milestone
+		if err := tx.Select("id", "project_id", "status").First(&milestone, milestoneID).Error; err != nil {
+			return err
+		}
+		if milestone.Status != models.MilestoneStatusFunding && milestone.Status != models.MilestoneStatusActive {
+			return fmt.Errorf("%w: %s", ErrEscrowNotAccepting, milestone.Status)
+		}
+
+		var ownerID uint
+		if err := tx.Model(&models.Project{}).Where("id = ?", milestone.ProjectID).Pluck("user_id", &ownerID).Error; err != nil {
+			return fmt.Errorf("프로젝트 조회 실패: %w", err)
+		}
+		if ownerID == userID {
+			return ErrEscrowSelfBacking
+		}
+
+		escrow = &models.FundingEscrow{
+			MilestoneID: milestone.ID,
+			ProjectID:   milestone.ProjectID,
+			BackerID:    userID,
+			Amount:      amount,
+			Status:      models.FundingEscrowLocked,
+		}
+		if err := tx.Create(escrow).Error; err != nil {
+			return fmt.Errorf("후원 저장 실패: %w", err)
+		}
+
+		if err := postLedgerEntry(tx, escrowLedgerEntry(escrow, models.WalletLedgerEntry{
+			UserID:       userID,
+			EntryType:    models.LedgerEscrowLock,
+			Amount:       -amount,
+			LockedAmount: amount,
+			Memo:         fmt.Sprintf("마일스톤 %d 후원 예치", milestone.ID),
+		})); err != nil {
+			return err
+		}
+
+		// 잔액 확인은 증분 반영 후에 해서 동시 예치/주문과 겹쳐도 음수 잔액을 남기지 않음
+		var wallet models.UserWallet
+		if err := tx.Select("usdc_balance").Where("user_id = ?", userID).First(&wallet).Error; err != nil {
+			return fmt.Errorf("지갑 조회 실패: %w", err)
+		}
+		if wallet.USDCBalance < 0 {
+			return fmt.Errorf("%w: 필요 $%.2f", ErrInsufficientBalance, float64(amount)/100)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🤝 User %d escrowed $%.2f for milestone %d", userID, float64(amount)/100, milestoneID)
+	if s.fundingService != nil {
+		if err := s.fundingService.UpdateTVL(milestoneID, "escrow", amount); err != nil {
+			log.Printf("⚠️ Failed to add escrow to TVL for milestone %d: %v", milestoneID, err)
+		}
+	}
+	return escrow, nil
+}
+
+// ReleaseMilestone 완료 확정된 마일스톤의 잠긴 후원금을 프로젝트 소유자에게 지급
+func (s *FundingEscrowService) ReleaseMilestone(milestoneID uint) (int, error) {
+	var ownerID uint
+	if err := s.db.Model(&models.Project{}).
+		Joins("JOIN milestones ON milestones.project_id = projects.id").
+		Where("milestones.id = ?", milestoneID).
+		Pluck("projects.user_id", &ownerID).Error; err != nil {
+		return 0, fmt.Errorf("프로젝트 소유자 조회 실패: %w", err)
+	}
+	if ownerID == 0 {
+		return 0, fmt.Errorf("마일스톤 %d의 프로젝트 소유자를 찾을 수 없습니다", milestoneID)
+	}
+
+	return s.resolve(milestoneID, models.FundingEscrowReleased, &ownerID, func(tx *gorm.DB, escrow *models.FundingEscrow) error {
+		if err := postLedgerEntry(tx, escrowLedgerEntry(escrow, models.WalletLedgerEntry{
+			UserID:       escrow.BackerID,
+			EntryType:    models.LedgerEscrowRelease,
+			LockedAmount: -escrow.Amount,
+			Memo:         fmt.Sprintf("마일스톤 %d 완료로 후원금 지급", milestoneID),
+		})); err != nil {
+			return err
+		}
+		return postLedgerEntry(tx, escrowLedgerEntry(escrow, models.WalletLedgerEntry{
+			UserID:    ownerID,
+			EntryType: models.LedgerEscrowPayout,
+			Amount:    escrow.Amount,
+			Memo:      fmt.Sprintf("마일스톤 %d 후원금 수령", milestoneID),
+		}))
+	})
+}
+
+// RefundMilestone 펀딩 실패/실패/취소된 마일스톤의 잠긴 후원금을 후원자에게 반환
+func (s *FundingEscrowService) RefundMilestone(milestoneID uint) (int, error) {
+	return s.resolve(milestoneID, models.FundingEscrowRefunded, nil, func(tx *gorm.DB, escrow *models.FundingEscrow) error {
+		return postLedgerEntry(tx, escrowLedgerEntry(escrow, models.WalletLedgerEntry{
+			UserID:       escrow.BackerID,
+			EntryType:    models.LedgerEscrowRefund,
+			Amount:       escrow.Amount,
+			LockedAmount: -escrow.Amount,
+			Memo:         fmt.Sprintf("마일스톤 %d 후원 반환", milestoneID),
+		}))
+	})
+}
+
+// resolve 잠긴 후원을 한 건씩 상태를 선점한 뒤 지급/반환 (중복 실행돼도 한 번만 처리)
+func (s *FundingEscrowService) resolve(milestoneID uint, status models.FundingEscrowStatus, recipientID *uint, settle func(tx *gorm.DB, escrow *models.FundingEscrow) error) (int, error) {
+	var escrows []models.FundingEscrow
+	if err := s.db.Where("milestone_id = ? AND status = ?", milestoneID, models.FundingEscrowLocked).
+		Order("id ASC").Find(&escrows).Error; err != nil {
+		return 0, fmt.Errorf("후원 조회 실패: %w", err)
+	}
+
+	resolved := 0
+	var total int64
+	for i := range escrows {
+		escrow := &escrows[i]
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			result := tx.Model(&models.FundingEscrow{}).
+				Where("id = ? AND status = ?", escrow.ID, models.FundingEscrowLocked).
+				Updates(map[string]interface{}{
+					"status":       status,
+					"recipient_id": recipientID,
+					"resolved_at":  time.Now(),
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return nil
+			}
+			if err := settle(tx, escrow); err != nil {
+				return err
+			}
+			resolved++
+			total += escrow.Amount
+			return nil
+		})
+		if err != nil {
+			return resolved, fmt.Errorf("후원 %d 처리 실패: %w", escrow.ID, err)
+		}
+	}
+
+	if resolved > 0 {
+		log.Printf("🤝 Milestone %d escrow %s: %d backings, $%.2f", milestoneID, status, resolved, float64(total)/100)
+	}
+	return resolved, nil
+}
+
+// GetMilestoneEscrow 마일스톤 후원 현황
+func (s *FundingEscrowService) GetMilestoneEscrow(milestoneID uint) (*MilestoneEscrowSummary, error) {
+	var rows []struct {
+		Status models.FundingEscrowStatus
+		Total  int64
+	}
+	if err := s.db.Model(&models.FundingEscrow{}).
+		Select("status, COALESCE(SUM(amount), 0) AS total").
+		Where("milestone_id = ?", milestoneID).
+		Group("status").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("후원 현황 조회 실패: %w", err)
+	}
+
+	summary := &MilestoneEscrowSummary{MilestoneID: milestoneID}
+	for _, row := range rows {
+		switch row.Status {
+		case models.FundingEscrowLocked:
+			summary.LockedAmount = row.Total
+		case models.FundingEscrowReleased:
+			summary.ReleasedAmount = row.Total
+		case models.FundingEscrowRefunded:
+			summary.RefundedAmount = row.Total
+		}
+	}
+	if err := s.db.Model(&models.FundingEscrow{}).Where("milestone_id = ?", milestoneID).
+		Distinct("backer_id").Count(&summary.BackerCount).Error; err != nil {
+		return nil, fmt.Errorf("후원자 수 조회 실패: %w", err)
+	}
+	return summary, nil
+}
+
+// GetMyEscrows 내 후원 내역 (최신순)
+func (s *FundingEscrowService) GetMyEscrows(userID uint, limit, offset int) ([]models.FundingEscrow, error) {
+	var escrows []models.FundingEscrow
+	if err := s.db.Where("backer_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&escrows).Error; err != nil {
+		return nil, fmt.Errorf("후원 내역 조회 실패: %w", err)
+	}
+	return escrows, nil
+}
+
+// escrowLedgerEntry 후원 건을 참조하는 USDC 원장 항목
+func escrowLedgerEntry(escrow *models.FundingEscrow, entry models.WalletLedgerEntry) *models.WalletLedgerEntry {
+	entry.Currency = models.LedgerCurrencyUSDC
+	entry.ReferenceType = "funding_escrow"
+	entry.ReferenceID = escrow.ID
+	return &entry
+}
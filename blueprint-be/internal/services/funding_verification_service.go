@@ -2,6 +2,7 @@ package services
 
 import (
 	"blueprint-module/pkg/models"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -12,18 +13,27 @@ import (
 
 // 🏛️ 마일스톤 시장성 검증 서비스 (Market Viability Verification)
 type FundingVerificationService struct {
-	db         *gorm.DB
-	sseService *SSEService
+	db           *gorm.DB
+	sseService   *SSEService
+	stateMachine *MilestoneStateMachine
+
+	matchingEngine MatchingEngine // 펀딩 실패 시 주문장에서 주문 제거 (선택)
 }
 
 // NewFundingVerificationService 펀딩 검증 서비스 생성자
 func NewFundingVerificationService(db *gorm.DB, sseService *SSEService) *FundingVerificationService {
 	return &FundingVerificationService{
-		db:         db,
-		sseService: sseService,
+		db:           db,
+		sseService:   sseService,
+		stateMachine: NewMilestoneStateMachine(db),
 	}
 }
 
+// SetMatchingEngine 펀딩 실패 환불 시 주문장에서도 주문을 제거하도록 매칭 엔진 연결
+func (fv *FundingVerificationService) SetMatchingEngine(engine MatchingEngine) {
+	fv.matchingEngine = engine
+}
+
 // StartFundingPhase 마일스톤의 펀딩 단계 시작
 func (fv *FundingVerificationService) StartFundingPhase(milestoneID uint) error {
 	log.Printf("🚀 Starting funding phase for milestone %d", milestoneID)
@@ -49,7 +59,25 @@ func (fv *FundingVerificationService) StartFundingPhase(milestoneID uint) error
 		return fmt.Errorf("milestone %d is not in proposal status (current: %s)", milestoneID, milestone.Status)
 	}
 
+	// 선행 마일스톤이 모두 완료되어야 마켓을 연다
+	unmet, err := UnmetDependencies(tx, milestoneID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to check milestone dependencies: %v", err)
+	}
+	if len(unmet) > 0 {
+		tx.Rollback()
+		return fmt.Errorf("%w: %s", ErrMilestoneDependenciesUnmet, unmet[0].Title)
+	}
+
 	// 펀딩 단계 시작
+	transition, err := fv.stateMachine.Transition(tx, &milestone, models.MilestoneStatusFunding, TransitionOptions{
+		Reason: "펀딩 단계 시작",
+	})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
 	milestone.StartFundingPhase()
 
 	// 카테고리별 최소 자본 요구액 설정
@@ -69,6 +97,7 @@ func (fv *FundingVerificationService) StartFundingPhase(milestoneID uint) error
 	if err := tx.Commit().Error; err != nil {
 		return fmt.Errorf("failed to commit transaction: %v", err)
 	}
+	fv.stateMachine.Dispatch(transition)
 
 	log.Printf("✅ Funding phase started for milestone %d (MVC: $%.2f, Duration: %d days)",
 		milestoneID, float64(milestone.MinViableCapital)/100, milestone.FundingDuration)
@@ -85,6 +114,7 @@ func (fv *FundingVerificationService) StartFundingPhase(milestoneID uint) error
 }
 
 // UpdateTVL 마일스톤의 총 베팅액 업데이트 (거래 발생 시 호출)
+// 동시 체결에도 누락이 없도록 증분 UPDATE로 반영하고, 목표액을 넘어서는 순간에만 달성 이벤트를 보낸다
 func (fv *FundingVerificationService) UpdateTVL(milestoneID uint, optionID string, additionalAmount int64) error {
 	tx := fv.db.Begin()
 	defer func() {
@@ -93,50 +123,65 @@ func (fv *FundingVerificationService) UpdateTVL(milestoneID uint, optionID strin
 		}
 	}()
 
+	result := tx.Model(&models.Milestone{}).Where("id = ?", milestoneID).
+		UpdateColumn("current_tvl", gorm.Expr("current_tvl + ?", additionalAmount))
+	if result.Error != nil {
+		tx.Rollback()
+		// 컬럼이 존재하지 않는 경우 로그만 남기고 넘어감
+		if fv.isColumnNotExistsError(result.Error) {
+			log.Printf("📋 Funding columns not available - skipping TVL update for milestone %d", milestoneID)
+			return nil
+		}
+		return fmt.Errorf("failed to update milestone TVL: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return fmt.Errorf("milestone not found: %d", milestoneID)
+	}
+
+	// 행 잠금이 걸린 상태에서 다시 읽어 이번 증분 직전/직후 값을 확정
 	var milestone models.Milestone
-	if err := tx.Where("id = ?", milestoneID).First(&milestone).Error; err != nil {
+	if err := tx.Select("id", "status", "current_tvl", "min_viable_capital").
+		Where("id = ?", milestoneID).First(&milestone).Error; err != nil {
 		tx.Rollback()
 		return fmt.Errorf("milestone not found: %v", err)
 	}
-
-	// TVL 업데이트 (새 컬럼이 없는 경우 gracefully 처리)
-	milestone.CurrentTVL += additionalAmount
+	previousTVL := milestone.CurrentTVL - additionalAmount
 	milestone.FundingProgress = milestone.CalculateFundingProgress()
 
-	if err := tx.Save(&milestone).Error; err != nil {
-		// 컬럼이 존재하지 않는 경우 로그만 남기고 넘어감
-		if fv.isColumnNotExistsError(err) {
-			tx.Rollback()
-			log.Printf("📋 Funding columns not available - skipping TVL update for milestone %d", milestoneID)
-			return nil
-		}
+	if err := tx.Model(&models.Milestone{}).Where("id = ?", milestoneID).
+		UpdateColumn("funding_progress", milestone.FundingProgress).Error; err != nil {
 		tx.Rollback()
-		return fmt.Errorf("failed to update milestone TVL: %v", err)
+		return fmt.Errorf("failed to update funding progress: %v", err)
 	}
 
 	if err := tx.Commit().Error; err != nil {
 		return fmt.Errorf("failed to commit transaction: %v", err)
 	}
 
-	log.Printf("📊 TVL updated for milestone %d: $%.2f (+$%.2f)",
-		milestoneID, float64(milestone.CurrentTVL)/100, float64(additionalAmount)/100)
+	log.Printf("📊 TVL updated for milestone %d (%s): $%.2f (+$%.2f)",
+		milestoneID, optionID, float64(milestone.CurrentTVL)/100, float64(additionalAmount)/100)
 
-	// 펀딩 목표 달성 확인
-	if milestone.Status == models.MilestoneStatusFunding && milestone.HasReachedMinViableCapital() {
+	// 펀딩 목표 돌파 시점에만 알림
+	if milestone.Status == models.MilestoneStatusFunding && milestone.MinViableCapital > 0 &&
+		previousTVL < milestone.MinViableCapital && milestone.HasReachedMinViableCapital() {
 		log.Printf("🎉 Milestone %d has reached minimum viable capital!", milestoneID)
 		fv.broadcastFundingUpdate(milestoneID, "funding_target_reached", map[string]interface{}{
-			"milestone_id":     milestoneID,
-			"current_tvl":      milestone.CurrentTVL,
-			"funding_progress": milestone.FundingProgress,
+			"milestone_id":       milestoneID,
+			"current_tvl":        milestone.CurrentTVL,
+			"min_viable_capital": milestone.MinViableCapital,
+			"funding_progress":   milestone.FundingProgress,
 		})
 	}
 
 	// 실시간 진행률 업데이트
 	fv.broadcastFundingUpdate(milestoneID, "tvl_updated", map[string]interface{}{
-		"milestone_id":      milestoneID,
-		"current_tvl":       milestone.CurrentTVL,
-		"funding_progress":  milestone.FundingProgress,
-		"additional_amount": additionalAmount,
+		"milestone_id":       milestoneID,
+		"option_id":          optionID,
+		"current_tvl":        milestone.CurrentTVL,
+		"min_viable_capital": milestone.MinViableCapital,
+		"funding_progress":   milestone.FundingProgress,
+		"additional_amount":  additionalAmount,
 	})
 
 	return nil
@@ -179,9 +224,10 @@ func (fv *FundingVerificationService) processSingleExpiredMilestone(milestone *m
 		}
 	}()
 
+	var nextStatus models.MilestoneStatus
 	if milestone.HasReachedMinViableCapital() {
 		// ✅ 펀딩 성공: 활성화
-		milestone.Status = models.MilestoneStatusActive
+		nextStatus = models.MilestoneStatusActive
 		log.Printf("✅ Milestone %d FUNDED successfully (TVL: $%.2f)",
 			milestone.ID, float64(milestone.CurrentTVL)/100)
 
@@ -193,7 +239,7 @@ func (fv *FundingVerificationService) processSingleExpiredMilestone(milestone *m
 
 	} else {
 		// ❌ 펀딩 실패: 거부 및 자금 반환 처리
-		milestone.Status = models.MilestoneStatusRejected
+		nextStatus = models.MilestoneStatusRejected
 		log.Printf("❌ Milestone %d REJECTED due to insufficient funding (TVL: $%.2f, Required: $%.2f)",
 			milestone.ID, float64(milestone.CurrentTVL)/100, float64(milestone.MinViableCapital)/100)
 
@@ -208,14 +254,18 @@ func (fv *FundingVerificationService) processSingleExpiredMilestone(milestone *m
 		})
 	}
 
-	if err := tx.Save(milestone).Error; err != nil {
+	transition, err := fv.stateMachine.Transition(tx, milestone, nextStatus, TransitionOptions{
+		Reason: "펀딩 기간 종료",
+	})
+	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("failed to update milestone status: %v", err)
+		return fmt.Errorf("failed to update milestone status: %w", err)
 	}
 
 	if err := tx.Commit().Error; err != nil {
 		return fmt.Errorf("failed to commit transaction: %v", err)
 	}
+	fv.stateMachine.Dispatch(transition)
 
 	return nil
 }
@@ -243,51 +293,33 @@ func (fv *FundingVerificationService) refundFailedFunding(milestoneID uint) {
 	log.Printf("✅ Completed refunds for %d orders", len(orders))
 }
 
-// refundOrderAmount 개별 주문의 자금 반환
+// refundOrderAmount 개별 주문 취소 및 미체결분 매수 잠금 반환 (매도 주문은 취소만)
 func (fv *FundingVerificationService) refundOrderAmount(order *models.Order) error {
-	if order.Side != models.OrderSideBuy {
-		return nil // 매도 주문은 자금이 잠겨있지 않음
-	}
-
-	refundAmount := int64(float64(order.Remaining) * order.Price * 100) // 미체결 부분만 반환
-
-	// 지갑 업데이트
-	tx := fv.db.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+	if fv.matchingEngine != nil {
+		if err := fv.matchingEngine.CancelOrder(order); err != nil {
+			return err
 		}
-	}()
-
-	var wallet models.UserWallet
-	if err := tx.Where("user_id = ?", order.UserID).First(&wallet).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("wallet not found for user %d: %v", order.UserID, err)
+		fv.matchingEngine.FlushOrderStates()
 	}
 
-	// 잠긴 잔액을 가용 잔액으로 이동
-	wallet.USDCLockedBalance -= refundAmount
-	wallet.USDCBalance += refundAmount
-
-	if err := tx.Save(&wallet).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to update wallet: %v", err)
+	var cancelled *models.Order
+	err := fv.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		cancelled, err = closeOrder(tx, order.ID, models.OrderStatusCancelled)
+		return err
+	})
+	if errors.Is(err, ErrOrderNotOpen) {
+		return nil // 그 사이 전량 체결 또는 사용자 취소
 	}
-
-	// 주문 상태를 취소로 변경
-	order.Status = models.OrderStatusCancelled
-	if err := tx.Save(order).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to cancel order: %v", err)
+	if err != nil {
+		return fmt.Errorf("failed to cancel order: %w", err)
 	}
+	publishOrderClosed(cancelled)
 
-	if err := tx.Commit().Error; err != nil {
-		return fmt.Errorf("failed to commit refund transaction: %v", err)
+	if refundAmount := cancelled.LockedCents(); refundAmount > 0 {
+		log.Printf("💰 Refunded $%.2f to user %d for cancelled order %d",
+			float64(refundAmount)/100, cancelled.UserID, cancelled.ID)
 	}
-
-	log.Printf("💰 Refunded $%.2f to user %d for cancelled order %d",
-		float64(refundAmount)/100, order.UserID, order.ID)
-
 	return nil
 }
 
@@ -357,35 +389,253 @@ func (fv *FundingVerificationService) GetFundingStats(milestoneID uint) (*Fundin
 	}
 
 	stats := &FundingStats{
-		MilestoneID:      milestoneID,
-		Status:           milestone.Status,
-		CurrentTVL:       milestone.CurrentTVL,
-		MinViableCapital: milestone.MinViableCapital,
-		FundingProgress:  milestone.FundingProgress,
-		FundingStartDate: milestone.FundingStartDate,
-		FundingEndDate:   milestone.FundingEndDate,
-		FundingDuration:  milestone.FundingDuration,
-		IsActive:         milestone.IsFundingActive(),
-		IsExpired:        milestone.IsFundingExpired(),
-		HasReachedTarget: milestone.HasReachedMinViableCapital(),
+		MilestoneID:       milestoneID,
+		Status:            milestone.Status,
+		CurrentTVL:        milestone.CurrentTVL,
+		MinViableCapital:  milestone.MinViableCapital,
+		RemainingAmount:   remainingFunding(&milestone),
+		FundingProgress:   milestone.FundingProgress,
+		FundingStartDate:  milestone.FundingStartDate,
+		FundingEndDate:    milestone.FundingEndDate,
+		FundingDuration:   milestone.FundingDuration,
+		IsActive:          milestone.IsFundingActive(),
+		IsExpired:         milestone.IsFundingExpired(),
+		HasReachedTarget:  milestone.HasReachedMinViableCapital(),
+		VerificationState: fundingVerificationState(&milestone),
+		OptionTVL:         []OptionTVL{},
+	}
+
+	// 옵션별 체결 금액 (진행률 바 세분화용)
+	if err := fv.db.Model(&models.Trade{}).
+		Select("option_id, COALESCE(SUM(total_amount), 0) AS tvl, COUNT(*) AS trade_count").
+		Where("milestone_id = ?", milestoneID).
+		Group("option_id").
+		Order("tvl DESC").
+		Scan(&stats.OptionTVL).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate option TVL: %v", err)
+	}
+
+	// 거래와 별개로 예치된 직접 후원 (UpdateTVL로 CurrentTVL에 이미 포함)
+	if err := fv.db.Model(&models.FundingEscrow{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("milestone_id = ?", milestoneID).
+		Scan(&stats.EscrowTVL).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate escrow TVL: %v", err)
+	}
+	if err := fv.db.Model(&models.FundingEscrow{}).
+		Where("milestone_id = ?", milestoneID).
+		Distinct("backer_id").Count(&stats.EscrowBackers).Error; err != nil {
+		return nil, fmt.Errorf("failed to count escrow backers: %v", err)
 	}
 
 	return stats, nil
 }
 
+// GetFundingMilestones 펀딩 진행 중인 마일스톤 목록 (sort: ending_soon | progress | tvl)
+func (fv *FundingVerificationService) GetFundingMilestones(category, sort string, limit, offset int) ([]FundingMilestoneSummary, int64, error) {
+	query := fv.db.Table("milestones").
+		Joins("JOIN projects ON projects.id = milestones.project_id").
+		Where("milestones.status = ? AND milestones.deleted_at IS NULL AND projects.deleted_at IS NULL", models.MilestoneStatusFunding)
+	if category != "" {
+		query = query.Where("projects.category = ?", category)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		if fv.isColumnNotExistsError(err) {
+			return []FundingMilestoneSummary{}, 0, nil
+		}
+		return nil, 0, fmt.Errorf("failed to count funding milestones: %v", err)
+	}
+
+	order := "milestones.funding_end_date ASC"
+	switch sort {
+	case "progress":
+		order = "milestones.funding_progress DESC"
+	case "tvl":
+		order = "milestones.current_tvl DESC"
+	}
+
+	summaries := []FundingMilestoneSummary{}
+	if err := query.Select(`milestones.id AS milestone_id, milestones.project_id, milestones.title,
+			projects.title AS project_title, projects.category, milestones.current_tvl,
+			milestones.min_viable_capital, milestones.funding_progress, milestones.funding_end_date`).
+		Order(order).Order("milestones.id ASC").
+		Limit(limit).Offset(offset).
+		Scan(&summaries).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to query funding milestones: %v", err)
+	}
+
+	for i := range summaries {
+		summary := &summaries[i]
+		summary.HasReachedTarget = summary.MinViableCapital > 0 && summary.CurrentTVL >= summary.MinViableCapital
+		if summary.CurrentTVL < summary.MinViableCapital {
+			summary.RemainingAmount = summary.MinViableCapital - summary.CurrentTVL
+		}
+		if summary.FundingEndDate != nil {
+			if remaining := time.Until(*summary.FundingEndDate); remaining > 0 {
+				summary.SecondsRemaining = int64(remaining.Seconds())
+			}
+		}
+	}
+
+	return summaries, total, nil
+}
+
+// GetFundingDashboard 펀딩 현황 대시보드 (진행 중 펀딩 TVL, 카테고리별 집계, 최근 결과)
+func (fv *FundingVerificationService) GetFundingDashboard() (*FundingDashboard, error) {
+	dashboard := &FundingDashboard{
+		ByCategory: []CategoryFundingStats{},
+	}
+
+	funding := fv.db.Table("milestones").
+		Joins("JOIN projects ON projects.id = milestones.project_id").
+		Where("milestones.status = ? AND milestones.deleted_at IS NULL AND projects.deleted_at IS NULL", models.MilestoneStatusFunding)
+
+	if err := funding.Session(&gorm.Session{}).
+		Select(`projects.category, COUNT(*) AS funding_count,
+			COALESCE(SUM(milestones.current_tvl), 0) AS total_tvl,
+			COALESCE(SUM(milestones.min_viable_capital), 0) AS total_target,
+			SUM(CASE WHEN milestones.current_tvl >= milestones.min_viable_capital THEN 1 ELSE 0 END) AS reached_count`).
+		Group("projects.category").
+		Order("total_tvl DESC").
+		Scan(&dashboard.ByCategory).Error; err != nil {
+		if fv.isColumnNotExistsError(err) {
+			return dashboard, nil
+		}
+		return nil, fmt.Errorf("failed to aggregate funding dashboard: %v", err)
+	}
+
+	for _, category := range dashboard.ByCategory {
+		dashboard.FundingCount += category.FundingCount
+		dashboard.TotalTVL += category.TotalTVL
+		dashboard.TotalTarget += category.TotalTarget
+		dashboard.ReachedCount += category.ReachedCount
+	}
+
+	if err := funding.Session(&gorm.Session{}).
+		Where("milestones.funding_end_date <= ?", time.Now().Add(24*time.Hour)).
+		Count(&dashboard.EndingWithin24h).Error; err != nil {
+		return nil, fmt.Errorf("failed to count ending funding: %v", err)
+	}
+
+	// 최근 30일 펀딩 결과 (펀딩을 거친 마일스톤 중 활성화/거부 비율)
+	since := time.Now().AddDate(0, 0, -30)
+	fv.db.Model(&models.MilestoneStatusHistory{}).
+		Where("from_status = ? AND to_status = ? AND created_at >= ?", models.MilestoneStatusFunding, models.MilestoneStatusActive, since).
+		Count(&dashboard.FundedLast30d)
+	fv.db.Model(&models.MilestoneStatusHistory{}).
+		Where("from_status = ? AND to_status = ? AND created_at >= ?", models.MilestoneStatusFunding, models.MilestoneStatusRejected, since).
+		Count(&dashboard.FailedLast30d)
+	if decided := dashboard.FundedLast30d + dashboard.FailedLast30d; decided > 0 {
+		dashboard.SuccessRate = float64(dashboard.FundedLast30d) / float64(decided)
+	}
+
+	return dashboard, nil
+}
+
+// fundingVerificationState 마일스톤 상태로부터 시장성 검증 단계 산출
+func fundingVerificationState(milestone *models.Milestone) FundingVerificationState {
+	switch milestone.Status {
+	case models.MilestoneStatusFunding:
+		if milestone.MinViableCapital > 0 && milestone.HasReachedMinViableCapital() {
+			return FundingStateTargetReached
+		}
+		return FundingStateFunding
+	case models.MilestoneStatusRejected:
+		return FundingStateFailed
+	case models.MilestoneStatusProposal, models.MilestoneStatusPending:
+		return FundingStateNotStarted
+	default:
+		// 펀딩 단계를 거치지 않은 구버전 마일스톤은 검증 대상이 아님
+		if milestone.FundingStartDate == nil {
+			return FundingStateNotStarted
+		}
+		return FundingStateVerified
+	}
+}
+
+func remainingFunding(milestone *models.Milestone) int64 {
+	if milestone.CurrentTVL >= milestone.MinViableCapital {
+		return 0
+	}
+	return milestone.MinViableCapital - milestone.CurrentTVL
+}
+
+// FundingVerificationState 시장성 검증 단계
+type FundingVerificationState string
+
+const (
+	FundingStateNotStarted    FundingVerificationState = "not_started"    // 제안 단계 (펀딩 전)
+	FundingStateFunding       FundingVerificationState = "funding"        // 펀딩 진행 중, 목표 미달
+	FundingStateTargetReached FundingVerificationState = "target_reached" // 펀딩 진행 중, 목표 달성
+	FundingStateVerified      FundingVerificationState = "verified"       // 펀딩 성공 후 활성화
+	FundingStateFailed        FundingVerificationState = "failed"         // 목표 미달로 폐기
+)
+
 // FundingStats 펀딩 통계 구조체
 type FundingStats struct {
-	MilestoneID      uint                   `json:"milestone_id"`
-	Status           models.MilestoneStatus `json:"status"`
-	CurrentTVL       int64                  `json:"current_tvl"`
-	MinViableCapital int64                  `json:"min_viable_capital"`
-	FundingProgress  float64                `json:"funding_progress"`
-	FundingStartDate *time.Time             `json:"funding_start_date,omitempty"`
-	FundingEndDate   *time.Time             `json:"funding_end_date,omitempty"`
-	FundingDuration  int                    `json:"funding_duration"`
-	IsActive         bool                   `json:"is_active"`
-	IsExpired        bool                   `json:"is_expired"`
-	HasReachedTarget bool                   `json:"has_reached_target"`
+	MilestoneID       uint                     `json:"milestone_id"`
+	Status            models.MilestoneStatus   `json:"status"`
+	CurrentTVL        int64                    `json:"current_tvl"`
+	MinViableCapital  int64                    `json:"min_viable_capital"`
+	RemainingAmount   int64                    `json:"remaining_amount"`
+	FundingProgress   float64                  `json:"funding_progress"`
+	FundingStartDate  *time.Time               `json:"funding_start_date,omitempty"`
+	FundingEndDate    *time.Time               `json:"funding_end_date,omitempty"`
+	FundingDuration   int                      `json:"funding_duration"`
+	IsActive          bool                     `json:"is_active"`
+	IsExpired         bool                     `json:"is_expired"`
+	HasReachedTarget  bool                     `json:"has_reached_target"`
+	VerificationState FundingVerificationState `json:"verification_state"`
+	OptionTVL         []OptionTVL              `json:"option_tvl"`
+	EscrowTVL         int64                    `json:"escrow_tvl"`     // CurrentTVL 중 직접 후원 예치액
+	EscrowBackers     int64                    `json:"escrow_backers"` // 직접 후원자 수
+}
+
+// OptionTVL 옵션별 체결 금액
+type OptionTVL struct {
+	OptionID   string `json:"option_id"`
+	TVL        int64  `json:"tvl"`
+	TradeCount int64  `json:"trade_count"`
+}
+
+// FundingMilestoneSummary 펀딩 목록 항목 (진행률 바 표시용)
+type FundingMilestoneSummary struct {
+	MilestoneID      uint       `json:"milestone_id"`
+	ProjectID        uint       `json:"project_id"`
+	Title            string     `json:"title"`
+	ProjectTitle     string     `json:"project_title"`
+	Category         string     `json:"category"`
+	CurrentTVL       int64      `json:"current_tvl"`
+	MinViableCapital int64      `json:"min_viable_capital"`
+	RemainingAmount  int64      `json:"remaining_amount"`
+	FundingProgress  float64    `json:"funding_progress"`
+	FundingEndDate   *time.Time `json:"funding_end_date,omitempty"`
+	SecondsRemaining int64      `json:"seconds_remaining"`
+	HasReachedTarget bool       `json:"has_reached_target"`
+}
+
+// CategoryFundingStats 카테고리별 펀딩 집계
+type CategoryFundingStats struct {
+	Category     string `json:"category"`
+	FundingCount int64  `json:"funding_count"`
+	TotalTVL     int64  `json:"total_tvl"`
+	TotalTarget  int64  `json:"total_target"`
+	ReachedCount int64  `json:"reached_count"`
+}
+
+// FundingDashboard 펀딩 현황 대시보드
+type FundingDashboard struct {
+	FundingCount    int64                  `json:"funding_count"`
+	TotalTVL        int64                  `json:"total_tvl"`
+	TotalTarget     int64                  `json:"total_target"`
+	ReachedCount    int64                  `json:"reached_count"`
+	EndingWithin24h int64                  `json:"ending_within_24h"`
+	FundedLast30d   int64                  `json:"funded_last_30d"`
+	FailedLast30d   int64                  `json:"failed_last_30d"`
+	SuccessRate     float64                `json:"success_rate"`
+	ByCategory      []CategoryFundingStats `json:"by_category"`
 }
 
 // isColumnNotExistsError 컬럼이 존재하지 않는 오류인지 확인
@@ -0,0 +1,550 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/oauth"
+	"blueprint-module/pkg/secrets"
+
+	"gorm.io/gorm"
+)
+
+const githubAPIURL = "https://api.github.com"
+
+var (
+	ErrGitHubNotConnected        = errors.New("GitHub 계정이 연결되어 있지 않습니다")
+	ErrGitHubNotProjectOwner     = errors.New("프로젝트 소유자만 GitHub 웹훅을 등록할 수 있습니다")
+	ErrGitHubRepoNotAdmin        = errors.New("웹훅을 등록하려면 저장소 관리자 권한이 필요합니다")
+	ErrGitHubInvalidTrigger      = errors.New("지원하지 않는 트리거입니다")
+	ErrGitHubSubscriptionExists  = errors.New("이미 이 저장소에 대한 웹훅이 등록되어 있습니다")
+	ErrGitHubSubscriptionMissing = errors.New("웹훅 구독을 찾을 수 없습니다")
+	ErrGitHubWebhookSignature    = errors.New("웹훅 서명이 올바르지 않습니다")
+)
+
+// GitHubWebhookResult 웹훅 수신 처리 결과
+type GitHubWebhookResult struct {
+	Status  models.GitHubDeliveryStatus `json:"status"`
+	Trigger models.GitHubWebhookTrigger `json:"trigger,omitempty"`
+	ProofID *uint                       `json:"proof_id,omitempty"`
+	Message string                      `json:"message,omitempty"`
+}
+
+// GitHubIntegrationService GitHub 저장소 연동 서비스
+//
+// 마일스톤별로 저장소 웹훅을 등록하고, 릴리스 게시/태그 푸시/CI 성공 이벤트가
+// 들어오면 API 타입 증거를 자동 제출해 검증인 확인 절차로 넘긴다.
+type GitHubIntegrationService struct {
+	db                  *gorm.DB
+	verificationService *VerificationService
+	webhookURL          string
+	encryptionKey       []byte // 토큰/웹훅 secret 암호화용 (AES-256-GCM)
+	httpClient          *http.Client
+}
+
+// NewGitHubIntegrationService 생성자
+func NewGitHubIntegrationService(db *gorm.DB, verificationService *VerificationService, webhookURL, encryptionSecret string) *GitHubIntegrationService {
+	return &GitHubIntegrationService{
+		db:                  db,
+		verificationService: verificationService,
+		webhookURL:          webhookURL,
+		encryptionKey:       secrets.DeriveKey(encryptionSecret),
+		httpClient:          &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// SaveConnection OAuth 연결 완료 시 GitHub 토큰 저장 (재연결 시 갱신)
+func (s *GitHubIntegrationService) SaveConnection(userID uint, profile *oauth.UserProfile, token *oauth.TokenResponse) (*models.GitHubConnection, error) {
+	githubUserID, err := strconv.ParseInt(profile.ID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("잘못된 GitHub 사용자 ID: %w", err)
+	}
+	login, _ := profile.RawData["login"].(string)
+	if login == "" {
+		login = profile.DisplayName
+	}
+
+	encrypted, err := secrets.Encrypt(s.encryptionKey, token.AccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("토큰 암호화 실패: %w", err)
+	}
+
+	var connection models.GitHubConnection
+	err = s.db.Where("user_id = ?", userID).First(&connection).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	connection.UserID = userID
+	connection.GitHubUserID = githubUserID
+	connection.Login = login
+	connection.Scopes = token.Scope
+	connection.EncryptedToken = encrypted
+	connection.ConnectedAt = time.Now()
+
+	if err := s.db.Save(&connection).Error; err != nil {
+		return nil, fmt.Errorf("GitHub 연결 저장 실패: %w", err)
+	}
+	return &connection, nil
+}
+
+// ListRepositories 연결된 계정으로 접근 가능한 저장소 목록
+func (s *GitHubIntegrationService) ListRepositories(ctx context.Context, userID uint) ([]models.GitHubRepository, error) {
+	token, err := s.accessToken(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var repos []githubRepo
+	if err := s.call(ctx, token, http.MethodGet, "/user/repos?per_page=100&sort=updated", nil, &repos); err != nil {
+		return nil, err
+	}
+
+	result := make([]models.GitHubRepository, 0, len(repos))
+	for _, repo := range repos {
+		result = append(result, repo.toModel())
+	}
+	return result, nil
+}
+
+// CreateSubscription 마일스톤에 저장소 웹훅 등록
+func (s *GitHubIntegrationService) CreateSubscription(ctx context.Context, userID, milestoneID uint, req *models.CreateGitHubWebhookRequest) (*models.GitHubWebhookSubscription, error) {
+	var milestone models.Milestone
+	if err := s.db.First(&milestone, milestoneID).Error; err != nil {
+		return nil, fmt.Errorf("마일스톤을 찾을 수 없습니다: %w", err)
+	}
+	var project models.Project
+	if err := s.db.First(&project, milestone.ProjectID).Error; err != nil {
+		return nil, fmt.Errorf("프로젝트를 찾을 수 없습니다: %w", err)
+	}
+	if project.UserID != userID {
+		return nil, ErrGitHubNotProjectOwner
+	}
+	if milestone.Status.IsTerminal() {
+		return nil, errors.New("종료된 마일스톤에는 웹훅을 등록할 수 없습니다")
+	}
+
+	triggers, events, err := normalizeGitHubTriggers(req.Triggers)
+	if err != nil {
+		return nil, err
+	}
+
+	repoName := strings.Trim(strings.TrimSpace(req.Repo), "/")
+	var existing int64
+	s.db.Model(&models.GitHubWebhookSubscription{}).
+		Where("milestone_id = ? AND LOWER(repo_full_name) = ? AND active = ?", milestoneID, strings.ToLower(repoName), true).
+		Count(&existing)
+	if existing > 0 {
+		return nil, ErrGitHubSubscriptionExists
+	}
+
+	token, err := s.accessToken(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var repo githubRepo
+	if err := s.call(ctx, token, http.MethodGet, "/repos/"+repoName, nil, &repo); err != nil {
+		return nil, err
+	}
+	if !repo.Permissions.Admin {
+		return nil, ErrGitHubRepoNotAdmin
+	}
+
+	branch := strings.TrimSpace(req.Branch)
+	if branch == "" {
+		branch = repo.DefaultBranch
+	}
+
+	secret, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	encryptedSecret, err := secrets.Encrypt(s.encryptionKey, secret)
+	if err != nil {
+		return nil, fmt.Errorf("웹훅 secret 암호화 실패: %w", err)
+	}
+
+	var hook struct {
+		ID int64 `json:"id"`
+	}
+	if err := s.call(ctx, token, http.MethodPost, "/repos/"+repo.FullName+"/hooks", map[string]interface{}{
+		"name":   "web",
+		"active": true,
+		"events": events,
+		"config": map[string]string{
+			"url":          s.webhookURL,
+			"content_type": "json",
+			"secret":       secret,
+			"insecure_ssl": "0",
+		},
+	}, &hook); err != nil {
+		return nil, err
+	}
+
+	subscription := &models.GitHubWebhookSubscription{
+		UserID:          userID,
+		MilestoneID:     milestoneID,
+		RepoFullName:    repo.FullName,
+		Triggers:        triggers,
+		Branch:          branch,
+		HookID:          hook.ID,
+		EncryptedSecret: encryptedSecret,
+		Active:          true,
+	}
+	if err := s.db.Create(subscription).Error; err != nil {
+		// 저장 실패 시 GitHub 쪽 웹훅도 정리
+		s.deleteHook(ctx, token, repo.FullName, hook.ID)
+		return nil, fmt.Errorf("웹훅 구독 저장 실패: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// ListSubscriptions 마일스톤의 웹훅 구독 목록 (프로젝트 소유자 전용)
+func (s *GitHubIntegrationService) ListSubscriptions(userID, milestoneID uint) ([]models.GitHubWebhookSubscription, error) {
+	var subscriptions []models.GitHubWebhookSubscription
+	if err := s.db.Where("user_id = ? AND milestone_id = ?", userID, milestoneID).
+		Order("created_at DESC").Find(&subscriptions).Error; err != nil {
+		return nil, fmt.Errorf("웹훅 구독 조회 실패: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// DeleteSubscription 웹훅 구독 해제 (GitHub 웹훅 삭제 후 비활성화, 수신 기록은 유지)
+func (s *GitHubIntegrationService) DeleteSubscription(ctx context.Context, userID, subscriptionID uint) error {
+	var subscription models.GitHubWebhookSubscription
+	if err := s.db.Where("id = ? AND user_id = ? AND active = ?", subscriptionID, userID, true).
+		First(&subscription).Error; err != nil {
+		return ErrGitHubSubscriptionMissing
+	}
+
+	if token, err := s.accessToken(userID); err == nil {
+		s.deleteHook(ctx, token, subscription.RepoFullName, subscription.HookID)
+	}
+
+	return s.db.Model(&subscription).Update("active", false).Error
+}
+
+// HandleWebhook GitHub 웹훅 수신 처리
+//
+// X-GitHub-Hook-ID로 구독을 찾아 서명을 검증하고, 구독한 트리거와 일치하면
+// 프로젝트 소유자 명의로 API 타입 증거를 제출한다. 같은 Delivery ID는 한 번만 처리한다.
+func (s *GitHubIntegrationService) HandleWebhook(hookID int64, deliveryID, event, signature string, body []byte) (*GitHubWebhookResult, error) {
+	var subscription models.GitHubWebhookSubscription
+	if err := s.db.Where("hook_id = ? AND active = ?", hookID, true).First(&subscription).Error; err != nil {
+		return nil, ErrGitHubSubscriptionMissing
+	}
+
+	secret, err := secrets.Decrypt(s.encryptionKey, subscription.EncryptedSecret)
+	if err != nil {
+		return nil, fmt.Errorf("웹훅 secret 복호화 실패: %w", err)
+	}
+	if !verifyGitHubSignature(secret, signature, body) {
+		return nil, ErrGitHubWebhookSignature
+	}
+
+	if event == "ping" {
+		return &GitHubWebhookResult{Status: models.GitHubDeliveryIgnored, Message: "pong"}, nil
+	}
+
+	var existing models.GitHubWebhookDelivery
+	if err := s.db.Where("delivery_id = ?", deliveryID).First(&existing).Error; err == nil {
+		return &GitHubWebhookResult{Status: existing.Status, Trigger: existing.Trigger, ProofID: existing.ProofID, Message: "이미 처리된 웹훅입니다"}, nil
+	}
+
+	delivery := &models.GitHubWebhookDelivery{
+		DeliveryID:     deliveryID,
+		SubscriptionID: subscription.ID,
+		Event:          event,
+	}
+
+	evidence, err := parseGitHubEvidence(&subscription, event, body)
+	switch {
+	case err != nil:
+		delivery.Status = models.GitHubDeliveryIgnored
+		delivery.Message = err.Error()
+	case evidence == nil:
+		delivery.Status = models.GitHubDeliveryIgnored
+		delivery.Message = "구독한 트리거 조건과 일치하지 않습니다"
+	default:
+		delivery.Trigger = evidence.trigger
+		proof, err := s.verificationService.SubmitProof(&models.SubmitProofRequest{
+			MilestoneID: subscription.MilestoneID,
+			ProofType:   models.ProofTypeAPI,
+			Title:       evidence.title,
+			Description: evidence.description,
+			ExternalURL: evidence.url,
+			APIData:     evidence.data,
+			Metadata: models.ProofMetadata{
+				"source":          "github_webhook",
+				"subscription_id": subscription.ID,
+				"delivery_id":     deliveryID,
+			},
+		}, subscription.UserID)
+		if err != nil {
+			delivery.Status = models.GitHubDeliveryRejected
+			delivery.Message = err.Error()
+		} else {
+			delivery.Status = models.GitHubDeliveryProofCreated
+			delivery.ProofID = &proof.ID
+			now := time.Now()
+			s.db.Model(&subscription).Update("last_triggered_at", &now)
+			log.Printf("🐙 GitHub %s → proof %d for milestone %d", evidence.trigger, proof.ID, subscription.MilestoneID)
+		}
+	}
+
+	if err := s.db.Create(delivery).Error; err != nil {
+		log.Printf("⚠️ Failed to record GitHub delivery %s: %v", deliveryID, err)
+	}
+
+	return &GitHubWebhookResult{
+		Status:  delivery.Status,
+		Trigger: delivery.Trigger,
+		ProofID: delivery.ProofID,
+		Message: delivery.Message,
+	}, nil
+}
+
+// githubEvidence 웹훅 이벤트에서 추출한 증거 내용
+type githubEvidence struct {
+	trigger     models.GitHubWebhookTrigger
+	title       string
+	description string
+	url         string
+	data        models.ProofMetadata
+}
+
+// parseGitHubEvidence 이벤트가 구독 트리거와 일치하면 증거 내용 반환 (불일치 시 nil)
+func parseGitHubEvidence(subscription *models.GitHubWebhookSubscription, event string, body []byte) (*githubEvidence, error) {
+	var payload githubWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("웹훅 본문 파싱 실패: %w", err)
+	}
+	if !strings.EqualFold(payload.Repository.FullName, subscription.RepoFullName) {
+		return nil, fmt.Errorf("구독하지 않은 저장소입니다: %s", payload.Repository.FullName)
+	}
+
+	repo := payload.Repository.FullName
+	data := models.ProofMetadata{
+		"source":     "github",
+		"repository": repo,
+		"event":      event,
+		"sender":     payload.Sender.Login,
+	}
+
+	switch event {
+	case "release":
+		release := payload.Release
+		if payload.Action != "published" || release.Draft || !subscription.HasTrigger(models.GitHubTriggerReleasePublished) {
+			return nil, nil
+		}
+		data["tag"] = release.TagName
+		data["release_name"] = release.Name
+		data["prerelease"] = release.Prerelease
+		data["published_at"] = release.PublishedAt
+		title := release.Name
+		if title == "" {
+			title = release.TagName
+		}
+		return &githubEvidence{
+			trigger:     models.GitHubTriggerReleasePublished,
+			title:       fmt.Sprintf("[GitHub] %s 릴리스 %s", repo, title),
+			description: release.Body,
+			url:         release.HTMLURL,
+			data:        data,
+		}, nil
+
+	case "create":
+		if payload.RefType != "tag" || !subscription.HasTrigger(models.GitHubTriggerTagPushed) {
+			return nil, nil
+		}
+		data["tag"] = payload.Ref
+		return &githubEvidence{
+			trigger: models.GitHubTriggerTagPushed,
+			title:   fmt.Sprintf("[GitHub] %s 태그 %s", repo, payload.Ref),
+			url:     fmt.Sprintf("%s/tree/%s", payload.Repository.HTMLURL, payload.Ref),
+			data:    data,
+		}, nil
+
+	case "workflow_run":
+		run := payload.WorkflowRun
+		if payload.Action != "completed" || run.Conclusion != "success" || !subscription.HasTrigger(models.GitHubTriggerCIPassed) {
+			return nil, nil
+		}
+		if subscription.Branch != "" && run.HeadBranch != subscription.Branch {
+			return nil, nil
+		}
+		data["workflow"] = run.Name
+		data["run_number"] = run.RunNumber
+		data["branch"] = run.HeadBranch
+		data["commit"] = run.HeadSHA
+		data["completed_at"] = run.UpdatedAt
+		return &githubEvidence{
+			trigger: models.GitHubTriggerCIPassed,
+			title:   fmt.Sprintf("[GitHub] %s CI 통과 (%s #%d)", repo, run.Name, run.RunNumber),
+			url:     run.HTMLURL,
+			data:    data,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// verifyGitHubSignature X-Hub-Signature-256 검증
+func verifyGitHubSignature(secret, signature string, body []byte) bool {
+	if !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// normalizeGitHubTriggers 트리거 검증 후 저장용 문자열과 GitHub 이벤트 목록 반환
+func normalizeGitHubTriggers(triggers []models.GitHubWebhookTrigger) (string, []string, error) {
+	requested := make(map[models.GitHubWebhookTrigger]bool, len(triggers))
+	for _, trigger := range triggers {
+		if trigger.GitHubEvent() == "" {
+			return "", nil, fmt.Errorf("%w: %s", ErrGitHubInvalidTrigger, trigger)
+		}
+		requested[trigger] = true
+	}
+
+	var names, events []string
+	for _, trigger := range models.ValidGitHubWebhookTriggers {
+		if requested[trigger] {
+			names = append(names, string(trigger))
+			events = append(events, trigger.GitHubEvent())
+		}
+	}
+	return strings.Join(names, ","), events, nil
+}
+
+func (s *GitHubIntegrationService) accessToken(userID uint) (string, error) {
+	var connection models.GitHubConnection
+	if err := s.db.Where("user_id = ?", userID).First(&connection).Error; err != nil {
+		return "", ErrGitHubNotConnected
+	}
+	token, err := secrets.Decrypt(s.encryptionKey, connection.EncryptedToken)
+	if err != nil {
+		return "", fmt.Errorf("GitHub 토큰 복호화 실패: %w", err)
+	}
+	return token, nil
+}
+
+func (s *GitHubIntegrationService) deleteHook(ctx context.Context, token, repo string, hookID int64) {
+	if hookID == 0 {
+		return
+	}
+	if err := s.call(ctx, token, http.MethodDelete, fmt.Sprintf("/repos/%s/hooks/%d", repo, hookID), nil, nil); err != nil {
+		log.Printf("⚠️ Failed to delete GitHub hook %d on %s: %v", hookID, repo, err)
+	}
+}
+
+// call GitHub REST API 호출 (out이 nil이면 응답 본문 무시)
+func (s *GitHubIntegrationService) call(ctx context.Context, token, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, githubAPIURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("GitHub API 호출 실패: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("GitHub API 오류 (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("GitHub 응답 디코딩 실패: %w", err)
+	}
+	return nil
+}
+
+// GitHub API 응답 구조체
+type githubRepo struct {
+	FullName      string `json:"full_name"`
+	Private       bool   `json:"private"`
+	DefaultBranch string `json:"default_branch"`
+	HTMLURL       string `json:"html_url"`
+	Permissions   struct {
+		Admin bool `json:"admin"`
+	} `json:"permissions"`
+}
+
+func (r githubRepo) toModel() models.GitHubRepository {
+	return models.GitHubRepository{
+		FullName:      r.FullName,
+		Private:       r.Private,
+		DefaultBranch: r.DefaultBranch,
+		HTMLURL:       r.HTMLURL,
+		CanAdmin:      r.Permissions.Admin,
+	}
+}
+
+type githubWebhookPayload struct {
+	Action     string `json:"action"`
+	Ref        string `json:"ref"`
+	RefType    string `json:"ref_type"`
+	Repository struct {
+		FullName string `json:"full_name"`
+		HTMLURL  string `json:"html_url"`
+	} `json:"repository"`
+	Sender struct {
+		Login string `json:"login"`
+	} `json:"sender"`
+	Release struct {
+		TagName     string `json:"tag_name"`
+		Name        string `json:"name"`
+		Body        string `json:"body"`
+		HTMLURL     string `json:"html_url"`
+		Draft       bool   `json:"draft"`
+		Prerelease  bool   `json:"prerelease"`
+		PublishedAt string `json:"published_at"`
+	} `json:"release"`
+	WorkflowRun struct {
+		Name       string `json:"name"`
+		HeadBranch string `json:"head_branch"`
+		HeadSHA    string `json:"head_sha"`
+		Conclusion string `json:"conclusion"`
+		HTMLURL    string `json:"html_url"`
+		RunNumber  int    `json:"run_number"`
+		UpdatedAt  string `json:"updated_at"`
+	} `json:"workflow_run"`
+}
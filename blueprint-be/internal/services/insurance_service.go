@@ -0,0 +1,443 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// InsuranceClaimWindow 거래 마감 후 사기 판결을 기다리며 보장을 유지하는 기간
+	InsuranceClaimWindow = 60 * 24 * time.Hour
+
+	// insuranceFallbackTerm 거래 마감 시각이 없는 마일스톤의 보장 기간 (가입 시점부터)
+	insuranceFallbackTerm = 180 * 24 * time.Hour
+
+	insuranceBaseRate         = 0.02 // 기본 요율
+	insuranceRiskLoading      = 0.08 // 마일스톤 위험 점수(0~1)에 비례한 할증
+	insuranceUtilizationLoad  = 0.05 // 풀 잔액 대비 보장 합계 비율(최대 1)에 비례한 할증
+	insuranceDefaultRiskScore = 0.5  // 위험 점수가 아직 계산되지 않은 마일스톤
+	insuranceExpiryBatchSize  = 500
+	insurancePolicyListLimit  = 100
+)
+
+var (
+	// ErrInsurancePolicyNotFound 없거나 본인 것이 아닌 보험
+	ErrInsurancePolicyNotFound = errors.New("보험을 찾을 수 없습니다")
+	// ErrInsurancePolicyNotActive 이미 보험금을 받았거나 만료된 보험
+	ErrInsurancePolicyNotActive = errors.New("보장 중인 보험이 아닙니다")
+	// ErrInsuranceCoverageExceeded 롱 포지션 취득 원가에서 기존 보장을 뺀 한도 초과
+	ErrInsuranceCoverageExceeded = errors.New("보장 가능 금액을 초과했습니다")
+	// ErrInsuranceNoFraudRuling 해당 마일스톤에 확정된 사기 판결이 없음
+	ErrInsuranceNoFraudRuling = errors.New("확정된 프로젝트 사기 판결이 없습니다")
+	// ErrInsurancePoolEmpty 풀 잔액이 없어 지급할 수 없음 (보험은 유지되어 나중에 다시 청구 가능)
+	ErrInsurancePoolEmpty = errors.New("보험 풀 잔액이 부족합니다")
+)
+
+// InsuranceService 카테고리별 풀로 운영하는 마일스톤 사기 보험
+//
+// 보유자는 롱 포지션 취득 원가 한도 안에서 보장 금액을 정해 가입하고, 보험료는 지갑에서 카테고리 풀로 옮겨진다.
+// 요율은 기본 요율에 마일스톤 위험 점수와 풀 사용률(보장 합계 / 잔액) 할증을 더한 값이다.
+// 마일스톤이 배심원 중재에서 프로젝트 사기로 확정(항소로 대체되지 않은 신청인 승소 판결)되면 보험금을 청구할 수 있고,
+// 풀 잔액이 부족하면 남은 잔액만큼만 지급한다. 청구 기한이 지난 보험은 만료되고 보험료는 풀에 남는다.
+type InsuranceService struct {
+	db                  *gorm.DB
+	risk                *MilestoneRiskService
+	notificationService *NotificationService
+}
+
+// NewInsuranceService 생성자
+func NewInsuranceService(db *gorm.DB) *InsuranceService {
+	return &InsuranceService{
+		db:                  db,
+		risk:                NewMilestoneRiskService(db),
+		notificationService: NewNotificationService(db),
+	}
+}
+
+// ListPools 카테고리별 보험 풀 현황
+func (s *InsuranceService) ListPools() ([]models.InsurancePool, error) {
+	pools := []models.InsurancePool{}
+	err := s.db.Order("category").Find(&pools).Error
+	return pools, err
+}
+
+// Quote 보험료 견적 (가입 가능한 마켓/보장 한도 확인 포함)
+func (s *InsuranceService) Quote(userID uint, req models.InsuranceQuoteRequest) (*models.InsuranceQuote, error) {
+	milestone, err := s.insurableMilestone(req.MilestoneID, req.OptionID)
+	if err != nil {
+		return nil, err
+	}
+	riskScore, err := s.riskScore(milestone.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.quote(s.db, userID, milestone, riskScore, req)
+}
+
+// Purchase 보험 가입 (보험료를 지갑에서 풀로 이동)
+func (s *InsuranceService) Purchase(userID uint, req models.InsuranceQuoteRequest) (*models.InsurancePolicy, error) {
+	milestone, err := s.insurableMilestone(req.MilestoneID, req.OptionID)
+	if err != nil {
+		return nil, err
+	}
+	riskScore, err := s.riskScore(milestone.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy *models.InsurancePolicy
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		quote, err := s.quote(tx, userID, milestone, riskScore, req)
+		if err != nil {
+			return err
+		}
+		if req.Coverage > quote.MaxCoverage {
+			return fmt.Errorf("%w: 최대 $%.2f", ErrInsuranceCoverageExceeded, float64(quote.MaxCoverage)/100)
+		}
+		pool, err := s.poolFor(tx, quote.Category)
+		if err != nil {
+			return err
+		}
+
+		policy = &models.InsurancePolicy{
+			UserID:      userID,
+			PoolID:      pool.ID,
+			ProjectID:   milestone.ProjectID,
+			MilestoneID: milestone.ID,
+			OptionID:    req.OptionID,
+			Coverage:    req.Coverage,
+			Premium:     quote.Premium,
+			PremiumRate: quote.PremiumRate,
+			Status:      models.InsurancePolicyActive,
+			ExpiresAt:   quote.ExpiresAt,
+		}
+		if err := tx.Create(policy).Error; err != nil {
+			return fmt.Errorf("보험 저장 실패: %w", err)
+		}
+
+		if err := postLedgerEntry(tx, insuranceLedgerEntry(policy, models.WalletLedgerEntry{
+			UserID:    userID,
+			EntryType: models.LedgerInsurancePremium,
+			Amount:    -policy.Premium,
+			Memo:      fmt.Sprintf("마일스톤 보험료 (%s, 보장 $%.2f)", milestone.Title, float64(policy.Coverage)/100),
+		})); err != nil {
+			return err
+		}
+		// 잔액 확인은 증분 반영 후에 해서 동시 주문과 겹쳐도 음수 잔액을 남기지 않음
+		var wallet models.UserWallet
+		if err := tx.Select("usdc_balance").Where("user_id = ?", userID).First(&wallet).Error; err != nil {
+			return fmt.Errorf("지갑 조회 실패: %w", err)
+		}
+		if wallet.USDCBalance < 0 {
+			return fmt.Errorf("%w: 보험료 $%.2f", ErrInsufficientBalance, float64(policy.Premium)/100)
+		}
+
+		return tx.Model(&models.InsurancePool{}).Where("id = ?", pool.ID).Updates(map[string]interface{}{
+			"balance":         gorm.Expr("balance + ?", policy.Premium),
+			"active_coverage": gorm.Expr("active_coverage + ?", policy.Coverage),
+			"total_premiums":  gorm.Expr("total_premiums + ?", policy.Premium),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notify(policy, "마일스톤 보험에 가입했습니다",
+		fmt.Sprintf("%s 보장 $%.2f, 보험료 $%.2f", milestone.Title, float64(policy.Coverage)/100, float64(policy.Premium)/100))
+	return policy, nil
+}
+
+// ListPolicies 내 보험 목록
+func (s *InsuranceService) ListPolicies(userID uint, status models.InsurancePolicyStatus) ([]models.InsurancePolicy, error) {
+	query := s.db.Preload("Milestone").Preload("Claim").Where("user_id = ?", userID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	policies := []models.InsurancePolicy{}
+	err := query.Order("created_at DESC").Limit(insurancePolicyListLimit).Find(&policies).Error
+	return policies, err
+}
+
+// Claim 사기 판결이 확정된 마일스톤의 보험금 청구 (풀 잔액 한도에서 지급)
+func (s *InsuranceService) Claim(policyID, userID uint) (*models.InsuranceClaim, error) {
+	var policy models.InsurancePolicy
+	if err := s.db.Where("id = ? AND user_id = ?", policyID, userID).First(&policy).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInsurancePolicyNotFound
+		}
+		return nil, err
+	}
+	if policy.Status != models.InsurancePolicyActive {
+		return nil, ErrInsurancePolicyNotActive
+	}
+
+	ruling, _, err := s.fraudRuling(policy.MilestoneID)
+	if err != nil {
+		return nil, err
+	}
+	if ruling == nil {
+		return nil, ErrInsuranceNoFraudRuling
+	}
+
+	claim := &models.InsuranceClaim{
+		PolicyID:          policy.ID,
+		UserID:            userID,
+		ArbitrationCaseID: ruling.ID,
+		Requested:         policy.Coverage,
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var pool models.InsurancePool
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&pool, policy.PoolID).Error; err != nil {
+			return fmt.Errorf("보험 풀 조회 실패: %w", err)
+		}
+		claim.Paid = policy.Coverage
+		if claim.Paid > pool.Balance {
+			claim.Paid = pool.Balance
+		}
+		if claim.Paid <= 0 {
+			return ErrInsurancePoolEmpty
+		}
+
+		result := tx.Model(&models.InsurancePolicy{}).
+			Where("id = ? AND status = ?", policy.ID, models.InsurancePolicyActive).
+			Update("status", models.InsurancePolicyClaimed)
+		if result.Error != nil {
+			return fmt.Errorf("보험 상태 변경 실패: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrInsurancePolicyNotActive
+		}
+
+		result = tx.Model(&models.InsurancePool{}).Where("id = ? AND balance >= ?", pool.ID, claim.Paid).Updates(map[string]interface{}{
+			"balance":         gorm.Expr("balance - ?", claim.Paid),
+			"active_coverage": gorm.Expr("active_coverage - ?", policy.Coverage),
+			"total_claims":    gorm.Expr("total_claims + ?", claim.Paid),
+		})
+		if result.Error != nil {
+			return fmt.Errorf("보험 풀 차감 실패: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrInsurancePoolEmpty
+		}
+
+		if err := tx.Create(claim).Error; err != nil {
+			return fmt.Errorf("보험금 지급 기록 실패: %w", err)
+		}
+		return postLedgerEntry(tx, insuranceLedgerEntry(&policy, models.WalletLedgerEntry{
+			UserID:    userID,
+			EntryType: models.LedgerInsuranceClaim,
+			Amount:    claim.Paid,
+			Memo:      fmt.Sprintf("마일스톤 보험금 (분쟁 사건 %s)", ruling.CaseNumber),
+		}))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	policy.Status = models.InsurancePolicyClaimed
+	s.notify(&policy, "보험금이 지급되었습니다",
+		fmt.Sprintf("사기 판결(%s)에 따라 $%.2f가 지급되었습니다.", ruling.CaseNumber, float64(claim.Paid)/100))
+	return claim, nil
+}
+
+// ExpirePolicies 청구 기한이 지난 보험 만료 (진행 중인 사기 사건이 있거나 청구 가능한 판결이 있으면 유지, 만료 건수 반환)
+func (s *InsuranceService) ExpirePolicies(now time.Time) (int, error) {
+	var policies []models.InsurancePolicy
+	if err := s.db.Where("status = ? AND expires_at <= ?", models.InsurancePolicyActive, now).
+		Order("id").Limit(insuranceExpiryBatchSize).Find(&policies).Error; err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for i := range policies {
+		policy := &policies[i]
+		ruling, pending, err := s.fraudRuling(policy.MilestoneID)
+		if err != nil {
+			return expired, err
+		}
+		if ruling != nil || pending {
+			continue
+		}
+
+		closed := false
+		err = s.db.Transaction(func(tx *gorm.DB) error {
+			result := tx.Model(&models.InsurancePolicy{}).
+				Where("id = ? AND status = ?", policy.ID, models.InsurancePolicyActive).
+				Update("status", models.InsurancePolicyExpired)
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error // 그 사이 청구됨
+			}
+			closed = true
+			return tx.Model(&models.InsurancePool{}).Where("id = ?", policy.PoolID).
+				Update("active_coverage", gorm.Expr("active_coverage - ?", policy.Coverage)).Error
+		})
+		if err != nil {
+			return expired, fmt.Errorf("보험 %d 만료 실패: %w", policy.ID, err)
+		}
+		if closed {
+			expired++
+			policy.Status = models.InsurancePolicyExpired
+			s.notify(policy, "마일스톤 보험이 만료되었습니다", "청구 기한 안에 확정된 사기 판결이 없어 보장이 종료되었습니다.")
+		}
+	}
+	return expired, nil
+}
+
+// quote 보장 한도/요율 계산 (가입 트랜잭션 안에서도 같은 계산을 다시 함)
+func (s *InsuranceService) quote(db *gorm.DB, userID uint, milestone *models.Milestone, riskScore float64, req models.InsuranceQuoteRequest) (*models.InsuranceQuote, error) {
+	var project models.Project
+	if err := db.Select("id", "category").First(&project, milestone.ProjectID).Error; err != nil {
+		return nil, fmt.Errorf("프로젝트 조회 실패: %w", err)
+	}
+	category := project.Category
+
+	var costBasis, insured int64
+	if err := db.Model(&models.Position{}).
+		Where("user_id = ? AND milestone_id = ? AND option_id = ? AND quantity > 0", userID, milestone.ID, req.OptionID).
+		Select("COALESCE(SUM(total_cost), 0)").Scan(&costBasis).Error; err != nil {
+		return nil, fmt.Errorf("포지션 조회 실패: %w", err)
+	}
+	if err := db.Model(&models.InsurancePolicy{}).
+		Where("user_id = ? AND milestone_id = ? AND option_id = ? AND status = ?", userID, milestone.ID, req.OptionID, models.InsurancePolicyActive).
+		Select("COALESCE(SUM(coverage), 0)").Scan(&insured).Error; err != nil {
+		return nil, fmt.Errorf("기존 보험 조회 실패: %w", err)
+	}
+	maxCoverage := costBasis - insured
+	if maxCoverage < 0 {
+		maxCoverage = 0
+	}
+
+	var pool models.InsurancePool
+	if err := db.Where("category = ?", category).Limit(1).Find(&pool).Error; err != nil {
+		return nil, fmt.Errorf("보험 풀 조회 실패: %w", err)
+	}
+
+	rate := insurancePremiumRate(riskScore, pool.ActiveCoverage+req.Coverage, pool.Balance)
+	expiresAt := time.Now().Add(insuranceFallbackTerm)
+	if closesAt := milestone.TradingCloseTime(); closesAt != nil {
+		expiresAt = closesAt.Add(InsuranceClaimWindow)
+	}
+
+	return &models.InsuranceQuote{
+		MilestoneID:  milestone.ID,
+		OptionID:     req.OptionID,
+		Category:     category,
+		Coverage:     req.Coverage,
+		MaxCoverage:  maxCoverage,
+		PremiumRate:  rate,
+		Premium:      int64(math.Ceil(float64(req.Coverage) * rate)),
+		RiskScore:    riskScore,
+		PoolBalance:  pool.Balance,
+		PoolCoverage: pool.ActiveCoverage,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+// riskScore 마지막으로 계산된 마일스톤 위험 점수 (계산 전이면 기본값)
+func (s *InsuranceService) riskScore(milestoneID uint) (float64, error) {
+	risk, err := s.risk.GetMilestoneRisk(milestoneID)
+	if errors.Is(err, ErrMilestoneRiskNotComputed) {
+		return insuranceDefaultRiskScore, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return risk.Score, nil
+}
+
+// insurancePremiumRate 기본 요율 + 위험 할증 + 풀 사용률 할증 (사용률은 잔액이 없으면 1)
+func insurancePremiumRate(riskScore float64, coverage, poolBalance int64) float64 {
+	utilization := 1.0
+	if poolBalance > 0 {
+		utilization = math.Min(float64(coverage)/float64(poolBalance), 1)
+	}
+	rate := insuranceBaseRate + insuranceRiskLoading*clampUnit(riskScore) + insuranceUtilizationLoad*utilization
+	return math.Round(rate*10000) / 10000
+}
+
+// poolFor 카테고리 풀 조회 (없으면 생성)
+func (s *InsuranceService) poolFor(tx *gorm.DB, category models.ProjectCategory) (*models.InsurancePool, error) {
+	pool := models.InsurancePool{Category: category}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&pool).Error; err != nil {
+		return nil, fmt.Errorf("보험 풀 생성 실패: %w", err)
+	}
+	if err := tx.Where("category = ?", category).First(&pool).Error; err != nil {
+		return nil, fmt.Errorf("보험 풀 조회 실패: %w", err)
+	}
+	return &pool, nil
+}
+
+// fraudRuling 마일스톤의 확정된 사기 판결 (항소로 대체되지 않은 신청인 승소 종결 사건)과 진행 중인 사기 사건 여부
+func (s *InsuranceService) fraudRuling(milestoneID uint) (*models.ArbitrationCase, bool, error) {
+	var cases []models.ArbitrationCase
+	if err := s.db.Select("id", "case_number", "status", "decision").
+		Where("milestone_id = ? AND dispute_type = ?", milestoneID, models.DisputeTypeProjectFraud).
+		Where("NOT EXISTS (SELECT 1 FROM arbitration_cases appeal WHERE appeal.parent_case_id = arbitration_cases.id)").
+		Order("id DESC").Find(&cases).Error; err != nil {
+		return nil, false, fmt.Errorf("사기 사건 조회 실패: %w", err)
+	}
+
+	pending := false
+	for i := range cases {
+		switch cases[i].Status {
+		case models.ArbitrationStatusClosed:
+			if cases[i].Decision == models.ArbitrationDecisionPlaintiffWins {
+				return &cases[i], false, nil
+			}
+		case models.ArbitrationStatusRejected:
+		default:
+			pending = true
+		}
+	}
+	return nil, pending, nil
+}
+
+// insurableMilestone 거래 가능한 마켓의 유효한 옵션인지 확인
+func (s *InsuranceService) insurableMilestone(milestoneID uint, optionID string) (*models.Milestone, error) {
+	var milestone models.Milestone
+	if err := s.db.Select("id", "project_id", "title", "status", "market_type", "outcomes", "target_date", "trading_closes_at").
+		First(&milestone, milestoneID).Error; err != nil {
+		return nil, fmt.Errorf("마일스톤을 찾을 수 없습니다: %w", err)
+	}
+	if !milestone.Status.IsTradable() {
+		return nil, ErrMarketFrozen
+	}
+	if milestone.IsTradingClosed(time.Now()) {
+		return nil, fmt.Errorf("%w: %s 마감", ErrMarketClosed, milestone.TradingCloseTime().UTC().Format(time.RFC3339))
+	}
+	if !milestone.HasOption(optionID) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownOption, optionID)
+	}
+	return &milestone, nil
+}
+
+func (s *InsuranceService) notify(policy *models.InsurancePolicy, title, message string) {
+	if _, err := s.notificationService.Notify(models.CreateNotificationRequest{
+		UserID:   policy.UserID,
+		Type:     models.NotificationTypeInsurance,
+		Priority: models.NotificationPriorityNormal,
+		Title:    title,
+		Message:  message,
+		Link:     "/portfolio/insurance",
+		Data:     map[string]interface{}{"policy_id": policy.ID, "milestone_id": policy.MilestoneID, "status": policy.Status},
+	}); err != nil {
+		log.Printf("⚠️ Failed to notify insurance policy %d: %v", policy.ID, err)
+	}
+}
+
+// insuranceLedgerEntry 보험 참조가 붙은 USDC 원장 항목
+func insuranceLedgerEntry(policy *models.InsurancePolicy, entry models.WalletLedgerEntry) *models.WalletLedgerEntry {
+	entry.Currency = models.LedgerCurrencyUSDC
+	entry.ReferenceType = "insurance_policy"
+	entry.ReferenceID = policy.ID
+	return &entry
+}
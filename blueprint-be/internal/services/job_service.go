@@ -0,0 +1,45 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// JobRetention 끝난 작업 기록 보관 기간
+const JobRetention = 7 * 24 * time.Hour
+
+// ErrJobNotFound 없는 작업 ID
+var ErrJobNotFound = errors.New("작업을 찾을 수 없습니다")
+
+// JobService 워커 작업 진행 상태 조회 (기록은 queue.PublishTrackedJob, 상태 갱신은 워커 컨슈머가 담당)
+type JobService struct {
+	db *gorm.DB
+}
+
+// NewJobService 생성자
+func NewJobService(db *gorm.DB) *JobService {
+	return &JobService{db: db}
+}
+
+// GetJob 작업 ID로 상태 조회
+func (s *JobService) GetJob(id string) (*models.Job, error) {
+	var job models.Job
+	if err := s.db.Where("id = ?", id).First(&job).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// CleanupFinished before 이전에 끝난 작업 기록 삭제 (진행 중인 작업은 남김)
+func (s *JobService) CleanupFinished(before time.Time) (int64, error) {
+	result := s.db.Where("status IN ? AND finished_at < ?", []models.JobStatus{models.JobSucceeded, models.JobFailed}, before).
+		Delete(&models.Job{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultJurorMaxCases   = 3              // 동시 사건 한도 기본값 (설정이 비어 있는 기존 배심원)
+	jurorReplacementPeriod = 24 * time.Hour // 교체 배심원 투표 기간 (기존 배심원의 커밋 기한도 함께 연장)
+)
+
+// jurorActiveStatuses 배심원이 응답해야 하는 단계 (동시 사건 한도 계산 대상)
+var jurorActiveStatuses = []models.ArbitrationStatus{
+	models.ArbitrationStatusJurySelection,
+	models.ArbitrationStatusVoting,
+	models.ArbitrationStatusReveal,
+}
+
+// jurorCaseLimit 배심원 동시 사건 한도
+func jurorCaseLimit(qualification models.JurorQualification) int {
+	if qualification.MaxConcurrentCases <= 0 {
+		return defaultJurorMaxCases
+	}
+	return qualification.MaxConcurrentCases
+}
+
+// jurorCaseLoads 배심원별 진행 중 사건 수 (선정된 배심원 목록 기준)
+func (s *ArbitrationService) jurorCaseLoads() (map[uint]int, error) {
+	var cases []models.ArbitrationCase
+	if err := s.db.Select("id", "selected_jurors").
+		Where("status IN ?", jurorActiveStatuses).
+		Find(&cases).Error; err != nil {
+		return nil, fmt.Errorf("배심원 사건 부담 조회 실패: %w", err)
+	}
+
+	loads := make(map[uint]int)
+	for _, arbitrationCase := range cases {
+		for _, jurorID := range arbitrationCase.SelectedJurors {
+			loads[jurorID]++
+		}
+	}
+	return loads, nil
+}
+
+// GetJurorAvailability 배심원 가용성 설정과 현재 사건 부담
+func (s *ArbitrationService) GetJurorAvailability(userID uint) (*models.JurorAvailability, error) {
+	var qualification models.JurorQualification
+	if err := s.db.Where("user_id = ?", userID).First(&qualification).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("배심원으로 등록되지 않았습니다")
+		}
+		return nil, fmt.Errorf("배심원 자격 조회 실패: %w", err)
+	}
+
+	loads, err := s.jurorCaseLoads()
+	if err != nil {
+		return nil, err
+	}
+
+	onVacation := qualification.VacationUntil != nil && time.Now().Before(*qualification.VacationUntil)
+	return &models.JurorAvailability{
+		MaxConcurrentCases: jurorCaseLimit(qualification),
+		VacationUntil:      qualification.VacationUntil,
+		ActiveCases:        loads[userID],
+		Available:          !onVacation && loads[userID] < jurorCaseLimit(qualification),
+	}, nil
+}
+
+// UpdateJurorAvailability 동시 사건 한도/휴가 모드 변경 (이미 선정된 사건은 그대로 유지)
+func (s *ArbitrationService) UpdateJurorAvailability(userID uint, req *models.UpdateJurorAvailabilityRequest) (*models.JurorAvailability, error) {
+	updates := map[string]interface{}{}
+	if req.MaxConcurrentCases != nil {
+		updates["max_concurrent_cases"] = *req.MaxConcurrentCases
+	}
+	switch {
+	case req.EndVacation:
+		updates["vacation_until"] = nil
+	case req.VacationUntil != nil:
+		if !req.VacationUntil.After(time.Now()) {
+			return nil, errors.New("휴가 종료 시각은 현재 이후여야 합니다")
+		}
+		updates["vacation_until"] = *req.VacationUntil
+	}
+
+	if len(updates) > 0 {
+		result := s.db.Model(&models.JurorQualification{}).Where("user_id = ?", userID).Updates(updates)
+		if result.Error != nil {
+			return nil, fmt.Errorf("배심원 가용성 변경 실패: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil, errors.New("배심원으로 등록되지 않았습니다")
+		}
+	}
+	return s.GetJurorAvailability(userID)
+}
+
+// replaceMissingJurors 투표 기한까지 커밋하지 않은 배심원을 새 후보로 교체하고 투표 기한 연장 (교체했으면 true)
+//
+// 교체된 배심원은 더 이상 투표할 수 없고 판결 시 미참여로 차감된다. 후보가 부족하면 가능한 만큼만 교체하며,
+// 한 명도 교체하지 못하면 기존 규칙대로 공개 단계로 넘어가거나 기각된다.
+func (s *ArbitrationService) replaceMissingJurors(arbitrationCase *models.ArbitrationCase, now time.Time) (bool, error) {
+	var committedIDs []uint
+	if err := s.db.Model(&models.ArbitrationVote{}).
+		Where("case_id = ? AND committed_at IS NOT NULL", arbitrationCase.ID).
+		Pluck("juror_id", &committedIDs).Error; err != nil {
+		return false, fmt.Errorf("커밋한 배심원 조회 실패: %w", err)
+	}
+	committed := make(map[uint]bool, len(committedIDs))
+	for _, jurorID := range committedIDs {
+		committed[jurorID] = true
+	}
+
+	var missing []uint
+	excluded := make(map[uint]bool)
+	for _, jurorID := range arbitrationCase.SelectedJurors {
+		excluded[jurorID] = true
+		if !committed[jurorID] {
+			missing = append(missing, jurorID)
+		}
+	}
+	for _, jurorID := range arbitrationCase.ReplacedJurors {
+		excluded[jurorID] = true
+	}
+	if len(missing) == 0 {
+		return false, nil
+	}
+
+	candidates, loads, err := s.getEligibleJurors(arbitrationCase.DisputeType, arbitrationCase.PlaintiffID, arbitrationCase.DefendantID)
+	if err != nil {
+		return false, err
+	}
+	fresh := candidates[:0]
+	for _, candidate := range candidates {
+		if !excluded[candidate.UserID] {
+			fresh = append(fresh, candidate)
+		}
+	}
+	count := len(missing)
+	if len(fresh) < count {
+		count = len(fresh)
+	}
+	if count == 0 {
+		return false, nil
+	}
+
+	replacements, err := s.selectJurors(fresh, loads, count)
+	if err != nil {
+		return false, err
+	}
+	replaced := missing[:count]
+	dropped := make(map[uint]bool, count)
+	for _, jurorID := range replaced {
+		dropped[jurorID] = true
+	}
+	selected := make([]uint, 0, len(arbitrationCase.SelectedJurors))
+	for _, jurorID := range arbitrationCase.SelectedJurors {
+		if !dropped[jurorID] {
+			selected = append(selected, jurorID)
+		}
+	}
+	selected = append(selected, replacements...)
+
+	votingDeadline := now.Add(jurorReplacementPeriod)
+	result := s.db.Model(&models.ArbitrationCase{}).
+		Where("id = ? AND status = ?", arbitrationCase.ID, models.ArbitrationStatusVoting).
+		Updates(&models.ArbitrationCase{
+			SelectedJurors: selected,
+			ReplacedJurors: append(append([]uint{}, arbitrationCase.ReplacedJurors...), replaced...),
+			VotingDeadline: &votingDeadline,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("배심원 교체 실패: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	for _, jurorID := range replacements {
+		s.notifyJurorSelection(jurorID, arbitrationCase.ID)
+	}
+	for _, jurorID := range replaced {
+		if _, err := s.notificationService.Notify(models.CreateNotificationRequest{
+			UserID:  jurorID,
+			Type:    models.NotificationTypeArbitration,
+			Title:   "배심원에서 교체되었습니다",
+			Message: fmt.Sprintf("분쟁 사건 %s의 투표 기한까지 투표하지 않아 다른 배심원으로 교체되었습니다. 판결 시 미참여로 처리됩니다.", arbitrationCase.CaseNumber),
+			Link:    fmt.Sprintf("/arbitration/cases/%d", arbitrationCase.ID),
+			Data: map[string]interface{}{
+				"case_id":     arbitrationCase.ID,
+				"case_number": arbitrationCase.CaseNumber,
+			},
+		}); err != nil {
+			log.Printf("❌ Failed to notify replaced juror %d: %v", jurorID, err)
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"blueprint-module/pkg/models"
+)
+
+// KYCProviderType 본인 인증 제공업체 타입
+type KYCProviderType string
+
+const (
+	KYCProviderManual   KYCProviderType = "manual"   // 운영자 수동 심사 (기본)
+	KYCProviderExternal KYCProviderType = "external" // 외부 KYC 업체 HTTP 연동
+)
+
+// KYCDecision 제공업체 심사 결과
+type KYCDecision string
+
+const (
+	KYCDecisionPending  KYCDecision = "pending"
+	KYCDecisionApproved KYCDecision = "approved"
+	KYCDecisionRejected KYCDecision = "rejected"
+)
+
+// KYCApplicant 제공업체에 전달하는 신청자 정보
+type KYCApplicant struct {
+	UserID       uint           `json:"external_user_id"`
+	Email        string         `json:"email"`
+	Tier         models.KYCTier `json:"level"`
+	FullName     string         `json:"full_name"`
+	DateOfBirth  string         `json:"date_of_birth"`
+	Country      string         `json:"country"`
+	DocumentType string         `json:"document_type"`
+}
+
+// KYCProviderResult 제공업체 응답
+type KYCProviderResult struct {
+	Reference string      `json:"reference"`
+	Decision  KYCDecision `json:"decision"`
+	Reason    string      `json:"reason,omitempty"`
+	AMLHit    bool        `json:"aml_hit"` // 제재/PEP 목록 일치
+}
+
+// KYCProvider 본인 인증 제공업체가 구현해야 하는 인터페이스
+type KYCProvider interface {
+	// Name 제공업체 이름 (KYCVerification.Provider에 기록)
+	Name() string
+
+	// Submit 심사 요청 (즉시 결정되지 않으면 pending 반환)
+	Submit(ctx context.Context, applicant KYCApplicant) (*KYCProviderResult, error)
+
+	// FetchResult 심사 결과 조회 (pending 건 주기적 동기화용)
+	FetchResult(ctx context.Context, reference string) (*KYCProviderResult, error)
+}
+
+// KYCProviderConfig 제공업체 설정
+type KYCProviderConfig struct {
+	Provider string
+	APIURL   string
+	APIKey   string
+}
+
+// NewKYCProvider 설정에 맞는 제공업체 생성
+func NewKYCProvider(cfg KYCProviderConfig) (KYCProvider, error) {
+	switch KYCProviderType(cfg.Provider) {
+	case KYCProviderManual, "":
+		return &ManualKYCProvider{}, nil
+	case KYCProviderExternal:
+		if cfg.APIURL == "" || cfg.APIKey == "" {
+			return nil, fmt.Errorf("외부 KYC 제공업체는 KYC_API_URL, KYC_API_KEY 설정이 필요합니다")
+		}
+		return NewExternalKYCProvider(cfg.APIURL, cfg.APIKey), nil
+	default:
+		return nil, fmt.Errorf("지원되지 않는 KYC 제공업체입니다: %s", cfg.Provider)
+	}
+}
+
+// ManualKYCProvider 운영자 수동 심사 (관리자 심사 API로 결정)
+type ManualKYCProvider struct{}
+
+func (p *ManualKYCProvider) Name() string { return string(KYCProviderManual) }
+
+func (p *ManualKYCProvider) Submit(ctx context.Context, applicant KYCApplicant) (*KYCProviderResult, error) {
+	return &KYCProviderResult{
+		Reference: fmt.Sprintf("manual-%d-%d", applicant.UserID, time.Now().Unix()),
+		Decision:  KYCDecisionPending,
+	}, nil
+}
+
+func (p *ManualKYCProvider) FetchResult(ctx context.Context, reference string) (*KYCProviderResult, error) {
+	// 수동 심사는 관리자 API에서만 결정됨
+	return &KYCProviderResult{Reference: reference, Decision: KYCDecisionPending}, nil
+}
+
+// ExternalKYCProvider 외부 KYC 업체 연동 어댑터
+//
+// 업체별 SDK 대신 최소한의 REST 계약만 가정한다:
+//
+//	POST {api_url}/applicants        → KYCProviderResult
+//	GET  {api_url}/applicants/{ref}  → KYCProviderResult
+//
+// 실제 업체(Sumsub, Onfido 등) 연동 시 이 어댑터를 교체하거나 프록시를 둔다.
+type ExternalKYCProvider struct {
+	apiURL string
+	apiKey string
+	client *http.Client
+}
+
+// NewExternalKYCProvider 생성자
+func NewExternalKYCProvider(apiURL, apiKey string) *ExternalKYCProvider {
+	return &ExternalKYCProvider{
+		apiURL: apiURL,
+		apiKey: apiKey,
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (p *ExternalKYCProvider) Name() string { return string(KYCProviderExternal) }
+
+func (p *ExternalKYCProvider) Submit(ctx context.Context, applicant KYCApplicant) (*KYCProviderResult, error) {
+	body, err := json.Marshal(applicant)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.apiURL+"/applicants", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return p.do(req)
+}
+
+func (p *ExternalKYCProvider) FetchResult(ctx context.Context, reference string) (*KYCProviderResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.apiURL+"/applicants/"+reference, nil)
+	if err != nil {
+		return nil, err
+	}
+	return p.do(req)
+}
+
+func (p *ExternalKYCProvider) do(req *http.Request) (*KYCProviderResult, error) {
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("KYC 제공업체 요청 실패: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("KYC 제공업체 오류: HTTP %d", resp.StatusCode)
+	}
+
+	var result KYCProviderResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("KYC 제공업체 응답 파싱 실패: %w", err)
+	}
+	if result.Decision == "" {
+		result.Decision = KYCDecisionPending
+	}
+	return &result, nil
+}
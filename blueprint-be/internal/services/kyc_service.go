@@ -0,0 +1,379 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"strings"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/redis"
+
+	"gorm.io/gorm"
+)
+
+const (
+	kycMinimumAge        = 18
+	kycDocumentURLTTL    = 15 * time.Minute
+	kycWithdrawalKeyTTL  = 48 * time.Hour
+	kycProviderTimeout   = 20 * time.Second
+	kycDocumentCategory  = "verification_docs"
+	kycDocumentSizeLimit = 10 * 1024 * 1024
+)
+
+var (
+	ErrKYCOrderLimitExceeded      = errors.New("본인 인증 단계의 주문 한도를 초과했습니다")
+	ErrKYCWithdrawalLimitExceeded = errors.New("본인 인증 단계의 일일 출금 한도를 초과했습니다")
+	ErrKYCAlreadyPending          = errors.New("이미 심사 중인 본인 인증 신청이 있습니다")
+	ErrKYCNotPending              = errors.New("심사 대기 중인 신청이 아닙니다")
+)
+
+// kycDocumentTypes 허용되는 신분증 파일 형식 (업로드 내용 기준)
+var kycDocumentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"application/pdf": true,
+}
+
+// amlBlockedCountries 제재 대상 국가 (신청 즉시 거절)
+var amlBlockedCountries = map[string]bool{
+	"KP": true, // 북한
+	"IR": true, // 이란
+	"SY": true, // 시리아
+	"CU": true, // 쿠바
+}
+
+// KYCService 본인 인증(KYC/AML) 및 인증 단계별 한도 관리 서비스
+type KYCService struct {
+	db                  *gorm.DB
+	provider            KYCProvider
+	fileService         *FileService
+	notificationService *NotificationService
+}
+
+// NewKYCService 생성자
+func NewKYCService(db *gorm.DB, provider KYCProvider, fileService *FileService) *KYCService {
+	return &KYCService{
+		db:                  db,
+		provider:            provider,
+		fileService:         fileService,
+		notificationService: NewNotificationService(db),
+	}
+}
+
+// GetStatus 사용자 인증 상태 + 한도
+func (s *KYCService) GetStatus(userID uint) (*models.KYCStatusResponse, error) {
+	var record models.KYCVerification
+	err := s.db.Where("user_id = ?", userID).First(&record).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("본인 인증 정보 조회 실패: %w", err)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		record.Status = models.KYCStatusNone
+	}
+
+	response := &models.KYCStatusResponse{
+		Tier:            record.Tier,
+		Status:          record.Status,
+		RejectionReason: record.RejectionReason,
+		Limits:          models.KYCTierLimits[record.Tier],
+	}
+	if record.Status == models.KYCStatusPending {
+		response.RequestedTier = record.RequestedTier
+	}
+	if next, ok := models.KYCTierLimits[record.Tier+1]; ok {
+		response.NextTierLimits = &next
+	}
+	return response, nil
+}
+
+// GetTier 승인된 인증 단계 (기록이 없으면 미인증)
+func (s *KYCService) GetTier(userID uint) models.KYCTier {
+	var record models.KYCVerification
+	if err := s.db.Select("tier").Where("user_id = ?", userID).First(&record).Error; err != nil {
+		return models.KYCTierNone
+	}
+	return record.Tier
+}
+
+// CheckOrderLimit 주문 금액(센트)이 인증 단계 한도 이내인지 확인
+func (s *KYCService) CheckOrderLimit(userID uint, orderValue int64) error {
+	tier := s.GetTier(userID)
+	limit := models.KYCTierLimits[tier]
+	if orderValue > limit.MaxOrderValue {
+		return fmt.Errorf("%w: 주문당 최대 $%.2f (인증 단계 %d)", ErrKYCOrderLimitExceeded, float64(limit.MaxOrderValue)/100, tier)
+	}
+	return nil
+}
+
+// ReserveWithdrawal 일일 출금 한도 차감 (출금 처리 전에 호출, 실패 시 ReleaseWithdrawal로 복구)
+func (s *KYCService) ReserveWithdrawal(userID uint, amount int64) error {
+	tier := s.GetTier(userID)
+	limit := models.KYCTierLimits[tier].DailyWithdrawal
+	if amount > limit {
+		return fmt.Errorf("%w: 일일 최대 $%.2f (인증 단계 %d)", ErrKYCWithdrawalLimitExceeded, float64(limit)/100, tier)
+	}
+
+	client := redis.GetClient()
+	if client == nil {
+		return nil // 누적 집계 불가 - 건별 한도만 적용
+	}
+
+	ctx := context.Background()
+	key := withdrawalCounterKey(userID)
+	total, err := client.IncrBy(ctx, key, amount).Result()
+	if err != nil {
+		return fmt.Errorf("출금 한도 확인 실패: %w", err)
+	}
+	client.Expire(ctx, key, kycWithdrawalKeyTTL)
+
+	if total > limit {
+		client.DecrBy(ctx, key, amount)
+		return fmt.Errorf("%w: 오늘 남은 한도 $%.2f", ErrKYCWithdrawalLimitExceeded, float64(limit-(total-amount))/100)
+	}
+	return nil
+}
+
+// ReleaseWithdrawal 실패한 출금의 한도 복구
+func (s *KYCService) ReleaseWithdrawal(userID uint, amount int64) {
+	if client := redis.GetClient(); client != nil {
+		client.DecrBy(context.Background(), withdrawalCounterKey(userID), amount)
+	}
+}
+
+// Submit 본인 인증 신청 (AML 사전 심사 → 제공업체 접수)
+func (s *KYCService) Submit(userID uint, req *models.SubmitKYCRequest, file multipart.File, header *multipart.FileHeader) (*models.KYCVerification, error) {
+	req.Country = strings.ToUpper(req.Country)
+	if err := validateDateOfBirth(req.DateOfBirth); err != nil {
+		return nil, err
+	}
+
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return nil, errors.New("사용자를 찾을 수 없습니다")
+	}
+
+	var record models.KYCVerification
+	err := s.db.Where("user_id = ?", userID).First(&record).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("본인 인증 정보 조회 실패: %w", err)
+	}
+	if record.Status == models.KYCStatusPending {
+		return nil, ErrKYCAlreadyPending
+	}
+	if record.Tier >= req.Tier {
+		return nil, fmt.Errorf("이미 인증 단계 %d 이상이 승인되었습니다", req.Tier)
+	}
+
+	// 수동 심사는 신분증 사본이 반드시 필요
+	if file != nil {
+		if header.Size > kycDocumentSizeLimit {
+			return nil, errors.New("신분증 파일은 10MB 이하만 업로드할 수 있습니다")
+		}
+		stored, err := s.fileService.StoreFile(file, header, kycDocumentCategory)
+		if err != nil {
+			return nil, err
+		}
+		if !kycDocumentTypes[stored.ContentType] {
+			s.fileService.RemoveStoredFile(stored)
+			return nil, errors.New("신분증은 JPEG, PNG, PDF 형식만 허용됩니다")
+		}
+		record.DocumentPath = stored.Path()
+	} else if s.provider.Name() == string(KYCProviderManual) {
+		return nil, errors.New("신분증 사본(document)을 첨부해주세요")
+	}
+
+	now := time.Now()
+	record.UserID = userID
+	record.RequestedTier = req.Tier
+	record.Status = models.KYCStatusPending
+	record.Provider = s.provider.Name()
+	record.FullName = req.FullName
+	record.DateOfBirth = req.DateOfBirth
+	record.Country = req.Country
+	record.DocumentType = req.DocumentType
+	record.AMLFlagged = false
+	record.RejectionReason = ""
+	record.ReviewedBy = nil
+	record.ReviewedAt = nil
+	record.SubmittedAt = &now
+
+	// AML 사전 심사 (제재 대상 국가)
+	if amlBlockedCountries[req.Country] {
+		record.AMLFlagged = true
+		if err := s.db.Save(&record).Error; err != nil {
+			return nil, fmt.Errorf("본인 인증 신청 저장 실패: %w", err)
+		}
+		s.applyResult(&record, &KYCProviderResult{Decision: KYCDecisionRejected, Reason: "서비스 제공이 제한된 국가입니다", AMLHit: true}, nil)
+		return &record, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), kycProviderTimeout)
+	defer cancel()
+	result, err := s.provider.Submit(ctx, KYCApplicant{
+		UserID:       userID,
+		Email:        user.Email,
+		Tier:         req.Tier,
+		FullName:     req.FullName,
+		DateOfBirth:  req.DateOfBirth,
+		Country:      req.Country,
+		DocumentType: req.DocumentType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("본인 인증 접수 실패: %w", err)
+	}
+	record.ProviderReference = result.Reference
+
+	if err := s.db.Save(&record).Error; err != nil {
+		return nil, fmt.Errorf("본인 인증 신청 저장 실패: %w", err)
+	}
+	if result.Decision != KYCDecisionPending {
+		s.applyResult(&record, result, nil)
+	}
+
+	log.Printf("🪪 KYC tier %d submitted by user %d via %s (%s)", req.Tier, userID, record.Provider, record.Status)
+	return &record, nil
+}
+
+// ListPending 심사 대기 목록 (심사자용 서명 URL 포함)
+func (s *KYCService) ListPending(limit, offset int) ([]models.KYCVerification, int64, error) {
+	query := s.db.Model(&models.KYCVerification{}).Where("status = ?", models.KYCStatusPending)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("심사 대기 건수 조회 실패: %w", err)
+	}
+
+	var records []models.KYCVerification
+	if err := query.Preload("User").Order("submitted_at ASC").Limit(limit).Offset(offset).Find(&records).Error; err != nil {
+		return nil, 0, fmt.Errorf("심사 대기 목록 조회 실패: %w", err)
+	}
+
+	for i := range records {
+		if records[i].DocumentPath != "" {
+			records[i].DocumentURL, _ = s.fileService.SignedURL(records[i].DocumentPath, kycDocumentURLTTL)
+		}
+	}
+	return records, total, nil
+}
+
+// Review 운영자 수동 심사
+func (s *KYCService) Review(reviewerID, verificationID uint, req *models.ReviewKYCRequest) (*models.KYCVerification, error) {
+	var record models.KYCVerification
+	if err := s.db.First(&record, verificationID).Error; err != nil {
+		return nil, errors.New("본인 인증 신청을 찾을 수 없습니다")
+	}
+	if record.Status != models.KYCStatusPending {
+		return nil, ErrKYCNotPending
+	}
+	if !req.Approved && req.Reason == "" {
+		return nil, errors.New("거절 사유를 입력해주세요")
+	}
+
+	decision := KYCDecisionApproved
+	if !req.Approved {
+		decision = KYCDecisionRejected
+	}
+	if err := s.applyResult(&record, &KYCProviderResult{Decision: decision, Reason: req.Reason}, &reviewerID); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// SyncPending 제공업체에서 결정된 심사 결과 반영 (반영된 건수 반환)
+func (s *KYCService) SyncPending() (int, error) {
+	var records []models.KYCVerification
+	if err := s.db.Where("status = ? AND provider = ? AND provider_reference <> ''",
+		models.KYCStatusPending, s.provider.Name()).Find(&records).Error; err != nil {
+		return 0, err
+	}
+
+	synced := 0
+	for i := range records {
+		ctx, cancel := context.WithTimeout(context.Background(), kycProviderTimeout)
+		result, err := s.provider.FetchResult(ctx, records[i].ProviderReference)
+		cancel()
+		if err != nil {
+			log.Printf("⚠️ KYC result fetch failed (user %d): %v", records[i].UserID, err)
+			continue
+		}
+		if result.Decision == KYCDecisionPending {
+			continue
+		}
+		if err := s.applyResult(&records[i], result, nil); err == nil {
+			synced++
+		}
+	}
+	return synced, nil
+}
+
+// applyResult 심사 결과 반영 + 사용자 알림
+func (s *KYCService) applyResult(record *models.KYCVerification, result *KYCProviderResult, reviewerID *uint) error {
+	now := time.Now()
+	record.ReviewedAt = &now
+	record.ReviewedBy = reviewerID
+
+	approved := result.Decision == KYCDecisionApproved && !result.AMLHit
+	if approved {
+		record.Status = models.KYCStatusApproved
+		record.Tier = record.RequestedTier
+		record.RejectionReason = ""
+	} else {
+		record.Status = models.KYCStatusRejected
+		record.RejectionReason = result.Reason
+		if result.AMLHit && record.RejectionReason == "" {
+			record.RejectionReason = "AML 심사에서 확인이 필요한 항목이 발견되었습니다"
+		}
+		record.AMLFlagged = record.AMLFlagged || result.AMLHit
+	}
+
+	if err := s.db.Model(record).
+		Select("status", "tier", "rejection_reason", "aml_flagged", "reviewed_at", "reviewed_by").
+		Updates(record).Error; err != nil {
+		return fmt.Errorf("본인 인증 결과 저장 실패: %w", err)
+	}
+
+	title := "본인 인증이 거절되었습니다"
+	message := record.RejectionReason
+	if approved {
+		title = "본인 인증이 완료되었습니다"
+		limits := models.KYCTierLimits[record.Tier]
+		message = fmt.Sprintf("주문당 최대 $%.2f, 일일 출금 최대 $%.2f까지 이용할 수 있습니다",
+			float64(limits.MaxOrderValue)/100, float64(limits.DailyWithdrawal)/100)
+	}
+	if _, err := s.notificationService.Notify(models.CreateNotificationRequest{
+		UserID:   record.UserID,
+		Type:     models.NotificationTypeKYC,
+		Priority: models.NotificationPriorityHigh,
+		Title:    title,
+		Message:  message,
+		Link:     "/settings",
+	}); err != nil {
+		log.Printf("⚠️ KYC 결과 알림 실패 (user %d): %v", record.UserID, err)
+	}
+
+	MarkTrustScoreStale(record.UserID)
+	recordActivity(models.ActivityVerificationKYCReviewed, record.UserID, title, models.ActivityMetadata{ResourceID: string(record.Status)})
+
+	log.Printf("🪪 KYC %s for user %d (tier %d)", record.Status, record.UserID, record.Tier)
+	return nil
+}
+
+// validateDateOfBirth 생년월일 형식 및 최소 연령 확인
+func validateDateOfBirth(value string) error {
+	dob, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return errors.New("생년월일은 YYYY-MM-DD 형식이어야 합니다")
+	}
+	if dob.AddDate(kycMinimumAge, 0, 0).After(time.Now()) {
+		return fmt.Errorf("만 %d세 이상만 본인 인증을 신청할 수 있습니다", kycMinimumAge)
+	}
+	return nil
+}
+
+func withdrawalCounterKey(userID uint) string {
+	return fmt.Sprintf("kyc_withdrawal:%d:%s", userID, time.Now().UTC().Format("2006-01-02"))
+}
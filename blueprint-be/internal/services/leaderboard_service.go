@@ -0,0 +1,268 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/redis"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// LeaderboardSize 종류/기간별로 캐시하는 최대 순위 수
+	LeaderboardSize = 1000
+
+	// LeaderboardMinValidations 검증인 리더보드 최소 투표 수 (소수 표본의 100% 정확도 방지)
+	LeaderboardMinValidations = 3
+)
+
+// leaderboardRow 집계 결과 한 줄
+type leaderboardRow struct {
+	UserID     uint
+	Score      float64
+	SampleSize int
+}
+
+// LeaderboardService 트레이더/검증인/멘토 리더보드 집계 및 조회 서비스
+type LeaderboardService struct {
+	db *gorm.DB
+}
+
+// NewLeaderboardService 생성자
+func NewLeaderboardService(db *gorm.DB) *LeaderboardService {
+	return &LeaderboardService{
+		db: db,
+	}
+}
+
+// RecomputeAll 모든 종류/기간 리더보드 재계산
+func (s *LeaderboardService) RecomputeAll() {
+	now := time.Now()
+	for _, kind := range models.LeaderboardKinds {
+		for _, period := range models.LeaderboardPeriods {
+			if err := s.Recompute(kind, period, now); err != nil {
+				log.Printf("❌ Failed to compute %s/%s leaderboard: %v", kind, period, err)
+			}
+		}
+	}
+}
+
+// RecomputeChanged 바뀐 종류(stats:dirty:leaderboard)의 모든 기간만 재계산 (재계산한 리더보드 수 반환)
+//
+// 체결은 트레이더, 검증 판정은 검증인, 성과 지표 계산은 멘토 리더보드를 표시한다.
+// 기간 창이 밀리며 빠지는 기록은 RecomputeAll 전체 재계산이 맞춘다.
+func (s *LeaderboardService) RecomputeChanged() (int, error) {
+	members, err := redis.PopStatsDirty(redis.StatsScopeLeaderboard, int64(len(models.LeaderboardKinds)))
+	if err != nil {
+		return 0, fmt.Errorf("리더보드 재계산 대상 조회 실패: %w", err)
+	}
+
+	now := time.Now()
+	recomputed := 0
+	for _, member := range members {
+		kind := models.LeaderboardKind(member)
+		if !kind.IsValid() {
+			continue
+		}
+		for _, period := range models.LeaderboardPeriods {
+			if err := s.Recompute(kind, period, now); err != nil {
+				log.Printf("❌ Failed to compute %s/%s leaderboard: %v", kind, period, err)
+				MarkLeaderboardDirty(kind) // 다음 주기에 다시 시도
+				continue
+			}
+			recomputed++
+		}
+	}
+	return recomputed, nil
+}
+
+// MarkLeaderboardDirty 리더보드 재계산 요청
+func MarkLeaderboardDirty(kind models.LeaderboardKind) {
+	if err := redis.MarkStatsDirty(redis.StatsScopeLeaderboard, string(kind)); err != nil {
+		log.Printf("⚠️ 리더보드 재계산 요청 실패 (%s): %v", kind, err)
+	}
+}
+
+// Recompute 리더보드 하나를 계산해 캐시 테이블 교체
+func (s *LeaderboardService) Recompute(kind models.LeaderboardKind, period models.LeaderboardPeriod, now time.Time) error {
+	since := period.Since(now)
+
+	var rows []leaderboardRow
+	var err error
+	switch kind {
+	case models.LeaderboardTraders:
+		rows, err = s.computeTraderPnL(since)
+	case models.LeaderboardValidators:
+		rows, err = s.computeValidatorAccuracy(since)
+	case models.LeaderboardMentors:
+		rows, err = s.computeMentorPerformance(period, since)
+	default:
+		return fmt.Errorf("unknown leaderboard kind: %s", kind)
+	}
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Score != rows[j].Score {
+			return rows[i].Score > rows[j].Score
+		}
+		if rows[i].SampleSize != rows[j].SampleSize {
+			return rows[i].SampleSize > rows[j].SampleSize
+		}
+		return rows[i].UserID < rows[j].UserID
+	})
+	if len(rows) > LeaderboardSize {
+		rows = rows[:LeaderboardSize]
+	}
+
+	usernames, err := s.loadUsernames(rows)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]models.LeaderboardEntry, 0, len(rows))
+	for i, row := range rows {
+		entries = append(entries, models.LeaderboardEntry{
+			Kind:       kind,
+			Period:     period,
+			Rank:       i + 1,
+			UserID:     row.UserID,
+			Username:   usernames[row.UserID],
+			Score:      row.Score,
+			SampleSize: row.SampleSize,
+			ComputedAt: now,
+		})
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("kind = ? AND period = ?", kind, period).
+			Delete(&models.LeaderboardEntry{}).Error; err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(&entries, 200).Error
+	})
+}
+
+// computeTraderPnL 기간 내 체결의 손익 (현재 시장가 기준 평가, 수수료 차감, 센트 단위)
+func (s *LeaderboardService) computeTraderPnL(since time.Time) ([]leaderboardRow, error) {
+	var rows []leaderboardRow
+	err := s.db.Raw(`
+		SELECT user_id, SUM(pnl) AS score, COUNT(*) AS sample_size FROM (
+			SELECT t.buyer_id AS user_id,
+				t.quantity * (COALESCE(md.current_price, t.price) - t.price) * 100 - t.buyer_fee AS pnl
+			FROM trades t
+			LEFT JOIN market_data md ON md.milestone_id = t.milestone_id AND md.option_id = t.option_id
+			WHERE t.created_at >= ?
+			UNION ALL
+			SELECT t.seller_id AS user_id,
+				t.quantity * (t.price - COALESCE(md.current_price, t.price)) * 100 - t.seller_fee AS pnl
+			FROM trades t
+			LEFT JOIN market_data md ON md.milestone_id = t.milestone_id AND md.option_id = t.option_id
+			WHERE t.created_at >= ?
+		) fills
+		GROUP BY user_id`, since, since).Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("트레이더 손익 집계 실패: %w", err)
+	}
+	return rows, nil
+}
+
+// computeValidatorAccuracy 최종 판정과 일치한 검증 투표 비율 (%)
+func (s *LeaderboardService) computeValidatorAccuracy(since time.Time) ([]leaderboardRow, error) {
+	var rows []leaderboardRow
+	err := s.db.Raw(`
+		SELECT pv.user_id AS user_id,
+			100.0 * SUM(CASE
+				WHEN pv.vote = 'approve' AND mv.final_result = 'approved' THEN 1
+				WHEN pv.vote = 'reject' AND mv.final_result = 'rejected' THEN 1
+				ELSE 0 END) / COUNT(*) AS score,
+			COUNT(*) AS sample_size
+		FROM proof_validators pv
+		JOIN milestone_verifications mv ON mv.proof_id = pv.proof_id
+		WHERE mv.final_result IN ('approved', 'rejected')
+			AND pv.vote IN ('approve', 'reject')
+			AND pv.voted_at >= ?
+		GROUP BY pv.user_id
+		HAVING COUNT(*) >= ?`, since, LeaderboardMinValidations).Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("검증 정확도 집계 실패: %w", err)
+	}
+	return rows, nil
+}
+
+// computeMentorPerformance 기간에 해당하는 성과 지표의 평균 종합 점수
+func (s *LeaderboardService) computeMentorPerformance(period models.LeaderboardPeriod, since time.Time) ([]leaderboardRow, error) {
+	query := s.db.Table("mentor_performance_metrics pm").
+		Select("m.user_id AS user_id, AVG(pm.performance_score) AS score, COUNT(*) AS sample_size").
+		Joins("JOIN mentors m ON m.id = pm.mentor_id").
+		Group("m.user_id")
+
+	switch period {
+	case models.LeaderboardWeekly:
+		query = query.Where("pm.period_type = ? AND pm.end_date >= ?", models.MetricPeriodWeekly, since)
+	case models.LeaderboardMonthly:
+		query = query.Where("pm.period_type = ? AND pm.end_date >= ?", models.MetricPeriodMonthly, since)
+	}
+
+	var rows []leaderboardRow
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("멘토 성과 집계 실패: %w", err)
+	}
+	return rows, nil
+}
+
+func (s *LeaderboardService) loadUsernames(rows []leaderboardRow) (map[uint]string, error) {
+	usernames := make(map[uint]string, len(rows))
+	if len(rows) == 0 {
+		return usernames, nil
+	}
+
+	userIDs := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		userIDs = append(userIDs, row.UserID)
+	}
+
+	var users []models.User
+	if err := s.db.Select("id", "username").Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("사용자 조회 실패: %w", err)
+	}
+	for _, user := range users {
+		usernames[user.ID] = user.Username
+	}
+	return usernames, nil
+}
+
+// GetLeaderboard 캐시된 리더보드 조회
+func (s *LeaderboardService) GetLeaderboard(kind models.LeaderboardKind, period models.LeaderboardPeriod, limit, offset int) (*models.LeaderboardResponse, error) {
+	response := &models.LeaderboardResponse{
+		Kind:    kind,
+		Period:  period,
+		Entries: []models.LeaderboardEntry{},
+		Limit:   limit,
+		Offset:  offset,
+	}
+
+	if err := s.db.Model(&models.LeaderboardEntry{}).
+		Where("kind = ? AND period = ?", kind, period).
+		Count(&response.Total).Error; err != nil {
+		return nil, fmt.Errorf("리더보드 조회 실패: %w", err)
+	}
+	if err := s.db.Where("kind = ? AND period = ?", kind, period).
+		Order("rank ASC").Limit(limit).Offset(offset).Find(&response.Entries).Error; err != nil {
+		return nil, fmt.Errorf("리더보드 조회 실패: %w", err)
+	}
+
+	if len(response.Entries) > 0 {
+		computedAt := response.Entries[0].ComputedAt
+		response.ComputedAt = &computedAt
+	}
+	return response, nil
+}
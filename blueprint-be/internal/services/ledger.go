@@ -0,0 +1,82 @@
+package services
+
+import (
+	"fmt"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// postLedgerEntry 지갑 잔액 변경과 원장 기록을 같은 트랜잭션에서 처리
+func postLedgerEntry(tx *gorm.DB, entry *models.WalletLedgerEntry) error {
+	if entry.Currency == "" {
+		return fmt.Errorf("원장 통화가 비어 있습니다")
+	}
+	if entry.Amount == 0 && entry.LockedAmount == 0 {
+		return nil
+	}
+	if err := changeWalletBalance(tx, entry.UserID, entry.Currency, entry.Amount, entry.LockedAmount); err != nil {
+		return err
+	}
+
+	if err := tx.Create(entry).Error; err != nil {
+		return fmt.Errorf("원장 기록 실패: %w", err)
+	}
+	publishWalletChanged(entry.UserID, string(entry.EntryType))
+	return nil
+}
+
+// walletBalance 통화별 잔액 위치 (갱신 대상 쿼리, 사용 가능/잠긴 잔액 컬럼)
+// usdc/blueprint는 UserWallet 컬럼, 설정으로 추가한 결제 통화는 WalletAccount 행
+func walletBalance(tx *gorm.DB, userID uint, currency models.LedgerCurrency) (*gorm.DB, string, string) {
+	switch currency {
+	case models.LedgerCurrencyUSDC:
+		return tx.Model(&models.UserWallet{}).Where("user_id = ?", userID), "usdc_balance", "usdc_locked_balance"
+	case models.LedgerCurrencyBlueprint:
+		return tx.Model(&models.UserWallet{}).Where("user_id = ?", userID), "blueprint_balance", "blueprint_locked_balance"
+	default:
+		return tx.Model(&models.WalletAccount{}).Where("user_id = ? AND currency = ?", userID, currency), "available", "locked"
+	}
+}
+
+// changeWalletBalance 사용 가능/잠긴 잔액을 컬럼 표현식으로 변경 (추가 통화 계정은 없으면 만듦)
+func changeWalletBalance(tx *gorm.DB, userID uint, currency models.LedgerCurrency, amount, locked int64) error {
+	if err := ensureWalletAccount(tx, userID, currency); err != nil {
+		return err
+	}
+
+	scope, balanceColumn, lockedColumn := walletBalance(tx, userID, currency)
+	updates := map[string]interface{}{}
+	if amount != 0 {
+		updates[balanceColumn] = gorm.Expr(balanceColumn+" + ?", amount)
+	}
+	if locked != 0 {
+		updates[lockedColumn] = gorm.Expr(lockedColumn+" + ?", locked)
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	result := scope.Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("지갑 잔액 변경 실패: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("사용자 %d의 지갑을 찾을 수 없습니다", userID)
+	}
+	return nil
+}
+
+// ensureWalletAccount 추가 결제 통화 계정 행 생성 (이미 있거나 usdc/blueprint면 그대로)
+func ensureWalletAccount(tx *gorm.DB, userID uint, currency models.LedgerCurrency) error {
+	if currency == models.LedgerCurrencyUSDC || currency == models.LedgerCurrencyBlueprint {
+		return nil
+	}
+	account := models.WalletAccount{UserID: userID, Currency: currency}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&account).Error; err != nil {
+		return fmt.Errorf("%s 지갑 계정 생성 실패: %w", currency, err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"log"
+	"sync"
+
+	"blueprint-module/pkg/models"
+)
+
+// LiquidationEngine 가격 변동 기반 강제 청산 워커
+//
+// 저장된 체결을 구독해(TradeListener) 강제 환매 주문 체결을 기록하고, 체결가로 가격이 움직인 마켓을 대기열에 넣는다.
+// 워커 고루틴이 대기열의 마켓마다 숏 포지션 보유자의 유지 증거금을 검사해 부족하면 주문장에 환매 주문을 낸다.
+// 환매 주문도 체결 구독을 다시 부르므로 검사는 체결 후처리 고루틴이 아닌 워커에서 한다.
+type LiquidationEngine struct {
+	marginService *MarginService
+
+	mu       sync.Mutex
+	pending  map[marginMarket]bool // 검사할 마켓 (같은 마켓 체결이 몰려도 한 번만 검사)
+	wake     chan struct{}
+	stopChan chan struct{}
+	done     chan struct{}
+	running  bool
+}
+
+var _ TradeListener = (*LiquidationEngine)(nil)
+
+// NewLiquidationEngine 생성자
+func NewLiquidationEngine(marginService *MarginService) *LiquidationEngine {
+	return &LiquidationEngine{
+		marginService: marginService,
+		pending:       make(map[marginMarket]bool),
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+// Start 강제 청산 워커 시작
+func (e *LiquidationEngine) Start() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.running {
+		return nil
+	}
+	e.running = true
+	e.stopChan = make(chan struct{})
+	e.done = make(chan struct{})
+	go e.run(e.stopChan, e.done)
+
+	log.Println("📉 Liquidation engine started!")
+	return nil
+}
+
+// Stop 워커 종료 (진행 중인 검사는 마치고 반환)
+func (e *LiquidationEngine) Stop() {
+	e.mu.Lock()
+	if !e.running {
+		e.mu.Unlock()
+		return
+	}
+	e.running = false
+	close(e.stopChan)
+	done := e.done
+	e.mu.Unlock()
+
+	<-done
+	log.Println("✅ Liquidation engine stopped")
+}
+
+// OnTradesPersisted 강제 환매 주문 체결 기록 후 체결된 마켓을 검사 대기열에 추가 (TradeListener)
+func (e *LiquidationEngine) OnTradesPersisted(trades []models.Trade) {
+	e.marginService.RecordFills(trades)
+
+	e.mu.Lock()
+	for _, trade := range trades {
+		e.pending[marginMarket{trade.MilestoneID, trade.OptionID}] = true
+	}
+	e.mu.Unlock()
+
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (e *LiquidationEngine) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	for {
+		select {
+		case <-stop:
+			return
+		case <-e.wake:
+			e.checkPending()
+		}
+	}
+}
+
+// checkPending 대기열의 마켓을 비우고 마켓별로 증거금 검사
+func (e *LiquidationEngine) checkPending() {
+	e.mu.Lock()
+	markets := e.pending
+	e.pending = make(map[marginMarket]bool)
+	e.mu.Unlock()
+
+	for market := range markets {
+		placed, err := e.marginService.CheckMarket(market.milestoneID, market.optionID)
+		if err != nil {
+			log.Printf("⚠️ Failed to check margins for milestone %d/%s: %v", market.milestoneID, market.optionID, err)
+			continue
+		}
+		if placed > 0 {
+			log.Printf("📉 Placed %d liquidation orders after price move on milestone %d/%s", placed, market.milestoneID, market.optionID)
+		}
+	}
+}
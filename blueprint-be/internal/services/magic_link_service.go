@@ -0,0 +1,424 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	// 6자리 코드 추측 방지: 이메일별 코드는 실패 5회면 링크째 취소, IP당 실패는 구간 안 허용 횟수 제한
+	magicLinkCodeMaxAttempts   = 5
+	magicLinkCodeFailureLimit  = 10
+	magicLinkCodeFailureWindow = 15 * time.Minute
+)
+
+var (
+	ErrMagicLinkUnavailable    = errors.New("매직링크 저장소를 사용할 수 없습니다")
+	ErrMagicLinkThrottled      = errors.New("매직링크 요청이 너무 많습니다. 잠시 후 다시 시도해주세요")
+	ErrMagicLinkInvalid        = errors.New("유효하지 않거나 만료된 로그인 링크입니다")
+	ErrMagicLinkDeviceMismatch = errors.New("로그인 링크를 요청한 기기에서만 사용할 수 있습니다")
+)
+
+// MagicLinkPolicy 매직링크 발급/사용 정책
+type MagicLinkPolicy struct {
+	SigningSecret  string
+	TTL            time.Duration
+	ResendInterval time.Duration // 같은 이메일 재발송 최소 간격 (0이면 제한 없음)
+	HourlyLimit    int           // 이메일당 시간당 발송 횟수
+	BindDevice     bool          // 요청한 기기(User-Agent + device_id)에서만 사용 허용
+}
+
+// MagicLinkClient 요청한 클라이언트 정보 (기기 바인딩/감사 기록용)
+type MagicLinkClient struct {
+	UserAgent string
+	DeviceID  string
+	IPAddress string
+}
+
+// IssuedMagicLink 발급 결과 (Token/Code 원문은 이메일로만 전달)
+type IssuedMagicLink struct {
+	Link  *models.MagicLink
+	Token string
+	Code  string
+}
+
+// magicLinkRecord Redis 저장 형식 (키는 토큰 식별자 해시)
+type magicLinkRecord struct {
+	LinkID     uint   `json:"link_id"`
+	Email      string `json:"email"`
+	DeviceHash string `json:"device_hash,omitempty"`
+}
+
+// magicLinkCode 이메일별 코드 저장 형식 (이메일당 마지막으로 발급한 링크 하나)
+type magicLinkCode struct {
+	Code      string `json:"code"`
+	TokenHash string `json:"token_hash"`
+}
+
+// MagicLinkService 일회용 매직링크 발급/소비
+//
+// 링크 토큰 형식: <nonce>.<HMAC-SHA256(secret, nonce)>
+// 서명으로 위조 토큰을 걸러내고, Redis에는 nonce 해시만 보관해 GETDEL로 한 번만 소비한다.
+// 같은 링크의 6자리 코드도 함께 발급되며 어느 쪽으로 로그인하든 링크 전체가 소비된다.
+// 코드는 받은 이메일과 함께만 쓸 수 있고, 틀린 코드가 magicLinkCodeMaxAttempts회 쌓이면 링크가 취소된다.
+type MagicLinkService struct {
+	db     *gorm.DB
+	policy MagicLinkPolicy
+}
+
+// NewMagicLinkService 생성자
+func NewMagicLinkService(db *gorm.DB, policy MagicLinkPolicy) *MagicLinkService {
+	return &MagicLinkService{
+		db:     db,
+		policy: policy,
+	}
+}
+
+// TTL 링크 유효 시간
+func (s *MagicLinkService) TTL() time.Duration {
+	return s.policy.TTL
+}
+
+// Issue 새 매직링크 발급 (같은 이메일의 미사용 링크는 모두 무효화)
+func (s *MagicLinkService) Issue(email, referralCode string, client MagicLinkClient) (*IssuedMagicLink, error) {
+	rdb := moduleRedis.GetClient()
+	if rdb == nil {
+		return nil, ErrMagicLinkUnavailable
+	}
+	ctx := context.Background()
+
+	if err := s.throttleIssue(ctx, rdb, email); err != nil {
+		return nil, err
+	}
+
+	nonce, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	tokenHash := hashMagicLinkNonce(nonce)
+
+	code, err := generateMagicLinkCode()
+	if err != nil {
+		return nil, err
+	}
+
+	s.revokePending(ctx, rdb, email)
+
+	link := models.MagicLink{
+		Email:        email,
+		Code:         code,
+		ExpiresAt:    time.Now().Add(s.policy.TTL),
+		ReferralCode: referralCode,
+		TokenHash:    tokenHash,
+	}
+	if err := s.db.Create(&link).Error; err != nil {
+		return nil, fmt.Errorf("매직링크 저장 실패: %w", err)
+	}
+
+	record := magicLinkRecord{LinkID: link.ID, Email: email}
+	if s.policy.BindDevice {
+		record.DeviceHash = magicLinkDeviceHash(client)
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	codeData, err := json.Marshal(magicLinkCode{Code: code, TokenHash: tokenHash})
+	if err != nil {
+		return nil, err
+	}
+	pipe := rdb.TxPipeline()
+	pipe.Set(ctx, magicLinkTokenKey(tokenHash), data, s.policy.TTL)
+	pipe.Set(ctx, magicLinkCodeKey(email), codeData, s.policy.TTL)
+	pipe.Del(ctx, magicLinkCodeAttemptsKey(email))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("매직링크 저장 실패: %w", err)
+	}
+
+	s.audit(&link, models.MagicLinkEventIssued, "", client)
+
+	return &IssuedMagicLink{
+		Link:  &link,
+		Token: nonce + "." + s.sign(nonce),
+		Code:  code,
+	}, nil
+}
+
+// ConsumeToken 이메일 링크 토큰으로 로그인 (한 번만 성공)
+func (s *MagicLinkService) ConsumeToken(token string, client MagicLinkClient) (*models.MagicLink, error) {
+	rdb := moduleRedis.GetClient()
+	if rdb == nil {
+		return nil, ErrMagicLinkUnavailable
+	}
+
+	nonce, signature, ok := strings.Cut(token, ".")
+	if !ok || nonce == "" || !hmac.Equal([]byte(signature), []byte(s.sign(nonce))) {
+		return nil, ErrMagicLinkInvalid
+	}
+
+	return s.consume(context.Background(), rdb, hashMagicLinkNonce(nonce), client)
+}
+
+// ConsumeCode 이메일과 6자리 코드로 로그인 (이메일별 시도 횟수, IP당 실패 횟수 제한)
+func (s *MagicLinkService) ConsumeCode(email, code string, client MagicLinkClient) (*models.MagicLink, error) {
+	rdb := moduleRedis.GetClient()
+	if rdb == nil {
+		return nil, ErrMagicLinkUnavailable
+	}
+	ctx := context.Background()
+
+	failureKey := "magic_link:code_failures:" + client.IPAddress
+	if failures, _ := rdb.Get(ctx, failureKey).Int(); failures >= magicLinkCodeFailureLimit {
+		return nil, ErrMagicLinkThrottled
+	}
+
+	data, err := rdb.Get(ctx, magicLinkCodeKey(email)).Bytes()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("매직링크 조회 실패: %w", err)
+	}
+	var current magicLinkCode
+	if err == nil {
+		if err := json.Unmarshal(data, &current); err != nil {
+			return nil, fmt.Errorf("매직링크 데이터 손상: %w", err)
+		}
+	}
+
+	if current.TokenHash == "" || !hmac.Equal([]byte(current.Code), []byte(code)) {
+		pipe := rdb.TxPipeline()
+		pipe.Incr(ctx, failureKey)
+		pipe.Expire(ctx, failureKey, magicLinkCodeFailureWindow)
+		if _, err := pipe.Exec(ctx); err != nil {
+			log.Printf("⚠️ Failed to count magic link code failure: %v", err)
+		}
+		if current.TokenHash == "" {
+			s.rejectSpent(s.db.Where("email = ? AND code = ?", email, code), client)
+		} else {
+			s.countCodeFailure(ctx, rdb, email, current.TokenHash, client)
+		}
+		return nil, ErrMagicLinkInvalid
+	}
+
+	return s.consume(ctx, rdb, current.TokenHash, client)
+}
+
+// countCodeFailure 이메일별 틀린 코드 횟수 기록 (한도에 닿으면 링크 취소)
+func (s *MagicLinkService) countCodeFailure(ctx context.Context, rdb *redis.Client, email, tokenHash string, client MagicLinkClient) {
+	attemptsKey := magicLinkCodeAttemptsKey(email)
+	attempts, err := rdb.Incr(ctx, attemptsKey).Result()
+	if err != nil {
+		log.Printf("⚠️ Failed to count magic link code attempt for %s: %v", email, err)
+		return
+	}
+	rdb.Expire(ctx, attemptsKey, s.policy.TTL)
+	if attempts < magicLinkCodeMaxAttempts {
+		return
+	}
+
+	// 링크 토큰까지 지워 이메일 링크로도 쓸 수 없게 함 (새 링크를 요청해야 함)
+	rdb.Del(ctx, magicLinkCodeKey(email), attemptsKey, magicLinkTokenKey(tokenHash))
+	var link models.MagicLink
+	if err := s.db.Where("token_hash = ?", tokenHash).First(&link).Error; err == nil {
+		s.audit(&link, models.MagicLinkEventRejected, "too_many_attempts", client)
+	}
+	log.Printf("🔒 Magic link for %s cancelled after %d wrong codes", email, attempts)
+}
+
+// consume 링크를 원자적으로 꺼내(GETDEL) 사용 처리 - 동시에 두 번 호출돼도 하나만 성공
+func (s *MagicLinkService) consume(ctx context.Context, rdb *redis.Client, tokenHash string, client MagicLinkClient) (*models.MagicLink, error) {
+	data, err := rdb.GetDel(ctx, magicLinkTokenKey(tokenHash)).Bytes()
+	if err == redis.Nil {
+		s.rejectSpent(s.db.Where("token_hash = ?", tokenHash), client)
+		return nil, ErrMagicLinkInvalid
+	}
+	if err != nil {
+		return nil, fmt.Errorf("매직링크 조회 실패: %w", err)
+	}
+
+	var record magicLinkRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("매직링크 데이터 손상: %w", err)
+	}
+	rdb.Del(ctx, magicLinkCodeKey(record.Email), magicLinkCodeAttemptsKey(record.Email))
+
+	var link models.MagicLink
+	if err := s.db.First(&link, record.LinkID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMagicLinkInvalid
+		}
+		return nil, err
+	}
+
+	if record.DeviceHash != "" && !hashEqual(record.DeviceHash, magicLinkDeviceHash(client)) {
+		s.audit(&link, models.MagicLinkEventRejected, "device_mismatch", client)
+		return nil, ErrMagicLinkDeviceMismatch
+	}
+
+	now := time.Now()
+	result := s.db.Model(&models.MagicLink{}).
+		Where("id = ? AND is_used = ? AND expires_at > ?", link.ID, false, now).
+		Updates(map[string]interface{}{"is_used": true, "consumed_at": now})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		s.audit(&link, models.MagicLinkEventRejected, spentReason(&link, now), client)
+		return nil, ErrMagicLinkInvalid
+	}
+
+	link.IsUsed = true
+	link.ConsumedAt = &now
+	s.audit(&link, models.MagicLinkEventConsumed, "", client)
+	return &link, nil
+}
+
+// AttachUser 로그인한 사용자를 링크에 연결
+func (s *MagicLinkService) AttachUser(link *models.MagicLink, userID uint) error {
+	link.UserID = &userID
+	return s.db.Model(link).Update("user_id", userID).Error
+}
+
+// throttleIssue 이메일별 재발송 간격과 시간당 발송 횟수 제한
+func (s *MagicLinkService) throttleIssue(ctx context.Context, rdb *redis.Client, email string) error {
+	email = strings.ToLower(email)
+
+	if s.policy.ResendInterval > 0 {
+		ok, err := rdb.SetNX(ctx, "magic_link:resend:"+email, 1, s.policy.ResendInterval).Result()
+		if err != nil {
+			return fmt.Errorf("재발송 제한 확인 실패: %w", err)
+		}
+		if !ok {
+			return ErrMagicLinkThrottled
+		}
+	}
+
+	if s.policy.HourlyLimit > 0 {
+		hourlyKey := "magic_link:hourly:" + email
+		count, err := rdb.Incr(ctx, hourlyKey).Result()
+		if err != nil {
+			return fmt.Errorf("발송 횟수 확인 실패: %w", err)
+		}
+		if count == 1 {
+			rdb.Expire(ctx, hourlyKey, time.Hour)
+		}
+		if count > int64(s.policy.HourlyLimit) {
+			return ErrMagicLinkThrottled
+		}
+	}
+	return nil
+}
+
+// revokePending 같은 이메일의 미사용 링크 무효화 (마지막으로 받은 메일만 유효)
+func (s *MagicLinkService) revokePending(ctx context.Context, rdb *redis.Client, email string) {
+	var pending []models.MagicLink
+	if err := s.db.Where("email = ? AND is_used = ?", email, false).Find(&pending).Error; err != nil {
+		log.Printf("⚠️ Failed to load pending magic links for %s: %v", email, err)
+		return
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	ids := make([]uint, 0, len(pending))
+	for _, link := range pending {
+		ids = append(ids, link.ID)
+		if link.TokenHash != "" {
+			rdb.Del(ctx, magicLinkTokenKey(link.TokenHash))
+		}
+	}
+	rdb.Del(ctx, magicLinkCodeKey(email))
+	s.db.Where("id IN ?", ids).Delete(&models.MagicLink{})
+}
+
+// rejectSpent 이미 소비됐거나 만료된 링크 재사용 시도 기록
+func (s *MagicLinkService) rejectSpent(query *gorm.DB, client MagicLinkClient) {
+	var link models.MagicLink
+	if err := query.First(&link).Error; err != nil {
+		return
+	}
+	s.audit(&link, models.MagicLinkEventRejected, spentReason(&link, time.Now()), client)
+}
+
+func (s *MagicLinkService) audit(link *models.MagicLink, event models.MagicLinkEventType, reason string, client MagicLinkClient) {
+	userAgent := client.UserAgent
+	if len(userAgent) > 500 {
+		userAgent = userAgent[:500]
+	}
+	record := models.MagicLinkEvent{
+		MagicLinkID: link.ID,
+		Email:       link.Email,
+		Event:       event,
+		Reason:      reason,
+		IPAddress:   client.IPAddress,
+		UserAgent:   userAgent,
+	}
+	if err := s.db.Create(&record).Error; err != nil {
+		log.Printf("⚠️ Failed to record magic link %s event for link %d: %v", event, link.ID, err)
+	}
+}
+
+func (s *MagicLinkService) sign(nonce string) string {
+	mac := hmac.New(sha256.New, []byte(s.policy.SigningSecret))
+	mac.Write([]byte("magic_link:" + nonce))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// spentReason 소비할 수 없는 링크의 거부 사유
+func spentReason(link *models.MagicLink, now time.Time) string {
+	switch {
+	case link.IsUsed:
+		return "replayed"
+	case now.After(link.ExpiresAt):
+		return "expired"
+	default:
+		return "revoked"
+	}
+}
+
+// generateMagicLinkCode 6자리 숫자 코드
+func generateMagicLinkCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(900000))
+	if err != nil {
+		return "", fmt.Errorf("코드 생성 실패: %w", err)
+	}
+	return fmt.Sprintf("%06d", 100000+n.Int64()), nil
+}
+
+func magicLinkDeviceHash(client MagicLinkClient) string {
+	sum := sha256.Sum256([]byte(client.UserAgent + "\n" + client.DeviceID))
+	return hex.EncodeToString(sum[:])
+}
+
+func hashMagicLinkNonce(nonce string) string {
+	sum := sha256.Sum256([]byte(nonce))
+	return hex.EncodeToString(sum[:])
+}
+
+func magicLinkTokenKey(tokenHash string) string {
+	return "magic_link:token:" + tokenHash
+}
+
+// magicLinkCodeKey 이메일별 코드 키 (대소문자 구분 없음)
+func magicLinkCodeKey(email string) string {
+	return "magic_link:code:" + strings.ToLower(email)
+}
+
+func magicLinkCodeAttemptsKey(email string) string {
+	return "magic_link:code_attempts:" + strings.ToLower(email)
+}
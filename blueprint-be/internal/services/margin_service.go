@@ -0,0 +1,427 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	liquidationUserAgent      = "margin-liquidation" // 강제 환매 주문의 UserAgent (주문 내역에서 구분)
+	liquidationSlippageTicks  = 500                  // 환매 지정가 = 현재가 + 5¢ (호가가 얇아도 체결되도록)
+	marginLiquidationListSize = 50
+	maintenanceMarginPercent  = 10 // 유지 증거금률 (환매 비용의 10%를 여유분으로 요구)
+)
+
+// ErrWalletNotFound 지갑이 없는 사용자
+var ErrWalletNotFound = errors.New("지갑을 찾을 수 없습니다")
+
+// marginMarket 마일스톤 옵션 (미체결 강제 환매 주문 조회 키)
+type marginMarket struct {
+	milestoneID uint
+	optionID    string
+}
+
+// MarginService 숏 포지션 포트폴리오 증거금 검사와 강제 청산
+//
+// 숏 매도분은 주문 시점에 1주당 최대 손실(1 - 지정가)을 담보로 잠그고(lockShortCollateral), 체결되면 포지션 담보가 된다.
+// 매도 대금은 사용 가능 잔액으로 들어오므로 인출하거나 다른 곳에 쓰면 가격이 오를 때 환매 비용을 감당하지 못할 수 있다.
+// 평가 자산(사용 가능 USDC + 숏 담보 + 매수 포지션 평가액)이 유지 증거금(숏 환매 비용 합계 + 10%)보다 적은 사용자는
+// 숏을 늘리는 미체결 매도 주문을 취소하고, 포지션 담보를 풀어 모든 숏 포지션을 현재가 + 5¢ 지정가 매수로 환매한다.
+// 가격이 움직인 마켓은 강제 청산 워커(LiquidationEngine)가 바로 검사하고, 주기 검사가 전체를 한 번 더 훑는다.
+type MarginService struct {
+	db                  *gorm.DB
+	tradingService      *TradingService
+	notificationService *NotificationService
+	mu                  sync.Mutex // 가격 변동 검사와 주기 검사가 같은 사용자를 동시에 청산하지 않도록
+}
+
+// NewMarginService 생성자
+func NewMarginService(db *gorm.DB, tradingService *TradingService) *MarginService {
+	return &MarginService{
+		db:                  db,
+		tradingService:      tradingService,
+		notificationService: NewNotificationService(db),
+	}
+}
+
+// GetSummary 사용자 포트폴리오 증거금 현황
+func (s *MarginService) GetSummary(userID uint) (*models.MarginSummary, error) {
+	var wallet models.UserWallet
+	if err := s.db.Select("usdc_balance").Where("user_id = ?", userID).First(&wallet).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, err
+	}
+
+	var positions []models.Position
+	if err := s.db.Where("user_id = ? AND quantity <> 0", userID).Order("id").Find(&positions).Error; err != nil {
+		return nil, fmt.Errorf("포지션 조회 실패: %w", err)
+	}
+
+	liquidating, err := s.openLiquidations(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &models.MarginSummary{
+		UserID:         userID,
+		Available:      wallet.USDCBalance,
+		ShortPositions: []models.MarginPosition{},
+	}
+	for _, position := range positions {
+		mark, err := s.markTicks(position.MilestoneID, position.OptionID)
+		if err != nil {
+			return nil, err
+		}
+		if position.Quantity > 0 {
+			summary.LongValue += models.NotionalCents(position.Quantity, mark)
+			continue
+		}
+
+		short := models.MarginPosition{
+			PositionID:  position.ID,
+			MilestoneID: position.MilestoneID,
+			OptionID:    position.OptionID,
+			Quantity:    -position.Quantity,
+			Collateral:  position.Collateral,
+			MarkPrice:   models.TicksToPrice(mark),
+			BuybackCost: models.ReserveCents(-position.Quantity, mark),
+			Liquidating: liquidating[marginMarket{position.MilestoneID, position.OptionID}],
+		}
+		summary.Collateral += short.Collateral
+		summary.Liability += short.BuybackCost
+		summary.ShortPositions = append(summary.ShortPositions, short)
+	}
+
+	summary.Equity = summary.Available + summary.Collateral + summary.LongValue
+	if summary.Liability > 0 {
+		summary.Maintenance = summary.Liability + (summary.Liability*maintenanceMarginPercent+99)/100
+		summary.MarginRatio = float64(summary.Equity) / float64(summary.Liability)
+		summary.Insufficient = summary.Equity < summary.Maintenance
+	}
+	return summary, nil
+}
+
+// ListLiquidations 내 강제 청산 내역 (최신순)
+func (s *MarginService) ListLiquidations(userID uint) ([]models.MarginLiquidation, error) {
+	liquidations := []models.MarginLiquidation{}
+	err := s.db.Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(marginLiquidationListSize).
+		Find(&liquidations).Error
+	return liquidations, err
+}
+
+// CheckMargins 숏 포지션 보유자 전체 증거금 검사 후 부족하면 강제 청산 (낸 환매 주문 수 반환, 스케줄러 작업)
+func (s *MarginService) CheckMargins(now time.Time) (int, error) {
+	if err := s.syncLiquidations(); err != nil {
+		log.Printf("⚠️ Failed to sync margin liquidations: %v", err)
+	}
+
+	var userIDs []uint
+	if err := s.db.Model(&models.Position{}).Where("quantity < 0").Distinct().Pluck("user_id", &userIDs).Error; err != nil {
+		return 0, fmt.Errorf("숏 포지션 보유자 조회 실패: %w", err)
+	}
+	return s.checkUsers(userIDs, models.MarginLiquidationTriggerSweep), nil
+}
+
+// CheckMarket 가격이 움직인 마켓에 숏 포지션이 있는 사용자만 검사 (강제 청산 워커)
+func (s *MarginService) CheckMarket(milestoneID uint, optionID string) (int, error) {
+	var userIDs []uint
+	if err := s.db.Model(&models.Position{}).
+		Where("milestone_id = ? AND option_id = ? AND quantity < 0", milestoneID, optionID).
+		Distinct().Pluck("user_id", &userIDs).Error; err != nil {
+		return 0, fmt.Errorf("숏 포지션 보유자 조회 실패: %w", err)
+	}
+	return s.checkUsers(userIDs, models.MarginLiquidationTriggerPriceMove), nil
+}
+
+// checkUsers 사용자별 증거금 검사, 유지 증거금에 못 미치면 강제 청산 (낸 환매 주문 수 반환)
+func (s *MarginService) checkUsers(userIDs []uint, trigger models.MarginLiquidationTrigger) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	liquidated := 0
+	for _, userID := range userIDs {
+		summary, err := s.GetSummary(userID)
+		if err != nil {
+			log.Printf("⚠️ Failed to check margin for user %d: %v", userID, err)
+			continue
+		}
+		if !summary.Insufficient {
+			continue
+		}
+		liquidated += s.liquidate(summary, trigger)
+	}
+	return liquidated
+}
+
+// liquidate 숏을 늘리는 매도 주문 취소 후 청산 중이 아닌 모든 숏 포지션 환매 주문
+func (s *MarginService) liquidate(summary *models.MarginSummary, trigger models.MarginLiquidationTrigger) int {
+	userID := summary.UserID
+	log.Printf("📉 Margin call for user %d (%s): equity %d < maintenance %d", userID, trigger, summary.Equity, summary.Maintenance)
+
+	placed := 0
+	for _, short := range summary.ShortPositions {
+		if short.Liquidating {
+			continue
+		}
+		if err := s.cancelShortSells(userID, short.MilestoneID, short.OptionID); err != nil {
+			log.Printf("⚠️ Failed to cancel sells before liquidation for user %d: %v", userID, err)
+		}
+
+		liquidation, err := s.liquidatePosition(summary, short, trigger)
+		if err != nil {
+			log.Printf("❌ Failed to liquidate position %d for user %d: %v", short.PositionID, userID, err)
+			continue
+		}
+		placed++
+		s.notify(userID, liquidation)
+	}
+	publishWalletChanged(userID, "margin_call")
+	return placed
+}
+
+// liquidatePosition 포지션 담보를 풀고 사용 가능 잔액 한도에서 숏 수량 환매 주문
+func (s *MarginService) liquidatePosition(summary *models.MarginSummary, short models.MarginPosition, trigger models.MarginLiquidationTrigger) (*models.MarginLiquidation, error) {
+	var milestone models.Milestone
+	if err := s.db.Select("id", "project_id", "price_tick_size").First(&milestone, short.MilestoneID).Error; err != nil {
+		return nil, err
+	}
+
+	var released int64
+	var available int64
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var position models.Position
+		if err := tx.Select("id", "collateral").First(&position, short.PositionID).Error; err != nil {
+			return err
+		}
+		if position.Collateral > 0 {
+			if err := tx.Model(&models.Position{}).Where("id = ?", position.ID).UpdateColumn("collateral", 0).Error; err != nil {
+				return err
+			}
+			if err := releaseOrderFunds(tx, summary.UserID, position.Collateral); err != nil {
+				return err
+			}
+			released = position.Collateral
+		}
+
+		var wallet models.UserWallet
+		if err := tx.Select("usdc_balance").Where("user_id = ?", summary.UserID).First(&wallet).Error; err != nil {
+			return err
+		}
+		available = wallet.USDCBalance
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("담보 해제 실패: %w", err)
+	}
+
+	priceTicks := liquidationPriceTicks(models.PriceToTicks(short.MarkPrice), milestone.PriceTickSize)
+	quantity := min(short.Quantity, models.MaxReserveQuantity(available, priceTicks))
+	if quantity < 1 {
+		return nil, fmt.Errorf("%w: 환매 자금 없음", ErrInsufficientBalance)
+	}
+
+	response, err := s.tradingService.createLiquidationOrder(summary.UserID, models.CreateOrderRequest{
+		ProjectID:   milestone.ProjectID,
+		MilestoneID: short.MilestoneID,
+		OptionID:    short.OptionID,
+		Type:        models.OrderTypeLimit,
+		Side:        models.OrderSideBuy,
+		Quantity:    quantity,
+		Price:       models.TicksToPrice(priceTicks),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	liquidation := &models.MarginLiquidation{
+		UserID:      summary.UserID,
+		PositionID:  short.PositionID,
+		MilestoneID: short.MilestoneID,
+		OptionID:    short.OptionID,
+		OrderID:     response.Order.ID,
+		Trigger:     trigger,
+		Status:      models.MarginLiquidationOpen,
+		Quantity:    quantity,
+		PriceTicks:  priceTicks,
+		Released:    released,
+		Equity:      summary.Equity,
+		Liability:   summary.Liability,
+		Maintenance: summary.Maintenance,
+	}
+	if err := s.db.Create(liquidation).Error; err != nil {
+		return nil, err
+	}
+	return liquidation, nil
+}
+
+// cancelShortSells 청산할 마켓의 미체결 매도 주문 취소 (숏 매도분 담보도 함께 해제)
+func (s *MarginService) cancelShortSells(userID, milestoneID uint, optionID string) error {
+	var orderIDs []uint
+	if err := s.db.Model(&models.Order{}).
+		Where("user_id = ? AND milestone_id = ? AND option_id = ? AND side = ? AND status IN ?",
+			userID, milestoneID, optionID, models.OrderSideSell, openOrderStatuses).
+		Pluck("id", &orderIDs).Error; err != nil {
+		return err
+	}
+	for _, orderID := range orderIDs {
+		if _, err := s.tradingService.CancelOrder(userID, orderID); err != nil && !errors.Is(err, ErrOrderNotOpen) {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordFills 강제 환매 주문 체결 수량 반영, 전량 체결되면 완료 처리 후 알림 (강제 청산 워커가 체결마다 호출)
+func (s *MarginService) RecordFills(trades []models.Trade) {
+	filled := make(map[uint]int64)
+	for _, trade := range trades {
+		filled[trade.BuyOrderID] += trade.Quantity
+	}
+	orderIDs := make([]uint, 0, len(filled))
+	for orderID := range filled {
+		orderIDs = append(orderIDs, orderID)
+	}
+
+	var liquidations []models.MarginLiquidation
+	if err := s.db.Where("order_id IN ? AND status = ?", orderIDs, models.MarginLiquidationOpen).Find(&liquidations).Error; err != nil {
+		log.Printf("⚠️ Failed to load margin liquidations for fills: %v", err)
+		return
+	}
+	for _, liquidation := range liquidations {
+		if err := s.db.Model(&models.MarginLiquidation{}).Where("id = ?", liquidation.ID).
+			Update("filled", gorm.Expr("filled + ?", filled[liquidation.OrderID])).Error; err != nil {
+			log.Printf("⚠️ Failed to record fill for margin liquidation %d: %v", liquidation.ID, err)
+			continue
+		}
+		result := s.db.Model(&models.MarginLiquidation{}).
+			Where("id = ? AND status = ? AND filled >= quantity", liquidation.ID, models.MarginLiquidationOpen).
+			Update("status", models.MarginLiquidationFilled)
+		if result.Error != nil {
+			log.Printf("⚠️ Failed to complete margin liquidation %d: %v", liquidation.ID, result.Error)
+			continue
+		}
+		if result.RowsAffected == 1 {
+			log.Printf("✅ Margin liquidation %d filled for user %d", liquidation.ID, liquidation.UserID)
+			s.notifyFilled(&liquidation)
+		}
+	}
+}
+
+// syncLiquidations 환매 주문이 전량 체결되지 않고 닫힌(취소/만료) 청산 기록을 취소 처리
+func (s *MarginService) syncLiquidations() error {
+	var liquidations []models.MarginLiquidation
+	if err := s.db.Select("id", "order_id").Where("status = ?", models.MarginLiquidationOpen).Find(&liquidations).Error; err != nil {
+		return err
+	}
+	for _, liquidation := range liquidations {
+		var order models.Order
+		if err := s.db.Select("status").First(&order, liquidation.OrderID).Error; err != nil {
+			return err
+		}
+		if order.Status == models.OrderStatusCancelled || order.Status == models.OrderStatusExpired {
+			if err := s.db.Model(&models.MarginLiquidation{}).
+				Where("id = ? AND status = ?", liquidation.ID, models.MarginLiquidationOpen).
+				Update("status", models.MarginLiquidationCancelled).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// openLiquidations 미체결 강제 환매 주문이 있는 마켓
+func (s *MarginService) openLiquidations(userID uint) (map[marginMarket]bool, error) {
+	var orders []models.Order
+	if err := s.db.Select("milestone_id", "option_id").
+		Where("user_id = ? AND user_agent = ? AND status IN ?", userID, liquidationUserAgent, openOrderStatuses).
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	open := make(map[marginMarket]bool, len(orders))
+	for _, order := range orders {
+		open[marginMarket{order.MilestoneID, order.OptionID}] = true
+	}
+	return open, nil
+}
+
+// markTicks 평가 가격 (시장 데이터 현재가, 없으면 마지막 체결가, 체결이 없으면 0)
+func (s *MarginService) markTicks(milestoneID uint, optionID string) (int64, error) {
+	var market models.MarketData
+	err := s.db.Select("current_price").
+		Where("milestone_id = ? AND option_id = ? AND current_price > 0", milestoneID, optionID).
+		First(&market).Error
+	if err == nil {
+		return models.PriceToTicks(market.CurrentPrice), nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+
+	var trade models.Trade
+	err = s.db.Select("price_ticks").
+		Where("milestone_id = ? AND option_id = ?", milestoneID, optionID).
+		Order("id DESC").
+		First(&trade).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	return trade.PriceTicks, err
+}
+
+func (s *MarginService) notify(userID uint, liquidation *models.MarginLiquidation) {
+	if _, err := s.notificationService.Notify(models.CreateNotificationRequest{
+		UserID:   userID,
+		Type:     models.NotificationTypeMarginCall,
+		Priority: models.NotificationPriorityHigh,
+		Title:    "증거금 부족 강제 청산",
+		Message: fmt.Sprintf("평가 자산 $%.2f가 유지 증거금 $%.2f보다 적어 숏 포지션 %d주를 환매 주문했습니다.",
+			float64(liquidation.Equity)/100, float64(liquidation.Maintenance)/100, liquidation.Quantity),
+		Link: "/portfolio/margin",
+		Data: map[string]interface{}{
+			"liquidation_id": liquidation.ID,
+			"milestone_id":   liquidation.MilestoneID,
+			"option_id":      liquidation.OptionID,
+			"order_id":       liquidation.OrderID,
+		},
+	}); err != nil {
+		log.Printf("⚠️ Failed to notify margin call for user %d: %v", userID, err)
+	}
+}
+
+func (s *MarginService) notifyFilled(liquidation *models.MarginLiquidation) {
+	if _, err := s.notificationService.Notify(models.CreateNotificationRequest{
+		UserID:  liquidation.UserID,
+		Type:    models.NotificationTypeMarginCall,
+		Title:   "강제 청산 완료",
+		Message: fmt.Sprintf("숏 포지션 강제 환매 주문 %d주가 모두 체결되었습니다.", liquidation.Quantity),
+		Link:    "/portfolio/margin",
+		Data: map[string]interface{}{
+			"liquidation_id": liquidation.ID,
+			"milestone_id":   liquidation.MilestoneID,
+			"option_id":      liquidation.OptionID,
+			"order_id":       liquidation.OrderID,
+		},
+	}); err != nil {
+		log.Printf("⚠️ Failed to notify margin liquidation fill for user %d: %v", liquidation.UserID, err)
+	}
+}
+
+// liquidationPriceTicks 환매 지정가 (현재가 + 슬리피지를 호가 단위로 올림, 최대 1 - 호가 단위)
+func liquidationPriceTicks(markTicks, tickSize int64) int64 {
+	if tickSize <= 0 {
+		tickSize = models.DefaultPriceTickSize
+	}
+	ticks := (markTicks + liquidationSlippageTicks + tickSize - 1) / tickSize * tickSize
+	return min(max(ticks, tickSize), models.PriceScale-tickSize)
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"container/heap"
+	"log"
+	"sync"
+	"time"
+
+	"blueprint-module/pkg/models"
+)
+
+const (
+	marketOrderBuffer  = 1000 // 시장별 주문 대기열 크기
+	marketCancelBuffer = 1000 // 시장별 취소 대기열 크기
+)
+
+// marketActor 시장 1개를 전담하는 단일 작성자 고루틴
+// 주문장은 이 고루틴만 읽고 쓰므로 매칭 경로에 잠금이 없고, 서로 다른 시장은 경합하지 않는다
+type marketActor struct {
+	book    *OrderBookEngine
+	orders  chan *OrderMatchRequest
+	cancels chan *CancelRequest         // 신규 주문보다 먼저 처리
+	queries chan func(*OrderBookEngine) // 호가 조회 등 읽기 작업
+	start   sync.Once
+
+	// 시장별 통계 (GetStats에서 합산)
+	statsMutex sync.Mutex
+	stats      MatchingStats
+}
+
+func newMarketActor(milestoneID uint, optionID string) *marketActor {
+	book := &OrderBookEngine{
+		MilestoneID: milestoneID,
+		OptionID:    optionID,
+		BuyOrders:   &BuyOrderHeap{},
+		SellOrders:  &SellOrderHeap{},
+		orderIndex:  make(map[uint]*models.Order),
+		priceIndex:  make(map[float64][]*models.Order),
+	}
+	heap.Init(book.BuyOrders)
+	heap.Init(book.SellOrders)
+
+	return &marketActor{
+		book:    book,
+		orders:  make(chan *OrderMatchRequest, marketOrderBuffer),
+		cancels: make(chan *CancelRequest, marketCancelBuffer),
+		queries: make(chan func(*OrderBookEngine)),
+	}
+}
+
+// actorFor 시장 액터 조회 또는 생성 (엔진 실행 중이면 바로 시작)
+func (me *LocalMatchingEngine) actorFor(milestoneID uint, optionID string) *marketActor {
+	key := me.getMarketKey(milestoneID, optionID)
+	if actor, ok := me.markets.Load(key); ok {
+		return actor.(*marketActor)
+	}
+
+	actual, loaded := me.markets.LoadOrStore(key, newMarketActor(milestoneID, optionID))
+	actor := actual.(*marketActor)
+	if !loaded && me.running.Load() {
+		me.startActor(actor)
+	}
+	return actor
+}
+
+// lookupActor 이미 있는 시장 액터만 조회
+func (me *LocalMatchingEngine) lookupActor(milestoneID uint, optionID string) (*marketActor, bool) {
+	actor, ok := me.markets.Load(me.getMarketKey(milestoneID, optionID))
+	if !ok {
+		return nil, false
+	}
+	return actor.(*marketActor), true
+}
+
+// eachActor 모든 시장 액터 순회
+func (me *LocalMatchingEngine) eachActor(fn func(*marketActor)) {
+	me.markets.Range(func(_, value interface{}) bool {
+		fn(value.(*marketActor))
+		return true
+	})
+}
+
+func (me *LocalMatchingEngine) startActor(actor *marketActor) {
+	actor.start.Do(func() {
+		me.goWorker(func() { me.runActor(actor) })
+	})
+}
+
+// runActor 시장 액터 루프 (취소 → 조회/주문 순)
+func (me *LocalMatchingEngine) runActor(actor *marketActor) {
+	for {
+		// 신규 주문보다 대기 중인 취소를 먼저 처리
+		select {
+		case request := <-actor.cancels:
+			me.applyCancel(actor, request)
+			continue
+		default:
+		}
+
+		select {
+		case <-me.stopChan:
+			return
+		case request := <-actor.cancels:
+			me.applyCancel(actor, request)
+		case query := <-actor.queries:
+			query(actor.book)
+		case request := <-actor.orders:
+			if !request.claim() {
+				continue // 호출자가 기다림을 포기한 주문
+			}
+			startTime := time.Now()
+			result := me.processOrder(actor.book, request.Order)
+
+			// 성능 통계 업데이트
+			processingTime := time.Since(startTime)
+			actor.recordMatch(processingTime)
+
+			// 느린 주문만 로그 출력 (100ms 이상)
+			if processingTime > 100*time.Millisecond {
+				log.Printf("⚠️ Slow order processing: market %d:%s, Order %d, Time %v",
+					actor.book.MilestoneID, actor.book.OptionID, request.Order.ID, processingTime)
+			}
+
+			// 응답 전송 (논블로킹)
+			select {
+			case request.Response <- result:
+			default:
+				// 응답 채널이 이미 닫혔거나 수신자가 없음 (타임아웃 발생)
+				log.Printf("⚠️ Response channel unavailable for order %d (likely timeout)", request.Order.ID)
+			}
+		}
+	}
+}
+
+// withBook 시장 액터 고루틴에서 fn 실행 후 완료까지 대기 (엔진 정지 상태면 직접 실행)
+// 액터 고루틴 안에서 호출하면 교착되므로 processOrder 등에서는 주문장을 직접 사용할 것
+func (me *LocalMatchingEngine) withBook(actor *marketActor, fn func(*OrderBookEngine)) bool {
+	if !me.running.Load() {
+		fn(actor.book)
+		return true
+	}
+
+	done := make(chan struct{})
+	select {
+	case actor.queries <- func(book *OrderBookEngine) {
+		fn(book)
+		close(done)
+	}:
+	case <-me.stopChan:
+		return false
+	}
+
+	select {
+	case <-done:
+		return true
+	case <-me.stopChan:
+		return false
+	}
+}
+
+func (a *marketActor) recordMatch(processingTime time.Duration) {
+	a.statsMutex.Lock()
+	defer a.statsMutex.Unlock()
+
+	a.stats.OrdersProcessed++
+	a.stats.TotalMatches++
+	a.stats.LastMatchTime = time.Now()
+
+	// 이동 평균으로 평균 매칭 시간 계산
+	a.stats.AvgMatchTime = (a.stats.AvgMatchTime * 0.95) + (processingTime.Seconds() * 1000 * 0.05)
+}
+
+// recordCancel 취소 처리 지연 시간 집계
+func (a *marketActor) recordCancel(latency time.Duration) {
+	a.statsMutex.Lock()
+	defer a.statsMutex.Unlock()
+
+	ms := latency.Seconds() * 1000
+	a.stats.CancelsProcessed++
+	if a.stats.CancelsProcessed == 1 {
+		a.stats.AvgCancelLatency = ms
+	} else {
+		a.stats.AvgCancelLatency = (a.stats.AvgCancelLatency * 0.95) + (ms * 0.05)
+	}
+	if ms > a.stats.MaxCancelLatency {
+		a.stats.MaxCancelLatency = ms
+	}
+	if latency > CancelSLA {
+		a.stats.CancelSLABreaches++
+		log.Printf("⚠️ Cancel SLA breached: %v (SLA %v)", latency, CancelSLA)
+	}
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrMarketClosed 마감 시각(설정 마감 또는 목표일)이 지나 결과 판정을 기다리는 마켓에 대한 주문
+	ErrMarketClosed = errors.New("거래가 마감되어 결과 판정을 기다리는 마켓입니다")
+	// ErrInvalidMarketCloseTime 결과 확정/종료된 마켓의 마감 시각 변경
+	ErrInvalidMarketCloseTime = errors.New("마감 시각을 바꿀 수 없는 마켓입니다")
+)
+
+// MarketCalendarEvent SSE market_closed / market_reopened 이벤트
+type MarketCalendarEvent struct {
+	MilestoneID   uint                      `json:"milestone_id"`
+	Closed        bool                      `json:"closed"`
+	State         models.MarketTradingState `json:"state"`
+	ClosesAt      *time.Time                `json:"closes_at,omitempty"`
+	ExpiredOrders int                       `json:"expired_orders,omitempty"`
+}
+
+// MarketCalendarService 마켓별 거래 마감 (목표일 또는 설정한 마감 시각)
+//
+// 신규 주문은 TradingService가 마감 시각으로 바로 거부하고, market_calendar 주기 작업이 마감된 마켓의 미체결 주문을
+// 주문장에서 제거해 만료 처리한 뒤 "closed_awaiting_resolution" 상태를 SSE로 알린다. 마감 처리한 시각은
+// trading_closed_at에 남기며, 목표일이 미뤄지거나 마감 시각을 다시 정해 마감이 미래가 되면 거래를 재개한다.
+type MarketCalendarService struct {
+	db             *gorm.DB
+	sseService     *SSEService
+	matchingEngine MatchingEngine
+}
+
+// NewMarketCalendarService 생성자
+func NewMarketCalendarService(db *gorm.DB, sseService *SSEService, matchingEngine MatchingEngine) *MarketCalendarService {
+	return &MarketCalendarService{
+		db:             db,
+		sseService:     sseService,
+		matchingEngine: matchingEngine,
+	}
+}
+
+// Sync 마감 시각이 지난 마켓을 마감하고, 마감 후 마감 시각이 미래로 바뀐 마켓을 재개
+func (s *MarketCalendarService) Sync(now time.Time) (closed, reopened int, err error) {
+	var due []models.Milestone
+	if err := s.db.Where("trading_closed_at IS NULL AND COALESCE(trading_closes_at, target_date) <= ?", now).
+		Find(&due).Error; err != nil {
+		return 0, 0, fmt.Errorf("마감 대상 마켓 조회 실패: %w", err)
+	}
+	for i := range due {
+		milestone := &due[i]
+		if milestone.Status.IsTerminal() || milestone.Status == models.MilestoneStatusProposal {
+			continue
+		}
+		if err := s.closeMarket(milestone, now); err != nil {
+			log.Printf("❌ Failed to close market %d: %v", milestone.ID, err)
+			continue
+		}
+		closed++
+	}
+
+	var rescheduled []models.Milestone
+	if err := s.db.Where("trading_closed_at IS NOT NULL AND (COALESCE(trading_closes_at, target_date) IS NULL OR COALESCE(trading_closes_at, target_date) > ?)", now).
+		Find(&rescheduled).Error; err != nil {
+		return closed, 0, fmt.Errorf("재개 대상 마켓 조회 실패: %w", err)
+	}
+	for i := range rescheduled {
+		milestone := &rescheduled[i]
+		if milestone.Status.IsTerminal() {
+			continue
+		}
+		if err := s.db.Model(&models.Milestone{}).Where("id = ?", milestone.ID).
+			UpdateColumn("trading_closed_at", nil).Error; err != nil {
+			log.Printf("❌ Failed to reopen market %d: %v", milestone.ID, err)
+			continue
+		}
+		milestone.TradingClosedAt = nil
+		log.Printf("▶️ Market %d reopened, now closes at %v", milestone.ID, milestone.TradingCloseTime())
+		BumpMarketSequence(milestone.ID)
+		s.broadcast(milestone, now, 0)
+		reopened++
+	}
+
+	return closed, reopened, nil
+}
+
+// closeMarket 미체결 주문을 주문장에서 제거해 만료 처리하고 마감 시각 기록
+func (s *MarketCalendarService) closeMarket(milestone *models.Milestone, now time.Time) error {
+	// 마감 기록을 먼저 남겨 다른 인스턴스가 같은 마켓을 중복 처리하지 않도록 함
+	result := s.db.Model(&models.Milestone{}).
+		Where("id = ? AND trading_closed_at IS NULL", milestone.ID).
+		UpdateColumn("trading_closed_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil
+	}
+	milestone.TradingClosedAt = &now
+
+	var orders []models.Order
+	if err := s.db.Where("milestone_id = ? AND status IN ?", milestone.ID, openOrderStatuses).
+		Find(&orders).Error; err != nil {
+		return fmt.Errorf("미체결 주문 조회 실패: %w", err)
+	}
+
+	// 주문장 제거를 확인하지 못한 주문은 체결될 수 있으므로 만료 처리하지 않음
+	stillBooked := make(map[uint]bool)
+	if s.matchingEngine != nil {
+		for i := range orders {
+			if err := s.matchingEngine.CancelOrder(&orders[i]); err != nil {
+				log.Printf("⚠️ Order %d left open on closed market %d: %v", orders[i].ID, milestone.ID, err)
+				stillBooked[orders[i].ID] = true
+			}
+		}
+		s.matchingEngine.FlushOrderStates()
+	}
+
+	expired := 0
+	for _, order := range orders {
+		if stillBooked[order.ID] {
+			continue
+		}
+		var closed *models.Order
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			closed, err = closeOrder(tx, order.ID, models.OrderStatusExpired)
+			return err
+		})
+		if errors.Is(err, ErrOrderNotOpen) {
+			continue // 주문장 제거 직전 전량 체결 또는 사용자 취소
+		}
+		if err != nil {
+			log.Printf("❌ Failed to expire order %d: %v", order.ID, err)
+			continue
+		}
+		publishOrderClosed(closed)
+		expired++
+	}
+
+	log.Printf("🔔 Market %d closed at %v, expired %d open orders", milestone.ID, milestone.TradingCloseTime(), expired)
+	BumpMarketSequence(milestone.ID)
+	s.broadcast(milestone, now, expired)
+	return nil
+}
+
+// SetCloseTime 마켓 마감 시각 지정 (nil이면 목표일 기준), 바로 마감/재개 반영
+func (s *MarketCalendarService) SetCloseTime(milestoneID uint, closesAt *time.Time) (*models.Milestone, error) {
+	var milestone models.Milestone
+	if err := s.db.First(&milestone, milestoneID).Error; err != nil {
+		return nil, err
+	}
+	if milestone.Status.IsTerminal() {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMarketCloseTime, milestone.Status)
+	}
+
+	if err := s.db.Model(&models.Milestone{}).Where("id = ?", milestoneID).
+		UpdateColumn("trading_closes_at", closesAt).Error; err != nil {
+		return nil, fmt.Errorf("마감 시각 저장 실패: %w", err)
+	}
+	BumpMarketSequence(milestoneID)
+
+	if _, _, err := s.Sync(time.Now()); err != nil {
+		return nil, err
+	}
+	var updated models.Milestone
+	if err := s.db.First(&updated, milestoneID).Error; err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (s *MarketCalendarService) broadcast(milestone *models.Milestone, now time.Time, expired int) {
+	if s.sseService == nil {
+		return
+	}
+	s.sseService.BroadcastMarketCalendar(MarketCalendarEvent{
+		MilestoneID:   milestone.ID,
+		Closed:        milestone.TradingClosedAt != nil,
+		State:         milestone.TradingState(now),
+		ClosesAt:      milestone.TradingCloseTime(),
+		ExpiredOrders: expired,
+	})
+}
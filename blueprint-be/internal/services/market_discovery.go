@@ -0,0 +1,178 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/redis"
+)
+
+// 마켓 목록 정렬 기준
+const (
+	MarketSortVolume      = "volume"       // 24시간 거래량 많은 순 (기본)
+	MarketSortClosingSoon = "closing_soon" // 마감 임박 순
+	MarketSortMovers      = "movers"       // 24시간 가격 변동률(절댓값) 큰 순
+	MarketSortNewest      = "newest"       // 최근 생성 순
+)
+
+// MarketStatusAll 상태 필터 해제 (기본은 거래 가능한 마켓만)
+const MarketStatusAll = "all"
+
+var ErrInvalidMarketSort = errors.New("지원하지 않는 정렬 기준입니다")
+
+// MarketListFilter 마켓 탐색 조건
+type MarketListFilter struct {
+	Category      models.ProjectCategory
+	Status        string        // 비우면 거래 가능 상태, "all"이면 전체
+	ClosingWithin time.Duration // 0보다 크면 해당 기간 안에 마감되는 마켓만
+	Sort          string
+	Limit         int
+	Offset        int
+}
+
+// MarketSummary 마켓 목록 항목
+type MarketSummary struct {
+	MilestoneID   uint                   `json:"milestone_id"`
+	ProjectID     uint                   `json:"project_id"`
+	Title         string                 `json:"title"`
+	ProjectTitle  string                 `json:"project_title"`
+	Category      models.ProjectCategory `json:"category"`
+	Status        models.MilestoneStatus `json:"status"`
+	ClosesAt      *time.Time             `json:"closes_at,omitempty"` // 증거 제출 마감 (없으면 목표일)
+	SuccessPrice  float64                `json:"success_price"`       // 성공 옵션 현재가 (= 시장이 보는 성공 확률)
+	ChangePercent float64                `json:"change_percent"`      // 성공 옵션 24시간 변동률 (%)
+	Volume24h     int64                  `json:"volume_24h"`
+	Trades24h     int                    `json:"trades_24h"`
+	Liquidity     int64                  `json:"liquidity"`
+	Views         int64                  `json:"views"`
+	ActiveUsers   int                    `json:"active_users"`
+}
+
+// ListMarkets 거래 가능한 마켓 탐색 (읽기 복제본의 MarketData 집계 + Redis 조회수/활성 사용자)
+func (s *TradingService) ListMarkets(filter MarketListFilter) ([]MarketSummary, int64, error) {
+	var order string
+	switch filter.Sort {
+	case "", MarketSortVolume:
+		order = "volume24h DESC, milestones.id DESC"
+	case MarketSortClosingSoon:
+		order = "COALESCE(milestones.proof_deadline, milestones.target_date) ASC, milestones.id ASC"
+	case MarketSortMovers:
+		order = "ABS(COALESCE(change_percent, 0)) DESC, volume24h DESC"
+	case MarketSortNewest:
+		order = "milestones.created_at DESC"
+	default:
+		return nil, 0, fmt.Errorf("%w: %s", ErrInvalidMarketSort, filter.Sort)
+	}
+
+	// 옵션별 MarketData를 마일스톤 단위로 집계 (가격/변동률은 성공 옵션 기준)
+	stats := s.readDB.Table("market_data").
+		Select(`milestone_id,
+			SUM(volume24h) AS volume24h,
+			SUM(trades24h) AS trades24h,
+			SUM(liquidity) AS liquidity,
+			MAX(CASE WHEN option_id = 'success' THEN current_price END) AS success_price,
+			MAX(CASE WHEN option_id = 'success' THEN change_percent END) AS change_percent`).
+		Group("milestone_id")
+
+	query := s.readDB.Table("milestones").
+		Joins("JOIN projects ON projects.id = milestones.project_id AND projects.deleted_at IS NULL").
+		Joins("LEFT JOIN (?) AS stats ON stats.milestone_id = milestones.id", stats).
+		Where("milestones.deleted_at IS NULL")
+
+	switch filter.Status {
+	case "":
+		query = query.Where("milestones.status IN ?", models.TradableMilestoneStatuses)
+	case MarketStatusAll:
+	default:
+		query = query.Where("milestones.status = ?", filter.Status)
+	}
+	if filter.Category != "" {
+		query = query.Where("projects.category = ?", filter.Category)
+	}
+
+	closesAt := "COALESCE(milestones.proof_deadline, milestones.target_date)"
+	if filter.Sort == MarketSortClosingSoon || filter.ClosingWithin > 0 {
+		query = query.Where(closesAt+" > ?", time.Now())
+	}
+	if filter.ClosingWithin > 0 {
+		query = query.Where(closesAt+" <= ?", time.Now().Add(filter.ClosingWithin))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("마켓 수 조회 실패: %w", err)
+	}
+
+	var rows []struct {
+		MilestoneID   uint
+		ProjectID     uint
+		Title         string
+		ProjectTitle  string
+		Category      models.ProjectCategory
+		Status        models.MilestoneStatus
+		ProofDeadline *time.Time
+		TargetDate    *time.Time
+		SuccessPrice  *float64
+		ChangePercent *float64
+		Volume24h     int64
+		Trades24h     int
+		Liquidity     int64
+	}
+	err := query.Select(`milestones.id AS milestone_id,
+			milestones.project_id,
+			milestones.title,
+			projects.title AS project_title,
+			projects.category,
+			milestones.status,
+			milestones.proof_deadline,
+			milestones.target_date,
+			stats.success_price,
+			stats.change_percent,
+			COALESCE(stats.volume24h, 0) AS volume24h,
+			COALESCE(stats.trades24h, 0) AS trades24h,
+			COALESCE(stats.liquidity, 0) AS liquidity`).
+		Order(order).
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("마켓 목록 조회 실패: %w", err)
+	}
+
+	cacheAvailable := redis.GetClient() != nil
+	markets := make([]MarketSummary, 0, len(rows))
+	for _, row := range rows {
+		market := MarketSummary{
+			MilestoneID:  row.MilestoneID,
+			ProjectID:    row.ProjectID,
+			Title:        row.Title,
+			ProjectTitle: row.ProjectTitle,
+			Category:     row.Category,
+			Status:       row.Status,
+			ClosesAt:     row.ProofDeadline,
+			SuccessPrice: 0.5, // 거래 전 마켓은 중립 가격
+			Volume24h:    row.Volume24h,
+			Trades24h:    row.Trades24h,
+			Liquidity:    row.Liquidity,
+		}
+		if market.ClosesAt == nil {
+			market.ClosesAt = row.TargetDate
+		}
+		if row.SuccessPrice != nil {
+			market.SuccessPrice = *row.SuccessPrice
+		}
+		if row.ChangePercent != nil {
+			market.ChangePercent = *row.ChangePercent
+		}
+		if cacheAvailable {
+			// 캐시 키가 없으면 0으로 둔다
+			market.Views, _ = redis.GetMarketViews(row.MilestoneID)
+			market.ActiveUsers, _ = redis.GetActiveUsers(row.MilestoneID)
+		}
+		markets = append(markets, market)
+	}
+
+	return markets, total, nil
+}
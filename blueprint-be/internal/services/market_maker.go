@@ -3,6 +3,7 @@ package services
 import (
 	"blueprint-module/pkg/models"
 	"blueprint-module/pkg/queue"
+	"errors"
 	"fmt"
 	"log"
 	"math"
@@ -12,11 +13,21 @@ import (
 	"gorm.io/gorm"
 )
 
+var (
+	// ErrMarketMakerRunning 이미 실행 중인 봇 시작 요청
+	ErrMarketMakerRunning = errors.New("market maker bot is already running")
+	// ErrMarketMakerStopped 실행 중이 아닌 봇 중지 요청
+	ErrMarketMakerStopped = errors.New("market maker bot is not running")
+	// ErrInvalidMarketMakerConfig 잘못된 봇 설정
+	ErrInvalidMarketMakerConfig = errors.New("invalid market maker config")
+)
+
 // MarketMakerBot 폴리마켓 스타일 마켓메이커 봇
 type MarketMakerBot struct {
 	db             *gorm.DB
 	tradingService *TradingService
 	queuePublisher *queue.Publisher
+	flags          FeatureChecker // 🚩 amm_liquidity 플래그 (nil이면 항상 호가 공급)
 
 	// 봇 설정
 	isRunning bool
@@ -29,6 +40,14 @@ type MarketMakerBot struct {
 
 	// 성과 추적
 	stats MarketMakerStats
+
+	// 손익 추적 (봇 체결 기준)
+	ledgers         map[string]*MarketMakerPnL // milestone_id:option_id -> 재고/원가 장부
+	lastTradeID     uint                       // 장부에 반영한 마지막 체결 ID
+	equityCurve     []int64                    // 사이클별 총 손익 (샤프 비율/낙폭 계산용)
+	peakPnL         int64                      // 총 손익 최고점
+	sessionBasePnL  int64                      // 시작 시점 총 손익 (킬 스위치 기준)
+	ordersCancelled int64
 }
 
 // MarketMakerConfig 마켓메이커 설정
@@ -43,10 +62,50 @@ type MarketMakerConfig struct {
 	RefreshInterval  int     `json:"refresh_interval"`  // 주문 갱신 주기 (초)
 	VolatilityFactor float64 `json:"volatility_factor"` // 변동성 기반 스프레드 조정
 	InventoryLimit   int64   `json:"inventory_limit"`   // 포지션 한도
+	InventorySkew    float64 `json:"inventory_skew"`    // 포지션 한도 도달 시 호가 중심 이동폭 (0.05 = 5¢)
 	RiskTolerance    float64 `json:"risk_tolerance"`    // 리스크 허용도
+	MaxLoss          int64   `json:"max_loss"`          // 실행 이후 최대 손실 (센트, 도달 시 킬 스위치, 0이면 끔)
 	EnabledMarkets   []uint  `json:"enabled_markets"`   // 활성화된 마일스톤 ID들
 }
 
+// DefaultMarketMakerConfig 기본 마켓메이커 설정
+var DefaultMarketMakerConfig = MarketMakerConfig{
+	UserID:           1,    // 시스템 봇 계정
+	MinSpread:        0.02, // 2%
+	MaxSpread:        0.08, // 8%
+	BaseOrderSize:    10,
+	MaxOrderSize:     100,
+	MinPrice:         0.05,
+	MaxPrice:         0.95,
+	RefreshInterval:  5, // 5초마다 갱신
+	VolatilityFactor: 2.0,
+	InventoryLimit:   1000,
+	InventorySkew:    0.05,
+	RiskTolerance:    0.1,
+	MaxLoss:          100000, // $1,000
+}
+
+// Validate 설정 값 검증
+func (c MarketMakerConfig) Validate() error {
+	switch {
+	case c.UserID == 0:
+		return fmt.Errorf("%w: user_id is required", ErrInvalidMarketMakerConfig)
+	case c.MinSpread <= 0 || c.MaxSpread < c.MinSpread:
+		return fmt.Errorf("%w: spreads must satisfy 0 < min_spread <= max_spread", ErrInvalidMarketMakerConfig)
+	case c.BaseOrderSize <= 0 || c.MaxOrderSize < c.BaseOrderSize:
+		return fmt.Errorf("%w: order sizes must satisfy 0 < base_order_size <= max_order_size", ErrInvalidMarketMakerConfig)
+	case c.MinPrice <= 0 || c.MaxPrice >= 1 || c.MinPrice >= c.MaxPrice:
+		return fmt.Errorf("%w: prices must satisfy 0 < min_price < max_price < 1", ErrInvalidMarketMakerConfig)
+	case c.RefreshInterval <= 0:
+		return fmt.Errorf("%w: refresh_interval must be positive", ErrInvalidMarketMakerConfig)
+	case c.InventoryLimit <= 0:
+		return fmt.Errorf("%w: inventory_limit must be positive", ErrInvalidMarketMakerConfig)
+	case c.InventorySkew < 0 || c.MaxLoss < 0:
+		return fmt.Errorf("%w: inventory_skew and max_loss must not be negative", ErrInvalidMarketMakerConfig)
+	}
+	return nil
+}
+
 // MarketInfo 개별 마켓 정보
 type MarketInfo struct {
 	MilestoneID   uint                   `json:"milestone_id"`
@@ -56,6 +115,7 @@ type MarketInfo struct {
 	Volatility    float64                `json:"volatility"`
 	Volume24h     int64                  `json:"volume_24h"`
 	Spread        float64                `json:"spread"`
+	TickSize      int64                  `json:"tick_size"` // 마켓 호가 단위 (틱)
 	BidPrice      float64                `json:"bid_price"`
 	AskPrice      float64                `json:"ask_price"`
 	Position      int64                  `json:"position"`      // 현재 포지션 (+매수, -매도)
@@ -68,62 +128,86 @@ type MarketInfo struct {
 // MarketMakerStats 마켓메이커 성과 통계
 type MarketMakerStats struct {
 	StartTime             time.Time `json:"start_time"`
-	TotalProfit           int64     `json:"total_profit"`
+	TotalProfit           int64     `json:"total_profit"`   // 실현 + 미실현 (센트)
+	RealizedPnL           int64     `json:"realized_pnl"`   // 수수료 차감 후
+	UnrealizedPnL         int64     `json:"unrealized_pnl"` // 현재 시세 평가
+	SessionPnL            int64     `json:"session_pnl"`    // 마지막 시작 이후 총 손익 변화
+	TotalFees             int64     `json:"total_fees"`
 	TotalVolume           int64     `json:"total_volume"`
-	TotalTrades           int64     `json:"total_trades"`
-	SuccessfulTrades      int64     `json:"successful_trades"`
-	FailedTrades          int64     `json:"failed_trades"`
+	TotalTrades           int64     `json:"total_trades"`      // 봇 체결 수
+	SuccessfulTrades      int64     `json:"successful_trades"` // 이익 실현 체결
+	FailedTrades          int64     `json:"failed_trades"`     // 손실 실현 체결
 	AverageProfitPerTrade int64     `json:"avg_profit_per_trade"`
 	MaxDrawdown           int64     `json:"max_drawdown"`
-	SharpeRatio           float64   `json:"sharpe_ratio"`
+	SharpeRatio           float64   `json:"sharpe_ratio"` // 사이클별 손익 변화 기준 (연율화하지 않음)
 	ActiveMarkets         int       `json:"active_markets"`
 	TotalOrdersPlaced     int64     `json:"total_orders_placed"`
 	OrderCancelRate       float64   `json:"order_cancel_rate"`
+
+	LiquidityPaused     bool       `json:"liquidity_paused"` // amm_liquidity 플래그로 호가 공급 중단 중
+	KillSwitchTriggered bool       `json:"kill_switch_triggered"`
+	HaltReason          string     `json:"halt_reason,omitempty"`
+	HaltedAt            *time.Time `json:"halted_at,omitempty"`
 }
 
 // NewMarketMakerBot 마켓메이커 봇 생성자
-func NewMarketMakerBot(db *gorm.DB, tradingService *TradingService) *MarketMakerBot {
+func NewMarketMakerBot(db *gorm.DB, tradingService *TradingService, config MarketMakerConfig) *MarketMakerBot {
 	return &MarketMakerBot{
 		db:             db,
 		tradingService: tradingService,
 		queuePublisher: queue.NewPublisher(),
 		stopChan:       make(chan struct{}),
 		activeMarkets:  make(map[string]*MarketInfo),
-		config: MarketMakerConfig{
-			UserID:           1,    // 시스템 봇 계정
-			MinSpread:        0.02, // 2%
-			MaxSpread:        0.08, // 8%
-			BaseOrderSize:    10,
-			MaxOrderSize:     100,
-			MinPrice:         0.05,
-			MaxPrice:         0.95,
-			RefreshInterval:  5, // 30초마다 갱신
-			VolatilityFactor: 2.0,
-			InventoryLimit:   1000,
-			RiskTolerance:    0.1,
-		},
+		ledgers:        make(map[string]*MarketMakerPnL),
+		config:         config,
 		stats: MarketMakerStats{
 			StartTime: time.Now(),
 		},
 	}
 }
 
+// SetFeatureFlags 기능 플래그 연결 (amm_liquidity가 꺼지면 실행 중이어도 호가 공급 중단)
+func (mm *MarketMakerBot) SetFeatureFlags(flags FeatureChecker) {
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+	mm.flags = flags
+}
+
+// liquidityEnabled amm_liquidity 플래그 확인
+func (mm *MarketMakerBot) liquidityEnabled() bool {
+	return mm.flags == nil || mm.flags.IsEnabled(models.FlagAMMLiquidity)
+}
+
 // Start 마켓메이커 봇 시작
 func (mm *MarketMakerBot) Start() error {
 	mm.mutex.Lock()
 	defer mm.mutex.Unlock()
 
 	if mm.isRunning {
-		return fmt.Errorf("market maker bot is already running")
+		return ErrMarketMakerRunning
 	}
 
+	// 중지 후 재시작할 수 있도록 실행마다 새 채널 사용
 	mm.isRunning = true
+	mm.stopChan = make(chan struct{})
+	stopChan := mm.stopChan
+
+	// 킬 스위치는 이번 실행 동안의 손실만 본다
+	mm.stats.StartTime = time.Now()
+	mm.stats.KillSwitchTriggered = false
+	mm.stats.HaltReason = ""
+	mm.stats.HaltedAt = nil
+	mm.sessionBasePnL = mm.stats.TotalProfit
 	log.Println("🤖 Market Maker Bot started!")
 
 	// 초기 마켓 스캔 (지연 후 실행)
 	go func() {
 		log.Printf("🤖 Market maker will start scanning in 15 seconds...")
-		time.Sleep(15 * time.Second) // 15초 대기하여 모든 서비스가 완전히 준비될 시간 제공
+		select {
+		case <-stopChan:
+			return
+		case <-time.After(15 * time.Second): // 15초 대기하여 모든 서비스가 완전히 준비될 시간 제공
+		}
 		log.Printf("🤖 Starting market scan...")
 		if err := mm.scanActiveMarkets(); err != nil {
 			log.Printf("❌ Error scanning markets: %v", err)
@@ -131,10 +215,10 @@ func (mm *MarketMakerBot) Start() error {
 	}()
 
 	// 메인 루프 시작
-	go mm.mainLoop()
+	go mm.mainLoop(stopChan)
 
 	// 통계 출력 루프
-	go mm.statsLoop()
+	go mm.statsLoop(stopChan)
 
 	return nil
 }
@@ -145,62 +229,113 @@ func (mm *MarketMakerBot) Stop() error {
 	defer mm.mutex.Unlock()
 
 	if !mm.isRunning {
-		return fmt.Errorf("market maker bot is not running")
+		return ErrMarketMakerStopped
 	}
 
+	mm.halt("stopped by operator")
+	log.Println("🛑 Market Maker Bot stopped!")
+	return nil
+}
+
+// halt 루프 중지 후 봇의 모든 미체결 주문 취소 (mutex 보유 상태에서 호출)
+func (mm *MarketMakerBot) halt(reason string) {
+	now := time.Now()
 	mm.isRunning = false
 	close(mm.stopChan)
+	mm.stats.HaltReason = reason
+	mm.stats.HaltedAt = &now
 
-	// 모든 활성 주문 취소
 	mm.cancelAllOrders()
-
-	log.Println("🛑 Market Maker Bot stopped!")
-	return nil
 }
 
 // mainLoop 메인 실행 루프
-func (mm *MarketMakerBot) mainLoop() {
-	ticker := time.NewTicker(time.Duration(mm.config.RefreshInterval) * time.Second)
+func (mm *MarketMakerBot) mainLoop(stopChan chan struct{}) {
+	interval := mm.refreshInterval()
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-mm.stopChan:
+		case <-stopChan:
 			return
 		case <-ticker.C:
 			mm.runMarketMakingCycle()
+
+			// 실행 중 갱신 주기 변경 반영
+			if next := mm.refreshInterval(); next != interval {
+				interval = next
+				ticker.Reset(interval)
+			}
 		}
 	}
 }
 
+func (mm *MarketMakerBot) refreshInterval() time.Duration {
+	mm.mutex.RLock()
+	defer mm.mutex.RUnlock()
+	return time.Duration(mm.config.RefreshInterval) * time.Second
+}
+
 // runMarketMakingCycle 마켓메이킹 사이클 실행
 func (mm *MarketMakerBot) runMarketMakingCycle() {
 	mm.mutex.Lock()
 	defer mm.mutex.Unlock()
 
+	// 사이클 대기 중 중지되었으면 주문을 새로 내지 않음
+	if !mm.isRunning {
+		return
+	}
+
+	// 플래그가 꺼지면 남은 호가를 한 번 거두고 다시 켜질 때까지 대기
+	if !mm.liquidityEnabled() {
+		if !mm.stats.LiquidityPaused {
+			log.Printf("🚩 AMM liquidity disabled by feature flag, cancelling market maker orders")
+			mm.cancelAllOrders()
+			mm.stats.LiquidityPaused = true
+		}
+		return
+	}
+	if mm.stats.LiquidityPaused {
+		log.Printf("🚩 AMM liquidity re-enabled by feature flag")
+		mm.stats.LiquidityPaused = false
+	}
+
 	// 1. 마켓 상태 업데이트
 	mm.updateMarketStates()
 
-	// 2. 기존 주문 관리
+	// 2. 새 체결 반영 및 손익 통계 업데이트
+	mm.syncFills()
+	mm.updateStats()
+
+	// 3. 킬 스위치 (최대 손실 도달 시 전체 주문 취소 후 중지)
+	if mm.config.MaxLoss > 0 && mm.stats.SessionPnL <= -mm.config.MaxLoss {
+		log.Printf("🚨 Market maker kill switch: session PnL $%.2f reached max loss $%.2f",
+			float64(mm.stats.SessionPnL)/100, float64(mm.config.MaxLoss)/100)
+		mm.stats.KillSwitchTriggered = true
+		mm.halt(fmt.Sprintf("max loss reached (session PnL %d cents)", mm.stats.SessionPnL))
+		return
+	}
+
+	// 4. 기존 주문 관리
 	mm.manageExistingOrders()
 
-	// 3. 새로운 주문 생성
+	// 5. 새로운 주문 생성
 	mm.placeNewOrders()
 
-	// 4. 리스크 관리
+	// 6. 리스크 관리
 	mm.performRiskManagement()
-
-	// 5. 통계 업데이트
-	mm.updateStats()
 }
 
 // scanActiveMarkets 활성 마켓 스캔
 func (mm *MarketMakerBot) scanActiveMarkets() error {
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
 	var milestones []models.Milestone
 
 	// 활성화된 마일스톤들 조회
-	err := mm.db.Where("status = ? AND target_date > ?",
-		models.MilestoneStatusPending, time.Now()).Find(&milestones).Error
+	err := mm.db.Where("status IN ? AND target_date > ?",
+		models.TradableMilestoneStatuses, time.Now()).Find(&milestones).Error
 	if err != nil {
 		return err
 	}
@@ -220,8 +355,8 @@ func (mm *MarketMakerBot) scanActiveMarkets() error {
 			}
 		}
 
-		// 성공/실패 두 옵션에 대해 마켓 정보 생성
-		for _, option := range []string{"success", "fail"} {
+		// 결과 옵션마다 마켓 정보 생성 (이진 마켓은 success/fail)
+		for _, option := range milestone.OptionIDs() {
 			key := fmt.Sprintf("%d:%s", milestone.ID, option)
 
 			if _, exists := mm.activeMarkets[key]; !exists {
@@ -235,13 +370,16 @@ func (mm *MarketMakerBot) scanActiveMarkets() error {
 					LastUpdate:   time.Now(),
 					Volatility:   0.05, // 기본 변동성 5%
 					Spread:       mm.config.MinSpread,
+					TickSize:     milestone.PriceTickSize,
 					ActiveOrders: make([]uint, 0),
 					PriceHistory: make([]float64, 0),
 					Metadata:     make(map[string]interface{}),
 				}
 
-				// 🎯 새 마켓에 초기 유동성 제공
-				go mm.provideInitialLiquidity(milestone.ID, option, currentPrice)
+				// 🎯 새 마켓에 초기 유동성 제공 (플래그가 꺼져 있으면 마켓만 등록)
+				if mm.liquidityEnabled() {
+					go mm.provideInitialLiquidity(mm.config, milestone, option, currentPrice)
+				}
 
 				log.Printf("🎯 Added market: %s (price: %.4f)", key, currentPrice)
 			}
@@ -287,12 +425,13 @@ func (mm *MarketMakerBot) updateMarketStates() {
 // manageExistingOrders 기존 주문 관리
 func (mm *MarketMakerBot) manageExistingOrders() {
 	for _, market := range mm.activeMarkets {
-		var ordersToCancel []uint
+		var ordersToCancel, closedOrders []uint
 
 		for _, orderID := range market.ActiveOrders {
 			order := mm.getOrder(orderID)
-			if order == nil {
-				// 주문이 체결되었거나 취소됨
+			if order == nil || (order.Status != models.OrderStatusPending && order.Status != models.OrderStatusPartial) {
+				// 주문이 체결되었거나 취소됨 → 호가 슬롯 반환
+				closedOrders = append(closedOrders, orderID)
 				continue
 			}
 
@@ -330,7 +469,12 @@ func (mm *MarketMakerBot) manageExistingOrders() {
 
 		// 주문 취소 실행
 		for _, orderID := range ordersToCancel {
-			mm.cancelOrder(orderID)
+			if err := mm.cancelOrder(orderID); err != nil {
+				log.Printf("⚠️ Failed to cancel market maker order %d: %v", orderID, err)
+			}
+			mm.removeOrderFromMarket(market, orderID)
+		}
+		for _, orderID := range closedOrders {
 			mm.removeOrderFromMarket(market, orderID)
 		}
 	}
@@ -348,13 +492,8 @@ func (mm *MarketMakerBot) placeNewOrders() {
 		shouldPlaceBuyOrder := len(market.ActiveOrders) < 2  // 최대 2개 주문만
 		shouldPlaceSellOrder := len(market.ActiveOrders) < 2 // 최대 2개 주문만
 
-		// 현재 가격 기준으로 Bid/Ask 가격 계산
-		bidPrice := market.CurrentPrice * (1 - market.Spread)
-		askPrice := market.CurrentPrice * (1 + market.Spread)
-
-		// 가격 범위 제한
-		bidPrice = math.Max(bidPrice, mm.config.MinPrice)
-		askPrice = math.Min(askPrice, mm.config.MaxPrice)
+		// 재고를 반영한 Bid/Ask 가격 계산
+		bidPrice, askPrice := SkewedQuotes(mm.config, market)
 
 		// 주문 수량 계산 (변동성과 포지션에 따라 조정)
 		orderSize := mm.calculateOrderSize(market)
@@ -390,6 +529,37 @@ func (mm *MarketMakerBot) placeNewOrders() {
 	}
 }
 
+// SkewedQuotes 재고 기반 호가 계산
+//
+// 보유 포지션이 한도에 가까울수록 호가 중심을 InventorySkew만큼 반대로 옮겨(매수 보유면 내림)
+// 재고를 줄이는 쪽 체결을 유도한다. 가격은 마켓 호가 단위로 매수는 내림, 매도는 올림한다.
+func SkewedQuotes(config MarketMakerConfig, market *MarketInfo) (bid, ask float64) {
+	mid := market.CurrentPrice
+	if config.InventoryLimit > 0 {
+		ratio := math.Max(-1, math.Min(1, float64(market.Position)/float64(config.InventoryLimit)))
+		mid -= ratio * config.InventorySkew
+	}
+
+	tick := market.TickSize
+	if tick <= 0 {
+		tick = models.DefaultPriceTickSize
+	}
+	minTicks := (models.PriceToTicks(config.MinPrice) + tick - 1) / tick * tick
+	maxTicks := models.PriceToTicks(config.MaxPrice) / tick * tick
+
+	// 부동소수 오차로 정확히 호가 단위인 가격이 한 틱 밀리지 않도록 여유를 둠
+	const epsilon = 1e-9
+	bidTicks := int64(math.Floor(mid*(1-market.Spread)*float64(models.PriceScale)/float64(tick)+epsilon)) * tick
+	askTicks := int64(math.Ceil(mid*(1+market.Spread)*float64(models.PriceScale)/float64(tick)-epsilon)) * tick
+	bidTicks = min(max(bidTicks, minTicks), maxTicks)
+	askTicks = min(max(askTicks, minTicks), maxTicks)
+	if askTicks <= bidTicks {
+		askTicks = bidTicks + tick
+	}
+
+	return models.TicksToPrice(bidTicks), models.TicksToPrice(askTicks)
+}
+
 // calculateOptimalSpread 최적 스프레드 계산
 func (mm *MarketMakerBot) calculateOptimalSpread(market *MarketInfo) float64 {
 	// 기본 스프레드
@@ -552,13 +722,12 @@ func (mm *MarketMakerBot) placeOrder(milestoneID uint, optionID string, side mod
 }
 
 func (mm *MarketMakerBot) cancelOrder(orderID uint) error {
-	// 주문 취소 로직 구현
-	err := mm.db.Model(&models.Order{}).Where("id = ?", orderID).
-		Update("status", models.OrderStatusCancelled).Error
-	if err != nil {
+	// 매칭 엔진 제거와 매수 잠금 해제까지 일반 취소 경로 사용
+	if _, err := mm.tradingService.CancelOrder(mm.config.UserID, orderID); err != nil {
 		return err
 	}
 
+	mm.ordersCancelled++
 	log.Printf("❌ Order cancelled: %d", orderID)
 	return nil
 }
@@ -581,11 +750,21 @@ func (mm *MarketMakerBot) removeOrderFromMarket(market *MarketInfo, orderID uint
 	}
 }
 
+// cancelAllOrders 봇 계정의 모든 미체결 주문 취소 (초기 유동성 주문 포함)
 func (mm *MarketMakerBot) cancelAllOrders() {
-	for _, market := range mm.activeMarkets {
-		for _, orderID := range market.ActiveOrders {
-			mm.cancelOrder(orderID)
+	var orderIDs []uint
+	if err := mm.db.Model(&models.Order{}).
+		Where("user_id = ? AND status IN ?", mm.config.UserID, openOrderStatuses).
+		Pluck("id", &orderIDs).Error; err != nil {
+		log.Printf("❌ Failed to load market maker orders: %v", err)
+	}
+	for _, orderID := range orderIDs {
+		if err := mm.cancelOrder(orderID); err != nil {
+			log.Printf("⚠️ Failed to cancel market maker order %d: %v", orderID, err)
 		}
+	}
+
+	for _, market := range mm.activeMarkets {
 		market.ActiveOrders = make([]uint, 0)
 	}
 }
@@ -600,20 +779,93 @@ func (mm *MarketMakerBot) performRiskManagement() {
 	}
 }
 
+// syncFills 봇의 새 체결을 시장별 장부에 반영 (사이클당 최대 1000건)
+func (mm *MarketMakerBot) syncFills() {
+	var trades []models.Trade
+	if err := mm.db.Where("id > ? AND (buyer_id = ? OR seller_id = ?)", mm.lastTradeID, mm.config.UserID, mm.config.UserID).
+		Order("id ASC").
+		Limit(1000).
+		Find(&trades).Error; err != nil {
+		log.Printf("❌ Failed to load market maker fills: %v", err)
+		return
+	}
+
+	for _, trade := range trades {
+		key := fmt.Sprintf("%d:%s", trade.MilestoneID, trade.OptionID)
+		ledger, ok := mm.ledgers[key]
+		if !ok {
+			ledger = &MarketMakerPnL{}
+			mm.ledgers[key] = ledger
+		}
+
+		// 자기 주문끼리 체결되면 양쪽 모두 반영
+		var realized int64
+		if trade.BuyerID == mm.config.UserID {
+			realized += ledger.ApplyFill(models.OrderSideBuy, trade.Quantity, trade.PriceTicks, trade.BuyerFee)
+		}
+		if trade.SellerID == mm.config.UserID {
+			realized += ledger.ApplyFill(models.OrderSideSell, trade.Quantity, trade.PriceTicks, trade.SellerFee)
+		}
+
+		mm.stats.TotalTrades++
+		mm.stats.TotalVolume += trade.Quantity
+		if realized > 0 {
+			mm.stats.SuccessfulTrades++
+		} else if realized < -(trade.BuyerFee + trade.SellerFee) {
+			mm.stats.FailedTrades++
+		}
+		mm.lastTradeID = trade.ID
+	}
+}
+
+// updateStats 장부와 현재 시세로 손익/낙폭/샤프 비율 계산
 func (mm *MarketMakerBot) updateStats() {
 	mm.stats.ActiveMarkets = len(mm.activeMarkets)
 
-	// 수익률 계산 등 추가 통계 업데이트
-	// (실제 구현에서는 더 정교한 수익률 계산 필요)
+	var realized, unrealized, fees int64
+	for key, ledger := range mm.ledgers {
+		mark := ledger.LastTicks
+		if market, ok := mm.activeMarkets[key]; ok && market.CurrentPrice > 0 {
+			mark = models.PriceToTicks(market.CurrentPrice)
+		}
+		realized += ledger.Realized
+		unrealized += ledger.Unrealized(mark)
+		fees += ledger.Fees
+	}
+
+	total := realized + unrealized
+	mm.stats.RealizedPnL = realized
+	mm.stats.UnrealizedPnL = unrealized
+	mm.stats.TotalFees = fees
+	mm.stats.TotalProfit = total
+	mm.stats.SessionPnL = total - mm.sessionBasePnL
+	if mm.stats.TotalTrades > 0 {
+		mm.stats.AverageProfitPerTrade = realized / mm.stats.TotalTrades
+	}
+
+	if len(mm.equityCurve) == 0 || total > mm.peakPnL {
+		mm.peakPnL = total
+	}
+	mm.stats.MaxDrawdown = max(mm.stats.MaxDrawdown, mm.peakPnL-total)
+
+	mm.equityCurve = append(mm.equityCurve, total)
+	if len(mm.equityCurve) > 1000 {
+		mm.equityCurve = mm.equityCurve[1:]
+	}
+	mm.stats.SharpeRatio = sharpeRatio(mm.equityCurve)
+
+	if mm.stats.TotalOrdersPlaced > 0 {
+		mm.stats.OrderCancelRate = float64(mm.ordersCancelled) / float64(mm.stats.TotalOrdersPlaced)
+	}
 }
 
-func (mm *MarketMakerBot) statsLoop() {
+func (mm *MarketMakerBot) statsLoop(stopChan chan struct{}) {
 	ticker := time.NewTicker(5 * time.Minute)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-mm.stopChan:
+		case <-stopChan:
 			return
 		case <-ticker.C:
 			mm.printStats()
@@ -629,6 +881,8 @@ func (mm *MarketMakerBot) printStats() {
 	log.Printf("   Active Markets: %d", mm.stats.ActiveMarkets)
 	log.Printf("   Total Orders: %d", mm.stats.TotalOrdersPlaced)
 	log.Printf("   Total Trades: %d", mm.stats.TotalTrades)
+	log.Printf("   PnL: $%.2f (realized $%.2f, unrealized $%.2f)",
+		float64(mm.stats.TotalProfit)/100, float64(mm.stats.RealizedPnL)/100, float64(mm.stats.UnrealizedPnL)/100)
 	log.Printf("   Runtime: %v", time.Since(mm.stats.StartTime))
 }
 
@@ -639,12 +893,26 @@ func (mm *MarketMakerBot) GetConfig() MarketMakerConfig {
 	return mm.config
 }
 
-// UpdateConfig 설정 업데이트
-func (mm *MarketMakerBot) UpdateConfig(config MarketMakerConfig) {
+// UpdateConfig 설정 업데이트 (실행 중에는 다음 사이클부터 적용, 봇 계정은 중지 상태에서만 변경 가능)
+func (mm *MarketMakerBot) UpdateConfig(config MarketMakerConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
 	mm.mutex.Lock()
 	defer mm.mutex.Unlock()
+	if mm.isRunning && config.UserID != mm.config.UserID {
+		return fmt.Errorf("%w: user_id cannot change while the bot is running", ErrInvalidMarketMakerConfig)
+	}
+	if config.UserID != mm.config.UserID {
+		// 다른 계정의 체결로 장부를 다시 쌓음
+		mm.ledgers = make(map[string]*MarketMakerPnL)
+		mm.lastTradeID = 0
+		mm.equityCurve = nil
+	}
 	mm.config = config
 	log.Println("🔧 Market Maker config updated")
+	return nil
 }
 
 // GetStats 통계 조회
@@ -659,9 +927,13 @@ func (mm *MarketMakerBot) GetActiveMarkets() map[string]*MarketInfo {
 	mm.mutex.RLock()
 	defer mm.mutex.RUnlock()
 
+	// 사이클이 갱신하는 값과 겹치지 않도록 복사본 반환
 	result := make(map[string]*MarketInfo)
 	for k, v := range mm.activeMarkets {
-		result[k] = v
+		market := *v
+		market.ActiveOrders = append([]uint(nil), v.ActiveOrders...)
+		market.PriceHistory = append([]float64(nil), v.PriceHistory...)
+		result[k] = &market
 	}
 	return result
 }
@@ -673,14 +945,9 @@ func (mm *MarketMakerBot) IsRunning() bool {
 	return mm.isRunning
 }
 
-// provideInitialLiquidity 새 마켓에 초기 유동성 제공
-func (mm *MarketMakerBot) provideInitialLiquidity(milestoneID uint, optionID string, currentPrice float64) {
-	// 🔍 마일스톤에서 프로젝트 ID 조회
-	var milestone models.Milestone
-	if err := mm.db.Where("id = ?", milestoneID).First(&milestone).Error; err != nil {
-		log.Printf("❌ Failed to get milestone %d: %v", milestoneID, err)
-		return
-	}
+// provideInitialLiquidity 새 마켓에 초기 유동성 제공 (스캔 시점 설정 사용)
+func (mm *MarketMakerBot) provideInitialLiquidity(config MarketMakerConfig, milestone models.Milestone, optionID string, currentPrice float64) {
+	milestoneID := milestone.ID
 
 	// 🔍 MarketData가 존재하는지 확인
 	var marketData models.MarketData
@@ -689,18 +956,12 @@ func (mm *MarketMakerBot) provideInitialLiquidity(milestoneID uint, optionID str
 		return
 	}
 
-	// 현재 가격 주변에 매수/매도 주문 생성
-	spread := mm.config.MinSpread
-	bidPrice := currentPrice - spread/2
-	askPrice := currentPrice + spread/2
-
-	// 가격 범위 검증
-	if bidPrice < mm.config.MinPrice {
-		bidPrice = mm.config.MinPrice
-	}
-	if askPrice > mm.config.MaxPrice {
-		askPrice = mm.config.MaxPrice
-	}
+	// 현재 가격 주변에 매수/매도 주문 생성 (신규 마켓이라 재고 없음)
+	bidPrice, askPrice := SkewedQuotes(config, &MarketInfo{
+		CurrentPrice: currentPrice,
+		Spread:       config.MinSpread / 2,
+		TickSize:     milestone.PriceTickSize,
+	})
 
 	// 매수 주문 생성
 	buyOrder := models.CreateOrderRequest{
@@ -709,7 +970,7 @@ func (mm *MarketMakerBot) provideInitialLiquidity(milestoneID uint, optionID str
 		OptionID:    optionID,
 		Type:        models.OrderTypeLimit,
 		Side:        models.OrderSideBuy,
-		Quantity:    mm.config.BaseOrderSize,
+		Quantity:    config.BaseOrderSize,
 		Price:       bidPrice,
 		Currency:    models.CurrencyUSDC,
 	}
@@ -721,7 +982,7 @@ func (mm *MarketMakerBot) provideInitialLiquidity(milestoneID uint, optionID str
 		OptionID:    optionID,
 		Type:        models.OrderTypeLimit,
 		Side:        models.OrderSideSell,
-		Quantity:    mm.config.BaseOrderSize,
+		Quantity:    config.BaseOrderSize,
 		Price:       askPrice,
 		Currency:    models.CurrencyUSDC,
 	}
@@ -730,27 +991,27 @@ func (mm *MarketMakerBot) provideInitialLiquidity(milestoneID uint, optionID str
 		optionID, bidPrice*100, askPrice*100)
 
 	// 🔍 마켓메이커 봇 지갑 확인/생성
-	mm.ensureMarketMakerWallet()
+	mm.ensureMarketMakerWallet(config.UserID)
 
 	// 주문 생성 (에러 발생 시 로그만 출력)
-	if _, err := mm.tradingService.CreateOrder(mm.config.UserID, buyOrder, "market-maker", "market-maker-bot"); err != nil {
+	if _, err := mm.tradingService.CreateOrder(config.UserID, buyOrder, "market-maker", "market-maker-bot"); err != nil {
 		log.Printf("❌ Failed to create initial buy order: %v", err)
 	}
 
-	if _, err := mm.tradingService.CreateOrder(mm.config.UserID, sellOrder, "market-maker", "market-maker-bot"); err != nil {
+	if _, err := mm.tradingService.CreateOrder(config.UserID, sellOrder, "market-maker", "market-maker-bot"); err != nil {
 		log.Printf("❌ Failed to create initial sell order: %v", err)
 	}
 }
 
 // ensureMarketMakerWallet 마켓메이커 봇 지갑 확인/생성
-func (mm *MarketMakerBot) ensureMarketMakerWallet() {
+func (mm *MarketMakerBot) ensureMarketMakerWallet(userID uint) {
 	var wallet models.UserWallet
-	err := mm.db.Where("user_id = ?", mm.config.UserID).First(&wallet).Error
+	err := mm.db.Where("user_id = ?", userID).First(&wallet).Error
 
 	if err == gorm.ErrRecordNotFound {
 		// 마켓메이커 봇 지갑 생성
 		wallet = models.UserWallet{
-			UserID:                 mm.config.UserID,
+			UserID:                 userID,
 			USDCBalance:            10000000, // 100,000 USDC (센트 단위)
 			USDCLockedBalance:      0,
 			BlueprintBalance:       0, // 봇은 BLUEPRINT 필요 없음
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"math"
+
+	"blueprint-module/pkg/models"
+)
+
+// MarketMakerPnL 마켓메이커 시장별 재고와 손익 장부 (센트, 체결 기준)
+//
+// Cost는 보유 포지션의 부호 있는 원가다. 매수 보유면 지불한 금액(+), 매도 보유면 받은 금액(-)이며
+// 포지션을 줄이는 체결마다 평균 원가 기준으로 실현 손익을 확정한다.
+type MarketMakerPnL struct {
+	Position  int64 `json:"position"`   // +매수 보유, -매도 보유
+	Cost      int64 `json:"cost"`       // 보유 포지션 원가 (센트, 부호 있음)
+	Realized  int64 `json:"realized"`   // 실현 손익 (수수료 차감 후)
+	Fees      int64 `json:"fees"`       // 누적 수수료
+	LastTicks int64 `json:"last_ticks"` // 마지막 체결가 (시세가 없을 때 평가 기준)
+}
+
+// ApplyFill 체결 반영, 이번 체결로 확정된 실현 손익(수수료 포함)을 반환
+func (p *MarketMakerPnL) ApplyFill(side models.OrderSide, quantity, priceTicks, fee int64) int64 {
+	if quantity <= 0 {
+		return 0
+	}
+
+	signed, cash := quantity, models.NotionalCents(quantity, priceTicks)
+	if side == models.OrderSideSell {
+		signed, cash = -quantity, -cash
+	}
+
+	realized := -fee
+	if p.Position != 0 && (p.Position > 0) != (signed > 0) {
+		// 반대 방향 체결: 보유분만큼 청산하고 남으면 새 포지션
+		closeQty := min(quantity, abs64(p.Position))
+		basis := p.Cost * closeQty / abs64(p.Position)
+		closeCash := cash * closeQty / quantity
+
+		realized += -basis - closeCash
+		p.Cost -= basis
+		p.Position += sign64(signed) * closeQty
+
+		signed -= sign64(signed) * closeQty
+		cash -= closeCash
+	}
+	p.Position += signed
+	p.Cost += cash
+	if p.Position == 0 {
+		p.Cost = 0
+	}
+
+	p.Realized += realized
+	p.Fees += fee
+	p.LastTicks = priceTicks
+	return realized
+}
+
+// Unrealized 평가 가격 기준 미실현 손익
+func (p *MarketMakerPnL) Unrealized(markTicks int64) int64 {
+	if p.Position == 0 || markTicks <= 0 {
+		return 0
+	}
+	value := models.NotionalCents(abs64(p.Position), markTicks)
+	if p.Position < 0 {
+		value = -value
+	}
+	return value - p.Cost
+}
+
+// sharpeRatio 사이클별 총 손익 곡선의 변화량 평균/표준편차 (연율화하지 않음)
+func sharpeRatio(equity []int64) float64 {
+	if len(equity) < 3 {
+		return 0
+	}
+
+	changes := make([]float64, len(equity)-1)
+	var mean float64
+	for i := 1; i < len(equity); i++ {
+		changes[i-1] = float64(equity[i] - equity[i-1])
+		mean += changes[i-1]
+	}
+	mean /= float64(len(changes))
+
+	var variance float64
+	for _, change := range changes {
+		variance += (change - mean) * (change - mean)
+	}
+	std := math.Sqrt(variance / float64(len(changes)))
+	if std == 0 {
+		return 0
+	}
+	return mean / std
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func sign64(v int64) int64 {
+	if v < 0 {
+		return -1
+	}
+	return 1
+}
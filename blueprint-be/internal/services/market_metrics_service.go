@@ -0,0 +1,184 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// marketMetricsColumns MarketMetricsService만 쓰는 MarketData 컬럼
+var marketMetricsColumns = []string{"twap_1h", "twap_24h", "vwap_1h", "vwap_24h", "metrics_updated_at"}
+
+// AveragePrices 기간별 평균 가격
+type AveragePrices struct {
+	TWAP1h  float64 `json:"twap_1h"`
+	TWAP24h float64 `json:"twap_24h"`
+	VWAP1h  float64 `json:"vwap_1h"`
+	VWAP24h float64 `json:"vwap_24h"`
+}
+
+// MarketMetricsService 마켓 옵션별 TWAP/VWAP 계산
+//
+// 얇은 호가에서는 소량 체결 한 번으로 직전 체결가를 움직일 수 있어, 최근 1시간/24시간 체결로 시간 가중
+// 평균가(TWAP)와 거래량 가중 평균가(VWAP)를 계산해 MarketData에 저장한다. 체결 이벤트 소비자
+// (queue:trades)가 체결마다 갱신하고, market_metrics 주기 작업이 체결이 없는 동안에도 창을 밀어 값을 맞춘다.
+type MarketMetricsService struct {
+	db *gorm.DB
+}
+
+// NewMarketMetricsService 생성자
+func NewMarketMetricsService(db *gorm.DB) *MarketMetricsService {
+	return &MarketMetricsService{db: db}
+}
+
+// RefreshActive 평균가가 바뀔 수 있는 마켓 전체 재계산
+func (s *MarketMetricsService) RefreshActive(now time.Time) (int, error) {
+	var markets []models.MarketData
+	if err := s.db.Select("milestone_id", "option_id").
+		Where("last_trade_time > ? OR twap_24h <> 0 OR vwap_24h <> 0", now.Add(-24*time.Hour)).
+		Find(&markets).Error; err != nil {
+		return 0, fmt.Errorf("평균가 대상 마켓 조회 실패: %w", err)
+	}
+
+	refreshed := 0
+	for _, market := range markets {
+		if _, err := s.Refresh(market.MilestoneID, market.OptionID, now); err != nil {
+			log.Printf("❌ Failed to refresh metrics for %d:%s: %v", market.MilestoneID, market.OptionID, err)
+			continue
+		}
+		refreshed++
+	}
+	return refreshed, nil
+}
+
+// RefreshAfterTrade 체결 이벤트 처리 (이미 체결 이후에 계산했으면 생략)
+func (s *MarketMetricsService) RefreshAfterTrade(milestoneID uint, optionID string, tradeID uint) error {
+	var trade models.Trade
+	if err := s.db.Select("id", "created_at").First(&trade, tradeID).Error; err != nil {
+		return fmt.Errorf("체결 조회 실패: %w", err)
+	}
+
+	var stale int64
+	if err := s.db.Model(&models.MarketData{}).
+		Where("milestone_id = ? AND option_id = ? AND (metrics_updated_at IS NULL OR metrics_updated_at < ?)", milestoneID, optionID, trade.CreatedAt).
+		Count(&stale).Error; err != nil {
+		return err
+	}
+	if stale == 0 {
+		return nil
+	}
+
+	_, err := s.Refresh(milestoneID, optionID, time.Now())
+	return err
+}
+
+// Refresh 마켓 옵션의 평균가를 계산해 MarketData에 저장 (MarketData가 없으면 계산만)
+func (s *MarketMetricsService) Refresh(milestoneID uint, optionID string, now time.Time) (*AveragePrices, error) {
+	prices, err := s.Compute(milestoneID, optionID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.db.Model(&models.MarketData{}).
+		Where("milestone_id = ? AND option_id = ?", milestoneID, optionID).
+		UpdateColumns(map[string]interface{}{
+			"twap_1h":            prices.TWAP1h,
+			"twap_24h":           prices.TWAP24h,
+			"vwap_1h":            prices.VWAP1h,
+			"vwap_24h":           prices.VWAP24h,
+			"metrics_updated_at": now,
+		})
+	if result.Error != nil {
+		return nil, fmt.Errorf("평균가 저장 실패: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		BumpMarketSequence(milestoneID)
+	}
+	return prices, nil
+}
+
+// Compute 최근 1시간/24시간 TWAP, VWAP 계산
+func (s *MarketMetricsService) Compute(milestoneID uint, optionID string, now time.Time) (*AveragePrices, error) {
+	dayStart := now.Add(-24 * time.Hour)
+
+	// 24시간 창 시작 시점의 가격 (창 이전 마지막 체결)
+	var opening []models.Trade
+	if err := s.db.Select("price", "quantity", "created_at").
+		Where("milestone_id = ? AND option_id = ? AND created_at <= ?", milestoneID, optionID, dayStart).
+		Order("created_at DESC, id DESC").
+		Limit(1).
+		Find(&opening).Error; err != nil {
+		return nil, fmt.Errorf("기준 체결 조회 실패: %w", err)
+	}
+
+	var trades []models.Trade
+	if err := s.db.Select("price", "quantity", "created_at").
+		Where("milestone_id = ? AND option_id = ? AND created_at > ? AND created_at <= ?", milestoneID, optionID, dayStart, now).
+		Order("created_at ASC, id ASC").
+		Find(&trades).Error; err != nil {
+		return nil, fmt.Errorf("체결 조회 실패: %w", err)
+	}
+
+	series := append(opening, trades...)
+	return &AveragePrices{
+		TWAP1h:  timeWeightedAverage(series, now.Add(-time.Hour), now),
+		TWAP24h: timeWeightedAverage(series, dayStart, now),
+		VWAP1h:  volumeWeightedAverage(trades, now.Add(-time.Hour)),
+		VWAP24h: volumeWeightedAverage(trades, dayStart),
+	}, nil
+}
+
+// timeWeightedAverage 체결가를 다음 체결까지 유지되는 계단 함수로 보고 [start, end] 구간 평균
+//
+// 창 시작 전 체결이 있으면 그 가격으로 창 시작부터, 없으면 창 안 첫 체결부터 평균한다. 체결이 하나도 없으면 0.
+func timeWeightedAverage(trades []models.Trade, start, end time.Time) float64 {
+	var (
+		price    float64
+		origin   time.Time // 평균 구간 시작
+		from     time.Time // 현재 가격이 유지되기 시작한 시각
+		started  bool
+		weighted float64
+	)
+	for _, trade := range trades {
+		switch {
+		case !trade.CreatedAt.After(start):
+			price, origin, from, started = trade.Price, start, start, true
+		case !started:
+			price, origin, from, started = trade.Price, trade.CreatedAt, trade.CreatedAt, true
+		default:
+			weighted += price * trade.CreatedAt.Sub(from).Seconds()
+			price, from = trade.Price, trade.CreatedAt
+		}
+	}
+	if !started {
+		return 0
+	}
+
+	duration := end.Sub(origin).Seconds()
+	if duration <= 0 {
+		return price
+	}
+	weighted += price * end.Sub(from).Seconds()
+	return weighted / duration
+}
+
+// volumeWeightedAverage since 이후 체결의 수량 가중 평균가 (체결 없으면 0)
+func volumeWeightedAverage(trades []models.Trade, since time.Time) float64 {
+	var notional float64
+	var quantity int64
+	for _, trade := range trades {
+		if !trade.CreatedAt.After(since) {
+			continue
+		}
+		notional += trade.Price * float64(trade.Quantity)
+		quantity += trade.Quantity
+	}
+	if quantity == 0 {
+		return 0
+	}
+	return notional / float64(quantity)
+}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"log"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	redisClient "github.com/redis/go-redis/v9"
+)
+
+// ErrMarketNotOwned 이 인스턴스가 담당하지 않는 마켓에 대한 주문 (담당 인스턴스로 보내야 함)
+var ErrMarketNotOwned = errors.New("다른 매칭 엔진 인스턴스가 담당하는 마켓입니다")
+
+const engineInstancesKey = "engine:instances" // 살아 있는 인스턴스 하트비트 (sorted set, score = 마지막 하트비트 ms)
+
+// MarketOwnershipConfig 마켓 담당 리스 설정
+type MarketOwnershipConfig struct {
+	LeaseTTL          time.Duration // 마켓 리스 만료 시간 (담당 인스턴스가 죽으면 이 시간 뒤 인수 가능)
+	HeartbeatInterval time.Duration // 하트비트/리밸런싱 주기 (LeaseTTL보다 충분히 짧아야 함)
+	InstanceTTL       time.Duration // 마지막 하트비트 후 인스턴스를 죽은 것으로 보는 시간
+	VirtualNodes      int           // 해시 링의 인스턴스당 가상 노드 수
+}
+
+// DefaultMarketOwnershipConfig 기본값: 5초마다 하트비트, 15초 무응답 시 장애 조치
+var DefaultMarketOwnershipConfig = MarketOwnershipConfig{
+	LeaseTTL:          15 * time.Second,
+	HeartbeatInterval: 5 * time.Second,
+	InstanceTTL:       15 * time.Second,
+	VirtualNodes:      64,
+}
+
+// HashRing 일관된 해싱 링 (인스턴스가 추가/제거되어도 일부 마켓만 이동)
+type HashRing struct {
+	hashes []uint32
+	owners map[uint32]string
+}
+
+// NewHashRing 생성자 (members는 인스턴스 ID)
+func NewHashRing(members []string, virtualNodes int) *HashRing {
+	if virtualNodes <= 0 {
+		virtualNodes = DefaultMarketOwnershipConfig.VirtualNodes
+	}
+
+	ring := &HashRing{owners: make(map[uint32]string, len(members)*virtualNodes)}
+	for _, member := range members {
+		for i := 0; i < virtualNodes; i++ {
+			hash := crc32.ChecksumIEEE([]byte(member + "#" + strconv.Itoa(i)))
+			if _, exists := ring.owners[hash]; exists {
+				continue // 충돌 시 먼저 배치된 노드 유지
+			}
+			ring.owners[hash] = member
+			ring.hashes = append(ring.hashes, hash)
+		}
+	}
+	sort.Slice(ring.hashes, func(i, j int) bool { return ring.hashes[i] < ring.hashes[j] })
+	return ring
+}
+
+// Owner 키를 담당하는 인스턴스 (링이 비어 있으면 빈 값)
+func (r *HashRing) Owner(key string) string {
+	if len(r.hashes) == 0 {
+		return ""
+	}
+	hash := crc32.ChecksumIEEE([]byte(key))
+	i := sort.Search(len(r.hashes), func(i int) bool { return r.hashes[i] >= hash })
+	if i == len(r.hashes) {
+		i = 0
+	}
+	return r.owners[r.hashes[i]]
+}
+
+// MarketOwnershipManager 마켓(마일스톤:옵션)별 담당 인스턴스 배정
+//
+// 살아 있는 인스턴스로 해시 링을 만들어 각 마켓의 담당자를 정하고, 담당자는 Redis 리스를 잡아
+// 단일 처리를 보장한다. 인스턴스가 죽으면 하트비트가 끊겨 링에서 빠지고, 리스가 만료되면 새 담당자가
+// 인수(onAcquire)해 이벤트 로그로 주문장을 재구성한다.
+type MarketOwnershipManager struct {
+	redisClient *redisClient.Client
+	lockManager *DistributedLockManager
+	instanceID  string
+	config      MarketOwnershipConfig
+
+	markets   func() ([]string, error)     // 배정 대상 마켓 목록
+	onAcquire func(marketKey string) error // 리스 획득 후 인수 처리 (실패하면 리스 반납)
+	onRelease func(marketKey string)       // 담당 해제 (재배정, 리스 상실, 종료)
+
+	mutex   sync.RWMutex
+	ring    *HashRing
+	owned   map[string]bool
+	running bool
+}
+
+// NewMarketOwnershipManager 생성자
+func NewMarketOwnershipManager(client *redisClient.Client, instanceID string, config MarketOwnershipConfig,
+	markets func() ([]string, error), onAcquire func(string) error, onRelease func(string)) *MarketOwnershipManager {
+	return &MarketOwnershipManager{
+		redisClient: client,
+		lockManager: NewDistributedLockManager(client),
+		instanceID:  instanceID,
+		config:      config,
+		markets:     markets,
+		onAcquire:   onAcquire,
+		onRelease:   onRelease,
+		ring:        NewHashRing(nil, config.VirtualNodes),
+		owned:       make(map[string]bool),
+	}
+}
+
+// Run 하트비트/리밸런싱 루프 (ctx 종료 시 모든 리스를 반납하고 링에서 빠짐)
+func (m *MarketOwnershipManager) Run(ctx context.Context) {
+	m.mutex.Lock()
+	m.running = true
+	m.mutex.Unlock()
+
+	ticker := time.NewTicker(m.config.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.Rebalance(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("❌ Market ownership rebalance failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			m.shutdown()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Rebalance 하트비트 갱신 후 해시 링에 따라 리스 획득/갱신/반납
+func (m *MarketOwnershipManager) Rebalance(ctx context.Context) error {
+	members, err := m.heartbeat(ctx)
+	if err != nil {
+		return err
+	}
+	ring := NewHashRing(members, m.config.VirtualNodes)
+
+	markets, err := m.markets()
+	if err != nil {
+		return err
+	}
+
+	m.mutex.Lock()
+	m.ring = ring
+	owned := make(map[string]bool, len(m.owned))
+	for market := range m.owned {
+		owned[market] = true
+	}
+	m.mutex.Unlock()
+
+	active := make(map[string]bool, len(markets))
+	for _, market := range markets {
+		active[market] = true
+		desired := ring.Owner(market) == m.instanceID
+
+		switch {
+		case owned[market] && !desired:
+			m.release(ctx, market, "재배정")
+		case owned[market]:
+			renewed, err := m.lockManager.RenewLock(ctx, leaseKey(market), m.config.LeaseTTL, m.instanceID)
+			if err != nil {
+				log.Printf("⚠️ Failed to renew lease for market %s: %v", market, err)
+			} else if !renewed {
+				// 갱신 전에 만료됨: 담당을 내려놓고, 아무도 가져가지 않았으면 재구성 후 다시 잡음
+				m.drop(market, "리스 상실")
+				m.acquire(ctx, market)
+			}
+		case desired:
+			m.acquire(ctx, market)
+		}
+	}
+
+	// 거래가 끝난 마켓은 반납
+	for market := range owned {
+		if !active[market] {
+			m.release(ctx, market, "비활성 마켓")
+		}
+	}
+	return nil
+}
+
+// Owns 이 인스턴스가 리스를 가진 마켓인지
+func (m *MarketOwnershipManager) Owns(marketKey string) bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.owned[marketKey]
+}
+
+// Owner 해시 링 기준 담당 인스턴스 (장애 조치 중에는 아직 리스를 잡지 못했을 수 있음)
+func (m *MarketOwnershipManager) Owner(marketKey string) string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.ring.Owner(marketKey)
+}
+
+// Running 리밸런싱 루프 실행 여부
+func (m *MarketOwnershipManager) Running() bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.running
+}
+
+// OwnedMarkets 이 인스턴스가 담당 중인 마켓 목록
+func (m *MarketOwnershipManager) OwnedMarkets() []string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	markets := make([]string, 0, len(m.owned))
+	for market := range m.owned {
+		markets = append(markets, market)
+	}
+	sort.Strings(markets)
+	return markets
+}
+
+// heartbeat 자기 하트비트를 기록하고 만료된 인스턴스를 지운 뒤 살아 있는 인스턴스 목록 반환
+func (m *MarketOwnershipManager) heartbeat(ctx context.Context) ([]string, error) {
+	now := time.Now()
+	pipe := m.redisClient.TxPipeline()
+	pipe.ZAdd(ctx, engineInstancesKey, redisClient.Z{Score: float64(now.UnixMilli()), Member: m.instanceID})
+	pipe.ZRemRangeByScore(ctx, engineInstancesKey, "-inf", fmt.Sprintf("(%d", now.Add(-m.config.InstanceTTL).UnixMilli()))
+	members := pipe.ZRange(ctx, engineInstancesKey, 0, -1)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("인스턴스 하트비트 실패: %w", err)
+	}
+	return members.Val(), nil
+}
+
+func (m *MarketOwnershipManager) acquire(ctx context.Context, market string) {
+	acquired, err := m.lockManager.AcquireLock(ctx, leaseKey(market), m.config.LeaseTTL, m.instanceID)
+	if err != nil {
+		log.Printf("⚠️ Failed to acquire lease for market %s: %v", market, err)
+		return
+	}
+	if !acquired {
+		return // 이전 담당자의 리스가 아직 유효 (만료 후 다음 주기에 인수)
+	}
+
+	if err := m.onAcquire(market); err != nil {
+		log.Printf("❌ Failed to take over market %s: %v", market, err)
+		m.lockManager.ReleaseLock(ctx, leaseKey(market), m.instanceID)
+		return
+	}
+
+	m.mutex.Lock()
+	m.owned[market] = true
+	m.mutex.Unlock()
+	log.Printf("🧭 Instance %s took ownership of market %s", m.instanceID, market)
+}
+
+func (m *MarketOwnershipManager) release(ctx context.Context, market, reason string) {
+	m.drop(market, reason)
+	if err := m.lockManager.ReleaseLock(ctx, leaseKey(market), m.instanceID); err != nil {
+		log.Printf("⚠️ Failed to release lease for market %s: %v", market, err)
+	}
+}
+
+// drop 담당 해제 (리스 반납은 호출자가 처리)
+func (m *MarketOwnershipManager) drop(market, reason string) {
+	m.mutex.Lock()
+	delete(m.owned, market)
+	m.mutex.Unlock()
+
+	m.onRelease(market)
+	log.Printf("🧭 Instance %s released market %s (%s)", m.instanceID, market, reason)
+}
+
+// shutdown 종료 시 리스 반납과 링 이탈 (다른 인스턴스가 만료를 기다리지 않고 인수)
+func (m *MarketOwnershipManager) shutdown() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, market := range m.OwnedMarkets() {
+		m.release(ctx, market, "종료")
+	}
+	m.redisClient.ZRem(ctx, engineInstancesKey, m.instanceID)
+
+	m.mutex.Lock()
+	m.running = false
+	m.mutex.Unlock()
+}
+
+func leaseKey(marketKey string) string {
+	return fmt.Sprintf("lease:market:%s", marketKey)
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// MarketSettlementService 결과가 확정된 마켓의 포지션 정산
+//
+// 옵션별 1주당 지급액은 models.Milestone.SettlementTicks가 정한다 (binary/categorical은 승리 옵션 1.0,
+// scalar는 판정 값의 범위 내 위치에 비례). 매수 포지션은 지급액을 받고, 매도(음수) 포지션은 같은 금액을 낸다.
+// 숏 포지션 담보는 정산 금액과 함께 사용 가능 잔액으로 돌아간다.
+// 정산 금액은 마켓 결제 통화 계정으로 오가고, 손익 통계는 USDC로 환산해 쌓는다.
+// 판정이 없는 무효 마켓은 정산하지 않는다.
+type MarketSettlementService struct {
+	db         *gorm.DB
+	currencies *CurrencyService
+}
+
+// NewMarketSettlementService 생성자
+func NewMarketSettlementService(db *gorm.DB) *MarketSettlementService {
+	return &MarketSettlementService{db: db, currencies: NewCurrencyService(db, nil, 0)}
+}
+
+// SettleMarket 마일스톤 포지션 일괄 정산 (미확정, 이미 정산, 무효면 0건)
+func (s *MarketSettlementService) SettleMarket(milestoneID uint) (int, error) {
+	settled := 0
+	var holders []uint
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var milestone models.Milestone
+		if err := tx.First(&milestone, milestoneID).Error; err != nil {
+			return err
+		}
+		if milestone.SettledAt != nil {
+			return nil
+		}
+		if milestone.Status != models.MilestoneStatusCompleted && milestone.Status != models.MilestoneStatusFailed {
+			return nil // 분쟁 기간이 끝나 결과가 확정된 뒤에만 정산
+		}
+		payouts, ok := milestone.SettlementTicks()
+		if !ok {
+			log.Printf("⚪ Milestone %d has no resolution, skipping settlement", milestoneID)
+			return nil
+		}
+
+		// 동시에 실행된 다른 정산과 겹치지 않도록 settled_at을 먼저 선점
+		result := tx.Model(&models.Milestone{}).
+			Where("id = ? AND settled_at IS NULL", milestoneID).
+			UpdateColumn("settled_at", time.Now())
+		if result.Error != nil {
+			return fmt.Errorf("정산 시각 기록 실패: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		var positions []models.Position
+		if err := tx.Where("milestone_id = ? AND quantity != 0", milestoneID).Find(&positions).Error; err != nil {
+			return fmt.Errorf("포지션 조회 실패: %w", err)
+		}
+
+		for _, position := range positions {
+			amount := settlementAmount(position.Quantity, payouts[position.OptionID])
+			pnl := amount - position.TotalCost
+
+			if err := tx.Model(&models.Position{}).Where("id = ?", position.ID).UpdateColumns(map[string]interface{}{
+				"quantity":   0,
+				"total_cost": 0,
+				"collateral": 0,
+				"unrealized": 0,
+				"realized":   gorm.Expr("realized + ?", pnl),
+				"updated_at": time.Now(),
+			}).Error; err != nil {
+				return fmt.Errorf("포지션(%d) 정산 실패: %w", position.ID, err)
+			}
+
+			// 정산 금액은 마켓 결제 통화로, 숏 담보와 손익 통계는 USDC로
+			currency := milestone.QuoteCurrency.OrUSDC()
+			walletUpdates := map[string]interface{}{}
+			if currency == models.LedgerCurrencyUSDC {
+				walletUpdates["usdc_balance"] = gorm.Expr("usdc_balance + ?", amount+position.Collateral)
+			} else {
+				if err := changeWalletBalance(tx, position.UserID, currency, amount, 0); err != nil {
+					return fmt.Errorf("포지션(%d) %s 정산 실패: %w", position.ID, currency, err)
+				}
+				if position.Collateral > 0 {
+					walletUpdates["usdc_balance"] = gorm.Expr("usdc_balance + ?", position.Collateral)
+				}
+				pnl = s.currencies.ToUSDC(currency, pnl)
+			}
+			if position.Collateral > 0 {
+				walletUpdates["usdc_locked_balance"] = gorm.Expr("usdc_locked_balance - ?", position.Collateral)
+			}
+			if pnl > 0 {
+				walletUpdates["total_usdc_profit"] = gorm.Expr("total_usdc_profit + ?", pnl)
+			} else if pnl < 0 {
+				walletUpdates["total_usdc_loss"] = gorm.Expr("total_usdc_loss + ?", -pnl)
+			}
+			if len(walletUpdates) > 0 {
+				result := tx.Model(&models.UserWallet{}).Where("user_id = ?", position.UserID).UpdateColumns(walletUpdates)
+				if result.Error != nil {
+					return fmt.Errorf("지갑 업데이트 실패: %w", result.Error)
+				}
+				if result.RowsAffected == 0 {
+					return fmt.Errorf("사용자(%d) 지갑이 없습니다", position.UserID)
+				}
+			}
+			holders = append(holders, position.UserID)
+			settled++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if settled > 0 {
+		log.Printf("💰 Settled %d positions for milestone %d", settled, milestoneID)
+	}
+	for _, userID := range holders {
+		publishWalletChanged(userID, "settlement")
+	}
+	MarkUserStatsDirty(holders...)
+	return settled, nil
+}
+
+// settlementAmount 포지션 정산 금액 (센트, 매수는 받을 금액 반올림, 매도는 낼 금액 올림)
+func settlementAmount(quantity, payoutTicks int64) int64 {
+	if quantity >= 0 {
+		return models.NotionalCents(quantity, payoutTicks)
+	}
+	return -models.ReserveCents(-quantity, payoutTicks)
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/redis"
+)
+
+const (
+	marketViewCacheTTL   = 3 * time.Second // 현재 뷰 캐시 (시퀀스가 바뀌면 즉시 무효)
+	marketVersionHistory = 2 * time.Minute // 델타 계산용 과거 스냅샷 보관 기간
+)
+
+// MarketView 마켓 정보 스냅샷 (ETag/델타 응답 기준)
+type MarketView struct {
+	Version     string              `json:"version"`
+	Sequence    int64               `json:"sequence"`
+	Milestone   models.Milestone    `json:"milestone"`
+	MarketData  []models.MarketData `json:"market_data"`
+	TotalVolume int64               `json:"total_volume"`
+	// 마켓 캘린더 기준 거래 상태와 마감 시각 (설정 마감, 없으면 목표일)
+	TradingState models.MarketTradingState `json:"trading_state"`
+	ClosesAt     *time.Time                `json:"closes_at,omitempty"`
+	// 수치 마켓의 long 가격이 나타내는 예상 값
+	ImpliedValue *float64 `json:"implied_value,omitempty"`
+}
+
+// MarketViewDelta 클라이언트 버전 이후 변경된 필드만 담은 응답
+type MarketViewDelta struct {
+	Version        string                            `json:"version"`
+	BaseVersion    string                            `json:"base_version"`
+	Delta          bool                              `json:"delta"`
+	Milestone      map[string]interface{}            `json:"milestone,omitempty"`
+	MarketData     map[string]map[string]interface{} `json:"market_data,omitempty"` // option_id -> 변경 필드
+	RemovedOptions []string                          `json:"removed_options,omitempty"`
+	TotalVolume    *int64                            `json:"total_volume,omitempty"`
+	TradingState   models.MarketTradingState         `json:"trading_state,omitempty"`
+	ImpliedValue   *float64                          `json:"implied_value,omitempty"`
+}
+
+// GetMarketView 마켓 스냅샷 조회 (시퀀스 기반 짧은 TTL 캐시)
+func (s *TradingService) GetMarketView(milestoneID uint) (*MarketView, error) {
+	cacheAvailable := redis.GetClient() != nil
+
+	var seq int64
+	if cacheAvailable {
+		current, err := redis.GetMarketSequence(milestoneID)
+		if err != nil {
+			log.Printf("⚠️ Failed to read market sequence for %d: %v", milestoneID, err)
+			cacheAvailable = false
+		} else {
+			seq = current
+		}
+	}
+
+	// 1. 같은 시퀀스의 캐시가 있으면 그대로 사용
+	if cacheAvailable {
+		var cached MarketView
+		if err := redis.GetMarketView(milestoneID, &cached); err == nil && cached.Sequence == seq {
+			return &cached, nil
+		}
+	}
+
+	// 2. DB에서 새로 구성
+	view := &MarketView{Sequence: seq}
+	if err := s.db.First(&view.Milestone, milestoneID).Error; err != nil {
+		return nil, err
+	}
+	view.TradingState = view.Milestone.TradingState(time.Now())
+	view.ClosesAt = view.Milestone.TradingCloseTime()
+	if err := s.db.Where("milestone_id = ?", milestoneID).Order("option_id").Find(&view.MarketData).Error; err != nil {
+		return nil, fmt.Errorf("마켓 데이터 조회 실패: %w", err)
+	}
+	for _, md := range view.MarketData {
+		view.TotalVolume += md.Volume24h
+		if md.OptionID == models.OptionLong {
+			if value, ok := view.Milestone.ImpliedScalarValue(md.CurrentPrice); ok {
+				view.ImpliedValue = &value
+			}
+		}
+	}
+
+	version, err := marketViewVersion(view)
+	if err != nil {
+		return nil, err
+	}
+	view.Version = version
+
+	// 3. 현재 뷰 + 버전 스냅샷 캐싱
+	if cacheAvailable {
+		redis.SetMarketView(milestoneID, view, marketViewCacheTTL)
+		redis.SetMarketVersion(milestoneID, view.Version, view, marketVersionHistory)
+	}
+
+	return view, nil
+}
+
+// GetMarketDelta baseVersion 이후 변경된 필드만 계산 (기준 스냅샷이 만료되었으면 false)
+func (s *TradingService) GetMarketDelta(milestoneID uint, baseVersion string, current *MarketView) (*MarketViewDelta, bool) {
+	delta := &MarketViewDelta{
+		Version:     current.Version,
+		BaseVersion: baseVersion,
+		Delta:       true,
+	}
+	if baseVersion == current.Version {
+		return delta, true
+	}
+	if redis.GetClient() == nil {
+		return nil, false
+	}
+
+	var base MarketView
+	if err := redis.GetMarketVersion(milestoneID, baseVersion, &base); err != nil {
+		return nil, false
+	}
+
+	delta.Milestone = diffFields(base.Milestone, current.Milestone)
+
+	baseByOption := make(map[string]models.MarketData, len(base.MarketData))
+	for _, md := range base.MarketData {
+		baseByOption[md.OptionID] = md
+	}
+	for _, md := range current.MarketData {
+		prev, exists := baseByOption[md.OptionID]
+		delete(baseByOption, md.OptionID)
+
+		var changed map[string]interface{}
+		if exists {
+			changed = diffFields(prev, md)
+		} else {
+			changed = diffFields(nil, md)
+		}
+		if len(changed) > 0 {
+			if delta.MarketData == nil {
+				delta.MarketData = make(map[string]map[string]interface{})
+			}
+			delta.MarketData[md.OptionID] = changed
+		}
+	}
+	for optionID := range baseByOption {
+		delta.RemovedOptions = append(delta.RemovedOptions, optionID)
+	}
+
+	if base.TotalVolume != current.TotalVolume {
+		totalVolume := current.TotalVolume
+		delta.TotalVolume = &totalVolume
+	}
+	if base.TradingState != current.TradingState {
+		delta.TradingState = current.TradingState
+	}
+	if current.ImpliedValue != nil && (base.ImpliedValue == nil || *base.ImpliedValue != *current.ImpliedValue) {
+		delta.ImpliedValue = current.ImpliedValue
+	}
+
+	return delta, true
+}
+
+// marketViewVersion 시퀀스 + 내용 해시로 버전 생성 (마일스톤 변경도 반영)
+func marketViewVersion(view *MarketView) (string, error) {
+	payload, err := json.Marshal(struct {
+		Milestone    models.Milestone          `json:"milestone"`
+		MarketData   []models.MarketData       `json:"market_data"`
+		TotalVolume  int64                     `json:"total_volume"`
+		TradingState models.MarketTradingState `json:"trading_state"`
+	}{view.Milestone, view.MarketData, view.TotalVolume, view.TradingState})
+	if err != nil {
+		return "", fmt.Errorf("마켓 스냅샷 직렬화 실패: %w", err)
+	}
+
+	hash := sha256.Sum256(payload)
+	return fmt.Sprintf("%d-%s", view.Sequence, hex.EncodeToString(hash[:6])), nil
+}
+
+// diffFields JSON 필드 단위로 변경된 값만 추출
+func diffFields(before, after interface{}) map[string]interface{} {
+	toMap := func(v interface{}) map[string]interface{} {
+		result := map[string]interface{}{}
+		if v == nil {
+			return result
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return result
+		}
+		json.Unmarshal(data, &result)
+		return result
+	}
+
+	beforeMap := toMap(before)
+	afterMap := toMap(after)
+
+	changed := make(map[string]interface{})
+	for key, value := range afterMap {
+		if prev, ok := beforeMap[key]; !ok || !reflect.DeepEqual(prev, value) {
+			changed[key] = value
+		}
+	}
+	return changed
+}
+
+// BumpMarketSequence 시장 데이터 변경 알림 (캐시된 뷰 즉시 무효화)
+func BumpMarketSequence(milestoneID uint) {
+	if redis.GetClient() == nil {
+		return
+	}
+	if _, err := redis.IncrMarketSequence(milestoneID); err != nil {
+		log.Printf("⚠️ Failed to bump market sequence for %d: %v", milestoneID, err)
+	}
+}
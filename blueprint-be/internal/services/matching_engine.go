@@ -2,13 +2,13 @@ package services
 
 import (
 	"blueprint-module/pkg/models"
-	"blueprint-module/pkg/queue"
-	"blueprint-module/pkg/redis"
 	"container/heap"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"gorm.io/gorm"
@@ -16,31 +16,83 @@ import (
 
 // 🚀 High-Performance Matching Engine (Polymarket Style)
 
-// MatchingEngine 고성능 매칭 엔진
-type MatchingEngine struct {
-	db                     *gorm.DB
-	queuePublisher         *queue.Publisher
-	sseService             *SSEService                 // SSE 실시간 브로드캐스트용
-	fundingService         *FundingVerificationService // 🆕 펀딩 검증 서비스
-	mentorQualificationSvc *MentorQualificationService // 🆕 멘토 자격 증명 서비스
+// LocalMatchingEngine 단일 노드 고성능 매칭 엔진 (주문장을 프로세스 메모리에 보관)
+type LocalMatchingEngine struct {
+	*tradePipeline // 체결 후처리 (분산 엔진과 공유)
 
 	// 매칭 엔진 상태
-	isRunning bool
-	stopChan  chan struct{}
-	orderChan chan *OrderMatchRequest
-	mutex     sync.RWMutex
+	running  atomic.Bool
+	stopChan chan struct{}
+	workers  sync.WaitGroup // 시장 액터/워커 고루틴 (Stop에서 종료 대기)
+	mutex    sync.Mutex     // Start/Stop 직렬화 전용
 
-	// 시장별 주문장 (인메모리 고속 처리)
-	orderBooks map[string]*OrderBookEngine // milestoneID:optionID -> OrderBook
-
-	// 성능 통계
-	stats MatchingStats
+	// 시장별 단일 작성자 액터 (milestoneID:optionID -> *marketActor)
+	markets   sync.Map
+	startTime time.Time
 }
 
 // OrderMatchRequest 매칭 요청
 type OrderMatchRequest struct {
 	Order    *models.Order
 	Response chan<- *MatchingResult
+
+	requestState
+}
+
+// CancelSLA 주문 취소 처리 목표 시간 (요청 접수 → 주문장 제거)
+//
+// 취소는 시장 액터의 별도 채널로 들어오며 액터는 신규 주문을 꺼내기 전에 대기 중인 취소를 모두 처리한다.
+// 과부하로 신규 주문 큐가 가득 차도 취소는 밀리지 않는다.
+// SLA를 넘긴 취소는 MatchingStats.CancelSLABreaches로 집계된다.
+const CancelSLA = 50 * time.Millisecond
+
+// tradeFeeBasisPoints 매수/매도 각각의 체결 수수료 (25bp = 0.25%, 매수 주문 수수료 잠금 요율과 같은 상한)
+const tradeFeeBasisPoints = models.MaxTradeFeeBasisPoints
+
+// new_fee_tiers 적용 시 메이커/테이커 수수료 (FeeService 기본 요율과 같음)
+const (
+	makerFeeBasisPoints = 10
+	takerFeeBasisPoints = 20
+)
+
+// matchWaitTimeout 매칭 결과 대기 최대 시간 (초과하면 큐에 남은 주문은 버려짐)
+const matchWaitTimeout = 30 * time.Second
+
+// cancelWaitTimeout 취소 결과 대기 최대 시간 (초과하면 큐에 남은 요청은 버려지고 주문은 주문장에 남음)
+const cancelWaitTimeout = 2 * time.Second
+
+// ErrCancelNotConfirmed 주문장에서 주문 제거를 확인하지 못함 (주문은 계속 체결될 수 있으므로 DB 상태/잠금을 바꾸면 안 됨)
+var ErrCancelNotConfirmed = errors.New("주문장에서 주문 제거를 확인하지 못했습니다. 잠시 후 다시 시도하세요")
+
+// 주문/취소 요청 상태 (대기 → 적용 또는 포기, 한 번만 바뀜)
+const (
+	requestPending int32 = iota
+	requestApplied
+	requestAbandoned
+)
+
+// requestState 시장 액터와 기다리던 호출자 중 먼저 가져간 쪽만 요청을 처리
+type requestState struct {
+	state atomic.Int32
+}
+
+// claim 액터가 적용할 차례 (호출자가 이미 포기했으면 false)
+func (r *requestState) claim() bool {
+	return r.state.CompareAndSwap(requestPending, requestApplied)
+}
+
+// abandon 호출자가 대기를 포기 (액터가 이미 적용했으면 false)
+func (r *requestState) abandon() bool {
+	return r.state.CompareAndSwap(requestPending, requestAbandoned)
+}
+
+// CancelRequest 취소 요청
+type CancelRequest struct {
+	Order      *models.Order
+	EnqueuedAt time.Time
+	Done       chan struct{}
+
+	requestState
 }
 
 // MatchingResult 매칭 결과
@@ -48,6 +100,11 @@ type MatchingResult struct {
 	Trades   []models.Trade
 	Error    error
 	Executed bool
+
+	// 매칭 직후 주문 상태 (주문장에 남은 주문은 이후에도 바뀌므로 복사본)
+	Filled    int64
+	Remaining int64
+	Status    models.OrderStatus
 }
 
 // OrderBookEngine 개별 시장의 주문장 엔진
@@ -68,7 +125,11 @@ type OrderBookEngine struct {
 	volume24h   int64
 	tradesCount int64
 
-	mutex sync.RWMutex
+	// sequence 가격 레벨이 바뀔 때마다 증가 (호가 스냅샷과 SSE 증분 순서 동기화용)
+	sequence         uint64
+	snapshotSequence uint64                          // 마지막 전체 스냅샷 전송 시점의 시퀀스
+	publishedBids    map[int64]models.OrderBookLevel // 마지막으로 내보낸 가격 레벨 (증분 계산 기준)
+	publishedAsks    map[int64]models.OrderBookLevel
 }
 
 // BuyOrderHeap 매수 주문 힙 (가격 높은 순, 시간 빠른 순)
@@ -78,8 +139,8 @@ func (h BuyOrderHeap) Len() int { return len(h) }
 
 func (h BuyOrderHeap) Less(i, j int) bool {
 	// 1. 가격이 높은 것이 우선
-	if h[i].Price != h[j].Price {
-		return h[i].Price > h[j].Price
+	if h[i].PriceTicks != h[j].PriceTicks {
+		return h[i].PriceTicks > h[j].PriceTicks
 	}
 	// 2. 가격이 같으면 시간이 빠른 것이 우선 (FIFO)
 	return h[i].CreatedAt.Before(h[j].CreatedAt)
@@ -106,8 +167,8 @@ func (h SellOrderHeap) Len() int { return len(h) }
 
 func (h SellOrderHeap) Less(i, j int) bool {
 	// 1. 가격이 낮은 것이 우선
-	if h[i].Price != h[j].Price {
-		return h[i].Price < h[j].Price
+	if h[i].PriceTicks != h[j].PriceTicks {
+		return h[i].PriceTicks < h[j].PriceTicks
 	}
 	// 2. 가격이 같으면 시간이 빠른 것이 우선 (FIFO)
 	return h[i].CreatedAt.Before(h[j].CreatedAt)
@@ -137,37 +198,65 @@ type MatchingStats struct {
 	CacheHitRate     float64   `json:"cache_hit_rate"`
 	LastMatchTime    time.Time `json:"last_match_time"`
 	StartTime        time.Time `json:"start_time"`
-}
 
-// NewMatchingEngine 매칭 엔진 생성자
-func NewMatchingEngine(db *gorm.DB, sseService *SSEService, fundingService *FundingVerificationService, mentorQualificationSvc *MentorQualificationService) *MatchingEngine {
-	return &MatchingEngine{
-		db:                     db,
-		queuePublisher:         queue.NewPublisher(),
-		sseService:             sseService,
-		fundingService:         fundingService,
-		mentorQualificationSvc: mentorQualificationSvc,
-		stopChan:               make(chan struct{}),
-		orderChan:              make(chan *OrderMatchRequest, 10000), // 고성능 버퍼
-		orderBooks:             make(map[string]*OrderBookEngine),
-		stats: MatchingStats{
-			StartTime: time.Now(),
-		},
-	}
+	// 취소 처리 지표 (CancelSLA 기준)
+	CancelsProcessed  int64   `json:"cancels_processed"`
+	AvgCancelLatency  float64 `json:"avg_cancel_latency_ms"`
+	MaxCancelLatency  float64 `json:"max_cancel_latency_ms"`
+	CancelSLABreaches int64   `json:"cancel_sla_breaches"`
+	PendingCancels    int     `json:"pending_cancels"`
+	PendingOrders     int     `json:"pending_orders"`
+}
+
+// NewLocalMatchingEngine 단일 노드 매칭 엔진 생성자
+func NewLocalMatchingEngine(db *gorm.DB, sseService *SSEService, fundingService *FundingVerificationService, mentorQualificationSvc *MentorQualificationService) *LocalMatchingEngine {
+	me := &LocalMatchingEngine{
+		tradePipeline: newTradePipeline(db, sseService, fundingService, mentorQualificationSvc),
+		stopChan:      make(chan struct{}),
+		startTime:     time.Now(),
+	}
+	me.quote = me.bookQuote
+	return me
+}
+
+// bookQuote 시장 액터에서 최우선 호가와 마지막 체결가 조회
+func (me *LocalMatchingEngine) bookQuote(milestoneID uint, optionID string) BookQuote {
+	var quote BookQuote
+	me.withBook(me.actorFor(milestoneID, optionID), func(orderBook *OrderBookEngine) {
+		quote.Last = orderBook.lastPrice
+		if orderBook.BuyOrders.Len() > 0 {
+			quote.Bid = (*orderBook.BuyOrders)[0].Price
+		}
+		if orderBook.SellOrders.Len() > 0 {
+			quote.Ask = (*orderBook.SellOrders)[0].Price
+		}
+	})
+	return quote
 }
 
 // Start 매칭 엔진 시작
-func (me *MatchingEngine) Start() error {
+func (me *LocalMatchingEngine) Start() error {
 	me.mutex.Lock()
 	defer me.mutex.Unlock()
 
-	if me.isRunning {
+	if me.running.Load() {
 		log.Println("⚠️ Matching engine is already running")
 		return nil
 	}
 
 	log.Println("🚀 Starting Matching Engine...")
 
+	// Stop 후 재시작이면 새 정지 채널로, 주문장은 Stop이 기록한 DB 상태로 다시 적재 (이전 고루틴은 모두 종료됨)
+	select {
+	case <-me.stopChan:
+		me.stopChan = make(chan struct{})
+		me.markets.Range(func(key, _ interface{}) bool {
+			me.markets.Delete(key)
+			return true
+		})
+	default:
+	}
+
 	// 기존 주문들을 메모리로 로드
 	log.Println("📊 Loading existing orders...")
 	if err := me.loadExistingOrders(); err != nil {
@@ -175,45 +264,71 @@ func (me *MatchingEngine) Start() error {
 		return err // 중요한 오류는 리턴
 	}
 
-	me.isRunning = true
+	me.running.Store(true)
 	log.Println("🔥 High-Performance Matching Engine started!")
 
-	// 매칭 워커 시작 (동시 처리)
-	log.Println("🔧 Starting matching workers...")
-	for i := 0; i < 4; i++ { // 4개 워커로 병렬 처리
-		go me.matchingWorker(i)
-	}
+	// 시장별 액터 시작 (이후 생기는 시장은 첫 주문 시 시작)
+	me.eachActor(me.startActor)
 
 	// 통계 업데이트 워커
-	go me.statsWorker()
+	me.goWorker(me.statsWorker)
+
+	// 호가 전체 스냅샷 주기 전송 워커
+	me.goWorker(me.snapshotWorker)
+
+	// 주문 상태 DB 반영 워커
+	me.goWorker(me.persistWorker)
 
 	log.Println("✅ All matching engine workers started successfully")
 	return nil
 }
 
 // Stop 매칭 엔진 중지
-func (me *MatchingEngine) Stop() error {
+func (me *LocalMatchingEngine) Stop() error {
 	me.mutex.Lock()
 	defer me.mutex.Unlock()
 
-	if !me.isRunning {
+	if !me.running.Load() {
 		return nil
 	}
 
-	me.isRunning = false
+	me.running.Store(false)
 	close(me.stopChan)
-	close(me.orderChan)
+	me.workers.Wait()
+
+	// 아직 반영되지 않은 주문 상태 기록
+	if persisted := me.FlushOrderStates(); persisted > 0 {
+		log.Printf("💾 Persisted %d pending order states on shutdown", persisted)
+	}
 
 	log.Println("🛑 Matching Engine stopped!")
 	return nil
 }
 
-// SubmitOrder 주문 제출 (비동기 고속 처리)
-func (me *MatchingEngine) SubmitOrder(order *models.Order) (*MatchingResult, error) {
-	if !me.isRunning {
+// goWorker 정지 채널이 닫히면 끝나는 고루틴 시작 (Stop이 종료를 기다림)
+func (me *LocalMatchingEngine) goWorker(fn func()) {
+	me.workers.Add(1)
+	go func() {
+		defer me.workers.Done()
+		fn()
+	}()
+}
+
+// SubmitOrder 주문 제출 (해당 시장 액터에 전달 후 결과 대기)
+func (me *LocalMatchingEngine) SubmitOrder(order *models.Order) (*MatchingResult, error) {
+	if !me.running.Load() {
 		return nil, fmt.Errorf("matching engine is not running")
 	}
 
+	// 점검/서킷브레이커 중단 중에는 대기열에 넣지 않음 (취소는 CancelOrder로 계속 처리)
+	if err := me.tradingPause.Check(order.MilestoneID); err != nil {
+		return nil, err
+	}
+	if until, halted := me.circuitBreaker.HaltedUntil(order.MilestoneID); halted {
+		return nil, fmt.Errorf("%w: %s 이후 재개", ErrMarketHalted, until.UTC().Format(time.RFC3339))
+	}
+
+	actor := me.actorFor(order.MilestoneID, order.OptionID)
 	responseChan := make(chan *MatchingResult, 1)
 
 	request := &OrderMatchRequest{
@@ -223,118 +338,70 @@ func (me *MatchingEngine) SubmitOrder(order *models.Order) (*MatchingResult, err
 
 	// 논블로킹 전송
 	select {
-	case me.orderChan <- request:
+	case actor.orders <- request:
 		// 응답 대기 (타임아웃 30초로 증가)
 		select {
 		case result := <-responseChan:
 			return result, nil
-		case <-time.After(30 * time.Second):
+		case <-time.After(matchWaitTimeout):
+		}
+
+		// 액터가 아직 꺼내지 않았으면 요청을 버려 주문장에 들어가지 않게 함 (이미 처리 중이면 결과를 기다림)
+		if request.abandon() {
 			log.Printf("❌ Matching timeout for order: %+v", order)
 			return nil, fmt.Errorf("matching timeout")
 		}
+		return <-responseChan, nil
 	default:
 		return nil, fmt.Errorf("matching queue is full")
 	}
 }
 
-// matchingWorker 매칭 워커 (병렬 처리)
-func (me *MatchingEngine) matchingWorker(workerID int) {
-	log.Printf("🔧 Matching worker %d started", workerID)
-
-	for {
-		select {
-		case <-me.stopChan:
-			return
-		case request := <-me.orderChan:
-			if request == nil {
-				return
-			}
-
-			startTime := time.Now()
-			result := me.processOrder(request.Order)
-
-			// 성능 통계 업데이트
-			processingTime := time.Since(startTime)
-			me.updateStats(processingTime)
-
-			// 느린 주문만 로그 출력 (100ms 이상)
-			if processingTime > 100*time.Millisecond {
-				log.Printf("⚠️ Slow order processing: Worker %d, Order %d, Time %v", workerID, request.Order.ID, processingTime)
-			}
+// processOrder 주문 처리 (핵심 매칭 로직, 시장 액터 고루틴에서만 호출)
+func (me *LocalMatchingEngine) processOrder(orderBook *OrderBookEngine, order *models.Order) *MatchingResult {
+	var trades []models.Trade
 
-			// 응답 전송 (논블로킹)
-			select {
-			case request.Response <- result:
-				// 성공적으로 응답 전송
-			default:
-				// 응답 채널이 이미 닫혔거나 수신자가 없음 (타임아웃 발생)
-				log.Printf("⚠️ Response channel unavailable for order %d (likely timeout)", request.Order.ID)
-			}
-		}
+	// 저장 전 주문이 들어와도 틱 기준으로 비교되도록
+	if order.PriceTicks == 0 {
+		order.PriceTicks = models.PriceToTicks(order.Price)
 	}
-}
-
-// processOrder 주문 처리 (핵심 매칭 로직)
-func (me *MatchingEngine) processOrder(order *models.Order) *MatchingResult {
-	// 주문장 가져오기 또는 생성
-	orderBook := me.getOrCreateOrderBook(order.MilestoneID, order.OptionID)
-
-	orderBook.mutex.Lock()
-	defer orderBook.mutex.Unlock()
-
-	var trades []models.Trade
 
 	// 폴리마켓 스타일: Limit Order만 처리
 	trades = me.executeLimitOrder(orderBook, order)
 
-	// 체결된 거래가 있으면 처리
-	if len(trades) > 0 {
-		// 🆕 펀딩 TVL 업데이트 (동기 처리 - 중요)
-		go me.updateFundingTVL(order.MilestoneID, order.OptionID, trades)
+	// 바뀐 가격 레벨을 시퀀스 번호와 함께 증분으로 전송
+	me.commitOrderBookChange(orderBook)
 
-		// 🆕 멘토 자격 업데이트 (비동기 처리 - "가장 똑똑한 돈" 식별)
-		go me.updateMentorQualification(order.MilestoneID, trades)
-
-		// 🆕 멘토 풀 수수료 적립 (비동기 처리 - "The Reward Engine")
-		go me.accumulateMentorPoolFees(order.MilestoneID, trades)
-
-		// 데이터베이스에 저장 (비동기)
-		go me.persistTrades(trades)
-
-		// 사용자 지갑 잔액 업데이트 (비동기)
-		go me.updateUserWallets(trades)
-
-		// 사용자 Position 업데이트 (비동기)
-		go me.updateUserPositions(trades)
-
-		// MarketData 업데이트 (비동기)
-		go me.updateMarketData(order.MilestoneID, order.OptionID, trades)
-
-		// 실시간 브로드캐스트
-		go me.broadcastTrades(trades)
-
-		// 캐시 업데이트
-		go me.updateMarketCache(order.MilestoneID, order.OptionID, trades)
+	if order.Filled > 0 {
+		me.markOrderDirty(order)
 	}
+	filled, remaining, status := order.Filled, order.Remaining, order.Status
+
+	// 체결된 거래가 있으면 후처리 (지갑/포지션/수수료/시장 데이터/브로드캐스트)
+	me.settleTrades(order.MilestoneID, order.OptionID, trades)
 
 	return &MatchingResult{
-		Trades:   trades,
-		Executed: len(trades) > 0,
-		Error:    nil,
+		Trades:    trades,
+		Executed:  len(trades) > 0,
+		Error:     nil,
+		Filled:    filled,
+		Remaining: remaining,
+		Status:    status,
 	}
 }
 
 // executeLimitOrder 지정가 주문 체결
-func (me *MatchingEngine) executeLimitOrder(orderBook *OrderBookEngine, order *models.Order) []models.Trade {
+func (me *LocalMatchingEngine) executeLimitOrder(orderBook *OrderBookEngine, order *models.Order) []models.Trade {
 	var trades []models.Trade
 	remaining := order.Quantity
+	buyerFee, sellerFee := me.feeBasisPoints(order)
 
 	if order.Side == models.OrderSideBuy {
 		// 매수 지정가: 지정가 이하의 매도 주문과 체결
 		for remaining > 0 && orderBook.SellOrders.Len() > 0 {
 			bestSell := (*orderBook.SellOrders)[0]
 
-			if bestSell.Price > order.Price {
+			if bestSell.PriceTicks > order.PriceTicks {
 				break // 가격 조건 불만족
 			}
 
@@ -345,24 +412,29 @@ func (me *MatchingEngine) executeLimitOrder(orderBook *OrderBookEngine, order *m
 
 			matchQuantity := min(remaining, bestSell.Remaining)
 
-			totalAmount := int64(float64(matchQuantity) * bestSell.Price * 100) // 센트 단위로 변환
-			buyerFee := totalAmount * 25 / 10000                                // 0.25% 수수료
-			sellerFee := totalAmount * 25 / 10000                               // 0.25% 수수료
+			// 메이커 가격으로 체결, 지정가와의 차액은 매수자 잠금에서 해제
+			settlement := models.SettleFillWithFees(matchQuantity, bestSell.PriceTicks, order.PriceTicks,
+				order.Quantity-remaining, buyerFee, sellerFee)
 
 			trade := models.Trade{
-				ProjectID:   order.ProjectID,
-				MilestoneID: order.MilestoneID,
-				OptionID:    order.OptionID,
-				BuyOrderID:  order.ID,
-				SellOrderID: bestSell.ID,
-				BuyerID:     order.UserID,
-				SellerID:    bestSell.UserID,
-				Quantity:    matchQuantity,
-				Price:       bestSell.Price,
-				TotalAmount: totalAmount,
-				BuyerFee:    buyerFee,
-				SellerFee:   sellerFee,
-				CreatedAt:   time.Now(),
+				ProjectID:     order.ProjectID,
+				MilestoneID:   order.MilestoneID,
+				OptionID:      order.OptionID,
+				BuyOrderID:    order.ID,
+				SellOrderID:   bestSell.ID,
+				BuyerID:       order.UserID,
+				SellerID:      bestSell.UserID,
+				Quantity:      matchQuantity,
+				Price:         bestSell.Price,
+				PriceTicks:    bestSell.PriceTicks,
+				TotalAmount:   settlement.Notional,
+				BuyerFee:      settlement.BuyerFee,
+				SellerFee:     settlement.SellerFee,
+				BuyerRelease:  settlement.BuyerRelease,
+				QuoteCurrency: order.QuoteCurrency.OrUSDC(),
+				CreatedAt:     time.Now(),
+
+				SellerCollateral: bestSell.ShortCollateralFill(bestSell.Filled, matchQuantity),
 			}
 
 			trades = append(trades, trade)
@@ -376,7 +448,10 @@ func (me *MatchingEngine) executeLimitOrder(orderBook *OrderBookEngine, order *m
 				bestSell.Status = models.OrderStatusFilled
 				// 🔧 메모리 리크 방지: 완료된 주문은 인덱스에서 제거
 				delete(orderBook.orderIndex, bestSell.ID)
+			} else {
+				bestSell.Status = models.OrderStatusPartial
 			}
+			me.markOrderDirty(bestSell)
 
 			orderBook.lastPrice = bestSell.Price
 		}
@@ -393,7 +468,7 @@ func (me *MatchingEngine) executeLimitOrder(orderBook *OrderBookEngine, order *m
 		for remaining > 0 && orderBook.BuyOrders.Len() > 0 {
 			bestBuy := (*orderBook.BuyOrders)[0]
 
-			if bestBuy.Price < order.Price {
+			if bestBuy.PriceTicks < order.PriceTicks {
 				break // 가격 조건 불만족
 			}
 
@@ -404,24 +479,28 @@ func (me *MatchingEngine) executeLimitOrder(orderBook *OrderBookEngine, order *m
 
 			matchQuantity := min(remaining, bestBuy.Remaining)
 
-			totalAmount := int64(float64(matchQuantity) * bestBuy.Price * 100) // 센트 단위로 변환
-			buyerFee := totalAmount * 25 / 10000                               // 0.25% 수수료
-			sellerFee := totalAmount * 25 / 10000                              // 0.25% 수수료
+			settlement := models.SettleFillWithFees(matchQuantity, bestBuy.PriceTicks, bestBuy.PriceTicks,
+				bestBuy.Filled, buyerFee, sellerFee)
 
 			trade := models.Trade{
-				ProjectID:   order.ProjectID,
-				MilestoneID: order.MilestoneID,
-				OptionID:    order.OptionID,
-				BuyOrderID:  bestBuy.ID,
-				SellOrderID: order.ID,
-				BuyerID:     bestBuy.UserID,
-				SellerID:    order.UserID,
-				Quantity:    matchQuantity,
-				Price:       bestBuy.Price,
-				TotalAmount: totalAmount,
-				BuyerFee:    buyerFee,
-				SellerFee:   sellerFee,
-				CreatedAt:   time.Now(),
+				ProjectID:     order.ProjectID,
+				MilestoneID:   order.MilestoneID,
+				OptionID:      order.OptionID,
+				BuyOrderID:    bestBuy.ID,
+				SellOrderID:   order.ID,
+				BuyerID:       bestBuy.UserID,
+				SellerID:      order.UserID,
+				Quantity:      matchQuantity,
+				Price:         bestBuy.Price,
+				PriceTicks:    bestBuy.PriceTicks,
+				TotalAmount:   settlement.Notional,
+				BuyerFee:      settlement.BuyerFee,
+				SellerFee:     settlement.SellerFee,
+				BuyerRelease:  settlement.BuyerRelease,
+				QuoteCurrency: order.QuoteCurrency.OrUSDC(),
+				CreatedAt:     time.Now(),
+
+				SellerCollateral: order.ShortCollateralFill(order.Quantity-remaining, matchQuantity),
 			}
 
 			trades = append(trades, trade)
@@ -435,7 +514,10 @@ func (me *MatchingEngine) executeLimitOrder(orderBook *OrderBookEngine, order *m
 				bestBuy.Status = models.OrderStatusFilled
 				// 🔧 메모리 리크 방지: 완료된 주문은 인덱스에서 제거
 				delete(orderBook.orderIndex, bestBuy.ID)
+			} else {
+				bestBuy.Status = models.OrderStatusPartial
 			}
+			me.markOrderDirty(bestBuy)
 
 			orderBook.lastPrice = bestBuy.Price
 		}
@@ -449,15 +531,14 @@ func (me *MatchingEngine) executeLimitOrder(orderBook *OrderBookEngine, order *m
 		}
 	}
 
-	// 주문 상태 업데이트
+	// 주문 상태 업데이트 (전량 체결도 잔량 0으로 기록)
 	order.Filled = order.Quantity - remaining
+	order.Remaining = remaining
 
 	if remaining <= 0 {
 		order.Status = models.OrderStatusFilled
 		// 🔧 메모리 리크 방지: 완전 체결된 주문도 인덱스에서 제거
-		orderBook.mutex.Lock()
 		delete(orderBook.orderIndex, order.ID)
-		orderBook.mutex.Unlock()
 	} else if order.Filled > 0 {
 		order.Status = models.OrderStatusPartial
 	}
@@ -465,200 +546,103 @@ func (me *MatchingEngine) executeLimitOrder(orderBook *OrderBookEngine, order *m
 	return trades
 }
 
-// CancelOrder 주문 취소 (매칭 엔진에서 제거)
-func (me *MatchingEngine) CancelOrder(order *models.Order) {
-	key := me.getMarketKey(order.MilestoneID, order.OptionID)
-
-	me.mutex.RLock()
-	orderBook, exists := me.orderBooks[key]
-	me.mutex.RUnlock()
-
+// CancelOrder 주문 취소 (시장 액터의 우선 채널로 전달 후 주문장 제거 완료까지 대기)
+// 대기 시간 안에 제거되지 않으면 요청을 버리고 ErrCancelNotConfirmed를 반환한다 (주문은 주문장에 그대로 남음).
+func (me *LocalMatchingEngine) CancelOrder(order *models.Order) error {
+	actor, exists := me.lookupActor(order.MilestoneID, order.OptionID)
 	if !exists {
-		return // 주문장이 없으면 무시
+		return nil // 주문장이 없으면 제거할 것도 없음
 	}
 
-	orderBook.mutex.Lock()
-	defer orderBook.mutex.Unlock()
-
-	// 인덱스에서 주문 제거
-	delete(orderBook.orderIndex, order.ID)
-
-	// 힙에서도 제거 (비효율적이지만 정확성 보장)
-	me.removeFromHeap(orderBook, order)
-}
-
-// removeFromHeap 힙에서 특정 주문 제거
-func (me *MatchingEngine) removeFromHeap(orderBook *OrderBookEngine, order *models.Order) {
-	if order.Side == models.OrderSideBuy {
-		for i, o := range *orderBook.BuyOrders {
-			if o.ID == order.ID {
-				(*orderBook.BuyOrders)[i] = (*orderBook.BuyOrders)[len(*orderBook.BuyOrders)-1]
-				*orderBook.BuyOrders = (*orderBook.BuyOrders)[:len(*orderBook.BuyOrders)-1]
-				heap.Init(orderBook.BuyOrders)
-				break
-			}
-		}
-	} else {
-		for i, o := range *orderBook.SellOrders {
-			if o.ID == order.ID {
-				(*orderBook.SellOrders)[i] = (*orderBook.SellOrders)[len(*orderBook.SellOrders)-1]
-				*orderBook.SellOrders = (*orderBook.SellOrders)[:len(*orderBook.SellOrders)-1]
-				heap.Init(orderBook.SellOrders)
-				break
-			}
-		}
-	}
-}
-
-// 🆕 updateFundingTVL 펀딩 TVL 업데이트
-func (me *MatchingEngine) updateFundingTVL(milestoneID uint, optionID string, trades []models.Trade) {
-	if me.fundingService == nil {
-		return
-	}
-
-	// 거래의 총 금액 계산
-	var totalAmount int64
-	for _, trade := range trades {
-		totalAmount += trade.TotalAmount
+	request := &CancelRequest{
+		Order:      order,
+		EnqueuedAt: time.Now(),
+		Done:       make(chan struct{}),
 	}
 
-	// 펀딩 서비스를 통해 TVL 업데이트
-	if err := me.fundingService.UpdateTVL(milestoneID, optionID, totalAmount); err != nil {
-		log.Printf("❌ Failed to update TVL for milestone %d: %v", milestoneID, err)
+	if !me.running.Load() {
+		me.applyCancel(actor, request)
+		return nil
 	}
-}
 
-// 🆕 updateMentorQualification 멘토 자격 업데이트
-func (me *MatchingEngine) updateMentorQualification(milestoneID uint, trades []models.Trade) {
-	if me.mentorQualificationSvc == nil {
-		return
-	}
+	// 주문장은 액터만 수정하므로 큐가 차 있어도 직접 제거하지 않고 대기
+	stopChan := me.stopChan
+	timeout := time.NewTimer(cancelWaitTimeout)
+	defer timeout.Stop()
 
-	// 성공 베팅과 관련된 거래만 처리 (optionID가 "success"인 경우)
-	hasSuccessBetting := false
-	for _, trade := range trades {
-		if trade.OptionID == "success" {
-			hasSuccessBetting = true
-			break
-		}
+	select {
+	case actor.cancels <- request:
+	case <-stopChan:
+		return fmt.Errorf("%w: 매칭 엔진 정지 중", ErrCancelNotConfirmed)
+	case <-timeout.C:
+		log.Printf("⚠️ Cancel queue for market %d:%s is full, order %d not removed", order.MilestoneID, order.OptionID, order.ID)
+		return fmt.Errorf("%w: 취소 대기열이 가득 참", ErrCancelNotConfirmed)
 	}
 
-	if !hasSuccessBetting {
-		return // 실패 베팅은 멘토 자격과 관련 없음
+	select {
+	case <-request.Done:
+		return nil
+	case <-stopChan:
+	case <-timeout.C:
 	}
 
-	// 멘토 자격 재처리 (베팅 순위 변동 반영)
-	if _, err := me.mentorQualificationSvc.ProcessMilestoneBetting(milestoneID); err != nil {
-		log.Printf("❌ Failed to update mentor qualification for milestone %d: %v", milestoneID, err)
-	} else {
-		log.Printf("✨ Mentor qualification updated for milestone %d after new trades", milestoneID)
+	// 액터가 아직 꺼내지 않았으면 요청을 버림 (그 사이 적용됐으면 완료를 기다림)
+	if request.abandon() {
+		log.Printf("⚠️ Cancel for order %d not applied within %v, order stays in the book", order.ID, cancelWaitTimeout)
+		return fmt.Errorf("%w: %v 안에 처리되지 않음", ErrCancelNotConfirmed, cancelWaitTimeout)
 	}
+	<-request.Done
+	return nil
 }
 
-// 🆕 accumulateMentorPoolFees 멘토 풀에 수수료 적립
-func (me *MatchingEngine) accumulateMentorPoolFees(milestoneID uint, trades []models.Trade) {
-	// 총 거래 수수료 계산
-	var totalFees int64
-	for _, trade := range trades {
-		totalFees += trade.BuyerFee + trade.SellerFee
-	}
-
-	if totalFees <= 0 {
+// applyCancel 취소 요청 적용 및 지연 시간 기록 (호출자가 포기한 요청은 건너뜀)
+func (me *LocalMatchingEngine) applyCancel(actor *marketActor, request *CancelRequest) {
+	if !request.claim() {
 		return
 	}
+	me.removeOrder(actor.book, request.Order)
+	close(request.Done)
+	actor.recordCancel(time.Since(request.EnqueuedAt))
+}
 
-	// 멘토 풀 조회 및 수수료 적립
-	var mentorPool models.MentorPool
-	if err := me.db.Where("milestone_id = ?", milestoneID).First(&mentorPool).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			log.Printf("📋 No mentor pool found for milestone %d, skipping fee accumulation", milestoneID)
-			return
-		}
-		log.Printf("❌ Failed to query mentor pool for milestone %d: %v", milestoneID, err)
-		return
-	}
-
-	// 설정된 비율만큼 멘토 풀에 적립 (기본 50%)
-	mentorPoolFees := int64(float64(totalFees) * mentorPool.FeePercentage / 100)
-
-	// 멘토 풀 업데이트
-	mentorPool.AccumulatedFees += mentorPoolFees
-	mentorPool.TotalPoolAmount += mentorPoolFees
+// removeOrder 주문장에서 주문 제거
+func (me *LocalMatchingEngine) removeOrder(orderBook *OrderBookEngine, order *models.Order) {
+	// 인덱스에서 주문 제거
+	delete(orderBook.orderIndex, order.ID)
 
-	if err := me.db.Save(&mentorPool).Error; err != nil {
-		log.Printf("❌ Failed to update mentor pool fees for milestone %d: %v", milestoneID, err)
-		return
+	// 힙에서도 제거 (비효율적이지만 정확성 보장)
+	if me.removeFromHeap(orderBook, order) {
+		me.commitOrderBookChange(orderBook)
 	}
-
-	log.Printf("💰 Accumulated $%.2f mentor pool fees for milestone %d (%.1f%% of total fees $%.2f)",
-		float64(mentorPoolFees)/100, milestoneID, mentorPool.FeePercentage, float64(totalFees)/100)
-
-	// 실시간 멘토 풀 업데이트 알림
-	go me.broadcastMentorPoolUpdate(milestoneID, &mentorPool, mentorPoolFees)
 }
 
-// broadcastMentorPoolUpdate 멘토 풀 업데이트 브로드캐스트
-func (me *MatchingEngine) broadcastMentorPoolUpdate(milestoneID uint, pool *models.MentorPool, addedAmount int64) {
-	if me.sseService == nil {
-		return
-	}
-
-	event := MarketUpdateEvent{
-		MilestoneID: milestoneID,
-		MarketData: map[string]interface{}{
-			"event_type": "mentor_pool_update",
-			"data": map[string]interface{}{
-				"milestone_id":      milestoneID,
-				"total_pool_amount": pool.TotalPoolAmount,
-				"accumulated_fees":  pool.AccumulatedFees,
-				"added_amount":      addedAmount,
-				"fee_percentage":    pool.FeePercentage,
-				"updated_at":        time.Now().Unix(),
-			},
-		},
-		Timestamp: time.Now().Unix(),
+// removeFromHeap 힙에서 특정 주문 제거 (제거했으면 true)
+func (me *LocalMatchingEngine) removeFromHeap(orderBook *OrderBookEngine, order *models.Order) bool {
+	if order.Side == models.OrderSideBuy {
+		for i, o := range *orderBook.BuyOrders {
+			if o.ID == order.ID {
+				heap.Remove(orderBook.BuyOrders, i)
+				return true
+			}
+		}
+	} else {
+		for i, o := range *orderBook.SellOrders {
+			if o.ID == order.ID {
+				heap.Remove(orderBook.SellOrders, i)
+				return true
+			}
+		}
 	}
-
-	me.sseService.BroadcastMarketUpdate(event)
+	return false
 }
 
 // Helper functions
 
-func (me *MatchingEngine) getMarketKey(milestoneID uint, optionID string) string {
+func (me *LocalMatchingEngine) getMarketKey(milestoneID uint, optionID string) string {
 	return fmt.Sprintf("%d:%s", milestoneID, optionID)
 }
 
-func (me *MatchingEngine) getOrCreateOrderBook(milestoneID uint, optionID string) *OrderBookEngine {
-	me.mutex.Lock()
-	defer me.mutex.Unlock()
-	return me.getOrCreateOrderBookUnsafe(milestoneID, optionID)
-}
-
-// getOrCreateOrderBookUnsafe - mutex 없이 오더북 생성 (내부 호출용)
-func (me *MatchingEngine) getOrCreateOrderBookUnsafe(milestoneID uint, optionID string) *OrderBookEngine {
-	key := me.getMarketKey(milestoneID, optionID)
-
-	if orderBook, exists := me.orderBooks[key]; exists {
-		return orderBook
-	}
-
-	orderBook := &OrderBookEngine{
-		MilestoneID: milestoneID,
-		OptionID:    optionID,
-		BuyOrders:   &BuyOrderHeap{},
-		SellOrders:  &SellOrderHeap{},
-		orderIndex:  make(map[uint]*models.Order),
-		priceIndex:  make(map[float64][]*models.Order),
-	}
-
-	heap.Init(orderBook.BuyOrders)
-	heap.Init(orderBook.SellOrders)
-
-	me.orderBooks[key] = orderBook
-	return orderBook
-}
-
-func (me *MatchingEngine) loadExistingOrders() error {
+func (me *LocalMatchingEngine) loadExistingOrders() error {
 	var orders []models.Order
 	err := me.db.Where("status IN ?", []models.OrderStatus{
 		models.OrderStatusPending,
@@ -675,10 +659,12 @@ func (me *MatchingEngine) loadExistingOrders() error {
 		return err
 	}
 
+	// 마지막 상태 반영 전에 중단됐을 수 있으므로 체결 내역 기준으로 보정 후 적재
+	orders = me.reconcileOrdersWithTrades(orders)
+
+	// 액터 시작 전이므로 주문장을 직접 채움
 	for _, order := range orders {
-		// mutex가 이미 Start()에서 잠겨있으므로 Unsafe 버전 사용
-		orderBook := me.getOrCreateOrderBookUnsafe(order.MilestoneID, order.OptionID)
-		orderBook.mutex.Lock()
+		orderBook := me.actorFor(order.MilestoneID, order.OptionID).book
 
 		if order.Side == models.OrderSideBuy {
 			heap.Push(orderBook.BuyOrders, &order)
@@ -687,15 +673,19 @@ func (me *MatchingEngine) loadExistingOrders() error {
 		}
 
 		orderBook.orderIndex[order.ID] = &order
-		orderBook.mutex.Unlock()
 	}
 
+	// 로드한 호가를 증분 계산 기준점으로 (시퀀스는 0부터)
+	me.eachActor(func(actor *marketActor) {
+		actor.book.resetPublishedLevels()
+	})
+
 	log.Printf("📊 Loaded %d existing orders into matching engine", len(orders))
 	return nil
 }
 
 // isTableNotExistsError 테이블이 존재하지 않는 오류인지 확인
-func (me *MatchingEngine) isTableNotExistsError(err error) bool {
+func (me *LocalMatchingEngine) isTableNotExistsError(err error) bool {
 	if err == nil {
 		return false
 	}
@@ -711,470 +701,7 @@ func (me *MatchingEngine) isTableNotExistsError(err error) bool {
 			strings.Contains(errStr, `no such table: orders`)))
 }
 
-func (me *MatchingEngine) persistTrades(trades []models.Trade) {
-	for _, trade := range trades {
-		if err := me.db.Create(&trade).Error; err != nil {
-			log.Printf("❌ Failed to persist trade: %v", err)
-		}
-	}
-}
-
-func (me *MatchingEngine) broadcastTrades(trades []models.Trade) {
-	for _, trade := range trades {
-		// Redis 브로드캐스트 (기존)
-		redis.BroadcastTradeUpdate(trade.MilestoneID, trade.OptionID, trade)
-		redis.BroadcastPriceChange(trade.MilestoneID, trade.OptionID, trade.Price)
-
-		// SSE 실시간 브로드캐스트 (신규 추가)
-		if me.sseService != nil {
-			// 거래 이벤트 브로드캐스트
-			me.sseService.BroadcastTradeUpdate(trade.MilestoneID, trade.OptionID, map[string]interface{}{
-				"trade_id":     trade.ID,
-				"option_id":    trade.OptionID,
-				"buyer_id":     trade.BuyerID,
-				"seller_id":    trade.SellerID,
-				"quantity":     trade.Quantity,
-				"price":        trade.Price,
-				"total_amount": trade.TotalAmount,
-				"timestamp":    trade.CreatedAt.Unix(),
-			})
-
-			// 가격 변동 브로드캐스트
-			me.sseService.BroadcastPriceChange(trade.MilestoneID, trade.OptionID, 0, trade.Price)
-
-			// Order Book 업데이트 브로드캐스트
-			orderBook := me.getOrCreateOrderBook(trade.MilestoneID, trade.OptionID)
-			me.broadcastOrderBookUpdate(orderBook, trade.MilestoneID, trade.OptionID)
-		}
-
-		// 큐에 작업 추가
-		me.queuePublisher.EnqueueTradeWork(trade.MilestoneID, trade.OptionID, queue.TradeEventData{
-			TradeID:     trade.ID,
-			BuyerID:     trade.BuyerID,
-			SellerID:    trade.SellerID,
-			Quantity:    trade.Quantity,
-			Price:       trade.Price,
-			TotalAmount: trade.TotalAmount,
-		})
-	}
-}
-
-func (me *MatchingEngine) updateMarketCache(milestoneID uint, optionID string, trades []models.Trade) {
-	// Redis 캐시 업데이트
-	if len(trades) > 0 {
-		lastTrade := trades[len(trades)-1]
-		redis.SetMarketPrice(milestoneID, optionID, lastTrade.Price)
-		redis.SetRecentTrades(milestoneID, optionID, trades)
-	}
-}
-
-// broadcastOrderBookUpdate Order Book 변경사항을 SSE로 브로드캐스트
-func (me *MatchingEngine) broadcastOrderBookUpdate(orderBook *OrderBookEngine, milestoneID uint, optionID string) {
-	if me.sseService == nil {
-		return
-	}
-
-	orderBook.mutex.RLock()
-	defer orderBook.mutex.RUnlock()
-
-	// 상위 5개 매수/매도 주문 추출
-	buyOrders := make([]map[string]interface{}, 0, 5)
-	sellOrders := make([]map[string]interface{}, 0, 5)
-
-	// 매수 주문 (높은 가격순)
-	buyCount := 0
-	for i := 0; i < orderBook.BuyOrders.Len() && buyCount < 5; i++ {
-		order := (*orderBook.BuyOrders)[i]
-		if order.Remaining > 0 {
-			buyOrders = append(buyOrders, map[string]interface{}{
-				"price":    order.Price,
-				"quantity": order.Remaining,
-			})
-			buyCount++
-		}
-	}
-
-	// 매도 주문 (낮은 가격순)
-	sellCount := 0
-	for i := 0; i < orderBook.SellOrders.Len() && sellCount < 5; i++ {
-		order := (*orderBook.SellOrders)[i]
-		if order.Remaining > 0 {
-			sellOrders = append(sellOrders, map[string]interface{}{
-				"price":    order.Price,
-				"quantity": order.Remaining,
-			})
-			sellCount++
-		}
-	}
-
-	orderBookData := map[string]interface{}{
-		"milestone_id": milestoneID,
-		"option_id":    optionID,
-		"buy_orders":   buyOrders,
-		"sell_orders":  sellOrders,
-	}
-
-	me.sseService.BroadcastOrderBookUpdate(milestoneID, optionID, orderBookData)
-}
-
-// updateMarketData MarketData 테이블 업데이트
-func (me *MatchingEngine) updateMarketData(milestoneID uint, optionID string, trades []models.Trade) {
-	if len(trades) == 0 {
-		return
-	}
-
-	// 최신 거래 정보
-	lastTrade := trades[len(trades)-1]
-	newPrice := lastTrade.Price
-	tradeTime := lastTrade.CreatedAt
-
-	// 기존 MarketData 조회
-	var marketData models.MarketData
-	err := me.db.Where("milestone_id = ? AND option_id = ?", milestoneID, optionID).First(&marketData).Error
-
-	if err != nil {
-		// MarketData가 없으면 새로 생성
-		marketData = models.MarketData{
-			MilestoneID:   milestoneID,
-			OptionID:      optionID,
-			CurrentPrice:  newPrice,
-			PreviousPrice: newPrice,
-			HighPrice24h:  newPrice,
-			LowPrice24h:   newPrice,
-			LastTradeTime: tradeTime,
-		}
-	} else {
-		// 기존 데이터 업데이트
-		marketData.PreviousPrice = marketData.CurrentPrice
-		marketData.CurrentPrice = newPrice
-		marketData.LastTradeTime = tradeTime
-
-		// 24시간 고가/저가 업데이트
-		if newPrice > marketData.HighPrice24h {
-			marketData.HighPrice24h = newPrice
-		}
-		if newPrice < marketData.LowPrice24h || marketData.LowPrice24h == 0 {
-			marketData.LowPrice24h = newPrice
-		}
-
-		// 24시간 변동폭 계산 (24시간 전 가격과 비교)
-		var price24hAgo float64
-		me.db.Model(&models.Trade{}).
-			Where("milestone_id = ? AND option_id = ? AND created_at <= ?",
-				milestoneID, optionID, tradeTime.Add(-24*time.Hour)).
-			Order("created_at DESC").
-			Limit(1).
-			Pluck("price", &price24hAgo)
-
-		if price24hAgo > 0 {
-			marketData.Change24h = newPrice - price24hAgo
-			marketData.ChangePercent = (marketData.Change24h / price24hAgo) * 100
-		} else {
-			// 24시간 전 데이터가 없으면 현재 가격 기준
-			marketData.Change24h = 0
-			marketData.ChangePercent = 0
-		}
-	}
-
-	// 24시간 거래량 및 거래 수 계산
-	var volume24h int64
-	var trades24h int
-
-	me.db.Model(&models.Trade{}).
-		Where("milestone_id = ? AND option_id = ? AND created_at > ?",
-			milestoneID, optionID, tradeTime.Add(-24*time.Hour)).
-		Select("COALESCE(SUM(quantity), 0) as volume, COUNT(*) as trades").
-		Row().Scan(&volume24h, &trades24h)
-
-	marketData.Volume24h = volume24h
-	marketData.Trades24h = trades24h
-
-	// 현재 호가창에서 BidPrice, AskPrice, Spread 계산
-	orderBook := me.getOrCreateOrderBook(milestoneID, optionID)
-	orderBook.mutex.RLock()
-
-	if orderBook.BuyOrders.Len() > 0 {
-		marketData.BidPrice = (*orderBook.BuyOrders)[0].Price
-	}
-	if orderBook.SellOrders.Len() > 0 {
-		marketData.AskPrice = (*orderBook.SellOrders)[0].Price
-	}
-	if marketData.BidPrice > 0 && marketData.AskPrice > 0 {
-		marketData.Spread = marketData.AskPrice - marketData.BidPrice
-	}
-
-	orderBook.mutex.RUnlock()
-	marketData.UpdatedAt = time.Now()
-
-	// 데이터베이스에 저장
-	if marketData.ID == 0 {
-		err = me.db.Create(&marketData).Error
-	} else {
-		err = me.db.Save(&marketData).Error
-	}
-
-	if err != nil {
-		log.Printf("❌ Failed to update market data for %d:%s: %v", milestoneID, optionID, err)
-	} else {
-		log.Printf("📊 Updated market data for %d:%s: price %.4f, volume %d",
-			milestoneID, optionID, newPrice, volume24h)
-	}
-}
-
-// updateUserPositions 사용자 포지션 업데이트
-func (me *MatchingEngine) updateUserPositions(trades []models.Trade) {
-	for _, trade := range trades {
-		// 매수자 포지션 업데이트 (+수량)
-		me.updateSinglePosition(trade.BuyerID, trade.ProjectID, trade.MilestoneID,
-			trade.OptionID, trade.Quantity, trade.Price, trade.TotalAmount, true)
-
-		// 매도자 포지션 업데이트 (-수량)
-		me.updateSinglePosition(trade.SellerID, trade.ProjectID, trade.MilestoneID,
-			trade.OptionID, -trade.Quantity, trade.Price, trade.TotalAmount, false)
-	}
-}
-
-// updateSinglePosition 개별 사용자 포지션 업데이트
-func (me *MatchingEngine) updateSinglePosition(userID, projectID, milestoneID uint,
-	optionID string, quantity int64, price float64, totalAmount int64, isBuy bool) {
-
-	// 기존 포지션 조회
-	var position models.Position
-	err := me.db.Where("user_id = ? AND project_id = ? AND milestone_id = ? AND option_id = ?",
-		userID, projectID, milestoneID, optionID).First(&position).Error
-
-	if err != nil {
-		// 새로운 포지션 생성
-		if isBuy {
-			position = models.Position{
-				UserID:      userID,
-				ProjectID:   projectID,
-				MilestoneID: milestoneID,
-				OptionID:    optionID,
-				Quantity:    quantity,
-				AvgPrice:    price,
-				TotalCost:   totalAmount,
-				Realized:    0,
-				Unrealized:  0,
-				UpdatedAt:   time.Now(),
-			}
-		} else {
-			// 매도인데 기존 포지션이 없으면 숏포지션 생성
-			position = models.Position{
-				UserID:      userID,
-				ProjectID:   projectID,
-				MilestoneID: milestoneID,
-				OptionID:    optionID,
-				Quantity:    quantity, // 음수
-				AvgPrice:    price,
-				TotalCost:   -totalAmount, // 매도로 인한 수익
-				Realized:    0,
-				Unrealized:  0,
-				UpdatedAt:   time.Now(),
-			}
-		}
-
-		err = me.db.Create(&position).Error
-		if err != nil {
-			log.Printf("❌ Failed to create position for user %d: %v", userID, err)
-		} else {
-			log.Printf("🆕 Created new position for user %d: %s %d@%.4f",
-				userID, optionID, quantity, price)
-		}
-	} else {
-		// 기존 포지션 업데이트
-		oldQuantity := position.Quantity
-		newQuantity := oldQuantity + quantity
-
-		if isBuy {
-			// 매수: 평균단가 재계산
-			if newQuantity > 0 {
-				// 순매수 포지션
-				totalValue := float64(position.TotalCost) + float64(totalAmount)
-				position.AvgPrice = totalValue / float64(newQuantity)
-				position.TotalCost += totalAmount
-			} else if newQuantity == 0 {
-				// 포지션 완전 청산
-				position.Realized += totalAmount - int64(float64(quantity)*position.AvgPrice)
-				position.AvgPrice = 0
-				position.TotalCost = 0
-			} else {
-				// 일부 청산 (숏포지션으로 전환)
-				realizedPnL := int64(float64(oldQuantity) * (price - position.AvgPrice))
-				position.Realized += realizedPnL
-				position.AvgPrice = price
-				position.TotalCost = int64(float64(newQuantity) * price)
-			}
-		} else {
-			// 매도: 실현손익 계산
-			if oldQuantity > 0 {
-				// 기존 매수 포지션에서 매도
-				sellQuantity := -quantity
-				realizedPnL := int64(float64(sellQuantity) * (price - position.AvgPrice))
-				position.Realized += realizedPnL
-
-				if newQuantity > 0 {
-					// 일부 매도
-					position.TotalCost = int64(float64(newQuantity) * position.AvgPrice)
-				} else if newQuantity == 0 {
-					// 전량 매도
-					position.AvgPrice = 0
-					position.TotalCost = 0
-				} else {
-					// 과매도 (숏포지션)
-					position.AvgPrice = price
-					position.TotalCost = int64(float64(newQuantity) * price)
-				}
-			} else {
-				// 기존 숏포지션에서 추가 매도 또는 신규 숏매도
-				if oldQuantity == 0 {
-					// 신규 숏매도
-					position.AvgPrice = price
-					position.TotalCost = int64(float64(newQuantity) * price)
-				} else {
-					// 기존 숏포지션에 추가
-					totalValue := float64(position.TotalCost) + float64(totalAmount)
-					position.AvgPrice = totalValue / float64(newQuantity)
-					position.TotalCost += totalAmount
-				}
-			}
-		}
-
-		position.Quantity = newQuantity
-		position.UpdatedAt = time.Now()
-
-		// 미실현 손익 계산 (현재 시장가 기준)
-		if newQuantity != 0 {
-			currentPrice := me.getCurrentMarketPrice(milestoneID, optionID)
-			if currentPrice > 0 {
-				position.Unrealized = int64(float64(newQuantity) * (currentPrice - position.AvgPrice))
-			}
-		} else {
-			position.Unrealized = 0
-		}
-
-		err = me.db.Save(&position).Error
-		if err != nil {
-			log.Printf("❌ Failed to update position for user %d: %v", userID, err)
-		} else {
-			log.Printf("🔄 Updated position for user %d: %s %d@%.4f (realized: %d)",
-				userID, optionID, newQuantity, position.AvgPrice, position.Realized)
-		}
-	}
-}
-
-// getCurrentMarketPrice 현재 시장가 조회
-func (me *MatchingEngine) getCurrentMarketPrice(milestoneID uint, optionID string) float64 {
-	orderBook := me.getOrCreateOrderBook(milestoneID, optionID)
-	orderBook.mutex.RLock()
-	defer orderBook.mutex.RUnlock()
-
-	// 마지막 체결가가 있으면 사용
-	if orderBook.lastPrice > 0 {
-		return orderBook.lastPrice
-	}
-
-	// 호가창 중간값 사용
-	if orderBook.BuyOrders.Len() > 0 && orderBook.SellOrders.Len() > 0 {
-		bidPrice := (*orderBook.BuyOrders)[0].Price
-		askPrice := (*orderBook.SellOrders)[0].Price
-		return (bidPrice + askPrice) / 2
-	}
-
-	// 기본값 (초기 확률)
-	return 0.33 // 33¢
-}
-
-// updateUserWallets 사용자 지갑 잔액 업데이트
-func (me *MatchingEngine) updateUserWallets(trades []models.Trade) {
-	for _, trade := range trades {
-		// 매수자 지갑 업데이트: USDC 차감, LockedBalance 감소
-		me.updateBuyerWallet(trade.BuyerID, trade.TotalAmount, trade.BuyerFee)
-
-		// 매도자 지갑 업데이트: USDC 증가, LockedBalance 감소
-		me.updateSellerWallet(trade.SellerID, trade.TotalAmount, trade.SellerFee)
-	}
-}
-
-// updateBuyerWallet 매수자 지갑 업데이트
-func (me *MatchingEngine) updateBuyerWallet(buyerID uint, totalAmount, fee int64) {
-	var wallet models.UserWallet
-	err := me.db.Where("user_id = ?", buyerID).First(&wallet).Error
-
-	if err != nil {
-		log.Printf("❌ Failed to find buyer wallet for user %d: %v", buyerID, err)
-		return
-	}
-
-	// 잠긴 잔액에서 거래금액 차감, 수수료는 일반 잔액에서 차감
-	if wallet.USDCLockedBalance >= totalAmount {
-		wallet.USDCLockedBalance -= totalAmount
-		wallet.USDCBalance -= fee // 수수료는 일반 잔액에서 차감
-	} else {
-		log.Printf("⚠️ Insufficient locked balance for buyer %d: locked=%d, needed=%d",
-			buyerID, wallet.USDCLockedBalance, totalAmount)
-		// 부족하면 일반 잔액에서 모두 차감
-		remaining := totalAmount - wallet.USDCLockedBalance
-		wallet.USDCLockedBalance = 0
-		wallet.USDCBalance -= (remaining + fee)
-	}
-
-	// 통계 업데이트
-	wallet.TotalUSDCFees += fee
-	wallet.TotalTrades++
-	wallet.UpdatedAt = time.Now()
-
-	err = me.db.Save(&wallet).Error
-	if err != nil {
-		log.Printf("❌ Failed to update buyer wallet for user %d: %v", buyerID, err)
-	} else {
-		log.Printf("💰 Updated buyer wallet for user %d: paid %d USDC (fee: %d)",
-			buyerID, totalAmount, fee)
-	}
-}
-
-// updateSellerWallet 매도자 지갑 업데이트
-func (me *MatchingEngine) updateSellerWallet(sellerID uint, totalAmount, fee int64) {
-	var wallet models.UserWallet
-	err := me.db.Where("user_id = ?", sellerID).First(&wallet).Error
-
-	if err != nil {
-		log.Printf("❌ Failed to find seller wallet for user %d: %v", sellerID, err)
-		return
-	}
-
-	// 매도 수익 추가 (수수료 제외)
-	netProceeds := totalAmount - fee
-	wallet.USDCBalance += netProceeds
-
-	// 통계 업데이트
-	wallet.TotalUSDCProfit += netProceeds
-	wallet.TotalUSDCFees += fee
-	wallet.TotalTrades++
-	wallet.UpdatedAt = time.Now()
-
-	err = me.db.Save(&wallet).Error
-	if err != nil {
-		log.Printf("❌ Failed to update seller wallet for user %d: %v", sellerID, err)
-	} else {
-		log.Printf("💰 Updated seller wallet for user %d: received %d USDC (fee: %d)",
-			sellerID, netProceeds, fee)
-	}
-}
-
-func (me *MatchingEngine) updateStats(processingTime time.Duration) {
-	me.mutex.Lock()
-	defer me.mutex.Unlock()
-
-	me.stats.OrdersProcessed++
-	me.stats.TotalMatches++
-	me.stats.LastMatchTime = time.Now()
-
-	// 이동 평균으로 평균 매칭 시간 계산
-	me.stats.AvgMatchTime = (me.stats.AvgMatchTime * 0.95) + (processingTime.Seconds() * 1000 * 0.05)
-}
-
-func (me *MatchingEngine) statsWorker() {
+func (me *LocalMatchingEngine) statsWorker() {
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
 
@@ -1188,89 +715,97 @@ func (me *MatchingEngine) statsWorker() {
 	}
 }
 
-func (me *MatchingEngine) printStats() {
-	me.mutex.RLock()
-	defer me.mutex.RUnlock()
+func (me *LocalMatchingEngine) printStats() {
+	stats := me.GetStats()
 
 	log.Printf("🔥 Matching Engine Stats:")
-	log.Printf("   Orders Processed: %d", me.stats.OrdersProcessed)
-	log.Printf("   Total Matches: %d", me.stats.TotalMatches)
-	log.Printf("   Avg Match Time: %.2fms", me.stats.AvgMatchTime)
-	log.Printf("   Active Order Books: %d", len(me.orderBooks))
-	log.Printf("   Uptime: %v", time.Since(me.stats.StartTime))
-}
-
-// GetStats 통계 조회
-func (me *MatchingEngine) GetStats() MatchingStats {
-	me.mutex.RLock()
-	defer me.mutex.RUnlock()
-	return me.stats
-}
-
-// GetOrderBook 주문장 조회
-func (me *MatchingEngine) GetOrderBook(milestoneID uint, optionID string) *models.OrderBook {
-	key := me.getMarketKey(milestoneID, optionID)
-
-	me.mutex.RLock()
-	orderBookEngine, exists := me.orderBooks[key]
-	me.mutex.RUnlock()
-
-	if !exists {
-		return &models.OrderBook{
-			MilestoneID: milestoneID,
-			OptionID:    optionID,
-			Bids:        []models.OrderBookLevel{},
-			Asks:        []models.OrderBookLevel{},
-			LastUpdate:  time.Now(),
+	log.Printf("   Orders Processed: %d", stats.OrdersProcessed)
+	log.Printf("   Total Matches: %d", stats.TotalMatches)
+	log.Printf("   Avg Match Time: %.2fms", stats.AvgMatchTime)
+	log.Printf("   Cancels: %d (avg %.2fms, max %.2fms, SLA breaches %d)",
+		stats.CancelsProcessed, stats.AvgCancelLatency, stats.MaxCancelLatency, stats.CancelSLABreaches)
+	log.Printf("   Active Order Books: %d", stats.ActiveOrderBooks)
+	log.Printf("   Uptime: %v", time.Since(stats.StartTime))
+}
+
+// GetStats 통계 조회 (시장별 통계 합산, 평균은 처리 건수 가중)
+func (me *LocalMatchingEngine) GetStats() MatchingStats {
+	stats := MatchingStats{StartTime: me.startTime}
+
+	var matchTimeSum, cancelLatencySum float64
+	me.eachActor(func(actor *marketActor) {
+		actor.statsMutex.Lock()
+		market := actor.stats
+		actor.statsMutex.Unlock()
+
+		stats.ActiveOrderBooks++
+		stats.OrdersProcessed += market.OrdersProcessed
+		stats.TotalMatches += market.TotalMatches
+		stats.CancelsProcessed += market.CancelsProcessed
+		stats.CancelSLABreaches += market.CancelSLABreaches
+		stats.PendingOrders += len(actor.orders)
+		stats.PendingCancels += len(actor.cancels)
+		matchTimeSum += market.AvgMatchTime * float64(market.OrdersProcessed)
+		cancelLatencySum += market.AvgCancelLatency * float64(market.CancelsProcessed)
+		if market.MaxCancelLatency > stats.MaxCancelLatency {
+			stats.MaxCancelLatency = market.MaxCancelLatency
 		}
-	}
-
-	orderBookEngine.mutex.RLock()
-	defer orderBookEngine.mutex.RUnlock()
-
-	// 매수 호가 생성
-	bids := make([]models.OrderBookLevel, 0)
-	bidPrices := make(map[float64]int64)
-
-	for _, order := range *orderBookEngine.BuyOrders {
-		if order.Remaining > 0 {
-			bidPrices[order.Price] += order.Remaining
+		if market.LastMatchTime.After(stats.LastMatchTime) {
+			stats.LastMatchTime = market.LastMatchTime
 		}
+	})
+
+	if stats.OrdersProcessed > 0 {
+		stats.AvgMatchTime = matchTimeSum / float64(stats.OrdersProcessed)
+	}
+	if stats.CancelsProcessed > 0 {
+		stats.AvgCancelLatency = cancelLatencySum / float64(stats.CancelsProcessed)
 	}
+	return stats
+}
 
-	for price, quantity := range bidPrices {
-		bids = append(bids, models.OrderBookLevel{
-			Price:    price,
-			Quantity: quantity,
-			Count:    1,
-		})
+// GetOrderBook 주문장 조회 (가격대별 집계, 최우선 호가부터 depth개)
+func (me *LocalMatchingEngine) GetOrderBook(milestoneID uint, optionID string, depth int, aggregation float64) *models.OrderBook {
+	empty := &models.OrderBook{
+		MilestoneID: milestoneID,
+		OptionID:    optionID,
+		Bids:        []models.OrderBookLevel{},
+		Asks:        []models.OrderBookLevel{},
+		Depth:       depth,
+		Aggregation: aggregation,
+		LastUpdate:  time.Now(),
 	}
 
-	// 매도 호가 생성
-	asks := make([]models.OrderBookLevel, 0)
-	askPrices := make(map[float64]int64)
+	actor, exists := me.lookupActor(milestoneID, optionID)
+	if !exists {
+		return empty
+	}
 
-	for _, order := range *orderBookEngine.SellOrders {
-		if order.Remaining > 0 {
-			askPrices[order.Price] += order.Remaining
-		}
+	var snapshot *models.OrderBook
+	if !me.withBook(actor, func(orderBook *OrderBookEngine) {
+		snapshot = orderBook.snapshot(depth, aggregation)
+	}) {
+		return empty
 	}
+	return snapshot
+}
 
-	for price, quantity := range askPrices {
-		asks = append(asks, models.OrderBookLevel{
-			Price:    price,
-			Quantity: quantity,
-			Count:    1,
+// RestingOrders 모든 시장 액터의 주문장에 남은 주문
+func (me *LocalMatchingEngine) RestingOrders() []RestingOrder {
+	var orders []RestingOrder
+	me.eachActor(func(actor *marketActor) {
+		me.withBook(actor, func(orderBook *OrderBookEngine) {
+			for _, order := range orderBook.orderIndex {
+				orders = append(orders, newRestingOrder(order))
+			}
 		})
-	}
+	})
+	return orders
+}
 
-	return &models.OrderBook{
-		MilestoneID: milestoneID,
-		OptionID:    optionID,
-		Bids:        bids,
-		Asks:        asks,
-		LastUpdate:  time.Now(),
-	}
+// ManagesMarket 단일 노드는 모든 마켓을 매칭
+func (me *LocalMatchingEngine) ManagesMarket(uint, string) bool {
+	return true
 }
 
 func min(a, b int64) int64 {
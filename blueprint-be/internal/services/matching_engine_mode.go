@@ -0,0 +1,89 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// 매칭 엔진 실행 방식 (MATCHING_ENGINE_MODE)
+const (
+	MatchingModeLocal       = "local"       // 단일 서버, 주문장을 메모리에 보관
+	MatchingModeDistributed = "distributed" // 여러 서버, 마켓별 담당 인스턴스가 Redis 주문장으로 매칭
+)
+
+// MatchingEngine 거래 서비스가 사용하는 매칭 엔진
+//
+// 단일 노드(LocalMatchingEngine)와 분산(DistributedMatchingEngine) 구현은 같은 수수료·지갑·포지션·
+// 멘토 풀 후처리(tradePipeline)를 거치므로 어느 쪽을 써도 체결 결과가 같다.
+type MatchingEngine interface {
+	Start() error
+	Stop() error
+
+	// SubmitOrder 주문 매칭 (미체결 잔량은 주문장에 등록)
+	SubmitOrder(order *models.Order) (*MatchingResult, error)
+	// CancelOrder 주문장에서 주문 제거 (DB 상태 변경은 호출자가 처리)
+	// 제거를 확인하지 못하면 ErrCancelNotConfirmed (주문은 주문장에 남아 계속 체결될 수 있음)
+	CancelOrder(order *models.Order) error
+	// FlushOrderStates 반영 대기 중인 주문 체결 상태를 DB에 기록하고 건수 반환
+	FlushOrderStates() int
+	// WaitForSettlement 진행 중인 체결 후처리(지갑/포지션/브로드캐스트 등) 완료 대기
+	WaitForSettlement(timeout time.Duration) bool
+
+	GetOrderBook(milestoneID uint, optionID string, depth int, aggregation float64) *models.OrderBook
+	// RestingOrders 이 인스턴스가 매칭하는 주문장에 남은 주문 전체 (대사용 복사본)
+	RestingOrders() []RestingOrder
+	// ManagesMarket 이 인스턴스가 해당 마켓의 주문장을 갖고 있는지 (분산 모드는 담당 마켓만)
+	ManagesMarket(milestoneID uint, optionID string) bool
+	GetStats() MatchingStats
+	CircuitBreaker() *CircuitBreakerService
+	TradingPause() *TradingPauseService
+	// SetFeatureFlags 기능 플래그 연결 (new_fee_tiers 수수료, 시작 전에 호출)
+	SetFeatureFlags(flags FeatureChecker)
+	// AddTradeListener 저장된 체결 구독자 추가 (복사 거래, 강제 청산, 주문 접수 전에 호출)
+	AddTradeListener(listener TradeListener)
+}
+
+// RestingOrder 주문장에 남아 있는 주문 (대사용 복사본)
+type RestingOrder struct {
+	OrderID     uint
+	MilestoneID uint
+	OptionID    string
+	Side        models.OrderSide
+	Remaining   int64
+	CreatedAt   time.Time
+}
+
+func newRestingOrder(order *models.Order) RestingOrder {
+	return RestingOrder{
+		OrderID:     order.ID,
+		MilestoneID: order.MilestoneID,
+		OptionID:    order.OptionID,
+		Side:        order.Side,
+		Remaining:   order.Remaining,
+		CreatedAt:   order.CreatedAt,
+	}
+}
+
+var (
+	_ MatchingEngine = (*LocalMatchingEngine)(nil)
+	_ MatchingEngine = (*DistributedMatchingEngine)(nil)
+)
+
+// NewMatchingEngine 설정된 방식의 매칭 엔진 생성 (빈 값은 local)
+func NewMatchingEngine(mode string, db *gorm.DB, sseService *SSEService, fundingService *FundingVerificationService, mentorQualificationSvc *MentorQualificationService) (MatchingEngine, error) {
+	switch mode {
+	case "", MatchingModeLocal:
+		return NewLocalMatchingEngine(db, sseService, fundingService, mentorQualificationSvc), nil
+	case MatchingModeDistributed:
+		engine := NewDistributedMatchingEngine(db, sseService)
+		engine.fundingService = fundingService
+		engine.mentorQualificationSvc = mentorQualificationSvc
+		return engine, nil
+	default:
+		return nil, fmt.Errorf("알 수 없는 매칭 엔진 모드: %s (local 또는 distributed)", mode)
+	}
+}
@@ -0,0 +1,691 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// 🧪 매칭 엔진 시뮬레이션/백테스트
+//
+// 과거 주문 흐름이나 합성 주문 흐름을 매칭 엔진에 그대로 흘려 보내면서 주문 접수 지연 시간, 체결률,
+// 불변식 위반(음수 잔액, 사라진 수량, 교차된 호가 등)을 측정한다. 지갑은 TradingService와 같은 규칙
+// (매수 주문 잠금 → 체결 정산 → 취소 환불)으로 메모리에서 따로 계산하므로 엔진 후처리의 비동기 DB 반영과
+// 무관하게 같은 흐름은 항상 같은 결과를 낸다.
+
+// SimActionType 시뮬레이션 동작 종류
+type SimActionType string
+
+const (
+	SimActionPlace  SimActionType = "place"  // 지정가 주문 접수
+	SimActionCancel SimActionType = "cancel" // 앞서 접수한 주문 취소
+)
+
+// SimAction 주문 흐름의 동작 하나
+type SimAction struct {
+	Type       SimActionType    `json:"type"`
+	UserID     uint             `json:"user_id,omitempty"`
+	Side       models.OrderSide `json:"side,omitempty"`
+	PriceTicks int64            `json:"price_ticks,omitempty"`
+	Quantity   int64            `json:"quantity,omitempty"`
+	Target     int              `json:"target,omitempty"` // 취소 대상 place 동작의 인덱스
+	At         time.Time        `json:"at,omitempty"`     // 과거 흐름의 원래 시각 (정렬용)
+}
+
+// SimulationFlow 시뮬레이션에 넣을 주문 흐름
+type SimulationFlow struct {
+	Source      string
+	MilestoneID uint
+	OptionID    string
+	Actions     []SimAction
+
+	// 과거 흐름일 때 실제로 체결된 거래 (시뮬레이션 결과와 비교용)
+	ReferenceTrades int64
+	ReferenceVolume int64
+}
+
+// SyntheticFlowConfig 합성 주문 흐름 설정
+type SyntheticFlowConfig struct {
+	Orders      int     // 생성할 주문 수
+	Users       int     // 주문을 나눠 낼 사용자 수
+	Seed        int64   // 난수 시드 (같은 시드는 같은 흐름)
+	MidPrice    float64 // 시작 중간 가격 (0-1)
+	Spread      float64 // 중간 가격에서 주문 가격이 벗어날 수 있는 최대 폭
+	Volatility  float64 // 주문마다 중간 가격이 움직이는 최대 폭
+	MinQuantity int64
+	MaxQuantity int64
+	CancelRate  float64 // 주문 하나를 낸 뒤 열린 주문 하나를 취소할 확률
+}
+
+// DefaultSyntheticFlowConfig 기본 합성 흐름: 사용자 20명, 50¢ 근처 ±5¢, 주문 10%는 취소가 뒤따름
+var DefaultSyntheticFlowConfig = SyntheticFlowConfig{
+	Orders:      10000,
+	Users:       20,
+	Seed:        1,
+	MidPrice:    0.5,
+	Spread:      0.05,
+	Volatility:  0.01,
+	MinQuantity: 1,
+	MaxQuantity: 100,
+	CancelRate:  0.1,
+}
+
+// SyntheticOrderFlow 중간 가격이 무작위로 움직이는 합성 주문 흐름 생성 (가격은 기본 호가 단위 1¢에 맞춤)
+func SyntheticOrderFlow(milestoneID uint, optionID string, config SyntheticFlowConfig) *SimulationFlow {
+	if config.Users <= 0 {
+		config.Users = 1
+	}
+	if config.MinQuantity <= 0 {
+		config.MinQuantity = 1
+	}
+	if config.MaxQuantity < config.MinQuantity {
+		config.MaxQuantity = config.MinQuantity
+	}
+
+	rng := rand.New(rand.NewSource(config.Seed))
+	tick := models.DefaultPriceTickSize
+	minTicks, maxTicks := tick, models.PriceScale-tick
+	clamp := func(ticks int64) int64 {
+		ticks = ticks / tick * tick
+		return max(minTicks, min(maxTicks, ticks))
+	}
+	randomTicks := func(width float64) int64 {
+		steps := models.PriceToTicks(width) / tick
+		if steps <= 0 {
+			return 0
+		}
+		return (rng.Int63n(2*steps+1) - steps) * tick
+	}
+
+	flow := &SimulationFlow{Source: "synthetic", MilestoneID: milestoneID, OptionID: optionID}
+	mid := clamp(models.PriceToTicks(config.MidPrice))
+	var placed []int
+	for i := 0; i < config.Orders; i++ {
+		mid = clamp(mid + randomTicks(config.Volatility))
+
+		side := models.OrderSideBuy
+		if rng.Intn(2) == 1 {
+			side = models.OrderSideSell
+		}
+		placed = append(placed, len(flow.Actions))
+		flow.Actions = append(flow.Actions, SimAction{
+			Type:       SimActionPlace,
+			UserID:     uint(rng.Intn(config.Users) + 1),
+			Side:       side,
+			PriceTicks: clamp(mid + randomTicks(config.Spread)),
+			Quantity:   config.MinQuantity + rng.Int63n(config.MaxQuantity-config.MinQuantity+1),
+		})
+
+		if len(placed) > 0 && rng.Float64() < config.CancelRate {
+			pick := rng.Intn(len(placed))
+			flow.Actions = append(flow.Actions, SimAction{Type: SimActionCancel, Target: placed[pick]})
+			placed = append(placed[:pick], placed[pick+1:]...)
+		}
+	}
+	return flow
+}
+
+// HistoricalOrderFlow 마켓의 과거 주문을 접수 순서대로 불러와 주문 흐름으로 변환
+//
+// 취소된 주문은 마지막 수정 시각에 취소 동작을 넣는다. 같은 기간 실제 체결은 비교 기준으로 함께 반환한다.
+func HistoricalOrderFlow(db *gorm.DB, milestoneID uint, optionID string, since time.Time) (*SimulationFlow, error) {
+	var orders []models.Order
+	if err := db.Where("milestone_id = ? AND option_id = ? AND created_at >= ? AND type = ?",
+		milestoneID, optionID, since, models.OrderTypeLimit).
+		Order("created_at ASC, id ASC").
+		Find(&orders).Error; err != nil {
+		return nil, fmt.Errorf("과거 주문 조회 실패: %w", err)
+	}
+
+	flow := &SimulationFlow{Source: "historical", MilestoneID: milestoneID, OptionID: optionID}
+	var cancels []SimAction
+	for _, order := range orders {
+		ticks := order.PriceTicks
+		if ticks == 0 {
+			ticks = models.PriceToTicks(order.Price)
+		}
+		if order.Status == models.OrderStatusCancelled {
+			cancels = append(cancels, SimAction{Type: SimActionCancel, Target: len(flow.Actions), At: order.UpdatedAt})
+		}
+		flow.Actions = append(flow.Actions, SimAction{
+			Type:       SimActionPlace,
+			UserID:     order.UserID,
+			Side:       order.Side,
+			PriceTicks: ticks,
+			Quantity:   order.Quantity,
+			At:         order.CreatedAt,
+		})
+	}
+
+	// 취소는 원래 시각 순서대로 주문 사이에 끼워 넣음 (대상 인덱스는 place 동작 기준이므로 병합 후 다시 매김)
+	if len(cancels) > 0 {
+		positions := make([]int, len(flow.Actions))
+		merged := make([]SimAction, 0, len(flow.Actions)+len(cancels))
+		all := append(append([]SimAction{}, flow.Actions...), cancels...)
+		sort.SliceStable(all, func(i, j int) bool { return all[i].At.Before(all[j].At) })
+		placeIndex := 0
+		for _, action := range all {
+			if action.Type == SimActionPlace {
+				positions[placeIndex] = len(merged)
+				placeIndex++
+			}
+			merged = append(merged, action)
+		}
+		for i := range merged {
+			if merged[i].Type == SimActionCancel {
+				merged[i].Target = positions[merged[i].Target]
+			}
+		}
+		flow.Actions = merged
+	}
+
+	var reference struct {
+		Trades int64
+		Volume int64
+	}
+	if err := db.Model(&models.Trade{}).
+		Select("COUNT(*) AS trades, COALESCE(SUM(quantity), 0) AS volume").
+		Where("milestone_id = ? AND option_id = ? AND created_at >= ?", milestoneID, optionID, since).
+		Scan(&reference).Error; err != nil {
+		return nil, fmt.Errorf("과거 체결 조회 실패: %w", err)
+	}
+	flow.ReferenceTrades = reference.Trades
+	flow.ReferenceVolume = reference.Volume
+	return flow, nil
+}
+
+// 불변식 위반 종류
+const (
+	ViolationNegativeBalance  = "negative_balance"   // 정산 후 사용 가능 잔액이 음수
+	ViolationNegativeLocked   = "negative_locked"    // 정산 후 잠긴 잔액이 음수
+	ViolationLostQuantity     = "lost_quantity"      // 체결 + 잔량 ≠ 주문 수량, 또는 주문장 잔량 불일치
+	ViolationOverfill         = "overfill"           // 주문 수량보다 많이 체결
+	ViolationPriceThrough     = "price_through"      // 지정가보다 불리한 가격에 체결
+	ViolationLockedMismatch   = "locked_mismatch"    // 잠긴 잔액 ≠ 열린 매수 주문 잠금액 합계
+	ViolationCashConservation = "cash_not_conserved" // 잔액 + 잠금 + 수수료 합계가 시작 잔액과 다름
+	ViolationCrossedBook      = "crossed_book"       // 최우선 매수호가 ≥ 최우선 매도호가
+)
+
+// simulationViolationKinds 보고서(CSV 열) 순서
+var simulationViolationKinds = []string{
+	ViolationNegativeBalance, ViolationNegativeLocked, ViolationLostQuantity, ViolationOverfill,
+	ViolationPriceThrough, ViolationLockedMismatch, ViolationCashConservation, ViolationCrossedBook,
+}
+
+// maxViolationSamples 보고서에 남기는 위반 상세 최대 건수 (건수 집계는 전부)
+const maxViolationSamples = 50
+
+// simulationSettleTimeout 재생 후 체결 후처리 완료를 기다리는 최대 시간
+const simulationSettleTimeout = time.Minute
+
+// SimulationViolation 불변식 위반 상세
+type SimulationViolation struct {
+	Kind    string `json:"kind"`
+	Action  int    `json:"action"` // 위반이 드러난 동작 인덱스 (-1: 종료 후 검사)
+	OrderID uint   `json:"order_id,omitempty"`
+	UserID  uint   `json:"user_id,omitempty"`
+	Detail  string `json:"detail"`
+}
+
+// SimulationLatency 지연 시간 분포 (밀리초)
+type SimulationLatency struct {
+	Mean float64 `json:"mean_ms"`
+	P50  float64 `json:"p50_ms"`
+	P95  float64 `json:"p95_ms"`
+	P99  float64 `json:"p99_ms"`
+	Max  float64 `json:"max_ms"`
+}
+
+// SimulationReport 시뮬레이션 결과
+type SimulationReport struct {
+	Source     string    `json:"source"`
+	Market     string    `json:"market"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMs float64   `json:"duration_ms"`
+	Throughput float64   `json:"orders_per_second"`
+
+	OrdersSubmitted   int     `json:"orders_submitted"`
+	OrdersAccepted    int     `json:"orders_accepted"`
+	OrdersRejected    int     `json:"orders_rejected"` // 잔액 부족
+	OrdersHalted      int     `json:"orders_halted"`   // 서킷브레이커
+	OrderErrors       int     `json:"order_errors"`    // 큐 포화/타임아웃 등 엔진 오류
+	Cancels           int     `json:"cancels"`
+	SubmittedQuantity int64   `json:"submitted_quantity"`
+	FilledQuantity    int64   `json:"filled_quantity"`
+	FillRate          float64 `json:"fill_rate"` // 접수된 주문 수량 중 체결 비율
+	FullyFilledOrders int     `json:"fully_filled_orders"`
+
+	Trades   int   `json:"trades"`
+	Volume   int64 `json:"volume"`
+	Notional int64 `json:"notional_cents"`
+	Fees     int64 `json:"fees_cents"`
+
+	SubmitLatency SimulationLatency `json:"submit_latency"`
+	CancelLatency SimulationLatency `json:"cancel_latency"`
+
+	// 재생이 끝난 뒤 밀린 체결 후처리(DB/Redis 반영)가 모두 끝나기까지 걸린 시간
+	SettlementDrainMs  float64 `json:"settlement_drain_ms"`
+	SettlementTimedOut bool    `json:"settlement_timed_out,omitempty"`
+
+	ReferenceTrades int64 `json:"reference_trades,omitempty"`
+	ReferenceVolume int64 `json:"reference_volume,omitempty"`
+
+	Violations       map[string]int        `json:"violations"`
+	TotalViolations  int                   `json:"total_violations"`
+	ViolationSamples []SimulationViolation `json:"violation_samples,omitempty"`
+}
+
+// SimulationCSVHeader CSV 출력 열 이름 (CSVRecord와 같은 순서)
+func SimulationCSVHeader() []string {
+	header := []string{
+		"started_at", "source", "market", "duration_ms", "orders_per_second",
+		"orders_submitted", "orders_accepted", "orders_rejected", "orders_halted", "order_errors", "cancels",
+		"fill_rate", "fully_filled_orders", "trades", "volume", "notional_cents", "fees_cents",
+		"submit_p50_ms", "submit_p95_ms", "submit_p99_ms", "submit_max_ms", "cancel_p99_ms", "settlement_drain_ms",
+		"reference_trades", "reference_volume", "total_violations",
+	}
+	return append(header, simulationViolationKinds...)
+}
+
+// CSVRecord 회귀 비교용 CSV 한 줄
+func (r *SimulationReport) CSVRecord() []string {
+	float := func(value float64) string { return strconv.FormatFloat(value, 'f', 4, 64) }
+	record := []string{
+		r.StartedAt.UTC().Format(time.RFC3339), r.Source, r.Market, float(r.DurationMs), float(r.Throughput),
+		strconv.Itoa(r.OrdersSubmitted), strconv.Itoa(r.OrdersAccepted), strconv.Itoa(r.OrdersRejected),
+		strconv.Itoa(r.OrdersHalted), strconv.Itoa(r.OrderErrors), strconv.Itoa(r.Cancels),
+		float(r.FillRate), strconv.Itoa(r.FullyFilledOrders), strconv.Itoa(r.Trades),
+		strconv.FormatInt(r.Volume, 10), strconv.FormatInt(r.Notional, 10), strconv.FormatInt(r.Fees, 10),
+		float(r.SubmitLatency.P50), float(r.SubmitLatency.P95), float(r.SubmitLatency.P99), float(r.SubmitLatency.Max),
+		float(r.CancelLatency.P99), float(r.SettlementDrainMs),
+		strconv.FormatInt(r.ReferenceTrades, 10), strconv.FormatInt(r.ReferenceVolume, 10), strconv.Itoa(r.TotalViolations),
+	}
+	for _, kind := range simulationViolationKinds {
+		record = append(record, strconv.Itoa(r.Violations[kind]))
+	}
+	return record
+}
+
+// SimulationConfig 시뮬레이터 설정
+type SimulationConfig struct {
+	InitialBalance int64 // 사용자별 시작 USDC 잔액 (센트)
+	CircuitBreaker bool  // false면 서킷브레이커를 끄고 매칭만 측정
+}
+
+// MatchingSimulator 주문 흐름을 매칭 엔진에 재생하며 지연 시간/체결률/불변식 위반 측정
+//
+// 엔진은 시뮬레이션 전용 DB로 만든 것을 넘겨야 한다 (체결 후처리가 엔진 DB에 거래/지갑/포지션을 기록).
+// Run은 체결 후처리가 끝날 때까지 기다린 뒤 반환한다.
+type MatchingSimulator struct {
+	engine MatchingEngine
+	config SimulationConfig
+}
+
+// NewMatchingSimulator 생성자
+func NewMatchingSimulator(engine MatchingEngine, config SimulationConfig) *MatchingSimulator {
+	return &MatchingSimulator{engine: engine, config: config}
+}
+
+// simWallet 시뮬레이터가 따로 계산하는 사용자 지갑 (센트)
+type simWallet struct {
+	balance int64
+	locked  int64
+}
+
+// simOrder 시뮬레이터가 추적하는 주문 (엔진이 들고 있는 주문 객체는 엔진 고루틴이 바꾸므로 읽지 않음)
+type simOrder struct {
+	order    *models.Order
+	quantity int64
+	filled   int64
+	open     bool
+}
+
+// simulationRun 한 번의 실행 상태
+type simulationRun struct {
+	report  *SimulationReport
+	wallets map[uint]*simWallet
+	orders  map[uint]*simOrder
+	placed  map[int]*simOrder // place 동작 인덱스 -> 주문
+	fees    int64
+}
+
+// Run 주문 흐름 재생 (엔진 시작/중지 포함)
+func (s *MatchingSimulator) Run(flow *SimulationFlow) (*SimulationReport, error) {
+	if !s.config.CircuitBreaker {
+		s.engine.CircuitBreaker().Configure(CircuitBreakerConfig{})
+	}
+	if err := s.engine.Start(); err != nil {
+		return nil, fmt.Errorf("매칭 엔진 시작 실패: %w", err)
+	}
+	defer s.engine.Stop()
+
+	run := &simulationRun{
+		report: &SimulationReport{
+			Source:          flow.Source,
+			Market:          fmt.Sprintf("%d:%s", flow.MilestoneID, flow.OptionID),
+			StartedAt:       time.Now(),
+			ReferenceTrades: flow.ReferenceTrades,
+			ReferenceVolume: flow.ReferenceVolume,
+			Violations:      make(map[string]int),
+		},
+		wallets: make(map[uint]*simWallet),
+		orders:  make(map[uint]*simOrder),
+		placed:  make(map[int]*simOrder),
+	}
+
+	var submitLatencies, cancelLatencies []time.Duration
+	var nextOrderID uint
+	started := time.Now()
+	for index, action := range flow.Actions {
+		switch action.Type {
+		case SimActionPlace:
+			nextOrderID++
+			latency, ok := s.place(run, flow, index, action, nextOrderID)
+			if ok {
+				submitLatencies = append(submitLatencies, latency)
+			}
+		case SimActionCancel:
+			if latency, ok := s.cancel(run, action); ok {
+				cancelLatencies = append(cancelLatencies, latency)
+			}
+		default:
+			return nil, fmt.Errorf("알 수 없는 시뮬레이션 동작: %s", action.Type)
+		}
+	}
+	elapsed := time.Since(started)
+
+	report := run.report
+	drainStarted := time.Now()
+	report.SettlementTimedOut = !s.engine.WaitForSettlement(simulationSettleTimeout)
+	report.SettlementDrainMs = durationMs(time.Since(drainStarted))
+
+	s.checkFinalState(run, flow)
+
+	report.DurationMs = durationMs(elapsed)
+	if elapsed > 0 {
+		report.Throughput = float64(report.OrdersSubmitted) / elapsed.Seconds()
+	}
+	if report.SubmittedQuantity > 0 {
+		report.FillRate = float64(report.FilledQuantity) / float64(report.SubmittedQuantity)
+	}
+	for _, order := range run.orders {
+		if order.filled == order.quantity {
+			report.FullyFilledOrders++
+		}
+	}
+	report.Fees = run.fees
+	report.SubmitLatency = latencyDistribution(submitLatencies)
+	report.CancelLatency = latencyDistribution(cancelLatencies)
+	return report, nil
+}
+
+// place TradingService.CreateOrder와 같은 순서로 잠금 → 엔진 제출 → 정산 (제출했으면 지연 시간 반환)
+func (s *MatchingSimulator) place(run *simulationRun, flow *SimulationFlow, index int, action SimAction, orderID uint) (time.Duration, bool) {
+	report := run.report
+	report.OrdersSubmitted++
+	wallet := run.wallet(action.UserID, s.config.InitialBalance)
+
+	reserve := int64(0)
+	if action.Side == models.OrderSideBuy {
+		reserve = models.BuyReserveCents(action.Quantity, action.PriceTicks)
+		if wallet.balance < reserve {
+			report.OrdersRejected++
+			return 0, false
+		}
+	}
+
+	order := &models.Order{
+		ID:          orderID,
+		MilestoneID: flow.MilestoneID,
+		OptionID:    flow.OptionID,
+		UserID:      action.UserID,
+		Type:        models.OrderTypeLimit,
+		Side:        action.Side,
+		Quantity:    action.Quantity,
+		Price:       models.TicksToPrice(action.PriceTicks),
+		PriceTicks:  action.PriceTicks,
+		Remaining:   action.Quantity,
+		Status:      models.OrderStatusPending,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	submitted := time.Now()
+	result, err := s.engine.SubmitOrder(order)
+	latency := time.Since(submitted)
+	if err != nil {
+		if errors.Is(err, ErrMarketHalted) {
+			report.OrdersHalted++
+		} else {
+			report.OrderErrors++
+		}
+		return latency, false
+	}
+
+	wallet.balance -= reserve
+	wallet.locked += reserve
+	tracked := &simOrder{order: order, quantity: action.Quantity, open: true}
+	run.orders[orderID] = tracked
+	run.placed[index] = tracked
+	report.OrdersAccepted++
+	report.SubmittedQuantity += action.Quantity
+
+	var takerFilled int64
+	for _, trade := range result.Trades {
+		takerFilled += trade.Quantity
+		s.settle(run, index, trade)
+	}
+
+	if result.Filled != takerFilled || result.Filled+result.Remaining != action.Quantity {
+		run.violate(ViolationLostQuantity, index, orderID, action.UserID,
+			fmt.Sprintf("주문 수량 %d, 엔진 보고 체결 %d + 잔량 %d, 거래 합계 %d",
+				action.Quantity, result.Filled, result.Remaining, takerFilled))
+	}
+	return latency, true
+}
+
+// settle 체결 1건을 시뮬레이터 지갑/주문에 반영하고 위반 검사
+func (s *MatchingSimulator) settle(run *simulationRun, index int, trade models.Trade) {
+	report := run.report
+	report.Trades++
+	report.Volume += trade.Quantity
+	report.FilledQuantity += 2 * trade.Quantity // 매수/매도 양쪽 주문의 체결
+	report.Notional += trade.TotalAmount
+	run.fees += trade.BuyerFee + trade.SellerFee
+
+	buyOrder, sellOrder := run.orders[trade.BuyOrderID], run.orders[trade.SellOrderID]
+	for _, side := range []struct {
+		order *simOrder
+		id    uint
+	}{{buyOrder, trade.BuyOrderID}, {sellOrder, trade.SellOrderID}} {
+		if side.order == nil {
+			run.violate(ViolationLostQuantity, index, side.id, 0, "시뮬레이터가 접수하지 않은 주문과 체결")
+			continue
+		}
+		if !side.order.open {
+			run.violate(ViolationLostQuantity, index, side.id, side.order.order.UserID, "취소된 주문과 체결")
+		}
+		side.order.filled += trade.Quantity
+		if side.order.filled > side.order.quantity {
+			run.violate(ViolationOverfill, index, side.id, side.order.order.UserID,
+				fmt.Sprintf("주문 수량 %d, 누적 체결 %d", side.order.quantity, side.order.filled))
+		}
+		if side.order.filled >= side.order.quantity {
+			side.order.open = false
+		}
+	}
+	if buyOrder != nil && trade.PriceTicks > buyOrder.order.PriceTicks {
+		run.violate(ViolationPriceThrough, index, trade.BuyOrderID, trade.BuyerID,
+			fmt.Sprintf("매수 지정가 %d틱, 체결가 %d틱", buyOrder.order.PriceTicks, trade.PriceTicks))
+	}
+	if sellOrder != nil && trade.PriceTicks < sellOrder.order.PriceTicks {
+		run.violate(ViolationPriceThrough, index, trade.SellOrderID, trade.SellerID,
+			fmt.Sprintf("매도 지정가 %d틱, 체결가 %d틱", sellOrder.order.PriceTicks, trade.PriceTicks))
+	}
+
+	// tradePipeline.updateUserWallets와 같은 정산
+	buyer := run.wallet(trade.BuyerID, s.config.InitialBalance)
+	buyer.locked -= trade.BuyerRelease
+	buyer.balance += trade.BuyerRelease - trade.TotalAmount - trade.BuyerFee
+	seller := run.wallet(trade.SellerID, s.config.InitialBalance)
+	seller.balance += trade.TotalAmount - trade.SellerFee
+
+	for _, party := range []struct {
+		userID uint
+		wallet *simWallet
+	}{{trade.BuyerID, buyer}, {trade.SellerID, seller}} {
+		if party.wallet.balance < 0 {
+			run.violate(ViolationNegativeBalance, index, 0, party.userID,
+				fmt.Sprintf("잔액 %d센트 (거래 %d→%d, 수량 %d)", party.wallet.balance, trade.BuyOrderID, trade.SellOrderID, trade.Quantity))
+		}
+		if party.wallet.locked < 0 {
+			run.violate(ViolationNegativeLocked, index, 0, party.userID,
+				fmt.Sprintf("잠긴 잔액 %d센트", party.wallet.locked))
+		}
+	}
+}
+
+// cancel TradingService.CancelOrder와 같이 주문장 제거 후 남은 잠금액 환불 (취소했으면 지연 시간 반환)
+func (s *MatchingSimulator) cancel(run *simulationRun, action SimAction) (time.Duration, bool) {
+	tracked, exists := run.placed[action.Target]
+	if !exists || !tracked.open {
+		return 0, false // 거부됐거나 이미 전량 체결/취소된 주문
+	}
+
+	started := time.Now()
+	if err := s.engine.CancelOrder(tracked.order); err != nil {
+		run.report.OrderErrors++
+		return 0, false // 주문장에 남았으므로 열린 주문으로 계속 추적
+	}
+	latency := time.Since(started)
+
+	tracked.open = false
+	if tracked.order.Side == models.OrderSideBuy {
+		refund := models.BuyReserveCents(tracked.quantity, tracked.order.PriceTicks) - models.BuyReserveCents(tracked.filled, tracked.order.PriceTicks)
+		wallet := run.wallet(tracked.order.UserID, s.config.InitialBalance)
+		wallet.locked -= refund
+		wallet.balance += refund
+	}
+	run.report.Cancels++
+	return latency, true
+}
+
+// checkFinalState 재생 후 주문장/지갑 전체 검사
+func (s *MatchingSimulator) checkFinalState(run *simulationRun, flow *SimulationFlow) {
+	// 1. 주문장 잔량 = 열린 주문의 미체결 수량
+	var expectedBids, expectedAsks int64
+	lockedByUser := make(map[uint]int64)
+	for _, order := range run.orders {
+		if !order.open {
+			continue
+		}
+		remaining := order.quantity - order.filled
+		if order.order.Side == models.OrderSideBuy {
+			expectedBids += remaining
+			lockedByUser[order.order.UserID] += models.BuyReserveCents(order.quantity, order.order.PriceTicks) -
+				models.BuyReserveCents(order.filled, order.order.PriceTicks)
+		} else {
+			expectedAsks += remaining
+		}
+	}
+
+	book := s.engine.GetOrderBook(flow.MilestoneID, flow.OptionID, int(models.PriceScale), 0)
+	bids, asks := bookQuantity(book.Bids), bookQuantity(book.Asks)
+	if bids != expectedBids || asks != expectedAsks {
+		run.violate(ViolationLostQuantity, -1, 0, 0,
+			fmt.Sprintf("주문장 잔량 매수 %d/매도 %d, 기대값 매수 %d/매도 %d", bids, asks, expectedBids, expectedAsks))
+	}
+	if len(book.Bids) > 0 && len(book.Asks) > 0 && book.Bids[0].Price >= book.Asks[0].Price {
+		run.violate(ViolationCrossedBook, -1, 0, 0,
+			fmt.Sprintf("최우선 매수 %.4f ≥ 최우선 매도 %.4f", book.Bids[0].Price, book.Asks[0].Price))
+	}
+
+	// 2. 잠긴 잔액 = 열린 매수 주문 잠금액, 전체 현금 보존
+	var total int64
+	userIDs := make([]uint, 0, len(run.wallets))
+	for userID := range run.wallets {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Slice(userIDs, func(i, j int) bool { return userIDs[i] < userIDs[j] })
+	for _, userID := range userIDs {
+		wallet := run.wallets[userID]
+		total += wallet.balance + wallet.locked
+		if wallet.locked != lockedByUser[userID] {
+			run.violate(ViolationLockedMismatch, -1, 0, userID,
+				fmt.Sprintf("잠긴 잔액 %d센트, 열린 매수 주문 잠금액 %d센트", wallet.locked, lockedByUser[userID]))
+		}
+	}
+	initial := s.config.InitialBalance * int64(len(run.wallets))
+	if total+run.fees != initial {
+		run.violate(ViolationCashConservation, -1, 0, 0,
+			fmt.Sprintf("잔액+잠금 %d + 수수료 %d ≠ 시작 잔액 %d", total, run.fees, initial))
+	}
+}
+
+// wallet 사용자 지갑 (처음 보는 사용자는 시작 잔액으로 생성)
+func (run *simulationRun) wallet(userID uint, initialBalance int64) *simWallet {
+	wallet, exists := run.wallets[userID]
+	if !exists {
+		wallet = &simWallet{balance: initialBalance}
+		run.wallets[userID] = wallet
+	}
+	return wallet
+}
+
+// violate 위반 집계 (상세는 최대 maxViolationSamples건)
+func (run *simulationRun) violate(kind string, action int, orderID, userID uint, detail string) {
+	report := run.report
+	report.Violations[kind]++
+	report.TotalViolations++
+	if len(report.ViolationSamples) < maxViolationSamples {
+		report.ViolationSamples = append(report.ViolationSamples, SimulationViolation{
+			Kind: kind, Action: action, OrderID: orderID, UserID: userID, Detail: detail,
+		})
+	}
+}
+
+// bookQuantity 가격 레벨 수량 합계
+func bookQuantity(levels []models.OrderBookLevel) int64 {
+	var total int64
+	for _, level := range levels {
+		total += level.Quantity
+	}
+	return total
+}
+
+// latencyDistribution 지연 시간 평균/백분위수
+func latencyDistribution(samples []time.Duration) SimulationLatency {
+	if len(samples) == 0 {
+		return SimulationLatency{}
+	}
+	sorted := append([]time.Duration{}, samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum time.Duration
+	for _, sample := range sorted {
+		sum += sample
+	}
+	percentile := func(p float64) float64 {
+		index := int(math.Ceil(p*float64(len(sorted)))) - 1
+		return durationMs(sorted[max(0, index)])
+	}
+	return SimulationLatency{
+		Mean: durationMs(sum / time.Duration(len(sorted))),
+		P50:  percentile(0.50),
+		P95:  percentile(0.95),
+		P99:  percentile(0.99),
+		Max:  durationMs(sorted[len(sorted)-1]),
+	}
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
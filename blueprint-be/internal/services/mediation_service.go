@@ -0,0 +1,635 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// 분쟁 조정 기한
+const (
+	mediationWindow    = 5 * 24 * time.Hour // 조정 개시부터 자동 결렬까지
+	mediationOfferTTL  = 48 * time.Hour     // 제안 응답 기한 (조정 기한을 넘지 않음)
+	maxMediationOffers = 12                 // 조정당 최대 제안 수 (넘으면 결렬 선언 후 배심원 중재)
+)
+
+var (
+	ErrMediationNotFound            = errors.New("조정을 찾을 수 없습니다")
+	ErrMediationForbidden           = errors.New("조정 당사자가 아닙니다")
+	ErrMediationClosed              = errors.New("이미 종료된 조정입니다")
+	ErrMediationOutcome             = errors.New("이 조정에서 선택할 수 없는 합의안입니다")
+	ErrMediationOfferLimit          = errors.New("제안 횟수를 모두 사용했습니다. 결렬을 선언해 배심원 중재로 넘기세요")
+	ErrMediationOfferNotFound       = errors.New("제안을 찾을 수 없습니다")
+	ErrMediationOfferNotPending     = errors.New("응답을 기다리는 제안이 아닙니다")
+	ErrMediationOwnOffer            = errors.New("자신의 제안에는 응답할 수 없습니다")
+	ErrMediationInsufficientBalance = errors.New("피신청인의 BLUEPRINT 잔액이 합의 금액보다 적습니다")
+)
+
+// MediationService 배심원 중재 전 당사자 조정
+//
+// 증거 분쟁과 멘토 신고는 먼저 조정으로 열린다. 당사자는 합의안(원 분쟁 결론 + 배상액)을 제안/역제안하고,
+// 상대가 대기 중인 제안을 수락하면 원 분쟁을 그대로 종결한다. 한쪽이 결렬을 선언하거나 기한이 지나면
+// 분쟁 제기 스테이킹을 승계한 배심원 중재 사건으로 이관한다 (추가 스테이킹 없음, 배심원 수 감축).
+type MediationService struct {
+	db                  *gorm.DB
+	arbitration         *ArbitrationService
+	mentorStaking       *MentorStakingService
+	stateMachine        *MilestoneStateMachine
+	notificationService *NotificationService
+}
+
+// NewMediationService 생성자
+func NewMediationService(db *gorm.DB, arbitration *ArbitrationService, mentorStaking *MentorStakingService) *MediationService {
+	return &MediationService{
+		db:                  db,
+		arbitration:         arbitration,
+		mentorStaking:       mentorStaking,
+		stateMachine:        NewMilestoneStateMachine(db),
+		notificationService: NewNotificationService(db),
+	}
+}
+
+// openForProofDispute 증거 분쟁 조정 개시 (분쟁 제기 트랜잭션 안에서, 알림은 커밋 후 notifyOpened)
+func (s *MediationService) openForProofDispute(tx *gorm.DB, dispute *models.ProofDispute, proof *models.MilestoneProof) (*models.Mediation, error) {
+	milestoneID := proof.MilestoneID
+	mediation := &models.Mediation{
+		SourceType:    models.MediationSourceProofDispute,
+		SourceID:      dispute.ID,
+		ClaimantID:    dispute.UserID,
+		RespondentID:  proof.UserID,
+		MilestoneID:   &milestoneID,
+		Title:         dispute.Title,
+		ClaimantStake: dispute.StakeAmount,
+	}
+	if err := s.open(tx, mediation); err != nil {
+		return nil, err
+	}
+	return mediation, nil
+}
+
+// OpenForMentorReport 멘토 신고 조정 개시 (조정 중에는 슬래싱 검토 보류)
+func (s *MediationService) OpenForMentorReport(slashEvent *models.MentorSlashEvent, mentorUserID uint) (*models.Mediation, error) {
+	if slashEvent.ReporterID == nil {
+		return nil, errors.New("신고자가 없는 시스템 슬래싱은 조정 대상이 아닙니다")
+	}
+	mediation := &models.Mediation{
+		SourceType:   models.MediationSourceMentorReport,
+		SourceID:     slashEvent.ID,
+		ClaimantID:   *slashEvent.ReporterID,
+		RespondentID: mentorUserID,
+		MilestoneID:  slashEvent.MilestoneID,
+		MentorshipID: slashEvent.MentorshipID,
+		Title:        slashEvent.Reason,
+	}
+	if err := s.open(s.db, mediation); err != nil {
+		return nil, err
+	}
+	s.notifyOpened(mediation)
+	return mediation, nil
+}
+
+func (s *MediationService) open(tx *gorm.DB, mediation *models.Mediation) error {
+	mediation.Status = models.MediationStatusOpen
+	mediation.Deadline = time.Now().Add(mediationWindow)
+	if err := tx.Create(mediation).Error; err != nil {
+		return fmt.Errorf("조정 생성 실패: %w", err)
+	}
+	return nil
+}
+
+// ListMediations 내가 당사자인 조정 목록 (최신순)
+func (s *MediationService) ListMediations(userID uint, status models.MediationStatus) ([]models.Mediation, error) {
+	query := s.db.Where("claimant_id = ? OR respondent_id = ?", userID, userID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var mediations []models.Mediation
+	if err := query.Order("id DESC").Limit(100).Find(&mediations).Error; err != nil {
+		return nil, fmt.Errorf("조정 목록 조회 실패: %w", err)
+	}
+	return mediations, nil
+}
+
+// GetMediation 조정 상세 (제안 이력 포함, 당사자만)
+func (s *MediationService) GetMediation(mediationID, userID uint) (*models.Mediation, error) {
+	var mediation models.Mediation
+	err := s.db.Preload("Offers", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		First(&mediation, mediationID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrMediationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("조정 조회 실패: %w", err)
+	}
+	if !mediation.IsParty(userID) {
+		return nil, ErrMediationForbidden
+	}
+	return &mediation, nil
+}
+
+// MakeOffer 합의안 제안 (상대의 대기 중인 제안은 역제안으로, 내 이전 제안은 철회로 처리)
+func (s *MediationService) MakeOffer(mediationID, userID uint, req *models.MediationOfferRequest) (*models.MediationOffer, error) {
+	var mediation models.Mediation
+	var offer *models.MediationOffer
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if mediation, err = s.loadOpen(tx, mediationID, userID); err != nil {
+			return err
+		}
+		if !mediation.SourceType.Allows(req.Outcome) {
+			return ErrMediationOutcome
+		}
+
+		var count int64
+		if err := tx.Model(&models.MediationOffer{}).Where("mediation_id = ?", mediation.ID).Count(&count).Error; err != nil {
+			return fmt.Errorf("제안 수 조회 실패: %w", err)
+		}
+		if count >= maxMediationOffers {
+			return ErrMediationOfferLimit
+		}
+
+		now := time.Now()
+		expiresAt := now.Add(mediationOfferTTL)
+		if expiresAt.After(mediation.Deadline) {
+			expiresAt = mediation.Deadline
+		}
+		offer = &models.MediationOffer{
+			MediationID:  mediation.ID,
+			ProposerID:   userID,
+			Outcome:      req.Outcome,
+			Compensation: req.Compensation,
+			Terms:        strings.TrimSpace(req.Terms),
+			Status:       models.MediationOfferPending,
+			ExpiresAt:    expiresAt,
+		}
+
+		var pending models.MediationOffer
+		if err := tx.Where("mediation_id = ? AND status = ?", mediation.ID, models.MediationOfferPending).
+			Limit(1).Find(&pending).Error; err != nil {
+			return fmt.Errorf("대기 중인 제안 조회 실패: %w", err)
+		}
+		if pending.ID != 0 {
+			status := models.MediationOfferWithdrawn
+			if pending.ProposerID != userID {
+				status = models.MediationOfferCountered
+				offer.CounterToID = &pending.ID
+			}
+			if err := tx.Model(&pending).Updates(map[string]interface{}{
+				"status":       status,
+				"responded_at": now,
+			}).Error; err != nil {
+				return fmt.Errorf("이전 제안 갱신 실패: %w", err)
+			}
+		}
+
+		if err := tx.Create(offer).Error; err != nil {
+			return fmt.Errorf("제안 저장 실패: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notify(mediation.Counterparty(userID), &mediation, "새 조정 제안이 도착했습니다",
+		fmt.Sprintf("'%s' 조정에 %s 제안이 도착했습니다. %s까지 응답하세요.",
+			mediation.Title, describeMediationOffer(offer), offer.ExpiresAt.Format("2006-01-02 15:04")))
+	return offer, nil
+}
+
+// AcceptOffer 상대의 대기 중인 제안 수락 → 배상 이체 후 합의안대로 원 분쟁 종결
+func (s *MediationService) AcceptOffer(mediationID, offerID, userID uint) (*models.Mediation, error) {
+	var mediation models.Mediation
+	var transition *MilestoneTransition
+	var slashed *models.MentorSlashEvent
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if mediation, err = s.loadOpen(tx, mediationID, userID); err != nil {
+			return err
+		}
+		offer, err := s.loadRespondableOffer(tx, &mediation, offerID, userID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		result := tx.Model(&models.Mediation{}).
+			Where("id = ? AND status = ?", mediation.ID, models.MediationStatusOpen).
+			Updates(map[string]interface{}{
+				"status":           models.MediationStatusSettled,
+				"settled_offer_id": offer.ID,
+				"resolved_at":      now,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("조정 종결 실패: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrMediationClosed
+		}
+		if err := tx.Model(offer).Updates(map[string]interface{}{
+			"status":       models.MediationOfferAccepted,
+			"responded_at": now,
+		}).Error; err != nil {
+			return fmt.Errorf("제안 수락 실패: %w", err)
+		}
+
+		if err := s.transferCompensation(tx, &mediation, offer); err != nil {
+			return err
+		}
+
+		switch mediation.SourceType {
+		case models.MediationSourceProofDispute:
+			transition, err = s.settleProofDispute(tx, &mediation, offer)
+		case models.MediationSourceMentorReport:
+			slashed, err = s.settleMentorReport(tx, &mediation, offer, userID)
+		}
+		if err != nil {
+			return err
+		}
+
+		mediation.Status = models.MediationStatusSettled
+		mediation.SettledOfferID = &offer.ID
+		mediation.ResolvedAt = &now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if transition != nil {
+		s.stateMachine.Dispatch(transition)
+	}
+	if slashed != nil {
+		s.mentorStaking.notifySlashing(slashed)
+		s.mentorStaking.publishSlashWebhook(slashed)
+	}
+	s.notifyParties(&mediation, "분쟁 조정이 합의로 종결되었습니다",
+		fmt.Sprintf("'%s' 조정이 합의로 종결되었습니다. 배심원 중재는 열리지 않습니다.", mediation.Title))
+	return &mediation, nil
+}
+
+// RejectOffer 상대의 대기 중인 제안 거절 (조정은 계속, 새 제안 가능)
+func (s *MediationService) RejectOffer(mediationID, offerID, userID uint) (*models.MediationOffer, error) {
+	var mediation models.Mediation
+	var offer *models.MediationOffer
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if mediation, err = s.loadOpen(tx, mediationID, userID); err != nil {
+			return err
+		}
+		if offer, err = s.loadRespondableOffer(tx, &mediation, offerID, userID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		offer.Status = models.MediationOfferRejected
+		offer.RespondedAt = &now
+		if err := tx.Model(offer).Updates(map[string]interface{}{
+			"status":       offer.Status,
+			"responded_at": now,
+		}).Error; err != nil {
+			return fmt.Errorf("제안 거절 실패: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notify(offer.ProposerID, &mediation, "조정 제안이 거절되었습니다",
+		fmt.Sprintf("'%s' 조정에서 %s 제안이 거절되었습니다.", mediation.Title, describeMediationOffer(offer)))
+	return offer, nil
+}
+
+// Escalate 당사자의 결렬 선언 → 배심원 중재 이관
+func (s *MediationService) Escalate(mediationID, userID uint, reason string) (*models.Mediation, error) {
+	mediation, err := s.loadOpen(s.db, mediationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		reason = "당사자 결렬 선언"
+	}
+	if _, err := s.escalate(&mediation, reason); err != nil {
+		return nil, err
+	}
+	return &mediation, nil
+}
+
+// ProcessDeadlines 응답 기한이 지난 제안 만료, 조정 기한이 지난 조정은 배심원 중재로 이관
+func (s *MediationService) ProcessDeadlines(now time.Time) (int, error) {
+	if err := s.db.Model(&models.MediationOffer{}).
+		Where("status = ? AND expires_at <= ?", models.MediationOfferPending, now).
+		Updates(map[string]interface{}{"status": models.MediationOfferExpired}).Error; err != nil {
+		return 0, fmt.Errorf("제안 만료 처리 실패: %w", err)
+	}
+
+	var mediations []models.Mediation
+	if err := s.db.Where("status = ? AND deadline <= ?", models.MediationStatusOpen, now).
+		Order("deadline ASC").Limit(100).Find(&mediations).Error; err != nil {
+		return 0, fmt.Errorf("기한 경과 조정 조회 실패: %w", err)
+	}
+
+	escalated := 0
+	for i := range mediations {
+		if _, err := s.escalate(&mediations[i], "조정 기한 경과"); err != nil {
+			if !errors.Is(err, ErrMediationClosed) {
+				log.Printf("❌ Failed to escalate mediation %d: %v", mediations[i].ID, err)
+			}
+			continue
+		}
+		escalated++
+	}
+	return escalated, nil
+}
+
+// escalate 조정을 결렬 처리하고 분쟁 제기 스테이킹을 승계한 배심원 중재 사건 생성
+func (s *MediationService) escalate(mediation *models.Mediation, reason string) (*models.ArbitrationCase, error) {
+	var arbitrationCase *models.ArbitrationCase
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.Mediation{}).
+			Where("id = ? AND status = ?", mediation.ID, models.MediationStatusOpen).
+			Updates(map[string]interface{}{
+				"status":            models.MediationStatusEscalated,
+				"escalation_reason": reason,
+				"resolved_at":       now,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("조정 결렬 처리 실패: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrMediationClosed
+		}
+		if err := tx.Model(&models.MediationOffer{}).
+			Where("mediation_id = ? AND status = ?", mediation.ID, models.MediationOfferPending).
+			Updates(map[string]interface{}{"status": models.MediationOfferExpired}).Error; err != nil {
+			return fmt.Errorf("대기 중인 제안 만료 실패: %w", err)
+		}
+
+		var offers []models.MediationOffer
+		if err := tx.Where("mediation_id = ?", mediation.ID).Order("id ASC").Find(&offers).Error; err != nil {
+			return fmt.Errorf("제안 이력 조회 실패: %w", err)
+		}
+
+		var err error
+		arbitrationCase, err = s.arbitration.OpenMediationEscalation(tx, mediation, offers, reason)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Mediation{}).Where("id = ?", mediation.ID).
+			Update("arbitration_case_id", arbitrationCase.ID).Error; err != nil {
+			return fmt.Errorf("중재 사건 연결 실패: %w", err)
+		}
+
+		// 원 분쟁은 배심원 판결을 기다림 (멘토 신고는 보류했던 운영자 검토 재개)
+		switch mediation.SourceType {
+		case models.MediationSourceProofDispute:
+			err = tx.Model(&models.ProofDispute{}).Where("id = ?", mediation.SourceID).Updates(map[string]interface{}{
+				"status":     "investigating",
+				"resolution": "배심원 중재 이관: " + arbitrationCase.CaseNumber,
+			}).Error
+		case models.MediationSourceMentorReport:
+			err = tx.Model(&models.MentorSlashEvent{}).
+				Where("id = ? AND status = ?", mediation.SourceID, models.SlashEventStatusPending).
+				Update("status", models.SlashEventStatusReviewing).Error
+		}
+		if err != nil {
+			return fmt.Errorf("원 분쟁 상태 갱신 실패: %w", err)
+		}
+
+		mediation.Status = models.MediationStatusEscalated
+		mediation.EscalationReason = reason
+		mediation.ResolvedAt = &now
+		mediation.ArbitrationCaseID = &arbitrationCase.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.arbitration.startJurySelection(arbitrationCase.ID)
+	s.notifyParties(mediation, "분쟁 조정이 결렬되어 배심원 중재로 넘어갑니다",
+		fmt.Sprintf("'%s' 조정이 결렬되었습니다 (%s). 사건 %s로 배심원 중재가 시작됩니다.", mediation.Title, reason, arbitrationCase.CaseNumber))
+	return arbitrationCase, nil
+}
+
+// transferCompensation 합의 배상액 이체 (피신청인 사용 가능 잔액 → 신청인)
+func (s *MediationService) transferCompensation(tx *gorm.DB, mediation *models.Mediation, offer *models.MediationOffer) error {
+	if offer.Compensation <= 0 {
+		return nil
+	}
+
+	var wallet models.UserWallet
+	if err := tx.Where("user_id = ?", mediation.RespondentID).Limit(1).Find(&wallet).Error; err != nil {
+		return fmt.Errorf("피신청인 지갑 조회 실패: %w", err)
+	}
+	if wallet.BlueprintBalance < offer.Compensation {
+		return ErrMediationInsufficientBalance
+	}
+
+	memo := fmt.Sprintf("분쟁 조정 #%d 합의금", mediation.ID)
+	if err := postLedgerEntry(tx, mediationLedgerEntry(mediation, models.WalletLedgerEntry{
+		UserID: mediation.RespondentID,
+		Amount: -offer.Compensation,
+		Memo:   memo,
+	})); err != nil {
+		return err
+	}
+	return postLedgerEntry(tx, mediationLedgerEntry(mediation, models.WalletLedgerEntry{
+		UserID: mediation.ClaimantID,
+		Amount: offer.Compensation,
+		Memo:   memo,
+	}))
+}
+
+// settleProofDispute 분쟁 종결 + 스테이킹 반환, 다른 분쟁이 없으면 합의한 판정으로 마일스톤 전환
+func (s *MediationService) settleProofDispute(tx *gorm.DB, mediation *models.Mediation, offer *models.MediationOffer) (*MilestoneTransition, error) {
+	var dispute models.ProofDispute
+	if err := tx.First(&dispute, mediation.SourceID).Error; err != nil {
+		return nil, fmt.Errorf("증거 분쟁 조회 실패: %w", err)
+	}
+
+	if dispute.StakeAmount > 0 && !dispute.StakeReturned {
+		if err := postLedgerEntry(tx, &models.WalletLedgerEntry{
+			UserID:        dispute.UserID,
+			Currency:      models.LedgerCurrencyBlueprint,
+			EntryType:     models.LedgerMediationStakeReturn,
+			Amount:        dispute.StakeAmount,
+			LockedAmount:  -dispute.StakeAmount,
+			ReferenceType: "proof_dispute",
+			ReferenceID:   dispute.ID,
+			Memo:          fmt.Sprintf("분쟁 조정 #%d 합의로 스테이킹 반환", mediation.ID),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	resolution := fmt.Sprintf("조정 합의 (%s)", offer.Outcome)
+	if offer.Terms != "" {
+		resolution += ": " + offer.Terms
+	}
+	if err := tx.Model(&dispute).Updates(map[string]interface{}{
+		"status":         "resolved",
+		"resolution":     resolution,
+		"resolved_at":    time.Now(),
+		"stake_returned": true,
+	}).Error; err != nil {
+		return nil, fmt.Errorf("증거 분쟁 종결 실패: %w", err)
+	}
+
+	// 같은 증거에 다른 분쟁이 남아 있으면 판정은 그쪽 결과를 기다림
+	var remaining int64
+	if err := tx.Model(&models.ProofDispute{}).
+		Where("proof_id = ? AND id <> ? AND status IN ?", dispute.ProofID, dispute.ID, []string{"open", "investigating"}).
+		Count(&remaining).Error; err != nil {
+		return nil, fmt.Errorf("남은 분쟁 조회 실패: %w", err)
+	}
+	if remaining > 0 {
+		return nil, nil
+	}
+
+	proofStatus, milestoneStatus := models.ProofStatusApproved, models.MilestoneStatusProofApproved
+	if offer.Outcome == models.MediationOutcomeRejectProof {
+		proofStatus, milestoneStatus = models.ProofStatusRejected, models.MilestoneStatusProofRejected
+	}
+
+	var proof models.MilestoneProof
+	if err := tx.First(&proof, dispute.ProofID).Error; err != nil {
+		return nil, fmt.Errorf("증거 조회 실패: %w", err)
+	}
+	if err := tx.Model(&proof).Update("status", proofStatus).Error; err != nil {
+		return nil, fmt.Errorf("증거 상태 업데이트 실패: %w", err)
+	}
+
+	var milestone models.Milestone
+	if err := tx.First(&milestone, proof.MilestoneID).Error; err != nil {
+		return nil, fmt.Errorf("마일스톤 조회 실패: %w", err)
+	}
+	if milestone.Status != models.MilestoneStatusDisputed {
+		return nil, nil
+	}
+	return s.stateMachine.Transition(tx, &milestone, milestoneStatus, TransitionOptions{
+		Reason: "분쟁 조정 합의: " + mediation.Title,
+	})
+}
+
+// settleMentorReport 보류했던 신고를 합의안대로 확정 (슬래싱 수용 또는 신고 철회)
+func (s *MediationService) settleMentorReport(tx *gorm.DB, mediation *models.Mediation, offer *models.MediationOffer, acceptorID uint) (*models.MentorSlashEvent, error) {
+	if err := tx.Model(&models.MentorSlashEvent{}).
+		Where("id = ? AND status = ?", mediation.SourceID, models.SlashEventStatusPending).
+		Update("status", models.SlashEventStatusReviewing).Error; err != nil {
+		return nil, fmt.Errorf("신고 상태 갱신 실패: %w", err)
+	}
+
+	comment := "분쟁 조정 합의"
+	if offer.Terms != "" {
+		comment += ": " + offer.Terms
+	}
+	return s.mentorStaking.processSlashingTx(tx, mediation.SourceID, acceptorID, offer.Outcome == models.MediationOutcomeAcceptSlash, comment)
+}
+
+// loadOpen 당사자가 진행 중인 조정을 조회
+func (s *MediationService) loadOpen(tx *gorm.DB, mediationID, userID uint) (models.Mediation, error) {
+	var mediation models.Mediation
+	err := tx.First(&mediation, mediationID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return mediation, ErrMediationNotFound
+	}
+	if err != nil {
+		return mediation, fmt.Errorf("조정 조회 실패: %w", err)
+	}
+	if !mediation.IsParty(userID) {
+		return mediation, ErrMediationForbidden
+	}
+	if mediation.Status != models.MediationStatusOpen || !time.Now().Before(mediation.Deadline) {
+		return mediation, ErrMediationClosed
+	}
+	return mediation, nil
+}
+
+// loadRespondableOffer 상대가 낸, 응답 기한이 남은 대기 중 제안
+func (s *MediationService) loadRespondableOffer(tx *gorm.DB, mediation *models.Mediation, offerID, userID uint) (*models.MediationOffer, error) {
+	var offer models.MediationOffer
+	err := tx.Where("id = ? AND mediation_id = ?", offerID, mediation.ID).First(&offer).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrMediationOfferNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("제안 조회 실패: %w", err)
+	}
+	if offer.ProposerID == userID {
+		return nil, ErrMediationOwnOffer
+	}
+	if offer.Status != models.MediationOfferPending || !time.Now().Before(offer.ExpiresAt) {
+		return nil, ErrMediationOfferNotPending
+	}
+	return &offer, nil
+}
+
+func (s *MediationService) notifyOpened(mediation *models.Mediation) {
+	s.notifyParties(mediation, "분쟁 조정이 시작되었습니다",
+		fmt.Sprintf("'%s' 건은 배심원 중재 전에 %s까지 당사자 간 조정을 거칩니다. 합의안을 제안하거나 상대 제안에 응답하세요.",
+			mediation.Title, mediation.Deadline.Format("2006-01-02 15:04")))
+}
+
+func (s *MediationService) notifyParties(mediation *models.Mediation, title, message string) {
+	s.notificationService.NotifyMany([]uint{mediation.ClaimantID, mediation.RespondentID}, mediationNotification(mediation, title, message))
+}
+
+func (s *MediationService) notify(userID uint, mediation *models.Mediation, title, message string) {
+	req := mediationNotification(mediation, title, message)
+	req.UserID = userID
+	if _, err := s.notificationService.Notify(req); err != nil {
+		log.Printf("❌ Failed to notify mediation %d: %v", mediation.ID, err)
+	}
+}
+
+func mediationNotification(mediation *models.Mediation, title, message string) models.CreateNotificationRequest {
+	return models.CreateNotificationRequest{
+		Type:    models.NotificationTypeMediation,
+		Title:   title,
+		Message: message,
+		Link:    fmt.Sprintf("/mediations/%d", mediation.ID),
+		Data: map[string]interface{}{
+			"mediation_id": mediation.ID,
+			"status":       mediation.Status,
+		},
+	}
+}
+
+func mediationLedgerEntry(mediation *models.Mediation, entry models.WalletLedgerEntry) *models.WalletLedgerEntry {
+	entry.Currency = models.LedgerCurrencyBlueprint
+	entry.EntryType = models.LedgerMediationSettlement
+	entry.ReferenceType = "mediation"
+	entry.ReferenceID = mediation.ID
+	return &entry
+}
+
+// describeMediationOffer 알림용 제안 요약 ("증거 승인, 배상 500 BLUEPRINT")
+func describeMediationOffer(offer *models.MediationOffer) string {
+	var outcome string
+	switch offer.Outcome {
+	case models.MediationOutcomeApproveProof:
+		outcome = "증거 승인"
+	case models.MediationOutcomeRejectProof:
+		outcome = "증거 거절"
+	case models.MediationOutcomeDismissReport:
+		outcome = "신고 철회"
+	case models.MediationOutcomeAcceptSlash:
+		outcome = "슬래싱 수용"
+	}
+	if offer.Compensation > 0 {
+		return fmt.Sprintf("%s, 배상 %d BLUEPRINT", outcome, offer.Compensation)
+	}
+	return outcome
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// noShowSlashWindow 무단 불참 신고를 집계하는 기간
+	noShowSlashWindow = 30 * 24 * time.Hour
+	// noShowSlashThreshold 기간 내 1점 불참 신고가 이 건수 이상이면 no_show 슬래싱 자동 접수
+	noShowSlashThreshold = 3
+)
+
+var (
+	ErrMentoringSessionNotFound = errors.New("멘토링 세션을 찾을 수 없습니다")
+	ErrFeedbackForbidden        = errors.New("해당 세션의 멘티만 평가할 수 있습니다")
+	ErrFeedbackExists           = errors.New("이미 평가한 세션입니다")
+)
+
+// MentorFeedbackService 멘티 세션 평가 (멘토 평점/성과 지표 반영, 반복 불참 시 자동 슬래싱 접수)
+type MentorFeedbackService struct {
+	db             *gorm.DB
+	stakingService *MentorStakingService
+}
+
+// NewMentorFeedbackService 생성자
+func NewMentorFeedbackService(db *gorm.DB, stakingService *MentorStakingService) *MentorFeedbackService {
+	return &MentorFeedbackService{
+		db:             db,
+		stakingService: stakingService,
+	}
+}
+
+// RateSession 멘티가 세션을 평가 (세션당 1회)
+func (s *MentorFeedbackService) RateSession(sessionID, menteeID uint, req *models.RateSessionRequest) (*models.MentorSessionFeedback, error) {
+	var session models.MentoringSession
+	if err := s.db.First(&session, sessionID).Error; err != nil {
+		return nil, ErrMentoringSessionNotFound
+	}
+	if session.MenteeID != menteeID {
+		return nil, ErrFeedbackForbidden
+	}
+	if session.Status == models.SessionStatusCancelled && !req.NoShow {
+		return nil, errors.New("취소된 세션은 불참 신고만 할 수 있습니다")
+	}
+
+	feedback := &models.MentorSessionFeedback{
+		SessionID:   session.ID,
+		MentorID:    session.MentorID,
+		MenteeID:    menteeID,
+		MilestoneID: session.MilestoneID,
+		Rating:      req.Rating,
+		Comment:     req.Comment,
+		NoShow:      req.NoShow,
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		tx.Model(&models.MentorSessionFeedback{}).Where("session_id = ?", session.ID).Count(&existing)
+		if existing > 0 {
+			return ErrFeedbackExists
+		}
+		if err := tx.Create(feedback).Error; err != nil {
+			return fmt.Errorf("평가 저장 실패: %w", err)
+		}
+
+		if err := tx.Model(&models.MentoringSession{}).Where("id = ?", session.ID).
+			Updates(map[string]interface{}{
+				"mentee_rating": float64(req.Rating),
+				"mentee_review": req.Comment,
+			}).Error; err != nil {
+			return fmt.Errorf("세션 평가 반영 실패: %w", err)
+		}
+
+		// 멘토 평균 평점 및 마일스톤별 멘티 평점 (멘토 풀 보상 가중치) 갱신
+		var mentorAverage float64
+		tx.Model(&models.MentorSessionFeedback{}).Where("mentor_id = ?", session.MentorID).
+			Select("COALESCE(AVG(rating), 0)").Scan(&mentorAverage)
+		if err := tx.Model(&models.Mentor{}).Where("id = ?", session.MentorID).
+			Update("average_rating", mentorAverage).Error; err != nil {
+			return fmt.Errorf("멘토 평점 갱신 실패: %w", err)
+		}
+
+		var milestoneAverage float64
+		tx.Model(&models.MentorSessionFeedback{}).
+			Where("mentor_id = ? AND milestone_id = ?", session.MentorID, session.MilestoneID).
+			Select("COALESCE(AVG(rating), 0)").Scan(&milestoneAverage)
+		return tx.Model(&models.MentorMilestone{}).
+			Where("mentor_id = ? AND milestone_id = ?", session.MentorID, session.MilestoneID).
+			Update("mentee_rating", milestoneAverage).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if feedback.NoShow && feedback.Rating == 1 {
+		s.checkNoShowSlash(session.MentorID)
+	}
+
+	return feedback, nil
+}
+
+// checkNoShowSlash 최근 1점 불참 신고가 누적되면 no_show 슬래싱 이벤트 자동 접수
+func (s *MentorFeedbackService) checkNoShowSlash(mentorID uint) {
+	var feedbackIDs []uint
+	if err := s.db.Model(&models.MentorSessionFeedback{}).
+		Where("mentor_id = ? AND no_show = ? AND rating = 1 AND created_at >= ?", mentorID, true, time.Now().Add(-noShowSlashWindow)).
+		Order("id ASC").Pluck("id", &feedbackIDs).Error; err != nil {
+		log.Printf("❌ Failed to count no-show reports for mentor %d: %v", mentorID, err)
+		return
+	}
+	if len(feedbackIDs) < noShowSlashThreshold {
+		return
+	}
+
+	evidence, _ := json.Marshal(map[string]interface{}{"feedback_ids": feedbackIDs})
+	slashEvent, err := s.stakingService.OpenSystemSlash(
+		mentorID,
+		models.SlashTypeNoShow,
+		models.SlashSeverityModerate,
+		"반복된 멘토링 세션 무단 불참",
+		fmt.Sprintf("최근 %d일간 1점 불참 신고 %d건", int(noShowSlashWindow.Hours()/24), len(feedbackIDs)),
+		string(evidence),
+	)
+	if err != nil {
+		log.Printf("⚠️ No-show slash for mentor %d not opened: %v", mentorID, err)
+		return
+	}
+	if slashEvent != nil {
+		log.Printf("🚨 Opened no-show slash event %d for mentor %d (%d reports)", slashEvent.ID, mentorID, len(feedbackIDs))
+	}
+}
+
+// GetMentorFeedback 멘토 평가 요약 및 최근 평가 목록
+func (s *MentorFeedbackService) GetMentorFeedback(mentorID uint, limit, offset int) (*models.MentorFeedbackSummary, error) {
+	summary := &models.MentorFeedbackSummary{
+		MentorID:     mentorID,
+		Distribution: map[int]int64{1: 0, 2: 0, 3: 0, 4: 0, 5: 0},
+	}
+
+	var rows []struct {
+		Rating int
+		Count  int64
+	}
+	if err := s.db.Model(&models.MentorSessionFeedback{}).
+		Select("rating, COUNT(*) AS count").
+		Where("mentor_id = ?", mentorID).
+		Group("rating").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("평가 집계 실패: %w", err)
+	}
+
+	var ratingSum int64
+	for _, row := range rows {
+		summary.Distribution[row.Rating] = row.Count
+		summary.TotalCount += row.Count
+		ratingSum += int64(row.Rating) * row.Count
+	}
+	if summary.TotalCount > 0 {
+		summary.AverageRating = float64(ratingSum) / float64(summary.TotalCount)
+	}
+	s.db.Model(&models.MentorSessionFeedback{}).
+		Where("mentor_id = ? AND no_show = ?", mentorID, true).
+		Count(&summary.NoShowCount)
+
+	if err := s.db.Where("mentor_id = ?", mentorID).
+		Order("created_at DESC").Limit(limit).Offset(offset).
+		Find(&summary.Feedbacks).Error; err != nil {
+		return nil, fmt.Errorf("평가 목록 조회 실패: %w", err)
+	}
+
+	return summary, nil
+}
@@ -12,8 +12,9 @@ import (
 
 // 🧭 멘토 자격 증명 서비스 - "Proof of Confidence"
 type MentorQualificationService struct {
-	db         *gorm.DB
-	sseService *SSEService
+	db          *gorm.DB
+	sseService  *SSEService
+	trustScores *TrustScoreService // 멘토 자격 신뢰 점수 확인 (nil이면 제한 없음)
 }
 
 // NewMentorQualificationService 멘토 자격 증명 서비스 생성자
@@ -24,6 +25,11 @@ func NewMentorQualificationService(db *gorm.DB, sseService *SSEService) *MentorQ
 	}
 }
 
+// SetTrustScores 멘토 자격 부여에 신뢰 점수 최소 기준 적용
+func (mqs *MentorQualificationService) SetTrustScores(trustScores *TrustScoreService) {
+	mqs.trustScores = trustScores
+}
+
 // BettorInfo 베팅자 정보 (내부 계산용)
 type BettorInfo struct {
 	UserID          uint      `json:"user_id"`
@@ -71,6 +77,15 @@ func (mqs *MentorQualificationService) ProcessMilestoneBetting(milestoneID uint)
 		return nil, fmt.Errorf("failed to analyze bettors: %v", err)
 	}
 
+	// 신뢰 점수 미달 사용자는 멘토 자격 대상에서 제외
+	eligible := bettors[:0]
+	for _, bettor := range bettors {
+		if err := mqs.trustScores.CheckEligibility(bettor.UserID, TrustGateMentor); err == nil {
+			eligible = append(eligible, bettor)
+		}
+	}
+	bettors = eligible
+
 	if len(bettors) == 0 {
 		log.Printf("📋 No bettors found for milestone %d", milestoneID)
 		return &MentorQualificationResult{
@@ -161,7 +176,7 @@ func (mqs *MentorQualificationService) analyzeMilestoneBettors(tx *gorm.DB, mile
 	var totalBetAmount int64
 
 	for _, order := range orders {
-		betAmount := int64(float64(order.Filled) * order.Price * 100) // 실제 체결된 금액만
+		betAmount := models.NotionalCents(order.Filled, order.PriceTicks) // 실제 체결된 금액만
 
 		if existing, exists := userBets[order.UserID]; exists {
 			existing.TotalBetAmount += betAmount
@@ -81,10 +81,12 @@ func (mrs *MentorRewardService) DistributeMentorPoolRewards(milestoneID uint) (*
 
 	// 이미 분배된 경우 확인
 	if mentorPool.IsDistributed {
+		tx.Rollback()
 		return nil, fmt.Errorf("rewards already distributed for milestone %d", milestoneID)
 	}
 
 	if mentorPool.TotalPoolAmount <= 0 {
+		tx.Rollback()
 		log.Printf("📋 No funds in mentor pool for milestone %d", milestoneID)
 		return nil, nil
 	}
@@ -108,27 +110,33 @@ func (mrs *MentorRewardService) DistributeMentorPoolRewards(milestoneID uint) (*
 		return nil, nil
 	}
 
-	// 3. 보상 분배 실행
+	// 3. 보상 분배 실행 (멘토별 청구 가능한 MentorStakeReward 생성)
+	now := time.Now()
 	totalDistributed := int64(0)
-	for i, reward := range mentorRewards {
-		if err := mrs.distributeSingleReward(tx, milestoneID, &reward); err != nil {
-			log.Printf("❌ Failed to distribute reward to mentor %d: %v", reward.MentorID, err)
-			continue
+	for i := range mentorRewards {
+		if err := mrs.distributeSingleReward(tx, &mentorPool, &mentorRewards[i], now); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to distribute reward to mentor %d: %v", mentorRewards[i].MentorID, err)
 		}
-		totalDistributed += reward.RewardAmount
-		mentorRewards[i] = reward // 업데이트된 정보 반영
+		totalDistributed += mentorRewards[i].RewardAmount
+	}
+
+	// 4. 멘토 풀 상태 업데이트 (동시 분배 방지: 미분배 상태일 때만)
+	result := tx.Model(&models.MentorPool{}).
+		Where("id = ? AND is_distributed = ?", mentorPool.ID, false).
+		Updates(map[string]interface{}{
+			"is_distributed":         true,
+			"distributed_amount":     totalDistributed,
+			"distributed_at":         now,
+			"eligible_mentors_count": len(mentorRewards),
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update mentor pool: %v", result.Error)
 	}
-
-	// 4. 멘토 풀 상태 업데이트
-	now := time.Now()
-	mentorPool.IsDistributed = true
-	mentorPool.DistributedAmount = totalDistributed
-	mentorPool.DistributedAt = &now
-	mentorPool.EligibleMentorsCount = len(mentorRewards)
-
-	if err := tx.Save(&mentorPool).Error; err != nil {
+	if result.RowsAffected == 0 {
 		tx.Rollback()
-		return nil, fmt.Errorf("failed to update mentor pool: %v", err)
+		return nil, fmt.Errorf("rewards already distributed for milestone %d", milestoneID)
 	}
 
 	// 5. 트랜잭션 커밋
@@ -136,7 +144,7 @@ func (mrs *MentorRewardService) DistributeMentorPoolRewards(milestoneID uint) (*
 		return nil, fmt.Errorf("failed to commit transaction: %v", err)
 	}
 
-	result := &RewardDistributionResult{
+	distribution := &RewardDistributionResult{
 		MilestoneID:         milestoneID,
 		ProjectID:           mentorPool.ProjectID,
 		TotalPoolAmount:     mentorPool.TotalPoolAmount,
@@ -152,17 +160,19 @@ func (mrs *MentorRewardService) DistributeMentorPoolRewards(milestoneID uint) (*
 		float64(totalDistributed)/100, len(mentorRewards), milestoneID)
 
 	// 6. 실시간 알림
-	go mrs.broadcastRewardDistribution(result)
+	go mrs.broadcastRewardDistribution(distribution)
 
-	return result, nil
+	return distribution, nil
 }
 
 // calculateMentorRewards 멘토 보상 정보 계산
 func (mrs *MentorRewardService) calculateMentorRewards(tx *gorm.DB, milestoneID uint, pool *models.MentorPool) ([]MentorRewardInfo, error) {
-	// 자격 있는 멘토들 조회 (활성 멘토링을 한 멘토들만)
+	// 자격 있는 멘토들 조회 (활성 멘토링을 했고 활성 스테이킹이 있는 멘토들만)
 	var mentorMilestones []models.MentorMilestone
 	if err := tx.Where("milestone_id = ? AND is_active = ? AND actions_count > 0",
 		milestoneID, true).
+		Where("mentor_id IN (?)", tx.Model(&models.MentorStake{}).
+			Select("mentor_id").Where("status = ?", models.MentorStakeStatusActive)).
 		Preload("Mentor").Preload("Mentor.User").
 		Find(&mentorMilestones).Error; err != nil {
 		return nil, err
@@ -227,19 +237,54 @@ func (mrs *MentorRewardService) calculateMentorRewards(tx *gorm.DB, milestoneID
 		totalScore += score
 	}
 
-	// 보상 금액 계산
+	// 보상 금액 계산 (반올림 잔액은 최고 점수 멘토에게)
 	if totalScore > 0 {
+		allocated := int64(0)
+		top := 0
 		for i := range rewards {
 			rewards[i].RewardPercentage = (rewards[i].TotalScore / totalScore) * 100
 			rewards[i].RewardAmount = int64(float64(pool.TotalPoolAmount) * rewards[i].TotalScore / totalScore)
+			allocated += rewards[i].RewardAmount
+			if rewards[i].TotalScore > rewards[top].TotalScore {
+				top = i
+			}
 		}
+		rewards[top].RewardAmount += pool.TotalPoolAmount - allocated
 	}
 
 	return rewards, nil
 }
 
-// distributeSingleReward 개별 멘토에게 보상 지급
-func (mrs *MentorRewardService) distributeSingleReward(tx *gorm.DB, milestoneID uint, reward *MentorRewardInfo) error {
+// distributeSingleReward 개별 멘토 보상 확정 (지갑 입금은 멘토가 청구할 때 처리)
+func (mrs *MentorRewardService) distributeSingleReward(tx *gorm.DB, pool *models.MentorPool, reward *MentorRewardInfo, now time.Time) error {
+	milestoneID := pool.MilestoneID
+	if reward.RewardAmount <= 0 {
+		return nil
+	}
+
+	// 0. 청구 대기 보상 기록 (가장 큰 활성 스테이킹에 귀속)
+	var stake models.MentorStake
+	if err := tx.Where("mentor_id = ? AND status = ?", reward.MentorID, models.MentorStakeStatusActive).
+		Order("amount DESC").First(&stake).Error; err != nil {
+		return fmt.Errorf("active stake not found: %v", err)
+	}
+	stakeReward := models.MentorStakeReward{
+		StakeID:             stake.ID,
+		MentorID:            reward.MentorID,
+		RewardType:          models.MentorRewardTypeCompletion,
+		Amount:              reward.RewardAmount,
+		BonusMultiplier:     1,
+		PeriodStart:         pool.CreatedAt,
+		PeriodEnd:           now,
+		TriggerEvent:        fmt.Sprintf("milestone_completed:%d", milestoneID),
+		MilestonesCompleted: 1,
+		SatisfactionScore:   reward.MentorRating,
+		Status:              models.MentorStakeRewardPending,
+	}
+	if err := tx.Create(&stakeReward).Error; err != nil {
+		return fmt.Errorf("failed to create stake reward: %v", err)
+	}
+
 	// 1. MentorMilestone 보상 정보 업데이트
 	if err := tx.Model(&models.MentorMilestone{}).
 		Where("mentor_id = ? AND milestone_id = ?", reward.MentorID, milestoneID).
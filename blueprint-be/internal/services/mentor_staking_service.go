@@ -3,6 +3,7 @@ package services
 import (
 	"errors"
 	"fmt"
+	"log"
 	"math"
 	"time"
 
@@ -11,18 +12,31 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// slashReviewDelay 슬래싱 신고 접수 후 자동 검토 시작까지 대기 시간
+const slashReviewDelay = 1 * time.Hour
+
 // MentorStakingService 멘토 스테이킹 및 슬래싱 서비스
 type MentorStakingService struct {
-	db *gorm.DB
+	db                  *gorm.DB
+	notificationService *NotificationService
+	webhookPublisher    *WebhookPublisher
+	mediation           *MediationService // 신고 접수 시 조정 개시 (nil이면 바로 검토 대기)
 }
 
 // NewMentorStakingService 생성자
 func NewMentorStakingService(db *gorm.DB) *MentorStakingService {
 	return &MentorStakingService{
-		db: db,
+		db:                  db,
+		notificationService: NewNotificationService(db),
+		webhookPublisher:    NewWebhookPublisher(),
 	}
 }
 
+// SetMediation 멘토 신고를 배심원 중재 전 당사자 조정으로 먼저 보냄
+func (s *MentorStakingService) SetMediation(mediation *MediationService) {
+	s.mediation = mediation
+}
+
 // StakeMentor 멘토 스테이킹
 func (s *MentorStakingService) StakeMentor(req *models.StakeMentorRequest, userID uint) (*models.MentorStake, error) {
 	// 1. 멘토 존재 확인
@@ -156,55 +170,169 @@ func (s *MentorStakingService) ReportMentor(req *models.ReportMentorRequest, rep
 		return nil, fmt.Errorf("슬래싱 이벤트 생성 실패: %w", err)
 	}
 
-	// 7. 자동 검토 시작 (비동기)
-	go s.startSlashEventReview(slashEvent.ID)
+	// 7. 조정 개시 (조정 중에는 검토를 보류하고, 결렬되면 검토와 배심원 중재로 넘어감)
+	if s.mediation != nil && mentor.UserID != reporterID {
+		if _, err := s.mediation.OpenForMentorReport(slashEvent, mentor.UserID); err != nil {
+			log.Printf("❌ Failed to open mediation for slash event %d: %v", slashEvent.ID, err)
+		}
+	}
+
+	// 8. 검토 단계 전환은 mentor_slash_reviews 주기 작업이 slashReviewDelay 후 처리
+
+	return slashEvent, nil
+}
+
+// OpenSystemSlash 시스템 자동 슬래싱 접수 (신고자 없음, 같은 유형이 처리 중이면 건너뜀)
+func (s *MentorStakingService) OpenSystemSlash(mentorID uint, slashType models.MentorSlashType, severity models.SlashSeverity, reason, description, evidence string) (*models.MentorSlashEvent, error) {
+	var inFlight int64
+	s.db.Model(&models.MentorSlashEvent{}).
+		Where("mentor_id = ? AND slash_type = ? AND status IN ?", mentorID, slashType, []models.SlashEventStatus{
+			models.SlashEventStatusPending,
+			models.SlashEventStatusReviewing,
+		}).Count(&inFlight)
+	if inFlight > 0 {
+		return nil, nil
+	}
+
+	var activeStakes []models.MentorStake
+	if err := s.db.Where("mentor_id = ? AND status = ?", mentorID, models.MentorStakeStatusActive).
+		Find(&activeStakes).Error; err != nil {
+		return nil, fmt.Errorf("멘토 스테이킹 조회 실패: %w", err)
+	}
+	if len(activeStakes) == 0 {
+		return nil, errors.New("해당 멘토의 활성 스테이킹이 없습니다")
+	}
+
+	slashRate := s.calculateSlashRate(slashType, severity)
+	appealDeadline := time.Now().Add(7 * 24 * time.Hour)
+	slashEvent := &models.MentorSlashEvent{
+		MentorID:       mentorID,
+		SlashType:      slashType,
+		Severity:       severity,
+		SlashedAmount:  int64(float64(s.calculateTotalStaked(activeStakes)) * slashRate),
+		SlashRate:      slashRate,
+		Reason:         reason,
+		Description:    description,
+		Evidence:       evidence,
+		Status:         models.SlashEventStatusPending,
+		CanAppeal:      true,
+		AppealDeadline: &appealDeadline,
+	}
+	if err := s.db.Create(slashEvent).Error; err != nil {
+		return nil, fmt.Errorf("슬래싱 이벤트 생성 실패: %w", err)
+	}
 
 	return slashEvent, nil
 }
 
 // ProcessSlashing 슬래싱 실행
 func (s *MentorStakingService) ProcessSlashing(slashEventID uint, reviewerID uint, approved bool, comment string) error {
-	return s.db.Transaction(func(tx *gorm.DB) error {
-		// 1. 슬래싱 이벤트 조회
-		var slashEvent models.MentorSlashEvent
-		if err := tx.Preload("Mentor").First(&slashEvent, slashEventID).Error; err != nil {
-			return fmt.Errorf("슬래싱 이벤트 조회 실패: %w", err)
-		}
+	var slashed *models.MentorSlashEvent
 
-		// 2. 상태 확인
-		if slashEvent.Status != models.SlashEventStatusReviewing {
-			return errors.New("현재 검토 중인 슬래싱 이벤트가 아닙니다")
-		}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		slashed, err = s.processSlashingTx(tx, slashEventID, reviewerID, approved, comment)
+		return err
+	})
+	if err != nil {
+		return err
+	}
 
-		// 3. 검토 결과 업데이트
-		now := time.Now()
-		slashEvent.ReviewedBy = &reviewerID
-		slashEvent.ReviewComment = comment
-		slashEvent.ProcessedAt = &now
-
-		if approved {
-			slashEvent.Status = models.SlashEventStatusApproved
-			
-			// 4. 실제 슬래싱 실행
-			if err := s.executeSlashing(tx, &slashEvent); err != nil {
-				return fmt.Errorf("슬래싱 실행 실패: %w", err)
-			}
-		} else {
-			slashEvent.Status = models.SlashEventStatusRejected
-		}
+	// 6. 커밋 이후 멘토 및 스테이커에게 알림, 외부 웹훅 발행
+	if slashed != nil {
+		s.notifySlashing(slashed)
+		s.publishSlashWebhook(slashed)
+	}
 
-		if err := tx.Save(&slashEvent).Error; err != nil {
-			return fmt.Errorf("슬래싱 이벤트 업데이트 실패: %w", err)
-		}
+	return nil
+}
 
-		// 5. 멘토 성과 지표 업데이트
-		if approved {
-			if err := s.updateMentorPerformanceAfterSlash(tx, slashEvent.MentorID, &slashEvent); err != nil {
-				return fmt.Errorf("멘토 성과 지표 업데이트 실패: %w", err)
-			}
+// processSlashingTx 검토 중인 슬래싱 이벤트 확정 (승인 시 슬래싱된 이벤트 반환, 알림은 호출자가 커밋 후 발송)
+func (s *MentorStakingService) processSlashingTx(tx *gorm.DB, slashEventID uint, reviewerID uint, approved bool, comment string) (*models.MentorSlashEvent, error) {
+	// 1. 슬래싱 이벤트 조회
+	var slashEvent models.MentorSlashEvent
+	if err := tx.Preload("Mentor").First(&slashEvent, slashEventID).Error; err != nil {
+		return nil, fmt.Errorf("슬래싱 이벤트 조회 실패: %w", err)
+	}
+
+	// 2. 상태 확인
+	if slashEvent.Status != models.SlashEventStatusReviewing {
+		return nil, errors.New("현재 검토 중인 슬래싱 이벤트가 아닙니다")
+	}
+
+	// 3. 검토 결과 업데이트
+	now := time.Now()
+	slashEvent.ReviewedBy = &reviewerID
+	slashEvent.ReviewComment = comment
+	slashEvent.ProcessedAt = &now
+
+	if approved {
+		slashEvent.Status = models.SlashEventStatusApproved
+
+		// 4. 실제 슬래싱 실행
+		if err := s.executeSlashing(tx, &slashEvent); err != nil {
+			return nil, fmt.Errorf("슬래싱 실행 실패: %w", err)
 		}
+	} else {
+		slashEvent.Status = models.SlashEventStatusRejected
+	}
 
-		return nil
+	if err := tx.Save(&slashEvent).Error; err != nil {
+		return nil, fmt.Errorf("슬래싱 이벤트 업데이트 실패: %w", err)
+	}
+
+	// 5. 멘토 성과 지표 업데이트
+	if !approved {
+		return nil, nil
+	}
+	if err := s.updateMentorPerformanceAfterSlash(tx, slashEvent.MentorID, &slashEvent); err != nil {
+		return nil, fmt.Errorf("멘토 성과 지표 업데이트 실패: %w", err)
+	}
+	return &slashEvent, nil
+}
+
+// publishSlashWebhook 슬래싱 승인을 외부 웹훅으로 발행 (멘토 본인과 스테이커 대상)
+func (s *MentorStakingService) publishSlashWebhook(slashEvent *models.MentorSlashEvent) {
+	var userIDs []uint
+	s.db.Model(&models.MentorStake{}).
+		Where("mentor_id = ?", slashEvent.MentorID).
+		Distinct().Pluck("user_id", &userIDs)
+	userIDs = append(userIDs, slashEvent.Mentor.UserID)
+
+	s.webhookPublisher.PublishSlashApproved(slashEvent, userIDs)
+}
+
+// notifySlashing 슬래싱 확정 시 멘토와 해당 멘토 스테이커들에게 알림
+func (s *MentorStakingService) notifySlashing(slashEvent *models.MentorSlashEvent) {
+	data := map[string]interface{}{
+		"slash_event_id": slashEvent.ID,
+		"mentor_id":      slashEvent.MentorID,
+		"slashed_amount": slashEvent.SlashedAmount,
+	}
+
+	s.notificationService.Notify(models.CreateNotificationRequest{
+		UserID:   slashEvent.Mentor.UserID,
+		Type:     models.NotificationTypeMentorSlashed,
+		Priority: models.NotificationPriorityHigh,
+		Title:    "멘토 스테이킹이 슬래싱되었습니다",
+		Message:  fmt.Sprintf("사유: %s (슬래싱 금액: %d BLUEPRINT)", slashEvent.Reason, slashEvent.SlashedAmount),
+		Link:     "/mentors/my/dashboard",
+		Data:     data,
+	})
+
+	var stakerIDs []uint
+	if err := s.db.Model(&models.MentorStake{}).
+		Where("mentor_id = ? AND user_id != ?", slashEvent.MentorID, slashEvent.Mentor.UserID).
+		Distinct().Pluck("user_id", &stakerIDs).Error; err != nil {
+		return
+	}
+
+	s.notificationService.NotifyMany(stakerIDs, models.CreateNotificationRequest{
+		Type:    models.NotificationTypeMentorSlashed,
+		Title:   "스테이킹한 멘토가 슬래싱되었습니다",
+		Message: fmt.Sprintf("스테이킹한 멘토에게 슬래싱이 확정되었습니다. 사유: %s", slashEvent.Reason),
+		Link:    fmt.Sprintf("/mentors/%d/slash-events", slashEvent.MentorID),
+		Data:    data,
 	})
 }
 
@@ -363,6 +491,7 @@ func (s *MentorStakingService) CalculatePerformanceMetrics(mentorID uint, period
 	if err := s.db.Create(metric).Error; err != nil {
 		return nil, fmt.Errorf("성과 지표 저장 실패: %w", err)
 	}
+	MarkLeaderboardDirty(models.LeaderboardMentors)
 
 	return metric, nil
 }
@@ -466,18 +595,14 @@ func (s *MentorStakingService) updateMentorTotalStake(tx *gorm.DB, mentorID uint
 		Update("total_staked", totalStake).Error
 }
 
-func (s *MentorStakingService) startSlashEventReview(slashEventID uint) {
-	// 비동기 검토 프로세스 시작
-	// 실제 구현에서는 더 복잡한 검토 로직이 필요
-	time.Sleep(1 * time.Hour) // 1시간 후 자동 검토 시작
-	
-	var slashEvent models.MentorSlashEvent
-	if err := s.db.First(&slashEvent, slashEventID).Error; err != nil {
-		return
-	}
-
-	slashEvent.Status = models.SlashEventStatusReviewing
-	s.db.Save(&slashEvent)
+// StartPendingSlashReviews 신고 접수 후 slashReviewDelay가 지난 대기 이벤트를 검토 중으로 변경 (조정 중인 신고는 보류)
+func (s *MentorStakingService) StartPendingSlashReviews(now time.Time) (int64, error) {
+	result := s.db.Model(&models.MentorSlashEvent{}).
+		Where("status = ? AND created_at <= ?", models.SlashEventStatusPending, now.Add(-slashReviewDelay)).
+		Where("NOT EXISTS (SELECT 1 FROM mediations WHERE mediations.source_type = ? AND mediations.source_id = mentor_slash_events.id AND mediations.status = ?)",
+			models.MediationSourceMentorReport, models.MediationStatusOpen).
+		Update("status", models.SlashEventStatusReviewing)
+	return result.RowsAffected, result.Error
 }
 
 func (s *MentorStakingService) updateMentorPerformanceAfterSlash(tx *gorm.DB, mentorID uint, slashEvent *models.MentorSlashEvent) error {
@@ -557,28 +682,70 @@ func (s *MentorStakingService) calculateMilestoneStats(mentorID uint, startDate,
 
 func (s *MentorStakingService) calculateParticipationStats(mentorID uint, startDate, endDate time.Time) (map[string]interface{}, error) {
 	stats := make(map[string]interface{})
-	
-	// 기본값으로 채우기 (실제 구현에서는 정확한 데이터 필요)
-	stats["total_sessions"] = 10
-	stats["attendance_rate"] = 0.9
-	stats["response_time"] = 4 // 4시간
-	stats["session_rating"] = 4.5
-	
+
+	var totalSessions int64
+	s.db.Model(&models.MentoringSession{}).
+		Where("mentor_id = ? AND created_at BETWEEN ? AND ?", mentorID, startDate, endDate).
+		Count(&totalSessions)
+
+	feedback := s.feedbackStats(mentorID, startDate, endDate)
+
+	// 출석률은 멘티 불참 신고 기준, 평가가 없으면 기본값
+	attendanceRate := 0.9
+	sessionRating := 4.5
+	if feedback.Count > 0 {
+		attendanceRate = 1 - float64(feedback.NoShows)/float64(feedback.Count)
+		sessionRating = feedback.Average
+	}
+
+	stats["total_sessions"] = int(totalSessions)
+	stats["attendance_rate"] = attendanceRate
+	stats["response_time"] = 4 // 4시간 (실제 구현 필요)
+	stats["session_rating"] = sessionRating
+
 	return stats, nil
 }
 
 func (s *MentorStakingService) calculateSatisfactionStats(mentorID uint, startDate, endDate time.Time) (map[string]interface{}, error) {
 	stats := make(map[string]interface{})
-	
-	// 기본값으로 채우기 (실제 구현에서는 정확한 데이터 필요)
-	stats["mentee_rating"] = 4.3
-	stats["feedback_score"] = 4.2
-	stats["retention_rate"] = 0.85
-	stats["referral_rate"] = 0.3
-	
+
+	// 멘티 평가가 없으면 기본값 (신규 멘토 불이익 방지)
+	menteeRating := 4.3
+	feedbackScore := 4.2
+	if feedback := s.feedbackStats(mentorID, startDate, endDate); feedback.Count > 0 {
+		menteeRating = feedback.Average
+		feedbackScore = 5 * float64(feedback.Positive) / float64(feedback.Count) // 4점 이상 비율을 5점 척도로
+	}
+
+	stats["mentee_rating"] = menteeRating
+	stats["feedback_score"] = feedbackScore
+	stats["retention_rate"] = 0.85 // 실제 구현 필요
+	stats["referral_rate"] = 0.3   // 실제 구현 필요
+
 	return stats, nil
 }
 
+// mentorFeedbackStats 기간 내 멘티 평가 집계
+type mentorFeedbackStats struct {
+	Count    int64
+	Average  float64
+	Positive int64 // 4점 이상
+	Negative int64 // 2점 이하 (불만 접수로 집계)
+	NoShows  int64
+}
+
+func (s *MentorStakingService) feedbackStats(mentorID uint, startDate, endDate time.Time) mentorFeedbackStats {
+	var stats mentorFeedbackStats
+	s.db.Model(&models.MentorSessionFeedback{}).
+		Select(`COUNT(*) AS count, COALESCE(AVG(rating), 0) AS average,
+			COALESCE(SUM(CASE WHEN rating >= 4 THEN 1 ELSE 0 END), 0) AS positive,
+			COALESCE(SUM(CASE WHEN rating <= 2 THEN 1 ELSE 0 END), 0) AS negative,
+			COALESCE(SUM(CASE WHEN no_show THEN 1 ELSE 0 END), 0) AS no_shows`).
+		Where("mentor_id = ? AND created_at BETWEEN ? AND ?", mentorID, startDate, endDate).
+		Scan(&stats)
+	return stats
+}
+
 func (s *MentorStakingService) calculateEconomicStats(mentorID uint, startDate, endDate time.Time) (map[string]interface{}, error) {
 	stats := make(map[string]interface{})
 	
@@ -608,7 +775,7 @@ func (s *MentorStakingService) calculateRiskStats(mentorID uint, startDate, endD
 		Select("COALESCE(SUM(slashed_amount), 0)").
 		Scan(&slashedAmount)
 	
-	stats["complaints"] = 0      // 실제 구현 필요
+	stats["complaints"] = int(s.feedbackStats(mentorID, startDate, endDate).Negative)
 	stats["disputes"] = 0        // 실제 구현 필요
 	stats["slashes"] = int(slashCount)
 	stats["slashed_amount"] = slashedAmount
@@ -689,6 +856,22 @@ func (s *MentorStakingService) UnstakeMentor(stakeID uint, userID uint) error {
 	})
 }
 
+// SetAutoRenewal 자동 갱신 설정 변경 (잠금 해제일에 StakingRewardsService가 최소 기간만큼 연장)
+func (s *MentorStakingService) SetAutoRenewal(stakeID, userID uint, enabled bool) (*models.MentorStake, error) {
+	var stake models.MentorStake
+	if err := s.db.Where("id = ? AND user_id = ?", stakeID, userID).First(&stake).Error; err != nil {
+		return nil, fmt.Errorf("스테이킹을 찾을 수 없습니다: %w", err)
+	}
+	if stake.Status != models.MentorStakeStatusActive {
+		return nil, errors.New("활성화된 스테이킹이 아닙니다")
+	}
+
+	if err := s.db.Model(&stake).Update("is_auto_renewal", enabled).Error; err != nil {
+		return nil, fmt.Errorf("자동 갱신 설정 변경 실패: %w", err)
+	}
+	return &stake, nil
+}
+
 // GetUserStakes 사용자 스테이킹 목록 조회
 func (s *MentorStakingService) GetUserStakes(userID uint, page, limit int, status, stakeType string) (interface{}, error) {
 	offset := (page - 1) * limit
@@ -737,7 +920,7 @@ func (s *MentorStakingService) GetMentorStakeInfo(mentorID uint) (*models.Mentor
 		Order("created_at DESC").Limit(5).Find(&recentSlashes)
 
 	var pendingRewards []models.MentorStakeReward
-	s.db.Where("mentor_id = ? AND status = ?", mentorID, "pending").Find(&pendingRewards)
+	s.db.Where("mentor_id = ? AND status = ?", mentorID, models.MentorStakeRewardPending).Find(&pendingRewards)
 
 	totalStaked := s.calculateTotalStaked(stakes)
 	statistics := s.calculateMentorStatistics(mentorID, stakes)
@@ -768,6 +951,69 @@ func (s *MentorStakingService) GetMentorByUserID(userID uint) (*models.Mentor, e
 	return &mentor, nil
 }
 
+// ClaimRewards 청구 대기 중인 멘토 보상을 멘토 지갑(USDC)으로 입금
+func (s *MentorStakingService) ClaimRewards(userID uint) (*models.MentorRewardClaimResponse, error) {
+	mentor, err := s.GetMentorByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.MentorRewardClaimResponse{Rewards: []models.MentorStakeReward{}}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var rewards []models.MentorStakeReward
+		if err := tx.Where("mentor_id = ? AND status = ?", mentor.ID, models.MentorStakeRewardPending).
+			Order("created_at ASC").Find(&rewards).Error; err != nil {
+			return fmt.Errorf("보상 조회 실패: %w", err)
+		}
+
+		now := time.Now()
+		for _, reward := range rewards {
+			// 동시 청구 방지: 대기 상태일 때만 지급 처리
+			result := tx.Model(&models.MentorStakeReward{}).
+				Where("id = ? AND status = ?", reward.ID, models.MentorStakeRewardPending).
+				Updates(map[string]interface{}{
+					"status":         models.MentorStakeRewardDistributed,
+					"distributed_at": now,
+				})
+			if result.Error != nil {
+				return fmt.Errorf("보상 상태 업데이트 실패: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				continue
+			}
+
+			if err := postLedgerEntry(tx, &models.WalletLedgerEntry{
+				UserID:        mentor.UserID,
+				Currency:      models.LedgerCurrencyUSDC,
+				EntryType:     models.LedgerMentorReward,
+				Amount:        reward.Amount,
+				ReferenceType: "mentor_stake_reward",
+				ReferenceID:   reward.ID,
+				Memo:          reward.TriggerEvent,
+			}); err != nil {
+				return err
+			}
+
+			reward.Status = models.MentorStakeRewardDistributed
+			reward.DistributedAt = &now
+			response.Rewards = append(response.Rewards, reward)
+			response.TotalAmount += reward.Amount
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response.ClaimedCount = len(response.Rewards)
+	if response.ClaimedCount == 0 {
+		return nil, errors.New("청구할 멘토 보상이 없습니다")
+	}
+
+	log.Printf("💰 Mentor %d claimed %d rewards ($%.2f)", mentor.ID, response.ClaimedCount, float64(response.TotalAmount)/100)
+	return response, nil
+}
+
 // GetMentorDashboard 멘토 대시보드 조회
 func (s *MentorStakingService) GetMentorDashboard(mentorID uint) (*models.MentorDashboardResponse, error) {
 	var stakes []models.MentorStake
@@ -916,13 +1162,17 @@ func (s *MentorStakingService) calculateMentorStatistics(mentorID uint, stakes [
 
 	var totalRewards int64
 	s.db.Model(&models.MentorStakeReward{}).
-		Where("mentor_id = ? AND status = ?", mentorID, "distributed").
+		Where("mentor_id = ? AND status = ?", mentorID, models.MentorStakeRewardDistributed).
 		Select("COALESCE(SUM(amount), 0)").Scan(&totalRewards)
 
 	stats["total_staked"] = totalStaked
 	stats["total_slashed"] = totalSlashed
 	stats["total_rewards"] = totalRewards
-	stats["current_apy"] = 12.5 // 임시값
+	stats["current_apy"] = 0.0 // 최근 처리된 발행 에포크 기준 연환산 수익률
+	var latestEpoch models.StakingEpoch
+	if err := s.db.Order("epoch_number DESC").First(&latestEpoch).Error; err == nil {
+		stats["current_apy"] = latestEpoch.MentorAPY
+	}
 	stats["risk_score"] = 25.0  // 임시값
 	stats["slashing_history"] = 1 // 임시값
 	stats["staking_rank"] = 10     // 임시값
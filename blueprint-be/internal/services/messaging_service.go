@@ -0,0 +1,452 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 사용자 비공개 스트림 대화 이벤트
+const (
+	UserEventMessage     = "message"      // 새 메시지 (보낸 사람의 다른 기기 포함 모든 참여자)
+	UserEventMessageRead = "message_read" // 상대방 읽음 표시
+)
+
+// maxMessagePage 메시지 목록 한 번에 내려주는 최대 개수
+const maxMessagePage = 100
+
+var (
+	ErrConversationNotFound     = errors.New("대화를 찾을 수 없습니다")
+	ErrConversationForbidden    = errors.New("대화 참여자가 아닙니다")
+	ErrConversationClosed       = errors.New("종료된 멘토링/분쟁의 대화에는 메시지를 보낼 수 없습니다")
+	ErrConversationScope        = errors.New("진행 중인 멘토링 세션 또는 분쟁 사건에서만 대화를 열 수 있습니다")
+	ErrArbitrationCaseNotFound  = errors.New("분쟁 사건을 찾을 수 없습니다")
+	ErrEmptyMessage             = errors.New("메시지 내용이 비어 있습니다")
+	ErrMessageNotFound          = errors.New("메시지를 찾을 수 없습니다")
+	ErrMessageReportOwn         = errors.New("자신의 메시지는 신고할 수 없습니다")
+	ErrMessageAlreadyReported   = errors.New("이미 신고한 메시지입니다")
+	ErrMessageReportNotFound    = errors.New("신고를 찾을 수 없습니다")
+	ErrMessageReportNotReviewed = errors.New("이미 처리된 신고입니다")
+)
+
+// MessagingService 멘토링 세션/분쟁 사건 대화
+//
+// 대화는 범위(세션 또는 사건)당 하나이고 참여자는 범위가 정한다 (세션의 멘토와 멘티, 사건의 신청인과 피신청인).
+// 새 메시지와 읽음 표시는 참여자의 비공개 스트림(/stream/me)으로 전달되고, 참여자는 메시지를 운영자 검토
+// 대기열에 신고할 수 있다. 보관 기간이 지난 메시지는 message_retention 주기 작업이 지운다 (검토 중인 신고가 있으면 보존).
+type MessagingService struct {
+	db *gorm.DB
+}
+
+// NewMessagingService 생성자
+func NewMessagingService(db *gorm.DB) *MessagingService {
+	return &MessagingService{db: db}
+}
+
+// OpenConversation 범위의 대화방을 열거나 기존 대화방을 돌려준다 (요청자가 범위의 참여자여야 함)
+func (s *MessagingService) OpenConversation(scope models.ConversationScope, scopeID, userID uint) (*models.Conversation, error) {
+	participants, active, err := s.scopeParticipants(scope, scopeID)
+	if err != nil {
+		return nil, err
+	}
+	if !containsUserID(participants, userID) {
+		return nil, ErrConversationForbidden
+	}
+
+	var conversation models.Conversation
+	err = s.db.Where("scope = ? AND scope_id = ?", scope, scopeID).First(&conversation).Error
+	if err == nil {
+		return s.loadConversation(conversation.ID)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("대화 조회 실패: %w", err)
+	}
+	if !active {
+		return nil, ErrConversationScope
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		conversation = models.Conversation{Scope: scope, ScopeID: scopeID}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&conversation).Error; err != nil {
+			return fmt.Errorf("대화 생성 실패: %w", err)
+		}
+		if conversation.ID == 0 { // 동시에 다른 참여자가 먼저 열었음
+			return tx.Where("scope = ? AND scope_id = ?", scope, scopeID).First(&conversation).Error
+		}
+		for _, participantID := range participants {
+			if err := tx.Create(&models.ConversationParticipant{ConversationID: conversation.ID, UserID: participantID}).Error; err != nil {
+				return fmt.Errorf("참여자 추가 실패: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.loadConversation(conversation.ID)
+}
+
+// ListConversations 사용자가 참여한 대화 (최근 메시지 순, 안 읽은 메시지 수 포함)
+func (s *MessagingService) ListConversations(userID uint) ([]models.ConversationSummary, error) {
+	var conversations []models.Conversation
+	if err := s.db.Preload("Participants").
+		Joins("JOIN conversation_participants ON conversation_participants.conversation_id = conversations.id").
+		Where("conversation_participants.user_id = ?", userID).
+		Order("conversations.last_message_at DESC, conversations.id DESC").
+		Find(&conversations).Error; err != nil {
+		return nil, fmt.Errorf("대화 목록 조회 실패: %w", err)
+	}
+
+	summaries := make([]models.ConversationSummary, 0, len(conversations))
+	for _, conversation := range conversations {
+		summary := models.ConversationSummary{Conversation: conversation}
+		var lastRead uint
+		for _, participant := range conversation.Participants {
+			summary.ParticipantIDs = append(summary.ParticipantIDs, participant.UserID)
+			if participant.UserID == userID {
+				lastRead = participant.LastReadMessageID
+			}
+		}
+
+		var last models.Message
+		if err := s.db.Where("conversation_id = ?", conversation.ID).Order("id DESC").Limit(1).Find(&last).Error; err != nil {
+			return nil, fmt.Errorf("마지막 메시지 조회 실패: %w", err)
+		}
+		if last.ID != 0 {
+			summary.LastMessage = redactMessage(last)
+		}
+		if err := s.db.Model(&models.Message{}).
+			Where("conversation_id = ? AND id > ? AND sender_id <> ?", conversation.ID, lastRead, userID).
+			Count(&summary.UnreadCount).Error; err != nil {
+			return nil, fmt.Errorf("안 읽은 메시지 수 조회 실패: %w", err)
+		}
+		if _, active, err := s.scopeParticipants(conversation.Scope, conversation.ScopeID); err == nil {
+			summary.Writable = active
+		}
+		summary.Participants = nil
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// ListMessages 대화 메시지 (beforeID보다 오래된 것을 최신순으로, 0이면 가장 최근부터)
+func (s *MessagingService) ListMessages(conversationID, userID, beforeID uint, limit int) ([]models.Message, error) {
+	if _, err := s.participant(conversationID, userID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > maxMessagePage {
+		limit = maxMessagePage
+	}
+
+	query := s.db.Where("conversation_id = ?", conversationID)
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	var messages []models.Message
+	if err := query.Order("id DESC").Limit(limit).Find(&messages).Error; err != nil {
+		return nil, fmt.Errorf("메시지 조회 실패: %w", err)
+	}
+	for i := range messages {
+		messages[i] = *redactMessage(messages[i])
+	}
+	return messages, nil
+}
+
+// SendMessage 메시지 보내기 (범위가 진행 중일 때만, 참여자 스트림으로 전달)
+func (s *MessagingService) SendMessage(conversationID, senderID uint, body string) (*models.Message, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, ErrEmptyMessage
+	}
+
+	conversation, err := s.loadConversation(conversationID)
+	if err != nil {
+		return nil, err
+	}
+	participantIDs := conversationParticipantIDs(conversation)
+	if !containsUserID(participantIDs, senderID) {
+		return nil, ErrConversationForbidden
+	}
+	if _, active, err := s.scopeParticipants(conversation.Scope, conversation.ScopeID); err != nil {
+		return nil, err
+	} else if !active {
+		return nil, ErrConversationClosed
+	}
+
+	message := &models.Message{ConversationID: conversationID, SenderID: senderID, Body: body}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(message).Error; err != nil {
+			return fmt.Errorf("메시지 저장 실패: %w", err)
+		}
+		if err := tx.Model(&models.Conversation{}).Where("id = ?", conversationID).
+			UpdateColumn("last_message_at", message.CreatedAt).Error; err != nil {
+			return fmt.Errorf("대화 갱신 실패: %w", err)
+		}
+		// 보낸 메시지까지는 읽은 것으로
+		if err := tx.Model(&models.ConversationParticipant{}).
+			Where("conversation_id = ? AND user_id = ?", conversationID, senderID).
+			Updates(map[string]interface{}{"last_read_message_id": message.ID, "last_read_at": message.CreatedAt}).Error; err != nil {
+			return fmt.Errorf("읽음 위치 갱신 실패: %w", err)
+		}
+		if conversation.Scope == models.ConversationMentorship {
+			if err := tx.Model(&models.MentoringSession{}).Where("id = ?", conversation.ScopeID).
+				Updates(map[string]interface{}{
+					"messages_count":  gorm.Expr("messages_count + 1"),
+					"last_message_at": message.CreatedAt,
+				}).Error; err != nil {
+				return fmt.Errorf("세션 메시지 수 갱신 실패: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, participantID := range participantIDs {
+		publishUserEvent(participantID, UserEventMessage, message)
+	}
+	return message, nil
+}
+
+// MarkRead 읽음 표시 (messageID가 0이면 마지막 메시지까지, 뒤로는 움직이지 않음)
+func (s *MessagingService) MarkRead(conversationID, userID, messageID uint) (*models.ConversationParticipant, error) {
+	participant, err := s.participant(conversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest uint
+	if err := s.db.Model(&models.Message{}).Where("conversation_id = ?", conversationID).
+		Select("COALESCE(MAX(id), 0)").Scan(&latest).Error; err != nil {
+		return nil, fmt.Errorf("메시지 조회 실패: %w", err)
+	}
+	if messageID == 0 || messageID > latest {
+		messageID = latest
+	}
+	if messageID <= participant.LastReadMessageID {
+		return participant, nil
+	}
+
+	now := time.Now()
+	if err := s.db.Model(&models.ConversationParticipant{}).
+		Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+		Updates(map[string]interface{}{"last_read_message_id": messageID, "last_read_at": now}).Error; err != nil {
+		return nil, fmt.Errorf("읽음 위치 갱신 실패: %w", err)
+	}
+	participant.LastReadMessageID = messageID
+	participant.LastReadAt = &now
+
+	var others []uint
+	s.db.Model(&models.ConversationParticipant{}).
+		Where("conversation_id = ? AND user_id <> ?", conversationID, userID).
+		Pluck("user_id", &others)
+	for _, otherID := range others {
+		publishUserEvent(otherID, UserEventMessageRead, participant)
+	}
+	return participant, nil
+}
+
+// ReportMessage 참여자가 상대방 메시지를 운영자에게 신고
+func (s *MessagingService) ReportMessage(messageID, reporterID uint, reason string) (*models.MessageReport, error) {
+	var message models.Message
+	if err := s.db.First(&message, messageID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMessageNotFound
+		}
+		return nil, fmt.Errorf("메시지 조회 실패: %w", err)
+	}
+	if _, err := s.participant(message.ConversationID, reporterID); err != nil {
+		return nil, err
+	}
+	if message.SenderID == reporterID {
+		return nil, ErrMessageReportOwn
+	}
+
+	report := &models.MessageReport{
+		MessageID:  messageID,
+		ReporterID: reporterID,
+		Reason:     strings.TrimSpace(reason),
+		Status:     models.MessageReportOpen,
+	}
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(report)
+	if result.Error != nil {
+		return nil, fmt.Errorf("신고 저장 실패: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrMessageAlreadyReported
+	}
+	return report, nil
+}
+
+// ListReports 신고 목록 (운영자, 원문 포함)
+func (s *MessagingService) ListReports(status models.MessageReportStatus, limit, offset int) ([]models.MessageReport, int64, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	query := s.db.Model(&models.MessageReport{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("신고 수 조회 실패: %w", err)
+	}
+	var reports []models.MessageReport
+	if err := query.Preload("Message").Order("id DESC").Limit(limit).Offset(offset).Find(&reports).Error; err != nil {
+		return nil, 0, fmt.Errorf("신고 조회 실패: %w", err)
+	}
+	return reports, total, nil
+}
+
+// DismissReport 문제 없음으로 종결
+func (s *MessagingService) DismissReport(reportID, reviewerID uint, note string) (*models.MessageReport, error) {
+	return s.reviewReport(reportID, reviewerID, note, models.MessageReportDismissed)
+}
+
+// RemoveMessage 신고된 메시지를 숨기고, 같은 메시지의 열린 신고를 모두 종결
+func (s *MessagingService) RemoveMessage(reportID, reviewerID uint, note string) (*models.MessageReport, error) {
+	return s.reviewReport(reportID, reviewerID, note, models.MessageReportRemoved)
+}
+
+func (s *MessagingService) reviewReport(reportID, reviewerID uint, note string, status models.MessageReportStatus) (*models.MessageReport, error) {
+	var report models.MessageReport
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&report, reportID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrMessageReportNotFound
+			}
+			return err
+		}
+		if report.Status != models.MessageReportOpen {
+			return ErrMessageReportNotReviewed
+		}
+
+		now := time.Now()
+		review := map[string]interface{}{
+			"status":      status,
+			"reviewed_by": reviewerID,
+			"reviewed_at": now,
+			"review_note": note,
+		}
+		query := tx.Model(&models.MessageReport{}).Where("id = ?", report.ID)
+		if status == models.MessageReportRemoved {
+			if err := tx.Model(&models.Message{}).Where("id = ?", report.MessageID).
+				Updates(map[string]interface{}{"hidden": true, "hidden_by": reviewerID, "hidden_at": now}).Error; err != nil {
+				return fmt.Errorf("메시지 숨김 실패: %w", err)
+			}
+			query = tx.Model(&models.MessageReport{}).Where("message_id = ? AND status = ?", report.MessageID, models.MessageReportOpen)
+		}
+		if err := query.Updates(review).Error; err != nil {
+			return fmt.Errorf("신고 처리 실패: %w", err)
+		}
+		return tx.Preload("Message").First(&report, report.ID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// CleanupMessages before 이전 메시지 삭제 (열린 신고가 있는 메시지는 보존, 삭제한 수 반환)
+func (s *MessagingService) CleanupMessages(before time.Time) (int, error) {
+	reported := s.db.Model(&models.MessageReport{}).Select("message_id").Where("status = ?", models.MessageReportOpen)
+	expired := s.db.Model(&models.Message{}).Select("id").Where("created_at < ? AND id NOT IN (?)", before, reported)
+
+	if err := s.db.Where("message_id IN (?)", expired).Delete(&models.MessageReport{}).Error; err != nil {
+		return 0, fmt.Errorf("만료 메시지 신고 삭제 실패: %w", err)
+	}
+	result := s.db.Where("created_at < ? AND id NOT IN (?)", before, reported).Delete(&models.Message{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("만료 메시지 삭제 실패: %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
+
+// scopeParticipants 범위가 정한 참여자와 진행 중 여부
+func (s *MessagingService) scopeParticipants(scope models.ConversationScope, scopeID uint) ([]uint, bool, error) {
+	switch scope {
+	case models.ConversationMentorship:
+		var session models.MentoringSession
+		if err := s.db.Preload("Mentor").First(&session, scopeID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, false, ErrMentoringSessionNotFound
+			}
+			return nil, false, fmt.Errorf("멘토링 세션 조회 실패: %w", err)
+		}
+		active := session.Status == models.SessionStatusActive || session.Status == models.SessionStatusPaused
+		return []uint{session.Mentor.UserID, session.MenteeID}, active, nil
+
+	case models.ConversationArbitration:
+		var arbitrationCase models.ArbitrationCase
+		if err := s.db.First(&arbitrationCase, scopeID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, false, ErrArbitrationCaseNotFound
+			}
+			return nil, false, fmt.Errorf("분쟁 사건 조회 실패: %w", err)
+		}
+		active := arbitrationCase.Status != models.ArbitrationStatusClosed && arbitrationCase.Status != models.ArbitrationStatusRejected
+		return []uint{arbitrationCase.PlaintiffID, arbitrationCase.DefendantID}, active, nil
+	}
+	return nil, false, ErrConversationScope
+}
+
+// participant 대화 참여자 확인
+func (s *MessagingService) participant(conversationID, userID uint) (*models.ConversationParticipant, error) {
+	var participant models.ConversationParticipant
+	if err := s.db.Where("conversation_id = ? AND user_id = ?", conversationID, userID).First(&participant).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("참여자 조회 실패: %w", err)
+		}
+		var count int64
+		s.db.Model(&models.Conversation{}).Where("id = ?", conversationID).Count(&count)
+		if count == 0 {
+			return nil, ErrConversationNotFound
+		}
+		return nil, ErrConversationForbidden
+	}
+	return &participant, nil
+}
+
+func (s *MessagingService) loadConversation(conversationID uint) (*models.Conversation, error) {
+	var conversation models.Conversation
+	if err := s.db.Preload("Participants").First(&conversation, conversationID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrConversationNotFound
+		}
+		return nil, fmt.Errorf("대화 조회 실패: %w", err)
+	}
+	return &conversation, nil
+}
+
+func conversationParticipantIDs(conversation *models.Conversation) []uint {
+	ids := make([]uint, 0, len(conversation.Participants))
+	for _, participant := range conversation.Participants {
+		ids = append(ids, participant.UserID)
+	}
+	return ids
+}
+
+func containsUserID(ids []uint, userID uint) bool {
+	for _, id := range ids {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// redactMessage 숨김 처리된 메시지의 본문 제거
+func redactMessage(message models.Message) *models.Message {
+	if message.Hidden {
+		message.Body = ""
+	}
+	return &message
+}
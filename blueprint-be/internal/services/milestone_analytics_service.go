@@ -0,0 +1,228 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultMilestoneAnalyticsDays = 30
+	MaxMilestoneAnalyticsDays     = 90
+
+	// OrderBookDepthRetention 호가 잔량 스냅샷 보관 기간
+	OrderBookDepthRetention = MaxMilestoneAnalyticsDays * 24 * time.Hour
+
+	// holderConcentrationTop 상위 보유 비중 계산에 쓰는 보유자 수
+	holderConcentrationTop = 10
+)
+
+// ErrInvalidAnalyticsDays 조회 기간 범위 오류
+var ErrInvalidAnalyticsDays = errors.New("days는 1-90 사이여야 합니다")
+
+// MilestoneAnalyticsService 마일스톤 마켓 보유 분포/거래량/호가 잔량 추이 집계
+type MilestoneAnalyticsService struct {
+	db *gorm.DB
+}
+
+// NewMilestoneAnalyticsService 생성자
+func NewMilestoneAnalyticsService(db *gorm.DB) *MilestoneAnalyticsService {
+	return &MilestoneAnalyticsService{db: db}
+}
+
+// GetAnalytics 옵션별 보유자 수/집중도, 최근 days일 일별 거래량과 호가 잔량 스냅샷
+func (s *MilestoneAnalyticsService) GetAnalytics(milestoneID uint, days int) (*models.MilestoneAnalytics, error) {
+	if days < 1 || days > MaxMilestoneAnalyticsDays {
+		return nil, ErrInvalidAnalyticsDays
+	}
+
+	var milestone models.Milestone
+	if err := s.db.Select("id").First(&milestone, milestoneID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMilestoneNotFound
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	since := now.UTC().Truncate(24*time.Hour).AddDate(0, 0, -(days - 1))
+	analytics := &models.MilestoneAnalytics{MilestoneID: milestoneID, Days: days, ComputedAt: now}
+
+	holders, err := s.holderStats(milestoneID)
+	if err != nil {
+		return nil, err
+	}
+	analytics.Holders = holders
+
+	volume, err := s.dailyVolume(milestoneID, since, days)
+	if err != nil {
+		return nil, err
+	}
+	analytics.DailyVolume = volume
+
+	if err := s.db.Where("milestone_id = ? AND captured_at >= ?", milestoneID, since).
+		Order("captured_at ASC, option_id ASC").
+		Find(&analytics.DepthHistory).Error; err != nil {
+		return nil, fmt.Errorf("호가 잔량 추이 조회 실패: %w", err)
+	}
+	return analytics, nil
+}
+
+// holderStats 옵션별 보유자 수, 상위 10명 비중, 지니 계수 (매도 포지션은 제외)
+func (s *MilestoneAnalyticsService) holderStats(milestoneID uint) ([]models.OptionHolderStats, error) {
+	var positions []struct {
+		OptionID string
+		Quantity int64
+	}
+	if err := s.db.Model(&models.Position{}).
+		Select("option_id, SUM(quantity) AS quantity").
+		Where("milestone_id = ? AND quantity > 0", milestoneID).
+		Group("option_id, user_id").
+		Scan(&positions).Error; err != nil {
+		return nil, fmt.Errorf("보유 포지션 조회 실패: %w", err)
+	}
+
+	byOption := make(map[string][]int64)
+	for _, position := range positions {
+		byOption[position.OptionID] = append(byOption[position.OptionID], position.Quantity)
+	}
+
+	stats := make([]models.OptionHolderStats, 0, len(byOption))
+	for optionID, quantities := range byOption {
+		sort.Slice(quantities, func(i, j int) bool { return quantities[i] > quantities[j] })
+
+		var total, top int64
+		for i, quantity := range quantities {
+			total += quantity
+			if i < holderConcentrationTop {
+				top += quantity
+			}
+		}
+		stat := models.OptionHolderStats{
+			OptionID:    optionID,
+			Holders:     len(quantities),
+			TotalShares: total,
+			Gini:        giniCoefficient(quantities, total),
+		}
+		if total > 0 {
+			stat.Top10Share = roundRatio(float64(top) / float64(total))
+		}
+		stats = append(stats, stat)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].OptionID < stats[j].OptionID })
+	return stats, nil
+}
+
+// dailyVolume since부터 days일의 UTC 날짜별 체결량 (체결이 없는 날은 0)
+func (s *MilestoneAnalyticsService) dailyVolume(milestoneID uint, since time.Time, days int) ([]models.DailyVolume, error) {
+	var trades []struct {
+		Quantity    int64
+		TotalAmount int64
+		CreatedAt   time.Time
+	}
+	if err := s.db.Model(&models.Trade{}).
+		Select("quantity, total_amount, created_at").
+		Where("milestone_id = ? AND created_at >= ?", milestoneID, since).
+		Scan(&trades).Error; err != nil {
+		return nil, fmt.Errorf("체결 내역 조회 실패: %w", err)
+	}
+
+	volume := make([]models.DailyVolume, days)
+	for i := range volume {
+		volume[i].Date = since.AddDate(0, 0, i).Format("2006-01-02")
+	}
+	for _, trade := range trades {
+		day := int(trade.CreatedAt.UTC().Sub(since) / (24 * time.Hour))
+		if day < 0 || day >= days {
+			continue
+		}
+		volume[day].Volume += trade.TotalAmount
+		volume[day].Shares += trade.Quantity
+		volume[day].Trades++
+	}
+	return volume, nil
+}
+
+// SnapshotDepth 거래 가능한 마켓의 옵션별 호가 잔량 기록 (기록한 스냅샷 수 반환)
+func (s *MilestoneAnalyticsService) SnapshotDepth(now time.Time) (int, error) {
+	var sides []struct {
+		MilestoneID uint
+		OptionID    string
+		Side        models.OrderSide
+		Quantity    int64
+		Levels      int
+		HighTicks   int64
+		LowTicks    int64
+	}
+	if err := s.db.Model(&models.Order{}).
+		Select("orders.milestone_id, orders.option_id, orders.side, SUM(orders.remaining) AS quantity, COUNT(DISTINCT orders.price_ticks) AS levels, MAX(orders.price_ticks) AS high_ticks, MIN(orders.price_ticks) AS low_ticks").
+		Joins("JOIN milestones ON milestones.id = orders.milestone_id").
+		Where("orders.status IN ? AND orders.remaining > 0", []models.OrderStatus{models.OrderStatusPending, models.OrderStatusPartial}).
+		Where("milestones.status IN ?", models.TradableMilestoneStatuses).
+		Group("orders.milestone_id, orders.option_id, orders.side").
+		Scan(&sides).Error; err != nil {
+		return 0, fmt.Errorf("호가 잔량 집계 실패: %w", err)
+	}
+
+	type bookKey struct {
+		milestoneID uint
+		optionID    string
+	}
+	snapshots := make(map[bookKey]*models.OrderBookDepthSnapshot)
+	for _, side := range sides {
+		key := bookKey{side.MilestoneID, side.OptionID}
+		snapshot, ok := snapshots[key]
+		if !ok {
+			snapshot = &models.OrderBookDepthSnapshot{MilestoneID: side.MilestoneID, OptionID: side.OptionID, CapturedAt: now}
+			snapshots[key] = snapshot
+		}
+		if side.Side == models.OrderSideBuy {
+			snapshot.BidQuantity, snapshot.BidLevels, snapshot.BestBid = side.Quantity, side.Levels, models.TicksToPrice(side.HighTicks)
+		} else {
+			snapshot.AskQuantity, snapshot.AskLevels, snapshot.BestAsk = side.Quantity, side.Levels, models.TicksToPrice(side.LowTicks)
+		}
+	}
+	if len(snapshots) == 0 {
+		return 0, nil
+	}
+
+	rows := make([]models.OrderBookDepthSnapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		rows = append(rows, *snapshot)
+	}
+	if err := s.db.CreateInBatches(&rows, 200).Error; err != nil {
+		return 0, fmt.Errorf("호가 잔량 스냅샷 저장 실패: %w", err)
+	}
+	return len(rows), nil
+}
+
+// CleanupDepthSnapshots 보관 기간이 지난 호가 잔량 스냅샷 삭제
+func (s *MilestoneAnalyticsService) CleanupDepthSnapshots(before time.Time) (int, error) {
+	result := s.db.Where("captured_at < ?", before).Delete(&models.OrderBookDepthSnapshot{})
+	return int(result.RowsAffected), result.Error
+}
+
+// giniCoefficient 내림차순으로 정렬된 보유 수량의 지니 계수
+func giniCoefficient(descending []int64, total int64) float64 {
+	n := len(descending)
+	if n < 2 || total <= 0 {
+		return 0
+	}
+	// 오름차순 i(1부터)에 대해 G = 2Σ(i·x_i)/(nΣx) - (n+1)/n
+	var weighted float64
+	for i, quantity := range descending {
+		weighted += float64(n-i) * float64(quantity)
+	}
+	gini := 2*weighted/(float64(n)*float64(total)) - float64(n+1)/float64(n)
+	return roundRatio(gini)
+}
+
+func roundRatio(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
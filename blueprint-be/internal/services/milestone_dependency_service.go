@@ -0,0 +1,465 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrMilestoneNotFound          = errors.New("마일스톤을 찾을 수 없습니다")
+	ErrInvalidMilestoneDependency = errors.New("같은 프로젝트의 다른 마일스톤만 선행 마일스톤으로 지정할 수 있습니다")
+	ErrMilestoneDependencyCycle   = errors.New("마일스톤 선후 관계에 순환이 생깁니다")
+	ErrMilestoneDependenciesUnmet = errors.New("선행 마일스톤이 아직 완료되지 않았습니다")
+	ErrMilestoneDependencyStarted = errors.New("펀딩이 시작된 마일스톤의 선행 관계는 변경할 수 없습니다")
+)
+
+// reschedulableStatuses 선행 지연 시 목표일을 자동으로 미룰 수 있는 상태 (증거 제출 이후는 고정)
+var reschedulableStatuses = []models.MilestoneStatus{
+	models.MilestoneStatusProposal,
+	models.MilestoneStatusPending,
+	models.MilestoneStatusFunding,
+	models.MilestoneStatusActive,
+}
+
+// MilestoneDependencyView 선행/후행 마일스톤 요약
+type MilestoneDependencyView struct {
+	MilestoneID uint                   `json:"milestone_id"`
+	Title       string                 `json:"title"`
+	Status      models.MilestoneStatus `json:"status"`
+	TargetDate  *time.Time             `json:"target_date,omitempty"`
+	GapDays     int                    `json:"gap_days"`
+	Met         bool                   `json:"met"` // 선행 마일스톤이 완료됨
+}
+
+// MilestoneDependencies 마일스톤의 선후 관계
+type MilestoneDependencies struct {
+	MilestoneID uint                      `json:"milestone_id"`
+	DependsOn   []MilestoneDependencyView `json:"depends_on"`
+	Dependents  []MilestoneDependencyView `json:"dependents"`
+	Blocked     bool                      `json:"blocked"` // 미완료 선행 마일스톤이 있어 펀딩(마켓 개설)을 시작할 수 없음
+}
+
+// MilestoneDependencyService 마일스톤 선후 관계 그래프와 목표일 자동 조정
+//
+// 선행 마일스톤이 완료되어야 후행 마일스톤의 펀딩이 시작되며, 선행이 목표일을 넘기거나
+// 목표일이 늦춰지면 연결 시점의 간격(GapDays)을 유지하도록 후행 목표일을 미룬다.
+type MilestoneDependencyService struct {
+	db                  *gorm.DB
+	members             *ProjectMemberService
+	notificationService *NotificationService
+}
+
+// NewMilestoneDependencyService 생성자
+func NewMilestoneDependencyService(db *gorm.DB) *MilestoneDependencyService {
+	return &MilestoneDependencyService{
+		db:                  db,
+		members:             NewProjectMemberService(db),
+		notificationService: NewNotificationService(db),
+	}
+}
+
+// GetDependencies 선행/후행 마일스톤과 차단 여부 (비공개 프로젝트는 팀원만)
+func (s *MilestoneDependencyService) GetDependencies(milestoneID, userID uint) (*MilestoneDependencies, error) {
+	milestone, err := s.findMilestone(milestoneID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.members.Authorize(milestone.ProjectID, userID, func(models.ProjectRole) bool { return true }); err != nil {
+		return nil, err
+	}
+	return s.dependencies(milestone)
+}
+
+// SetDependencies 선행 마일스톤 목록 교체 (작성자/editor, 펀딩 시작 전에만)
+func (s *MilestoneDependencyService) SetDependencies(milestoneID, userID uint, dependsOn []uint) (*MilestoneDependencies, error) {
+	milestone, err := s.findMilestone(milestoneID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.members.Authorize(milestone.ProjectID, userID, models.ProjectRole.CanEdit); err != nil {
+		return nil, err
+	}
+	if milestone.Status != models.MilestoneStatusProposal && milestone.Status != models.MilestoneStatusPending {
+		return nil, ErrMilestoneDependencyStarted
+	}
+
+	upstreamIDs := uniqueIDs(dependsOn)
+	var upstreams []models.Milestone
+	if len(upstreamIDs) > 0 {
+		if err := s.db.Where("id IN ?", upstreamIDs).Find(&upstreams).Error; err != nil {
+			return nil, fmt.Errorf("선행 마일스톤 조회 실패: %w", err)
+		}
+	}
+	if len(upstreams) != len(upstreamIDs) {
+		return nil, ErrInvalidMilestoneDependency
+	}
+	for _, upstream := range upstreams {
+		if upstream.ID == milestone.ID || upstream.ProjectID != milestone.ProjectID {
+			return nil, ErrInvalidMilestoneDependency
+		}
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		graph, err := s.projectGraph(tx, milestone.ProjectID)
+		if err != nil {
+			return err
+		}
+		delete(graph, milestone.ID)
+		graph[milestone.ID] = upstreamIDs
+		if hasDependencyCycle(graph) {
+			return ErrMilestoneDependencyCycle
+		}
+
+		if err := tx.Where("milestone_id = ?", milestone.ID).Delete(&models.MilestoneDependency{}).Error; err != nil {
+			return fmt.Errorf("선행 관계 삭제 실패: %w", err)
+		}
+		if len(upstreams) == 0 {
+			return nil
+		}
+		rows := make([]models.MilestoneDependency, 0, len(upstreams))
+		for _, upstream := range upstreams {
+			rows = append(rows, models.MilestoneDependency{
+				MilestoneID: milestone.ID,
+				DependsOnID: upstream.ID,
+				GapDays:     gapDays(upstream.TargetDate, milestone.TargetDate),
+			})
+		}
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.RescheduleProject(milestone.ProjectID); err != nil {
+		log.Printf("⚠️ Failed to reschedule project %d after dependency change: %v", milestone.ProjectID, err)
+	}
+	return s.dependencies(milestone)
+}
+
+// UnmetDependencies 아직 완료되지 않은 선행 마일스톤
+func UnmetDependencies(db *gorm.DB, milestoneID uint) ([]models.Milestone, error) {
+	var upstreams []models.Milestone
+	err := db.Where("id IN (?) AND status <> ?",
+		db.Model(&models.MilestoneDependency{}).Select("depends_on_id").Where("milestone_id = ?", milestoneID),
+		models.MilestoneStatusCompleted).
+		Order("\"order\" ASC").
+		Find(&upstreams).Error
+	return upstreams, err
+}
+
+// dependenciesMet 선행 마일스톤이 모두 완료된 마일스톤만 남기는 조건 (milestones 테이블 쿼리용)
+func dependenciesMet(query *gorm.DB) *gorm.DB {
+	return query.Where("NOT EXISTS (SELECT 1 FROM milestone_dependencies d JOIN milestones u ON u.id = d.depends_on_id WHERE d.milestone_id = milestones.id AND u.deleted_at IS NULL AND u.status <> ?)",
+		models.MilestoneStatusCompleted)
+}
+
+// RescheduleProject 프로젝트 내 후행 마일스톤 목표일 조정 (위상 순서로 연쇄 적용, 조정된 마일스톤 수 반환)
+//
+// 완료되지 않은 선행 마일스톤의 예상 종료일은 목표일(이미 넘겼다면 다음 날)이며,
+// 후행 목표일이 예상 종료일 + GapDays보다 이르면 그만큼 미루고 증거 제출 마감일도 같이 미룬다.
+func (s *MilestoneDependencyService) RescheduleProject(projectID uint) (int, error) {
+	var milestones []models.Milestone
+	if err := s.db.Where("project_id = ?", projectID).Find(&milestones).Error; err != nil {
+		return 0, err
+	}
+	byID := make(map[uint]*models.Milestone, len(milestones))
+	for i := range milestones {
+		byID[milestones[i].ID] = &milestones[i]
+	}
+
+	var edges []models.MilestoneDependency
+	if err := s.db.Where("milestone_id IN ?", milestoneKeys(byID)).Find(&edges).Error; err != nil {
+		return 0, err
+	}
+	upstreamsOf := make(map[uint][]models.MilestoneDependency)
+	graph := make(map[uint][]uint)
+	for _, edge := range edges {
+		upstreamsOf[edge.MilestoneID] = append(upstreamsOf[edge.MilestoneID], edge)
+		graph[edge.MilestoneID] = append(graph[edge.MilestoneID], edge.DependsOnID)
+	}
+
+	// 목표일을 넘긴 선행 마일스톤은 다음 날 끝나는 것으로 보고 하루 단위로 미룬다 (매 주기마다 조정/알림하지 않도록)
+	slippedEnd := time.Now().Truncate(24 * time.Hour).Add(24 * time.Hour)
+	shifted := 0
+	for _, id := range topologicalOrder(graph) {
+		milestone := byID[id]
+		if milestone == nil || milestone.TargetDate == nil || !isReschedulable(milestone.Status) {
+			continue
+		}
+
+		var required time.Time
+		for _, edge := range upstreamsOf[id] {
+			upstream := byID[edge.DependsOnID]
+			if upstream == nil || upstream.TargetDate == nil || upstream.Status == models.MilestoneStatusCompleted {
+				continue
+			}
+			end := *upstream.TargetDate
+			if end.Before(time.Now()) {
+				end = slippedEnd
+			}
+			if candidate := end.AddDate(0, 0, edge.GapDays); candidate.After(required) {
+				required = candidate
+			}
+		}
+		if !required.After(*milestone.TargetDate) {
+			continue
+		}
+
+		previous := *milestone.TargetDate
+		if err := s.shiftTargetDate(milestone, required); err != nil {
+			log.Printf("❌ Failed to shift target date of milestone %d: %v", milestone.ID, err)
+			continue
+		}
+		shifted++
+		s.notifyShift(milestone, previous)
+	}
+	return shifted, nil
+}
+
+// RescheduleSlipped 목표일을 넘긴 미완료 선행 마일스톤이 있는 프로젝트의 후행 목표일 조정 (라이프사이클 서비스에서 주기적으로 호출)
+func (s *MilestoneDependencyService) RescheduleSlipped(ctx context.Context) error {
+	var projectIDs []uint
+	if err := s.db.WithContext(ctx).Model(&models.Milestone{}).
+		Where("status <> ? AND target_date < ?", models.MilestoneStatusCompleted, time.Now()).
+		Where("id IN (?)", s.db.Model(&models.MilestoneDependency{}).Select("depends_on_id")).
+		Distinct().Pluck("project_id", &projectIDs).Error; err != nil {
+		return err
+	}
+
+	for _, projectID := range projectIDs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if shifted, err := s.RescheduleProject(projectID); err != nil {
+			log.Printf("❌ Failed to reschedule project %d: %v", projectID, err)
+		} else if shifted > 0 {
+			log.Printf("📅 Shifted %d milestone target dates in project %d", shifted, projectID)
+		}
+	}
+	return nil
+}
+
+func (s *MilestoneDependencyService) shiftTargetDate(milestone *models.Milestone, target time.Time) error {
+	delta := target.Sub(*milestone.TargetDate)
+	updates := map[string]interface{}{"target_date": target}
+	if milestone.ProofDeadline != nil {
+		deadline := milestone.ProofDeadline.Add(delta)
+		updates["proof_deadline"] = deadline
+		milestone.ProofDeadline = &deadline
+	}
+	if err := s.db.Model(&models.Milestone{}).Where("id = ?", milestone.ID).UpdateColumns(updates).Error; err != nil {
+		return err
+	}
+	milestone.TargetDate = &target
+	return nil
+}
+
+// notifyShift 목표일 자동 조정을 프로젝트 작성자에게 알림
+func (s *MilestoneDependencyService) notifyShift(milestone *models.Milestone, previous time.Time) {
+	var ownerID uint
+	if err := s.db.Model(&models.Project{}).Where("id = ?", milestone.ProjectID).Pluck("user_id", &ownerID).Error; err != nil || ownerID == 0 {
+		return
+	}
+	if _, err := s.notificationService.Notify(models.CreateNotificationRequest{
+		UserID:  ownerID,
+		Type:    models.NotificationTypeMilestone,
+		Title:   "마일스톤 목표일이 조정되었습니다",
+		Message: fmt.Sprintf("선행 마일스톤 지연으로 '%s' 목표일이 %s에서 %s로 미뤄졌습니다.", milestone.Title, previous.Format("2006-01-02"), milestone.TargetDate.Format("2006-01-02")),
+		Link:    fmt.Sprintf("/milestones/%d", milestone.ID),
+		Data: map[string]interface{}{
+			"milestone_id":         milestone.ID,
+			"previous_target_date": previous,
+			"target_date":          milestone.TargetDate,
+		},
+	}); err != nil {
+		log.Printf("⚠️ Failed to notify target date shift for milestone %d: %v", milestone.ID, err)
+	}
+}
+
+func (s *MilestoneDependencyService) findMilestone(milestoneID uint) (*models.Milestone, error) {
+	var milestone models.Milestone
+	if err := s.db.First(&milestone, milestoneID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMilestoneNotFound
+		}
+		return nil, err
+	}
+	return &milestone, nil
+}
+
+func (s *MilestoneDependencyService) dependencies(milestone *models.Milestone) (*MilestoneDependencies, error) {
+	result := &MilestoneDependencies{
+		MilestoneID: milestone.ID,
+		DependsOn:   []MilestoneDependencyView{},
+		Dependents:  []MilestoneDependencyView{},
+	}
+
+	var upstreamEdges, downstreamEdges []models.MilestoneDependency
+	if err := s.db.Where("milestone_id = ?", milestone.ID).Find(&upstreamEdges).Error; err != nil {
+		return nil, fmt.Errorf("선행 관계 조회 실패: %w", err)
+	}
+	if err := s.db.Where("depends_on_id = ?", milestone.ID).Find(&downstreamEdges).Error; err != nil {
+		return nil, fmt.Errorf("후행 관계 조회 실패: %w", err)
+	}
+
+	ids := make([]uint, 0, len(upstreamEdges)+len(downstreamEdges))
+	for _, edge := range upstreamEdges {
+		ids = append(ids, edge.DependsOnID)
+	}
+	for _, edge := range downstreamEdges {
+		ids = append(ids, edge.MilestoneID)
+	}
+	if len(ids) == 0 {
+		return result, nil
+	}
+	var related []models.Milestone
+	if err := s.db.Where("id IN ?", ids).Order("\"order\" ASC, id ASC").Find(&related).Error; err != nil {
+		return nil, fmt.Errorf("연결된 마일스톤 조회 실패: %w", err)
+	}
+
+	upstreamGap := make(map[uint]int, len(upstreamEdges))
+	for _, edge := range upstreamEdges {
+		upstreamGap[edge.DependsOnID] = edge.GapDays
+	}
+	downstreamGap := make(map[uint]int, len(downstreamEdges))
+	for _, edge := range downstreamEdges {
+		downstreamGap[edge.MilestoneID] = edge.GapDays
+	}
+	for i := range related {
+		if gap, ok := upstreamGap[related[i].ID]; ok {
+			view := dependencyView(&related[i], gap)
+			result.DependsOn = append(result.DependsOn, view)
+			if !view.Met {
+				result.Blocked = true
+			}
+		}
+		if gap, ok := downstreamGap[related[i].ID]; ok {
+			result.Dependents = append(result.Dependents, dependencyView(&related[i], gap))
+		}
+	}
+	return result, nil
+}
+
+// projectGraph 프로젝트 내 선후 관계 (마일스톤 → 선행 마일스톤들)
+func (s *MilestoneDependencyService) projectGraph(tx *gorm.DB, projectID uint) (map[uint][]uint, error) {
+	var edges []models.MilestoneDependency
+	if err := tx.Where("milestone_id IN (?)", tx.Model(&models.Milestone{}).Select("id").Where("project_id = ?", projectID)).
+		Find(&edges).Error; err != nil {
+		return nil, fmt.Errorf("선후 관계 조회 실패: %w", err)
+	}
+	graph := make(map[uint][]uint)
+	for _, edge := range edges {
+		graph[edge.MilestoneID] = append(graph[edge.MilestoneID], edge.DependsOnID)
+	}
+	return graph, nil
+}
+
+func dependencyView(milestone *models.Milestone, gap int) MilestoneDependencyView {
+	return MilestoneDependencyView{
+		MilestoneID: milestone.ID,
+		Title:       milestone.Title,
+		Status:      milestone.Status,
+		TargetDate:  milestone.TargetDate,
+		GapDays:     gap,
+		Met:         milestone.Status == models.MilestoneStatusCompleted,
+	}
+}
+
+// hasDependencyCycle 선후 관계 그래프에 순환이 있는지 (DFS)
+func hasDependencyCycle(graph map[uint][]uint) bool {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[uint]int)
+	var visit func(id uint) bool
+	visit = func(id uint) bool {
+		switch state[id] {
+		case visiting:
+			return true
+		case done:
+			return false
+		}
+		state[id] = visiting
+		for _, upstream := range graph[id] {
+			if visit(upstream) {
+				return true
+			}
+		}
+		state[id] = done
+		return false
+	}
+	for id := range graph {
+		if visit(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// topologicalOrder 선행 마일스톤이 먼저 오는 순서 (순환 그래프는 만들어지지 않으므로 방문 여부만 확인)
+func topologicalOrder(graph map[uint][]uint) []uint {
+	visited := make(map[uint]bool)
+	order := []uint{}
+	var visit func(id uint)
+	visit = func(id uint) {
+		if visited[id] {
+			return
+		}
+		visited[id] = true
+		for _, upstream := range graph[id] {
+			visit(upstream)
+		}
+		order = append(order, id)
+	}
+	for id := range graph {
+		visit(id)
+	}
+	return order
+}
+
+func isReschedulable(status models.MilestoneStatus) bool {
+	for _, s := range reschedulableStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// gapDays 선행 목표일 → 후행 목표일 간격 (일 단위 내림, 날짜가 없거나 역순이면 0)
+func gapDays(upstream, downstream *time.Time) int {
+	if upstream == nil || downstream == nil || !downstream.After(*upstream) {
+		return 0
+	}
+	return int(math.Floor(downstream.Sub(*upstream).Hours() / 24))
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	result := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
+
+func milestoneKeys(m map[uint]*models.Milestone) []uint {
+	result := make([]uint, 0, len(m))
+	for id := range m {
+		result = append(result, id)
+	}
+	return result
+}
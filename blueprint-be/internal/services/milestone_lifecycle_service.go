@@ -3,7 +3,10 @@ package services
 import (
 	"blueprint-module/pkg/models"
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"math"
 	"strings"
 	"sync"
 	"time"
@@ -11,10 +14,25 @@ import (
 	"gorm.io/gorm"
 )
 
+var (
+	// ErrNotCategoricalMarket 이진 마켓에 대한 결과 옵션 판정 요청 (이진 마켓은 증거 검증으로 판정)
+	ErrNotCategoricalMarket = errors.New("다중 결과 마켓이 아닙니다")
+	ErrNotScalarMarket      = errors.New("수치 범위 마켓이 아닙니다")
+	ErrInvalidResolvedValue = errors.New("판정 값이 올바르지 않습니다")
+)
+
+// outcomeResolutionPath 결과 옵션 판정 시 승인 전까지 거치는 상태
+var outcomeResolutionPath = map[models.MilestoneStatus]models.MilestoneStatus{
+	models.MilestoneStatusActive:         models.MilestoneStatusProofSubmitted,
+	models.MilestoneStatusProofSubmitted: models.MilestoneStatusUnderVerification,
+}
+
 // 🔄 마일스톤 라이프사이클 자동 관리 서비스
 type MilestoneLifecycleService struct {
 	db                     *gorm.DB
 	fundingVerificationSvc *FundingVerificationService
+	stateMachine           *MilestoneStateMachine
+	dependencyService      *MilestoneDependencyService
 
 	// 스케줄러 관련
 	isRunning bool
@@ -32,6 +50,8 @@ func NewMilestoneLifecycleService(db *gorm.DB, fundingVerificationSvc *FundingVe
 	return &MilestoneLifecycleService{
 		db:                     db,
 		fundingVerificationSvc: fundingVerificationSvc,
+		stateMachine:           NewMilestoneStateMachine(db),
+		dependencyService:      NewMilestoneDependencyService(db),
 		isRunning:              false,
 		stopChan:               make(chan struct{}),
 		checkInterval:          time.Minute,      // 1분마다 체크
@@ -118,15 +138,30 @@ func (mls *MilestoneLifecycleService) processAllLifecycleStages() {
 	if err := mls.processEarlyFundingSuccess(ctx); err != nil {
 		log.Printf("❌ Error processing early funding success: %v", err)
 	}
+
+	// 4단계: 분쟁 기간이 지난 판정 확정 (승인→완료, 거절→재제출 또는 실패)
+	if err := mls.processDueResolutions(ctx); err != nil {
+		log.Printf("❌ Error processing due resolutions: %v", err)
+	}
+
+	// 5단계: 증거 제출 마감일을 넘긴 활성 마일스톤 실패 처리
+	if err := mls.processMissedProofDeadlines(ctx); err != nil {
+		log.Printf("❌ Error processing missed proof deadlines: %v", err)
+	}
+
+	// 6단계: 선행 마일스톤이 목표일을 넘기면 후행 마일스톤 목표일 조정
+	if err := mls.dependencyService.RescheduleSlipped(ctx); err != nil {
+		log.Printf("❌ Error rescheduling dependent milestones: %v", err)
+	}
 }
 
 // processProposalToFunding 제안 상태의 마일스톤들을 펀딩 단계로 전환
 func (mls *MilestoneLifecycleService) processProposalToFunding(ctx context.Context) error {
-	// 제안 상태이면서 생성된 지 일정 시간이 지난 마일스톤들 조회
+	// 제안 상태이면서 생성된 지 일정 시간이 지나고 선행 마일스톤이 모두 완료된 마일스톤들 조회
 	cutoffTime := time.Now().Add(-mls.autoStartFundingDelay)
 
 	var milestones []models.Milestone
-	if err := mls.db.WithContext(ctx).Where("status = ? AND created_at <= ?",
+	if err := dependenciesMet(mls.db.WithContext(ctx)).Where("status = ? AND created_at <= ?",
 		models.MilestoneStatusProposal, cutoffTime).Find(&milestones).Error; err != nil {
 
 		// 새로운 상태가 존재하지 않는 경우 (기존 데이터베이스) - 정상적인 상황
@@ -177,9 +212,9 @@ func (mls *MilestoneLifecycleService) processEarlyFundingSuccess(ctx context.Con
 		// 목표 달성 및 최소 펀딩 기간 경과 확인
 		if milestone.HasReachedMinViableCapital() && mls.hasMinFundingPeriodPassed(&milestone) {
 			// 즉시 활성화
-			milestone.Status = models.MilestoneStatusActive
-
-			if err := mls.db.WithContext(ctx).Save(&milestone).Error; err != nil {
+			if err := mls.stateMachine.Apply(&milestone, models.MilestoneStatusActive, TransitionOptions{
+				Reason: "펀딩 목표 조기 달성",
+			}); err != nil {
 				log.Printf("❌ Failed to activate milestone %d early: %v", milestone.ID, err)
 				continue
 			}
@@ -206,6 +241,58 @@ func (mls *MilestoneLifecycleService) processEarlyFundingSuccess(ctx context.Con
 	return nil
 }
 
+// processDueResolutions 분쟁 기간이 끝난 검증 판정을 최종 상태로 확정
+func (mls *MilestoneLifecycleService) processDueResolutions(ctx context.Context) error {
+	now := time.Now()
+
+	var milestones []models.Milestone
+	if err := mls.db.WithContext(ctx).Where("status IN ? AND resolution_due_at <= ?",
+		[]models.MilestoneStatus{models.MilestoneStatusProofApproved, models.MilestoneStatusProofRejected},
+		now).Find(&milestones).Error; err != nil {
+		return err
+	}
+
+	for _, milestone := range milestones {
+		next := models.MilestoneStatusCompleted
+		reason := "분쟁 기간 종료: 승인 확정"
+		if milestone.Status == models.MilestoneStatusProofRejected {
+			// 증거 제출 마감 전이면 재제출 기회 부여
+			if milestone.ProofDeadline != nil && now.Before(*milestone.ProofDeadline) {
+				next = models.MilestoneStatusActive
+				reason = "분쟁 기간 종료: 증거 재제출 가능"
+			} else {
+				next = models.MilestoneStatusFailed
+				reason = "분쟁 기간 종료: 거절 확정"
+			}
+		}
+
+		if err := mls.stateMachine.Apply(&milestone, next, TransitionOptions{Reason: reason}); err != nil {
+			log.Printf("❌ Failed to resolve milestone %d: %v", milestone.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// processMissedProofDeadlines 증거 제출 마감일이 지난 활성 마일스톤 실패 처리
+func (mls *MilestoneLifecycleService) processMissedProofDeadlines(ctx context.Context) error {
+	var milestones []models.Milestone
+	if err := mls.db.WithContext(ctx).Where("status = ? AND requires_proof = ? AND proof_deadline <= ?",
+		models.MilestoneStatusActive, true, time.Now()).Find(&milestones).Error; err != nil {
+		return err
+	}
+
+	for _, milestone := range milestones {
+		if err := mls.stateMachine.Apply(&milestone, models.MilestoneStatusFailed, TransitionOptions{
+			Reason: "증거 제출 마감일 경과",
+		}); err != nil {
+			log.Printf("❌ Failed to fail milestone %d: %v", milestone.ID, err)
+		}
+	}
+
+	return nil
+}
+
 // hasMinFundingPeriodPassed 최소 펀딩 기간이 지났는지 확인 (조기 활성화 남용 방지)
 func (mls *MilestoneLifecycleService) hasMinFundingPeriodPassed(milestone *models.Milestone) bool {
 	if milestone.FundingStartDate == nil {
@@ -258,6 +345,81 @@ func (mls *MilestoneLifecycleService) ForceStartFunding(milestoneID uint) error
 	return mls.fundingVerificationSvc.StartFundingPhase(milestoneID)
 }
 
+// ResolveOutcome 다중 결과 마켓의 승리 옵션 판정 (관리자용)
+//
+// 거래 중(active)이면 마켓을 동결하며 검증 단계로 옮긴 뒤 승인(proof_approved)으로 전환한다.
+// 이후 일반 판정과 같이 분쟁 기간이 지나면 완료로 확정된다.
+func (mls *MilestoneLifecycleService) ResolveOutcome(milestoneID uint, optionID string, actorID uint) (*models.Milestone, error) {
+	return mls.resolveMarket(milestoneID, actorID, fmt.Sprintf("결과 옵션 판정: %s", optionID), func(milestone *models.Milestone) error {
+		if !milestone.IsCategorical() {
+			return ErrNotCategoricalMarket
+		}
+		if !milestone.HasOption(optionID) {
+			return fmt.Errorf("%w: %s", ErrUnknownOption, optionID)
+		}
+		milestone.WinningOptionID = optionID
+		return nil
+	})
+}
+
+// ResolveScalar 수치 마켓의 판정 값 확정 (관리자용, 범위 밖 값은 양 끝으로 정산)
+func (mls *MilestoneLifecycleService) ResolveScalar(milestoneID uint, value float64, actorID uint) (*models.Milestone, error) {
+	return mls.resolveMarket(milestoneID, actorID, fmt.Sprintf("수치 판정: %g", value), func(milestone *models.Milestone) error {
+		if !milestone.IsScalar() {
+			return ErrNotScalarMarket
+		}
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return fmt.Errorf("%w: %g", ErrInvalidResolvedValue, value)
+		}
+		milestone.ResolvedValue = &value
+		return nil
+	})
+}
+
+// resolveMarket 판정 결과를 기록하고 검증 단계를 거쳐 승인(proof_approved)으로 전환
+func (mls *MilestoneLifecycleService) resolveMarket(milestoneID, actorID uint, reason string, apply func(*models.Milestone) error) (*models.Milestone, error) {
+	var milestone models.Milestone
+	var transitions []*MilestoneTransition
+
+	err := mls.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&milestone, milestoneID).Error; err != nil {
+			return err
+		}
+		result := milestone
+		if err := apply(&result); err != nil {
+			return err
+		}
+
+		opts := TransitionOptions{Reason: reason, ActorID: &actorID}
+		for milestone.Status != models.MilestoneStatusUnderVerification && milestone.Status != models.MilestoneStatusDisputed {
+			next, ok := outcomeResolutionPath[milestone.Status]
+			if !ok {
+				return fmt.Errorf("%w: %s 상태에서는 결과를 판정할 수 없습니다", ErrInvalidMilestoneTransition, milestone.Status)
+			}
+			transition, err := mls.stateMachine.Transition(tx, &milestone, next, opts)
+			if err != nil {
+				return err
+			}
+			transitions = append(transitions, transition)
+		}
+
+		milestone.WinningOptionID = result.WinningOptionID
+		milestone.ResolvedValue = result.ResolvedValue
+		transition, err := mls.stateMachine.Transition(tx, &milestone, models.MilestoneStatusProofApproved, opts)
+		if err != nil {
+			return err
+		}
+		transitions = append(transitions, transition)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mls.stateMachine.Dispatch(transitions...)
+	return &milestone, nil
+}
+
 // ForceProcessExpired 만료된 펀딩들 강제 처리 (관리자용)
 func (mls *MilestoneLifecycleService) ForceProcessExpired() error {
 	log.Printf("🔧 Force processing expired funding milestones")
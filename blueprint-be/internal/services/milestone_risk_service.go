@@ -0,0 +1,326 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/redis"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MilestoneRiskModel 위험 점수 모델 버전 (가중치를 바꾸면 올림)
+const MilestoneRiskModel = "logit-v1"
+
+const (
+	// milestoneRiskInactivityWindow 이 기간 동안 프로젝트 활동이 없으면 비활동 신호 최대
+	milestoneRiskInactivityWindow = 30 * 24 * time.Hour
+
+	// milestoneRiskBatchSize 한 번의 증분 계산에서 처리할 최대 프로젝트 수
+	milestoneRiskBatchSize = 500
+)
+
+// ErrMilestoneRiskNotComputed 아직 위험 점수가 계산되지 않은 마일스톤
+var ErrMilestoneRiskNotComputed = errors.New("위험 점수가 아직 계산되지 않았습니다")
+
+// riskScoredStatuses 위험 점수를 계산하는 (결과가 확정되지 않은) 상태
+var riskScoredStatuses = []models.MilestoneStatus{
+	models.MilestoneStatusFunding,
+	models.MilestoneStatusActive,
+	models.MilestoneStatusProofSubmitted,
+	models.MilestoneStatusUnderVerification,
+	models.MilestoneStatusProofRejected,
+	models.MilestoneStatusDisputed,
+}
+
+// milestoneRiskWeights 로지스틱 모델 가중치 (z = Bias + Σ 가중치 × 신호, 점수 = sigmoid(z))
+//
+// 신호가 모두 중립(마켓 0.5, 나머지 0)이면 약 32%, 모두 최대면 약 99%가 된다.
+var milestoneRiskWeights = struct {
+	Bias, Market, Schedule, ProofHistory, Inactivity float64
+}{
+	Bias:         -2.0,
+	Market:       2.5,
+	Schedule:     1.5,
+	ProofHistory: 1.5,
+	Inactivity:   1.0,
+}
+
+// MilestoneRiskService 마켓 가격, 증거 제출 적시성, 프로젝트 활동으로 마일스톤 실패 위험을 계산해 ProjectStatsCache에 저장
+type MilestoneRiskService struct {
+	db *gorm.DB
+}
+
+// NewMilestoneRiskService 생성자
+func NewMilestoneRiskService(db *gorm.DB) *MilestoneRiskService {
+	return &MilestoneRiskService{db: db}
+}
+
+// ScoreAll 진행 중 마일스톤이 있는 모든 프로젝트의 위험 점수 재계산 (처리한 프로젝트 수 반환)
+func (s *MilestoneRiskService) ScoreAll() (int, error) {
+	var projectIDs []uint
+	if err := s.db.Model(&models.Milestone{}).
+		Where("status IN ?", riskScoredStatuses).
+		Distinct().Pluck("project_id", &projectIDs).Error; err != nil {
+		return 0, fmt.Errorf("대상 프로젝트 조회 실패: %w", err)
+	}
+
+	scored := 0
+	for _, projectID := range projectIDs {
+		if _, err := s.ScoreProject(projectID); err != nil {
+			log.Printf("⚠️ Failed to score milestone risk for project %d: %v", projectID, err)
+			continue
+		}
+		scored++
+	}
+	return scored, nil
+}
+
+// ScoreChanged 바뀐 프로젝트(stats:dirty:projects)와 아직 점수가 없는 프로젝트만 재계산 (처리한 프로젝트 수 반환)
+//
+// 체결, 마일스톤 상태 전환, 프로젝트 활동 기록이 프로젝트를 표시한다. 시간이 지나며 바뀌는 일정/비활동 신호는
+// ScoreAll 전체 재계산이 맞춘다.
+func (s *MilestoneRiskService) ScoreChanged() (int, error) {
+	projectIDs := make(map[uint]bool)
+
+	members, err := redis.PopStatsDirty(redis.StatsScopeProjects, milestoneRiskBatchSize)
+	if err != nil {
+		log.Printf("⚠️ 위험 점수 재계산 대상 조회 실패: %v", err)
+	}
+	for _, member := range members {
+		if id, err := strconv.ParseUint(member, 10, 32); err == nil {
+			projectIDs[uint(id)] = true
+		}
+	}
+
+	var unscored []uint
+	if err := s.db.Model(&models.Milestone{}).
+		Joins("LEFT JOIN project_stats_caches ON project_stats_caches.project_id = milestones.project_id").
+		Where("milestones.status IN ? AND project_stats_caches.project_id IS NULL", riskScoredStatuses).
+		Limit(milestoneRiskBatchSize).
+		Distinct().Pluck("milestones.project_id", &unscored).Error; err != nil {
+		return 0, fmt.Errorf("대상 프로젝트 조회 실패: %w", err)
+	}
+	for _, id := range unscored {
+		projectIDs[id] = true
+	}
+
+	scored := 0
+	for projectID := range projectIDs {
+		if _, err := s.ScoreProject(projectID); err != nil {
+			log.Printf("⚠️ Failed to score milestone risk for project %d: %v", projectID, err)
+			MarkProjectStatsDirty(projectID) // 다음 주기에 다시 시도
+			continue
+		}
+		scored++
+	}
+	return scored, nil
+}
+
+// MarkProjectStatsDirty 프로젝트 지표(마일스톤 위험 점수) 재계산 요청
+func MarkProjectStatsDirty(projectID uint) {
+	if projectID == 0 {
+		return
+	}
+	if err := redis.MarkStatsDirty(redis.StatsScopeProjects, projectID); err != nil {
+		log.Printf("⚠️ 프로젝트 지표 재계산 요청 실패 (project %d): %v", projectID, err)
+	}
+}
+
+// MarkMilestoneStatsDirty 마일스톤이 속한 프로젝트의 지표 재계산 요청
+func MarkMilestoneStatsDirty(db *gorm.DB, milestoneID uint) {
+	var projectIDs []uint
+	if err := db.Model(&models.Milestone{}).Where("id = ?", milestoneID).Limit(1).Pluck("project_id", &projectIDs).Error; err != nil {
+		log.Printf("⚠️ 마일스톤 %d 프로젝트 조회 실패: %v", milestoneID, err)
+		return
+	}
+	if len(projectIDs) > 0 {
+		MarkProjectStatsDirty(projectIDs[0])
+	}
+}
+
+// ScoreProject 프로젝트의 진행 중 마일스톤 위험 점수 계산 및 저장
+func (s *MilestoneRiskService) ScoreProject(projectID uint) (*models.ProjectStatsCache, error) {
+	var milestones []models.Milestone
+	if err := s.db.Where("project_id = ? AND status IN ?", projectID, riskScoredStatuses).Find(&milestones).Error; err != nil {
+		return nil, fmt.Errorf("마일스톤 조회 실패: %w", err)
+	}
+
+	lastActivity, err := s.lastProjectActivity(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	stats := &models.ProjectStatsCache{
+		ProjectID:      projectID,
+		MilestoneRisks: make(map[uint]models.MilestoneRiskScore, len(milestones)),
+		RiskComputedAt: &now,
+	}
+	for i := range milestones {
+		factors, err := s.factors(&milestones[i], lastActivity, now)
+		if err != nil {
+			return nil, err
+		}
+		score := scoreMilestoneRisk(factors)
+		stats.MilestoneRisks[milestones[i].ID] = models.MilestoneRiskScore{
+			MilestoneID: milestones[i].ID,
+			Score:       score,
+			Level:       milestoneRiskLevel(score),
+			Factors:     factors,
+			Model:       MilestoneRiskModel,
+			ComputedAt:  now,
+		}
+		stats.MaxRiskScore = math.Max(stats.MaxRiskScore, score)
+	}
+
+	if err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "project_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"milestone_risks", "max_risk_score", "risk_computed_at", "updated_at"}),
+	}).Create(stats).Error; err != nil {
+		return nil, fmt.Errorf("프로젝트 지표 저장 실패: %w", err)
+	}
+	return stats, nil
+}
+
+// GetMilestoneRisk 마지막으로 계산된 마일스톤 위험 점수
+func (s *MilestoneRiskService) GetMilestoneRisk(milestoneID uint) (*models.MilestoneRiskScore, error) {
+	var projectID uint
+	if err := s.db.Model(&models.Milestone{}).Where("id = ?", milestoneID).Pluck("project_id", &projectID).Error; err != nil {
+		return nil, err
+	}
+	if projectID == 0 {
+		return nil, ErrMilestoneRiskNotComputed
+	}
+
+	var stats models.ProjectStatsCache
+	if err := s.db.First(&stats, "project_id = ?", projectID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMilestoneRiskNotComputed
+		}
+		return nil, err
+	}
+	risk, ok := stats.MilestoneRisks[milestoneID]
+	if !ok {
+		return nil, ErrMilestoneRiskNotComputed
+	}
+	return &risk, nil
+}
+
+// factors 마일스톤 위험 신호 계산
+func (s *MilestoneRiskService) factors(milestone *models.Milestone, lastActivity time.Time, now time.Time) (models.MilestoneRiskFactors, error) {
+	factors := models.MilestoneRiskFactors{Market: 0.5}
+
+	// 마켓: 이진 마켓의 성공 옵션 가격을 성공 확률로 본다 (다중 결과/수치 마켓은 중립)
+	if milestone.IsBinary() {
+		var prices []float64
+		if err := s.db.Model(&models.MarketData{}).
+			Where("milestone_id = ? AND option_id = ?", milestone.ID, models.OptionSuccess).
+			Limit(1).Pluck("current_price", &prices).Error; err != nil {
+			return factors, fmt.Errorf("마켓 가격 조회 실패: %w", err)
+		}
+		if len(prices) > 0 && prices[0] > 0 {
+			factors.Market = clampUnit(1 - prices[0])
+		}
+	}
+
+	// 증거 이력: 거절된 증거 비율
+	var proofs struct {
+		Total    int64
+		Rejected int64
+	}
+	if err := s.db.Model(&models.MilestoneProof{}).
+		Select("COUNT(*) AS total, COALESCE(SUM(CASE WHEN status = ? THEN 1 ELSE 0 END), 0) AS rejected", models.ProofStatusRejected).
+		Where("milestone_id = ?", milestone.ID).
+		Scan(&proofs).Error; err != nil {
+		return factors, fmt.Errorf("증거 이력 조회 실패: %w", err)
+	}
+	if proofs.Total > 0 {
+		factors.ProofHistory = float64(proofs.Rejected) / float64(proofs.Total)
+	}
+
+	factors.Schedule = scheduleRisk(milestone, now)
+	if !lastActivity.IsZero() {
+		factors.Inactivity = clampUnit(float64(now.Sub(lastActivity)) / float64(milestoneRiskInactivityWindow))
+	}
+	return factors, nil
+}
+
+// lastProjectActivity 프로젝트의 마지막 활동 시각 (프로젝트/마일스톤 수정, 증거 제출, 프로젝트 활동 기록 중 최근)
+func (s *MilestoneRiskService) lastProjectActivity(projectID uint) (time.Time, error) {
+	var project models.Project
+	if err := s.db.Select("id", "updated_at").First(&project, projectID).Error; err != nil {
+		return time.Time{}, fmt.Errorf("프로젝트 조회 실패: %w", err)
+	}
+	latest := project.UpdatedAt
+
+	candidates := []*gorm.DB{
+		s.db.Model(&models.Milestone{}).Where("project_id = ?", projectID).Order("updated_at DESC").Select("updated_at"),
+		s.db.Model(&models.MilestoneProof{}).
+			Joins("JOIN milestones ON milestones.id = milestone_proofs.milestone_id").
+			Where("milestones.project_id = ?", projectID).
+			Order("milestone_proofs.submitted_at DESC").Select("milestone_proofs.submitted_at"),
+		s.db.Model(&models.ActivityLog{}).Where("project_id = ?", projectID).Order("created_at DESC").Select("created_at"),
+	}
+	for _, query := range candidates {
+		var times []time.Time
+		if err := query.Limit(1).Scan(&times).Error; err != nil {
+			return time.Time{}, fmt.Errorf("프로젝트 활동 조회 실패: %w", err)
+		}
+		if len(times) > 0 && times[0].After(latest) {
+			latest = times[0]
+		}
+	}
+	return latest, nil
+}
+
+// scheduleRisk 목표일까지 경과 비율 (증거 제출 후에는 낮춤, 증거 없이 목표일/제출 마감을 넘기면 최대)
+func scheduleRisk(milestone *models.Milestone, now time.Time) float64 {
+	if milestone.Status != models.MilestoneStatusActive && milestone.Status != models.MilestoneStatusFunding {
+		return 0.1 // 증거 제출 이후 - 일정보다는 검증 결과에 달림
+	}
+	if milestone.ProofDeadline != nil && now.After(*milestone.ProofDeadline) {
+		return 1
+	}
+	if milestone.TargetDate == nil {
+		return 0.3
+	}
+
+	start := milestone.CreatedAt
+	if milestone.FundingStartDate != nil {
+		start = *milestone.FundingStartDate
+	}
+	total := milestone.TargetDate.Sub(start)
+	if total <= 0 || !now.Before(*milestone.TargetDate) {
+		return 1
+	}
+	return clampUnit(float64(now.Sub(start)) / float64(total))
+}
+
+// scoreMilestoneRisk 로지스틱 모델로 0~1 실패 위험 계산
+func scoreMilestoneRisk(f models.MilestoneRiskFactors) float64 {
+	w := milestoneRiskWeights
+	z := w.Bias + w.Market*f.Market + w.Schedule*f.Schedule + w.ProofHistory*f.ProofHistory + w.Inactivity*f.Inactivity
+	return math.Round(1/(1+math.Exp(-z))*1000) / 1000
+}
+
+func milestoneRiskLevel(score float64) models.MilestoneRiskLevel {
+	switch {
+	case score >= 0.66:
+		return models.MilestoneRiskHigh
+	case score >= 0.33:
+		return models.MilestoneRiskMedium
+	default:
+		return models.MilestoneRiskLow
+	}
+}
+
+func clampUnit(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
@@ -0,0 +1,421 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/redis"
+
+	"gorm.io/gorm"
+)
+
+// MilestoneDisputeWindow 검증 판정 후 분쟁 제기 가능 기간 (경과 시 판정 확정)
+const MilestoneDisputeWindow = 48 * time.Hour
+
+var (
+	ErrInvalidMilestoneTransition  = errors.New("허용되지 않는 마일스톤 상태 전환입니다")
+	ErrMilestoneTransitionConflict = errors.New("마일스톤 상태가 이미 변경되었습니다")
+	ErrWinningOptionRequired       = errors.New("다중 결과 마켓은 승인 전에 결과 옵션을 지정해야 합니다")
+	ErrResolvedValueRequired       = errors.New("수치 마켓은 승인 전에 판정 값을 지정해야 합니다")
+)
+
+// TransitionOptions 상태 전환 부가 정보
+type TransitionOptions struct {
+	Reason  string
+	ActorID *uint // nil이면 시스템 전환
+}
+
+// MilestoneTransition 완료된 상태 전환 (커밋 후 훅에 전달)
+type MilestoneTransition struct {
+	Milestone models.Milestone
+	From      models.MilestoneStatus
+	To        models.MilestoneStatus
+	Reason    string
+	ActorID   *uint
+}
+
+// MilestoneTransitionHook 상태 진입 시 실행되는 훅 (트랜잭션 커밋 이후 호출)
+type MilestoneTransitionHook func(t *MilestoneTransition)
+
+// MilestoneStateMachine 마일스톤 상태 전환의 단일 진입점
+//
+// 모든 상태 변경은 Transition을 거치며, 허용된 전환인지 검사하고
+// 이력을 남긴 뒤 커밋 후 Dispatch로 진입 훅(마켓 동결, 알림 등)을 실행한다.
+type MilestoneStateMachine struct {
+	db                  *gorm.DB
+	notificationService *NotificationService
+	watchlistService    *WatchlistService
+	webhookPublisher    *WebhookPublisher
+	hooks               map[models.MilestoneStatus][]MilestoneTransitionHook
+}
+
+// NewMilestoneStateMachine 생성자 (기본 훅 등록)
+func NewMilestoneStateMachine(db *gorm.DB) *MilestoneStateMachine {
+	sm := &MilestoneStateMachine{
+		db:                  db,
+		notificationService: NewNotificationService(db),
+		watchlistService:    NewWatchlistService(db),
+		webhookPublisher:    NewWebhookPublisher(),
+		hooks:               make(map[models.MilestoneStatus][]MilestoneTransitionHook),
+	}
+
+	for _, status := range []models.MilestoneStatus{
+		models.MilestoneStatusProofSubmitted,
+		models.MilestoneStatusFailed,
+		models.MilestoneStatusRejected,
+		models.MilestoneStatusCancelled,
+	} {
+		sm.OnEnter(status, sm.freezeMarket)
+	}
+	for _, status := range []models.MilestoneStatus{
+		models.MilestoneStatusProofApproved,
+		models.MilestoneStatusProofRejected,
+		models.MilestoneStatusDisputed,
+		models.MilestoneStatusCompleted,
+		models.MilestoneStatusFailed,
+		models.MilestoneStatusCancelled,
+	} {
+		sm.OnEnter(status, sm.notifyHolders)
+	}
+	sm.OnEnter(models.MilestoneStatusProofApproved, func(t *MilestoneTransition) {
+		sm.watchlistService.PublishMilestoneCompleted(&t.Milestone)
+	})
+	sm.OnEnter(models.MilestoneStatusCompleted, sm.publishResolved)
+	sm.OnEnter(models.MilestoneStatusFailed, sm.publishResolved)
+
+	// 결과 확정 시 보유 포지션을 옵션별 지급액으로 정산
+	settlementService := NewMarketSettlementService(db)
+	for _, status := range []models.MilestoneStatus{models.MilestoneStatusCompleted, models.MilestoneStatusFailed} {
+		sm.OnEnter(status, func(t *MilestoneTransition) {
+			if _, err := settlementService.SettleMarket(t.Milestone.ID); err != nil {
+				log.Printf("❌ Failed to settle market for milestone %d: %v", t.Milestone.ID, err)
+			}
+		})
+	}
+
+	// 멘토 풀: 완료 시 멘토별 보상 확정, 실패/취소 시 풀 종료
+	mentorRewardService := NewMentorRewardService(db, nil)
+	sm.OnEnter(models.MilestoneStatusCompleted, func(t *MilestoneTransition) {
+		if _, err := mentorRewardService.DistributeMentorPoolRewards(t.Milestone.ID); err != nil {
+			log.Printf("❌ Failed to distribute mentor pool for milestone %d: %v", t.Milestone.ID, err)
+		}
+	})
+	for _, status := range []models.MilestoneStatus{models.MilestoneStatusFailed, models.MilestoneStatusCancelled} {
+		sm.OnEnter(status, func(t *MilestoneTransition) {
+			if err := mentorRewardService.ProcessExpiredMilestonePools(t.Milestone.ID); err != nil {
+				log.Printf("❌ Failed to close mentor pool for milestone %d: %v", t.Milestone.ID, err)
+			}
+		})
+	}
+
+	// 직접 후원 에스크로: 완료 확정 시 프로젝트 소유자에게 지급, 펀딩 실패/실패/취소 시 후원자에게 반환
+	escrowService := NewFundingEscrowService(db, nil)
+	sm.OnEnter(models.MilestoneStatusCompleted, func(t *MilestoneTransition) {
+		if _, err := escrowService.ReleaseMilestone(t.Milestone.ID); err != nil {
+			log.Printf("❌ Failed to release escrow for milestone %d: %v", t.Milestone.ID, err)
+		}
+	})
+	for _, status := range []models.MilestoneStatus{models.MilestoneStatusRejected, models.MilestoneStatusFailed, models.MilestoneStatusCancelled} {
+		sm.OnEnter(status, func(t *MilestoneTransition) {
+			if _, err := escrowService.RefundMilestone(t.Milestone.ID); err != nil {
+				log.Printf("❌ Failed to refund escrow for milestone %d: %v", t.Milestone.ID, err)
+			}
+		})
+	}
+
+	// 창작자 수익: 멘토 보상 분배 후 남은 멘토 풀을 프로젝트 소유자에게 (적립만 하므로 계좌 암호화 키 불필요)
+	payoutService := NewCreatorPayoutService(db, "")
+	sm.OnEnter(models.MilestoneStatusCompleted, func(t *MilestoneTransition) {
+		if _, err := payoutService.CreditMentorPoolShare(t.Milestone.ID); err != nil {
+			log.Printf("❌ Failed to credit mentor pool share for milestone %d: %v", t.Milestone.ID, err)
+		}
+	})
+
+	// 증분 통계: 상태가 바뀐 마일스톤의 프로젝트 위험 점수, 판정이 난 검증의 검증인 리더보드
+	for _, status := range []models.MilestoneStatus{
+		models.MilestoneStatusFunding,
+		models.MilestoneStatusActive,
+		models.MilestoneStatusProofSubmitted,
+		models.MilestoneStatusUnderVerification,
+		models.MilestoneStatusProofApproved,
+		models.MilestoneStatusProofRejected,
+		models.MilestoneStatusDisputed,
+		models.MilestoneStatusCompleted,
+		models.MilestoneStatusFailed,
+		models.MilestoneStatusRejected,
+		models.MilestoneStatusCancelled,
+	} {
+		sm.OnEnter(status, func(t *MilestoneTransition) {
+			MarkProjectStatsDirty(t.Milestone.ProjectID)
+		})
+	}
+	for _, status := range []models.MilestoneStatus{models.MilestoneStatusProofApproved, models.MilestoneStatusProofRejected} {
+		sm.OnEnter(status, func(*MilestoneTransition) {
+			MarkLeaderboardDirty(models.LeaderboardValidators)
+		})
+	}
+
+	return sm
+}
+
+// OnEnter 상태 진입 훅 등록
+func (sm *MilestoneStateMachine) OnEnter(status models.MilestoneStatus, hook MilestoneTransitionHook) {
+	sm.hooks[status] = append(sm.hooks[status], hook)
+}
+
+// Transition 트랜잭션 안에서 상태 전환 (훅은 커밋 후 Dispatch로 실행)
+//
+// 조회 이후 다른 요청이 먼저 상태를 바꿨다면 ErrMilestoneTransitionConflict를 반환한다.
+func (sm *MilestoneStateMachine) Transition(tx *gorm.DB, milestone *models.Milestone, to models.MilestoneStatus, opts TransitionOptions) (*MilestoneTransition, error) {
+	from := milestone.Status
+	if !from.CanTransitionTo(to) {
+		return nil, fmt.Errorf("%w: %s → %s", ErrInvalidMilestoneTransition, from, to)
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":     to,
+		"updated_at": now,
+	}
+
+	// 판정 확정 예약: 승인/거절 판정 후 분쟁 기간이 지나면 라이프사이클 서비스가 확정
+	var resolutionDueAt *time.Time
+	if to == models.MilestoneStatusProofApproved || to == models.MilestoneStatusProofRejected {
+		due := now.Add(MilestoneDisputeWindow)
+		resolutionDueAt = &due
+	}
+	updates["resolution_due_at"] = resolutionDueAt
+
+	winningOptionID, err := winningOptionFor(milestone, to)
+	if err != nil {
+		return nil, err
+	}
+	updates["winning_option_id"] = winningOptionID
+	resolvedValue, err := resolvedValueFor(milestone, to)
+	if err != nil {
+		return nil, err
+	}
+	updates["resolved_value"] = resolvedValue
+
+	// 펀딩/검증 결과로 거래 가능 상태에 (재)진입하면 쿨다운 동안 주문을 받지 않음 (서킷브레이커)
+	var haltedUntil *time.Time
+	if to.IsTradable() && from != models.MilestoneStatusProposal {
+		until := now.Add(StatusChangeHaltDuration)
+		haltedUntil = &until
+		updates["trading_halted_until"] = until
+		updates["trading_halt_reason"] = models.TradingHaltStatusChange
+	}
+
+	result := tx.Model(&models.Milestone{}).
+		Where("id = ? AND status = ?", milestone.ID, from).
+		UpdateColumns(updates)
+	if result.Error != nil {
+		return nil, fmt.Errorf("마일스톤 상태 업데이트 실패: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrMilestoneTransitionConflict
+	}
+
+	milestone.Status = to
+	milestone.ResolutionDueAt = resolutionDueAt
+	milestone.WinningOptionID = winningOptionID
+	milestone.ResolvedValue = resolvedValue
+	milestone.UpdatedAt = now
+	if haltedUntil != nil {
+		milestone.TradingHaltedUntil = haltedUntil
+		milestone.TradingHaltReason = models.TradingHaltStatusChange
+	}
+
+	history := &models.MilestoneStatusHistory{
+		MilestoneID: milestone.ID,
+		FromStatus:  from,
+		ToStatus:    to,
+		Reason:      opts.Reason,
+		ActorID:     opts.ActorID,
+	}
+	if err := tx.Create(history).Error; err != nil {
+		return nil, fmt.Errorf("상태 전환 이력 저장 실패: %w", err)
+	}
+
+	log.Printf("🔀 Milestone %d: %s → %s (%s)", milestone.ID, from, to, opts.Reason)
+
+	return &MilestoneTransition{
+		Milestone: *milestone,
+		From:      from,
+		To:        to,
+		Reason:    opts.Reason,
+		ActorID:   opts.ActorID,
+	}, nil
+}
+
+// winningOptionFor 전환 후 판정 옵션
+//
+// 이진 마켓은 승인/완료면 success, 거절/실패면 fail로 정해진다. 다중 결과 마켓은 판정 시
+// 지정된 옵션(milestone.WinningOptionID)으로 승인/완료되고, 실패는 승자 없는 무효로 처리한다.
+// 수치 마켓은 승리 옵션 없이 판정 값(resolvedValueFor)으로 정산한다.
+func winningOptionFor(milestone *models.Milestone, to models.MilestoneStatus) (string, error) {
+	if milestone.IsScalar() {
+		return "", nil
+	}
+
+	switch to {
+	case models.MilestoneStatusProofApproved, models.MilestoneStatusCompleted:
+		if milestone.IsBinary() {
+			return models.OptionSuccess, nil
+		}
+		if milestone.WinningOptionID == "" || !milestone.HasOption(milestone.WinningOptionID) {
+			return "", ErrWinningOptionRequired
+		}
+		return milestone.WinningOptionID, nil
+	case models.MilestoneStatusProofRejected, models.MilestoneStatusFailed:
+		if milestone.IsBinary() {
+			return models.OptionFail, nil
+		}
+		return "", nil
+	case models.MilestoneStatusDisputed:
+		return milestone.WinningOptionID, nil // 분쟁 결과가 나올 때까지 판정 유지
+	default:
+		return "", nil
+	}
+}
+
+// resolvedValueFor 전환 후 수치 마켓 판정 값 (승인/완료에는 필수, 거절/실패는 무효로 비움)
+func resolvedValueFor(milestone *models.Milestone, to models.MilestoneStatus) (*float64, error) {
+	if !milestone.IsScalar() {
+		return nil, nil
+	}
+
+	switch to {
+	case models.MilestoneStatusProofApproved, models.MilestoneStatusCompleted:
+		if milestone.ResolvedValue == nil {
+			return nil, ErrResolvedValueRequired
+		}
+		return milestone.ResolvedValue, nil
+	case models.MilestoneStatusDisputed:
+		return milestone.ResolvedValue, nil
+	default:
+		return nil, nil
+	}
+}
+
+// Dispatch 커밋된 전환의 진입 훅 실행 (백그라운드)
+func (sm *MilestoneStateMachine) Dispatch(transitions ...*MilestoneTransition) {
+	for _, t := range transitions {
+		if t == nil {
+			continue
+		}
+		hooks := sm.hooks[t.To]
+		if len(hooks) == 0 {
+			continue
+		}
+		go func(t *MilestoneTransition) {
+			for _, hook := range hooks {
+				hook(t)
+			}
+		}(t)
+	}
+}
+
+// Apply 단독 전환 (자체 트랜잭션 + 커밋 후 훅 실행)
+func (sm *MilestoneStateMachine) Apply(milestone *models.Milestone, to models.MilestoneStatus, opts TransitionOptions) error {
+	var transition *MilestoneTransition
+	err := sm.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		transition, err = sm.Transition(tx, milestone, to, opts)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	sm.Dispatch(transition)
+	return nil
+}
+
+// GetHistory 마일스톤 상태 전환 이력 (오래된 순)
+func (sm *MilestoneStateMachine) GetHistory(milestoneID uint) ([]models.MilestoneStatusHistory, error) {
+	var history []models.MilestoneStatusHistory
+	if err := sm.db.Where("milestone_id = ?", milestoneID).
+		Order("created_at ASC, id ASC").Find(&history).Error; err != nil {
+		return nil, fmt.Errorf("상태 전환 이력 조회 실패: %w", err)
+	}
+	return history, nil
+}
+
+// freezeMarket 거래 불가 상태 진입 시 마켓 동결 알림 (신규 주문은 TradingService에서 거부)
+func (sm *MilestoneStateMachine) freezeMarket(t *MilestoneTransition) {
+	if !t.From.IsTradable() {
+		return
+	}
+	if redis.GetClient() == nil {
+		return
+	}
+
+	event := map[string]interface{}{
+		"type":         "market_frozen",
+		"milestone_id": t.Milestone.ID,
+		"status":       t.To,
+		"timestamp":    time.Now().Unix(),
+	}
+	if err := redis.BroadcastRealtimeUpdate(fmt.Sprintf("milestone_status:%d", t.Milestone.ID), event); err != nil {
+		log.Printf("⚠️ Failed to broadcast market freeze for milestone %d: %v", t.Milestone.ID, err)
+	}
+}
+
+// publishResolved 결과 확정을 외부 웹훅으로 발행 (프로젝트 소유자와 포지션 보유자 대상)
+func (sm *MilestoneStateMachine) publishResolved(t *MilestoneTransition) {
+	var userIDs []uint
+	sm.db.Model(&models.Position{}).
+		Where("milestone_id = ? AND quantity != 0", t.Milestone.ID).
+		Distinct().Pluck("user_id", &userIDs)
+
+	var ownerID uint
+	if err := sm.db.Model(&models.Project{}).Where("id = ?", t.Milestone.ProjectID).Pluck("user_id", &ownerID).Error; err == nil && ownerID != 0 {
+		userIDs = append(userIDs, ownerID)
+	}
+
+	sm.webhookPublisher.PublishMilestoneResolved(&t.Milestone, userIDs)
+}
+
+// notifyHolders 판정/종료 상태 진입을 포지션 보유자에게 알림
+func (sm *MilestoneStateMachine) notifyHolders(t *MilestoneTransition) {
+	var message string
+	switch t.To {
+	case models.MilestoneStatusProofApproved:
+		message = fmt.Sprintf("'%s' 마일스톤 증거가 승인되었습니다. %d시간의 분쟁 기간 후 결과가 확정됩니다.", t.Milestone.Title, int(MilestoneDisputeWindow.Hours()))
+	case models.MilestoneStatusProofRejected:
+		message = fmt.Sprintf("'%s' 마일스톤 증거가 거절되었습니다. %d시간의 분쟁 기간 후 결과가 확정됩니다.", t.Milestone.Title, int(MilestoneDisputeWindow.Hours()))
+	case models.MilestoneStatusDisputed:
+		message = fmt.Sprintf("'%s' 마일스톤 검증 결과에 분쟁이 제기되었습니다.", t.Milestone.Title)
+	case models.MilestoneStatusCompleted:
+		message = fmt.Sprintf("'%s' 마일스톤이 완료로 확정되었습니다.", t.Milestone.Title)
+	case models.MilestoneStatusFailed:
+		message = fmt.Sprintf("'%s' 마일스톤이 실패로 확정되었습니다.", t.Milestone.Title)
+	case models.MilestoneStatusCancelled:
+		message = fmt.Sprintf("'%s' 마일스톤이 취소되었습니다.", t.Milestone.Title)
+	default:
+		return
+	}
+
+	var holderIDs []uint
+	if err := sm.db.Model(&models.Position{}).
+		Where("milestone_id = ? AND quantity != 0", t.Milestone.ID).
+		Distinct().Pluck("user_id", &holderIDs).Error; err != nil {
+		return
+	}
+
+	sm.notificationService.NotifyMany(holderIDs, models.CreateNotificationRequest{
+		Type:    models.NotificationTypeMilestone,
+		Title:   "마일스톤 상태가 변경되었습니다",
+		Message: message,
+		Link:    fmt.Sprintf("/milestones/%d", t.Milestone.ID),
+		Data: map[string]interface{}{
+			"milestone_id": t.Milestone.ID,
+			"from_status":  t.From,
+			"to_status":    t.To,
+		},
+	})
+}
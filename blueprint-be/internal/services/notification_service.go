@@ -0,0 +1,292 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/queue"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPushEndpointInUse   = errors.New("다른 계정에 등록된 푸시 구독입니다")
+	ErrPushEndpointInvalid = errors.New("푸시 구독 주소는 https여야 합니다")
+)
+
+// NotificationService 인앱 알림 + 이메일/SMS/Web Push 발송 서비스
+type NotificationService struct {
+	db *gorm.DB
+}
+
+// NewNotificationService 생성자
+func NewNotificationService(db *gorm.DB) *NotificationService {
+	return &NotificationService{
+		db: db,
+	}
+}
+
+// Notify 알림 생성 후 사용자 설정에 따라 외부 채널로 발송
+func (s *NotificationService) Notify(req models.CreateNotificationRequest) (*models.Notification, error) {
+	if req.UserID == 0 {
+		return nil, errors.New("알림 대상 사용자가 없습니다")
+	}
+	if req.Priority == "" {
+		req.Priority = models.NotificationPriorityNormal
+	}
+
+	notification := &models.Notification{
+		UserID:   req.UserID,
+		Type:     req.Type,
+		Priority: req.Priority,
+		Title:    req.Title,
+		Message:  req.Message,
+		Link:     req.Link,
+	}
+	if len(req.Data) > 0 {
+		if data, err := json.Marshal(req.Data); err == nil {
+			notification.Data = string(data)
+		}
+	}
+
+	// 1. 인앱 수신함 저장
+	if err := s.db.Create(notification).Error; err != nil {
+		return nil, fmt.Errorf("알림 저장 실패: %w", err)
+	}
+
+	// 2. 연결된 비공개 스트림(/stream/me)으로 즉시 전달
+	publishUserEvent(notification.UserID, UserEventNotification, notification)
+
+	// 3. 외부 채널 발송 (실패해도 인앱 알림은 유지)
+	s.dispatch(notification)
+
+	return notification, nil
+}
+
+// NotifyMany 여러 사용자에게 동일한 알림 발송
+func (s *NotificationService) NotifyMany(userIDs []uint, req models.CreateNotificationRequest) {
+	for _, userID := range userIDs {
+		req.UserID = userID
+		if _, err := s.Notify(req); err != nil {
+			log.Printf("❌ 알림 발송 실패 (user %d): %v", userID, err)
+		}
+	}
+}
+
+// dispatch 사용자 알림 설정(UserProfile)에 따라 이메일/SMS/푸시 워커 큐로 전달
+func (s *NotificationService) dispatch(notification *models.Notification) {
+	var user models.User
+	if err := s.db.Preload("Profile").Preload("Verification").First(&user, notification.UserID).Error; err != nil {
+		log.Printf("⚠️ 알림 대상 사용자 조회 실패 (user %d): %v", notification.UserID, err)
+		return
+	}
+
+	updates := map[string]interface{}{}
+
+	if s.shouldSendEmail(&user, notification) {
+		emailJob := map[string]interface{}{
+			"type":     "send_email",
+			"to":       user.Email,
+			"template": "notification",
+			"data": map[string]interface{}{
+				"username": user.Username,
+				"title":    notification.Title,
+				"message":  notification.Message,
+				"link":     notification.Link,
+			},
+			"user_id":   user.ID,
+			"locale":    user.Profile.Locale,
+			"timestamp": time.Now().Unix(),
+		}
+		if err := queue.PublishJob("email_queue", emailJob); err != nil {
+			log.Printf("⚠️ 알림 이메일 큐 전송 실패 (notification %d): %v", notification.ID, err)
+		} else {
+			updates["email_queued"] = true
+		}
+	}
+
+	if s.shouldSendSMS(&user, notification) {
+		smsJob := map[string]interface{}{
+			"type":      "send_sms",
+			"to":        user.Verification.PhoneNumber,
+			"message":   fmt.Sprintf("[Blueprint] %s", notification.Title),
+			"user_id":   user.ID,
+			"timestamp": time.Now().Unix(),
+		}
+		if err := queue.PublishJob("sms_queue", smsJob); err != nil {
+			log.Printf("⚠️ 알림 SMS 큐 전송 실패 (notification %d): %v", notification.ID, err)
+		} else {
+			updates["sms_queued"] = true
+		}
+	}
+
+	if s.shouldSendPush(&user, notification) {
+		pushJob := map[string]interface{}{
+			"type":            "send_push",
+			"user_id":         user.ID,
+			"notification_id": notification.ID,
+			"title":           notification.Title,
+			"body":            notification.Message,
+			"url":             notification.Link,
+			"timestamp":       time.Now().Unix(),
+		}
+		if err := queue.PublishJob("push_queue", pushJob); err != nil {
+			log.Printf("⚠️ 알림 푸시 큐 전송 실패 (notification %d): %v", notification.ID, err)
+		} else {
+			updates["push_queued"] = true
+		}
+	}
+
+	if len(updates) > 0 {
+		s.db.Model(notification).Updates(updates)
+	}
+}
+
+// shouldSendEmail 이메일 발송 여부 (마케팅 알림은 별도 동의 필요)
+func (s *NotificationService) shouldSendEmail(user *models.User, notification *models.Notification) bool {
+	if user.Email == "" || user.Profile == nil {
+		return false
+	}
+	if notification.Type == models.NotificationTypeMarketing {
+		return user.Profile.MarketingNotifications
+	}
+	return user.Profile.EmailNotifications
+}
+
+// shouldSendSMS SMS 발송 여부 (휴대폰 인증 완료 + 중요 알림만)
+func (s *NotificationService) shouldSendSMS(user *models.User, notification *models.Notification) bool {
+	if notification.Priority != models.NotificationPriorityHigh {
+		return false
+	}
+	if user.Verification == nil || !user.Verification.PhoneVerified || user.Verification.PhoneNumber == "" {
+		return false
+	}
+	return true
+}
+
+// shouldSendPush Web Push 발송 여부 (푸시 동의 + 구독 기기 존재)
+func (s *NotificationService) shouldSendPush(user *models.User, notification *models.Notification) bool {
+	if user.Profile == nil || !user.Profile.PushNotifications {
+		return false
+	}
+	if notification.Type == models.NotificationTypeMarketing && !user.Profile.MarketingNotifications {
+		return false
+	}
+
+	var count int64
+	s.db.Model(&models.PushSubscription{}).Where("user_id = ?", user.ID).Count(&count)
+	return count > 0
+}
+
+// Subscribe Web Push 구독 등록 (본인 구독의 같은 endpoint면 갱신, 다른 계정 구독이면 ErrPushEndpointInUse)
+func (s *NotificationService) Subscribe(userID uint, req *models.SubscribePushRequest, userAgent string) (*models.PushSubscription, error) {
+	if endpoint, err := url.Parse(req.Endpoint); err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return nil, ErrPushEndpointInvalid
+	}
+
+	var subscription models.PushSubscription
+	err := s.db.Where("endpoint = ?", req.Endpoint).First(&subscription).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("푸시 구독 조회 실패: %w", err)
+	}
+	if err == nil && subscription.UserID != userID {
+		return nil, ErrPushEndpointInUse
+	}
+
+	subscription.UserID = userID
+	subscription.Endpoint = req.Endpoint
+	subscription.P256dh = req.Keys.P256dh
+	subscription.Auth = req.Keys.Auth
+	subscription.UserAgent = userAgent
+
+	if err := s.db.Save(&subscription).Error; err != nil {
+		return nil, fmt.Errorf("푸시 구독 저장 실패: %w", err)
+	}
+	return &subscription, nil
+}
+
+// Unsubscribe Web Push 구독 해제
+func (s *NotificationService) Unsubscribe(userID uint, endpoint string) error {
+	result := s.db.Where("user_id = ? AND endpoint = ?", userID, endpoint).Delete(&models.PushSubscription{})
+	if result.Error != nil {
+		return fmt.Errorf("푸시 구독 해제 실패: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("구독 정보를 찾을 수 없습니다")
+	}
+	return nil
+}
+
+// GetUserNotifications 사용자 알림 목록 조회
+func (s *NotificationService) GetUserNotifications(userID uint, unreadOnly bool, limit, offset int) (*models.NotificationListResponse, error) {
+	query := s.db.Model(&models.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("is_read = ?", false)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("알림 개수 조회 실패: %w", err)
+	}
+
+	var notifications []models.Notification
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&notifications).Error; err != nil {
+		return nil, fmt.Errorf("알림 목록 조회 실패: %w", err)
+	}
+
+	unreadCount, err := s.GetUnreadCount(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.NotificationListResponse{
+		Notifications: notifications,
+		UnreadCount:   unreadCount,
+		Total:         total,
+		Limit:         limit,
+		Offset:        offset,
+	}, nil
+}
+
+// GetUnreadCount 읽지 않은 알림 개수
+func (s *NotificationService) GetUnreadCount(userID uint) (int64, error) {
+	var count int64
+	if err := s.db.Model(&models.Notification{}).
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("읽지 않은 알림 개수 조회 실패: %w", err)
+	}
+	return count, nil
+}
+
+// MarkAsRead 알림 읽음 처리 (본인 알림만)
+func (s *NotificationService) MarkAsRead(userID, notificationID uint) error {
+	now := time.Now()
+	result := s.db.Model(&models.Notification{}).
+		Where("id = ? AND user_id = ?", notificationID, userID).
+		Updates(map[string]interface{}{"is_read": true, "read_at": &now})
+	if result.Error != nil {
+		return fmt.Errorf("알림 읽음 처리 실패: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("알림을 찾을 수 없습니다")
+	}
+	return nil
+}
+
+// MarkAllAsRead 모든 알림 읽음 처리
+func (s *NotificationService) MarkAllAsRead(userID uint) (int64, error) {
+	now := time.Now()
+	result := s.db.Model(&models.Notification{}).
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Updates(map[string]interface{}{"is_read": true, "read_at": &now})
+	if result.Error != nil {
+		return 0, fmt.Errorf("알림 읽음 처리 실패: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
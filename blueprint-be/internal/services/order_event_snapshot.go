@@ -0,0 +1,405 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	redisClient "github.com/redis/go-redis/v9"
+)
+
+// 📸 주문 이벤트 스냅샷 / 재생 / 압축
+//
+// 마켓별 이벤트 로그(events:{market})는 계속 늘어나므로 N개마다 재생 결과를 스냅샷(snapshot:{market})으로
+// 저장하고, 인수·감사 재생은 스냅샷 이후 이벤트만 읽는다. 스냅샷에 포함되고 보존 기간이 지난 이벤트는
+// 스트림에서 잘라낸다.
+
+// SnapshotPolicy 이벤트 스냅샷/압축 정책
+type SnapshotPolicy struct {
+	EveryEvents        int           // 마켓별 이벤트 N개마다 스냅샷 (0이면 자동 스냅샷 안 함)
+	Retention          time.Duration // 스냅샷에 포함된 이벤트도 이 기간은 스트림에 남김 (감사용)
+	CompactionInterval time.Duration // 담당 마켓 스트림 압축 주기
+}
+
+// DefaultSnapshotPolicy 기본 정책 (이벤트 1000개마다 스냅샷, 7일 보존, 1시간마다 압축)
+var DefaultSnapshotPolicy = SnapshotPolicy{
+	EveryEvents:        1000,
+	Retention:          7 * 24 * time.Hour,
+	CompactionInterval: time.Hour,
+}
+
+// ReplayBalance 이벤트 재생으로 집계한 사용자별 잔액 변화 (마켓 단위, 센트)
+type ReplayBalance struct {
+	UserID    uint  `json:"user_id"`
+	CashDelta int64 `json:"cash_delta"` // 매도 대금 - 매수 대금 - 수수료
+	FeesPaid  int64 `json:"fees_paid"`
+	Shares    int64 `json:"shares"` // 순 보유 수량 (매수 +, 매도 -)
+	Trades    int   `json:"trades"`
+}
+
+// OrderBookSnapshot 특정 이벤트까지 재생한 마켓 상태
+type OrderBookSnapshot struct {
+	MarketKey  string                  `json:"market_key"`
+	StreamID   string                  `json:"stream_id"`   // 마지막으로 반영한 이벤트의 스트림 ID
+	EventCount int64                   `json:"event_count"` // 스트림 처음부터 반영한 이벤트 수
+	Orders     []models.Order          `json:"orders"`      // 열린 주문 (접수 순서)
+	LastPrice  float64                 `json:"last_price"`
+	Balances   map[uint]*ReplayBalance `json:"balances"`
+	CreatedAt  time.Time               `json:"created_at"`
+}
+
+// OrderBookReplayer 이벤트를 순서대로 적용해 주문장과 잔액을 재구성
+//
+// 주문 생성 이벤트는 접수 시점 잔량으로 등록하고, 체결 이벤트마다 양쪽 주문의 잔량을 줄이며 잔액을 집계하고,
+// 취소/만료 이벤트는 주문을 제거한다. 남은 주문이 곧 현재 주문장이다.
+type OrderBookReplayer struct {
+	marketKey  string
+	orders     map[uint]*models.Order
+	sequence   []uint
+	lastPrice  float64
+	balances   map[uint]*ReplayBalance
+	streamID   string
+	eventCount int64
+}
+
+// NewOrderBookReplayer 스냅샷(없으면 빈 상태)에서 시작하는 재생기
+func NewOrderBookReplayer(marketKey string, snapshot *OrderBookSnapshot) *OrderBookReplayer {
+	replayer := &OrderBookReplayer{
+		marketKey: marketKey,
+		orders:    make(map[uint]*models.Order),
+		balances:  make(map[uint]*ReplayBalance),
+	}
+	if snapshot == nil {
+		return replayer
+	}
+
+	replayer.streamID = snapshot.StreamID
+	replayer.eventCount = snapshot.EventCount
+	replayer.lastPrice = snapshot.LastPrice
+	for i := range snapshot.Orders {
+		order := snapshot.Orders[i]
+		replayer.orders[order.ID] = &order
+		replayer.sequence = append(replayer.sequence, order.ID)
+	}
+	for userID, balance := range snapshot.Balances {
+		copied := *balance
+		replayer.balances[userID] = &copied
+	}
+	return replayer
+}
+
+// Apply 이벤트 하나 반영
+func (r *OrderBookReplayer) Apply(streamID string, event *OrderEvent) {
+	r.streamID = streamID
+	r.eventCount++
+
+	switch event.EventType {
+	case EventOrderCreated:
+		var order models.Order
+		if decodeEventPayload(event.Payload["order"], &order) != nil || order.ID == 0 {
+			return
+		}
+		prepareOrder(&order)
+		if _, exists := r.orders[order.ID]; !exists {
+			r.sequence = append(r.sequence, order.ID)
+		}
+		r.orders[order.ID] = &order
+	case EventTradeExecuted:
+		var trade models.Trade
+		if decodeEventPayload(event.Payload["trade"], &trade) != nil {
+			return
+		}
+		for _, orderID := range []uint{trade.BuyOrderID, trade.SellOrderID} {
+			if order, exists := r.orders[orderID]; exists {
+				order.Filled += trade.Quantity
+				order.Remaining -= trade.Quantity
+			}
+		}
+		r.lastPrice = trade.Price
+		r.applyTradeBalances(&trade)
+	case EventOrderCancelled, EventOrderExpired:
+		delete(r.orders, event.OrderID)
+	}
+}
+
+// applyTradeBalances 체결 대금/수수료/수량을 양쪽 사용자에 반영
+func (r *OrderBookReplayer) applyTradeBalances(trade *models.Trade) {
+	if trade.BuyerID != 0 {
+		buyer := r.balance(trade.BuyerID)
+		buyer.CashDelta -= trade.TotalAmount + trade.BuyerFee
+		buyer.FeesPaid += trade.BuyerFee
+		buyer.Shares += trade.Quantity
+		buyer.Trades++
+	}
+	if trade.SellerID != 0 {
+		seller := r.balance(trade.SellerID)
+		seller.CashDelta += trade.TotalAmount - trade.SellerFee
+		seller.FeesPaid += trade.SellerFee
+		seller.Shares -= trade.Quantity
+		seller.Trades++
+	}
+}
+
+func (r *OrderBookReplayer) balance(userID uint) *ReplayBalance {
+	balance, exists := r.balances[userID]
+	if !exists {
+		balance = &ReplayBalance{UserID: userID}
+		r.balances[userID] = balance
+	}
+	return balance
+}
+
+// OpenOrders 잔량이 남은 주문 (접수 순서)
+func (r *OrderBookReplayer) OpenOrders() []*models.Order {
+	open := make([]*models.Order, 0, len(r.orders))
+	for _, orderID := range r.sequence {
+		if order, exists := r.orders[orderID]; exists && order.Remaining > 0 {
+			open = append(open, order)
+		}
+	}
+	return open
+}
+
+// Balances 사용자 ID 순 잔액 변화
+func (r *OrderBookReplayer) Balances() []ReplayBalance {
+	balances := make([]ReplayBalance, 0, len(r.balances))
+	for _, balance := range r.balances {
+		balances = append(balances, *balance)
+	}
+	sort.Slice(balances, func(i, j int) bool { return balances[i].UserID < balances[j].UserID })
+	return balances
+}
+
+// LastPrice 마지막 체결가
+func (r *OrderBookReplayer) LastPrice() float64 {
+	return r.lastPrice
+}
+
+// StreamID 마지막으로 반영한 이벤트의 스트림 ID (없으면 빈 값)
+func (r *OrderBookReplayer) StreamID() string {
+	return r.streamID
+}
+
+// EventCount 스트림 처음부터 반영한 이벤트 수
+func (r *OrderBookReplayer) EventCount() int64 {
+	return r.eventCount
+}
+
+// Snapshot 현재 재생 상태를 스냅샷으로 (닫힌 주문은 버림)
+func (r *OrderBookReplayer) Snapshot() *OrderBookSnapshot {
+	snapshot := &OrderBookSnapshot{
+		MarketKey:  r.marketKey,
+		StreamID:   r.streamID,
+		EventCount: r.eventCount,
+		Orders:     []models.Order{},
+		LastPrice:  r.lastPrice,
+		Balances:   make(map[uint]*ReplayBalance, len(r.balances)),
+		CreatedAt:  time.Now(),
+	}
+	for _, order := range r.OpenOrders() {
+		snapshot.Orders = append(snapshot.Orders, *order)
+	}
+	for userID, balance := range r.balances {
+		copied := *balance
+		snapshot.Balances[userID] = &copied
+	}
+	return snapshot
+}
+
+// snapshotKey 마켓 스냅샷 키
+func snapshotKey(marketKey string) string {
+	return fmt.Sprintf("snapshot:%s", marketKey)
+}
+
+// SaveSnapshot 마켓 스냅샷 저장 (이미 더 많은 이벤트를 반영한 스냅샷이 있으면 덮어쓰지 않음)
+func (oes *OrderEventSourcing) SaveSnapshot(ctx context.Context, snapshot *OrderBookSnapshot) error {
+	existing, err := oes.LoadSnapshot(ctx, snapshot.MarketKey)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.EventCount > snapshot.EventCount {
+		return nil
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return oes.redisClient.Set(ctx, snapshotKey(snapshot.MarketKey), data, 0).Err()
+}
+
+// LoadSnapshot 마켓의 최신 스냅샷 (없으면 nil)
+func (oes *OrderEventSourcing) LoadSnapshot(ctx context.Context, marketKey string) (*OrderBookSnapshot, error) {
+	data, err := oes.redisClient.Get(ctx, snapshotKey(marketKey)).Bytes()
+	if err == redisClient.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot OrderBookSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("스냅샷 해석 실패 (%s): %w", marketKey, err)
+	}
+	return &snapshot, nil
+}
+
+// ReplayMarket 마켓 재생 (fromSnapshot이면 최신 스냅샷 이후 이벤트만, 아니면 스트림 처음부터)
+func (oes *OrderEventSourcing) ReplayMarket(ctx context.Context, marketKey string, fromSnapshot bool) (*OrderBookReplayer, error) {
+	var snapshot *OrderBookSnapshot
+	if fromSnapshot {
+		loaded, err := oes.LoadSnapshot(ctx, marketKey)
+		if err != nil {
+			return nil, err
+		}
+		snapshot = loaded
+	}
+
+	replayer := NewOrderBookReplayer(marketKey, snapshot)
+	if _, err := oes.ReplayEvents(ctx, marketKey, replayer.StreamID(), replayer.Apply); err != nil {
+		return nil, err
+	}
+	return replayer, nil
+}
+
+// CompactStream 스냅샷에 포함되고 보존 기간이 지난 이벤트를 스트림에서 제거하고 제거 건수 반환
+func (oes *OrderEventSourcing) CompactStream(ctx context.Context, marketKey string, retention time.Duration) (int64, error) {
+	snapshot, err := oes.LoadSnapshot(ctx, marketKey)
+	if err != nil || snapshot == nil || snapshot.StreamID == "" {
+		return 0, err
+	}
+
+	// 스냅샷 시점과 보존 기한 중 더 이른 쪽 이전만 제거
+	minID := snapshot.StreamID
+	cutoffID := fmt.Sprintf("%d-0", time.Now().Add(-retention).UnixMilli())
+	if compareStreamIDs(cutoffID, minID) < 0 {
+		minID = cutoffID
+	}
+
+	return oes.redisClient.XTrimMinID(ctx, fmt.Sprintf("events:%s", marketKey), minID).Result()
+}
+
+// MarketStreams 이벤트 로그가 있는 마켓 목록
+func (oes *OrderEventSourcing) MarketStreams(ctx context.Context) ([]string, error) {
+	var markets []string
+	iter := oes.redisClient.Scan(ctx, 0, "events:*", 100).Iterator()
+	for iter.Next(ctx) {
+		markets = append(markets, strings.TrimPrefix(iter.Val(), "events:"))
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	sort.Strings(markets)
+	return markets, nil
+}
+
+// compareStreamIDs Redis 스트림 ID("ms-seq") 비교
+func compareStreamIDs(a, b string) int {
+	aMillis, aSeq := parseStreamID(a)
+	bMillis, bSeq := parseStreamID(b)
+	switch {
+	case aMillis != bMillis:
+		if aMillis < bMillis {
+			return -1
+		}
+		return 1
+	case aSeq != bSeq:
+		if aSeq < bSeq {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+func parseStreamID(id string) (uint64, uint64) {
+	millisPart, seqPart, _ := strings.Cut(id, "-")
+	millis, _ := strconv.ParseUint(millisPart, 10, 64)
+	seq, _ := strconv.ParseUint(seqPart, 10, 64)
+	return millis, seq
+}
+
+// SetSnapshotPolicy 스냅샷/압축 정책 변경 (Start 전에 호출)
+func (dme *DistributedMatchingEngine) SetSnapshotPolicy(policy SnapshotPolicy) {
+	dme.snapshotPolicy = policy
+}
+
+// appendEvent 이벤트 기록 후 마켓별 건수를 세어 N개마다 백그라운드 스냅샷
+func (dme *DistributedMatchingEngine) appendEvent(marketKey string, event *OrderEvent) error {
+	if err := dme.eventSourcing.AppendEvent(dme.ctx, marketKey, event); err != nil {
+		return err
+	}
+	if dme.snapshotPolicy.EveryEvents <= 0 {
+		return nil
+	}
+
+	dme.eventCountMutex.Lock()
+	dme.eventCounts[marketKey]++
+	due := dme.eventCounts[marketKey] >= dme.snapshotPolicy.EveryEvents
+	if due {
+		dme.eventCounts[marketKey] = 0
+	}
+	dme.eventCountMutex.Unlock()
+
+	if due {
+		dme.wg.Add(1)
+		go func() {
+			defer dme.wg.Done()
+			if _, err := dme.SnapshotMarket(marketKey); err != nil {
+				log.Printf("⚠️ Failed to snapshot market %s: %v", marketKey, err)
+			}
+		}()
+	}
+	return nil
+}
+
+// SnapshotMarket 최신 스냅샷 이후 이벤트를 재생해 새 스냅샷 저장
+func (dme *DistributedMatchingEngine) SnapshotMarket(marketKey string) (*OrderBookSnapshot, error) {
+	dme.snapshotMutex.Lock()
+	defer dme.snapshotMutex.Unlock()
+
+	replayer, err := dme.eventSourcing.ReplayMarket(dme.ctx, marketKey, true)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := replayer.Snapshot()
+	if err := dme.eventSourcing.SaveSnapshot(dme.ctx, snapshot); err != nil {
+		return nil, err
+	}
+
+	log.Printf("📸 Snapshot of %s at event %d (%s, %d open orders)", marketKey, snapshot.EventCount, snapshot.StreamID, len(snapshot.Orders))
+	return snapshot, nil
+}
+
+// runStreamCompactor 담당 마켓 이벤트 스트림을 주기적으로 압축
+func (dme *DistributedMatchingEngine) runStreamCompactor() {
+	if dme.snapshotPolicy.CompactionInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(dme.snapshotPolicy.CompactionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-dme.ctx.Done():
+			return
+		case <-ticker.C:
+			for _, marketKey := range dme.ownership.OwnedMarkets() {
+				removed, err := dme.eventSourcing.CompactStream(dme.ctx, marketKey, dme.snapshotPolicy.Retention)
+				if err != nil {
+					log.Printf("⚠️ Failed to compact event stream %s: %v", marketKey, err)
+				} else if removed > 0 {
+					log.Printf("🗜️ Compacted %d events from %s", removed, marketKey)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// 매수 주문 USDC 잠금 수명 주기
+//
+//   - 접수: 지정가 기준 주문 금액과 최대 수수료(BuyReserveCents)를 사용 가능 잔액에서 잠금 (lockOrderFunds)
+//   - 체결: 체결 수량만큼 잠금을 풀어 체결 금액+수수료를 지불하고 남는 금액은 잔액으로 (tradePipeline.updateBuyerWallet)
+//   - 종료: 취소/펀딩 실패 환불 시 미체결분(Order.LockedCents) 해제 (closeOrder)
+//
+// 매도 주문 중 매도 가능 수량을 넘는 숏 매도분(Order.ShortQuantity)은 1주당 최대 손실(1 - 지정가)을 담보로 잠근다.
+// 체결되면 그 담보가 주문에서 숏 포지션(Position.Collateral)으로 옮겨지고, 환매하거나 마켓이 정산되면 해제된다.
+//
+// 마켓 결제 통화(Milestone.QuoteCurrency)가 USDC가 아니면 같은 수명 주기를 그 통화의 지갑 계정(WalletAccount)에서 따른다.
+// 숏 매도는 USDC 마켓에서만 받는다 (증거금 검사가 USDC 기준).
+//
+// 잔액은 모두 컬럼 표현식으로 갱신해 체결 후처리와 주문 접수/취소가 같은 지갑을 동시에 바꿔도 서로 덮어쓰지 않는다.
+// 부분 체결 후 취소하면 체결분은 체결 시점에, 나머지는 종료 시점에 풀려 합계가 접수 시 잠금액과 같다.
+
+var (
+	// ErrInsufficientBalance 매수 주문 금액을 잠글 결제 통화 잔액이 부족
+	ErrInsufficientBalance = errors.New("잔액이 부족합니다")
+	// ErrInsufficientCollateral 숏 매도 담보를 잠글 USDC가 부족
+	ErrInsufficientCollateral = errors.New("숏 매도 담보로 잠글 USDC가 부족합니다")
+	// ErrShortSellingUnavailable USDC가 아닌 결제 통화 마켓의 숏 매도
+	ErrShortSellingUnavailable = errors.New("USDC 결제 마켓에서만 보유 수량보다 많이 매도할 수 있습니다")
+	// ErrOrderNotOpen 이미 체결/취소되어 종료할 수 없는 주문
+	ErrOrderNotOpen = errors.New("이미 종료된 주문입니다")
+)
+
+// lockOrderFunds 사용 가능 USDC가 충분할 때만 주문 금액을 잠금
+func lockOrderFunds(tx *gorm.DB, userID uint, amount int64) error {
+	return lockFunds(tx, userID, models.LedgerCurrencyUSDC, amount)
+}
+
+// lockFunds 결제 통화의 사용 가능 잔액이 충분할 때만 잠금
+func lockFunds(tx *gorm.DB, userID uint, currency models.LedgerCurrency, amount int64) error {
+	if amount <= 0 {
+		return nil
+	}
+
+	scope, balanceColumn, lockedColumn := walletBalance(tx, userID, currency)
+	result := scope.
+		Where(balanceColumn+" >= ?", amount).
+		Updates(map[string]interface{}{
+			balanceColumn: gorm.Expr(balanceColumn+" - ?", amount),
+			lockedColumn:  gorm.Expr(lockedColumn+" + ?", amount),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("지갑 잠금 실패: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: 필요 %s", ErrInsufficientBalance, formatCurrencyAmount(currency, amount))
+	}
+	return nil
+}
+
+// lockShortCollateral 매도 가능 수량을 넘는 매도 수량을 계산하고 그만큼의 담보를 잠금 (숏 매도 수량 반환)
+func lockShortCollateral(tx *gorm.DB, userID uint, req models.CreateOrderRequest, priceTicks int64, currency models.LedgerCurrency) (int64, error) {
+	available, err := transferableQuantity(tx, userID, req.MilestoneID, req.OptionID, 0)
+	if err != nil {
+		return 0, err
+	}
+	short := req.Quantity - available
+	if short <= 0 {
+		return 0, nil
+	}
+	if currency != models.LedgerCurrencyUSDC {
+		return 0, fmt.Errorf("%w: 매도 가능 %d주", ErrShortSellingUnavailable, max(available, 0))
+	}
+
+	collateral := models.ShortCollateralCents(short, priceTicks)
+	if err := lockOrderFunds(tx, userID, collateral); err != nil {
+		if errors.Is(err, ErrInsufficientBalance) {
+			return 0, fmt.Errorf("%w: 숏 %d주, 필요 $%.2f", ErrInsufficientCollateral, short, float64(collateral)/100)
+		}
+		return 0, err
+	}
+	return short, nil
+}
+
+// releaseOrderFunds USDC 잠금액을 사용 가능 잔액으로 되돌림
+func releaseOrderFunds(tx *gorm.DB, userID uint, amount int64) error {
+	return releaseFunds(tx, userID, models.LedgerCurrencyUSDC, amount)
+}
+
+// releaseFunds 결제 통화 잠금액을 사용 가능 잔액으로 되돌림
+func releaseFunds(tx *gorm.DB, userID uint, currency models.LedgerCurrency, amount int64) error {
+	if amount <= 0 {
+		return nil
+	}
+
+	scope, balanceColumn, lockedColumn := walletBalance(tx, userID, currency)
+	result := scope.Updates(map[string]interface{}{
+		lockedColumn:  gorm.Expr(lockedColumn+" - ?", amount),
+		balanceColumn: gorm.Expr(balanceColumn+" + ?", amount),
+	})
+	if result.Error != nil {
+		return fmt.Errorf("지갑 잠금 해제 실패: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("사용자 %d의 지갑을 찾을 수 없습니다", userID)
+	}
+	return nil
+}
+
+// cancelOpenOrder 사용자 주문을 주문장에서 먼저 제거한 뒤 취소하고 미체결분 매수 잠금 해제
+// 엔진 구현(단일/분산)과 무관하게 같은 순서를 따라, 취소 이후에는 더 이상 체결되지 않는다
+// 주문장 제거를 확인하지 못하면(ErrCancelNotConfirmed) 주문 상태와 잠금을 그대로 둔다
+func cancelOpenOrder(db *gorm.DB, engine MatchingEngine, userID, orderID uint) (*models.CancelOrderResponse, error) {
+	var order models.Order
+	if err := db.Where("id = ? AND user_id = ?", orderID, userID).First(&order).Error; err != nil {
+		return nil, err
+	}
+	if order.Status != models.OrderStatusPending && order.Status != models.OrderStatusPartial {
+		return nil, fmt.Errorf("%w: %s", ErrOrderNotOpen, order.Status)
+	}
+
+	// 🔧 매칭 엔진에서 먼저 제거 (주문장 변경은 엔진이 SSE로 알림)
+	if err := engine.CancelOrder(&order); err != nil {
+		return nil, err
+	}
+
+	// 취소 직전까지의 체결 수량이 덮어써지지 않도록 대기 중인 상태를 먼저 반영한 뒤
+	// 상태 변경과 미체결분 매수 잠금 해제 (그 사이 전량 체결됐으면 ErrOrderNotOpen)
+	engine.FlushOrderStates()
+	var cancelled *models.Order
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		cancelled, err = closeOrder(tx, order.ID, models.OrderStatusCancelled)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	publishOrderClosed(cancelled)
+
+	return &models.CancelOrderResponse{
+		Order:          *cancelled,
+		ReleasedAmount: cancelled.LockedCents(),
+	}, nil
+}
+
+// closeOrder 열린 주문을 종료 상태로 바꾸고 미체결분 매수 잠금/숏 담보 해제
+// 체결 상태가 DB에 반영된 뒤(FlushOrderStates) 호출해야 해제액이 정확하다
+func closeOrder(tx *gorm.DB, orderID uint, status models.OrderStatus) (*models.Order, error) {
+	result := tx.Model(&models.Order{}).
+		Where("id = ? AND status IN ?", orderID, openOrderStatuses).
+		Update("status", status)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrOrderNotOpen, orderID)
+	}
+
+	var order models.Order
+	if err := tx.First(&order, orderID).Error; err != nil {
+		return nil, err
+	}
+	if err := releaseFunds(tx, order.UserID, order.QuoteCurrency.OrUSDC(), order.LockedCents()); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
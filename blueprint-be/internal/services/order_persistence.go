@@ -0,0 +1,200 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// orderPersistInterval 메모리에서 바뀐 주문 상태를 DB에 반영하는 주기
+	orderPersistInterval = 200 * time.Millisecond
+	// maxOrderPersistAttempts 아직 커밋되지 않은 주문(주문 생성 트랜잭션 진행 중)을 기다리는 최대 재시도 횟수
+	maxOrderPersistAttempts = 50
+)
+
+var openOrderStatuses = []models.OrderStatus{models.OrderStatusPending, models.OrderStatusPartial}
+
+// pendingOrderState DB 반영 대기 중인 주문 상태 (시장 액터가 복사한 값)
+type pendingOrderState struct {
+	Filled    int64
+	Remaining int64
+	Status    models.OrderStatus
+	UpdatedAt time.Time
+	attempts  int
+}
+
+// markOrderDirty 체결로 바뀐 주문 상태를 반영 대기열에 기록 (매칭 직후 호출)
+func (tp *tradePipeline) markOrderDirty(order *models.Order) {
+	tp.dirtyMutex.Lock()
+	tp.dirtyOrders[order.ID] = &pendingOrderState{
+		Filled:    order.Filled,
+		Remaining: order.Remaining,
+		Status:    order.Status,
+		UpdatedAt: time.Now(),
+	}
+	tp.dirtyMutex.Unlock()
+}
+
+// persistWorker 주기적으로 대기 중인 주문 상태를 DB에 반영 (write-behind)
+func (me *LocalMatchingEngine) persistWorker() {
+	ticker := time.NewTicker(orderPersistInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-me.stopChan:
+			return
+		case <-ticker.C:
+			me.FlushOrderStates()
+		}
+	}
+}
+
+// FlushOrderStates 대기 중인 주문 상태를 즉시 DB에 반영
+// 열린 주문만, 남은 수량이 줄어드는 방향으로만 갱신해 취소된 주문이나 더 최신 상태를 덮어쓰지 않는다
+func (tp *tradePipeline) FlushOrderStates() int {
+	tp.dirtyMutex.Lock()
+	if len(tp.dirtyOrders) == 0 {
+		tp.dirtyMutex.Unlock()
+		return 0
+	}
+	batch := tp.dirtyOrders
+	tp.dirtyOrders = make(map[uint]*pendingOrderState, len(batch))
+	tp.dirtyMutex.Unlock()
+
+	persisted := 0
+	for orderID, state := range batch {
+		result := tp.db.Model(&models.Order{}).
+			Where("id = ? AND status IN ? AND remaining >= ?", orderID, openOrderStatuses, state.Remaining).
+			Updates(map[string]interface{}{
+				"filled":     state.Filled,
+				"remaining":  state.Remaining,
+				"status":     state.Status,
+				"updated_at": state.UpdatedAt,
+			})
+		if result.Error == nil && result.RowsAffected > 0 {
+			persisted++
+			continue
+		}
+
+		if result.Error == nil {
+			// 행이 보이면 이미 종료됐거나 더 최신 상태 → 버림, 안 보이면 주문 생성 트랜잭션 커밋 대기
+			var count int64
+			tp.db.Model(&models.Order{}).Where("id = ?", orderID).Count(&count)
+			if count > 0 {
+				continue
+			}
+		} else {
+			log.Printf("⚠️ Failed to persist order %d state: %v", orderID, result.Error)
+		}
+		tp.requeueOrderState(orderID, state)
+	}
+	return persisted
+}
+
+// requeueOrderState 실패한 반영을 다음 주기로 넘김 (그 사이 더 새로운 상태가 기록됐으면 그것을 우선)
+func (tp *tradePipeline) requeueOrderState(orderID uint, state *pendingOrderState) {
+	state.attempts++
+	if state.attempts >= maxOrderPersistAttempts {
+		log.Printf("❌ Giving up persisting order %d state (filled %d, remaining %d) - recovered from trades on restart",
+			orderID, state.Filled, state.Remaining)
+		return
+	}
+
+	tp.dirtyMutex.Lock()
+	if _, newer := tp.dirtyOrders[orderID]; !newer {
+		tp.dirtyOrders[orderID] = state
+	}
+	tp.dirtyMutex.Unlock()
+}
+
+// reconcileOrdersWithTrades 저장된 체결 내역 기준으로 열린 주문의 체결 수량 복구 (재시작 시)
+// 체결은 주문 상태보다 먼저 저장되므로, DB 주문이 체결보다 뒤처져 있으면 체결 합계에 맞춰 올린다
+func (me *LocalMatchingEngine) reconcileOrdersWithTrades(orders []models.Order) []models.Order {
+	if len(orders) == 0 {
+		return orders
+	}
+
+	filledByOrder, err := tradeFilledByOrder(me.db)
+	if err != nil {
+		log.Printf("⚠️ Skipping order reconciliation - failed to aggregate trades: %v", err)
+		return orders
+	}
+
+	open := orders[:0]
+	corrected := 0
+	for _, order := range orders {
+		tradeFilled := filledByOrder[order.ID]
+		if tradeFilled > order.Quantity {
+			tradeFilled = order.Quantity
+		}
+
+		switch {
+		case tradeFilled > order.Filled:
+			order.Filled = tradeFilled
+		case tradeFilled < order.Filled:
+			log.Printf("⚠️ Order %d records %d filled but trades only sum to %d - keeping order state",
+				order.ID, order.Filled, tradeFilled)
+		}
+
+		remaining := order.Quantity - order.Filled
+		status := order.Status
+		switch {
+		case remaining <= 0:
+			status = models.OrderStatusFilled
+		case order.Filled > 0:
+			status = models.OrderStatusPartial
+		}
+
+		if remaining != order.Remaining || status != order.Status {
+			order.Remaining = remaining
+			order.Status = status
+			if err := me.db.Model(&models.Order{}).Where("id = ?", order.ID).Updates(map[string]interface{}{
+				"filled":    order.Filled,
+				"remaining": order.Remaining,
+				"status":    order.Status,
+			}).Error; err != nil {
+				log.Printf("❌ Failed to reconcile order %d: %v", order.ID, err)
+			}
+			corrected++
+		}
+
+		if order.Remaining > 0 {
+			open = append(open, order)
+		}
+	}
+
+	if corrected > 0 {
+		log.Printf("🩹 Reconciled %d orders with persisted trades", corrected)
+	}
+	return open
+}
+
+// tradeFilledByOrder 열린 주문별 체결 수량 합계 (매수/매도 양쪽)
+func tradeFilledByOrder(db *gorm.DB) (map[uint]int64, error) {
+	type orderFill struct {
+		OrderID uint
+		Filled  int64
+	}
+
+	openOrderIDs := db.Model(&models.Order{}).Select("id").Where("status IN ?", openOrderStatuses)
+	filled := make(map[uint]int64)
+	for _, column := range []string{"buy_order_id", "sell_order_id"} {
+		var fills []orderFill
+		if err := db.Model(&models.Trade{}).
+			Select(column+" AS order_id, COALESCE(SUM(quantity), 0) AS filled").
+			Where(column+" IN (?)", openOrderIDs.Session(&gorm.Session{})).
+			Group(column).
+			Scan(&fills).Error; err != nil {
+			return nil, err
+		}
+		for _, fill := range fills {
+			filled[fill.OrderID] += fill.Filled
+		}
+	}
+	return filled, nil
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"blueprint-module/pkg/models"
+)
+
+const (
+	// orderBookSnapshotEvery 이 횟수의 증분마다 전체 스냅샷을 함께 보냄
+	orderBookSnapshotEvery = 100
+	// orderBookSnapshotInterval 변경이 있었던 주문장은 이 주기마다 전체 스냅샷을 보냄
+	orderBookSnapshotInterval = 30 * time.Second
+)
+
+// OrderBookLevelAction 가격 레벨 변경 종류
+type OrderBookLevelAction string
+
+const (
+	OrderBookLevelAdd    OrderBookLevelAction = "add"
+	OrderBookLevelModify OrderBookLevelAction = "modify"
+	OrderBookLevelRemove OrderBookLevelAction = "remove"
+)
+
+// OrderBookLevelChange 가격 레벨 1건의 변경 (remove는 quantity/count 0)
+type OrderBookLevelChange struct {
+	Side     string               `json:"side"` // bid | ask
+	Action   OrderBookLevelAction `json:"action"`
+	Price    float64              `json:"price"`
+	Quantity int64                `json:"quantity"`
+	Count    int                  `json:"count"`
+}
+
+// OrderBookDelta 주문장 증분 (prev_sequence가 클라이언트의 마지막 시퀀스와 다르면 스냅샷을 다시 받아야 함)
+type OrderBookDelta struct {
+	MilestoneID  uint                   `json:"milestone_id"`
+	OptionID     string                 `json:"option_id"`
+	Sequence     uint64                 `json:"sequence"`
+	PrevSequence uint64                 `json:"prev_sequence"`
+	Changes      []OrderBookLevelChange `json:"changes"`
+}
+
+// commitOrderBookChange 마지막으로 내보낸 호가와 비교해 바뀐 레벨이 있으면 시퀀스를 올리고 증분을 보냄
+// 시퀀스 순서대로 나가도록 시장 액터 고루틴에서 호출한다
+func (me *LocalMatchingEngine) commitOrderBookChange(orderBook *OrderBookEngine) {
+	changes := orderBook.diffPublishedLevels()
+	if len(changes) == 0 {
+		return
+	}
+
+	delta := OrderBookDelta{
+		MilestoneID:  orderBook.MilestoneID,
+		OptionID:     orderBook.OptionID,
+		PrevSequence: orderBook.sequence,
+		Sequence:     orderBook.sequence + 1,
+		Changes:      changes,
+	}
+	orderBook.sequence = delta.Sequence
+
+	if me.sseService == nil {
+		return
+	}
+	me.sseService.BroadcastOrderBookDelta(delta)
+
+	if orderBook.sequence-orderBook.snapshotSequence >= orderBookSnapshotEvery {
+		me.publishOrderBookSnapshot(orderBook)
+	}
+}
+
+// publishOrderBookSnapshot 전체 호가 스냅샷 전송 (시장 액터 고루틴에서 호출)
+func (me *LocalMatchingEngine) publishOrderBookSnapshot(orderBook *OrderBookEngine) {
+	snapshot := orderBook.snapshot(MaxOrderBookDepth, 0)
+	orderBook.snapshotSequence = snapshot.Sequence
+
+	me.sseService.BroadcastOrderBookUpdate(orderBook.MilestoneID, orderBook.OptionID, map[string]interface{}{
+		"milestone_id": orderBook.MilestoneID,
+		"option_id":    orderBook.OptionID,
+		"sequence":     snapshot.Sequence,
+		"bids":         snapshot.Bids,
+		"asks":         snapshot.Asks,
+		"spread":       snapshot.Spread,
+		"depth":        snapshot.Depth,
+	})
+}
+
+// snapshotWorker 증분만 받은 클라이언트가 어긋나지 않도록 변경된 주문장의 전체 스냅샷을 주기적으로 전송
+func (me *LocalMatchingEngine) snapshotWorker() {
+	ticker := time.NewTicker(orderBookSnapshotInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-me.stopChan:
+			return
+		case <-ticker.C:
+			if me.sseService == nil {
+				continue
+			}
+			me.eachActor(func(actor *marketActor) {
+				me.withBook(actor, func(orderBook *OrderBookEngine) {
+					if orderBook.sequence != orderBook.snapshotSequence {
+						me.publishOrderBookSnapshot(orderBook)
+					}
+				})
+			})
+		}
+	}
+}
+
+// diffPublishedLevels 현재 가격 레벨과 마지막으로 내보낸 레벨의 차이 (매수 높은 가격순, 매도 낮은 가격순)
+func (ob *OrderBookEngine) diffPublishedLevels() []OrderBookLevelChange {
+	bids := levelsByTick(*ob.BuyOrders)
+	asks := levelsByTick(*ob.SellOrders)
+
+	changes := diffLevels("bid", ob.publishedBids, bids, true)
+	changes = append(changes, diffLevels("ask", ob.publishedAsks, asks, false)...)
+
+	ob.publishedBids = bids
+	ob.publishedAsks = asks
+	return changes
+}
+
+// resetPublishedLevels 현재 상태를 기준점으로 삼음 (주문 로드 직후)
+func (ob *OrderBookEngine) resetPublishedLevels() {
+	ob.publishedBids = levelsByTick(*ob.BuyOrders)
+	ob.publishedAsks = levelsByTick(*ob.SellOrders)
+}
+
+func levelsByTick(orders []*models.Order) map[int64]models.OrderBookLevel {
+	levels := make(map[int64]models.OrderBookLevel)
+	for _, order := range orders {
+		if order.Remaining <= 0 {
+			continue
+		}
+		tick := priceToTick(order.Price)
+		level := levels[tick]
+		level.Price = tickToPrice(tick)
+		level.Quantity += order.Remaining
+		level.Count++
+		levels[tick] = level
+	}
+	return levels
+}
+
+func diffLevels(side string, previous, current map[int64]models.OrderBookLevel, descending bool) []OrderBookLevelChange {
+	ticks := make([]int64, 0, len(current))
+	for tick, level := range current {
+		if old, exists := previous[tick]; !exists || old.Quantity != level.Quantity || old.Count != level.Count {
+			ticks = append(ticks, tick)
+		}
+	}
+	for tick := range previous {
+		if _, exists := current[tick]; !exists {
+			ticks = append(ticks, tick)
+		}
+	}
+	sort.Slice(ticks, func(i, j int) bool {
+		if descending {
+			return ticks[i] > ticks[j]
+		}
+		return ticks[i] < ticks[j]
+	})
+
+	changes := make([]OrderBookLevelChange, 0, len(ticks))
+	for _, tick := range ticks {
+		level, exists := current[tick]
+		change := OrderBookLevelChange{Side: side, Price: tickToPrice(tick)}
+		switch _, existed := previous[tick]; {
+		case !exists:
+			change.Action = OrderBookLevelRemove
+		case !existed:
+			change.Action = OrderBookLevelAdd
+		default:
+			change.Action = OrderBookLevelModify
+		}
+		if exists {
+			change.Quantity = level.Quantity
+			change.Count = level.Count
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"blueprint-module/pkg/models"
+)
+
+const (
+	DefaultOrderBookDepth = 20
+	MaxOrderBookDepth     = 100
+
+	// 가격은 확률(0.01-0.99)이므로 백만분의 1 단위 정수 틱으로 묶어 부동소수 오차를 피한다
+	priceTickScale = 1_000_000
+)
+
+var (
+	ErrInvalidOrderBookDepth       = errors.New("depth는 1-100 사이여야 합니다")
+	ErrInvalidOrderBookAggregation = errors.New("agg는 0(미집계) 또는 0.001-0.5 사이여야 합니다")
+)
+
+// ValidateOrderBookParams 호가 깊이/가격 집계 단위 검증
+func ValidateOrderBookParams(depth int, aggregation float64) error {
+	if depth < 1 || depth > MaxOrderBookDepth {
+		return ErrInvalidOrderBookDepth
+	}
+	if aggregation != 0 && (aggregation < 0.001 || aggregation > 0.5) {
+		return ErrInvalidOrderBookAggregation
+	}
+	return nil
+}
+
+// snapshot 가격대별로 집계·정렬된 호가 스냅샷 (시장 액터 고루틴에서 호출)
+// 집계 시 매수는 내림, 매도는 올림으로 묶어 실제보다 유리한 호가가 표시되지 않게 한다
+func (ob *OrderBookEngine) snapshot(depth int, aggregation float64) *models.OrderBook {
+	bidOrders := make([]*models.Order, 0, ob.BuyOrders.Len())
+	for _, order := range *ob.BuyOrders {
+		bidOrders = append(bidOrders, order)
+	}
+	askOrders := make([]*models.Order, 0, ob.SellOrders.Len())
+	for _, order := range *ob.SellOrders {
+		askOrders = append(askOrders, order)
+	}
+
+	book := &models.OrderBook{
+		MilestoneID: ob.MilestoneID,
+		OptionID:    ob.OptionID,
+		Bids:        aggregateLevels(bidOrders, depth, aggregation, true),
+		Asks:        aggregateLevels(askOrders, depth, aggregation, false),
+		Sequence:    ob.sequence,
+		Depth:       depth,
+		Aggregation: aggregation,
+		LastUpdate:  time.Now(),
+	}
+	if len(book.Bids) > 0 && len(book.Asks) > 0 {
+		book.Spread = tickToPrice(priceToTick(book.Asks[0].Price) - priceToTick(book.Bids[0].Price))
+	}
+	return book
+}
+
+// aggregateLevels 주문 목록을 가격대별 레벨로 묶고 최우선 호가부터 depth개 반환 (누적 수량 포함)
+func aggregateLevels(orders []*models.Order, depth int, aggregation float64, isBid bool) []models.OrderBookLevel {
+	bucket := priceToTick(aggregation)
+	byTick := make(map[int64]*models.OrderBookLevel)
+
+	for _, order := range orders {
+		if order.Remaining <= 0 {
+			continue
+		}
+		tick := priceToTick(order.Price)
+		if bucket > 0 {
+			if isBid {
+				tick = tick / bucket * bucket
+			} else {
+				tick = (tick + bucket - 1) / bucket * bucket
+			}
+		}
+
+		level, exists := byTick[tick]
+		if !exists {
+			level = &models.OrderBookLevel{Price: tickToPrice(tick)}
+			byTick[tick] = level
+		}
+		level.Quantity += order.Remaining
+		level.Count++
+	}
+
+	ticks := make([]int64, 0, len(byTick))
+	for tick := range byTick {
+		ticks = append(ticks, tick)
+	}
+	sort.Slice(ticks, func(i, j int) bool {
+		if isBid {
+			return ticks[i] > ticks[j]
+		}
+		return ticks[i] < ticks[j]
+	})
+	if len(ticks) > depth {
+		ticks = ticks[:depth]
+	}
+
+	levels := make([]models.OrderBookLevel, 0, len(ticks))
+	var cumulative int64
+	for _, tick := range ticks {
+		level := *byTick[tick]
+		cumulative += level.Quantity
+		level.Total = cumulative
+		levels = append(levels, level)
+	}
+	return levels
+}
+
+func priceToTick(price float64) int64 {
+	return int64(math.Round(price * priceTickScale))
+}
+
+func tickToPrice(tick int64) float64 {
+	return float64(tick) / priceTickScale
+}
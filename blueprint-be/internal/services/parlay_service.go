@@ -0,0 +1,592 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// 🎰 Parlay (조합 베팅) 서비스
+//
+// 가격 산정: 레그별 시장 가격을 곱하되, 같은 프로젝트의 마일스톤은 결과가 강하게 연동되므로
+// 완전 상관(그룹 확률 = 그룹 내 최소 확률)으로 보수적으로 취급한 뒤 하우스 마진을 더한다.
+// 정산: 모든 레그가 결정되면 지급하며, 하나라도 미적중이면 즉시 패배 처리한다.
+// 취소/폐기된 마일스톤 레그는 무효(void)로 제외하고 나머지 레그 가격으로 지급액을 재산정한다.
+// 원금과 지급액의 차이는 하우스 계정 지갑과 주고받으며, 모든 잔액 변경은 원장에 기록한다.
+
+const (
+	parlayHouseMargin     = 0.05      // 조합 가격 마진 (5%)
+	parlayMinPrice        = 0.01      // 최대 배당 100배
+	parlayMaxPotentialWin = 1_000_000 // 건당 최대 지급액 ($10,000)
+	parlayMilestoneLimit  = 5_000_000 // 마일스톤별 미정산 조합 지급액 한도 ($50,000)
+	parlayUserOpenLimit   = 2_000_000 // 사용자별 미정산 조합 지급액 한도 ($20,000)
+	parlayDefaultPrice    = 0.5       // 시장 데이터가 없을 때 기본 가격
+	parlayMinLegs         = 2
+	parlayMaxLegs         = 5 // 상관 가정과 지급 한도를 감당할 수 있는 최대 레그 수
+)
+
+var (
+	ErrParlayLimitExceeded      = errors.New("조합 베팅 한도를 초과했습니다")
+	ErrRoadmapNotBundleable     = errors.New("로드맵 묶음을 만들 수 없는 프로젝트입니다")
+	ErrParlayHouseNotConfigured = errors.New("조합 베팅 하우스 계정이 설정되지 않았습니다")
+	ErrParlayHouseInsufficient  = errors.New("하우스 계정 잔액이 부족해 조합 지급을 보류합니다")
+)
+
+// ParlayService 조합 포지션 가격 산정/접수/정산 서비스
+type ParlayService struct {
+	db                  *gorm.DB
+	notificationService *NotificationService
+	responsible         *ResponsibleTradingService // 원금을 하루 주문 금액 한도에 합산 (nil이면 검사 생략)
+	houseUserID         uint                       // 적중 지급액을 내고 미적중 원금을 받는 계정 (0이면 접수/정산 불가)
+}
+
+// NewParlayService 생성자
+func NewParlayService(db *gorm.DB) *ParlayService {
+	return &ParlayService{
+		db:                  db,
+		notificationService: NewNotificationService(db),
+	}
+}
+
+// SetResponsibleTrading 접수 전에 사용자 일일 주문 금액 한도와 휴식/자기 배제 검사
+func (s *ParlayService) SetResponsibleTrading(responsible *ResponsibleTradingService) {
+	s.responsible = responsible
+}
+
+// SetHouseAccount 조합 지급액을 주고받는 하우스 계정 지정
+func (s *ParlayService) SetHouseAccount(userID uint) {
+	s.houseUserID = userID
+}
+
+// Quote 조합 가격 견적
+func (s *ParlayService) Quote(legs []models.ParlayLegRequest, stake int64) (*models.ParlayQuote, error) {
+	if len(legs) < parlayMinLegs {
+		return nil, fmt.Errorf("조합 베팅은 최소 %d개 레그가 필요합니다", parlayMinLegs)
+	}
+	if len(legs) > parlayMaxLegs {
+		return nil, fmt.Errorf("조합 베팅은 최대 %d개 레그까지 가능합니다", parlayMaxLegs)
+	}
+
+	seen := make(map[uint]bool, len(legs))
+	quotes := make([]models.ParlayLegQuote, 0, len(legs))
+
+	for _, leg := range legs {
+		if seen[leg.MilestoneID] {
+			return nil, fmt.Errorf("같은 마일스톤(%d)을 중복으로 포함할 수 없습니다", leg.MilestoneID)
+		}
+		seen[leg.MilestoneID] = true
+
+		var milestone models.Milestone
+		if err := s.db.First(&milestone, leg.MilestoneID).Error; err != nil {
+			return nil, fmt.Errorf("마일스톤(%d)을 찾을 수 없습니다", leg.MilestoneID)
+		}
+		if !isParlayEligible(&milestone) {
+			return nil, fmt.Errorf("마일스톤(%d)은 현재 조합 베팅이 불가능합니다 (상태: %s)", leg.MilestoneID, milestone.Status)
+		}
+		if milestone.IsScalar() {
+			return nil, fmt.Errorf("마일스톤(%d)은 수치 마켓이라 조합 베팅에 포함할 수 없습니다", leg.MilestoneID)
+		}
+		if !milestone.HasOption(leg.OptionID) {
+			return nil, fmt.Errorf("마일스톤(%d)에 없는 결과 옵션입니다: %s", leg.MilestoneID, leg.OptionID)
+		}
+
+		quotes = append(quotes, models.ParlayLegQuote{
+			MilestoneID: leg.MilestoneID,
+			ProjectID:   milestone.ProjectID,
+			OptionID:    leg.OptionID,
+			Price:       s.legPrice(leg.MilestoneID, leg.OptionID),
+		})
+	}
+
+	fair, groups := combinedProbability(quotes)
+	price := math.Max(math.Min(fair*(1+parlayHouseMargin), 0.99), parlayMinPrice)
+
+	quote := &models.ParlayQuote{
+		Legs:             quotes,
+		FairProbability:  fair,
+		CombinedPrice:    price,
+		Odds:             1 / price,
+		CorrelatedGroups: groups,
+	}
+	if stake > 0 {
+		quote.Stake = stake
+		quote.PotentialWin = int64(float64(stake) / price)
+	}
+	return quote, nil
+}
+
+// PlaceParlay 조합 포지션 생성 (원금 잠금)
+func (s *ParlayService) PlaceParlay(userID uint, req *models.CreateParlayRequest) (*models.Parlay, error) {
+	return s.placeParlay(userID, req, models.ParlayKindCustom, nil)
+}
+
+// RoadmapLegs 프로젝트의 남은 마일스톤 전체 성공 레그 (순서대로)
+//
+// 이미 성공한 마일스톤은 제외하고, 취소된 마일스톤은 건너뛴다. 실패했거나 아직 거래가 시작되지 않은
+// 마일스톤, 이진 마켓이 아닌 마일스톤이 남아 있거나 남은 마일스톤이 최대 레그 수보다 많으면
+// 로드맵 전체를 담을 수 없으므로 거부한다.
+func (s *ParlayService) RoadmapLegs(projectID uint) ([]models.ParlayLegRequest, error) {
+	var milestones []models.Milestone
+	if err := s.db.Where("project_id = ?", projectID).Order(`"order" ASC`).Find(&milestones).Error; err != nil {
+		return nil, fmt.Errorf("마일스톤 조회 실패: %w", err)
+	}
+
+	var legs []models.ParlayLegRequest
+	for _, milestone := range milestones {
+		switch milestone.Status {
+		case models.MilestoneStatusCompleted, models.MilestoneStatusProofApproved, models.MilestoneStatusCancelled:
+			continue
+		case models.MilestoneStatusFailed, models.MilestoneStatusRejected, models.MilestoneStatusProofRejected:
+			return nil, fmt.Errorf("%w: 마일스톤(%d)이 이미 실패했습니다", ErrRoadmapNotBundleable, milestone.ID)
+		}
+		if !milestone.IsBinary() {
+			return nil, fmt.Errorf("%w: 마일스톤(%d)은 성공/실패 마켓이 아닙니다", ErrRoadmapNotBundleable, milestone.ID)
+		}
+		if !isParlayEligible(&milestone) {
+			return nil, fmt.Errorf("%w: 마일스톤(%d)은 현재 거래할 수 없습니다 (상태: %s)", ErrRoadmapNotBundleable, milestone.ID, milestone.Status)
+		}
+		legs = append(legs, models.ParlayLegRequest{MilestoneID: milestone.ID, OptionID: models.OptionSuccess})
+	}
+
+	if len(legs) < parlayMinLegs {
+		return nil, fmt.Errorf("%w: 남은 마일스톤이 %d개 이상이어야 합니다 (단일 마일스톤은 일반 주문 이용)", ErrRoadmapNotBundleable, parlayMinLegs)
+	}
+	if len(legs) > parlayMaxLegs {
+		return nil, fmt.Errorf("%w: 남은 마일스톤이 %d개로 최대 %d개 레그를 넘습니다", ErrRoadmapNotBundleable, len(legs), parlayMaxLegs)
+	}
+	return legs, nil
+}
+
+// QuoteRoadmap 로드맵 묶음 가격 견적
+func (s *ParlayService) QuoteRoadmap(projectID uint, stake int64) (*models.ParlayQuote, error) {
+	legs, err := s.RoadmapLegs(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return s.Quote(legs, stake)
+}
+
+// PlaceRoadmapBundle 로드맵 묶음 포지션 생성 (원금 잠금, 정산은 일반 조합과 동일)
+func (s *ParlayService) PlaceRoadmapBundle(userID, projectID uint, req *models.CreateRoadmapBundleRequest) (*models.Parlay, error) {
+	legs, err := s.RoadmapLegs(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return s.placeParlay(userID, &models.CreateParlayRequest{
+		Legs:     legs,
+		Stake:    req.Stake,
+		MaxPrice: req.MaxPrice,
+	}, models.ParlayKindRoadmap, &projectID)
+}
+
+func (s *ParlayService) placeParlay(userID uint, req *models.CreateParlayRequest, kind models.ParlayKind, projectID *uint) (*models.Parlay, error) {
+	if s.houseUserID == 0 {
+		return nil, ErrParlayHouseNotConfigured
+	}
+	if s.responsible != nil {
+		if err := s.responsible.CheckOrder(userID, req.Stake); err != nil {
+			return nil, err
+		}
+	}
+	quote, err := s.Quote(req.Legs, req.Stake)
+	if err != nil {
+		return nil, err
+	}
+	if req.MaxPrice > 0 && quote.CombinedPrice > req.MaxPrice {
+		return nil, fmt.Errorf("조합 가격이 변동되었습니다 (현재 %.4f > 허용 %.4f)", quote.CombinedPrice, req.MaxPrice)
+	}
+	if quote.PotentialWin > parlayMaxPotentialWin {
+		return nil, fmt.Errorf("%w: 건당 최대 지급액 $%.2f", ErrParlayLimitExceeded, float64(parlayMaxPotentialWin)/100)
+	}
+
+	parlay := &models.Parlay{
+		UserID:        userID,
+		Kind:          kind,
+		ProjectID:     projectID,
+		Stake:         req.Stake,
+		CombinedPrice: quote.CombinedPrice,
+		PotentialWin:  quote.PotentialWin,
+		Status:        models.ParlayStatusOpen,
+	}
+	for _, leg := range quote.Legs {
+		parlay.Legs = append(parlay.Legs, models.ParlayLeg{
+			ProjectID:   leg.ProjectID,
+			MilestoneID: leg.MilestoneID,
+			OptionID:    leg.OptionID,
+			Price:       leg.Price,
+			Result:      models.ParlayLegPending,
+		})
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.checkExposure(tx, userID, quote); err != nil {
+			return err
+		}
+
+		if err := tx.Create(parlay).Error; err != nil {
+			return err
+		}
+		if err := postLedgerEntry(tx, parlayLedgerEntry(parlay, models.WalletLedgerEntry{
+			UserID:       userID,
+			EntryType:    models.LedgerParlayStake,
+			Amount:       -req.Stake,
+			LockedAmount: req.Stake,
+			Memo:         fmt.Sprintf("조합 베팅 원금 (%d개 레그)", len(parlay.Legs)),
+		})); err != nil {
+			return err
+		}
+
+		// 잔액 확인은 증분 반영 후에 해서 동시 주문과 겹쳐도 음수 잔액을 남기지 않음
+		var wallet models.UserWallet
+		if err := tx.Select("usdc_balance").Where("user_id = ?", userID).First(&wallet).Error; err != nil {
+			return fmt.Errorf("지갑 조회 실패: %v", err)
+		}
+		if wallet.USDCBalance < 0 {
+			return fmt.Errorf("%w: 필요 $%.2f", ErrInsufficientBalance, float64(req.Stake)/100)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🎰 Parlay %d (%s) placed by user %d: %d legs, stake %d, price %.4f", parlay.ID, kind, userID, len(parlay.Legs), parlay.Stake, parlay.CombinedPrice)
+	return parlay, nil
+}
+
+// checkExposure 상관 리스크 한도 확인 (마일스톤별/사용자별 미정산 지급액)
+func (s *ParlayService) checkExposure(tx *gorm.DB, userID uint, quote *models.ParlayQuote) error {
+	var userOpen int64
+	tx.Model(&models.Parlay{}).
+		Where("user_id = ? AND status = ?", userID, models.ParlayStatusOpen).
+		Select("COALESCE(SUM(potential_win), 0)").Scan(&userOpen)
+	if userOpen+quote.PotentialWin > parlayUserOpenLimit {
+		return fmt.Errorf("%w: 사용자 미정산 지급액 한도 $%.2f", ErrParlayLimitExceeded, float64(parlayUserOpenLimit)/100)
+	}
+
+	for _, leg := range quote.Legs {
+		var milestoneExposure int64
+		tx.Model(&models.Parlay{}).
+			Joins("JOIN parlay_legs ON parlay_legs.parlay_id = parlays.id").
+			Where("parlays.status = ? AND parlay_legs.milestone_id = ? AND parlay_legs.option_id = ?",
+				models.ParlayStatusOpen, leg.MilestoneID, leg.OptionID).
+			Select("COALESCE(SUM(parlays.potential_win), 0)").Scan(&milestoneExposure)
+		if milestoneExposure+quote.PotentialWin > parlayMilestoneLimit {
+			return fmt.Errorf("%w: 마일스톤(%d) 조합 노출 한도 도달", ErrParlayLimitExceeded, leg.MilestoneID)
+		}
+	}
+	return nil
+}
+
+// GetUserParlays 사용자 조합 포지션 목록
+func (s *ParlayService) GetUserParlays(userID uint, status string) ([]models.Parlay, error) {
+	query := s.db.Preload("Legs").Where("user_id = ?", userID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var parlays []models.Parlay
+	if err := query.Order("created_at DESC").Find(&parlays).Error; err != nil {
+		return nil, fmt.Errorf("조합 포지션 조회 실패: %w", err)
+	}
+	return parlays, nil
+}
+
+// GetParlay 조합 포지션 상세 (본인 것만)
+func (s *ParlayService) GetParlay(userID, parlayID uint) (*models.Parlay, error) {
+	var parlay models.Parlay
+	if err := s.db.Preload("Legs").Where("id = ? AND user_id = ?", parlayID, userID).First(&parlay).Error; err != nil {
+		return nil, errors.New("조합 포지션을 찾을 수 없습니다")
+	}
+	return &parlay, nil
+}
+
+// SettlePendingLegs 미정산 레그 결과 반영 후 완료된 조합 정산 (보류됐던 조합 포함, 정산된 조합 수 반환)
+func (s *ParlayService) SettlePendingLegs() (int, error) {
+	var legs []models.ParlayLeg
+	if err := s.db.Preload("Milestone").Where("result = ?", models.ParlayLegPending).Find(&legs).Error; err != nil {
+		return 0, err
+	}
+
+	affected := make(map[uint]bool)
+	now := time.Now()
+	for _, leg := range legs {
+		result, resolved := legResult(&leg.Milestone, leg.OptionID)
+		if !resolved {
+			continue
+		}
+		if err := s.db.Model(&models.ParlayLeg{}).Where("id = ? AND result = ?", leg.ID, models.ParlayLegPending).
+			Updates(map[string]interface{}{"result": result, "settled_at": &now}).Error; err != nil {
+			return 0, err
+		}
+		affected[leg.ParlayID] = true
+	}
+
+	// 하우스 잔액 부족 등으로 보류됐던 조합도 다시 정산 (모든 레그가 결정된 미정산 조합)
+	var resolved []uint
+	if err := s.db.Model(&models.Parlay{}).
+		Where("status = ? AND NOT EXISTS (SELECT 1 FROM parlay_legs WHERE parlay_legs.parlay_id = parlays.id AND parlay_legs.result = ?)",
+			models.ParlayStatusOpen, models.ParlayLegPending).
+		Pluck("id", &resolved).Error; err != nil {
+		return 0, err
+	}
+	for _, parlayID := range resolved {
+		affected[parlayID] = true
+	}
+
+	settled := 0
+	for parlayID := range affected {
+		done, err := s.settleParlay(parlayID)
+		if err != nil {
+			log.Printf("❌ Failed to settle parlay %d: %v", parlayID, err)
+			continue
+		}
+		if done {
+			settled++
+		}
+	}
+	return settled, nil
+}
+
+// settleParlay 레그 결과에 따라 조합 정산 (정산 완료 여부 반환)
+func (s *ParlayService) settleParlay(parlayID uint) (bool, error) {
+	var parlay models.Parlay
+	settled := false
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Preload("Legs").Where("id = ? AND status = ?", parlayID, models.ParlayStatusOpen).First(&parlay).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil // 이미 정산됨
+			}
+			return err
+		}
+
+		status, payout, ok := evaluateParlay(&parlay)
+		if !ok {
+			return nil // 미결정 레그 남음
+		}
+
+		if err := s.postSettlement(tx, &parlay, payout); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		parlay.Status = status
+		parlay.Payout = payout
+		parlay.SettledAt = &now
+		settled = true
+		return tx.Model(&parlay).Updates(map[string]interface{}{
+			"status":     status,
+			"payout":     payout,
+			"settled_at": &now,
+		}).Error
+	})
+	if err != nil || !settled {
+		return false, err
+	}
+
+	s.notifySettlement(&parlay)
+	return true, nil
+}
+
+// postSettlement 정산 원장 기록 (사용자: 원금 잠금 차감 + 지급액, 하우스 계정: 원금 - 지급액)
+// 적중 지급액이 하우스 계정 잔액을 넘으면 ErrParlayHouseInsufficient로 정산을 보류하고 다음 주기에 다시 시도한다
+func (s *ParlayService) postSettlement(tx *gorm.DB, parlay *models.Parlay, payout int64) error {
+	if s.houseUserID == 0 {
+		return ErrParlayHouseNotConfigured
+	}
+
+	memo := fmt.Sprintf("조합 베팅 정산 (원금 $%.2f, 지급 $%.2f)", float64(parlay.Stake)/100, float64(payout)/100)
+	if err := postLedgerEntry(tx, parlayLedgerEntry(parlay, models.WalletLedgerEntry{
+		UserID:       parlay.UserID,
+		EntryType:    models.LedgerParlaySettlement,
+		Amount:       payout,
+		LockedAmount: -parlay.Stake,
+		Memo:         memo,
+	})); err != nil {
+		return err
+	}
+	if err := postLedgerEntry(tx, parlayLedgerEntry(parlay, models.WalletLedgerEntry{
+		UserID:    s.houseUserID,
+		EntryType: models.LedgerParlaySettlement,
+		Amount:    parlay.Stake - payout,
+		Memo:      fmt.Sprintf("조합 베팅 %d 정산 (사용자 %d)", parlay.ID, parlay.UserID),
+	})); err != nil {
+		return err
+	}
+
+	if payout > parlay.Stake {
+		var house models.UserWallet
+		if err := tx.Select("usdc_balance").Where("user_id = ?", s.houseUserID).First(&house).Error; err != nil {
+			return fmt.Errorf("하우스 계정 조회 실패: %w", err)
+		}
+		if house.USDCBalance < 0 {
+			return fmt.Errorf("%w: 필요 $%.2f", ErrParlayHouseInsufficient, float64(payout-parlay.Stake)/100)
+		}
+	}
+
+	// 사용자 누적 손익
+	switch {
+	case payout > parlay.Stake:
+		return tx.Model(&models.UserWallet{}).Where("user_id = ?", parlay.UserID).
+			Update("total_usdc_profit", gorm.Expr("total_usdc_profit + ?", payout-parlay.Stake)).Error
+	case payout < parlay.Stake:
+		return tx.Model(&models.UserWallet{}).Where("user_id = ?", parlay.UserID).
+			Update("total_usdc_loss", gorm.Expr("total_usdc_loss + ?", parlay.Stake-payout)).Error
+	}
+	return nil
+}
+
+// parlayLedgerEntry 조합 포지션 원장 기록 공통 필드
+func parlayLedgerEntry(parlay *models.Parlay, entry models.WalletLedgerEntry) *models.WalletLedgerEntry {
+	entry.Currency = models.LedgerCurrencyUSDC
+	entry.ReferenceType = "parlay"
+	entry.ReferenceID = parlay.ID
+	return &entry
+}
+
+// notifySettlement 정산 결과 알림
+func (s *ParlayService) notifySettlement(parlay *models.Parlay) {
+	var title, message string
+	switch parlay.Status {
+	case models.ParlayStatusWon:
+		title = "조합 베팅 적중!"
+		message = fmt.Sprintf("%d개 레그 조합이 적중하여 $%.2f가 지급되었습니다.", len(parlay.Legs), float64(parlay.Payout)/100)
+	case models.ParlayStatusVoid:
+		title = "조합 베팅 무효"
+		message = fmt.Sprintf("모든 레그가 무효 처리되어 원금 $%.2f가 환불되었습니다.", float64(parlay.Payout)/100)
+	default:
+		title = "조합 베팅 정산"
+		message = fmt.Sprintf("%d개 레그 조합이 미적중으로 정산되었습니다.", len(parlay.Legs))
+	}
+
+	_, err := s.notificationService.Notify(models.CreateNotificationRequest{
+		UserID:  parlay.UserID,
+		Type:    models.NotificationTypeTrade,
+		Title:   title,
+		Message: message,
+		Link:    "/parlays",
+		Data:    map[string]interface{}{"parlay_id": parlay.ID, "status": parlay.Status},
+	})
+	if err != nil {
+		log.Printf("⚠️ Parlay settlement notification failed (parlay %d): %v", parlay.ID, err)
+	}
+}
+
+// legPrice 레그 시장 가격 (체결가 → 호가 → 기본값)
+func (s *ParlayService) legPrice(milestoneID uint, optionID string) float64 {
+	var marketData models.MarketData
+	if err := s.db.Where("milestone_id = ? AND option_id = ?", milestoneID, optionID).First(&marketData).Error; err != nil {
+		return parlayDefaultPrice
+	}
+
+	price := marketData.CurrentPrice
+	if price <= 0 {
+		price = marketData.AskPrice
+	}
+	if price <= 0 || price >= 1 {
+		return parlayDefaultPrice
+	}
+	return price
+}
+
+// combinedProbability 상관관계를 반영한 조합 확률 (같은 프로젝트 레그 = 완전 상관)
+func combinedProbability(legs []models.ParlayLegQuote) (float64, int) {
+	groupMin := make(map[uint]float64)
+	for _, leg := range legs {
+		if current, ok := groupMin[leg.ProjectID]; !ok || leg.Price < current {
+			groupMin[leg.ProjectID] = leg.Price
+		}
+	}
+
+	probability := 1.0
+	correlated := 0
+	counts := make(map[uint]int)
+	for _, leg := range legs {
+		counts[leg.ProjectID]++
+	}
+	for projectID, price := range groupMin {
+		probability *= price
+		if counts[projectID] > 1 {
+			correlated++
+		}
+	}
+	return probability, correlated
+}
+
+// evaluateParlay 레그 결과로 조합 상태/지급액 계산 (모든 레그가 결정되었을 때만 ok)
+func evaluateParlay(parlay *models.Parlay) (models.ParlayStatus, int64, bool) {
+	var live []models.ParlayLegQuote
+	for _, leg := range parlay.Legs {
+		switch leg.Result {
+		case models.ParlayLegLost:
+			return models.ParlayStatusLost, 0, true
+		case models.ParlayLegPending:
+			return models.ParlayStatusOpen, 0, false
+		case models.ParlayLegWon:
+			live = append(live, models.ParlayLegQuote{ProjectID: leg.ProjectID, Price: leg.Price})
+		}
+	}
+
+	if len(live) == 0 {
+		return models.ParlayStatusVoid, parlay.Stake, true
+	}
+	if len(live) == len(parlay.Legs) {
+		return models.ParlayStatusWon, parlay.PotentialWin, true
+	}
+
+	// 무효 레그 제외 후 접수 시점 가격으로 재산정
+	fair, _ := combinedProbability(live)
+	price := math.Max(math.Min(fair*(1+parlayHouseMargin), 0.99), parlayMinPrice)
+	payout := int64(float64(parlay.Stake) / price)
+	if payout > parlay.PotentialWin {
+		payout = parlay.PotentialWin
+	}
+	return models.ParlayStatusWon, payout, true
+}
+
+// isParlayEligible 조합 베팅 가능한 마일스톤 상태 (결과 미확정 + 거래 가능)
+func isParlayEligible(milestone *models.Milestone) bool {
+	switch milestone.Status {
+	case models.MilestoneStatusFunding, models.MilestoneStatusActive,
+		models.MilestoneStatusProofSubmitted, models.MilestoneStatusUnderVerification:
+		return true
+	default:
+		return false
+	}
+}
+
+// legResult 마일스톤 상태와 판정 옵션으로 레그 결과 판정
+func legResult(milestone *models.Milestone, optionID string) (models.ParlayLegResult, bool) {
+	switch milestone.Status {
+	case models.MilestoneStatusProofApproved, models.MilestoneStatusCompleted,
+		models.MilestoneStatusProofRejected, models.MilestoneStatusFailed:
+	case models.MilestoneStatusCancelled, models.MilestoneStatusRejected:
+		return models.ParlayLegVoid, true
+	default:
+		return models.ParlayLegPending, false
+	}
+
+	// 판정 옵션이 없는 실패(다중 결과 마켓)는 무효
+	winner := milestone.WinningOptionID
+	if winner == "" && milestone.IsBinary() {
+		winner = models.OptionFail
+		if milestone.Status == models.MilestoneStatusProofApproved || milestone.Status == models.MilestoneStatusCompleted {
+			winner = models.OptionSuccess
+		}
+	}
+	if winner == "" {
+		return models.ParlayLegVoid, true
+	}
+
+	if optionID == winner {
+		return models.ParlayLegWon, true
+	}
+	return models.ParlayLegLost, true
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/redis"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// platformStatsCacheTTL 공개 지표 Redis 캐시 유지 시간
+	platformStatsCacheTTL = time.Minute
+
+	// platformActiveTraderWindow 이 기간 안에 체결에 참여한 사용자를 활동 트레이더로 집계
+	platformActiveTraderWindow = 30 * 24 * time.Hour
+)
+
+// PlatformStatsService 플랫폼 전체 지표 집계(GlobalStatsCache) 및 공개 조회
+type PlatformStatsService struct {
+	db *gorm.DB
+}
+
+// NewPlatformStatsService 생성자
+func NewPlatformStatsService(db *gorm.DB) *PlatformStatsService {
+	return &PlatformStatsService{db: db}
+}
+
+// Compute 플랫폼 지표를 집계해 GlobalStatsCache에 저장하고 캐시를 갱신
+func (s *PlatformStatsService) Compute(now time.Time) (*models.GlobalStatsCache, error) {
+	stats := &models.GlobalStatsCache{ID: models.GlobalStatsCacheID, ComputedAt: now}
+
+	if err := s.db.Model(&models.Trade{}).
+		Select("COALESCE(SUM(total_amount), 0)").Scan(&stats.TotalVolume).Error; err != nil {
+		return nil, fmt.Errorf("체결 금액 집계 실패: %w", err)
+	}
+	if err := s.db.Model(&models.Trade{}).Where("created_at >= ?", now.Add(-24*time.Hour)).
+		Select("COALESCE(SUM(total_amount), 0)").Scan(&stats.Volume24h).Error; err != nil {
+		return nil, fmt.Errorf("24시간 체결 금액 집계 실패: %w", err)
+	}
+	if err := s.db.Raw(`SELECT COUNT(*) FROM (
+			SELECT buyer_id AS user_id FROM trades WHERE created_at >= ?
+			UNION
+			SELECT seller_id AS user_id FROM trades WHERE created_at >= ?
+		) traders`, now.Add(-platformActiveTraderWindow), now.Add(-platformActiveTraderWindow)).
+		Scan(&stats.ActiveTraders).Error; err != nil {
+		return nil, fmt.Errorf("활동 트레이더 집계 실패: %w", err)
+	}
+
+	resolved := []models.MilestoneStatus{models.MilestoneStatusCompleted, models.MilestoneStatusFailed}
+	closed := append([]models.MilestoneStatus{models.MilestoneStatusRejected, models.MilestoneStatusCancelled}, resolved...)
+	if err := s.db.Model(&models.Milestone{}).
+		Where("status IN ?", models.TradableMilestoneStatuses).Count(&stats.OpenMarkets).Error; err != nil {
+		return nil, fmt.Errorf("열린 마켓 집계 실패: %w", err)
+	}
+	if err := s.db.Model(&models.Milestone{}).
+		Where("status IN ?", resolved).Count(&stats.ResolvedMilestones).Error; err != nil {
+		return nil, fmt.Errorf("확정 마일스톤 집계 실패: %w", err)
+	}
+	if err := s.db.Model(&models.Milestone{}).Where("status NOT IN ?", closed).
+		Select("COALESCE(SUM(current_tvl), 0)").Scan(&stats.TotalValueLocked).Error; err != nil {
+		return nil, fmt.Errorf("TVL 집계 실패: %w", err)
+	}
+
+	if err := s.db.Model(&models.ArbitrationCase{}).Count(&stats.ArbitrationCases).Error; err != nil {
+		return nil, fmt.Errorf("분쟁 사건 집계 실패: %w", err)
+	}
+	if err := s.db.Model(&models.ArbitrationCase{}).
+		Where("status IN ?", []models.ArbitrationStatus{models.ArbitrationStatusDecided, models.ArbitrationStatusClosed}).
+		Count(&stats.ArbitrationResolved).Error; err != nil {
+		return nil, fmt.Errorf("분쟁 해결 집계 실패: %w", err)
+	}
+	if stats.ArbitrationCases > 0 {
+		stats.ArbitrationResolutionRate = float64(stats.ArbitrationResolved) / float64(stats.ArbitrationCases)
+	}
+
+	if err := s.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(stats).Error; err != nil {
+		return nil, fmt.Errorf("플랫폼 지표 저장 실패: %w", err)
+	}
+	if redis.GetClient() != nil {
+		if err := redis.SetPlatformStats(stats, platformStatsCacheTTL); err != nil {
+			log.Printf("⚠️ Failed to cache platform stats: %v", err)
+		}
+	}
+	return stats, nil
+}
+
+// Get 마지막으로 집계된 플랫폼 지표 (Redis 캐시 → DB, 아직 집계되지 않았으면 지금 집계)
+func (s *PlatformStatsService) Get() (*models.GlobalStatsCache, error) {
+	cacheAvailable := redis.GetClient() != nil
+	if cacheAvailable {
+		var cached models.GlobalStatsCache
+		if err := redis.GetPlatformStats(&cached); err == nil {
+			return &cached, nil
+		}
+	}
+
+	var stats models.GlobalStatsCache
+	if err := s.db.First(&stats, models.GlobalStatsCacheID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return s.Compute(time.Now())
+		}
+		return nil, err
+	}
+
+	if cacheAvailable {
+		redis.SetPlatformStats(&stats, platformStatsCacheTTL)
+	}
+	return &stats, nil
+}
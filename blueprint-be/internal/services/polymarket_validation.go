@@ -62,6 +62,71 @@ func (pv *ProbabilityValidator) ValidateMarketPrices(milestoneID uint, optionPri
 	return pv.ValidateProbabilitySum(prices)
 }
 
+// ValidateOutcomePrices 마일스톤의 모든 결과 옵션 가격이 빠짐없이 주어지고 합이 1인지 검증
+func (pv *ProbabilityValidator) ValidateOutcomePrices(milestone *models.Milestone, optionPrices map[string]float64) error {
+	options := milestone.OptionIDs()
+	if len(optionPrices) != len(options) {
+		return fmt.Errorf("expected prices for %d outcomes, got %d", len(options), len(optionPrices))
+	}
+	for _, option := range options {
+		if _, ok := optionPrices[option]; !ok {
+			return fmt.Errorf("missing price for outcome %q", option)
+		}
+	}
+
+	return pv.ValidateMarketPrices(milestone.ID, optionPrices)
+}
+
+// InitialOutcomePrices 결과 옵션별 초기 확률 (지정값이 없으면 1/N 균등, 일부만 지정하면 오류)
+func (pv *ProbabilityValidator) InitialOutcomePrices(milestone *models.Milestone) (map[string]float64, error) {
+	options := milestone.OptionIDs()
+	prices := make(map[string]float64, len(options))
+
+	specified := 0
+	for _, outcome := range milestone.OutcomesArray {
+		if outcome.InitialPrice > 0 {
+			prices[outcome.ID] = outcome.InitialPrice
+			specified++
+		}
+	}
+	switch specified {
+	case 0:
+		for _, option := range options {
+			prices[option] = 1.0 / float64(len(options))
+		}
+	case len(options):
+	default:
+		return nil, fmt.Errorf("initial_price must be set for all outcomes or none (%d of %d set)", specified, len(options))
+	}
+
+	if err := pv.ValidateOutcomePrices(milestone, prices); err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+// InitialScalarPrices 수치 마켓 long/short 초기 가격 (예상 값의 범위 내 위치, 생략 시 범위 중간)
+func (pv *ProbabilityValidator) InitialScalarPrices(milestone *models.Milestone, initialValue *float64) (map[string]float64, error) {
+	if !milestone.IsScalar() {
+		return nil, fmt.Errorf("milestone is not a scalar market")
+	}
+
+	long := 0.5
+	if initialValue != nil {
+		long = math.Round(milestone.ScalarLongFraction(*initialValue)*100) / 100 // 1¢ 호가 단위
+	}
+	long = math.Max(0.01, math.Min(0.99, long))
+
+	prices := map[string]float64{
+		models.OptionLong:  long,
+		models.OptionShort: math.Round((1-long)*100) / 100,
+	}
+	if err := pv.ValidateOutcomePrices(milestone, prices); err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
 // CalculateImpliedProbability 주문장 기반 내재 확률 계산
 func (pv *ProbabilityValidator) CalculateImpliedProbability(orderBook *models.OrderBook) (float64, error) {
 	if orderBook == nil || len(orderBook.Bids) == 0 || len(orderBook.Asks) == 0 {
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PortfolioHistoryMaxDays 자산 곡선 최대 조회 기간
+const PortfolioHistoryMaxDays = 365
+
+// PortfolioSnapshotService 포트폴리오 일별 스냅샷 및 자산 곡선 조회 서비스
+type PortfolioSnapshotService struct {
+	db *gorm.DB
+}
+
+// NewPortfolioSnapshotService 생성자
+func NewPortfolioSnapshotService(db *gorm.DB) *PortfolioSnapshotService {
+	return &PortfolioSnapshotService{
+		db: db,
+	}
+}
+
+// SnapshotDue now가 속한 UTC 날짜의 스냅샷이 아직 없으면 전체 사용자 스냅샷 저장 (저장한 사용자 수 반환)
+func (s *PortfolioSnapshotService) SnapshotDue(now time.Time) (int, error) {
+	today := snapshotDate(now)
+	var existing int64
+	if err := s.db.Model(&models.PositionSnapshot{}).Where("snapshot_date = ?", today).Count(&existing).Error; err != nil {
+		return 0, err
+	}
+	if existing > 0 {
+		return 0, nil
+	}
+	return s.SnapshotAll(today)
+}
+
+// SnapshotAll 지갑 또는 보유 포지션이 있는 모든 사용자의 스냅샷 저장 (같은 날짜는 덮어씀)
+func (s *PortfolioSnapshotService) SnapshotAll(date time.Time) (int, error) {
+	date = snapshotDate(date)
+
+	prices, err := s.loadPrices()
+	if err != nil {
+		return 0, err
+	}
+
+	snapshots := make(map[uint]*models.PositionSnapshot)
+	get := func(userID uint) *models.PositionSnapshot {
+		snapshot, ok := snapshots[userID]
+		if !ok {
+			snapshot = &models.PositionSnapshot{UserID: userID, SnapshotDate: date}
+			snapshots[userID] = snapshot
+		}
+		return snapshot
+	}
+
+	var wallets []models.UserWallet
+	if err := s.db.Select("user_id", "usdc_balance", "usdc_locked_balance").Find(&wallets).Error; err != nil {
+		return 0, fmt.Errorf("지갑 조회 실패: %w", err)
+	}
+	for _, wallet := range wallets {
+		get(wallet.UserID).CashBalance = wallet.USDCBalance + wallet.USDCLockedBalance
+	}
+
+	var positions []models.Position
+	if err := s.db.Where("quantity != 0 OR realized != 0").Find(&positions).Error; err != nil {
+		return 0, fmt.Errorf("포지션 조회 실패: %w", err)
+	}
+	for _, position := range positions {
+		addPosition(get(position.UserID), position, prices)
+	}
+
+	rows := make([]models.PositionSnapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		snapshot.TotalValue = snapshot.CashBalance + snapshot.PositionValue
+		rows = append(rows, *snapshot)
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	err = s.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "snapshot_date"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"cash_balance", "position_value", "cost_basis", "realized_pnl", "unrealized_pnl", "total_value", "open_positions",
+		}),
+	}).CreateInBatches(rows, 500).Error
+	if err != nil {
+		return 0, fmt.Errorf("스냅샷 저장 실패: %w", err)
+	}
+	return len(rows), nil
+}
+
+// GetHistory 최근 days일 스냅샷과 현재 시점 평가 조회
+func (s *PortfolioSnapshotService) GetHistory(userID uint, days int) (*models.PortfolioHistoryResponse, error) {
+	if days <= 0 || days > PortfolioHistoryMaxDays {
+		days = PortfolioHistoryMaxDays
+	}
+	since := snapshotDate(time.Now()).AddDate(0, 0, -days)
+
+	var snapshots []models.PositionSnapshot
+	if err := s.db.Where("user_id = ? AND snapshot_date >= ?", userID, since).
+		Order("snapshot_date ASC").
+		Find(&snapshots).Error; err != nil {
+		return nil, fmt.Errorf("스냅샷 조회 실패: %w", err)
+	}
+
+	current, err := s.currentValue(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PortfolioHistoryResponse{Snapshots: snapshots, Current: current}, nil
+}
+
+// currentValue 저장하지 않고 현재 시점 포트폴리오 평가
+func (s *PortfolioSnapshotService) currentValue(userID uint) (*models.PositionSnapshot, error) {
+	prices, err := s.loadPrices()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &models.PositionSnapshot{UserID: userID, SnapshotDate: time.Now().UTC()}
+
+	var wallet models.UserWallet
+	if err := s.db.Where("user_id = ?", userID).Limit(1).Find(&wallet).Error; err != nil {
+		return nil, fmt.Errorf("지갑 조회 실패: %w", err)
+	}
+	snapshot.CashBalance = wallet.USDCBalance + wallet.USDCLockedBalance
+
+	var positions []models.Position
+	if err := s.db.Where("user_id = ? AND (quantity != 0 OR realized != 0)", userID).Find(&positions).Error; err != nil {
+		return nil, fmt.Errorf("포지션 조회 실패: %w", err)
+	}
+	for _, position := range positions {
+		addPosition(snapshot, position, prices)
+	}
+	snapshot.TotalValue = snapshot.CashBalance + snapshot.PositionValue
+	return snapshot, nil
+}
+
+// loadPrices 마일스톤/옵션별 현재가
+func (s *PortfolioSnapshotService) loadPrices() (map[string]float64, error) {
+	var marketData []models.MarketData
+	if err := s.db.Select("milestone_id", "option_id", "current_price").Find(&marketData).Error; err != nil {
+		return nil, fmt.Errorf("시장 가격 조회 실패: %w", err)
+	}
+
+	prices := make(map[string]float64, len(marketData))
+	for _, md := range marketData {
+		prices[positionPriceKey(md.MilestoneID, md.OptionID)] = md.CurrentPrice
+	}
+	return prices, nil
+}
+
+// addPosition 포지션 1건을 스냅샷에 합산 (시장가가 없으면 평균 단가로 평가)
+func addPosition(snapshot *models.PositionSnapshot, position models.Position, prices map[string]float64) {
+	snapshot.RealizedPnL += position.Realized
+	if position.Quantity == 0 {
+		return
+	}
+
+	price, ok := prices[positionPriceKey(position.MilestoneID, position.OptionID)]
+	if !ok || price <= 0 {
+		price = position.AvgPrice
+	}
+
+	// 매칭 엔진의 미실현 손익 계산(수량 × 가격)과 같은 기준
+	snapshot.PositionValue += int64(float64(position.Quantity) * price)
+	snapshot.CostBasis += int64(float64(position.Quantity) * position.AvgPrice)
+	snapshot.UnrealizedPnL += int64(float64(position.Quantity) * (price - position.AvgPrice))
+	snapshot.OpenPositions++
+}
+
+func positionPriceKey(milestoneID uint, optionID string) string {
+	return fmt.Sprintf("%d:%s", milestoneID, optionID)
+}
+
+// snapshotDate 스냅샷 기준일 (UTC 자정)
+func snapshotDate(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,428 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// PositionTransferTTL 받는 사람이 응답할 수 있는 기간 (지나면 만료, 수수료 반환)
+const PositionTransferTTL = 72 * time.Hour
+
+var (
+	// ErrTransferNotFound 없거나 이미 처리/만료된 이전 요청
+	ErrTransferNotFound = errors.New("유효한 포지션 이전 요청을 찾을 수 없습니다")
+	// ErrTransferRecipientNotFound 받는 사람 사용자명이 없음
+	ErrTransferRecipientNotFound = errors.New("받는 사람을 찾을 수 없습니다")
+	// ErrTransferToSelf 자기 자신에게 이전
+	ErrTransferToSelf = errors.New("자신에게는 포지션을 이전할 수 없습니다")
+	// ErrTransferQuantityUnavailable 보유 수량에서 미체결 매도 주문과 대기 중인 이전을 뺀 수량 초과
+	ErrTransferQuantityUnavailable = errors.New("이전할 수 있는 보유 수량이 부족합니다")
+	// ErrTransferOppositePosition 받는 사람이 같은 옵션을 숏으로 보유 (평균가 합산 불가)
+	ErrTransferOppositePosition = errors.New("받는 사람이 같은 옵션에 반대 방향 포지션을 보유하고 있습니다")
+)
+
+// PositionTransferService 사용자 간 포지션 이전/선물 (양쪽 확인)
+//
+// 보낸 사람이 요청하면 수수료를 잠그고, 받는 사람이 수락하면 수량과 보낸 사람 평균가 기준의 취득 원가를
+// 옮긴 뒤 수수료를 차감한다. 이전 가능 수량은 보유 수량에서 미체결 매도 주문 잔량과 대기 중인 다른
+// 이전 요청을 뺀 값이며 수락 시점에 다시 확인한다. 거래 가능한 마켓에서만 요청/수락할 수 있다.
+type PositionTransferService struct {
+	db                  *gorm.DB
+	fee                 int64 // 1건당 보낸 사람 부담 수수료 (센트)
+	notificationService *NotificationService
+}
+
+// NewPositionTransferService 생성자
+func NewPositionTransferService(db *gorm.DB, fee int64) *PositionTransferService {
+	return &PositionTransferService{
+		db:                  db,
+		fee:                 fee,
+		notificationService: NewNotificationService(db),
+	}
+}
+
+// RequestTransfer 포지션 이전 요청 (수수료 잠금, 받는 사람에게 알림)
+func (s *PositionTransferService) RequestTransfer(senderID uint, req models.CreatePositionTransferRequest) (*models.PositionTransfer, error) {
+	var recipient models.User
+	if err := s.db.Select("id", "username").Where("username = ?", req.RecipientUsername).First(&recipient).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTransferRecipientNotFound
+		}
+		return nil, err
+	}
+	if recipient.ID == senderID {
+		return nil, ErrTransferToSelf
+	}
+
+	milestone, err := s.tradableMilestone(req.MilestoneID, req.OptionID)
+	if err != nil {
+		return nil, err
+	}
+	var shorts int64
+	if err := s.db.Model(&models.Position{}).
+		Where("user_id = ? AND milestone_id = ? AND option_id = ? AND quantity < 0", recipient.ID, milestone.ID, req.OptionID).
+		Count(&shorts).Error; err != nil {
+		return nil, err
+	}
+	if shorts > 0 {
+		return nil, ErrTransferOppositePosition
+	}
+
+	transfer := &models.PositionTransfer{
+		SenderID:    senderID,
+		RecipientID: recipient.ID,
+		ProjectID:   milestone.ProjectID,
+		MilestoneID: milestone.ID,
+		OptionID:    req.OptionID,
+		Quantity:    req.Quantity,
+		Fee:         s.fee,
+		Message:     req.Message,
+		Status:      models.PositionTransferPending,
+		ExpiresAt:   time.Now().Add(PositionTransferTTL),
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		available, err := transferableQuantity(tx, senderID, milestone.ID, req.OptionID, 0)
+		if err != nil {
+			return err
+		}
+		if req.Quantity > available {
+			return fmt.Errorf("%w: 최대 %d", ErrTransferQuantityUnavailable, available)
+		}
+
+		if err := tx.Create(transfer).Error; err != nil {
+			return fmt.Errorf("이전 요청 저장 실패: %w", err)
+		}
+		if transfer.Fee == 0 {
+			return nil
+		}
+		if err := postLedgerEntry(tx, transferLedgerEntry(transfer, models.WalletLedgerEntry{
+			UserID:       senderID,
+			EntryType:    models.LedgerTransferFeeHold,
+			Amount:       -transfer.Fee,
+			LockedAmount: transfer.Fee,
+			Memo:         fmt.Sprintf("포지션 이전 수수료 (%s에게)", recipient.Username),
+		})); err != nil {
+			return err
+		}
+
+		// 잔액 확인은 증분 반영 후에 해서 동시 주문과 겹쳐도 음수 잔액을 남기지 않음
+		var wallet models.UserWallet
+		if err := tx.Select("usdc_balance").Where("user_id = ?", senderID).First(&wallet).Error; err != nil {
+			return fmt.Errorf("지갑 조회 실패: %w", err)
+		}
+		if wallet.USDCBalance < 0 {
+			return fmt.Errorf("%w: 수수료 $%.2f", ErrInsufficientBalance, float64(transfer.Fee)/100)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🎁 User %d requested transfer %d of %d %s shares (milestone %d) to user %d",
+		senderID, transfer.ID, transfer.Quantity, transfer.OptionID, transfer.MilestoneID, recipient.ID)
+	s.notify(recipient.ID, transfer, "포지션 이전 요청", fmt.Sprintf("'%s' 마일스톤의 %s 포지션 %d주를 받을지 확인해 주세요", milestone.Title, transfer.OptionID, transfer.Quantity))
+	return transfer, nil
+}
+
+// AcceptTransfer 받는 사람이 수락 - 수량/취득 원가 이동, 수수료 차감
+func (s *PositionTransferService) AcceptTransfer(transferID, recipientID uint) (*models.PositionTransfer, error) {
+	transfer, err := s.pendingTransfer(s.db.Where("recipient_id = ?", recipientID), transferID)
+	if err != nil {
+		return nil, err
+	}
+	milestone, err := s.tradableMilestone(transfer.MilestoneID, transfer.OptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		available, err := transferableQuantity(tx, transfer.SenderID, transfer.MilestoneID, transfer.OptionID, transfer.ID)
+		if err != nil {
+			return err
+		}
+		if transfer.Quantity > available {
+			return fmt.Errorf("%w: 보낸 사람의 이전 가능 수량 %d", ErrTransferQuantityUnavailable, available)
+		}
+
+		result := tx.Model(&models.PositionTransfer{}).
+			Where("id = ? AND status = ?", transfer.ID, models.PositionTransferPending).
+			Updates(map[string]interface{}{"status": models.PositionTransferAccepted, "responded_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrTransferNotFound
+		}
+
+		if err := movePosition(tx, transfer); err != nil {
+			return err
+		}
+		if err := tx.Model(&models.PositionTransfer{}).Where("id = ?", transfer.ID).
+			Updates(map[string]interface{}{"avg_price": transfer.AvgPrice, "cost_basis": transfer.CostBasis}).Error; err != nil {
+			return err
+		}
+		if transfer.Fee == 0 {
+			return nil
+		}
+		return postLedgerEntry(tx, transferLedgerEntry(transfer, models.WalletLedgerEntry{
+			UserID:       transfer.SenderID,
+			EntryType:    models.LedgerTransferFee,
+			LockedAmount: -transfer.Fee,
+			Memo:         "포지션 이전 수수료",
+		}))
+	})
+	if err != nil {
+		return nil, err
+	}
+	transfer.Status, transfer.RespondedAt = models.PositionTransferAccepted, &now
+
+	log.Printf("🎁 Transfer %d accepted: %d %s shares (milestone %d) moved from user %d to %d",
+		transfer.ID, transfer.Quantity, transfer.OptionID, transfer.MilestoneID, transfer.SenderID, transfer.RecipientID)
+	s.notify(transfer.SenderID, transfer, "포지션 이전 완료", fmt.Sprintf("'%s' 마일스톤의 %s 포지션 %d주 이전이 수락되었습니다", milestone.Title, transfer.OptionID, transfer.Quantity))
+	return transfer, nil
+}
+
+// DeclineTransfer 받는 사람이 거절 (수수료 반환)
+func (s *PositionTransferService) DeclineTransfer(transferID, recipientID uint) (*models.PositionTransfer, error) {
+	transfer, err := s.pendingTransfer(s.db.Where("recipient_id = ?", recipientID), transferID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.close(transfer, models.PositionTransferDeclined, "포지션 이전 거절로 수수료 반환"); err != nil {
+		return nil, err
+	}
+	s.notify(transfer.SenderID, transfer, "포지션 이전 거절", fmt.Sprintf("%s 포지션 %d주 이전 요청이 거절되었습니다", transfer.OptionID, transfer.Quantity))
+	return transfer, nil
+}
+
+// CancelTransfer 보낸 사람이 대기 중 요청 취소 (수수료 반환)
+func (s *PositionTransferService) CancelTransfer(transferID, senderID uint) (*models.PositionTransfer, error) {
+	transfer, err := s.pendingTransfer(s.db.Where("sender_id = ?", senderID), transferID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.close(transfer, models.PositionTransferCancelled, "포지션 이전 취소로 수수료 반환"); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// ListTransfers 내가 보내거나 받은 이전 요청 (direction: sent|received, 비우면 모두)
+func (s *PositionTransferService) ListTransfers(userID uint, direction string, status models.PositionTransferStatus, limit, offset int) ([]models.PositionTransfer, error) {
+	query := s.db.Preload("Milestone")
+	switch direction {
+	case "sent":
+		query = query.Where("sender_id = ?", userID)
+	case "received":
+		query = query.Where("recipient_id = ?", userID)
+	default:
+		query = query.Where("sender_id = ? OR recipient_id = ?", userID, userID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	transfers := []models.PositionTransfer{}
+	err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&transfers).Error
+	return transfers, err
+}
+
+// ExpireTransfers 기한이 지난 대기 요청 만료 및 수수료 반환 (만료 건수 반환)
+func (s *PositionTransferService) ExpireTransfers(now time.Time) (int, error) {
+	var transfers []models.PositionTransfer
+	if err := s.db.Where("status = ? AND expires_at <= ?", models.PositionTransferPending, now).
+		Find(&transfers).Error; err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for i := range transfers {
+		err := s.close(&transfers[i], models.PositionTransferExpired, "포지션 이전 만료로 수수료 반환")
+		if errors.Is(err, ErrTransferNotFound) {
+			continue // 그 사이 수락/거절됨
+		}
+		if err != nil {
+			return expired, err
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+// close 대기 중 요청을 종결하고 잠근 수수료 반환
+func (s *PositionTransferService) close(transfer *models.PositionTransfer, status models.PositionTransferStatus, memo string) error {
+	now := time.Now()
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.PositionTransfer{}).
+			Where("id = ? AND status = ?", transfer.ID, models.PositionTransferPending).
+			Updates(map[string]interface{}{"status": status, "responded_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrTransferNotFound
+		}
+		if transfer.Fee == 0 {
+			return nil
+		}
+		return postLedgerEntry(tx, transferLedgerEntry(transfer, models.WalletLedgerEntry{
+			UserID:       transfer.SenderID,
+			EntryType:    models.LedgerTransferFeeRelease,
+			Amount:       transfer.Fee,
+			LockedAmount: -transfer.Fee,
+			Memo:         memo,
+		}))
+	})
+	if err != nil {
+		return err
+	}
+	transfer.Status, transfer.RespondedAt = status, &now
+	return nil
+}
+
+// pendingTransfer 응답 기한 안의 대기 요청 (scope로 보낸/받는 사람 제한)
+func (s *PositionTransferService) pendingTransfer(scope *gorm.DB, transferID uint) (*models.PositionTransfer, error) {
+	var transfer models.PositionTransfer
+	err := scope.Where("id = ? AND status = ? AND expires_at > ?", transferID, models.PositionTransferPending, time.Now()).
+		First(&transfer).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTransferNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// tradableMilestone 거래 가능한 마켓인지 확인 (주문 접수와 같은 기준)
+func (s *PositionTransferService) tradableMilestone(milestoneID uint, optionID string) (*models.Milestone, error) {
+	var milestone models.Milestone
+	if err := s.db.Select("id", "project_id", "title", "status", "market_type", "outcomes", "target_date", "trading_closes_at").
+		First(&milestone, milestoneID).Error; err != nil {
+		return nil, fmt.Errorf("마일스톤을 찾을 수 없습니다: %w", err)
+	}
+	if !milestone.Status.IsTradable() {
+		return nil, ErrMarketFrozen
+	}
+	if milestone.IsTradingClosed(time.Now()) {
+		return nil, fmt.Errorf("%w: %s 마감", ErrMarketClosed, milestone.TradingCloseTime().UTC().Format(time.RFC3339))
+	}
+	if !milestone.HasOption(optionID) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownOption, optionID)
+	}
+	return &milestone, nil
+}
+
+func (s *PositionTransferService) notify(userID uint, transfer *models.PositionTransfer, title, message string) {
+	if _, err := s.notificationService.Notify(models.CreateNotificationRequest{
+		UserID:   userID,
+		Type:     models.NotificationTypePositionTransfer,
+		Priority: models.NotificationPriorityNormal,
+		Title:    title,
+		Message:  message,
+		Link:     "/portfolio/transfers",
+		Data:     map[string]interface{}{"transfer_id": transfer.ID, "milestone_id": transfer.MilestoneID, "status": transfer.Status},
+	}); err != nil {
+		log.Printf("⚠️ Failed to notify position transfer %d: %v", transfer.ID, err)
+	}
+}
+
+// transferableQuantity 이전 가능 수량 = 롱 보유 수량 - 미체결 매도 잔량 - 대기 중인 다른 이전 요청
+func transferableQuantity(tx *gorm.DB, userID, milestoneID uint, optionID string, excludeTransferID uint) (int64, error) {
+	var held int64
+	if err := tx.Model(&models.Position{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("user_id = ? AND milestone_id = ? AND option_id = ?", userID, milestoneID, optionID).
+		Scan(&held).Error; err != nil {
+		return 0, err
+	}
+
+	var selling int64
+	if err := tx.Model(&models.Order{}).
+		Select("COALESCE(SUM(remaining), 0)").
+		Where("user_id = ? AND milestone_id = ? AND option_id = ? AND side = ? AND status IN ?",
+			userID, milestoneID, optionID, models.OrderSideSell, openOrderStatuses).
+		Scan(&selling).Error; err != nil {
+		return 0, err
+	}
+
+	var pending int64
+	if err := tx.Model(&models.PositionTransfer{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("sender_id = ? AND milestone_id = ? AND option_id = ? AND status = ? AND id <> ?",
+			userID, milestoneID, optionID, models.PositionTransferPending, excludeTransferID).
+		Scan(&pending).Error; err != nil {
+		return 0, err
+	}
+
+	return max(held-selling-pending, 0), nil
+}
+
+// movePosition 보낸 사람 포지션에서 수량과 비례 원가를 떼어 받는 사람 포지션에 합산 (평균가는 수량 가중)
+func movePosition(tx *gorm.DB, transfer *models.PositionTransfer) error {
+	var from models.Position
+	if err := tx.Where("user_id = ? AND milestone_id = ? AND option_id = ?", transfer.SenderID, transfer.MilestoneID, transfer.OptionID).
+		First(&from).Error; err != nil {
+		return fmt.Errorf("보낸 사람 포지션 조회 실패: %w", err)
+	}
+
+	moved := models.Position{
+		UserID:      transfer.RecipientID,
+		ProjectID:   from.ProjectID,
+		MilestoneID: from.MilestoneID,
+		OptionID:    from.OptionID,
+		Quantity:    transfer.Quantity,
+		AvgPrice:    from.AvgPrice,
+		TotalCost:   from.TotalCost * transfer.Quantity / from.Quantity,
+		Unrealized:  from.Unrealized * transfer.Quantity / from.Quantity,
+		UpdatedAt:   time.Now(),
+	}
+	transfer.AvgPrice, transfer.CostBasis = moved.AvgPrice, moved.TotalCost
+
+	from.Quantity -= moved.Quantity
+	from.TotalCost -= moved.TotalCost
+	from.Unrealized -= moved.Unrealized
+	if from.Quantity == 0 {
+		from.AvgPrice, from.TotalCost, from.Unrealized = 0, 0, 0
+	}
+	from.UpdatedAt = moved.UpdatedAt
+	if err := tx.Save(&from).Error; err != nil {
+		return fmt.Errorf("보낸 사람 포지션 갱신 실패: %w", err)
+	}
+
+	var into models.Position
+	err := tx.Where("user_id = ? AND milestone_id = ? AND option_id = ?", transfer.RecipientID, transfer.MilestoneID, transfer.OptionID).
+		First(&into).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return tx.Create(&moved).Error
+	}
+	if err != nil {
+		return fmt.Errorf("받는 사람 포지션 조회 실패: %w", err)
+	}
+	if into.Quantity < 0 {
+		return ErrTransferOppositePosition
+	}
+
+	combinePositions(&into, &moved)
+	into.UpdatedAt = moved.UpdatedAt
+	return tx.Save(&into).Error
+}
+
+// transferLedgerEntry 포지션 이전 수수료 원장 항목
+func transferLedgerEntry(transfer *models.PositionTransfer, entry models.WalletLedgerEntry) *models.WalletLedgerEntry {
+	entry.Currency = models.LedgerCurrencyUSDC
+	entry.ReferenceType = "position_transfer"
+	entry.ReferenceID = transfer.ID
+	return &entry
+}
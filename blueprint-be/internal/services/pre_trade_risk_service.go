@@ -0,0 +1,180 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrRiskLimitExceeded 주문 전 리스크 한도 초과
+var ErrRiskLimitExceeded = errors.New("리스크 한도를 초과했습니다")
+
+// RiskLimits 사용자별 전역 리스크 한도 (0이면 제한 없음)
+type RiskLimits struct {
+	MaxOpenOrderNotional int64 `json:"max_open_order_notional"` // 미체결 주문 금액 합계 (센트)
+	MaxMarketPosition    int64 `json:"max_market_position"`     // 시장(마일스톤+옵션)별 최대 보유 수량 (미체결 주문 포함)
+	MaxDailyLoss         int64 `json:"max_daily_loss"`          // 당일(UTC) 최대 손실 (센트)
+}
+
+// RiskExposure 현재 사용량
+type RiskExposure struct {
+	OpenOrderNotional int64 `json:"open_order_notional"`
+	DailyLoss         int64 `json:"daily_loss"`
+}
+
+// RiskLimitStatus 한도 조회 응답
+type RiskLimitStatus struct {
+	Limits   RiskLimits   `json:"limits"`
+	Exposure RiskExposure `json:"exposure"`
+	// 남은 한도 (제한 없는 항목은 생략)
+	RemainingOpenOrderNotional *int64 `json:"remaining_open_order_notional,omitempty"`
+	RemainingDailyLoss         *int64 `json:"remaining_daily_loss,omitempty"`
+}
+
+// PreTradeRiskService 주문 접수 전 사용자 노출/포지션/손실 한도 검사
+type PreTradeRiskService struct {
+	db        *gorm.DB
+	portfolio *PortfolioSnapshotService
+	limits    RiskLimits
+}
+
+// NewPreTradeRiskService 생성자
+func NewPreTradeRiskService(db *gorm.DB, portfolio *PortfolioSnapshotService, limits RiskLimits) *PreTradeRiskService {
+	return &PreTradeRiskService{
+		db:        db,
+		portfolio: portfolio,
+		limits:    limits,
+	}
+}
+
+// CheckOrder 신규 주문이 한도를 넘는지 검사 (매칭 엔진 제출 전에 호출)
+func (s *PreTradeRiskService) CheckOrder(userID uint, req models.CreateOrderRequest) error {
+	priceTicks := models.PriceToTicks(req.Price)
+
+	if s.limits.MaxOpenOrderNotional > 0 {
+		open, err := s.openOrderNotional(userID)
+		if err != nil {
+			return err
+		}
+		if open+models.ReserveCents(req.Quantity, priceTicks) > s.limits.MaxOpenOrderNotional {
+			return fmt.Errorf("%w: 미체결 주문 금액 최대 $%.2f (현재 $%.2f)", ErrRiskLimitExceeded,
+				float64(s.limits.MaxOpenOrderNotional)/100, float64(open)/100)
+		}
+	}
+
+	if s.limits.MaxMarketPosition > 0 {
+		position, err := s.worstCasePosition(userID, req)
+		if err != nil {
+			return err
+		}
+		if position > s.limits.MaxMarketPosition || -position > s.limits.MaxMarketPosition {
+			return fmt.Errorf("%w: 시장별 최대 포지션 %d주", ErrRiskLimitExceeded, s.limits.MaxMarketPosition)
+		}
+	}
+
+	if s.limits.MaxDailyLoss > 0 {
+		loss, err := s.dailyLoss(userID)
+		if err != nil {
+			return err
+		}
+		if loss >= s.limits.MaxDailyLoss {
+			return fmt.Errorf("%w: 당일 손실 한도 $%.2f 도달", ErrRiskLimitExceeded, float64(s.limits.MaxDailyLoss)/100)
+		}
+	}
+
+	return nil
+}
+
+// GetStatus 한도와 현재 사용량 조회
+func (s *PreTradeRiskService) GetStatus(userID uint) (*RiskLimitStatus, error) {
+	open, err := s.openOrderNotional(userID)
+	if err != nil {
+		return nil, err
+	}
+	loss, err := s.dailyLoss(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &RiskLimitStatus{
+		Limits:   s.limits,
+		Exposure: RiskExposure{OpenOrderNotional: open, DailyLoss: loss},
+	}
+	if s.limits.MaxOpenOrderNotional > 0 {
+		remaining := max(s.limits.MaxOpenOrderNotional-open, 0)
+		status.RemainingOpenOrderNotional = &remaining
+	}
+	if s.limits.MaxDailyLoss > 0 {
+		remaining := max(s.limits.MaxDailyLoss-loss, 0)
+		status.RemainingDailyLoss = &remaining
+	}
+	return status, nil
+}
+
+// openOrderNotional 미체결 주문(매수/매도)의 남은 수량 기준 금액 합계 (센트)
+func (s *PreTradeRiskService) openOrderNotional(userID uint) (int64, error) {
+	var orders []models.Order
+	if err := s.db.Select("remaining", "price_ticks").
+		Where("user_id = ? AND status IN ?", userID, openOrderStatuses).
+		Find(&orders).Error; err != nil {
+		return 0, fmt.Errorf("미체결 주문 조회 실패: %w", err)
+	}
+
+	var total int64
+	for _, order := range orders {
+		total += models.ReserveCents(order.Remaining, order.PriceTicks)
+	}
+	return total, nil
+}
+
+// worstCasePosition 같은 방향 미체결 주문이 모두 체결된다고 가정한 주문 후 포지션 수량
+func (s *PreTradeRiskService) worstCasePosition(userID uint, req models.CreateOrderRequest) (int64, error) {
+	var position int64
+	if err := s.db.Model(&models.Position{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("user_id = ? AND milestone_id = ? AND option_id = ?", userID, req.MilestoneID, req.OptionID).
+		Scan(&position).Error; err != nil {
+		return 0, fmt.Errorf("포지션 조회 실패: %w", err)
+	}
+
+	var pending int64
+	if err := s.db.Model(&models.Order{}).
+		Select("COALESCE(SUM(remaining), 0)").
+		Where("user_id = ? AND milestone_id = ? AND option_id = ? AND side = ? AND status IN ?",
+			userID, req.MilestoneID, req.OptionID, req.Side, openOrderStatuses).
+		Scan(&pending).Error; err != nil {
+		return 0, fmt.Errorf("미체결 주문 조회 실패: %w", err)
+	}
+
+	if req.Side == models.OrderSideBuy {
+		return position + pending + req.Quantity, nil
+	}
+	return position - pending - req.Quantity, nil
+}
+
+// dailyLoss 오늘(UTC) 자정 스냅샷 대비 손익 감소분 (이익이면 0)
+// 오늘 스냅샷이 아직 없으면 가장 최근 스냅샷, 스냅샷이 전혀 없으면 손익 0을 기준으로 한다
+func (s *PreTradeRiskService) dailyLoss(userID uint) (int64, error) {
+	var baseline models.PositionSnapshot
+	if err := s.db.Where("user_id = ? AND snapshot_date <= ?", userID, snapshotDate(time.Now())).
+		Order("snapshot_date DESC").
+		Limit(1).
+		Find(&baseline).Error; err != nil {
+		return 0, fmt.Errorf("스냅샷 조회 실패: %w", err)
+	}
+
+	current, err := s.portfolio.currentValue(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	change := (current.RealizedPnL + current.UnrealizedPnL) - (baseline.RealizedPnL + baseline.UnrealizedPnL)
+	if change >= 0 {
+		return 0, nil
+	}
+	return -change, nil
+}
@@ -0,0 +1,339 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// ProjectInviteTTL 팀 초대 유효 기간
+const ProjectInviteTTL = 7 * 24 * time.Hour
+
+var (
+	ErrProjectNotFound       = errors.New("프로젝트를 찾을 수 없습니다")
+	ErrProjectForbidden      = errors.New("프로젝트에 대한 권한이 없습니다")
+	ErrInvalidProjectRole    = errors.New("초대할 수 있는 역할은 editor, viewer입니다")
+	ErrAlreadyProjectMember  = errors.New("이미 프로젝트 팀원입니다")
+	ErrProjectInviteNotFound = errors.New("유효한 초대를 찾을 수 없습니다")
+	ErrProjectMemberNotFound = errors.New("프로젝트 팀원을 찾을 수 없습니다")
+)
+
+// ProjectMemberView 팀원 목록 항목 (작성자 포함)
+type ProjectMemberView struct {
+	UserID   uint               `json:"user_id"`
+	Username string             `json:"username"`
+	Role     models.ProjectRole `json:"role"`
+	JoinedAt time.Time          `json:"joined_at"`
+}
+
+// ProjectMemberService 프로젝트 팀원(owner/editor/viewer) 및 초대 관리, 역할 기반 권한 확인
+//
+// 작성자(Project.UserID)는 항상 owner이며, 나머지 팀원은 이메일 초대를 수락해 합류한다.
+type ProjectMemberService struct {
+	db                  *gorm.DB
+	notificationService *NotificationService
+}
+
+// NewProjectMemberService 생성자
+func NewProjectMemberService(db *gorm.DB) *ProjectMemberService {
+	return &ProjectMemberService{
+		db:                  db,
+		notificationService: NewNotificationService(db),
+	}
+}
+
+// RoleOf 사용자의 프로젝트 역할 (팀원이 아니면 빈 값)
+func (s *ProjectMemberService) RoleOf(projectID, userID uint) (*models.Project, models.ProjectRole, error) {
+	var project models.Project
+	if err := s.db.First(&project, projectID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", ErrProjectNotFound
+		}
+		return nil, "", err
+	}
+	if project.UserID == userID {
+		return &project, models.ProjectRoleOwner, nil
+	}
+
+	var member models.ProjectMember
+	err := s.db.Where("project_id = ? AND user_id = ?", projectID, userID).First(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &project, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return &project, member.Role, nil
+}
+
+// Authorize 역할 권한 확인 - 팀원이 아니면 비공개 프로젝트는 ErrProjectNotFound, 그 외 ErrProjectForbidden
+//
+//	project, err := s.Authorize(projectID, userID, models.ProjectRole.CanEdit)
+func (s *ProjectMemberService) Authorize(projectID, userID uint, allowed func(models.ProjectRole) bool) (*models.Project, error) {
+	project, role, err := s.RoleOf(projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role == "" && !project.IsPublic {
+		return nil, ErrProjectNotFound
+	}
+	if !allowed(role) {
+		return nil, ErrProjectForbidden
+	}
+	return project, nil
+}
+
+// CanView 비공개 프로젝트는 팀원만 열람
+func (s *ProjectMemberService) CanView(project *models.Project, userID uint) (bool, error) {
+	if project.IsPublic || project.UserID == userID {
+		return true, nil
+	}
+	var count int64
+	if err := s.db.Model(&models.ProjectMember{}).
+		Where("project_id = ? AND user_id = ?", project.ID, userID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// MemberProjectIDs 사용자가 팀원(editor/viewer)으로 참여 중인 프로젝트
+func (s *ProjectMemberService) MemberProjectIDs(userID uint) *gorm.DB {
+	return s.db.Model(&models.ProjectMember{}).Select("project_id").Where("user_id = ?", userID)
+}
+
+// ListMembers 작성자와 팀원 목록 (팀원만 조회 가능)
+func (s *ProjectMemberService) ListMembers(projectID, userID uint) ([]ProjectMemberView, error) {
+	project, err := s.Authorize(projectID, userID, func(role models.ProjectRole) bool { return role != "" })
+	if err != nil {
+		return nil, err
+	}
+
+	var owner models.User
+	if err := s.db.Select("id", "username").First(&owner, project.UserID).Error; err != nil {
+		return nil, fmt.Errorf("작성자 조회 실패: %w", err)
+	}
+	views := []ProjectMemberView{{UserID: owner.ID, Username: owner.Username, Role: models.ProjectRoleOwner, JoinedAt: project.CreatedAt}}
+
+	var members []models.ProjectMember
+	if err := s.db.Preload("User").Where("project_id = ?", projectID).Order("created_at ASC").Find(&members).Error; err != nil {
+		return nil, fmt.Errorf("팀원 조회 실패: %w", err)
+	}
+	for _, member := range members {
+		views = append(views, ProjectMemberView{UserID: member.UserID, Username: member.User.Username, Role: member.Role, JoinedAt: member.CreatedAt})
+	}
+	return views, nil
+}
+
+// Invite 이메일로 팀 초대 (작성자만, 같은 이메일의 대기 중 초대는 새 초대로 대체)
+func (s *ProjectMemberService) Invite(projectID, inviterID uint, email string, role models.ProjectRole) (*models.ProjectInvite, error) {
+	if !role.IsValid() {
+		return nil, ErrInvalidProjectRole
+	}
+	project, err := s.Authorize(projectID, inviterID, models.ProjectRole.CanManage)
+	if err != nil {
+		return nil, err
+	}
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	var invitee models.User
+	inviteeFound := s.db.Where("LOWER(email) = ?", email).First(&invitee).Error == nil
+	if inviteeFound {
+		_, existing, err := s.RoleOf(projectID, invitee.ID)
+		if err != nil {
+			return nil, err
+		}
+		if existing != "" {
+			return nil, ErrAlreadyProjectMember
+		}
+	}
+
+	invite := models.ProjectInvite{
+		ProjectID: projectID,
+		Email:     email,
+		Role:      role,
+		Status:    models.ProjectInvitePending,
+		InvitedBy: inviterID,
+		ExpiresAt: time.Now().Add(ProjectInviteTTL),
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ProjectInvite{}).
+			Where("project_id = ? AND email = ? AND status = ?", projectID, email, models.ProjectInvitePending).
+			Update("status", models.ProjectInviteRevoked).Error; err != nil {
+			return err
+		}
+		return tx.Create(&invite).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("초대 저장 실패: %w", err)
+	}
+
+	// 이미 가입한 사용자에게는 알림 (미가입자는 가입 후 내 초대 목록에서 확인)
+	if inviteeFound {
+		if _, err := s.notificationService.Notify(models.CreateNotificationRequest{
+			UserID:   invitee.ID,
+			Type:     models.NotificationTypeProjectInvite,
+			Priority: models.NotificationPriorityNormal,
+			Title:    "프로젝트 팀 초대",
+			Message:  fmt.Sprintf("'%s' 프로젝트에 %s(으)로 초대되었습니다", project.Title, role),
+			Link:     "/settings/project-invites",
+			Data:     map[string]interface{}{"invite_id": invite.ID, "project_id": projectID, "role": role},
+		}); err != nil {
+			log.Printf("⚠️ Failed to notify project invite %d: %v", invite.ID, err)
+		}
+	}
+	return &invite, nil
+}
+
+// ListInvites 프로젝트의 대기 중 초대 (작성자만)
+func (s *ProjectMemberService) ListInvites(projectID, userID uint) ([]models.ProjectInvite, error) {
+	if _, err := s.Authorize(projectID, userID, models.ProjectRole.CanManage); err != nil {
+		return nil, err
+	}
+	invites := []models.ProjectInvite{}
+	err := s.db.Where("project_id = ? AND status = ? AND expires_at > ?", projectID, models.ProjectInvitePending, time.Now()).
+		Order("created_at DESC").
+		Find(&invites).Error
+	return invites, err
+}
+
+// RevokeInvite 대기 중 초대 취소 (작성자만)
+func (s *ProjectMemberService) RevokeInvite(projectID, inviteID, userID uint) error {
+	if _, err := s.Authorize(projectID, userID, models.ProjectRole.CanManage); err != nil {
+		return err
+	}
+	result := s.db.Model(&models.ProjectInvite{}).
+		Where("id = ? AND project_id = ? AND status = ?", inviteID, projectID, models.ProjectInvitePending).
+		Update("status", models.ProjectInviteRevoked)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrProjectInviteNotFound
+	}
+	return nil
+}
+
+// MyInvites 내 이메일로 온 대기 중 초대
+func (s *ProjectMemberService) MyInvites(userID uint) ([]models.ProjectInvite, error) {
+	var user models.User
+	if err := s.db.Select("id", "email").First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+	invites := []models.ProjectInvite{}
+	err := s.db.Preload("Project").
+		Where("email = ? AND status = ? AND expires_at > ?", strings.ToLower(user.Email), models.ProjectInvitePending, time.Now()).
+		Order("created_at DESC").
+		Find(&invites).Error
+	return invites, err
+}
+
+// pendingInviteFor 사용자 이메일로 온 유효한 초대
+func (s *ProjectMemberService) pendingInviteFor(inviteID, userID uint) (*models.ProjectInvite, error) {
+	var user models.User
+	if err := s.db.Select("id", "email").First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+	var invite models.ProjectInvite
+	err := s.db.Where("id = ? AND email = ? AND status = ? AND expires_at > ?",
+		inviteID, strings.ToLower(user.Email), models.ProjectInvitePending, time.Now()).
+		First(&invite).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrProjectInviteNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// AcceptInvite 초대 수락 - 팀원으로 합류
+func (s *ProjectMemberService) AcceptInvite(inviteID, userID uint) (*models.ProjectMember, error) {
+	invite, err := s.pendingInviteFor(inviteID, userID)
+	if err != nil {
+		return nil, err
+	}
+	_, existing, err := s.RoleOf(invite.ProjectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != "" {
+		return nil, ErrAlreadyProjectMember
+	}
+
+	member := models.ProjectMember{ProjectID: invite.ProjectID, UserID: userID, Role: invite.Role, InvitedBy: invite.InvitedBy}
+	now := time.Now()
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.ProjectInvite{}).
+			Where("id = ? AND status = ?", invite.ID, models.ProjectInvitePending).
+			Updates(map[string]interface{}{"status": models.ProjectInviteAccepted, "accepted_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrProjectInviteNotFound
+		}
+		return tx.Create(&member).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// DeclineInvite 초대 거절
+func (s *ProjectMemberService) DeclineInvite(inviteID, userID uint) error {
+	invite, err := s.pendingInviteFor(inviteID, userID)
+	if err != nil {
+		return err
+	}
+	return s.db.Model(invite).Update("status", models.ProjectInviteDeclined).Error
+}
+
+// UpdateRole 팀원 역할 변경 (작성자만, 작성자 자신의 역할은 변경 불가)
+func (s *ProjectMemberService) UpdateRole(projectID, ownerID, memberUserID uint, role models.ProjectRole) (*models.ProjectMember, error) {
+	if !role.IsValid() {
+		return nil, ErrInvalidProjectRole
+	}
+	if _, err := s.Authorize(projectID, ownerID, models.ProjectRole.CanManage); err != nil {
+		return nil, err
+	}
+
+	var member models.ProjectMember
+	if err := s.db.Where("project_id = ? AND user_id = ?", projectID, memberUserID).First(&member).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectMemberNotFound
+		}
+		return nil, err
+	}
+	if err := s.db.Model(&member).Update("role", role).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// RemoveMember 팀원 제거 (작성자는 누구든, 팀원은 자기 자신만 - 탈퇴)
+func (s *ProjectMemberService) RemoveMember(projectID, actorID, memberUserID uint) error {
+	allowed := models.ProjectRole.CanManage
+	if actorID == memberUserID {
+		allowed = func(role models.ProjectRole) bool { return role != "" && role != models.ProjectRoleOwner }
+	}
+	if _, err := s.Authorize(projectID, actorID, allowed); err != nil {
+		return err
+	}
+
+	result := s.db.Where("project_id = ? AND user_id = ?", projectID, memberUserID).Delete(&models.ProjectMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrProjectMemberNotFound
+	}
+	return nil
+}
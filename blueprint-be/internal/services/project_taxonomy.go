@@ -0,0 +1,153 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// 프로젝트 진행 단계 (마일스톤 상태 묶음 - 해당 단계의 마일스톤이 하나라도 있으면 일치)
+const (
+	ProjectStageProposal     = "proposal"     // 펀딩 전 제안
+	ProjectStageFunding      = "funding"      // 펀딩 진행 중
+	ProjectStageActive       = "active"       // 펀딩 성공, 진행 중
+	ProjectStageVerification = "verification" // 증거 제출/검증/분쟁
+	ProjectStageCompleted    = "completed"    // 완료
+)
+
+// 펀딩 현황
+const (
+	FundingStatusFunded      = "funded"      // 최소 목표 금액을 달성한 마일스톤이 있음
+	FundingStatusUnderfunded = "underfunded" // 펀딩 중이고 아직 최소 목표 금액 미달
+	FundingStatusRejected    = "rejected"    // 펀딩 실패로 폐기된 마일스톤이 있음
+)
+
+var (
+	ErrInvalidProjectCategory = errors.New("지원하지 않는 카테고리입니다")
+	ErrInvalidProjectStage    = errors.New("지원하지 않는 진행 단계입니다")
+	ErrInvalidFundingStatus   = errors.New("지원하지 않는 펀딩 현황입니다")
+)
+
+// projectStageStatuses 진행 단계별 마일스톤 상태
+var projectStageStatuses = map[string][]models.MilestoneStatus{
+	ProjectStageProposal:     {models.MilestoneStatusProposal, models.MilestoneStatusPending},
+	ProjectStageFunding:      {models.MilestoneStatusFunding},
+	ProjectStageActive:       {models.MilestoneStatusActive},
+	ProjectStageVerification: {models.MilestoneStatusProofSubmitted, models.MilestoneStatusUnderVerification, models.MilestoneStatusProofApproved, models.MilestoneStatusProofRejected, models.MilestoneStatusDisputed},
+	ProjectStageCompleted:    {models.MilestoneStatusCompleted},
+}
+
+// ProjectTaxonomyFilter 카테고리/태그/진행 단계/펀딩 현황 탐색 조건 (빈 값은 조건 없음)
+type ProjectTaxonomyFilter struct {
+	Category models.ProjectCategory
+	Tag      string
+	Stage    string
+	Funding  string
+}
+
+// Normalize 조건 검증 및 태그 정규화
+func (f *ProjectTaxonomyFilter) Normalize() error {
+	if f.Category != "" && !f.Category.IsValid() {
+		return fmt.Errorf("%w: %s", ErrInvalidProjectCategory, f.Category)
+	}
+	if f.Stage != "" {
+		if _, ok := projectStageStatuses[f.Stage]; !ok {
+			return fmt.Errorf("%w: %s", ErrInvalidProjectStage, f.Stage)
+		}
+	}
+	switch f.Funding {
+	case "", FundingStatusFunded, FundingStatusUnderfunded, FundingStatusRejected:
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidFundingStatus, f.Funding)
+	}
+	if f.Tag != "" {
+		tags, err := models.NormalizeProjectTags([]string{f.Tag})
+		if err != nil {
+			return err
+		}
+		f.Tag = ""
+		if len(tags) > 0 {
+			f.Tag = tags[0]
+		}
+	}
+	return nil
+}
+
+// Apply projects 테이블 쿼리에 조건 적용 (Normalize 후 호출)
+func (f ProjectTaxonomyFilter) Apply(query *gorm.DB) *gorm.DB {
+	if f.Category != "" {
+		query = query.Where("projects.category = ?", f.Category)
+	}
+	if f.Tag != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM project_tags WHERE project_tags.project_id = projects.id AND project_tags.tag = ?)", f.Tag)
+	}
+	if f.Stage != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM milestones WHERE milestones.project_id = projects.id AND milestones.deleted_at IS NULL AND milestones.status IN ?)",
+			projectStageStatuses[f.Stage])
+	}
+
+	switch f.Funding {
+	case FundingStatusFunded:
+		query = query.Where("EXISTS (SELECT 1 FROM milestones WHERE milestones.project_id = projects.id AND milestones.deleted_at IS NULL AND milestones.current_tvl >= milestones.min_viable_capital AND milestones.status NOT IN ?)",
+			[]models.MilestoneStatus{models.MilestoneStatusProposal, models.MilestoneStatusRejected})
+	case FundingStatusUnderfunded:
+		query = query.Where("EXISTS (SELECT 1 FROM milestones WHERE milestones.project_id = projects.id AND milestones.deleted_at IS NULL AND milestones.status = ? AND milestones.current_tvl < milestones.min_viable_capital)",
+			models.MilestoneStatusFunding)
+	case FundingStatusRejected:
+		query = query.Where("EXISTS (SELECT 1 FROM milestones WHERE milestones.project_id = projects.id AND milestones.deleted_at IS NULL AND milestones.status = ?)",
+			models.MilestoneStatusRejected)
+	}
+	return query
+}
+
+// SyncProjectTags 프로젝트 태그 인덱스를 정규화된 태그 목록으로 교체
+func SyncProjectTags(tx *gorm.DB, projectID uint, tags []string) error {
+	if err := tx.Where("project_id = ?", projectID).Delete(&models.ProjectTag{}).Error; err != nil {
+		return fmt.Errorf("프로젝트 태그 삭제 실패: %w", err)
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	rows := make([]models.ProjectTag, 0, len(tags))
+	for _, tag := range tags {
+		rows = append(rows, models.ProjectTag{ProjectID: projectID, Tag: tag})
+	}
+	if err := tx.Create(&rows).Error; err != nil {
+		return fmt.Errorf("프로젝트 태그 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// TagCount 태그별 공개 프로젝트 수
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
+// PopularTags 공개 프로젝트에서 많이 쓰인 태그 (카테고리 지정 시 해당 카테고리만)
+func (s *PublicProjectService) PopularTags(category models.ProjectCategory, limit int) ([]TagCount, error) {
+	if category != "" && !category.IsValid() {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidProjectCategory, category)
+	}
+
+	projects := s.publicProjects().Select("projects.id")
+	if category != "" {
+		projects = projects.Where("projects.category = ?", category)
+	}
+
+	tags := []TagCount{}
+	if err := s.db.Model(&models.ProjectTag{}).
+		Select("tag, COUNT(*) AS count").
+		Where("project_id IN (?)", projects).
+		Group("tag").
+		Order("count DESC, tag ASC").
+		Limit(limit).
+		Scan(&tags).Error; err != nil {
+		return nil, fmt.Errorf("태그 집계 실패: %w", err)
+	}
+	return tags, nil
+}
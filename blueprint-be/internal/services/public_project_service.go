@@ -0,0 +1,366 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/redis"
+
+	"gorm.io/gorm"
+)
+
+// 공개 프로젝트 목록 정렬 기준
+const (
+	PublicProjectSortNewest = "newest" // 최근 생성 순 (기본)
+	PublicProjectSortTVL    = "tvl"    // 마일스톤 총 베팅액 많은 순
+	PublicProjectSortTrust  = "trust"  // 작성자 신뢰 점수 높은 순
+)
+
+// publicProjectCacheTTL 공개 페이지 캐시 유지 시간 (가격/TVL 변화를 너무 늦게 반영하지 않도록 짧게)
+const publicProjectCacheTTL = 30 * time.Second
+
+var (
+	ErrPublicProjectNotFound = errors.New("공개된 프로젝트를 찾을 수 없습니다")
+	ErrInvalidPublicSort     = errors.New("지원하지 않는 정렬 기준입니다")
+)
+
+// PublicProjectFilter 공개 프로젝트 목록 조건
+type PublicProjectFilter struct {
+	ProjectTaxonomyFilter
+	Sort   string
+	Limit  int
+	Offset int
+}
+
+// PublicCreator 공개 프로필 작성자 정보 (ProfilePublic이 꺼져 있으면 응답에서 제외)
+type PublicCreator struct {
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name,omitempty"`
+	Avatar      string `json:"avatar,omitempty"`
+	Bio         string `json:"bio,omitempty"`
+}
+
+// PublicMilestone 공개 페이지의 마일스톤 상태와 마켓 가격
+type PublicMilestone struct {
+	ID               uint                   `json:"id"`
+	Order            int                    `json:"order"`
+	Title            string                 `json:"title"`
+	Description      string                 `json:"description"`
+	Status           models.MilestoneStatus `json:"status"`
+	MarketType       models.MarketType      `json:"market_type"`
+	TargetDate       *time.Time             `json:"target_date,omitempty"`
+	CompletedAt      *time.Time             `json:"completed_at,omitempty"`
+	CurrentTVL       int64                  `json:"current_tvl"`
+	MinViableCapital int64                  `json:"min_viable_capital"`
+	FundingProgress  float64                `json:"funding_progress"`
+	SupporterCount   int                    `json:"supporter_count"`
+	Prices           map[string]float64     `json:"prices"` // 옵션별 현재가 (거래 전이면 비어 있음)
+	Volume24h        int64                  `json:"volume_24h"`
+	CreatorHoldings  map[string]int64       `json:"creator_holdings,omitempty"` // 작성자 보유 수량 (InvestmentPublic일 때만)
+}
+
+// PublicProject 공개 프로젝트 페이지 (목록에서는 Milestones 생략)
+type PublicProject struct {
+	ID                  uint                   `json:"id"`
+	Title               string                 `json:"title"`
+	Description         string                 `json:"description"`
+	Category            models.ProjectCategory `json:"category"`
+	Status              models.ProjectStatus   `json:"status"`
+	TargetDate          *time.Time             `json:"target_date,omitempty"`
+	Tags                []string               `json:"tags"`
+	CreatedAt           time.Time              `json:"created_at"`
+	Creator             *PublicCreator         `json:"creator,omitempty"`
+	CreatorTrustScore   int                    `json:"creator_trust_score"`
+	TotalTVL            int64                  `json:"total_tvl"`
+	MilestoneCount      int                    `json:"milestone_count"`
+	CompletedMilestones int                    `json:"completed_milestones"`
+	Milestones          []PublicMilestone      `json:"milestones,omitempty"`
+}
+
+// publicProjectList 목록 캐시 단위
+type publicProjectList struct {
+	Projects []PublicProject `json:"projects"`
+	Total    int64           `json:"total"`
+}
+
+// PublicProjectService 비로그인 사용자용 공개 프로젝트 조회 (프로젝트 + 마일스톤 + 마켓 집계, Redis 캐시)
+//
+// IsPublic인 프로젝트 중 초안이 아니고 작성자 계정이 활성 상태인 것만 노출한다.
+type PublicProjectService struct {
+	db *gorm.DB
+}
+
+// NewPublicProjectService 생성자
+func NewPublicProjectService(db *gorm.DB) *PublicProjectService {
+	return &PublicProjectService{
+		db: db,
+	}
+}
+
+// publicProjects 공개 조건을 적용한 프로젝트 쿼리
+func (s *PublicProjectService) publicProjects() *gorm.DB {
+	return s.db.Model(&models.Project{}).
+		Joins("JOIN users ON users.id = projects.user_id AND users.deleted_at IS NULL AND users.is_active = ?", true).
+		Where("projects.is_public = ? AND projects.status <> ?", true, models.ProjectDraft)
+}
+
+// GetProject 공개 프로젝트 상세 (마일스톤 상태, 옵션별 가격, TVL, 작성자 신뢰 점수)
+func (s *PublicProjectService) GetProject(projectID uint) (*PublicProject, error) {
+	cacheAvailable := redis.GetClient() != nil
+	if cacheAvailable {
+		var cached PublicProject
+		if err := redis.GetPublicProject(projectID, &cached); err == nil {
+			return &cached, nil
+		}
+	}
+
+	var project models.Project
+	err := s.publicProjects().
+		Preload("Milestones", func(db *gorm.DB) *gorm.DB { return db.Order("\"order\" ASC, id ASC") }).
+		Where("projects.id = ?", projectID).
+		First(&project).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrPublicProjectNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("프로젝트 조회 실패: %w", err)
+	}
+
+	pages, err := s.assemble([]models.Project{project})
+	if err != nil {
+		return nil, err
+	}
+	page := pages[0]
+
+	if err := s.attachMilestones(&page, project); err != nil {
+		return nil, err
+	}
+
+	if cacheAvailable {
+		redis.SetPublicProject(projectID, page, publicProjectCacheTTL)
+	}
+	return &page, nil
+}
+
+// ListProjects 공개 프로젝트 목록 (카테고리/태그/진행 단계/펀딩 현황 필터, 최신/TVL/신뢰 점수 정렬)
+func (s *PublicProjectService) ListProjects(filter PublicProjectFilter) ([]PublicProject, int64, error) {
+	var order string
+	switch filter.Sort {
+	case "", PublicProjectSortNewest:
+		order = "projects.created_at DESC, projects.id DESC"
+	case PublicProjectSortTVL:
+		order = "COALESCE(tvl.total_tvl, 0) DESC, projects.id DESC"
+	case PublicProjectSortTrust:
+		order = "users.trust_score DESC, projects.id DESC"
+	default:
+		return nil, 0, fmt.Errorf("%w: %s", ErrInvalidPublicSort, filter.Sort)
+	}
+	if err := filter.Normalize(); err != nil {
+		return nil, 0, err
+	}
+
+	cacheKey := fmt.Sprintf("%s:%s:%s:%s:%s:%d:%d", filter.Category, filter.Tag, filter.Stage, filter.Funding, filter.Sort, filter.Limit, filter.Offset)
+	cacheAvailable := redis.GetClient() != nil
+	if cacheAvailable {
+		var cached publicProjectList
+		if err := redis.GetPublicProjectList(cacheKey, &cached); err == nil {
+			return cached.Projects, cached.Total, nil
+		}
+	}
+
+	query := filter.Apply(s.publicProjects())
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("프로젝트 수 조회 실패: %w", err)
+	}
+
+	tvl := s.db.Model(&models.Milestone{}).
+		Select("project_id, SUM(current_tvl) AS total_tvl").
+		Group("project_id")
+
+	var projects []models.Project
+	if err := query.
+		Joins("LEFT JOIN (?) AS tvl ON tvl.project_id = projects.id", tvl).
+		Order(order).
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&projects).Error; err != nil {
+		return nil, 0, fmt.Errorf("프로젝트 목록 조회 실패: %w", err)
+	}
+
+	pages, err := s.assemble(projects)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if cacheAvailable {
+		redis.SetPublicProjectList(cacheKey, publicProjectList{Projects: pages, Total: total}, publicProjectCacheTTL)
+	}
+	return pages, total, nil
+}
+
+// InvalidatePublicProject 프로젝트 수정/삭제 시 공개 페이지 캐시 삭제
+func InvalidatePublicProject(projectID uint) {
+	if redis.GetClient() == nil {
+		return
+	}
+	redis.DeletePublicProject(projectID)
+}
+
+// assemble 프로젝트 기본 정보에 작성자(공개 설정 반영)와 마일스톤 집계 추가
+func (s *PublicProjectService) assemble(projects []models.Project) ([]PublicProject, error) {
+	if len(projects) == 0 {
+		return []PublicProject{}, nil
+	}
+
+	projectIDs := make([]uint, 0, len(projects))
+	userIDs := make([]uint, 0, len(projects))
+	for _, project := range projects {
+		projectIDs = append(projectIDs, project.ID)
+		userIDs = append(userIDs, project.UserID)
+	}
+
+	var users []models.User
+	if err := s.db.Select("id", "username", "trust_score").Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("작성자 조회 실패: %w", err)
+	}
+	userByID := make(map[uint]models.User, len(users))
+	for _, user := range users {
+		userByID[user.ID] = user
+	}
+
+	profileByUser, err := s.profiles(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []struct {
+		ProjectID uint
+		TotalTVL  int64
+		Count     int
+		Completed int
+	}
+	if err := s.db.Model(&models.Milestone{}).
+		Select("project_id, COALESCE(SUM(current_tvl), 0) AS total_tvl, COUNT(*) AS count, SUM(CASE WHEN is_completed THEN 1 ELSE 0 END) AS completed").
+		Where("project_id IN ?", projectIDs).
+		Group("project_id").
+		Scan(&stats).Error; err != nil {
+		return nil, fmt.Errorf("마일스톤 집계 실패: %w", err)
+	}
+
+	pages := make([]PublicProject, 0, len(projects))
+	for _, project := range projects {
+		user := userByID[project.UserID]
+		page := PublicProject{
+			ID:                project.ID,
+			Title:             project.Title,
+			Description:       project.Description,
+			Category:          project.Category,
+			Status:            project.Status,
+			TargetDate:        project.TargetDate,
+			Tags:              project.TagsArray,
+			CreatedAt:         project.CreatedAt,
+			CreatorTrustScore: user.TrustScore,
+		}
+		if profile, ok := profileByUser[project.UserID]; !ok || profile.ProfilePublic {
+			page.Creator = &PublicCreator{Username: user.Username}
+			if ok {
+				page.Creator.DisplayName = profile.DisplayName
+				page.Creator.Avatar = profile.Avatar
+				page.Creator.Bio = profile.Bio
+			}
+		}
+		for _, stat := range stats {
+			if stat.ProjectID == project.ID {
+				page.TotalTVL = stat.TotalTVL
+				page.MilestoneCount = stat.Count
+				page.CompletedMilestones = stat.Completed
+			}
+		}
+		pages = append(pages, page)
+	}
+	return pages, nil
+}
+
+// profiles 작성자 공개 설정 조회 (프로필이 없으면 기본값 - 프로필 공개, 투자 비공개)
+func (s *PublicProjectService) profiles(userIDs []uint) (map[uint]models.UserProfile, error) {
+	var profiles []models.UserProfile
+	if err := s.db.Where("user_id IN ?", userIDs).Find(&profiles).Error; err != nil {
+		return nil, fmt.Errorf("작성자 프로필 조회 실패: %w", err)
+	}
+	byUser := make(map[uint]models.UserProfile, len(profiles))
+	for _, profile := range profiles {
+		byUser[profile.UserID] = profile
+	}
+	return byUser, nil
+}
+
+// attachMilestones 마일스톤별 상태/펀딩 현황과 MarketData 옵션 가격 추가
+func (s *PublicProjectService) attachMilestones(page *PublicProject, project models.Project) error {
+	page.Milestones = make([]PublicMilestone, 0, len(project.Milestones))
+	if len(project.Milestones) == 0 {
+		return nil
+	}
+
+	milestoneIDs := make([]uint, 0, len(project.Milestones))
+	for _, milestone := range project.Milestones {
+		milestoneIDs = append(milestoneIDs, milestone.ID)
+	}
+
+	var marketData []models.MarketData
+	if err := s.db.Select("milestone_id", "option_id", "current_price", "volume24h").
+		Where("milestone_id IN ?", milestoneIDs).
+		Find(&marketData).Error; err != nil {
+		return fmt.Errorf("마켓 가격 조회 실패: %w", err)
+	}
+
+	// 작성자의 보유 포지션은 투자 내역 공개 설정일 때만 노출
+	holdings := map[uint]map[string]int64{}
+	profiles, err := s.profiles([]uint{project.UserID})
+	if err != nil {
+		return err
+	}
+	if profile, ok := profiles[project.UserID]; ok && profile.InvestmentPublic {
+		var positions []models.Position
+		if err := s.db.Where("user_id = ? AND milestone_id IN ? AND quantity > 0", project.UserID, milestoneIDs).
+			Find(&positions).Error; err != nil {
+			return fmt.Errorf("작성자 포지션 조회 실패: %w", err)
+		}
+		for _, position := range positions {
+			if holdings[position.MilestoneID] == nil {
+				holdings[position.MilestoneID] = map[string]int64{}
+			}
+			holdings[position.MilestoneID][position.OptionID] += position.Quantity
+		}
+	}
+
+	for _, milestone := range project.Milestones {
+		public := PublicMilestone{
+			ID:               milestone.ID,
+			Order:            milestone.Order,
+			Title:            milestone.Title,
+			Description:      milestone.Description,
+			Status:           milestone.Status,
+			MarketType:       milestone.MarketType,
+			TargetDate:       milestone.TargetDate,
+			CompletedAt:      milestone.CompletedAt,
+			CurrentTVL:       milestone.CurrentTVL,
+			MinViableCapital: milestone.MinViableCapital,
+			FundingProgress:  milestone.FundingProgress,
+			SupporterCount:   milestone.SupporterCount,
+			Prices:           map[string]float64{},
+			CreatorHoldings:  holdings[milestone.ID],
+		}
+		for _, data := range marketData {
+			if data.MilestoneID == milestone.ID {
+				public.Prices[data.OptionID] = data.CurrentPrice
+				public.Volume24h += data.Volume24h
+			}
+		}
+		page.Milestones = append(page.Milestones, public)
+	}
+	return nil
+}
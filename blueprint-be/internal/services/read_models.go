@@ -0,0 +1,217 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// 📚 조회 전용 모델 (CQRS 읽기 측)
+//
+// 체결 이벤트가 들어올 때마다 가격 캔들(PriceCandle)을 미리 집계해 두고, 조회 API는 읽기 복제본에서
+// 이 테이블을 바로 읽는다. 쓰기는 항상 기본 DB로 가고 복제본은 몇 초 늦을 수 있다.
+
+var ErrInvalidCandleInterval = errors.New("지원하지 않는 캔들 간격입니다")
+
+// PriceCandleProjector 체결을 간격별 OHLCV 캔들에 반영
+// 같은 캔들을 동시에 고치지 않도록 반영을 직렬화한다 (마켓별 매칭은 한 인스턴스가 담당)
+type PriceCandleProjector struct {
+	db    *gorm.DB
+	mutex sync.Mutex
+}
+
+// NewPriceCandleProjector 캔들 반영기 생성자
+func NewPriceCandleProjector(db *gorm.DB) *PriceCandleProjector {
+	return &PriceCandleProjector{db: db}
+}
+
+// ApplyTrades 체결 목록(체결 순서)을 모든 간격의 캔들에 반영
+func (p *PriceCandleProjector) ApplyTrades(trades []models.Trade) error {
+	if len(trades) == 0 {
+		return nil
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.applyTrades(trades)
+}
+
+func (p *PriceCandleProjector) applyTrades(trades []models.Trade) error {
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		for _, trade := range trades {
+			tradeTime := trade.CreatedAt
+			if tradeTime.IsZero() {
+				tradeTime = time.Now()
+			}
+			ticks := trade.PriceTicks
+			if ticks == 0 {
+				ticks = models.PriceToTicks(trade.Price)
+			}
+
+			for _, interval := range models.CandleIntervals {
+				if err := applyCandle(tx, trade, interval, tradeTime.UTC().Truncate(interval.Duration()), ticks); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// applyCandle 캔들 하나에 체결 반영 (없으면 생성)
+func applyCandle(tx *gorm.DB, trade models.Trade, interval models.CandleInterval, bucketStart time.Time, ticks int64) error {
+	var candle models.PriceCandle
+	err := tx.Where("milestone_id = ? AND option_id = ? AND candle_interval = ? AND bucket_start = ?",
+		trade.MilestoneID, trade.OptionID, interval, bucketStart).First(&candle).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return tx.Create(&models.PriceCandle{
+			MilestoneID: trade.MilestoneID,
+			OptionID:    trade.OptionID,
+			Interval:    interval,
+			BucketStart: bucketStart,
+			OpenTicks:   ticks,
+			HighTicks:   ticks,
+			LowTicks:    ticks,
+			CloseTicks:  ticks,
+			Quantity:    trade.Quantity,
+			Volume:      trade.TotalAmount,
+			Trades:      1,
+		}).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{
+		"close_ticks": ticks,
+		"quantity":    candle.Quantity + trade.Quantity,
+		"volume":      candle.Volume + trade.TotalAmount,
+		"trades":      candle.Trades + 1,
+	}
+	if ticks > candle.HighTicks {
+		updates["high_ticks"] = ticks
+	}
+	if ticks < candle.LowTicks {
+		updates["low_ticks"] = ticks
+	}
+	return tx.Model(&candle).Updates(updates).Error
+}
+
+// RebuildCandles 저장된 체결 내역으로 마켓 캔들을 다시 집계 (배포 직후 백필 / 불일치 복구)
+// 저장 전 체결이 실시간 반영과 겹치지 않도록 거래가 멈춘 마켓에서 실행한다
+func (p *PriceCandleProjector) RebuildCandles(milestoneID uint, optionID string) (int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if err := p.db.Where("milestone_id = ? AND option_id = ?", milestoneID, optionID).
+		Delete(&models.PriceCandle{}).Error; err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	var batch []models.Trade
+	err := p.db.Where("milestone_id = ? AND option_id = ?", milestoneID, optionID).
+		FindInBatches(&batch, 500, func(_ *gorm.DB, _ int) error {
+			if err := p.applyTrades(batch); err != nil {
+				return err
+			}
+			applied += len(batch)
+			return nil
+		}).Error
+	return applied, err
+}
+
+// RebuildPriceCandles 마켓 가격 캔들을 체결 내역으로 다시 집계 (관리자 백필)
+func (s *TradingService) RebuildPriceCandles(milestoneID uint, optionID string) (int, error) {
+	return NewPriceCandleProjector(s.db).RebuildCandles(milestoneID, optionID)
+}
+
+// projectReadModels 체결을 조회 전용 모델에 반영 (체결 후처리에서 비동기 호출)
+func (tp *tradePipeline) projectReadModels(trades []models.Trade) {
+	if err := tp.candleProjector.ApplyTrades(trades); err != nil {
+		log.Printf("❌ Failed to project price candles: %v", err)
+	}
+}
+
+// RecentTradesQuery 최근 체결 조회
+type RecentTradesQuery struct {
+	MilestoneID uint
+	OptionID    string
+	Limit       int
+}
+
+// GetRecentTrades 마켓 최근 체결 (최신순, 읽기 복제본)
+func (tqh *TradingQueryHandler) GetRecentTrades(query *RecentTradesQuery) ([]models.Trade, error) {
+	limit := query.Limit
+	if limit <= 0 || limit > 500 {
+		limit = 50
+	}
+
+	var trades []models.Trade
+	err := tqh.db.Where("milestone_id = ? AND option_id = ?", query.MilestoneID, query.OptionID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&trades).Error
+	return trades, err
+}
+
+// PriceCandlesQuery 가격 캔들 조회
+type PriceCandlesQuery struct {
+	MilestoneID uint
+	OptionID    string
+	Interval    models.CandleInterval
+	Limit       int
+}
+
+// PriceCandleView 가격 캔들 응답 (표시용 가격)
+type PriceCandleView struct {
+	Bucket string  `json:"bucket"`
+	Open   float64 `json:"open"`
+	High   float64 `json:"high"`
+	Low    float64 `json:"low"`
+	Close  float64 `json:"close"`
+	VWAP   float64 `json:"vwap"` // 구간 거래량 가중 평균가
+	Volume int64   `json:"volume"`
+	Trades int     `json:"trades"`
+}
+
+// GetPriceCandles 최근 캔들 (오래된 것부터, 읽기 복제본)
+func (tqh *TradingQueryHandler) GetPriceCandles(query *PriceCandlesQuery) ([]PriceCandleView, error) {
+	if query.Interval.Duration() == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCandleInterval, query.Interval)
+	}
+	limit := query.Limit
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+
+	var candles []models.PriceCandle
+	if err := tqh.db.Where("milestone_id = ? AND option_id = ? AND candle_interval = ?", query.MilestoneID, query.OptionID, query.Interval).
+		Order("bucket_start DESC").
+		Limit(limit).
+		Find(&candles).Error; err != nil {
+		return nil, err
+	}
+
+	views := make([]PriceCandleView, 0, len(candles))
+	for i := len(candles) - 1; i >= 0; i-- {
+		candle := candles[i]
+		views = append(views, PriceCandleView{
+			Bucket: candle.BucketStart.Format(time.RFC3339),
+			Open:   models.TicksToPrice(candle.OpenTicks),
+			High:   models.TicksToPrice(candle.HighTicks),
+			Low:    models.TicksToPrice(candle.LowTicks),
+			Close:  models.TicksToPrice(candle.CloseTicks),
+			VWAP:   candle.VWAP(),
+			Volume: candle.Volume,
+			Trades: candle.Trades,
+		})
+	}
+	return views, nil
+}
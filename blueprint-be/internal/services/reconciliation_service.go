@@ -0,0 +1,556 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// reconcileGracePeriod 막 생성/취소된 주문은 주문장과 DB가 잠시 어긋나므로 이 시간이 지난 주문만 대조
+	reconcileGracePeriod = time.Minute
+	// reconcileSettleTimeout 대조 전에 진행 중인 체결 후처리를 기다리는 최대 시간
+	reconcileSettleTimeout = 10 * time.Second
+	// maxReconciliationReports 목록 조회 최대 건수
+	maxReconciliationReports = 100
+)
+
+var (
+	ErrReconciliationRunning        = errors.New("대사가 이미 실행 중입니다")
+	ErrReconciliationReportNotFound = errors.New("대사 리포트를 찾을 수 없습니다")
+)
+
+// reconcileFinding 대조 중 발견한 불일치 (fix가 있으면 자동 보정 가능)
+type reconcileFinding struct {
+	models.ReconciliationDiscrepancy
+	fix func() error
+}
+
+func (f reconcileFinding) key() string {
+	return fmt.Sprintf("%+v", f.ReconciliationDiscrepancy)
+}
+
+// ReconciliationService 주문장·주문·체결·지갑 대사
+//
+// 체결 후처리(지갑/포지션)와 주문 상태 반영은 비동기 goroutine이라 실패하면 로그만 남는다. 대사는
+// 다음을 서로 맞춰 본다.
+//   - 주문장에 남은 주문 ↔ DB 주문 상태/남은 수량
+//   - DB 주문 체결 수량 ↔ 체결 내역 합계
+//   - 지갑 거래 수/수수료 통계 ↔ 체결 내역
+//   - 잠긴 USDC ↔ 열린 매수 주문 잠금액 + 미정산 조합 베팅 원금
+//
+// 진행 중인 체결과 구분하기 위해 후처리를 기다린 뒤 두 번 대조하고, 두 번 모두 같은 값으로 관찰된
+// 불일치만 리포트에 남긴다. 자동 보정은 원인이 분명한 경우로 한정한다: 종료된 주문을 주문장에서 제거,
+// 체결 내역에 맞춰 뒤처진 주문 체결 수량 반영, 열린 주문보다 많이 잠긴 USDC 해제. 나머지는 보고만 한다.
+type ReconciliationService struct {
+	db      *gorm.DB
+	engine  MatchingEngine
+	running atomic.Bool
+}
+
+// NewReconciliationService 생성자
+func NewReconciliationService(db *gorm.DB, engine MatchingEngine) *ReconciliationService {
+	return &ReconciliationService{
+		db:     db,
+		engine: engine,
+	}
+}
+
+// Run 대사 1회 실행 후 리포트 저장 (autoCorrect면 원인이 분명한 불일치를 보정)
+func (s *ReconciliationService) Run(autoCorrect bool) (*models.ReconciliationReport, error) {
+	if !s.running.CompareAndSwap(false, true) {
+		return nil, ErrReconciliationRunning
+	}
+	defer s.running.Store(false)
+
+	report := &models.ReconciliationReport{
+		StartedAt:   time.Now(),
+		AutoCorrect: autoCorrect,
+	}
+	if err := s.db.Create(report).Error; err != nil {
+		return nil, fmt.Errorf("대사 리포트 생성 실패: %w", err)
+	}
+
+	runErr := s.reconcile(report)
+	if runErr != nil {
+		report.Error = runErr.Error()
+	}
+
+	finishedAt := time.Now()
+	report.FinishedAt = &finishedAt
+	report.DiscrepancyCount = len(report.Discrepancies)
+	if err := s.db.Save(report).Error; err != nil {
+		return nil, fmt.Errorf("대사 리포트 저장 실패: %w", err)
+	}
+
+	log.Printf("🧾 Reconciliation %d: %d orders, %d wallets, %d discrepancies (%d corrected) in %v",
+		report.ID, report.OrdersChecked, report.WalletsChecked, report.DiscrepancyCount,
+		report.CorrectedCount, finishedAt.Sub(report.StartedAt))
+	return report, runErr
+}
+
+// reconcile 두 번 대조해 지속되는 불일치만 리포트에 기록하고 보정
+func (s *ReconciliationService) reconcile(report *models.ReconciliationReport) error {
+	s.settle()
+	first, err := s.collect(report)
+	if err != nil {
+		return err
+	}
+	if len(first) == 0 {
+		return nil
+	}
+
+	observed := make(map[string]bool, len(first))
+	for _, finding := range first {
+		observed[finding.key()] = true
+	}
+
+	s.settle()
+	second, err := s.collect(report)
+	if err != nil {
+		return err
+	}
+
+	for _, finding := range second {
+		if !observed[finding.key()] {
+			continue // 진행 중이던 체결/주문 처리로 해소됨
+		}
+		if report.AutoCorrect && finding.fix != nil {
+			if err := finding.fix(); err != nil {
+				log.Printf("⚠️ Reconciliation could not correct %s (order %d, user %d): %v",
+					finding.Check, finding.OrderID, finding.UserID, err)
+			} else {
+				finding.Corrected = true
+				report.CorrectedCount++
+			}
+		}
+		report.Discrepancies = append(report.Discrepancies, finding.ReconciliationDiscrepancy)
+	}
+	return nil
+}
+
+// recordSettlementDiscrepancy 체결 후처리에서 정산하지 못한 건을 대사 리포트로 남김 (운영자가 대사 목록에서 확인)
+func recordSettlementDiscrepancy(db *gorm.DB, discrepancy models.ReconciliationDiscrepancy) {
+	now := time.Now()
+	report := &models.ReconciliationReport{
+		StartedAt:        now,
+		FinishedAt:       &now,
+		DiscrepancyCount: 1,
+		Discrepancies:    []models.ReconciliationDiscrepancy{discrepancy},
+		Error:            discrepancy.Detail,
+	}
+	if err := db.Create(report).Error; err != nil {
+		log.Printf("❌ Failed to record settlement discrepancy for user %d: %v", discrepancy.UserID, err)
+	}
+}
+
+// settle 진행 중인 체결 후처리를 기다리고 대기 중인 주문 상태를 DB에 반영
+func (s *ReconciliationService) settle() {
+	if !s.engine.WaitForSettlement(reconcileSettleTimeout) {
+		log.Printf("⚠️ Reconciliation started before trade settlement drained")
+	}
+	s.engine.FlushOrderStates()
+}
+
+// collect 주문장/주문/체결/지갑을 대조해 불일치 수집
+func (s *ReconciliationService) collect(report *models.ReconciliationReport) ([]reconcileFinding, error) {
+	// 주문장을 먼저 읽어야 이후 생성된 주문은 유예 기간으로, 이후 종료된 주문은 DB에서 종료로 보인다
+	resting := make(map[uint]RestingOrder)
+	for _, order := range s.engine.RestingOrders() {
+		resting[order.OrderID] = order
+	}
+
+	var orders []models.Order
+	if err := s.db.Where("status IN ?", openOrderStatuses).Find(&orders).Error; err != nil {
+		return nil, fmt.Errorf("열린 주문 조회 실패: %w", err)
+	}
+	filledByOrder, err := tradeFilledByOrder(s.db)
+	if err != nil {
+		return nil, fmt.Errorf("주문별 체결 수량 집계 실패: %w", err)
+	}
+	report.OrdersChecked = len(orders)
+
+	cutoff := time.Now().Add(-reconcileGracePeriod)
+	findings, lockedByUser := s.checkOrders(orders, filledByOrder, resting, cutoff)
+
+	bookFindings, err := s.checkBook(orders, resting, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, bookFindings...)
+
+	var wallets []models.UserWallet
+	if err := s.db.Select("user_id", "usdc_locked_balance", "total_trades", "total_usdc_fees").
+		Find(&wallets).Error; err != nil {
+		return nil, fmt.Errorf("지갑 조회 실패: %w", err)
+	}
+	report.WalletsChecked = len(wallets)
+
+	walletFindings, err := s.checkWalletTrades(wallets)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, walletFindings...)
+
+	lockedFindings, err := s.checkLockedBalances(report, wallets, lockedByUser)
+	if err != nil {
+		return nil, err
+	}
+	return append(findings, lockedFindings...), nil
+}
+
+// checkOrders 열린 주문의 체결 수량을 체결 내역과 대조하고, 사용자별 주문 잠금액(체결 내역 기준) 합산
+func (s *ReconciliationService) checkOrders(orders []models.Order, filledByOrder map[uint]int64, resting map[uint]RestingOrder, cutoff time.Time) ([]reconcileFinding, map[uint]int64) {
+	var findings []reconcileFinding
+	lockedByUser := make(map[uint]int64)
+
+	for i := range orders {
+		order := orders[i]
+		tradeFilled := filledByOrder[order.ID]
+		if tradeFilled > order.Quantity {
+			tradeFilled = order.Quantity
+		}
+
+		// 파이프라인은 체결마다 잠금을 풀기 때문에 잠금액은 체결 내역 기준으로 계산
+		locked := order
+		if tradeFilled > locked.Filled {
+			locked.Filled = tradeFilled
+		}
+		if order.QuoteCurrency.OrUSDC() == models.LedgerCurrencyUSDC {
+			lockedByUser[order.UserID] += locked.LockedCents() // 다른 결제 통화 잠금은 통화 계정에 있음
+		}
+
+		book, inBook := resting[order.ID]
+		if tradeFilled != order.Filled {
+			finding := reconcileFinding{ReconciliationDiscrepancy: models.ReconciliationDiscrepancy{
+				Check:       models.ReconcileOrderFill,
+				UserID:      order.UserID,
+				OrderID:     order.ID,
+				MilestoneID: order.MilestoneID,
+				OptionID:    order.OptionID,
+				Expected:    tradeFilled,
+				Actual:      order.Filled,
+				Detail:      fmt.Sprintf("주문 체결 수량 %d, 체결 내역 합계 %d", order.Filled, tradeFilled),
+			}}
+			// 주문 상태 반영(write-behind)이 유실된 경우: 주문장도 체결 내역과 같은 남은 수량이어야 보정
+			if tradeFilled > order.Filled && (!inBook || book.Remaining == order.Quantity-tradeFilled) {
+				finding.fix = func() error { return s.applyTradeFill(order, tradeFilled) }
+			}
+			findings = append(findings, finding)
+			continue
+		}
+
+		if inBook || order.CreatedAt.After(cutoff) || !s.engine.ManagesMarket(order.MilestoneID, order.OptionID) {
+			continue
+		}
+		findings = append(findings, reconcileFinding{ReconciliationDiscrepancy: models.ReconciliationDiscrepancy{
+			Check:       models.ReconcileOrderNotInBook,
+			UserID:      order.UserID,
+			OrderID:     order.ID,
+			MilestoneID: order.MilestoneID,
+			OptionID:    order.OptionID,
+			Expected:    order.Remaining,
+			Detail:      fmt.Sprintf("DB에서 %s 상태인 주문이 주문장에 없음", order.Status),
+		}})
+	}
+	return findings, lockedByUser
+}
+
+// applyTradeFill 체결 내역 합계로 주문 체결 수량/상태 보정 (그 사이 상태가 바뀌었으면 건너뜀)
+func (s *ReconciliationService) applyTradeFill(order models.Order, tradeFilled int64) error {
+	status := models.OrderStatusPartial
+	if tradeFilled >= order.Quantity {
+		status = models.OrderStatusFilled
+	}
+
+	result := s.db.Model(&models.Order{}).
+		Where("id = ? AND filled = ? AND status IN ?", order.ID, order.Filled, openOrderStatuses).
+		Updates(map[string]interface{}{
+			"filled":    tradeFilled,
+			"remaining": order.Quantity - tradeFilled,
+			"status":    status,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("주문 상태가 대사 중에 변경됨")
+	}
+	return nil
+}
+
+// checkBook 주문장에 남은 주문을 DB 주문과 대조 (종료되었거나 DB에 없는 주문은 주문장에서 제거 가능)
+func (s *ReconciliationService) checkBook(openOrders []models.Order, resting map[uint]RestingOrder, cutoff time.Time) ([]reconcileFinding, error) {
+	open := make(map[uint]models.Order, len(openOrders))
+	for _, order := range openOrders {
+		open[order.ID] = order
+	}
+
+	var missingIDs []uint
+	for orderID := range resting {
+		if _, ok := open[orderID]; !ok {
+			missingIDs = append(missingIDs, orderID)
+		}
+	}
+	closed := make(map[uint]models.Order)
+	if len(missingIDs) > 0 {
+		var orders []models.Order
+		if err := s.db.Where("id IN ?", missingIDs).Find(&orders).Error; err != nil {
+			return nil, fmt.Errorf("종료된 주문 조회 실패: %w", err)
+		}
+		for _, order := range orders {
+			closed[order.ID] = order
+		}
+	}
+
+	var findings []reconcileFinding
+	for orderID, book := range resting {
+		discrepancy := models.ReconciliationDiscrepancy{
+			OrderID:     orderID,
+			MilestoneID: book.MilestoneID,
+			OptionID:    book.OptionID,
+			Actual:      book.Remaining,
+		}
+
+		if order, ok := open[orderID]; ok {
+			if order.Remaining == book.Remaining || order.UpdatedAt.After(cutoff) {
+				continue
+			}
+			discrepancy.Check = models.ReconcileBookRemaining
+			discrepancy.UserID = order.UserID
+			discrepancy.Expected = order.Remaining
+			discrepancy.Detail = fmt.Sprintf("주문장 남은 수량 %d, DB %d", book.Remaining, order.Remaining)
+			findings = append(findings, reconcileFinding{ReconciliationDiscrepancy: discrepancy})
+			continue
+		}
+
+		if order, ok := closed[orderID]; ok {
+			if order.UpdatedAt.After(cutoff) {
+				continue // 방금 취소/체결되어 주문장 반영 대기 중
+			}
+			discrepancy.Check = models.ReconcileBookOrderClosed
+			discrepancy.UserID = order.UserID
+			discrepancy.Detail = fmt.Sprintf("DB에서 %s 상태인 주문이 주문장에 남아 있음", order.Status)
+		} else {
+			if book.CreatedAt.After(cutoff) {
+				continue // 주문 생성 트랜잭션 커밋 대기 중
+			}
+			discrepancy.Check = models.ReconcileBookOrderUnknown
+			discrepancy.Detail = "DB에 없는 주문이 주문장에 남아 있음"
+		}
+
+		stale := &models.Order{ID: orderID, MilestoneID: book.MilestoneID, OptionID: book.OptionID, Side: book.Side}
+		findings = append(findings, reconcileFinding{
+			ReconciliationDiscrepancy: discrepancy,
+			fix: func() error {
+				return s.engine.CancelOrder(stale)
+			},
+		})
+	}
+	return findings, nil
+}
+
+// checkWalletTrades 지갑 거래 수/수수료 통계를 체결 내역과 대조 (지갑 후처리 유실 신호, 잔액은 역산할 수 없어 보고만)
+// 누적 수수료는 USDC 마켓 체결만 쌓인다.
+func (s *ReconciliationService) checkWalletTrades(wallets []models.UserWallet) ([]reconcileFinding, error) {
+	type userTrades struct {
+		UserID uint
+		Trades int64
+		Fees   int64
+	}
+
+	expectedTrades := make(map[uint]int64)
+	expectedFees := make(map[uint]int64)
+	for _, side := range []struct{ user, fee string }{{"buyer_id", "buyer_fee"}, {"seller_id", "seller_fee"}} {
+		var rows []userTrades
+		if err := s.db.Model(&models.Trade{}).
+			Select(side.user + " AS user_id, COUNT(*) AS trades, COALESCE(SUM(CASE WHEN quote_currency = 'usdc' THEN " + side.fee + " ELSE 0 END), 0) AS fees").
+			Group(side.user).
+			Scan(&rows).Error; err != nil {
+			return nil, fmt.Errorf("사용자별 체결 집계 실패: %w", err)
+		}
+		for _, row := range rows {
+			expectedTrades[row.UserID] += row.Trades
+			expectedFees[row.UserID] += row.Fees
+		}
+	}
+
+	var findings []reconcileFinding
+	for _, wallet := range wallets {
+		if wallet.TotalTrades != expectedTrades[wallet.UserID] {
+			findings = append(findings, reconcileFinding{ReconciliationDiscrepancy: models.ReconciliationDiscrepancy{
+				Check:    models.ReconcileWalletTrades,
+				UserID:   wallet.UserID,
+				Expected: expectedTrades[wallet.UserID],
+				Actual:   wallet.TotalTrades,
+				Detail:   "지갑 거래 수와 체결 내역 건수 불일치",
+			}})
+		}
+		if wallet.TotalUSDCFees != expectedFees[wallet.UserID] {
+			findings = append(findings, reconcileFinding{ReconciliationDiscrepancy: models.ReconciliationDiscrepancy{
+				Check:    models.ReconcileWalletTrades,
+				UserID:   wallet.UserID,
+				Expected: expectedFees[wallet.UserID],
+				Actual:   wallet.TotalUSDCFees,
+				Detail:   "지갑 누적 수수료와 체결 수수료 합계 불일치",
+			}})
+		}
+	}
+	return findings, nil
+}
+
+// checkLockedBalances 잠긴 USDC를 열린 매수 주문 잠금액, 미정산 조합 베팅 원금, 판정 대기 후원금, 진행 중 지급 요청,
+// 대기 중 포지션 이전 수수료 합계와 대조
+// 초과 잠금은 풀리지 않은 잔액이므로 해제 가능, 부족분은 이미 쓴 돈일 수 있어 보고만 한다
+func (s *ReconciliationService) checkLockedBalances(report *models.ReconciliationReport, wallets []models.UserWallet, lockedByUser map[uint]int64) ([]reconcileFinding, error) {
+	type userStake struct {
+		UserID uint
+		Stake  int64
+	}
+
+	expected := make(map[uint]int64, len(lockedByUser))
+	for userID, locked := range lockedByUser {
+		expected[userID] = locked
+	}
+
+	var stakes []userStake
+	if err := s.db.Model(&models.Parlay{}).
+		Select("user_id, COALESCE(SUM(stake), 0) AS stake").
+		Where("status = ?", models.ParlayStatusOpen).
+		Group("user_id").
+		Scan(&stakes).Error; err != nil {
+		return nil, fmt.Errorf("조합 베팅 원금 집계 실패: %w", err)
+	}
+	for _, stake := range stakes {
+		expected[stake.UserID] += stake.Stake
+	}
+
+	var escrows []userStake
+	if err := s.db.Model(&models.FundingEscrow{}).
+		Select("backer_id AS user_id, COALESCE(SUM(amount), 0) AS stake").
+		Where("status = ?", models.FundingEscrowLocked).
+		Group("backer_id").
+		Scan(&escrows).Error; err != nil {
+		return nil, fmt.Errorf("후원 예치액 집계 실패: %w", err)
+	}
+	for _, escrow := range escrows {
+		expected[escrow.UserID] += escrow.Stake
+	}
+
+	var payouts []userStake
+	if err := s.db.Model(&models.CreatorPayout{}).
+		Select("user_id, COALESCE(SUM(amount), 0) AS stake").
+		Where("status IN ?", []models.CreatorPayoutStatus{models.CreatorPayoutRequested, models.CreatorPayoutApproved, models.CreatorPayoutProcessing}).
+		Group("user_id").
+		Scan(&payouts).Error; err != nil {
+		return nil, fmt.Errorf("지급 요청 잠금액 집계 실패: %w", err)
+	}
+	for _, payout := range payouts {
+		expected[payout.UserID] += payout.Stake
+	}
+
+	var transferFees []userStake
+	if err := s.db.Model(&models.PositionTransfer{}).
+		Select("sender_id AS user_id, COALESCE(SUM(fee), 0) AS stake").
+		Where("status = ?", models.PositionTransferPending).
+		Group("sender_id").
+		Scan(&transferFees).Error; err != nil {
+		return nil, fmt.Errorf("포지션 이전 수수료 집계 실패: %w", err)
+	}
+	for _, fee := range transferFees {
+		expected[fee.UserID] += fee.Stake
+	}
+
+	var collateral []userStake
+	if err := s.db.Model(&models.Position{}).
+		Select("user_id, COALESCE(SUM(collateral), 0) AS stake").
+		Where("collateral > 0").
+		Group("user_id").
+		Scan(&collateral).Error; err != nil {
+		return nil, fmt.Errorf("숏 포지션 담보 집계 실패: %w", err)
+	}
+	for _, stake := range collateral {
+		expected[stake.UserID] += stake.Stake
+	}
+
+	var findings []reconcileFinding
+	for _, wallet := range wallets {
+		want := expected[wallet.UserID]
+		if wallet.USDCLockedBalance == want {
+			continue
+		}
+
+		finding := reconcileFinding{ReconciliationDiscrepancy: models.ReconciliationDiscrepancy{
+			Check:    models.ReconcileLockedBalance,
+			UserID:   wallet.UserID,
+			Expected: want,
+			Actual:   wallet.USDCLockedBalance,
+		}}
+		if surplus := wallet.USDCLockedBalance - want; surplus > 0 {
+			finding.Detail = fmt.Sprintf("열린 주문/조합 베팅보다 %d센트 더 잠김", surplus)
+			userID, actual := wallet.UserID, wallet.USDCLockedBalance
+			finding.fix = func() error { return s.releaseLockedSurplus(report.ID, userID, actual, surplus) }
+		} else {
+			finding.Detail = fmt.Sprintf("열린 주문/조합 베팅보다 %d센트 덜 잠김", -surplus)
+		}
+		findings = append(findings, finding)
+	}
+	return findings, nil
+}
+
+// releaseLockedSurplus 초과 잠금액을 사용 가능 잔액으로 되돌림 (대사 후 잠금액이 바뀌었으면 건너뜀)
+func (s *ReconciliationService) releaseLockedSurplus(reportID, userID uint, observed, surplus int64) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.UserWallet{}).
+			Where("user_id = ? AND usdc_locked_balance = ?", userID, observed).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.New("잠긴 잔액이 대사 중에 변경됨")
+		}
+
+		return postLedgerEntry(tx, &models.WalletLedgerEntry{
+			UserID:        userID,
+			Currency:      models.LedgerCurrencyUSDC,
+			EntryType:     models.LedgerReconcileAdjustment,
+			Amount:        surplus,
+			LockedAmount:  -surplus,
+			ReferenceType: "reconciliation_report",
+			ReferenceID:   reportID,
+			Memo:          "열린 주문/조합 베팅을 넘는 잠금액 해제",
+		})
+	})
+}
+
+// ListReports 최근 대사 리포트 (불일치 목록 제외)
+func (s *ReconciliationService) ListReports(limit int) ([]models.ReconciliationReport, error) {
+	if limit <= 0 || limit > maxReconciliationReports {
+		limit = maxReconciliationReports
+	}
+
+	var reports []models.ReconciliationReport
+	if err := s.db.Omit("discrepancies").Order("started_at DESC").Limit(limit).Find(&reports).Error; err != nil {
+		return nil, fmt.Errorf("대사 리포트 조회 실패: %w", err)
+	}
+	return reports, nil
+}
+
+// GetReport 대사 리포트 상세 (불일치 목록 포함)
+func (s *ReconciliationService) GetReport(reportID uint) (*models.ReconciliationReport, error) {
+	var report models.ReconciliationReport
+	if err := s.db.First(&report, reportID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %d", ErrReconciliationReportNotFound, reportID)
+		}
+		return nil, fmt.Errorf("대사 리포트 조회 실패: %w", err)
+	}
+	return &report, nil
+}
@@ -0,0 +1,336 @@
+package services
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	referralCodeLength   = 8
+	referralCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // 혼동되는 문자(0/O, 1/I) 제외
+
+	// ReferralMinPayout 최소 지급 금액 (센트) - 미만이면 다음 지급 주기로 이월
+	ReferralMinPayout int64 = 100
+)
+
+var (
+	ErrReferralCodeInvalid = errors.New("유효하지 않은 추천 코드입니다")
+	ErrSelfReferral        = errors.New("본인의 추천 코드는 사용할 수 없습니다")
+	ErrAlreadyReferred     = errors.New("이미 추천인이 등록된 사용자입니다")
+)
+
+// ReferralService 추천 코드 발급, 가입 귀속, 수수료 보상 적립/지급 서비스
+type ReferralService struct {
+	db *gorm.DB
+}
+
+// NewReferralService 생성자
+func NewReferralService(db *gorm.DB) *ReferralService {
+	return &ReferralService{
+		db: db,
+	}
+}
+
+// GetOrCreateCode 내 추천 코드 (없으면 발급)
+func (s *ReferralService) GetOrCreateCode(userID uint) (*models.ReferralCode, error) {
+	var code models.ReferralCode
+	if err := s.db.Where("user_id = ?", userID).First(&code).Error; err == nil {
+		return &code, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// 코드 충돌 시 재시도
+	for attempt := 0; attempt < 5; attempt++ {
+		value, err := generateReferralCode()
+		if err != nil {
+			return nil, err
+		}
+		code = models.ReferralCode{UserID: userID, Code: value}
+		result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&code)
+		if result.Error != nil {
+			return nil, fmt.Errorf("추천 코드 발급 실패: %w", result.Error)
+		}
+		if result.RowsAffected > 0 {
+			return &code, nil
+		}
+		// 동시 요청으로 이미 발급된 경우
+		if err := s.db.Where("user_id = ?", userID).First(&code).Error; err == nil {
+			return &code, nil
+		}
+	}
+	return nil, errors.New("추천 코드 발급 실패: 재시도 횟수 초과")
+}
+
+// AttributeSignup 신규 가입자를 추천인에게 귀속 (회원가입 후속 작업에서 호출)
+func (s *ReferralService) AttributeSignup(refereeID uint, rawCode string) (*models.Referral, error) {
+	code := strings.ToUpper(strings.TrimSpace(rawCode))
+	if code == "" {
+		return nil, ErrReferralCodeInvalid
+	}
+
+	var referralCode models.ReferralCode
+	if err := s.db.Where("code = ?", code).First(&referralCode).Error; err != nil {
+		return nil, ErrReferralCodeInvalid
+	}
+	if referralCode.UserID == refereeID {
+		return nil, ErrSelfReferral
+	}
+
+	var referee models.User
+	if err := s.db.First(&referee, refereeID).Error; err != nil {
+		return nil, errors.New("사용자를 찾을 수 없습니다")
+	}
+
+	referral := &models.Referral{
+		ReferrerID:   referralCode.UserID,
+		RefereeID:    refereeID,
+		Code:         code,
+		RewardEndsAt: referee.CreatedAt.Add(models.ReferralRewardWindow),
+	}
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(referral)
+	if result.Error != nil {
+		return nil, fmt.Errorf("추천 등록 실패: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrAlreadyReferred
+	}
+
+	log.Printf("🤝 User %d referred by user %d (code %s)", refereeID, referralCode.UserID, code)
+	return referral, nil
+}
+
+// AccrueTradeFees 체결 수수료 중 추천인 몫을 원장에 적립 (같은 체결은 한 번만 적립)
+func (s *ReferralService) AccrueTradeFees(trades []models.Trade) {
+	if len(trades) == 0 {
+		return
+	}
+
+	userIDs := make([]uint, 0, len(trades)*2)
+	for _, trade := range trades {
+		userIDs = append(userIDs, trade.BuyerID, trade.SellerID)
+	}
+
+	var referrals []models.Referral
+	if err := s.db.Where("referee_id IN ?", userIDs).Find(&referrals).Error; err != nil {
+		log.Printf("❌ Failed to load referrals for accrual: %v", err)
+		return
+	}
+	if len(referrals) == 0 {
+		return
+	}
+	byReferee := make(map[uint]models.Referral, len(referrals))
+	for _, referral := range referrals {
+		byReferee[referral.RefereeID] = referral
+	}
+
+	var rewards []models.ReferralReward
+	accrue := func(trade models.Trade, refereeID uint, fee int64) {
+		referral, ok := byReferee[refereeID]
+		if !ok || fee <= 0 || trade.CreatedAt.After(referral.RewardEndsAt) {
+			return
+		}
+		reward := fee * models.ReferralRewardShareBps / 10000
+		if reward <= 0 {
+			return
+		}
+		rewards = append(rewards, models.ReferralReward{
+			ReferralID:   referral.ID,
+			ReferrerID:   referral.ReferrerID,
+			RefereeID:    refereeID,
+			TradeID:      trade.ID,
+			FeeAmount:    fee,
+			RewardAmount: reward,
+			Status:       models.ReferralRewardPending,
+		})
+	}
+	currencies := NewCurrencyService(s.db, nil, 0) // 보상은 USDC로 지급하므로 수수료를 USDC로 환산
+	for _, trade := range trades {
+		accrue(trade, trade.BuyerID, currencies.ToUSDC(trade.QuoteCurrency, trade.BuyerFee))
+		accrue(trade, trade.SellerID, currencies.ToUSDC(trade.QuoteCurrency, trade.SellerFee))
+	}
+	if len(rewards) == 0 {
+		return
+	}
+
+	if err := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&rewards).Error; err != nil {
+		log.Printf("❌ Failed to accrue referral rewards: %v", err)
+	}
+}
+
+// PayoutAll 지급 대기 보상이 최소 금액 이상인 추천인 전원에게 지급
+func (s *ReferralService) PayoutAll() (int, error) {
+	var referrerIDs []uint
+	if err := s.db.Model(&models.ReferralReward{}).
+		Where("status = ?", models.ReferralRewardPending).
+		Group("referrer_id").
+		Having("SUM(reward_amount) >= ?", ReferralMinPayout).
+		Pluck("referrer_id", &referrerIDs).Error; err != nil {
+		return 0, err
+	}
+
+	paid := 0
+	for _, referrerID := range referrerIDs {
+		payout, err := s.Payout(referrerID)
+		if err != nil {
+			log.Printf("❌ Referral payout for user %d failed: %v", referrerID, err)
+			continue
+		}
+		if payout != nil {
+			paid++
+		}
+	}
+	return paid, nil
+}
+
+// Payout 추천인 한 명의 지급 대기 보상을 USDC 잔액으로 지급 (지급할 보상이 없으면 nil)
+func (s *ReferralService) Payout(referrerID uint) (*models.ReferralPayout, error) {
+	var payout *models.ReferralPayout
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var rewards []models.ReferralReward
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("referrer_id = ? AND status = ?", referrerID, models.ReferralRewardPending).
+			Find(&rewards).Error; err != nil {
+			return err
+		}
+
+		var total int64
+		rewardIDs := make([]uint, 0, len(rewards))
+		for _, reward := range rewards {
+			total += reward.RewardAmount
+			rewardIDs = append(rewardIDs, reward.ID)
+		}
+		if total < ReferralMinPayout {
+			return nil
+		}
+
+		var wallet models.UserWallet
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", referrerID).First(&wallet).Error; err != nil {
+			return fmt.Errorf("추천인 지갑을 찾을 수 없습니다: %w", err)
+		}
+
+		payout = &models.ReferralPayout{
+			ReferrerID:  referrerID,
+			Amount:      total,
+			RewardCount: len(rewards),
+		}
+		if err := tx.Create(payout).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.ReferralReward{}).
+			Where("id IN ?", rewardIDs).
+			Updates(map[string]interface{}{
+				"status":    models.ReferralRewardPaid,
+				"payout_id": payout.ID,
+			}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&wallet).Updates(map[string]interface{}{
+			"usdc_balance":      gorm.Expr("usdc_balance + ?", total),
+			"total_usdc_profit": gorm.Expr("total_usdc_profit + ?", total),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payout, nil
+}
+
+// GetStats 내 추천 코드 및 적립 현황
+func (s *ReferralService) GetStats(userID uint) (*models.ReferralStatsResponse, error) {
+	code, err := s.GetOrCreateCode(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &models.ReferralStatsResponse{
+		Code:            code.Code,
+		RewardShareRate: float64(models.ReferralRewardShareBps) / 10000,
+		RewardWindowDay: int(models.ReferralRewardWindow / (24 * time.Hour)),
+	}
+
+	s.db.Model(&models.Referral{}).Where("referrer_id = ?", userID).Count(&stats.TotalReferees)
+	s.db.Model(&models.Referral{}).
+		Where("referrer_id = ? AND reward_ends_at > ?", userID, time.Now()).
+		Count(&stats.ActiveReferees)
+
+	var totals []struct {
+		Status models.ReferralRewardStatus
+		Amount int64
+	}
+	if err := s.db.Model(&models.ReferralReward{}).
+		Select("status, COALESCE(SUM(reward_amount), 0) AS amount").
+		Where("referrer_id = ?", userID).
+		Group("status").Scan(&totals).Error; err != nil {
+		return nil, fmt.Errorf("추천 보상 집계 실패: %w", err)
+	}
+	for _, total := range totals {
+		switch total.Status {
+		case models.ReferralRewardPending:
+			stats.PendingAmount = total.Amount
+		case models.ReferralRewardPaid:
+			stats.PaidAmount = total.Amount
+		}
+	}
+	stats.TotalAccrued = stats.PendingAmount + stats.PaidAmount
+
+	return stats, nil
+}
+
+// GetReferees 내가 추천한 사용자 목록
+func (s *ReferralService) GetReferees(userID uint, limit, offset int) ([]models.Referral, int64, error) {
+	var total int64
+	s.db.Model(&models.Referral{}).Where("referrer_id = ?", userID).Count(&total)
+
+	var referrals []models.Referral
+	if err := s.db.Preload("Referee", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id", "username", "created_at")
+	}).Where("referrer_id = ?", userID).
+		Order("created_at DESC").Limit(limit).Offset(offset).
+		Find(&referrals).Error; err != nil {
+		return nil, 0, fmt.Errorf("추천 목록 조회 실패: %w", err)
+	}
+	return referrals, total, nil
+}
+
+// GetPayouts 지급 내역
+func (s *ReferralService) GetPayouts(userID uint, limit, offset int) ([]models.ReferralPayout, int64, error) {
+	var total int64
+	s.db.Model(&models.ReferralPayout{}).Where("referrer_id = ?", userID).Count(&total)
+
+	var payouts []models.ReferralPayout
+	if err := s.db.Where("referrer_id = ?", userID).
+		Order("created_at DESC").Limit(limit).Offset(offset).
+		Find(&payouts).Error; err != nil {
+		return nil, 0, fmt.Errorf("지급 내역 조회 실패: %w", err)
+	}
+	return payouts, total, nil
+}
+
+func generateReferralCode() (string, error) {
+	alphabetSize := big.NewInt(int64(len(referralCodeAlphabet)))
+	code := make([]byte, referralCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		code[i] = referralCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
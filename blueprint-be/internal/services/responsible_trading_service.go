@@ -0,0 +1,326 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrTradingRestricted 휴식 기간/자기 배제 중 주문, 후원, 조합 베팅
+	ErrTradingRestricted = errors.New("휴식 기간 또는 자기 배제 중에는 거래할 수 없습니다")
+	// ErrDailyLimitExceeded 일일 후원 예치/주문 금액 한도 초과
+	ErrDailyLimitExceeded = errors.New("일일 한도를 초과했습니다")
+	// ErrRestrictionShortened 이미 설정한 휴식/자기 배제 기간을 줄이려는 요청
+	ErrRestrictionShortened = errors.New("설정된 기간을 줄이거나 해제할 수 없습니다")
+)
+
+// ResponsibleTradingDefaults 플랫폼 기본 일일 한도 (사용자가 정하지 않았을 때, 0이면 제한 없음)
+type ResponsibleTradingDefaults struct {
+	DailyDeposit       int64         // 하루 후원 예치 한도 (센트)
+	DailyOrderNotional int64         // 하루 주문 금액 한도 (센트)
+	IncreaseDelay      time.Duration // 한도를 올리거나 해제할 때 적용까지 유예
+}
+
+// ResponsibleTradingStatus 내 한도 조회 응답
+type ResponsibleTradingStatus struct {
+	Settings models.UserTradingLimit `json:"settings"`
+
+	// 적용 중인 한도 (직접 정한 값, 없으면 플랫폼 기본값 / 0이면 제한 없음)
+	DailyDepositLimit       int64 `json:"daily_deposit_limit"`
+	DailyOrderNotionalLimit int64 `json:"daily_order_notional_limit"`
+
+	// 오늘(UTC) 사용량과 남은 한도 (제한 없는 항목은 생략)
+	DepositedToday         int64  `json:"deposited_today"`
+	OrderNotionalToday     int64  `json:"order_notional_today"`
+	RemainingDeposit       *int64 `json:"remaining_deposit,omitempty"`
+	RemainingOrderNotional *int64 `json:"remaining_order_notional,omitempty"`
+
+	TradingAllowed  bool       `json:"trading_allowed"`
+	RestrictedUntil *time.Time `json:"restricted_until,omitempty"`
+}
+
+// ResponsibleTradingService 사용자 일일 한도, 휴식, 자기 배제 (책임 있는 거래)
+//
+// 주문(TradingService), 후원 예치(FundingEscrowService), 조합 베팅(ParlayService)이 접수 전에 검사한다.
+// 한도는 UTC 하루 동안 접수한 금액 기준이며 주문 취소는 언제나 허용한다. 한도를 낮추거나 휴식/자기
+// 배제 기간을 늘리는 것은 바로 적용되지만, 한도를 올리거나 해제하면 유예 시간 뒤에 적용되고 휴식/자기
+// 배제는 기한 전에 끝낼 수 없다.
+type ResponsibleTradingService struct {
+	db       *gorm.DB
+	defaults ResponsibleTradingDefaults
+}
+
+// NewResponsibleTradingService 생성자
+func NewResponsibleTradingService(db *gorm.DB, defaults ResponsibleTradingDefaults) *ResponsibleTradingService {
+	return &ResponsibleTradingService{
+		db:       db,
+		defaults: defaults,
+	}
+}
+
+// CheckOrder 신규 주문/조합 베팅 금액이 휴식·자기 배제·하루 주문 금액 한도에 걸리는지 검사
+func (s *ResponsibleTradingService) CheckOrder(userID uint, notional int64) error {
+	now := time.Now()
+	limit, err := s.load(s.db, userID, now)
+	if err != nil {
+		return err
+	}
+	if err := restrictionError(limit, now); err != nil {
+		return err
+	}
+
+	allowed := effectiveLimit(limit.DailyOrderNotionalLimit, s.defaults.DailyOrderNotional)
+	if allowed == 0 {
+		return nil
+	}
+	used, err := s.orderNotionalToday(userID, now)
+	if err != nil {
+		return err
+	}
+	if used+notional > allowed {
+		return fmt.Errorf("%w: 하루 주문 금액 최대 $%.2f (오늘 $%.2f)", ErrDailyLimitExceeded, float64(allowed)/100, float64(used)/100)
+	}
+	return nil
+}
+
+// CheckDeposit 후원 예치 금액이 휴식·자기 배제·하루 예치 한도에 걸리는지 검사
+func (s *ResponsibleTradingService) CheckDeposit(userID uint, amount int64) error {
+	now := time.Now()
+	limit, err := s.load(s.db, userID, now)
+	if err != nil {
+		return err
+	}
+	if err := restrictionError(limit, now); err != nil {
+		return err
+	}
+
+	allowed := effectiveLimit(limit.DailyDepositLimit, s.defaults.DailyDeposit)
+	if allowed == 0 {
+		return nil
+	}
+	used, err := s.depositedToday(userID, now)
+	if err != nil {
+		return err
+	}
+	if used+amount > allowed {
+		return fmt.Errorf("%w: 하루 후원 예치 최대 $%.2f (오늘 $%.2f)", ErrDailyLimitExceeded, float64(allowed)/100, float64(used)/100)
+	}
+	return nil
+}
+
+// GetStatus 내 한도, 오늘 사용량, 거래 제한 상태
+func (s *ResponsibleTradingService) GetStatus(userID uint) (*ResponsibleTradingStatus, error) {
+	now := time.Now()
+	limit, err := s.load(s.db, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	deposited, err := s.depositedToday(userID, now)
+	if err != nil {
+		return nil, err
+	}
+	ordered, err := s.orderNotionalToday(userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &ResponsibleTradingStatus{
+		Settings:                *limit,
+		DailyDepositLimit:       effectiveLimit(limit.DailyDepositLimit, s.defaults.DailyDeposit),
+		DailyOrderNotionalLimit: effectiveLimit(limit.DailyOrderNotionalLimit, s.defaults.DailyOrderNotional),
+		DepositedToday:          deposited,
+		OrderNotionalToday:      ordered,
+		RestrictedUntil:         limit.RestrictedUntil(now),
+	}
+	status.TradingAllowed = status.RestrictedUntil == nil
+	if status.DailyDepositLimit > 0 {
+		remaining := max(status.DailyDepositLimit-deposited, 0)
+		status.RemainingDeposit = &remaining
+	}
+	if status.DailyOrderNotionalLimit > 0 {
+		remaining := max(status.DailyOrderNotionalLimit-ordered, 0)
+		status.RemainingOrderNotional = &remaining
+	}
+	return status, nil
+}
+
+// UpdateLimits 일일 한도 변경 (낮추면 즉시, 올리거나 해제하면 유예 후 적용)
+func (s *ResponsibleTradingService) UpdateLimits(userID uint, req models.UpdateTradingLimitsRequest) (*ResponsibleTradingStatus, error) {
+	now := time.Now()
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		limit, err := s.loadForUpdate(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		if req.DailyDepositLimit != nil {
+			s.changeLimit(limit, &limit.DailyDepositLimit, &limit.PendingDailyDepositLimit, *req.DailyDepositLimit, s.defaults.DailyDeposit, now)
+		}
+		if req.DailyOrderNotionalLimit != nil {
+			s.changeLimit(limit, &limit.DailyOrderNotionalLimit, &limit.PendingDailyOrderNotionalLimit, *req.DailyOrderNotionalLimit, s.defaults.DailyOrderNotional, now)
+		}
+		if limit.PendingDailyDepositLimit == nil && limit.PendingDailyOrderNotionalLimit == nil {
+			limit.PendingEffectiveAt = nil
+		}
+		return tx.Save(limit).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("한도 변경 실패: %w", err)
+	}
+	return s.GetStatus(userID)
+}
+
+// changeLimit 적용 중인 한도보다 엄격하면 바로 바꾸고, 완화하면 유예 시각과 함께 대기 값으로 둠
+func (s *ResponsibleTradingService) changeLimit(limit *models.UserTradingLimit, current *int64, pending **int64, requested, platformDefault int64, now time.Time) {
+	before := effectiveLimit(*current, platformDefault)
+	after := effectiveLimit(requested, platformDefault)
+	stricter := after != 0 && (before == 0 || after <= before)
+
+	if stricter || s.defaults.IncreaseDelay <= 0 {
+		*current = requested
+		*pending = nil
+		return
+	}
+	*pending = &requested
+	effectiveAt := now.Add(s.defaults.IncreaseDelay)
+	limit.PendingEffectiveAt = &effectiveAt
+}
+
+// StartCoolingOff 휴식 기간 시작 (이미 더 긴 휴식 중이면 거부)
+func (s *ResponsibleTradingService) StartCoolingOff(userID uint, hours int) (*ResponsibleTradingStatus, error) {
+	until := time.Now().Add(time.Duration(hours) * time.Hour)
+	if err := s.extendRestriction(userID, func(limit *models.UserTradingLimit) **time.Time { return &limit.CoolingOffUntil }, until); err != nil {
+		return nil, err
+	}
+	return s.GetStatus(userID)
+}
+
+// SelfExclude 자기 배제 시작 (기한 전 해제 불가, 이미 더 긴 배제 중이면 거부)
+func (s *ResponsibleTradingService) SelfExclude(userID uint, days int) (*ResponsibleTradingStatus, error) {
+	until := time.Now().AddDate(0, 0, days)
+	if err := s.extendRestriction(userID, func(limit *models.UserTradingLimit) **time.Time { return &limit.SelfExcludedUntil }, until); err != nil {
+		return nil, err
+	}
+	return s.GetStatus(userID)
+}
+
+func (s *ResponsibleTradingService) extendRestriction(userID uint, field func(*models.UserTradingLimit) **time.Time, until time.Time) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		limit, err := s.loadForUpdate(tx, userID)
+		if err != nil {
+			return err
+		}
+		current := field(limit)
+		if *current != nil && (*current).After(until) {
+			return fmt.Errorf("%w: %s까지 설정됨", ErrRestrictionShortened, (*current).UTC().Format(time.RFC3339))
+		}
+		*current = &until
+		return tx.Save(limit).Error
+	})
+}
+
+// load 사용자 설정 조회 (없으면 빈 설정), 유예가 끝난 대기 한도는 이때 반영
+func (s *ResponsibleTradingService) load(db *gorm.DB, userID uint, now time.Time) (*models.UserTradingLimit, error) {
+	limit := &models.UserTradingLimit{UserID: userID}
+	if err := db.Where("user_id = ?", userID).Limit(1).Find(limit).Error; err != nil {
+		return nil, fmt.Errorf("거래 한도 조회 실패: %w", err)
+	}
+	if limit.PendingEffectiveAt == nil || limit.PendingEffectiveAt.After(now) {
+		return limit, nil
+	}
+
+	if limit.PendingDailyDepositLimit != nil {
+		limit.DailyDepositLimit = *limit.PendingDailyDepositLimit
+	}
+	if limit.PendingDailyOrderNotionalLimit != nil {
+		limit.DailyOrderNotionalLimit = *limit.PendingDailyOrderNotionalLimit
+	}
+	limit.PendingDailyDepositLimit, limit.PendingDailyOrderNotionalLimit, limit.PendingEffectiveAt = nil, nil, nil
+
+	// 같은 대기 값을 다른 요청이 먼저 반영했으면 그대로 둠
+	if err := db.Model(&models.UserTradingLimit{}).
+		Where("id = ? AND pending_effective_at IS NOT NULL", limit.ID).
+		Updates(map[string]interface{}{
+			"daily_deposit_limit":                limit.DailyDepositLimit,
+			"daily_order_notional_limit":         limit.DailyOrderNotionalLimit,
+			"pending_daily_deposit_limit":        nil,
+			"pending_daily_order_notional_limit": nil,
+			"pending_effective_at":               nil,
+		}).Error; err != nil {
+		return nil, fmt.Errorf("대기 한도 반영 실패: %w", err)
+	}
+	return limit, nil
+}
+
+// loadForUpdate 변경용 설정 조회 (없으면 생성)
+func (s *ResponsibleTradingService) loadForUpdate(tx *gorm.DB, userID uint) (*models.UserTradingLimit, error) {
+	limit, err := s.load(tx, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if limit.ID == 0 {
+		if err := tx.Create(limit).Error; err != nil {
+			return nil, fmt.Errorf("거래 한도 생성 실패: %w", err)
+		}
+	}
+	return limit, nil
+}
+
+// depositedToday 오늘(UTC) 후원 예치 합계 (반환된 예치 포함)
+func (s *ResponsibleTradingService) depositedToday(userID uint, now time.Time) (int64, error) {
+	var total int64
+	if err := s.db.Model(&models.FundingEscrow{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("backer_id = ? AND created_at >= ?", userID, snapshotDate(now)).
+		Scan(&total).Error; err != nil {
+		return 0, fmt.Errorf("오늘 후원 예치 조회 실패: %w", err)
+	}
+	return total, nil
+}
+
+// orderNotionalToday 오늘(UTC) 접수한 주문 금액(취소 포함)과 조합 베팅 원금 합계
+func (s *ResponsibleTradingService) orderNotionalToday(userID uint, now time.Time) (int64, error) {
+	dayStart := snapshotDate(now)
+
+	var orders []models.Order
+	if err := s.db.Select("quantity", "price_ticks").
+		Where("user_id = ? AND created_at >= ?", userID, dayStart).
+		Find(&orders).Error; err != nil {
+		return 0, fmt.Errorf("오늘 주문 조회 실패: %w", err)
+	}
+	var total int64
+	for _, order := range orders {
+		total += models.ReserveCents(order.Quantity, order.PriceTicks)
+	}
+
+	var stakes int64
+	if err := s.db.Model(&models.Parlay{}).
+		Select("COALESCE(SUM(stake), 0)").
+		Where("user_id = ? AND created_at >= ?", userID, dayStart).
+		Scan(&stakes).Error; err != nil {
+		return 0, fmt.Errorf("오늘 조합 베팅 조회 실패: %w", err)
+	}
+	return total + stakes, nil
+}
+
+// restrictionError 휴식/자기 배제 중이면 해제 시각을 담은 에러
+func restrictionError(limit *models.UserTradingLimit, now time.Time) error {
+	until := limit.RestrictedUntil(now)
+	if until == nil {
+		return nil
+	}
+	return fmt.Errorf("%w: %s까지", ErrTradingRestricted, until.UTC().Format(time.RFC3339))
+}
+
+// effectiveLimit 직접 정한 한도, 없으면 플랫폼 기본값
+func effectiveLimit(own, platformDefault int64) int64 {
+	if own > 0 {
+		return own
+	}
+	return platformDefault
+}
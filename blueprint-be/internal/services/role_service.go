@@ -0,0 +1,207 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidRole      = errors.New("정의되지 않은 역할입니다")
+	ErrRoleNotAssigned  = errors.New("해당 역할이 부여되어 있지 않습니다")
+	ErrLastAdminRevoke  = errors.New("마지막 관리자의 admin 역할은 회수할 수 없습니다")
+	ErrRoleAlreadyGiven = errors.New("이미 부여된 역할입니다")
+)
+
+// RoleService 역할 기반 접근 제어 (RBAC) 서비스
+type RoleService struct {
+	db *gorm.DB
+}
+
+// NewRoleService 생성자
+func NewRoleService(db *gorm.DB) *RoleService {
+	return &RoleService{
+		db: db,
+	}
+}
+
+// GetUserRoles 사용자에게 현재 부여된 역할 목록
+func (s *RoleService) GetUserRoles(userID uint) ([]models.Role, error) {
+	var roles []models.Role
+	if err := s.db.Model(&models.UserRole{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Distinct().Pluck("role", &roles).Error; err != nil {
+		return nil, fmt.Errorf("역할 조회 실패: %w", err)
+	}
+	return roles, nil
+}
+
+// HasRole 특정 역할 보유 여부 (admin은 모든 역할을 가진 것으로 간주)
+func (s *RoleService) HasRole(userID uint, roles ...models.Role) (bool, error) {
+	userRoles, err := s.GetUserRoles(userID)
+	if err != nil {
+		return false, err
+	}
+	for _, userRole := range userRoles {
+		if userRole == models.RoleAdmin {
+			return true, nil
+		}
+		for _, role := range roles {
+			if userRole == role {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// HasPermission 권한 보유 여부
+func (s *RoleService) HasPermission(userID uint, permission models.Permission) (bool, error) {
+	roles, err := s.GetUserRoles(userID)
+	if err != nil {
+		return false, err
+	}
+	for _, role := range roles {
+		if role.Grants(permission) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetUserRolesResponse 역할 + 역할에서 파생된 권한 목록
+func (s *RoleService) GetUserRolesResponse(userID uint) (*models.UserRolesResponse, error) {
+	roles, err := s.GetUserRoles(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[models.Permission]bool)
+	permissions := []models.Permission{}
+	for _, role := range roles {
+		granted := models.RolePermissions[role]
+		if role == models.RoleAdmin {
+			granted = models.AllPermissions
+		}
+		for _, permission := range granted {
+			if !seen[permission] {
+				seen[permission] = true
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+
+	return &models.UserRolesResponse{
+		UserID:      userID,
+		Roles:       roles,
+		Permissions: permissions,
+	}, nil
+}
+
+// GrantRole 역할 부여 (grantedBy가 nil이면 시스템 부여)
+func (s *RoleService) GrantRole(userID uint, role models.Role, grantedBy *uint, reason string) (*models.UserRole, error) {
+	if !role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return nil, errors.New("사용자를 찾을 수 없습니다")
+	}
+
+	var count int64
+	s.db.Model(&models.UserRole{}).
+		Where("user_id = ? AND role = ? AND revoked_at IS NULL", userID, role).
+		Count(&count)
+	if count > 0 {
+		return nil, ErrRoleAlreadyGiven
+	}
+
+	userRole := &models.UserRole{
+		UserID:    userID,
+		Role:      role,
+		GrantedBy: grantedBy,
+		Reason:    reason,
+	}
+	if err := s.db.Create(userRole).Error; err != nil {
+		return nil, fmt.Errorf("역할 부여 실패: %w", err)
+	}
+
+	log.Printf("🛡️ Role %s granted to user %d (by %v)", role, userID, describeActor(grantedBy))
+	return userRole, nil
+}
+
+// RevokeRole 역할 회수 (이력은 보존)
+func (s *RoleService) RevokeRole(userID uint, role models.Role, revokedBy uint) error {
+	if !role.IsValid() {
+		return ErrInvalidRole
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if role == models.RoleAdmin {
+			var admins int64
+			tx.Model(&models.UserRole{}).
+				Where("role = ? AND revoked_at IS NULL", models.RoleAdmin).
+				Distinct("user_id").Count(&admins)
+			if admins <= 1 {
+				return ErrLastAdminRevoke
+			}
+		}
+
+		now := time.Now()
+		result := tx.Model(&models.UserRole{}).
+			Where("user_id = ? AND role = ? AND revoked_at IS NULL", userID, role).
+			Updates(map[string]interface{}{"revoked_at": &now, "revoked_by": revokedBy})
+		if result.Error != nil {
+			return fmt.Errorf("역할 회수 실패: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrRoleNotAssigned
+		}
+
+		log.Printf("🛡️ Role %s revoked from user %d (by %d)", role, userID, revokedBy)
+		return nil
+	})
+}
+
+// ListRoleMembers 역할별 사용자 목록
+func (s *RoleService) ListRoleMembers(role models.Role) ([]models.UserRole, error) {
+	if !role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+
+	var members []models.UserRole
+	if err := s.db.Preload("User").
+		Where("role = ? AND revoked_at IS NULL", role).
+		Order("created_at ASC").Find(&members).Error; err != nil {
+		return nil, fmt.Errorf("역할 사용자 조회 실패: %w", err)
+	}
+	return members, nil
+}
+
+// BootstrapAdmins 설정된 이메일의 사용자에게 admin 역할 부여 (최초 관리자 지정용)
+func (s *RoleService) BootstrapAdmins(emails []string) {
+	for _, email := range emails {
+		var user models.User
+		if err := s.db.Where("LOWER(email) = ?", strings.ToLower(email)).First(&user).Error; err != nil {
+			log.Printf("⚠️ Bootstrap admin %s not found (will retry on next start)", email)
+			continue
+		}
+		if _, err := s.GrantRole(user.ID, models.RoleAdmin, nil, "bootstrap (ADMIN_EMAILS)"); err != nil && !errors.Is(err, ErrRoleAlreadyGiven) {
+			log.Printf("❌ Failed to bootstrap admin %s: %v", email, err)
+		}
+	}
+}
+
+func describeActor(userID *uint) string {
+	if userID == nil {
+		return "system"
+	}
+	return fmt.Sprintf("user %d", *userID)
+}
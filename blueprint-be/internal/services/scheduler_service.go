@@ -0,0 +1,376 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	redisClient "github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// schedulerLockTTL 실행 중 락 유지 시간 (실행하는 동안 schedulerLockTTL/3마다 연장, 인스턴스가 죽으면 만료)
+	schedulerLockTTL = time.Minute
+	// SchedulerRunRetention 실행 이력 보관 기간
+	SchedulerRunRetention = 30 * 24 * time.Hour
+	// MinScheduleInterval 허용하는 최소 주기
+	MinScheduleInterval = 10 * time.Second
+)
+
+var (
+	ErrScheduledJobNotFound = errors.New("등록되지 않은 주기 작업입니다")
+	ErrScheduledJobRunning  = errors.New("이미 실행 중인 작업입니다")
+	ErrInvalidSchedule      = errors.New("주기는 10초 이상이어야 합니다")
+)
+
+// ScheduledJobFunc 주기 작업 1회 실행, 처리한 건수 반환
+type ScheduledJobFunc func(now time.Time) (int, error)
+
+type scheduledJob struct {
+	name            string
+	defaultInterval time.Duration
+	run             ScheduledJobFunc
+}
+
+// SchedulerService API 서버 주기 작업 레지스트리
+//
+// 작업은 Register로 등록하고, 일정(주기/활성 여부/다음 실행 시각)은 scheduled_jobs 테이블에서 읽는다.
+// 여러 인스턴스가 떠 있어도 작업별 Redis 락을 잡은 한 곳만 실행하며, 실행마다 scheduled_job_runs에 기록한다.
+type SchedulerService struct {
+	db          *gorm.DB
+	lockManager *DistributedLockManager
+	instanceID  string
+
+	mu      sync.RWMutex
+	jobs    map[string]*scheduledJob
+	synced  bool
+	running sync.WaitGroup
+}
+
+// NewSchedulerService 생성자
+func NewSchedulerService(db *gorm.DB, client *redisClient.Client) *SchedulerService {
+	hostname, _ := os.Hostname()
+	return &SchedulerService{
+		db:          db,
+		lockManager: NewDistributedLockManager(client),
+		instanceID:  fmt.Sprintf("%s-%d-%d", hostname, os.Getpid(), time.Now().UnixNano()),
+		jobs:        make(map[string]*scheduledJob),
+	}
+}
+
+// Register 주기 작업 등록 (defaultInterval은 DB에 일정이 없을 때만 사용)
+func (s *SchedulerService) Register(name string, defaultInterval time.Duration, run ScheduledJobFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[name] = &scheduledJob{name: name, defaultInterval: defaultInterval, run: run}
+	s.synced = false
+}
+
+// Run tick마다 실행 시각이 지난 작업 실행 (서버 종료 시까지 실행)
+func (s *SchedulerService) Run(tick time.Duration) {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := s.RunDue(time.Now()); err != nil {
+			log.Printf("❌ Scheduler tick failed: %v", err)
+		}
+	}
+}
+
+// RunDue 실행 시각이 지난 작업을 락을 잡아 시작 (시작한 작업 수 반환, 작업은 백그라운드에서 실행)
+func (s *SchedulerService) RunDue(now time.Time) (int, error) {
+	if err := s.syncJobs(now); err != nil {
+		return 0, err
+	}
+
+	var due []models.ScheduledJob
+	if err := s.db.Where("enabled = ? AND next_run_at <= ?", true, now).Find(&due).Error; err != nil {
+		return 0, err
+	}
+
+	started := 0
+	for _, schedule := range due {
+		job := s.job(schedule.Name)
+		if job == nil {
+			continue // 다른 버전의 서버가 등록한 작업
+		}
+		run, err := s.start(job, now, models.ScheduledJobTriggerSchedule, nil)
+		if err != nil {
+			log.Printf("❌ Failed to start scheduled job %s: %v", job.name, err)
+			continue
+		}
+		if run != nil {
+			started++
+		}
+	}
+	return started, nil
+}
+
+// Trigger 관리자 즉시 실행 (일정은 그대로 두고 실행 이력만 남김)
+func (s *SchedulerService) Trigger(name string, userID uint) (*models.ScheduledJobRun, error) {
+	job := s.job(name)
+	if job == nil {
+		return nil, ErrScheduledJobNotFound
+	}
+	if err := s.syncJobs(time.Now()); err != nil {
+		return nil, err
+	}
+
+	run, err := s.start(job, time.Now(), models.ScheduledJobTriggerManual, &userID)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, ErrScheduledJobRunning
+	}
+	return run, nil
+}
+
+// Wait 시작한 작업이 모두 끝날 때까지 대기 (종료/테스트용)
+func (s *SchedulerService) Wait() {
+	s.running.Wait()
+}
+
+// ListJobs 등록된 작업의 일정과 마지막 실행 결과
+func (s *SchedulerService) ListJobs() ([]models.ScheduledJob, error) {
+	if err := s.syncJobs(time.Now()); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	names := make([]string, 0, len(s.jobs))
+	for name := range s.jobs {
+		names = append(names, name)
+	}
+	s.mu.RUnlock()
+	sort.Strings(names)
+
+	var jobs []models.ScheduledJob
+	err := s.db.Where("name IN ?", names).Order("name ASC").Find(&jobs).Error
+	return jobs, err
+}
+
+// ListRuns 작업 실행 이력 (최신순)
+func (s *SchedulerService) ListRuns(name string, limit int) ([]models.ScheduledJobRun, error) {
+	if s.job(name) == nil {
+		return nil, ErrScheduledJobNotFound
+	}
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	var runs []models.ScheduledJobRun
+	err := s.db.Where("job_name = ?", name).Order("started_at DESC, id DESC").Limit(limit).Find(&runs).Error
+	return runs, err
+}
+
+// UpdateSchedule 주기/활성 여부 변경 (주기를 바꾸면 다음 실행 시각을 마지막 실행 기준으로 다시 계산)
+func (s *SchedulerService) UpdateSchedule(name string, req models.UpdateScheduledJobRequest) (*models.ScheduledJob, error) {
+	if s.job(name) == nil {
+		return nil, ErrScheduledJobNotFound
+	}
+	if req.IntervalSeconds != nil && time.Duration(*req.IntervalSeconds)*time.Second < MinScheduleInterval {
+		return nil, ErrInvalidSchedule
+	}
+	if err := s.syncJobs(time.Now()); err != nil {
+		return nil, err
+	}
+
+	var schedule models.ScheduledJob
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("name = ?", name).First(&schedule).Error; err != nil {
+			return err
+		}
+		updates := map[string]interface{}{}
+		if req.Enabled != nil {
+			updates["enabled"] = *req.Enabled
+		}
+		if req.IntervalSeconds != nil {
+			updates["interval_seconds"] = *req.IntervalSeconds
+			base := time.Now()
+			if schedule.LastRunAt != nil {
+				base = *schedule.LastRunAt
+			}
+			updates["next_run_at"] = base.Add(time.Duration(*req.IntervalSeconds) * time.Second)
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+		if err := tx.Model(&schedule).Updates(updates).Error; err != nil {
+			return err
+		}
+		return tx.Where("name = ?", name).First(&schedule).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// CleanupRuns before 이전에 시작한 실행 이력 삭제
+func (s *SchedulerService) CleanupRuns(before time.Time) (int, error) {
+	result := s.db.Where("started_at < ? AND status <> ?", before, models.ScheduledJobRunning).Delete(&models.ScheduledJobRun{})
+	return int(result.RowsAffected), result.Error
+}
+
+func (s *SchedulerService) job(name string) *scheduledJob {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.jobs[name]
+}
+
+// syncJobs 처음 보는 작업의 일정 행 생성 (기본 주기, 바로 1회 실행)
+func (s *SchedulerService) syncJobs(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.synced {
+		return nil
+	}
+
+	for _, job := range s.jobs {
+		schedule := models.ScheduledJob{
+			Name:            job.name,
+			IntervalSeconds: int(job.defaultInterval / time.Second),
+			Enabled:         true,
+			NextRunAt:       &now,
+		}
+		if err := s.db.Where("name = ?", job.name).FirstOrCreate(&schedule).Error; err != nil {
+			return fmt.Errorf("failed to register scheduled job %s: %w", job.name, err)
+		}
+	}
+	s.synced = true
+	return nil
+}
+
+// start 락을 잡고 실행 이력을 만든 뒤 백그라운드로 실행 (다른 곳에서 실행 중이거나 이미 실행됐으면 nil)
+func (s *SchedulerService) start(job *scheduledJob, now time.Time, trigger models.ScheduledJobTrigger, triggeredBy *uint) (*models.ScheduledJobRun, error) {
+	ctx := context.Background()
+	lockKey := "scheduler:" + job.name
+	acquired, err := s.lockManager.AcquireLock(ctx, lockKey, schedulerLockTTL, s.instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, nil
+	}
+
+	var run *models.ScheduledJobRun
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var schedule models.ScheduledJob
+		if err := tx.Where("name = ?", job.name).First(&schedule).Error; err != nil {
+			return err
+		}
+		// 락을 기다리는 사이 다른 인스턴스가 이미 실행한 경우
+		if trigger == models.ScheduledJobTriggerSchedule && (!schedule.Enabled || schedule.NextRunAt == nil || schedule.NextRunAt.After(now)) {
+			return nil
+		}
+
+		// 락을 잡았으니 남아 있는 실행 중 기록은 죽은 인스턴스의 것
+		if err := tx.Model(&models.ScheduledJobRun{}).
+			Where("job_name = ? AND status = ?", job.name, models.ScheduledJobRunning).
+			Updates(map[string]interface{}{"status": models.ScheduledJobFailed, "error": "interrupted", "finished_at": now}).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{"last_run_at": now, "last_status": models.ScheduledJobRunning, "last_error": ""}
+		if trigger == models.ScheduledJobTriggerSchedule {
+			updates["next_run_at"] = now.Add(time.Duration(schedule.IntervalSeconds) * time.Second)
+		}
+		if err := tx.Model(&schedule).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		run = &models.ScheduledJobRun{
+			JobName:     job.name,
+			Trigger:     trigger,
+			TriggeredBy: triggeredBy,
+			Instance:    s.instanceID,
+			Status:      models.ScheduledJobRunning,
+			StartedAt:   now,
+		}
+		return tx.Create(run).Error
+	})
+	if err != nil || run == nil {
+		s.lockManager.ReleaseLock(ctx, lockKey, s.instanceID)
+		return nil, err
+	}
+
+	s.running.Add(1)
+	go s.execute(job, *run, lockKey)
+	return run, nil
+}
+
+// execute 작업 실행 후 결과 기록 및 락 해제 (실행 중에는 락 연장)
+func (s *SchedulerService) execute(job *scheduledJob, run models.ScheduledJobRun, lockKey string) {
+	defer s.running.Done()
+	ctx := context.Background()
+	defer s.lockManager.ReleaseLock(ctx, lockKey, s.instanceID)
+
+	stopRenew := make(chan struct{})
+	defer close(stopRenew)
+	go func() {
+		ticker := time.NewTicker(schedulerLockTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopRenew:
+				return
+			case <-ticker.C:
+				if ok, err := s.lockManager.RenewLock(ctx, lockKey, schedulerLockTTL, s.instanceID); err != nil || !ok {
+					log.Printf("⚠️ Lost scheduler lock for %s", job.name)
+				}
+			}
+		}
+	}()
+
+	affected, runErr := runScheduledJob(job, run.StartedAt)
+	finished := time.Now()
+	duration := finished.Sub(run.StartedAt).Milliseconds()
+
+	status := models.ScheduledJobSucceeded
+	errMessage := ""
+	if runErr != nil {
+		status = models.ScheduledJobFailed
+		errMessage = runErr.Error()
+		log.Printf("❌ Scheduled job %s failed: %v", job.name, runErr)
+	} else if affected > 0 {
+		log.Printf("⏱️ Scheduled job %s processed %d", job.name, affected)
+	}
+
+	if err := s.db.Model(&models.ScheduledJobRun{}).Where("id = ?", run.ID).Updates(map[string]interface{}{
+		"status":      status,
+		"affected":    affected,
+		"error":       errMessage,
+		"finished_at": finished,
+		"duration_ms": duration,
+	}).Error; err != nil {
+		log.Printf("⚠️ Failed to record scheduled job run %s: %v", job.name, err)
+	}
+	if err := s.db.Model(&models.ScheduledJob{}).Where("name = ?", job.name).Updates(map[string]interface{}{
+		"last_status":      status,
+		"last_error":       errMessage,
+		"last_duration_ms": duration,
+	}).Error; err != nil {
+		log.Printf("⚠️ Failed to record scheduled job status %s: %v", job.name, err)
+	}
+}
+
+// runScheduledJob 작업 패닉을 실패로 기록
+func runScheduledJob(job *scheduledJob, now time.Time) (affected int, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+	return job.run(now)
+}
@@ -0,0 +1,431 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
+	"blueprint/pkg/utils"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	AccessTokenTTL     = time.Hour           // 액세스 토큰 유효 시간
+	RefreshTokenTTL    = 30 * 24 * time.Hour // 리프레시 토큰(세션) 유효 시간, 갱신할 때마다 연장
+	maxSessionsPerUser = 20                  // 초과 시 가장 오래 사용하지 않은 세션부터 폐기
+
+	// 동시에 두 탭이 같은 토큰으로 갱신하는 경우를 탈취로 오인하지 않기 위한 유예 시간
+	refreshReuseGrace = 10 * time.Second
+
+	// Redis 미연결 시 발급하는 세션 없는 토큰의 유효 시간 (기존 동작)
+	legacyTokenTTL = 24 * time.Hour
+)
+
+var (
+	ErrSessionInvalid     = errors.New("세션이 만료되었거나 유효하지 않습니다")
+	ErrSessionNotFound    = errors.New("세션을 찾을 수 없습니다")
+	ErrRefreshTokenReused = errors.New("이미 사용된 리프레시 토큰입니다. 보안을 위해 세션이 종료되었습니다")
+)
+
+// storedSession Redis 저장 형식 (리프레시 토큰은 해시만 보관)
+type storedSession struct {
+	models.Session
+	RefreshHash         string    `json:"refresh_hash"`
+	PreviousRefreshHash string    `json:"previous_refresh_hash,omitempty"`
+	RotatedAt           time.Time `json:"rotated_at"`
+}
+
+// SessionService Redis 기반 로그인 세션 관리 (리프레시 토큰 회전 + 폐기 목록)
+//
+// 리프레시 토큰 형식: <session_id>.<secret>
+// 갱신할 때마다 새 secret을 발급하고 직전 secret이 다시 사용되면 탈취로 간주해 세션을 폐기한다.
+type SessionService struct {
+	db        *gorm.DB
+	jwtSecret string
+}
+
+// NewSessionService 생성자
+func NewSessionService(db *gorm.DB, jwtSecret string) *SessionService {
+	return &SessionService{
+		db:        db,
+		jwtSecret: jwtSecret,
+	}
+}
+
+// CreateSession 로그인 성공 시 세션 생성 후 토큰 발급
+func (s *SessionService) CreateSession(user *models.User, userAgent, ipAddress string) (*models.AuthTokens, error) {
+	recordActivity(models.ActivityAuthLogin, user.ID, describeDevice(userAgent)+"에서 로그인", models.ActivityMetadata{
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+	})
+
+	client := moduleRedis.GetClient()
+	if client == nil {
+		// 세션 저장소가 없으면 기존 방식(갱신 불가 토큰)으로 발급
+		log.Printf("⚠️ Redis unavailable, issuing sessionless token for user %d", user.ID)
+		token, err := utils.GenerateTokenWithExpiry(user, s.jwtSecret, legacyTokenTTL)
+		if err != nil {
+			return nil, err
+		}
+		return &models.AuthTokens{AccessToken: token, ExpiresIn: int(legacyTokenTTL.Seconds())}, nil
+	}
+
+	sessionID, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session := &storedSession{
+		Session: models.Session{
+			ID:         sessionID,
+			UserID:     user.ID,
+			DeviceName: describeDevice(userAgent),
+			UserAgent:  userAgent,
+			IPAddress:  ipAddress,
+			CreatedAt:  now,
+			LastUsedAt: now,
+			ExpiresAt:  now.Add(RefreshTokenTTL),
+		},
+		RefreshHash: hashRefreshSecret(secret),
+		RotatedAt:   now,
+	}
+
+	ctx := context.Background()
+	data, err := json.Marshal(session)
+	if err != nil {
+		return nil, err
+	}
+	_, err = client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, sessionKey(sessionID), data, RefreshTokenTTL)
+		pipe.SAdd(ctx, userSessionsKey(user.ID), sessionID)
+		pipe.Expire(ctx, userSessionsKey(user.ID), RefreshTokenTTL)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("세션 저장 실패: %w", err)
+	}
+
+	s.enforceSessionLimit(user.ID)
+
+	return s.issueTokens(user, sessionID, secret)
+}
+
+// Refresh 리프레시 토큰 회전 후 새 토큰 발급
+func (s *SessionService) Refresh(refreshToken, userAgent, ipAddress string) (*models.AuthTokens, error) {
+	client := moduleRedis.GetClient()
+	if client == nil {
+		return nil, ErrSessionInvalid
+	}
+
+	sessionID, secret, ok := strings.Cut(refreshToken, ".")
+	if !ok || sessionID == "" || secret == "" {
+		return nil, ErrSessionInvalid
+	}
+
+	ctx := context.Background()
+	key := sessionKey(sessionID)
+	presented := hashRefreshSecret(secret)
+	newSecret, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	var session storedSession
+	err = client.Watch(ctx, func(tx *redis.Tx) error {
+		data, err := tx.Get(ctx, key).Bytes()
+		if err == redis.Nil {
+			return ErrSessionInvalid
+		}
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &session); err != nil {
+			return ErrSessionInvalid
+		}
+
+		if !hashEqual(presented, session.RefreshHash) {
+			if session.PreviousRefreshHash != "" && hashEqual(presented, session.PreviousRefreshHash) {
+				if time.Since(session.RotatedAt) < refreshReuseGrace {
+					return ErrSessionInvalid // 동시 갱신 요청 - 먼저 성공한 응답의 토큰을 사용해야 함
+				}
+				return ErrRefreshTokenReused
+			}
+			return ErrSessionInvalid
+		}
+
+		now := time.Now()
+		session.PreviousRefreshHash = session.RefreshHash
+		session.RefreshHash = hashRefreshSecret(newSecret)
+		session.RotatedAt = now
+		session.LastUsedAt = now
+		session.ExpiresAt = now.Add(RefreshTokenTTL)
+		session.IPAddress = ipAddress
+		if userAgent != "" {
+			session.UserAgent = userAgent
+			session.DeviceName = describeDevice(userAgent)
+		}
+
+		updated, err := json.Marshal(&session)
+		if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, updated, RefreshTokenTTL)
+			pipe.Expire(ctx, userSessionsKey(session.UserID), RefreshTokenTTL)
+			return nil
+		})
+		return err
+	}, key)
+
+	switch {
+	case errors.Is(err, ErrRefreshTokenReused):
+		log.Printf("🚨 Refresh token reuse detected for session %s (user %d), revoking", sessionID, session.UserID)
+		s.revoke(&session.Session)
+		recordActivity(models.ActivityAuthTokenReused, session.UserID, session.DeviceName+" 세션이 탈취 의심으로 종료되었습니다", models.ActivityMetadata{
+			IPAddress: ipAddress,
+			UserAgent: userAgent,
+		})
+		return nil, err
+	case errors.Is(err, redis.TxFailedErr):
+		return nil, ErrSessionInvalid
+	case err != nil:
+		return nil, err
+	}
+
+	var user models.User
+	if err := s.db.First(&user, session.UserID).Error; err != nil || !user.IsActive {
+		s.revoke(&session.Session)
+		return nil, ErrSessionInvalid
+	}
+	return s.issueTokens(&user, sessionID, newSecret)
+}
+
+// ListSessions 사용자 활성 세션 목록 (최근 사용 순)
+func (s *SessionService) ListSessions(userID uint, currentSessionID string) ([]models.Session, error) {
+	client := moduleRedis.GetClient()
+	if client == nil {
+		return []models.Session{}, nil
+	}
+
+	sessions, err := s.loadUserSessions(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.Session, 0, len(sessions))
+	for _, session := range sessions {
+		session.Current = session.ID == currentSessionID
+		result = append(result, session.Session)
+	}
+	return result, nil
+}
+
+// RevokeSession 특정 세션 폐기 (본인 세션만)
+func (s *SessionService) RevokeSession(userID uint, sessionID string) error {
+	session, err := s.getSession(sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return ErrSessionNotFound
+	}
+	return s.revoke(&session.Session)
+}
+
+// RevokeOtherSessions 현재 세션을 제외한 모든 세션 폐기
+func (s *SessionService) RevokeOtherSessions(userID uint, currentSessionID string) (int, error) {
+	sessions, err := s.loadUserSessions(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	revoked := 0
+	for _, session := range sessions {
+		if session.ID == currentSessionID {
+			continue
+		}
+		if err := s.revoke(&session.Session); err != nil {
+			return revoked, err
+		}
+		revoked++
+	}
+	return revoked, nil
+}
+
+// revokeAllSessions 사용자의 모든 로그인 세션 폐기 (계정 삭제 요청/병합 후, 실패는 기록만 하고 인증 미들웨어의 계정 상태 확인에 맡김)
+func revokeAllSessions(sessions *SessionService, userID uint) {
+	if sessions == nil {
+		return
+	}
+	if _, err := sessions.RevokeOtherSessions(userID, ""); err != nil {
+		log.Printf("⚠️ Failed to revoke sessions of user %d: %v", userID, err)
+	}
+}
+
+// revoke 세션 삭제 + 폐기 목록 등록 (이미 발급된 액세스 토큰 차단)
+func (s *SessionService) revoke(session *models.Session) error {
+	client := moduleRedis.GetClient()
+	if client == nil {
+		return ErrSessionNotFound
+	}
+
+	ctx := context.Background()
+	_, err := client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, sessionKey(session.ID))
+		pipe.SRem(ctx, userSessionsKey(session.UserID), session.ID)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("세션 폐기 실패: %w", err)
+	}
+	return moduleRedis.RevokeSession(session.ID, AccessTokenTTL)
+}
+
+func (s *SessionService) getSession(sessionID string) (*storedSession, error) {
+	client := moduleRedis.GetClient()
+	if client == nil || sessionID == "" {
+		return nil, ErrSessionNotFound
+	}
+
+	data, err := client.Get(context.Background(), sessionKey(sessionID)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("세션 조회 실패: %w", err)
+	}
+
+	var session storedSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("세션 데이터 손상: %w", err)
+	}
+	return &session, nil
+}
+
+// loadUserSessions 사용자 세션 조회 (만료되어 사라진 세션은 인덱스에서 정리)
+func (s *SessionService) loadUserSessions(userID uint) ([]storedSession, error) {
+	client := moduleRedis.GetClient()
+	if client == nil {
+		return nil, nil
+	}
+
+	ctx := context.Background()
+	ids, err := client.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("세션 목록 조회 실패: %w", err)
+	}
+
+	sessions := make([]storedSession, 0, len(ids))
+	for _, id := range ids {
+		session, err := s.getSession(id)
+		if errors.Is(err, ErrSessionNotFound) {
+			client.SRem(ctx, userSessionsKey(userID), id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt)
+	})
+	return sessions, nil
+}
+
+// enforceSessionLimit 세션 수 제한 초과 시 오래된 세션 폐기
+func (s *SessionService) enforceSessionLimit(userID uint) {
+	sessions, err := s.loadUserSessions(userID)
+	if err != nil || len(sessions) <= maxSessionsPerUser {
+		return
+	}
+	for _, session := range sessions[maxSessionsPerUser:] {
+		if err := s.revoke(&session.Session); err != nil {
+			log.Printf("⚠️ Failed to evict session %s: %v", session.ID, err)
+		}
+	}
+}
+
+func (s *SessionService) issueTokens(user *models.User, sessionID, secret string) (*models.AuthTokens, error) {
+	accessToken, err := utils.GenerateSessionToken(user, s.jwtSecret, sessionID, AccessTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &models.AuthTokens{
+		AccessToken:      accessToken,
+		RefreshToken:     sessionID + "." + secret,
+		ExpiresIn:        int(AccessTokenTTL.Seconds()),
+		RefreshExpiresIn: int(RefreshTokenTTL.Seconds()),
+		SessionID:        sessionID,
+	}, nil
+}
+
+func sessionKey(sessionID string) string {
+	return "auth_session:" + sessionID
+}
+
+func userSessionsKey(userID uint) string {
+	return fmt.Sprintf("user_sessions:%d", userID)
+}
+
+func hashRefreshSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func hashEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// describeDevice User-Agent에서 기기 설명 추출 (세션 목록 표시용)
+func describeDevice(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+
+	browser := "Unknown browser"
+	switch {
+	case strings.Contains(ua, "edg/"):
+		browser = "Edge"
+	case strings.Contains(ua, "chrome/"):
+		browser = "Chrome"
+	case strings.Contains(ua, "firefox/"):
+		browser = "Firefox"
+	case strings.Contains(ua, "safari/"):
+		browser = "Safari"
+	case userAgent != "":
+		browser = strings.SplitN(userAgent, " ", 2)[0]
+	}
+
+	platform := "Unknown OS"
+	switch {
+	case strings.Contains(ua, "iphone"), strings.Contains(ua, "ipad"):
+		platform = "iOS"
+	case strings.Contains(ua, "android"):
+		platform = "Android"
+	case strings.Contains(ua, "windows"):
+		platform = "Windows"
+	case strings.Contains(ua, "mac os"):
+		platform = "macOS"
+	case strings.Contains(ua, "linux"):
+		platform = "Linux"
+	}
+
+	return browser + " on " + platform
+}
@@ -218,6 +218,81 @@ func (s *SSEService) BroadcastOrderBookUpdate(milestoneID uint, optionID string,
 	}
 }
 
+// BroadcastOrderBookDelta broadcasts sequence-numbered order book level changes
+func (s *SSEService) BroadcastOrderBookDelta(delta OrderBookDelta) {
+	message := SSEMessage{
+		Type:      "orderbook_delta",
+		Data:      delta,
+		Timestamp: time.Now().Unix(),
+	}
+
+	select {
+	case s.broadcast <- message:
+	default:
+		log.Println("Warning: SSE broadcast channel is full")
+	}
+}
+
+// BroadcastTradingHalt broadcasts circuit breaker halts (market_halted) and resumptions (market_resumed)
+func (s *SSEService) BroadcastTradingHalt(event TradingHaltEvent) {
+	eventType := "market_resumed"
+	if event.Halted {
+		eventType = "market_halted"
+	}
+
+	message := SSEMessage{
+		Type:      eventType,
+		Data:      event,
+		Timestamp: time.Now().Unix(),
+	}
+
+	select {
+	case s.broadcast <- message:
+	default:
+		log.Println("Warning: SSE broadcast channel is full")
+	}
+}
+
+// BroadcastTradingPause broadcasts maintenance pauses (trading_paused) and their end (trading_resumed)
+func (s *SSEService) BroadcastTradingPause(event TradingPauseEvent) {
+	eventType := "trading_resumed"
+	if event.Paused {
+		eventType = "trading_paused"
+	}
+
+	message := SSEMessage{
+		Type:      eventType,
+		Data:      event,
+		Timestamp: time.Now().Unix(),
+	}
+
+	select {
+	case s.broadcast <- message:
+	default:
+		log.Println("Warning: SSE broadcast channel is full")
+	}
+}
+
+// BroadcastMarketCalendar broadcasts scheduled market closes (market_closed) and reopenings (market_reopened)
+func (s *SSEService) BroadcastMarketCalendar(event MarketCalendarEvent) {
+	eventType := "market_reopened"
+	if event.Closed {
+		eventType = "market_closed"
+	}
+
+	message := SSEMessage{
+		Type:      eventType,
+		Data:      event,
+		Timestamp: time.Now().Unix(),
+	}
+
+	select {
+	case s.broadcast <- message:
+	default:
+		log.Println("Warning: SSE broadcast channel is full")
+	}
+}
+
 // BroadcastPriceChange broadcasts price changes to clients watching specific milestone
 func (s *SSEService) BroadcastPriceChange(milestoneID uint, option string, oldPrice, newPrice float64) {
 	priceChangeEvent := map[string]interface{}{
@@ -0,0 +1,364 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// maxEpochCatchUp 한 번의 처리 주기에서 따라잡을 최대 에포크 수 (서버 중단 후 재시작 대비)
+const maxEpochCatchUp = 48
+
+var ErrNoStakingRewards = errors.New("청구할 스테이킹 보상이 없습니다")
+
+// StakingEmissionSchedule BLUEPRINT 스테이킹 발행 스케줄
+//
+// 에포크 n의 발행량 = EpochEmission × (1 - DecayPercent/100)^(n / DecayEveryEpochs), 최소 MinEpochEmission
+type StakingEmissionSchedule struct {
+	Genesis           time.Time
+	EpochDuration     time.Duration
+	EpochEmission     int64
+	DecayPercent      int
+	DecayEveryEpochs  int
+	MinEpochEmission  int64
+	JurorSharePercent int
+}
+
+// Validate 스케줄 설정 검증
+func (s StakingEmissionSchedule) Validate() error {
+	switch {
+	case s.EpochDuration <= 0:
+		return errors.New("에포크 길이는 0보다 커야 합니다")
+	case s.EpochEmission < 0 || s.MinEpochEmission < 0:
+		return errors.New("발행량은 음수일 수 없습니다")
+	case s.DecayPercent < 0 || s.DecayPercent > 100:
+		return errors.New("발행 감소율은 0-100% 사이여야 합니다")
+	case s.JurorSharePercent < 0 || s.JurorSharePercent > 100:
+		return errors.New("배심원 몫은 0-100% 사이여야 합니다")
+	}
+	return nil
+}
+
+// EpochAt 시각이 속한 에포크 번호 (제네시스 이전이면 -1)
+func (s StakingEmissionSchedule) EpochAt(t time.Time) int64 {
+	if t.Before(s.Genesis) {
+		return -1
+	}
+	return int64(t.Sub(s.Genesis) / s.EpochDuration)
+}
+
+// EpochBounds 에포크 시작/종료 시각
+func (s StakingEmissionSchedule) EpochBounds(epoch int64) (time.Time, time.Time) {
+	start := s.Genesis.Add(time.Duration(epoch) * s.EpochDuration)
+	return start, start.Add(s.EpochDuration)
+}
+
+// EmissionFor 에포크 발행량
+func (s StakingEmissionSchedule) EmissionFor(epoch int64) int64 {
+	emission := s.EpochEmission
+	if s.DecayEveryEpochs > 0 && s.DecayPercent > 0 {
+		periods := float64(epoch / int64(s.DecayEveryEpochs))
+		emission = int64(float64(s.EpochEmission) * math.Pow(1-float64(s.DecayPercent)/100, periods))
+	}
+	if emission < s.MinEpochEmission {
+		emission = s.MinEpochEmission
+	}
+	return emission
+}
+
+// epochsPerYear APY 연환산용
+func (s StakingEmissionSchedule) epochsPerYear() float64 {
+	return float64(365*24*time.Hour) / float64(s.EpochDuration)
+}
+
+// StakingRewardsService 멘토/배심원 스테이킹 에포크 보상 적립, 자동 갱신, 청구
+type StakingRewardsService struct {
+	db       *gorm.DB
+	schedule StakingEmissionSchedule
+}
+
+// NewStakingRewardsService 생성자
+func NewStakingRewardsService(db *gorm.DB, schedule StakingEmissionSchedule) *StakingRewardsService {
+	return &StakingRewardsService{
+		db:       db,
+		schedule: schedule,
+	}
+}
+
+// ProcessEpochs 만기 스테이킹 자동 갱신 후 종료된 에포크 보상 적립 (갱신한 스테이킹 + 처리한 에포크 수 반환)
+//
+// 자동 갱신이 실패해도 에포크 적립은 진행한다.
+func (s *StakingRewardsService) ProcessEpochs(now time.Time) (int, error) {
+	renewed, renewErr := s.ProcessMaturedStakes(now)
+	if renewErr != nil {
+		log.Printf("❌ Stake auto-renewal failed: %v", renewErr)
+	}
+
+	processed, err := s.ProcessDueEpochs(now)
+	if err != nil {
+		return renewed, err
+	}
+	return renewed + processed, renewErr
+}
+
+// ProcessDueEpochs 종료됐지만 아직 적립하지 않은 에포크 처리
+//
+// 처음 실행 시에는 직전 에포크부터 적립하며(과거 발행분 소급 없음), 이후에는 빠진 에포크를 순서대로 따라잡는다.
+func (s *StakingRewardsService) ProcessDueEpochs(now time.Time) (int, error) {
+	latest := s.schedule.EpochAt(now) - 1
+	if latest < 0 {
+		return 0, nil
+	}
+
+	next := latest
+	var last models.StakingEpoch
+	if err := s.db.Order("epoch_number DESC").First(&last).Error; err == nil {
+		next = last.EpochNumber + 1
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+
+	processed := 0
+	for epoch := next; epoch <= latest && processed < maxEpochCatchUp; epoch++ {
+		if err := s.processEpoch(epoch); err != nil {
+			return processed, fmt.Errorf("에포크 %d 처리 실패: %w", epoch, err)
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// stakeWeight 에포크 보상 배분 대상 스테이킹
+type stakeWeight struct {
+	Kind   models.StakeKind
+	RefID  uint
+	UserID uint
+	Amount int64
+}
+
+// processEpoch 에포크 종료 시점의 스테이킹 잔액 비례로 발행량 배분
+func (s *StakingRewardsService) processEpoch(epochNumber int64) error {
+	start, end := s.schedule.EpochBounds(epochNumber)
+	emission := s.schedule.EmissionFor(epochNumber)
+	jurorPool := emission * int64(s.schedule.JurorSharePercent) / 100
+	mentorPool := emission - jurorPool
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var exists int64
+		tx.Model(&models.StakingEpoch{}).Where("epoch_number = ?", epochNumber).Count(&exists)
+		if exists > 0 {
+			return nil // 다른 인스턴스가 먼저 처리
+		}
+
+		var mentorStakes []stakeWeight
+		if err := tx.Model(&models.MentorStake{}).
+			Select("? AS kind, id AS ref_id, user_id, available_amount AS amount", models.StakeKindMentor).
+			Where("status = ? AND available_amount > 0 AND staked_at < ?", models.MentorStakeStatusActive, end).
+			Scan(&mentorStakes).Error; err != nil {
+			return fmt.Errorf("멘토 스테이킹 조회 실패: %w", err)
+		}
+
+		var jurorStakes []stakeWeight
+		if err := tx.Model(&models.JurorQualification{}).
+			Select("? AS kind, id AS ref_id, user_id, current_stake AS amount", models.StakeKindJuror).
+			Where("is_active = ? AND current_stake > 0 AND created_at < ?", true, end).
+			Scan(&jurorStakes).Error; err != nil {
+			return fmt.Errorf("배심원 스테이킹 조회 실패: %w", err)
+		}
+
+		mentorRewards, mentorTotal := allocateEpochPool(mentorPool, mentorStakes)
+		jurorRewards, jurorTotal := allocateEpochPool(jurorPool, jurorStakes)
+		rewards := append(mentorRewards, jurorRewards...)
+
+		epoch := &models.StakingEpoch{
+			EpochNumber:      epochNumber,
+			StartAt:          start,
+			EndAt:            end,
+			EmissionAmount:   emission,
+			MentorStakeTotal: mentorTotal,
+			JurorStakeTotal:  jurorTotal,
+		}
+		if mentorTotal > 0 {
+			epoch.MentorAPY = float64(mentorPool) / float64(mentorTotal) * s.schedule.epochsPerYear() * 100
+		}
+		if jurorTotal > 0 {
+			epoch.JurorAPY = float64(jurorPool) / float64(jurorTotal) * s.schedule.epochsPerYear() * 100
+		}
+		for _, reward := range rewards {
+			epoch.DistributedAmount += reward.Amount
+		}
+		if err := tx.Create(epoch).Error; err != nil {
+			return fmt.Errorf("에포크 기록 실패: %w", err)
+		}
+
+		if len(rewards) == 0 {
+			return nil
+		}
+		for i := range rewards {
+			rewards[i].EpochID = epoch.ID
+		}
+		if err := tx.CreateInBatches(rewards, 500).Error; err != nil {
+			return fmt.Errorf("스테이킹 보상 적립 실패: %w", err)
+		}
+		return nil
+	})
+}
+
+// allocateEpochPool 스테이킹 잔액 비례 배분 (나머지는 미발행)
+func allocateEpochPool(pool int64, stakes []stakeWeight) ([]models.StakingEpochReward, int64) {
+	var total int64
+	for _, stake := range stakes {
+		total += stake.Amount
+	}
+	if pool <= 0 || total <= 0 {
+		return nil, total
+	}
+
+	rewards := make([]models.StakingEpochReward, 0, len(stakes))
+	for _, stake := range stakes {
+		amount := int64(float64(pool) * float64(stake.Amount) / float64(total))
+		if amount <= 0 {
+			continue
+		}
+		rewards = append(rewards, models.StakingEpochReward{
+			UserID:       stake.UserID,
+			StakeKind:    stake.Kind,
+			StakeRefID:   stake.RefID,
+			StakedAmount: stake.Amount,
+			Amount:       amount,
+			Status:       models.StakingEpochRewardPending,
+		})
+	}
+	return rewards, total
+}
+
+// ProcessMaturedStakes 잠금 해제일이 지난 자동 갱신 스테이킹의 잠금 기간 연장
+func (s *StakingRewardsService) ProcessMaturedStakes(now time.Time) (int, error) {
+	var stakes []models.MentorStake
+	if err := s.db.Where("status = ? AND is_auto_renewal = ? AND unlock_date <= ?", models.MentorStakeStatusActive, true, now).
+		Find(&stakes).Error; err != nil {
+		return 0, err
+	}
+
+	renewed := 0
+	for _, stake := range stakes {
+		period := stake.MinimumPeriod
+		if period <= 0 {
+			period = 30
+		}
+		unlockDate := stake.UnlockDate
+		for !unlockDate.After(now) {
+			unlockDate = unlockDate.AddDate(0, 0, period)
+		}
+
+		result := s.db.Model(&models.MentorStake{}).
+			Where("id = ? AND status = ? AND unlock_date = ?", stake.ID, models.MentorStakeStatusActive, stake.UnlockDate).
+			Update("unlock_date", unlockDate)
+		if result.Error != nil {
+			return renewed, result.Error
+		}
+		if result.RowsAffected > 0 {
+			renewed++
+		}
+	}
+	return renewed, nil
+}
+
+// GetSummary 내 스테이킹 보상 현황
+func (s *StakingRewardsService) GetSummary(userID uint) (*models.StakingRewardSummary, error) {
+	summary := &models.StakingRewardSummary{
+		CurrentEpoch: s.schedule.EpochAt(time.Now()),
+	}
+	if summary.CurrentEpoch >= 0 {
+		summary.EpochEmission = s.schedule.EmissionFor(summary.CurrentEpoch)
+	}
+
+	var totals []struct {
+		Status models.StakingEpochRewardStatus
+		Amount int64
+		Count  int64
+	}
+	if err := s.db.Model(&models.StakingEpochReward{}).
+		Select("status, COALESCE(SUM(amount), 0) AS amount, COUNT(*) AS count").
+		Where("user_id = ?", userID).Group("status").
+		Scan(&totals).Error; err != nil {
+		return nil, fmt.Errorf("스테이킹 보상 집계 실패: %w", err)
+	}
+	for _, total := range totals {
+		switch total.Status {
+		case models.StakingEpochRewardPending:
+			summary.PendingAmount, summary.PendingCount = total.Amount, total.Count
+		case models.StakingEpochRewardClaimed:
+			summary.ClaimedAmount = total.Amount
+		}
+	}
+
+	var latest models.StakingEpoch
+	if err := s.db.Order("epoch_number DESC").First(&latest).Error; err == nil {
+		summary.MentorAPY, summary.JurorAPY = latest.MentorAPY, latest.JurorAPY
+	}
+
+	if err := s.db.Where("user_id = ?", userID).Order("id DESC").Limit(20).
+		Find(&summary.RecentRewards).Error; err != nil {
+		return nil, fmt.Errorf("스테이킹 보상 조회 실패: %w", err)
+	}
+	return summary, nil
+}
+
+// ClaimRewards 청구 대기 중인 스테이킹 보상 전체를 BLUEPRINT 잔액으로 입금
+func (s *StakingRewardsService) ClaimRewards(userID uint) (*models.StakingRewardClaimResponse, error) {
+	response := &models.StakingRewardClaimResponse{}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var pending []models.StakingEpochReward
+		if err := tx.Select("id", "amount").
+			Where("user_id = ? AND status = ?", userID, models.StakingEpochRewardPending).
+			Order("id ASC").Find(&pending).Error; err != nil {
+			return fmt.Errorf("스테이킹 보상 조회 실패: %w", err)
+		}
+		if len(pending) == 0 {
+			return ErrNoStakingRewards
+		}
+
+		ids := make([]uint, len(pending))
+		for i, reward := range pending {
+			ids[i] = reward.ID
+			response.TotalAmount += reward.Amount
+		}
+
+		// 동시 청구 방지: 조회한 보상이 모두 대기 상태일 때만 지급
+		result := tx.Model(&models.StakingEpochReward{}).
+			Where("id IN ? AND status = ?", ids, models.StakingEpochRewardPending).
+			Updates(map[string]interface{}{
+				"status":     models.StakingEpochRewardClaimed,
+				"claimed_at": time.Now(),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("스테이킹 보상 상태 업데이트 실패: %w", result.Error)
+		}
+		if result.RowsAffected != int64(len(ids)) {
+			return errors.New("다른 요청에서 보상을 청구하고 있습니다. 잠시 후 다시 시도해주세요")
+		}
+
+		response.ClaimedCount = len(ids)
+		return postLedgerEntry(tx, &models.WalletLedgerEntry{
+			UserID:        userID,
+			Currency:      models.LedgerCurrencyBlueprint,
+			EntryType:     models.LedgerStakingReward,
+			Amount:        response.TotalAmount,
+			ReferenceType: "staking_epoch_reward",
+			ReferenceID:   ids[len(ids)-1],
+			Memo:          fmt.Sprintf("스테이킹 보상 %d건 청구", len(ids)),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
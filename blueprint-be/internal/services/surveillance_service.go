@@ -0,0 +1,672 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 시장 감시 탐지 기준
+const (
+	// surveillanceBucket 자기 거래/허수 주문은 UTC 하루 단위로 집계 (검토한 알림이 다음 실행에서 다시 뜨지 않도록 구간 고정)
+	surveillanceBucket = 24 * time.Hour
+	// surveillanceSettleDelay 자정 직후에는 늦게 기록된 체결/취소를 반영하도록 전날 구간도 다시 훑음
+	surveillanceSettleDelay = time.Hour
+
+	washMinRingQuantity = 50 // 고리를 한 바퀴 도는 최소 수량
+
+	spoofCancelWindow   = 10 * time.Second // 체결 없이 이 시간 안에 취소되면 빠른 취소
+	spoofMinCancels     = 10               // 하루 최소 빠른 취소 건수
+	spoofMinCancelRatio = 0.7              // 접수 주문 대비 빠른 취소 비율
+
+	paintWindow   = 30 * time.Minute // 기한 직전 감시 구간
+	paintLookback = 24 * time.Hour   // 이 시간 안에 지난 기한까지 훑음
+	paintMinMove  = 0.10             // 구간 직전 대비 최소 가격 변동
+	paintMinShare = 0.5              // 가격 방향 체결 중 한 계정의 최소 비중
+
+	maxSurveillanceAlerts = 100 // 목록 조회 최대 건수
+)
+
+var (
+	ErrSurveillanceAlertNotFound = errors.New("시장 감시 알림을 찾을 수 없습니다")
+	ErrSurveillanceAlertReviewed = errors.New("이미 검토한 알림입니다")
+)
+
+// surveillanceFinding 탐지 결과 1건 (알림으로 저장 전)
+type surveillanceFinding struct {
+	key string
+	models.SurveillanceAlert
+}
+
+// SurveillanceService 시장 감시 (자기 거래 고리, 허수 주문, 마감 직전 종가 관여)
+//
+// 주기적으로 체결/주문 기록을 훑어 조작 의심 패턴을 SurveillanceAlert로 남긴다.
+//   - wash_trading: 하루 동안 같은 마켓에서 자기 자신 또는 2~3개 계정이 서로 주고받아 포지션이 제자리로
+//     돌아오는 체결 고리
+//   - spoofing: 하루 동안 체결 없이 10초 안에 취소한 주문이 많고 비율이 높은 계정 (같은 날 반대 방향으로
+//     체결했으면 심각도 상향)
+//   - painting_the_close: 거래 마감/검증 기한 직전 30분 동안 가격이 크게 움직였고 그 방향 체결 대부분을
+//     한 계정이 만든 경우
+//
+// 같은 패턴은 DedupeKey로 묶어 열린 알림이면 증거만 갱신한다. 운영자가 종결하거나 배심원 중재로 이관한다.
+type SurveillanceService struct {
+	db          *gorm.DB
+	arbitration *ArbitrationService
+	spoofExempt map[uint]bool
+}
+
+// NewSurveillanceService 생성자
+func NewSurveillanceService(db *gorm.DB, arbitration *ArbitrationService) *SurveillanceService {
+	return &SurveillanceService{
+		db:          db,
+		arbitration: arbitration,
+		spoofExempt: map[uint]bool{},
+	}
+}
+
+// SetSpoofingExempt 호가를 계속 정정하는 계정(마켓메이커 봇)을 허수 주문 탐지에서 제외
+func (s *SurveillanceService) SetSpoofingExempt(userIDs ...uint) {
+	for _, userID := range userIDs {
+		if userID != 0 {
+			s.spoofExempt[userID] = true
+		}
+	}
+}
+
+// Scan 감시 1회 실행, 새로 만들거나 갱신한 알림 수 반환
+func (s *SurveillanceService) Scan(now time.Time) (int, error) {
+	var findings []surveillanceFinding
+	for day := now.Add(-surveillanceSettleDelay).UTC().Truncate(surveillanceBucket); day.Before(now); day = day.Add(surveillanceBucket) {
+		end := day.Add(surveillanceBucket)
+		if end.After(now) {
+			end = now
+		}
+		wash, err := s.detectWashTrading(day, end)
+		if err != nil {
+			return 0, err
+		}
+		spoof, err := s.detectSpoofing(day, end)
+		if err != nil {
+			return 0, err
+		}
+		findings = append(append(findings, wash...), spoof...)
+	}
+	paint, err := s.detectPaintingTheClose(now)
+	if err != nil {
+		return 0, err
+	}
+	findings = append(findings, paint...)
+
+	raised := 0
+	for i := range findings {
+		ok, err := s.record(&findings[i], now)
+		if err != nil {
+			log.Printf("❌ Failed to record surveillance alert %s: %v", findings[i].key, err)
+			continue
+		}
+		if ok {
+			raised++
+		}
+	}
+	if raised > 0 {
+		log.Printf("🕵️ Market surveillance: %d alerts raised or updated", raised)
+	}
+	return raised, nil
+}
+
+// record 새 패턴이면 알림 생성, 열린 알림이면 증거 갱신 (검토한 알림은 그대로 둠)
+func (s *SurveillanceService) record(finding *surveillanceFinding, now time.Time) (bool, error) {
+	alert := finding.SurveillanceAlert
+	alert.DedupeKey = finding.key
+	alert.Status = models.SurveillanceAlertOpen
+	alert.DetectedAt = now
+	alert.LastSeenAt = now
+
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&alert)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	result = s.db.Model(&models.SurveillanceAlert{}).
+		Where("dedupe_key = ? AND status = ?", finding.key, models.SurveillanceAlertOpen).
+		Select("severity", "user_ids", "summary", "evidence", "last_seen_at").
+		Updates(&models.SurveillanceAlert{
+			Severity:   alert.Severity,
+			UserIDs:    alert.UserIDs,
+			Summary:    alert.Summary,
+			Evidence:   alert.Evidence,
+			LastSeenAt: now,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// washEdge 매도자 → 매수자 방향 체결 묶음
+type washEdge struct {
+	quantity int64
+	volume   int64
+	tradeIDs []uint
+}
+
+// detectWashTrading 마켓별로 매도자→매수자 그래프를 만들어 자기 체결과 2~3개 계정 고리 탐지
+func (s *SurveillanceService) detectWashTrading(from, to time.Time) ([]surveillanceFinding, error) {
+	var trades []models.Trade
+	if err := s.db.Select("id", "milestone_id", "option_id", "buyer_id", "seller_id", "quantity", "total_amount").
+		Where("created_at >= ? AND created_at < ? AND buyer_id <> 0 AND seller_id <> 0", from, to).
+		Order("milestone_id, option_id, id").
+		Find(&trades).Error; err != nil {
+		return nil, fmt.Errorf("체결 조회 실패: %w", err)
+	}
+
+	var findings []surveillanceFinding
+	for start := 0; start < len(trades); {
+		end := start
+		for end < len(trades) && trades[end].MilestoneID == trades[start].MilestoneID && trades[end].OptionID == trades[start].OptionID {
+			end++
+		}
+		findings = append(findings, washRings(trades[start:end], from, to)...)
+		start = end
+	}
+	return findings, nil
+}
+
+// washRings 한 마켓 옵션의 체결에서 고리 탐지 (같은 계정 조합은 한 번만)
+func washRings(trades []models.Trade, from, to time.Time) []surveillanceFinding {
+	edges := map[[2]uint]*washEdge{}
+	outgoing := map[uint][]uint{}
+	var total int64
+	for _, trade := range trades {
+		total += trade.Quantity
+		key := [2]uint{trade.SellerID, trade.BuyerID}
+		edge, ok := edges[key]
+		if !ok {
+			edge = &washEdge{}
+			edges[key] = edge
+			outgoing[trade.SellerID] = append(outgoing[trade.SellerID], trade.BuyerID)
+		}
+		edge.quantity += trade.Quantity
+		edge.volume += trade.TotalAmount
+		edge.tradeIDs = append(edge.tradeIDs, trade.ID)
+	}
+
+	// 고리는 가장 작은 계정부터 체결 방향 순서로 저장 (a → b → c → a)
+	rings := map[string][]uint{}
+	addRing := func(users []uint) {
+		key := userSetKey(users)
+		// 같은 세 계정이 양방향 고리를 모두 이루면 한 바퀴 수량이 큰 쪽
+		if existing, ok := rings[key]; !ok || ringQuantity(edges, users) > ringQuantity(edges, existing) {
+			rings[key] = users
+		}
+	}
+	for key := range edges {
+		a, b := key[0], key[1]
+		switch {
+		case a == b:
+			addRing([]uint{a})
+		case a < b:
+			if _, ok := edges[[2]uint{b, a}]; ok {
+				addRing([]uint{a, b})
+			}
+			for _, c := range outgoing[b] {
+				if c <= a || c == b {
+					continue
+				}
+				if _, ok := edges[[2]uint{c, a}]; ok {
+					addRing([]uint{a, b, c})
+				}
+			}
+		}
+	}
+
+	var findings []surveillanceFinding
+	for _, users := range rings {
+		if finding, ok := washFinding(edges, users, total, trades[0], from, to); ok {
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// washFinding 고리 구성 계정 사이의 체결을 모아 기준 이상이면 알림 생성
+func washFinding(edges map[[2]uint]*washEdge, users []uint, total int64, market models.Trade, from, to time.Time) (surveillanceFinding, bool) {
+	roundTrip := ringQuantity(edges, users)
+	if roundTrip < washMinRingQuantity {
+		return surveillanceFinding{}, false
+	}
+
+	// 근거는 방향과 무관하게 고리 계정 사이의 체결 전체
+	var quantity, volume int64
+	var tradeIDs []uint
+	for _, seller := range users {
+		for _, buyer := range users {
+			if edge, ok := edges[[2]uint{seller, buyer}]; ok {
+				quantity += edge.quantity
+				volume += edge.volume
+				tradeIDs = append(tradeIDs, edge.tradeIDs...)
+			}
+		}
+	}
+
+	sort.Slice(tradeIDs, func(i, j int) bool { return tradeIDs[i] < tradeIDs[j] })
+	share := float64(quantity) / float64(total)
+	severity := models.SurveillanceSeverityLow
+	switch {
+	case share >= 0.5:
+		severity = models.SurveillanceSeverityHigh
+	case share >= 0.2:
+		severity = models.SurveillanceSeverityMedium
+	}
+
+	summary := fmt.Sprintf("계정 %s 사이 주고받기 체결 %d주 (구간 거래량의 %.0f%%)", formatUserIDs(users), quantity, share*100)
+	if len(users) == 1 {
+		summary = fmt.Sprintf("계정 %d 자기 체결 %d주 (구간 거래량의 %.0f%%)", users[0], quantity, share*100)
+	}
+	return surveillanceFinding{
+		key: fmt.Sprintf("%s:%d:%s:%s:%s", models.SurveillanceWashTrading, market.MilestoneID, market.OptionID, userSetKey(users), from.Format("20060102")),
+		SurveillanceAlert: models.SurveillanceAlert{
+			Type:        models.SurveillanceWashTrading,
+			Severity:    severity,
+			MilestoneID: market.MilestoneID,
+			OptionID:    market.OptionID,
+			UserIDs:     users,
+			Summary:     summary,
+			Evidence: models.SurveillanceEvidence{
+				WindowStart:  from,
+				WindowEnd:    to,
+				TradeIDs:     tradeIDs,
+				RingQuantity: roundTrip,
+				RingVolume:   volume,
+				MarketShare:  share,
+			},
+		},
+	}, true
+}
+
+// ringQuantity 고리를 한 바퀴 도는 수량 (고리 방향 구간 중 가장 적은 체결 수량)
+func ringQuantity(edges map[[2]uint]*washEdge, users []uint) int64 {
+	quantity := int64(math.MaxInt64)
+	for i, seller := range users {
+		edge, ok := edges[[2]uint{seller, users[(i+1)%len(users)]}]
+		if !ok {
+			return 0
+		}
+		if edge.quantity < quantity {
+			quantity = edge.quantity
+		}
+	}
+	return quantity
+}
+
+// spoofStats 계정·마켓별 주문 집계
+type spoofStats struct {
+	placed        int
+	quickOrderIDs []uint
+	cancelledBy   map[models.OrderSide]int64
+	filledBy      map[models.OrderSide]int64
+}
+
+// detectSpoofing 체결 없이 바로 취소한 주문이 많은 계정 탐지
+func (s *SurveillanceService) detectSpoofing(from, to time.Time) ([]surveillanceFinding, error) {
+	var orders []models.Order
+	if err := s.db.Select("id", "milestone_id", "option_id", "user_id", "side", "quantity", "filled", "status", "created_at", "updated_at").
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Order("id").
+		Find(&orders).Error; err != nil {
+		return nil, fmt.Errorf("주문 조회 실패: %w", err)
+	}
+
+	type spoofKey struct {
+		userID      uint
+		milestoneID uint
+		optionID    string
+	}
+	stats := map[spoofKey]*spoofStats{}
+	var keys []spoofKey
+	for _, order := range orders {
+		if s.spoofExempt[order.UserID] {
+			continue
+		}
+		key := spoofKey{order.UserID, order.MilestoneID, order.OptionID}
+		stat, ok := stats[key]
+		if !ok {
+			stat = &spoofStats{cancelledBy: map[models.OrderSide]int64{}, filledBy: map[models.OrderSide]int64{}}
+			stats[key] = stat
+			keys = append(keys, key)
+		}
+		stat.placed++
+		stat.filledBy[order.Side] += order.Filled
+		if order.Status == models.OrderStatusCancelled && order.Filled == 0 && order.UpdatedAt.Sub(order.CreatedAt) <= spoofCancelWindow {
+			stat.quickOrderIDs = append(stat.quickOrderIDs, order.ID)
+			stat.cancelledBy[order.Side] += order.Quantity
+		}
+	}
+
+	var findings []surveillanceFinding
+	for _, key := range keys {
+		stat := stats[key]
+		ratio := float64(len(stat.quickOrderIDs)) / float64(stat.placed)
+		if len(stat.quickOrderIDs) < spoofMinCancels || ratio < spoofMinCancelRatio {
+			continue
+		}
+
+		// 허수 주문이 주로 걸린 방향과 반대 방향 체결 (매수 허수로 가격을 올리고 매도 체결 등)
+		spoofSide, opposite := models.OrderSideBuy, models.OrderSideSell
+		if stat.cancelledBy[models.OrderSideSell] > stat.cancelledBy[models.OrderSideBuy] {
+			spoofSide, opposite = models.OrderSideSell, models.OrderSideBuy
+		}
+		cancelled := stat.cancelledBy[models.OrderSideBuy] + stat.cancelledBy[models.OrderSideSell]
+
+		severity := models.SurveillanceSeverityLow
+		switch {
+		case stat.filledBy[opposite] > 0:
+			severity = models.SurveillanceSeverityHigh
+		case len(stat.quickOrderIDs) >= 2*spoofMinCancels:
+			severity = models.SurveillanceSeverityMedium
+		}
+
+		findings = append(findings, surveillanceFinding{
+			key: fmt.Sprintf("%s:%d:%s:%d:%s", models.SurveillanceSpoofing, key.milestoneID, key.optionID, key.userID, from.Format("20060102")),
+			SurveillanceAlert: models.SurveillanceAlert{
+				Type:        models.SurveillanceSpoofing,
+				Severity:    severity,
+				MilestoneID: key.milestoneID,
+				OptionID:    key.optionID,
+				UserIDs:     []uint{key.userID},
+				Summary: fmt.Sprintf("계정 %d 주문 %d건 중 %d건을 체결 없이 %v 안에 취소 (주로 %s, 반대 방향 체결 %d주)",
+					key.userID, stat.placed, len(stat.quickOrderIDs), spoofCancelWindow, spoofSide, stat.filledBy[opposite]),
+				Evidence: models.SurveillanceEvidence{
+					WindowStart:       from,
+					WindowEnd:         to,
+					OrderIDs:          stat.quickOrderIDs,
+					OrdersPlaced:      stat.placed,
+					QuickCancels:      len(stat.quickOrderIDs),
+					CancelRatio:       ratio,
+					CancelledQuantity: cancelled,
+					OppositeFills:     stat.filledBy[opposite],
+				},
+			},
+		})
+	}
+	return findings, nil
+}
+
+// detectPaintingTheClose 최근 지났거나 곧 닥칠 거래 마감/검증 기한 직전 구간의 가격 변동 주도 계정 탐지
+func (s *SurveillanceService) detectPaintingTheClose(now time.Time) ([]surveillanceFinding, error) {
+	from, to := now.Add(-paintLookback), now.Add(paintWindow)
+	var milestones []models.Milestone
+	if err := s.db.Select("id", "target_date", "trading_closes_at", "verification_deadline").
+		Where("(COALESCE(trading_closes_at, target_date) > ? AND COALESCE(trading_closes_at, target_date) <= ?) OR (verification_deadline > ? AND verification_deadline <= ?)", from, to, from, to).
+		Find(&milestones).Error; err != nil {
+		return nil, fmt.Errorf("기한 임박 마일스톤 조회 실패: %w", err)
+	}
+
+	var findings []surveillanceFinding
+	for i := range milestones {
+		deadlines := map[string]*time.Time{
+			"trading_close": milestones[i].TradingCloseTime(),
+			"verification":  milestones[i].VerificationDeadline,
+		}
+		for _, kind := range []string{"trading_close", "verification"} {
+			deadline := deadlines[kind]
+			if deadline == nil || !deadline.After(from) || deadline.After(to) {
+				continue
+			}
+			found, err := s.paintingFindings(milestones[i].ID, kind, *deadline, now)
+			if err != nil {
+				return nil, err
+			}
+			findings = append(findings, found...)
+		}
+	}
+	return findings, nil
+}
+
+// paintingFindings 기한 직전 구간을 옵션별로 직전 체결가와 비교
+func (s *SurveillanceService) paintingFindings(milestoneID uint, kind string, deadline, now time.Time) ([]surveillanceFinding, error) {
+	windowStart, windowEnd := deadline.Add(-paintWindow), deadline
+	if now.Before(windowEnd) {
+		windowEnd = now
+	}
+	if !windowEnd.After(windowStart) {
+		return nil, nil
+	}
+
+	var trades []models.Trade
+	if err := s.db.Select("id", "option_id", "buyer_id", "seller_id", "quantity", "price", "created_at").
+		Where("milestone_id = ? AND created_at >= ? AND created_at <= ?", milestoneID, windowStart, windowEnd).
+		Order("option_id, created_at, id").
+		Find(&trades).Error; err != nil {
+		return nil, fmt.Errorf("기한 직전 체결 조회 실패: %w", err)
+	}
+
+	var findings []surveillanceFinding
+	for start := 0; start < len(trades); {
+		end := start
+		for end < len(trades) && trades[end].OptionID == trades[start].OptionID {
+			end++
+		}
+		window := trades[start:end]
+		start = end
+
+		var before models.Trade
+		err := s.db.Select("price").
+			Where("milestone_id = ? AND option_id = ? AND created_at < ?", milestoneID, window[0].OptionID, windowStart).
+			Order("created_at DESC, id DESC").
+			First(&before).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue // 비교할 직전 가격이 없음
+		}
+		if err != nil {
+			return nil, fmt.Errorf("기준 체결 조회 실패: %w", err)
+		}
+
+		after := window[len(window)-1].Price
+		move := after - before.Price
+		if math.Abs(move) < paintMinMove {
+			continue
+		}
+
+		// 가격을 올렸으면 매수자, 내렸으면 매도자 중 체결 수량이 가장 많은 계정
+		driven := map[uint]int64{}
+		driverIDs := map[uint][]uint{}
+		var windowVolume int64
+		for _, trade := range window {
+			windowVolume += trade.Quantity
+			userID := trade.BuyerID
+			if move < 0 {
+				userID = trade.SellerID
+			}
+			driven[userID] += trade.Quantity
+			driverIDs[userID] = append(driverIDs[userID], trade.ID)
+		}
+		var driver uint
+		var driverVolume int64
+		for userID, quantity := range driven {
+			if quantity > driverVolume || (quantity == driverVolume && userID < driver) {
+				driver, driverVolume = userID, quantity
+			}
+		}
+		share := float64(driverVolume) / float64(windowVolume)
+		if driver == 0 || share < paintMinShare {
+			continue
+		}
+
+		severity := models.SurveillanceSeverityLow
+		switch {
+		case share >= 0.8 && math.Abs(move) >= 2*paintMinMove:
+			severity = models.SurveillanceSeverityHigh
+		case share >= 0.8 || math.Abs(move) >= 2*paintMinMove:
+			severity = models.SurveillanceSeverityMedium
+		}
+
+		deadlineAt := deadline
+		findings = append(findings, surveillanceFinding{
+			key: fmt.Sprintf("%s:%d:%s:%s:%d", models.SurveillancePaintingTheClose, milestoneID, window[0].OptionID, kind, deadline.Unix()),
+			SurveillanceAlert: models.SurveillanceAlert{
+				Type:        models.SurveillancePaintingTheClose,
+				Severity:    severity,
+				MilestoneID: milestoneID,
+				OptionID:    window[0].OptionID,
+				UserIDs:     []uint{driver},
+				Summary: fmt.Sprintf("%s 기한 %v 전 가격 %.2f → %.2f, 계정 %d가 해당 방향 체결의 %.0f%%",
+					kind, paintWindow, before.Price, after, driver, share*100),
+				Evidence: models.SurveillanceEvidence{
+					WindowStart:  windowStart,
+					WindowEnd:    windowEnd,
+					TradeIDs:     driverIDs[driver],
+					MarketShare:  share,
+					Deadline:     &deadlineAt,
+					DeadlineKind: kind,
+					PriceBefore:  before.Price,
+					PriceAfter:   after,
+					DriverVolume: driverVolume,
+					WindowVolume: windowVolume,
+				},
+			},
+		})
+	}
+	return findings, nil
+}
+
+// ListAlerts 알림 목록 (최근 탐지 순, 빈 값은 조건에서 제외)
+func (s *SurveillanceService) ListAlerts(status models.SurveillanceAlertStatus, alertType models.SurveillanceAlertType, milestoneID uint, limit, offset int) ([]models.SurveillanceAlert, int64, error) {
+	if limit <= 0 || limit > maxSurveillanceAlerts {
+		limit = maxSurveillanceAlerts
+	}
+
+	query := s.db.Model(&models.SurveillanceAlert{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if alertType != "" {
+		query = query.Where("type = ?", alertType)
+	}
+	if milestoneID != 0 {
+		query = query.Where("milestone_id = ?", milestoneID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var alerts []models.SurveillanceAlert
+	if err := query.Order("last_seen_at DESC, id DESC").Limit(limit).Offset(offset).Find(&alerts).Error; err != nil {
+		return nil, 0, err
+	}
+	return alerts, total, nil
+}
+
+// GetAlert 알림 상세 (마일스톤 포함)
+func (s *SurveillanceService) GetAlert(alertID uint) (*models.SurveillanceAlert, error) {
+	var alert models.SurveillanceAlert
+	if err := s.db.Preload("Milestone").First(&alert, alertID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSurveillanceAlertNotFound
+		}
+		return nil, err
+	}
+	return &alert, nil
+}
+
+// DismissAlert 문제 없음으로 종결 (같은 패턴은 다시 알리지 않음)
+func (s *SurveillanceService) DismissAlert(alertID, reviewerID uint, note string) (*models.SurveillanceAlert, error) {
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		return s.review(tx, alertID, reviewerID, models.SurveillanceAlertDismissed, note, nil)
+	}); err != nil {
+		return nil, err
+	}
+	return s.GetAlert(alertID)
+}
+
+// EscalateAlert 배심원 중재 사건으로 이관 (이관한 운영자가 신청인, 주 대상 계정이 피신청인)
+func (s *SurveillanceService) EscalateAlert(alertID, reviewerID uint, note string) (*models.SurveillanceAlert, error) {
+	if s.arbitration == nil {
+		return nil, errors.New("분쟁 해결 서비스가 설정되지 않았습니다")
+	}
+
+	var arbitrationCase *models.ArbitrationCase
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var alert models.SurveillanceAlert
+		if err := tx.First(&alert, alertID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrSurveillanceAlertNotFound
+			}
+			return err
+		}
+		if alert.Status != models.SurveillanceAlertOpen {
+			return ErrSurveillanceAlertReviewed
+		}
+
+		var err error
+		arbitrationCase, err = s.arbitration.OpenSurveillanceEscalation(tx, &alert, reviewerID, note)
+		if err != nil {
+			return err
+		}
+		return s.review(tx, alertID, reviewerID, models.SurveillanceAlertEscalated, note, &arbitrationCase.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go s.arbitration.startJurySelection(arbitrationCase.ID)
+	return s.GetAlert(alertID)
+}
+
+// review 열린 알림만 종결/이관 상태로 전환
+func (s *SurveillanceService) review(tx *gorm.DB, alertID, reviewerID uint, status models.SurveillanceAlertStatus, note string, caseID *uint) error {
+	updates := map[string]interface{}{
+		"status":      status,
+		"reviewed_by": reviewerID,
+		"reviewed_at": time.Now(),
+		"review_note": note,
+	}
+	if caseID != nil {
+		updates["arbitration_case_id"] = *caseID
+	}
+
+	result := tx.Model(&models.SurveillanceAlert{}).
+		Where("id = ? AND status = ?", alertID, models.SurveillanceAlertOpen).
+		Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("알림 상태 변경 실패: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	var count int64
+	if err := tx.Model(&models.SurveillanceAlert{}).Where("id = ?", alertID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrSurveillanceAlertNotFound
+	}
+	return ErrSurveillanceAlertReviewed
+}
+
+// userSetKey 계정 조합 키 (순서 무관)
+func userSetKey(users []uint) string {
+	sorted := append([]uint(nil), users...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return formatUserIDs(sorted)
+}
+
+func formatUserIDs(users []uint) string {
+	parts := make([]string, len(users))
+	for i, userID := range users {
+		parts[i] = strconv.FormatUint(uint64(userID), 10)
+	}
+	return strings.Join(parts, "-")
+}
@@ -0,0 +1,844 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/queue"
+	"blueprint-module/pkg/redis"
+
+	"gorm.io/gorm"
+)
+
+// BookQuote 주문장 최우선 호가와 마지막 체결가 (없으면 0)
+type BookQuote struct {
+	Bid  float64
+	Ask  float64
+	Last float64
+}
+
+// tradePipeline 체결 후처리 (저장, 지갑/포지션, 수수료 적립, 시장 데이터, 알림/브로드캐스트)
+//
+// 단일 노드와 분산 매칭 엔진이 같은 후처리를 거치도록 두 엔진에 포함된다. 주문장 상태는 엔진마다 다르게
+// 보관하므로 호가가 필요한 계산은 엔진이 넘겨준 quote로 조회한다.
+type tradePipeline struct {
+	db                     *gorm.DB
+	queuePublisher         *queue.Publisher
+	sseService             *SSEService                 // SSE 실시간 브로드캐스트용
+	fundingService         *FundingVerificationService // 🆕 펀딩 검증 서비스
+	mentorQualificationSvc *MentorQualificationService // 🆕 멘토 자격 증명 서비스
+	watchlistService       *WatchlistService           // 👀 팔로워 가격 변동 피드
+	referralService        *ReferralService            // 🤝 추천 수수료 보상 적립
+	webhookPublisher       *WebhookPublisher           // 📮 외부 웹훅 체결 이벤트
+	notificationService    *NotificationService        // 🔔 체결 알림
+	circuitBreaker         *CircuitBreakerService      // 🧯 급변동 시 마켓 일시 중단
+	tradingPause           *TradingPauseService        // 🚧 점검 모드/관리자 거래 중단
+	candleProjector        *PriceCandleProjector       // 📚 가격 캔들 조회 모델
+	flags                  FeatureChecker              // 🚩 기능 플래그 (nil이면 기본 수수료)
+	tradeListeners         []TradeListener             // 👥 저장된 체결 구독 (복사 거래, 강제 청산)
+	currencyService        *CurrencyService            // 💱 결제 통화 금액의 USDC 환산 (TVL, 멘토 풀 수수료)
+
+	quote func(milestoneID uint, optionID string) BookQuote
+
+	// 체결로 바뀐 주문 상태 (write-behind로 DB 반영)
+	dirtyOrders map[uint]*pendingOrderState
+	dirtyMutex  sync.Mutex
+
+	settling sync.WaitGroup // 진행 중인 체결 후처리 고루틴
+}
+
+func newTradePipeline(db *gorm.DB, sseService *SSEService, fundingService *FundingVerificationService, mentorQualificationSvc *MentorQualificationService) *tradePipeline {
+	return &tradePipeline{
+		db:                     db,
+		queuePublisher:         queue.NewPublisher(),
+		sseService:             sseService,
+		fundingService:         fundingService,
+		mentorQualificationSvc: mentorQualificationSvc,
+		notificationService:    NewNotificationService(db),
+		watchlistService:       NewWatchlistService(db),
+		referralService:        NewReferralService(db),
+		webhookPublisher:       NewWebhookPublisher(),
+		circuitBreaker:         NewCircuitBreakerService(db, sseService, DefaultCircuitBreakerConfig),
+		tradingPause:           NewTradingPauseService(db, sseService),
+		candleProjector:        NewPriceCandleProjector(db),
+		currencyService:        NewCurrencyService(db, nil, 0), // 환산만 하므로 통화 목록은 필요 없음
+		quote:                  func(uint, string) BookQuote { return BookQuote{} },
+		dirtyOrders:            make(map[uint]*pendingOrderState),
+	}
+}
+
+// CircuitBreaker 매칭 엔진이 체결가를 알리는 서킷브레이커 (주문 접수 검사와 모니터링에 공유)
+func (tp *tradePipeline) CircuitBreaker() *CircuitBreakerService {
+	return tp.circuitBreaker
+}
+
+// TradingPause 점검 모드/관리자 거래 중단 (주문 접수 검사와 관리자 API에 공유)
+func (tp *tradePipeline) TradingPause() *TradingPauseService {
+	return tp.tradingPause
+}
+
+// SetFeatureFlags 기능 플래그 연결 (엔진 시작 전에 호출)
+func (tp *tradePipeline) SetFeatureFlags(flags FeatureChecker) {
+	tp.flags = flags
+}
+
+// TradeListener 저장된 체결을 받는 구독자 (체결 ID가 정해진 뒤, 후처리 고루틴에서 호출)
+type TradeListener interface {
+	OnTradesPersisted(trades []models.Trade)
+}
+
+// AddTradeListener 체결 구독자 추가 (주문 접수 전에 호출, 추가한 순서대로 호출)
+func (tp *tradePipeline) AddTradeListener(listener TradeListener) {
+	tp.tradeListeners = append(tp.tradeListeners, listener)
+}
+
+// feeBasisPoints 테이커 주문 기준 매수/매도 체결 수수료율
+//
+// new_fee_tiers가 테이커 사용자에게 켜져 있으면 테이커는 takerFeeBasisPoints, 메이커는 makerFeeBasisPoints를 내고
+// 아니면 양쪽 모두 tradeFeeBasisPoints를 낸다.
+func (tp *tradePipeline) feeBasisPoints(taker *models.Order) (buyer, seller int64) {
+	if tp.flags == nil || !tp.flags.IsEnabledFor(models.FlagNewFeeTiers, taker.UserID) {
+		return tradeFeeBasisPoints, tradeFeeBasisPoints
+	}
+	if taker.Side == models.OrderSideBuy {
+		return takerFeeBasisPoints, makerFeeBasisPoints
+	}
+	return makerFeeBasisPoints, takerFeeBasisPoints
+}
+
+// goSettle 체결 후처리 고루틴 실행 (WaitForSettlement가 끝날 때까지 기다릴 수 있도록 추적)
+func (tp *tradePipeline) goSettle(task func()) {
+	tp.settling.Add(1)
+	go func() {
+		defer tp.settling.Done()
+		task()
+	}()
+}
+
+// WaitForSettlement 진행 중인 체결 후처리가 모두 끝날 때까지 대기 (시간 안에 끝나면 true)
+func (tp *tradePipeline) WaitForSettlement(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		tp.settling.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// settleTrades 한 시장에서 한 번에 체결된 거래들의 후처리 시작
+func (tp *tradePipeline) settleTrades(milestoneID uint, optionID string, trades []models.Trade) {
+	if len(trades) == 0 {
+		return
+	}
+
+	// 급변동 감지 (발동 시 이후 주문은 SubmitOrder에서 거부)
+	tp.circuitBreaker.RecordTrades(milestoneID, optionID, trades)
+
+	// 🆕 펀딩 TVL 업데이트 (동기 처리 - 중요)
+	tp.goSettle(func() { tp.updateFundingTVL(milestoneID, optionID, trades) })
+
+	// 🆕 멘토 자격 업데이트 (비동기 처리 - "가장 똑똑한 돈" 식별)
+	tp.goSettle(func() { tp.updateMentorQualification(milestoneID, trades) })
+
+	// 🆕 멘토 풀 수수료 적립 (비동기 처리 - "The Reward Engine")
+	tp.goSettle(func() { tp.accumulateMentorPoolFees(milestoneID, trades) })
+
+	// 데이터베이스에 저장 (비동기)
+	tp.goSettle(func() { tp.persistTrades(trades) })
+
+	// 사용자 지갑 잔액 업데이트 (비동기)
+	tp.goSettle(func() { tp.updateUserWallets(trades) })
+
+	// 사용자 Position 업데이트 (비동기)
+	tp.goSettle(func() { tp.updateUserPositions(trades) })
+
+	// MarketData 업데이트 (비동기)
+	tp.goSettle(func() { tp.updateMarketData(milestoneID, optionID, trades) })
+
+	// 조회 전용 모델(가격 캔들) 반영 (비동기)
+	tp.goSettle(func() { tp.projectReadModels(trades) })
+
+	// 실시간 브로드캐스트
+	tp.goSettle(func() { tp.broadcastTrades(trades) })
+
+	// 캐시 업데이트
+	tp.goSettle(func() { tp.updateMarketCache(milestoneID, optionID, trades) })
+
+	// 체결 알림 (인앱 + 푸시)
+	tp.goSettle(func() { tp.notifyOrderFills(trades) })
+}
+
+// 🆕 updateFundingTVL 펀딩 TVL 업데이트
+func (tp *tradePipeline) updateFundingTVL(milestoneID uint, optionID string, trades []models.Trade) {
+	if tp.fundingService == nil {
+		return
+	}
+
+	// 거래의 총 금액 계산
+	var totalAmount int64
+	for _, trade := range trades {
+		totalAmount += tp.currencyService.ToUSDC(trade.QuoteCurrency, trade.TotalAmount)
+	}
+
+	// 펀딩 서비스를 통해 TVL 업데이트
+	if err := tp.fundingService.UpdateTVL(milestoneID, optionID, totalAmount); err != nil {
+		log.Printf("❌ Failed to update TVL for milestone %d: %v", milestoneID, err)
+	}
+}
+
+// 🆕 updateMentorQualification 멘토 자격 업데이트
+func (tp *tradePipeline) updateMentorQualification(milestoneID uint, trades []models.Trade) {
+	if tp.mentorQualificationSvc == nil {
+		return
+	}
+
+	// 성공 베팅과 관련된 거래만 처리 (optionID가 "success"인 경우)
+	hasSuccessBetting := false
+	for _, trade := range trades {
+		if trade.OptionID == "success" {
+			hasSuccessBetting = true
+			break
+		}
+	}
+
+	if !hasSuccessBetting {
+		return // 실패 베팅은 멘토 자격과 관련 없음
+	}
+
+	// 멘토 자격 재처리 (베팅 순위 변동 반영)
+	if _, err := tp.mentorQualificationSvc.ProcessMilestoneBetting(milestoneID); err != nil {
+		log.Printf("❌ Failed to update mentor qualification for milestone %d: %v", milestoneID, err)
+	} else {
+		log.Printf("✨ Mentor qualification updated for milestone %d after new trades", milestoneID)
+	}
+}
+
+// 🆕 accumulateMentorPoolFees 멘토 풀에 수수료 적립
+func (tp *tradePipeline) accumulateMentorPoolFees(milestoneID uint, trades []models.Trade) {
+	// 총 거래 수수료 계산 (멘토 풀은 USDC로 적립)
+	var totalFees int64
+	for _, trade := range trades {
+		totalFees += tp.currencyService.ToUSDC(trade.QuoteCurrency, trade.BuyerFee+trade.SellerFee)
+	}
+
+	if totalFees <= 0 {
+		return
+	}
+
+	// 멘토 풀 조회 및 수수료 적립
+	var mentorPool models.MentorPool
+	if err := tp.db.Where("milestone_id = ?", milestoneID).First(&mentorPool).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Printf("📋 No mentor pool found for milestone %d, skipping fee accumulation", milestoneID)
+			return
+		}
+		log.Printf("❌ Failed to query mentor pool for milestone %d: %v", milestoneID, err)
+		return
+	}
+
+	// 설정된 비율만큼 멘토 풀에 적립 (기본 50%)
+	mentorPoolFees := int64(float64(totalFees) * mentorPool.FeePercentage / 100)
+
+	// 멘토 풀 업데이트
+	mentorPool.AccumulatedFees += mentorPoolFees
+	mentorPool.TotalPoolAmount += mentorPoolFees
+
+	if err := tp.db.Save(&mentorPool).Error; err != nil {
+		log.Printf("❌ Failed to update mentor pool fees for milestone %d: %v", milestoneID, err)
+		return
+	}
+
+	log.Printf("💰 Accumulated $%.2f mentor pool fees for milestone %d (%.1f%% of total fees $%.2f)",
+		float64(mentorPoolFees)/100, milestoneID, mentorPool.FeePercentage, float64(totalFees)/100)
+
+	// 실시간 멘토 풀 업데이트 알림
+	tp.goSettle(func() { tp.broadcastMentorPoolUpdate(milestoneID, &mentorPool, mentorPoolFees) })
+}
+
+// broadcastMentorPoolUpdate 멘토 풀 업데이트 브로드캐스트
+func (tp *tradePipeline) broadcastMentorPoolUpdate(milestoneID uint, pool *models.MentorPool, addedAmount int64) {
+	if tp.sseService == nil {
+		return
+	}
+
+	event := MarketUpdateEvent{
+		MilestoneID: milestoneID,
+		MarketData: map[string]interface{}{
+			"event_type": "mentor_pool_update",
+			"data": map[string]interface{}{
+				"milestone_id":      milestoneID,
+				"total_pool_amount": pool.TotalPoolAmount,
+				"accumulated_fees":  pool.AccumulatedFees,
+				"added_amount":      addedAmount,
+				"fee_percentage":    pool.FeePercentage,
+				"updated_at":        time.Now().Unix(),
+			},
+		},
+		Timestamp: time.Now().Unix(),
+	}
+
+	tp.sseService.BroadcastMarketUpdate(event)
+}
+
+func (tp *tradePipeline) persistTrades(trades []models.Trade) {
+	persisted := make([]models.Trade, 0, len(trades))
+	for _, trade := range trades {
+		if err := tp.db.Create(&trade).Error; err != nil {
+			log.Printf("❌ Failed to persist trade: %v", err)
+			continue
+		}
+		persisted = append(persisted, trade)
+	}
+
+	// 피추천인 수수료의 추천인 몫 적립 (체결 ID가 필요하므로 저장 이후)
+	tp.referralService.AccrueTradeFees(persisted)
+
+	tp.publishTradeWebhooks(persisted)
+
+	if len(persisted) > 0 {
+		for _, listener := range tp.tradeListeners {
+			listener.OnTradesPersisted(persisted)
+		}
+	}
+}
+
+// publishTradeWebhooks 저장된 체결을 외부 웹훅 이벤트로 발행
+func (tp *tradePipeline) publishTradeWebhooks(trades []models.Trade) {
+	projectIDs := make(map[uint]uint)
+	for i := range trades {
+		trade := &trades[i]
+		projectID, ok := projectIDs[trade.MilestoneID]
+		if !ok {
+			tp.db.Model(&models.Milestone{}).Where("id = ?", trade.MilestoneID).Pluck("project_id", &projectID)
+			projectIDs[trade.MilestoneID] = projectID
+		}
+		tp.webhookPublisher.PublishTradeExecuted(trade, projectID)
+	}
+}
+
+func (tp *tradePipeline) broadcastTrades(trades []models.Trade) {
+	for _, trade := range trades {
+		// Redis 브로드캐스트 (기존, 미연결이면 SSE만)
+		if redis.GetClient() != nil {
+			redis.BroadcastTradeUpdate(trade.MilestoneID, trade.OptionID, trade)
+			redis.BroadcastPriceChange(trade.MilestoneID, trade.OptionID, trade.Price)
+		}
+
+		// SSE 실시간 브로드캐스트 (신규 추가)
+		if tp.sseService != nil {
+			// 거래 이벤트 브로드캐스트
+			tp.sseService.BroadcastTradeUpdate(trade.MilestoneID, trade.OptionID, map[string]interface{}{
+				"trade_id":     trade.ID,
+				"option_id":    trade.OptionID,
+				"buyer_id":     trade.BuyerID,
+				"seller_id":    trade.SellerID,
+				"quantity":     trade.Quantity,
+				"price":        trade.Price,
+				"total_amount": trade.TotalAmount,
+				"timestamp":    trade.CreatedAt.Unix(),
+			})
+
+			// 가격 변동 브로드캐스트
+			tp.sseService.BroadcastPriceChange(trade.MilestoneID, trade.OptionID, 0, trade.Price)
+
+		}
+
+		// 매수자/매도자 비공개 스트림 (/stream/me)
+		publishOrderFill(&trade, trade.BuyerID, trade.BuyOrderID, models.OrderSideBuy, trade.BuyerFee)
+		publishOrderFill(&trade, trade.SellerID, trade.SellOrderID, models.OrderSideSell, trade.SellerFee)
+
+		// 큐에 작업 추가
+		tp.queuePublisher.EnqueueTradeWork(trade.MilestoneID, trade.OptionID, queue.TradeEventData{
+			TradeID:     trade.ID,
+			BuyerID:     trade.BuyerID,
+			SellerID:    trade.SellerID,
+			Quantity:    trade.Quantity,
+			Price:       trade.Price,
+			TotalAmount: trade.TotalAmount,
+		})
+	}
+}
+
+func (tp *tradePipeline) updateMarketCache(milestoneID uint, optionID string, trades []models.Trade) {
+	// Redis 캐시 업데이트 (미연결이면 건너뜀)
+	if len(trades) > 0 && redis.GetClient() != nil {
+		lastTrade := trades[len(trades)-1]
+		redis.SetMarketPrice(milestoneID, optionID, lastTrade.Price)
+		redis.SetRecentTrades(milestoneID, optionID, trades)
+	}
+}
+
+// notifyOrderFills 주문 체결 알림 (사용자/주문별로 묶어서 1회 발송)
+func (tp *tradePipeline) notifyOrderFills(trades []models.Trade) {
+	if tp.notificationService == nil {
+		return
+	}
+
+	type fill struct {
+		userID   uint
+		orderID  uint
+		side     string
+		quantity int64
+		amount   float64
+	}
+
+	fills := make(map[uint]*fill) // orderID -> fill
+	order := make([]uint, 0)
+	add := func(userID, orderID uint, side string, trade models.Trade) {
+		f, ok := fills[orderID]
+		if !ok {
+			f = &fill{userID: userID, orderID: orderID, side: side}
+			fills[orderID] = f
+			order = append(order, orderID)
+		}
+		f.quantity += trade.Quantity
+		f.amount += float64(trade.Quantity) * trade.Price
+	}
+	for _, trade := range trades {
+		add(trade.BuyerID, trade.BuyOrderID, "매수", trade)
+		add(trade.SellerID, trade.SellOrderID, "매도", trade)
+	}
+
+	milestoneID, optionID := trades[0].MilestoneID, trades[0].OptionID
+	for _, orderID := range order {
+		f := fills[orderID]
+		avgPrice := f.amount / float64(f.quantity)
+		tp.notificationService.Notify(models.CreateNotificationRequest{
+			UserID:   f.userID,
+			Type:     models.NotificationTypeTrade,
+			Priority: models.NotificationPriorityLow,
+			Title:    "주문이 체결되었습니다",
+			Message:  fmt.Sprintf("%s %s %d주가 평균 %.2f에 체결되었습니다", optionID, f.side, f.quantity, avgPrice),
+			Link:     fmt.Sprintf("/milestones/%d", milestoneID),
+			Data: map[string]interface{}{
+				"order_id":     f.orderID,
+				"milestone_id": milestoneID,
+				"option_id":    optionID,
+				"quantity":     f.quantity,
+				"avg_price":    avgPrice,
+			},
+		})
+	}
+}
+
+// updateMarketData MarketData 테이블 업데이트
+func (tp *tradePipeline) updateMarketData(milestoneID uint, optionID string, trades []models.Trade) {
+	if len(trades) == 0 {
+		return
+	}
+
+	// 최신 거래 정보
+	lastTrade := trades[len(trades)-1]
+	newPrice := lastTrade.Price
+	tradeTime := lastTrade.CreatedAt
+
+	// 기존 MarketData 조회
+	var marketData models.MarketData
+	err := tp.db.Where("milestone_id = ? AND option_id = ?", milestoneID, optionID).First(&marketData).Error
+
+	if err != nil {
+		// MarketData가 없으면 새로 생성
+		marketData = models.MarketData{
+			MilestoneID:   milestoneID,
+			OptionID:      optionID,
+			CurrentPrice:  newPrice,
+			PreviousPrice: newPrice,
+			HighPrice24h:  newPrice,
+			LowPrice24h:   newPrice,
+			LastTradeTime: tradeTime,
+		}
+	} else {
+		// 기존 데이터 업데이트
+		marketData.PreviousPrice = marketData.CurrentPrice
+		marketData.CurrentPrice = newPrice
+		marketData.LastTradeTime = tradeTime
+
+		// 24시간 고가/저가 업데이트
+		if newPrice > marketData.HighPrice24h {
+			marketData.HighPrice24h = newPrice
+		}
+		if newPrice < marketData.LowPrice24h || marketData.LowPrice24h == 0 {
+			marketData.LowPrice24h = newPrice
+		}
+
+		// 24시간 변동폭 계산 (24시간 전 가격과 비교)
+		var price24hAgo float64
+		tp.db.Model(&models.Trade{}).
+			Where("milestone_id = ? AND option_id = ? AND created_at <= ?",
+				milestoneID, optionID, tradeTime.Add(-24*time.Hour)).
+			Order("created_at DESC").
+			Limit(1).
+			Pluck("price", &price24hAgo)
+
+		if price24hAgo > 0 {
+			marketData.Change24h = newPrice - price24hAgo
+			marketData.ChangePercent = (marketData.Change24h / price24hAgo) * 100
+		} else {
+			// 24시간 전 데이터가 없으면 현재 가격 기준
+			marketData.Change24h = 0
+			marketData.ChangePercent = 0
+		}
+	}
+
+	// 24시간 거래량 및 거래 수 계산
+	var volume24h int64
+	var trades24h int
+
+	tp.db.Model(&models.Trade{}).
+		Where("milestone_id = ? AND option_id = ? AND created_at > ?",
+			milestoneID, optionID, tradeTime.Add(-24*time.Hour)).
+		Select("COALESCE(SUM(quantity), 0) as volume, COUNT(*) as trades").
+		Row().Scan(&volume24h, &trades24h)
+
+	marketData.Volume24h = volume24h
+	marketData.Trades24h = trades24h
+
+	// 현재 호가창에서 BidPrice, AskPrice, Spread 계산
+	quote := tp.quote(milestoneID, optionID)
+	marketData.BidPrice, marketData.AskPrice = quote.Bid, quote.Ask
+	if marketData.BidPrice > 0 && marketData.AskPrice > 0 {
+		marketData.Spread = marketData.AskPrice - marketData.BidPrice
+	}
+	marketData.UpdatedAt = time.Now()
+
+	// 데이터베이스에 저장 (TWAP/VWAP는 체결 이벤트 소비자가 따로 갱신하므로 덮어쓰지 않음)
+	if marketData.ID == 0 {
+		err = tp.db.Create(&marketData).Error
+	} else {
+		err = tp.db.Omit(marketMetricsColumns...).Save(&marketData).Error
+	}
+
+	if err != nil {
+		log.Printf("❌ Failed to update market data for %d:%s: %v", milestoneID, optionID, err)
+	} else {
+		BumpMarketSequence(milestoneID)
+		tp.watchlistService.CheckMarketMove(milestoneID, optionID, newPrice) // 팔로워 가격 변동 알림
+		log.Printf("📊 Updated market data for %d:%s: price %.4f, volume %d",
+			milestoneID, optionID, newPrice, volume24h)
+	}
+}
+
+// updateUserPositions 사용자 포지션 업데이트
+func (tp *tradePipeline) updateUserPositions(trades []models.Trade) {
+	for _, trade := range trades {
+		// 숏 포지션을 환매하면 줄어든 비율만큼 담보 해제 (포지션 수량이 바뀌기 전에 계산)
+		tp.releaseShortCollateral(&trade)
+
+		// 매수자 포지션 업데이트 (+수량)
+		tp.updateSinglePosition(trade.BuyerID, trade.ProjectID, trade.MilestoneID,
+			trade.OptionID, trade.Quantity, trade.Price, trade.TotalAmount, true)
+
+		// 매도자 포지션 업데이트 (-수량)
+		tp.updateSinglePosition(trade.SellerID, trade.ProjectID, trade.MilestoneID,
+			trade.OptionID, -trade.Quantity, trade.Price, trade.TotalAmount, false)
+
+		// 숏 매도분 담보는 주문 잠금에서 포지션 담보로 이동 (지갑 잠금액은 그대로)
+		tp.addShortCollateral(&trade)
+	}
+}
+
+// releaseShortCollateral 매수자가 숏 포지션을 환매한 만큼 포지션 담보를 사용 가능 잔액으로 해제
+func (tp *tradePipeline) releaseShortCollateral(trade *models.Trade) {
+	var position models.Position
+	if err := tp.db.Select("id", "quantity", "collateral").
+		Where("user_id = ? AND milestone_id = ? AND option_id = ? AND quantity < 0 AND collateral > 0",
+			trade.BuyerID, trade.MilestoneID, trade.OptionID).
+		First(&position).Error; err != nil {
+		return
+	}
+
+	release := position.Collateral
+	if short := -position.Quantity; trade.Quantity < short {
+		release = position.Collateral * trade.Quantity / short
+	}
+	if release <= 0 {
+		return
+	}
+
+	err := tp.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Position{}).
+			Where("id = ? AND collateral >= ?", position.ID, release).
+			UpdateColumn("collateral", gorm.Expr("collateral - ?", release))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		return releaseOrderFunds(tx, trade.BuyerID, release)
+	})
+	if err != nil {
+		log.Printf("❌ Failed to release short collateral for user %d: %v", trade.BuyerID, err)
+	}
+}
+
+// addShortCollateral 매도 주문의 숏 매도분 담보를 매도자 포지션 담보로 기록
+func (tp *tradePipeline) addShortCollateral(trade *models.Trade) {
+	if trade.SellerCollateral <= 0 {
+		return
+	}
+	if err := tp.db.Model(&models.Position{}).
+		Where("user_id = ? AND milestone_id = ? AND option_id = ?", trade.SellerID, trade.MilestoneID, trade.OptionID).
+		UpdateColumn("collateral", gorm.Expr("collateral + ?", trade.SellerCollateral)).Error; err != nil {
+		log.Printf("❌ Failed to record short collateral for user %d: %v", trade.SellerID, err)
+	}
+}
+
+// updateSinglePosition 개별 사용자 포지션 업데이트
+func (tp *tradePipeline) updateSinglePosition(userID, projectID, milestoneID uint,
+	optionID string, quantity int64, price float64, totalAmount int64, isBuy bool) {
+
+	// 기존 포지션 조회
+	var position models.Position
+	err := tp.db.Where("user_id = ? AND project_id = ? AND milestone_id = ? AND option_id = ?",
+		userID, projectID, milestoneID, optionID).First(&position).Error
+
+	if err != nil {
+		// 새로운 포지션 생성
+		if isBuy {
+			position = models.Position{
+				UserID:      userID,
+				ProjectID:   projectID,
+				MilestoneID: milestoneID,
+				OptionID:    optionID,
+				Quantity:    quantity,
+				AvgPrice:    price,
+				TotalCost:   totalAmount,
+				Realized:    0,
+				Unrealized:  0,
+				UpdatedAt:   time.Now(),
+			}
+		} else {
+			// 매도인데 기존 포지션이 없으면 숏포지션 생성
+			position = models.Position{
+				UserID:      userID,
+				ProjectID:   projectID,
+				MilestoneID: milestoneID,
+				OptionID:    optionID,
+				Quantity:    quantity, // 음수
+				AvgPrice:    price,
+				TotalCost:   -totalAmount, // 매도로 인한 수익
+				Realized:    0,
+				Unrealized:  0,
+				UpdatedAt:   time.Now(),
+			}
+		}
+
+		err = tp.db.Create(&position).Error
+		if err != nil {
+			log.Printf("❌ Failed to create position for user %d: %v", userID, err)
+		} else {
+			log.Printf("🆕 Created new position for user %d: %s %d@%.4f",
+				userID, optionID, quantity, price)
+		}
+	} else {
+		// 기존 포지션 업데이트
+		oldQuantity := position.Quantity
+		newQuantity := oldQuantity + quantity
+
+		if isBuy {
+			// 매수: 평균단가 재계산
+			if newQuantity > 0 {
+				// 순매수 포지션
+				totalValue := float64(position.TotalCost) + float64(totalAmount)
+				position.AvgPrice = totalValue / float64(newQuantity)
+				position.TotalCost += totalAmount
+			} else if newQuantity == 0 {
+				// 포지션 완전 청산
+				position.Realized += totalAmount - int64(float64(quantity)*position.AvgPrice)
+				position.AvgPrice = 0
+				position.TotalCost = 0
+			} else {
+				// 일부 청산 (숏포지션으로 전환)
+				realizedPnL := int64(float64(oldQuantity) * (price - position.AvgPrice))
+				position.Realized += realizedPnL
+				position.AvgPrice = price
+				position.TotalCost = int64(float64(newQuantity) * price)
+			}
+		} else {
+			// 매도: 실현손익 계산
+			if oldQuantity > 0 {
+				// 기존 매수 포지션에서 매도
+				sellQuantity := -quantity
+				realizedPnL := int64(float64(sellQuantity) * (price - position.AvgPrice))
+				position.Realized += realizedPnL
+
+				if newQuantity > 0 {
+					// 일부 매도
+					position.TotalCost = int64(float64(newQuantity) * position.AvgPrice)
+				} else if newQuantity == 0 {
+					// 전량 매도
+					position.AvgPrice = 0
+					position.TotalCost = 0
+				} else {
+					// 과매도 (숏포지션)
+					position.AvgPrice = price
+					position.TotalCost = int64(float64(newQuantity) * price)
+				}
+			} else {
+				// 기존 숏포지션에서 추가 매도 또는 신규 숏매도
+				if oldQuantity == 0 {
+					// 신규 숏매도
+					position.AvgPrice = price
+					position.TotalCost = int64(float64(newQuantity) * price)
+				} else {
+					// 기존 숏포지션에 추가
+					totalValue := float64(position.TotalCost) + float64(totalAmount)
+					position.AvgPrice = totalValue / float64(newQuantity)
+					position.TotalCost += totalAmount
+				}
+			}
+		}
+
+		position.Quantity = newQuantity
+		position.UpdatedAt = time.Now()
+
+		// 미실현 손익 계산 (현재 시장가 기준)
+		if newQuantity != 0 {
+			currentPrice := tp.getCurrentMarketPrice(milestoneID, optionID)
+			if currentPrice > 0 {
+				position.Unrealized = int64(float64(newQuantity) * (currentPrice - position.AvgPrice))
+			}
+		} else {
+			position.Unrealized = 0
+		}
+
+		err = tp.db.Omit("collateral").Save(&position).Error // 담보는 컬럼 표현식으로만 갱신
+		if err != nil {
+			log.Printf("❌ Failed to update position for user %d: %v", userID, err)
+		} else {
+			log.Printf("🔄 Updated position for user %d: %s %d@%.4f (realized: %d)",
+				userID, optionID, newQuantity, position.AvgPrice, position.Realized)
+		}
+	}
+}
+
+// getCurrentMarketPrice 현재 시장가 조회 (마지막 체결가, 없으면 호가 중간값)
+func (tp *tradePipeline) getCurrentMarketPrice(milestoneID uint, optionID string) float64 {
+	quote := tp.quote(milestoneID, optionID)
+	if quote.Last > 0 {
+		return quote.Last
+	}
+	if quote.Bid > 0 && quote.Ask > 0 {
+		return (quote.Bid + quote.Ask) / 2
+	}
+	return 0.33 // 기본값 (초기 확률) 33¢
+}
+
+// updateUserWallets 사용자 지갑 잔액 업데이트
+func (tp *tradePipeline) updateUserWallets(trades []models.Trade) {
+	for _, trade := range trades {
+		currency := trade.QuoteCurrency.OrUSDC()
+		if currency != models.LedgerCurrencyUSDC {
+			// USDC가 아닌 결제 통화 마켓은 그 통화 계정에서 정산
+			tp.updateAccountWallets(&trade, currency)
+		} else {
+			// 매수자 지갑 업데이트: 지정가 기준 잠금 해제 후 체결가 기준 금액 차감
+			tp.updateBuyerWallet(&trade)
+
+			// 매도자 지갑 업데이트: USDC 증가, LockedBalance 감소
+			tp.updateSellerWallet(trade.SellerID, trade.TotalAmount, trade.SellerFee)
+		}
+
+		publishWalletChanged(trade.BuyerID, "trade")
+		publishWalletChanged(trade.SellerID, "trade")
+	}
+}
+
+// updateAccountWallets 추가 결제 통화 체결의 매수/매도자 계정 정산
+// 잔액은 통화 계정에서 바꾸고, 지갑 통계에는 거래 횟수만 더한다 (누적 수수료는 USDC 단위 통계).
+func (tp *tradePipeline) updateAccountWallets(trade *models.Trade, currency models.LedgerCurrency) {
+	err := tp.db.Transaction(func(tx *gorm.DB) error {
+		if err := changeWalletBalance(tx, trade.BuyerID, currency, trade.BuyerRelease-trade.TotalAmount-trade.BuyerFee, -trade.BuyerRelease); err != nil {
+			return fmt.Errorf("매수자 %d: %w", trade.BuyerID, err)
+		}
+		if err := changeWalletBalance(tx, trade.SellerID, currency, trade.TotalAmount-trade.SellerFee, 0); err != nil {
+			return fmt.Errorf("매도자 %d: %w", trade.SellerID, err)
+		}
+		return tx.Model(&models.UserWallet{}).Where("user_id IN ?", []uint{trade.BuyerID, trade.SellerID}).
+			UpdateColumn("total_trades", gorm.Expr("total_trades + 1")).Error
+	})
+	if err != nil {
+		log.Printf("❌ Failed to settle %s trade wallets: %v", currency, err)
+		return
+	}
+	log.Printf("💰 Settled %d %s between buyer %d and seller %d (fees: %d/%d)",
+		trade.TotalAmount, currency, trade.BuyerID, trade.SellerID, trade.BuyerFee, trade.SellerFee)
+}
+
+// updateBuyerWallet 매수자 지갑 업데이트 (trade.BuyerRelease: 이번 체결로 풀리는 주문 잠금액, 수수료 잠금 포함)
+// 주문 접수/취소와 동시에 같은 지갑을 바꿀 수 있으므로 읽고 덮어쓰지 않고 컬럼 표현식으로 갱신
+//
+// 잠금액이 모자라면 다른 열린 주문의 잠금을 건드리지 않도록 정산하지 않고 대사 불일치로 기록한다.
+func (tp *tradePipeline) updateBuyerWallet(trade *models.Trade) {
+	buyerID, release := trade.BuyerID, trade.BuyerRelease
+
+	// 주문 잠금액을 풀어 체결 금액과 수수료를 지불 (가격 개선분과 남은 수수료 잠금은 일반 잔액으로 복귀)
+	result := tp.db.Model(&models.UserWallet{}).
+		Where("user_id = ? AND usdc_locked_balance >= ?", buyerID, release).
+		Updates(map[string]interface{}{
+			"usdc_locked_balance": gorm.Expr("usdc_locked_balance - ?", release),
+			"usdc_balance":        gorm.Expr("usdc_balance + ?", release-trade.TotalAmount-trade.BuyerFee),
+			"total_usdc_fees":     gorm.Expr("total_usdc_fees + ?", trade.BuyerFee),
+			"total_trades":        gorm.Expr("total_trades + 1"),
+		})
+
+	switch {
+	case result.Error != nil:
+		log.Printf("❌ Failed to update buyer wallet for user %d: %v", buyerID, result.Error)
+	case result.RowsAffected == 0:
+		var wallet models.UserWallet
+		if err := tp.db.Select("usdc_locked_balance").Where("user_id = ?", buyerID).First(&wallet).Error; err != nil {
+			log.Printf("❌ Failed to find buyer wallet for user %d: %v", buyerID, err)
+			return
+		}
+		log.Printf("❌ Insufficient locked balance for buyer %d on trade %d: needed=%d locked=%d, settlement held",
+			buyerID, trade.ID, release, wallet.USDCLockedBalance)
+		recordSettlementDiscrepancy(tp.db, models.ReconciliationDiscrepancy{
+			Check:       models.ReconcileBuyerSettlement,
+			UserID:      buyerID,
+			OrderID:     trade.BuyOrderID,
+			MilestoneID: trade.MilestoneID,
+			OptionID:    trade.OptionID,
+			Expected:    release,
+			Actual:      wallet.USDCLockedBalance,
+			Detail:      fmt.Sprintf("체결 %d 매수 정산 보류: 주문 잠금액 부족 (체결 %d, 수수료 %d)", trade.ID, trade.TotalAmount, trade.BuyerFee),
+		})
+	default:
+		log.Printf("💰 Updated buyer wallet for user %d: paid %d USDC (fee: %d)",
+			buyerID, trade.TotalAmount, trade.BuyerFee)
+	}
+}
+
+// updateSellerWallet 매도자 지갑 업데이트
+func (tp *tradePipeline) updateSellerWallet(sellerID uint, totalAmount, fee int64) {
+	// 매도 수익 추가 (수수료 제외)
+	netProceeds := totalAmount - fee
+	result := tp.db.Model(&models.UserWallet{}).
+		Where("user_id = ?", sellerID).
+		Updates(map[string]interface{}{
+			"usdc_balance":      gorm.Expr("usdc_balance + ?", netProceeds),
+			"total_usdc_profit": gorm.Expr("total_usdc_profit + ?", netProceeds),
+			"total_usdc_fees":   gorm.Expr("total_usdc_fees + ?", fee),
+			"total_trades":      gorm.Expr("total_trades + 1"),
+		})
+
+	switch {
+	case result.Error != nil:
+		log.Printf("❌ Failed to update seller wallet for user %d: %v", sellerID, result.Error)
+	case result.RowsAffected == 0:
+		log.Printf("❌ Failed to find seller wallet for user %d", sellerID)
+	default:
+		log.Printf("💰 Updated seller wallet for user %d: received %d USDC (fee: %d)",
+			sellerID, netProceeds, fee)
+	}
+}
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrTradingPaused 점검 모드/관리자 중단으로 신규 주문을 받지 않음 (취소는 허용)
+	ErrTradingPaused        = errors.New("점검으로 거래가 중단되었습니다")
+	ErrInvalidTradingPause  = errors.New("잘못된 거래 중단 요청입니다")
+	ErrTradingPauseNotFound = errors.New("적용 중이거나 예약된 거래 중단을 찾을 수 없습니다")
+)
+
+// TradingPauseConfig 설정으로 거는 중단 (서버 재시작 전까지 유지, 관리자 API로 해제 불가)
+type TradingPauseConfig struct {
+	Global       bool   // 전체 점검 모드
+	MilestoneIDs []uint // 중단할 마켓
+	Reason       string
+}
+
+// TradingPauseEvent SSE trading_paused / trading_resumed 이벤트
+type TradingPauseEvent struct {
+	PauseID     uint                     `json:"pause_id"`
+	Paused      bool                     `json:"paused"`
+	Scope       models.TradingPauseScope `json:"scope"`
+	MilestoneID *uint                    `json:"milestone_id,omitempty"`
+	Reason      string                   `json:"reason,omitempty"`
+	EndsAt      *time.Time               `json:"ends_at,omitempty"`
+}
+
+// TradingPauseService 점검 모드와 마켓별 거래 중단
+//
+// 중단은 trading_pauses에 저장되어 모든 서버 인스턴스가 공유한다. 적용 중이거나 예약된 중단을 메모리에
+// 두고 주문마다 시각으로 판단하므로 예약 점검은 정해진 시각에 바로 걸리고 풀린다. RunScheduler가
+// DB를 다시 읽어 다른 서버에서 건 중단을 반영하고 시작/종료를 SSE로 알린다.
+type TradingPauseService struct {
+	db         *gorm.DB
+	sseService *SSEService
+
+	mutex     sync.RWMutex
+	config    []models.TradingPause // 설정으로 건 중단 (ID 0)
+	pauses    []models.TradingPause // 적용 중이거나 예약된 중단
+	announced map[uint]models.TradingPause
+}
+
+// NewTradingPauseService 생성자
+func NewTradingPauseService(db *gorm.DB, sseService *SSEService) *TradingPauseService {
+	return &TradingPauseService{
+		db:         db,
+		sseService: sseService,
+		announced:  make(map[uint]models.TradingPause),
+	}
+}
+
+// Configure 설정으로 거는 중단 반영 (서버 설정 반영용)
+func (s *TradingPauseService) Configure(config TradingPauseConfig) {
+	var pauses []models.TradingPause
+	if config.Global {
+		pauses = append(pauses, models.TradingPause{Scope: models.TradingPauseGlobal, Reason: config.Reason})
+	}
+	for _, milestoneID := range config.MilestoneIDs {
+		id := milestoneID
+		pauses = append(pauses, models.TradingPause{Scope: models.TradingPauseMarket, MilestoneID: &id, Reason: config.Reason})
+	}
+
+	s.mutex.Lock()
+	s.config = pauses
+	s.mutex.Unlock()
+
+	if len(pauses) > 0 {
+		log.Printf("🚧 Trading paused by config (global=%t, markets=%v): %s", config.Global, config.MilestoneIDs, config.Reason)
+	}
+}
+
+// Check 신규 주문 접수 가능 여부 (전체 중단이 마켓 중단보다 우선)
+func (s *TradingPauseService) Check(milestoneID uint) error {
+	pause, paused := s.PausedFor(milestoneID, time.Now())
+	if !paused {
+		return nil
+	}
+
+	detail := fmt.Sprintf("마일스톤 %d", milestoneID)
+	if pause.Scope == models.TradingPauseGlobal {
+		detail = "전체 점검 모드"
+	}
+	if pause.Reason != "" {
+		detail += " (" + pause.Reason + ")"
+	}
+	if pause.EndsAt != nil {
+		detail += ", " + pause.EndsAt.UTC().Format(time.RFC3339) + " 이후 재개"
+	}
+	return fmt.Errorf("%w: %s", ErrTradingPaused, detail)
+}
+
+// PausedFor 해당 마켓에 적용 중인 중단
+func (s *TradingPauseService) PausedFor(milestoneID uint, now time.Time) (models.TradingPause, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var market *models.TradingPause
+	for _, list := range [][]models.TradingPause{s.config, s.pauses} {
+		for i := range list {
+			pause := &list[i]
+			if !pause.ActiveAt(now) {
+				continue
+			}
+			if pause.Scope == models.TradingPauseGlobal {
+				return *pause, true
+			}
+			if market == nil && pause.MilestoneID != nil && *pause.MilestoneID == milestoneID {
+				market = pause
+			}
+		}
+	}
+	if market != nil {
+		return *market, true
+	}
+	return models.TradingPause{}, false
+}
+
+// List 설정 중단과 적용 중/예약된 중단 (시작 시각 순)
+func (s *TradingPauseService) List() []models.TradingPause {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	pauses := make([]models.TradingPause, 0, len(s.config)+len(s.pauses))
+	pauses = append(pauses, s.config...)
+	return append(pauses, s.pauses...)
+}
+
+// Pause 전체 또는 마켓 거래 중단 (StartsAt이 미래면 예약 점검)
+func (s *TradingPauseService) Pause(req models.CreateTradingPauseRequest, adminID uint) (*models.TradingPause, error) {
+	now := time.Now()
+	pause := models.TradingPause{
+		Scope:     models.TradingPauseGlobal,
+		Reason:    req.Reason,
+		StartsAt:  now,
+		EndsAt:    req.EndsAt,
+		CreatedBy: &adminID,
+	}
+	if req.StartsAt != nil {
+		pause.StartsAt = *req.StartsAt
+	}
+	if pause.EndsAt != nil && (!pause.EndsAt.After(pause.StartsAt) || !pause.EndsAt.After(now)) {
+		return nil, fmt.Errorf("%w: 종료 시각은 시작 시각과 현재 이후여야 합니다", ErrInvalidTradingPause)
+	}
+
+	if req.MilestoneID != nil {
+		var count int64
+		if err := s.db.Model(&models.Milestone{}).Where("id = ?", *req.MilestoneID).Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("마일스톤 조회 실패: %w", err)
+		}
+		if count == 0 {
+			return nil, fmt.Errorf("%w: 마일스톤 %d를 찾을 수 없습니다", ErrInvalidTradingPause, *req.MilestoneID)
+		}
+		pause.Scope = models.TradingPauseMarket
+		pause.MilestoneID = req.MilestoneID
+	}
+
+	if err := s.db.Create(&pause).Error; err != nil {
+		return nil, fmt.Errorf("거래 중단 저장 실패: %w", err)
+	}
+	log.Printf("🚧 Trading pause %d (%s) scheduled by admin %d from %s: %s",
+		pause.ID, pause.Scope, adminID, pause.StartsAt.Format(time.RFC3339), pause.Reason)
+
+	if _, _, err := s.Sync(now); err != nil {
+		return nil, err
+	}
+	return &pause, nil
+}
+
+// Lift 적용 중이거나 예약된 중단 해제
+func (s *TradingPauseService) Lift(pauseID, adminID uint) error {
+	now := time.Now()
+	result := s.db.Model(&models.TradingPause{}).
+		Where("id = ? AND lifted_at IS NULL AND (ends_at IS NULL OR ends_at > ?)", pauseID, now).
+		Updates(map[string]interface{}{"lifted_at": now, "lifted_by": adminID})
+	if result.Error != nil {
+		return fmt.Errorf("거래 중단 해제 실패: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %d", ErrTradingPauseNotFound, pauseID)
+	}
+	log.Printf("▶️ Trading pause %d lifted by admin %d", pauseID, adminID)
+
+	_, _, err := s.Sync(now)
+	return err
+}
+
+// RunScheduler 주기적으로 중단 목록을 다시 읽고 예약 점검의 시작/종료를 알림 (서버 종료 시까지 실행)
+func (s *TradingPauseService) RunScheduler(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, _, err := s.Sync(time.Now()); err != nil {
+			log.Printf("❌ Trading pause sync failed: %v", err)
+		}
+	}
+}
+
+// Sync 적용 중/예약된 중단을 DB에서 다시 읽고, 새로 시작된 중단과 끝난 중단을 SSE로 알림
+func (s *TradingPauseService) Sync(now time.Time) (paused, resumed int, err error) {
+	var pauses []models.TradingPause
+	if err := s.db.Where("lifted_at IS NULL AND (ends_at IS NULL OR ends_at > ?)", now).
+		Order("starts_at ASC").Find(&pauses).Error; err != nil {
+		return 0, 0, fmt.Errorf("거래 중단 조회 실패: %w", err)
+	}
+
+	active := make(map[uint]models.TradingPause)
+	for _, pause := range pauses {
+		if pause.ActiveAt(now) {
+			active[pause.ID] = pause
+		}
+	}
+
+	s.mutex.Lock()
+	s.pauses = pauses
+	var started, ended []models.TradingPause
+	for id, pause := range active {
+		if _, ok := s.announced[id]; !ok {
+			started = append(started, pause)
+		}
+	}
+	for id, pause := range s.announced {
+		if _, ok := active[id]; !ok {
+			ended = append(ended, pause)
+		}
+	}
+	s.announced = active
+	s.mutex.Unlock()
+
+	sort.Slice(started, func(i, j int) bool { return started[i].ID < started[j].ID })
+	sort.Slice(ended, func(i, j int) bool { return ended[i].ID < ended[j].ID })
+	for _, pause := range started {
+		log.Printf("🚧 Trading pause %d (%s) started: %s", pause.ID, pause.Scope, pause.Reason)
+		s.broadcast(pause, true)
+	}
+	for _, pause := range ended {
+		log.Printf("▶️ Trading pause %d (%s) ended", pause.ID, pause.Scope)
+		s.broadcast(pause, false)
+	}
+	return len(started), len(ended), nil
+}
+
+func (s *TradingPauseService) broadcast(pause models.TradingPause, paused bool) {
+	if s.sseService == nil {
+		return
+	}
+	s.sseService.BroadcastTradingPause(TradingPauseEvent{
+		PauseID:     pause.ID,
+		Paused:      paused,
+		Scope:       pause.Scope,
+		MilestoneID: pause.MilestoneID,
+		Reason:      pause.Reason,
+		EndsAt:      pause.EndsAt,
+	})
+}
@@ -2,27 +2,42 @@ package services
 
 import (
 	"blueprint-module/pkg/models"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
 	"blueprint-module/pkg/queue"
+	"blueprint-module/pkg/redis"
 
 	"gorm.io/gorm"
 )
 
+// ErrMarketFrozen 거래 불가 상태(증거 제출 이후, 종료 등) 마일스톤에 대한 주문
+var ErrMarketFrozen = errors.New("거래가 중지된 마켓입니다")
+
+// ErrUnknownOption 마켓에 없는 결과 옵션에 대한 주문
+var ErrUnknownOption = errors.New("마켓에 없는 결과 옵션입니다")
+
 // TradingService P2P 거래 서비스 (매칭 엔진 기반)
 type TradingService struct {
 	db             *gorm.DB
+	readDB         *gorm.DB             // 조회 전용 (읽기 복제본, 기본값은 db)
+	queries        *TradingQueryHandler // CQRS 읽기 측 (readDB + Redis 뷰)
 	sseService     *SSEService
 	queuePublisher *queue.Publisher
-	matchingEngine *MatchingEngine
+	matchingEngine MatchingEngine
+	responsible    *ResponsibleTradingService // 사용자 일일 한도/휴식/자기 배제 (nil이면 검사 생략)
+	kyc            *KYCService                // 본인 인증 단계별 주문 한도 (nil이면 검사 생략)
+	risk           *PreTradeRiskService       // 사용자 노출/포지션/당일 손실 한도 (nil이면 검사 생략)
 }
 
 // NewTradingService 거래 서비스 생성자
-func NewTradingService(db *gorm.DB, sseService *SSEService, matchingEngine *MatchingEngine) *TradingService {
+func NewTradingService(db *gorm.DB, sseService *SSEService, matchingEngine MatchingEngine) *TradingService {
 	return &TradingService{
 		db:             db,
+		readDB:         db,
+		queries:        NewTradingQueryHandler(redis.GetClient(), db),
 		sseService:     sseService,
 		queuePublisher: queue.NewPublisher(),
 		matchingEngine: matchingEngine,
@@ -31,68 +46,114 @@ func NewTradingService(db *gorm.DB, sseService *SSEService, matchingEngine *Matc
 
 // CreateOrder 주문 생성 및 매칭 실행
 func (s *TradingService) CreateOrder(userID uint, req models.CreateOrderRequest, ipAddress, userAgent string) (*models.OrderResponse, error) {
-	tx := s.db.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
+	return s.createOrder(userID, req, ipAddress, userAgent, false)
+}
 
-	// 1. 매수 주문인 경우 지갑 잠금 처리
-	if req.Side == models.OrderSideBuy {
-		requiredUSDC := int64(float64(req.Quantity) * req.Price * 100) // 확률을 센트로 변환
+// createLiquidationOrder 증거금 부족 숏 포지션 강제 환매 주문 (위험을 줄이는 주문이라 책임 거래 한도/휴식은 검사하지 않음)
+func (s *TradingService) createLiquidationOrder(userID uint, req models.CreateOrderRequest) (*models.OrderResponse, error) {
+	return s.createOrder(userID, req, "", liquidationUserAgent, true)
+}
 
-		var wallet models.UserWallet
-		if err := tx.Where("user_id = ?", userID).First(&wallet).Error; err != nil {
-			tx.Rollback()
-			return nil, fmt.Errorf("지갑 조회 실패: %v", err)
-		}
+func (s *TradingService) createOrder(userID uint, req models.CreateOrderRequest, ipAddress, userAgent string, liquidation bool) (*models.OrderResponse, error) {
+	// 0. 마일스톤 상태 확인 (거래 가능 상태에서만 주문 접수)
+	var milestone models.Milestone
+	if err := s.db.Select("id", "status", "price_tick_size", "trading_halted_until", "market_type", "outcomes", "target_date", "trading_closes_at", "quote_currency").First(&milestone, req.MilestoneID).Error; err != nil {
+		return nil, fmt.Errorf("마일스톤을 찾을 수 없습니다: %v", err)
+	}
+	if !milestone.Status.IsTradable() {
+		return nil, ErrMarketFrozen
+	}
+	if milestone.IsTradingClosed(time.Now()) {
+		return nil, fmt.Errorf("%w: %s 마감", ErrMarketClosed, milestone.TradingCloseTime().UTC().Format(time.RFC3339))
+	}
+	if !milestone.HasOption(req.OptionID) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownOption, req.OptionID)
+	}
+	if err := s.matchingEngine.TradingPause().Check(milestone.ID); err != nil {
+		return nil, err
+	}
+	if err := s.matchingEngine.CircuitBreaker().Check(&milestone); err != nil {
+		return nil, err
+	}
 
-		// 잔액에서 잠긴 잔액으로 이동
-		if wallet.USDCBalance < requiredUSDC {
-			tx.Rollback()
-			return nil, fmt.Errorf("USDC 잔액 부족: 필요 $%.2f, 보유 $%.2f",
-				float64(requiredUSDC)/100, float64(wallet.USDCBalance)/100)
+	// 가격은 마켓 호가 단위의 정수 틱으로만 받음
+	priceTicks := models.PriceToTicks(req.Price)
+	if err := models.ValidatePriceTicks(priceTicks, milestone.PriceTickSize); err != nil {
+		return nil, err
+	}
+	if s.responsible != nil && !liquidation {
+		if err := s.responsible.CheckOrder(userID, models.ReserveCents(req.Quantity, priceTicks)); err != nil {
+			return nil, err
 		}
+	}
+	if s.kyc != nil && !liquidation {
+		if err := s.kyc.CheckOrderLimit(userID, models.ReserveCents(req.Quantity, priceTicks)); err != nil {
+			return nil, err
+		}
+	}
+	if s.risk != nil && !liquidation {
+		if err := s.risk.CheckOrder(userID, req); err != nil {
+			return nil, err
+		}
+	}
 
-		wallet.USDCBalance -= requiredUSDC
-		wallet.USDCLockedBalance += requiredUSDC
-
-		if err := tx.Save(&wallet).Error; err != nil {
-			tx.Rollback()
-			return nil, fmt.Errorf("지갑 업데이트 실패: %v", err)
+	// 1~2. 잠금과 주문을 먼저 커밋한 뒤 매칭 엔진에 제출 (엔진 write-behind가 커밋된 주문을 갱신하도록)
+	currency := milestone.QuoteCurrency.OrUSDC()
+	var order models.Order
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		// 1. 매수 주문인 경우 지정가 기준 주문 금액과 최대 수수료를 마켓 결제 통화로 잠금
+		if req.Side == models.OrderSideBuy {
+			required := models.BuyReserveCents(req.Quantity, priceTicks)
+			if err := lockFunds(tx, userID, currency, required); err != nil {
+				return err
+			}
+
+			log.Printf("🔒 Locked %d %s for user %d order", required, currency, userID)
 		}
 
-		log.Printf("🔒 Locked %d USDC for user %d order", requiredUSDC, userID)
-	}
+		// 1-1. 매도 주문 중 보유 수량을 넘는 숏 매도분은 최대 손실만큼 담보 잠금
+		var shortQuantity int64
+		if req.Side == models.OrderSideSell {
+			var err error
+			if shortQuantity, err = lockShortCollateral(tx, userID, req, priceTicks, currency); err != nil {
+				return err
+			}
+		}
 
-	// 2. 주문 생성
-	order := models.Order{
-		ProjectID:   req.ProjectID,
-		MilestoneID: req.MilestoneID,
-		OptionID:    req.OptionID,
-		UserID:      userID,
-		Type:        req.Type,
-		Side:        req.Side,
-		Quantity:    req.Quantity,
-		Price:       req.Price,
-		Remaining:   req.Quantity,
-		Status:      models.OrderStatusPending,
-		IPAddress:   ipAddress,
-		UserAgent:   userAgent,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		// 2. 주문 생성
+		order = models.Order{
+			ProjectID:     req.ProjectID,
+			MilestoneID:   req.MilestoneID,
+			OptionID:      req.OptionID,
+			UserID:        userID,
+			Type:          req.Type,
+			Side:          req.Side,
+			Quantity:      req.Quantity,
+			PriceTicks:    priceTicks,
+			Remaining:     req.Quantity,
+			ShortQuantity: shortQuantity,
+			QuoteCurrency: currency,
+			Status:        models.OrderStatusPending,
+			IPAddress:     ipAddress,
+			UserAgent:     userAgent,
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+		}
+		if err := tx.Create(&order).Error; err != nil {
+			return fmt.Errorf("failed to create order: %v", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
 	}
-
-	if err := tx.Create(&order).Error; err != nil {
-		tx.Rollback()
-		return nil, fmt.Errorf("failed to create order: %v", err)
+	if req.Side == models.OrderSideBuy || order.ShortQuantity > 0 {
+		publishWalletChanged(userID, "order_lock")
 	}
 
 	// 3. 고성능 매칭 엔진으로 매칭 실행
 	result, err := s.matchingEngine.SubmitOrder(&order)
 	if err != nil {
-		tx.Rollback()
+		s.withdrawOrder(&order)
 		return nil, fmt.Errorf("matching failed: %v", err)
 	}
 
@@ -101,23 +162,43 @@ func (s *TradingService) CreateOrder(userID uint, req models.CreateOrderRequest,
 	if result.Executed && len(result.Trades) > 0 {
 		trades = result.Trades
 
+		// 매칭 직후 상태 기록 (엔진의 write-behind가 이미 더 최신 체결을 반영했으면 덮어쓰지 않음)
+		if err := s.db.Model(&models.Order{}).Where("id = ? AND filled < ?", order.ID, result.Filled).Updates(map[string]interface{}{
+			"filled":    result.Filled,
+			"remaining": result.Remaining,
+			"status":    result.Status,
+		}).Error; err != nil {
+			log.Printf("⚠️ Failed to record matching state for order %d: %v", order.ID, err)
+		}
+
 		// 실시간 브로드캐스트는 매칭 엔진에서 처리됨
 		log.Printf("✅ Order %d executed with %d trades", order.ID, len(trades))
 	}
 
-	if err := tx.Commit().Error; err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %v", err)
-	}
-
+	response := order
+	response.Filled, response.Remaining, response.Status = result.Filled, result.Remaining, result.Status
 	return &models.OrderResponse{
-		Order:  order,
+		Order:  response,
 		Trades: trades,
 	}, nil
 }
 
-// GetOrderBook 호가창 조회 (매칭 엔진에서 직접 조회)
-func (s *TradingService) GetOrderBook(milestoneID uint, optionID string) (*models.OrderBook, error) {
-	return s.matchingEngine.GetOrderBook(milestoneID, optionID), nil
+// withdrawOrder 매칭에 실패한 주문을 엔진에서 제거한 뒤 취소하고 잠금 해제
+// 엔진에서 제거를 확인하지 못하면 주문이 주문장에 남아 있을 수 있으므로 열린 주문으로 두고 사용자가 다시 취소하게 한다
+func (s *TradingService) withdrawOrder(order *models.Order) {
+	if _, err := cancelOpenOrder(s.db, s.matchingEngine, order.UserID, order.ID); err != nil {
+		log.Printf("⚠️ Order %d left open after matching failure: %v", order.ID, err)
+		return
+	}
+	log.Printf("↩️ Order %d withdrawn after matching failure", order.ID)
+}
+
+// GetOrderBook 호가창 조회 (매칭 엔진에서 직접 조회, aggregation 0이면 가격별 그대로)
+func (s *TradingService) GetOrderBook(milestoneID uint, optionID string, depth int, aggregation float64) (*models.OrderBook, error) {
+	if err := ValidateOrderBookParams(depth, aggregation); err != nil {
+		return nil, err
+	}
+	return s.matchingEngine.GetOrderBook(milestoneID, optionID, depth, aggregation), nil
 }
 
 // GetMyOrders 내 주문 목록 조회
@@ -176,30 +257,48 @@ func (s *TradingService) GetPosition(userID uint, milestoneID uint, optionID str
 	return &position, err
 }
 
-// CancelOrder 주문 취소
-func (s *TradingService) CancelOrder(userID uint, orderID uint) error {
-	var order models.Order
-	err := s.db.Where("id = ? AND user_id = ?", orderID, userID).First(&order).Error
-	if err != nil {
-		return err
-	}
+// CancelOrder 주문 취소 후 해제된 매수 잠금액 반환 (이미 체결/취소된 주문은 ErrOrderNotOpen)
+func (s *TradingService) CancelOrder(userID uint, orderID uint) (*models.CancelOrderResponse, error) {
+	return cancelOpenOrder(s.db, s.matchingEngine, userID, orderID)
+}
 
-	if order.Status != models.OrderStatusPending && order.Status != models.OrderStatusPartial {
-		return fmt.Errorf("cannot cancel order with status: %s", order.Status)
+// SetResponsibleTrading 주문 접수 전에 사용자 일일 주문 금액 한도와 휴식/자기 배제 검사
+func (s *TradingService) SetResponsibleTrading(responsible *ResponsibleTradingService) {
+	s.responsible = responsible
+}
+
+// SetKYC 잔액 잠금과 매칭 엔진 제출 전에 본인 인증 단계별 주문 한도 검사 (API 주문과 복사 거래 주문 모두)
+func (s *TradingService) SetKYC(kyc *KYCService) {
+	s.kyc = kyc
+}
+
+// SetPreTradeRisk 잔액 잠금과 매칭 엔진 제출 전에 사용자 노출/포지션/당일 손실 한도 검사
+func (s *TradingService) SetPreTradeRisk(risk *PreTradeRiskService) {
+	s.risk = risk
+}
+
+// UseReadReplica 무거운 조회(최근 체결, 마켓 목록)를 읽기 복제본으로 보냄
+func (s *TradingService) UseReadReplica(readDB *gorm.DB) {
+	if readDB != nil {
+		s.readDB = readDB
+		s.queries = NewTradingQueryHandler(redis.GetClient(), readDB)
 	}
+}
 
-	// 🔧 매칭 엔진에서도 주문 제거 (메모리 리크 방지)
-	s.matchingEngine.CancelOrder(&order)
+// Queries 조회 전용 핸들러 (최근 체결, 가격 캔들 등)
+func (s *TradingService) Queries() *TradingQueryHandler {
+	return s.queries
+}
 
-	// 주문 상태 업데이트
-	order.Status = models.OrderStatusCancelled
-	return s.db.Save(&order).Error
+// ReadDB 조회 전용 DB (복제본이 없으면 기본 DB)
+func (s *TradingService) ReadDB() *gorm.DB {
+	return s.readDB
 }
 
-// GetRecentTrades 최근 거래 내역 조회
+// GetRecentTrades 최근 거래 내역 조회 (읽기 복제본)
 func (s *TradingService) GetRecentTrades(milestoneID uint, optionID string, limit int) ([]models.Trade, error) {
 	var trades []models.Trade
-	err := s.db.Where("milestone_id = ? AND option_id = ?", milestoneID, optionID).
+	err := s.readDB.Where("milestone_id = ? AND option_id = ?", milestoneID, optionID).
 		Order("created_at DESC").
 		Limit(limit).
 		Find(&trades).Error
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/redis"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// TrustScoreStaleKey 신뢰 점수를 다시 계산해야 하는 사용자 ID 집합 (스케줄러가 비움)
+	TrustScoreStaleKey = "trust_score:stale"
+
+	// trustScoreBatchSize 한 번의 재계산 주기에서 처리할 최대 사용자 수
+	trustScoreBatchSize = 500
+)
+
+// ErrTrustScoreTooLow 역할 참여에 필요한 신뢰 점수 미달
+var ErrTrustScoreTooLow = errors.New("신뢰 점수가 부족합니다")
+
+// TrustGate 신뢰 점수로 참여를 제한하는 역할
+type TrustGate string
+
+const (
+	TrustGateValidator TrustGate = "validator"
+	TrustGateJuror     TrustGate = "juror"
+	TrustGateMentor    TrustGate = "mentor"
+)
+
+// TrustScoreWeights 검증 신호별 가중치 (합계 대비 비율로 0~100점 환산)
+type TrustScoreWeights struct {
+	Email        int
+	Phone        int
+	LinkedIn     int
+	GitHub       int
+	Twitter      int
+	WorkEmail    int
+	Professional int
+	Education    int
+	KYC          int
+}
+
+func (w TrustScoreWeights) total() int {
+	return w.Email + w.Phone + w.LinkedIn + w.GitHub + w.Twitter + w.WorkEmail + w.Professional + w.Education + w.KYC
+}
+
+// TrustScorePolicy 가중치와 역할별 최소 점수
+type TrustScorePolicy struct {
+	Weights  TrustScoreWeights
+	Minimums map[TrustGate]int // 0이거나 없으면 제한 없음
+}
+
+// TrustScoreService 검증 신호를 합산한 사용자 신뢰 점수 계산/저장 및 역할 자격 확인
+type TrustScoreService struct {
+	db     *gorm.DB
+	policy TrustScorePolicy
+}
+
+// NewTrustScoreService 생성자
+func NewTrustScoreService(db *gorm.DB, policy TrustScorePolicy) *TrustScoreService {
+	return &TrustScoreService{
+		db:     db,
+		policy: policy,
+	}
+}
+
+// Compute 현재 검증 상태로 신뢰 점수 계산 (저장하지 않음)
+func (s *TrustScoreService) Compute(userID uint) (int, error) {
+	var verification models.UserVerification
+	if err := s.db.Where("user_id = ?", userID).First(&verification).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, fmt.Errorf("검증 정보 조회 실패: %w", err)
+	}
+
+	var approvedKYC int64
+	if err := s.db.Model(&models.KYCVerification{}).
+		Where("user_id = ? AND status = ?", userID, models.KYCStatusApproved).
+		Count(&approvedKYC).Error; err != nil {
+		return 0, fmt.Errorf("본인 인증 조회 실패: %w", err)
+	}
+
+	w := s.policy.Weights
+	total := w.total()
+	if total <= 0 {
+		return 0, nil
+	}
+
+	earned := 0
+	signals := []struct {
+		ok     bool
+		weight int
+	}{
+		{verification.EmailVerified, w.Email},
+		{verification.PhoneVerified, w.Phone},
+		{verification.LinkedInConnected, w.LinkedIn},
+		{verification.GitHubConnected, w.GitHub},
+		{verification.TwitterConnected, w.Twitter},
+		{verification.WorkEmailVerified, w.WorkEmail},
+		{verification.ProfessionalStatus == models.VerificationApproved, w.Professional},
+		{verification.EducationStatus == models.VerificationApproved, w.Education},
+		{approvedKYC > 0, w.KYC},
+	}
+	for _, signal := range signals {
+		if signal.ok {
+			earned += signal.weight
+		}
+	}
+	return (earned*100 + total/2) / total, nil
+}
+
+// Recalculate 신뢰 점수를 다시 계산해 사용자에 저장
+func (s *TrustScoreService) Recalculate(userID uint) (int, error) {
+	score, err := s.Compute(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.db.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"trust_score":            score,
+		"trust_score_updated_at": time.Now(),
+	}).Error; err != nil {
+		return 0, fmt.Errorf("신뢰 점수 저장 실패: %w", err)
+	}
+	return score, nil
+}
+
+// RecalculateChanged 재계산 요청된 사용자(trust_score:stale)와 마지막 계산 이후 검증 정보가 바뀐 사용자 재계산
+func (s *TrustScoreService) RecalculateChanged() (int, error) {
+	userIDs := make(map[uint]bool)
+
+	if client := redis.GetClient(); client != nil {
+		members, err := client.SPopN(context.Background(), TrustScoreStaleKey, trustScoreBatchSize).Result()
+		if err != nil {
+			log.Printf("⚠️ 신뢰 점수 재계산 요청 조회 실패: %v", err)
+		}
+		for _, member := range members {
+			if id, err := strconv.ParseUint(member, 10, 32); err == nil {
+				userIDs[uint(id)] = true
+			}
+		}
+	}
+
+	var changed []uint
+	if err := s.db.Table("user_verifications").
+		Joins("JOIN users ON users.id = user_verifications.user_id").
+		Where("users.deleted_at IS NULL").
+		Where("users.trust_score_updated_at IS NULL OR user_verifications.updated_at > users.trust_score_updated_at").
+		Limit(trustScoreBatchSize).
+		Pluck("user_verifications.user_id", &changed).Error; err != nil {
+		return 0, fmt.Errorf("변경된 검증 정보 조회 실패: %w", err)
+	}
+	for _, id := range changed {
+		userIDs[id] = true
+	}
+
+	updated := 0
+	for userID := range userIDs {
+		if _, err := s.Recalculate(userID); err != nil {
+			log.Printf("⚠️ 신뢰 점수 재계산 실패 (user %d): %v", userID, err)
+			MarkTrustScoreStale(userID) // 다음 주기에 다시 시도
+			continue
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+// MarkTrustScoreStale 검증 상태가 바뀐 사용자의 신뢰 점수 재계산 요청
+func MarkTrustScoreStale(userID uint) {
+	client := redis.GetClient()
+	if client == nil {
+		return
+	}
+	if err := client.SAdd(context.Background(), TrustScoreStaleKey, userID).Err(); err != nil {
+		log.Printf("⚠️ 신뢰 점수 재계산 요청 실패 (user %d): %v", userID, err)
+	}
+}
+
+// MinimumScore 역할 참여에 필요한 최소 점수 (서비스가 없으면 0)
+func (s *TrustScoreService) MinimumScore(gate TrustGate) int {
+	if s == nil {
+		return 0
+	}
+	return s.policy.Minimums[gate]
+}
+
+// CheckEligibility 저장된 신뢰 점수가 역할 최소 점수 이상인지 확인 (서비스가 없으면 통과)
+func (s *TrustScoreService) CheckEligibility(userID uint, gate TrustGate) error {
+	minimum := s.MinimumScore(gate)
+	if minimum <= 0 {
+		return nil
+	}
+
+	var user models.User
+	if err := s.db.Select("id", "trust_score").First(&user, userID).Error; err != nil {
+		return fmt.Errorf("사용자 조회 실패: %w", err)
+	}
+	if user.TrustScore < minimum {
+		return fmt.Errorf("%w: %s 참여에는 %d점 이상이 필요합니다 (현재 %d점)", ErrTrustScoreTooLow, gate, minimum, user.TrustScore)
+	}
+	return nil
+}
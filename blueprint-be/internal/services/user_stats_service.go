@@ -0,0 +1,247 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/redis"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// userStatsBatchSize 한 번의 증분 계산에서 처리할 최대 사용자 수
+const userStatsBatchSize = 500
+
+// ErrUserStatsNotComputed 아직 지표가 계산되지 않은 사용자
+var ErrUserStatsNotComputed = errors.New("사용자 지표가 아직 계산되지 않았습니다")
+
+// UserStatsService 프로필에 노출하는 사용자 거래/검증/멘토 지표 계산(UserStatsCache)
+type UserStatsService struct {
+	db *gorm.DB
+}
+
+// NewUserStatsService 생성자
+func NewUserStatsService(db *gorm.DB) *UserStatsService {
+	return &UserStatsService{db: db}
+}
+
+// Get 마지막으로 계산된 사용자 지표
+func (s *UserStatsService) Get(userID uint) (*models.UserStatsCache, error) {
+	var stats models.UserStatsCache
+	if err := s.db.First(&stats, "user_id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserStatsNotComputed
+		}
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// ComputeChanged 바뀐 사용자(stats:dirty:users)와 체결 이력이 있지만 아직 지표가 없는 사용자만 계산 (계산한 사용자 수 반환)
+//
+// 체결, 마켓 정산, 검증 통계 재계산이 사용자를 표시한다. 멘토 평점 등 나머지는 ComputeAll 전체 재계산이 맞춘다.
+func (s *UserStatsService) ComputeChanged(now time.Time) (int, error) {
+	userIDs := make(map[uint]bool)
+
+	members, err := redis.PopStatsDirty(redis.StatsScopeUsers, userStatsBatchSize)
+	if err != nil {
+		log.Printf("⚠️ 사용자 지표 재계산 대상 조회 실패: %v", err)
+	}
+	for _, member := range members {
+		if id, err := strconv.ParseUint(member, 10, 32); err == nil {
+			userIDs[uint(id)] = true
+		}
+	}
+
+	var unscored []uint
+	if err := s.db.Model(&models.UserWallet{}).
+		Joins("LEFT JOIN user_stats_caches ON user_stats_caches.user_id = user_wallets.user_id").
+		Where("user_wallets.total_trades > 0 AND user_stats_caches.user_id IS NULL").
+		Limit(userStatsBatchSize).
+		Pluck("user_wallets.user_id", &unscored).Error; err != nil {
+		return 0, fmt.Errorf("대상 사용자 조회 실패: %w", err)
+	}
+	for _, id := range unscored {
+		userIDs[id] = true
+	}
+
+	return s.computeUsers(userIDs, now), nil
+}
+
+// ComputeAll 체결/검증/멘토 이력이 있는 모든 사용자 재계산 (계산한 사용자 수 반환)
+func (s *UserStatsService) ComputeAll(now time.Time) (int, error) {
+	userIDs := make(map[uint]bool)
+	sources := []*gorm.DB{
+		s.db.Model(&models.Trade{}).Distinct().Select("buyer_id"),
+		s.db.Model(&models.Trade{}).Distinct().Select("seller_id"),
+		s.db.Model(&models.ValidatorQualification{}).Where("total_verifications > 0").Select("user_id"),
+		s.db.Model(&models.Mentor{}).Select("user_id"),
+	}
+	for _, query := range sources {
+		var ids []uint
+		if err := query.Scan(&ids).Error; err != nil {
+			return 0, fmt.Errorf("대상 사용자 조회 실패: %w", err)
+		}
+		for _, id := range ids {
+			userIDs[id] = true
+		}
+	}
+
+	return s.computeUsers(userIDs, now), nil
+}
+
+func (s *UserStatsService) computeUsers(userIDs map[uint]bool, now time.Time) int {
+	computed := 0
+	for userID := range userIDs {
+		if _, err := s.ComputeUser(userID, now); err != nil {
+			log.Printf("⚠️ Failed to compute stats for user %d: %v", userID, err)
+			MarkUserStatsDirty(userID) // 다음 주기에 다시 시도
+			continue
+		}
+		computed++
+	}
+	return computed
+}
+
+// ComputeUser 사용자 지표 계산 및 저장
+func (s *UserStatsService) ComputeUser(userID uint, now time.Time) (*models.UserStatsCache, error) {
+	stats := &models.UserStatsCache{UserID: userID, ComputedAt: now}
+
+	// 1. 거래량
+	var volume struct {
+		Trades int
+		Volume int64
+	}
+	if err := s.db.Model(&models.Trade{}).
+		Select("COUNT(*) AS trades, COALESCE(SUM(total_amount), 0) AS volume").
+		Where("buyer_id = ? OR seller_id = ?", userID, userID).
+		Scan(&volume).Error; err != nil {
+		return nil, fmt.Errorf("거래량 집계 실패: %w", err)
+	}
+	stats.TotalTrades, stats.TotalVolume = volume.Trades, volume.Volume
+
+	// 2. 승률 - 정산된 마켓의 포지션 중 실현 손익이 양수인 비율
+	var positions struct {
+		Settled int
+		Won     int
+	}
+	if err := s.db.Model(&models.Position{}).
+		Select("COUNT(*) AS settled, COALESCE(SUM(CASE WHEN positions.realized > 0 THEN 1 ELSE 0 END), 0) AS won").
+		Joins("JOIN milestones ON milestones.id = positions.milestone_id").
+		Where("positions.user_id = ? AND milestones.settled_at IS NOT NULL AND positions.realized != 0", userID).
+		Scan(&positions).Error; err != nil {
+		return nil, fmt.Errorf("정산 포지션 집계 실패: %w", err)
+	}
+	stats.SettledPositions = positions.Settled
+	stats.WinRate = ratio(positions.Won, positions.Settled)
+
+	// 3. 확정 마켓 적중률
+	resolved, correct, err := s.marketAccuracy(userID)
+	if err != nil {
+		return nil, err
+	}
+	stats.ResolvedMarkets = resolved
+	stats.MarketAccuracy = ratio(correct, resolved)
+
+	// 4. 검증 정확도 (검증 워커가 계산한 값)
+	var qualification models.ValidatorQualification
+	if err := s.db.Where("user_id = ?", userID).First(&qualification).Error; err == nil {
+		stats.ValidatorVotes = qualification.TotalVerifications
+		stats.ValidatorAccuracy = qualification.AccuracyRate
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("검증 자격 조회 실패: %w", err)
+	}
+
+	// 5. 멘토 평점
+	var ratings []float64
+	if err := s.db.Model(&models.Mentor{}).Where("user_id = ?", userID).Limit(1).Pluck("average_rating", &ratings).Error; err != nil {
+		return nil, fmt.Errorf("멘토 평점 조회 실패: %w", err)
+	}
+	if len(ratings) > 0 {
+		stats.MentorRating = ratings[0]
+	}
+
+	if err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		UpdateAll: true,
+	}).Create(stats).Error; err != nil {
+		return nil, fmt.Errorf("사용자 지표 저장 실패: %w", err)
+	}
+	return stats, nil
+}
+
+// marketAccuracy 결과가 확정된 마켓 중 가장 많이 순매수한 옵션이 절반 넘게 지급된 마켓 수
+//
+// 정산하면 포지션 수량이 0이 되므로 체결 내역으로 순매수 수량을 다시 계산한다.
+func (s *UserStatsService) marketAccuracy(userID uint) (resolved, correct int, err error) {
+	var nets []struct {
+		MilestoneID uint
+		OptionID    string
+		Net         int64
+	}
+	if err := s.db.Model(&models.Trade{}).
+		Select("trades.milestone_id, trades.option_id, SUM(CASE WHEN trades.buyer_id = ? THEN trades.quantity ELSE 0 END) - SUM(CASE WHEN trades.seller_id = ? THEN trades.quantity ELSE 0 END) AS net", userID, userID).
+		Joins("JOIN milestones ON milestones.id = trades.milestone_id").
+		Where("(trades.buyer_id = ? OR trades.seller_id = ?) AND milestones.settled_at IS NOT NULL", userID, userID).
+		Group("trades.milestone_id, trades.option_id").
+		Scan(&nets).Error; err != nil {
+		return 0, 0, fmt.Errorf("순매수 집계 실패: %w", err)
+	}
+
+	picks := make(map[uint]string)
+	largest := make(map[uint]int64)
+	for _, net := range nets {
+		if net.Net > largest[net.MilestoneID] {
+			largest[net.MilestoneID] = net.Net
+			picks[net.MilestoneID] = net.OptionID
+		}
+	}
+	if len(picks) == 0 {
+		return 0, 0, nil
+	}
+
+	milestoneIDs := make([]uint, 0, len(picks))
+	for id := range picks {
+		milestoneIDs = append(milestoneIDs, id)
+	}
+	var milestones []models.Milestone
+	if err := s.db.Where("id IN ?", milestoneIDs).Find(&milestones).Error; err != nil {
+		return 0, 0, fmt.Errorf("확정 마켓 조회 실패: %w", err)
+	}
+	for i := range milestones {
+		payouts, ok := milestones[i].SettlementTicks()
+		if !ok {
+			continue
+		}
+		resolved++
+		if payouts[picks[milestones[i].ID]]*2 > models.PriceScale {
+			correct++
+		}
+	}
+	return resolved, correct, nil
+}
+
+// MarkUserStatsDirty 사용자 지표 재계산 요청
+func MarkUserStatsDirty(userIDs ...uint) {
+	members := make([]interface{}, 0, len(userIDs))
+	for _, id := range userIDs {
+		if id != 0 {
+			members = append(members, id)
+		}
+	}
+	if err := redis.MarkStatsDirty(redis.StatsScopeUsers, members...); err != nil {
+		log.Printf("⚠️ 사용자 지표 재계산 요청 실패 (users %v): %v", userIDs, err)
+	}
+}
+
+func ratio(hits, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(hits) / float64(total)
+}
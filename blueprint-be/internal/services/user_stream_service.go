@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/redis"
+
+	"gorm.io/gorm"
+)
+
+// 사용자 비공개 스트림 이벤트 종류
+const (
+	UserEventOrderFill      = "order_fill"      // 내 주문 체결 (매수/매도 각각)
+	UserEventOrderCancelled = "order_cancelled" // 내 주문 취소/만료 (해제된 매수 잠금액 포함)
+	UserEventWallet         = "wallet"          // 지갑 잔액 스냅샷
+	UserEventNotification   = "notification"    // 인앱 알림 (검증 결과, 분쟁 진행, 본인 인증 등)
+
+	// userEventWalletChanged 발행 측 힌트 (트랜잭션 안에서도 발행), 스트림이 커밋된 잔액을 읽어 wallet으로 전달
+	userEventWalletChanged = "wallet_changed"
+)
+
+const (
+	userStreamBuffer    = 32                     // 연결별 대기 이벤트 (넘치면 버림, 클라이언트는 REST로 재동기화)
+	walletSnapshotDelay = 300 * time.Millisecond // 잔액 변경 힌트를 모아 커밋 이후 한 번만 조회
+)
+
+// UserEvent 사용자 비공개 스트림 이벤트
+type UserEvent struct {
+	Type      string      `json:"type"`
+	Data      interface{} `json:"data"`
+	Timestamp int64       `json:"timestamp"`
+}
+
+// UserStreamService 로그인 사용자별 비공개 SSE 스트림 (/stream/me)
+//
+// 이벤트는 발행한 인스턴스와 무관하게 Redis user_events:{id} 채널로 나가고, 각 API 인스턴스는
+// user_events:* 를 한 번 구독해 자기에게 연결된 사용자에게만 전달한다. 잔액 변경은 힌트만 발행되며
+// 스트림이 잠시 모았다가 커밋된 지갑을 읽어 스냅샷으로 보낸다.
+type UserStreamService struct {
+	db *gorm.DB
+
+	mu            sync.Mutex
+	subscribers   map[uint]map[chan []byte]struct{}
+	walletPending map[uint]bool
+}
+
+// NewUserStreamService 생성자
+func NewUserStreamService(db *gorm.DB) *UserStreamService {
+	return &UserStreamService{
+		db:            db,
+		subscribers:   make(map[uint]map[chan []byte]struct{}),
+		walletPending: make(map[uint]bool),
+	}
+}
+
+// Run Redis 사용자 이벤트 구독 후 연결된 사용자에게 전달 (Redis가 없으면 바로 종료)
+func (s *UserStreamService) Run() {
+	client := redis.GetClient()
+	if client == nil {
+		log.Printf("⚠️ Redis unavailable, user streams will only receive the connection event")
+		return
+	}
+
+	pubsub := client.PSubscribe(context.Background(), redis.UserEventsPattern)
+	defer pubsub.Close()
+
+	for msg := range pubsub.Channel() {
+		userID, err := strconv.ParseUint(strings.TrimPrefix(msg.Channel, "user_events:"), 10, 32)
+		if err != nil {
+			continue
+		}
+		s.route(uint(userID), []byte(msg.Payload))
+	}
+}
+
+// Subscribe 사용자 스트림 연결 (반환된 함수로 해제)
+func (s *UserStreamService) Subscribe(userID uint) (<-chan []byte, func()) {
+	ch := make(chan []byte, userStreamBuffer)
+
+	s.mu.Lock()
+	if s.subscribers[userID] == nil {
+		s.subscribers[userID] = make(map[chan []byte]struct{})
+	}
+	s.subscribers[userID][ch] = struct{}{}
+	s.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			delete(s.subscribers[userID], ch)
+			if len(s.subscribers[userID]) == 0 {
+				delete(s.subscribers, userID)
+			}
+			s.mu.Unlock()
+		})
+	}
+}
+
+// ConnectedUsers 이 인스턴스에 스트림이 연결된 사용자 수
+func (s *UserStreamService) ConnectedUsers() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subscribers)
+}
+
+// FormatUserEvent SSE data 프레임으로 직렬화
+func FormatUserEvent(eventType string, data interface{}) []byte {
+	payload, err := json.Marshal(UserEvent{Type: eventType, Data: data, Timestamp: time.Now().Unix()})
+	if err != nil {
+		log.Printf("Error marshaling user event: %v", err)
+		return []byte("data: {\"error\": \"Failed to format message\"}\n\n")
+	}
+	return []byte(fmt.Sprintf("data: %s\n\n", payload))
+}
+
+// route 구독 메시지를 이 인스턴스의 연결로 전달 (연결이 없으면 무시)
+func (s *UserStreamService) route(userID uint, payload []byte) {
+	if !s.connected(userID) {
+		return
+	}
+
+	var event struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return
+	}
+	if event.Type == userEventWalletChanged {
+		s.scheduleWalletSnapshot(userID)
+		return
+	}
+	s.deliver(userID, []byte(fmt.Sprintf("data: %s\n\n", payload)))
+}
+
+func (s *UserStreamService) connected(userID uint) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subscribers[userID]) > 0
+}
+
+// deliver 사용자 연결마다 전송 (대기열이 찬 연결은 이번 이벤트를 건너뜀)
+func (s *UserStreamService) deliver(userID uint, frame []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subscribers[userID] {
+		select {
+		case ch <- frame:
+		default:
+			log.Printf("⚠️ User stream buffer full for user %d, dropping event", userID)
+		}
+	}
+}
+
+// scheduleWalletSnapshot 잔액 변경 힌트를 모아 잠시 뒤 커밋된 지갑 잔액 전송
+func (s *UserStreamService) scheduleWalletSnapshot(userID uint) {
+	s.mu.Lock()
+	if s.walletPending[userID] {
+		s.mu.Unlock()
+		return
+	}
+	s.walletPending[userID] = true
+	s.mu.Unlock()
+
+	time.AfterFunc(walletSnapshotDelay, func() {
+		s.mu.Lock()
+		delete(s.walletPending, userID)
+		s.mu.Unlock()
+
+		var wallet models.UserWallet
+		if err := s.db.Select("usdc_balance", "usdc_locked_balance", "blueprint_balance", "blueprint_locked_balance").
+			Where("user_id = ?", userID).First(&wallet).Error; err != nil {
+			log.Printf("⚠️ Failed to load wallet snapshot for user %d: %v", userID, err)
+			return
+		}
+		s.deliver(userID, FormatUserEvent(UserEventWallet, map[string]interface{}{
+			"usdc_balance":             wallet.USDCBalance,
+			"usdc_locked_balance":      wallet.USDCLockedBalance,
+			"blueprint_balance":        wallet.BlueprintBalance,
+			"blueprint_locked_balance": wallet.BlueprintLockedBalance,
+		}))
+	})
+}
+
+// publishUserEvent 사용자 비공개 스트림으로 이벤트 발행 (Redis가 없으면 생략, 실패는 로그만)
+func publishUserEvent(userID uint, eventType string, data interface{}) {
+	if userID == 0 || redis.GetClient() == nil {
+		return
+	}
+	if err := redis.PublishUserEvent(userID, UserEvent{Type: eventType, Data: data, Timestamp: time.Now().Unix()}); err != nil {
+		log.Printf("⚠️ Failed to publish %s event to user %d: %v", eventType, userID, err)
+	}
+}
+
+// publishWalletChanged 지갑 잔액이 바뀌었음을 알림 (스트림이 잔액 스냅샷으로 바꿔 전달)
+func publishWalletChanged(userID uint, reason string) {
+	publishUserEvent(userID, userEventWalletChanged, map[string]string{"reason": reason})
+}
+
+// publishOrderClosed 주문 취소/만료 이벤트와 잠금 해제에 따른 잔액 변경 발행 (커밋 이후 호출)
+func publishOrderClosed(order *models.Order) {
+	publishUserEvent(order.UserID, UserEventOrderCancelled, map[string]interface{}{
+		"order_id":        order.ID,
+		"milestone_id":    order.MilestoneID,
+		"option_id":       order.OptionID,
+		"side":            order.Side,
+		"status":          order.Status,
+		"filled":          order.Filled,
+		"remaining":       order.Remaining,
+		"released_amount": order.LockedCents(),
+	})
+	if order.LockedCents() > 0 {
+		publishWalletChanged(order.UserID, "order_"+string(order.Status))
+	}
+}
+
+// publishOrderFill 체결 당사자 한쪽에 주문 체결 이벤트 발행
+func publishOrderFill(trade *models.Trade, userID, orderID uint, side models.OrderSide, fee int64) {
+	publishUserEvent(userID, UserEventOrderFill, map[string]interface{}{
+		"order_id":     orderID,
+		"milestone_id": trade.MilestoneID,
+		"option_id":    trade.OptionID,
+		"side":         side,
+		"quantity":     trade.Quantity,
+		"price":        trade.Price,
+		"total_amount": trade.TotalAmount,
+		"fee":          fee,
+		"executed_at":  trade.CreatedAt.Unix(),
+	})
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+const credentialDocURLTTL = 15 * time.Minute
+
+var (
+	ErrInvalidCredentialType    = errors.New("doc_type은 professional 또는 education이어야 합니다")
+	ErrCredentialNotPending     = errors.New("심사 대기 중인 서류가 아닙니다")
+	ErrCredentialNotScanned     = errors.New("보안 검사를 통과하지 않은 서류입니다")
+	ErrCredentialReasonRequired = errors.New("거절 사유를 입력해주세요")
+)
+
+// credentialLabels 서류 종류(컬럼 접두사)별 표시 이름
+var credentialLabels = map[string]string{
+	"professional": "전문 자격",
+	"education":    "학력",
+}
+
+// VerificationReviewService 전문 자격/학력 서류 운영자 심사 서비스
+type VerificationReviewService struct {
+	db                  *gorm.DB
+	fileService         *FileService
+	notificationService *NotificationService
+}
+
+// NewVerificationReviewService 생성자
+func NewVerificationReviewService(db *gorm.DB, fileService *FileService) *VerificationReviewService {
+	return &VerificationReviewService{
+		db:                  db,
+		fileService:         fileService,
+		notificationService: NewNotificationService(db),
+	}
+}
+
+// ListPending 심사 대기 서류 목록 (오래된 제출 순, 검사 통과한 서류만 서명 URL 포함)
+func (s *VerificationReviewService) ListPending(docType string, limit, offset int) ([]models.PendingVerificationDoc, int64, error) {
+	if _, ok := credentialLabels[docType]; !ok {
+		return nil, 0, ErrInvalidCredentialType
+	}
+
+	query := s.db.Model(&models.UserVerification{}).Where(docType+"_status = ?", models.VerificationPending)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("심사 대기 건수 조회 실패: %w", err)
+	}
+
+	var records []models.UserVerification
+	if err := query.Preload("User").Order("updated_at ASC").Limit(limit).Offset(offset).Find(&records).Error; err != nil {
+		return nil, 0, fmt.Errorf("심사 대기 목록 조회 실패: %w", err)
+	}
+
+	pending := make([]models.PendingVerificationDoc, 0, len(records))
+	for i := range records {
+		item := credentialDoc(&records[i], docType)
+		if item.ScanStatus == models.DocumentScanClean {
+			docPath, _ := credentialDocState(&records[i], docType)
+			item.DocumentURL, _ = s.fileService.SignedURL(docPath, credentialDocURLTTL)
+		}
+		pending = append(pending, item)
+	}
+	return pending, total, nil
+}
+
+// DocumentURL 검토자 서류 열람용 서명 URL (검사 통과한 서류만)
+func (s *VerificationReviewService) DocumentURL(userID uint, docType string) (string, error) {
+	if _, ok := credentialLabels[docType]; !ok {
+		return "", ErrInvalidCredentialType
+	}
+
+	var record models.UserVerification
+	if err := s.db.Where("user_id = ?", userID).First(&record).Error; err != nil {
+		return "", errors.New("검증 정보를 찾을 수 없습니다")
+	}
+
+	docPath, _ := credentialDocState(&record, docType)
+	if docPath == "" {
+		return "", errors.New("제출된 서류가 없습니다")
+	}
+	if credentialDoc(&record, docType).ScanStatus != models.DocumentScanClean {
+		return "", ErrCredentialNotScanned
+	}
+	return s.fileService.SignedURL(docPath, credentialDocURLTTL)
+}
+
+// Review 서류 승인/거절 + 사용자 알림 + 신뢰 점수 재계산 요청
+func (s *VerificationReviewService) Review(reviewerID, userID uint, docType string, req *models.ReviewVerificationDocRequest) (*models.UserVerification, error) {
+	label, ok := credentialLabels[docType]
+	if !ok {
+		return nil, ErrInvalidCredentialType
+	}
+	if !req.Approved && req.Reason == "" {
+		return nil, ErrCredentialReasonRequired
+	}
+
+	var record models.UserVerification
+	if err := s.db.Where("user_id = ?", userID).First(&record).Error; err != nil {
+		return nil, errors.New("검증 정보를 찾을 수 없습니다")
+	}
+
+	docPath, docStatus := credentialDocState(&record, docType)
+	if docStatus != models.VerificationPending {
+		return nil, ErrCredentialNotPending
+	}
+	item := credentialDoc(&record, docType)
+	// 검사 전이거나 감염/실패한 서류는 승인할 수 없음 (거절은 가능)
+	if req.Approved && item.ScanStatus != models.DocumentScanClean {
+		return nil, ErrCredentialNotScanned
+	}
+
+	now := time.Now()
+	status := models.VerificationApproved
+	updates := map[string]interface{}{
+		docType + "_reviewed_by":      reviewerID,
+		docType + "_reviewed_at":      now,
+		docType + "_rejection_reason": "",
+		docType + "_verified_at":      now,
+	}
+	if !req.Approved {
+		status = models.VerificationRejected
+		updates[docType+"_rejection_reason"] = req.Reason
+		updates[docType+"_verified_at"] = nil
+	}
+	updates[docType+"_status"] = status
+
+	// 검토 중 사용자가 서류를 다시 제출했으면 반영하지 않음
+	result := s.db.Model(&models.UserVerification{}).
+		Where("id = ? AND "+docType+"_status = ? AND "+docType+"_doc_path = ?", record.ID, models.VerificationPending, docPath).
+		Updates(updates)
+	if result.Error != nil {
+		return nil, fmt.Errorf("심사 결과 저장 실패: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrCredentialNotPending
+	}
+	if err := s.db.First(&record, record.ID).Error; err != nil {
+		return nil, err
+	}
+
+	title := label + " 인증이 완료되었습니다"
+	message := item.Claim + " 인증이 프로필에 표시됩니다"
+	if !req.Approved {
+		title = label + " 인증이 거절되었습니다"
+		message = req.Reason
+	}
+	if _, err := s.notificationService.Notify(models.CreateNotificationRequest{
+		UserID:   userID,
+		Type:     models.NotificationTypeVerification,
+		Priority: models.NotificationPriorityNormal,
+		Title:    title,
+		Message:  message,
+		Link:     "/settings",
+	}); err != nil {
+		log.Printf("⚠️ 서류 심사 결과 알림 실패 (user %d): %v", userID, err)
+	}
+
+	MarkTrustScoreStale(userID)
+	recordActivity(models.ActivityVerificationReviewed, userID, title, models.ActivityMetadata{ResourceID: docType})
+
+	log.Printf("🎓 %s verification %s for user %d by reviewer %d", docType, status, userID, reviewerID)
+	return &record, nil
+}
+
+// credentialDoc 서류 종류별 심사 정보
+func credentialDoc(record *models.UserVerification, docType string) models.PendingVerificationDoc {
+	item := models.PendingVerificationDoc{
+		UserID:      record.UserID,
+		Username:    record.User.Username,
+		DocType:     docType,
+		SubmittedAt: record.UpdatedAt,
+	}
+	if docType == "education" {
+		item.Claim = record.EducationDegree
+		item.ScanStatus = record.EducationDocScanStatus
+		item.ScanDetail = record.EducationDocScanDetail
+	} else {
+		item.Claim = record.ProfessionalTitle
+		item.ScanStatus = record.ProfessionalDocScanStatus
+		item.ScanDetail = record.ProfessionalDocScanDetail
+	}
+	return item
+}
+
+// credentialDocState 서류 종류별 저장 경로와 심사 상태
+func credentialDocState(record *models.UserVerification, docType string) (string, models.VerificationStatus) {
+	if docType == "education" {
+		return record.EducationDocPath, record.EducationStatus
+	}
+	return record.ProfessionalDocPath, record.ProfessionalStatus
+}
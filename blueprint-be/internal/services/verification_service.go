@@ -3,28 +3,65 @@ package services
 import (
 	"errors"
 	"fmt"
+	"log"
 	"math"
 	"mime/multipart"
 	"time"
 
 	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/queue"
 	"gorm.io/gorm"
 )
 
+// ProofPrescreenQueue AI 사전 검토 작업 큐 (워커가 소비)
+const ProofPrescreenQueue = "proof_prescreen_queue"
+
+// FileProcessingQueue 업로드 파일 처리 작업 큐 (워커가 소비)
+const FileProcessingQueue = "file_processing_queue"
+
+// ValidatorStatsQueue 검증 완료 후 검증인 정확도/평판 재계산 작업 큐 (워커가 소비)
+const ValidatorStatsQueue = "validator_stats_queue"
+
+// ProofFileCategory 증거 파일 저장 카테고리
+const ProofFileCategory = "proofs"
+
+// ErrProofFileNotFound 제출한 file_url이 이 서버에 업로드된 증거 파일이 아님
+var ErrProofFileNotFound = errors.New("업로드한 증거 파일을 찾을 수 없습니다")
+
 // VerificationService 마일스톤 증명 및 검증 서비스
 type VerificationService struct {
-	db          *gorm.DB
-	fileService *FileService // 파일 업로드 서비스
+	db                  *gorm.DB
+	fileService         *FileService         // 파일 업로드 서비스
+	notificationService *NotificationService // 검증인 알림
+	watchlistService    *WatchlistService    // 프로젝트 팔로워 피드
+	stateMachine        *MilestoneStateMachine
+	members             *ProjectMemberService // 증거 제출 권한 (owner/editor), 검증 이해충돌 (팀원 전체)
+	trustScores         *TrustScoreService   // 검증 참여 신뢰 점수 확인 (nil이면 제한 없음)
+	mediation           *MediationService    // 분쟁 제기 시 조정 개시 (nil이면 조정 없이 분쟁 상태 유지)
 }
 
 // NewVerificationService 생성자
 func NewVerificationService(db *gorm.DB, fileService *FileService) *VerificationService {
 	return &VerificationService{
-		db:          db,
-		fileService: fileService,
+		db:                  db,
+		fileService:         fileService,
+		notificationService: NewNotificationService(db),
+		watchlistService:    NewWatchlistService(db),
+		stateMachine:        NewMilestoneStateMachine(db),
+		members:             NewProjectMemberService(db),
 	}
 }
 
+// SetTrustScores 검증 참여에 신뢰 점수 최소 기준 적용
+func (s *VerificationService) SetTrustScores(trustScores *TrustScoreService) {
+	s.trustScores = trustScores
+}
+
+// SetMediation 증거 분쟁을 배심원 중재 전 당사자 조정으로 먼저 보냄
+func (s *VerificationService) SetMediation(mediation *MediationService) {
+	s.mediation = mediation
+}
+
 // UploadFile 파일 업로드 (FileService 래퍼)
 func (s *VerificationService) UploadFile(file multipart.File, header *multipart.FileHeader, category string) (string, error) {
 	return s.fileService.UploadFile(file, header, category)
@@ -38,14 +75,14 @@ func (s *VerificationService) SubmitProof(req *models.SubmitProofRequest, userID
 		return nil, fmt.Errorf("마일스톤을 찾을 수 없습니다: %w", err)
 	}
 
-	// 2. 증거 제출 권한 확인 (프로젝트 소유자인지 확인)
-	var project models.Project
-	if err := s.db.First(&project, milestone.ProjectID).Error; err != nil {
+	// 2. 증거 제출 권한 확인 (프로젝트 작성자 또는 editor 팀원)
+	_, role, err := s.members.RoleOf(milestone.ProjectID, userID)
+	if err != nil {
 		return nil, fmt.Errorf("프로젝트를 찾을 수 없습니다: %w", err)
 	}
 
-	if project.UserID != userID {
-		return nil, errors.New("마일스톤 증거는 프로젝트 소유자만 제출할 수 있습니다")
+	if !role.CanEdit() {
+		return nil, fmt.Errorf("%w: 마일스톤 증거는 프로젝트 작성자와 editor 팀원만 제출할 수 있습니다", ErrProjectForbidden)
 	}
 
 	// 3. 마일스톤 상태 확인
@@ -59,7 +96,17 @@ func (s *VerificationService) SubmitProof(req *models.SubmitProofRequest, userID
 		return nil, errors.New("이미 증거가 제출되었습니다")
 	}
 
-	// 5. 증거 생성
+	// 5. 업로드 파일 확인 (이 서버가 발급한 증거 파일 URL만 허용)
+	var proofFile *StoredFile
+	if req.FileURL != "" {
+		stored, err := s.fileService.LookupFileURL(req.FileURL)
+		if err != nil || stored.Category != ProofFileCategory {
+			return nil, ErrProofFileNotFound
+		}
+		proofFile = stored
+	}
+
+	// 6. 증거 생성
 	proof := &models.MilestoneProof{
 		MilestoneID:    req.MilestoneID,
 		UserID:         userID,
@@ -73,26 +120,97 @@ func (s *VerificationService) SubmitProof(req *models.SubmitProofRequest, userID
 		SubmittedAt:    time.Now(),
 		ReviewDeadline: time.Now().Add(72 * time.Hour), // 72시간 후
 	}
+	if proofFile != nil {
+		proof.FileURL = req.FileURL
+		proof.FileStatus = models.ProofFileStatusPending
+		proof.FileContentType = proofFile.ContentType
+	}
 
-	// 6. 데이터베이스에 저장
+	// 7. 데이터베이스에 저장
 	if err := s.db.Create(proof).Error; err != nil {
 		return nil, fmt.Errorf("증거 저장 실패: %w", err)
 	}
 
-	// 7. 마일스톤 상태 업데이트
-	milestone.Status = models.MilestoneStatusProofSubmitted
-	if err := s.db.Save(&milestone).Error; err != nil {
-		return nil, fmt.Errorf("마일스톤 상태 업데이트 실패: %w", err)
+	// 8. 마일스톤 상태 업데이트
+	if err := s.stateMachine.Apply(&milestone, models.MilestoneStatusProofSubmitted, TransitionOptions{
+		Reason:  "증거 제출",
+		ActorID: &userID,
+	}); err != nil {
+		return nil, err
 	}
 
-	// 8. 검증 프로세스 시작
+	// 9. 검증 프로세스 시작
 	if err := s.StartVerificationProcess(proof.ID); err != nil {
 		return nil, fmt.Errorf("검증 프로세스 시작 실패: %w", err)
 	}
 
+	// 10. 파일 처리(형식 확인/악성코드 검사/미리보기) 후 AI 사전 검토, 파일이 없으면 바로 사전 검토
+	if proofFile != nil {
+		s.requestFileProcessing(proof)
+	} else {
+		s.requestPrescreen(proof)
+	}
+
+	// 11. 포지션 보유자들에게 증거 제출 알림
+	go s.notifyPositionHolders(&milestone, proof)
+
+	// 12. 프로젝트 팔로워 피드에 전파
+	go s.watchlistService.PublishProofSubmitted(&milestone, proof)
+
 	return proof, nil
 }
 
+// requestPrescreen 증거 AI 사전 검토 작업 발행 (실패해도 검증은 그대로 진행)
+func (s *VerificationService) requestPrescreen(proof *models.MilestoneProof) {
+	job := map[string]interface{}{
+		"type":      "prescreen_proof",
+		"proof_id":  proof.ID,
+		"timestamp": time.Now().Unix(),
+	}
+	if err := queue.PublishJob(ProofPrescreenQueue, job); err != nil {
+		log.Printf("⚠️ 증거 사전 검토 요청 실패 (proof %d): %v", proof.ID, err)
+	}
+}
+
+// requestFileProcessing 증거 파일 처리 작업 발행 (워커가 처리를 마치면 사전 검토를 이어서 요청)
+func (s *VerificationService) requestFileProcessing(proof *models.MilestoneProof) {
+	job := map[string]interface{}{
+		"type":      "process_proof_file",
+		"proof_id":  proof.ID,
+		"timestamp": time.Now().Unix(),
+	}
+	if err := queue.PublishJob(FileProcessingQueue, job); err != nil {
+		log.Printf("⚠️ 증거 파일 처리 요청 실패 (proof %d): %v", proof.ID, err)
+		s.requestPrescreen(proof)
+	}
+}
+
+// notifyPositionHolders 마일스톤 포지션 보유자들에게 증거 제출 알림
+func (s *VerificationService) notifyPositionHolders(milestone *models.Milestone, proof *models.MilestoneProof) {
+	var holderIDs []uint
+	if err := s.db.Model(&models.Position{}).
+		Where("milestone_id = ? AND quantity > 0 AND user_id != ?", milestone.ID, proof.UserID).
+		Distinct().Pluck("user_id", &holderIDs).Error; err != nil {
+		return
+	}
+
+	s.notificationService.NotifyMany(holderIDs, models.CreateNotificationRequest{
+		Type:    models.NotificationTypeProofSubmitted,
+		Title:   "마일스톤 증거가 제출되었습니다",
+		Message: fmt.Sprintf("포지션을 보유한 '%s' 마일스톤의 완료 증거가 제출되어 검증이 시작되었습니다.", milestone.Title),
+		Link:    fmt.Sprintf("/milestones/%d", milestone.ID),
+		Data: map[string]interface{}{
+			"proof_id":     proof.ID,
+			"milestone_id": milestone.ID,
+		},
+	})
+}
+
+// GetMilestoneStatusHistory 마일스톤 상태 전환 이력
+func (s *VerificationService) GetMilestoneStatusHistory(milestoneID uint) ([]models.MilestoneStatusHistory, error) {
+	return s.stateMachine.GetHistory(milestoneID)
+}
+
 // StartVerificationProcess 검증 프로세스 시작
 func (s *VerificationService) StartVerificationProcess(proofID uint) error {
 	// 1. 증거 조회
@@ -117,18 +235,50 @@ func (s *VerificationService) StartVerificationProcess(proofID uint) error {
 		return fmt.Errorf("검증 프로세스 생성 실패: %w", err)
 	}
 
+	// 검증인 대기열에 노출
+	if err := s.db.Model(&proof).Update("status", models.ProofStatusUnderReview).Error; err != nil {
+		return fmt.Errorf("증거 상태 업데이트 실패: %w", err)
+	}
+
 	// 3. 마일스톤 상태 업데이트
+	if err := s.stateMachine.Apply(&proof.Milestone, models.MilestoneStatusUnderVerification, TransitionOptions{
+		Reason: "검증 시작",
+	}); err != nil {
+		return err
+	}
 	proof.Milestone.StartVerificationProcess()
-	if err := s.db.Save(&proof.Milestone).Error; err != nil {
-		return fmt.Errorf("마일스톤 상태 업데이트 실패: %w", err)
+	if err := s.db.Model(&proof.Milestone).
+		UpdateColumn("verification_deadline", proof.Milestone.VerificationDeadline).Error; err != nil {
+		return fmt.Errorf("검증 마감일 설정 실패: %w", err)
 	}
 
-	// 4. 검증인들에게 알림 발송 (향후 구현)
-	// TODO: 검증인들에게 이메일/푸시 알림 발송
+	// 4. 검증인들에게 알림 발송
+	s.notifyValidators(&proof, verification)
 
 	return nil
 }
 
+// notifyValidators 검증 가능한 검증인들에게 새 검증 요청 알림
+func (s *VerificationService) notifyValidators(proof *models.MilestoneProof, verification *models.MilestoneVerification) {
+	var validatorIDs []uint
+	if err := s.db.Model(&models.ValidatorQualification{}).
+		Where("is_suspended = ? AND user_id != ?", false, proof.UserID).
+		Pluck("user_id", &validatorIDs).Error; err != nil {
+		return
+	}
+
+	s.notificationService.NotifyMany(validatorIDs, models.CreateNotificationRequest{
+		Type:    models.NotificationTypeValidatorAlert,
+		Title:   "새로운 마일스톤 검증 요청",
+		Message: fmt.Sprintf("'%s' 마일스톤의 증거가 제출되었습니다. %s까지 검증에 참여해주세요.", proof.Milestone.Title, verification.ReviewDeadline.Format("2006-01-02 15:04")),
+		Link:    fmt.Sprintf("/proofs/%d/verification", proof.ID),
+		Data: map[string]interface{}{
+			"proof_id":     proof.ID,
+			"milestone_id": proof.MilestoneID,
+		},
+	})
+}
+
 // ValidateProof 증거 검증 (검증인 투표)
 func (s *VerificationService) ValidateProof(req *models.ValidateProofRequest, validatorID uint) (*models.ProofValidator, error) {
 	// 1. 증거 조회
@@ -217,13 +367,15 @@ func (s *VerificationService) CanUserValidate(userID, milestoneID uint) (bool, *
 		if qualification.SuspendedUntil != nil && time.Now().Before(*qualification.SuspendedUntil) {
 			return false, nil, errors.New("계정이 제재 중입니다")
 		}
-		// 제재 기간이 만료된 경우 제재 해제
+		// 제재 기간이 만료된 경우 제재 해제 (SuspendedUntil은 이전 투표를 다시 제재 근거로 쓰지 않도록 유지)
 		qualification.IsSuspended = false
-		qualification.SuspendedUntil = nil
 		s.db.Save(&qualification)
 	}
 
 	// 3. 최소 자격 요건 확인
+	if err := s.trustScores.CheckEligibility(userID, TrustGateValidator); err != nil {
+		return false, nil, err
+	}
 	minStake := int64(1000) // 최소 1000 BLUEPRINT 스테이킹
 	if qualification.StakedAmount < minStake {
 		return false, nil, errors.New("검증에 필요한 최소 스테이킹 양이 부족합니다")
@@ -235,9 +387,13 @@ func (s *VerificationService) CanUserValidate(userID, milestoneID uint) (bool, *
 		return false, nil, fmt.Errorf("마일스톤 조회 실패: %w", err)
 	}
 
-	// 프로젝트 소유자는 자신의 마일스톤을 검증할 수 없음
-	if milestone.Project.UserID == userID {
-		return false, nil, errors.New("자신의 프로젝트는 검증할 수 없습니다")
+	// 프로젝트 작성자와 팀원은 자신의 마일스톤을 검증할 수 없음
+	_, role, err := s.members.RoleOf(milestone.ProjectID, userID)
+	if err != nil {
+		return false, nil, fmt.Errorf("프로젝트 조회 실패: %w", err)
+	}
+	if role != "" {
+		return false, nil, errors.New("자신이 참여한 프로젝트는 검증할 수 없습니다")
 	}
 
 	return true, &qualification, nil
@@ -379,8 +535,10 @@ func (s *VerificationService) CheckVerificationCompletion(proofID uint) error {
 
 // CompleteVerification 검증 완료 처리
 func (s *VerificationService) CompleteVerification(proofID uint, approved bool) error {
+	var transition *MilestoneTransition
+
 	// 트랜잭션 시작
-	return s.db.Transaction(func(tx *gorm.DB) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
 		// 1. 검증 정보 조회
 		var verification models.MilestoneVerification
 		if err := tx.Preload("Milestone").Preload("Proof").First(&verification, "proof_id = ?", proofID).Error; err != nil {
@@ -413,7 +571,18 @@ func (s *VerificationService) CompleteVerification(proofID uint, approved bool)
 			return fmt.Errorf("증거 상태 업데이트 실패: %w", err)
 		}
 
-		// 4. 마일스톤 완료 처리
+		// 4. 마일스톤 판정 (분쟁 기간 후 완료/실패 확정)
+		nextStatus := models.MilestoneStatusProofApproved
+		if !approved {
+			nextStatus = models.MilestoneStatusProofRejected
+		}
+		var err error
+		transition, err = s.stateMachine.Transition(tx, &verification.Milestone, nextStatus, TransitionOptions{
+			Reason: "검증 완료: " + verification.FinalResult,
+		})
+		if err != nil {
+			return err
+		}
 		verification.Milestone.CompleteVerification(approved)
 		if err := tx.Save(&verification.Milestone).Error; err != nil {
 			return fmt.Errorf("마일스톤 상태 업데이트 실패: %w", err)
@@ -424,13 +593,33 @@ func (s *VerificationService) CompleteVerification(proofID uint, approved bool)
 			return fmt.Errorf("검증인 보상 지급 실패: %w", err)
 		}
 
-		// 6. 베팅 정산 (승인된 경우)
-		if approved {
-			// TODO: 베팅 정산 로직 구현
-		}
+		// 6. 베팅 정산
+		// TODO: 판정 확정(completed/failed) 시 베팅 정산 로직 구현
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	// 7. 상태 진입 훅 (알림, 팔로워 피드) 실행 (커밋 이후)
+	s.stateMachine.Dispatch(transition)
+
+	// 8. 참여 검증인 정확도/합의율/평판 재계산 요청
+	s.requestValidatorStats(proofID)
+	return nil
+}
+
+// requestValidatorStats 검증인 통계 재계산 작업 발행 (실패해도 검증 결과에는 영향 없음)
+func (s *VerificationService) requestValidatorStats(proofID uint) {
+	job := map[string]interface{}{
+		"type":      "recalculate_validators",
+		"proof_id":  proofID,
+		"timestamp": time.Now().Unix(),
+	}
+	if err := queue.PublishJob(ValidatorStatsQueue, job); err != nil {
+		log.Printf("⚠️ 검증인 통계 재계산 요청 실패 (proof %d): %v", proofID, err)
+	}
 }
 
 // DistributeValidatorRewards 검증인 보상 지급
@@ -512,6 +701,8 @@ func (s *VerificationService) DisputeProof(req *models.DisputeProofRequest, disp
 
 	// 트랜잭션 시작
 	var dispute *models.ProofDispute
+	var transition *MilestoneTransition
+	var mediation *models.Mediation
 	err = s.db.Transaction(func(tx *gorm.DB) error {
 		// 4. BLUEPRINT 스테이킹 (잠금)
 		userWallet.BlueprintBalance -= req.StakeAmount
@@ -547,17 +738,29 @@ func (s *VerificationService) DisputeProof(req *models.DisputeProofRequest, disp
 			return fmt.Errorf("마일스톤 조회 실패: %w", err)
 		}
 
-		milestone.SetDisputed()
-		if err := tx.Save(&milestone).Error; err != nil {
-			return fmt.Errorf("마일스톤 상태 업데이트 실패: %w", err)
+		var err error
+		transition, err = s.stateMachine.Transition(tx, &milestone, models.MilestoneStatusDisputed, TransitionOptions{
+			Reason:  "분쟁 제기: " + req.Title,
+			ActorID: &disputerID,
+		})
+		if err != nil {
+			return err
 		}
 
-		return nil
+		// 7. 조정 개시 (결렬될 때만 배심원 중재로 이관)
+		if s.mediation != nil && proof.UserID != disputerID {
+			mediation, err = s.mediation.openForProofDispute(tx, dispute, &proof)
+		}
+		return err
 	})
 	
 	if err != nil {
 		return nil, err
 	}
+	s.stateMachine.Dispatch(transition)
+	if mediation != nil {
+		s.mediation.notifyOpened(mediation)
+	}
 	
 	return dispute, nil
 }
@@ -628,10 +831,13 @@ func (s *VerificationService) GetValidatorDashboard(userID uint) (*models.Valida
 		}
 	}
 
-	// 2. 대기 중인 증거 목록 조회
+	// 2. 대기 중인 증거 목록 조회 (AI 사전 검토 신뢰도 높은 순, 미검토는 뒤로, 같은 점수면 마감 임박 순)
 	var pendingProofs []models.MilestoneProof
 	s.db.Preload("Milestone").Preload("User").
 		Where("status = ? AND review_deadline > ?", models.ProofStatusUnderReview, time.Now()).
+		Order("CASE WHEN prescreen_score IS NULL THEN 1 ELSE 0 END").
+		Order("prescreen_score DESC").
+		Order("review_deadline ASC").
 		Find(&pendingProofs)
 
 	// 3. 최근 투표 내역 조회
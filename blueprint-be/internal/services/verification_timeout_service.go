@@ -0,0 +1,203 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// VerificationTimeoutOutcome 검증 마감 처리 결과
+type VerificationTimeoutOutcome string
+
+const (
+	VerificationTimeoutApproved  VerificationTimeoutOutcome = "approved"  // 승인 기준 충족 → 승인
+	VerificationTimeoutRejected  VerificationTimeoutOutcome = "rejected"  // 정족수 충족, 승인 기준 미달 → 거절
+	VerificationTimeoutEscalated VerificationTimeoutOutcome = "escalated" // 결론 없음 → 배심원 중재
+	VerificationTimeoutPending   VerificationTimeoutOutcome = ""          // 아직 자동 완료 시각 전
+)
+
+// VerificationTimeoutService 검증 마감(ReviewDeadline)과 자동 완료 시각(AutoCompleteAfter) 집행
+//
+//   - 검토 마감 후 정족수(MinimumVotes)를 채운 검증은 투표 결과대로 완료
+//   - 자동 완료 시각까지 정족수를 못 채우면 투표가 승인 기준을 넘었을 때만 승인, 아니면 배심원 중재로 이관
+type VerificationTimeoutService struct {
+	db                  *gorm.DB
+	verificationService *VerificationService
+	arbitrationService  *ArbitrationService
+	stateMachine        *MilestoneStateMachine
+	notificationService *NotificationService
+}
+
+// NewVerificationTimeoutService 생성자
+func NewVerificationTimeoutService(db *gorm.DB, verificationService *VerificationService, arbitrationService *ArbitrationService) *VerificationTimeoutService {
+	return &VerificationTimeoutService{
+		db:                  db,
+		verificationService: verificationService,
+		arbitrationService:  arbitrationService,
+		stateMachine:        NewMilestoneStateMachine(db),
+		notificationService: NewNotificationService(db),
+	}
+}
+
+// ProcessExpired 검토 마감이 지난 진행 중 검증 처리 (처리한 건수 반환)
+func (s *VerificationTimeoutService) ProcessExpired(now time.Time) (int, error) {
+	var verifications []models.MilestoneVerification
+	if err := s.db.Preload("Milestone").Preload("Proof").
+		Joins("JOIN milestones ON milestones.id = milestone_verifications.milestone_id").
+		Where("milestone_verifications.status = ? AND milestone_verifications.review_deadline <= ? AND milestones.status = ?",
+			models.MilestoneVerificationStatusActive, now, models.MilestoneStatusUnderVerification).
+		Find(&verifications).Error; err != nil {
+		return 0, fmt.Errorf("만료된 검증 조회 실패: %w", err)
+	}
+
+	processed := 0
+	for i := range verifications {
+		outcome, err := s.processVerification(&verifications[i], now)
+		if err != nil {
+			log.Printf("⚠️ Failed to process expired verification for proof %d: %v", verifications[i].ProofID, err)
+			continue
+		}
+		if outcome == VerificationTimeoutPending {
+			continue
+		}
+		processed++
+		s.notifyStakeholders(&verifications[i], outcome)
+	}
+	return processed, nil
+}
+
+// processVerification 검증 정책에 따라 완료 또는 중재 이관
+func (s *VerificationTimeoutService) processVerification(verification *models.MilestoneVerification, now time.Time) (VerificationTimeoutOutcome, error) {
+	milestone := &verification.Milestone
+	thresholdMet := milestone.TotalValidators > 0 && milestone.HasReachedApprovalThreshold()
+
+	switch {
+	case milestone.TotalValidators > 0 && milestone.TotalValidators >= verification.MinimumVotes:
+		if err := s.complete(verification, thresholdMet); err != nil {
+			return VerificationTimeoutPending, err
+		}
+		if thresholdMet {
+			return VerificationTimeoutApproved, nil
+		}
+		return VerificationTimeoutRejected, nil
+	case now.Before(verification.AutoCompleteAfter):
+		return VerificationTimeoutPending, nil
+	case thresholdMet:
+		if err := s.complete(verification, true); err != nil {
+			return VerificationTimeoutPending, err
+		}
+		return VerificationTimeoutApproved, nil
+	default:
+		if err := s.escalate(verification); err != nil {
+			return VerificationTimeoutPending, err
+		}
+		return VerificationTimeoutEscalated, nil
+	}
+}
+
+// complete 일반 검증 완료 처리를 거친 뒤 자동 완료로 표시
+func (s *VerificationTimeoutService) complete(verification *models.MilestoneVerification, approved bool) error {
+	if err := s.verificationService.CompleteVerification(verification.ProofID, approved); err != nil {
+		return err
+	}
+	return s.db.Model(&models.MilestoneVerification{}).
+		Where("id = ?", verification.ID).
+		Update("auto_completed", true).Error
+}
+
+// escalate 검증 만료 처리 후 마일스톤을 분쟁 상태로 전환하고 배심원 중재 사건 생성
+func (s *VerificationTimeoutService) escalate(verification *models.MilestoneVerification) error {
+	ownerID, err := s.projectOwner(verification.Milestone.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	var transition *MilestoneTransition
+	var arbitrationCase *models.ArbitrationCase
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.MilestoneVerification{}).
+			Where("id = ? AND status = ?", verification.ID, models.MilestoneVerificationStatusActive).
+			Updates(map[string]interface{}{
+				"status":         models.MilestoneVerificationStatusExpired,
+				"auto_completed": true,
+				"completed_at":   time.Now(),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("검증 만료 처리 실패: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrMilestoneTransitionConflict
+		}
+
+		if err := tx.Model(&verification.Proof).Update("status", models.ProofStatusDisputed).Error; err != nil {
+			return fmt.Errorf("증거 상태 업데이트 실패: %w", err)
+		}
+
+		reason := fmt.Sprintf("검증 기간 만료: 투표 %d/%d건, 승인률 %.0f%%로 결론을 내지 못해 배심원 중재로 이관",
+			verification.Milestone.TotalValidators, verification.MinimumVotes, verification.Milestone.CurrentApprovalRate*100)
+		var err error
+		transition, err = s.stateMachine.Transition(tx, &verification.Milestone, models.MilestoneStatusDisputed, TransitionOptions{Reason: reason})
+		if err != nil {
+			return err
+		}
+
+		arbitrationCase, err = s.arbitrationService.OpenVerificationEscalation(tx, &verification.Milestone, &verification.Proof, ownerID, reason)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.stateMachine.Dispatch(transition)
+	// 이미 백그라운드 작업이므로 바로 배심원단 구성 (실패하면 구성 기한 경과 후 arbitration_phase_timers 주기 작업이 처리)
+	s.arbitrationService.startJurySelection(arbitrationCase.ID)
+	return nil
+}
+
+// notifyStakeholders 프로젝트 소유자와 투표한 검증인에게 처리 결과 알림 (포지션 보유자는 상태 전환 훅이 알림)
+func (s *VerificationTimeoutService) notifyStakeholders(verification *models.MilestoneVerification, outcome VerificationTimeoutOutcome) {
+	var recipients []uint
+	if err := s.db.Model(&models.ProofValidator{}).
+		Where("proof_id = ?", verification.ProofID).
+		Pluck("user_id", &recipients).Error; err != nil {
+		log.Printf("⚠️ 검증인 조회 실패 (proof %d): %v", verification.ProofID, err)
+	}
+	if ownerID, err := s.projectOwner(verification.Milestone.ProjectID); err == nil {
+		recipients = append(recipients, ownerID)
+	}
+
+	title := verification.Milestone.Title
+	var message string
+	switch outcome {
+	case VerificationTimeoutApproved:
+		message = fmt.Sprintf("'%s' 마일스톤 검증 기간이 끝나 투표 결과에 따라 승인되었습니다.", title)
+	case VerificationTimeoutRejected:
+		message = fmt.Sprintf("'%s' 마일스톤 검증 기간이 끝나 투표 결과에 따라 거절되었습니다.", title)
+	case VerificationTimeoutEscalated:
+		message = fmt.Sprintf("'%s' 마일스톤 검증 기간 내 결론이 나지 않아 배심원 중재로 이관되었습니다.", title)
+	}
+
+	s.notificationService.NotifyMany(uniqueIDs(recipients), models.CreateNotificationRequest{
+		Type:    models.NotificationTypeMilestone,
+		Title:   "마일스톤 검증 기간 종료",
+		Message: message,
+		Link:    fmt.Sprintf("/proofs/%d/verification", verification.ProofID),
+		Data: map[string]interface{}{
+			"proof_id":     verification.ProofID,
+			"milestone_id": verification.MilestoneID,
+			"outcome":      string(outcome),
+		},
+	})
+}
+
+func (s *VerificationTimeoutService) projectOwner(projectID uint) (uint, error) {
+	var ownerID uint
+	if err := s.db.Model(&models.Project{}).Where("id = ?", projectID).Pluck("user_id", &ownerID).Error; err != nil {
+		return 0, fmt.Errorf("프로젝트 조회 실패: %w", err)
+	}
+	return ownerID, nil
+}
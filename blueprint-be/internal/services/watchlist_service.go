@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/queue"
+	"blueprint-module/pkg/redis"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// ProjectFeedQueue 프로젝트 이벤트 큐 (워커가 팔로워 알림함으로 전파)
+	ProjectFeedQueue = "feed_queue"
+
+	// MarketMoveThreshold 팔로워에게 알리는 가격 변동 비율 (기준가 대비 초과 시)
+	MarketMoveThreshold = 0.10
+
+	marketMoveRefTTL   = 7 * 24 * time.Hour
+	marketMoveCooldown = 30 * time.Minute // 같은 마켓 변동 알림 최소 간격
+)
+
+var ErrProjectNotWatchable = errors.New("팔로우할 수 없는 프로젝트입니다")
+
+// WatchlistService 프로젝트 팔로우(관심 목록) 및 이벤트 피드 서비스
+type WatchlistService struct {
+	db *gorm.DB
+}
+
+// NewWatchlistService 생성자
+func NewWatchlistService(db *gorm.DB) *WatchlistService {
+	return &WatchlistService{
+		db: db,
+	}
+}
+
+// Watch 프로젝트 팔로우 (이미 팔로우 중이면 그대로 성공)
+func (s *WatchlistService) Watch(userID, projectID uint) (*models.ProjectWatchStatus, error) {
+	var project models.Project
+	if err := s.db.First(&project, projectID).Error; err != nil {
+		return nil, errors.New("프로젝트를 찾을 수 없습니다")
+	}
+	if !project.IsPublic && project.UserID != userID {
+		return nil, ErrProjectNotWatchable
+	}
+
+	watch := models.ProjectWatch{UserID: userID, ProjectID: projectID}
+	if err := s.db.Where("user_id = ? AND project_id = ?", userID, projectID).
+		FirstOrCreate(&watch).Error; err != nil {
+		return nil, fmt.Errorf("프로젝트 팔로우 실패: %w", err)
+	}
+
+	return s.GetWatchStatus(userID, projectID)
+}
+
+// Unwatch 프로젝트 팔로우 해제
+func (s *WatchlistService) Unwatch(userID, projectID uint) (*models.ProjectWatchStatus, error) {
+	if err := s.db.Where("user_id = ? AND project_id = ?", userID, projectID).
+		Delete(&models.ProjectWatch{}).Error; err != nil {
+		return nil, fmt.Errorf("프로젝트 팔로우 해제 실패: %w", err)
+	}
+
+	return s.GetWatchStatus(userID, projectID)
+}
+
+// GetWatchStatus 팔로우 여부 및 팔로워 수
+func (s *WatchlistService) GetWatchStatus(userID, projectID uint) (*models.ProjectWatchStatus, error) {
+	status := &models.ProjectWatchStatus{ProjectID: projectID}
+
+	if err := s.db.Model(&models.ProjectWatch{}).
+		Where("project_id = ?", projectID).
+		Count(&status.FollowerCount).Error; err != nil {
+		return nil, err
+	}
+
+	var mine int64
+	s.db.Model(&models.ProjectWatch{}).
+		Where("user_id = ? AND project_id = ?", userID, projectID).
+		Count(&mine)
+	status.Watching = mine > 0
+
+	return status, nil
+}
+
+// GetWatchlist 내가 팔로우한 프로젝트 목록 (최근 팔로우 순)
+func (s *WatchlistService) GetWatchlist(userID uint) (*models.WatchlistResponse, error) {
+	var watches []models.ProjectWatch
+	if err := s.db.Preload("Project").Preload("Project.Milestones").
+		Joins("JOIN projects ON projects.id = project_watches.project_id AND projects.deleted_at IS NULL").
+		Where("project_watches.user_id = ?", userID).
+		Order("project_watches.created_at DESC").
+		Find(&watches).Error; err != nil {
+		return nil, fmt.Errorf("관심 목록 조회 실패: %w", err)
+	}
+
+	return &models.WatchlistResponse{
+		Watches: watches,
+		Total:   len(watches),
+	}, nil
+}
+
+// PublishProjectEvent 프로젝트 이벤트를 피드 큐에 발행 (팔로워 전파는 워커 담당)
+func (s *WatchlistService) PublishProjectEvent(event models.ProjectEvent) {
+	job := map[string]interface{}{
+		"type":         "project_event",
+		"event":        string(event.Type),
+		"project_id":   event.ProjectID,
+		"milestone_id": event.MilestoneID,
+		"actor_id":     event.ActorID,
+		"title":        event.Title,
+		"message":      event.Message,
+		"link":         event.Link,
+		"data":         event.Data,
+		"timestamp":    time.Now().Unix(),
+	}
+
+	if err := queue.PublishJob(ProjectFeedQueue, job); err != nil {
+		log.Printf("⚠️ 프로젝트 이벤트 큐 전송 실패 (project %d, %s): %v", event.ProjectID, event.Type, err)
+	}
+}
+
+// PublishProofSubmitted 마일스톤 증거 제출 이벤트
+func (s *WatchlistService) PublishProofSubmitted(milestone *models.Milestone, proof *models.MilestoneProof) {
+	s.PublishProjectEvent(models.ProjectEvent{
+		Type:        models.ProjectEventProofSubmitted,
+		ProjectID:   milestone.ProjectID,
+		MilestoneID: milestone.ID,
+		ActorID:     proof.UserID,
+		Title:       "팔로우한 프로젝트에 증거가 제출되었습니다",
+		Message:     fmt.Sprintf("'%s' 마일스톤의 완료 증거가 제출되어 검증이 시작되었습니다.", milestone.Title),
+		Link:        fmt.Sprintf("/milestones/%d", milestone.ID),
+		Data: map[string]interface{}{
+			"proof_id": proof.ID,
+		},
+	})
+}
+
+// PublishMilestoneCompleted 마일스톤 완료 이벤트
+func (s *WatchlistService) PublishMilestoneCompleted(milestone *models.Milestone) {
+	s.PublishProjectEvent(models.ProjectEvent{
+		Type:        models.ProjectEventMilestoneCompleted,
+		ProjectID:   milestone.ProjectID,
+		MilestoneID: milestone.ID,
+		Title:       "팔로우한 프로젝트의 마일스톤이 완료되었습니다",
+		Message:     fmt.Sprintf("'%s' 마일스톤이 검증을 통과해 완료되었습니다.", milestone.Title),
+		Link:        fmt.Sprintf("/milestones/%d", milestone.ID),
+	})
+}
+
+// CheckMarketMove 체결가가 마지막 알림 기준가 대비 MarketMoveThreshold 이상 움직이면 이벤트 발행
+//
+// 기준가는 Redis에 마켓별로 보관하며, 알림을 보낸 시점의 가격으로 갱신한다.
+// Redis가 없으면 변동 알림은 생략된다.
+func (s *WatchlistService) CheckMarketMove(milestoneID uint, optionID string, price float64) {
+	client := redis.GetClient()
+	if client == nil || price <= 0 {
+		return
+	}
+
+	ctx := context.Background()
+	refKey := fmt.Sprintf("feed_market_ref:%d:%s", milestoneID, optionID)
+
+	refPrice, err := client.Get(ctx, refKey).Float64()
+	if err != nil || refPrice <= 0 {
+		client.Set(ctx, refKey, price, marketMoveRefTTL)
+		return
+	}
+
+	change := (price - refPrice) / refPrice
+	if math.Abs(change) <= MarketMoveThreshold {
+		return
+	}
+
+	cooldownKey := fmt.Sprintf("feed_market_cooldown:%d:%s", milestoneID, optionID)
+	if ok, err := client.SetNX(ctx, cooldownKey, 1, marketMoveCooldown).Result(); err != nil || !ok {
+		return
+	}
+	client.Set(ctx, refKey, price, marketMoveRefTTL)
+
+	var milestone models.Milestone
+	if err := s.db.Select("id", "project_id", "title").First(&milestone, milestoneID).Error; err != nil {
+		return
+	}
+
+	direction := "상승"
+	if change < 0 {
+		direction = "하락"
+	}
+	s.PublishProjectEvent(models.ProjectEvent{
+		Type:        models.ProjectEventMarketMoved,
+		ProjectID:   milestone.ProjectID,
+		MilestoneID: milestone.ID,
+		Title:       fmt.Sprintf("'%s' 마켓 가격이 크게 %s했습니다", milestone.Title, direction),
+		Message: fmt.Sprintf("%s 가격이 %.2f → %.2f (%+.1f%%)로 움직였습니다.",
+			optionID, refPrice, price, change*100),
+		Link: fmt.Sprintf("/milestones/%d", milestone.ID),
+		Data: map[string]interface{}{
+			"option_id":      optionID,
+			"previous_price": refPrice,
+			"price":          price,
+			"change_percent": math.Round(change*1000) / 10,
+		},
+	})
+}
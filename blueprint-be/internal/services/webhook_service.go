@@ -0,0 +1,419 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/queue"
+	"blueprint-module/pkg/secrets"
+
+	"gorm.io/gorm"
+)
+
+// WebhookQueue 외부 웹훅 이벤트/발송 작업 큐 (워커가 구독 주소별로 서명 후 발송)
+const WebhookQueue = "webhook_queue"
+
+const maxWebhookEndpoints = 10 // 사용자당 최대 웹훅 개수
+
+var (
+	ErrWebhookEndpointNotFound = errors.New("웹훅을 찾을 수 없습니다")
+	ErrWebhookLimitExceeded    = fmt.Errorf("웹훅은 최대 %d개까지 등록할 수 있습니다", maxWebhookEndpoints)
+	ErrWebhookInvalidEvent     = errors.New("지원하지 않는 이벤트입니다")
+	ErrWebhookInvalidURL       = errors.New("웹훅 URL은 http(s) 주소여야 합니다")
+	ErrWebhookNotProjectOwner  = errors.New("프로젝트 소유자만 프로젝트 웹훅을 등록할 수 있습니다")
+)
+
+// WebhookService 외부 웹훅 구독 관리 서비스
+//
+// 이벤트 발행은 WebhookPublisher가, 구독 매칭/서명/재시도는 워커가 담당한다.
+type WebhookService struct {
+	db            *gorm.DB
+	encryptionKey []byte // 서명 secret 암호화용 (AES-256-GCM)
+}
+
+// NewWebhookService 생성자
+func NewWebhookService(db *gorm.DB, encryptionSecret string) *WebhookService {
+	return &WebhookService{
+		db:            db,
+		encryptionKey: secrets.DeriveKey(encryptionSecret),
+	}
+}
+
+// CreateEndpoint 웹훅 등록 (서명 secret은 응답으로 한 번만 반환)
+func (s *WebhookService) CreateEndpoint(userID uint, req *models.CreateWebhookEndpointRequest) (*models.WebhookEndpointSecretResponse, error) {
+	if err := validateWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+	events, err := normalizeWebhookEvents(req.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ProjectID != nil {
+		var project models.Project
+		if err := s.db.First(&project, *req.ProjectID).Error; err != nil {
+			return nil, fmt.Errorf("프로젝트를 찾을 수 없습니다: %w", err)
+		}
+		if project.UserID != userID {
+			return nil, ErrWebhookNotProjectOwner
+		}
+	}
+
+	var count int64
+	s.db.Model(&models.WebhookEndpoint{}).Where("user_id = ?", userID).Count(&count)
+	if count >= maxWebhookEndpoints {
+		return nil, ErrWebhookLimitExceeded
+	}
+
+	secret, encrypted, err := s.newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := &models.WebhookEndpoint{
+		UserID:          userID,
+		ProjectID:       req.ProjectID,
+		URL:             req.URL,
+		Description:     req.Description,
+		Events:          events,
+		EncryptedSecret: encrypted,
+		Active:          true,
+	}
+	if err := s.db.Create(endpoint).Error; err != nil {
+		return nil, fmt.Errorf("웹훅 저장 실패: %w", err)
+	}
+
+	return &models.WebhookEndpointSecretResponse{Endpoint: endpoint, Secret: secret}, nil
+}
+
+// ListEndpoints 내 웹훅 목록
+func (s *WebhookService) ListEndpoints(userID uint) ([]models.WebhookEndpoint, error) {
+	var endpoints []models.WebhookEndpoint
+	if err := s.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&endpoints).Error; err != nil {
+		return nil, fmt.Errorf("웹훅 조회 실패: %w", err)
+	}
+	return endpoints, nil
+}
+
+// UpdateEndpoint 웹훅 수정 (재활성화 시 연속 실패 횟수 초기화)
+func (s *WebhookService) UpdateEndpoint(userID, endpointID uint, req *models.UpdateWebhookEndpointRequest) (*models.WebhookEndpoint, error) {
+	endpoint, err := s.getEndpoint(userID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{}
+	if req.URL != nil {
+		if err := validateWebhookURL(*req.URL); err != nil {
+			return nil, err
+		}
+		updates["url"] = *req.URL
+	}
+	if len(req.Events) > 0 {
+		events, err := normalizeWebhookEvents(req.Events)
+		if err != nil {
+			return nil, err
+		}
+		updates["events"] = events
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.Active != nil {
+		updates["active"] = *req.Active
+		if *req.Active {
+			updates["consecutive_failures"] = 0
+			updates["disabled_at"] = nil
+		}
+	}
+	if len(updates) == 0 {
+		return endpoint, nil
+	}
+
+	if err := s.db.Model(endpoint).Updates(updates).Error; err != nil {
+		return nil, fmt.Errorf("웹훅 수정 실패: %w", err)
+	}
+	return s.getEndpoint(userID, endpointID)
+}
+
+// DeleteEndpoint 웹훅 삭제 (발송 기록도 함께 삭제)
+func (s *WebhookService) DeleteEndpoint(userID, endpointID uint) error {
+	endpoint, err := s.getEndpoint(userID, endpointID)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("endpoint_id = ?", endpoint.ID).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(endpoint).Error
+	})
+}
+
+// RotateSecret 서명 secret 재발급 (이전 secret은 즉시 무효)
+func (s *WebhookService) RotateSecret(userID, endpointID uint) (*models.WebhookEndpointSecretResponse, error) {
+	endpoint, err := s.getEndpoint(userID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, encrypted, err := s.newSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.Model(endpoint).Update("encrypted_secret", encrypted).Error; err != nil {
+		return nil, fmt.Errorf("secret 재발급 실패: %w", err)
+	}
+
+	return &models.WebhookEndpointSecretResponse{Endpoint: endpoint, Secret: secret}, nil
+}
+
+// GetDeliveries 웹훅 발송 기록 (최신순)
+func (s *WebhookService) GetDeliveries(userID, endpointID uint, limit, offset int) ([]models.WebhookDelivery, int64, error) {
+	if _, err := s.getEndpoint(userID, endpointID); err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	query := s.db.Model(&models.WebhookDelivery{}).Where("endpoint_id = ?", endpointID)
+	query.Count(&total)
+
+	var deliveries []models.WebhookDelivery
+	if err := query.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&deliveries).Error; err != nil {
+		return nil, 0, fmt.Errorf("발송 기록 조회 실패: %w", err)
+	}
+	return deliveries, total, nil
+}
+
+// SendTestEvent ping 이벤트 발송 요청
+func (s *WebhookService) SendTestEvent(userID, endpointID uint) (*models.WebhookDelivery, error) {
+	endpoint, err := s.getEndpoint(userID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	eventID, err := newWebhookEventID()
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(models.WebhookPayload{
+		ID:        eventID,
+		Type:      models.WebhookEventPing,
+		CreatedAt: time.Now().UTC(),
+		Data:      map[string]interface{}{"endpoint_id": endpoint.ID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &models.WebhookDelivery{
+		EndpointID: endpoint.ID,
+		EventID:    eventID,
+		EventType:  models.WebhookEventPing,
+		Payload:    string(payload),
+		Status:     models.WebhookDeliveryPending,
+	}
+	if err := s.db.Create(delivery).Error; err != nil {
+		return nil, fmt.Errorf("발송 기록 저장 실패: %w", err)
+	}
+
+	if err := enqueueWebhookDelivery(delivery.ID); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// Redeliver 발송 기록을 같은 이벤트 ID로 다시 발송
+func (s *WebhookService) Redeliver(userID, deliveryID uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := s.db.First(&delivery, deliveryID).Error; err != nil {
+		return nil, ErrWebhookEndpointNotFound
+	}
+	if _, err := s.getEndpoint(userID, delivery.EndpointID); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Model(&delivery).Updates(map[string]interface{}{
+		"status":        models.WebhookDeliveryPending,
+		"next_retry_at": nil,
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := enqueueWebhookDelivery(delivery.ID); err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (s *WebhookService) getEndpoint(userID, endpointID uint) (*models.WebhookEndpoint, error) {
+	var endpoint models.WebhookEndpoint
+	if err := s.db.Where("id = ? AND user_id = ?", endpointID, userID).First(&endpoint).Error; err != nil {
+		return nil, ErrWebhookEndpointNotFound
+	}
+	return &endpoint, nil
+}
+
+func (s *WebhookService) newSecret() (string, string, error) {
+	token, err := randomToken(24)
+	if err != nil {
+		return "", "", err
+	}
+	secret := "whsec_" + token
+	encrypted, err := secrets.Encrypt(s.encryptionKey, secret)
+	if err != nil {
+		return "", "", fmt.Errorf("secret 암호화 실패: %w", err)
+	}
+	return secret, encrypted, nil
+}
+
+func enqueueWebhookDelivery(deliveryID uint) error {
+	if err := queue.PublishJob(WebhookQueue, map[string]interface{}{
+		"type":        "deliver_webhook",
+		"delivery_id": deliveryID,
+		"timestamp":   time.Now().Unix(),
+	}); err != nil {
+		return fmt.Errorf("웹훅 발송 요청 실패: %w", err)
+	}
+	return nil
+}
+
+func validateWebhookURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ErrWebhookInvalidURL
+	}
+	return nil
+}
+
+// normalizeWebhookEvents 이벤트 검증 후 정해진 순서의 쉼표 구분 문자열로 변환
+func normalizeWebhookEvents(events []models.WebhookEventType) (string, error) {
+	requested := make(map[models.WebhookEventType]bool, len(events))
+	for _, event := range events {
+		requested[event] = true
+	}
+
+	var result []string
+	for _, event := range models.ValidWebhookEventTypes {
+		if requested[event] {
+			result = append(result, string(event))
+			delete(requested, event)
+		}
+	}
+	for event := range requested {
+		return "", fmt.Errorf("%w: %s", ErrWebhookInvalidEvent, event)
+	}
+	return strings.Join(result, ","), nil
+}
+
+func newWebhookEventID() (string, error) {
+	token, err := randomToken(12)
+	if err != nil {
+		return "", err
+	}
+	return "evt_" + token, nil
+}
+
+// WebhookPublisher 플랫폼 이벤트를 웹훅 큐로 발행
+//
+// 관련 사용자와 프로젝트만 실어 보내고, 어느 구독 주소로 보낼지는 워커가 결정한다.
+type WebhookPublisher struct{}
+
+// NewWebhookPublisher 생성자
+func NewWebhookPublisher() *WebhookPublisher {
+	return &WebhookPublisher{}
+}
+
+// Publish 이벤트 발행 (실패해도 원래 작업에는 영향 없음)
+func (p *WebhookPublisher) Publish(event models.WebhookEventType, userIDs []uint, projectID uint, data map[string]interface{}) {
+	eventID, err := newWebhookEventID()
+	if err != nil {
+		log.Printf("⚠️ 웹훅 이벤트 ID 생성 실패 (%s): %v", event, err)
+		return
+	}
+
+	job := map[string]interface{}{
+		"type":        "platform_event",
+		"event_id":    eventID,
+		"event":       string(event),
+		"user_ids":    userIDs,
+		"project_id":  projectID,
+		"data":        data,
+		"occurred_at": time.Now().UTC().Format(time.RFC3339),
+	}
+	if err := queue.PublishJob(WebhookQueue, job); err != nil {
+		log.Printf("⚠️ 웹훅 이벤트 큐 전송 실패 (%s): %v", event, err)
+	}
+}
+
+// PublishTradeExecuted 체결 이벤트 (매수자/매도자, 마켓의 프로젝트)
+func (p *WebhookPublisher) PublishTradeExecuted(trade *models.Trade, projectID uint) {
+	p.Publish(models.WebhookEventTradeExecuted, []uint{trade.BuyerID, trade.SellerID}, projectID, map[string]interface{}{
+		"trade_id":     trade.ID,
+		"milestone_id": trade.MilestoneID,
+		"option_id":    trade.OptionID,
+		"buyer_id":     trade.BuyerID,
+		"seller_id":    trade.SellerID,
+		"quantity":     trade.Quantity,
+		"price":        trade.Price,
+		"total_amount": trade.TotalAmount,
+		"executed_at":  trade.CreatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// PublishMilestoneResolved 마일스톤 완료/실패 확정 이벤트 (프로젝트 소유자와 포지션 보유자)
+func (p *WebhookPublisher) PublishMilestoneResolved(milestone *models.Milestone, userIDs []uint) {
+	p.Publish(models.WebhookEventMilestoneResolved, userIDs, milestone.ProjectID, map[string]interface{}{
+		"milestone_id":      milestone.ID,
+		"project_id":        milestone.ProjectID,
+		"title":             milestone.Title,
+		"status":            milestone.Status,
+		"market_type":       milestone.MarketType,
+		"winning_option_id": milestone.WinningOptionID,
+		"resolved_value":    milestone.ResolvedValue,
+		"resolved_at":       milestone.UpdatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// PublishArbitrationDecided 분쟁 판결 이벤트 (신청인/피신청인)
+func (p *WebhookPublisher) PublishArbitrationDecided(arbitrationCase *models.ArbitrationCase, projectID uint) {
+	data := map[string]interface{}{
+		"case_id":      arbitrationCase.ID,
+		"case_number":  arbitrationCase.CaseNumber,
+		"dispute_type": arbitrationCase.DisputeType,
+		"decision":     arbitrationCase.Decision,
+		"award_amount": arbitrationCase.AwardAmount,
+		"plaintiff_id": arbitrationCase.PlaintiffID,
+		"defendant_id": arbitrationCase.DefendantID,
+	}
+	if arbitrationCase.MilestoneID != nil {
+		data["milestone_id"] = *arbitrationCase.MilestoneID
+	}
+	if arbitrationCase.DecidedAt != nil {
+		data["decided_at"] = arbitrationCase.DecidedAt.UTC().Format(time.RFC3339)
+	}
+	p.Publish(models.WebhookEventArbitrationDecided, []uint{arbitrationCase.PlaintiffID, arbitrationCase.DefendantID}, projectID, data)
+}
+
+// PublishSlashApproved 멘토 슬래싱 승인 이벤트 (멘토 본인과 스테이커)
+func (p *WebhookPublisher) PublishSlashApproved(slashEvent *models.MentorSlashEvent, userIDs []uint) {
+	data := map[string]interface{}{
+		"slash_event_id": slashEvent.ID,
+		"mentor_id":      slashEvent.MentorID,
+		"slash_type":     slashEvent.SlashType,
+		"severity":       slashEvent.Severity,
+		"slashed_amount": slashEvent.SlashedAmount,
+		"reason":         slashEvent.Reason,
+	}
+	if slashEvent.MilestoneID != nil {
+		data["milestone_id"] = *slashEvent.MilestoneID
+	}
+	p.Publish(models.WebhookEventSlashApproved, userIDs, 0, data)
+}
@@ -21,6 +21,8 @@ type WorkerService struct {
 	stopChan  chan struct{}
 	wg        sync.WaitGroup
 	mutex     sync.RWMutex
+
+	marketMetrics *MarketMetricsService // 체결 이벤트로 TWAP/VWAP 갱신
 }
 
 // NewWorkerService 워커 서비스 생성
@@ -29,6 +31,8 @@ func NewWorkerService() *WorkerService {
 		db:        database.GetDB(),
 		consumers: make(map[string]*queue.Consumer),
 		stopChan:  make(chan struct{}),
+
+		marketMetrics: NewMarketMetricsService(database.GetDB()),
 	}
 }
 
@@ -49,6 +53,7 @@ func (w *WorkerService) Start() error {
 	w.startQueueWorker(queue.QueueWallet, "wallet-worker", w.handleWalletTasks)
 	w.startQueueWorker(queue.QueueMarket, "market-worker", w.handleMarketTasks)
 	w.startQueueWorker(queue.QueueWelcome, "welcome-worker", w.handleWelcomeTasks)
+	w.startQueueWorker(queue.QueueTrades, "trade-metrics-worker", w.handleTradeTasks)
 
 	log.Printf("✅ Worker Service started with %d workers", len(w.consumers))
 	return nil
@@ -134,6 +139,28 @@ func (w *WorkerService) handleWelcomeTasks(event queue.QueueEvent) error {
 	}
 }
 
+// handleTradeTasks 체결 이벤트 처리 (마켓 평균가 갱신, 프로젝트/사용자 지표와 트레이더 리더보드 재계산 표시)
+func (w *WorkerService) handleTradeTasks(event queue.QueueEvent) error {
+	switch event.Type {
+	case queue.EventTypeTrade:
+		tradeID, _ := event.Data["trade_id"].(float64)
+		if tradeID == 0 {
+			return fmt.Errorf("missing trade_id")
+		}
+		if err := w.marketMetrics.RefreshAfterTrade(event.MilestoneID, event.OptionID, uint(tradeID)); err != nil {
+			return err
+		}
+		MarkMilestoneStatsDirty(w.db, event.MilestoneID)
+		buyerID, _ := event.Data["buyer_id"].(float64)
+		sellerID, _ := event.Data["seller_id"].(float64)
+		MarkUserStatsDirty(uint(buyerID), uint(sellerID))
+		MarkLeaderboardDirty(models.LeaderboardTraders)
+		return nil
+	default:
+		return fmt.Errorf("unknown trade task type: %s", event.Type)
+	}
+}
+
 // processUserCreated 사용자 생성 후속 처리
 func (w *WorkerService) processUserCreated(event queue.QueueEvent) error {
 	userID := uint(event.Data["user_id"].(float64))
@@ -163,6 +190,13 @@ func (w *WorkerService) processUserCreated(event queue.QueueEvent) error {
 		log.Printf("❌ Failed to enqueue welcome user: %v", err)
 	}
 
+	// 3. 추천 코드로 가입한 경우 추천인에게 귀속
+	if referralCode, _ := event.Data["referral_code"].(string); referralCode != "" {
+		if _, err := NewReferralService(w.db).AttributeSignup(userID, referralCode); err != nil {
+			log.Printf("⚠️ Referral attribution skipped for UserID=%d: %v", userID, err)
+		}
+	}
+
 	log.Printf("✅ User created tasks queued: UserID=%d", userID)
 	return nil
 }
@@ -226,9 +260,10 @@ func (w *WorkerService) processMarketInit(event queue.QueueEvent) error {
 		return fmt.Errorf("market must have at least 2 options")
 	}
 
-	// 🎯 각 옵션의 초기 확률은 1/N (균등 분배)
+	// 🎯 각 옵션의 초기 확률은 1/N (균등 분배), 생성 시 지정한 확률이 있으면 그 값 사용
 	// 예: 2개 옵션 = 50¢씩, 5개 옵션 = 20¢씩
 	initialPrice := 1.0 / float64(optionCount)
+	initialPrices, _ := event.Data["initial_prices"].(map[string]interface{})
 
 	// 범위 검증 (0.01-0.99)
 	if initialPrice < 0.01 {
@@ -248,11 +283,16 @@ func (w *WorkerService) processMarketInit(event queue.QueueEvent) error {
 			continue // 이미 존재하면 스킵
 		}
 
+		price := initialPrice
+		if specified, ok := initialPrices[option].(float64); ok {
+			price = specified
+		}
+
 		marketData := models.MarketData{
 			MilestoneID:   milestoneID,
 			OptionID:      option,
-			CurrentPrice:  initialPrice, // 균등 확률로 초기화
-			PreviousPrice: initialPrice,
+			CurrentPrice:  price,
+			PreviousPrice: price,
 			Volume24h:     0,
 			Trades24h:     0,
 		}
@@ -263,9 +303,7 @@ func (w *WorkerService) processMarketInit(event queue.QueueEvent) error {
 		}
 	}
 
-	// 확률 합계 검증 (로그)
-	totalProbability := float64(optionCount) * initialPrice
-	log.Printf("✅ Market initialized: MilestoneID=%d, Total probability=%.4f", milestoneID, totalProbability)
+	BumpMarketSequence(milestoneID)
 
 	log.Printf("✅ Market initialized: MilestoneID=%d, Options=%v", milestoneID, optionStrings)
 	return nil
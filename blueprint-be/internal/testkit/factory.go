@@ -0,0 +1,148 @@
+package testkit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// Factory 결정적인 테스트 데이터 생성기
+//
+// 이메일/이름은 생성 순번으로 만들고, 주문 접수 시각은 팩토리 시계를 1ms씩 진행해 가격-시간 우선순위가
+// 실행마다 같게 한다. 각 메서드는 기본값을 채운 뒤 옵션 함수로 필드를 덮어쓰고 DB에 저장한다.
+type Factory struct {
+	t     testing.TB
+	db    *gorm.DB
+	seq   int
+	clock time.Time
+}
+
+// NewFactory 생성자
+func NewFactory(t testing.TB, db *gorm.DB) *Factory {
+	return &Factory{t: t, db: db, clock: time.Now().Truncate(time.Second)}
+}
+
+// next 다음 생성 순번
+func (f *Factory) next() int {
+	f.seq++
+	return f.seq
+}
+
+// tick 팩토리 시계를 1ms 진행한 시각
+func (f *Factory) tick() time.Time {
+	f.clock = f.clock.Add(time.Millisecond)
+	return f.clock
+}
+
+// create 옵션 적용 후 저장
+func create[T any](f *Factory, record *T, opts []func(*T)) *T {
+	f.t.Helper()
+	for _, opt := range opts {
+		opt(record)
+	}
+	if err := f.db.Create(record).Error; err != nil {
+		f.t.Fatalf("testkit: failed to create %T: %v", record, err)
+	}
+	return record
+}
+
+// User 활성 사용자
+func (f *Factory) User(opts ...func(*models.User)) *models.User {
+	n := f.next()
+	return create(f, &models.User{
+		Email:    fmt.Sprintf("user%d@test.com", n),
+		Username: fmt.Sprintf("user%d", n),
+		IsActive: true,
+	}, opts)
+}
+
+// Wallet 사용자 지갑 (USDC 센트)
+func (f *Factory) Wallet(userID uint, usdcCents int64, opts ...func(*models.UserWallet)) *models.UserWallet {
+	return create(f, &models.UserWallet{UserID: userID, USDCBalance: usdcCents}, opts)
+}
+
+// FundedUser USDC 잔액이 있는 지갑을 가진 사용자
+func (f *Factory) FundedUser(usdcCents int64) *models.User {
+	user := f.User()
+	f.Wallet(user.ID, usdcCents)
+	return user
+}
+
+// Project 진행 중인 프로젝트
+func (f *Factory) Project(ownerID uint, opts ...func(*models.Project)) *models.Project {
+	n := f.next()
+	return create(f, &models.Project{
+		UserID:   ownerID,
+		Title:    fmt.Sprintf("project %d", n),
+		Category: models.BusinessProject,
+		Status:   models.ProjectActive,
+	}, opts)
+}
+
+// Milestone 거래 가능한(active) 이진 마켓 마일스톤
+func (f *Factory) Milestone(projectID uint, opts ...func(*models.Milestone)) *models.Milestone {
+	n := f.next()
+	return create(f, &models.Milestone{
+		ProjectID: projectID,
+		Title:     fmt.Sprintf("milestone %d", n),
+		Order:     n,
+		Status:    models.MilestoneStatusActive,
+	}, opts)
+}
+
+// Market 새 소유자/프로젝트 아래 거래 가능한 마일스톤
+func (f *Factory) Market(opts ...func(*models.Milestone)) *models.Milestone {
+	owner := f.User()
+	return f.Milestone(f.Project(owner.ID).ID, opts...)
+}
+
+// Order 열린 지정가 주문 (DB에만 저장, 매칭 엔진에는 제출하지 않음)
+func (f *Factory) Order(userID uint, milestone *models.Milestone, side models.OrderSide, price float64, quantity int64, opts ...func(*models.Order)) *models.Order {
+	at := f.tick()
+	return create(f, &models.Order{
+		ProjectID:   milestone.ProjectID,
+		MilestoneID: milestone.ID,
+		OptionID:    models.OptionSuccess,
+		UserID:      userID,
+		Type:        models.OrderTypeLimit,
+		Side:        side,
+		Quantity:    quantity,
+		PriceTicks:  models.PriceToTicks(price),
+		Remaining:   quantity,
+		Status:      models.OrderStatusPending,
+		CreatedAt:   at,
+		UpdatedAt:   at,
+	}, opts)
+}
+
+// Position 보유 포지션
+func (f *Factory) Position(userID uint, milestone *models.Milestone, optionID string, quantity int64, avgPrice float64, opts ...func(*models.Position)) *models.Position {
+	return create(f, &models.Position{
+		UserID:      userID,
+		ProjectID:   milestone.ProjectID,
+		MilestoneID: milestone.ID,
+		OptionID:    optionID,
+		Quantity:    quantity,
+		AvgPrice:    avgPrice,
+		TotalCost:   models.NotionalCents(quantity, models.PriceToTicks(avgPrice)),
+	}, opts)
+}
+
+// Mentor 사용자의 멘토 프로필
+func (f *Factory) Mentor(userID uint, opts ...func(*models.Mentor)) *models.Mentor {
+	return create(f, &models.Mentor{UserID: userID, Status: models.MentorStatusActive}, opts)
+}
+
+// Juror 배심원 자격 (스테이킹 양과 평판 점수 지정)
+func (f *Factory) Juror(userID uint, stake int64, opts ...func(*models.JurorQualification)) *models.JurorQualification {
+	return create(f, &models.JurorQualification{
+		UserID:          userID,
+		CurrentStake:    stake,
+		ReputationScore: 0.5,
+		IsActive:        true,
+	}, opts)
+}
@@ -0,0 +1,113 @@
+// Package testkit 서비스 단위 테스트용 환경 (Postgres/Redis 없이 실행)
+//
+// sqlite in-memory DB에 운영과 같은 모델 목록으로 스키마를 만들고, miniredis를 전역 Redis 클라이언트에 연결한다.
+// 정리는 모두 t.Cleanup에 등록되므로 테스트는 생성만 하면 된다.
+//
+//	env := testkit.New(t)
+//	buyer := env.Factory.FundedUser(100000)
+//	milestone := env.Factory.Market()
+//	engine := testkit.StartMatchingEngine(t, env.DB)
+package testkit
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/database"
+	moduleRedis "blueprint-module/pkg/redis"
+	"blueprint/internal/services"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// settleTimeout 테스트 종료 전 체결 후처리를 기다리는 최대 시간
+const settleTimeout = 5 * time.Second
+
+// Env DB + Redis + 팩토리 묶음
+type Env struct {
+	DB      *gorm.DB
+	Redis   *miniredis.Miniredis
+	Factory *Factory
+}
+
+// New 테스트 환경 생성 (DB, Redis, 팩토리)
+func New(t testing.TB) *Env {
+	t.Helper()
+	db := NewDB(t)
+	return &Env{
+		DB:      db,
+		Redis:   NewRedis(t),
+		Factory: NewFactory(t, db),
+	}
+}
+
+// NewDB 전체 스키마가 만들어진 in-memory DB
+//
+// 연결을 하나로 고정해 서비스가 띄운 고루틴도 같은 DB를 보게 하고, 전역 DB(database.GetDB)도 이 DB로 바꾼다.
+func NewDB(t testing.TB) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("testkit: failed to open sqlite: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("testkit: failed to get sql.DB: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := db.AutoMigrate(database.Models()...); err != nil {
+		t.Fatalf("testkit: failed to migrate: %v", err)
+	}
+
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() {
+		database.DB = previous
+		sqlDB.Close()
+	})
+	return db
+}
+
+// NewRedis miniredis 실행 후 전역 Redis 클라이언트로 연결 (정리 시 이전 클라이언트로 복원)
+func NewRedis(t testing.TB) *miniredis.Miniredis {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+	previous := moduleRedis.Client
+	moduleRedis.Client = client
+	t.Cleanup(func() {
+		moduleRedis.Client = previous
+		client.Close()
+	})
+	return server
+}
+
+// StartMatchingEngine 단일 노드 매칭 엔진 시작 (정리 시 체결 후처리를 기다린 뒤 중지)
+//
+// 체결 후처리는 Redis로 브로드캐스트하므로 NewRedis(또는 New) 다음에 호출해야 정리 순서가 맞는다.
+func StartMatchingEngine(t testing.TB, db *gorm.DB) *services.LocalMatchingEngine {
+	t.Helper()
+	engine := services.NewLocalMatchingEngine(db, nil, nil, nil)
+	if err := engine.Start(); err != nil {
+		t.Fatalf("testkit: failed to start matching engine: %v", err)
+	}
+	t.Cleanup(func() {
+		engine.Stop()
+		Settle(t, engine)
+	})
+	return engine
+}
+
+// Settle 진행 중인 체결 후처리(지갑/포지션/시장 데이터 반영)가 끝날 때까지 대기
+func Settle(t testing.TB, engine services.MatchingEngine) {
+	t.Helper()
+	if !engine.WaitForSettlement(settleTimeout) {
+		t.Errorf("testkit: trade settlement did not finish within %v", settleTimeout)
+	}
+}
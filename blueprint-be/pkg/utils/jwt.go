@@ -9,9 +9,10 @@ import (
 )
 
 type Claims struct {
-	UserID   uint   `json:"user_id"`
-	Email    string `json:"email"`
-	Username string `json:"username"`
+	UserID    uint   `json:"user_id"`
+	Email     string `json:"email"`
+	Username  string `json:"username"`
+	SessionID string `json:"sid,omitempty"` // 로그인 세션 ID (폐기 여부 확인용)
 	jwt.RegisteredClaims
 }
 
@@ -22,12 +23,18 @@ func GenerateToken(user *models.User, jwtSecret string) (string, error) {
 
 // GenerateTokenWithExpiry 만료 시간을 지정하여 JWT 토큰 생성
 func GenerateTokenWithExpiry(user *models.User, jwtSecret string, expiry time.Duration) (string, error) {
+	return GenerateSessionToken(user, jwtSecret, "", expiry)
+}
+
+// GenerateSessionToken 로그인 세션에 묶인 액세스 토큰 생성
+func GenerateSessionToken(user *models.User, jwtSecret, sessionID string, expiry time.Duration) (string, error) {
 	expirationTime := time.Now().Add(expiry)
 
 	claims := &Claims{
-		UserID:   user.ID,
-		Email:    user.Email,
-		Username: user.Username,
+		UserID:    user.ID,
+		Email:     user.Email,
+		Username:  user.Username,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
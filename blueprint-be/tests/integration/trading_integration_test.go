@@ -2,6 +2,7 @@ package integration_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -51,6 +52,7 @@ func (suite *TradingIntegrationTestSuite) SetupSuite() {
 		&models.MarketData{},
 		&models.UserWallet{},
 		&models.PriceHistory{},
+		&models.PriceCandle{},
 		&models.StakingPool{},
 		&models.RevenueDistribution{},
 		&models.StakingReward{},
@@ -275,27 +277,26 @@ func (suite *TradingIntegrationTestSuite) TestConcurrentTrading() {
 
 // TestSSEStreaming SSE 스트리밍 테스트
 func (suite *TradingIntegrationTestSuite) TestSSEStreaming() {
-	// SSE 연결 시뮬레이션
-	req, _ := http.NewRequest("GET", "/api/v1/stream/1", nil)
+	// SSE 연결 (스트림은 실제 연결이 필요하므로 테스트 서버 사용)
+	server := httptest.NewServer(suite.router)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/api/v1/stream/1", nil)
 	req.Header.Set("Accept", "text/event-stream")
 	req.Header.Set("Cache-Control", "no-cache")
 
-	w := httptest.NewRecorder()
-
-	// 별도 고루틴에서 SSE 연결 처리
-	go func() {
-		suite.router.ServeHTTP(w, req)
-	}()
-
-	// 잠시 대기 후 거래 실행하여 SSE 이벤트 발생
-	time.Sleep(100 * time.Millisecond)
+	resp, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
 
-	_, err := suite.tradingService.CreateOrder(1, 1, "success", "buy", 100, 0.75)
+	// 연결 후 거래 실행하여 SSE 이벤트 발생
+	_, err = suite.tradingService.CreateOrder(1, 1, "success", "buy", 100, 0.75)
 	suite.Assert().NoError(err)
 
 	// SSE 연결 응답 확인
-	time.Sleep(200 * time.Millisecond)
-	suite.Assert().Contains(w.Header().Get("Content-Type"), "text/event-stream")
+	suite.Assert().Contains(resp.Header.Get("Content-Type"), "text/event-stream")
 }
 
 // TestHealthCheck 헬스 체크 테스트
@@ -0,0 +1,149 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupAccountDeletionDB(t *testing.T) (*gorm.DB, models.User) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&models.User{}, &models.UserProfile{}, &models.UserVerification{}, &models.UserIdentity{},
+		&models.AccountDeletion{}, &models.Order{}, &models.WalletLedgerEntry{}, &models.DataExport{},
+		&models.MentorStake{}, &models.StakingPool{}, &models.JurorQualification{}, &models.Parlay{},
+		&models.ArbitrationCase{}, &models.ArbitrationVote{},
+		&models.PushSubscription{}, &models.APIKey{}, &models.WebhookEndpoint{}, &models.GitHubConnection{},
+		&models.GitHubWebhookSubscription{}, &models.ProjectWatch{}, &models.Notification{}, &models.ActivityLog{},
+		&models.UserRole{}, &models.MagicLink{}, &models.PositionTransfer{}, &models.UserWallet{}, &models.WalletAccount{},
+	))
+
+	user := models.User{Email: "alice@test.com", Username: "alice", IsActive: true}
+	require.NoError(t, db.Create(&user).Error)
+	require.NoError(t, db.Create(&models.UserProfile{UserID: user.ID}).Error)
+	return db, user
+}
+
+func TestAccountDeletionLifecycle(t *testing.T) {
+	db, user := setupAccountDeletionDB(t)
+	deletionService := services.NewAccountDeletionService(db, nil, nil)
+
+	deletion, err := deletionService.RequestDeletion(user.ID, "no longer needed")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(services.AccountDeletionGracePeriod), deletion.ScheduledFor, time.Minute)
+
+	_, err = deletionService.RequestDeletion(user.ID, "")
+	assert.ErrorIs(t, err, services.ErrDeletionAlreadyRequested)
+
+	var stored models.User
+	require.NoError(t, db.First(&stored, user.ID).Error)
+	assert.False(t, stored.IsActive)
+
+	// 유예 기간 중 취소하면 다시 활성화
+	_, err = deletionService.CancelDeletion(user.ID)
+	require.NoError(t, err)
+	require.NoError(t, db.First(&stored, user.ID).Error)
+	assert.True(t, stored.IsActive)
+
+	// 유예 기간이 지나면 개인 데이터 삭제 및 익명화 (원장 기록은 유지)
+	db.Create(&models.WalletLedgerEntry{UserID: user.ID, Currency: models.LedgerCurrencyUSDC, Amount: 100, EntryType: models.LedgerAccountMerge})
+	deletion, err = deletionService.RequestDeletion(user.ID, "")
+	require.NoError(t, err)
+	db.Model(deletion).Update("scheduled_for", time.Now().Add(-time.Minute))
+
+	purged, err := deletionService.PurgeDue()
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	var deleted models.User
+	require.NoError(t, db.Unscoped().First(&deleted, user.ID).Error)
+	assert.True(t, deleted.DeletedAt.Valid)
+	assert.NotEqual(t, "alice@test.com", deleted.Email)
+
+	var profiles, ledger int64
+	db.Model(&models.UserProfile{}).Where("user_id = ?", user.ID).Count(&profiles)
+	db.Model(&models.WalletLedgerEntry{}).Where("user_id = ?", user.ID).Count(&ledger)
+	assert.Zero(t, profiles)
+	assert.Equal(t, int64(1), ledger)
+
+	// 같은 이메일로 다시 가입 가능
+	assert.NoError(t, db.Create(&models.User{Email: "alice@test.com", Username: "alice"}).Error)
+}
+
+func TestAccountDeletionBlockedByStaking(t *testing.T) {
+	db, user := setupAccountDeletionDB(t)
+	deletionService := services.NewAccountDeletionService(db, nil, nil)
+
+	db.Create(&models.StakingPool{UserID: user.ID, Amount: 1000, Status: "active"})
+
+	_, err := deletionService.RequestDeletion(user.ID, "")
+	assert.ErrorIs(t, err, services.ErrDeletionBlocked)
+
+	var pending int64
+	db.Model(&models.AccountDeletion{}).Count(&pending)
+	assert.Zero(t, pending)
+}
+
+// TestAccountDeletionRevokesAPIKeys 삭제를 요청하면 API 키를 바로 폐기
+func TestAccountDeletionRevokesAPIKeys(t *testing.T) {
+	db, user := setupAccountDeletionDB(t)
+	deletionService := services.NewAccountDeletionService(db, nil, nil)
+	require.NoError(t, db.Create(&models.APIKey{UserID: user.ID, Name: "bot", KeyID: "bpk_test", Scopes: "read,trade", EncryptedSecret: "x"}).Error)
+
+	_, err := deletionService.RequestDeletion(user.ID, "")
+	require.NoError(t, err)
+
+	var key models.APIKey
+	require.NoError(t, db.Where("user_id = ?", user.ID).First(&key).Error)
+	assert.NotNil(t, key.RevokedAt)
+}
+
+// TestAccountDeletionPurgeHeld 유예 기간 중 생긴 차단 사유나 남은 지갑 잔액이 있으면 삭제하지 않고 예약 유지
+func TestAccountDeletionPurgeHeld(t *testing.T) {
+	db, user := setupAccountDeletionDB(t)
+	deletionService := services.NewAccountDeletionService(db, nil, nil)
+
+	deletion, err := deletionService.RequestDeletion(user.ID, "")
+	require.NoError(t, err)
+	db.Model(deletion).Update("scheduled_for", time.Now().Add(-time.Minute))
+
+	assertHeld := func() {
+		t.Helper()
+		purged, err := deletionService.PurgeDue()
+		require.NoError(t, err)
+		assert.Zero(t, purged)
+		pending, err := deletionService.GetPending(user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, deletion.ID, pending.ID)
+		var profiles int64
+		db.Model(&models.UserProfile{}).Where("user_id = ?", user.ID).Count(&profiles)
+		assert.Equal(t, int64(1), profiles)
+	}
+
+	// 정산 지급 등으로 잔액이 생김
+	wallet := models.UserWallet{UserID: user.ID, USDCBalance: 250}
+	require.NoError(t, db.Create(&wallet).Error)
+	assertHeld()
+
+	require.NoError(t, db.Model(&wallet).Update("usdc_balance", 0).Error)
+	require.NoError(t, db.Create(&models.WalletAccount{UserID: user.ID, Currency: "krws", Locked: 10}).Error)
+	assertHeld()
+
+	// 잔액을 비워도 새 차단 사유가 있으면 보류
+	require.NoError(t, db.Where("user_id = ?", user.ID).Delete(&models.WalletAccount{}).Error)
+	require.NoError(t, db.Create(&models.StakingPool{UserID: user.ID, Amount: 1000, Status: "active"}).Error)
+	assertHeld()
+
+	require.NoError(t, db.Model(&models.StakingPool{}).Where("user_id = ?", user.ID).Update("status", "withdrawn").Error)
+	purged, err := deletionService.PurgeDue()
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+}
@@ -0,0 +1,201 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// AccountLinkTestSuite 로그인 수단 연결 및 계정 병합 테스트 슈트
+type AccountLinkTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	service *services.AccountLinkService
+	target  models.User
+	source  models.User
+}
+
+func (suite *AccountLinkTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(
+		&models.User{}, &models.UserVerification{}, &models.UserIdentity{}, &models.AccountMerge{},
+		&models.UserWallet{}, &models.WalletAccount{}, &models.WalletLedgerEntry{}, &models.Position{}, &models.Order{},
+		&models.ArbitrationCase{}, &models.ArbitrationVote{}, &models.JurorQualification{},
+		&models.Parlay{}, &models.MentorStake{}, &models.StakingPool{}, &models.StakingEpochReward{},
+		&models.Project{}, &models.GitHubConnection{}, &models.GitHubWebhookSubscription{}, &models.APIKey{},
+	))
+	suite.db = db
+	suite.service = services.NewAccountLinkService(db, nil)
+
+	suite.target = models.User{Email: "alice@test.com", Username: "alice", IsActive: true}
+	suite.source = models.User{Email: "alice.old@test.com", Username: "alice_old", IsActive: true}
+	suite.Require().NoError(db.Create(&suite.target).Error)
+	suite.Require().NoError(db.Create(&suite.source).Error)
+}
+
+// startAndConfirm 병합 요청 후 발급된 코드로 확인
+func (suite *AccountLinkTestSuite) startAndConfirm() (*models.AccountMerge, error) {
+	merge, source, code, err := suite.service.StartMerge(suite.target.ID, "Alice.Old@test.com")
+	if err != nil {
+		return nil, err
+	}
+	suite.Equal(suite.source.ID, source.ID)
+	return suite.service.ConfirmMerge(suite.target.ID, merge.ID, code)
+}
+
+// TestIdentityLinkedElsewhere 다른 계정이 쓰는 소셜 계정은 연결 거부
+func (suite *AccountLinkTestSuite) TestIdentityLinkedElsewhere() {
+	identity := models.UserIdentity{Provider: models.IdentityProviderGitHub, ProviderUserID: "gh-1"}
+
+	_, err := suite.service.LinkIdentity(suite.source.ID, identity)
+	suite.Require().NoError(err)
+
+	_, err = suite.service.LinkIdentity(suite.target.ID, identity)
+	suite.ErrorIs(err, services.ErrIdentityLinkedElsewhere)
+
+	// 같은 사용자의 재연결은 허용
+	_, err = suite.service.LinkIdentity(suite.source.ID, identity)
+	suite.NoError(err)
+}
+
+// TestMergeMovesAssets 지갑, 포지션, 배심원 스테이킹, 프로젝트가 target으로 이전되고 source는 비활성화
+func (suite *AccountLinkTestSuite) TestMergeMovesAssets() {
+	suite.db.Create(&models.UserWallet{UserID: suite.source.ID, USDCBalance: 5000, USDCLockedBalance: 1000, BlueprintBalance: 300})
+	suite.db.Create(&models.UserWallet{UserID: suite.target.ID, USDCBalance: 2000})
+	suite.db.Create(&models.Position{UserID: suite.source.ID, MilestoneID: 1, OptionID: "success", Quantity: 100, AvgPrice: 40, TotalCost: 4000})
+	suite.db.Create(&models.Position{UserID: suite.target.ID, MilestoneID: 1, OptionID: "success", Quantity: 100, AvgPrice: 60, TotalCost: 6000})
+	suite.db.Create(&models.Position{UserID: suite.source.ID, MilestoneID: 2, OptionID: "fail", Quantity: 10, AvgPrice: 30})
+	suite.db.Create(&models.JurorQualification{UserID: suite.source.ID, CurrentStake: 700, IsActive: true})
+	suite.db.Create(&models.JurorQualification{UserID: suite.target.ID, CurrentStake: 300, IsActive: true})
+	suite.db.Create(&models.Project{UserID: suite.source.ID, Title: "old project"})
+
+	merge, err := suite.startAndConfirm()
+	suite.Require().NoError(err)
+	suite.Equal(models.AccountMergeCompleted, merge.Status)
+	suite.Equal(int64(5000), merge.Summary.USDC)
+	suite.Equal(1, merge.Summary.Positions)
+	suite.Equal(1, merge.Summary.PositionsCombined)
+	suite.Equal(1, merge.Summary.Projects)
+
+	var wallet models.UserWallet
+	suite.Require().NoError(suite.db.Where("user_id = ?", suite.target.ID).First(&wallet).Error)
+	suite.Equal(int64(7000), wallet.USDCBalance)
+	suite.Equal(int64(1000), wallet.USDCLockedBalance)
+	suite.Equal(int64(300), wallet.BlueprintBalance)
+
+	var combined models.Position
+	suite.Require().NoError(suite.db.Where("user_id = ? AND milestone_id = 1", suite.target.ID).First(&combined).Error)
+	suite.Equal(int64(200), combined.Quantity)
+	suite.InDelta(50.0, combined.AvgPrice, 0.001)
+
+	var juror models.JurorQualification
+	suite.Require().NoError(suite.db.Where("user_id = ?", suite.target.ID).First(&juror).Error)
+	suite.Equal(int64(1000), juror.CurrentStake)
+
+	var source models.User
+	suite.Require().NoError(suite.db.First(&source, suite.source.ID).Error)
+	suite.False(source.IsActive)
+	suite.Require().NotNil(source.MergedIntoID)
+
+	// 이후 흡수된 계정으로 로그인하면 병합된 계정으로 연결
+	resolved, err := suite.service.ResolveMerged(&source)
+	suite.Require().NoError(err)
+	suite.Equal(suite.target.ID, resolved.ID)
+}
+
+// TestMergeRevokesSourceCredentials 병합하면 흡수된 계정의 세션과 API 키를 폐기하고, 흡수된 계정의 세션은 갱신할 수 없음
+func (suite *AccountLinkTestSuite) TestMergeRevokesSourceCredentials() {
+	testkit.NewRedis(suite.T())
+	sessions := services.NewSessionService(suite.db, "test-secret")
+	suite.service = services.NewAccountLinkService(suite.db, sessions)
+
+	tokens, err := sessions.CreateSession(&suite.source, "Mozilla/5.0 Chrome/120.0", "127.0.0.1")
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.db.Create(&models.APIKey{UserID: suite.source.ID, Name: "bot", KeyID: "bpk_old", Scopes: "read", EncryptedSecret: "x"}).Error)
+
+	_, err = suite.startAndConfirm()
+	suite.Require().NoError(err)
+
+	suite.True(moduleRedis.IsSessionRevoked(tokens.SessionID))
+	_, err = sessions.Refresh(tokens.RefreshToken, "Mozilla/5.0 Chrome/120.0", "127.0.0.1")
+	suite.Error(err)
+
+	var key models.APIKey
+	suite.Require().NoError(suite.db.Where("user_id = ?", suite.source.ID).First(&key).Error)
+	suite.NotNil(key.RevokedAt)
+}
+
+// TestMergeBlockedByOpenOrders 미체결 주문이 있으면 병합 불가
+func (suite *AccountLinkTestSuite) TestMergeBlockedByOpenOrders() {
+	suite.db.Create(&models.Order{UserID: suite.source.ID, MilestoneID: 1, OptionID: "success", Status: models.OrderStatusPending})
+
+	_, err := suite.startAndConfirm()
+	suite.ErrorIs(err, services.ErrMergeBlocked)
+}
+
+// TestMergeMovesCurrencyAccounts 추가 결제 통화 잔액도 원장 기록과 함께 target 계정 행으로 이전
+func (suite *AccountLinkTestSuite) TestMergeMovesCurrencyAccounts() {
+	krw := models.LedgerCurrency("krw")
+	suite.db.Create(&models.UserWallet{UserID: suite.source.ID, USDCBalance: 100})
+	suite.db.Create(&models.WalletAccount{UserID: suite.source.ID, Currency: krw, Available: 50000, Locked: 2000})
+	suite.db.Create(&models.WalletAccount{UserID: suite.target.ID, Currency: krw, Available: 1000})
+
+	merge, err := suite.startAndConfirm()
+	suite.Require().NoError(err)
+	suite.Require().Len(merge.Summary.Accounts, 1)
+	suite.Equal(krw, merge.Summary.Accounts[0].Currency)
+	suite.Equal(int64(50000), merge.Summary.Accounts[0].Available)
+	suite.Equal(int64(2000), merge.Summary.Accounts[0].Locked)
+
+	var target, source models.WalletAccount
+	suite.Require().NoError(suite.db.Where("user_id = ? AND currency = ?", suite.target.ID, krw).First(&target).Error)
+	suite.Equal(int64(51000), target.Available)
+	suite.Equal(int64(2000), target.Locked)
+	suite.Require().NoError(suite.db.Where("user_id = ? AND currency = ?", suite.source.ID, krw).First(&source).Error)
+	suite.Zero(source.Available)
+	suite.Zero(source.Locked)
+
+	var entries []models.WalletLedgerEntry
+	suite.Require().NoError(suite.db.Where("currency = ? AND entry_type = ?", krw, models.LedgerAccountMerge).Order("id ASC").Find(&entries).Error)
+	suite.Require().Len(entries, 2)
+	suite.Equal(suite.source.ID, entries[0].UserID)
+	suite.Equal(int64(-50000), entries[0].Amount)
+	suite.Equal(suite.target.ID, entries[1].UserID)
+	suite.Equal(int64(2000), entries[1].LockedAmount)
+	suite.Equal(merge.ID, entries[1].ReferenceID)
+}
+
+// TestMergeBlockedByOpenCurrencyOrders 추가 결제 통화 마켓의 미체결 주문도 병합을 막음
+func (suite *AccountLinkTestSuite) TestMergeBlockedByOpenCurrencyOrders() {
+	suite.db.Create(&models.Order{UserID: suite.source.ID, MilestoneID: 1, OptionID: "success",
+		QuoteCurrency: models.LedgerCurrency("krw"), Status: models.OrderStatusPartial})
+
+	_, err := suite.startAndConfirm()
+	suite.ErrorIs(err, services.ErrMergeBlocked)
+	suite.ErrorContains(err, "KRW 1건")
+}
+
+// TestWrongCodeCancelsAfterLimit 코드를 반복해서 틀리면 병합 요청 취소
+func (suite *AccountLinkTestSuite) TestWrongCodeCancelsAfterLimit() {
+	merge, _, _, err := suite.service.StartMerge(suite.target.ID, suite.source.Email)
+	suite.Require().NoError(err)
+
+	for i := 0; i < 5; i++ {
+		_, err = suite.service.ConfirmMerge(suite.target.ID, merge.ID, "000000")
+		suite.ErrorIs(err, services.ErrMergeInvalidCode)
+	}
+	_, err = suite.service.ConfirmMerge(suite.target.ID, merge.ID, "000000")
+	suite.ErrorIs(err, services.ErrMergeNotFound)
+}
+
+func TestAccountLinkTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountLinkTestSuite))
+}
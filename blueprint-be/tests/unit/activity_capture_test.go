@@ -0,0 +1,68 @@
+package unit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeActivityRecorder struct {
+	requests []models.CreateActivityLogRequest
+}
+
+func (f *fakeActivityRecorder) LogActivity(ctx context.Context, req models.CreateActivityLogRequest) error {
+	f.requests = append(f.requests, req)
+	return nil
+}
+
+func newActivityRouter(recorder *fakeActivityRecorder) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ActivityCapture(recorder))
+
+	authed := router.Group("/api/v1", func(c *gin.Context) {
+		c.Set("user_id", uint(7))
+		c.Set("auth_method", "api_key")
+	})
+	authed.DELETE("/orders/:id", func(c *gin.Context) {
+		if c.Param("id") == "404" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	authed.GET("/orders/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+// TestActivityCaptureRecordsSuccessfulRoutes 등록된 라우트의 성공 응답만 분류/액션과 대상 ID로 기록
+func TestActivityCaptureRecordsSuccessfulRoutes(t *testing.T) {
+	recorder := &fakeActivityRecorder{}
+	router := newActivityRouter(recorder)
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodDelete, "/api/v1/orders/42", nil),
+		httptest.NewRequest(http.MethodDelete, "/api/v1/orders/404", nil), // 실패 응답
+		httptest.NewRequest(http.MethodGet, "/api/v1/orders/42", nil),     // 미등록 라우트
+	} {
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	require.Len(t, recorder.requests, 1)
+	logged := recorder.requests[0]
+	assert.Equal(t, uint(7), logged.UserID)
+	assert.Equal(t, models.ActivityTypeTrading, logged.ActivityType)
+	assert.Equal(t, "order_cancelled", logged.Action)
+	require.NotNil(t, logged.OrderID)
+	assert.Equal(t, uint(42), *logged.OrderID)
+	assert.Equal(t, "42", logged.Metadata.ResourceID)
+	assert.Equal(t, "api_key", logged.Metadata.AuthMethod)
+}
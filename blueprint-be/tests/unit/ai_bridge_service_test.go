@@ -0,0 +1,107 @@
+package unit_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	moduleConfig "blueprint-module/pkg/config"
+	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
+	"blueprint/internal/config"
+	"blueprint/internal/services"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// BridgeAIServiceTestSuite AI 마일스톤 생성 할당량/캐시/스트리밍 테스트 슈트
+type BridgeAIServiceTestSuite struct {
+	suite.Suite
+	db        *gorm.DB
+	aiService *services.BridgeAIService
+}
+
+func (suite *BridgeAIServiceTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(&models.User{}, &models.AIUsageLog{}))
+	suite.db = db
+
+	redisServer := miniredis.RunT(suite.T())
+	moduleRedis.Client = redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+
+	db.Create(&models.User{ID: 1, Email: "dreamer@test.com", Username: "dreamer", AIUsageLimit: 2})
+
+	cfg := &config.Config{Config: moduleConfig.Config{AI: moduleConfig.AIConfig{Provider: "mock"}}}
+	suite.aiService = services.NewBridgeAIService(cfg, db)
+}
+
+func (suite *BridgeAIServiceTestSuite) TearDownTest() {
+	moduleRedis.Client = nil
+}
+
+func (suite *BridgeAIServiceTestSuite) usageCount() int {
+	var user models.User
+	suite.db.First(&user, 1)
+	return user.AIUsageCount
+}
+
+// TestIdenticalPromptServedFromCache 동일 프롬프트는 캐시로 응답하고 사용 횟수를 차감하지 않음
+func (suite *BridgeAIServiceTestSuite) TestIdenticalPromptServedFromCache() {
+	project := models.CreateProjectRequest{Title: "풀스택 개발자 되기", Category: "career"}
+
+	first, err := suite.aiService.GenerateMilestones(1, project)
+	suite.Require().NoError(err)
+	suite.False(first.Cached)
+	suite.NotEmpty(first.Milestones)
+
+	second, err := suite.aiService.GenerateMilestones(1, project)
+	suite.Require().NoError(err)
+	suite.True(second.Cached)
+	suite.Equal(first.Milestones, second.Milestones)
+	suite.Equal(1, suite.usageCount())
+
+	var logs []models.AIUsageLog
+	suite.db.Order("id").Find(&logs)
+	suite.Require().Len(logs, 2)
+	suite.False(logs[0].Cached)
+	suite.True(logs[1].Cached)
+	suite.Equal(logs[0].PromptHash, logs[1].PromptHash)
+}
+
+// TestQuotaEnforced 한도에 도달하면 ErrAIQuotaExceeded
+func (suite *BridgeAIServiceTestSuite) TestQuotaEnforced() {
+	for _, title := range []string{"마라톤 완주", "책 출간"} {
+		_, err := suite.aiService.GenerateMilestones(1, models.CreateProjectRequest{Title: title})
+		suite.Require().NoError(err)
+	}
+
+	_, err := suite.aiService.GenerateMilestones(1, models.CreateProjectRequest{Title: "창업하기"})
+	suite.ErrorIs(err, services.ErrAIQuotaExceeded)
+	suite.Equal(2, suite.usageCount())
+}
+
+// TestStreamDeliversWholeResponse 스트리밍 조각을 이어 붙이면 최종 응답 JSON이 됨
+func (suite *BridgeAIServiceTestSuite) TestStreamDeliversWholeResponse() {
+	var chunks []string
+	response, err := suite.aiService.StreamMilestones(context.Background(), 1,
+		models.CreateProjectRequest{Title: "영어 회화 마스터"},
+		func(chunk string) { chunks = append(chunks, chunk) })
+	suite.Require().NoError(err)
+	suite.Greater(len(chunks), 1)
+
+	joined := strings.Join(chunks, "")
+	suite.Contains(joined, response.Milestones[0].Title)
+
+	var log models.AIUsageLog
+	suite.Require().NoError(suite.db.First(&log).Error)
+	suite.True(log.Streamed)
+}
+
+func TestBridgeAIServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(BridgeAIServiceTestSuite))
+}
@@ -0,0 +1,65 @@
+package unit_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// signRequest 클라이언트 측 HMAC 서명
+func signRequest(secret, timestamp, method, uri string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + method + uri))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestAPIKeyAuthenticate(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.APIKey{}))
+	db.Create(&models.User{ID: 1, Email: "bot@test.com", Username: "bot"})
+
+	apiKeyService := services.NewAPIKeyService(db, "test-secret")
+	created, err := apiKeyService.CreateKey(1, &models.CreateAPIKeyRequest{
+		Name:   "market maker",
+		Scopes: []string{"trade", "read", "trade"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "read,trade", created.APIKey.Scopes)
+
+	body := []byte(`{"milestone_id":1}`)
+	timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	signature := signRequest(created.Secret, timestamp, "POST", "/api/v1/orders", body)
+
+	apiKey, err := apiKeyService.Authenticate(created.APIKey.KeyID, timestamp, signature, "POST", "/api/v1/orders", body, "127.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), apiKey.UserID)
+	assert.True(t, apiKey.HasScope(models.APIKeyScopeTrade))
+	assert.False(t, apiKey.HasScope(models.APIKeyScopeWithdraw))
+
+	// 본문 변조
+	_, err = apiKeyService.Authenticate(created.APIKey.KeyID, timestamp, signature, "POST", "/api/v1/orders", []byte(`{}`), "127.0.0.1")
+	assert.ErrorIs(t, err, services.ErrAPIKeySignatureInvalid)
+
+	// 오래된 요청
+	stale := strconv.FormatInt(time.Now().Add(-time.Minute).UnixMilli(), 10)
+	_, err = apiKeyService.Authenticate(created.APIKey.KeyID, stale, signRequest(created.Secret, stale, "POST", "/api/v1/orders", body), "POST", "/api/v1/orders", body, "127.0.0.1")
+	assert.ErrorIs(t, err, services.ErrAPIKeyTimestampInvalid)
+
+	// 폐기된 키
+	require.NoError(t, apiKeyService.RevokeKey(1, created.APIKey.ID))
+	_, err = apiKeyService.Authenticate(created.APIKey.KeyID, timestamp, signature, "POST", "/api/v1/orders", body, "127.0.0.1")
+	assert.ErrorIs(t, err, services.ErrAPIKeyInvalid)
+}
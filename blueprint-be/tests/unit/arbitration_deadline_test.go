@@ -0,0 +1,101 @@
+package unit_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestArbitrationPhaseDeadlines 단계별 마감 전에는 그대로 두고, 마감이 지나도 진행할 수 없는 사건은 기각 후 스테이킹 반환
+func TestArbitrationPhaseDeadlines(t *testing.T) {
+	env := testkit.New(t)
+	service := services.NewArbitrationService(env.DB)
+	now := time.Now()
+
+	// 배심원 후보가 없는 환경 (선정/교체 불가)
+	newCase := func(status models.ArbitrationStatus, opts func(*models.ArbitrationCase)) *models.ArbitrationCase {
+		plaintiff := env.Factory.User()
+		defendant := env.Factory.User()
+		env.Factory.Wallet(plaintiff.ID, 0, func(w *models.UserWallet) { w.BlueprintLockedBalance = 1000 })
+		arbitrationCase := &models.ArbitrationCase{
+			CaseNumber: fmt.Sprintf("ARB-2026-%06d", plaintiff.ID), PlaintiffID: plaintiff.ID, DefendantID: defendant.ID,
+			Title: "분쟁", Description: "test", DisputeType: models.DisputeTypePaymentIssue, Status: status,
+			StakeAmount: 1000, RequiredJurors: 3, JuryFormationDeadline: now.Add(time.Hour),
+		}
+		if opts != nil {
+			opts(arbitrationCase)
+		}
+		require.NoError(t, env.DB.Create(arbitrationCase).Error)
+		return arbitrationCase
+	}
+	at := func(d time.Duration) *time.Time {
+		ts := now.Add(d)
+		return &ts
+	}
+
+	waitingJury := newCase(models.ArbitrationStatusSubmitted, nil)
+	missedJury := newCase(models.ArbitrationStatusSubmitted, func(c *models.ArbitrationCase) {
+		c.JuryFormationDeadline = now.Add(-time.Minute)
+	})
+	votingOpen := newCase(models.ArbitrationStatusVoting, func(c *models.ArbitrationCase) {
+		c.SelectedJurors = []uint{901, 902, 903}
+		c.VotingDeadline = at(time.Hour)
+	})
+	noVotes := newCase(models.ArbitrationStatusVoting, func(c *models.ArbitrationCase) {
+		c.SelectedJurors = []uint{901, 902, 903}
+		c.VotingDeadline = at(-time.Minute)
+	})
+	revealOpen := newCase(models.ArbitrationStatusReveal, func(c *models.ArbitrationCase) {
+		c.SelectedJurors = []uint{901}
+		c.RevealDeadline = at(time.Hour)
+	})
+	noReveals := newCase(models.ArbitrationStatusReveal, func(c *models.ArbitrationCase) {
+		c.SelectedJurors = []uint{901}
+		c.RevealDeadline = at(-time.Minute)
+	})
+	for _, pending := range []*models.ArbitrationCase{revealOpen, noReveals} {
+		require.NoError(t, env.DB.Create(&models.ArbitrationVote{
+			CaseID: pending.ID, JurorID: 901, CommitHash: "hash", CommittedAt: at(-2 * time.Hour),
+		}).Error)
+	}
+
+	advanced, err := service.AdvancePhases(now)
+	require.NoError(t, err)
+	assert.Equal(t, 3, advanced)
+
+	reload := func(c *models.ArbitrationCase) *models.ArbitrationCase {
+		var current models.ArbitrationCase
+		require.NoError(t, env.DB.First(&current, c.ID).Error)
+		return &current
+	}
+	for name, c := range map[string]*models.ArbitrationCase{"배심원 구성 대기": waitingJury, "투표 진행": votingOpen, "공개 진행": revealOpen} {
+		assert.Equal(t, c.Status, reload(c).Status, name)
+	}
+
+	for name, c := range map[string]*models.ArbitrationCase{"배심원 구성 기한": missedJury, "투표 기한": noVotes, "공개 기한": noReveals} {
+		current := reload(c)
+		assert.Equal(t, models.ArbitrationStatusRejected, current.Status, name)
+		assert.Equal(t, models.ArbitrationDecisionDismissed, current.Decision, name)
+		assert.True(t, current.StakeReturned, name)
+
+		var wallet models.UserWallet
+		require.NoError(t, env.DB.Where("user_id = ?", c.PlaintiffID).First(&wallet).Error)
+		assert.Zero(t, wallet.BlueprintLockedBalance, name)
+		assert.Equal(t, int64(1000), wallet.BlueprintBalance, name)
+	}
+
+	// 다시 실행해도 기각/반환은 한 번만
+	advanced, err = service.AdvancePhases(now)
+	require.NoError(t, err)
+	assert.Zero(t, advanced)
+	var returns int64
+	env.DB.Model(&models.WalletLedgerEntry{}).Where("entry_type = ?", models.LedgerArbitrationStakeReturn).Count(&returns)
+	assert.Equal(t, int64(3), returns)
+}
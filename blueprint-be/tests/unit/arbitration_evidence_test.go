@@ -0,0 +1,98 @@
+package unit_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
+	"blueprint/internal/services"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestArbitrationEvidenceLifecycle(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.ArbitrationCase{}, &models.ArbitrationEvidence{}))
+
+	redisServer := miniredis.RunT(t)
+	moduleRedis.Client = redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+	defer func() { moduleRedis.Client = nil }()
+
+	original := &models.ArbitrationCase{
+		CaseNumber: "ARB-2026-000001", PlaintiffID: 1, DefendantID: 2, Title: "분쟁", Description: "test",
+		Status: models.ArbitrationStatusAppealed, StakeAmount: 1000,
+	}
+	require.NoError(t, db.Create(original).Error)
+	votingDeadline := time.Now().Add(48 * time.Hour)
+	appeal := &models.ArbitrationCase{
+		CaseNumber: "ARB-2026-000001-A1", PlaintiffID: 1, DefendantID: 2, Title: "분쟁", Description: "test",
+		Status: models.ArbitrationStatusVoting, StakeAmount: 2000, SelectedJurors: []uint{7},
+		ParentCaseID: &original.ID, AppealRound: 1, VotingDeadline: &votingDeadline,
+	}
+	require.NoError(t, db.Create(appeal).Error)
+
+	evidenceService := services.NewArbitrationEvidenceService(db, services.NewFileService(t.TempDir(), "http://api/files", "secret"))
+
+	// 원심 종료 후에는 제출 불가, 당사자가 아니면 제출 불가
+	file, header := uploadForm(t, "contract.pdf", []byte("%PDF-1.4 original"))
+	_, err = evidenceService.SubmitEvidence(original.ID, 1, "", []services.EvidenceUpload{{File: file, Header: header}})
+	assert.ErrorIs(t, err, services.ErrEvidenceClosed)
+	_, err = evidenceService.SubmitEvidence(appeal.ID, 9, "", []services.EvidenceUpload{{File: file, Header: header}})
+	assert.Error(t, err)
+
+	// 원심 증거는 항소심 목록에 이어서 노출
+	require.NoError(t, db.Create(&models.ArbitrationEvidence{
+		CaseID: original.ID, SubmitterID: 1, FilePath: "arbitration_evidence/abcdef0123456789abcdef0123456789", ScanStatus: models.EvidenceScanClean,
+	}).Error)
+
+	content := []byte("%PDF-1.4 appeal evidence")
+	file, header = uploadForm(t, "chat-log.pdf", content)
+	evidences, err := evidenceService.SubmitEvidence(appeal.ID, 2, "대화 기록", []services.EvidenceUpload{{File: file, Header: header}})
+	require.NoError(t, err)
+	require.Len(t, evidences, 1)
+	sum := sha256.Sum256(content)
+	assert.Equal(t, hex.EncodeToString(sum[:]), evidences[0].SHA256)
+	assert.Equal(t, models.ArbitrationStatusVoting, evidences[0].Phase)
+	assert.Equal(t, models.EvidenceScanPending, evidences[0].ScanStatus)
+
+	queued, err := moduleRedis.Client.XLen(context.Background(), "file_processing_queue").Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), queued)
+
+	bundle, err := evidenceService.ListEvidence(appeal.ID, 7) // 배심원 열람
+	require.NoError(t, err)
+	assert.Len(t, bundle, 2)
+	_, err = evidenceService.ListEvidence(appeal.ID, 9)
+	assert.ErrorIs(t, err, services.ErrEvidenceForbidden)
+
+	// 검사 전에는 다운로드 불가, 검사 통과 후 다운로드
+	_, _, _, err = evidenceService.OpenEvidence(appeal.ID, evidences[0].ID, 1)
+	assert.ErrorIs(t, err, services.ErrEvidenceNotScanned)
+
+	db.Model(&models.ArbitrationEvidence{}).Where("id = ?", evidences[0].ID).Update("scan_status", models.EvidenceScanClean)
+	opened, stored, _, err := evidenceService.OpenEvidence(appeal.ID, evidences[0].ID, 1)
+	require.NoError(t, err)
+	defer opened.Close()
+	data, err := io.ReadAll(opened)
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+	assert.Equal(t, "chat-log.pdf", stored.OriginalName)
+
+	// 투표 마감 24시간 전부터는 제출 불가
+	soon := time.Now().Add(time.Hour)
+	db.Model(appeal).Update("voting_deadline", soon)
+	file, header = uploadForm(t, "late.pdf", []byte("%PDF-1.4 late"))
+	_, err = evidenceService.SubmitEvidence(appeal.ID, 1, "", []services.EvidenceUpload{{File: file, Header: header}})
+	assert.Error(t, err)
+}
@@ -1,15 +1,18 @@
 package unit
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"testing"
 	"time"
 
 	"blueprint-module/pkg/models"
 	"blueprint/internal/services"
+	"blueprint/internal/testkit"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"github.com/gin-gonic/gin"
 )
@@ -20,54 +23,15 @@ type ArbitrationServiceTestSuite struct {
 	arbitrationService *services.ArbitrationService
 }
 
-func (suite *ArbitrationServiceTestSuite) SetupSuite() {
-	// 테스트용 인메모리 SQLite 데이터베이스 설정
-	db, err := gorm.Open(postgres.Open("host=localhost user=test password=test dbname=test_blueprint port=5432 sslmode=disable"), &gorm.Config{})
-	if err != nil {
-		// 포스트그레스 연결 실패시 테스트 스킵
-		suite.T().Skip("PostgreSQL 테스트 데이터베이스에 연결할 수 없습니다")
-		return
-	}
-	
-	suite.db = db
+func (suite *ArbitrationServiceTestSuite) SetupTest() {
+	// 테스트마다 새 in-memory DB (Postgres 없이 실행)
+	suite.db = testkit.NewDB(suite.T())
 	suite.arbitrationService = services.NewArbitrationService(suite.db)
-	
-	// 테스트에 필요한 테이블 생성
-	suite.db.AutoMigrate(
-		&models.User{},
-		&models.UserWallet{},
-		&models.ArbitrationCase{},
-		&models.ArbitrationVote{},
-		&models.JurorQualification{},
-		&models.ArbitrationReward{},
-	)
-}
 
-func (suite *ArbitrationServiceTestSuite) TearDownSuite() {
-	if suite.db != nil {
-		// 테스트 테이블 정리
-		suite.db.Exec("DROP TABLE IF EXISTS arbitration_rewards CASCADE")
-		suite.db.Exec("DROP TABLE IF EXISTS arbitration_votes CASCADE")
-		suite.db.Exec("DROP TABLE IF EXISTS arbitration_cases CASCADE")
-		suite.db.Exec("DROP TABLE IF EXISTS juror_qualifications CASCADE")
-		suite.db.Exec("DROP TABLE IF EXISTS user_wallets CASCADE")
-		suite.db.Exec("DROP TABLE IF EXISTS users CASCADE")
-	}
-}
-
-func (suite *ArbitrationServiceTestSuite) SetupTest() {
-	if suite.db == nil {
-		return
-	}
-	
-	// 각 테스트 전에 테이블 데이터 초기화
-	suite.db.Exec("TRUNCATE TABLE arbitration_rewards, arbitration_votes, arbitration_cases, juror_qualifications, user_wallets, users RESTART IDENTITY CASCADE")
-	
-	// 테스트 데이터 시드
-	user1 := &models.User{ID: 1, Email: "plaintiff@test.com"}
-	user2 := &models.User{ID: 2, Email: "defendant@test.com"}
-	suite.db.Create(user1)
-	suite.db.Create(user2)
+	// 테스트 데이터 시드 (원고 ID 1, 피고 ID 2)
+	factory := testkit.NewFactory(suite.T(), suite.db)
+	factory.User(func(u *models.User) { u.Email = "plaintiff@test.com" })
+	factory.User(func(u *models.User) { u.Email = "defendant@test.com" })
 }
 
 func (suite *ArbitrationServiceTestSuite) TestSubmitCase() {
@@ -210,6 +174,10 @@ func (suite *ArbitrationServiceTestSuite) TestCommitVote() {
 	}
 	suite.db.Create(qualification)
 
+	// 배심원은 커밋 전에 nonce를 발급받아야 함
+	_, err := suite.arbitrationService.IssueVoteNonce(arbitrationCase.ID, jurorID)
+	assert.NoError(suite.T(), err)
+
 	// 2. 투표 요청 생성
 	req := &models.JurorVoteRequest{
 		CaseID:     arbitrationCase.ID,
@@ -244,11 +212,17 @@ func (suite *ArbitrationServiceTestSuite) TestRevealVote() {
 	}
 	suite.db.Create(arbitrationCase)
 
+	// 투표 기간에 발급된 nonce와 그 nonce로 만든 커밋
+	nonce := &models.ArbitrationVoteNonce{CaseID: arbitrationCase.ID, JurorID: jurorID, Nonce: "nonce123"}
+	suite.db.Create(nonce)
+	commitHash := sha256.Sum256([]byte(fmt.Sprintf("%d:%d:%s:%s:%s",
+		arbitrationCase.ID, jurorID, models.ArbitrationDecisionPlaintiffWins, "salt123", nonce.Nonce)))
+
 	// 기존 투표 생성
 	vote := &models.ArbitrationVote{
 		CaseID:     arbitrationCase.ID,
 		JurorID:    jurorID,
-		CommitHash: "a665a45920422f9d417e4867efdc4fb8a04a1f3fff1fa07e998e86f7f7a27ae3", // SHA256("hello" + "salt123")
+		CommitHash: hex.EncodeToString(commitHash[:]), // SHA256("case_id:juror_id:vote:salt:nonce")
 		CommittedAt: &[]time.Time{time.Now()}[0],
 	}
 	suite.db.Create(vote)
@@ -0,0 +1,188 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestFinalizeCaseSettlesStakesAndRewardsJurors(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&models.UserWallet{}, &models.ArbitrationCase{}, &models.ArbitrationVote{},
+		&models.JurorQualification{}, &models.ArbitrationReward{}, &models.WalletLedgerEntry{},
+		&models.Notification{},
+	))
+
+	// 신청인 1, 피신청인 2, 배심원 3~5
+	db.Create(&models.UserWallet{UserID: 1, BlueprintBalance: 0, BlueprintLockedBalance: 10000})
+	db.Create(&models.UserWallet{UserID: 2, BlueprintBalance: 3000})
+	for _, jurorID := range []uint{3, 4, 5} {
+		db.Create(&models.UserWallet{UserID: jurorID, BlueprintBalance: 10000})
+		db.Create(&models.JurorQualification{UserID: jurorID, CurrentStake: 5000, ReputationScore: 0.5, ParticipationRate: 1})
+	}
+
+	deadline := time.Now().Add(-time.Hour)
+	arbitrationCase := &models.ArbitrationCase{
+		CaseNumber:     "ARB-TEST-1",
+		PlaintiffID:    1,
+		DefendantID:    2,
+		Title:          "test",
+		Description:    "test",
+		ClaimedAmount:  5000,
+		Status:         models.ArbitrationStatusReveal,
+		StakeAmount:    10000,
+		SelectedJurors: []uint{3, 4, 5},
+		RevealDeadline: &deadline,
+	}
+	require.NoError(t, db.Create(arbitrationCase).Error)
+
+	plaintiffWins := models.ArbitrationDecisionPlaintiffWins
+	defendantWins := models.ArbitrationDecisionDefendantWins
+	committed := time.Now().Add(-2 * time.Hour)
+	for _, vote := range []models.ArbitrationVote{
+		{CaseID: arbitrationCase.ID, JurorID: 3, JurorStake: 5000, QualificationScore: 0.5, RevealedVote: &plaintiffWins, CommittedAt: &committed, RevealedAt: &committed},
+		{CaseID: arbitrationCase.ID, JurorID: 4, JurorStake: 5000, QualificationScore: 0.5, RevealedVote: &plaintiffWins, CommittedAt: &committed, RevealedAt: &committed},
+		{CaseID: arbitrationCase.ID, JurorID: 5, JurorStake: 5000, QualificationScore: 0.5, RevealedVote: &defendantWins, CommittedAt: &committed, RevealedAt: &committed},
+	} {
+		require.NoError(t, db.Create(&vote).Error)
+	}
+
+	arbitrationService := services.NewArbitrationService(db)
+	require.NoError(t, arbitrationService.FinalizeCase(arbitrationCase.ID))
+
+	balance := func(userID uint) models.UserWallet {
+		var wallet models.UserWallet
+		db.Where("user_id = ?", userID).First(&wallet)
+		return wallet
+	}
+
+	// 신청인 스테이킹 90% 반환, 배상은 항소 기한이 지나 판결이 확정될 때 처리
+	assert.Equal(t, int64(9000), balance(1).BlueprintBalance)
+	assert.Equal(t, int64(0), balance(1).BlueprintLockedBalance)
+	assert.Equal(t, int64(3000), balance(2).BlueprintBalance)
+
+	// 소수 의견 배심원 10% 차감, 풀(수수료 1000 + 차감 500)은 다수 의견 배심원이 균등 분배
+	assert.Equal(t, int64(9500), balance(5).BlueprintBalance)
+	assert.Equal(t, int64(10750), balance(3).BlueprintBalance)
+	assert.Equal(t, int64(10750), balance(4).BlueprintBalance)
+
+	var slashedJuror models.JurorQualification
+	db.Where("user_id = ?", 5).First(&slashedJuror)
+	assert.Equal(t, int64(4500), slashedJuror.CurrentStake)
+	assert.Equal(t, 1, slashedJuror.TotalCases)
+
+	var decided models.ArbitrationCase
+	db.First(&decided, arbitrationCase.ID)
+	assert.Equal(t, models.ArbitrationStatusDecided, decided.Status)
+	assert.True(t, decided.StakeReturned)
+	require.NotNil(t, decided.AppealDeadline)
+
+	// 항소 기한 경과 → 확정 및 피신청인 잔액 한도(3000) 배상
+	advanced, err := arbitrationService.AdvancePhases(decided.AppealDeadline.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, advanced)
+	assert.Equal(t, int64(9000+3000), balance(1).BlueprintBalance)
+	assert.Equal(t, int64(0), balance(2).BlueprintBalance)
+
+	var closed models.ArbitrationCase
+	db.First(&closed, arbitrationCase.ID)
+	assert.Equal(t, models.ArbitrationStatusClosed, closed.Status)
+	assert.Equal(t, int64(3000), closed.AwardAmount)
+
+	// 사용 가능 잔액 변동 합계 = 스테이킹 반환 9000 + 수수료 분배 1000 (배상/차감은 사용자 간 이동)
+	var ledgerSum int64
+	db.Model(&models.WalletLedgerEntry{}).Select("COALESCE(SUM(amount), 0)").Scan(&ledgerSum)
+	assert.Equal(t, int64(10000), ledgerSum)
+
+	dashboard, err := arbitrationService.GetJurorDashboard(5)
+	if err == nil {
+		assert.Equal(t, int64(500), dashboard.Statistics.TotalSlashed)
+		assert.Len(t, dashboard.RecentRewards, 1)
+	}
+
+	// 이미 판결된 사건은 다시 정산하지 않음
+	assert.Error(t, arbitrationService.FinalizeCase(arbitrationCase.ID))
+}
+
+func TestAppealCaseEscalatesJuryAndStake(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.UserWallet{}, &models.ArbitrationCase{}, &models.WalletLedgerEntry{}))
+
+	db.Create(&models.UserWallet{UserID: 1, BlueprintBalance: 100000})
+	db.Create(&models.UserWallet{UserID: 2, BlueprintBalance: 100000})
+
+	appealDeadline := time.Now().Add(time.Hour)
+	original := &models.ArbitrationCase{
+		CaseNumber:     "ARB-2026-000001",
+		PlaintiffID:    1,
+		DefendantID:    2,
+		Title:          "분쟁",
+		Description:    "test",
+		Evidence:       "원심 증거",
+		Status:         models.ArbitrationStatusDecided,
+		Decision:       models.ArbitrationDecisionPlaintiffWins,
+		StakeAmount:    2000,
+		RequiredJurors: 5,
+		AppealDeadline: &appealDeadline,
+	}
+	require.NoError(t, db.Create(original).Error)
+
+	arbitrationService := services.NewArbitrationService(db)
+
+	_, err = arbitrationService.AppealCase(original.ID, 3, "부당", "", 4000)
+	assert.Error(t, err, "당사자가 아니면 항소 불가")
+	_, err = arbitrationService.AppealCase(original.ID, 2, "부당", "", 3999)
+	assert.Error(t, err, "스테이킹은 이전 심급의 두 배 이상")
+
+	result, err := arbitrationService.AppealCase(original.ID, 2, "부당", "추가 증거", 4000)
+	require.NoError(t, err)
+	appeal := result.(*models.ArbitrationCase)
+
+	assert.Equal(t, "ARB-2026-000001-A1", appeal.CaseNumber)
+	assert.Equal(t, 1, appeal.AppealRound)
+	assert.Equal(t, 11, appeal.RequiredJurors)
+	assert.Equal(t, uint(1), appeal.PlaintiffID)
+	require.NotNil(t, appeal.AppellantID)
+	assert.Equal(t, uint(2), *appeal.AppellantID)
+	assert.Contains(t, appeal.Evidence, "원심 증거")
+	assert.Contains(t, appeal.Evidence, "추가 증거")
+
+	var wallet models.UserWallet
+	db.Where("user_id = ?", 2).First(&wallet)
+	assert.Equal(t, int64(96000), wallet.BlueprintBalance)
+	assert.Equal(t, int64(4000), wallet.BlueprintLockedBalance)
+
+	var parent models.ArbitrationCase
+	db.First(&parent, original.ID)
+	assert.Equal(t, models.ArbitrationStatusAppealed, parent.Status)
+
+	_, err = arbitrationService.AppealCase(original.ID, 1, "부당", "", 4000)
+	assert.Error(t, err, "같은 판결은 한 번만 항소")
+
+	// 최종심 판결은 항소 불가
+	finalCase := &models.ArbitrationCase{
+		CaseNumber:     "ARB-2026-000002-A3",
+		PlaintiffID:    1,
+		DefendantID:    2,
+		Title:          "최종심",
+		Description:    "test",
+		Status:         models.ArbitrationStatusDecided,
+		StakeAmount:    16000,
+		AppealRound:    3,
+		IsFinal:        true,
+		AppealDeadline: &appealDeadline,
+	}
+	require.NoError(t, db.Create(finalCase).Error)
+	_, err = arbitrationService.AppealCase(finalCase.ID, 2, "부당", "", 32000)
+	assert.Error(t, err)
+}
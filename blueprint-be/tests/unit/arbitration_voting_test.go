@@ -0,0 +1,107 @@
+package unit_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func commitHash(caseID, jurorID uint, vote models.ArbitrationDecision, salt, nonce string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d:%d:%s:%s:%s", caseID, jurorID, vote, salt, nonce)))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestArbitrationCommitRevealWithNonce(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&models.UserWallet{}, &models.ArbitrationCase{}, &models.ArbitrationVote{}, &models.ArbitrationVoteNonce{},
+		&models.JurorQualification{}, &models.ArbitrationReward{}, &models.WalletLedgerEntry{}, &models.Notification{},
+	))
+
+	for _, jurorID := range []uint{3, 4, 5} {
+		db.Create(&models.UserWallet{UserID: jurorID, BlueprintBalance: 10000})
+		db.Create(&models.JurorQualification{UserID: jurorID, MinStakeAmount: 5000, CurrentStake: 5000, ReputationScore: 0.5, ParticipationRate: 1})
+	}
+	db.Create(&models.UserWallet{UserID: 1, BlueprintLockedBalance: 1000})
+
+	arbitrationCase := &models.ArbitrationCase{
+		CaseNumber: "ARB-2026-000001", PlaintiffID: 1, DefendantID: 2, Title: "분쟁", Description: "test",
+		DisputeType: models.DisputeTypePaymentIssue, Status: models.ArbitrationStatusSubmitted, StakeAmount: 1000,
+		RequiredJurors: 3, JuryFormationDeadline: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, db.Create(arbitrationCase).Error)
+
+	arbitrationService := services.NewArbitrationService(db)
+
+	// 후보 3명 → 중복 없이 3명 선정 후 투표 시작
+	advanced, err := arbitrationService.AdvancePhases(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, advanced)
+	db.First(arbitrationCase, arbitrationCase.ID)
+	assert.Equal(t, models.ArbitrationStatusVoting, arbitrationCase.Status)
+	assert.ElementsMatch(t, []uint{3, 4, 5}, arbitrationCase.SelectedJurors)
+
+	_, err = arbitrationService.IssueVoteNonce(arbitrationCase.ID, 9)
+	assert.Error(t, err, "배심원이 아니면 nonce 발급 불가")
+
+	// nonce 없이는 커밋 불가
+	_, err = arbitrationService.CommitVote(&models.JurorVoteRequest{CaseID: arbitrationCase.ID, CommitHash: commitHash(arbitrationCase.ID, 3, models.ArbitrationDecisionPlaintiffWins, "salt-salt-salt-1", "")}, 3)
+	assert.Error(t, err)
+
+	salt := "0123456789abcdef"
+	nonces := map[uint]string{}
+	for _, jurorID := range []uint{3, 4, 5} {
+		nonce, err := arbitrationService.IssueVoteNonce(arbitrationCase.ID, jurorID)
+		require.NoError(t, err)
+		again, _ := arbitrationService.IssueVoteNonce(arbitrationCase.ID, jurorID)
+		assert.Equal(t, nonce, again)
+		nonces[jurorID] = nonce
+
+		hash := commitHash(arbitrationCase.ID, jurorID, models.ArbitrationDecisionPlaintiffWins, salt, nonce)
+		_, err = arbitrationService.CommitVote(&models.JurorVoteRequest{CaseID: arbitrationCase.ID, CommitHash: hash}, jurorID)
+		require.NoError(t, err)
+	}
+
+	// 전원 커밋 → 공개 단계로 즉시 전환
+	db.First(arbitrationCase, arbitrationCase.ID)
+	require.Equal(t, models.ArbitrationStatusReveal, arbitrationCase.Status)
+
+	// 다른 배심원의 nonce/솔트로는 공개 불가
+	err = arbitrationService.RevealVote(&models.RevealVoteRequest{CaseID: arbitrationCase.ID, Vote: models.ArbitrationDecisionDefendantWins, Salt: salt}, 3)
+	assert.Error(t, err)
+
+	require.NoError(t, arbitrationService.RevealVote(&models.RevealVoteRequest{CaseID: arbitrationCase.ID, Vote: models.ArbitrationDecisionPlaintiffWins, Salt: salt}, 3))
+	require.NoError(t, arbitrationService.RevealVote(&models.RevealVoteRequest{CaseID: arbitrationCase.ID, Vote: models.ArbitrationDecisionPlaintiffWins, Salt: salt}, 4))
+	assert.Error(t, arbitrationService.RevealVote(&models.RevealVoteRequest{CaseID: arbitrationCase.ID, Vote: models.ArbitrationDecisionPlaintiffWins, Salt: salt}, 4), "중복 공개 불가")
+
+	// 공개 마감 경과: 배심원 5는 공개하지 않음 → 공개 불가, 판결 시 20% 차감
+	db.Model(arbitrationCase).Update("reveal_deadline", time.Now().Add(-time.Minute))
+	assert.Error(t, arbitrationService.RevealVote(&models.RevealVoteRequest{CaseID: arbitrationCase.ID, Vote: models.ArbitrationDecisionPlaintiffWins, Salt: salt}, 5))
+
+	_, err = arbitrationService.AdvancePhases(time.Now())
+	require.NoError(t, err)
+
+	db.First(arbitrationCase, arbitrationCase.ID)
+	assert.Equal(t, models.ArbitrationStatusDecided, arbitrationCase.Status)
+	assert.Equal(t, models.ArbitrationDecisionPlaintiffWins, arbitrationCase.Decision)
+
+	var hidden models.ArbitrationVote
+	db.Where("case_id = ? AND juror_id = ?", arbitrationCase.ID, 5).First(&hidden)
+	assert.Equal(t, int64(1000), hidden.PenaltyAmount)
+	assert.False(t, hidden.IsValid)
+
+	var qualification models.JurorQualification
+	db.Where("user_id = ?", 5).First(&qualification)
+	assert.Equal(t, int64(4000), qualification.CurrentStake)
+}
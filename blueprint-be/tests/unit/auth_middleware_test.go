@@ -0,0 +1,103 @@
+package unit_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	moduleConfig "blueprint-module/pkg/config"
+	"blueprint-module/pkg/models"
+	"blueprint/internal/config"
+	"blueprint/internal/middleware"
+	"blueprint/internal/testkit"
+	"blueprint/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const authTestSecret = "auth-middleware-test-secret"
+
+func newAuthRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{Config: moduleConfig.Config{JWT: moduleConfig.JWTConfig{Secret: authTestSecret}}}
+
+	router := gin.New()
+	ok := func(c *gin.Context) { c.String(http.StatusOK, "ok") }
+	router.GET("/me", middleware.AuthMiddleware(cfg), ok)
+	router.GET("/me/deletion", middleware.DeactivatedAccountAuthMiddleware(cfg), ok)
+	return router
+}
+
+func serveAuthorized(t *testing.T, router *gin.Engine, path string, user *models.User) int {
+	token, err := utils.GenerateToken(user, authTestSecret)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder.Code
+}
+
+// TestAuthMiddlewareRejectsDeactivatedAccounts 비활성화된 계정의 토큰은 삭제 예약 조회/취소 라우트에서만, 병합/삭제된 계정은 어디서도 통과하지 않음
+func TestAuthMiddlewareRejectsDeactivatedAccounts(t *testing.T) {
+	env := testkit.New(t)
+	router := newAuthRouter()
+	user := env.Factory.User()
+
+	assert.Equal(t, http.StatusOK, serveAuthorized(t, router, "/me", user))
+	assert.Equal(t, http.StatusOK, serveAuthorized(t, router, "/me/deletion", user))
+
+	require.NoError(t, env.DB.Model(user).Update("is_active", false).Error)
+	assert.Equal(t, http.StatusUnauthorized, serveAuthorized(t, router, "/me", user))
+	assert.Equal(t, http.StatusOK, serveAuthorized(t, router, "/me/deletion", user))
+
+	// 다른 계정에 병합된 계정은 예약 조회/취소 라우트에서도 거부
+	target := env.Factory.User()
+	require.NoError(t, env.DB.Model(user).Update("merged_into_id", target.ID).Error)
+	assert.Equal(t, http.StatusUnauthorized, serveAuthorized(t, router, "/me/deletion", user))
+
+	require.NoError(t, env.DB.Delete(user).Error)
+	assert.Equal(t, http.StatusUnauthorized, serveAuthorized(t, router, "/me", user))
+	assert.Equal(t, http.StatusUnauthorized, serveAuthorized(t, router, "/me/deletion", user))
+}
+
+// stubAPIKeyAuthenticator 서명 검증 없이 받은 본문만 기록
+type stubAPIKeyAuthenticator struct {
+	userID uint
+	body   []byte
+}
+
+func (a *stubAPIKeyAuthenticator) Authenticate(keyID, timestamp, signature, method, requestURI string, body []byte, clientIP string) (*models.APIKey, error) {
+	a.body = body
+	return &models.APIKey{UserID: a.userID, Scopes: string(models.APIKeyScopeTrade)}, nil
+}
+
+// TestAPIKeyAuthRejectsOversizedBody 서명 대상 본문이 1MB를 넘으면 잘라서 검증하지 않고 413
+func TestAPIKeyAuthRejectsOversizedBody(t *testing.T) {
+	env := testkit.New(t)
+	gin.SetMode(gin.TestMode)
+	authenticator := &stubAPIKeyAuthenticator{userID: env.Factory.User().ID}
+	cfg := &config.Config{Config: moduleConfig.Config{JWT: moduleConfig.JWTConfig{Secret: authTestSecret}}}
+
+	router := gin.New()
+	router.POST("/orders", middleware.SessionOrAPIKeyMiddleware(cfg, authenticator, models.APIKeyScopeTrade), func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	post := func(size int) int {
+		req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader(make([]byte, size)))
+		req.Header.Set(middleware.HeaderAPIKey, "bpk_test")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	assert.Equal(t, http.StatusOK, post(1<<20))
+	assert.Len(t, authenticator.body, 1<<20)
+
+	authenticator.body = nil
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post(1<<20+1))
+	assert.Nil(t, authenticator.body, "서명 검증 전에 거부")
+}
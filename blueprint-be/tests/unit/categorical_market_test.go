@@ -0,0 +1,166 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func categoricalOutcomes() []models.MilestoneOutcome {
+	return []models.MilestoneOutcome{
+		{ID: "q1", Label: "1분기"},
+		{ID: "q2", Label: "2분기"},
+		{ID: "later", Label: "그 이후"},
+	}
+}
+
+// TestValidateOutcomes 옵션 개수/ID 형식/중복 검증
+func TestValidateOutcomes(t *testing.T) {
+	assert.NoError(t, models.ValidateOutcomes(categoricalOutcomes()))
+
+	assert.ErrorIs(t, models.ValidateOutcomes(categoricalOutcomes()[:1]), models.ErrInvalidOutcomes)
+	assert.ErrorIs(t, models.ValidateOutcomes([]models.MilestoneOutcome{
+		{ID: "q1", Label: "a"}, {ID: "q1", Label: "b"},
+	}), models.ErrInvalidOutcomes)
+	assert.ErrorIs(t, models.ValidateOutcomes([]models.MilestoneOutcome{
+		{ID: "Q1", Label: "a"}, {ID: "q2", Label: "b"},
+	}), models.ErrInvalidOutcomes)
+	assert.ErrorIs(t, models.ValidateOutcomes([]models.MilestoneOutcome{
+		{ID: "q1", Label: "a"}, {ID: "q2"},
+	}), models.ErrInvalidOutcomes)
+}
+
+// TestMilestoneOptionIDs 이진 마켓은 success/fail, 다중 결과 마켓은 정의된 옵션
+func TestMilestoneOptionIDs(t *testing.T) {
+	binary := &models.Milestone{}
+	assert.Equal(t, []string{models.OptionSuccess, models.OptionFail}, binary.OptionIDs())
+	assert.True(t, binary.HasOption("success"))
+	assert.False(t, binary.HasOption("q1"))
+
+	categorical := &models.Milestone{MarketType: models.MarketTypeCategorical, OutcomesArray: categoricalOutcomes()}
+	assert.Equal(t, []string{"q1", "q2", "later"}, categorical.OptionIDs())
+	assert.True(t, categorical.HasOption("later"))
+	assert.False(t, categorical.HasOption("success"))
+}
+
+// TestInitialOutcomePrices 초기 확률은 균등 분배하거나 모두 지정해 합이 1이어야 함
+func TestInitialOutcomePrices(t *testing.T) {
+	validator := services.NewProbabilityValidator()
+	milestone := &models.Milestone{MarketType: models.MarketTypeCategorical, OutcomesArray: categoricalOutcomes()}
+
+	prices, err := validator.InitialOutcomePrices(milestone)
+	assert.NoError(t, err)
+	assert.Len(t, prices, 3)
+	assert.InDelta(t, 1.0/3, prices["q2"], 1e-9)
+
+	milestone.OutcomesArray[0].InitialPrice = 0.5
+	_, err = validator.InitialOutcomePrices(milestone)
+	assert.Error(t, err, "일부 옵션만 초기 확률 지정")
+
+	milestone.OutcomesArray[1].InitialPrice = 0.3
+	milestone.OutcomesArray[2].InitialPrice = 0.3
+	_, err = validator.InitialOutcomePrices(milestone)
+	assert.Error(t, err, "합계 1.1")
+
+	milestone.OutcomesArray[2].InitialPrice = 0.2
+	prices, err = validator.InitialOutcomePrices(milestone)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.2, prices["later"])
+
+	assert.Error(t, validator.ValidateOutcomePrices(milestone, map[string]float64{"q1": 0.5, "q2": 0.5}), "누락된 옵션")
+}
+
+// CategoricalResolutionTestSuite 다중 결과 마켓 판정 테스트 슈트
+type CategoricalResolutionTestSuite struct {
+	suite.Suite
+	db        *gorm.DB
+	lifecycle *services.MilestoneLifecycleService
+}
+
+func (suite *CategoricalResolutionTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(&models.Milestone{}, &models.MilestoneStatusHistory{}))
+	suite.db = db
+	suite.lifecycle = services.NewMilestoneLifecycleService(db, nil)
+}
+
+func (suite *CategoricalResolutionTestSuite) createMilestone(marketType models.MarketType) *models.Milestone {
+	milestone := &models.Milestone{
+		ProjectID:  1,
+		Title:      "출시 분기",
+		Order:      1,
+		Status:     models.MilestoneStatusActive,
+		MarketType: marketType,
+	}
+	if marketType == models.MarketTypeCategorical {
+		milestone.OutcomesArray = categoricalOutcomes()
+	}
+	suite.Require().NoError(suite.db.Create(milestone).Error)
+	return milestone
+}
+
+// TestResolveOutcomeRecordsWinner 거래 중 마켓을 검증 단계를 거쳐 승인하고 승리 옵션 저장
+func (suite *CategoricalResolutionTestSuite) TestResolveOutcomeRecordsWinner() {
+	milestone := suite.createMilestone(models.MarketTypeCategorical)
+
+	resolved, err := suite.lifecycle.ResolveOutcome(milestone.ID, "q2", 1)
+	suite.Require().NoError(err)
+	suite.Equal(models.MilestoneStatusProofApproved, resolved.Status)
+
+	var stored models.Milestone
+	suite.Require().NoError(suite.db.First(&stored, milestone.ID).Error)
+	suite.Equal("q2", stored.WinningOptionID)
+	suite.Len(stored.OutcomesArray, 3)
+}
+
+// TestResolveOutcomeRejectsInvalidRequests 이진 마켓과 없는 옵션은 거부
+func (suite *CategoricalResolutionTestSuite) TestResolveOutcomeRejectsInvalidRequests() {
+	binary := suite.createMilestone(models.MarketTypeBinary)
+	_, err := suite.lifecycle.ResolveOutcome(binary.ID, "success", 1)
+	suite.ErrorIs(err, services.ErrNotCategoricalMarket)
+
+	categorical := suite.createMilestone(models.MarketTypeCategorical)
+	_, err = suite.lifecycle.ResolveOutcome(categorical.ID, "q9", 1)
+	suite.ErrorIs(err, services.ErrUnknownOption)
+
+	var stored models.Milestone
+	suite.Require().NoError(suite.db.First(&stored, categorical.ID).Error)
+	suite.Equal(models.MilestoneStatusActive, stored.Status)
+	suite.Empty(stored.WinningOptionID)
+}
+
+// TestApprovalRequiresWinner 승리 옵션 없이 다중 결과 마켓을 승인할 수 없음, 이진 마켓은 자동 지정
+func (suite *CategoricalResolutionTestSuite) TestApprovalRequiresWinner() {
+	stateMachine := services.NewMilestoneStateMachine(suite.db)
+	approve := func(milestone *models.Milestone) error {
+		return suite.db.Transaction(func(tx *gorm.DB) error {
+			for _, status := range []models.MilestoneStatus{
+				models.MilestoneStatusProofSubmitted,
+				models.MilestoneStatusUnderVerification,
+				models.MilestoneStatusProofApproved,
+			} {
+				if _, err := stateMachine.Transition(tx, milestone, status, services.TransitionOptions{Reason: "test"}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	suite.ErrorIs(approve(suite.createMilestone(models.MarketTypeCategorical)), services.ErrWinningOptionRequired)
+
+	binary := suite.createMilestone(models.MarketTypeBinary)
+	suite.Require().NoError(approve(binary))
+	suite.Equal(models.OptionSuccess, binary.WinningOptionID)
+}
+
+func TestCategoricalResolutionSuite(t *testing.T) {
+	suite.Run(t, new(CategoricalResolutionTestSuite))
+}
@@ -0,0 +1,65 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestCircuitBreakerHaltsAndResumesMarket(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Milestone{}))
+	require.NoError(t, db.Create(&[]models.Milestone{
+		{ProjectID: 1, Title: "calm", Status: models.MilestoneStatusFunding},
+		{ProjectID: 1, Title: "volatile", Status: models.MilestoneStatusFunding},
+	}).Error)
+
+	breaker := services.NewCircuitBreakerService(db, nil, services.DefaultCircuitBreakerConfig)
+	trade := func(ticks int64) []models.Trade {
+		return []models.Trade{{PriceTicks: ticks, Quantity: 1}}
+	}
+	load := func(id uint) *models.Milestone {
+		var milestone models.Milestone
+		require.NoError(t, db.Select("id", "trading_halted_until").First(&milestone, id).Error)
+		return &milestone
+	}
+
+	// 10% 변동은 기준(20%) 이내
+	breaker.RecordTrades(1, "success", trade(5000))
+	breaker.RecordTrades(1, "success", trade(5500))
+	assert.NoError(t, breaker.Check(load(1)))
+
+	// 50% → 65%는 30% 상승으로 발동
+	breaker.RecordTrades(2, "success", trade(5000))
+	breaker.RecordTrades(2, "success", trade(6500))
+	assert.ErrorIs(t, breaker.Check(load(2)), services.ErrMarketHalted)
+	_, halted := breaker.HaltedUntil(2)
+	assert.True(t, halted)
+
+	require.Eventually(t, func() bool {
+		return load(2).TradingHaltedUntil != nil
+	}, time.Second, 10*time.Millisecond, "중단은 DB에 기록되어 다른 인스턴스와 공유")
+
+	// 쿨다운 전에는 유지, 이후에는 해제
+	_, resumed, err := breaker.SyncHalts(time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, resumed)
+
+	_, resumed, err = breaker.SyncHalts(time.Now().Add(services.DefaultCircuitBreakerConfig.Cooldown + time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 1, resumed)
+	assert.Nil(t, load(2).TradingHaltedUntil)
+	assert.NoError(t, breaker.Check(load(2)))
+
+	// DB에만 기록된 중단(상태 전환 등)도 주문 접수 시 거부
+	until := time.Now().Add(services.StatusChangeHaltDuration)
+	assert.ErrorIs(t, breaker.Check(&models.Milestone{ID: 1, TradingHaltedUntil: &until}), services.ErrMarketHalted)
+}
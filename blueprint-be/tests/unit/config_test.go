@@ -0,0 +1,99 @@
+package unit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	moduleConfig "blueprint-module/pkg/config"
+	"blueprint/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoadConfigResolvesSecrets 비밀 값을 _FILE 파일과 Vault 참조에서 읽고, 같은 Vault 경로는 한 번만 조회
+func TestLoadConfigResolvesSecrets(t *testing.T) {
+	vaultCalls := 0
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vaultCalls++
+		if r.Header.Get("X-Vault-Token") != "root-token" || r.URL.Path != "/v1/secret/data/blueprint" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"data":{"data":{"db_password":"db-from-vault","redis_password":"redis-from-vault"}}}`))
+	}))
+	defer vault.Close()
+
+	secretFile := filepath.Join(t.TempDir(), "jwt_secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte("jwt-from-file\n"), 0o600))
+
+	t.Setenv("VAULT_ADDR", vault.URL)
+	t.Setenv("VAULT_TOKEN", "root-token")
+	t.Setenv("JWT_SECRET_FILE", secretFile)
+	t.Setenv("DB_PASSWORD", "vault:secret/data/blueprint#db_password")
+	t.Setenv("REDIS_PASSWORD", "vault:secret/data/blueprint#redis_password")
+
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "jwt-from-file", cfg.JWT.Secret)
+	assert.Equal(t, "db-from-vault", cfg.Database.Password)
+	assert.Equal(t, "redis-from-vault", cfg.Redis.Password)
+	assert.Equal(t, "jwt-from-file", cfg.APIKey.EncryptionSecret, "미설정 시 JWT 비밀 값 사용")
+	assert.Equal(t, 1, vaultCalls)
+
+	t.Setenv("REDIS_PASSWORD", "vault:secret/data/blueprint#missing")
+	_, err = config.LoadConfig()
+	assert.ErrorIs(t, err, moduleConfig.ErrSecretUnavailable)
+}
+
+// TestLoadConfigReportsAllProblems 잘못된 설정은 시작 단계에서 한 번에 모두 보고
+func TestLoadConfigReportsAllProblems(t *testing.T) {
+	t.Setenv("GIN_MODE", "release")
+	t.Setenv("AI_PROVIDER", "openai")
+	t.Setenv("MATCHING_ENGINE_MODE", "sharded")
+	t.Setenv("GITHUB_CLIENT_ID", "gh-client")
+	t.Setenv("TRADING_PAUSED_MILESTONES", "12,abc")
+
+	_, err := config.LoadConfig()
+	require.ErrorIs(t, err, moduleConfig.ErrInvalidConfig)
+	for _, field := range []string{"JWT_SECRET", "OPENAI_API_KEY", "MATCHING_ENGINE_MODE", "GITHUB_CLIENT_SECRET", "API_KEY_ENCRYPTION_SECRET", "TRADING_PAUSED_MILESTONES"} {
+		assert.Contains(t, err.Error(), field)
+	}
+
+	// 개발 모드 기본값은 통과
+	t.Setenv("GIN_MODE", "debug")
+	t.Setenv("AI_PROVIDER", "mock")
+	t.Setenv("MATCHING_ENGINE_MODE", "local")
+	t.Setenv("GITHUB_CLIENT_ID", "")
+	t.Setenv("TRADING_PAUSED_MILESTONES", "12")
+	cfg, err := config.LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{12}, cfg.Maintenance.MilestoneIDs())
+}
+
+// TestLoadConfigInternalAPI 내부 gRPC는 포트 설정 시에만 열고, JWT 비밀 값과 다른 별도 토큰이 필수이며 기본은 루프백에 바인딩
+func TestLoadConfigInternalAPI(t *testing.T) {
+	t.Setenv("JWT_SECRET", "jwt-secret-for-internal-api-test")
+
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Internal.GRPCPort, "기본값은 띄우지 않음")
+
+	t.Setenv("INTERNAL_GRPC_PORT", "9090")
+	_, err = config.LoadConfig()
+	require.ErrorIs(t, err, moduleConfig.ErrInvalidConfig)
+	assert.Contains(t, err.Error(), "INTERNAL_API_TOKEN", "JWT 비밀 값으로 대체하지 않음")
+
+	t.Setenv("INTERNAL_API_TOKEN", "jwt-secret-for-internal-api-test")
+	_, err = config.LoadConfig()
+	require.ErrorIs(t, err, moduleConfig.ErrInvalidConfig)
+	assert.Contains(t, err.Error(), "INTERNAL_API_TOKEN")
+
+	t.Setenv("INTERNAL_API_TOKEN", "separate-internal-api-token")
+	cfg, err = config.LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:9090", cfg.Internal.ListenAddress())
+}
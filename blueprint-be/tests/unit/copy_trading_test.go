@@ -0,0 +1,180 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCopyTrading 리더 체결을 배율만큼 같은 가격 지정가로 복사, 일일 한도 초과분은 건너뛰고 매도는 보유 수량까지, 일시 중지/해제 후에는 복사 안 함
+func TestCopyTrading(t *testing.T) {
+	env := testkit.New(t)
+	engine := testkit.StartMatchingEngine(t, env.DB)
+	tradingService := services.NewTradingService(env.DB, nil, engine)
+	copyTrading := services.NewCopyTradingService(env.DB, tradingService)
+	engine.AddTradeListener(copyTrading)
+	milestone := env.Factory.Market()
+
+	request := func(side models.OrderSide, price float64, quantity int64) models.CreateOrderRequest {
+		return models.CreateOrderRequest{
+			ProjectID: milestone.ProjectID, MilestoneID: milestone.ID, OptionID: models.OptionSuccess,
+			Type: models.OrderTypeLimit, Side: side, Quantity: quantity, Price: price,
+		}
+	}
+	place := func(userID uint, side models.OrderSide, price float64, quantity int64) {
+		_, err := tradingService.CreateOrder(userID, request(side, price, quantity), "", "")
+		require.NoError(t, err)
+		testkit.Settle(t, engine)
+	}
+
+	// 리더 모드는 투자 내역 공개 사용자만
+	leader := env.Factory.FundedUser(10000)
+	_, err := copyTrading.SetLeader(leader.ID, true)
+	assert.ErrorIs(t, err, services.ErrCopyLeaderNotPublic)
+	require.NoError(t, env.DB.Create(&models.UserProfile{UserID: leader.ID, InvestmentPublic: true}).Error)
+	_, err = copyTrading.SetLeader(leader.ID, true)
+	require.NoError(t, err)
+
+	// 현재 버전 위험 고지 동의 필수, 본인 팔로우 불가, 팔로워는 리더가 될 수 없음
+	follower := env.Factory.FundedUser(5000)
+	follow := models.FollowTraderRequest{SizeScale: 0.5, MaxOrderNotional: 1500, MaxDailyNotional: 2000, DisclaimerVersion: models.CopyTradingDisclaimerVersion}
+	_, err = copyTrading.Follow(follower.ID, leader.ID, follow)
+	assert.ErrorIs(t, err, services.ErrCopyDisclaimerRequired)
+	follow.AcceptDisclaimer = true
+	_, err = copyTrading.Follow(leader.ID, leader.ID, follow)
+	assert.ErrorIs(t, err, services.ErrCopyFollowSelf)
+
+	followed, err := copyTrading.Follow(follower.ID, leader.ID, follow)
+	require.NoError(t, err)
+	require.NoError(t, env.DB.Create(&models.UserProfile{UserID: follower.ID, InvestmentPublic: true}).Error)
+	_, err = copyTrading.SetLeader(follower.ID, true)
+	assert.ErrorIs(t, err, services.ErrCopyChainNotAllowed)
+
+	leaders, err := copyTrading.ListLeaders()
+	require.NoError(t, err)
+	require.Len(t, leaders, 1)
+	assert.Equal(t, 1, leaders[0].FollowerCount)
+
+	// 40주 매수 → 20주 복사(1000센트), 다시 40주 → 일일 한도 남은 1000센트로 20주, 10주 → 한도 소진으로 건너뜀
+	seller := env.Factory.FundedUser(0)
+	env.Factory.Position(seller.ID, milestone, models.OptionSuccess, 200, 0.3)
+	place(seller.ID, models.OrderSideSell, 0.5, 130)
+	place(leader.ID, models.OrderSideBuy, 0.5, 40)
+	place(leader.ID, models.OrderSideBuy, 0.5, 40)
+	place(leader.ID, models.OrderSideBuy, 0.5, 10)
+
+	executions, err := copyTrading.ListExecutions(follower.ID)
+	require.NoError(t, err)
+	require.Len(t, executions, 3)
+	assert.Equal(t, models.CopyTradeSkipped, executions[0].Status)
+	assert.Zero(t, executions[0].Quantity)
+	for _, execution := range executions[1:] {
+		assert.Equal(t, models.CopyTradePlaced, execution.Status)
+		assert.Equal(t, int64(20), execution.Quantity)
+		assert.Equal(t, int64(1000), execution.Notional)
+		require.NotNil(t, execution.OrderID)
+	}
+
+	var held models.Position
+	require.NoError(t, env.DB.Where("user_id = ? AND milestone_id = ?", follower.ID, milestone.ID).First(&held).Error)
+	assert.Equal(t, int64(40), held.Quantity)
+
+	// 매도 복사: 리더 60주 매도 → 30주, 보유 40주 안이므로 그대로
+	bidder := env.Factory.FundedUser(10000)
+	place(bidder.ID, models.OrderSideBuy, 0.4, 100)
+	place(leader.ID, models.OrderSideSell, 0.4, 60)
+
+	executions, err = copyTrading.ListExecutions(follower.ID)
+	require.NoError(t, err)
+	require.Len(t, executions, 4)
+	assert.Equal(t, models.OrderSideSell, executions[0].Side)
+	assert.Equal(t, models.CopyTradePlaced, executions[0].Status)
+	assert.Equal(t, int64(30), executions[0].Quantity)
+
+	// 일시 중지/해제 후에는 복사하지 않음
+	paused := models.CopyFollowPaused
+	_, err = copyTrading.UpdateFollow(followed.ID, follower.ID, models.UpdateCopyFollowRequest{Status: &paused})
+	require.NoError(t, err)
+	place(leader.ID, models.OrderSideSell, 0.4, 10)
+
+	require.NoError(t, copyTrading.Unfollow(followed.ID, follower.ID))
+	place(leader.ID, models.OrderSideSell, 0.4, 10)
+
+	executions, err = copyTrading.ListExecutions(follower.ID)
+	require.NoError(t, err)
+	assert.Len(t, executions, 4)
+
+	follows, err := copyTrading.ListFollows(follower.ID)
+	require.NoError(t, err)
+	assert.Empty(t, follows)
+	leaders, err = copyTrading.ListLeaders()
+	require.NoError(t, err)
+	assert.Zero(t, leaders[0].FollowerCount)
+}
+
+// TestCopyTradingOrderChecks 복사 주문도 본인 인증 주문 한도와 주문 전 리스크 한도를 거치며, 넘으면 실패로 기록하고 잠그지 않음
+func TestCopyTradingOrderChecks(t *testing.T) {
+	env := testkit.New(t)
+	engine := testkit.StartMatchingEngine(t, env.DB)
+	tradingService := services.NewTradingService(env.DB, nil, engine)
+	tradingService.SetKYC(services.NewKYCService(env.DB, &services.ManualKYCProvider{}, nil))
+	tradingService.SetPreTradeRisk(services.NewPreTradeRiskService(env.DB, services.NewPortfolioSnapshotService(env.DB), services.RiskLimits{
+		MaxMarketPosition: 150,
+	}))
+	copyTrading := services.NewCopyTradingService(env.DB, tradingService)
+	engine.AddTradeListener(copyTrading)
+	milestone := env.Factory.Market()
+
+	place := func(userID uint, side models.OrderSide, quantity int64) {
+		_, err := tradingService.CreateOrder(userID, models.CreateOrderRequest{
+			ProjectID: milestone.ProjectID, MilestoneID: milestone.ID, OptionID: models.OptionSuccess,
+			Type: models.OrderTypeLimit, Side: side, Quantity: quantity, Price: 0.5,
+		}, "", "")
+		require.NoError(t, err)
+		testkit.Settle(t, engine)
+	}
+
+	leader := env.Factory.FundedUser(100000)
+	require.NoError(t, env.DB.Create(&models.KYCVerification{UserID: leader.ID, Tier: models.KYCTierEnhanced, Status: models.KYCStatusApproved}).Error)
+	require.NoError(t, env.DB.Create(&models.UserProfile{UserID: leader.ID, InvestmentPublic: true}).Error)
+	_, err := copyTrading.SetLeader(leader.ID, true)
+	require.NoError(t, err)
+
+	// 미인증 팔로워는 주문당 $100까지
+	follower := env.Factory.FundedUser(100000)
+	_, err = copyTrading.Follow(follower.ID, leader.ID, models.FollowTraderRequest{
+		SizeScale: 4, MaxOrderNotional: 50000, MaxDailyNotional: 100000,
+		DisclaimerVersion: models.CopyTradingDisclaimerVersion, AcceptDisclaimer: true,
+	})
+	require.NoError(t, err)
+
+	seller := env.Factory.FundedUser(0)
+	env.Factory.Position(seller.ID, milestone, models.OptionSuccess, 180, 0.3)
+	place(seller.ID, models.OrderSideSell, 180)
+
+	// 4배 복사: 40주($20)는 체결, 300주($150)는 인증 한도 초과, 140주($70)는 시장별 포지션 한도(보유 40 + 140 > 150) 초과
+	place(leader.ID, models.OrderSideBuy, 10)
+	place(leader.ID, models.OrderSideBuy, 75)
+	place(leader.ID, models.OrderSideBuy, 35)
+
+	executions, err := copyTrading.ListExecutions(follower.ID)
+	require.NoError(t, err)
+	require.Len(t, executions, 3)
+	assert.Equal(t, models.CopyTradeFailed, executions[0].Status)
+	assert.Contains(t, executions[0].Reason, services.ErrRiskLimitExceeded.Error())
+	assert.Equal(t, models.CopyTradeFailed, executions[1].Status)
+	assert.Contains(t, executions[1].Reason, services.ErrKYCOrderLimitExceeded.Error())
+	assert.Equal(t, models.CopyTradePlaced, executions[2].Status)
+
+	var wallet models.UserWallet
+	require.NoError(t, env.DB.Where("user_id = ?", follower.ID).First(&wallet).Error)
+	assert.Zero(t, wallet.USDCLockedBalance, "거절된 복사 주문은 잠그지 않음")
+	var held models.Position
+	require.NoError(t, env.DB.Where("user_id = ? AND milestone_id = ?", follower.ID, milestone.ID).First(&held).Error)
+	assert.Equal(t, int64(40), held.Quantity)
+}
@@ -51,7 +51,7 @@ func (suite *CQRSTestSuite) SetupSuite() {
 	})
 
 	// CQRS 컴포넌트 초기화
-	suite.matchingEngine = services.NewDistributedMatchingEngine(suite.db, nil)
+	suite.matchingEngine = services.NewDistributedMatchingEngineWithRedis(suite.db, nil, suite.redisClient)
 	suite.commandHandler = services.NewTradingCommandHandler(suite.matchingEngine)
 	suite.queryHandler = services.NewTradingQueryHandler(suite.redisClient, suite.db)
 
@@ -115,9 +115,14 @@ func (suite *CQRSTestSuite) TestCancelOrderCommand() {
 		OrderID: 1,
 	}
 
-	// 명령 실행
-	err := suite.commandHandler.HandleCancelOrder(cmd)
-	suite.Assert().NoError(err)
+	// 명령 실행 (주문장 제거 후 DB 취소, 미체결분 매수 잠금 해제)
+	result, err := suite.commandHandler.HandleCancelOrder(cmd)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(models.OrderStatusCancelled, result.Order.Status)
+	suite.Assert().Equal(int64(7519), result.ReleasedAmount) // 주문 금액 7500 + 최대 수수료 19
+
+	_, err = suite.commandHandler.HandleCancelOrder(cmd)
+	suite.Assert().ErrorIs(err, services.ErrOrderNotOpen)
 }
 
 // TestMarketDataQuery 마켓 데이터 조회 테스트
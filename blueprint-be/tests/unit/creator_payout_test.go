@@ -0,0 +1,102 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreatorPayoutEarningsAndReview 후원금과 미분배 멘토 풀이 지급 가능 금액이 되고, 요청 시 잠금, 반려 시 해제
+func TestCreatorPayoutEarningsAndReview(t *testing.T) {
+	env := testkit.New(t)
+	escrowService := services.NewFundingEscrowService(env.DB, nil)
+	payoutService := services.NewCreatorPayoutService(env.DB, "test-secret")
+
+	owner := env.Factory.FundedUser(0)
+	admin := env.Factory.User()
+	project := env.Factory.Project(owner.ID)
+	milestone := env.Factory.Milestone(project.ID, func(m *models.Milestone) { m.Status = models.MilestoneStatusFunding })
+	backer := env.Factory.FundedUser(10000)
+
+	wallet := func() models.UserWallet {
+		var w models.UserWallet
+		require.NoError(t, env.DB.Where("user_id = ?", owner.ID).First(&w).Error)
+		return w
+	}
+
+	// 후원금 3000 지급 + 자격 멘토가 없어 남은 멘토 풀 500
+	_, err := escrowService.Deposit(backer.ID, milestone.ID, 3000)
+	require.NoError(t, err)
+	_, err = escrowService.ReleaseMilestone(milestone.ID)
+	require.NoError(t, err)
+	require.NoError(t, env.DB.Create(&models.MentorPool{
+		MilestoneID:     milestone.ID,
+		ProjectID:       project.ID,
+		TotalPoolAmount: 500,
+		IsDistributed:   true,
+	}).Error)
+	share, err := payoutService.CreditMentorPoolShare(milestone.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), share)
+	share, err = payoutService.CreditMentorPoolShare(milestone.ID)
+	require.NoError(t, err)
+	assert.Zero(t, share, "풀마다 한 번만 지급")
+
+	earnings, err := payoutService.GetEarnings(owner.ID)
+	require.NoError(t, err)
+	require.Len(t, earnings.Milestones, 1)
+	assert.Equal(t, int64(3000), earnings.Milestones[0].EscrowAmount)
+	assert.Equal(t, int64(500), earnings.Milestones[0].MentorPoolShare)
+	assert.Equal(t, int64(3500), earnings.Payable)
+
+	// 계좌 없이 요청하면 거부, 계좌번호는 마스킹
+	_, err = payoutService.RequestPayout(owner.ID, models.CreateCreatorPayoutRequest{Amount: 1000})
+	assert.ErrorIs(t, err, services.ErrPayoutAccountRequired)
+	account, err := payoutService.RegisterAccount(owner.ID, models.RegisterPayoutAccountRequest{
+		Type:          models.PayoutAccountBank,
+		BankName:      "Test Bank",
+		AccountHolder: "Owner",
+		AccountNumber: "110-123-456789",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "****6789", account.MaskedDetails)
+	assert.True(t, account.IsDefault)
+	assert.NotContains(t, account.DetailsEncrypted, "456789")
+
+	_, err = payoutService.RequestPayout(owner.ID, models.CreateCreatorPayoutRequest{Amount: 4000})
+	assert.ErrorIs(t, err, services.ErrPayoutExceedsEarnings)
+
+	payout, err := payoutService.RequestPayout(owner.ID, models.CreateCreatorPayoutRequest{Amount: 2000})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), wallet().USDCBalance)
+	assert.Equal(t, int64(2000), wallet().USDCLockedBalance)
+	earnings, err = payoutService.GetEarnings(owner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), earnings.Pending)
+	assert.Equal(t, int64(1500), earnings.Payable)
+
+	// 반려: 잠금 해제, 다시 반려 불가
+	rejected, err := payoutService.RejectPayout(admin.ID, payout.ID, "계좌 확인 필요")
+	require.NoError(t, err)
+	assert.Equal(t, models.CreatorPayoutRejected, rejected.Status)
+	assert.Equal(t, int64(3500), wallet().USDCBalance)
+	assert.Zero(t, wallet().USDCLockedBalance)
+	_, err = payoutService.RejectPayout(admin.ID, payout.ID, "중복")
+	assert.ErrorIs(t, err, services.ErrPayoutNotReviewable)
+
+	// 승인: 워커가 송금할 때까지 잠금 유지
+	payout, err = payoutService.RequestPayout(owner.ID, models.CreateCreatorPayoutRequest{Amount: 3500})
+	require.NoError(t, err)
+	approved, err := payoutService.ApprovePayout(admin.ID, payout.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.CreatorPayoutApproved, approved.Status)
+	assert.NotNil(t, approved.ScheduledFor)
+	assert.Equal(t, int64(3500), wallet().USDCLockedBalance)
+	_, err = payoutService.ApprovePayout(admin.ID, payout.ID, nil)
+	assert.ErrorIs(t, err, services.ErrPayoutNotReviewable)
+}
@@ -0,0 +1,137 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMultiCurrencyWallet 추가 결제 통화는 환율로 환전하고, 그 통화 마켓의 주문 잠금/체결이 통화 계정에서 일어남
+func TestMultiCurrencyWallet(t *testing.T) {
+	env := testkit.New(t)
+	engine := testkit.StartMatchingEngine(t, env.DB)
+	tradingService := services.NewTradingService(env.DB, nil, engine)
+	currencies := services.NewCurrencyService(env.DB, []string{"KRWS"}, 0)
+	krws := models.LedgerCurrency("krws")
+
+	balance := func(userID uint, currency models.LedgerCurrency) models.WalletBalance {
+		accounts, err := currencies.ListAccounts(userID)
+		require.NoError(t, err)
+		for _, account := range accounts {
+			if account.Currency == currency {
+				return account
+			}
+		}
+		t.Fatalf("%s 계정 없음", currency)
+		return models.WalletBalance{}
+	}
+
+	// 환율이 없으면 환전 불가, 설정에 없는 통화는 환율도 지정 불가
+	alice := env.Factory.FundedUser(10000)
+	_, err := currencies.Convert(alice.ID, models.ConvertCurrencyRequest{From: models.LedgerCurrencyUSDC, To: krws, Amount: 100})
+	assert.ErrorIs(t, err, services.ErrExchangeRateNotFound)
+	_, err = currencies.SetRate("usdt", 1, 1)
+	assert.ErrorIs(t, err, services.ErrUnsupportedCurrency)
+
+	// 1 KRWS = 0.001 USDC → 50 USDC(5000센트)는 50000 KRWS
+	_, err = currencies.SetRate(krws, 0.001, 1)
+	require.NoError(t, err)
+	conversion, err := currencies.Convert(alice.ID, models.ConvertCurrencyRequest{From: models.LedgerCurrencyUSDC, To: krws, Amount: 5000})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000000), conversion.Converted)
+	assert.Equal(t, int64(5000), balance(alice.ID, models.LedgerCurrencyUSDC).Available)
+	assert.Equal(t, int64(5000000), balance(alice.ID, krws).Available)
+
+	// KRWS 마켓 매수는 KRWS를 잠그고, 체결되면 매도자 KRWS 계정으로 대금이 이동
+	milestone := env.Factory.Market()
+	_, err = currencies.SetMarketQuoteCurrency(milestone.ID, krws)
+	require.NoError(t, err)
+
+	place := func(userID uint, side models.OrderSide, price float64, quantity int64) error {
+		_, err := tradingService.CreateOrder(userID, models.CreateOrderRequest{
+			ProjectID: milestone.ProjectID, MilestoneID: milestone.ID, OptionID: models.OptionSuccess,
+			Type: models.OrderTypeLimit, Side: side, Quantity: quantity, Price: price,
+		}, "", "")
+		testkit.Settle(t, engine)
+		return err
+	}
+
+	require.NoError(t, place(alice.ID, models.OrderSideBuy, 0.5, 100))
+	assert.Equal(t, int64(5013), balance(alice.ID, krws).Locked, "주문 금액 5000 + 최대 수수료 13")
+	assert.Equal(t, int64(5000), balance(alice.ID, models.LedgerCurrencyUSDC).Available)
+
+	// 주문이 들어온 뒤에는 결제 통화 변경 불가
+	_, err = currencies.SetMarketQuoteCurrency(milestone.ID, models.LedgerCurrencyUSDC)
+	assert.ErrorIs(t, err, services.ErrQuoteCurrencyLocked)
+
+	// 보유 수량보다 많이 매도(숏)는 USDC 마켓에서만
+	bob := env.Factory.FundedUser(10000)
+	assert.ErrorIs(t, place(bob.ID, models.OrderSideSell, 0.5, 100), services.ErrShortSellingUnavailable)
+
+	env.Factory.Position(bob.ID, milestone, models.OptionSuccess, 100, 0.3)
+	require.NoError(t, place(bob.ID, models.OrderSideSell, 0.5, 100))
+
+	var trade models.Trade
+	require.NoError(t, env.DB.Where("milestone_id = ?", milestone.ID).First(&trade).Error)
+	assert.Equal(t, krws, trade.QuoteCurrency)
+
+	buyer := balance(alice.ID, krws)
+	assert.Zero(t, buyer.Locked)
+	assert.Equal(t, int64(5000000)-trade.TotalAmount-trade.BuyerFee, buyer.Available)
+	assert.Equal(t, trade.TotalAmount-trade.SellerFee, balance(bob.ID, krws).Available)
+	assert.Equal(t, int64(10000), balance(bob.ID, models.LedgerCurrencyUSDC).Available)
+}
+
+// TestBlueprintConversion BLUEPRINT는 관리자 환율로 USDC와만 환전하고, 하루 한도를 넘으면 거부하며 양쪽 원장이 내역에 남음
+func TestBlueprintConversion(t *testing.T) {
+	env := testkit.New(t)
+	currencies := services.NewCurrencyService(env.DB, []string{"krws"}, 6000)
+
+	user := env.Factory.FundedUser(10000)
+	require.NoError(t, env.DB.Model(&models.UserWallet{}).Where("user_id = ?", user.ID).Update("blueprint_balance", 300).Error)
+
+	// 1 BLUEPRINT = 0.5 USDC → 100 BLUEPRINT는 5000센트
+	_, err := currencies.SetRate(models.LedgerCurrencyBlueprint, 0.5, 1)
+	require.NoError(t, err)
+	conversion, err := currencies.Convert(user.ID, models.ConvertCurrencyRequest{From: models.LedgerCurrencyBlueprint, To: models.LedgerCurrencyUSDC, Amount: 100})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), conversion.Converted)
+	assert.Equal(t, int64(5000), conversion.USDCValue)
+	assert.Equal(t, int64(1000), conversion.DailyRemaining)
+
+	var wallet models.UserWallet
+	require.NoError(t, env.DB.Where("user_id = ?", user.ID).First(&wallet).Error)
+	assert.Equal(t, int64(200), wallet.BlueprintBalance)
+	assert.Equal(t, int64(15000), wallet.USDCBalance)
+
+	// USDC → BLUEPRINT는 내림 (999센트 = 19.98 BLUEPRINT → 19)
+	quote, err := currencies.Quote(models.LedgerCurrencyUSDC, models.LedgerCurrencyBlueprint, 999)
+	require.NoError(t, err)
+	assert.Equal(t, int64(19), quote.Converted)
+
+	// 오늘 남은 한도 1000센트를 넘는 환전은 거부, BLUEPRINT는 다른 결제 통화와 환전 불가
+	_, err = currencies.Convert(user.ID, models.ConvertCurrencyRequest{From: models.LedgerCurrencyUSDC, To: models.LedgerCurrencyBlueprint, Amount: 1001})
+	assert.ErrorIs(t, err, services.ErrDailyConvertLimit)
+	_, err = currencies.Quote(models.LedgerCurrencyBlueprint, "krws", 10)
+	assert.ErrorIs(t, err, services.ErrUnsupportedCurrency)
+
+	// 두 통화 원장이 같은 환전 기록을 참조
+	entries, err := currencies.History(user.ID, models.WalletHistoryQuery{})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, models.LedgerCurrencyConvertIn, entries[0].EntryType)
+	assert.Equal(t, int64(5000), entries[0].Amount)
+	assert.Equal(t, models.LedgerCurrencyConvertOut, entries[1].EntryType)
+	assert.Equal(t, int64(-100), entries[1].Amount)
+	assert.Equal(t, conversion.SwapID, entries[0].ReferenceID)
+	assert.Equal(t, conversion.SwapID, entries[1].ReferenceID)
+
+	blueprintOnly, err := currencies.History(user.ID, models.WalletHistoryQuery{Currency: models.LedgerCurrencyBlueprint})
+	require.NoError(t, err)
+	assert.Len(t, blueprintOnly, 1)
+}
@@ -0,0 +1,115 @@
+package unit_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/queue"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDeadLetterReplay 재시도를 소진한 작업은 DLQ에서 조회/재발행/삭제되고, /metrics에 크기와 경보가 나온다
+func TestDeadLetterReplay(t *testing.T) {
+	env := testkit.New(t)
+	jobs := services.NewJobService(env.DB)
+	deadLetters := services.NewDeadLetterService(2)
+
+	var ids []string
+	for _, to := range []string{"a@example.com", "b@example.com"} {
+		id, err := queue.PublishTrackedJob("email_queue", map[string]interface{}{"type": "send_email", "to": to})
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		queue.ConsumeJobsWithPolicy(ctx, "email_queue", "email_workers", "test_worker", queue.RetryPolicy{MaxRetries: 0},
+			func(map[string]interface{}) error { return errors.New("smtp down") })
+	}()
+	require.Eventually(t, func() bool {
+		for _, id := range ids {
+			if job, err := jobs.GetJob(id); err != nil || job.Status != models.JobFailed {
+				return false
+			}
+		}
+		return true
+	}, 3*time.Second, 20*time.Millisecond)
+	cancel()
+	<-done
+
+	queues, err := deadLetters.ListQueues()
+	require.NoError(t, err)
+	require.Len(t, queues, 1)
+	assert.Equal(t, "email_queue", queues[0].Queue)
+	assert.Equal(t, int64(2), queues[0].Size)
+
+	var metrics bytes.Buffer
+	require.NoError(t, deadLetters.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), `blueprint_dlq_messages{queue="email_queue"} 2`)
+	assert.Contains(t, metrics.String(), `blueprint_dlq_alert{queue="email_queue"} 1`)
+
+	letters, err := deadLetters.List("email_queue", 10, "")
+	require.NoError(t, err)
+	require.Len(t, letters, 2)
+	assert.Equal(t, queue.DeadLetterJob, letters[0].Kind)
+	assert.Equal(t, "smtp down", letters[0].LastError)
+	assert.Equal(t, ids[1], letters[0].JobID, "최신순")
+
+	letter, err := deadLetters.Get("email_queue", letters[1].ID)
+	require.NoError(t, err)
+	assert.Contains(t, string(letter.Payload), "a@example.com")
+
+	_, err = deadLetters.Get("email_queue:dlq", letters[1].ID)
+	assert.ErrorIs(t, err, services.ErrInvalidDeadLetterQueue)
+
+	result, err := deadLetters.Replay("email_queue", []string{letters[1].ID, "0-1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{letters[1].ID}, result.Replayed)
+	assert.Contains(t, result.Failed, "0-1")
+
+	replayed, err := jobs.GetJob(ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, models.JobQueued, replayed.Status)
+	assert.Nil(t, replayed.FinishedAt)
+
+	// 재발행한 작업은 재시도 횟수 없이 다시 처리된다
+	processed := make(chan map[string]interface{}, 1)
+	ctx, cancel = context.WithCancel(context.Background())
+	done = make(chan struct{})
+	go func() {
+		defer close(done)
+		queue.ConsumeJobsWithPolicy(ctx, "email_queue", "email_workers", "test_worker", queue.DefaultRetryPolicy,
+			func(data map[string]interface{}) error {
+				processed <- data
+				return nil
+			})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	select {
+	case data := <-processed:
+		assert.Equal(t, "a@example.com", data["to"])
+		assert.NotContains(t, data, "retry_count")
+	case <-time.After(3 * time.Second):
+		t.Fatal("replayed job was not consumed")
+	}
+
+	removed, err := deadLetters.Purge("email_queue", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+	queues, err = deadLetters.ListQueues()
+	require.NoError(t, err)
+	assert.Empty(t, queues)
+}
@@ -0,0 +1,86 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNotificationDigest 포지션 가격 변동, 마감 임박 마켓, 미투표 증거, 배심원 기한을 모아 주기마다 한 번 보내고 서명 토큰으로 수신 거부
+func TestNotificationDigest(t *testing.T) {
+	env := testkit.New(t)
+	service := services.NewDigestService(env.DB, "digest-secret")
+	now := time.Now()
+
+	user, other := env.Factory.User(), env.Factory.User()
+	require.NoError(t, env.DB.Create(&models.UserProfile{
+		UserID:             user.ID,
+		EmailNotifications: true,
+		DigestFrequency:    models.DigestWeekly,
+		Locale:             "ko",
+	}).Error)
+
+	// 가격 40¢ → 55¢, 이틀 뒤 마감
+	closesAt := now.Add(48 * time.Hour)
+	market := env.Factory.Market(func(m *models.Milestone) { m.TargetDate = &closesAt })
+	env.Factory.Position(user.ID, market, models.OptionSuccess, 10, 0.4)
+	require.NoError(t, env.DB.Create(&[]models.Trade{
+		{MilestoneID: market.ID, OptionID: models.OptionSuccess, BuyerID: other.ID, SellerID: user.ID, Quantity: 1, PriceTicks: 4000, CreatedAt: now.Add(-8 * 24 * time.Hour)},
+		{MilestoneID: market.ID, OptionID: models.OptionSuccess, BuyerID: other.ID, SellerID: user.ID, Quantity: 1, PriceTicks: 5500, CreatedAt: now.Add(-time.Hour)},
+	}).Error)
+
+	// 검증 대기 증거 중 아직 투표하지 않은 것만
+	require.NoError(t, env.DB.Create(&models.ValidatorQualification{UserID: user.ID}).Error)
+	proofs := []models.MilestoneProof{
+		{MilestoneID: market.ID, UserID: other.ID, Title: "pending", Status: models.ProofStatusUnderReview, ReviewDeadline: now.Add(24 * time.Hour)},
+		{MilestoneID: market.ID, UserID: other.ID, Title: "voted", Status: models.ProofStatusUnderReview, ReviewDeadline: now.Add(24 * time.Hour)},
+	}
+	require.NoError(t, env.DB.Create(&proofs).Error)
+	require.NoError(t, env.DB.Create(&models.ProofValidator{ProofID: proofs[1].ID, UserID: user.ID, Vote: "approve"}).Error)
+
+	// 투표를 제출하지 않은 배심원 기한만
+	votingDeadline := now.Add(72 * time.Hour)
+	cases := []models.ArbitrationCase{
+		{CaseNumber: "ARB-1", Title: "open", Status: models.ArbitrationStatusVoting, PlaintiffID: other.ID, SelectedJurors: []uint{user.ID}, VotingDeadline: &votingDeadline},
+		{CaseNumber: "ARB-2", Title: "committed", Status: models.ArbitrationStatusVoting, PlaintiffID: other.ID, SelectedJurors: []uint{user.ID}, VotingDeadline: &votingDeadline},
+	}
+	require.NoError(t, env.DB.Create(&cases).Error)
+	require.NoError(t, env.DB.Create(&models.ArbitrationVote{CaseID: cases[1].ID, JurorID: user.ID, CommittedAt: &now}).Error)
+
+	digest, err := service.Build(user.ID, models.DigestWeekly, now.Add(-7*24*time.Hour), now)
+	require.NoError(t, err)
+	require.Len(t, digest.Positions, 1)
+	assert.Equal(t, 15.0, digest.Positions[0].ChangePercent)
+	require.Len(t, digest.Closing, 1)
+	assert.Equal(t, market.ID, digest.Closing[0].MilestoneID)
+	require.Len(t, digest.Proofs, 1)
+	assert.Equal(t, proofs[0].ID, digest.Proofs[0].ProofID)
+	require.Len(t, digest.Arbitration, 1)
+	assert.Equal(t, "ARB-1", digest.Arbitration[0].CaseNumber)
+	assert.Equal(t, "juror", digest.Arbitration[0].Role)
+
+	// 주기당 한 번만 발송
+	sent, err := service.SendDue(now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	sent, err = service.SendDue(now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+
+	// 수신 거부
+	_, err = service.Unsubscribe(service.UnsubscribeToken(user.ID) + "x")
+	assert.ErrorIs(t, err, services.ErrInvalidUnsubscribeToken)
+	userID, err := service.Unsubscribe(service.UnsubscribeToken(user.ID))
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, userID)
+
+	var profile models.UserProfile
+	require.NoError(t, env.DB.First(&profile, "user_id = ?", user.ID).Error)
+	assert.Equal(t, models.DigestOff, profile.DigestFrequency)
+}
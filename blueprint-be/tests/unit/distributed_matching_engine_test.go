@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
 	"blueprint/internal/services"
 	"github.com/alicebob/miniredis/v2"
 	"github.com/redis/go-redis/v9"
@@ -30,6 +31,11 @@ func (suite *DistributedMatchingEngineTestSuite) SetupSuite() {
 	suite.Require().NoError(err)
 	suite.db = db
 
+	// 체결 후처리 고루틴도 같은 in-memory DB를 보도록 연결 하나로 고정
+	sqlDB, err := db.DB()
+	suite.Require().NoError(err)
+	sqlDB.SetMaxOpenConns(1)
+
 	// 테이블 마이그레이션
 	err = db.AutoMigrate(
 		&models.User{},
@@ -38,8 +44,10 @@ func (suite *DistributedMatchingEngineTestSuite) SetupSuite() {
 		&models.Order{},
 		&models.Trade{},
 		&models.Position{},
+		&models.PositionTransfer{},
 		&models.MarketData{},
 		&models.UserWallet{},
+		&models.MentorPool{},
 	)
 	suite.Require().NoError(err)
 
@@ -48,13 +56,13 @@ func (suite *DistributedMatchingEngineTestSuite) SetupSuite() {
 	suite.redisClient = redis.NewClient(&redis.Options{
 		Addr: suite.redisServer.Addr(),
 	})
+	moduleRedis.Client = suite.redisClient // 체결 후처리의 가격 캐시/브로드캐스트
 
-	// 분산 매칭 엔진 초기화 (테스트용 Redis 클라이언트 사용)
-	suite.engine = services.NewDistributedMatchingEngineWithRedis(suite.db, nil, suite.redisClient)
 }
 
 // TearDownSuite 테스트 슈트 정리
 func (suite *DistributedMatchingEngineTestSuite) TearDownSuite() {
+	moduleRedis.Client = nil
 	suite.redisServer.Close()
 	suite.redisClient.Close()
 }
@@ -63,6 +71,9 @@ func (suite *DistributedMatchingEngineTestSuite) TearDownSuite() {
 func (suite *DistributedMatchingEngineTestSuite) SetupTest() {
 	// Redis 데이터 초기화
 	suite.redisServer.FlushAll()
+
+	// 분산 매칭 엔진 초기화 (테스트마다 새 인스턴스)
+	suite.engine = services.NewDistributedMatchingEngineWithRedis(suite.db, nil, suite.redisClient)
 }
 
 // TestOrderBookCreation 주문장 생성 테스트
@@ -144,6 +155,97 @@ func (suite *DistributedMatchingEngineTestSuite) TestOrderMatching() {
 	suite.Assert().Equal(0.70, trade.Price)         // 매도 주문 가격으로 거래
 }
 
+// TestRestartAfterStop 정지 후 다시 시작하면 마켓 리스를 다시 잡고 주문 스트림을 처리
+func (suite *DistributedMatchingEngineTestSuite) TestRestartAfterStop() {
+	suite.createTestData()
+
+	suite.Require().NoError(suite.engine.Start())
+	suite.Require().NoError(suite.engine.Stop())
+	suite.Assert().Empty(suite.engine.Ownership().OwnedMarkets(), "정지 시 리스 반납")
+
+	suite.Require().NoError(suite.engine.Start())
+	defer suite.engine.Stop()
+	suite.Assert().True(suite.engine.Ownership().Owns("1:success"))
+
+	_, err := suite.engine.SubmitOrder(&models.Order{
+		UserID: 2, MilestoneID: 1, OptionID: "success", Side: models.OrderSideSell,
+		Quantity: 20, Price: 0.60, Status: models.OrderStatusPending, CreatedAt: time.Now(),
+	})
+	suite.Require().NoError(err)
+
+	result, err := suite.engine.SubmitOrder(&models.Order{
+		UserID: 1, MilestoneID: 1, OptionID: "success", Side: models.OrderSideBuy,
+		Quantity: 20, Price: 0.60, Status: models.OrderStatusPending, CreatedAt: time.Now(),
+	})
+	suite.Require().NoError(err)
+	suite.Assert().True(result.Executed)
+	suite.Assert().Len(result.Trades, 1)
+}
+
+// TestTradingServiceSettlement 거래 서비스를 거친 분산 체결도 단일 노드와 같은 수수료/지갑/멘토 풀 처리
+func (suite *DistributedMatchingEngineTestSuite) TestTradingServiceSettlement() {
+	suite.createTestData()
+	suite.Require().NoError(suite.db.Create(&models.Milestone{
+		ID: 2, ProjectID: 1, Title: "Settlement Milestone", Status: models.MilestoneStatusActive, Order: 2,
+	}).Error)
+	suite.Require().NoError(suite.db.Create(&models.MentorPool{MilestoneID: 2, FeePercentage: 50}).Error)
+	for _, userID := range []uint{11, 12} {
+		suite.Require().NoError(suite.db.Create(&models.UserWallet{UserID: userID, USDCBalance: 100000}).Error)
+	}
+	// 보유분 매도 (숏 담보 없이)
+	suite.Require().NoError(suite.db.Create(&models.Position{
+		UserID: 12, ProjectID: 1, MilestoneID: 2, OptionID: "success", Quantity: 50, AvgPrice: 0.5,
+	}).Error)
+
+	err := suite.engine.Start()
+	suite.Require().NoError(err)
+	defer suite.engine.Stop()
+
+	var engine services.MatchingEngine = suite.engine
+	tradingService := services.NewTradingService(suite.db, nil, engine)
+
+	sell, err := tradingService.CreateOrder(12, models.CreateOrderRequest{
+		ProjectID: 1, MilestoneID: 2, OptionID: "success", Type: models.OrderTypeLimit, Side: models.OrderSideSell, Quantity: 50, Price: 0.70,
+	}, "", "")
+	suite.Require().NoError(err)
+	buy, err := tradingService.CreateOrder(11, models.CreateOrderRequest{
+		ProjectID: 1, MilestoneID: 2, OptionID: "success", Type: models.OrderTypeLimit, Side: models.OrderSideBuy, Quantity: 30, Price: 0.75,
+	}, "", "")
+	suite.Require().NoError(err)
+
+	suite.Require().Len(buy.Trades, 1)
+	trade := buy.Trades[0]
+	suite.Equal(int64(7000), trade.PriceTicks, "메이커 가격으로 체결")
+	suite.Equal(models.NotionalCents(30, 7000), trade.TotalAmount)
+	fee := models.FeeCents(trade.TotalAmount, 25)
+	suite.Equal(fee, trade.BuyerFee)
+	suite.Equal(fee, trade.SellerFee)
+	suite.Equal(models.OrderStatusFilled, buy.Order.Status)
+
+	// 메이커 주문 상태는 write-behind로 반영
+	suite.engine.FlushOrderStates()
+	var stored models.Order
+	suite.Require().NoError(suite.db.First(&stored, sell.Order.ID).Error)
+	suite.Equal(int64(30), stored.Filled)
+	suite.Equal(int64(20), stored.Remaining)
+	suite.Equal(models.OrderStatusPartial, stored.Status)
+
+	// 지갑/멘토 풀은 체결 후처리에서 반영 (지정가 잠금을 풀고 체결가 + 수수료 차감)
+	suite.Eventually(func() bool {
+		var buyer, seller models.UserWallet
+		suite.db.Where("user_id = ?", 11).First(&buyer)
+		suite.db.Where("user_id = ?", 12).First(&seller)
+		return buyer.USDCLockedBalance == 0 &&
+			buyer.USDCBalance == 100000-trade.TotalAmount-fee &&
+			seller.USDCBalance == 100000+trade.TotalAmount-fee
+	}, 2*time.Second, 10*time.Millisecond)
+	suite.Eventually(func() bool {
+		var pool models.MentorPool
+		suite.db.Where("milestone_id = ?", 2).First(&pool)
+		return pool.AccumulatedFees == fee
+	}, 2*time.Second, 10*time.Millisecond, "수수료 합계의 50%")
+}
+
 // TestConcurrentOrders 동시 주문 처리 테스트
 func (suite *DistributedMatchingEngineTestSuite) TestConcurrentOrders() {
 	suite.createTestData()
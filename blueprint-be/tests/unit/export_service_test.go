@@ -0,0 +1,120 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
+	"blueprint/internal/services"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestRequestExport(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.DataExport{}))
+
+	redisServer := miniredis.RunT(t)
+	moduleRedis.Client = redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+	defer func() { moduleRedis.Client = nil }()
+
+	exportService := services.NewExportService(db, services.NewFileService(t.TempDir(), "http://localhost/api/v1/files", "secret"))
+	year := time.Now().UTC().Year()
+
+	export, err := exportService.RequestExport(1, models.DataExportTrades, models.DataExportCSV, year)
+	require.NoError(t, err)
+	assert.Equal(t, models.DataExportPending, export.Status)
+
+	// 진행 중인 같은 요청은 재사용
+	again, err := exportService.RequestExport(1, models.DataExportTrades, models.DataExportCSV, year)
+	require.NoError(t, err)
+	assert.Equal(t, export.ID, again.ID)
+
+	// 개인 데이터 내보내기는 전용 요청으로만 (항상 JSON)
+	_, err = exportService.RequestExport(1, models.DataExportPersonalData, models.DataExportCSV, year)
+	assert.ErrorIs(t, err, services.ErrExportInvalidKind)
+	personal, err := exportService.RequestPersonalDataExport(1)
+	require.NoError(t, err)
+	assert.Equal(t, models.DataExportJSON, personal.Format)
+
+	length, err := moduleRedis.Client.XLen(context.Background(), services.ExportQueue).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), length)
+
+	_, err = exportService.RequestExport(1, models.DataExportTrades, "pdf", year)
+	assert.ErrorIs(t, err, services.ErrExportInvalidFormat)
+	_, err = exportService.RequestExport(1, models.DataExportTrades, models.DataExportCSV, year+1)
+	assert.ErrorIs(t, err, services.ErrExportInvalidYear)
+
+	// 완료 전 다운로드 불가, 다른 사용자 접근 불가
+	_, _, err = exportService.OpenExport(1, export.ID)
+	assert.ErrorIs(t, err, services.ErrExportNotReady)
+	_, err = exportService.GetExport(2, export.ID)
+	assert.ErrorIs(t, err, services.ErrExportNotFound)
+}
+
+// TestFeeStatements 월간 수수료 명세서는 지난 달까지(이번 달 포함)만 요청할 수 있고, 자동 생성은 수수료/스테이킹 내역이 있는 사용자에게 한 번만
+func TestFeeStatements(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.DataExport{}, &models.Trade{}, &models.WalletLedgerEntry{}))
+
+	redisServer := miniredis.RunT(t)
+	moduleRedis.Client = redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+	defer func() { moduleRedis.Client = nil }()
+
+	exportService := services.NewExportService(db, services.NewFileService(t.TempDir(), "http://localhost/api/v1/files", "secret"))
+	now := time.Now().UTC()
+	next := now.AddDate(0, 1, 0)
+
+	statement, err := exportService.RequestFeeStatement(1, now.Year(), int(now.Month()))
+	require.NoError(t, err)
+	assert.Equal(t, models.DataExportFeeStatement, statement.Kind)
+	assert.Equal(t, models.DataExportPDF, statement.Format)
+	assert.Equal(t, int(now.Month()), statement.Month)
+
+	again, err := exportService.RequestFeeStatement(1, now.Year(), int(now.Month()))
+	require.NoError(t, err)
+	assert.Equal(t, statement.ID, again.ID)
+
+	_, err = exportService.RequestFeeStatement(1, next.Year(), int(next.Month()))
+	assert.ErrorIs(t, err, services.ErrExportInvalidPeriod)
+	_, err = exportService.RequestFeeStatement(1, now.Year(), 13)
+	assert.ErrorIs(t, err, services.ErrExportInvalidPeriod)
+
+	// 9월: 사용자 2는 매수 수수료, 3은 매도 수수료, 4는 분쟁 스테이킹, 5는 수수료 없는 체결만
+	september := time.Date(2026, 9, 15, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, db.Create(&[]models.Trade{
+		{MilestoneID: 1, OptionID: "success", BuyerID: 2, SellerID: 3, Quantity: 10, Price: 0.5, TotalAmount: 500, BuyerFee: 5, SellerFee: 5, CreatedAt: september},
+		{MilestoneID: 1, OptionID: "success", BuyerID: 5, SellerID: 5, Quantity: 10, Price: 0.5, TotalAmount: 500, CreatedAt: september},
+		{MilestoneID: 1, OptionID: "success", BuyerID: 6, SellerID: 6, Quantity: 10, Price: 0.5, TotalAmount: 500, BuyerFee: 5, CreatedAt: september.AddDate(0, 1, 0)},
+	}).Error)
+	require.NoError(t, db.Create(&models.WalletLedgerEntry{
+		UserID: 4, Currency: models.LedgerCurrencyBlueprint, EntryType: models.LedgerArbitrationStakeLock,
+		Amount: -100, LockedAmount: 100, CreatedAt: september,
+	}).Error)
+	// 사용자 3은 이미 9월 명세서를 요청함
+	_, err = exportService.RequestFeeStatement(3, 2026, 9)
+	require.NoError(t, err)
+
+	created, err := exportService.GenerateMonthlyFeeStatements(time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 2, created)
+
+	var users []uint
+	require.NoError(t, db.Model(&models.DataExport{}).Where("kind = ? AND year = 2026 AND month = 9", models.DataExportFeeStatement).
+		Order("user_id ASC").Pluck("user_id", &users).Error)
+	assert.Equal(t, []uint{2, 3, 4}, users)
+
+	created, err = exportService.GenerateMonthlyFeeStatements(time.Date(2026, 10, 2, 3, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Zero(t, created)
+}
@@ -0,0 +1,92 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFeatureFlagUpdateAndRollout 변경은 DB와 Redis 스냅샷에 남고, 비율/허용 목록에 따라 사용자별로 평가됨
+func TestFeatureFlagUpdateAndRollout(t *testing.T) {
+	env := testkit.New(t)
+	flags := services.NewFeatureFlagService(env.DB)
+	require.NoError(t, flags.Load())
+
+	// 행이 없으면 정의된 기본값
+	assert.True(t, flags.IsEnabled(models.FlagAMMLiquidity))
+	assert.False(t, flags.IsEnabled(models.FlagNewFeeTiers))
+
+	_, err := flags.Update("unknown_flag", models.UpdateFeatureFlagRequest{}, 1)
+	assert.ErrorIs(t, err, services.ErrUnknownFeatureFlag)
+
+	// 0% 롤아웃이면 허용 목록 사용자만 적용
+	enabled, rollout := true, 0
+	_, err = flags.Update(models.FlagNewFeeTiers, models.UpdateFeatureFlagRequest{
+		Enabled:        &enabled,
+		RolloutPercent: &rollout,
+		AllowUserIDs:   []uint{7},
+		Reason:         "beta",
+	}, 1)
+	require.NoError(t, err)
+	assert.True(t, flags.IsEnabled(models.FlagNewFeeTiers))
+	assert.True(t, flags.IsEnabledFor(models.FlagNewFeeTiers, 7))
+	assert.False(t, flags.IsEnabledFor(models.FlagNewFeeTiers, 8))
+
+	var stored models.FeatureFlag
+	require.NoError(t, env.DB.First(&stored, "key = ?", models.FlagNewFeeTiers).Error)
+	assert.Equal(t, []uint{7}, stored.AllowUserIDs)
+	require.NotNil(t, stored.UpdatedBy)
+	assert.Equal(t, uint(1), *stored.UpdatedBy)
+
+	// 다른 서버는 Redis 스냅샷으로 같은 상태를 봄
+	other := services.NewFeatureFlagService(env.DB)
+	require.NoError(t, other.Refresh())
+	assert.True(t, other.IsEnabledFor(models.FlagNewFeeTiers, 7))
+
+	// 비율을 올리면 버킷이 비율 안에 드는 사용자가 적용됨
+	rollout = 50
+	_, err = flags.Update(models.FlagNewFeeTiers, models.UpdateFeatureFlagRequest{RolloutPercent: &rollout}, 1)
+	require.NoError(t, err)
+	for userID := uint(100); userID < 120; userID++ {
+		expected := models.RolloutBucket(models.FlagNewFeeTiers, userID) < 50
+		assert.Equal(t, expected, flags.IsEnabledFor(models.FlagNewFeeTiers, userID), "user %d", userID)
+	}
+	assert.True(t, flags.EvaluateFor(7)[models.FlagNewFeeTiers])
+}
+
+// TestNewFeeTiersSplitsMakerTakerFees new_fee_tiers가 테이커에게 적용되면 테이커 20bp, 메이커 10bp
+func TestNewFeeTiersSplitsMakerTakerFees(t *testing.T) {
+	env := testkit.New(t)
+	flags := services.NewFeatureFlagService(env.DB)
+	engine := testkit.StartMatchingEngine(t, env.DB)
+	engine.SetFeatureFlags(flags)
+	milestone := env.Factory.Market()
+
+	enabled := true
+	_, err := flags.Update(models.FlagNewFeeTiers, models.UpdateFeatureFlagRequest{Enabled: &enabled}, 1)
+	require.NoError(t, err)
+
+	seller := env.Factory.FundedUser(0)
+	env.Factory.Position(seller.ID, milestone, models.OptionSuccess, 1000, 0.5)
+	reserve := models.ReserveCents(1000, models.PriceToTicks(0.6))
+	buyer := env.Factory.User()
+	env.Factory.Wallet(buyer.ID, 100000, func(w *models.UserWallet) { w.USDCLockedBalance = reserve })
+
+	_, err = engine.SubmitOrder(env.Factory.Order(seller.ID, milestone, models.OrderSideSell, 0.6, 1000))
+	require.NoError(t, err)
+	result, err := engine.SubmitOrder(env.Factory.Order(buyer.ID, milestone, models.OrderSideBuy, 0.6, 1000))
+	require.NoError(t, err)
+	require.Len(t, result.Trades, 1)
+	testkit.Settle(t, engine)
+
+	// 체결 금액 600달러(60000센트): 테이커(매수자) 20bp = 120, 메이커(매도자) 10bp = 60
+	var trade models.Trade
+	require.NoError(t, env.DB.Where("milestone_id = ?", milestone.ID).First(&trade).Error)
+	assert.Equal(t, int64(120), trade.BuyerFee)
+	assert.Equal(t, int64(60), trade.SellerFee)
+}
@@ -0,0 +1,100 @@
+package unit_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"mime/multipart"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// uploadForm 멀티파트 업로드 파일 생성
+func uploadForm(t *testing.T, filename string, content []byte) (multipart.File, *multipart.FileHeader) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	part.Write(content)
+	require.NoError(t, writer.Close())
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1 << 20)
+	require.NoError(t, err)
+	header := form.File["file"][0]
+	file, err := header.Open()
+	require.NoError(t, err)
+	return file, header
+}
+
+// TestStoreFileSniffsContentType 저장 키는 파일명과 무관하고 형식은 내용으로 감지
+func TestStoreFileSniffsContentType(t *testing.T) {
+	fileService := services.NewFileService(t.TempDir(), "http://api/files", "secret")
+
+	content := []byte("<html><script>alert(1)</script></html>")
+	file, header := uploadForm(t, "../../evil.png", content)
+	stored, err := fileService.StoreFile(file, header, "proofs")
+	require.NoError(t, err)
+
+	assert.Regexp(t, `^[a-f0-9]{32}$`, stored.Key)
+	assert.Equal(t, "evil.png", stored.OriginalName)
+	assert.True(t, strings.HasPrefix(stored.ContentType, "text/html"))
+	assert.False(t, stored.Inline())
+	sum := sha256.Sum256(content)
+	assert.Equal(t, hex.EncodeToString(sum[:]), stored.SHA256, "워커가 대조할 저장 시점 해시")
+
+	_, _, err = fileService.OpenFile("proofs", "../"+stored.Key)
+	assert.ErrorIs(t, err, services.ErrInvalidFileKey)
+}
+
+// TestSignedURL 서명 URL 검증 및 만료
+func TestSignedURL(t *testing.T) {
+	fileService := services.NewFileService(t.TempDir(), "http://api/files", "secret")
+	key := strings.Repeat("ab", 16)
+
+	signed, err := fileService.SignedURL("verification_docs/"+key, time.Minute)
+	require.NoError(t, err)
+	parsed, err := url.Parse(signed)
+	require.NoError(t, err)
+	expires, signature := parsed.Query().Get("expires"), parsed.Query().Get("signature")
+
+	assert.NoError(t, fileService.VerifySignature("verification_docs", key, expires, signature))
+	assert.ErrorIs(t, fileService.VerifySignature("verification_docs", strings.Repeat("cd", 16), expires, signature), services.ErrFileSignatureInvalid)
+
+	other := services.NewFileService(t.TempDir(), "http://api/files", "other-secret")
+	assert.ErrorIs(t, other.VerifySignature("verification_docs", key, expires, signature), services.ErrFileSignatureInvalid)
+
+	expired, err := fileService.SignedURL("verification_docs/"+key, -time.Minute)
+	require.NoError(t, err)
+	parsed, _ = url.Parse(expired)
+	assert.ErrorIs(t, fileService.VerifySignature("verification_docs", key, parsed.Query().Get("expires"), parsed.Query().Get("signature")), services.ErrFileSignatureExpired)
+}
+
+// TestLookupFileURL 이 서버가 발급한 다운로드 URL만 저장된 파일로 되돌림
+func TestLookupFileURL(t *testing.T) {
+	fileService := services.NewFileService(t.TempDir(), "http://api/files", "secret")
+
+	file, header := uploadForm(t, "proof.png", []byte("\x89PNG\r\n\x1a\n"))
+	fileURL, err := fileService.UploadFile(file, header, "proofs")
+	require.NoError(t, err)
+
+	stored, err := fileService.LookupFileURL(fileURL)
+	require.NoError(t, err)
+	assert.Equal(t, "proofs", stored.Category)
+	assert.Equal(t, "image/png", stored.ContentType)
+
+	for _, other := range []string{
+		"http://evil/files/proofs/" + stored.Key,
+		"http://api/files/proofs/" + strings.Repeat("ab", 16),
+		"http://api/files/proofs/../" + stored.Key,
+	} {
+		_, err := fileService.LookupFileURL(other)
+		assert.ErrorIs(t, err, services.ErrInvalidFileKey, other)
+	}
+}
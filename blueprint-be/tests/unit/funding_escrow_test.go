@@ -0,0 +1,84 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFundingEscrowReleaseAndRefund 후원금은 예치 시 잠기고 TVL에 포함, 완료 시 소유자에게 지급, 실패 시 반환
+func TestFundingEscrowReleaseAndRefund(t *testing.T) {
+	env := testkit.New(t)
+	fundingService := services.NewFundingVerificationService(env.DB, nil)
+	escrowService := services.NewFundingEscrowService(env.DB, fundingService)
+
+	owner := env.Factory.FundedUser(0)
+	project := env.Factory.Project(owner.ID)
+	funding := func(m *models.Milestone) { m.Status = models.MilestoneStatusFunding }
+	completed := env.Factory.Milestone(project.ID, funding)
+	failed := env.Factory.Milestone(project.ID, funding)
+	backer := env.Factory.FundedUser(10000)
+
+	wallet := func(userID uint) models.UserWallet {
+		var w models.UserWallet
+		require.NoError(t, env.DB.Where("user_id = ?", userID).First(&w).Error)
+		return w
+	}
+
+	_, err := escrowService.Deposit(backer.ID, completed.ID, 3000)
+	require.NoError(t, err)
+	_, err = escrowService.Deposit(backer.ID, failed.ID, 2000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), wallet(backer.ID).USDCBalance)
+	assert.Equal(t, int64(5000), wallet(backer.ID).USDCLockedBalance)
+
+	_, err = escrowService.Deposit(backer.ID, completed.ID, 6000)
+	assert.ErrorIs(t, err, services.ErrInsufficientBalance)
+	assert.Equal(t, int64(5000), wallet(backer.ID).USDCBalance, "잔액 부족이면 잠그지 않음")
+	_, err = escrowService.Deposit(owner.ID, completed.ID, 100)
+	assert.ErrorIs(t, err, services.ErrEscrowSelfBacking)
+
+	stats, err := fundingService.GetFundingStats(completed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3000), stats.CurrentTVL)
+	assert.Equal(t, int64(3000), stats.EscrowTVL)
+	assert.Equal(t, int64(1), stats.EscrowBackers)
+
+	// 완료: 잠금 차감 후 소유자 지급, 다시 호출해도 중복 지급 없음
+	released, err := escrowService.ReleaseMilestone(completed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, released)
+	released, err = escrowService.ReleaseMilestone(completed.ID)
+	require.NoError(t, err)
+	assert.Zero(t, released)
+	assert.Equal(t, int64(3000), wallet(owner.ID).USDCBalance)
+
+	// 실패: 후원자에게 반환
+	refunded, err := escrowService.RefundMilestone(failed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, refunded)
+	assert.Equal(t, int64(7000), wallet(backer.ID).USDCBalance)
+	assert.Zero(t, wallet(backer.ID).USDCLockedBalance)
+
+	summary, err := escrowService.GetMilestoneEscrow(completed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3000), summary.ReleasedAmount)
+	assert.Zero(t, summary.LockedAmount)
+
+	var entries []models.WalletLedgerEntry
+	require.NoError(t, env.DB.Where("reference_type = ?", "funding_escrow").Order("id").Find(&entries).Error)
+	var types []models.LedgerEntryType
+	for _, entry := range entries {
+		types = append(types, entry.EntryType)
+	}
+	assert.Equal(t, []models.LedgerEntryType{
+		models.LedgerEscrowLock, models.LedgerEscrowLock,
+		models.LedgerEscrowRelease, models.LedgerEscrowPayout,
+		models.LedgerEscrowRefund,
+	}, types)
+}
@@ -0,0 +1,60 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestFundingTVLAndDashboard(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Project{}, &models.Milestone{}, &models.Trade{}, &models.MilestoneStatusHistory{}, &models.FundingEscrow{}))
+
+	end := time.Now().Add(12 * time.Hour)
+	db.Create(&models.Project{ID: 1, UserID: 1, Title: "창업", Category: models.BusinessProject})
+	db.Create(&models.Project{ID: 2, UserID: 1, Title: "공부", Category: models.EducationProject})
+	db.Create(&models.Milestone{ID: 10, ProjectID: 1, Title: "MVP 출시", Status: models.MilestoneStatusFunding, MinViableCapital: 1000, FundingEndDate: &end})
+	db.Create(&models.Milestone{ID: 20, ProjectID: 2, Title: "자격증", Status: models.MilestoneStatusFunding, MinViableCapital: 500, FundingEndDate: &end})
+	db.Create(&models.Trade{MilestoneID: 10, OptionID: "success", TotalAmount: 700})
+	db.Create(&models.Trade{MilestoneID: 10, OptionID: "fail", TotalAmount: 400})
+
+	fundingService := services.NewFundingVerificationService(db, nil)
+	require.NoError(t, fundingService.UpdateTVL(10, "success", 700))
+	require.NoError(t, fundingService.UpdateTVL(10, "fail", 400))
+	require.Error(t, fundingService.UpdateTVL(999, "success", 100))
+
+	stats, err := fundingService.GetFundingStats(10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1100), stats.CurrentTVL)
+	assert.Equal(t, int64(0), stats.RemainingAmount)
+	assert.Equal(t, 1.0, stats.FundingProgress)
+	assert.Equal(t, services.FundingStateTargetReached, stats.VerificationState)
+	require.Len(t, stats.OptionTVL, 2)
+	assert.Equal(t, "success", stats.OptionTVL[0].OptionID)
+
+	milestones, total, err := fundingService.GetFundingMilestones("", "progress", 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, milestones, 2)
+	assert.Equal(t, uint(10), milestones[0].MilestoneID)
+	assert.Equal(t, int64(500), milestones[1].RemainingAmount)
+
+	_, total, err = fundingService.GetFundingMilestones(string(models.EducationProject), "ending_soon", 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	dashboard, err := fundingService.GetFundingDashboard()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), dashboard.FundingCount)
+	assert.Equal(t, int64(1100), dashboard.TotalTVL)
+	assert.Equal(t, int64(1), dashboard.ReachedCount)
+	assert.Equal(t, int64(2), dashboard.EndingWithin24h)
+}
@@ -0,0 +1,84 @@
+package unit_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/secrets"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signGitHubBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// TestGitHubWebhookSubmitsProof 서명이 맞고 구독한 트리거와 일치하는 이벤트만 증거로 제출, 같은 Delivery ID는 한 번만 처리
+func TestGitHubWebhookSubmitsProof(t *testing.T) {
+	env := testkit.New(t)
+	const encryptionSecret, hookSecret = "test-encryption-secret", "hook-secret"
+	service := services.NewGitHubIntegrationService(env.DB, services.NewVerificationService(env.DB, nil),
+		"https://api.example.com/webhooks/github", encryptionSecret)
+
+	owner := env.Factory.User()
+	milestone := env.Factory.Milestone(env.Factory.Project(owner.ID).ID, func(m *models.Milestone) { m.RequiresProof = true })
+	encrypted, err := secrets.Encrypt(secrets.DeriveKey(encryptionSecret), hookSecret)
+	require.NoError(t, err)
+	require.NoError(t, env.DB.Create(&models.GitHubWebhookSubscription{
+		UserID: owner.ID, MilestoneID: milestone.ID, RepoFullName: "acme/app",
+		Triggers: string(models.GitHubTriggerReleasePublished), HookID: 42, EncryptedSecret: encrypted, Active: true,
+	}).Error)
+
+	deliver := func(deliveryID, event, body string) (*services.GitHubWebhookResult, error) {
+		return service.HandleWebhook(42, deliveryID, event, signGitHubBody(hookSecret, []byte(body)), []byte(body))
+	}
+	published := `{"action":"published","repository":{"full_name":"acme/app"},"sender":{"login":"octo"},
+		"release":{"tag_name":"v1.0.0","name":"1.0","html_url":"https://github.com/acme/app/releases/v1.0.0"}}`
+
+	// 서명 불일치
+	_, err = service.HandleWebhook(42, "d-0", "release", signGitHubBody("wrong", []byte(published)), []byte(published))
+	assert.ErrorIs(t, err, services.ErrGitHubWebhookSignature)
+
+	// 구독하지 않은 트리거 / 초안 릴리스 / 다른 저장소는 무시
+	for deliveryID, delivery := range map[string][2]string{
+		"d-tag":   {"create", `{"ref":"v1.0.0","ref_type":"tag","repository":{"full_name":"acme/app"}}`},
+		"d-draft": {"release", `{"action":"published","repository":{"full_name":"acme/app"},"release":{"tag_name":"v0.9","draft":true}}`},
+		"d-other": {"release", `{"action":"published","repository":{"full_name":"acme/other"},"release":{"tag_name":"v1"}}`},
+	} {
+		result, err := deliver(deliveryID, delivery[0], delivery[1])
+		require.NoError(t, err, deliveryID)
+		assert.Equal(t, models.GitHubDeliveryIgnored, result.Status, deliveryID)
+		assert.Nil(t, result.ProofID, deliveryID)
+	}
+
+	result, err := deliver("d-1", "release", published)
+	require.NoError(t, err)
+	require.Equal(t, models.GitHubDeliveryProofCreated, result.Status, result.Message)
+	assert.Equal(t, models.GitHubTriggerReleasePublished, result.Trigger)
+	require.NotNil(t, result.ProofID)
+
+	var proof models.MilestoneProof
+	require.NoError(t, env.DB.First(&proof, *result.ProofID).Error)
+	assert.Equal(t, owner.ID, proof.UserID)
+	assert.Equal(t, models.ProofTypeAPI, proof.ProofType)
+	assert.Equal(t, models.ProofStatusUnderReview, proof.Status)
+	assert.Equal(t, "https://github.com/acme/app/releases/v1.0.0", proof.ExternalURL)
+	assert.Equal(t, "v1.0.0", proof.APIData["tag"])
+	assert.Equal(t, "github_webhook", proof.Metadata["source"])
+
+	// 재전송은 같은 결과, 증거는 하나
+	replay, err := deliver("d-1", "release", published)
+	require.NoError(t, err)
+	assert.Equal(t, result.ProofID, replay.ProofID)
+	var proofs int64
+	env.DB.Model(&models.MilestoneProof{}).Where("milestone_id = ?", milestone.ID).Count(&proofs)
+	assert.Equal(t, int64(1), proofs)
+}
@@ -0,0 +1,79 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/queue"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIdempotentConsumers 같은 키로 여러 번 발행된 작업/이벤트는 컨슈머 그룹당 한 번만 처리되고, 실패한 작업은 재시도에서 다시 처리된다
+func TestIdempotentConsumers(t *testing.T) {
+	testkit.New(t)
+
+	// 작업 큐: 같은 idempotency_key 중복 발행
+	for i := 0; i < 3; i++ {
+		require.NoError(t, queue.PublishJob("push_queue", map[string]interface{}{
+			"type":                    "send_push",
+			queue.IdempotencyKeyField: "fill-42",
+		}))
+	}
+	require.NoError(t, queue.PublishJob("push_queue", map[string]interface{}{"type": "send_push"}))
+	require.NoError(t, queue.PublishJob("push_queue", map[string]interface{}{"type": "flaky"}))
+
+	var pushes, flaky int32
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		queue.ConsumeJobsWithPolicy(ctx, "push_queue", "push_workers", "test_worker",
+			queue.RetryPolicy{MaxRetries: 1, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond},
+			func(data map[string]interface{}) error {
+				if data["type"] == "flaky" {
+					if atomic.AddInt32(&flaky, 1) == 1 {
+						return errors.New("temporary")
+					}
+					return nil
+				}
+				atomic.AddInt32(&pushes, 1)
+				return nil
+			})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&pushes) == 2 && atomic.LoadInt32(&flaky) == 2
+	}, 5*time.Second, 20*time.Millisecond)
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&pushes), "중복 발행은 한 번만 처리")
+
+	// 이벤트 큐: 지갑 생성 이벤트는 사용자당 한 번만 처리
+	publisher := queue.NewPublisher()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, publisher.EnqueueWalletCreate(queue.WalletCreateEventData{UserID: 7, InitialAmount: 10000}))
+	}
+
+	var wallets int32
+	consumer := queue.NewConsumer("test-wallet-worker", "blueprint-workers")
+	require.NoError(t, consumer.StartConsuming(queue.QueueWallet, func(event queue.QueueEvent) error {
+		atomic.AddInt32(&wallets, 1)
+		return nil
+	}))
+	t.Cleanup(consumer.StopConsuming)
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&wallets) >= 1
+	}, 5*time.Second, 20*time.Millisecond)
+	time.Sleep(500 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&wallets))
+}
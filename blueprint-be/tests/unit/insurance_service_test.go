@@ -0,0 +1,92 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMilestoneInsurance 보장 한도는 롱 포지션 취득 원가, 보험료는 카테고리 풀로 이동, 사기 판결 확정 후 풀 잔액 한도에서 지급, 기한 지난 보험은 만료
+func TestMilestoneInsurance(t *testing.T) {
+	env := testkit.New(t)
+	insurance := services.NewInsuranceService(env.DB)
+
+	milestone := env.Factory.Market()
+	holder := env.Factory.FundedUser(10000)
+	env.Factory.Position(holder.ID, milestone, models.OptionSuccess, 100, 0.6) // 취득 원가 $60
+
+	// 빈 풀: 기본 2% + 위험(계산 전 0.5) 4% + 사용률 5%
+	quote, err := insurance.Quote(holder.ID, models.InsuranceQuoteRequest{MilestoneID: milestone.ID, OptionID: models.OptionSuccess, Coverage: 4000})
+	require.NoError(t, err)
+	assert.Equal(t, int64(6000), quote.MaxCoverage)
+	assert.InDelta(t, 0.11, quote.PremiumRate, 1e-9)
+	assert.Equal(t, int64(440), quote.Premium)
+	assert.Equal(t, models.BusinessProject, quote.Category)
+
+	_, err = insurance.Purchase(holder.ID, models.InsuranceQuoteRequest{MilestoneID: milestone.ID, OptionID: models.OptionSuccess, Coverage: 7000})
+	assert.ErrorIs(t, err, services.ErrInsuranceCoverageExceeded)
+
+	policy, err := insurance.Purchase(holder.ID, models.InsuranceQuoteRequest{MilestoneID: milestone.ID, OptionID: models.OptionSuccess, Coverage: 4000})
+	require.NoError(t, err)
+	assert.Equal(t, models.InsurancePolicyActive, policy.Status)
+
+	_, err = insurance.Purchase(holder.ID, models.InsuranceQuoteRequest{MilestoneID: milestone.ID, OptionID: models.OptionSuccess, Coverage: 2500})
+	assert.ErrorIs(t, err, services.ErrInsuranceCoverageExceeded, "기존 보장 $40을 빼면 $20까지")
+
+	var wallet models.UserWallet
+	require.NoError(t, env.DB.First(&wallet, "user_id = ?", holder.ID).Error)
+	assert.Equal(t, int64(9560), wallet.USDCBalance)
+
+	pools, err := insurance.ListPools()
+	require.NoError(t, err)
+	require.Len(t, pools, 1)
+	assert.Equal(t, int64(440), pools[0].Balance)
+	assert.Equal(t, int64(4000), pools[0].ActiveCoverage)
+
+	// 사기 판결 전에는 청구 불가, 항소로 대체되지 않은 신청인 승소 종결 사건이 있으면 풀 잔액 한도에서 지급
+	_, err = insurance.Claim(policy.ID, holder.ID)
+	assert.ErrorIs(t, err, services.ErrInsuranceNoFraudRuling)
+
+	milestoneID := milestone.ID
+	ruling := &models.ArbitrationCase{
+		CaseNumber: "ARB-2026-000200", PlaintiffID: holder.ID, DefendantID: env.Factory.User().ID, Title: "사기", Description: "test",
+		DisputeType: models.DisputeTypeProjectFraud, MilestoneID: &milestoneID, Status: models.ArbitrationStatusClosed,
+		Decision: models.ArbitrationDecisionPlaintiffWins,
+	}
+	require.NoError(t, env.DB.Create(ruling).Error)
+
+	claim, err := insurance.Claim(policy.ID, holder.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4000), claim.Requested)
+	assert.Equal(t, int64(440), claim.Paid, "풀 잔액만큼만 지급")
+	assert.Equal(t, ruling.ID, claim.ArbitrationCaseID)
+
+	_, err = insurance.Claim(policy.ID, holder.ID)
+	assert.ErrorIs(t, err, services.ErrInsurancePolicyNotActive)
+
+	require.NoError(t, env.DB.First(&wallet, "user_id = ?", holder.ID).Error)
+	assert.Equal(t, int64(10000), wallet.USDCBalance)
+
+	// 청구 기한이 지난 보험 만료 (보험료는 풀에 남음)
+	other := env.Factory.Milestone(milestone.ProjectID)
+	env.Factory.Position(holder.ID, other, models.OptionSuccess, 100, 0.5)
+	expiring, err := insurance.Purchase(holder.ID, models.InsuranceQuoteRequest{MilestoneID: other.ID, OptionID: models.OptionSuccess, Coverage: 1000})
+	require.NoError(t, err)
+	require.NoError(t, env.DB.Model(expiring).Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+	expired, err := insurance.ExpirePolicies(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+
+	var pool models.InsurancePool
+	require.NoError(t, env.DB.First(&pool, pools[0].ID).Error)
+	assert.Equal(t, expiring.Premium, pool.Balance)
+	assert.Zero(t, pool.ActiveCoverage)
+	assert.Equal(t, int64(440), pool.TotalClaims)
+}
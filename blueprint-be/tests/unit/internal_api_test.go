@@ -0,0 +1,87 @@
+package unit_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/internalapi"
+	"blueprint-module/pkg/models"
+	"blueprint/internal/rpc"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestInternalAPI 토큰 인증, 헬스 체크, 마켓 상태/지갑 잔액 조회, 확정된 마켓만 정산 예약
+func TestInternalAPI(t *testing.T) {
+	env := testkit.New(t)
+	engine := testkit.StartMatchingEngine(t, env.DB)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := rpc.NewServer(env.DB, engine, "internal-secret")
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	dial := func(token string) *internalapi.Client {
+		client, err := internalapi.Dial(listener.Addr().String(), token)
+		require.NoError(t, err)
+		t.Cleanup(func() { client.Close() })
+		return client
+	}
+	client := dial("internal-secret")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, client.Check(ctx))
+
+	user := env.Factory.FundedUser(2500)
+	_, err = dial("wrong").GetWalletBalance(ctx, &internalapi.GetWalletBalanceRequest{UserId: uint32(user.ID)})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	balance, err := client.GetWalletBalance(ctx, &internalapi.GetWalletBalanceRequest{UserId: uint32(user.ID)})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2500), balance.GetUsdcBalance())
+
+	_, err = client.GetWalletBalance(ctx, &internalapi.GetWalletBalanceRequest{UserId: 999999})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	market := env.Factory.Market()
+	open, err := client.GetMarketStatus(ctx, &internalapi.GetMarketStatusRequest{MilestoneId: uint32(market.ID)})
+	require.NoError(t, err)
+	assert.True(t, open.GetTradingOpen())
+
+	// 아직 결과가 없는 마켓은 정산 예약 거부
+	_, err = client.EnqueueResolution(ctx, &internalapi.EnqueueResolutionRequest{MilestoneId: uint32(market.ID), RequestedBy: "test"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	require.NoError(t, env.DB.Model(market).Updates(map[string]interface{}{
+		"status":            models.MilestoneStatusCompleted,
+		"winning_option_id": models.OptionSuccess,
+	}).Error)
+	holder := env.Factory.FundedUser(0)
+	env.Factory.Position(holder.ID, market, models.OptionSuccess, 10, 0.4)
+
+	closed, err := client.GetMarketStatus(ctx, &internalapi.GetMarketStatusRequest{MilestoneId: uint32(market.ID)})
+	require.NoError(t, err)
+	assert.False(t, closed.GetTradingOpen())
+	assert.Equal(t, "frozen", closed.GetReason())
+	assert.Equal(t, models.OptionSuccess, closed.GetWinningOptionId())
+
+	queued, err := client.EnqueueResolution(ctx, &internalapi.EnqueueResolutionRequest{MilestoneId: uint32(market.ID), RequestedBy: "test"})
+	require.NoError(t, err)
+	assert.True(t, queued.GetAccepted())
+	require.Eventually(t, func() bool {
+		var m models.Milestone
+		return env.DB.First(&m, market.ID).Error == nil && m.SettledAt != nil
+	}, 2*time.Second, 20*time.Millisecond)
+
+	again, err := client.EnqueueResolution(ctx, &internalapi.EnqueueResolutionRequest{MilestoneId: uint32(market.ID), RequestedBy: "test"})
+	require.NoError(t, err)
+	assert.True(t, again.GetAlreadySettled())
+}
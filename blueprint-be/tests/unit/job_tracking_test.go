@@ -0,0 +1,76 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/queue"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestJobTracking 추적 작업은 queued로 기록되고, 워커 컨슈머가 처리 결과를 succeeded/failed(사유 포함)로 갱신
+func TestJobTracking(t *testing.T) {
+	env := testkit.New(t)
+	jobs := services.NewJobService(env.DB)
+	user := env.Factory.User()
+
+	sent, err := queue.PublishTrackedJob("email_queue", map[string]interface{}{"type": "send_email", "to": "ok@example.com", "user_id": user.ID})
+	require.NoError(t, err)
+	bounced, err := queue.PublishTrackedJob("email_queue", map[string]interface{}{"type": "send_email", "to": "bounce@example.com"})
+	require.NoError(t, err)
+
+	job, err := jobs.GetJob(sent)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobQueued, job.Status)
+	assert.Equal(t, "send_email", job.Type)
+	assert.Equal(t, user.ID, job.UserID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		queue.ConsumeJobsWithPolicy(ctx, "email_queue", "email_workers", "test_worker", queue.RetryPolicy{MaxRetries: 0},
+			func(data map[string]interface{}) error {
+				if data["to"] == "bounce@example.com" {
+					return errors.New("mailbox unavailable")
+				}
+				return nil
+			})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	status := func(id string) models.JobStatus {
+		job, err := jobs.GetJob(id)
+		require.NoError(t, err)
+		return job.Status
+	}
+	require.Eventually(t, func() bool {
+		return status(sent).IsFinished() && status(bounced).IsFinished()
+	}, 3*time.Second, 20*time.Millisecond)
+
+	succeeded, _ := jobs.GetJob(sent)
+	assert.Equal(t, models.JobSucceeded, succeeded.Status)
+	assert.Equal(t, 1, succeeded.Attempts)
+	assert.NotNil(t, succeeded.FinishedAt)
+
+	failed, _ := jobs.GetJob(bounced)
+	assert.Equal(t, models.JobFailed, failed.Status)
+	assert.Equal(t, "mailbox unavailable", failed.Error)
+
+	_, err = jobs.GetJob("missing")
+	assert.ErrorIs(t, err, services.ErrJobNotFound)
+
+	removed, err := jobs.CleanupFinished(time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), removed)
+}
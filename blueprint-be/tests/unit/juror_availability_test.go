@@ -0,0 +1,101 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestJurorAvailability 휴가 중/한도 초과 배심원은 선정에서 빠지고, 커밋하지 않은 배심원은 한 번만 교체된 뒤 투표 기한이 연장됨
+func TestJurorAvailability(t *testing.T) {
+	env := testkit.New(t)
+	arbitrationService := services.NewArbitrationService(env.DB)
+
+	plaintiff, defendant := env.Factory.User(), env.Factory.User()
+	env.Factory.Wallet(plaintiff.ID, 0, func(w *models.UserWallet) { w.BlueprintLockedBalance = 1000 })
+
+	jurors := make([]uint, 6)
+	for i := range jurors {
+		jurors[i] = env.Factory.User().ID
+		env.Factory.Wallet(jurors[i], 0, func(w *models.UserWallet) { w.BlueprintBalance = 5000 })
+		env.Factory.Juror(jurors[i], 5000)
+	}
+	onVacation, busy := jurors[0], jurors[1]
+
+	vacationUntil := time.Now().Add(7 * 24 * time.Hour)
+	_, err := arbitrationService.UpdateJurorAvailability(onVacation, &models.UpdateJurorAvailabilityRequest{VacationUntil: &vacationUntil})
+	require.NoError(t, err)
+	limit := 1
+	_, err = arbitrationService.UpdateJurorAvailability(busy, &models.UpdateJurorAvailabilityRequest{MaxConcurrentCases: &limit})
+	require.NoError(t, err)
+
+	// busy 배심원은 다른 사건의 투표 단계에 이미 참여 중
+	farDeadline := time.Now().Add(30 * 24 * time.Hour)
+	require.NoError(t, env.DB.Create(&models.ArbitrationCase{
+		CaseNumber: "ARB-2026-000100", PlaintiffID: defendant.ID, DefendantID: plaintiff.ID, Title: "다른 분쟁", Description: "test",
+		DisputeType: models.DisputeTypePaymentIssue, Status: models.ArbitrationStatusVoting, StakeAmount: 1000,
+		RequiredJurors: 1, SelectedJurors: []uint{busy}, VotingDeadline: &farDeadline,
+	}).Error)
+
+	availability, err := arbitrationService.GetJurorAvailability(busy)
+	require.NoError(t, err)
+	assert.Equal(t, 1, availability.ActiveCases)
+	assert.False(t, availability.Available)
+	_, err = arbitrationService.GetJurorAvailability(plaintiff.ID)
+	assert.Error(t, err, "배심원이 아니면 조회 불가")
+
+	arbitrationCase := &models.ArbitrationCase{
+		CaseNumber: "ARB-2026-000101", PlaintiffID: plaintiff.ID, DefendantID: defendant.ID, Title: "분쟁", Description: "test",
+		DisputeType: models.DisputeTypePaymentIssue, Status: models.ArbitrationStatusSubmitted, StakeAmount: 1000,
+		RequiredJurors: 3, JuryFormationDeadline: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, env.DB.Create(arbitrationCase).Error)
+
+	_, err = arbitrationService.AdvancePhases(time.Now())
+	require.NoError(t, err)
+	require.NoError(t, env.DB.First(arbitrationCase, arbitrationCase.ID).Error)
+	require.Equal(t, models.ArbitrationStatusVoting, arbitrationCase.Status)
+	require.Len(t, arbitrationCase.SelectedJurors, 3)
+	assert.NotContains(t, arbitrationCase.SelectedJurors, onVacation)
+	assert.NotContains(t, arbitrationCase.SelectedJurors, busy)
+
+	// 아무도 커밋하지 않은 채 마감 → 남은 후보 1명으로 한 명만 교체, 기한 24시간 연장
+	var spare uint
+	for _, jurorID := range jurors[2:] {
+		if !containsUint(arbitrationCase.SelectedJurors, jurorID) {
+			spare = jurorID
+		}
+	}
+	afterDeadline := arbitrationCase.VotingDeadline.Add(time.Minute)
+	_, err = arbitrationService.AdvancePhases(afterDeadline)
+	require.NoError(t, err)
+
+	var replaced models.ArbitrationCase
+	require.NoError(t, env.DB.First(&replaced, arbitrationCase.ID).Error)
+	assert.Equal(t, models.ArbitrationStatusVoting, replaced.Status)
+	require.Len(t, replaced.ReplacedJurors, 1)
+	assert.Contains(t, replaced.SelectedJurors, spare)
+	assert.NotContains(t, replaced.SelectedJurors, replaced.ReplacedJurors[0])
+	assert.WithinDuration(t, afterDeadline.Add(24*time.Hour), *replaced.VotingDeadline, time.Second)
+
+	// 교체는 사건당 한 번 → 연장된 기한도 지나면 기존 규칙대로 기각
+	_, err = arbitrationService.AdvancePhases(replaced.VotingDeadline.Add(time.Minute))
+	require.NoError(t, err)
+	require.NoError(t, env.DB.First(&replaced, arbitrationCase.ID).Error)
+	assert.Equal(t, models.ArbitrationStatusRejected, replaced.Status)
+}
+
+func containsUint(values []uint, target uint) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
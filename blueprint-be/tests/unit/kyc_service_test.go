@@ -0,0 +1,106 @@
+package unit_test
+
+import (
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// KYCTestSuite 본인 인증 서비스 테스트 슈트
+type KYCTestSuite struct {
+	suite.Suite
+	db         *gorm.DB
+	tempDir    string
+	kycService *services.KYCService
+}
+
+func (suite *KYCTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(
+		&models.User{},
+		&models.UserProfile{},
+		&models.UserVerification{},
+		&models.Notification{},
+		&models.KYCVerification{},
+	))
+	suite.db = db
+	suite.tempDir = suite.T().TempDir()
+
+	db.Create(&models.User{ID: 1, Email: "alice@test.com", Username: "alice"})
+
+	fileService := services.NewFileService(suite.tempDir, "http://localhost/api/v1/files", "secret")
+	suite.kycService = services.NewKYCService(db, &services.ManualKYCProvider{}, fileService)
+}
+
+// openDocument PNG 신분증 파일 준비
+func (suite *KYCTestSuite) openDocument() (multipart.File, *multipart.FileHeader) {
+	path := filepath.Join(suite.tempDir, "id.png")
+	content := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 64)...)
+	suite.Require().NoError(os.WriteFile(path, content, 0600))
+
+	file, err := os.Open(path)
+	suite.Require().NoError(err)
+	suite.T().Cleanup(func() { file.Close() })
+	return file, &multipart.FileHeader{Filename: "id.png", Size: int64(len(content))}
+}
+
+func (suite *KYCTestSuite) request(country string) *models.SubmitKYCRequest {
+	return &models.SubmitKYCRequest{
+		Tier:         models.KYCTierBasic,
+		FullName:     "Alice Kim",
+		DateOfBirth:  "1990-01-01",
+		Country:      country,
+		DocumentType: "passport",
+	}
+}
+
+// TestManualReviewRaisesOrderLimit 수동 승인 후 주문 한도 상향
+func (suite *KYCTestSuite) TestManualReviewRaisesOrderLimit() {
+	suite.ErrorIs(suite.kycService.CheckOrderLimit(1, 50_000), services.ErrKYCOrderLimitExceeded)
+	suite.ErrorIs(suite.kycService.ReserveWithdrawal(1, 100), services.ErrKYCWithdrawalLimitExceeded)
+
+	_, err := suite.kycService.Submit(1, suite.request("kr"), nil, nil)
+	suite.Error(err, "수동 심사는 신분증 필수")
+
+	file, header := suite.openDocument()
+	record, err := suite.kycService.Submit(1, suite.request("kr"), file, header)
+	suite.Require().NoError(err)
+	suite.Equal(models.KYCStatusPending, record.Status)
+	suite.Equal("KR", record.Country)
+
+	_, err = suite.kycService.Submit(1, suite.request("KR"), file, header)
+	suite.ErrorIs(err, services.ErrKYCAlreadyPending)
+
+	reviewed, err := suite.kycService.Review(99, record.ID, &models.ReviewKYCRequest{Approved: true})
+	suite.Require().NoError(err)
+	suite.Equal(models.KYCTierBasic, reviewed.Tier)
+
+	suite.NoError(suite.kycService.CheckOrderLimit(1, 50_000))
+	status, err := suite.kycService.GetStatus(1)
+	suite.Require().NoError(err)
+	suite.Equal(models.KYCStatusApproved, status.Status)
+	suite.NotNil(status.NextTierLimits)
+}
+
+// TestSanctionedCountryRejected 제재 대상 국가 자동 거절
+func (suite *KYCTestSuite) TestSanctionedCountryRejected() {
+	file, header := suite.openDocument()
+	record, err := suite.kycService.Submit(1, suite.request("KP"), file, header)
+	suite.Require().NoError(err)
+	suite.Equal(models.KYCStatusRejected, record.Status)
+	suite.True(record.AMLFlagged)
+	suite.Equal(models.KYCTierNone, suite.kycService.GetTier(1))
+}
+
+func TestKYCTestSuite(t *testing.T) {
+	suite.Run(t, new(KYCTestSuite))
+}
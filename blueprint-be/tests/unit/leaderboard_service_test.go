@@ -0,0 +1,93 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// LeaderboardTestSuite 리더보드 집계 테스트 슈트
+type LeaderboardTestSuite struct {
+	suite.Suite
+	db                 *gorm.DB
+	leaderboardService *services.LeaderboardService
+}
+
+func (suite *LeaderboardTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(
+		&models.User{},
+		&models.Trade{},
+		&models.MarketData{},
+		&models.ProofValidator{},
+		&models.MilestoneVerification{},
+		&models.Mentor{},
+		&models.MentorPerformanceMetric{},
+		&models.LeaderboardEntry{},
+	))
+	suite.db = db
+
+	for i, name := range []string{"alice", "bob", "carol"} {
+		db.Create(&models.User{ID: uint(i + 1), Email: name + "@test.com", Username: name})
+	}
+
+	suite.leaderboardService = services.NewLeaderboardService(db)
+}
+
+// TestTraderPnLRanksByMarkToMarket 현재 시장가 기준 손익으로 순위 결정
+func (suite *LeaderboardTestSuite) TestTraderPnLRanksByMarkToMarket() {
+	now := time.Now()
+	suite.db.Create(&models.MarketData{MilestoneID: 1, OptionID: "success", CurrentPrice: 0.8})
+	// alice가 bob에게 0.5에 100주 매수 → alice +3000, bob -3000
+	suite.db.Create(&models.Trade{MilestoneID: 1, OptionID: "success", BuyerID: 1, SellerID: 2, Quantity: 100, Price: 0.5, CreatedAt: now})
+	// 한 달 전 거래: carol 매수 (주간 리더보드에는 포함되지 않음)
+	suite.db.Create(&models.Trade{MilestoneID: 1, OptionID: "success", BuyerID: 3, SellerID: 2, Quantity: 100, Price: 0.2, CreatedAt: now.AddDate(0, 0, -20)})
+
+	suite.Require().NoError(suite.leaderboardService.Recompute(models.LeaderboardTraders, models.LeaderboardWeekly, now))
+	weekly, err := suite.leaderboardService.GetLeaderboard(models.LeaderboardTraders, models.LeaderboardWeekly, 10, 0)
+	suite.Require().NoError(err)
+	suite.Require().Len(weekly.Entries, 2)
+	suite.Equal("alice", weekly.Entries[0].Username)
+	suite.InDelta(3000, weekly.Entries[0].Score, 0.01)
+	suite.Equal("bob", weekly.Entries[1].Username)
+
+	suite.Require().NoError(suite.leaderboardService.Recompute(models.LeaderboardTraders, models.LeaderboardMonthly, now))
+	monthly, err := suite.leaderboardService.GetLeaderboard(models.LeaderboardTraders, models.LeaderboardMonthly, 10, 0)
+	suite.Require().NoError(err)
+	suite.Require().Len(monthly.Entries, 3)
+	suite.Equal("carol", monthly.Entries[0].Username) // +6000
+	suite.Equal(1, monthly.Entries[0].Rank)
+}
+
+// TestValidatorAccuracyRequiresMinimumVotes 최소 투표 수 미만 검증인은 제외
+func (suite *LeaderboardTestSuite) TestValidatorAccuracyRequiresMinimumVotes() {
+	now := time.Now()
+	for proofID := uint(1); proofID <= 3; proofID++ {
+		suite.db.Create(&models.MilestoneVerification{MilestoneID: proofID, ProofID: proofID, FinalResult: "approved"})
+		vote := "approve"
+		if proofID == 3 {
+			vote = "reject"
+		}
+		suite.db.Create(&models.ProofValidator{ProofID: proofID, UserID: 1, Vote: vote, VotedAt: now})
+	}
+	suite.db.Create(&models.ProofValidator{ProofID: 1, UserID: 2, Vote: "approve", VotedAt: now})
+
+	suite.Require().NoError(suite.leaderboardService.Recompute(models.LeaderboardValidators, models.LeaderboardAllTime, now))
+	board, err := suite.leaderboardService.GetLeaderboard(models.LeaderboardValidators, models.LeaderboardAllTime, 10, 0)
+	suite.Require().NoError(err)
+	suite.Require().Len(board.Entries, 1)
+	suite.Equal(uint(1), board.Entries[0].UserID)
+	suite.InDelta(66.67, board.Entries[0].Score, 0.01)
+	suite.Equal(3, board.Entries[0].SampleSize)
+}
+
+func TestLeaderboardTestSuite(t *testing.T) {
+	suite.Run(t, new(LeaderboardTestSuite))
+}
@@ -0,0 +1,76 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLiquidationEngine 가격이 움직이면 유지 증거금에 못 미치는 숏을 바로 환매 주문하고, 환매 주문이 전량 체결되면 완료 기록/알림
+func TestLiquidationEngine(t *testing.T) {
+	env := testkit.New(t)
+	engine := testkit.StartMatchingEngine(t, env.DB)
+	tradingService := services.NewTradingService(env.DB, nil, engine)
+	margin := services.NewMarginService(env.DB, tradingService)
+	liquidation := services.NewLiquidationEngine(margin)
+	engine.AddTradeListener(liquidation)
+	require.NoError(t, liquidation.Start())
+	t.Cleanup(liquidation.Stop)
+	milestone := env.Factory.Market()
+
+	place := func(userID uint, side models.OrderSide, price float64, quantity int64) {
+		_, err := tradingService.CreateOrder(userID, models.CreateOrderRequest{
+			ProjectID: milestone.ProjectID, MilestoneID: milestone.ID, OptionID: models.OptionSuccess,
+			Type: models.OrderTypeLimit, Side: side, Quantity: quantity, Price: price,
+		}, "", "")
+		require.NoError(t, err)
+		testkit.Settle(t, engine)
+	}
+
+	// 0.5에 100주 숏 (담보 5000센트), 잔액이 충분하면 체결 후 검사에서 청산하지 않음
+	shorter := env.Factory.FundedUser(10000)
+	buyer := env.Factory.FundedUser(10000)
+	place(shorter.ID, models.OrderSideSell, 0.5, 100)
+	place(buyer.ID, models.OrderSideBuy, 0.5, 100)
+
+	// 잔액이 바닥난 뒤 0.54 체결 → 평가 자산 5000 < 유지 증거금 5940 (환매 비용 5400 + 10%) → 0.59에 84주 환매 주문
+	require.NoError(t, env.DB.Model(&models.UserWallet{}).Where("user_id = ?", shorter.ID).Update("usdc_balance", 0).Error)
+	holder := env.Factory.FundedUser(0)
+	env.Factory.Position(holder.ID, milestone, models.OptionSuccess, 200, 0.3)
+	place(holder.ID, models.OrderSideSell, 0.54, 10)
+	place(buyer.ID, models.OrderSideBuy, 0.54, 10)
+
+	var event models.MarginLiquidation
+	require.Eventually(t, func() bool {
+		return env.DB.Where("user_id = ?", shorter.ID).First(&event).Error == nil
+	}, 2*time.Second, 10*time.Millisecond)
+	testkit.Settle(t, engine)
+	assert.Equal(t, models.MarginLiquidationTriggerPriceMove, event.Trigger)
+	assert.Equal(t, models.MarginLiquidationOpen, event.Status)
+	assert.Equal(t, int64(84), event.Quantity)
+	assert.Equal(t, models.PriceToTicks(0.59), event.PriceTicks)
+	assert.Equal(t, int64(5940), event.Maintenance)
+
+	// 주문장에서 환매 주문이 전량 체결되면 완료 처리 + 알림
+	place(holder.ID, models.OrderSideSell, 0.59, 84)
+	require.Eventually(t, func() bool {
+		return env.DB.First(&event, event.ID).Error == nil && event.Status == models.MarginLiquidationFilled
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(84), event.Filled)
+
+	var short models.Position
+	require.NoError(t, env.DB.Where("user_id = ? AND milestone_id = ?", shorter.ID, milestone.ID).First(&short).Error)
+	assert.Equal(t, int64(-16), short.Quantity)
+
+	var completed int64
+	require.NoError(t, env.DB.Model(&models.Notification{}).
+		Where("user_id = ? AND type = ? AND title = ?", shorter.ID, models.NotificationTypeMarginCall, "강제 청산 완료").
+		Count(&completed).Error)
+	assert.Equal(t, int64(1), completed)
+}
@@ -0,0 +1,209 @@
+package unit_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
+	"blueprint/internal/services"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// MagicLinkTestSuite 매직링크 일회용 토큰 테스트 슈트
+type MagicLinkTestSuite struct {
+	suite.Suite
+	db     *gorm.DB
+	policy services.MagicLinkPolicy
+	client services.MagicLinkClient
+}
+
+func (suite *MagicLinkTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(&models.MagicLink{}, &models.MagicLinkEvent{}))
+	suite.db = db
+
+	redisServer := miniredis.RunT(suite.T())
+	moduleRedis.Client = redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+
+	suite.policy = services.MagicLinkPolicy{
+		SigningSecret: "test-secret",
+		TTL:           15 * time.Minute,
+		HourlyLimit:   5,
+	}
+	suite.client = services.MagicLinkClient{UserAgent: "Mozilla/5.0 Chrome/120.0", IPAddress: "127.0.0.1"}
+}
+
+func (suite *MagicLinkTestSuite) TearDownTest() {
+	moduleRedis.Client = nil
+}
+
+func (suite *MagicLinkTestSuite) events(event models.MagicLinkEventType) []models.MagicLinkEvent {
+	var events []models.MagicLinkEvent
+	suite.Require().NoError(suite.db.Where("event = ?", event).Find(&events).Error)
+	return events
+}
+
+// TestTokenIsSingleUse 링크는 한 번만 로그인에 쓰이고 재사용은 거부 후 기록됨
+func (suite *MagicLinkTestSuite) TestTokenIsSingleUse() {
+	service := services.NewMagicLinkService(suite.db, suite.policy)
+
+	issued, err := service.Issue("alice@test.com", "", suite.client)
+	suite.Require().NoError(err)
+
+	link, err := service.ConsumeToken(issued.Token, suite.client)
+	suite.Require().NoError(err)
+	suite.True(link.IsUsed)
+	suite.NotNil(link.ConsumedAt)
+
+	_, err = service.ConsumeToken(issued.Token, suite.client)
+	suite.ErrorIs(err, services.ErrMagicLinkInvalid)
+
+	// 같은 링크의 코드도 함께 소비됨
+	_, err = service.ConsumeCode("alice@test.com", issued.Code, suite.client)
+	suite.ErrorIs(err, services.ErrMagicLinkInvalid)
+
+	suite.Len(suite.events(models.MagicLinkEventIssued), 1)
+	suite.Len(suite.events(models.MagicLinkEventConsumed), 1)
+	rejected := suite.events(models.MagicLinkEventRejected)
+	suite.Require().Len(rejected, 2)
+	suite.Equal("replayed", rejected[0].Reason)
+}
+
+// TestForgedTokenRejected 서명이 맞지 않는 토큰은 조회 없이 거부
+func (suite *MagicLinkTestSuite) TestForgedTokenRejected() {
+	service := services.NewMagicLinkService(suite.db, suite.policy)
+
+	issued, err := service.Issue("alice@test.com", "", suite.client)
+	suite.Require().NoError(err)
+
+	other := services.NewMagicLinkService(suite.db, services.MagicLinkPolicy{SigningSecret: "other-secret", TTL: time.Minute})
+	_, err = other.ConsumeToken(issued.Token, suite.client)
+	suite.ErrorIs(err, services.ErrMagicLinkInvalid)
+
+	// 위조 시도로 원래 링크가 소비되지는 않음
+	_, err = service.ConsumeToken(issued.Token, suite.client)
+	suite.NoError(err)
+}
+
+// TestReissueRevokesPreviousLink 재발송하면 이전 링크는 쓸 수 없음
+func (suite *MagicLinkTestSuite) TestReissueRevokesPreviousLink() {
+	service := services.NewMagicLinkService(suite.db, suite.policy)
+
+	first, err := service.Issue("alice@test.com", "", suite.client)
+	suite.Require().NoError(err)
+	second, err := service.Issue("alice@test.com", "", suite.client)
+	suite.Require().NoError(err)
+
+	_, err = service.ConsumeToken(first.Token, suite.client)
+	suite.ErrorIs(err, services.ErrMagicLinkInvalid)
+	_, err = service.ConsumeCode("alice@test.com", second.Code, suite.client)
+	suite.NoError(err)
+}
+
+// TestDeviceBinding 기기 바인딩 시 다른 User-Agent에서 사용 불가
+func (suite *MagicLinkTestSuite) TestDeviceBinding() {
+	suite.policy.BindDevice = true
+	service := services.NewMagicLinkService(suite.db, suite.policy)
+
+	issued, err := service.Issue("alice@test.com", "", suite.client)
+	suite.Require().NoError(err)
+
+	attacker := services.MagicLinkClient{UserAgent: "curl/8.0", IPAddress: "10.0.0.1"}
+	_, err = service.ConsumeToken(issued.Token, attacker)
+	suite.ErrorIs(err, services.ErrMagicLinkDeviceMismatch)
+
+	rejected := suite.events(models.MagicLinkEventRejected)
+	suite.Require().Len(rejected, 1)
+	suite.Equal("device_mismatch", rejected[0].Reason)
+	suite.Equal("10.0.0.1", rejected[0].IPAddress)
+}
+
+// TestResendThrottled 재발송 간격과 시간당 횟수 제한
+func (suite *MagicLinkTestSuite) TestResendThrottled() {
+	suite.policy.ResendInterval = time.Minute
+	service := services.NewMagicLinkService(suite.db, suite.policy)
+
+	_, err := service.Issue("alice@test.com", "", suite.client)
+	suite.Require().NoError(err)
+	_, err = service.Issue("Alice@test.com", "", suite.client)
+	suite.ErrorIs(err, services.ErrMagicLinkThrottled)
+
+	suite.policy.ResendInterval = 0
+	suite.policy.HourlyLimit = 2
+	service = services.NewMagicLinkService(suite.db, suite.policy)
+	_, err = service.Issue("bob@test.com", "", suite.client)
+	suite.Require().NoError(err)
+	_, err = service.Issue("bob@test.com", "", suite.client)
+	suite.Require().NoError(err)
+	_, err = service.Issue("bob@test.com", "", suite.client)
+	suite.ErrorIs(err, services.ErrMagicLinkThrottled)
+}
+
+// TestCodeRequiresMatchingEmail 코드는 발급받은 이메일과 함께만 쓸 수 있음
+func (suite *MagicLinkTestSuite) TestCodeRequiresMatchingEmail() {
+	service := services.NewMagicLinkService(suite.db, suite.policy)
+
+	issued, err := service.Issue("alice@test.com", "", suite.client)
+	suite.Require().NoError(err)
+	_, err = service.Issue("bob@test.com", "", suite.client)
+	suite.Require().NoError(err)
+
+	_, err = service.ConsumeCode("bob@test.com", issued.Code, suite.client)
+	suite.ErrorIs(err, services.ErrMagicLinkInvalid)
+
+	link, err := service.ConsumeCode("Alice@test.com", issued.Code, suite.client)
+	suite.Require().NoError(err)
+	suite.Equal("alice@test.com", link.Email)
+}
+
+// TestWrongCodesCancelLink 같은 이메일로 틀린 코드가 5회 쌓이면 링크가 취소되고 다른 이메일은 영향 없음
+func (suite *MagicLinkTestSuite) TestWrongCodesCancelLink() {
+	service := services.NewMagicLinkService(suite.db, suite.policy)
+
+	alice, err := service.Issue("alice@test.com", "", suite.client)
+	suite.Require().NoError(err)
+	bob, err := service.Issue("bob@test.com", "", suite.client)
+	suite.Require().NoError(err)
+
+	wrong := "100000"
+	if alice.Code == wrong {
+		wrong = "100001"
+	}
+	for i := 0; i < 5; i++ {
+		client := services.MagicLinkClient{UserAgent: suite.client.UserAgent, IPAddress: fmt.Sprintf("10.0.0.%d", i)}
+		_, err = service.ConsumeCode("alice@test.com", wrong, client)
+		suite.ErrorIs(err, services.ErrMagicLinkInvalid)
+	}
+
+	// 여러 IP로 나눠 시도해도 맞는 코드와 이메일 링크 모두 쓸 수 없음
+	_, err = service.ConsumeCode("alice@test.com", alice.Code, suite.client)
+	suite.ErrorIs(err, services.ErrMagicLinkInvalid)
+	_, err = service.ConsumeToken(alice.Token, suite.client)
+	suite.ErrorIs(err, services.ErrMagicLinkInvalid)
+
+	rejected := suite.events(models.MagicLinkEventRejected)
+	suite.Require().NotEmpty(rejected)
+	suite.Equal("too_many_attempts", rejected[0].Reason)
+	suite.Equal(alice.Link.ID, rejected[0].MagicLinkID)
+
+	_, err = service.ConsumeCode("bob@test.com", bob.Code, suite.client)
+	suite.NoError(err)
+
+	// 새로 발급받으면 다시 시도할 수 있음
+	reissued, err := service.Issue("alice@test.com", "", suite.client)
+	suite.Require().NoError(err)
+	_, err = service.ConsumeCode("alice@test.com", reissued.Code, suite.client)
+	suite.NoError(err)
+}
+
+func TestMagicLinkTestSuite(t *testing.T) {
+	suite.Run(t, new(MagicLinkTestSuite))
+}
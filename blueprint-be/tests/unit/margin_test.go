@@ -0,0 +1,101 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestShortMargin 숏 매도는 수량 × (1 - 가격) 담보를 잠그고, 환매 비율만큼 풀리며, 평가 자산이 환매 비용보다 적으면 강제 환매
+func TestShortMargin(t *testing.T) {
+	env := testkit.New(t)
+	engine := testkit.StartMatchingEngine(t, env.DB)
+	tradingService := services.NewTradingService(env.DB, nil, engine)
+	margin := services.NewMarginService(env.DB, tradingService)
+	milestone := env.Factory.Market()
+
+	place := func(userID uint, side models.OrderSide, price float64, quantity int64) error {
+		_, err := tradingService.CreateOrder(userID, models.CreateOrderRequest{
+			ProjectID: milestone.ProjectID, MilestoneID: milestone.ID, OptionID: models.OptionSuccess,
+			Type: models.OrderTypeLimit, Side: side, Quantity: quantity, Price: price,
+		}, "", "")
+		testkit.Settle(t, engine)
+		return err
+	}
+	wallet := func(userID uint) models.UserWallet {
+		var w models.UserWallet
+		require.NoError(t, env.DB.Where("user_id = ?", userID).First(&w).Error)
+		return w
+	}
+	position := func(userID uint) models.Position {
+		var p models.Position
+		require.NoError(t, env.DB.Where("user_id = ? AND milestone_id = ?", userID, milestone.ID).First(&p).Error)
+		return p
+	}
+
+	// 보유 없이 100주 0.5 매도 → 담보 100 × 0.5 = 5000센트, 잔액이 모자라면 거부
+	poor := env.Factory.FundedUser(1000)
+	assert.ErrorIs(t, place(poor.ID, models.OrderSideSell, 0.5, 100), services.ErrInsufficientCollateral)
+
+	shorter := env.Factory.FundedUser(10000)
+	require.NoError(t, place(shorter.ID, models.OrderSideSell, 0.5, 100))
+	assert.Equal(t, int64(5000), wallet(shorter.ID).USDCLockedBalance)
+
+	// 체결되면 담보가 포지션으로 옮겨지고 잠긴 잔액은 그대로
+	buyer := env.Factory.FundedUser(10000)
+	require.NoError(t, place(buyer.ID, models.OrderSideBuy, 0.5, 100))
+	short := position(shorter.ID)
+	assert.Equal(t, int64(-100), short.Quantity)
+	assert.Equal(t, int64(5000), short.Collateral)
+	assert.Equal(t, int64(5000), wallet(shorter.ID).USDCLockedBalance)
+
+	// 절반 환매 → 담보 절반 해제
+	holder := env.Factory.FundedUser(0)
+	env.Factory.Position(holder.ID, milestone, models.OptionSuccess, 100, 0.3)
+	require.NoError(t, place(holder.ID, models.OrderSideSell, 0.5, 50))
+	require.NoError(t, place(shorter.ID, models.OrderSideBuy, 0.5, 50))
+	short = position(shorter.ID)
+	assert.Equal(t, int64(-50), short.Quantity)
+	assert.Equal(t, int64(2500), short.Collateral)
+	assert.Equal(t, int64(2500), wallet(shorter.ID).USDCLockedBalance)
+
+	// 현재가 0.5에서는 담보만으로 환매 비용을 감당
+	liquidated, err := margin.CheckMargins(time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, liquidated)
+
+	// 잔액이 바닥난 뒤 가격이 0.58로 오르면 평가 자산 2500 < 환매 비용 2900 → 담보를 풀어 0.63에 39주 환매 주문
+	require.NoError(t, env.DB.Model(&models.UserWallet{}).Where("user_id = ?", shorter.ID).Update("usdc_balance", 0).Error)
+	require.NoError(t, place(holder.ID, models.OrderSideSell, 0.58, 10))
+	require.NoError(t, place(buyer.ID, models.OrderSideBuy, 0.58, 10))
+
+	summary, err := margin.GetSummary(shorter.ID)
+	require.NoError(t, err)
+	assert.True(t, summary.Insufficient)
+	assert.Equal(t, int64(2500), summary.Equity)
+	assert.Equal(t, int64(2900), summary.Liability)
+
+	liquidated, err = margin.CheckMargins(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, liquidated)
+	testkit.Settle(t, engine)
+
+	liquidations, err := margin.ListLiquidations(shorter.ID)
+	require.NoError(t, err)
+	require.Len(t, liquidations, 1)
+	assert.Equal(t, int64(39), liquidations[0].Quantity)
+	assert.Equal(t, models.PriceToTicks(0.63), liquidations[0].PriceTicks)
+	assert.Equal(t, int64(2500), liquidations[0].Released)
+	assert.Zero(t, position(shorter.ID).Collateral)
+
+	// 청산 주문이 열려 있는 동안에는 다시 청산하지 않음
+	liquidated, err = margin.CheckMargins(time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, liquidated)
+}
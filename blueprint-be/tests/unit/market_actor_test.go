@@ -0,0 +1,87 @@
+package unit_test
+
+import (
+	"sync"
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestMatchingEngineShardsMarketsOntoActors(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Order{}, &models.Trade{}))
+
+	// 두 시장에 겹치지 않는 매수 호가를 20개씩 적재
+	var resting []models.Order
+	for i := 0; i < 40; i++ {
+		optionID := "success"
+		if i%2 == 1 {
+			optionID = "fail"
+		}
+		resting = append(resting, models.Order{
+			ID: uint(i + 1), UserID: uint(i + 1), MilestoneID: 7, OptionID: optionID,
+			Side: models.OrderSideBuy, Price: 0.10 + float64(i)*0.01, Quantity: 1, Remaining: 1,
+			Status: models.OrderStatusPending,
+		})
+	}
+	require.NoError(t, db.Create(&resting).Error)
+
+	engine := services.NewLocalMatchingEngine(db, nil, nil, nil)
+	require.NoError(t, engine.Start())
+	defer engine.Stop()
+
+	// 두 시장에 취소와 조회를 동시에 보내도 각 시장 액터가 순서대로 처리
+	var wg sync.WaitGroup
+	for i := range resting {
+		if i%4 >= 2 {
+			continue // 시장별로 절반만 취소
+		}
+		wg.Add(2)
+		go func(order models.Order) {
+			defer wg.Done()
+			engine.CancelOrder(&order)
+		}(resting[i])
+		go func(optionID string) {
+			defer wg.Done()
+			engine.GetOrderBook(7, optionID, 100, 0)
+		}(resting[i].OptionID)
+	}
+	wg.Wait()
+
+	assert.Len(t, engine.GetOrderBook(7, "success", 100, 0).Bids, 10)
+	assert.Len(t, engine.GetOrderBook(7, "fail", 100, 0).Bids, 10)
+
+	stats := engine.GetStats()
+	assert.Equal(t, 2, stats.ActiveOrderBooks)
+	assert.Equal(t, int64(20), stats.CancelsProcessed)
+	assert.Zero(t, stats.PendingCancels)
+}
+
+// TestMatchingEngineRestartsAfterStop 정지 후 다시 시작하면 주문장을 DB에서 한 번만 적재하고 시장 액터가 다시 주문을 처리
+func TestMatchingEngineRestartsAfterStop(t *testing.T) {
+	env := testkit.New(t)
+	market := env.Factory.Market()
+	seller := env.Factory.FundedUser(0)
+	buyer := env.Factory.FundedUser(100_000)
+	env.Factory.Position(seller.ID, market, models.OptionSuccess, 10, 0.4)
+	env.Factory.Order(seller.ID, market, models.OrderSideSell, 0.5, 10)
+
+	engine := testkit.StartMatchingEngine(t, env.DB)
+	require.NoError(t, engine.Stop())
+	require.NoError(t, engine.Start())
+	assert.Len(t, engine.GetOrderBook(market.ID, models.OptionSuccess, 10, 0).Asks, 1)
+
+	buy := env.Factory.Order(buyer.ID, market, models.OrderSideBuy, 0.5, 4)
+	result, err := engine.SubmitOrder(buy)
+	require.NoError(t, err)
+	require.Len(t, result.Trades, 1)
+	assert.Equal(t, int64(4), result.Trades[0].Quantity)
+}
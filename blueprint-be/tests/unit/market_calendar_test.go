@@ -0,0 +1,77 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMarketCalendarClosesAtTargetDate 목표일이 지나면 주문을 거부하고 미체결 주문을 만료, 마감 시각을 미루면 재개
+func TestMarketCalendarClosesAtTargetDate(t *testing.T) {
+	env := testkit.New(t)
+	engine := testkit.StartMatchingEngine(t, env.DB)
+	tradingService := services.NewTradingService(env.DB, nil, engine)
+	calendar := services.NewMarketCalendarService(env.DB, nil, engine)
+
+	target := time.Now().Add(time.Hour)
+	milestone := env.Factory.Market(func(m *models.Milestone) { m.TargetDate = &target })
+	buyer := env.Factory.FundedUser(10000)
+
+	request := models.CreateOrderRequest{
+		ProjectID:   milestone.ProjectID,
+		MilestoneID: milestone.ID,
+		OptionID:    models.OptionSuccess,
+		Type:        models.OrderTypeLimit,
+		Side:        models.OrderSideBuy,
+		Quantity:    100,
+		Price:       0.4,
+	}
+	placed, err := tradingService.CreateOrder(buyer.ID, request, "", "")
+	require.NoError(t, err)
+
+	closed, _, err := calendar.Sync(time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, closed, "목표일 전에는 마감하지 않음")
+
+	// 목표일 경과: 주문 거부 후 캘린더가 미체결 주문 만료
+	require.NoError(t, env.DB.Model(milestone).UpdateColumn("target_date", time.Now().Add(-time.Minute)).Error)
+	_, err = tradingService.CreateOrder(buyer.ID, request, "", "")
+	assert.ErrorIs(t, err, services.ErrMarketClosed)
+
+	closed, _, err = calendar.Sync(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, closed)
+
+	var order models.Order
+	require.NoError(t, env.DB.First(&order, placed.Order.ID).Error)
+	assert.Equal(t, models.OrderStatusExpired, order.Status)
+	var wallet models.UserWallet
+	require.NoError(t, env.DB.Where("user_id = ?", buyer.ID).First(&wallet).Error)
+	assert.Zero(t, wallet.USDCLockedBalance)
+	assert.Equal(t, int64(10000), wallet.USDCBalance)
+	assert.Empty(t, engine.GetOrderBook(milestone.ID, models.OptionSuccess, 10, 0).Bids)
+
+	view, err := tradingService.GetMarketView(milestone.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.MarketStateClosed, view.TradingState)
+	assert.NotNil(t, view.Milestone.TradingClosedAt)
+
+	closed, _, err = calendar.Sync(time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, closed, "이미 마감한 마켓은 다시 처리하지 않음")
+
+	// 마감 시각을 미래로 지정하면 재개
+	later := time.Now().Add(24 * time.Hour)
+	updated, err := calendar.SetCloseTime(milestone.ID, &later)
+	require.NoError(t, err)
+	assert.Nil(t, updated.TradingClosedAt)
+	assert.Equal(t, models.MarketStateOpen, updated.TradingState(time.Now()))
+	_, err = tradingService.CreateOrder(buyer.ID, request, "", "")
+	assert.NoError(t, err)
+}
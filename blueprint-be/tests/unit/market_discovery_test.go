@@ -0,0 +1,71 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestListMarkets(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Project{}, &models.Milestone{}, &models.MarketData{}))
+
+	db.Create(&models.User{ID: 1, Email: "owner@test.com", Username: "owner"})
+	db.Create(&models.Project{ID: 1, UserID: 1, Title: "Startup", Category: models.BusinessProject})
+	db.Create(&models.Project{ID: 2, UserID: 1, Title: "Study", Category: models.EducationProject})
+
+	soon := time.Now().Add(12 * time.Hour)
+	later := time.Now().Add(30 * 24 * time.Hour)
+	db.Create(&models.Milestone{ID: 1, ProjectID: 1, Title: "MVP", Status: models.MilestoneStatusActive, TargetDate: &later})
+	db.Create(&models.Milestone{ID: 2, ProjectID: 1, Title: "Launch", Status: models.MilestoneStatusFunding, TargetDate: &soon})
+	db.Create(&models.Milestone{ID: 3, ProjectID: 2, Title: "Exam", Status: models.MilestoneStatusActive, TargetDate: &later})
+	db.Create(&models.Milestone{ID: 4, ProjectID: 2, Title: "Done", Status: models.MilestoneStatusCompleted, TargetDate: &later})
+
+	db.Create(&models.MarketData{MilestoneID: 1, OptionID: "success", CurrentPrice: 0.7, ChangePercent: 2, Volume24h: 500})
+	db.Create(&models.MarketData{MilestoneID: 1, OptionID: "fail", CurrentPrice: 0.3, ChangePercent: -2, Volume24h: 300})
+	db.Create(&models.MarketData{MilestoneID: 3, OptionID: "success", CurrentPrice: 0.4, ChangePercent: -25, Volume24h: 100})
+
+	tradingService := services.NewTradingService(db, nil, nil)
+
+	// 기본: 거래 가능한 마켓을 거래량 순으로
+	markets, total, err := tradingService.ListMarkets(services.MarketListFilter{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, markets, 3)
+	assert.Equal(t, uint(1), markets[0].MilestoneID)
+	assert.Equal(t, int64(800), markets[0].Volume24h)
+	assert.Equal(t, 0.7, markets[0].SuccessPrice)
+	assert.Equal(t, 0.5, markets[2].SuccessPrice) // 거래 없는 마켓은 중립 가격
+
+	// 변동률 순
+	markets, _, err = tradingService.ListMarkets(services.MarketListFilter{Sort: services.MarketSortMovers, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, uint(3), markets[0].MilestoneID)
+
+	// 마감 임박 + 카테고리
+	markets, total, err = tradingService.ListMarkets(services.MarketListFilter{
+		Category:      models.BusinessProject,
+		ClosingWithin: 24 * time.Hour,
+		Sort:          services.MarketSortClosingSoon,
+		Limit:         10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, uint(2), markets[0].MilestoneID)
+
+	// 종료된 마켓 포함
+	_, total, err = tradingService.ListMarkets(services.MarketListFilter{Status: services.MarketStatusAll, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+
+	_, _, err = tradingService.ListMarkets(services.MarketListFilter{Sort: "random", Limit: 10})
+	assert.ErrorIs(t, err, services.ErrInvalidMarketSort)
+}
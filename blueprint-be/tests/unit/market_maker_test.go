@@ -0,0 +1,68 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarketMakerPnLFromFills(t *testing.T) {
+	var pnl services.MarketMakerPnL
+
+	// 10주 40¢ 매수 후 4주 50¢ 매도 → 평균 원가 기준 40¢ 실현
+	assert.Zero(t, pnl.ApplyFill(models.OrderSideBuy, 10, 4000, 0))
+	assert.Equal(t, int64(40), pnl.ApplyFill(models.OrderSideSell, 4, 5000, 0))
+	assert.Equal(t, int64(6), pnl.Position)
+	assert.Equal(t, int64(240), pnl.Cost)
+	assert.Equal(t, int64(60), pnl.Unrealized(5000))
+
+	// 10주 매도로 6주 청산(+60¢) 후 4주 매도 포지션, 수수료는 실현 손익에서 차감
+	assert.Equal(t, int64(60-3), pnl.ApplyFill(models.OrderSideSell, 10, 5000, 3))
+	assert.Equal(t, int64(-4), pnl.Position)
+	assert.Equal(t, int64(-200), pnl.Cost)
+	assert.Equal(t, int64(20), pnl.Unrealized(4500), "매도 포지션은 가격 하락 시 이익")
+	assert.Equal(t, int64(97), pnl.Realized)
+	assert.Equal(t, int64(3), pnl.Fees)
+}
+
+func TestMarketMakerSkewsQuotesByInventory(t *testing.T) {
+	config := services.DefaultMarketMakerConfig
+	market := &services.MarketInfo{CurrentPrice: 0.50, Spread: 0.02}
+
+	bid, ask := services.SkewedQuotes(config, market)
+	assert.InDelta(t, 0.49, bid, 1e-9)
+	assert.InDelta(t, 0.51, ask, 1e-9)
+
+	// 한도만큼 매수 보유 → 호가 중심 5¢ 하락
+	market.Position = config.InventoryLimit
+	bid, ask = services.SkewedQuotes(config, market)
+	assert.InDelta(t, 0.44, bid, 1e-9)
+	assert.InDelta(t, 0.46, ask, 1e-9)
+
+	// 매도 보유는 반대로, 한도 초과분은 더 옮기지 않음
+	market.Position = -3 * config.InventoryLimit
+	bid, ask = services.SkewedQuotes(config, market)
+	assert.InDelta(t, 0.53, bid, 1e-9)
+	assert.InDelta(t, 0.57, ask, 1e-9)
+
+	// 호가 단위로 매수는 내림, 매도는 올림
+	market = &services.MarketInfo{CurrentPrice: 0.50, Spread: 0.03, TickSize: 500}
+	bid, ask = services.SkewedQuotes(config, market)
+	assert.InDelta(t, 0.45, bid, 1e-9)
+	assert.InDelta(t, 0.55, ask, 1e-9)
+}
+
+func TestMarketMakerConfigValidation(t *testing.T) {
+	config := services.DefaultMarketMakerConfig
+	assert.NoError(t, config.Validate())
+
+	config.MaxSpread = config.MinSpread / 2
+	assert.ErrorIs(t, config.Validate(), services.ErrInvalidMarketMakerConfig)
+
+	config = services.DefaultMarketMakerConfig
+	config.MaxLoss = -1
+	assert.ErrorIs(t, config.Validate(), services.ErrInvalidMarketMakerConfig)
+}
@@ -0,0 +1,64 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMarketMetricsTWAPAndVWAP 창 시작 전 체결가부터 시간 가중, 창 안 체결만 수량 가중, 체결이 없어도 창을 밀면 재계산
+func TestMarketMetricsTWAPAndVWAP(t *testing.T) {
+	env := testkit.New(t)
+	metrics := services.NewMarketMetricsService(env.DB)
+	milestone := env.Factory.Market()
+	now := time.Now().Truncate(time.Second)
+
+	trade := func(ago time.Duration, price float64, quantity int64) {
+		require.NoError(t, env.DB.Create(&models.Trade{
+			ProjectID:   milestone.ProjectID,
+			MilestoneID: milestone.ID,
+			OptionID:    models.OptionSuccess,
+			Quantity:    quantity,
+			Price:       price,
+			PriceTicks:  models.PriceToTicks(price),
+			TotalAmount: int64(price * float64(quantity) * 100),
+			CreatedAt:   now.Add(-ago),
+		}).Error)
+	}
+	trade(30*time.Hour, 0.40, 10) // 24시간 창 시작 가격
+	trade(12*time.Hour, 0.60, 10) // 1시간 창 시작 가격
+	trade(30*time.Minute, 0.50, 30)
+	trade(15*time.Minute, 0.70, 10)
+	require.NoError(t, env.DB.Create(&models.MarketData{
+		MilestoneID:   milestone.ID,
+		OptionID:      models.OptionSuccess,
+		CurrentPrice:  0.70,
+		LastTradeTime: now.Add(-15 * time.Minute),
+	}).Error)
+
+	_, err := metrics.Refresh(milestone.ID, models.OptionSuccess, now)
+	require.NoError(t, err)
+
+	var data models.MarketData
+	require.NoError(t, env.DB.Where("milestone_id = ? AND option_id = ?", milestone.ID, models.OptionSuccess).First(&data).Error)
+	assert.InDelta(t, 0.60, data.TWAP1h, 1e-9)  // (0.6×30분 + 0.5×15분 + 0.7×15분) / 60분
+	assert.InDelta(t, 0.50, data.TWAP24h, 1e-9) // (0.4×12h + 0.6×11.5h + 0.5×15분 + 0.7×15분) / 24h
+	assert.InDelta(t, 0.55, data.VWAP1h, 1e-9)  // (0.5×30 + 0.7×10) / 40
+	assert.InDelta(t, 0.56, data.VWAP24h, 1e-9) // (0.6×10 + 0.5×30 + 0.7×10) / 50
+	require.NotNil(t, data.MetricsUpdatedAt)
+
+	// 2시간 뒤: 1시간 창에는 체결이 없어 VWAP 0, TWAP는 마지막 체결가 유지
+	refreshed, err := metrics.RefreshActive(now.Add(2 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, refreshed)
+	require.NoError(t, env.DB.Where("milestone_id = ? AND option_id = ?", milestone.ID, models.OptionSuccess).First(&data).Error)
+	assert.InDelta(t, 0.70, data.TWAP1h, 1e-9)
+	assert.Zero(t, data.VWAP1h)
+	assert.InDelta(t, 0.56, data.VWAP24h, 1e-9)
+}
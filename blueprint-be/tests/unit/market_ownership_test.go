@@ -0,0 +1,193 @@
+package unit_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestHashRingMovesOnlyRemovedMembersMarkets 인스턴스가 빠지면 그 인스턴스의 마켓만 이동
+func TestHashRingMovesOnlyRemovedMembersMarkets(t *testing.T) {
+	full := services.NewHashRing([]string{"engine-a", "engine-b", "engine-c"}, 64)
+	reduced := services.NewHashRing([]string{"engine-a", "engine-b"}, 64)
+
+	counts := map[string]int{}
+	for i := 1; i <= 300; i++ {
+		market := fmt.Sprintf("%d:success", i)
+		owner := full.Owner(market)
+		counts[owner]++
+		if owner != "engine-c" {
+			assert.Equal(t, owner, reduced.Owner(market), "남은 인스턴스의 마켓은 그대로")
+		}
+	}
+	for _, member := range []string{"engine-a", "engine-b", "engine-c"} {
+		assert.Greater(t, counts[member], 50, "가상 노드로 고르게 분산")
+	}
+
+	assert.Empty(t, services.NewHashRing(nil, 64).Owner("1:success"))
+}
+
+// MarketOwnershipTestSuite 마켓 담당 배정/장애 조치 테스트 슈트
+type MarketOwnershipTestSuite struct {
+	suite.Suite
+	redisServer *miniredis.Miniredis
+	redisClient *redis.Client
+	markets     []string
+}
+
+func (suite *MarketOwnershipTestSuite) SetupTest() {
+	suite.redisServer = miniredis.RunT(suite.T())
+	suite.redisClient = redis.NewClient(&redis.Options{Addr: suite.redisServer.Addr()})
+	suite.markets = nil
+	for i := 1; i <= 20; i++ {
+		suite.markets = append(suite.markets, fmt.Sprintf("%d:success", i), fmt.Sprintf("%d:fail", i))
+	}
+}
+
+func (suite *MarketOwnershipTestSuite) TearDownTest() {
+	suite.redisClient.Close()
+}
+
+// newManager 인수/해제를 기록하는 관리자 (리스 1초, 인스턴스 50ms 무응답 시 제외)
+func (suite *MarketOwnershipTestSuite) newManager(instanceID string, acquired map[string]int, mutex *sync.Mutex) *services.MarketOwnershipManager {
+	config := services.MarketOwnershipConfig{
+		LeaseTTL:          time.Second,
+		HeartbeatInterval: 10 * time.Millisecond,
+		InstanceTTL:       50 * time.Millisecond,
+		VirtualNodes:      64,
+	}
+	return services.NewMarketOwnershipManager(suite.redisClient, instanceID, config,
+		func() ([]string, error) { return suite.markets, nil },
+		func(market string) error {
+			mutex.Lock()
+			acquired[market]++
+			mutex.Unlock()
+			return nil
+		},
+		func(string) {})
+}
+
+// TestInstancesSplitMarketsAndFailOver 두 인스턴스가 마켓을 나누고, 한쪽이 죽으면 리스 만료 후 인수
+func (suite *MarketOwnershipTestSuite) TestInstancesSplitMarketsAndFailOver() {
+	ctx := context.Background()
+	var mutex sync.Mutex
+	acquired := map[string]int{}
+	first := suite.newManager("engine-a", acquired, &mutex)
+	second := suite.newManager("engine-b", acquired, &mutex)
+
+	// 혼자일 때는 전부 담당, 두 번째 인스턴스가 합류하면 링에 따라 넘겨줌
+	suite.Require().NoError(first.Rebalance(ctx))
+	suite.Len(first.OwnedMarkets(), len(suite.markets))
+	suite.Require().NoError(second.Rebalance(ctx))
+	suite.Empty(second.OwnedMarkets(), "이전 담당자의 리스가 남아 있으면 인수하지 않음")
+	suite.Require().NoError(first.Rebalance(ctx))
+	suite.Require().NoError(second.Rebalance(ctx))
+
+	suite.NotEmpty(first.OwnedMarkets())
+	suite.NotEmpty(second.OwnedMarkets())
+	suite.Len(first.OwnedMarkets(), len(suite.markets)-len(second.OwnedMarkets()))
+	for _, market := range second.OwnedMarkets() {
+		suite.False(first.Owns(market))
+		suite.Equal("engine-b", first.Owner(market))
+	}
+
+	// engine-a 장애: 하트비트가 끊기고 리스가 만료되면 engine-b가 모두 인수
+	time.Sleep(60 * time.Millisecond)
+	suite.Require().NoError(second.Rebalance(ctx))
+	suite.Len(second.OwnedMarkets(), len(suite.markets)-len(first.OwnedMarkets()), "리스 만료 전")
+
+	suite.redisServer.FastForward(2 * time.Second)
+	suite.Require().NoError(second.Rebalance(ctx))
+	suite.Len(second.OwnedMarkets(), len(suite.markets))
+
+	takenOver := first.OwnedMarkets()[0]
+	suite.Equal(2, acquired[takenOver], "인수 시마다 onAcquire(주문장 재구성) 실행")
+}
+
+// TestRunReleasesLeasesOnShutdown 정상 종료 시 리스를 반납해 다른 인스턴스가 바로 인수
+func (suite *MarketOwnershipTestSuite) TestRunReleasesLeasesOnShutdown() {
+	var mutex sync.Mutex
+	first := suite.newManager("engine-a", map[string]int{}, &mutex)
+	second := suite.newManager("engine-b", map[string]int{}, &mutex)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		first.Run(ctx)
+		close(done)
+	}()
+	suite.Eventually(func() bool { return len(first.OwnedMarkets()) == len(suite.markets) }, time.Second, 5*time.Millisecond)
+	suite.True(first.Running())
+
+	cancel()
+	<-done
+	suite.False(first.Running())
+	suite.Empty(first.OwnedMarkets())
+
+	suite.Require().NoError(second.Rebalance(context.Background()))
+	suite.Len(second.OwnedMarkets(), len(suite.markets))
+}
+
+func TestMarketOwnershipSuite(t *testing.T) {
+	suite.Run(t, new(MarketOwnershipTestSuite))
+}
+
+// TestRebuildOrderBookFromEvents 생성/체결/취소 이벤트를 재생해 잔량 기준 주문장 복원
+func TestRebuildOrderBookFromEvents(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	redisServer := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+	defer client.Close()
+
+	ctx := context.Background()
+	eventSourcing := services.NewOrderEventSourcing(client)
+	appendEvent := func(eventType services.OrderEventType, orderID uint, payload map[string]interface{}) {
+		assert.NoError(t, eventSourcing.AppendEvent(ctx, "1:success", &services.OrderEvent{
+			EventID:   fmt.Sprintf("%s-%d", eventType, orderID),
+			EventType: eventType,
+			OrderID:   orderID,
+			Payload:   payload,
+		}))
+	}
+	order := func(id uint, side models.OrderSide, quantity int64, price float64) map[string]interface{} {
+		return map[string]interface{}{"order": models.Order{
+			ID: id, MilestoneID: 1, OptionID: "success", Side: side, Quantity: quantity, Price: price,
+		}}
+	}
+
+	appendEvent(services.EventOrderCreated, 1, order(1, models.OrderSideBuy, 100, 0.6))
+	appendEvent(services.EventOrderCreated, 2, order(2, models.OrderSideSell, 40, 0.6))
+	appendEvent(services.EventTradeExecuted, 0, map[string]interface{}{"trade": models.Trade{
+		BuyOrderID: 1, SellOrderID: 2, Quantity: 40, Price: 0.6,
+	}})
+	appendEvent(services.EventOrderCreated, 3, order(3, models.OrderSideSell, 50, 0.7))
+	appendEvent(services.EventOrderCreated, 4, order(4, models.OrderSideBuy, 10, 0.55))
+	appendEvent(services.EventOrderCancelled, 4, nil)
+
+	engine := services.NewDistributedMatchingEngineWithRedis(db, nil, client)
+	orderBook, err := engine.RebuildOrderBook("1:success")
+	assert.NoError(t, err)
+	if assert.Len(t, orderBook.Bids, 1) {
+		assert.Equal(t, uint(1), orderBook.Bids[0].ID)
+		assert.Equal(t, int64(100), orderBook.Bids[0].Quantity)
+		assert.Equal(t, int64(60), orderBook.Bids[0].Remaining, "체결 후 잔량")
+	}
+	if assert.Len(t, orderBook.Asks, 1) {
+		assert.Equal(t, uint(3), orderBook.Asks[0].ID)
+	}
+	assert.Equal(t, 0.6, orderBook.LastPrice)
+	assert.True(t, redisServer.Exists("orderbook:1:success"), "재구성한 주문장 저장")
+}
@@ -0,0 +1,143 @@
+package unit_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/handlers"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// marketResponse GET /milestones/:id/market 응답 (전체/델타 공통 필드)
+type marketResponse struct {
+	Data struct {
+		Version     string          `json:"version"`
+		BaseVersion string          `json:"base_version"`
+		Delta       bool            `json:"delta"`
+		MarketData  json.RawMessage `json:"market_data"`
+		TotalVolume *int64          `json:"total_volume"`
+	} `json:"data"`
+}
+
+func newMarketRouter(t *testing.T) (*gin.Engine, *testkit.Env, *models.Milestone) {
+	t.Helper()
+	env := testkit.New(t)
+	milestone := env.Factory.Market()
+	require.NoError(t, env.DB.Create(&[]models.MarketData{
+		{MilestoneID: milestone.ID, OptionID: models.OptionSuccess, CurrentPrice: 0.6, Volume24h: 100},
+		{MilestoneID: milestone.ID, OptionID: models.OptionFail, CurrentPrice: 0.4, Volume24h: 50},
+	}).Error)
+
+	handler := handlers.NewTradingHandler(services.NewTradingService(env.DB, nil, nil), nil, services.NewMilestoneRiskService(env.DB))
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/milestones/:id/market", handler.GetMilestoneMarket)
+	return router, env, milestone
+}
+
+func getMarket(t *testing.T, router *gin.Engine, path string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeMarket(t *testing.T, rec *httptest.ResponseRecorder) marketResponse {
+	t.Helper()
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var response marketResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	return response
+}
+
+// TestMarketViewNotModified 같은 버전의 If-None-Match는 본문 없이 304
+func TestMarketViewNotModified(t *testing.T) {
+	router, _, milestone := newMarketRouter(t)
+	path := fmt.Sprintf("/milestones/%d/market", milestone.ID)
+
+	first := getMarket(t, router, path, nil)
+	response := decodeMarket(t, first)
+	etag := first.Header().Get("ETag")
+	assert.Equal(t, fmt.Sprintf(`"%s"`, response.Data.Version), etag)
+	assert.False(t, response.Data.Delta)
+	require.NotNil(t, response.Data.TotalVolume)
+	assert.Equal(t, int64(150), *response.Data.TotalVolume)
+
+	for _, ifNoneMatch := range []string{etag, "W/" + etag, `"stale", ` + etag} {
+		rec := getMarket(t, router, path, http.Header{"If-None-Match": {ifNoneMatch}})
+		assert.Equal(t, http.StatusNotModified, rec.Code, ifNoneMatch)
+		assert.Empty(t, rec.Body.Bytes())
+		assert.Equal(t, etag, rec.Header().Get("ETag"))
+	}
+
+	stale := getMarket(t, router, path, http.Header{"If-None-Match": {`"0-000000000000"`}})
+	assert.Equal(t, http.StatusOK, stale.Code)
+}
+
+// TestMarketViewDeltaSinceVersion ?since=<version> 응답에는 바뀐 옵션의 바뀐 필드만 들어감
+func TestMarketViewDeltaSinceVersion(t *testing.T) {
+	router, env, milestone := newMarketRouter(t)
+	path := fmt.Sprintf("/milestones/%d/market", milestone.ID)
+	base := decodeMarket(t, getMarket(t, router, path, nil)).Data.Version
+
+	require.NoError(t, env.DB.Model(&models.MarketData{}).
+		Where("milestone_id = ? AND option_id = ?", milestone.ID, models.OptionSuccess).
+		UpdateColumn("current_price", 0.7).Error)
+	services.BumpMarketSequence(milestone.ID)
+
+	response := decodeMarket(t, getMarket(t, router, path+"?since="+base, nil))
+	assert.True(t, response.Data.Delta)
+	assert.Equal(t, base, response.Data.BaseVersion)
+	assert.NotEqual(t, base, response.Data.Version)
+	assert.Nil(t, response.Data.TotalVolume, "거래량은 바뀌지 않음")
+
+	var changes map[string]map[string]interface{}
+	require.NoError(t, json.Unmarshal(response.Data.MarketData, &changes))
+	assert.Equal(t, map[string]map[string]interface{}{
+		models.OptionSuccess: {"current_price": 0.7},
+	}, changes)
+
+	// 최신 버전 기준이면 빈 델타
+	current := decodeMarket(t, getMarket(t, router, path+"?since="+response.Data.Version, nil))
+	assert.True(t, current.Data.Delta)
+	assert.Empty(t, current.Data.MarketData)
+}
+
+// TestMarketViewDeltaFallsBackWhenBaseExpired 기준 스냅샷이 만료되었거나 모르는 버전이면 전체 응답
+func TestMarketViewDeltaFallsBackWhenBaseExpired(t *testing.T) {
+	router, env, milestone := newMarketRouter(t)
+	path := fmt.Sprintf("/milestones/%d/market", milestone.ID)
+	base := decodeMarket(t, getMarket(t, router, path, nil)).Data.Version
+
+	require.NoError(t, env.DB.Model(&models.MarketData{}).
+		Where("milestone_id = ? AND option_id = ?", milestone.ID, models.OptionFail).
+		UpdateColumns(models.MarketData{Volume24h: 80}).Error)
+	services.BumpMarketSequence(milestone.ID)
+	env.Redis.FastForward(3 * time.Minute)
+
+	for _, since := range []string{base, "unknown-version"} {
+		response := decodeMarket(t, getMarket(t, router, path+"?since="+since, nil))
+		assert.False(t, response.Data.Delta, since)
+		assert.Empty(t, response.Data.BaseVersion)
+		require.NotNil(t, response.Data.TotalVolume)
+		assert.Equal(t, int64(180), *response.Data.TotalVolume)
+
+		var full []models.MarketData
+		require.NoError(t, json.Unmarshal(response.Data.MarketData, &full))
+		assert.Len(t, full, 2)
+	}
+}
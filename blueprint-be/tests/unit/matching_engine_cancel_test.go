@@ -0,0 +1,137 @@
+package unit_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// actorBlocker 지정한 사용자의 주문 수수료를 계산하는 동안 시장 액터를 멈춤 (기능 플래그 확인은 액터 안에서 일어남)
+type actorBlocker struct {
+	userID  uint
+	once    sync.Once
+	entered chan struct{}
+	release chan struct{}
+}
+
+func newActorBlocker(userID uint) *actorBlocker {
+	return &actorBlocker{userID: userID, entered: make(chan struct{}), release: make(chan struct{})}
+}
+
+func (b *actorBlocker) IsEnabled(models.FeatureFlagKey) bool { return false }
+
+func (b *actorBlocker) IsEnabledFor(_ models.FeatureFlagKey, userID uint) bool {
+	if userID == b.userID {
+		b.once.Do(func() {
+			close(b.entered)
+			<-b.release
+		})
+	}
+	return false
+}
+
+// startBlockedEngine 매도 호가 1개가 있는 엔진을 띄우고 차단용 주문으로 액터를 멈춤
+func startBlockedEngine(t *testing.T, db *gorm.DB, blocker *actorBlocker) (*services.LocalMatchingEngine, *models.Order) {
+	t.Helper()
+	resting := &models.Order{
+		UserID: 1, MilestoneID: 7, OptionID: "success", Type: models.OrderTypeLimit, Side: models.OrderSideSell,
+		Price: 0.5, Quantity: 10, Remaining: 10, Status: models.OrderStatusPending,
+	}
+	require.NoError(t, db.Create(resting).Error)
+
+	engine := services.NewLocalMatchingEngine(db, nil, nil, nil)
+	engine.SetFeatureFlags(blocker)
+	require.NoError(t, engine.Start())
+	t.Cleanup(func() {
+		engine.Stop()
+		testkit.Settle(t, engine)
+	})
+
+	go engine.SubmitOrder(&models.Order{
+		ID: 900, UserID: blocker.userID, MilestoneID: 7, OptionID: "success", Type: models.OrderTypeLimit,
+		Side: models.OrderSideBuy, Price: 0.1, Quantity: 1, Status: models.OrderStatusPending,
+	})
+	select {
+	case <-blocker.entered:
+	case <-time.After(time.Second):
+		t.Fatal("market actor did not pick up the blocking order")
+	}
+	return engine, resting
+}
+
+// TestCancelJumpsAheadOfQueuedOrders 액터가 바쁜 동안 들어온 취소는 먼저 대기 중이던 주문보다 먼저 처리되고 SLA 초과로 집계됨
+func TestCancelJumpsAheadOfQueuedOrders(t *testing.T) {
+	db := testkit.NewDB(t)
+	blocker := newActorBlocker(99)
+	engine, resting := startBlockedEngine(t, db, blocker)
+
+	// 호가를 가져갈 매수 주문이 취소보다 먼저 대기열에 들어감
+	matched := make(chan *services.MatchingResult, 1)
+	go func() {
+		result, _ := engine.SubmitOrder(&models.Order{
+			ID: 901, UserID: 2, MilestoneID: 7, OptionID: "success", Type: models.OrderTypeLimit,
+			Side: models.OrderSideBuy, Price: 0.6, Quantity: 10, Status: models.OrderStatusPending,
+		})
+		matched <- result
+	}()
+	require.Eventually(t, func() bool { return engine.GetStats().PendingOrders == 1 }, time.Second, 5*time.Millisecond)
+
+	cancelled := make(chan error, 1)
+	go func() { cancelled <- engine.CancelOrder(resting) }()
+	require.Eventually(t, func() bool { return engine.GetStats().PendingCancels == 1 }, time.Second, 5*time.Millisecond)
+
+	time.Sleep(2 * services.CancelSLA)
+	close(blocker.release)
+
+	require.NoError(t, <-cancelled)
+	result := <-matched
+	require.NotNil(t, result)
+	assert.False(t, result.Executed, "취소된 호가와 체결되면 안 됨")
+	assert.Empty(t, engine.GetOrderBook(7, "success", 10, 0).Asks)
+
+	// 다른 시장의 빠른 취소와 합산
+	other := &models.Order{
+		UserID: 3, MilestoneID: 8, OptionID: "success", Type: models.OrderTypeLimit, Side: models.OrderSideBuy,
+		Price: 0.3, Quantity: 5, Status: models.OrderStatusPending,
+	}
+	require.NoError(t, db.Create(other).Error)
+	_, err := engine.SubmitOrder(other)
+	require.NoError(t, err)
+	require.NoError(t, engine.CancelOrder(other))
+
+	stats := engine.GetStats()
+	assert.Equal(t, 2, stats.ActiveOrderBooks)
+	assert.Equal(t, int64(2), stats.CancelsProcessed)
+	assert.Equal(t, int64(1), stats.CancelSLABreaches)
+	assert.GreaterOrEqual(t, stats.MaxCancelLatency, float64(2*services.CancelSLA/time.Millisecond))
+	assert.Less(t, stats.AvgCancelLatency, stats.MaxCancelLatency)
+	assert.Zero(t, stats.PendingCancels)
+	assert.Zero(t, stats.PendingOrders)
+}
+
+// TestCancelTimeoutLeavesOrderInBook 시간 안에 처리되지 않은 취소는 오류를 반환하고, 버려진 요청은 나중에도 적용되지 않음
+func TestCancelTimeoutLeavesOrderInBook(t *testing.T) {
+	db := testkit.NewDB(t)
+	blocker := newActorBlocker(99)
+	engine, resting := startBlockedEngine(t, db, blocker)
+
+	err := engine.CancelOrder(resting)
+	assert.ErrorIs(t, err, services.ErrCancelNotConfirmed)
+
+	close(blocker.release)
+	require.Eventually(t, func() bool { return engine.GetStats().PendingCancels == 0 }, time.Second, 5*time.Millisecond)
+	assert.Len(t, engine.GetOrderBook(7, "success", 10, 0).Asks, 1)
+	assert.Zero(t, engine.GetStats().CancelsProcessed)
+
+	// 다시 요청하면 제거됨
+	require.NoError(t, engine.CancelOrder(resting))
+	assert.Empty(t, engine.GetOrderBook(7, "success", 10, 0).Asks)
+}
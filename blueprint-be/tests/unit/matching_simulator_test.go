@@ -0,0 +1,45 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMatchingSimulatorSyntheticFlow 합성 흐름을 단일 노드 엔진에 재생하면 수량/잔액 불변식이 모두 지켜짐
+func TestMatchingSimulatorSyntheticFlow(t *testing.T) {
+	env := testkit.New(t)
+
+	config := services.DefaultSyntheticFlowConfig
+	config.Orders = 300
+	config.Users = 5
+	flow := services.SyntheticOrderFlow(1, models.OptionSuccess, config)
+	assert.Equal(t, flow.Actions, services.SyntheticOrderFlow(1, models.OptionSuccess, config).Actions, "같은 시드는 같은 흐름")
+
+	simulator := services.NewMatchingSimulator(services.NewLocalMatchingEngine(env.DB, nil, nil, nil),
+		services.SimulationConfig{InitialBalance: 20000})
+	report, err := simulator.Run(flow)
+	require.NoError(t, err)
+
+	assert.Zero(t, report.TotalViolations, "%+v", report.ViolationSamples)
+	assert.Equal(t, 300, report.OrdersSubmitted)
+	assert.Equal(t, report.OrdersSubmitted, report.OrdersAccepted+report.OrdersRejected)
+	assert.Positive(t, report.Trades)
+	assert.Positive(t, report.FullyFilledOrders)
+	assert.Greater(t, report.FillRate, 0.0)
+	assert.LessOrEqual(t, report.FillRate, 1.0)
+	assert.Equal(t, 2*report.Volume, report.FilledQuantity)
+	assert.GreaterOrEqual(t, report.SubmitLatency.P99, report.SubmitLatency.P50)
+	assert.Len(t, report.CSVRecord(), len(services.SimulationCSVHeader()))
+
+	// Run은 체결 후처리까지 기다린 뒤 반환
+	assert.False(t, report.SettlementTimedOut)
+	var persisted int64
+	require.NoError(t, env.DB.Model(&models.Trade{}).Count(&persisted).Error)
+	assert.Equal(t, int64(report.Trades), persisted)
+}
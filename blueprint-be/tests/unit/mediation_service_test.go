@@ -0,0 +1,122 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMediation 증거 분쟁은 제안/역제안 합의로 종결(배상 이체, 스테이킹 반환, 판정 전환)되고, 기한이 지난 멘토 신고는 배심원 중재로 이관
+func TestMediation(t *testing.T) {
+	env := testkit.New(t)
+	arbitration := services.NewArbitrationService(env.DB)
+	mentorStaking := services.NewMentorStakingService(env.DB)
+	mediation := services.NewMediationService(env.DB, arbitration, mentorStaking)
+	verification := services.NewVerificationService(env.DB, nil)
+	verification.SetMediation(mediation)
+
+	// 증거 승인 후 검증인이 분쟁 제기 → 조정 개시
+	milestone := env.Factory.Market(func(m *models.Milestone) { m.Status = models.MilestoneStatusProofApproved })
+	var project models.Project
+	require.NoError(t, env.DB.First(&project, milestone.ProjectID).Error)
+	owner, disputer, outsider := project.UserID, env.Factory.User(), env.Factory.User()
+	env.Factory.Wallet(owner, 0, func(w *models.UserWallet) { w.BlueprintBalance = 2000 })
+	env.Factory.Wallet(disputer.ID, 0, func(w *models.UserWallet) { w.BlueprintBalance = 1000 })
+	require.NoError(t, env.DB.Create(&models.ValidatorQualification{UserID: disputer.ID, StakedAmount: 1000}).Error)
+	proof := &models.MilestoneProof{MilestoneID: milestone.ID, UserID: owner, Title: "demo", Status: models.ProofStatusApproved}
+	require.NoError(t, env.DB.Create(proof).Error)
+
+	dispute, err := verification.DisputeProof(&models.DisputeProofRequest{
+		ProofID: proof.ID, DisputeType: "insufficient_proof", Title: "데모가 동작하지 않음", Description: "...", StakeAmount: 1000,
+	}, disputer.ID)
+	require.NoError(t, err)
+
+	mediations, err := mediation.ListMediations(owner, models.MediationStatusOpen)
+	require.NoError(t, err)
+	require.Len(t, mediations, 1)
+	opened := mediations[0]
+	assert.Equal(t, dispute.ID, opened.SourceID)
+	assert.Equal(t, disputer.ID, opened.ClaimantID)
+	assert.Equal(t, int64(1000), opened.ClaimantStake)
+
+	_, err = mediation.GetMediation(opened.ID, outsider.ID)
+	assert.ErrorIs(t, err, services.ErrMediationForbidden)
+	_, err = mediation.MakeOffer(opened.ID, disputer.ID, &models.MediationOfferRequest{Outcome: models.MediationOutcomeDismissReport})
+	assert.ErrorIs(t, err, services.ErrMediationOutcome)
+
+	// 제안 → 역제안 → 수락
+	ask, err := mediation.MakeOffer(opened.ID, disputer.ID, &models.MediationOfferRequest{Outcome: models.MediationOutcomeRejectProof, Compensation: 300})
+	require.NoError(t, err)
+	_, err = mediation.AcceptOffer(opened.ID, ask.ID, disputer.ID)
+	assert.ErrorIs(t, err, services.ErrMediationOwnOffer)
+	counter, err := mediation.MakeOffer(opened.ID, owner, &models.MediationOfferRequest{Outcome: models.MediationOutcomeRejectProof, Compensation: 100, Terms: "다음 증거 재제출"})
+	require.NoError(t, err)
+	require.NotNil(t, counter.CounterToID)
+	assert.Equal(t, ask.ID, *counter.CounterToID)
+	_, err = mediation.AcceptOffer(opened.ID, ask.ID, owner)
+	assert.ErrorIs(t, err, services.ErrMediationOfferNotPending, "역제안된 제안은 수락할 수 없음")
+
+	settled, err := mediation.AcceptOffer(opened.ID, counter.ID, disputer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.MediationStatusSettled, settled.Status)
+
+	var disputerWallet, ownerWallet models.UserWallet
+	require.NoError(t, env.DB.First(&disputerWallet, "user_id = ?", disputer.ID).Error)
+	require.NoError(t, env.DB.First(&ownerWallet, "user_id = ?", owner).Error)
+	assert.Equal(t, int64(1100), disputerWallet.BlueprintBalance, "스테이킹 반환 + 합의금")
+	assert.Zero(t, disputerWallet.BlueprintLockedBalance)
+	assert.Equal(t, int64(1900), ownerWallet.BlueprintBalance)
+
+	var resolved models.ProofDispute
+	require.NoError(t, env.DB.First(&resolved, dispute.ID).Error)
+	assert.Equal(t, "resolved", resolved.Status)
+	assert.True(t, resolved.StakeReturned)
+	var updated models.Milestone
+	require.NoError(t, env.DB.First(&updated, milestone.ID).Error)
+	assert.Equal(t, models.MilestoneStatusProofRejected, updated.Status)
+
+	// 멘토 신고: 조정 중에는 검토 보류, 기한이 지나면 배심원 수를 줄인 중재 사건으로 이관
+	mentorUser, mentee := env.Factory.User(), env.Factory.User()
+	mentor := env.Factory.Mentor(mentorUser.ID)
+	slashEvent := &models.MentorSlashEvent{
+		MentorID:   mentor.ID,
+		ReporterID: &mentee.ID,
+		SlashType:  models.SlashTypeNoShow,
+		Severity:   models.SlashSeverityMinor,
+		Reason:     "세션 불참",
+		Status:     models.SlashEventStatusPending,
+	}
+	require.NoError(t, env.DB.Create(slashEvent).Error)
+	report, err := mediation.OpenForMentorReport(slashEvent, mentorUser.ID)
+	require.NoError(t, err)
+
+	started, err := mentorStaking.StartPendingSlashReviews(time.Now().Add(2 * time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, started)
+
+	escalated, err := mediation.ProcessDeadlines(time.Now().Add(6 * 24 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, escalated)
+
+	detail, err := mediation.GetMediation(report.ID, mentee.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.MediationStatusEscalated, detail.Status)
+	require.NotNil(t, detail.ArbitrationCaseID)
+
+	var arbitrationCase models.ArbitrationCase
+	require.NoError(t, env.DB.First(&arbitrationCase, *detail.ArbitrationCaseID).Error)
+	assert.Equal(t, models.DisputeTypeMentorMalpractice, arbitrationCase.DisputeType)
+	assert.Equal(t, mentee.ID, arbitrationCase.PlaintiffID)
+	assert.Equal(t, mentorUser.ID, arbitrationCase.DefendantID)
+	assert.Equal(t, 3, arbitrationCase.RequiredJurors)
+
+	var held models.MentorSlashEvent
+	require.NoError(t, env.DB.First(&held, slashEvent.ID).Error)
+	assert.Equal(t, models.SlashEventStatusReviewing, held.Status)
+}
@@ -0,0 +1,74 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestMenteeFeedbackUpdatesRatingAndOpensNoShowSlash(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&models.Mentor{}, &models.MentorMilestone{}, &models.MentoringSession{}, &models.MentorSessionFeedback{},
+		&models.MentorStake{}, &models.MentorSlashEvent{},
+	))
+
+	db.Create(&models.Mentor{ID: 1, UserID: 10})
+	db.Create(&models.MentorMilestone{MentorID: 1, MilestoneID: 5, ProjectID: 1, TotalBetAmount: 1000, IsActive: true})
+	db.Create(&models.MentorStake{MentorID: 1, UserID: 10, Amount: 10000, AvailableAmount: 10000, Status: models.MentorStakeStatusActive})
+	for i := uint(1); i <= 4; i++ {
+		db.Create(&models.MentoringSession{ID: i, MentorID: 1, MenteeID: 100 + i, MilestoneID: 5, ProjectID: 1, Title: "세션", StartedAt: time.Now()})
+	}
+
+	feedbackService := services.NewMentorFeedbackService(db, services.NewMentorStakingService(db))
+
+	_, err = feedbackService.RateSession(1, 999, &models.RateSessionRequest{Rating: 5})
+	assert.ErrorIs(t, err, services.ErrFeedbackForbidden)
+
+	_, err = feedbackService.RateSession(1, 101, &models.RateSessionRequest{Rating: 5, Comment: "훌륭한 조언"})
+	require.NoError(t, err)
+	_, err = feedbackService.RateSession(1, 101, &models.RateSessionRequest{Rating: 4})
+	assert.ErrorIs(t, err, services.ErrFeedbackExists)
+
+	var mentor models.Mentor
+	db.First(&mentor, 1)
+	assert.Equal(t, 5.0, mentor.AverageRating)
+
+	// 1점 불참 신고 2건까지는 슬래싱 없음, 3건째에 자동 접수
+	for sessionID := uint(2); sessionID <= 4; sessionID++ {
+		_, err = feedbackService.RateSession(sessionID, 100+sessionID, &models.RateSessionRequest{Rating: 1, NoShow: true})
+		require.NoError(t, err)
+
+		var count int64
+		db.Model(&models.MentorSlashEvent{}).Where("mentor_id = ? AND slash_type = ?", 1, models.SlashTypeNoShow).Count(&count)
+		if sessionID < 4 {
+			assert.Zero(t, count)
+		} else {
+			assert.Equal(t, int64(1), count)
+		}
+	}
+
+	var slashEvent models.MentorSlashEvent
+	require.NoError(t, db.Where("mentor_id = ?", 1).First(&slashEvent).Error)
+	assert.Nil(t, slashEvent.ReporterID)
+	assert.Equal(t, models.SlashEventStatusPending, slashEvent.Status)
+	assert.Greater(t, slashEvent.SlashedAmount, int64(0))
+
+	var mentorMilestone models.MentorMilestone
+	db.Where("mentor_id = ? AND milestone_id = ?", 1, 5).First(&mentorMilestone)
+	assert.Equal(t, 2.0, mentorMilestone.MenteeRating)
+
+	summary, err := feedbackService.GetMentorFeedback(1, 20, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), summary.TotalCount)
+	assert.Equal(t, int64(3), summary.NoShowCount)
+	assert.Equal(t, int64(3), summary.Distribution[1])
+}
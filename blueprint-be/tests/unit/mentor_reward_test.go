@@ -0,0 +1,61 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestMentorPoolDistributionAndClaim(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&models.User{}, &models.UserWallet{}, &models.Mentor{}, &models.MentorMilestone{}, &models.MentorPool{},
+		&models.MentorStake{}, &models.MentorStakeReward{}, &models.MentorReputation{}, &models.WalletLedgerEntry{},
+	))
+
+	// 멘토 1, 2는 스테이킹 보유, 멘토 3은 스테이킹 없음 (보상 제외)
+	for i, userID := range []uint{11, 12, 13} {
+		db.Create(&models.User{ID: userID, Email: "mentor" + string(rune('a'+i)) + "@example.com", Username: "mentor" + string(rune('a'+i))})
+		db.Create(&models.UserWallet{UserID: userID})
+		db.Create(&models.Mentor{ID: uint(i + 1), UserID: userID})
+		db.Create(&models.MentorMilestone{MentorID: uint(i + 1), MilestoneID: 7, ProjectID: 1, TotalBetAmount: 1000, IsActive: true, ActionsCount: 1, MenteeRating: 5})
+	}
+	db.Create(&models.MentorStake{MentorID: 1, UserID: 11, Amount: 5000, Status: models.MentorStakeStatusActive})
+	db.Create(&models.MentorStake{MentorID: 2, UserID: 12, Amount: 5000, Status: models.MentorStakeStatusActive})
+	db.Create(&models.MentorPool{MilestoneID: 7, ProjectID: 1, TotalPoolAmount: 1001, AccumulatedFees: 1001, FeePercentage: 50, PerformanceWeighted: true, MentorRatingWeight: 30, BettingAmountWeight: 70})
+
+	rewardService := services.NewMentorRewardService(db, nil)
+	result, err := rewardService.DistributeMentorPoolRewards(7)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.EligibleMentorCount)
+	assert.Equal(t, int64(1001), result.DistributedAmount)
+
+	_, err = rewardService.DistributeMentorPoolRewards(7)
+	assert.Error(t, err, "중복 분배 불가")
+
+	var pending []models.MentorStakeReward
+	db.Where("status = ?", models.MentorStakeRewardPending).Find(&pending)
+	require.Len(t, pending, 2)
+
+	stakingService := services.NewMentorStakingService(db)
+	claim, err := stakingService.ClaimRewards(11)
+	require.NoError(t, err)
+	assert.Equal(t, 1, claim.ClaimedCount)
+
+	var wallet models.UserWallet
+	db.Where("user_id = ?", 11).First(&wallet)
+	assert.Equal(t, claim.TotalAmount, wallet.USDCBalance)
+
+	_, err = stakingService.ClaimRewards(11)
+	assert.Error(t, err, "이미 청구한 보상은 다시 청구할 수 없음")
+
+	_, err = stakingService.ClaimRewards(13)
+	assert.Error(t, err)
+}
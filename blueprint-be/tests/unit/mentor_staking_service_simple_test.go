@@ -6,10 +6,10 @@ import (
 
 	"blueprint-module/pkg/models"
 	"blueprint/internal/services"
+	"blueprint/internal/testkit"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
@@ -19,63 +19,16 @@ type MentorStakingSimpleTestSuite struct {
 	mentorStakingService *services.MentorStakingService
 }
 
-func (suite *MentorStakingSimpleTestSuite) SetupSuite() {
-	// 테스트용 PostgreSQL 데이터베이스 설정
-	db, err := gorm.Open(postgres.Open("host=localhost user=test password=test dbname=test_blueprint port=5432 sslmode=disable"), &gorm.Config{})
-	if err != nil {
-		// 포스트그레스 연결 실패시 테스트 스킵
-		suite.T().Skip("PostgreSQL 테스트 데이터베이스에 연결할 수 없습니다")
-		return
-	}
-	
-	suite.db = db
+func (suite *MentorStakingSimpleTestSuite) SetupTest() {
+	// 테스트마다 새 in-memory DB (Postgres 없이 실행)
+	suite.db = testkit.NewDB(suite.T())
 	suite.mentorStakingService = services.NewMentorStakingService(suite.db)
-	
-	// 테스트에 필요한 테이블 생성
-	suite.db.AutoMigrate(
-		&models.User{},
-		&models.UserWallet{},
-		&models.Mentor{},
-		&models.MentorStake{},
-		&models.MentorSlashEvent{},
-		&models.MentorPerformanceMetric{},
-		&models.MentorStakeReward{},
-	)
-}
-
-func (suite *MentorStakingSimpleTestSuite) TearDownSuite() {
-	if suite.db != nil {
-		// 테스트 테이블 정리
-		suite.db.Exec("DROP TABLE IF EXISTS mentor_stake_rewards CASCADE")
-		suite.db.Exec("DROP TABLE IF EXISTS mentor_performance_metrics CASCADE")
-		suite.db.Exec("DROP TABLE IF EXISTS mentor_slash_events CASCADE")
-		suite.db.Exec("DROP TABLE IF EXISTS mentor_stakes CASCADE")
-		suite.db.Exec("DROP TABLE IF EXISTS mentors CASCADE")
-		suite.db.Exec("DROP TABLE IF EXISTS user_wallets CASCADE")
-		suite.db.Exec("DROP TABLE IF EXISTS users CASCADE")
-	}
-}
 
-func (suite *MentorStakingSimpleTestSuite) SetupTest() {
-	if suite.db == nil {
-		return
-	}
-	
-	// 각 테스트 전에 테이블 데이터 초기화
-	suite.db.Exec("TRUNCATE TABLE mentor_stake_rewards, mentor_performance_metrics, mentor_slash_events, mentor_stakes, mentors, user_wallets, users RESTART IDENTITY CASCADE")
-	
-	// 테스트 데이터 시드
-	user1 := &models.User{ID: 1, Email: "mentor@test.com"}
-	user2 := &models.User{ID: 2, Email: "staker@test.com"}
-	suite.db.Create(user1)
-	suite.db.Create(user2)
-	
-	// 멘토 생성
-	mentor := &models.Mentor{
-		ID:     1,
-		UserID: 1,
-	}
-	suite.db.Create(mentor)
+	// 테스트 데이터 시드 (멘토 사용자 ID 1, 스테이커 ID 2, 멘토 ID 1)
+	factory := testkit.NewFactory(suite.T(), suite.db)
+	mentorUser := factory.User(func(u *models.User) { u.Email = "mentor@test.com" })
+	factory.User(func(u *models.User) { u.Email = "staker@test.com" })
+	factory.Mentor(mentorUser.ID)
 }
 
 func (suite *MentorStakingSimpleTestSuite) TestStakeMentorSuccess() {
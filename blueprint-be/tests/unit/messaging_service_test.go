@@ -0,0 +1,94 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMessaging 진행 중인 멘토링 세션 참여자만 대화하고, 읽음 표시/신고/운영자 숨김/보관 기간 삭제가 동작
+func TestMessaging(t *testing.T) {
+	env := testkit.New(t)
+	service := services.NewMessagingService(env.DB)
+
+	mentorUser, mentee, outsider := env.Factory.User(), env.Factory.User(), env.Factory.User()
+	mentor := env.Factory.Mentor(mentorUser.ID)
+	milestone := env.Factory.Market()
+	session := &models.MentoringSession{
+		MentorID:    mentor.ID,
+		MenteeID:    mentee.ID,
+		MilestoneID: milestone.ID,
+		ProjectID:   milestone.ProjectID,
+		Title:       "weekly sync",
+		Status:      models.SessionStatusActive,
+	}
+	require.NoError(t, env.DB.Create(session).Error)
+
+	_, err := service.OpenConversation(models.ConversationMentorship, session.ID, outsider.ID)
+	assert.ErrorIs(t, err, services.ErrConversationForbidden)
+
+	conversation, err := service.OpenConversation(models.ConversationMentorship, session.ID, mentee.ID)
+	require.NoError(t, err)
+	again, err := service.OpenConversation(models.ConversationMentorship, session.ID, mentorUser.ID)
+	require.NoError(t, err)
+	assert.Equal(t, conversation.ID, again.ID, "범위당 대화방 하나")
+
+	_, err = service.SendMessage(conversation.ID, outsider.ID, "hi")
+	assert.ErrorIs(t, err, services.ErrConversationForbidden)
+	message, err := service.SendMessage(conversation.ID, mentorUser.ID, "  이번 주 목표를 정리해볼까요?  ")
+	require.NoError(t, err)
+	assert.Equal(t, "이번 주 목표를 정리해볼까요?", message.Body)
+
+	summaries, err := service.ListConversations(mentee.ID)
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, int64(1), summaries[0].UnreadCount)
+	assert.True(t, summaries[0].Writable)
+
+	participant, err := service.MarkRead(conversation.ID, mentee.ID, 0)
+	require.NoError(t, err)
+	assert.Equal(t, message.ID, participant.LastReadMessageID)
+	summaries, err = service.ListConversations(mentee.ID)
+	require.NoError(t, err)
+	assert.Zero(t, summaries[0].UnreadCount)
+
+	var updated models.MentoringSession
+	require.NoError(t, env.DB.First(&updated, session.ID).Error)
+	assert.Equal(t, 1, updated.MessagesCount)
+
+	// 신고 → 운영자 숨김
+	_, err = service.ReportMessage(message.ID, mentorUser.ID, "mine")
+	assert.ErrorIs(t, err, services.ErrMessageReportOwn)
+	report, err := service.ReportMessage(message.ID, mentee.ID, "spam")
+	require.NoError(t, err)
+	_, err = service.ReportMessage(message.ID, mentee.ID, "spam")
+	assert.ErrorIs(t, err, services.ErrMessageAlreadyReported)
+
+	reviewed, err := service.RemoveMessage(report.ID, outsider.ID, "")
+	require.NoError(t, err)
+	assert.Equal(t, models.MessageReportRemoved, reviewed.Status)
+	_, err = service.DismissReport(report.ID, outsider.ID, "")
+	assert.ErrorIs(t, err, services.ErrMessageReportNotReviewed)
+
+	messages, err := service.ListMessages(conversation.ID, mentee.ID, 0, 50)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.True(t, messages[0].Hidden)
+	assert.Empty(t, messages[0].Body)
+
+	// 세션이 끝나면 읽기만 가능
+	require.NoError(t, env.DB.Model(session).Update("status", models.SessionStatusCompleted).Error)
+	_, err = service.SendMessage(conversation.ID, mentee.ID, "thanks")
+	assert.ErrorIs(t, err, services.ErrConversationClosed)
+
+	// 보관 기간이 지난 메시지 삭제
+	deleted, err := service.CleanupMessages(time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+}
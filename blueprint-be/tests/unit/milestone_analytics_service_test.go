@@ -0,0 +1,64 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMilestoneAnalytics 옵션별 보유 집중도, 일별 거래량, 호가 잔량 스냅샷
+func TestMilestoneAnalytics(t *testing.T) {
+	env := testkit.New(t)
+	service := services.NewMilestoneAnalyticsService(env.DB)
+	market := env.Factory.Market()
+
+	whale, small, shorter := env.Factory.User(), env.Factory.User(), env.Factory.User()
+	env.Factory.Position(whale.ID, market, models.OptionSuccess, 90, 0.5)
+	env.Factory.Position(small.ID, market, models.OptionSuccess, 10, 0.5)
+	env.Factory.Position(shorter.ID, market, models.OptionSuccess, -20, 0.5)
+
+	now := time.Now()
+	require.NoError(t, env.DB.Create(&[]models.Trade{
+		{MilestoneID: market.ID, OptionID: models.OptionSuccess, BuyerID: whale.ID, SellerID: small.ID, Quantity: 10, TotalAmount: 500, CreatedAt: now},
+		{MilestoneID: market.ID, OptionID: models.OptionSuccess, BuyerID: small.ID, SellerID: whale.ID, Quantity: 4, TotalAmount: 200, CreatedAt: now.AddDate(0, 0, -2)},
+	}).Error)
+
+	env.Factory.Order(whale.ID, market, models.OrderSideBuy, 0.4, 30)
+	env.Factory.Order(small.ID, market, models.OrderSideBuy, 0.45, 20)
+	env.Factory.Order(shorter.ID, market, models.OrderSideSell, 0.6, 15)
+
+	snapshots, err := service.SnapshotDepth(now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, snapshots)
+
+	analytics, err := service.GetAnalytics(market.ID, 7)
+	require.NoError(t, err)
+
+	require.Len(t, analytics.Holders, 1)
+	holders := analytics.Holders[0]
+	assert.Equal(t, 2, holders.Holders, "매도 포지션은 보유자에서 제외")
+	assert.Equal(t, int64(100), holders.TotalShares)
+	assert.Equal(t, 1.0, holders.Top10Share)
+	assert.InDelta(t, 0.4, holders.Gini, 0.0001)
+
+	require.Len(t, analytics.DailyVolume, 7)
+	assert.Equal(t, int64(500), analytics.DailyVolume[6].Volume)
+	assert.Equal(t, int64(200), analytics.DailyVolume[4].Volume)
+
+	require.Len(t, analytics.DepthHistory, 1)
+	depth := analytics.DepthHistory[0]
+	assert.Equal(t, int64(50), depth.BidQuantity)
+	assert.Equal(t, 2, depth.BidLevels)
+	assert.Equal(t, 0.45, depth.BestBid)
+	assert.Equal(t, int64(15), depth.AskQuantity)
+	assert.Equal(t, 0.6, depth.BestAsk)
+
+	_, err = service.GetAnalytics(market.ID, 0)
+	assert.ErrorIs(t, err, services.ErrInvalidAnalyticsDays)
+}
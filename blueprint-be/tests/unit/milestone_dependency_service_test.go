@@ -0,0 +1,83 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestMilestoneDependencies 순환/다른 프로젝트 연결 거부, 선행 미완료 시 펀딩 차단, 선행 지연 시 후행 목표일 자동 조정
+func TestMilestoneDependencies(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&models.User{}, &models.Project{}, &models.ProjectMember{}, &models.Milestone{},
+		&models.MilestoneDependency{}, &models.MilestoneStatusHistory{}, &models.Notification{}, &models.UserProfile{},
+	))
+	service := services.NewMilestoneDependencyService(db)
+
+	owner := models.User{Email: "owner@test.com", Username: "owner", IsActive: true}
+	require.NoError(t, db.Create(&owner).Error)
+	project := models.Project{UserID: owner.ID, Title: "roadmap", Category: models.BusinessProject, Status: models.ProjectActive}
+	other := models.Project{UserID: owner.ID, Title: "other", Category: models.BusinessProject, Status: models.ProjectActive}
+	require.NoError(t, db.Create(&project).Error)
+	require.NoError(t, db.Create(&other).Error)
+
+	day := func(offset int) *time.Time {
+		date := time.Now().Truncate(24*time.Hour).AddDate(0, 0, offset)
+		return &date
+	}
+	m1 := models.Milestone{ProjectID: project.ID, Title: "prototype", Order: 1, Status: models.MilestoneStatusCompleted, TargetDate: day(10)}
+	m2 := models.Milestone{ProjectID: project.ID, Title: "beta", Order: 2, Status: models.MilestoneStatusProposal, TargetDate: day(20)}
+	m3 := models.Milestone{ProjectID: project.ID, Title: "launch", Order: 3, Status: models.MilestoneStatusProposal, TargetDate: day(30)}
+	foreign := models.Milestone{ProjectID: other.ID, Title: "foreign", Order: 1, Status: models.MilestoneStatusProposal}
+	for _, milestone := range []*models.Milestone{&m1, &m2, &m3, &foreign} {
+		require.NoError(t, db.Create(milestone).Error)
+	}
+
+	_, err = service.SetDependencies(m2.ID, owner.ID, []uint{m1.ID})
+	require.NoError(t, err)
+	deps, err := service.SetDependencies(m3.ID, owner.ID, []uint{m2.ID})
+	require.NoError(t, err)
+	assert.True(t, deps.Blocked)
+	assert.Equal(t, 10, deps.DependsOn[0].GapDays)
+
+	_, err = service.SetDependencies(m1.ID, owner.ID, []uint{m3.ID})
+	assert.ErrorIs(t, err, services.ErrMilestoneDependencyStarted, "완료된 마일스톤은 변경 불가")
+	_, err = service.SetDependencies(m2.ID, owner.ID, []uint{m1.ID, m3.ID})
+	assert.ErrorIs(t, err, services.ErrMilestoneDependencyCycle)
+	_, err = service.SetDependencies(m2.ID, owner.ID, []uint{foreign.ID})
+	assert.ErrorIs(t, err, services.ErrInvalidMilestoneDependency)
+
+	// 선행(m2)이 완료되지 않아 m3의 마켓을 열 수 없음
+	funding := services.NewFundingVerificationService(db, nil)
+	assert.ErrorIs(t, funding.StartFundingPhase(m3.ID), services.ErrMilestoneDependenciesUnmet)
+
+	// m2 목표일이 늦춰지면 m3 목표일을 간격(10일)을 유지하도록 미룸
+	require.NoError(t, db.Model(&m2).Update("target_date", day(25)).Error)
+	shifted, err := service.RescheduleProject(project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, shifted)
+	require.NoError(t, db.First(&m3, m3.ID).Error)
+	assert.True(t, m3.TargetDate.Equal(*day(35)), "got %v", m3.TargetDate)
+
+	// m2가 목표일을 넘기면 다음 날 끝나는 것으로 보고 조정 (같은 날 다시 조정하지 않음)
+	require.NoError(t, db.Model(&m2).Update("target_date", day(-1)).Error)
+	require.NoError(t, db.Model(&m3).Update("target_date", day(5)).Error)
+	shifted, err = service.RescheduleProject(project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, shifted)
+	require.NoError(t, db.First(&m3, m3.ID).Error)
+	assert.True(t, m3.TargetDate.Equal(*day(11)), "got %v", m3.TargetDate)
+
+	shifted, err = service.RescheduleProject(project.ID)
+	require.NoError(t, err)
+	assert.Zero(t, shifted)
+}
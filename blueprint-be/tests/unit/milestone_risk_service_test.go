@@ -0,0 +1,95 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestMilestoneRiskScoring 마켓 가격이 낮고 증거 없이 목표일을 넘긴 마일스톤이 더 위험하며, 재계산 시 캐시를 갱신
+func TestMilestoneRiskScoring(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&models.User{}, &models.Project{}, &models.Milestone{}, &models.MarketData{},
+		&models.MilestoneProof{}, &models.ActivityLog{}, &models.ProjectStatsCache{},
+	))
+	service := services.NewMilestoneRiskService(db)
+
+	owner := models.User{Email: "owner@test.com", Username: "owner", IsActive: true}
+	require.NoError(t, db.Create(&owner).Error)
+	project := models.Project{UserID: owner.ID, Title: "app", Category: models.BusinessProject, Status: models.ProjectActive}
+	require.NoError(t, db.Create(&project).Error)
+
+	overdue := time.Now().Add(-48 * time.Hour)
+	upcoming := time.Now().Add(60 * 24 * time.Hour)
+	risky := models.Milestone{ProjectID: project.ID, Title: "launch", Order: 1, Status: models.MilestoneStatusActive, TargetDate: &overdue}
+	safe := models.Milestone{ProjectID: project.ID, Title: "beta", Order: 2, Status: models.MilestoneStatusActive, TargetDate: &upcoming}
+	done := models.Milestone{ProjectID: project.ID, Title: "prototype", Order: 3, Status: models.MilestoneStatusCompleted}
+	for _, milestone := range []*models.Milestone{&risky, &safe, &done} {
+		require.NoError(t, db.Create(milestone).Error)
+	}
+	require.NoError(t, db.Create(&models.MarketData{MilestoneID: risky.ID, OptionID: models.OptionSuccess, CurrentPrice: 0.2}).Error)
+	require.NoError(t, db.Create(&models.MarketData{MilestoneID: safe.ID, OptionID: models.OptionSuccess, CurrentPrice: 0.85}).Error)
+
+	scored, err := service.ScoreAll()
+	require.NoError(t, err)
+	assert.Equal(t, 1, scored)
+
+	riskyScore, err := service.GetMilestoneRisk(risky.ID)
+	require.NoError(t, err)
+	safeScore, err := service.GetMilestoneRisk(safe.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.MilestoneRiskHigh, riskyScore.Level)
+	assert.Equal(t, models.MilestoneRiskLow, safeScore.Level)
+	assert.InDelta(t, 0.8, riskyScore.Factors.Market, 0.0001)
+	assert.Equal(t, 1.0, riskyScore.Factors.Schedule)
+	assert.Equal(t, services.MilestoneRiskModel, riskyScore.Model)
+
+	_, err = service.GetMilestoneRisk(done.ID)
+	assert.ErrorIs(t, err, services.ErrMilestoneRiskNotComputed, "결과가 확정된 마일스톤은 계산하지 않음")
+
+	// 가격이 회복되면 재계산 시 위험 점수가 내려감
+	require.NoError(t, db.Model(&models.MarketData{}).Where("milestone_id = ?", risky.ID).Update("current_price", 0.9).Error)
+	_, err = service.ScoreProject(project.ID)
+	require.NoError(t, err)
+	updated, err := service.GetMilestoneRisk(risky.ID)
+	require.NoError(t, err)
+	assert.Less(t, updated.Score, riskyScore.Score)
+
+	var caches int64
+	require.NoError(t, db.Model(&models.ProjectStatsCache{}).Count(&caches).Error)
+	assert.Equal(t, int64(1), caches)
+}
+
+// TestMilestoneRiskScoreChanged 증분 계산은 점수가 없거나 바뀐 것으로 표시된 프로젝트만 재계산
+func TestMilestoneRiskScoreChanged(t *testing.T) {
+	env := testkit.New(t)
+	service := services.NewMilestoneRiskService(env.DB)
+
+	first := env.Factory.Project(env.Factory.User().ID)
+	second := env.Factory.Project(env.Factory.User().ID)
+	firstMilestone := env.Factory.Milestone(first.ID)
+	env.Factory.Milestone(second.ID)
+
+	scored, err := service.ScoreChanged()
+	require.NoError(t, err)
+	assert.Equal(t, 2, scored, "아직 점수가 없는 프로젝트는 모두 계산")
+
+	scored, err = service.ScoreChanged()
+	require.NoError(t, err)
+	assert.Zero(t, scored, "바뀐 프로젝트가 없으면 계산하지 않음")
+
+	services.MarkMilestoneStatsDirty(env.DB, firstMilestone.ID)
+	scored, err = service.ScoreChanged()
+	require.NoError(t, err)
+	assert.Equal(t, 1, scored)
+}
@@ -0,0 +1,116 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// MilestoneStateMachineTestSuite 마일스톤 상태 전환 테스트 슈트
+type MilestoneStateMachineTestSuite struct {
+	suite.Suite
+	db           *gorm.DB
+	stateMachine *services.MilestoneStateMachine
+}
+
+func (suite *MilestoneStateMachineTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(
+		&models.User{},
+		&models.Project{},
+		&models.Milestone{},
+		&models.MilestoneStatusHistory{},
+	))
+	suite.db = db
+	suite.stateMachine = services.NewMilestoneStateMachine(db)
+}
+
+func (suite *MilestoneStateMachineTestSuite) createMilestone(status models.MilestoneStatus) *models.Milestone {
+	milestone := &models.Milestone{ProjectID: 1, Title: "MVP 출시", Order: 1, Status: status}
+	suite.Require().NoError(suite.db.Create(milestone).Error)
+	return milestone
+}
+
+func (suite *MilestoneStateMachineTestSuite) transition(milestone *models.Milestone, to models.MilestoneStatus) error {
+	return suite.db.Transaction(func(tx *gorm.DB) error {
+		_, err := suite.stateMachine.Transition(tx, milestone, to, services.TransitionOptions{Reason: "test"})
+		return err
+	})
+}
+
+// TestFullLifecycleRecordsHistory 정상 경로 전환 및 이력 기록
+func (suite *MilestoneStateMachineTestSuite) TestFullLifecycleRecordsHistory() {
+	milestone := suite.createMilestone(models.MilestoneStatusProposal)
+
+	path := []models.MilestoneStatus{
+		models.MilestoneStatusFunding,
+		models.MilestoneStatusActive,
+		models.MilestoneStatusProofSubmitted,
+		models.MilestoneStatusUnderVerification,
+		models.MilestoneStatusProofApproved,
+		models.MilestoneStatusCompleted,
+	}
+	for _, status := range path {
+		suite.Require().NoError(suite.transition(milestone, status))
+	}
+
+	var stored models.Milestone
+	suite.db.First(&stored, milestone.ID)
+	suite.Equal(models.MilestoneStatusCompleted, stored.Status)
+	suite.Nil(stored.ResolutionDueAt)
+
+	history, err := suite.stateMachine.GetHistory(milestone.ID)
+	suite.Require().NoError(err)
+	suite.Require().Len(history, len(path))
+	suite.Equal(models.MilestoneStatusProposal, history[0].FromStatus)
+	suite.Equal(models.MilestoneStatusCompleted, history[len(history)-1].ToStatus)
+}
+
+// TestInvalidTransitionRejected 허용되지 않은 전환은 거부되고 상태가 유지됨
+func (suite *MilestoneStateMachineTestSuite) TestInvalidTransitionRejected() {
+	milestone := suite.createMilestone(models.MilestoneStatusFunding)
+
+	err := suite.transition(milestone, models.MilestoneStatusCompleted)
+	suite.ErrorIs(err, services.ErrInvalidMilestoneTransition)
+
+	var stored models.Milestone
+	suite.db.First(&stored, milestone.ID)
+	suite.Equal(models.MilestoneStatusFunding, stored.Status)
+
+	var count int64
+	suite.db.Model(&models.MilestoneStatusHistory{}).Count(&count)
+	suite.Zero(count)
+}
+
+// TestStaleTransitionConflicts 조회 이후 다른 요청이 상태를 바꾼 경우 충돌
+func (suite *MilestoneStateMachineTestSuite) TestStaleTransitionConflicts() {
+	milestone := suite.createMilestone(models.MilestoneStatusActive)
+	stale := *milestone
+
+	suite.Require().NoError(suite.transition(milestone, models.MilestoneStatusFailed))
+	suite.ErrorIs(suite.transition(&stale, models.MilestoneStatusProofSubmitted), services.ErrMilestoneTransitionConflict)
+}
+
+// TestVerdictSchedulesResolution 판정 시 분쟁 기간 후 확정 예약, 분쟁 제기 시 해제
+func (suite *MilestoneStateMachineTestSuite) TestVerdictSchedulesResolution() {
+	milestone := suite.createMilestone(models.MilestoneStatusUnderVerification)
+
+	suite.Require().NoError(suite.transition(milestone, models.MilestoneStatusProofRejected))
+	suite.Require().NotNil(milestone.ResolutionDueAt)
+
+	suite.Require().NoError(suite.transition(milestone, models.MilestoneStatusDisputed))
+	var stored models.Milestone
+	suite.db.First(&stored, milestone.ID)
+	suite.Nil(stored.ResolutionDueAt)
+	suite.False(stored.Status.IsTradable())
+}
+
+func TestMilestoneStateMachineTestSuite(t *testing.T) {
+	suite.Run(t, new(MilestoneStateMachineTestSuite))
+}
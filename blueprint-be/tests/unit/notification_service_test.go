@@ -0,0 +1,133 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+
+	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
+	"blueprint/internal/services"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// NotificationTestSuite 알림 서비스 테스트 슈트
+type NotificationTestSuite struct {
+	suite.Suite
+	db                  *gorm.DB
+	redisServer         *miniredis.Miniredis
+	notificationService *services.NotificationService
+}
+
+func (suite *NotificationTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(
+		&models.User{},
+		&models.UserProfile{},
+		&models.UserVerification{},
+		&models.Notification{},
+		&models.PushSubscription{},
+	))
+	suite.db = db
+
+	// 워커 큐 확인용 Mock Redis
+	suite.redisServer = miniredis.RunT(suite.T())
+	moduleRedis.Client = redis.NewClient(&redis.Options{Addr: suite.redisServer.Addr()})
+
+	suite.notificationService = services.NewNotificationService(db)
+
+	suite.db.Create(&models.User{ID: 1, Email: "alice@test.com", Username: "alice"})
+	suite.db.Create(&models.UserProfile{UserID: 1, EmailNotifications: true})
+	suite.db.Create(&models.User{ID: 2, Email: "bob@test.com", Username: "bob"})
+	suite.db.Create(&models.UserProfile{UserID: 2})
+	suite.db.Model(&models.UserProfile{}).Where("user_id = ?", 2).Update("email_notifications", false)
+}
+
+func (suite *NotificationTestSuite) TearDownTest() {
+	moduleRedis.Client = nil
+}
+
+// TestNotifyRespectsEmailPreference 이메일 수신 설정에 따른 발송 여부
+func (suite *NotificationTestSuite) TestNotifyRespectsEmailPreference() {
+	req := models.CreateNotificationRequest{
+		Type:  models.NotificationTypeSystem,
+		Title: "테스트 알림",
+	}
+
+	req.UserID = 1
+	n1, err := suite.notificationService.Notify(req)
+	suite.Require().NoError(err)
+
+	req.UserID = 2
+	n2, err := suite.notificationService.Notify(req)
+	suite.Require().NoError(err)
+
+	var stored1, stored2 models.Notification
+	suite.db.First(&stored1, n1.ID)
+	suite.db.First(&stored2, n2.ID)
+	suite.True(stored1.EmailQueued)
+	suite.False(stored2.EmailQueued)
+	suite.False(stored1.SMSQueued) // 휴대폰 미인증
+
+	length, err := moduleRedis.Client.XLen(context.Background(), "email_queue").Result()
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), length)
+}
+
+// TestMarkAsRead 읽음 처리 및 본인 알림 검증
+func (suite *NotificationTestSuite) TestMarkAsRead() {
+	n, err := suite.notificationService.Notify(models.CreateNotificationRequest{
+		UserID: 1,
+		Type:   models.NotificationTypeSystem,
+		Title:  "읽음 테스트",
+	})
+	suite.Require().NoError(err)
+
+	count, err := suite.notificationService.GetUnreadCount(1)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), count)
+
+	// 다른 사용자는 읽음 처리 불가
+	suite.Error(suite.notificationService.MarkAsRead(2, n.ID))
+
+	suite.NoError(suite.notificationService.MarkAsRead(1, n.ID))
+	count, _ = suite.notificationService.GetUnreadCount(1)
+	suite.Equal(int64(0), count)
+}
+
+// TestSubscribeKeepsEndpointOwner 다른 계정이 등록한 endpoint로는 구독을 가져갈 수 없음
+func (suite *NotificationTestSuite) TestSubscribeKeepsEndpointOwner() {
+	req := &models.SubscribePushRequest{Endpoint: "https://fcm.googleapis.com/fcm/send/alice-device"}
+	req.Keys.P256dh, req.Keys.Auth = "alice-key", "alice-auth"
+	_, err := suite.notificationService.Subscribe(1, req, "Chrome")
+	suite.Require().NoError(err)
+
+	// 본인 재등록은 키 갱신
+	req.Keys.P256dh = "alice-key-2"
+	_, err = suite.notificationService.Subscribe(1, req, "Chrome")
+	suite.Require().NoError(err)
+
+	hijack := &models.SubscribePushRequest{Endpoint: req.Endpoint}
+	hijack.Keys.P256dh, hijack.Keys.Auth = "bob-key", "bob-auth"
+	_, err = suite.notificationService.Subscribe(2, hijack, "curl")
+	suite.ErrorIs(err, services.ErrPushEndpointInUse)
+
+	var subscription models.PushSubscription
+	suite.Require().NoError(suite.db.Where("endpoint = ?", req.Endpoint).First(&subscription).Error)
+	suite.Equal(uint(1), subscription.UserID)
+	suite.Equal("alice-key-2", subscription.P256dh)
+
+	insecure := &models.SubscribePushRequest{Endpoint: "http://169.254.169.254/latest"}
+	insecure.Keys.P256dh, insecure.Keys.Auth = "k", "a"
+	_, err = suite.notificationService.Subscribe(2, insecure, "curl")
+	suite.ErrorIs(err, services.ErrPushEndpointInvalid)
+}
+
+func TestNotificationSuite(t *testing.T) {
+	suite.Run(t, new(NotificationTestSuite))
+}
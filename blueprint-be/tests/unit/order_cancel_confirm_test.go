@@ -0,0 +1,156 @@
+package unit_test
+
+import (
+	"fmt"
+	"testing"
+
+	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// openBuyLock 100주 @0.40 매수 주문 잠금액 (주문 금액 4000 + 최대 수수료 10)
+const openBuyLock int64 = 4010
+
+// assertOrderStillOpen 취소가 확인되지 않으면 주문 상태와 매수 잠금이 그대로여야 함
+func assertOrderStillOpen(t *testing.T, db *gorm.DB, orderID, userID uint, locked int64) {
+	t.Helper()
+	var order models.Order
+	require.NoError(t, db.First(&order, orderID).Error)
+	assert.Equal(t, models.OrderStatusPending, order.Status)
+
+	var wallet models.UserWallet
+	require.NoError(t, db.Where("user_id = ?", userID).First(&wallet).Error)
+	assert.Equal(t, locked, wallet.USDCLockedBalance)
+}
+
+// TestCancelOrderKeepsLockWhenRemovalTimesOut 단일 엔진에서 취소가 시간 안에 반영되지 않으면 주문과 잠금을 그대로 둠
+func TestCancelOrderKeepsLockWhenRemovalTimesOut(t *testing.T) {
+	env := testkit.New(t)
+	blocker := newActorBlocker(99)
+	engine := services.NewLocalMatchingEngine(env.DB, nil, nil, nil)
+	engine.SetFeatureFlags(blocker)
+	require.NoError(t, engine.Start())
+	t.Cleanup(func() {
+		engine.Stop()
+		testkit.Settle(t, engine)
+	})
+	tradingService := services.NewTradingService(env.DB, nil, engine)
+
+	alice := env.Factory.FundedUser(10000)
+	milestone := env.Factory.Market()
+	placed, err := tradingService.CreateOrder(alice.ID, models.CreateOrderRequest{
+		ProjectID: milestone.ProjectID, MilestoneID: milestone.ID, OptionID: models.OptionSuccess,
+		Type: models.OrderTypeLimit, Side: models.OrderSideBuy, Quantity: 100, Price: 0.4,
+	}, "", "")
+	require.NoError(t, err)
+
+	// 같은 시장 액터를 멈춘 채 취소
+	go engine.SubmitOrder(&models.Order{
+		ID: 900, UserID: blocker.userID, MilestoneID: milestone.ID, OptionID: models.OptionSuccess,
+		Type: models.OrderTypeLimit, Side: models.OrderSideBuy, Price: 0.1, Quantity: 1, Status: models.OrderStatusPending,
+	})
+	<-blocker.entered
+
+	_, err = tradingService.CancelOrder(alice.ID, placed.Order.ID)
+	assert.ErrorIs(t, err, services.ErrCancelNotConfirmed)
+	assertOrderStillOpen(t, env.DB, placed.Order.ID, alice.ID, openBuyLock)
+
+	close(blocker.release)
+	response, err := tradingService.CancelOrder(alice.ID, placed.Order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, openBuyLock, response.ReleasedAmount)
+	bids := engine.GetOrderBook(milestone.ID, models.OptionSuccess, 10, 0).Bids
+	require.Len(t, bids, 1) // 액터를 멈췄던 주문만 남음
+	assert.Equal(t, 0.1, bids[0].Price)
+}
+
+// TestCancelOrderKeepsLockWhenMarketLocked 분산 엔진에서 마켓 락을 잡지 못하면 주문과 잠금을 그대로 둠
+func TestCancelOrderKeepsLockWhenMarketLocked(t *testing.T) {
+	env := testkit.New(t)
+	// 마켓 배정 루프 없이 (모든 마켓을 이 인스턴스가 매칭)
+	engine := services.NewDistributedMatchingEngineWithRedis(env.DB, nil, moduleRedis.Client)
+	t.Cleanup(func() { testkit.Settle(t, engine) })
+	tradingService := services.NewTradingService(env.DB, nil, engine)
+
+	alice := env.Factory.FundedUser(10000)
+	milestone := env.Factory.Market()
+	placed, err := tradingService.CreateOrder(alice.ID, models.CreateOrderRequest{
+		ProjectID: milestone.ProjectID, MilestoneID: milestone.ID, OptionID: models.OptionSuccess,
+		Type: models.OrderTypeLimit, Side: models.OrderSideBuy, Quantity: 100, Price: 0.4,
+	}, "", "")
+	require.NoError(t, err)
+
+	// 다른 인스턴스가 마켓 락을 쥐고 있음
+	lockKey := fmt.Sprintf("lock:match:%d:%s", milestone.ID, models.OptionSuccess)
+	require.NoError(t, env.Redis.Set(lockKey, "other-instance"))
+
+	_, err = tradingService.CancelOrder(alice.ID, placed.Order.ID)
+	assert.ErrorIs(t, err, services.ErrCancelNotConfirmed)
+	assertOrderStillOpen(t, env.DB, placed.Order.ID, alice.ID, openBuyLock)
+
+	env.Redis.Del(lockKey)
+	response, err := tradingService.CancelOrder(alice.ID, placed.Order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, openBuyLock, response.ReleasedAmount)
+}
+
+// TestCreateOrderWithdrawsWhenMatchingFails 매칭 제출이 실패하면 커밋된 주문을 엔진에서 제거한 뒤 취소하고 잠금 해제
+func TestCreateOrderWithdrawsWhenMatchingFails(t *testing.T) {
+	env := testkit.New(t)
+	// 시작하지 않은 엔진은 주문을 받지 않음
+	engine := services.NewLocalMatchingEngine(env.DB, nil, nil, nil)
+	tradingService := services.NewTradingService(env.DB, nil, engine)
+
+	alice := env.Factory.FundedUser(10000)
+	milestone := env.Factory.Market()
+	_, err := tradingService.CreateOrder(alice.ID, models.CreateOrderRequest{
+		ProjectID: milestone.ProjectID, MilestoneID: milestone.ID, OptionID: models.OptionSuccess,
+		Type: models.OrderTypeLimit, Side: models.OrderSideBuy, Quantity: 100, Price: 0.4,
+	}, "", "")
+	require.Error(t, err)
+
+	var order models.Order
+	require.NoError(t, env.DB.Where("user_id = ?", alice.ID).First(&order).Error)
+	assert.Equal(t, models.OrderStatusCancelled, order.Status)
+
+	var wallet models.UserWallet
+	require.NoError(t, env.DB.Where("user_id = ?", alice.ID).First(&wallet).Error)
+	assert.Zero(t, wallet.USDCLockedBalance)
+	assert.Equal(t, int64(10000), wallet.USDCBalance)
+}
+
+// TestCreateOrderStaysOpenWhenWithdrawalNotConfirmed 매칭 실패 후 엔진에서 제거도 확인하지 못하면 주문과 잠금을 남겨 다시 취소하게 함
+func TestCreateOrderStaysOpenWhenWithdrawalNotConfirmed(t *testing.T) {
+	env := testkit.New(t)
+	engine := services.NewDistributedMatchingEngineWithRedis(env.DB, nil, moduleRedis.Client)
+	t.Cleanup(func() { testkit.Settle(t, engine) })
+	tradingService := services.NewTradingService(env.DB, nil, engine)
+
+	alice := env.Factory.FundedUser(10000)
+	milestone := env.Factory.Market()
+
+	// 다른 인스턴스가 마켓 락을 쥐고 있어 매칭도 제거도 못함
+	lockKey := fmt.Sprintf("lock:match:%d:%s", milestone.ID, models.OptionSuccess)
+	require.NoError(t, env.Redis.Set(lockKey, "other-instance"))
+
+	_, err := tradingService.CreateOrder(alice.ID, models.CreateOrderRequest{
+		ProjectID: milestone.ProjectID, MilestoneID: milestone.ID, OptionID: models.OptionSuccess,
+		Type: models.OrderTypeLimit, Side: models.OrderSideBuy, Quantity: 100, Price: 0.4,
+	}, "", "")
+	require.Error(t, err)
+
+	var order models.Order
+	require.NoError(t, env.DB.Where("user_id = ?", alice.ID).First(&order).Error)
+	assertOrderStillOpen(t, env.DB, order.ID, alice.ID, openBuyLock)
+
+	env.Redis.Del(lockKey)
+	response, err := tradingService.CancelOrder(alice.ID, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, openBuyLock, response.ReleasedAmount)
+}
@@ -0,0 +1,111 @@
+package unit_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestSnapshotReplayAndCompaction 스냅샷 + 이후 이벤트 재생 결과가 전체 재생과 같고, 압축 후에도 주문장/잔액 복원
+func TestSnapshotReplayAndCompaction(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	redisServer := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+	defer client.Close()
+
+	ctx := context.Background()
+	eventSourcing := services.NewOrderEventSourcing(client)
+	sequence := 0
+	appendEvent := func(eventType services.OrderEventType, orderID uint, payload map[string]interface{}) {
+		sequence++
+		require.NoError(t, eventSourcing.AppendEvent(ctx, "1:success", &services.OrderEvent{
+			EventID:   fmt.Sprintf("event-%d", sequence),
+			EventType: eventType,
+			OrderID:   orderID,
+			Payload:   payload,
+		}))
+	}
+	createOrder := func(id, userID uint, side models.OrderSide, quantity int64, price float64) {
+		appendEvent(services.EventOrderCreated, id, map[string]interface{}{"order": models.Order{
+			ID: id, UserID: userID, MilestoneID: 1, OptionID: "success", Side: side, Quantity: quantity, Price: price,
+		}})
+	}
+	trade := func(buyOrderID, sellOrderID, buyerID, sellerID uint, quantity int64, ticks int64) {
+		settlement := models.SettleFill(quantity, ticks, ticks, 0, 25)
+		appendEvent(services.EventTradeExecuted, 0, map[string]interface{}{"trade": models.Trade{
+			BuyOrderID: buyOrderID, SellOrderID: sellOrderID, BuyerID: buyerID, SellerID: sellerID,
+			Quantity: quantity, Price: models.TicksToPrice(ticks), PriceTicks: ticks,
+			TotalAmount: settlement.Notional, BuyerFee: settlement.BuyerFee, SellerFee: settlement.SellerFee,
+		}})
+	}
+
+	createOrder(1, 11, models.OrderSideBuy, 100, 0.6)
+	createOrder(2, 12, models.OrderSideSell, 40, 0.6)
+	trade(1, 2, 11, 12, 40, 6000)
+
+	engine := services.NewDistributedMatchingEngineWithRedis(db, nil, client)
+	snapshot, err := engine.SnapshotMarket("1:success")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), snapshot.EventCount)
+	assert.Len(t, snapshot.Orders, 1, "체결 완료된 매도 주문은 스냅샷에서 제외")
+
+	createOrder(3, 12, models.OrderSideSell, 50, 0.7)
+	createOrder(4, 13, models.OrderSideSell, 10, 0.65)
+	trade(1, 4, 11, 13, 10, 6500)
+	createOrder(5, 13, models.OrderSideBuy, 5, 0.5)
+	appendEvent(services.EventOrderCancelled, 5, nil)
+
+	full, err := eventSourcing.ReplayMarket(ctx, "1:success", false)
+	require.NoError(t, err)
+	incremental, err := eventSourcing.ReplayMarket(ctx, "1:success", true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), full.EventCount())
+	assert.Equal(t, full.Snapshot().Orders, incremental.Snapshot().Orders)
+	assert.Equal(t, full.Balances(), incremental.Balances())
+	assert.Equal(t, full.StreamID(), incremental.StreamID())
+
+	buyer := full.Balances()[0]
+	assert.Equal(t, uint(11), buyer.UserID)
+	assert.Equal(t, int64(50), buyer.Shares)
+	firstFill := models.SettleFill(40, 6000, 6000, 0, 25)
+	secondFill := models.SettleFill(10, 6500, 6500, 0, 25)
+	assert.Equal(t, -(firstFill.Notional + firstFill.BuyerFee + secondFill.Notional + secondFill.BuyerFee), buyer.CashDelta)
+
+	// 보존 기간 0: 두 번째 스냅샷 이전 이벤트 제거, 스냅샷 + 꼬리로 그대로 복원
+	_, err = engine.SnapshotMarket("1:success")
+	require.NoError(t, err)
+	createOrder(6, 14, models.OrderSideBuy, 20, 0.55)
+	redisServer.SetTime(time.Now().Add(time.Second))
+
+	removed, err := eventSourcing.CompactStream(ctx, "1:success", 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), removed, "스냅샷의 마지막 이벤트와 이후 이벤트는 남김")
+
+	orderBook, err := engine.RebuildOrderBook("1:success")
+	require.NoError(t, err)
+	if assert.Len(t, orderBook.Bids, 2) {
+		assert.Equal(t, uint(1), orderBook.Bids[0].ID)
+		assert.Equal(t, int64(50), orderBook.Bids[0].Remaining)
+		assert.Equal(t, uint(6), orderBook.Bids[1].ID)
+	}
+	if assert.Len(t, orderBook.Asks, 1) {
+		assert.Equal(t, uint(3), orderBook.Asks[0].ID)
+	}
+	assert.Equal(t, 0.65, orderBook.LastPrice)
+
+	markets, err := eventSourcing.MarketStreams(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1:success"}, markets)
+}
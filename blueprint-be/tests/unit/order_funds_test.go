@@ -0,0 +1,140 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOrderFundsLockLifecycle 매수 주문 접수 시 잠그고, 부분 체결분은 체결 시점에, 나머지는 취소 시점에 해제
+func TestOrderFundsLockLifecycle(t *testing.T) {
+	env := testkit.New(t)
+	engine := testkit.StartMatchingEngine(t, env.DB)
+	tradingService := services.NewTradingService(env.DB, nil, engine)
+	milestone := env.Factory.Market()
+
+	buyer := env.Factory.FundedUser(10000)
+	seller := env.Factory.FundedUser(0)
+	env.Factory.Position(seller.ID, milestone, models.OptionSuccess, 40, 0.3)
+
+	request := func(side models.OrderSide, price float64, quantity int64) models.CreateOrderRequest {
+		return models.CreateOrderRequest{
+			ProjectID:   milestone.ProjectID,
+			MilestoneID: milestone.ID,
+			OptionID:    models.OptionSuccess,
+			Type:        models.OrderTypeLimit,
+			Side:        side,
+			Quantity:    quantity,
+			Price:       price,
+		}
+	}
+	wallet := func() models.UserWallet {
+		var w models.UserWallet
+		require.NoError(t, env.DB.Where("user_id = ?", buyer.ID).First(&w).Error)
+		return w
+	}
+
+	// 먼저 올라온 40주 매도 호가와 부분 체결
+	_, err := tradingService.CreateOrder(seller.ID, request(models.OrderSideSell, 0.5, 40), "", "")
+	require.NoError(t, err)
+
+	// 접수 시 100주 × 60센트 + 최대 수수료(15) 잠금, 체결된 40주분 잠금(2400+6) 해제 후 체결가 50센트(2000)+수수료 차감
+	placed, err := tradingService.CreateOrder(buyer.ID, request(models.OrderSideBuy, 0.6, 100), "", "")
+	require.NoError(t, err)
+	require.Len(t, placed.Trades, 1)
+	testkit.Settle(t, engine)
+	fee := placed.Trades[0].BuyerFee
+	assert.Equal(t, int64(3609), wallet().USDCLockedBalance)
+	assert.Equal(t, int64(4000)+400-9-fee, wallet().USDCBalance, "가격 개선분은 사용 가능 잔액으로, 미체결분 최대 수수료는 잠금에 남음")
+
+	// 잔액을 넘는 주문은 잠그지 않고 거부
+	_, err = tradingService.CreateOrder(buyer.ID, request(models.OrderSideBuy, 0.5, 100), "", "")
+	assert.ErrorIs(t, err, services.ErrInsufficientBalance)
+	assert.Equal(t, int64(3609), wallet().USDCLockedBalance)
+
+	// 취소: 미체결 60주분 해제
+	cancelled, err := tradingService.CancelOrder(buyer.ID, placed.Order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3609), cancelled.ReleasedAmount)
+	assert.Equal(t, int64(40), cancelled.Order.Filled)
+	assert.Zero(t, wallet().USDCLockedBalance)
+	assert.Equal(t, int64(10000)-2000-fee, wallet().USDCBalance)
+
+	_, err = tradingService.CancelOrder(buyer.ID, placed.Order.ID)
+	assert.ErrorIs(t, err, services.ErrOrderNotOpen)
+	assert.Zero(t, wallet().USDCLockedBalance, "중복 취소로 다시 해제하지 않음")
+}
+
+// TestBuyerFeeComesOutOfOrderLock 사용 가능 잔액이 0이어도 체결 수수료는 주문 잠금에서 지불
+func TestBuyerFeeComesOutOfOrderLock(t *testing.T) {
+	env := testkit.New(t)
+	engine := testkit.StartMatchingEngine(t, env.DB)
+	tradingService := services.NewTradingService(env.DB, nil, engine)
+	milestone := env.Factory.Market()
+
+	seller := env.Factory.FundedUser(0)
+	env.Factory.Position(seller.ID, milestone, models.OptionSuccess, 100, 0.3)
+	buyer := env.Factory.FundedUser(models.BuyReserveCents(100, models.PriceToTicks(0.5)))
+
+	request := models.CreateOrderRequest{
+		ProjectID: milestone.ProjectID, MilestoneID: milestone.ID, OptionID: models.OptionSuccess,
+		Type: models.OrderTypeLimit, Side: models.OrderSideSell, Quantity: 100, Price: 0.5,
+	}
+	_, err := tradingService.CreateOrder(seller.ID, request, "", "")
+	require.NoError(t, err)
+	request.Side = models.OrderSideBuy
+	placed, err := tradingService.CreateOrder(buyer.ID, request, "", "")
+	require.NoError(t, err)
+	require.Len(t, placed.Trades, 1)
+	testkit.Settle(t, engine)
+
+	var wallet models.UserWallet
+	require.NoError(t, env.DB.Where("user_id = ?", buyer.ID).First(&wallet).Error)
+	assert.Zero(t, wallet.USDCLockedBalance)
+	assert.Equal(t, int64(13)-placed.Trades[0].BuyerFee, wallet.USDCBalance, "남은 수수료 잠금만 돌려받음")
+	assert.GreaterOrEqual(t, wallet.USDCBalance, int64(0))
+}
+
+// TestBuyerSettlementHeldWhenLockMissing 체결분 잠금이 없으면 다른 주문의 잠금을 쓰지 않고 정산을 보류한 뒤 대사 불일치로 기록
+func TestBuyerSettlementHeldWhenLockMissing(t *testing.T) {
+	env := testkit.New(t)
+	engine := testkit.StartMatchingEngine(t, env.DB)
+	milestone := env.Factory.Market()
+	other := env.Factory.Market()
+
+	seller := env.Factory.FundedUser(0)
+	env.Factory.Position(seller.ID, milestone, models.OptionSuccess, 10, 0.3)
+	buyer := env.Factory.User()
+
+	// 다른 마켓 주문의 잠금만 있는 지갑 (이번 체결분은 잠그지 않은 채 엔진에 직접 제출)
+	resting := env.Factory.Order(buyer.ID, other, models.OrderSideBuy, 0.2, 10)
+	env.Factory.Wallet(buyer.ID, 0, func(w *models.UserWallet) { w.USDCLockedBalance = resting.LockedCents() })
+
+	_, err := engine.SubmitOrder(env.Factory.Order(seller.ID, milestone, models.OrderSideSell, 0.5, 10))
+	require.NoError(t, err)
+	bid := env.Factory.Order(buyer.ID, milestone, models.OrderSideBuy, 0.5, 10)
+	result, err := engine.SubmitOrder(bid)
+	require.NoError(t, err)
+	require.Len(t, result.Trades, 1)
+	testkit.Settle(t, engine)
+
+	var wallet models.UserWallet
+	require.NoError(t, env.DB.Where("user_id = ?", buyer.ID).First(&wallet).Error)
+	assert.Equal(t, resting.LockedCents(), wallet.USDCLockedBalance, "다른 주문의 잠금은 그대로")
+	assert.Zero(t, wallet.USDCBalance)
+
+	var report models.ReconciliationReport
+	require.NoError(t, env.DB.Order("id DESC").First(&report).Error)
+	require.Len(t, report.Discrepancies, 1)
+	discrepancy := report.Discrepancies[0]
+	assert.Equal(t, models.ReconcileBuyerSettlement, discrepancy.Check)
+	assert.Equal(t, buyer.ID, discrepancy.UserID)
+	assert.Equal(t, bid.ID, discrepancy.OrderID)
+	assert.Equal(t, models.BuyReserveCents(10, models.PriceToTicks(0.5)), discrepancy.Expected)
+	assert.Equal(t, resting.LockedCents(), discrepancy.Actual)
+}
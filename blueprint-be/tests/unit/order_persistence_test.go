@@ -0,0 +1,67 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestMatchingEngineRecoversOrderStateFromTrades(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Order{}, &models.Trade{}, &models.UserWallet{}))
+
+	// 중단 직전 체결은 저장됐지만 주문 상태는 반영되지 못한 상황
+	orders := []models.Order{
+		{ID: 1, UserID: 1, MilestoneID: 5, OptionID: "success", Side: models.OrderSideBuy, Price: 0.45, Quantity: 10, Remaining: 10, Status: models.OrderStatusPending},
+		{ID: 2, UserID: 2, MilestoneID: 5, OptionID: "success", Side: models.OrderSideSell, Price: 0.55, Quantity: 5, Remaining: 5, Status: models.OrderStatusPending},
+		{ID: 3, UserID: 3, MilestoneID: 5, OptionID: "success", Side: models.OrderSideSell, Price: 0.60, Quantity: 8, Remaining: 8, Status: models.OrderStatusPending},
+	}
+	require.NoError(t, db.Create(&orders).Error)
+	require.NoError(t, db.Create(&[]models.Trade{
+		{MilestoneID: 5, OptionID: "success", BuyOrderID: 1, SellOrderID: 90, Quantity: 4, Price: 0.45},
+		{MilestoneID: 5, OptionID: "success", BuyOrderID: 91, SellOrderID: 2, Quantity: 5, Price: 0.55},
+	}).Error)
+
+	engine := services.NewLocalMatchingEngine(db, nil, nil, nil)
+	require.NoError(t, engine.Start())
+	defer engine.Stop()
+
+	var buy, sell models.Order
+	db.First(&buy, 1)
+	db.First(&sell, 2)
+	assert.Equal(t, int64(4), buy.Filled)
+	assert.Equal(t, int64(6), buy.Remaining)
+	assert.Equal(t, models.OrderStatusPartial, buy.Status)
+	assert.Equal(t, models.OrderStatusFilled, sell.Status)
+
+	book := engine.GetOrderBook(5, "success", 10, 0)
+	require.Len(t, book.Bids, 1)
+	assert.Equal(t, int64(6), book.Bids[0].Quantity)
+	require.Len(t, book.Asks, 1, "완전 체결된 주문은 주문장에 올리지 않음")
+	assert.Equal(t, 0.60, book.Asks[0].Price)
+
+	// 취소는 상태만 바꾸고 체결 수량은 유지, 미체결 6주분 잠금(주문 금액 270 + 최대 수수료 1)만 해제
+	require.NoError(t, db.Create(&models.UserWallet{UserID: 1, USDCBalance: 1000, USDCLockedBalance: 271}).Error)
+	tradingService := services.NewTradingService(db, nil, engine)
+	cancelled, err := tradingService.CancelOrder(1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(271), cancelled.ReleasedAmount)
+	db.First(&buy, 1)
+	assert.Equal(t, models.OrderStatusCancelled, buy.Status)
+	assert.Equal(t, int64(4), buy.Filled)
+	var wallet models.UserWallet
+	db.Where("user_id = ?", 1).First(&wallet)
+	assert.Equal(t, int64(1271), wallet.USDCBalance)
+	assert.Zero(t, wallet.USDCLockedBalance)
+	assert.Empty(t, engine.GetOrderBook(5, "success", 10, 0).Bids)
+
+	_, err = tradingService.CancelOrder(2, 2)
+	assert.ErrorIs(t, err, services.ErrOrderNotOpen, "체결 완료 주문은 취소 불가")
+}
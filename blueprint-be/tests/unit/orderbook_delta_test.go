@@ -0,0 +1,101 @@
+package unit_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestOrderBookDeltaStreaming(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Order{}))
+
+	resting := []models.Order{
+		{ID: 1, UserID: 1, MilestoneID: 5, OptionID: "success", Side: models.OrderSideBuy, Price: 0.45, Quantity: 5, Remaining: 5, Status: models.OrderStatusPending},
+		{ID: 2, UserID: 2, MilestoneID: 5, OptionID: "success", Side: models.OrderSideBuy, Price: 0.45, Quantity: 7, Remaining: 7, Status: models.OrderStatusPending},
+		{ID: 3, UserID: 3, MilestoneID: 5, OptionID: "success", Side: models.OrderSideSell, Price: 0.52, Quantity: 4, Remaining: 4, Status: models.OrderStatusPending},
+	}
+	require.NoError(t, db.Create(&resting).Error)
+
+	sseService := services.NewSSEService()
+	engine := services.NewLocalMatchingEngine(db, sseService, nil, nil)
+	require.NoError(t, engine.Start())
+	defer engine.Stop()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/milestones/:id/stream", sseService.HandleSSEConnection)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/milestones/5/stream")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	reader := bufio.NewReader(resp.Body)
+
+	next := func() services.SSEMessage {
+		for {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			if strings.HasPrefix(line, "data: ") {
+				var message services.SSEMessage
+				require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &message))
+				return message
+			}
+		}
+	}
+	require.Equal(t, "connection", next().Type)
+
+	done := make(chan struct{})
+	go func() {
+		engine.CancelOrder(&resting[0])
+		engine.CancelOrder(&resting[2])
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("cancel timed out")
+	}
+
+	type delta struct {
+		Sequence     uint64                          `json:"sequence"`
+		PrevSequence uint64                          `json:"prev_sequence"`
+		Changes      []services.OrderBookLevelChange `json:"changes"`
+	}
+	decode := func(message services.SSEMessage) delta {
+		require.Equal(t, "orderbook_delta", message.Type)
+		raw, _ := json.Marshal(message.Data)
+		var d delta
+		require.NoError(t, json.Unmarshal(raw, &d))
+		return d
+	}
+
+	first := decode(next())
+	assert.Equal(t, uint64(0), first.PrevSequence)
+	assert.Equal(t, uint64(1), first.Sequence)
+	require.Len(t, first.Changes, 1)
+	assert.Equal(t, services.OrderBookLevelChange{Side: "bid", Action: services.OrderBookLevelModify, Price: 0.45, Quantity: 7, Count: 1}, first.Changes[0])
+
+	second := decode(next())
+	assert.Equal(t, uint64(1), second.PrevSequence)
+	require.Len(t, second.Changes, 1)
+	assert.Equal(t, services.OrderBookLevelRemove, second.Changes[0].Action)
+	assert.Equal(t, "ask", second.Changes[0].Side)
+
+	assert.Equal(t, uint64(2), engine.GetOrderBook(5, "success", 10, 0).Sequence)
+}
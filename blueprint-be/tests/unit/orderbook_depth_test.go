@@ -0,0 +1,60 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestOrderBookDepthAggregation(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Order{}))
+
+	resting := []models.Order{
+		{ID: 1, UserID: 1, MilestoneID: 5, OptionID: "success", Side: models.OrderSideBuy, Price: 0.41, Quantity: 10, Remaining: 10, Status: models.OrderStatusPending},
+		{ID: 2, UserID: 2, MilestoneID: 5, OptionID: "success", Side: models.OrderSideBuy, Price: 0.45, Quantity: 5, Remaining: 5, Status: models.OrderStatusPending},
+		{ID: 3, UserID: 3, MilestoneID: 5, OptionID: "success", Side: models.OrderSideBuy, Price: 0.45, Quantity: 7, Remaining: 7, Status: models.OrderStatusPartial},
+		{ID: 4, UserID: 4, MilestoneID: 5, OptionID: "success", Side: models.OrderSideBuy, Price: 0.38, Quantity: 3, Remaining: 3, Status: models.OrderStatusPending},
+		{ID: 5, UserID: 5, MilestoneID: 5, OptionID: "success", Side: models.OrderSideSell, Price: 0.52, Quantity: 4, Remaining: 4, Status: models.OrderStatusPending},
+		{ID: 6, UserID: 6, MilestoneID: 5, OptionID: "success", Side: models.OrderSideSell, Price: 0.56, Quantity: 6, Remaining: 6, Status: models.OrderStatusPending},
+	}
+	require.NoError(t, db.Create(&resting).Error)
+
+	engine := services.NewLocalMatchingEngine(db, nil, nil, nil)
+	require.NoError(t, engine.Start())
+	defer engine.Stop()
+
+	book := engine.GetOrderBook(5, "success", 20, 0)
+	require.Len(t, book.Bids, 3)
+	assert.Equal(t, 0.45, book.Bids[0].Price)
+	assert.Equal(t, int64(12), book.Bids[0].Quantity)
+	assert.Equal(t, 2, book.Bids[0].Count)
+	assert.Equal(t, 0.41, book.Bids[1].Price)
+	assert.Equal(t, int64(25), book.Bids[2].Total)
+	assert.InDelta(t, 0.07, book.Spread, 1e-9)
+
+	// 0.1 단위 집계: 매수는 내림, 매도는 올림
+	book = engine.GetOrderBook(5, "success", 1, 0.1)
+	require.Len(t, book.Bids, 1)
+	assert.Equal(t, 0.4, book.Bids[0].Price)
+	assert.Equal(t, int64(22), book.Bids[0].Quantity)
+	require.Len(t, book.Asks, 1)
+	assert.Equal(t, 0.6, book.Asks[0].Price)
+	assert.Equal(t, int64(10), book.Asks[0].Quantity)
+
+	before := book.Sequence
+	engine.CancelOrder(&resting[1])
+	book = engine.GetOrderBook(5, "success", 20, 0)
+	assert.Equal(t, before+1, book.Sequence)
+	assert.Equal(t, int64(7), book.Bids[0].Quantity)
+
+	assert.ErrorIs(t, services.ValidateOrderBookParams(0, 0), services.ErrInvalidOrderBookDepth)
+	assert.ErrorIs(t, services.ValidateOrderBookParams(10, 0.0001), services.ErrInvalidOrderBookAggregation)
+}
@@ -0,0 +1,274 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const (
+	parlayHouseID  = uint(1)
+	parlayBettorID = uint(7)
+)
+
+// ParlayServiceTestSuite 조합 베팅 가격/한도/정산 테스트 슈트
+type ParlayServiceTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	service *services.ParlayService
+}
+
+func (suite *ParlayServiceTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(
+		&models.User{},
+		&models.Project{},
+		&models.Milestone{},
+		&models.MarketData{},
+		&models.UserWallet{},
+		&models.WalletLedgerEntry{},
+		&models.Parlay{},
+		&models.ParlayLeg{},
+		&models.Notification{},
+	))
+	suite.db = db
+	suite.service = services.NewParlayService(db)
+	suite.service.SetHouseAccount(parlayHouseID)
+
+	suite.Require().NoError(db.Create(&models.UserWallet{UserID: parlayHouseID, USDCBalance: 100000}).Error)
+	suite.Require().NoError(db.Create(&models.UserWallet{UserID: parlayBettorID, USDCBalance: 10000}).Error)
+}
+
+// market 성공 옵션 가격이 있는 거래 중 마일스톤
+func (suite *ParlayServiceTestSuite) market(projectID uint, price float64) *models.Milestone {
+	milestone := &models.Milestone{ProjectID: projectID, Title: "마일스톤", Status: models.MilestoneStatusActive}
+	suite.Require().NoError(suite.db.Create(milestone).Error)
+	suite.Require().NoError(suite.db.Create(&models.MarketData{
+		MilestoneID:  milestone.ID,
+		OptionID:     models.OptionSuccess,
+		CurrentPrice: price,
+	}).Error)
+	return milestone
+}
+
+func (suite *ParlayServiceTestSuite) resolve(milestone *models.Milestone, status models.MilestoneStatus) {
+	suite.Require().NoError(suite.db.Model(milestone).Update("status", status).Error)
+}
+
+func (suite *ParlayServiceTestSuite) place(userID uint, stake int64, milestones ...*models.Milestone) (*models.Parlay, error) {
+	req := &models.CreateParlayRequest{Stake: stake}
+	for _, milestone := range milestones {
+		req.Legs = append(req.Legs, models.ParlayLegRequest{MilestoneID: milestone.ID, OptionID: models.OptionSuccess})
+	}
+	return suite.service.PlaceParlay(userID, req)
+}
+
+func (suite *ParlayServiceTestSuite) wallet(userID uint) models.UserWallet {
+	var wallet models.UserWallet
+	suite.Require().NoError(suite.db.Where("user_id = ?", userID).First(&wallet).Error)
+	return wallet
+}
+
+func (suite *ParlayServiceTestSuite) parlayStatus(id uint) models.ParlayStatus {
+	var parlay models.Parlay
+	suite.Require().NoError(suite.db.First(&parlay, id).Error)
+	return parlay.Status
+}
+
+// TestQuoteTreatsSameProjectAsCorrelated 같은 프로젝트 레그는 최소 확률 하나로 계산하고 마진을 더함
+func (suite *ParlayServiceTestSuite) TestQuoteTreatsSameProjectAsCorrelated() {
+	first := suite.market(1, 0.6)
+	second := suite.market(1, 0.4)
+	other := suite.market(2, 0.5)
+
+	legs := []models.ParlayLegRequest{
+		{MilestoneID: first.ID, OptionID: models.OptionSuccess},
+		{MilestoneID: second.ID, OptionID: models.OptionSuccess},
+		{MilestoneID: other.ID, OptionID: models.OptionSuccess},
+	}
+	quote, err := suite.service.Quote(legs, 1000)
+	suite.Require().NoError(err)
+	suite.InDelta(0.2, quote.FairProbability, 1e-9)
+	suite.InDelta(0.21, quote.CombinedPrice, 1e-9)
+	suite.Equal(1, quote.CorrelatedGroups)
+	suite.Equal(int64(4761), quote.PotentialWin)
+
+	_, err = suite.service.Quote(legs[:1], 1000)
+	suite.Error(err, "레그 1개")
+	_, err = suite.service.Quote(append(legs, legs[0]), 1000)
+	suite.Error(err, "같은 마일스톤 중복")
+}
+
+// TestWinningParlayIsPaidFromHouse 적중 지급액 중 원금을 넘는 몫은 하우스 계정에서 원장으로 이전
+func (suite *ParlayServiceTestSuite) TestWinningParlayIsPaidFromHouse() {
+	first := suite.market(1, 0.5)
+	second := suite.market(2, 0.5)
+	parlay, err := suite.place(parlayBettorID, 1000, first, second)
+	suite.Require().NoError(err)
+	suite.Equal(int64(3809), parlay.PotentialWin) // 1000 / (0.25 × 1.05)
+
+	wallet := suite.wallet(parlayBettorID)
+	suite.Equal(int64(9000), wallet.USDCBalance)
+	suite.Equal(int64(1000), wallet.USDCLockedBalance)
+
+	suite.resolve(first, models.MilestoneStatusCompleted)
+	settled, err := suite.service.SettlePendingLegs()
+	suite.Require().NoError(err)
+	suite.Zero(settled, "레그가 남아 있으면 정산하지 않음")
+
+	suite.resolve(second, models.MilestoneStatusCompleted)
+	settled, err = suite.service.SettlePendingLegs()
+	suite.Require().NoError(err)
+	suite.Equal(1, settled)
+	suite.Equal(models.ParlayStatusWon, suite.parlayStatus(parlay.ID))
+
+	wallet = suite.wallet(parlayBettorID)
+	suite.Equal(int64(12809), wallet.USDCBalance)
+	suite.Zero(wallet.USDCLockedBalance)
+	suite.Equal(int64(2809), wallet.TotalUSDCProfit)
+	suite.Equal(int64(97191), suite.wallet(parlayHouseID).USDCBalance)
+
+	// 원금 잠금, 사용자 정산, 하우스 정산이 모두 원장에 남음
+	var entries []models.WalletLedgerEntry
+	suite.Require().NoError(suite.db.Where("reference_type = ? AND reference_id = ?", "parlay", parlay.ID).Order("id").Find(&entries).Error)
+	suite.Require().Len(entries, 3)
+	suite.Equal(models.LedgerParlayStake, entries[0].EntryType)
+	suite.Equal(models.LedgerParlaySettlement, entries[1].EntryType)
+	suite.Equal(int64(3809), entries[1].Amount)
+	suite.Equal(int64(-1000), entries[1].LockedAmount)
+	suite.Equal(parlayHouseID, entries[2].UserID)
+	suite.Equal(int64(-2809), entries[2].Amount)
+}
+
+// TestLosingParlaySettlesImmediately 미적중 레그가 하나라도 나오면 남은 레그와 무관하게 원금이 하우스 계정으로 감
+func (suite *ParlayServiceTestSuite) TestLosingParlaySettlesImmediately() {
+	first := suite.market(1, 0.5)
+	second := suite.market(2, 0.5)
+	parlay, err := suite.place(parlayBettorID, 1000, first, second)
+	suite.Require().NoError(err)
+
+	suite.resolve(first, models.MilestoneStatusFailed)
+	settled, err := suite.service.SettlePendingLegs()
+	suite.Require().NoError(err)
+	suite.Equal(1, settled)
+	suite.Equal(models.ParlayStatusLost, suite.parlayStatus(parlay.ID))
+
+	wallet := suite.wallet(parlayBettorID)
+	suite.Equal(int64(9000), wallet.USDCBalance)
+	suite.Zero(wallet.USDCLockedBalance)
+	suite.Equal(int64(1000), wallet.TotalUSDCLoss)
+	suite.Equal(int64(101000), suite.wallet(parlayHouseID).USDCBalance)
+}
+
+// TestVoidLegRepricesPayout 취소된 마일스톤 레그는 빼고 남은 레그 접수가로 재산정, 전부 무효면 원금 환불
+func (suite *ParlayServiceTestSuite) TestVoidLegRepricesPayout() {
+	cancelled := suite.market(1, 0.5)
+	won := suite.market(2, 0.4)
+	parlay, err := suite.place(parlayBettorID, 1000, cancelled, won)
+	suite.Require().NoError(err)
+
+	suite.resolve(cancelled, models.MilestoneStatusCancelled)
+	suite.resolve(won, models.MilestoneStatusCompleted)
+	_, err = suite.service.SettlePendingLegs()
+	suite.Require().NoError(err)
+
+	var settledParlay models.Parlay
+	suite.Require().NoError(suite.db.First(&settledParlay, parlay.ID).Error)
+	suite.Equal(models.ParlayStatusWon, settledParlay.Status)
+	suite.Equal(int64(2380), settledParlay.Payout) // 1000 / (0.4 × 1.05)
+	suite.Equal(int64(100000-1380), suite.wallet(parlayHouseID).USDCBalance)
+
+	// 모든 레그 무효 → 원금 환불, 하우스 계정 변동 없음
+	first := suite.market(3, 0.5)
+	second := suite.market(4, 0.5)
+	refunded, err := suite.place(parlayBettorID, 1000, first, second)
+	suite.Require().NoError(err)
+	suite.resolve(first, models.MilestoneStatusCancelled)
+	suite.resolve(second, models.MilestoneStatusRejected)
+	_, err = suite.service.SettlePendingLegs()
+	suite.Require().NoError(err)
+
+	suite.Equal(models.ParlayStatusVoid, suite.parlayStatus(refunded.ID))
+	wallet := suite.wallet(parlayBettorID)
+	suite.Equal(int64(10000+1380), wallet.USDCBalance)
+	suite.Zero(wallet.USDCLockedBalance)
+	suite.Equal(int64(100000-1380), suite.wallet(parlayHouseID).USDCBalance)
+}
+
+// TestPayoutWaitsForHouseBalance 하우스 계정 잔액이 모자라면 지급을 보류하고 채워진 뒤 다음 정산에서 지급
+func (suite *ParlayServiceTestSuite) TestPayoutWaitsForHouseBalance() {
+	suite.Require().NoError(suite.db.Model(&models.UserWallet{}).Where("user_id = ?", parlayHouseID).Update("usdc_balance", 1000).Error)
+	first := suite.market(1, 0.5)
+	second := suite.market(2, 0.5)
+	parlay, err := suite.place(parlayBettorID, 1000, first, second)
+	suite.Require().NoError(err)
+
+	suite.resolve(first, models.MilestoneStatusCompleted)
+	suite.resolve(second, models.MilestoneStatusCompleted)
+	settled, err := suite.service.SettlePendingLegs()
+	suite.Require().NoError(err)
+	suite.Zero(settled)
+	suite.Equal(models.ParlayStatusOpen, suite.parlayStatus(parlay.ID))
+	suite.Equal(int64(1000), suite.wallet(parlayBettorID).USDCLockedBalance)
+	suite.Equal(int64(1000), suite.wallet(parlayHouseID).USDCBalance)
+
+	suite.Require().NoError(suite.db.Model(&models.UserWallet{}).Where("user_id = ?", parlayHouseID).Update("usdc_balance", 5000).Error)
+	settled, err = suite.service.SettlePendingLegs()
+	suite.Require().NoError(err)
+	suite.Equal(1, settled)
+	suite.Equal(models.ParlayStatusWon, suite.parlayStatus(parlay.ID))
+	suite.Equal(int64(2191), suite.wallet(parlayHouseID).USDCBalance)
+}
+
+// TestExposureLimits 건당 지급액, 사용자별/마일스톤별 미정산 지급액 한도
+func (suite *ParlayServiceTestSuite) TestExposureLimits() {
+	first := suite.market(1, 0.5)
+	second := suite.market(2, 0.5)
+	for userID := uint(10); userID < 16; userID++ {
+		suite.Require().NoError(suite.db.Create(&models.UserWallet{UserID: userID, USDCBalance: 1000000}).Error)
+	}
+
+	// 건당 최대 지급액 $10,000 초과
+	_, err := suite.place(10, 270000, first, second)
+	suite.ErrorIs(err, services.ErrParlayLimitExceeded)
+
+	// 사용자별 미정산 한도 $20,000: 약 $9,980씩 2건까지
+	_, err = suite.place(10, 262000, first, second)
+	suite.Require().NoError(err)
+	_, err = suite.place(10, 262000, first, second)
+	suite.Require().NoError(err)
+	_, err = suite.place(10, 262000, first, second)
+	suite.ErrorIs(err, services.ErrParlayLimitExceeded)
+
+	// 마일스톤별 미정산 한도 $50,000: 다른 사용자 3건까지, 그다음은 거부
+	for userID := uint(11); userID < 14; userID++ {
+		_, err = suite.place(userID, 262000, first, second)
+		suite.Require().NoError(err)
+	}
+	_, err = suite.place(14, 262000, first, second)
+	suite.ErrorIs(err, services.ErrParlayLimitExceeded)
+	suite.Equal(int64(1000000), suite.wallet(14).USDCBalance, "거부된 조합은 원금을 잠그지 않음")
+
+	// 다른 마일스톤 조합은 영향 없음
+	third := suite.market(3, 0.5)
+	fourth := suite.market(4, 0.5)
+	_, err = suite.place(14, 262000, third, fourth)
+	suite.NoError(err)
+}
+
+// TestPlaceRequiresHouseAccount 하우스 계정이 없으면 접수하지 않음
+func (suite *ParlayServiceTestSuite) TestPlaceRequiresHouseAccount() {
+	service := services.NewParlayService(suite.db)
+	_, err := service.PlaceParlay(parlayBettorID, &models.CreateParlayRequest{Stake: 1000})
+	suite.ErrorIs(err, services.ErrParlayHouseNotConfigured)
+}
+
+func TestParlayServiceSuite(t *testing.T) {
+	suite.Run(t, new(ParlayServiceTestSuite))
+}
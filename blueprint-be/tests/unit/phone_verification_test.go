@@ -0,0 +1,117 @@
+package unit_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/handlers"
+	"blueprint/internal/testkit"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// phoneRouter 인증된 사용자로 휴대폰 인증 엔드포인트 호출
+func phoneRouter(t *testing.T) (*testkit.Env, func(userID uint, path, body string) *httptest.ResponseRecorder) {
+	t.Helper()
+	env := testkit.New(t)
+	handler := handlers.NewUserSettingsHandler(nil, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		var userID uint
+		fmt.Sscan(c.GetHeader("X-Test-User"), &userID)
+		c.Set("user_id", userID)
+	})
+	router.POST("/verify/phone", handler.RequestVerifyPhone)
+	router.POST("/verify/phone/confirm", handler.VerifyPhoneCode)
+
+	return env, func(userID uint, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Test-User", fmt.Sprint(userID))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+}
+
+// sentPhoneCode 발송 대기 중인 인증 코드와 정규화된 번호
+func sentPhoneCode(t *testing.T, env *testkit.Env, userID uint) (string, string) {
+	t.Helper()
+	stored, err := env.Redis.Get(fmt.Sprintf("phone_verification:%d", userID))
+	require.NoError(t, err)
+	var pending struct {
+		Code        string `json:"code"`
+		PhoneNumber string `json:"phone_number"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stored), &pending))
+	return pending.Code, pending.PhoneNumber
+}
+
+// TestPhoneCodeConfirmation 번호를 E.164로 정규화해 코드 발송, 재발송 간격 제한, 맞는 코드로 인증 완료, 같은 번호의 다른 계정은 거부
+func TestPhoneCodeConfirmation(t *testing.T) {
+	env, post := phoneRouter(t)
+	alice := env.Factory.User()
+	bob := env.Factory.User()
+
+	assert.Equal(t, http.StatusBadRequest, post(alice.ID, "/verify/phone", `{"phone_number":"12345"}`).Code)
+
+	require.Equal(t, http.StatusAccepted, post(alice.ID, "/verify/phone", `{"phone_number":"010-1234-5678"}`).Code)
+	code, phone := sentPhoneCode(t, env, alice.ID)
+	assert.Equal(t, "+821012345678", phone)
+	assert.Len(t, code, 6)
+
+	// 1분 안에 재발송 불가
+	assert.Equal(t, http.StatusTooManyRequests, post(alice.ID, "/verify/phone", `{"phone_number":"010-1234-5678"}`).Code)
+
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+	assert.Equal(t, http.StatusBadRequest, post(alice.ID, "/verify/phone/confirm", fmt.Sprintf(`{"code":%q}`, wrong)).Code)
+	require.Equal(t, http.StatusOK, post(alice.ID, "/verify/phone/confirm", fmt.Sprintf(`{"code":%q}`, code)).Code)
+
+	var verification models.UserVerification
+	require.NoError(t, env.DB.Where("user_id = ?", alice.ID).First(&verification).Error)
+	assert.True(t, verification.PhoneVerified)
+	assert.Equal(t, "+821012345678", verification.PhoneNumber)
+	assert.False(t, env.Redis.Exists(fmt.Sprintf("phone_verification:%d", alice.ID)), "사용한 코드는 폐기")
+
+	// 같은 번호를 다른 형식으로 입력해도 이미 인증된 번호
+	require.Equal(t, http.StatusAccepted, post(bob.ID, "/verify/phone", `{"phone_number":"+82 10 1234 5678"}`).Code)
+	code, _ = sentPhoneCode(t, env, bob.ID)
+	assert.Equal(t, http.StatusConflict, post(bob.ID, "/verify/phone/confirm", fmt.Sprintf(`{"code":%q}`, code)).Code)
+}
+
+// TestPhoneCodeAttemptLimit 코드당 5회 틀리면 코드를 폐기해 맞는 코드로도 인증할 수 없음, 새 코드는 다시 5회
+func TestPhoneCodeAttemptLimit(t *testing.T) {
+	env, post := phoneRouter(t)
+	user := env.Factory.User()
+
+	require.Equal(t, http.StatusAccepted, post(user.ID, "/verify/phone", `{"phone_number":"01098765432"}`).Code)
+	code, _ := sentPhoneCode(t, env, user.ID)
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusBadRequest, post(user.ID, "/verify/phone/confirm", fmt.Sprintf(`{"code":%q}`, wrong)).Code)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, post(user.ID, "/verify/phone/confirm", fmt.Sprintf(`{"code":%q}`, code)).Code)
+	assert.Equal(t, http.StatusBadRequest, post(user.ID, "/verify/phone/confirm", fmt.Sprintf(`{"code":%q}`, code)).Code, "폐기된 코드")
+
+	// 재발송 간격이 지나면 새 코드로 다시 시도 가능
+	env.Redis.FastForward(time.Minute)
+	require.Equal(t, http.StatusAccepted, post(user.ID, "/verify/phone", `{"phone_number":"01098765432"}`).Code)
+	code, _ = sentPhoneCode(t, env, user.ID)
+	assert.Equal(t, http.StatusOK, post(user.ID, "/verify/phone/confirm", fmt.Sprintf(`{"code":%q}`, code)).Code)
+}
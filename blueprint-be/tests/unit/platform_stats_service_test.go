@@ -0,0 +1,59 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPlatformStats 거래량, 열린 마켓, 활동 트레이더, 확정 마일스톤, TVL, 분쟁 해결률을 집계하고 캐시에서 조회
+func TestPlatformStats(t *testing.T) {
+	env := testkit.New(t)
+	service := services.NewPlatformStatsService(env.DB)
+
+	buyer, seller := env.Factory.User(), env.Factory.User()
+	open := env.Factory.Market(func(m *models.Milestone) { m.CurrentTVL = 5000 })
+	env.Factory.Milestone(open.ProjectID, func(m *models.Milestone) {
+		m.Status = models.MilestoneStatusCompleted
+		m.CurrentTVL = 9000
+	})
+
+	now := time.Now()
+	trades := []models.Trade{
+		{MilestoneID: open.ID, BuyerID: buyer.ID, SellerID: seller.ID, Quantity: 10, TotalAmount: 600, CreatedAt: now.Add(-time.Hour)},
+		{MilestoneID: open.ID, BuyerID: buyer.ID, SellerID: buyer.ID, Quantity: 5, TotalAmount: 250, CreatedAt: now.Add(-48 * time.Hour)},
+	}
+	require.NoError(t, env.DB.Create(&trades).Error)
+	require.NoError(t, env.DB.Create(&[]models.ArbitrationCase{
+		{CaseNumber: "ARB-1", Title: "a", Status: models.ArbitrationStatusDecided},
+		{CaseNumber: "ARB-2", Title: "b", Status: models.ArbitrationStatusVoting},
+	}).Error)
+
+	stats, err := service.Compute(now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(850), stats.TotalVolume)
+	assert.Equal(t, int64(600), stats.Volume24h)
+	assert.Equal(t, int64(1), stats.OpenMarkets)
+	assert.Equal(t, int64(2), stats.ActiveTraders)
+	assert.Equal(t, int64(1), stats.ResolvedMilestones)
+	assert.Equal(t, int64(5000), stats.TotalValueLocked)
+	assert.InDelta(t, 0.5, stats.ArbitrationResolutionRate, 0.0001)
+
+	// 다시 집계하면 같은 행을 갱신
+	_, err = service.Compute(now.Add(time.Minute))
+	require.NoError(t, err)
+	var rows int64
+	require.NoError(t, env.DB.Model(&models.GlobalStatsCache{}).Count(&rows).Error)
+	assert.Equal(t, int64(1), rows)
+
+	cached, err := service.Get()
+	require.NoError(t, err)
+	assert.Equal(t, int64(850), cached.TotalVolume)
+	assert.WithinDuration(t, now.Add(time.Minute), cached.ComputedAt, time.Second)
+}
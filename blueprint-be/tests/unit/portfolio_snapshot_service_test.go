@@ -0,0 +1,60 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestPortfolioSnapshots(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.UserWallet{}, &models.Position{}, &models.MarketData{}, &models.PositionSnapshot{}))
+
+	db.Create(&models.UserWallet{UserID: 1, USDCBalance: 10000, USDCLockedBalance: 2000})
+	db.Create(&models.Position{UserID: 1, MilestoneID: 1, OptionID: "success", Quantity: 100, AvgPrice: 50, Realized: 300})
+	db.Create(&models.Position{UserID: 2, MilestoneID: 2, OptionID: "fail", Quantity: 10, AvgPrice: 40}) // 시장가 없음 → 평균 단가
+	db.Create(&models.MarketData{MilestoneID: 1, OptionID: "success", CurrentPrice: 70})
+
+	snapshotService := services.NewPortfolioSnapshotService(db)
+	yesterday := time.Now().AddDate(0, 0, -1)
+
+	count, err := snapshotService.SnapshotAll(yesterday)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// 같은 날짜 재실행은 덮어쓰기
+	db.Model(&models.MarketData{}).Where("milestone_id = ?", 1).Update("current_price", 80)
+	_, err = snapshotService.SnapshotAll(yesterday)
+	require.NoError(t, err)
+
+	var total int64
+	db.Model(&models.PositionSnapshot{}).Count(&total)
+	assert.Equal(t, int64(2), total)
+
+	history, err := snapshotService.GetHistory(1, 30)
+	require.NoError(t, err)
+	require.Len(t, history.Snapshots, 1)
+	snapshot := history.Snapshots[0]
+	assert.Equal(t, int64(12000), snapshot.CashBalance)
+	assert.Equal(t, int64(8000), snapshot.PositionValue)
+	assert.Equal(t, int64(3000), snapshot.UnrealizedPnL)
+	assert.Equal(t, int64(300), snapshot.RealizedPnL)
+	assert.Equal(t, int64(20000), snapshot.TotalValue)
+
+	require.NotNil(t, history.Current)
+	assert.Equal(t, int64(20000), history.Current.TotalValue)
+
+	other, err := snapshotService.GetHistory(2, 30)
+	require.NoError(t, err)
+	require.Len(t, other.Snapshots, 1)
+	assert.Equal(t, int64(400), other.Snapshots[0].PositionValue)
+	assert.Equal(t, int64(0), other.Snapshots[0].UnrealizedPnL)
+}
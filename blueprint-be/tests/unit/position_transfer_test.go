@@ -0,0 +1,106 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPositionTransfer 수락하면 수량과 비례 원가가 옮겨져 평균가가 합산되고 수수료가 차감, 거절/만료되면 수수료 반환
+func TestPositionTransfer(t *testing.T) {
+	env := testkit.New(t)
+	transfers := services.NewPositionTransferService(env.DB, 100)
+
+	market := env.Factory.Market()
+	sender := env.Factory.FundedUser(1000)
+	recipient := env.Factory.FundedUser(0)
+	env.Factory.Position(sender.ID, market, models.OptionSuccess, 100, 0.40)
+	env.Factory.Position(recipient.ID, market, models.OptionSuccess, 20, 0.70)
+	env.Factory.Order(sender.ID, market, models.OrderSideSell, 0.60, 30) // 미체결 매도 30주
+
+	wallet := func(userID uint) models.UserWallet {
+		var w models.UserWallet
+		require.NoError(t, env.DB.Where("user_id = ?", userID).First(&w).Error)
+		return w
+	}
+	position := func(userID uint) models.Position {
+		var p models.Position
+		require.NoError(t, env.DB.Where("user_id = ? AND milestone_id = ?", userID, market.ID).First(&p).Error)
+		return p
+	}
+	request := func(quantity int64) (*models.PositionTransfer, error) {
+		return transfers.RequestTransfer(sender.ID, models.CreatePositionTransferRequest{
+			RecipientUsername: recipient.Username,
+			MilestoneID:       market.ID,
+			OptionID:          models.OptionSuccess,
+			Quantity:          quantity,
+		})
+	}
+
+	// 100주 중 미체결 매도 30주를 뺀 70주까지 이전 가능
+	_, err := request(71)
+	assert.ErrorIs(t, err, services.ErrTransferQuantityUnavailable)
+
+	gift, err := request(50)
+	require.NoError(t, err)
+	assert.Equal(t, int64(900), wallet(sender.ID).USDCBalance)
+	assert.Equal(t, int64(100), wallet(sender.ID).USDCLockedBalance)
+
+	// 대기 중인 이전도 이전 가능 수량에서 빠짐
+	_, err = request(21)
+	assert.ErrorIs(t, err, services.ErrTransferQuantityUnavailable)
+
+	_, err = transfers.AcceptTransfer(gift.ID, sender.ID)
+	assert.ErrorIs(t, err, services.ErrTransferNotFound)
+
+	accepted, err := transfers.AcceptTransfer(gift.ID, recipient.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PositionTransferAccepted, accepted.Status)
+	assert.Equal(t, int64(2000), accepted.CostBasis) // 4000 * 50/100
+
+	from := position(sender.ID)
+	assert.Equal(t, int64(50), from.Quantity)
+	assert.Equal(t, int64(2000), from.TotalCost)
+	assert.InDelta(t, 0.40, from.AvgPrice, 1e-9)
+
+	into := position(recipient.ID)
+	assert.Equal(t, int64(70), into.Quantity)
+	assert.Equal(t, int64(3400), into.TotalCost)
+	assert.InDelta(t, (0.70*20+0.40*50)/70, into.AvgPrice, 1e-9)
+
+	assert.Equal(t, int64(900), wallet(sender.ID).USDCBalance)
+	assert.Zero(t, wallet(sender.ID).USDCLockedBalance)
+
+	// 거절과 만료는 수수료 반환
+	declined, err := request(10)
+	require.NoError(t, err)
+	_, err = transfers.DeclineTransfer(declined.ID, recipient.ID)
+	require.NoError(t, err)
+	_, err = request(10)
+	require.NoError(t, err)
+	expired, err := transfers.ExpireTransfers(time.Now().Add(services.PositionTransferTTL + time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	assert.Equal(t, int64(900), wallet(sender.ID).USDCBalance)
+	assert.Zero(t, wallet(sender.ID).USDCLockedBalance)
+
+	var fees []models.WalletLedgerEntry
+	require.NoError(t, env.DB.Where("user_id = ? AND reference_type = ?", sender.ID, "position_transfer").Order("id").Find(&fees).Error)
+	require.Len(t, fees, 6)
+	assert.Equal(t, models.LedgerTransferFee, fees[1].EntryType)
+	assert.Equal(t, models.LedgerTransferFeeRelease, fees[5].EntryType)
+
+	// 받는 사람이 숏이면 평균가를 합칠 수 없음
+	short := env.Factory.User()
+	env.Factory.Position(short.ID, market, models.OptionSuccess, -10, 0.50)
+	_, err = transfers.RequestTransfer(sender.ID, models.CreatePositionTransferRequest{
+		RecipientUsername: short.Username, MilestoneID: market.ID, OptionID: models.OptionSuccess, Quantity: 5,
+	})
+	assert.ErrorIs(t, err, services.ErrTransferOppositePosition)
+}
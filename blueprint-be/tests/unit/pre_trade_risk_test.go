@@ -0,0 +1,85 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestPreTradeRiskLimits(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Order{}, &models.Position{}, &models.PositionSnapshot{},
+		&models.UserWallet{}, &models.MarketData{}))
+
+	require.NoError(t, db.Create(&[]models.Order{
+		{UserID: 1, MilestoneID: 3, OptionID: "success", Side: models.OrderSideBuy, Price: 0.50, Quantity: 100, Remaining: 100, Status: models.OrderStatusPending},
+		{UserID: 1, MilestoneID: 4, OptionID: "success", Side: models.OrderSideSell, Price: 0.20, Quantity: 50, Remaining: 50, Status: models.OrderStatusPending},
+		{UserID: 1, MilestoneID: 4, OptionID: "success", Side: models.OrderSideBuy, Price: 0.20, Quantity: 999, Remaining: 0, Status: models.OrderStatusFilled},
+	}).Error)
+	require.NoError(t, db.Create(&models.Position{UserID: 1, MilestoneID: 3, OptionID: "success", Quantity: 300, AvgPrice: 0.4}).Error)
+
+	risk := services.NewPreTradeRiskService(db, services.NewPortfolioSnapshotService(db), services.RiskLimits{
+		MaxOpenOrderNotional: 10000, // $100
+		MaxMarketPosition:    500,
+		MaxDailyLoss:         1000,
+	})
+
+	status, err := risk.GetStatus(1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000+1000), status.Exposure.OpenOrderNotional, "미체결 수량분만 합산")
+	require.NotNil(t, status.RemainingOpenOrderNotional)
+	assert.Equal(t, int64(4000), *status.RemainingOpenOrderNotional)
+
+	buy := func(milestoneID uint, quantity int64, price float64) models.CreateOrderRequest {
+		return models.CreateOrderRequest{MilestoneID: milestoneID, OptionID: "success", Side: models.OrderSideBuy, Type: models.OrderTypeLimit, Quantity: quantity, Price: price}
+	}
+
+	assert.NoError(t, risk.CheckOrder(1, buy(5, 40, 0.50)))
+	assert.ErrorIs(t, risk.CheckOrder(1, buy(5, 90, 0.50)), services.ErrRiskLimitExceeded, "미체결 금액 한도")
+
+	// 보유 300 + 미체결 매수 100 + 신규 → 500 초과 여부
+	assert.NoError(t, risk.CheckOrder(1, buy(3, 100, 0.10)))
+	assert.ErrorIs(t, risk.CheckOrder(1, buy(3, 101, 0.10)), services.ErrRiskLimitExceeded, "시장별 포지션 한도")
+
+	// 당일 실현 손실이 한도에 도달하면 신규 주문 거절
+	require.NoError(t, db.Model(&models.Position{}).Where("user_id = ?", 1).Update("realized", -1000).Error)
+	assert.ErrorIs(t, risk.CheckOrder(1, buy(5, 1, 0.10)), services.ErrRiskLimitExceeded, "당일 손실 한도")
+}
+
+// TestCreateOrderEnforcesPreTradeRisk 한도를 넘는 주문은 잔액 잠금과 매칭 엔진 제출 전에 거래 서비스에서 거절
+func TestCreateOrderEnforcesPreTradeRisk(t *testing.T) {
+	env := testkit.New(t)
+	engine := testkit.StartMatchingEngine(t, env.DB)
+	tradingService := services.NewTradingService(env.DB, nil, engine)
+	tradingService.SetPreTradeRisk(services.NewPreTradeRiskService(env.DB, services.NewPortfolioSnapshotService(env.DB), services.RiskLimits{
+		MaxOpenOrderNotional: 2000,
+	}))
+	milestone := env.Factory.Market()
+	user := env.Factory.FundedUser(10000)
+
+	buy := func(quantity int64) error {
+		_, err := tradingService.CreateOrder(user.ID, models.CreateOrderRequest{
+			ProjectID: milestone.ProjectID, MilestoneID: milestone.ID, OptionID: models.OptionSuccess,
+			Type: models.OrderTypeLimit, Side: models.OrderSideBuy, Quantity: quantity, Price: 0.5,
+		}, "", "")
+		return err
+	}
+
+	require.NoError(t, buy(30))
+	assert.ErrorIs(t, buy(20), services.ErrRiskLimitExceeded)
+
+	var orders int64
+	env.DB.Model(&models.Order{}).Where("user_id = ?", user.ID).Count(&orders)
+	assert.Equal(t, int64(1), orders)
+	var wallet models.UserWallet
+	require.NoError(t, env.DB.Where("user_id = ?", user.ID).First(&wallet).Error)
+	assert.Equal(t, models.BuyReserveCents(30, models.PriceToTicks(0.5)), wallet.USDCLockedBalance, "거절된 주문은 잠그지 않음")
+}
@@ -0,0 +1,64 @@
+package unit_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"blueprint-module/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceTicksAvoidFloatTruncation(t *testing.T) {
+	// int64(float64(1) * 0.29 * 100) == 28 이던 문제
+	assert.Equal(t, int64(2900), models.PriceToTicks(0.29))
+	assert.Equal(t, int64(29), models.NotionalCents(1, models.PriceToTicks(0.29)))
+	assert.Equal(t, int64(5700), models.NotionalCents(100, models.PriceToTicks(0.57)))
+
+	assert.NoError(t, models.ValidatePriceTicks(4500, models.DefaultPriceTickSize))
+	assert.ErrorIs(t, models.ValidatePriceTicks(4550, models.DefaultPriceTickSize), models.ErrPriceOffTick)
+	assert.NoError(t, models.ValidatePriceTicks(4550, 10))
+	assert.ErrorIs(t, models.ValidatePriceTicks(0, 10), models.ErrPriceOutOfRange)
+	assert.ErrorIs(t, models.ValidatePriceTicks(models.PriceScale, 10), models.ErrPriceOutOfRange)
+}
+
+func TestTradeSettlementConservesValue(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	tickSizes := []int64{10, 50, 100, 500}
+
+	for round := 0; round < 500; round++ {
+		tickSize := tickSizes[rng.Intn(len(tickSizes))]
+		steps := models.PriceScale/tickSize - 1
+		limit := (rng.Int63n(steps) + 1) * tickSize
+		quantity := rng.Int63n(1000) + 1
+
+		buyer := struct{ balance, locked int64 }{locked: models.BuyReserveCents(quantity, limit)}
+		var seller, fees int64
+		before := buyer.balance + buyer.locked + seller + fees
+
+		// 지정가 이하의 여러 가격으로 나눠 체결
+		order := models.Order{Side: models.OrderSideBuy, Quantity: quantity, PriceTicks: limit}
+		for order.Filled < quantity {
+			fill := rng.Int63n(quantity-order.Filled) + 1
+			price := (rng.Int63n(limit/tickSize) + 1) * tickSize
+
+			s := models.SettleFill(fill, price, limit, order.Filled, 25)
+			require.GreaterOrEqual(t, s.Notional, int64(0))
+
+			buyer.locked -= s.BuyerRelease
+			buyer.balance += s.BuyerRelease - s.Notional - s.BuyerFee
+			seller += s.Notional - s.SellerFee
+			fees += s.BuyerFee + s.SellerFee
+			order.Filled += fill
+
+			// 남은 잠금액은 항상 미체결 수량분 잠금액과 같아야 하고, 체결 금액과 수수료는 잠금에서 나가므로
+			// 사용 가능 잔액이 0에서 시작해도 음수가 되지 않음
+			require.Equal(t, order.LockedCents(), buyer.locked)
+			require.GreaterOrEqual(t, buyer.balance, int64(0))
+		}
+
+		assert.Zero(t, buyer.locked, "전량 체결 후 잠금액이 남거나 모자라면 안 됨")
+		assert.Equal(t, before, buyer.balance+buyer.locked+seller+fees, "정산 전후 총액 불변")
+	}
+}
@@ -0,0 +1,77 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestProjectMemberInviteFlow 초대 수락 후 역할별 권한 (editor 수정 가능, viewer는 열람만, 비팀원은 비공개 프로젝트를 볼 수 없음)
+func TestProjectMemberInviteFlow(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&models.User{}, &models.Project{}, &models.ProjectMember{}, &models.ProjectInvite{}, &models.Notification{},
+	))
+	service := services.NewProjectMemberService(db)
+
+	owner := models.User{Email: "owner@test.com", Username: "owner", IsActive: true}
+	editor := models.User{Email: "editor@test.com", Username: "editor", IsActive: true}
+	viewer := models.User{Email: "viewer@test.com", Username: "viewer", IsActive: true}
+	outsider := models.User{Email: "outsider@test.com", Username: "outsider", IsActive: true}
+	for _, user := range []*models.User{&owner, &editor, &viewer, &outsider} {
+		require.NoError(t, db.Create(user).Error)
+	}
+	project := models.Project{UserID: owner.ID, Title: "team project", Category: models.BusinessProject, Status: models.ProjectActive}
+	require.NoError(t, db.Create(&project).Error)
+
+	_, err = service.Invite(project.ID, owner.ID, "editor@test.com", models.ProjectRoleOwner)
+	assert.ErrorIs(t, err, services.ErrInvalidProjectRole)
+	_, err = service.Invite(project.ID, outsider.ID, "outsider@test.com", models.ProjectRoleEditor)
+	assert.ErrorIs(t, err, services.ErrProjectNotFound, "비공개 프로젝트는 비팀원에게 존재를 노출하지 않음")
+
+	editorInvite, err := service.Invite(project.ID, owner.ID, "Editor@Test.com", models.ProjectRoleEditor)
+	require.NoError(t, err)
+	viewerInvite, err := service.Invite(project.ID, owner.ID, "viewer@test.com", models.ProjectRoleViewer)
+	require.NoError(t, err)
+
+	_, err = service.AcceptInvite(editorInvite.ID, outsider.ID)
+	assert.ErrorIs(t, err, services.ErrProjectInviteNotFound, "다른 이메일의 초대는 수락 불가")
+	_, err = service.AcceptInvite(editorInvite.ID, editor.ID)
+	require.NoError(t, err)
+	_, err = service.AcceptInvite(viewerInvite.ID, viewer.ID)
+	require.NoError(t, err)
+
+	_, err = service.Authorize(project.ID, editor.ID, models.ProjectRole.CanEdit)
+	assert.NoError(t, err)
+	_, err = service.Authorize(project.ID, viewer.ID, models.ProjectRole.CanEdit)
+	assert.ErrorIs(t, err, services.ErrProjectForbidden)
+	_, err = service.Authorize(project.ID, editor.ID, models.ProjectRole.CanManage)
+	assert.ErrorIs(t, err, services.ErrProjectForbidden)
+	_, err = service.Authorize(project.ID, outsider.ID, models.ProjectRole.CanEdit)
+	assert.ErrorIs(t, err, services.ErrProjectNotFound)
+
+	canView, err := service.CanView(&project, viewer.ID)
+	require.NoError(t, err)
+	assert.True(t, canView)
+	canView, err = service.CanView(&project, outsider.ID)
+	require.NoError(t, err)
+	assert.False(t, canView)
+
+	members, err := service.ListMembers(project.ID, viewer.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 3)
+	assert.Equal(t, models.ProjectRoleOwner, members[0].Role)
+
+	// viewer는 스스로 탈퇴할 수 있지만 다른 팀원을 제거할 수는 없음
+	assert.ErrorIs(t, service.RemoveMember(project.ID, viewer.ID, editor.ID), services.ErrProjectForbidden)
+	require.NoError(t, service.RemoveMember(project.ID, viewer.ID, viewer.ID))
+	_, err = service.Authorize(project.ID, viewer.ID, func(models.ProjectRole) bool { return true })
+	assert.ErrorIs(t, err, services.ErrProjectNotFound)
+}
@@ -0,0 +1,159 @@
+package unit_test
+
+import (
+	"fmt"
+	"testing"
+
+	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
+	"blueprint/internal/services"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newPublicProjectDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&models.User{}, &models.UserProfile{}, &models.Project{}, &models.Milestone{},
+		&models.MarketData{}, &models.Position{}, &models.ProjectTag{},
+	))
+	return db
+}
+
+// TestPublicProjectHonorsPrivacy 공개 프로젝트만 노출하고 작성자 공개 설정(프로필/투자 내역)을 반영
+func TestPublicProjectHonorsPrivacy(t *testing.T) {
+	db := newPublicProjectDB(t)
+	service := services.NewPublicProjectService(db)
+
+	creator := models.User{Email: "creator@test.com", Username: "creator", IsActive: true, TrustScore: 72}
+	require.NoError(t, db.Create(&creator).Error)
+	require.NoError(t, db.Create(&models.UserProfile{UserID: creator.ID, DisplayName: "Creator"}).Error)
+	require.NoError(t, db.Model(&models.UserProfile{}).Where("user_id = ?", creator.ID).
+		Updates(map[string]interface{}{"profile_public": false, "investment_public": true}).Error)
+
+	public := models.Project{UserID: creator.ID, Title: "public project", Category: models.BusinessProject, Status: models.ProjectActive, IsPublic: true}
+	private := models.Project{UserID: creator.ID, Title: "private project", Category: models.BusinessProject, Status: models.ProjectActive}
+	require.NoError(t, db.Create(&public).Error)
+	require.NoError(t, db.Create(&private).Error)
+
+	milestone := models.Milestone{ProjectID: public.ID, Title: "launch", Order: 1, Status: models.MilestoneStatusFunding, CurrentTVL: 25000}
+	require.NoError(t, db.Create(&milestone).Error)
+	require.NoError(t, db.Create(&models.MarketData{MilestoneID: milestone.ID, OptionID: "success", CurrentPrice: 0.64, Volume24h: 900}).Error)
+	require.NoError(t, db.Create(&models.Position{UserID: creator.ID, MilestoneID: milestone.ID, OptionID: "success", Quantity: 40}).Error)
+
+	page, err := service.GetProject(public.ID)
+	require.NoError(t, err)
+	assert.Nil(t, page.Creator, "비공개 프로필은 작성자 정보를 숨김")
+	assert.Equal(t, 72, page.CreatorTrustScore)
+	assert.Equal(t, int64(25000), page.TotalTVL)
+	require.Len(t, page.Milestones, 1)
+	assert.InDelta(t, 0.64, page.Milestones[0].Prices["success"], 0.0001)
+	assert.Equal(t, int64(40), page.Milestones[0].CreatorHoldings["success"])
+
+	_, err = service.GetProject(private.ID)
+	assert.ErrorIs(t, err, services.ErrPublicProjectNotFound)
+
+	projects, total, err := service.ListProjects(services.PublicProjectFilter{Sort: services.PublicProjectSortTVL, Limit: 20})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, projects, 1)
+	assert.Equal(t, public.ID, projects[0].ID)
+	assert.Nil(t, projects[0].Milestones)
+
+	_, _, err = service.ListProjects(services.PublicProjectFilter{Sort: "random", Limit: 20})
+	assert.ErrorIs(t, err, services.ErrInvalidPublicSort)
+}
+
+// TestPublicProjectCacheInvalidation 캐시된 페이지는 프로젝트 수정 후 무효화되면 비공개 전환을 반영
+func TestPublicProjectCacheInvalidation(t *testing.T) {
+	db := newPublicProjectDB(t)
+	service := services.NewPublicProjectService(db)
+
+	redisServer := miniredis.RunT(t)
+	moduleRedis.Client = redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+	defer func() { moduleRedis.Client = nil }()
+
+	creator := models.User{Email: "creator@test.com", Username: "creator", IsActive: true}
+	require.NoError(t, db.Create(&creator).Error)
+	project := models.Project{UserID: creator.ID, Title: "public project", Category: models.CareerProject, Status: models.ProjectActive, IsPublic: true}
+	require.NoError(t, db.Create(&project).Error)
+
+	page, err := service.GetProject(project.ID)
+	require.NoError(t, err)
+	require.NotNil(t, page.Creator, "프로필이 없으면 기본값(공개)")
+	assert.Equal(t, "creator", page.Creator.Username)
+
+	require.NoError(t, db.Model(&project).Update("is_public", false).Error)
+	_, err = service.GetProject(project.ID)
+	assert.NoError(t, err, "TTL 동안은 캐시 응답")
+
+	services.InvalidatePublicProject(project.ID)
+	_, err = service.GetProject(project.ID)
+	assert.ErrorIs(t, err, services.ErrPublicProjectNotFound)
+}
+
+// TestNormalizeProjectTags 태그는 소문자/하이픈으로 정규화하고 중복 제거, 허용되지 않는 문자와 개수 초과는 거부
+func TestNormalizeProjectTags(t *testing.T) {
+	tags, err := models.NormalizeProjectTags([]string{"#Side Project", "side-project", " AI ", "", "한국어"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"side-project", "ai", "한국어"}, tags)
+
+	_, err = models.NormalizeProjectTags([]string{"drop table;"})
+	assert.ErrorIs(t, err, models.ErrInvalidProjectTag)
+
+	tooMany := make([]string, models.MaxProjectTags+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("tag%d", i)
+	}
+	_, err = models.NormalizeProjectTags(tooMany)
+	assert.ErrorIs(t, err, models.ErrInvalidProjectTag)
+}
+
+// TestPublicProjectTaxonomyFilters 태그/진행 단계/펀딩 현황으로 공개 프로젝트 탐색 및 인기 태그 집계
+func TestPublicProjectTaxonomyFilters(t *testing.T) {
+	db := newPublicProjectDB(t)
+	service := services.NewPublicProjectService(db)
+
+	creator := models.User{Email: "creator@test.com", Username: "creator", IsActive: true}
+	require.NoError(t, db.Create(&creator).Error)
+
+	funded := models.Project{UserID: creator.ID, Title: "funded app", Category: models.BusinessProject, Status: models.ProjectActive, IsPublic: true}
+	funding := models.Project{UserID: creator.ID, Title: "funding course", Category: models.EducationProject, Status: models.ProjectActive, IsPublic: true}
+	require.NoError(t, db.Create(&funded).Error)
+	require.NoError(t, db.Create(&funding).Error)
+	require.NoError(t, services.SyncProjectTags(db, funded.ID, []string{"ai", "saas"}))
+	require.NoError(t, services.SyncProjectTags(db, funding.ID, []string{"ai"}))
+
+	require.NoError(t, db.Create(&models.Milestone{ProjectID: funded.ID, Title: "beta", Order: 1, Status: models.MilestoneStatusActive, CurrentTVL: 200000, MinViableCapital: 100000}).Error)
+	require.NoError(t, db.Create(&models.Milestone{ProjectID: funding.ID, Title: "syllabus", Order: 1, Status: models.MilestoneStatusFunding, CurrentTVL: 5000, MinViableCapital: 100000}).Error)
+
+	list := func(filter services.ProjectTaxonomyFilter) []uint {
+		projects, _, err := service.ListProjects(services.PublicProjectFilter{ProjectTaxonomyFilter: filter, Limit: 20})
+		require.NoError(t, err)
+		ids := []uint{}
+		for _, project := range projects {
+			ids = append(ids, project.ID)
+		}
+		return ids
+	}
+
+	assert.ElementsMatch(t, []uint{funded.ID, funding.ID}, list(services.ProjectTaxonomyFilter{Tag: "#AI"}))
+	assert.Equal(t, []uint{funded.ID}, list(services.ProjectTaxonomyFilter{Tag: "saas"}))
+	assert.Equal(t, []uint{funding.ID}, list(services.ProjectTaxonomyFilter{Stage: services.ProjectStageFunding}))
+	assert.Equal(t, []uint{funded.ID}, list(services.ProjectTaxonomyFilter{Funding: services.FundingStatusFunded}))
+	assert.Equal(t, []uint{funding.ID}, list(services.ProjectTaxonomyFilter{Funding: services.FundingStatusUnderfunded, Category: models.EducationProject}))
+
+	_, _, err := service.ListProjects(services.PublicProjectFilter{ProjectTaxonomyFilter: services.ProjectTaxonomyFilter{Stage: "someday"}, Limit: 20})
+	assert.ErrorIs(t, err, services.ErrInvalidProjectStage)
+
+	tags, err := service.PopularTags("", 10)
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+	assert.Equal(t, services.TagCount{Tag: "ai", Count: 2}, tags[0])
+}
@@ -0,0 +1,70 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestPriceCandleProjection 체결이 간격별 캔들에 반영되고, 체결 내역 재집계 결과도 같다
+func TestPriceCandleProjection(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Trade{}, &models.PriceCandle{}))
+
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	trade := func(minute int, ticks, quantity int64) models.Trade {
+		return models.Trade{
+			MilestoneID: 1, OptionID: "success", Quantity: quantity, PriceTicks: ticks,
+			Price: models.TicksToPrice(ticks), TotalAmount: models.NotionalCents(quantity, ticks),
+			CreatedAt: base.Add(time.Duration(minute) * time.Minute),
+		}
+	}
+	trades := []models.Trade{trade(0, 6000, 10), trade(2, 6500, 5), trade(3, 5500, 20), trade(7, 5800, 10)}
+	require.NoError(t, db.Create(&trades).Error)
+
+	projector := services.NewPriceCandleProjector(db)
+	require.NoError(t, projector.ApplyTrades(trades[:2]))
+	require.NoError(t, projector.ApplyTrades(trades[2:]))
+
+	queries := services.NewTradingQueryHandler(nil, db)
+	candles, err := queries.GetPriceCandles(&services.PriceCandlesQuery{MilestoneID: 1, OptionID: "success", Interval: models.CandleInterval5m})
+	require.NoError(t, err)
+	require.Len(t, candles, 2)
+	assert.Equal(t, base.Format(time.RFC3339), candles[0].Bucket, "오래된 캔들부터")
+	assert.Equal(t, 0.6, candles[0].Open)
+	assert.Equal(t, 0.65, candles[0].High)
+	assert.Equal(t, 0.55, candles[0].Low)
+	assert.Equal(t, 0.55, candles[0].Close)
+	assert.Equal(t, 3, candles[0].Trades)
+	assert.Equal(t, trades[0].TotalAmount+trades[1].TotalAmount+trades[2].TotalAmount, candles[0].Volume)
+	assert.Equal(t, 0.58, candles[1].Close)
+
+	hourly, err := queries.GetPriceCandles(&services.PriceCandlesQuery{MilestoneID: 1, OptionID: "success", Interval: models.CandleInterval1h})
+	require.NoError(t, err)
+	require.Len(t, hourly, 1)
+	assert.Equal(t, 4, hourly[0].Trades)
+
+	_, err = queries.GetPriceCandles(&services.PriceCandlesQuery{MilestoneID: 1, OptionID: "success", Interval: "2h"})
+	assert.ErrorIs(t, err, services.ErrInvalidCandleInterval)
+
+	// 재집계는 같은 캔들을 만든다
+	applied, err := projector.RebuildCandles(1, "success")
+	require.NoError(t, err)
+	assert.Equal(t, 4, applied)
+	rebuilt, err := queries.GetPriceCandles(&services.PriceCandlesQuery{MilestoneID: 1, OptionID: "success", Interval: models.CandleInterval5m})
+	require.NoError(t, err)
+	assert.Equal(t, candles, rebuilt)
+
+	recent, err := queries.GetRecentTrades(&services.RecentTradesQuery{MilestoneID: 1, OptionID: "success", Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, recent, 2)
+	assert.Equal(t, int64(5800), recent[0].PriceTicks, "최신순")
+}
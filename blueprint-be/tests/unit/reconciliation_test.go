@@ -0,0 +1,104 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReconciliationReportsAndCorrectsDrift 종료된 주문이 주문장에 남거나 잠금액이 남으면 보고하고, 자동 보정은 원인이 분명한 것만
+func TestReconciliationReportsAndCorrectsDrift(t *testing.T) {
+	env := testkit.New(t)
+	engine := testkit.StartMatchingEngine(t, env.DB)
+	reconciler := services.NewReconciliationService(env.DB, engine)
+	milestone := env.Factory.Market()
+
+	// 정상 체결 1건 (양쪽 지갑 통계가 체결 내역과 일치)
+	seller := env.Factory.FundedUser(0)
+	env.Factory.Position(seller.ID, milestone, models.OptionSuccess, 100, 0.5)
+	buyer := env.Factory.User()
+	resting := env.Factory.Order(buyer.ID, milestone, models.OrderSideBuy, 0.4, 100)
+	traded := models.BuyReserveCents(100, models.PriceToTicks(0.5))
+	surplus := int64(500)
+	wallet := env.Factory.Wallet(buyer.ID, 100000, func(w *models.UserWallet) {
+		w.USDCLockedBalance = traded + resting.LockedCents() + surplus
+	})
+
+	_, err := engine.SubmitOrder(env.Factory.Order(seller.ID, milestone, models.OrderSideSell, 0.5, 100))
+	require.NoError(t, err)
+	result, err := engine.SubmitOrder(env.Factory.Order(buyer.ID, milestone, models.OrderSideBuy, 0.5, 100))
+	require.NoError(t, err)
+	require.Len(t, result.Trades, 1)
+	_, err = engine.SubmitOrder(resting)
+	require.NoError(t, err)
+
+	// 한 시간 전 취소가 DB에만 반영되고 주문장에는 남은 매도 주문
+	stale := env.Factory.Order(seller.ID, milestone, models.OrderSideSell, 0.7, 10)
+	_, err = engine.SubmitOrder(stale)
+	require.NoError(t, err)
+	testkit.Settle(t, engine)
+	require.NoError(t, env.DB.Model(stale).UpdateColumns(map[string]interface{}{
+		"status":     models.OrderStatusCancelled,
+		"updated_at": time.Now().Add(-time.Hour),
+	}).Error)
+
+	// 판매자 지갑 통계 후처리 유실
+	require.NoError(t, env.DB.Model(&models.UserWallet{}).Where("user_id = ?", seller.ID).
+		UpdateColumn("total_trades", 0).Error)
+
+	byCheck := func(report *models.ReconciliationReport) map[models.ReconciliationCheck]models.ReconciliationDiscrepancy {
+		found := make(map[models.ReconciliationCheck]models.ReconciliationDiscrepancy)
+		for _, discrepancy := range report.Discrepancies {
+			found[discrepancy.Check] = discrepancy
+		}
+		return found
+	}
+
+	// 보고만
+	report, err := reconciler.Run(false)
+	require.NoError(t, err)
+	found := byCheck(report)
+	require.Len(t, report.Discrepancies, 3, "%+v", report.Discrepancies)
+	assert.Equal(t, stale.ID, found[models.ReconcileBookOrderClosed].OrderID)
+	assert.Equal(t, seller.ID, found[models.ReconcileWalletTrades].UserID)
+	assert.Equal(t, int64(1), found[models.ReconcileWalletTrades].Expected)
+	assert.Equal(t, resting.LockedCents(), found[models.ReconcileLockedBalance].Expected)
+	assert.Equal(t, resting.LockedCents()+surplus, found[models.ReconcileLockedBalance].Actual)
+	assert.Zero(t, report.CorrectedCount)
+	assert.NotEmpty(t, engine.GetOrderBook(milestone.ID, models.OptionSuccess, 10, 0).Asks)
+
+	// 자동 보정: 주문장 정리와 초과 잠금 해제, 지갑 통계는 보고만
+	report, err = reconciler.Run(true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.CorrectedCount)
+	assert.False(t, byCheck(report)[models.ReconcileWalletTrades].Corrected)
+	assert.Empty(t, engine.GetOrderBook(milestone.ID, models.OptionSuccess, 10, 0).Asks)
+
+	var updated models.UserWallet
+	require.NoError(t, env.DB.Where("user_id = ?", buyer.ID).First(&updated).Error)
+	assert.Equal(t, resting.LockedCents(), updated.USDCLockedBalance)
+	var entry models.WalletLedgerEntry
+	require.NoError(t, env.DB.Where("user_id = ? AND entry_type = ?", buyer.ID, models.LedgerReconcileAdjustment).First(&entry).Error)
+	assert.Equal(t, surplus, entry.Amount)
+	assert.Equal(t, report.ID, entry.ReferenceID)
+	assert.Equal(t, wallet.USDCBalance+traded-result.Trades[0].TotalAmount-result.Trades[0].BuyerFee+surplus, updated.USDCBalance,
+		"체결 대금과 수수료는 잠금액에서 지급")
+
+	report, err = reconciler.Run(true)
+	require.NoError(t, err)
+	require.Len(t, report.Discrepancies, 1)
+	assert.Equal(t, models.ReconcileWalletTrades, report.Discrepancies[0].Check)
+
+	stored, err := reconciler.GetReport(report.ID)
+	require.NoError(t, err)
+	assert.Len(t, stored.Discrepancies, 1)
+	reports, err := reconciler.ListReports(10)
+	require.NoError(t, err)
+	assert.Len(t, reports, 3)
+}
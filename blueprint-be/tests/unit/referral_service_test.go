@@ -0,0 +1,105 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// ReferralTestSuite 추천 프로그램 테스트 슈트
+type ReferralTestSuite struct {
+	suite.Suite
+	db              *gorm.DB
+	referralService *services.ReferralService
+}
+
+func (suite *ReferralTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(
+		&models.User{},
+		&models.UserWallet{},
+		&models.ReferralCode{},
+		&models.Referral{},
+		&models.ReferralReward{},
+		&models.ReferralPayout{},
+	))
+	suite.db = db
+
+	db.Create(&models.User{ID: 1, Email: "referrer@test.com", Username: "referrer"})
+	db.Create(&models.User{ID: 2, Email: "referee@test.com", Username: "referee"})
+	db.Create(&models.UserWallet{UserID: 1, USDCBalance: 0})
+
+	suite.referralService = services.NewReferralService(db)
+}
+
+// TestAttributionRules 코드 정규화, 본인 추천 및 중복 귀속 방지
+func (suite *ReferralTestSuite) TestAttributionRules() {
+	code, err := suite.referralService.GetOrCreateCode(1)
+	suite.Require().NoError(err)
+	suite.Len(code.Code, 8)
+
+	again, err := suite.referralService.GetOrCreateCode(1)
+	suite.Require().NoError(err)
+	suite.Equal(code.Code, again.Code)
+
+	_, err = suite.referralService.AttributeSignup(1, code.Code)
+	suite.ErrorIs(err, services.ErrSelfReferral)
+
+	_, err = suite.referralService.AttributeSignup(2, "NOPE1234")
+	suite.ErrorIs(err, services.ErrReferralCodeInvalid)
+
+	referral, err := suite.referralService.AttributeSignup(2, " "+code.Code+" ")
+	suite.Require().NoError(err)
+	suite.Equal(uint(1), referral.ReferrerID)
+
+	_, err = suite.referralService.AttributeSignup(2, code.Code)
+	suite.ErrorIs(err, services.ErrAlreadyReferred)
+}
+
+// TestAccrualAndPayout 수수료 적립(중복 방지, 기간 제한) 후 지갑 지급
+func (suite *ReferralTestSuite) TestAccrualAndPayout() {
+	code, err := suite.referralService.GetOrCreateCode(1)
+	suite.Require().NoError(err)
+	_, err = suite.referralService.AttributeSignup(2, code.Code)
+	suite.Require().NoError(err)
+
+	now := time.Now()
+	trades := []models.Trade{
+		{ID: 10, BuyerID: 2, SellerID: 3, BuyerFee: 500, SellerFee: 500, CreatedAt: now},
+		{ID: 11, BuyerID: 3, SellerID: 2, BuyerFee: 300, SellerFee: 300, CreatedAt: now},
+		{ID: 12, BuyerID: 2, SellerID: 3, BuyerFee: 900, CreatedAt: now.Add(models.ReferralRewardWindow + time.Hour)},
+	}
+	suite.referralService.AccrueTradeFees(trades)
+	suite.referralService.AccrueTradeFees(trades) // 재처리되어도 한 번만 적립
+
+	stats, err := suite.referralService.GetStats(1)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), stats.TotalReferees)
+	suite.Equal(int64(160), stats.PendingAmount) // (500 + 300) * 20%
+
+	payout, err := suite.referralService.Payout(1)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(payout)
+	suite.Equal(int64(160), payout.Amount)
+	suite.Equal(2, payout.RewardCount)
+
+	var wallet models.UserWallet
+	suite.db.Where("user_id = ?", 1).First(&wallet)
+	suite.Equal(int64(160), wallet.USDCBalance)
+
+	stats, err = suite.referralService.GetStats(1)
+	suite.Require().NoError(err)
+	suite.Equal(int64(0), stats.PendingAmount)
+	suite.Equal(int64(160), stats.PaidAmount)
+}
+
+func TestReferralTestSuite(t *testing.T) {
+	suite.Run(t, new(ReferralTestSuite))
+}
@@ -0,0 +1,92 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestResponsibleTradingLimits 일일 한도는 낮추면 즉시, 올리면 유예 후 적용되고 휴식/자기 배제 중에는 주문과 후원이 막히며 기간을 줄일 수 없음
+func TestResponsibleTradingLimits(t *testing.T) {
+	env := testkit.New(t)
+	responsible := services.NewResponsibleTradingService(env.DB, services.ResponsibleTradingDefaults{
+		DailyOrderNotional: 10000,
+		IncreaseDelay:      24 * time.Hour,
+	})
+	escrowService := services.NewFundingEscrowService(env.DB, services.NewFundingVerificationService(env.DB, nil))
+	escrowService.SetResponsibleTrading(responsible)
+
+	user := env.Factory.FundedUser(100000)
+	market := env.Factory.Market()
+	limit := func(v int64) *int64 { return &v }
+
+	// 오늘 접수한 $30 주문(취소 포함) + 어제 주문은 제외
+	now := time.Now()
+	env.Factory.Order(user.ID, market, models.OrderSideBuy, 0.50, 40, func(o *models.Order) { o.CreatedAt = now })
+	env.Factory.Order(user.ID, market, models.OrderSideBuy, 0.50, 20, func(o *models.Order) {
+		o.Status = models.OrderStatusCancelled
+		o.CreatedAt = now
+	})
+	env.Factory.Order(user.ID, market, models.OrderSideBuy, 0.50, 1000, func(o *models.Order) { o.CreatedAt = now.AddDate(0, 0, -1) })
+
+	status, err := responsible.GetStatus(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10000), status.DailyOrderNotionalLimit) // 플랫폼 기본값
+	assert.Equal(t, int64(3000), status.OrderNotionalToday)
+	assert.Equal(t, int64(7000), *status.RemainingOrderNotional)
+	assert.Nil(t, status.RemainingDeposit)
+	assert.True(t, status.TradingAllowed)
+
+	require.NoError(t, responsible.CheckOrder(user.ID, 7000))
+	assert.ErrorIs(t, responsible.CheckOrder(user.ID, 7001), services.ErrDailyLimitExceeded)
+
+	// 낮추면 즉시 적용, 올리면 유예 후 적용
+	status, err = responsible.UpdateLimits(user.ID, models.UpdateTradingLimitsRequest{DailyOrderNotionalLimit: limit(5000), DailyDepositLimit: limit(2000)})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), status.DailyOrderNotionalLimit)
+	assert.Nil(t, status.Settings.PendingEffectiveAt)
+	assert.ErrorIs(t, responsible.CheckOrder(user.ID, 2001), services.ErrDailyLimitExceeded)
+
+	status, err = responsible.UpdateLimits(user.ID, models.UpdateTradingLimitsRequest{DailyOrderNotionalLimit: limit(50000)})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), status.DailyOrderNotionalLimit)
+	require.NotNil(t, status.Settings.PendingDailyOrderNotionalLimit)
+	assert.Equal(t, int64(50000), *status.Settings.PendingDailyOrderNotionalLimit)
+
+	require.NoError(t, env.DB.Model(&models.UserTradingLimit{}).Where("user_id = ?", user.ID).
+		Update("pending_effective_at", now.Add(-time.Minute)).Error)
+	status, err = responsible.GetStatus(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(50000), status.DailyOrderNotionalLimit)
+	assert.Nil(t, status.Settings.PendingDailyOrderNotionalLimit)
+
+	// 후원 예치 한도
+	owner := env.Factory.User()
+	funding := env.Factory.Milestone(env.Factory.Project(owner.ID).ID, func(m *models.Milestone) { m.Status = models.MilestoneStatusFunding })
+	_, err = escrowService.Deposit(user.ID, funding.ID, 1500)
+	require.NoError(t, err)
+	_, err = escrowService.Deposit(user.ID, funding.ID, 600)
+	assert.ErrorIs(t, err, services.ErrDailyLimitExceeded)
+
+	// 휴식 중에는 주문/후원 거부, 더 짧은 기간으로 바꿀 수 없음
+	status, err = responsible.StartCoolingOff(user.ID, 48)
+	require.NoError(t, err)
+	assert.False(t, status.TradingAllowed)
+	require.NotNil(t, status.RestrictedUntil)
+	assert.ErrorIs(t, responsible.CheckOrder(user.ID, 100), services.ErrTradingRestricted)
+	_, err = escrowService.Deposit(user.ID, funding.ID, 100)
+	assert.ErrorIs(t, err, services.ErrTradingRestricted)
+
+	_, err = responsible.StartCoolingOff(user.ID, 1)
+	assert.ErrorIs(t, err, services.ErrRestrictionShortened)
+
+	status, err = responsible.SelfExclude(user.ID, 30)
+	require.NoError(t, err)
+	assert.WithinDuration(t, now.AddDate(0, 0, 30), *status.RestrictedUntil, time.Minute)
+}
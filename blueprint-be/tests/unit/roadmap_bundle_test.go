@@ -0,0 +1,135 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// RoadmapBundleTestSuite 프로젝트 로드맵 묶음 테스트 슈트
+type RoadmapBundleTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	service *services.ParlayService
+}
+
+func (suite *RoadmapBundleTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(
+		&models.User{},
+		&models.Project{},
+		&models.Milestone{},
+		&models.MarketData{},
+		&models.UserWallet{},
+		&models.WalletLedgerEntry{},
+		&models.Parlay{},
+		&models.ParlayLeg{},
+		&models.Notification{},
+	))
+	suite.db = db
+	suite.service = services.NewParlayService(db)
+	suite.service.SetHouseAccount(1)
+}
+
+func (suite *RoadmapBundleTestSuite) createMilestone(order int, status models.MilestoneStatus, successPrice float64) *models.Milestone {
+	milestone := &models.Milestone{ProjectID: 1, Title: "마일스톤", Order: order, Status: status}
+	suite.Require().NoError(suite.db.Create(milestone).Error)
+	if successPrice > 0 {
+		suite.Require().NoError(suite.db.Create(&models.MarketData{
+			MilestoneID:  milestone.ID,
+			OptionID:     models.OptionSuccess,
+			CurrentPrice: successPrice,
+		}).Error)
+	}
+	return milestone
+}
+
+// TestBundleCoversRemainingMilestones 완료/취소 마일스톤은 제외하고 남은 마일스톤 성공만 묶음
+func (suite *RoadmapBundleTestSuite) TestBundleCoversRemainingMilestones() {
+	suite.createMilestone(1, models.MilestoneStatusCompleted, 0)
+	second := suite.createMilestone(2, models.MilestoneStatusActive, 0.6)
+	suite.createMilestone(3, models.MilestoneStatusCancelled, 0)
+	fourth := suite.createMilestone(4, models.MilestoneStatusFunding, 0.4)
+
+	quote, err := suite.service.QuoteRoadmap(1, 1000)
+	suite.Require().NoError(err)
+	suite.Require().Len(quote.Legs, 2)
+	suite.Equal(second.ID, quote.Legs[0].MilestoneID)
+	suite.Equal(fourth.ID, quote.Legs[1].MilestoneID)
+	suite.Equal(models.OptionSuccess, quote.Legs[1].OptionID)
+	suite.InDelta(0.4, quote.FairProbability, 1e-9, "같은 프로젝트는 완전 상관 (최소 확률)")
+	suite.Equal(1, quote.CorrelatedGroups)
+
+	suite.Require().NoError(suite.db.Create(&models.UserWallet{UserID: 7, USDCBalance: 5000}).Error)
+	parlay, err := suite.service.PlaceRoadmapBundle(7, 1, &models.CreateRoadmapBundleRequest{Stake: 1000})
+	suite.Require().NoError(err)
+	suite.Equal(models.ParlayKindRoadmap, parlay.Kind)
+	suite.Require().NotNil(parlay.ProjectID)
+	suite.Equal(uint(1), *parlay.ProjectID)
+	suite.Len(parlay.Legs, 2)
+}
+
+// TestBundleRejectsFailedOrUntradableRoadmap 실패/거래 전 마일스톤이 있거나 남은 마일스톤이 1개면 거부
+func (suite *RoadmapBundleTestSuite) TestBundleRejectsFailedOrUntradableRoadmap() {
+	suite.createMilestone(1, models.MilestoneStatusActive, 0.5)
+	_, err := suite.service.QuoteRoadmap(1, 0)
+	suite.ErrorIs(err, services.ErrRoadmapNotBundleable, "남은 마일스톤 1개")
+
+	proposal := suite.createMilestone(2, models.MilestoneStatusProposal, 0)
+	_, err = suite.service.QuoteRoadmap(1, 0)
+	suite.ErrorIs(err, services.ErrRoadmapNotBundleable, "거래 전 마일스톤")
+
+	suite.db.Model(proposal).Update("status", models.MilestoneStatusFailed)
+	_, err = suite.service.QuoteRoadmap(1, 0)
+	suite.ErrorIs(err, services.ErrRoadmapNotBundleable, "이미 실패한 로드맵")
+}
+
+// TestBundleRejectsRoadmapOverLegLimit 남은 마일스톤이 최대 레그 수(5)를 넘으면 묶음 거부, 완료된 마일스톤은 세지 않음
+func (suite *RoadmapBundleTestSuite) TestBundleRejectsRoadmapOverLegLimit() {
+	first := suite.createMilestone(1, models.MilestoneStatusActive, 0.9)
+	for order := 2; order <= 6; order++ {
+		suite.createMilestone(order, models.MilestoneStatusActive, 0.9)
+	}
+
+	_, err := suite.service.QuoteRoadmap(1, 1000)
+	suite.ErrorIs(err, services.ErrRoadmapNotBundleable, "남은 마일스톤 6개")
+
+	suite.Require().NoError(suite.db.Create(&models.UserWallet{UserID: 7, USDCBalance: 5000}).Error)
+	_, err = suite.service.PlaceRoadmapBundle(7, 1, &models.CreateRoadmapBundleRequest{Stake: 1000})
+	suite.ErrorIs(err, services.ErrRoadmapNotBundleable)
+	var count int64
+	suite.db.Model(&models.Parlay{}).Count(&count)
+	suite.Zero(count)
+
+	suite.db.Model(first).Update("status", models.MilestoneStatusCompleted)
+	quote, err := suite.service.QuoteRoadmap(1, 1000)
+	suite.Require().NoError(err)
+	suite.Len(quote.Legs, 5)
+}
+
+// TestQuoteRejectsTooManyLegs 직접 조합 견적도 2~5개 레그만 허용
+func (suite *RoadmapBundleTestSuite) TestQuoteRejectsTooManyLegs() {
+	var legs []models.ParlayLegRequest
+	for order := 1; order <= 6; order++ {
+		milestone := suite.createMilestone(order, models.MilestoneStatusActive, 0.9)
+		legs = append(legs, models.ParlayLegRequest{MilestoneID: milestone.ID, OptionID: models.OptionSuccess})
+	}
+
+	_, err := suite.service.Quote(legs, 1000)
+	suite.Error(err)
+	_, err = suite.service.Quote(legs[:1], 1000)
+	suite.Error(err)
+	quote, err := suite.service.Quote(legs[:5], 1000)
+	suite.Require().NoError(err)
+	suite.Len(quote.Legs, 5)
+}
+
+func TestRoadmapBundleSuite(t *testing.T) {
+	suite.Run(t, new(RoadmapBundleTestSuite))
+}
@@ -0,0 +1,66 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// RoleTestSuite 역할 기반 접근 제어 테스트 슈트
+type RoleTestSuite struct {
+	suite.Suite
+	roleService *services.RoleService
+}
+
+func (suite *RoleTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(&models.User{}, &models.UserRole{}))
+
+	db.Create(&models.User{ID: 1, Email: "admin@test.com", Username: "admin"})
+	db.Create(&models.User{ID: 2, Email: "mod@test.com", Username: "mod"})
+
+	suite.roleService = services.NewRoleService(db)
+	suite.roleService.BootstrapAdmins([]string{"ADMIN@test.com"})
+}
+
+// TestPermissionsFollowRoles 역할 부여/회수에 따른 권한 변화
+func (suite *RoleTestSuite) TestPermissionsFollowRoles() {
+	allowed, err := suite.roleService.HasPermission(1, models.PermissionManageRoles)
+	suite.Require().NoError(err)
+	suite.True(allowed, "bootstrap admin은 모든 권한 보유")
+
+	allowed, _ = suite.roleService.HasPermission(2, models.PermissionProcessSlashing)
+	suite.False(allowed)
+
+	adminID := uint(1)
+	_, err = suite.roleService.GrantRole(2, models.RoleModerator, &adminID, "운영 지원")
+	suite.Require().NoError(err)
+	_, err = suite.roleService.GrantRole(2, models.RoleModerator, &adminID, "")
+	suite.ErrorIs(err, services.ErrRoleAlreadyGiven)
+
+	allowed, _ = suite.roleService.HasPermission(2, models.PermissionProcessSlashing)
+	suite.True(allowed)
+	allowed, _ = suite.roleService.HasPermission(2, models.PermissionManageRoles)
+	suite.False(allowed)
+
+	suite.Require().NoError(suite.roleService.RevokeRole(2, models.RoleModerator, adminID))
+	allowed, _ = suite.roleService.HasPermission(2, models.PermissionProcessSlashing)
+	suite.False(allowed)
+}
+
+// TestCannotRevokeLastAdmin 마지막 관리자 보호
+func (suite *RoleTestSuite) TestCannotRevokeLastAdmin() {
+	suite.ErrorIs(suite.roleService.RevokeRole(1, models.RoleAdmin, 1), services.ErrLastAdminRevoke)
+	_, err := suite.roleService.GrantRole(2, models.Role("superuser"), nil, "")
+	suite.ErrorIs(err, services.ErrInvalidRole)
+}
+
+func TestRoleTestSuite(t *testing.T) {
+	suite.Run(t, new(RoleTestSuite))
+}
@@ -0,0 +1,148 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func scalarMarket(lower, upper float64) *models.Milestone {
+	return &models.Milestone{
+		MarketType:  models.MarketTypeScalar,
+		ScalarLower: &lower,
+		ScalarUpper: &upper,
+		ScalarUnit:  "users",
+	}
+}
+
+// TestScalarRangeValidate 하한 < 상한, 초기 예상 값은 범위 안
+func TestScalarRangeValidate(t *testing.T) {
+	initial := 5000.0
+	assert.NoError(t, models.ScalarRange{Lower: 0, Upper: 10000, InitialValue: &initial}.Validate())
+
+	assert.ErrorIs(t, models.ScalarRange{Lower: 10, Upper: 10}.Validate(), models.ErrInvalidScalarRange)
+	outside := 20000.0
+	assert.ErrorIs(t, models.ScalarRange{Lower: 0, Upper: 10000, InitialValue: &outside}.Validate(), models.ErrInvalidScalarRange)
+}
+
+// TestScalarSettlementTicks 판정 값 위치에 비례한 long/short 지급액 (범위 밖은 양 끝)
+func TestScalarSettlementTicks(t *testing.T) {
+	milestone := scalarMarket(0, 10000)
+	assert.Equal(t, []string{models.OptionLong, models.OptionShort}, milestone.OptionIDs())
+
+	_, ok := milestone.SettlementTicks()
+	assert.False(t, ok, "판정 전에는 정산 불가")
+
+	value := 7500.0
+	milestone.ResolvedValue = &value
+	ticks, ok := milestone.SettlementTicks()
+	assert.True(t, ok)
+	assert.Equal(t, int64(7500), ticks[models.OptionLong])
+	assert.Equal(t, int64(2500), ticks[models.OptionShort])
+
+	value = 12000
+	ticks, _ = milestone.SettlementTicks()
+	assert.Equal(t, models.PriceScale, ticks[models.OptionLong])
+	assert.Equal(t, int64(0), ticks[models.OptionShort])
+
+	implied, ok := milestone.ImpliedScalarValue(0.42)
+	assert.True(t, ok)
+	assert.InDelta(t, 4200, implied, 1e-9)
+}
+
+// TestInitialScalarPrices 초기 예상 값의 범위 내 위치로 long 가격 설정
+func TestInitialScalarPrices(t *testing.T) {
+	validator := services.NewProbabilityValidator()
+
+	prices, err := validator.InitialScalarPrices(scalarMarket(0, 10000), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, prices[models.OptionLong])
+
+	initial := 2000.0
+	prices, err = validator.InitialScalarPrices(scalarMarket(0, 10000), &initial)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.2, prices[models.OptionLong])
+	assert.Equal(t, 0.8, prices[models.OptionShort])
+}
+
+// ScalarSettlementTestSuite 수치 마켓 판정/정산 테스트 슈트
+type ScalarSettlementTestSuite struct {
+	suite.Suite
+	db *gorm.DB
+}
+
+func (suite *ScalarSettlementTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(
+		&models.User{},
+		&models.Project{},
+		&models.Milestone{},
+		&models.MilestoneStatusHistory{},
+		&models.Position{},
+		&models.UserWallet{},
+	))
+	suite.db = db
+}
+
+// TestResolveAndSettleProportionally 판정 값 7,500 (범위 0-10,000) → long 75¢, short 25¢
+func (suite *ScalarSettlementTestSuite) TestResolveAndSettleProportionally() {
+	milestone := scalarMarket(0, 10000)
+	milestone.ProjectID, milestone.Title, milestone.Order = 1, "사용자 1만 명", 1
+	milestone.Status = models.MilestoneStatusActive
+	suite.Require().NoError(suite.db.Create(milestone).Error)
+
+	for userID := uint(1); userID <= 2; userID++ {
+		suite.Require().NoError(suite.db.Create(&models.UserWallet{UserID: userID}).Error)
+	}
+	suite.Require().NoError(suite.db.Create(&[]models.Position{
+		{UserID: 1, MilestoneID: milestone.ID, OptionID: models.OptionLong, Quantity: 100, TotalCost: 5000},
+		{UserID: 2, MilestoneID: milestone.ID, OptionID: models.OptionShort, Quantity: 100, TotalCost: 5000},
+	}).Error)
+
+	lifecycle := services.NewMilestoneLifecycleService(suite.db, nil)
+	_, err := lifecycle.ResolveOutcome(milestone.ID, models.OptionLong, 1)
+	suite.ErrorIs(err, services.ErrNotCategoricalMarket)
+
+	resolved, err := lifecycle.ResolveScalar(milestone.ID, 7500, 1)
+	suite.Require().NoError(err)
+	suite.Equal(models.MilestoneStatusProofApproved, resolved.Status)
+	suite.Require().NotNil(resolved.ResolvedValue)
+
+	settlement := services.NewMarketSettlementService(suite.db)
+	settled, err := settlement.SettleMarket(milestone.ID)
+	suite.Require().NoError(err)
+	suite.Zero(settled, "분쟁 기간 중(proof_approved)에는 정산하지 않음")
+
+	stateMachine := services.NewMilestoneStateMachine(suite.db)
+	suite.Require().NoError(suite.db.Transaction(func(tx *gorm.DB) error {
+		_, err := stateMachine.Transition(tx, resolved, models.MilestoneStatusCompleted, services.TransitionOptions{Reason: "test"})
+		return err
+	}))
+
+	settled, err = settlement.SettleMarket(milestone.ID)
+	suite.Require().NoError(err)
+	suite.Equal(2, settled)
+
+	var long, short models.UserWallet
+	suite.db.Where("user_id = ?", 1).First(&long)
+	suite.db.Where("user_id = ?", 2).First(&short)
+	suite.Equal(int64(7500), long.USDCBalance)
+	suite.Equal(int64(2500), long.TotalUSDCProfit)
+	suite.Equal(int64(2500), short.USDCBalance)
+	suite.Equal(int64(2500), short.TotalUSDCLoss)
+
+	settled, err = settlement.SettleMarket(milestone.ID)
+	suite.Require().NoError(err)
+	suite.Zero(settled, "중복 정산 방지")
+}
+
+func TestScalarSettlementSuite(t *testing.T) {
+	suite.Run(t, new(ScalarSettlementTestSuite))
+}
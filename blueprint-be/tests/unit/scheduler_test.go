@@ -0,0 +1,79 @@
+package unit_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSchedulerRunsJobOnce 여러 인스턴스가 같은 작업을 등록해도 주기마다 한 곳에서만 실행되고, 실행 이력과 일정 변경이 반영된다
+func TestSchedulerRunsJobOnce(t *testing.T) {
+	env := testkit.New(t)
+
+	var runs int32
+	job := func(now time.Time) (int, error) {
+		atomic.AddInt32(&runs, 1)
+		time.Sleep(50 * time.Millisecond)
+		return 3, nil
+	}
+
+	a := services.NewSchedulerService(env.DB, moduleRedis.GetClient())
+	b := services.NewSchedulerService(env.DB, moduleRedis.GetClient())
+	a.Register("test_job", time.Minute, job)
+	b.Register("test_job", time.Minute, job)
+
+	now := time.Now()
+	startedA, err := a.RunDue(now)
+	require.NoError(t, err)
+	startedB, err := b.RunDue(now)
+	require.NoError(t, err)
+	a.Wait()
+	b.Wait()
+
+	assert.Equal(t, 1, startedA+startedB)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+
+	// 다음 실행 시각 전에는 다시 실행하지 않음
+	started, err := b.RunDue(now.Add(30 * time.Second))
+	require.NoError(t, err)
+	assert.Zero(t, started)
+
+	jobs, err := a.ListJobs()
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, 60, jobs[0].IntervalSeconds)
+	assert.Equal(t, models.ScheduledJobSucceeded, jobs[0].LastStatus)
+
+	// 즉시 실행은 이력에 관리자와 함께 남음
+	run, err := b.Trigger("test_job", 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.ScheduledJobTriggerManual, run.Trigger)
+	b.Wait()
+
+	history, err := a.ListRuns("test_job", 10)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, models.ScheduledJobSucceeded, history[0].Status)
+	assert.Equal(t, 3, history[0].Affected)
+	require.NotNil(t, history[0].TriggeredBy)
+
+	// 비활성화하면 주기가 와도 실행하지 않음
+	disabled := false
+	_, err = a.UpdateSchedule("test_job", models.UpdateScheduledJobRequest{Enabled: &disabled})
+	require.NoError(t, err)
+	started, err = a.RunDue(now.Add(2 * time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, started)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&runs))
+
+	_, err = a.Trigger("missing_job", 1)
+	assert.ErrorIs(t, err, services.ErrScheduledJobNotFound)
+}
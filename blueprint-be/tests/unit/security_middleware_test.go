@@ -0,0 +1,95 @@
+package unit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	moduleConfig "blueprint-module/pkg/config"
+	"blueprint/internal/config"
+	"blueprint/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSecurityRouter(t *testing.T, security config.SecurityConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{
+		Config:   moduleConfig.Config{Server: moduleConfig.ServerConfig{FrontendURL: "http://localhost:3000"}},
+		Security: security,
+	}
+
+	router := gin.New()
+	require.NoError(t, middleware.ConfigureTrustedProxies(router, cfg))
+	router.Use(middleware.SecurityHeaders(cfg), middleware.CORSMiddleware(cfg))
+	router.GET("/ip", func(c *gin.Context) { c.String(http.StatusOK, c.ClientIP()) })
+	return router
+}
+
+func serve(router *gin.Engine, method, origin, remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/ip", nil)
+	req.RemoteAddr = remoteAddr
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// TestCORSAllowsOnlyConfiguredOrigins 허용 출처(하위 도메인 와일드카드 포함)만 CORS 헤더, 그 외 preflight는 403
+func TestCORSAllowsOnlyConfiguredOrigins(t *testing.T) {
+	router := newSecurityRouter(t, config.SecurityConfig{
+		AllowedOrigins: []string{"https://app.blueprint.io", "https://*.preview.blueprint.io"},
+	})
+
+	allowed := serve(router, http.MethodGet, "https://app.blueprint.io", "203.0.113.5:1234", "")
+	assert.Equal(t, "https://app.blueprint.io", allowed.Header().Get("Access-Control-Allow-Origin"))
+
+	preview := serve(router, http.MethodOptions, "https://pr-12.preview.blueprint.io", "203.0.113.5:1234", "")
+	assert.Equal(t, http.StatusNoContent, preview.Code)
+	assert.Equal(t, "https://pr-12.preview.blueprint.io", preview.Header().Get("Access-Control-Allow-Origin"))
+
+	for _, origin := range []string{"https://evil.io", "https://preview.blueprint.io", "http://pr-12.preview.blueprint.io"} {
+		rejected := serve(router, http.MethodOptions, origin, "203.0.113.5:1234", "")
+		assert.Equal(t, http.StatusForbidden, rejected.Code, origin)
+		assert.Empty(t, rejected.Header().Get("Access-Control-Allow-Origin"), origin)
+	}
+
+	// 설정이 없으면 FRONTEND_URL만 허용
+	fallback := newSecurityRouter(t, config.SecurityConfig{})
+	assert.Equal(t, "http://localhost:3000",
+		serve(fallback, http.MethodGet, "http://localhost:3000", "203.0.113.5:1234", "").Header().Get("Access-Control-Allow-Origin"))
+}
+
+// TestClientIPHonorsOnlyTrustedProxies 신뢰한 프록시에서 온 요청만 X-Forwarded-For를 클라이언트 IP로 사용
+func TestClientIPHonorsOnlyTrustedProxies(t *testing.T) {
+	router := newSecurityRouter(t, config.SecurityConfig{TrustedProxies: []string{"10.0.0.0/8"}})
+	assert.Equal(t, "198.51.100.7", serve(router, http.MethodGet, "", "10.1.2.3:5555", "198.51.100.7").Body.String())
+	assert.Equal(t, "203.0.113.5", serve(router, http.MethodGet, "", "203.0.113.5:1234", "198.51.100.7").Body.String(), "위조된 헤더 무시")
+
+	untrusted := newSecurityRouter(t, config.SecurityConfig{})
+	assert.Equal(t, "10.1.2.3", serve(untrusted, http.MethodGet, "", "10.1.2.3:5555", "198.51.100.7").Body.String())
+
+	cfg := &config.Config{Security: config.SecurityConfig{TrustedProxies: []string{"not-a-cidr"}}}
+	assert.Error(t, middleware.ConfigureTrustedProxies(gin.New(), cfg))
+}
+
+// TestSecurityHeaders 기본 보안 헤더와 설정 시 HSTS
+func TestSecurityHeaders(t *testing.T) {
+	router := newSecurityRouter(t, config.SecurityConfig{HSTSMaxAgeSeconds: 31536000, ContentSecurityPolicy: "default-src 'none'"})
+	recorder := serve(router, http.MethodGet, "", "203.0.113.5:1234", "")
+
+	assert.Equal(t, "nosniff", recorder.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "DENY", recorder.Header().Get("X-Frame-Options"))
+	assert.Equal(t, "default-src 'none'", recorder.Header().Get("Content-Security-Policy"))
+	assert.Equal(t, "max-age=31536000; includeSubDomains", recorder.Header().Get("Strict-Transport-Security"))
+
+	plain := serve(newSecurityRouter(t, config.SecurityConfig{}), http.MethodGet, "", "203.0.113.5:1234", "")
+	assert.Empty(t, plain.Header().Get("Strict-Transport-Security"))
+}
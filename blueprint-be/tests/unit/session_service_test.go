@@ -0,0 +1,91 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
+	"blueprint/internal/services"
+	"blueprint/pkg/utils"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// SessionTestSuite 로그인 세션 서비스 테스트 슈트
+type SessionTestSuite struct {
+	suite.Suite
+	user           *models.User
+	sessionService *services.SessionService
+}
+
+func (suite *SessionTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(&models.User{}))
+
+	redisServer := miniredis.RunT(suite.T())
+	moduleRedis.Client = redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+
+	suite.user = &models.User{ID: 1, Email: "alice@test.com", Username: "alice", IsActive: true}
+	suite.Require().NoError(db.Create(suite.user).Error)
+
+	suite.sessionService = services.NewSessionService(db, "test-secret")
+}
+
+func (suite *SessionTestSuite) TearDownTest() {
+	moduleRedis.Client = nil
+}
+
+// TestRefreshRotatesToken 갱신 시 리프레시 토큰이 교체되고 이전 토큰은 거부됨
+func (suite *SessionTestSuite) TestRefreshRotatesToken() {
+	tokens, err := suite.sessionService.CreateSession(suite.user, "Mozilla/5.0 (Macintosh; Intel Mac OS X) Chrome/120.0", "127.0.0.1")
+	suite.Require().NoError(err)
+	suite.NotEmpty(tokens.RefreshToken)
+
+	claims, err := utils.ValidateToken(tokens.AccessToken, "test-secret")
+	suite.Require().NoError(err)
+	suite.Equal(tokens.SessionID, claims.SessionID)
+
+	refreshed, err := suite.sessionService.Refresh(tokens.RefreshToken, "", "127.0.0.1")
+	suite.Require().NoError(err)
+	suite.NotEqual(tokens.RefreshToken, refreshed.RefreshToken)
+	suite.Equal(tokens.SessionID, refreshed.SessionID)
+
+	_, err = suite.sessionService.Refresh(tokens.RefreshToken, "", "127.0.0.1")
+	suite.ErrorIs(err, services.ErrSessionInvalid)
+
+	sessions, err := suite.sessionService.ListSessions(suite.user.ID, tokens.SessionID)
+	suite.Require().NoError(err)
+	suite.Require().Len(sessions, 1)
+	suite.True(sessions[0].Current)
+	suite.Equal("Chrome on macOS", sessions[0].DeviceName)
+}
+
+// TestRevokeSessionBlocksTokens 세션 폐기 후 액세스/리프레시 토큰 모두 무효화
+func (suite *SessionTestSuite) TestRevokeSessionBlocksTokens() {
+	tokens, err := suite.sessionService.CreateSession(suite.user, "", "127.0.0.1")
+	suite.Require().NoError(err)
+	other, err := suite.sessionService.CreateSession(suite.user, "", "10.0.0.1")
+	suite.Require().NoError(err)
+
+	suite.ErrorIs(suite.sessionService.RevokeSession(2, tokens.SessionID), services.ErrSessionNotFound)
+	suite.Require().NoError(suite.sessionService.RevokeSession(suite.user.ID, tokens.SessionID))
+
+	suite.True(moduleRedis.IsSessionRevoked(tokens.SessionID))
+	suite.False(moduleRedis.IsSessionRevoked(other.SessionID))
+
+	_, err = suite.sessionService.Refresh(tokens.RefreshToken, "", "127.0.0.1")
+	suite.ErrorIs(err, services.ErrSessionInvalid)
+
+	revoked, err := suite.sessionService.RevokeOtherSessions(suite.user.ID, "")
+	suite.Require().NoError(err)
+	suite.Equal(1, revoked)
+}
+
+func TestSessionTestSuite(t *testing.T) {
+	suite.Run(t, new(SessionTestSuite))
+}
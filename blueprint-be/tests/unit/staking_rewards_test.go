@@ -0,0 +1,99 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestStakingEmissionSchedule(t *testing.T) {
+	schedule := services.StakingEmissionSchedule{
+		Genesis:          time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		EpochDuration:    24 * time.Hour,
+		EpochEmission:    100000,
+		DecayPercent:     10,
+		DecayEveryEpochs: 30,
+		MinEpochEmission: 80000,
+	}
+
+	assert.Equal(t, int64(-1), schedule.EpochAt(schedule.Genesis.Add(-time.Second)))
+	assert.Equal(t, int64(31), schedule.EpochAt(schedule.Genesis.Add(31*24*time.Hour+time.Hour)))
+	assert.Equal(t, int64(100000), schedule.EmissionFor(29))
+	assert.Equal(t, int64(90000), schedule.EmissionFor(30))
+	assert.Equal(t, int64(81000), schedule.EmissionFor(60))
+	assert.Equal(t, int64(80000), schedule.EmissionFor(90), "최소 발행량 이하로 내려가지 않음")
+}
+
+func TestStakingEpochAccrualAndClaim(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&models.UserWallet{}, &models.MentorStake{}, &models.JurorQualification{},
+		&models.StakingEpoch{}, &models.StakingEpochReward{}, &models.WalletLedgerEntry{},
+	))
+
+	genesis := time.Now().Add(-50 * time.Hour).Truncate(time.Hour)
+	schedule := services.StakingEmissionSchedule{
+		Genesis:           genesis,
+		EpochDuration:     24 * time.Hour,
+		EpochEmission:     10000,
+		JurorSharePercent: 30,
+	}
+
+	staked := genesis.Add(-time.Hour)
+	db.Create(&models.UserWallet{UserID: 1})
+	db.Create(&models.UserWallet{UserID: 2})
+	db.Create(&models.MentorStake{MentorID: 1, UserID: 1, Amount: 3000, AvailableAmount: 3000, Status: models.MentorStakeStatusActive, StakedAt: staked,
+		MinimumPeriod: 30, IsAutoRenewal: true, UnlockDate: time.Now().Add(-time.Hour)})
+	db.Create(&models.MentorStake{MentorID: 2, UserID: 2, Amount: 1000, AvailableAmount: 1000, Status: models.MentorStakeStatusActive, StakedAt: staked,
+		MinimumPeriod: 30, UnlockDate: time.Now().Add(-time.Hour)})
+	require.NoError(t, db.Create(&models.JurorQualification{UserID: 2, CurrentStake: 5000, IsActive: true, CreatedAt: staked}).Error)
+
+	rewardsService := services.NewStakingRewardsService(db, schedule)
+
+	// 첫 실행은 직전 에포크(1)만 적립
+	processed, err := rewardsService.ProcessDueEpochs(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	processed, _ = rewardsService.ProcessDueEpochs(time.Now())
+	assert.Zero(t, processed, "같은 에포크는 한 번만 처리")
+
+	var epoch models.StakingEpoch
+	require.NoError(t, db.First(&epoch).Error)
+	assert.Equal(t, int64(1), epoch.EpochNumber)
+	assert.Equal(t, int64(10000), epoch.DistributedAmount)
+	assert.Equal(t, int64(4000), epoch.MentorStakeTotal)
+
+	summary, err := rewardsService.GetSummary(1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5250), summary.PendingAmount) // 멘토 몫 7000 × 3/4
+
+	summary, _ = rewardsService.GetSummary(2)
+	assert.Equal(t, int64(1750+3000), summary.PendingAmount) // 멘토 몫 7000 × 1/4 + 배심원 몫 전체
+
+	// 자동 갱신 스테이킹만 잠금 기간 연장
+	renewed, err := rewardsService.ProcessMaturedStakes(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, renewed)
+	var stake models.MentorStake
+	db.Where("user_id = ?", 1).First(&stake)
+	assert.True(t, stake.UnlockDate.After(time.Now().Add(29*24*time.Hour)))
+
+	claim, err := rewardsService.ClaimRewards(1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5250), claim.TotalAmount)
+
+	var wallet models.UserWallet
+	db.Where("user_id = ?", 1).First(&wallet)
+	assert.Equal(t, int64(5250), wallet.BlueprintBalance)
+
+	_, err = rewardsService.ClaimRewards(1)
+	assert.ErrorIs(t, err, services.ErrNoStakingRewards)
+}
@@ -0,0 +1,134 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSurveillanceDetectsManipulation 주고받기 고리, 빠른 취소, 마감 직전 가격 주도를 탐지하고 재탐지 시 중복 없이 갱신, 검토 후에는 그대로 유지
+func TestSurveillanceDetectsManipulation(t *testing.T) {
+	env := testkit.New(t)
+	surveillance := services.NewSurveillanceService(env.DB, services.NewArbitrationService(env.DB))
+	now := time.Now().Truncate(time.Second)
+	moderator := env.Factory.User()
+
+	trade := func(milestone *models.Milestone, seller, buyer *models.User, price float64, quantity int64, ago time.Duration) {
+		require.NoError(t, env.DB.Create(&models.Trade{
+			ProjectID:   milestone.ProjectID,
+			MilestoneID: milestone.ID,
+			OptionID:    models.OptionSuccess,
+			BuyerID:     buyer.ID,
+			SellerID:    seller.ID,
+			Quantity:    quantity,
+			Price:       price,
+			PriceTicks:  models.PriceToTicks(price),
+			TotalAmount: int64(price * float64(quantity) * 100),
+			CreatedAt:   now.Add(-ago),
+		}).Error)
+	}
+
+	// 2계정 고리(a ↔ b), 3계정 고리(f → g → h → f), 일반 체결
+	market := env.Factory.Market()
+	a, b := env.Factory.User(), env.Factory.User()
+	f, g, h := env.Factory.User(), env.Factory.User(), env.Factory.User()
+	seller, buyer := env.Factory.User(), env.Factory.User()
+	trade(market, a, b, 0.50, 60, 20*time.Minute)
+	trade(market, b, a, 0.50, 60, 19*time.Minute)
+	trade(market, f, g, 0.50, 50, 18*time.Minute)
+	trade(market, g, h, 0.50, 50, 17*time.Minute)
+	trade(market, h, f, 0.50, 50, 16*time.Minute)
+	trade(market, seller, buyer, 0.50, 100, 15*time.Minute)
+
+	// 매수 주문 10건을 바로 취소하고 매도는 체결
+	spoofer := env.Factory.User()
+	for i := 0; i < 10; i++ {
+		env.Factory.Order(spoofer.ID, market, models.OrderSideBuy, 0.60, 500, func(o *models.Order) {
+			o.Status = models.OrderStatusCancelled
+			o.CreatedAt = now.Add(-10 * time.Minute)
+			o.UpdatedAt = o.CreatedAt.Add(2 * time.Second)
+		})
+	}
+	env.Factory.Order(spoofer.ID, market, models.OrderSideSell, 0.55, 20, func(o *models.Order) {
+		o.Status = models.OrderStatusFilled
+		o.Filled, o.Remaining = 20, 0
+		o.CreatedAt = now.Add(-10 * time.Minute)
+		o.UpdatedAt = o.CreatedAt
+	})
+
+	// 마감 1분 전까지 30분 구간에서 한 계정이 가격을 0.40 → 0.66으로 끌어올림
+	closesAt := now.Add(-time.Minute)
+	closing := env.Factory.Market(func(m *models.Milestone) { m.TradingClosesAt = &closesAt })
+	painter, other := env.Factory.User(), env.Factory.User()
+	trade(closing, seller, buyer, 0.40, 10, 2*time.Hour)
+	trade(closing, seller, painter, 0.50, 30, 10*time.Minute)
+	trade(closing, other, painter, 0.65, 40, 5*time.Minute)
+	trade(closing, seller, other, 0.66, 10, 3*time.Minute)
+
+	raised, err := surveillance.Scan(now)
+	require.NoError(t, err)
+	assert.Equal(t, 4, raised)
+
+	alertOf := func(alertType models.SurveillanceAlertType, userID uint) models.SurveillanceAlert {
+		alerts, _, err := surveillance.ListAlerts("", alertType, 0, 0, 0)
+		require.NoError(t, err)
+		for _, alert := range alerts {
+			if alert.UserIDs[0] == userID {
+				return alert
+			}
+		}
+		t.Fatalf("no %s alert for user %d", alertType, userID)
+		return models.SurveillanceAlert{}
+	}
+
+	pair := alertOf(models.SurveillanceWashTrading, a.ID)
+	assert.Equal(t, []uint{a.ID, b.ID}, pair.UserIDs)
+	assert.Equal(t, int64(60), pair.Evidence.RingQuantity)
+	assert.Equal(t, models.SurveillanceSeverityMedium, pair.Severity) // 120 / 370
+	ring := alertOf(models.SurveillanceWashTrading, f.ID)
+	assert.ElementsMatch(t, []uint{f.ID, g.ID, h.ID}, ring.UserIDs)
+	assert.Len(t, ring.Evidence.TradeIDs, 3)
+
+	spoof := alertOf(models.SurveillanceSpoofing, spoofer.ID)
+	assert.Equal(t, 10, spoof.Evidence.QuickCancels)
+	assert.Equal(t, int64(20), spoof.Evidence.OppositeFills)
+	assert.Equal(t, models.SurveillanceSeverityHigh, spoof.Severity)
+
+	paint := alertOf(models.SurveillancePaintingTheClose, painter.ID)
+	assert.Equal(t, closing.ID, paint.MilestoneID)
+	assert.InDelta(t, 0.875, paint.Evidence.MarketShare, 1e-9)
+	assert.Equal(t, models.SurveillanceSeverityHigh, paint.Severity)
+
+	// 종결한 알림은 다시 열리지 않고, 열린 알림은 중복 없이 갱신
+	_, err = surveillance.DismissAlert(pair.ID, moderator.ID, "시장 조성 계약 계정")
+	require.NoError(t, err)
+	raised, err = surveillance.Scan(now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 3, raised)
+	_, total, err := surveillance.ListAlerts("", "", 0, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+	assert.Equal(t, models.SurveillanceAlertDismissed, alertOf(models.SurveillanceWashTrading, a.ID).Status)
+
+	// 중재 이관: 운영자가 신청인, 가격 주도 계정이 피신청인
+	escalated, err := surveillance.EscalateAlert(paint.ID, moderator.ID, "마감 직전 단독 매수")
+	require.NoError(t, err)
+	assert.Equal(t, models.SurveillanceAlertEscalated, escalated.Status)
+	require.NotNil(t, escalated.ArbitrationCaseID)
+
+	var arbitrationCase models.ArbitrationCase
+	require.NoError(t, env.DB.First(&arbitrationCase, *escalated.ArbitrationCaseID).Error)
+	assert.Equal(t, models.DisputeTypeMarketManipulation, arbitrationCase.DisputeType)
+	assert.Equal(t, moderator.ID, arbitrationCase.PlaintiffID)
+	assert.Equal(t, painter.ID, arbitrationCase.DefendantID)
+	assert.Zero(t, arbitrationCase.StakeAmount)
+
+	_, err = surveillance.EscalateAlert(paint.ID, moderator.ID, "")
+	assert.ErrorIs(t, err, services.ErrSurveillanceAlertReviewed)
+}
@@ -0,0 +1,49 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMatchingEngineSettlesFactoryOrders 팩토리로 만든 주문이 체결되면 체결/포지션/지갑이 DB에 반영됨
+func TestMatchingEngineSettlesFactoryOrders(t *testing.T) {
+	env := testkit.New(t)
+	engine := testkit.StartMatchingEngine(t, env.DB)
+	milestone := env.Factory.Market()
+
+	// 매도자는 보유 포지션을 팔고, 매수자는 주문 금액과 최대 수수료를 미리 잠가 둠 (TradingService.CreateOrder와 같은 상태)
+	seller := env.Factory.FundedUser(0)
+	env.Factory.Position(seller.ID, milestone, models.OptionSuccess, 10, 0.5)
+	reserve := models.BuyReserveCents(10, models.PriceToTicks(0.6))
+	buyer := env.Factory.User()
+	env.Factory.Wallet(buyer.ID, 100000-reserve, func(w *models.UserWallet) { w.USDCLockedBalance = reserve })
+
+	ask := env.Factory.Order(seller.ID, milestone, models.OrderSideSell, 0.6, 10)
+	_, err := engine.SubmitOrder(ask)
+	require.NoError(t, err)
+	bid := env.Factory.Order(buyer.ID, milestone, models.OrderSideBuy, 0.6, 10)
+	result, err := engine.SubmitOrder(bid)
+	require.NoError(t, err)
+	require.Len(t, result.Trades, 1)
+	assert.Equal(t, models.OrderStatusFilled, result.Status)
+	testkit.Settle(t, engine)
+
+	var trades []models.Trade
+	require.NoError(t, env.DB.Where("milestone_id = ?", milestone.ID).Find(&trades).Error)
+	require.Len(t, trades, 1)
+	assert.Equal(t, int64(10), trades[0].Quantity)
+	assert.Equal(t, models.PriceToTicks(0.6), trades[0].PriceTicks)
+
+	var position models.Position
+	require.NoError(t, env.DB.Where("user_id = ? AND milestone_id = ?", buyer.ID, milestone.ID).First(&position).Error)
+	assert.Equal(t, int64(10), position.Quantity)
+
+	var wallet models.UserWallet
+	require.NoError(t, env.DB.Where("user_id = ?", buyer.ID).First(&wallet).Error)
+	assert.Zero(t, wallet.USDCLockedBalance)
+}
@@ -0,0 +1,91 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTradingPauseRejectsNewOrdersButAllowsCancel 전체 점검 중에는 신규 주문만 거부하고 기존 주문 취소는 처리
+func TestTradingPauseRejectsNewOrdersButAllowsCancel(t *testing.T) {
+	env := testkit.New(t)
+	engine := testkit.StartMatchingEngine(t, env.DB)
+	milestone := env.Factory.Market()
+	buyer := env.Factory.FundedUser(100000)
+
+	resting := env.Factory.Order(buyer.ID, milestone, models.OrderSideBuy, 0.4, 10)
+	_, err := engine.SubmitOrder(resting)
+	require.NoError(t, err)
+
+	admin := env.Factory.User()
+	pause, err := engine.TradingPause().Pause(models.CreateTradingPauseRequest{Reason: "DB 마이그레이션"}, admin.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.TradingPauseGlobal, pause.Scope)
+
+	_, err = engine.SubmitOrder(env.Factory.Order(buyer.ID, milestone, models.OrderSideBuy, 0.45, 10))
+	assert.ErrorIs(t, err, services.ErrTradingPaused)
+	assert.Contains(t, err.Error(), "DB 마이그레이션")
+
+	engine.CancelOrder(resting)
+	assert.Empty(t, engine.GetOrderBook(milestone.ID, models.OptionSuccess, 10, 0).Bids)
+
+	require.NoError(t, engine.TradingPause().Lift(pause.ID, admin.ID))
+	assert.ErrorIs(t, engine.TradingPause().Lift(pause.ID, admin.ID), services.ErrTradingPauseNotFound)
+	_, err = engine.SubmitOrder(env.Factory.Order(buyer.ID, milestone, models.OrderSideBuy, 0.45, 10))
+	assert.NoError(t, err)
+}
+
+// TestScheduledMarketPause 예약 점검은 시작 시각부터 해당 마켓에만 적용되고 종료 시각에 풀림
+func TestScheduledMarketPause(t *testing.T) {
+	env := testkit.New(t)
+	pauses := services.NewTradingPauseService(env.DB, nil)
+	milestone := env.Factory.Market()
+	other := env.Factory.Market()
+
+	startsAt := time.Now().Add(time.Hour)
+	endsAt := startsAt.Add(30 * time.Minute)
+	_, err := pauses.Pause(models.CreateTradingPauseRequest{
+		MilestoneID: &milestone.ID,
+		Reason:      "정산 테이블 이전",
+		StartsAt:    &startsAt,
+		EndsAt:      &endsAt,
+	}, 1)
+	require.NoError(t, err)
+	assert.NoError(t, pauses.Check(milestone.ID), "시작 전에는 주문 접수")
+
+	_, paused := pauses.PausedFor(milestone.ID, startsAt.Add(time.Minute))
+	assert.True(t, paused)
+	_, paused = pauses.PausedFor(other.ID, startsAt.Add(time.Minute))
+	assert.False(t, paused, "다른 마켓은 영향 없음")
+	_, paused = pauses.PausedFor(milestone.ID, endsAt)
+	assert.False(t, paused, "종료 시각에 자동 해제")
+
+	// 스케줄러 동기화: 시작 시각이 지나면 시작, 종료 시각이 지나면 종료로 집계
+	started, ended, err := pauses.Sync(startsAt.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, started)
+	assert.Zero(t, ended)
+	started, ended, err = pauses.Sync(endsAt.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Zero(t, started)
+	assert.Equal(t, 1, ended)
+
+	// 잘못된 요청
+	missing := uint(9999)
+	_, err = pauses.Pause(models.CreateTradingPauseRequest{MilestoneID: &missing, Reason: "x"}, 1)
+	assert.ErrorIs(t, err, services.ErrInvalidTradingPause)
+	past := time.Now().Add(-time.Minute)
+	_, err = pauses.Pause(models.CreateTradingPauseRequest{Reason: "x", EndsAt: &past}, 1)
+	assert.ErrorIs(t, err, services.ErrInvalidTradingPause)
+
+	// 설정으로 건 중단
+	pauses.Configure(services.TradingPauseConfig{MilestoneIDs: []uint{other.ID}, Reason: "설정"})
+	assert.ErrorIs(t, pauses.Check(other.ID), services.ErrTradingPaused)
+	assert.NoError(t, pauses.Check(milestone.ID))
+}
@@ -0,0 +1,85 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
+	"blueprint/internal/services"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newTrustScoreTestService(t *testing.T) (*gorm.DB, *services.TrustScoreService) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.UserVerification{}, &models.KYCVerification{}))
+
+	redisServer := miniredis.RunT(t)
+	moduleRedis.Client = redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+	t.Cleanup(func() { moduleRedis.Client = nil })
+
+	return db, services.NewTrustScoreService(db, services.TrustScorePolicy{
+		Weights:  services.TrustScoreWeights{Email: 20, Phone: 20, LinkedIn: 30, Education: 30},
+		Minimums: map[services.TrustGate]int{services.TrustGateJuror: 50},
+	})
+}
+
+// TestTrustScoreWeightsAndGate 가중치 비율로 점수를 계산하고 저장된 점수로 역할 자격 확인
+func TestTrustScoreWeightsAndGate(t *testing.T) {
+	db, trustScores := newTrustScoreTestService(t)
+
+	db.Create(&models.User{ID: 1, Email: "alice@test.com", Username: "alice"})
+	db.Create(&models.UserVerification{UserID: 1, EmailVerified: true, PhoneVerified: true, GitHubConnected: true})
+
+	score, err := trustScores.Compute(1)
+	require.NoError(t, err)
+	assert.Equal(t, 40, score, "GitHub은 가중치 0")
+
+	assert.ErrorIs(t, trustScores.CheckEligibility(1, services.TrustGateJuror), services.ErrTrustScoreTooLow, "계산 전 저장된 점수는 0")
+	assert.NoError(t, trustScores.CheckEligibility(1, services.TrustGateValidator), "최소 점수가 없는 역할은 제한 없음")
+
+	db.Model(&models.UserVerification{}).Where("user_id = ?", 1).Update("education_status", models.VerificationApproved)
+	score, err = trustScores.Recalculate(1)
+	require.NoError(t, err)
+	assert.Equal(t, 70, score)
+	assert.NoError(t, trustScores.CheckEligibility(1, services.TrustGateJuror))
+
+	var nilService *services.TrustScoreService
+	assert.NoError(t, nilService.CheckEligibility(1, services.TrustGateJuror), "서비스가 없으면 제한 없음")
+}
+
+// TestTrustScoreRecalculatesChangedUsers 재계산 요청된 사용자와 검증 정보가 바뀐 사용자만 다시 계산
+func TestTrustScoreRecalculatesChangedUsers(t *testing.T) {
+	db, trustScores := newTrustScoreTestService(t)
+
+	db.Create(&models.User{ID: 1, Email: "alice@test.com", Username: "alice"})
+	db.Create(&models.User{ID: 2, Email: "bob@test.com", Username: "bob"})
+	db.Create(&models.UserVerification{UserID: 1, EmailVerified: true})
+	db.Create(&models.UserVerification{UserID: 2, LinkedInConnected: true})
+
+	updated, err := trustScores.RecalculateChanged()
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated, "한 번도 계산하지 않은 사용자")
+
+	updated, err = trustScores.RecalculateChanged()
+	require.NoError(t, err)
+	assert.Equal(t, 0, updated)
+
+	db.Model(&models.UserVerification{}).Where("user_id = ?", 2).
+		Updates(map[string]interface{}{"phone_verified": true, "updated_at": time.Now().Add(time.Second)})
+	services.MarkTrustScoreStale(1)
+	updated, err = trustScores.RecalculateChanged()
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated)
+
+	var bob models.User
+	require.NoError(t, db.First(&bob, 2).Error)
+	assert.Equal(t, 50, bob.TrustScore)
+}
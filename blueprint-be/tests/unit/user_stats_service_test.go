@@ -0,0 +1,65 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUserStats 승률, 거래량, 확정 마켓 적중률, 검증 정확도를 계산하고 표시된 사용자만 다시 계산
+func TestUserStats(t *testing.T) {
+	env := testkit.New(t)
+	service := services.NewUserStatsService(env.DB)
+
+	trader, counterparty := env.Factory.FundedUser(100000), env.Factory.FundedUser(100000)
+	settledAt := time.Now()
+	won := env.Factory.Market(func(m *models.Milestone) {
+		m.Status = models.MilestoneStatusCompleted
+		m.WinningOptionID = models.OptionSuccess
+		m.SettledAt = &settledAt
+	})
+	lost := env.Factory.Market(func(m *models.Milestone) {
+		m.Status = models.MilestoneStatusFailed
+		m.WinningOptionID = models.OptionFail
+		m.SettledAt = &settledAt
+	})
+
+	require.NoError(t, env.DB.Create(&[]models.Trade{
+		{MilestoneID: won.ID, OptionID: models.OptionSuccess, BuyerID: trader.ID, SellerID: counterparty.ID, Quantity: 10, TotalAmount: 500},
+		{MilestoneID: lost.ID, OptionID: models.OptionSuccess, BuyerID: trader.ID, SellerID: counterparty.ID, Quantity: 10, TotalAmount: 700},
+	}).Error)
+	env.Factory.Position(trader.ID, won, models.OptionSuccess, 0, 0.5, func(p *models.Position) { p.Realized = 500 })
+	env.Factory.Position(trader.ID, lost, models.OptionSuccess, 0, 0.7, func(p *models.Position) { p.Realized = -700 })
+	require.NoError(t, env.DB.Create(&models.ValidatorQualification{UserID: trader.ID, TotalVerifications: 4, AccuracyRate: 0.75}).Error)
+
+	stats, err := service.ComputeUser(trader.ID, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1200), stats.TotalVolume)
+	assert.Equal(t, 2, stats.TotalTrades)
+	assert.Equal(t, 2, stats.SettledPositions)
+	assert.Equal(t, 0.5, stats.WinRate)
+	assert.Equal(t, 2, stats.ResolvedMarkets)
+	assert.Equal(t, 0.5, stats.MarketAccuracy)
+	assert.Equal(t, 4, stats.ValidatorVotes)
+	assert.Equal(t, 0.75, stats.ValidatorAccuracy)
+
+	// 표시된 사용자만 다시 계산
+	computed, err := service.ComputeChanged(time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, computed)
+	services.MarkUserStatsDirty(counterparty.ID)
+	computed, err = service.ComputeChanged(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, computed)
+
+	counterpartyStats, err := service.Get(counterparty.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, counterpartyStats.MarketAccuracy, "순매도만 한 마켓은 적중률에서 제외")
+	assert.Zero(t, counterpartyStats.ResolvedMarkets)
+}
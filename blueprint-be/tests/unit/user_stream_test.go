@@ -0,0 +1,104 @@
+package unit_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUserStreamDeliversPrivateEvents 체결/취소/지갑/알림 이벤트가 당사자 스트림에만 전달
+func TestUserStreamDeliversPrivateEvents(t *testing.T) {
+	env := testkit.New(t)
+	engine := testkit.StartMatchingEngine(t, env.DB)
+	tradingService := services.NewTradingService(env.DB, nil, engine)
+	stream := services.NewUserStreamService(env.DB)
+	go stream.Run()
+	require.Eventually(t, func() bool { return env.Redis.PubSubNumPat() == 1 }, time.Second, 10*time.Millisecond)
+
+	milestone := env.Factory.Market()
+	buyer := env.Factory.FundedUser(10000)
+	seller := env.Factory.FundedUser(0)
+	bystander := env.Factory.FundedUser(10000)
+	env.Factory.Position(seller.ID, milestone, models.OptionSuccess, 40, 0.3)
+
+	buyerEvents, unsubscribe := stream.Subscribe(buyer.ID)
+	defer unsubscribe()
+	bystanderEvents, unsubscribeBystander := stream.Subscribe(bystander.ID)
+	defer unsubscribeBystander()
+
+	// 원하는 종류의 이벤트가 올 때까지 읽음 (다른 종류는 건너뜀)
+	next := func(events <-chan []byte, eventType string) map[string]interface{} {
+		t.Helper()
+		timeout := time.After(2 * time.Second)
+		for {
+			select {
+			case frame := <-events:
+				var event services.UserEvent
+				require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(string(frame), "data: "))), &event))
+				if event.Type == eventType {
+					return event.Data.(map[string]interface{})
+				}
+			case <-timeout:
+				t.Fatalf("no %s event within timeout", eventType)
+				return nil
+			}
+		}
+	}
+	request := func(side models.OrderSide, quantity int64) models.CreateOrderRequest {
+		return models.CreateOrderRequest{
+			ProjectID:   milestone.ProjectID,
+			MilestoneID: milestone.ID,
+			OptionID:    models.OptionSuccess,
+			Type:        models.OrderTypeLimit,
+			Side:        side,
+			Quantity:    quantity,
+			Price:       0.5,
+		}
+	}
+
+	_, err := tradingService.CreateOrder(seller.ID, request(models.OrderSideSell, 40), "", "")
+	require.NoError(t, err)
+	placed, err := tradingService.CreateOrder(buyer.ID, request(models.OrderSideBuy, 100), "", "")
+	require.NoError(t, err)
+	testkit.Settle(t, engine)
+
+	fill := next(buyerEvents, services.UserEventOrderFill)
+	assert.EqualValues(t, placed.Order.ID, fill["order_id"])
+	assert.EqualValues(t, 40, fill["quantity"])
+
+	// 연속된 잔액 변경은 커밋된 스냅샷 하나로 전달
+	wallet := next(buyerEvents, services.UserEventWallet)
+	assert.EqualValues(t, 3008, wallet["usdc_locked_balance"], "미체결 60주분 주문 금액 + 최대 수수료")
+
+	_, err = tradingService.CancelOrder(buyer.ID, placed.Order.ID)
+	require.NoError(t, err)
+	cancelled := next(buyerEvents, services.UserEventOrderCancelled)
+	assert.EqualValues(t, placed.Order.ID, cancelled["order_id"])
+	assert.EqualValues(t, 3008, cancelled["released_amount"])
+	assert.EqualValues(t, 0, next(buyerEvents, services.UserEventWallet)["usdc_locked_balance"])
+
+	// 검증 결과/분쟁 진행 등은 알림 경로로 전달
+	_, err = services.NewNotificationService(env.DB).Notify(models.CreateNotificationRequest{
+		UserID:  buyer.ID,
+		Type:    models.NotificationTypeArbitration,
+		Title:   "분쟁 진행",
+		Message: "배심원 투표가 시작되었습니다",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "분쟁 진행", next(buyerEvents, services.UserEventNotification)["title"])
+
+	// 다른 사용자 스트림에는 아무것도 가지 않음
+	select {
+	case frame := <-bystanderEvents:
+		t.Fatalf("unexpected event for bystander: %s", frame)
+	case <-time.After(500 * time.Millisecond):
+	}
+}
@@ -0,0 +1,52 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+	"blueprint/internal/testkit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidatorQueueOrderedByPrescreen 검증인 대기열은 사전 검토 신뢰도 높은 순, 같은 점수는 마감 임박 순, 미검토는 뒤로
+func TestValidatorQueueOrderedByPrescreen(t *testing.T) {
+	env := testkit.New(t)
+	service := services.NewVerificationService(env.DB, nil)
+	owner := env.Factory.User()
+	validator := env.Factory.User()
+	milestone := env.Factory.Market()
+
+	now := time.Now()
+	score := func(v float64) *float64 { return &v }
+	queued := func(title string, status models.ProofStatus, prescreen *float64, deadline time.Time) uint {
+		proof := models.MilestoneProof{
+			MilestoneID: milestone.ID, UserID: owner.ID, ProofType: models.ProofTypeText, Title: title,
+			Status: status, PrescreenScore: prescreen, ReviewDeadline: deadline,
+		}
+		if prescreen != nil {
+			proof.PrescreenStatus = models.PrescreenStatusCompleted
+		}
+		require.NoError(t, env.DB.Create(&proof).Error)
+		return proof.ID
+	}
+
+	unscreened := queued("unscreened", models.ProofStatusUnderReview, nil, now.Add(time.Hour))
+	weak := queued("weak", models.ProofStatusUnderReview, score(0.2), now.Add(time.Hour))
+	strongLater := queued("strong-later", models.ProofStatusUnderReview, score(0.9), now.Add(48*time.Hour))
+	strongSooner := queued("strong-sooner", models.ProofStatusUnderReview, score(0.9), now.Add(2*time.Hour))
+	queued("expired", models.ProofStatusUnderReview, score(1), now.Add(-time.Hour))
+	queued("not-started", models.ProofStatusSubmitted, score(1), now.Add(time.Hour))
+
+	dashboard, err := service.GetValidatorDashboard(validator.ID)
+	require.NoError(t, err)
+
+	var order []uint
+	for _, proof := range dashboard.PendingProofs {
+		order = append(order, proof.ID)
+	}
+	assert.Equal(t, []uint{strongSooner, strongLater, weak, unscreened}, order)
+}
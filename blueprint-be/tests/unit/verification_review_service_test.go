@@ -0,0 +1,75 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+
+	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
+	"blueprint/internal/services"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestVerificationReviewFlow 검사 통과 서류만 승인 가능, 결정 시 알림과 신뢰 점수 재계산 요청
+func TestVerificationReviewFlow(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.UserProfile{}, &models.UserVerification{}, &models.Notification{}))
+
+	redisServer := miniredis.RunT(t)
+	moduleRedis.Client = redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+	defer func() { moduleRedis.Client = nil }()
+
+	db.Create(&models.User{ID: 1, Email: "alice@test.com", Username: "alice"})
+	db.Create(&models.UserVerification{
+		UserID:                    1,
+		ProfessionalStatus:        models.VerificationPending,
+		ProfessionalTitle:         "CPA",
+		ProfessionalDocPath:       "verification_docs/0123456789abcdef0123456789abcdef",
+		ProfessionalDocScanStatus: models.DocumentScanPending,
+	})
+
+	fileService := services.NewFileService(t.TempDir(), "http://localhost/api/v1/files", "secret")
+	reviewService := services.NewVerificationReviewService(db, fileService)
+
+	pending, total, err := reviewService.ListPending("professional", 20, 0)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, total)
+	assert.Equal(t, "alice", pending[0].Username)
+	assert.Empty(t, pending[0].DocumentURL, "검사 전 서류는 열람 불가")
+
+	approve := &models.ReviewVerificationDocRequest{Approved: true}
+	_, err = reviewService.Review(9, 1, "professional", approve)
+	assert.ErrorIs(t, err, services.ErrCredentialNotScanned)
+	_, err = reviewService.Review(9, 1, "professional", &models.ReviewVerificationDocRequest{})
+	assert.ErrorIs(t, err, services.ErrCredentialReasonRequired)
+
+	db.Model(&models.UserVerification{}).Where("user_id = ?", 1).Update("professional_doc_scan_status", models.DocumentScanClean)
+	pending, _, err = reviewService.ListPending("professional", 20, 0)
+	require.NoError(t, err)
+	assert.NotEmpty(t, pending[0].DocumentURL)
+
+	record, err := reviewService.Review(9, 1, "professional", approve)
+	require.NoError(t, err)
+	assert.Equal(t, models.VerificationApproved, record.ProfessionalStatus)
+	assert.NotNil(t, record.ProfessionalVerifiedAt)
+	require.NotNil(t, record.ProfessionalReviewedBy)
+	assert.EqualValues(t, 9, *record.ProfessionalReviewedBy)
+
+	_, err = reviewService.Review(9, 1, "professional", approve)
+	assert.ErrorIs(t, err, services.ErrCredentialNotPending)
+
+	var notifications int64
+	db.Model(&models.Notification{}).Where("user_id = ? AND type = ?", 1, models.NotificationTypeVerification).Count(&notifications)
+	assert.EqualValues(t, 1, notifications)
+
+	stale, err := moduleRedis.Client.SMembers(context.Background(), services.TrustScoreStaleKey).Result()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1"}, stale)
+}
@@ -0,0 +1,112 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
+	"blueprint/internal/services"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestVerificationTimeouts 정족수를 채운 검증은 투표대로 완료, 자동 완료 시각까지 결론이 없으면 배심원 중재로 이관
+func TestVerificationTimeouts(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	// 상태 전환 훅 고루틴도 같은 in-memory DB를 보도록 연결 하나로 고정
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	require.NoError(t, db.AutoMigrate(
+		&models.User{}, &models.UserProfile{}, &models.UserVerification{}, &models.Project{}, &models.Milestone{}, &models.MilestoneStatusHistory{},
+		&models.MilestoneProof{}, &models.MilestoneVerification{}, &models.ProofValidator{}, &models.VerificationReward{},
+		&models.ArbitrationCase{}, &models.Notification{}, &models.Position{},
+	))
+
+	redisServer := miniredis.RunT(t)
+	moduleRedis.Client = redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+	defer func() { moduleRedis.Client = nil }()
+
+	service := services.NewVerificationTimeoutService(db,
+		services.NewVerificationService(db, nil), services.NewArbitrationService(db))
+
+	owner := models.User{Email: "owner@test.com", Username: "owner", IsActive: true}
+	validator := models.User{Email: "validator@test.com", Username: "validator", IsActive: true}
+	require.NoError(t, db.Create(&owner).Error)
+	require.NoError(t, db.Create(&validator).Error)
+	project := models.Project{UserID: owner.ID, Title: "app", Category: models.BusinessProject, Status: models.ProjectActive}
+	require.NoError(t, db.Create(&project).Error)
+
+	now := time.Now()
+	underReview := func(title string, votes int, autoCompleteAfter time.Time) models.Milestone {
+		milestone := models.Milestone{
+			ProjectID: project.ID, Title: title, Order: 1, Status: models.MilestoneStatusUnderVerification,
+			MinValidators: 2, MinApprovalRate: 0.6, TotalValidators: votes, ApprovalVotes: votes, CurrentApprovalRate: 1,
+		}
+		if votes == 0 {
+			milestone.CurrentApprovalRate = 0
+		}
+		require.NoError(t, db.Create(&milestone).Error)
+		proof := models.MilestoneProof{MilestoneID: milestone.ID, UserID: owner.ID, ProofType: models.ProofTypeFile, Title: "proof", Status: models.ProofStatusUnderReview}
+		require.NoError(t, db.Create(&proof).Error)
+		require.NoError(t, db.Create(&models.MilestoneVerification{
+			MilestoneID: milestone.ID, ProofID: proof.ID, Status: models.MilestoneVerificationStatusActive,
+			ReviewDeadline: now.Add(-time.Hour), AutoCompleteAfter: autoCompleteAfter, MinimumVotes: 2,
+		}).Error)
+		for i := 0; i < votes; i++ {
+			require.NoError(t, db.Create(&models.ProofValidator{ProofID: proof.ID, UserID: validator.ID, Vote: "approve", VoteWeight: 1}).Error)
+		}
+		return milestone
+	}
+
+	quorum := underReview("quorum", 2, now.Add(time.Hour))
+	waiting := underReview("waiting", 0, now.Add(time.Hour))
+	silent := underReview("silent", 0, now.Add(-time.Minute))
+
+	processed, err := service.ProcessExpired(now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, processed)
+
+	statusOf := func(milestone models.Milestone) models.MilestoneStatus {
+		require.NoError(t, db.First(&milestone, milestone.ID).Error)
+		return milestone.Status
+	}
+	assert.Equal(t, models.MilestoneStatusProofApproved, statusOf(quorum))
+	assert.Equal(t, models.MilestoneStatusUnderVerification, statusOf(waiting), "자동 완료 시각 전에는 정족수를 기다림")
+	assert.Equal(t, models.MilestoneStatusDisputed, statusOf(silent))
+
+	var completed, expired models.MilestoneVerification
+	require.NoError(t, db.First(&completed, "milestone_id = ?", quorum.ID).Error)
+	assert.True(t, completed.AutoCompleted)
+	require.NoError(t, db.First(&expired, "milestone_id = ?", silent.ID).Error)
+	assert.Equal(t, models.MilestoneVerificationStatusExpired, expired.Status)
+
+	var arbitrationCase models.ArbitrationCase
+	require.NoError(t, db.First(&arbitrationCase, "milestone_id = ?", silent.ID).Error)
+	assert.Equal(t, models.DisputeTypeMilestoneCompletion, arbitrationCase.DisputeType)
+	assert.Equal(t, owner.ID, arbitrationCase.DefendantID)
+
+	var notified int64
+	require.NoError(t, db.Model(&models.Notification{}).Where("user_id = ? AND type = ?", owner.ID, models.NotificationTypeMilestone).Count(&notified).Error)
+	assert.Equal(t, int64(2), notified)
+
+	// 처리된 검증은 다시 처리하지 않음
+	processed, err = service.ProcessExpired(now)
+	require.NoError(t, err)
+	assert.Zero(t, processed)
+
+	// 완료된 검증은 검증인 통계 재계산을 요청하고, 승인 훅은 팔로워 피드에 발행 (훅 고루틴 종료 대기)
+	ctx := context.Background()
+	assert.Equal(t, int64(1), moduleRedis.Client.XLen(ctx, services.ValidatorStatsQueue).Val())
+	assert.Eventually(t, func() bool {
+		return moduleRedis.Client.XLen(ctx, services.ProjectFeedQueue).Val() == 1
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,106 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+
+	"blueprint-module/pkg/models"
+	moduleRedis "blueprint-module/pkg/redis"
+	"blueprint/internal/services"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// WatchlistTestSuite 프로젝트 팔로우 서비스 테스트 슈트
+type WatchlistTestSuite struct {
+	suite.Suite
+	db               *gorm.DB
+	watchlistService *services.WatchlistService
+}
+
+func (suite *WatchlistTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(
+		&models.User{},
+		&models.Project{},
+		&models.Milestone{},
+		&models.ProjectWatch{},
+	))
+	suite.db = db
+
+	redisServer := miniredis.RunT(suite.T())
+	moduleRedis.Client = redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+
+	db.Create(&models.User{ID: 1, Email: "owner@test.com", Username: "owner"})
+	db.Create(&models.User{ID: 2, Email: "fan@test.com", Username: "fan"})
+	db.Create(&models.Project{ID: 10, UserID: 1, Title: "Public", Category: "career", IsPublic: true})
+	db.Create(&models.Project{ID: 11, UserID: 1, Title: "Private", Category: "career"})
+	db.Create(&models.Milestone{ID: 100, ProjectID: 10, Title: "Ship v1"})
+
+	suite.watchlistService = services.NewWatchlistService(db)
+}
+
+func (suite *WatchlistTestSuite) TearDownTest() {
+	moduleRedis.Client = nil
+}
+
+// TestWatchAndUnwatch 팔로우/해제 및 관심 목록
+func (suite *WatchlistTestSuite) TestWatchAndUnwatch() {
+	status, err := suite.watchlistService.Watch(2, 10)
+	suite.Require().NoError(err)
+	suite.True(status.Watching)
+	suite.Equal(int64(1), status.FollowerCount)
+
+	// 중복 팔로우는 멱등
+	status, err = suite.watchlistService.Watch(2, 10)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), status.FollowerCount)
+
+	watchlist, err := suite.watchlistService.GetWatchlist(2)
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, watchlist.Total)
+	suite.Equal("Public", watchlist.Watches[0].Project.Title)
+
+	status, err = suite.watchlistService.Unwatch(2, 10)
+	suite.Require().NoError(err)
+	suite.False(status.Watching)
+	suite.Equal(int64(0), status.FollowerCount)
+}
+
+// TestPrivateProjectNotWatchable 비공개 프로젝트는 소유자만 팔로우 가능
+func (suite *WatchlistTestSuite) TestPrivateProjectNotWatchable() {
+	_, err := suite.watchlistService.Watch(2, 11)
+	suite.ErrorIs(err, services.ErrProjectNotWatchable)
+
+	_, err = suite.watchlistService.Watch(1, 11)
+	suite.NoError(err)
+}
+
+// TestMarketMovePublishesOnlyAboveThreshold 기준가 대비 10% 초과 변동만 피드에 발행
+func (suite *WatchlistTestSuite) TestMarketMovePublishesOnlyAboveThreshold() {
+	ctx := context.Background()
+	feedLength := func() int64 {
+		n, _ := moduleRedis.Client.XLen(ctx, services.ProjectFeedQueue).Result()
+		return n
+	}
+
+	suite.watchlistService.CheckMarketMove(100, "success", 0.50) // 기준가 설정
+	suite.watchlistService.CheckMarketMove(100, "success", 0.54) // +8%
+	suite.Equal(int64(0), feedLength())
+
+	suite.watchlistService.CheckMarketMove(100, "success", 0.60) // +20%
+	suite.Equal(int64(1), feedLength())
+
+	// 쿨다운 동안은 추가 변동이 있어도 발행하지 않음
+	suite.watchlistService.CheckMarketMove(100, "success", 0.30)
+	suite.Equal(int64(1), feedLength())
+}
+
+func TestWatchlistTestSuite(t *testing.T) {
+	suite.Run(t, new(WatchlistTestSuite))
+}
@@ -0,0 +1,72 @@
+package unit_test
+
+import (
+	"testing"
+
+	"blueprint-module/pkg/models"
+	"blueprint/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestWebhookEndpointLifecycle(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Project{}, &models.WebhookEndpoint{}, &models.WebhookDelivery{}))
+	db.Create(&models.User{ID: 1, Email: "owner@test.com", Username: "owner"})
+	db.Create(&models.User{ID: 2, Email: "other@test.com", Username: "other"})
+	db.Create(&models.Project{ID: 1, UserID: 1, Title: "Project"})
+
+	webhookService := services.NewWebhookService(db, "test-secret")
+
+	created, err := webhookService.CreateEndpoint(1, &models.CreateWebhookEndpointRequest{
+		URL:    "https://example.com/hooks",
+		Events: []models.WebhookEventType{models.WebhookEventSlashApproved, models.WebhookEventTradeExecuted},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "trade.executed,slash.approved", created.Endpoint.Events)
+	assert.Contains(t, created.Secret, "whsec_")
+	assert.True(t, created.Endpoint.HasEvent(models.WebhookEventTradeExecuted))
+	assert.False(t, created.Endpoint.HasEvent(models.WebhookEventMilestoneResolved))
+	assert.True(t, created.Endpoint.HasEvent(models.WebhookEventPing))
+
+	// 지원하지 않는 이벤트, 잘못된 URL
+	_, err = webhookService.CreateEndpoint(1, &models.CreateWebhookEndpointRequest{
+		URL:    "https://example.com/hooks",
+		Events: []models.WebhookEventType{"order.created"},
+	})
+	assert.ErrorIs(t, err, services.ErrWebhookInvalidEvent)
+	_, err = webhookService.CreateEndpoint(1, &models.CreateWebhookEndpointRequest{
+		URL:    "ftp://example.com",
+		Events: []models.WebhookEventType{models.WebhookEventTradeExecuted},
+	})
+	assert.ErrorIs(t, err, services.ErrWebhookInvalidURL)
+
+	// 프로젝트 웹훅은 소유자만
+	projectID := uint(1)
+	_, err = webhookService.CreateEndpoint(2, &models.CreateWebhookEndpointRequest{
+		URL:       "https://example.com/hooks",
+		ProjectID: &projectID,
+		Events:    []models.WebhookEventType{models.WebhookEventMilestoneResolved},
+	})
+	assert.ErrorIs(t, err, services.ErrWebhookNotProjectOwner)
+
+	// 재활성화 시 연속 실패 초기화
+	db.Model(created.Endpoint).Updates(map[string]interface{}{"active": false, "consecutive_failures": 10})
+	active := true
+	updated, err := webhookService.UpdateEndpoint(1, created.Endpoint.ID, &models.UpdateWebhookEndpointRequest{Active: &active})
+	require.NoError(t, err)
+	assert.True(t, updated.Active)
+	assert.Equal(t, 0, updated.ConsecutiveFailures)
+
+	// 다른 사용자는 접근 불가
+	_, err = webhookService.RotateSecret(2, created.Endpoint.ID)
+	assert.ErrorIs(t, err, services.ErrWebhookEndpointNotFound)
+
+	rotated, err := webhookService.RotateSecret(1, created.Endpoint.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, created.Secret, rotated.Secret)
+}
@@ -69,7 +69,7 @@ export default function AuthModal({ isOpen, onClose }: AuthModalProps) {
 
     setIsLoading(true);
     try {
-      const response = await apiClient.verifyMagicLink({ code });
+      const response = await apiClient.verifyMagicLink({ email, code });
 
       if (response.success && response.data) {
         notification.success({
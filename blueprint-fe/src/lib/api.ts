@@ -151,6 +151,7 @@ class ApiClient {
 
   // 매직링크 인증
   async verifyMagicLink(data: {
+    email: string;
     code: string;
   }): Promise<ApiResponse<{ token: string; user: User }>> {
     return this.request("/auth/verify-magic-link", {
@@ -0,0 +1,107 @@
+import type { OrderBook, OrderBookLevel } from "../types";
+
+// 서버가 보내는 가격 레벨 (REST/SSE 공통)
+export interface OrderBookLevelPayload {
+  price: number;
+  quantity: number;
+  count: number;
+}
+
+export interface OrderBookSnapshotPayload {
+  milestone_id: number;
+  option_id: string;
+  sequence: number;
+  bids: OrderBookLevelPayload[];
+  asks: OrderBookLevelPayload[];
+  spread: number;
+}
+
+export interface OrderBookLevelChange {
+  side: "bid" | "ask";
+  action: "add" | "modify" | "remove";
+  price: number;
+  quantity: number;
+  count: number;
+}
+
+export interface OrderBookDeltaPayload {
+  milestone_id: number;
+  option_id: string;
+  sequence: number;
+  prev_sequence: number;
+  changes: OrderBookLevelChange[];
+}
+
+const toLevel = (level: OrderBookLevelPayload): OrderBookLevel => ({
+  price: level.price,
+  quantity: level.quantity,
+  orders: level.count,
+});
+
+// 전체 스냅샷 → 화면용 호가창
+export const orderBookFromSnapshot = (
+  snapshot: OrderBookSnapshotPayload,
+  previous: OrderBook | null
+): OrderBook => ({
+  milestone_id: snapshot.milestone_id,
+  option_id: snapshot.option_id,
+  bids: snapshot.bids.map(toLevel),
+  asks: snapshot.asks.map(toLevel),
+  spread: snapshot.spread,
+  last_price: previous?.last_price ?? 0,
+  volume_24h: previous?.volume_24h ?? 0,
+  sequence: snapshot.sequence,
+  timestamp: new Date().toISOString(),
+});
+
+const applySide = (
+  levels: OrderBookLevel[],
+  changes: OrderBookLevelChange[],
+  descending: boolean
+): OrderBookLevel[] => {
+  const byPrice = new Map(levels.map((level) => [level.price, level]));
+  for (const change of changes) {
+    if (change.action === "remove") {
+      byPrice.delete(change.price);
+    } else {
+      byPrice.set(change.price, {
+        price: change.price,
+        quantity: change.quantity,
+        orders: change.count,
+      });
+    }
+  }
+  return [...byPrice.values()].sort((a, b) =>
+    descending ? b.price - a.price : a.price - b.price
+  );
+};
+
+// 증분 적용 (시퀀스가 이어지지 않으면 null → 스냅샷을 다시 받아야 함)
+export const applyOrderBookDelta = (
+  book: OrderBook | null,
+  delta: OrderBookDeltaPayload
+): OrderBook | null => {
+  if (!book || book.sequence === undefined) return null;
+  if (delta.sequence <= book.sequence) return book; // 스냅샷에 이미 반영된 증분
+  if (delta.prev_sequence !== book.sequence) return null;
+
+  const bids = applySide(
+    book.bids,
+    delta.changes.filter((change) => change.side === "bid"),
+    true
+  );
+  const asks = applySide(
+    book.asks,
+    delta.changes.filter((change) => change.side === "ask"),
+    false
+  );
+
+  return {
+    ...book,
+    bids,
+    asks,
+    spread: bids.length > 0 && asks.length > 0 ? asks[0].price - bids[0].price : 0,
+    sequence: delta.sequence,
+    timestamp: new Date().toISOString(),
+  };
+};
@@ -16,6 +16,12 @@ import OrderBook from "../components/OrderBook";
 import ThemeToggle from "../components/ThemeToggle";
 import TradingPanel from "../components/TradingPanel";
 import { apiClient } from "../lib/api";
+import {
+  applyOrderBookDelta,
+  orderBookFromSnapshot,
+  type OrderBookDeltaPayload,
+  type OrderBookSnapshotPayload,
+} from "../lib/orderbook";
 import type {
   CreateOrderRequest,
   Milestone,
@@ -43,6 +49,7 @@ interface SSEMessage {
     | "price_change"
     | "order_update"
     | "orderbook_update"
+    | "orderbook_delta"
     | "trade"
     | "ping"
     | "error";
@@ -69,6 +76,7 @@ const PolymarketTradingPage: React.FC = () => {
   // Trading state
   const [selectedOption, setSelectedOption] = useState<string>("");
   const [orderBook, setOrderBook] = useState<OrderBookType | null>(null);
+  const orderBookRef = useRef<OrderBookType | null>(null);
   const [recentTrades, setRecentTrades] = useState<Trade[]>([]);
   const [chartData, setChartData] = useState<ChartDataPoint[]>([]);
   const [orderLoading, setOrderLoading] = useState(false);
@@ -78,6 +86,10 @@ const PolymarketTradingPage: React.FC = () => {
   const [isSSEConnected, setIsSSEConnected] = useState(false);
   const sseRef = useRef<EventSource | null>(null);
 
+  useEffect(() => {
+    orderBookRef.current = orderBook;
+  }, [orderBook]);
+
   // Load initial data
   useEffect(() => {
     if (!projectId || !milestoneId) {
@@ -168,7 +180,12 @@ const PolymarketTradingPage: React.FC = () => {
       ]);
 
       if (orderBookRes.success && orderBookRes.data) {
-        setOrderBook(orderBookRes.data.order_book);
+        const snapshot = orderBookFromSnapshot(
+          orderBookRes.data.order_book as unknown as OrderBookSnapshotPayload,
+          null
+        );
+        orderBookRef.current = snapshot;
+        setOrderBook(snapshot);
 
         // Extract current market price from order book or price history
         const orderBookData = orderBookRes.data.order_book;
@@ -381,39 +398,28 @@ const PolymarketTradingPage: React.FC = () => {
         }
         break;
       case "orderbook_update":
-        // Handle real-time order book updates
+        // 전체 호가 스냅샷 (주기적으로 전송, 증분 누락 시 기준점)
         if (message.data) {
-          const orderBookData = message.data as {
-            milestone_id: number;
-            option_id: string;
-            buy_orders: Array<{ price: number; quantity: number }>;
-            sell_orders: Array<{ price: number; quantity: number }>;
-          };
-
-          // Only update if it's for the currently selected option
-          if (orderBookData.option_id === selectedOption) {
-            setOrderBook({
-              milestone_id: orderBookData.milestone_id,
-              option_id: orderBookData.option_id,
-              bids: orderBookData.buy_orders.map((order) => ({
-                price: order.price,
-                quantity: order.quantity,
-                orders: 1,
-              })),
-              asks: orderBookData.sell_orders.map((order) => ({
-                price: order.price,
-                quantity: order.quantity,
-                orders: 1,
-              })),
-              spread: 0,
-              last_price: 0,
-              volume_24h: 0,
-              timestamp: new Date().toISOString(),
-            });
-
-            console.log(
-              `📊 Real-time order book update for ${orderBookData.option_id}`
-            );
+          const snapshot = message.data as OrderBookSnapshotPayload;
+          if (snapshot.option_id === selectedOption) {
+            const next = orderBookFromSnapshot(snapshot, orderBookRef.current);
+            orderBookRef.current = next;
+            setOrderBook(next);
+          }
+        }
+        break;
+      case "orderbook_delta":
+        // 가격 레벨 증분 (시퀀스가 끊기면 REST로 스냅샷을 다시 받음)
+        if (message.data) {
+          const delta = message.data as OrderBookDeltaPayload;
+          if (delta.option_id === selectedOption) {
+            const next = applyOrderBookDelta(orderBookRef.current, delta);
+            if (next) {
+              orderBookRef.current = next;
+              setOrderBook(next);
+            } else {
+              loadMarketDataForOption(selectedOption);
+            }
           }
         }
         break;
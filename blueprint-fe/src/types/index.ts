@@ -846,6 +846,7 @@ export interface OrderBook {
   spread: number; // 스프레드
   last_price: number; // 최종 거래가
   volume_24h: number; // 24시간 거래량
+  sequence?: number; // 주문장 시퀀스 (SSE 증분 동기화용)
   timestamp: string;
 }
 
@@ -4,6 +4,8 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -24,6 +26,10 @@ type DatabaseConfig struct {
 	Password string
 	Name     string
 	SSLMode  string
+
+	// 읽기 복제본 (host 또는 host:port, 비우면 기본 DB로 읽음)
+	ReplicaHosts  []string
+	ReplicaMaxLag time.Duration // 이 이상 뒤처진 복제본은 읽기 대상에서 제외 (0이면 확인 안 함)
 }
 
 type JWTConfig struct {
@@ -42,10 +48,24 @@ type OpenAIConfig struct {
 	Model  string
 }
 
+// AnthropicConfig Anthropic(Claude) 설정
+type AnthropicConfig struct {
+	APIKey string
+	Model  string
+}
+
+// OllamaConfig 로컬 Ollama 설정
+type OllamaConfig struct {
+	BaseURL string
+	Model   string
+}
+
 // AIConfig AI 전반적인 설정
 type AIConfig struct {
-	Provider string // openai, mock, claude, gemini
-	OpenAI   OpenAIConfig
+	Provider  string // openai, claude(anthropic), ollama, mock
+	OpenAI    OpenAIConfig
+	Anthropic AnthropicConfig
+	Ollama    OllamaConfig
 }
 
 // RedisConfig Redis 설정
@@ -90,10 +110,15 @@ type GitHubOAuthConfig struct {
 	ClientSecret string `json:"client_secret"`
 	RedirectURL  string `json:"redirect_url"`
 	Scopes       string `json:"scopes"`
+	WebhookURL   string `json:"webhook_url"` // 저장소 웹훅 수신 주소 (GitHub에서 접근 가능해야 함)
 }
 
 // LoadConfig .env 파일을 로드하고 설정을 반환합니다 🔧
-func LoadConfig() *Config {
+//
+// 비밀 값(DB/Redis 비밀번호, JWT, OAuth/AI 키)은 SecretLoader로 읽는다:
+// KEY_FILE(파일) → KEY(환경변수, "vault:경로#필드"면 Vault 조회) → 기본값 순.
+// 값 검증은 Validate에서 한다.
+func LoadConfig() (*Config, error) {
 	// .env 파일 로드 (파일이 없어도 오류 없이 진행)
 	if err := godotenv.Load(); err != nil {
 		log.Println("📁 .env 파일을 찾을 수 없습니다. 시스템 환경변수를 사용합니다.")
@@ -101,42 +126,49 @@ func LoadConfig() *Config {
 		log.Println("✅ .env 파일을 성공적으로 로드했습니다.")
 	}
 
-	return &Config{
+	secrets := NewSecretLoader()
+	apiPublicURL := getEnv("API_PUBLIC_URL", "http://localhost:8080")
+
+	cfg := &Config{
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
 			Port:     getEnv("DB_PORT", "5432"),
 			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "password"),
+			Password: secrets.Get("DB_PASSWORD", "password"),
 			Name:     getEnv("DB_NAME", "blueprint"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+
+			ReplicaHosts:  getEnvAsList("DB_REPLICA_HOSTS"),
+			ReplicaMaxLag: time.Duration(getEnvAsInt("DB_REPLICA_MAX_LAG_SECONDS", 30)) * time.Second,
 		},
 		JWT: JWTConfig{
-			Secret: getEnv("JWT_SECRET", "your-super-secret-jwt-key-change-this-in-production"),
+			Secret: secrets.Get("JWT_SECRET", InsecureDefaultSecret),
 		},
 		OAuth: OAuthConfig{
 			Google: GoogleOAuthConfig{
 				ClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
-				ClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
+				ClientSecret: secrets.Get("GOOGLE_CLIENT_SECRET", ""),
 				RedirectURL:  getEnv("GOOGLE_REDIRECT_URL", "http://localhost:8080/api/v1/auth/google/callback"),
 				Scopes:       getEnv("GOOGLE_SCOPES", "profile email"),
 			},
 			LinkedIn: LinkedInOAuthConfig{
 				ClientID:     getEnv("LINKEDIN_CLIENT_ID", ""),
-				ClientSecret: getEnv("LINKEDIN_CLIENT_SECRET", ""),
+				ClientSecret: secrets.Get("LINKEDIN_CLIENT_SECRET", ""),
 				RedirectURL:  getEnv("LINKEDIN_REDIRECT_URL", "http://localhost:8080/api/v1/auth/linkedin/callback"),
 				Scopes:       getEnv("LINKEDIN_SCOPES", "r_liteprofile r_emailaddress"),
 			},
 			Twitter: TwitterOAuthConfig{
 				ClientID:     getEnv("TWITTER_CLIENT_ID", ""),
-				ClientSecret: getEnv("TWITTER_CLIENT_SECRET", ""),
+				ClientSecret: secrets.Get("TWITTER_CLIENT_SECRET", ""),
 				RedirectURL:  getEnv("TWITTER_REDIRECT_URL", "http://localhost:8080/api/v1/auth/twitter/callback"),
 				Scopes:       getEnv("TWITTER_SCOPES", "tweet.read users.read"),
 			},
 			GitHub: GitHubOAuthConfig{
 				ClientID:     getEnv("GITHUB_CLIENT_ID", ""),
-				ClientSecret: getEnv("GITHUB_CLIENT_SECRET", ""),
+				ClientSecret: secrets.Get("GITHUB_CLIENT_SECRET", ""),
 				RedirectURL:  getEnv("GITHUB_REDIRECT_URL", "http://localhost:8080/api/v1/auth/github/callback"),
-				Scopes:       getEnv("GITHUB_SCOPES", "user:email"),
+				Scopes:       getEnv("GITHUB_SCOPES", "read:user user:email admin:repo_hook"), // 저장소 웹훅 등록 권한 포함
+				WebhookURL:   getEnv("GITHUB_WEBHOOK_URL", apiPublicURL+"/api/v1/webhooks/github"),
 			},
 		},
 		Server: ServerConfig{
@@ -147,17 +179,30 @@ func LoadConfig() *Config {
 		AI: AIConfig{
 			Provider: getEnv("AI_PROVIDER", "mock"),
 			OpenAI: OpenAIConfig{
-				APIKey: getEnv("OPENAI_API_KEY", ""),
+				APIKey: secrets.Get("OPENAI_API_KEY", ""),
 				Model:  getEnv("OPENAI_MODEL", "gpt-4o-mini"),
 			},
+			Anthropic: AnthropicConfig{
+				APIKey: secrets.Get("ANTHROPIC_API_KEY", ""),
+				Model:  getEnv("ANTHROPIC_MODEL", "claude-3-5-haiku-latest"),
+			},
+			Ollama: OllamaConfig{
+				BaseURL: getEnv("OLLAMA_BASE_URL", "http://localhost:11434"),
+				Model:   getEnv("OLLAMA_MODEL", "llama3.1"),
+			},
 		},
 		Redis: RedisConfig{
 			Host:     getEnv("REDIS_HOST", "localhost"),
 			Port:     getEnv("REDIS_PORT", "6379"),
-			Password: getEnv("REDIS_PASSWORD", ""),
+			Password: secrets.Get("REDIS_PASSWORD", ""),
 			DB:       getEnvAsInt("REDIS_DB", 0),
 		},
 	}
+
+	if err := secrets.Err(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
 }
 
 // getEnv 환경변수를 가져오거나 기본값을 반환합니다
@@ -168,6 +213,17 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvAsList 쉼표로 구분된 환경변수를 목록으로 가져옵니다
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 // getEnvAsInt 환경변수를 정수로 가져오거나 기본값을 반환합니다
 func getEnvAsInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrSecretUnavailable 비밀 값 파일/Vault 조회 실패
+var ErrSecretUnavailable = errors.New("secret unavailable")
+
+// vaultPrefix 환경변수 값이 이 접두사로 시작하면 Vault에서 읽음 ("vault:secret/data/blueprint#jwt_secret")
+const vaultPrefix = "vault:"
+
+// SecretLoader 비밀 값을 파일 → 환경변수 → Vault 참조 순으로 읽는 로더
+//
+//   - KEY_FILE이 있으면 파일 내용을 사용 (Docker/Kubernetes secret 마운트)
+//   - 없으면 KEY 환경변수, 값이 "vault:<경로>#<필드>"면 VAULT_ADDR/VAULT_TOKEN으로 조회
+//   - 둘 다 없으면 기본값
+//
+// 조회 실패는 즉시 중단하지 않고 모아 두었다가 Err로 한 번에 돌려준다.
+type SecretLoader struct {
+	vaultAddr  string
+	vaultToken string
+	client     *http.Client
+	cache      map[string]map[string]string // Vault 경로별 응답 (같은 경로의 여러 필드는 한 번만 조회)
+	errs       []error
+}
+
+// NewSecretLoader VAULT_ADDR / VAULT_TOKEN(또는 VAULT_TOKEN_FILE) 환경으로 로더 생성
+func NewSecretLoader() *SecretLoader {
+	loader := &SecretLoader{
+		vaultAddr: strings.TrimSuffix(os.Getenv("VAULT_ADDR"), "/"),
+		client:    &http.Client{Timeout: 5 * time.Second},
+		cache:     make(map[string]map[string]string),
+	}
+	loader.vaultToken = loader.fromFile("VAULT_TOKEN", os.Getenv("VAULT_TOKEN"))
+	return loader
+}
+
+// Get 비밀 값 조회 (실패 시 기본값을 돌려주고 오류는 Err에 모음)
+func (l *SecretLoader) Get(key, defaultValue string) string {
+	value := l.fromFile(key, os.Getenv(key))
+	if value == "" {
+		return defaultValue
+	}
+	if strings.HasPrefix(value, vaultPrefix) {
+		resolved, err := l.fromVault(strings.TrimPrefix(value, vaultPrefix))
+		if err != nil {
+			l.errs = append(l.errs, fmt.Errorf("%w: %s: %v", ErrSecretUnavailable, key, err))
+			return defaultValue
+		}
+		return resolved
+	}
+	return value
+}
+
+// Err 지금까지 실패한 비밀 값 조회 오류
+func (l *SecretLoader) Err() error {
+	return errors.Join(l.errs...)
+}
+
+// fromFile KEY_FILE이 설정되어 있으면 파일 내용(끝 개행 제거), 아니면 fallback
+func (l *SecretLoader) fromFile(key, fallback string) string {
+	path := os.Getenv(key + "_FILE")
+	if path == "" {
+		return fallback
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%w: %s_FILE: %v", ErrSecretUnavailable, key, err))
+		return fallback
+	}
+	return strings.TrimRight(string(content), "\r\n")
+}
+
+// fromVault "경로#필드" 참조를 Vault KV(v1/v2)에서 조회
+func (l *SecretLoader) fromVault(reference string) (string, error) {
+	path, field, found := strings.Cut(reference, "#")
+	if !found || path == "" || field == "" {
+		return "", fmt.Errorf("vault 참조 형식은 vault:<경로>#<필드> 입니다: %q", reference)
+	}
+	if l.vaultAddr == "" || l.vaultToken == "" {
+		return "", errors.New("VAULT_ADDR / VAULT_TOKEN이 설정되지 않았습니다")
+	}
+
+	data, ok := l.cache[path]
+	if !ok {
+		var err error
+		if data, err = l.readVaultPath(path); err != nil {
+			return "", err
+		}
+		l.cache[path] = data
+	}
+
+	value, ok := data[field]
+	if !ok {
+		return "", fmt.Errorf("vault %s에 %s 필드가 없습니다", path, field)
+	}
+	return value, nil
+}
+
+func (l *SecretLoader) readVaultPath(path string) (map[string]string, error) {
+	req, err := http.NewRequest(http.MethodGet, l.vaultAddr+"/v1/"+strings.TrimPrefix(path, "/"), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", l.vaultToken)
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault %s 응답 %d", path, resp.StatusCode)
+	}
+
+	// KV v2는 data.data, KV v1은 data에 값이 들어 있다
+	var body struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("vault %s 응답 파싱 실패: %v", path, err)
+	}
+	fields := body.Data
+	if nested, ok := body.Data["data"]; ok {
+		var inner map[string]json.RawMessage
+		if err := json.Unmarshal(nested, &inner); err == nil {
+			fields = inner
+		}
+	}
+
+	values := make(map[string]string, len(fields))
+	for name, raw := range fields {
+		var text string
+		if err := json.Unmarshal(raw, &text); err != nil {
+			text = string(raw) // 숫자/불리언은 그대로 문자열로
+		}
+		values[name] = text
+	}
+	return values, nil
+}
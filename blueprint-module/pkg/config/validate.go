@@ -0,0 +1,124 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidConfig 필수 값 누락 또는 잘못된 설정
+var ErrInvalidConfig = errors.New("invalid config")
+
+// InsecureDefaultSecret 개발용 기본 JWT 비밀 값 (release 모드에서는 거부)
+const InsecureDefaultSecret = "your-super-secret-jwt-key-change-this-in-production"
+
+// minReleaseSecretLength release 모드에서 요구하는 최소 비밀 값 길이
+const minReleaseSecretLength = 32
+
+// Problems 설정 문제 목록 (필드 이름: 사유)
+type Problems []string
+
+// Require 값이 비어 있으면 문제로 기록
+func (p *Problems) Require(name, value string) {
+	if strings.TrimSpace(value) == "" {
+		*p = append(*p, name+": 필수 값입니다")
+	}
+}
+
+// Add 문제 기록
+func (p *Problems) Add(name, format string, args ...interface{}) {
+	*p = append(*p, name+": "+fmt.Sprintf(format, args...))
+}
+
+// Port 1~65535 숫자인지 확인
+func (p *Problems) Port(name, value string) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		p.Add(name, "올바른 포트가 아닙니다 (%q)", value)
+	}
+}
+
+// Secret release 모드에서 개발용 기본값이나 짧은 비밀 값을 거부
+func (p *Problems) Secret(name, value string, release bool) {
+	p.Require(name, value)
+	if !release || value == "" {
+		return
+	}
+	if value == InsecureDefaultSecret {
+		p.Add(name, "release 모드에서 개발용 기본값을 쓸 수 없습니다")
+	} else if len(value) < minReleaseSecretLength {
+		p.Add(name, "release 모드에서는 %d자 이상이어야 합니다", minReleaseSecretLength)
+	}
+}
+
+// Err 문제가 있으면 ErrInvalidConfig로 감싼 하나의 오류
+func (p Problems) Err() error {
+	if len(p) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w:\n  - %s", ErrInvalidConfig, strings.Join(p, "\n  - "))
+}
+
+// IsRelease GIN_MODE=release 여부
+func (c *Config) IsRelease() bool {
+	return c.Server.Mode == "release"
+}
+
+// Problems 공통 설정 검사 (서버/워커가 각자 검사를 덧붙임)
+func (c *Config) Problems() Problems {
+	var problems Problems
+
+	problems.Require("DB_HOST", c.Database.Host)
+	problems.Require("DB_USER", c.Database.User)
+	problems.Require("DB_NAME", c.Database.Name)
+	problems.Port("DB_PORT", c.Database.Port)
+	if c.Database.ReplicaMaxLag < 0 {
+		problems.Add("DB_REPLICA_MAX_LAG_SECONDS", "0 이상이어야 합니다")
+	}
+
+	problems.Secret("JWT_SECRET", c.JWT.Secret, c.IsRelease())
+
+	switch c.Server.Mode {
+	case "debug", "release", "test":
+	default:
+		problems.Add("GIN_MODE", "debug, release, test 중 하나여야 합니다 (%q)", c.Server.Mode)
+	}
+	problems.Port("PORT", c.Server.Port)
+	problems.Require("FRONTEND_URL", c.Server.FrontendURL)
+
+	problems.Require("REDIS_HOST", c.Redis.Host)
+	problems.Port("REDIS_PORT", c.Redis.Port)
+
+	switch strings.ToLower(c.AI.Provider) {
+	case "openai":
+		problems.Require("OPENAI_API_KEY", c.AI.OpenAI.APIKey)
+	case "claude", "anthropic":
+		problems.Require("ANTHROPIC_API_KEY", c.AI.Anthropic.APIKey)
+	case "ollama":
+		problems.Require("OLLAMA_BASE_URL", c.AI.Ollama.BaseURL)
+	case "mock":
+	default:
+		problems.Add("AI_PROVIDER", "openai, claude, ollama, mock 중 하나여야 합니다 (%q)", c.AI.Provider)
+	}
+
+	// OAuth는 제공업체별 선택 사항이지만 client id만 있고 secret이 없으면 로그인이 런타임에 실패한다
+	oauthPairs := []struct{ provider, id, secret string }{
+		{"GOOGLE", c.OAuth.Google.ClientID, c.OAuth.Google.ClientSecret},
+		{"LINKEDIN", c.OAuth.LinkedIn.ClientID, c.OAuth.LinkedIn.ClientSecret},
+		{"TWITTER", c.OAuth.Twitter.ClientID, c.OAuth.Twitter.ClientSecret},
+		{"GITHUB", c.OAuth.GitHub.ClientID, c.OAuth.GitHub.ClientSecret},
+	}
+	for _, pair := range oauthPairs {
+		if pair.id != "" {
+			problems.Require(pair.provider+"_CLIENT_SECRET", pair.secret)
+		}
+	}
+
+	return problems
+}
+
+// Validate 공통 설정 검사 (문제를 모두 모아 하나의 오류로 반환)
+func (c *Config) Validate() error {
+	return c.Problems().Err()
+}
@@ -8,6 +8,7 @@ import (
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 )
 
@@ -47,7 +48,29 @@ func AutoMigrate() error {
 	}
 
 	// 자동 마이그레이션 실행
-	err := DB.AutoMigrate(
+	err := DB.AutoMigrate(Models()...)
+
+	if err != nil {
+		return fmt.Errorf("failed to auto migrate: %w", err)
+	}
+
+	// 가격 틱 컬럼 도입 이전 주문/체결 백필
+	if err := backfillPriceTicks(); err != nil {
+		log.Printf("Warning: price tick backfill failed: %v", err)
+	}
+
+	// 태그 인덱스 도입 이전 프로젝트 백필
+	if err := backfillProjectTags(); err != nil {
+		log.Printf("Warning: project tag backfill failed: %v", err)
+	}
+
+	log.Println("Database migration completed successfully")
+	return nil
+}
+
+// Models AutoMigrate 대상 모델 전체 (테스트용 DB도 같은 목록으로 스키마 생성)
+func Models() []interface{} {
+	return []interface{}{
 		// 👤 User 관련 모델
 		&models.User{},
 		&models.UserProfile{},
@@ -55,6 +78,9 @@ func AutoMigrate() error {
 		
 		// 🏗️ Project 관련 모델
 		&models.Project{},
+		&models.ProjectTag{},
+		&models.ProjectMember{},
+		&models.ProjectInvite{},
 		&models.Milestone{},
 		
 		// 🔍 마일스톤 증명 및 검증 시스템 모델
@@ -62,6 +88,13 @@ func AutoMigrate() error {
 		&models.ProofValidator{},
 		&models.ProofDispute{},
 		&models.MilestoneVerification{},
+		&models.MilestoneStatusHistory{},
+		&models.MilestoneDependency{},
+		&models.ProjectStatsCache{},
+		&models.GlobalStatsCache{},
+		&models.UserStatsCache{},
+		&models.OrderBookDepthSnapshot{},
+		&models.AIUsageLog{},
 		&models.ValidatorQualification{},
 		&models.VerificationReward{},
 		
@@ -70,12 +103,18 @@ func AutoMigrate() error {
 		&models.ArbitrationVote{},
 		&models.JurorQualification{},
 		&models.ArbitrationReward{},
+		&models.ArbitrationEvidence{},
+		&models.ArbitrationVoteNonce{},
 		
 		// 💎 멘토 스테이킹 및 슬래싱 시스템 모델
 		&models.MentorStake{},
 		&models.MentorSlashEvent{},
 		&models.MentorPerformanceMetric{},
 		&models.MentorStakeReward{},
+
+		// 🌱 스테이킹 발행 보상 (멘토/배심원 스테이킹 에포크 적립)
+		&models.StakingEpoch{},
+		&models.StakingEpochReward{},
 		
 		// 💰 Trading 관련 모델
 		&models.Order{},
@@ -84,6 +123,9 @@ func AutoMigrate() error {
 		&models.MarketData{},
 		&models.UserWallet{},
 		&models.PriceHistory{},
+		&models.PriceCandle{},
+		&models.Parlay{},
+		&models.ParlayLeg{},
 		
 		// 🎁 Token Economy 모델
 		&models.StakingPool{},
@@ -101,17 +143,153 @@ func AutoMigrate() error {
 		&models.MentorAction{},
 		&models.MentorPool{},
 		&models.MentorReputation{},
+		&models.MentorSessionFeedback{},
+
+		// 💬 멘토링/분쟁 대화 (메시지, 읽음 표시, 신고)
+		&models.Conversation{},
+		&models.ConversationParticipant{},
+		&models.Message{},
+		&models.MessageReport{},
+
+		// 🤝 분쟁 조정 (배심원 중재 전 합의 협상)
+		&models.Mediation{},
+		&models.MediationOffer{},
+
+		// 🛡️ 마일스톤 보험 (카테고리별 풀)
+		&models.InsurancePool{},
+		&models.InsurancePolicy{},
+		&models.InsuranceClaim{},
+
+		// 👥 복사 거래 (리더 체결 자동 추종)
+		&models.CopyLeader{},
+		&models.CopyFollow{},
+		&models.CopyTradeExecution{},
+
+		// 📉 숏 증거금 강제 청산 기록
+		&models.MarginLiquidation{},
+
+		// 💱 추가 결제 통화 지갑 계정/환율
+		&models.WalletAccount{},
+		&models.ExchangeRate{},
+		&models.CurrencySwap{},
 		
 		// 🔗 기타 모델
 		&models.MagicLink{},
+		&models.MagicLinkEvent{},
+		&models.UserIdentity{},
+		&models.AccountMerge{},
+		&models.AccountDeletion{},
 		&models.ActivityLog{},
-	)
+		
+		// 🔔 알림 모델
+		&models.Notification{},
+		&models.PushSubscription{},
 
-	if err != nil {
-		return fmt.Errorf("failed to auto migrate: %w", err)
+		// 🔑 API 키 (프로그래매틱 거래)
+		&models.APIKey{},
+
+		// 🛡️ 역할 기반 접근 제어
+		&models.UserRole{},
+
+		// 🪪 본인 인증 (KYC/AML)
+		&models.KYCVerification{},
+
+		// 👀 프로젝트 팔로우 (관심 목록)
+		&models.ProjectWatch{},
+
+		// 🏆 리더보드 캐시
+		&models.LeaderboardEntry{},
+
+		// 🤝 추천 프로그램
+		&models.ReferralCode{},
+		&models.Referral{},
+		&models.ReferralReward{},
+		&models.ReferralPayout{},
+
+		// 🐙 GitHub 연동 (저장소 웹훅 → 마일스톤 증거)
+		&models.GitHubConnection{},
+		&models.GitHubWebhookSubscription{},
+		&models.GitHubWebhookDelivery{},
+
+		// 📮 외부 웹훅 (플랫폼 이벤트 발송)
+		&models.WebhookEndpoint{},
+		&models.WebhookDelivery{},
+
+		// 📉 포트폴리오 일별 스냅샷 (자산 곡선)
+		&models.PositionSnapshot{},
+
+		// 📤 거래 내역 / 개인 데이터 내보내기
+		&models.DataExport{},
+
+		// 📒 지갑 잔액 변동 원장
+		&models.WalletLedgerEntry{},
+
+		// 🚩 기능 플래그 (런타임 토글)
+		&models.FeatureFlag{},
+
+		// 🚧 관리자 거래 중단 (점검 모드/예약 점검)
+		&models.TradingPause{},
+
+		// 🧾 주문/체결/지갑 대사 리포트
+		&models.ReconciliationReport{},
+
+		// 🤝 마일스톤 직접 후원 에스크로
+		&models.FundingEscrow{},
+
+		// 💸 창작자 지급 계좌/지급 요청
+		&models.PayoutAccount{},
+		&models.CreatorPayout{},
+		// 🕵️ 시장 감시 알림
+		&models.SurveillanceAlert{},
+		// 🧘 책임 있는 거래 (일일 한도/휴식/자기 배제)
+		&models.UserTradingLimit{},
+		// 🎁 사용자 간 포지션 이전
+		&models.PositionTransfer{},
+		// 📋 워커 작업 진행 상태
+		&models.Job{},
+		// ⏱️ 주기 작업 일정/실행 이력
+		&models.ScheduledJob{},
+		&models.ScheduledJobRun{},
+	}
+}
+
+// backfillPriceTicks price_ticks가 비어 있는 기존 주문/체결을 float 가격에서 채움
+func backfillPriceTicks() error {
+	for _, table := range []string{"orders", "trades"} {
+		if err := DB.Exec(fmt.Sprintf("UPDATE %s SET price_ticks = ROUND(price * ?) WHERE price_ticks = 0 AND price > 0", table),
+			models.PriceScale).Error; err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	log.Println("Database migration completed successfully")
+// backfillProjectTags 태그 인덱스가 없는 기존 프로젝트의 Tags JSON을 project_tags로 옮김 (잘못된 태그는 건너뜀)
+func backfillProjectTags() error {
+	var projects []models.Project
+	if err := DB.Select("id", "tags").
+		Where("tags IS NOT NULL AND tags <> '' AND tags <> '[]'").
+		Where("NOT EXISTS (SELECT 1 FROM project_tags WHERE project_tags.project_id = projects.id)").
+		Find(&projects).Error; err != nil {
+		return err
+	}
+
+	for _, project := range projects {
+		var rows []models.ProjectTag
+		for _, tag := range project.TagsArray {
+			normalized, err := models.NormalizeProjectTags([]string{tag})
+			if err != nil || len(normalized) == 0 || len(rows) >= models.MaxProjectTags {
+				continue
+			}
+			rows = append(rows, models.ProjectTag{ProjectID: project.ID, Tag: normalized[0]})
+		}
+		if len(rows) == 0 {
+			continue
+		}
+		if err := DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
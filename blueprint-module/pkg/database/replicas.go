@@ -0,0 +1,173 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"blueprint-module/pkg/config"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// ReadDB 읽기 전용 연결 (복제본이 없으면 nil → GetReadDB가 기본 DB 반환)
+//
+// 조회 쿼리마다 정상 복제본을 돌아가며 고르고, 모두 장애/지연이면 기본(쓰기) DB로 읽는다.
+// 트랜잭션과 쓰기는 항상 DB(기본)를 사용한다.
+var ReadDB *gorm.DB
+
+var readPool *replicaPool
+
+// replica 읽기 복제본 연결과 상태
+type replica struct {
+	host    string
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+// replicaPool 정상 복제본을 라운드로빈으로 고르는 gorm.ConnPool (없으면 기본 DB)
+type replicaPool struct {
+	primary  *sql.DB
+	replicas []*replica
+	maxLag   time.Duration
+	next     atomic.Uint64
+}
+
+func (p *replicaPool) pick() *sql.DB {
+	for range p.replicas {
+		candidate := p.replicas[p.next.Add(1)%uint64(len(p.replicas))]
+		if candidate.healthy.Load() {
+			return candidate.db
+		}
+	}
+	return p.primary
+}
+
+func (p *replicaPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return p.pick().PrepareContext(ctx, query)
+}
+
+func (p *replicaPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return p.pick().ExecContext(ctx, query, args...)
+}
+
+func (p *replicaPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return p.pick().QueryContext(ctx, query, args...)
+}
+
+func (p *replicaPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return p.pick().QueryRowContext(ctx, query, args...)
+}
+
+// ConnectReplicas 읽기 복제본 연결 (host 또는 host:port, 계정/DB 이름은 기본 DB와 같음)
+// 연결에 실패한 복제본은 건너뛰고, 연결된 복제본 수를 반환한다
+func ConnectReplicas(cfg *config.Config) (int, error) {
+	if DB == nil {
+		return 0, fmt.Errorf("database connection is nil")
+	}
+	if len(cfg.Database.ReplicaHosts) == 0 {
+		return 0, nil
+	}
+
+	primary, err := DB.DB()
+	if err != nil {
+		return 0, err
+	}
+
+	pool := &replicaPool{primary: primary, maxLag: cfg.Database.ReplicaMaxLag}
+	for _, address := range cfg.Database.ReplicaHosts {
+		host, port, found := strings.Cut(address, ":")
+		if !found {
+			port = cfg.Database.Port
+		}
+		dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
+			host, cfg.Database.User, cfg.Database.Password, cfg.Database.Name, port, cfg.Database.SSLMode)
+
+		conn, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Error)})
+		if err != nil {
+			log.Printf("⚠️ Skipping read replica %s: %v", address, err)
+			continue
+		}
+		sqlDB, err := conn.DB()
+		if err != nil {
+			log.Printf("⚠️ Skipping read replica %s: %v", address, err)
+			continue
+		}
+
+		r := &replica{host: address, db: sqlDB}
+		r.healthy.Store(true)
+		pool.replicas = append(pool.replicas, r)
+	}
+	if len(pool.replicas) == 0 {
+		return 0, nil
+	}
+
+	ReadDB, err = gorm.Open(postgres.New(postgres.Config{Conn: pool}), &gorm.Config{
+		Logger:                 logger.Default.LogMode(logger.Error),
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to open read replica pool: %w", err)
+	}
+	readPool = pool
+
+	log.Printf("Read replicas connected: %d", len(pool.replicas))
+	return len(pool.replicas), nil
+}
+
+// GetReadDB 조회 전용 연결 (복제본이 없으면 기본 DB)
+func GetReadDB() *gorm.DB {
+	if ReadDB != nil {
+		return ReadDB
+	}
+	return DB
+}
+
+// RunReplicaHealthCheck 주기적으로 복제본 상태와 복제 지연을 확인해 장애/지연 복제본을 읽기 대상에서 제외
+func RunReplicaHealthCheck(interval time.Duration) {
+	if readPool == nil {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		for _, r := range readPool.replicas {
+			healthy := r.check(readPool.maxLag)
+			if previous := r.healthy.Swap(healthy); previous != healthy {
+				if healthy {
+					log.Printf("✅ Read replica %s is back in rotation", r.host)
+				} else {
+					log.Printf("⚠️ Read replica %s removed from rotation", r.host)
+				}
+			}
+		}
+	}
+}
+
+// check 연결 확인 + 복제 지연(마지막으로 재생한 트랜잭션 이후 경과 시간) 확인
+func (r *replica) check(maxLag time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if err := r.db.PingContext(ctx); err != nil {
+		return false
+	}
+	if maxLag <= 0 {
+		return true
+	}
+
+	var lagSeconds float64
+	if err := r.db.QueryRowContext(ctx,
+		"SELECT COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)").Scan(&lagSeconds); err != nil {
+		return false
+	}
+	return time.Duration(lagSeconds*float64(time.Second)) <= maxLag
+}
@@ -0,0 +1,122 @@
+package internalapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/health" // 서비스 설정의 healthCheckConfig로 클라이언트 측 헬스 체크 사용
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ServiceName 헬스 체크에 쓰는 서비스 이름
+const ServiceName = "blueprint.internal.v1.InternalService"
+
+// TokenMetadataKey 서비스 간 공유 토큰을 싣는 gRPC 메타데이터 키
+const TokenMetadataKey = "x-internal-token"
+
+// DefaultCallTimeout 호출자가 기한을 정하지 않았을 때 한 번의 호출(재시도 포함) 기한
+const DefaultCallTimeout = 5 * time.Second
+
+// serviceConfig 일시적 실패(UNAVAILABLE, RESOURCE_EXHAUSTED)는 지수 백오프로 최대 4번까지 재시도하고,
+// 헬스 체크가 SERVING이 아닌 서버로는 요청을 보내지 않는다.
+var serviceConfig = fmt.Sprintf(`{
+	"loadBalancingConfig": [{"round_robin": {}}],
+	"healthCheckConfig": {"serviceName": %q},
+	"methodConfig": [{
+		"name": [{"service": %q}],
+		"retryPolicy": {
+			"maxAttempts": 4,
+			"initialBackoff": "0.2s",
+			"maxBackoff": "2s",
+			"backoffMultiplier": 2,
+			"retryableStatusCodes": ["UNAVAILABLE", "RESOURCE_EXHAUSTED"]
+		}
+	}]
+}`, ServiceName, ServiceName)
+
+// Client API 서버 내부 gRPC 클라이언트 (워커/스케줄러용)
+type Client struct {
+	InternalServiceClient
+	conn   *grpc.ClientConn
+	health healthpb.HealthClient
+}
+
+// Dial 내부 API 연결 (연결은 첫 호출 때 맺어지고 끊기면 자동으로 다시 맺음)
+//
+// address는 "host:port" 또는 여러 인스턴스를 돌려 쓰는 "dns:///api-internal:9090" 형태다.
+func Dial(address, token string) (*Client, error) {
+	conn, err := grpc.NewClient(address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(tokenCredentials(token)),
+		grpc.WithDefaultServiceConfig(serviceConfig),
+		grpc.WithUnaryInterceptor(defaultTimeout),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create internal API client for %s: %w", address, err)
+	}
+	return &Client{
+		InternalServiceClient: NewInternalServiceClient(conn),
+		conn:                  conn,
+		health:                healthpb.NewHealthClient(conn),
+	}, nil
+}
+
+// Check 서버 헬스 체크 (SERVING이 아니면 오류)
+func (c *Client) Check(ctx context.Context) error {
+	resp, err := c.health.Check(ctx, &healthpb.HealthCheckRequest{Service: ServiceName})
+	if err != nil {
+		return err
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("internal API is %s", resp.GetStatus())
+	}
+	return nil
+}
+
+// Close 연결 종료
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// tokenCredentials 호출마다 공유 토큰을 메타데이터로 실음 (내부망 평문 연결 허용)
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{TokenMetadataKey: string(t)}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// defaultTimeout 기한 없는 호출이 서버 장애 때 무한히 기다리지 않도록 기본 기한 부여
+func defaultTimeout(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultCallTimeout)
+		defer cancel()
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// TokenInterceptor 서버 측 공유 토큰 검사 (헬스 체크는 토큰 없이 허용)
+func TokenInterceptor(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if info.FullMethod == healthpb.Health_Check_FullMethodName {
+			return handler(ctx, req)
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(TokenMetadataKey)
+		if len(values) == 0 || subtle.ConstantTimeCompare([]byte(values[0]), []byte(token)) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "invalid internal token")
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,621 @@
+// 서비스 간 내부 gRPC 계약 (API 서버가 제공, 워커/스케줄러가 호출)
+//
+// 코드 생성: blueprint-module/pkg/internalapi 에서
+//   protoc --go_out=. --go_opt=paths=source_relative \
+//          --go-grpc_out=. --go-grpc_opt=paths=source_relative internalapi.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.1
+// 	protoc        v4.25.3
+// source: internalapi.proto
+
+package internalapi
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetMarketStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MilestoneId uint32 `protobuf:"varint,1,opt,name=milestone_id,json=milestoneId,proto3" json:"milestone_id,omitempty"`
+}
+
+func (x *GetMarketStatusRequest) Reset() {
+	*x = GetMarketStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internalapi_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMarketStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMarketStatusRequest) ProtoMessage() {}
+
+func (x *GetMarketStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internalapi_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMarketStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetMarketStatusRequest) Descriptor() ([]byte, []int) {
+	return file_internalapi_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetMarketStatusRequest) GetMilestoneId() uint32 {
+	if x != nil {
+		return x.MilestoneId
+	}
+	return 0
+}
+
+type MarketStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MilestoneId     uint32 `protobuf:"varint,1,opt,name=milestone_id,json=milestoneId,proto3" json:"milestone_id,omitempty"`
+	Status          string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                                             // 마일스톤 상태 (funding, active, proof_submitted ...)
+	TradingOpen     bool   `protobuf:"varint,3,opt,name=trading_open,json=tradingOpen,proto3" json:"trading_open,omitempty"`               // 지금 주문 접수 가능 여부
+	Reason          string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`                                             // 닫혀 있으면 사유 (frozen, closed, halted)
+	TradingClosesAt int64  `protobuf:"varint,5,opt,name=trading_closes_at,json=tradingClosesAt,proto3" json:"trading_closes_at,omitempty"` // 거래 마감 시각 (unix 초, 없으면 0)
+	WinningOptionId string `protobuf:"bytes,6,opt,name=winning_option_id,json=winningOptionId,proto3" json:"winning_option_id,omitempty"`  // 판정된 승리 옵션 (미판정이면 빈 값)
+}
+
+func (x *MarketStatus) Reset() {
+	*x = MarketStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internalapi_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MarketStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketStatus) ProtoMessage() {}
+
+func (x *MarketStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_internalapi_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketStatus.ProtoReflect.Descriptor instead.
+func (*MarketStatus) Descriptor() ([]byte, []int) {
+	return file_internalapi_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MarketStatus) GetMilestoneId() uint32 {
+	if x != nil {
+		return x.MilestoneId
+	}
+	return 0
+}
+
+func (x *MarketStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MarketStatus) GetTradingOpen() bool {
+	if x != nil {
+		return x.TradingOpen
+	}
+	return false
+}
+
+func (x *MarketStatus) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *MarketStatus) GetTradingClosesAt() int64 {
+	if x != nil {
+		return x.TradingClosesAt
+	}
+	return 0
+}
+
+func (x *MarketStatus) GetWinningOptionId() string {
+	if x != nil {
+		return x.WinningOptionId
+	}
+	return ""
+}
+
+type GetWalletBalanceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId uint32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *GetWalletBalanceRequest) Reset() {
+	*x = GetWalletBalanceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internalapi_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetWalletBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletBalanceRequest) ProtoMessage() {}
+
+func (x *GetWalletBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internalapi_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetWalletBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_internalapi_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetWalletBalanceRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type WalletBalance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId                 uint32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UsdcBalance            int64  `protobuf:"varint,2,opt,name=usdc_balance,json=usdcBalance,proto3" json:"usdc_balance,omitempty"`
+	UsdcLockedBalance      int64  `protobuf:"varint,3,opt,name=usdc_locked_balance,json=usdcLockedBalance,proto3" json:"usdc_locked_balance,omitempty"`
+	BlueprintBalance       int64  `protobuf:"varint,4,opt,name=blueprint_balance,json=blueprintBalance,proto3" json:"blueprint_balance,omitempty"`
+	BlueprintLockedBalance int64  `protobuf:"varint,5,opt,name=blueprint_locked_balance,json=blueprintLockedBalance,proto3" json:"blueprint_locked_balance,omitempty"`
+}
+
+func (x *WalletBalance) Reset() {
+	*x = WalletBalance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internalapi_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WalletBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletBalance) ProtoMessage() {}
+
+func (x *WalletBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_internalapi_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletBalance.ProtoReflect.Descriptor instead.
+func (*WalletBalance) Descriptor() ([]byte, []int) {
+	return file_internalapi_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WalletBalance) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *WalletBalance) GetUsdcBalance() int64 {
+	if x != nil {
+		return x.UsdcBalance
+	}
+	return 0
+}
+
+func (x *WalletBalance) GetUsdcLockedBalance() int64 {
+	if x != nil {
+		return x.UsdcLockedBalance
+	}
+	return 0
+}
+
+func (x *WalletBalance) GetBlueprintBalance() int64 {
+	if x != nil {
+		return x.BlueprintBalance
+	}
+	return 0
+}
+
+func (x *WalletBalance) GetBlueprintLockedBalance() int64 {
+	if x != nil {
+		return x.BlueprintLockedBalance
+	}
+	return 0
+}
+
+type EnqueueResolutionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MilestoneId uint32 `protobuf:"varint,1,opt,name=milestone_id,json=milestoneId,proto3" json:"milestone_id,omitempty"`
+	RequestedBy string `protobuf:"bytes,2,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"` // 호출한 서비스 이름 (로그용)
+}
+
+func (x *EnqueueResolutionRequest) Reset() {
+	*x = EnqueueResolutionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internalapi_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnqueueResolutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnqueueResolutionRequest) ProtoMessage() {}
+
+func (x *EnqueueResolutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internalapi_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnqueueResolutionRequest.ProtoReflect.Descriptor instead.
+func (*EnqueueResolutionRequest) Descriptor() ([]byte, []int) {
+	return file_internalapi_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EnqueueResolutionRequest) GetMilestoneId() uint32 {
+	if x != nil {
+		return x.MilestoneId
+	}
+	return 0
+}
+
+func (x *EnqueueResolutionRequest) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
+}
+
+type EnqueueResolutionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MilestoneId    uint32 `protobuf:"varint,1,opt,name=milestone_id,json=milestoneId,proto3" json:"milestone_id,omitempty"`
+	Accepted       bool   `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`                                   // 정산 작업을 예약했는지
+	AlreadySettled bool   `protobuf:"varint,3,opt,name=already_settled,json=alreadySettled,proto3" json:"already_settled,omitempty"` // 이미 정산된 마켓
+}
+
+func (x *EnqueueResolutionResponse) Reset() {
+	*x = EnqueueResolutionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internalapi_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnqueueResolutionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnqueueResolutionResponse) ProtoMessage() {}
+
+func (x *EnqueueResolutionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internalapi_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnqueueResolutionResponse.ProtoReflect.Descriptor instead.
+func (*EnqueueResolutionResponse) Descriptor() ([]byte, []int) {
+	return file_internalapi_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EnqueueResolutionResponse) GetMilestoneId() uint32 {
+	if x != nil {
+		return x.MilestoneId
+	}
+	return 0
+}
+
+func (x *EnqueueResolutionResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *EnqueueResolutionResponse) GetAlreadySettled() bool {
+	if x != nil {
+		return x.AlreadySettled
+	}
+	return false
+}
+
+var File_internalapi_proto protoreflect.FileDescriptor
+
+var file_internalapi_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x15, 0x62, 0x6c, 0x75, 0x65, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x2e, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x22, 0x3b, 0x0a, 0x16, 0x47, 0x65,
+	0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x69, 0x6c, 0x65,
+	0x73, 0x74, 0x6f, 0x6e, 0x65, 0x49, 0x64, 0x22, 0xdc, 0x01, 0x0a, 0x0c, 0x4d, 0x61, 0x72, 0x6b,
+	0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x69, 0x6c, 0x65,
+	0x73, 0x74, 0x6f, 0x6e, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b,
+	0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6f,
+	0x70, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x64, 0x69,
+	0x6e, 0x67, 0x4f, 0x70, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x2a,
+	0x0a, 0x11, 0x74, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x73,
+	0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x64, 0x69,
+	0x6e, 0x67, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x73, 0x41, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x77, 0x69,
+	0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x77, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x32, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x57, 0x61, 0x6c,
+	0x6c, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0xe2, 0x01, 0x0a, 0x0d, 0x57,
+	0x61, 0x6c, 0x6c, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x17, 0x0a, 0x07,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x75, 0x73, 0x64, 0x63, 0x5f, 0x62, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x75, 0x73, 0x64,
+	0x63, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x75, 0x73, 0x64, 0x63,
+	0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x75, 0x73, 0x64, 0x63, 0x4c, 0x6f, 0x63, 0x6b, 0x65,
+	0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x62, 0x6c, 0x75, 0x65,
+	0x70, 0x72, 0x69, 0x6e, 0x74, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x10, 0x62, 0x6c, 0x75, 0x65, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x42, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x38, 0x0a, 0x18, 0x62, 0x6c, 0x75, 0x65, 0x70, 0x72, 0x69,
+	0x6e, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x16, 0x62, 0x6c, 0x75, 0x65, 0x70, 0x72, 0x69,
+	0x6e, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x22,
+	0x60, 0x0a, 0x18, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6d,
+	0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0b, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x21,
+	0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42,
+	0x79, 0x22, 0x83, 0x01, 0x0a, 0x19, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x73,
+	0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x21, 0x0a, 0x0c, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65,
+	0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x12, 0x27,
+	0x0a, 0x0f, 0x61, 0x6c, 0x72, 0x65, 0x61, 0x64, 0x79, 0x5f, 0x73, 0x65, 0x74, 0x74, 0x6c, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x61, 0x6c, 0x72, 0x65, 0x61, 0x64, 0x79,
+	0x53, 0x65, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x32, 0xda, 0x02, 0x0a, 0x0f, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x65, 0x0a, 0x0f, 0x47,
+	0x65, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2d,
+	0x2e, 0x62, 0x6c, 0x75, 0x65, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x2e, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e,
+	0x62, 0x6c, 0x75, 0x65, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x2e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x68, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x42,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x2e, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x70, 0x72, 0x69,
+	0x6e, 0x74, 0x2e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x70, 0x72, 0x69,
+	0x6e, 0x74, 0x2e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x57,
+	0x61, 0x6c, 0x6c, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x76, 0x0a, 0x11,
+	0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x2f, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x2e, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x30, 0x2e, 0x62, 0x6c, 0x75, 0x65, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x2e, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x71, 0x75, 0x65,
+	0x75, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x22, 0x5a, 0x20, 0x62, 0x6c, 0x75, 0x65, 0x70, 0x72, 0x69, 0x6e,
+	0x74, 0x2d, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_internalapi_proto_rawDescOnce sync.Once
+	file_internalapi_proto_rawDescData = file_internalapi_proto_rawDesc
+)
+
+func file_internalapi_proto_rawDescGZIP() []byte {
+	file_internalapi_proto_rawDescOnce.Do(func() {
+		file_internalapi_proto_rawDescData = protoimpl.X.CompressGZIP(file_internalapi_proto_rawDescData)
+	})
+	return file_internalapi_proto_rawDescData
+}
+
+var file_internalapi_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_internalapi_proto_goTypes = []interface{}{
+	(*GetMarketStatusRequest)(nil),    // 0: blueprint.internal.v1.GetMarketStatusRequest
+	(*MarketStatus)(nil),              // 1: blueprint.internal.v1.MarketStatus
+	(*GetWalletBalanceRequest)(nil),   // 2: blueprint.internal.v1.GetWalletBalanceRequest
+	(*WalletBalance)(nil),             // 3: blueprint.internal.v1.WalletBalance
+	(*EnqueueResolutionRequest)(nil),  // 4: blueprint.internal.v1.EnqueueResolutionRequest
+	(*EnqueueResolutionResponse)(nil), // 5: blueprint.internal.v1.EnqueueResolutionResponse
+}
+var file_internalapi_proto_depIdxs = []int32{
+	0, // 0: blueprint.internal.v1.InternalService.GetMarketStatus:input_type -> blueprint.internal.v1.GetMarketStatusRequest
+	2, // 1: blueprint.internal.v1.InternalService.GetWalletBalance:input_type -> blueprint.internal.v1.GetWalletBalanceRequest
+	4, // 2: blueprint.internal.v1.InternalService.EnqueueResolution:input_type -> blueprint.internal.v1.EnqueueResolutionRequest
+	1, // 3: blueprint.internal.v1.InternalService.GetMarketStatus:output_type -> blueprint.internal.v1.MarketStatus
+	3, // 4: blueprint.internal.v1.InternalService.GetWalletBalance:output_type -> blueprint.internal.v1.WalletBalance
+	5, // 5: blueprint.internal.v1.InternalService.EnqueueResolution:output_type -> blueprint.internal.v1.EnqueueResolutionResponse
+	3, // [3:6] is the sub-list for method output_type
+	0, // [0:3] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_internalapi_proto_init() }
+func file_internalapi_proto_init() {
+	if File_internalapi_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_internalapi_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMarketStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internalapi_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MarketStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internalapi_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetWalletBalanceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internalapi_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WalletBalance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internalapi_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnqueueResolutionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internalapi_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnqueueResolutionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internalapi_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_internalapi_proto_goTypes,
+		DependencyIndexes: file_internalapi_proto_depIdxs,
+		MessageInfos:      file_internalapi_proto_msgTypes,
+	}.Build()
+	File_internalapi_proto = out.File
+	file_internalapi_proto_rawDesc = nil
+	file_internalapi_proto_goTypes = nil
+	file_internalapi_proto_depIdxs = nil
+}
@@ -0,0 +1,213 @@
+// 서비스 간 내부 gRPC 계약 (API 서버가 제공, 워커/스케줄러가 호출)
+//
+// 코드 생성: blueprint-module/pkg/internalapi 에서
+//   protoc --go_out=. --go_opt=paths=source_relative \
+//          --go-grpc_out=. --go-grpc_opt=paths=source_relative internalapi.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v4.25.3
+// source: internalapi.proto
+
+package internalapi
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	InternalService_GetMarketStatus_FullMethodName   = "/blueprint.internal.v1.InternalService/GetMarketStatus"
+	InternalService_GetWalletBalance_FullMethodName  = "/blueprint.internal.v1.InternalService/GetWalletBalance"
+	InternalService_EnqueueResolution_FullMethodName = "/blueprint.internal.v1.InternalService/EnqueueResolution"
+)
+
+// InternalServiceClient is the client API for InternalService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// InternalService 공유 DB/Redis 큐로는 답할 수 없는 동기 조회와 요청
+type InternalServiceClient interface {
+	// 마켓(마일스톤)이 지금 주문을 받을 수 있는지
+	GetMarketStatus(ctx context.Context, in *GetMarketStatusRequest, opts ...grpc.CallOption) (*MarketStatus, error)
+	// 사용자 지갑 잔액 (센트)
+	GetWalletBalance(ctx context.Context, in *GetWalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalance, error)
+	// 결과가 확정된 마켓의 포지션 정산 예약 (이미 정산됐으면 그대로 응답)
+	EnqueueResolution(ctx context.Context, in *EnqueueResolutionRequest, opts ...grpc.CallOption) (*EnqueueResolutionResponse, error)
+}
+
+type internalServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInternalServiceClient(cc grpc.ClientConnInterface) InternalServiceClient {
+	return &internalServiceClient{cc}
+}
+
+func (c *internalServiceClient) GetMarketStatus(ctx context.Context, in *GetMarketStatusRequest, opts ...grpc.CallOption) (*MarketStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarketStatus)
+	err := c.cc.Invoke(ctx, InternalService_GetMarketStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *internalServiceClient) GetWalletBalance(ctx context.Context, in *GetWalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalance, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WalletBalance)
+	err := c.cc.Invoke(ctx, InternalService_GetWalletBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *internalServiceClient) EnqueueResolution(ctx context.Context, in *EnqueueResolutionRequest, opts ...grpc.CallOption) (*EnqueueResolutionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnqueueResolutionResponse)
+	err := c.cc.Invoke(ctx, InternalService_EnqueueResolution_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InternalServiceServer is the server API for InternalService service.
+// All implementations must embed UnimplementedInternalServiceServer
+// for forward compatibility.
+//
+// InternalService 공유 DB/Redis 큐로는 답할 수 없는 동기 조회와 요청
+type InternalServiceServer interface {
+	// 마켓(마일스톤)이 지금 주문을 받을 수 있는지
+	GetMarketStatus(context.Context, *GetMarketStatusRequest) (*MarketStatus, error)
+	// 사용자 지갑 잔액 (센트)
+	GetWalletBalance(context.Context, *GetWalletBalanceRequest) (*WalletBalance, error)
+	// 결과가 확정된 마켓의 포지션 정산 예약 (이미 정산됐으면 그대로 응답)
+	EnqueueResolution(context.Context, *EnqueueResolutionRequest) (*EnqueueResolutionResponse, error)
+	mustEmbedUnimplementedInternalServiceServer()
+}
+
+// UnimplementedInternalServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedInternalServiceServer struct{}
+
+func (UnimplementedInternalServiceServer) GetMarketStatus(context.Context, *GetMarketStatusRequest) (*MarketStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMarketStatus not implemented")
+}
+func (UnimplementedInternalServiceServer) GetWalletBalance(context.Context, *GetWalletBalanceRequest) (*WalletBalance, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWalletBalance not implemented")
+}
+func (UnimplementedInternalServiceServer) EnqueueResolution(context.Context, *EnqueueResolutionRequest) (*EnqueueResolutionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnqueueResolution not implemented")
+}
+func (UnimplementedInternalServiceServer) mustEmbedUnimplementedInternalServiceServer() {}
+func (UnimplementedInternalServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeInternalServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InternalServiceServer will
+// result in compilation errors.
+type UnsafeInternalServiceServer interface {
+	mustEmbedUnimplementedInternalServiceServer()
+}
+
+func RegisterInternalServiceServer(s grpc.ServiceRegistrar, srv InternalServiceServer) {
+	// If the following call pancis, it indicates UnimplementedInternalServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&InternalService_ServiceDesc, srv)
+}
+
+func _InternalService_GetMarketStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMarketStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServiceServer).GetMarketStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InternalService_GetMarketStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServiceServer).GetMarketStatus(ctx, req.(*GetMarketStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InternalService_GetWalletBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWalletBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServiceServer).GetWalletBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InternalService_GetWalletBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServiceServer).GetWalletBalance(ctx, req.(*GetWalletBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InternalService_EnqueueResolution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnqueueResolutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServiceServer).EnqueueResolution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InternalService_EnqueueResolution_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServiceServer).EnqueueResolution(ctx, req.(*EnqueueResolutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// InternalService_ServiceDesc is the grpc.ServiceDesc for InternalService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var InternalService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "blueprint.internal.v1.InternalService",
+	HandlerType: (*InternalServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetMarketStatus",
+			Handler:    _InternalService_GetMarketStatus_Handler,
+		},
+		{
+			MethodName: "GetWalletBalance",
+			Handler:    _InternalService_GetWalletBalance_Handler,
+		},
+		{
+			MethodName: "EnqueueResolution",
+			Handler:    _InternalService_EnqueueResolution_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internalapi.proto",
+}
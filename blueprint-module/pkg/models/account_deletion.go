@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// AccountDeletionStatus 계정 삭제 요청 상태
+type AccountDeletionStatus string
+
+const (
+	AccountDeletionPending   AccountDeletionStatus = "pending"   // 유예 기간 (취소 가능)
+	AccountDeletionCancelled AccountDeletionStatus = "cancelled" // 사용자가 취소
+	AccountDeletionCompleted AccountDeletionStatus = "completed" // 개인 데이터 삭제 및 익명화 완료
+)
+
+// AccountDeletion 계정 삭제 요청 (유예 기간이 지나면 개인 데이터를 지우고 계정을 익명화)
+type AccountDeletion struct {
+	ID     uint                  `json:"id" gorm:"primaryKey"`
+	UserID uint                  `json:"user_id" gorm:"not null;index"`
+	Status AccountDeletionStatus `json:"status" gorm:"size:20;not null;default:'pending';index"`
+	Reason string                `json:"reason,omitempty" gorm:"type:text"`
+
+	CancelledOrders int        `json:"cancelled_orders"`                    // 요청 시 취소한 미체결 주문 수
+	ScheduledFor    time.Time  `json:"scheduled_for" gorm:"not null;index"` // 이 시각 이후 삭제 실행
+	CancelledAt     *time.Time `json:"cancelled_at,omitempty"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DeleteAccountRequest 계정 삭제 요청 본문
+type DeleteAccountRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
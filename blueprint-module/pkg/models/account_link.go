@@ -0,0 +1,100 @@
+package models
+
+import "time"
+
+// 로그인/연결 제공업체 (UserIdentity.Provider)
+const (
+	IdentityProviderEmail    = "email" // 매직링크 (이메일 소유 확인)
+	IdentityProviderGoogle   = "google"
+	IdentityProviderGitHub   = "github"
+	IdentityProviderLinkedIn = "linkedin"
+	IdentityProviderTwitter  = "twitter"
+)
+
+// UserIdentity 계정에 연결된 외부 신원 (제공업체 계정 하나는 한 사용자에게만 연결)
+type UserIdentity struct {
+	ID             uint   `json:"id" gorm:"primaryKey"`
+	UserID         uint   `json:"user_id" gorm:"not null;index"`
+	Provider       string `json:"provider" gorm:"type:varchar(20);not null;uniqueIndex:idx_user_identity_provider_subject"`
+	ProviderUserID string `json:"-" gorm:"type:varchar(255);not null;uniqueIndex:idx_user_identity_provider_subject"`
+
+	Email         string `json:"email,omitempty" gorm:"type:varchar(255);index"`
+	EmailVerified bool   `json:"email_verified" gorm:"default:false"` // 제공업체가 소유를 확인한 이메일 (중복 계정 탐지에 사용)
+	DisplayName   string `json:"display_name,omitempty" gorm:"type:varchar(255)"`
+
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
+
+// AccountMergeStatus 계정 병합 상태
+type AccountMergeStatus string
+
+const (
+	AccountMergePending   AccountMergeStatus = "pending"   // 병합될 계정 이메일로 확인 코드 발송됨
+	AccountMergeCompleted AccountMergeStatus = "completed" // 자산 이전 완료
+	AccountMergeCancelled AccountMergeStatus = "cancelled" // 새 요청으로 대체되었거나 시도 횟수 초과
+)
+
+// AccountMerge 중복 계정 병합 (Source의 지갑/포지션/스테이킹을 Target으로 이전 후 Source 비활성화)
+type AccountMerge struct {
+	ID           uint               `json:"id" gorm:"primaryKey"`
+	TargetUserID uint               `json:"target_user_id" gorm:"not null;index"` // 남는 계정 (요청자)
+	SourceUserID uint               `json:"source_user_id" gorm:"not null;index"` // 흡수되어 비활성화되는 계정
+	Status       AccountMergeStatus `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"`
+
+	CodeHash  string    `json:"-" gorm:"type:varchar(64)"`
+	Attempts  int       `json:"-" gorm:"default:0"`
+	ExpiresAt time.Time `json:"expires_at"`
+
+	Summary     *AccountMergeSummary `json:"summary,omitempty" gorm:"type:text;serializer:json"`
+	CompletedAt *time.Time           `json:"completed_at,omitempty"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
+
+func (AccountMerge) TableName() string {
+	return "account_merges"
+}
+
+// AccountMergeSummary 병합으로 이전된 내역
+type AccountMergeSummary struct {
+	USDC            int64           `json:"usdc"`               // 이전된 사용 가능 USDC (센트)
+	USDCLocked      int64           `json:"usdc_locked"`        // 이전된 잠긴 USDC
+	Blueprint       int64           `json:"blueprint"`          // 이전된 사용 가능 BLUEPRINT
+	BlueprintLocked int64           `json:"blueprint_locked"`   // 이전된 잠긴 BLUEPRINT
+	Accounts        []WalletBalance `json:"accounts,omitempty"` // 이전된 추가 결제 통화 잔액 (usdc/blueprint 외)
+
+	Positions         int  `json:"positions"`          // 그대로 옮긴 포지션
+	PositionsCombined int  `json:"positions_combined"` // 같은 옵션 포지션과 합친 수
+	Parlays           int  `json:"parlays"`
+	MentorStakes      int  `json:"mentor_stakes"`
+	StakingPools      int  `json:"staking_pools"`
+	StakingRewards    int  `json:"staking_rewards"` // 미청구 스테이킹 보상
+	JurorStakeMerged  bool `json:"juror_stake_merged"`
+	Projects          int  `json:"projects"`
+	Identities        int  `json:"identities"`
+}
+
+// DuplicateAccount 같은 인증 이메일을 쓰는 다른 계정 (병합 후보)
+type DuplicateAccount struct {
+	UserID       uint      `json:"user_id"`
+	Username     string    `json:"username"`
+	MatchedEmail string    `json:"matched_email"`
+	Providers    []string  `json:"providers"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// StartAccountMergeRequest 병합 요청 (흡수할 계정의 이메일로 확인 코드 발송)
+type StartAccountMergeRequest struct {
+	SourceEmail string `json:"source_email" binding:"required,email"`
+}
+
+// ConfirmAccountMergeRequest 병합 확인
+type ConfirmAccountMergeRequest struct {
+	Code string `json:"code" binding:"required,len=6"`
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -26,7 +27,7 @@ type ActivityLog struct {
 	TradeID     *uint `json:"trade_id,omitempty" gorm:"index"`
 
 	// 메타데이터 (JSON)
-	Metadata ActivityMetadata `json:"metadata" gorm:"type:jsonb"`
+	Metadata ActivityMetadata `json:"metadata" gorm:"type:jsonb;serializer:json"`
 
 	// 관계
 	User      User       `json:"user,omitempty" gorm:"foreignKey:UserID"`
@@ -59,6 +60,10 @@ type ActivityMetadata struct {
 	IPAddress       string  `json:"ip_address,omitempty"`
 	UserAgent       string  `json:"user_agent,omitempty"`
 	Platform        string  `json:"platform,omitempty"` // "web", "mobile"
+
+	// 자동 기록 (요청 경로의 대상 ID, 인증 방식)
+	ResourceID string `json:"resource_id,omitempty"`
+	AuthMethod string `json:"auth_method,omitempty"` // "session", "api_key"
 }
 
 // ActivityType 상수 정의
@@ -105,8 +110,99 @@ const (
 	ActionInvestmentCreate = "create"
 	ActionInvestmentWithdraw = "withdraw"
 	ActionInvestmentPayout = "payout"
+
+	// 자동 기록 분류 (ActivityEvent의 앞부분)
+	ActivityTypeAuth         = "auth"
+	ActivityTypeTrading      = "trading"
+	ActivityTypeVerification = "verification"
+	ActivityTypeArbitration  = "arbitration"
+	ActivityTypeStaking      = "staking"
+)
+
+// ActivityTypes 조회 필터로 사용할 수 있는 활동 타입
+var ActivityTypes = []string{
+	ActivityTypeAuth, ActivityTypeTrading, ActivityTypeVerification, ActivityTypeArbitration, ActivityTypeStaking,
+	ActivityTypeProject, ActivityTypeMilestone, ActivityTypeTrade, ActivityTypeMentoring, ActivityTypeAccount, ActivityTypeInvestment,
+}
+
+// IsValidActivityType 알려진 활동 타입 여부
+func IsValidActivityType(activityType string) bool {
+	for _, known := range ActivityTypes {
+		if known == activityType {
+			return true
+		}
+	}
+	return false
+}
+
+// ActivityEvent 자동 기록되는 활동 ("분류.액션")
+type ActivityEvent string
+
+const (
+	// 인증/계정 보안
+	ActivityAuthLogin             ActivityEvent = "auth.login"
+	ActivityAuthLogout            ActivityEvent = "auth.logout"
+	ActivityAuthSessionRevoked    ActivityEvent = "auth.session_revoked"
+	ActivityAuthTokenReused       ActivityEvent = "auth.refresh_token_reused" // 탈취 의심으로 세션 강제 종료
+	ActivityAuthAPIKeyCreated     ActivityEvent = "auth.api_key_created"
+	ActivityAuthAPIKeyRevoked     ActivityEvent = "auth.api_key_revoked"
+	ActivityAuthIdentityUnlinked  ActivityEvent = "auth.identity_unlinked"
+	ActivityAuthAccountMerged     ActivityEvent = "auth.account_merged"
+	ActivityAuthDeletionRequested ActivityEvent = "auth.deletion_requested"
+	ActivityAuthDeletionCancelled ActivityEvent = "auth.deletion_cancelled"
+
+	// 거래
+	ActivityTradingOrderPlaced    ActivityEvent = "trading.order_placed"
+	ActivityTradingOrderCancelled ActivityEvent = "trading.order_cancelled"
+	ActivityTradingParlayPlaced   ActivityEvent = "trading.parlay_placed"
+
+	// 검증 (신원/경력 인증, 마일스톤 증거)
+	ActivityVerificationContactVerified   ActivityEvent = "verification.contact_verified"
+	ActivityVerificationProviderConnected ActivityEvent = "verification.provider_connected"
+	ActivityVerificationDocSubmitted      ActivityEvent = "verification.document_submitted"
+	ActivityVerificationReviewed          ActivityEvent = "verification.reviewed"
+	ActivityVerificationKYCSubmitted      ActivityEvent = "verification.kyc_submitted"
+	ActivityVerificationKYCReviewed       ActivityEvent = "verification.kyc_reviewed"
+	ActivityVerificationProofSubmitted    ActivityEvent = "verification.proof_submitted"
+	ActivityVerificationProofValidated    ActivityEvent = "verification.proof_validated"
+	ActivityVerificationProofDisputed     ActivityEvent = "verification.proof_disputed"
+
+	// 분쟁 해결
+	ActivityArbitrationCaseOpened      ActivityEvent = "arbitration.case_opened"
+	ActivityArbitrationVoteCommitted   ActivityEvent = "arbitration.vote_committed"
+	ActivityArbitrationVoteRevealed    ActivityEvent = "arbitration.vote_revealed"
+	ActivityArbitrationAppealed        ActivityEvent = "arbitration.appealed"
+	ActivityArbitrationEvidenceAdded   ActivityEvent = "arbitration.evidence_submitted"
+	ActivityArbitrationJurorRegistered ActivityEvent = "arbitration.juror_registered"
+
+	// 스테이킹
+	ActivityStakingMentorStaked   ActivityEvent = "staking.mentor_staked"
+	ActivityStakingUnstaked       ActivityEvent = "staking.unstaked"
+	ActivityStakingRewardsClaimed ActivityEvent = "staking.rewards_claimed"
 )
 
+// Type 활동 분류 (ActivityLog.ActivityType)
+func (e ActivityEvent) Type() string {
+	activityType, _, _ := strings.Cut(string(e), ".")
+	return activityType
+}
+
+// Action 분류 내 액션 (ActivityLog.Action)
+func (e ActivityEvent) Action() string {
+	_, action, _ := strings.Cut(string(e), ".")
+	return action
+}
+
+// Request 활동 로그 생성 요청으로 변환
+func (e ActivityEvent) Request(userID uint, description string) CreateActivityLogRequest {
+	return CreateActivityLogRequest{
+		UserID:       userID,
+		ActivityType: e.Type(),
+		Action:       e.Action(),
+		Description:  description,
+	}
+}
+
 // CreateActivityLogRequest 활동 로그 생성 요청
 type CreateActivityLogRequest struct {
 	UserID       uint             `json:"user_id" binding:"required"`
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// AIUsageLog AI 마일스톤 생성 사용 기록 (사용자별 할당량/토큰 집계용)
+type AIUsageLog struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	UserID     uint      `json:"user_id" gorm:"not null;index"`
+	Provider   string    `json:"provider" gorm:"size:20;not null"`
+	Model      string    `json:"model" gorm:"size:100"`
+	PromptHash string    `json:"prompt_hash" gorm:"size:64;index"`
+	TokensUsed int       `json:"tokens_used"`
+	Cached     bool      `json:"cached"`   // 캐시 응답 (할당량 차감 없음)
+	Streamed   bool      `json:"streamed"` // SSE 스트리밍 요청 여부
+	CreatedAt  time.Time `json:"created_at"`
+}
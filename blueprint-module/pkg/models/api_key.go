@@ -0,0 +1,73 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// APIKeyScope API 키 권한 범위
+type APIKeyScope string
+
+const (
+	APIKeyScopeRead     APIKeyScope = "read"     // 지갑/주문/포지션 조회
+	APIKeyScopeTrade    APIKeyScope = "trade"    // 주문 생성/취소
+	APIKeyScopeWithdraw APIKeyScope = "withdraw" // 출금 (별도 동의 필요)
+)
+
+// ValidAPIKeyScopes 발급 가능한 권한 목록
+var ValidAPIKeyScopes = []APIKeyScope{APIKeyScopeRead, APIKeyScopeTrade, APIKeyScopeWithdraw}
+
+// APIKey 프로그래매틱 거래용 API 키 (HMAC 서명 인증)
+type APIKey struct {
+	ID     uint `json:"id" gorm:"primaryKey"`
+	UserID uint `json:"user_id" gorm:"not null;index"`
+
+	Name   string `json:"name" gorm:"size:100;not null"`
+	KeyID  string `json:"key" gorm:"size:64;uniqueIndex;not null"` // 공개 키 식별자 (bpk_...)
+	Scopes string `json:"scopes" gorm:"size:100;not null"`         // 쉼표 구분 (read,trade,withdraw)
+
+	// 서명 검증에 원문이 필요하므로 서버 키로 암호화하여 보관
+	EncryptedSecret string `json:"-" gorm:"type:text;not null"`
+
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP string     `json:"last_used_ip,omitempty" gorm:"size:64"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// 외래키 참조
+	User User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// HasScope 권한 보유 여부
+func (k *APIKey) HasScope(scope APIKeyScope) bool {
+	for _, s := range strings.Split(k.Scopes, ",") {
+		if APIKeyScope(strings.TrimSpace(s)) == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActive 사용 가능 여부 (폐기/만료 확인)
+func (k *APIKey) IsActive() bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || time.Now().Before(*k.ExpiresAt)
+}
+
+// CreateAPIKeyRequest API 키 발급 요청
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name" binding:"required,max=100"`
+	Scopes        []string `json:"scopes" binding:"required,min=1,dive,oneof=read trade withdraw"`
+	ExpiresInDays int      `json:"expires_in_days" binding:"omitempty,min=1,max=365"`
+}
+
+// CreateAPIKeyResponse API 키 발급 결과 (secret은 이 응답에서만 노출)
+type CreateAPIKeyResponse struct {
+	APIKey *APIKey `json:"api_key"`
+	Secret string  `json:"secret"`
+}
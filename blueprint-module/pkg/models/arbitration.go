@@ -37,7 +37,8 @@ type ArbitrationCase struct {
 	
 	// 배심원단 구성
 	RequiredJurors    int       `json:"required_jurors" gorm:"default:5"`    // 필요한 배심원 수
-	SelectedJurors    []uint    `json:"selected_jurors" gorm:"type:jsonb"`   // 선정된 배심원 ID 목록
+	SelectedJurors    []uint    `json:"selected_jurors" gorm:"type:jsonb;serializer:json"`   // 선정된 배심원 ID 목록
+	ReplacedJurors    []uint    `json:"replaced_jurors,omitempty" gorm:"type:jsonb;serializer:json"` // 투표 기한을 넘겨 교체된 배심원 (판결 시 미참여로 차감)
 	JuryFormationDeadline time.Time `json:"jury_formation_deadline"`          // 배심원단 구성 마감일
 	
 	// 심리 과정
@@ -45,6 +46,13 @@ type ArbitrationCase struct {
 	VotingDeadline   *time.Time `json:"voting_deadline"`                     // 투표 마감일
 	RevealDeadline   *time.Time `json:"reveal_deadline"`                     // 투표 공개 마감일
 	
+	// 항소 (상위 심급일수록 배심원 수와 스테이킹이 두 배로 증가)
+	ParentCaseID   *uint      `json:"parent_case_id,omitempty" gorm:"index"` // 이전 심급 사건
+	AppealRound    int        `json:"appeal_round" gorm:"default:0"`         // 0 = 최초 심리
+	AppellantID    *uint      `json:"appellant_id,omitempty"`                // 항소 제기자 (항소 스테이킹 주체)
+	AppealDeadline *time.Time `json:"appeal_deadline"`                       // 항소 가능 기한 (지나면 판결 확정)
+	IsFinal        bool       `json:"is_final" gorm:"default:false"`         // 최종심 (추가 항소 불가)
+	
 	// 최종 결과
 	Decision        ArbitrationDecision `json:"decision"`                     // 최종 판결
 	DecisionReason  string             `json:"decision_reason" gorm:"type:text"` // 판결 이유
@@ -77,6 +85,7 @@ const (
 	DisputeTypePaymentIssue        ArbitrationDisputeType = "payment_issue"        // 결제 문제
 	DisputeTypeIntellectualProperty ArbitrationDisputeType = "intellectual_property" // 지적재산권 침해
 	DisputeTypeContractBreach      ArbitrationDisputeType = "contract_breach"      // 계약 위반
+	DisputeTypeMarketManipulation  ArbitrationDisputeType = "market_manipulation"  // 시세 조종 (시장 감시 알림 이관)
 )
 
 // ArbitrationStatus 분쟁 상태
@@ -123,7 +132,7 @@ type ArbitrationVote struct {
 	JurorID  uint `json:"juror_id" gorm:"not null;index"`
 	
 	// 투표 내용 (Commit-Reveal 방식)
-	CommitHash    string    `json:"commit_hash"`                    // SHA256(vote + salt)
+	CommitHash    string    `json:"commit_hash"`                    // SHA256("case:juror:vote:salt:nonce")
 	RevealedVote  *ArbitrationDecision `json:"revealed_vote"`      // 공개된 투표
 	RevealedSalt  string    `json:"revealed_salt"`                 // 공개된 솔트
 	VoteReason    string    `json:"vote_reason" gorm:"type:text"`  // 투표 이유
@@ -164,8 +173,8 @@ type JurorQualification struct {
 	ReputationScore    float64 `json:"reputation_score" gorm:"default:0.5"`     // 평판 점수 (0-1)
 	
 	// 전문성
-	ExpertiseAreas     []string `json:"expertise_areas" gorm:"type:jsonb"`      // 전문 분야
-	LanguageSkills     []string `json:"language_skills" gorm:"type:jsonb"`      // 언어 능력
+	ExpertiseAreas     []string `json:"expertise_areas" gorm:"type:jsonb;serializer:json"`      // 전문 분야
+	LanguageSkills     []string `json:"language_skills" gorm:"type:jsonb;serializer:json"`      // 언어 능력
 	LegalBackground    bool     `json:"legal_background" gorm:"default:false"`  // 법률 배경 지식
 	
 	// 배심원 히스토리
@@ -179,6 +188,10 @@ type JurorQualification struct {
 	IsSuspended       bool       `json:"is_suspended" gorm:"default:false"`      // 정지 상태
 	SuspendedUntil    *time.Time `json:"suspended_until"`                        // 정지 해제일
 	SuspensionReason  string     `json:"suspension_reason"`                      // 정지 사유
+
+	// 가용성 (배심원 선정 시 반영)
+	MaxConcurrentCases int        `json:"max_concurrent_cases" gorm:"default:3"` // 동시에 맡을 수 있는 진행 중 사건 수
+	VacationUntil      *time.Time `json:"vacation_until,omitempty"`              // 휴가 모드 (이 시각까지 선정 제외)
 	
 	LastActiveAt time.Time `json:"last_active_at"`
 	CreatedAt    time.Time `json:"created_at"`
@@ -192,6 +205,50 @@ func (JurorQualification) TableName() string {
 	return "juror_qualifications"
 }
 
+// ArbitrationVoteNonce 배심원별 투표 nonce (서버 발급, 커밋 해시에 포함되어 다른 배심원의 커밋 복제/사전 계산 방지)
+type ArbitrationVoteNonce struct {
+	ID        uint      `json:"-" gorm:"primaryKey"`
+	CaseID    uint      `json:"case_id" gorm:"not null;uniqueIndex:idx_vote_nonce_case_juror"`
+	JurorID   uint      `json:"juror_id" gorm:"not null;uniqueIndex:idx_vote_nonce_case_juror"`
+	Nonce     string    `json:"nonce" gorm:"type:varchar(64);not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (ArbitrationVoteNonce) TableName() string {
+	return "arbitration_vote_nonces"
+}
+
+// EvidenceScanStatus 증거 파일 악성코드 검사 상태
+type EvidenceScanStatus string
+
+const (
+	EvidenceScanPending  EvidenceScanStatus = "pending"  // 워커 검사 대기
+	EvidenceScanClean    EvidenceScanStatus = "clean"    // 열람 가능
+	EvidenceScanInfected EvidenceScanStatus = "infected" // 악성코드 감지 (열람 차단)
+	EvidenceScanFailed   EvidenceScanStatus = "failed"   // 검사 실패 (열람 차단)
+)
+
+// ArbitrationEvidence 분쟁 증거 파일 (내용은 FileService에 저장, 해시로 위변조 확인)
+type ArbitrationEvidence struct {
+	ID           uint               `json:"id" gorm:"primaryKey"`
+	CaseID       uint               `json:"case_id" gorm:"not null;index"`
+	SubmitterID  uint               `json:"submitter_id" gorm:"not null;index"`
+	Phase        ArbitrationStatus  `json:"phase" gorm:"type:varchar(20)"` // 제출 당시 사건 단계
+	FilePath     string             `json:"-" gorm:"not null"`             // category/key
+	OriginalName string             `json:"original_name"`
+	ContentType  string             `json:"content_type"`
+	Size         int64              `json:"size"`
+	SHA256       string             `json:"sha256" gorm:"type:varchar(64);column:sha256"`
+	Description  string             `json:"description" gorm:"type:text"`
+	ScanStatus   EvidenceScanStatus `json:"scan_status" gorm:"type:varchar(20);default:'pending'"`
+	ScannedAt    *time.Time         `json:"scanned_at"`
+	CreatedAt    time.Time          `json:"created_at"`
+}
+
+func (ArbitrationEvidence) TableName() string {
+	return "arbitration_evidences"
+}
+
 // ArbitrationReward 배심원 보상
 type ArbitrationReward struct {
 	ID       uint `json:"id" gorm:"primaryKey"`
@@ -203,6 +260,7 @@ type ArbitrationReward struct {
 	PerformanceBonus int64  `json:"performance_bonus"`                  // 성과 보너스
 	QualityBonus    int64   `json:"quality_bonus"`                      // 품질 보너스 (상세한 이유 제공 등)
 	TotalReward     int64   `json:"total_reward"`                       // 총 보상
+	SlashedAmount   int64   `json:"slashed_amount"`                     // 소수 의견/미참여로 차감된 스테이킹
 	
 	// 보상 조건
 	VotedWithMajority bool    `json:"voted_with_majority"`               // 다수 의견과 일치 여부
@@ -243,14 +301,14 @@ type SubmitArbitrationRequest struct {
 // JurorVoteRequest 배심원 투표 요청
 type JurorVoteRequest struct {
 	CaseID     uint   `json:"case_id" binding:"required"`
-	CommitHash string `json:"commit_hash" binding:"required"`  // SHA256(vote + salt)
+	CommitHash string `json:"commit_hash" binding:"required,len=64,hexadecimal"` // hex(SHA256("case_id:juror_id:vote:salt:nonce"))
 }
 
 // RevealVoteRequest 투표 공개 요청
 type RevealVoteRequest struct {
 	CaseID       uint                `json:"case_id" binding:"required"`
 	Vote         ArbitrationDecision `json:"vote" binding:"required"`
-	Salt         string              `json:"salt" binding:"required"`
+	Salt         string              `json:"salt" binding:"required,min=16,max=128"` // 클라이언트가 생성한 무작위 값
 	VoteReason   string              `json:"vote_reason"`
 }
 
@@ -280,9 +338,25 @@ type JurorDashboardResponse struct {
 	ActiveCases     []ArbitrationCase   `json:"active_cases"`      // 현재 참여 중인 사건들
 	CompletedCases  []ArbitrationCase   `json:"completed_cases"`   // 완료된 사건들
 	TotalRewards    int64               `json:"total_rewards"`     // 총 보상
+	RecentRewards   []ArbitrationReward `json:"recent_rewards"`    // 최근 보상/차감 내역
 	Statistics      JurorStatistics     `json:"statistics"`
 }
 
+// JurorAvailability 배심원 가용성 설정과 현재 사건 부담
+type JurorAvailability struct {
+	MaxConcurrentCases int        `json:"max_concurrent_cases"`
+	VacationUntil      *time.Time `json:"vacation_until,omitempty"`
+	ActiveCases        int        `json:"active_cases"` // 배심원 선정/투표/공개 단계 사건 수
+	Available          bool       `json:"available"`    // 지금 새 사건에 선정될 수 있는지
+}
+
+// UpdateJurorAvailabilityRequest 배심원 가용성 변경 (휴가 종료는 end_vacation)
+type UpdateJurorAvailabilityRequest struct {
+	MaxConcurrentCases *int       `json:"max_concurrent_cases" binding:"omitempty,min=1,max=10"`
+	VacationUntil      *time.Time `json:"vacation_until"`
+	EndVacation        bool       `json:"end_vacation"`
+}
+
 // JurorStatistics 배심원 통계
 type JurorStatistics struct {
 	TotalCases        int     `json:"total_cases"`
@@ -291,4 +365,5 @@ type JurorStatistics struct {
 	AverageResponseTime int   `json:"avg_response_time"`
 	Rank              int     `json:"rank"`              // 전체 배심원 중 순위
 	TotalEarnings     int64   `json:"total_earnings"`
+	TotalSlashed      int64   `json:"total_slashed"`
 }
\ No newline at end of file
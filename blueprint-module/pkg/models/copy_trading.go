@@ -0,0 +1,111 @@
+package models
+
+import "time"
+
+// CopyTradingDisclaimerVersion 복사 거래 위험 고지 버전 (문구를 바꾸면 올리고, 팔로우 시 동의한 버전을 기록)
+const CopyTradingDisclaimerVersion = "2026-10"
+
+// CopyTradingDisclaimer 팔로우 전에 동의해야 하는 위험 고지
+const CopyTradingDisclaimer = "복사 거래는 다른 사용자의 체결을 같은 가격의 지정가 주문으로 자동 따라 하는 기능입니다. " +
+	"리더의 과거 성과는 미래 수익을 보장하지 않으며, 호가 상황에 따라 리더와 다른 가격에 체결되거나 체결되지 않을 수 있습니다. " +
+	"복사 주문도 본인 주문과 같이 잔액, 일일 거래 한도, 마켓 거래 중단 규칙이 적용되고 손실은 전적으로 본인에게 귀속됩니다. " +
+	"플랫폼과 리더는 투자 조언을 제공하지 않으며, 언제든 일시 중지하거나 팔로우를 해제할 수 있습니다."
+
+// CopyLeader 복사 거래 리더 설정 (본인이 켜야 팔로우 가능, 투자 공개 설정 필요)
+type CopyLeader struct {
+	UserID        uint      `json:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	Enabled       bool      `json:"enabled" gorm:"index"`
+	FollowerCount int       `json:"follower_count"` // 활성/일시 중지 팔로워 수
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// CopyFollowStatus 팔로우 상태
+type CopyFollowStatus string
+
+const (
+	CopyFollowActive  CopyFollowStatus = "active"  // 리더 체결을 따라 주문
+	CopyFollowPaused  CopyFollowStatus = "paused"  // 팔로워가 일시 중지하거나 잔액 부족으로 자동 중지
+	CopyFollowStopped CopyFollowStatus = "stopped" // 팔로우 해제
+)
+
+// CopyFollow 팔로워 → 리더 복사 거래 관계와 위험 한도
+type CopyFollow struct {
+	ID                   uint             `json:"id" gorm:"primaryKey"`
+	FollowerID           uint             `json:"follower_id" gorm:"not null;uniqueIndex:idx_copy_follow,priority:1"`
+	LeaderID             uint             `json:"leader_id" gorm:"not null;uniqueIndex:idx_copy_follow,priority:2;index"`
+	SizeScale            float64          `json:"size_scale"`         // 리더 체결 수량 대비 주문 수량 비율
+	MaxOrderNotional     int64            `json:"max_order_notional"` // 복사 매수 1건 최대 금액 (센트)
+	MaxDailyNotional     int64            `json:"max_daily_notional"` // 하루(UTC) 복사 매수 최대 금액 (센트)
+	Status               CopyFollowStatus `json:"status" gorm:"type:varchar(16);not null;default:'active';index"`
+	PausedReason         string           `json:"paused_reason,omitempty"`
+	DisclaimerVersion    string           `json:"disclaimer_version" gorm:"type:varchar(16)"`
+	DisclaimerAcceptedAt time.Time        `json:"disclaimer_accepted_at"`
+	CreatedAt            time.Time        `json:"created_at"`
+	UpdatedAt            time.Time        `json:"updated_at"`
+
+	Leader User `json:"leader,omitempty" gorm:"foreignKey:LeaderID"`
+}
+
+// CopyTradeStatus 복사 주문 처리 결과
+type CopyTradeStatus string
+
+const (
+	CopyTradePlaced  CopyTradeStatus = "placed"  // 주문 접수
+	CopyTradeSkipped CopyTradeStatus = "skipped" // 한도/보유 수량으로 건너뜀
+	CopyTradeFailed  CopyTradeStatus = "failed"  // 주문 거부 (잔액 부족, 거래 중단 등)
+)
+
+// CopyTradeExecution 리더 체결 1건에 대한 팔로워 복사 주문 기록 (팔로우당 체결 1회만 처리)
+type CopyTradeExecution struct {
+	ID             uint            `json:"id" gorm:"primaryKey"`
+	FollowID       uint            `json:"follow_id" gorm:"not null;uniqueIndex:idx_copy_trade_source,priority:1"`
+	SourceTradeID  uint            `json:"source_trade_id" gorm:"not null;uniqueIndex:idx_copy_trade_source,priority:2"`
+	FollowerID     uint            `json:"follower_id" gorm:"not null;index:idx_copy_trade_follower,priority:1"`
+	LeaderID       uint            `json:"leader_id" gorm:"not null"`
+	MilestoneID    uint            `json:"milestone_id"`
+	OptionID       string          `json:"option_id"`
+	Side           OrderSide       `json:"side" gorm:"type:varchar(10)"`
+	Price          float64         `json:"price"`
+	LeaderQuantity int64           `json:"leader_quantity"`
+	Quantity       int64           `json:"quantity"` // 주문 수량 (건너뛰면 0)
+	Notional       int64           `json:"notional"` // 매수 잠금액 기준 금액 (센트, 매도는 0)
+	OrderID        *uint           `json:"order_id,omitempty"`
+	Status         CopyTradeStatus `json:"status" gorm:"type:varchar(16);not null"`
+	Reason         string          `json:"reason,omitempty"`
+	CreatedAt      time.Time       `json:"created_at" gorm:"index:idx_copy_trade_follower,priority:2"`
+}
+
+// CopyLeaderSummary 팔로우할 수 있는 리더 목록 항목 (공개 거래 지표 포함)
+type CopyLeaderSummary struct {
+	UserID         uint    `json:"user_id"`
+	Username       string  `json:"username"`
+	DisplayName    string  `json:"display_name"`
+	FollowerCount  int     `json:"follower_count"`
+	TotalVolume    int64   `json:"total_volume"`
+	TotalTrades    int     `json:"total_trades"`
+	WinRate        float64 `json:"win_rate"`
+	MarketAccuracy float64 `json:"market_accuracy"`
+}
+
+// UpdateCopyLeaderRequest 리더 모드 켜기/끄기
+type UpdateCopyLeaderRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// FollowTraderRequest 리더 팔로우 (위험 고지 동의 필수)
+type FollowTraderRequest struct {
+	SizeScale         float64 `json:"size_scale" binding:"required,gt=0,lte=2"`
+	MaxOrderNotional  int64   `json:"max_order_notional" binding:"required,min=100"`
+	MaxDailyNotional  int64   `json:"max_daily_notional" binding:"required,min=100"`
+	AcceptDisclaimer  bool    `json:"accept_disclaimer"`
+	DisclaimerVersion string  `json:"disclaimer_version" binding:"required"`
+}
+
+// UpdateCopyFollowRequest 팔로우 한도 변경/일시 중지/재개
+type UpdateCopyFollowRequest struct {
+	SizeScale        *float64          `json:"size_scale" binding:"omitempty,gt=0,lte=2"`
+	MaxOrderNotional *int64            `json:"max_order_notional" binding:"omitempty,min=100"`
+	MaxDailyNotional *int64            `json:"max_daily_notional" binding:"omitempty,min=100"`
+	Status           *CopyFollowStatus `json:"status" binding:"omitempty,oneof=active paused"`
+}
@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+// DigestFrequency 알림 요약 메일 주기
+type DigestFrequency string
+
+const (
+	DigestOff    DigestFrequency = "off"
+	DigestDaily  DigestFrequency = "daily"
+	DigestWeekly DigestFrequency = "weekly"
+)
+
+// Period 요약 메일이 다루는 기간 (off면 0)
+func (f DigestFrequency) Period() time.Duration {
+	switch f {
+	case DigestDaily:
+		return 24 * time.Hour
+	case DigestWeekly:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// DigestPosition 기간 중 가격이 움직인 보유 포지션
+type DigestPosition struct {
+	MilestoneID    uint    `json:"milestone_id"`
+	MilestoneTitle string  `json:"milestone_title"`
+	OptionID       string  `json:"option_id"`
+	Quantity       int64   `json:"quantity"`
+	FromPrice      float64 `json:"from_price"`
+	ToPrice        float64 `json:"to_price"`
+	ChangePercent  float64 `json:"change_percent"` // 가격 변화 (퍼센트포인트, 소수 첫째 자리)
+}
+
+// DigestMarket 다음 요약 전에 거래가 마감되는 마켓 (보유 포지션 또는 관심 프로젝트)
+type DigestMarket struct {
+	MilestoneID    uint      `json:"milestone_id"`
+	MilestoneTitle string    `json:"milestone_title"`
+	ClosesAt       time.Time `json:"closes_at"`
+}
+
+// DigestProof 검증인이 아직 투표하지 않은 검증 대기 증거
+type DigestProof struct {
+	ProofID        uint      `json:"proof_id"`
+	MilestoneTitle string    `json:"milestone_title"`
+	ReviewDeadline time.Time `json:"review_deadline"`
+}
+
+// DigestArbitration 다음 요약 전에 돌아오는 분쟁 기한
+type DigestArbitration struct {
+	CaseID     uint              `json:"case_id"`
+	CaseNumber string            `json:"case_number"`
+	Title      string            `json:"title"`
+	Role       string            `json:"role"`  // juror | party
+	Phase      ArbitrationStatus `json:"phase"` // voting(투표) | reveal(공개) | decided(항소 기한)
+	Deadline   time.Time         `json:"deadline"`
+}
+
+// NotificationDigest 사용자 한 명의 알림 요약 (email_queue의 digest 템플릿 데이터)
+type NotificationDigest struct {
+	UserID      uint            `json:"user_id"`
+	Frequency   DigestFrequency `json:"frequency"`
+	PeriodStart time.Time       `json:"period_start"`
+	PeriodEnd   time.Time       `json:"period_end"`
+
+	Positions   []DigestPosition    `json:"positions"`
+	Closing     []DigestMarket      `json:"closing"`
+	Proofs      []DigestProof       `json:"proofs"`
+	Arbitration []DigestArbitration `json:"arbitration"`
+}
+
+// IsEmpty 보낼 내용이 없는 요약 (빈 메일은 보내지 않는다)
+func (d *NotificationDigest) IsEmpty() bool {
+	return len(d.Positions) == 0 && len(d.Closing) == 0 && len(d.Proofs) == 0 && len(d.Arbitration) == 0
+}
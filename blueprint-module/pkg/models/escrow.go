@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// FundingEscrowStatus 펀딩 에스크로 상태
+type FundingEscrowStatus string
+
+const (
+	FundingEscrowLocked   FundingEscrowStatus = "locked"   // 판정 대기 (후원자 지갑 잠금)
+	FundingEscrowReleased FundingEscrowStatus = "released" // 완료 확정으로 프로젝트 소유자에게 지급
+	FundingEscrowRefunded FundingEscrowStatus = "refunded" // 펀딩 실패/마일스톤 실패/취소로 후원자에게 반환
+)
+
+// FundingEscrow 마일스톤 직접 후원 (거래와 별개)
+//
+// 후원자가 마일스톤에 USDC를 잠그면 완료가 확정될 때 프로젝트 소유자에게 지급되고, 펀딩 실패나
+// 마일스톤 실패/취소 시 후원자에게 돌아간다. 예치액은 마일스톤 TVL(current_tvl)에 포함된다.
+type FundingEscrow struct {
+	ID          uint                `json:"id" gorm:"primaryKey"`
+	MilestoneID uint                `json:"milestone_id" gorm:"not null;index"`
+	ProjectID   uint                `json:"project_id" gorm:"not null;index"`
+	BackerID    uint                `json:"backer_id" gorm:"not null;index"`
+	Amount      int64               `json:"amount" gorm:"not null"` // 센트
+	Status      FundingEscrowStatus `json:"status" gorm:"size:20;not null;default:'locked';index"`
+
+	RecipientID *uint      `json:"recipient_id,omitempty"` // 지급받은 프로젝트 소유자 (released)
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateFundingEscrowRequest 마일스톤 후원 예치 요청 (센트)
+type CreateFundingEscrowRequest struct {
+	Amount int64 `json:"amount" binding:"required,min=100"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// DataExportKind 내보내기 종류
+type DataExportKind string
+
+const (
+	DataExportTrades    DataExportKind = "trades"     // 체결 내역
+	DataExportOrders    DataExportKind = "orders"     // 주문 내역
+	DataExportTaxReport DataExportKind = "tax_report" // 연간 실현 손익 (평균 단가 기준)
+
+	DataExportPersonalData DataExportKind = "personal_data" // 계정에 저장된 개인 데이터 전체 (항상 JSON)
+	DataExportFeeStatement DataExportKind = "fee_statement" // 월간 수수료 명세서 (항상 PDF, 만료 없음)
+)
+
+// DataExportFormat 내보내기 파일 형식
+type DataExportFormat string
+
+const (
+	DataExportCSV  DataExportFormat = "csv"
+	DataExportXLSX DataExportFormat = "xlsx"
+	DataExportJSON DataExportFormat = "json" // 개인 데이터 내보내기 전용
+	DataExportPDF  DataExportFormat = "pdf"  // 수수료 명세서 전용
+)
+
+// IsValid 거래 내역 내보내기에서 선택 가능한 형식 여부
+func (f DataExportFormat) IsValid() bool {
+	return f == DataExportCSV || f == DataExportXLSX
+}
+
+// DataExportStatus 내보내기 진행 상태
+type DataExportStatus string
+
+const (
+	DataExportPending    DataExportStatus = "pending"    // 워커 대기
+	DataExportProcessing DataExportStatus = "processing" // 생성 중
+	DataExportCompleted  DataExportStatus = "completed"  // 다운로드 가능
+	DataExportFailed     DataExportStatus = "failed"     // 생성 실패
+	DataExportExpired    DataExportStatus = "expired"    // 보관 기간 만료로 파일 삭제
+)
+
+// DataExport 회계/세무용 거래 내역 내보내기 요청 (파일은 워커가 비동기로 생성)
+type DataExport struct {
+	ID     uint             `json:"id" gorm:"primaryKey"`
+	UserID uint             `json:"user_id" gorm:"not null;index"`
+	Kind   DataExportKind   `json:"kind" gorm:"size:20;not null"`
+	Format DataExportFormat `json:"format" gorm:"size:10;not null"`
+	Year   int              `json:"year" gorm:"not null"`
+	Month  int              `json:"month,omitempty" gorm:"not null;default:0"` // 월간 명세서의 월 (1~12, 연간 내보내기는 0)
+
+	Status      DataExportStatus `json:"status" gorm:"size:20;not null;default:'pending';index"`
+	FilePath    string           `json:"-" gorm:"size:100"` // exports/<저장 키>
+	FileName    string           `json:"file_name,omitempty" gorm:"size:100"`
+	RowCount    int              `json:"row_count"`
+	Error       string           `json:"error,omitempty" gorm:"type:text"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time       `json:"expires_at,omitempty"` // 파일 보관 기한
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
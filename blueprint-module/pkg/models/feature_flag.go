@@ -0,0 +1,93 @@
+package models
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+)
+
+// FeatureFlagKey 기능 플래그 키
+type FeatureFlagKey string
+
+const (
+	FlagDistributedMatching FeatureFlagKey = "distributed_matching" // 분산 매칭 엔진 (서버 시작 시 엔진 모드 결정)
+	FlagAMMLiquidity        FeatureFlagKey = "amm_liquidity"        // 마켓메이커 봇 자동 호가 공급
+	FlagSandboxMode         FeatureFlagKey = "sandbox_mode"         // 모의 거래(샌드박스) 화면 노출 (클라이언트가 평가 결과로 판단)
+	FlagNewFeeTiers         FeatureFlagKey = "new_fee_tiers"        // 메이커/테이커 분리 수수료 (테이커 사용자 기준)
+)
+
+// FeatureFlagDefinition 정의된 플래그와 DB에 상태가 없을 때의 기본값
+type FeatureFlagDefinition struct {
+	Key         FeatureFlagKey `json:"key"`
+	Description string         `json:"description"`
+	Default     bool           `json:"default"`
+}
+
+// FeatureFlagDefinitions 정의된 기능 플래그 목록 (기존 동작을 유지하는 값이 기본값)
+var FeatureFlagDefinitions = []FeatureFlagDefinition{
+	{FlagDistributedMatching, "분산 매칭 엔진 사용 (MATCHING_ENGINE_MODE=local일 때만 의미, 서버 재시작 시 적용)", false},
+	{FlagAMMLiquidity, "마켓메이커 봇 호가 공급 (끄면 봇의 미체결 주문을 취소하고 대기)", true},
+	{FlagSandboxMode, "모의 거래(샌드박스) 화면 노출", false},
+	{FlagNewFeeTiers, "메이커/테이커 분리 수수료 (테이커가 적용 대상일 때)", false},
+}
+
+// Definition 플래그 정의 조회
+func (k FeatureFlagKey) Definition() (FeatureFlagDefinition, bool) {
+	for _, definition := range FeatureFlagDefinitions {
+		if definition.Key == k {
+			return definition, true
+		}
+	}
+	return FeatureFlagDefinition{}, false
+}
+
+// IsValid 정의된 플래그인지 확인
+func (k FeatureFlagKey) IsValid() bool {
+	_, ok := k.Definition()
+	return ok
+}
+
+// FeatureFlag 기능 플래그 상태 (행이 없으면 정의된 기본값으로 간주)
+type FeatureFlag struct {
+	Key            FeatureFlagKey `json:"key" gorm:"primaryKey;size:50"`
+	Enabled        bool           `json:"enabled"`
+	RolloutPercent int            `json:"rollout_percent"`                                 // 켜진 상태에서 적용할 사용자 비율 (0-100)
+	AllowUserIDs   []uint         `json:"allow_user_ids" gorm:"type:text;serializer:json"` // 비율과 무관하게 적용할 사용자
+
+	UpdatedBy *uint  `json:"updated_by,omitempty"` // nil이면 기본값
+	Reason    string `json:"reason,omitempty" gorm:"size:500"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// EnabledFor 사용자에게 적용되는지 (허용 목록 → 사용자 ID 해시 버킷 순으로 판단)
+func (f *FeatureFlag) EnabledFor(userID uint) bool {
+	if !f.Enabled {
+		return false
+	}
+	if f.RolloutPercent >= 100 {
+		return true
+	}
+	for _, allowed := range f.AllowUserIDs {
+		if allowed == userID {
+			return true
+		}
+	}
+	return userID != 0 && RolloutBucket(f.Key, userID) < f.RolloutPercent
+}
+
+// RolloutBucket 플래그별 사용자 버킷 (0-99, 비율을 늘려도 이미 포함된 사용자는 계속 포함)
+func RolloutBucket(key FeatureFlagKey, userID uint) int {
+	hash := fnv.New32a()
+	hash.Write([]byte(fmt.Sprintf("%s:%d", key, userID)))
+	return int(hash.Sum32() % 100)
+}
+
+// UpdateFeatureFlagRequest 기능 플래그 변경 요청 (비운 필드는 유지)
+type UpdateFeatureFlagRequest struct {
+	Enabled        *bool  `json:"enabled"`
+	RolloutPercent *int   `json:"rollout_percent" binding:"omitempty,min=0,max=100"`
+	AllowUserIDs   []uint `json:"allow_user_ids"`
+	Reason         string `json:"reason" binding:"max=500"`
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// GitHubWebhookTrigger 증거 자동 생성 조건
+type GitHubWebhookTrigger string
+
+const (
+	GitHubTriggerReleasePublished GitHubWebhookTrigger = "release_published" // 릴리스 게시
+	GitHubTriggerTagPushed        GitHubWebhookTrigger = "tag_pushed"        // 태그 푸시
+	GitHubTriggerCIPassed         GitHubWebhookTrigger = "ci_passed"         // GitHub Actions 워크플로 성공
+)
+
+// ValidGitHubWebhookTriggers 등록 가능한 트리거 목록
+var ValidGitHubWebhookTriggers = []GitHubWebhookTrigger{
+	GitHubTriggerReleasePublished,
+	GitHubTriggerTagPushed,
+	GitHubTriggerCIPassed,
+}
+
+// GitHubEvent 트리거가 구독하는 GitHub 웹훅 이벤트 이름
+func (t GitHubWebhookTrigger) GitHubEvent() string {
+	switch t {
+	case GitHubTriggerReleasePublished:
+		return "release"
+	case GitHubTriggerTagPushed:
+		return "create"
+	case GitHubTriggerCIPassed:
+		return "workflow_run"
+	default:
+		return ""
+	}
+}
+
+// GitHubDeliveryStatus 웹훅 수신 처리 결과
+type GitHubDeliveryStatus string
+
+const (
+	GitHubDeliveryProofCreated GitHubDeliveryStatus = "proof_created" // 증거 생성됨
+	GitHubDeliveryIgnored      GitHubDeliveryStatus = "ignored"       // 트리거 조건 불일치
+	GitHubDeliveryRejected     GitHubDeliveryStatus = "rejected"      // 증거 제출 불가 (마일스톤 상태 등)
+)
+
+// GitHubConnection GitHub 계정 연동 (OAuth 토큰 보관)
+type GitHubConnection struct {
+	ID     uint `json:"id" gorm:"primaryKey"`
+	UserID uint `json:"user_id" gorm:"not null;uniqueIndex"`
+
+	GitHubUserID int64  `json:"github_user_id" gorm:"not null;index"`
+	Login        string `json:"login" gorm:"size:100;not null"`
+	Scopes       string `json:"scopes" gorm:"size:255"`
+
+	// 웹훅 등록에 필요하므로 서버 키로 암호화하여 보관
+	EncryptedToken string `json:"-" gorm:"type:text;not null"`
+
+	ConnectedAt time.Time `json:"connected_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// 외래키 참조
+	User User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// GitHubWebhookSubscription 마일스톤별 저장소 웹훅 구독
+type GitHubWebhookSubscription struct {
+	ID          uint `json:"id" gorm:"primaryKey"`
+	UserID      uint `json:"user_id" gorm:"not null;index"`
+	MilestoneID uint `json:"milestone_id" gorm:"not null;index"`
+
+	RepoFullName string `json:"repo_full_name" gorm:"size:200;not null"` // owner/repo
+	Triggers     string `json:"triggers" gorm:"size:100;not null"`       // 쉼표 구분 (release_published,tag_pushed,ci_passed)
+	Branch       string `json:"branch" gorm:"size:100"`                  // ci_passed 대상 브랜치
+
+	HookID          int64  `json:"hook_id" gorm:"index"` // GitHub 웹훅 ID (X-GitHub-Hook-ID)
+	EncryptedSecret string `json:"-" gorm:"type:text;not null"`
+
+	Active          bool       `json:"active" gorm:"default:true;index"`
+	LastTriggeredAt *time.Time `json:"last_triggered_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// 외래키 참조
+	User      User      `json:"-" gorm:"foreignKey:UserID"`
+	Milestone Milestone `json:"-" gorm:"foreignKey:MilestoneID"`
+}
+
+// HasTrigger 트리거 구독 여부
+func (s *GitHubWebhookSubscription) HasTrigger(trigger GitHubWebhookTrigger) bool {
+	for _, t := range strings.Split(s.Triggers, ",") {
+		if GitHubWebhookTrigger(strings.TrimSpace(t)) == trigger {
+			return true
+		}
+	}
+	return false
+}
+
+// GitHubWebhookDelivery 웹훅 수신 기록 (X-GitHub-Delivery 기준 중복 처리 방지)
+type GitHubWebhookDelivery struct {
+	ID             uint   `json:"id" gorm:"primaryKey"`
+	DeliveryID     string `json:"delivery_id" gorm:"size:64;uniqueIndex;not null"`
+	SubscriptionID uint   `json:"subscription_id" gorm:"not null;index"`
+
+	Event   string               `json:"event" gorm:"size:50"`
+	Trigger GitHubWebhookTrigger `json:"trigger,omitempty" gorm:"size:30"`
+	Status  GitHubDeliveryStatus `json:"status" gorm:"size:20;not null"`
+	ProofID *uint                `json:"proof_id,omitempty"`
+	Message string               `json:"message,omitempty" gorm:"type:text"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateGitHubWebhookRequest 웹훅 구독 등록 요청
+type CreateGitHubWebhookRequest struct {
+	Repo     string                 `json:"repo" binding:"required"` // owner/repo
+	Triggers []GitHubWebhookTrigger `json:"triggers" binding:"required,min=1"`
+	Branch   string                 `json:"branch"` // 비우면 저장소 기본 브랜치
+}
+
+// GitHubRepository 연동 가능한 저장소 정보
+type GitHubRepository struct {
+	FullName      string `json:"full_name"`
+	Private       bool   `json:"private"`
+	DefaultBranch string `json:"default_branch"`
+	HTMLURL       string `json:"html_url"`
+	CanAdmin      bool   `json:"can_admin"` // 웹훅 등록에 관리자 권한 필요
+}
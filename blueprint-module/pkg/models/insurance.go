@@ -0,0 +1,80 @@
+package models
+
+import "time"
+
+// InsurancePool 카테고리별 마일스톤 보험 풀 (보험료가 쌓이고 보험금이 빠져나감, USDC 센트)
+type InsurancePool struct {
+	ID             uint            `json:"id" gorm:"primaryKey"`
+	Category       ProjectCategory `json:"category" gorm:"type:varchar(20);not null;uniqueIndex"`
+	Balance        int64           `json:"balance"`         // 지급 가능 잔액
+	ActiveCoverage int64           `json:"active_coverage"` // 유효한 보험의 보장 금액 합계 (지급 책임)
+	TotalPremiums  int64           `json:"total_premiums"`  // 누적 보험료 수입
+	TotalClaims    int64           `json:"total_claims"`    // 누적 보험금 지급
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// InsurancePolicyStatus 보험 상태
+type InsurancePolicyStatus string
+
+const (
+	InsurancePolicyActive  InsurancePolicyStatus = "active"  // 보장 중
+	InsurancePolicyClaimed InsurancePolicyStatus = "claimed" // 보험금 지급 완료
+	InsurancePolicyExpired InsurancePolicyStatus = "expired" // 청구 기한 경과 (보험료는 풀에 귀속)
+)
+
+// InsurancePolicy 마일스톤 보유자의 사기 보험 (가입 시점 롱 포지션 취득 원가 한도)
+//
+// 마일스톤이 배심원 중재에서 프로젝트 사기로 확정되면 보장 금액을 풀에서 받을 수 있다.
+// 청구 기한은 거래 마감 후 InsuranceClaimWindow까지이며, 그 사이 진행 중인 사기 사건이 있으면 끝날 때까지 연장된다.
+type InsurancePolicy struct {
+	ID          uint                  `json:"id" gorm:"primaryKey"`
+	UserID      uint                  `json:"user_id" gorm:"not null;index"`
+	PoolID      uint                  `json:"pool_id" gorm:"not null;index"`
+	ProjectID   uint                  `json:"project_id" gorm:"not null"`
+	MilestoneID uint                  `json:"milestone_id" gorm:"not null;index"`
+	OptionID    string                `json:"option_id" gorm:"not null"`
+	Coverage    int64                 `json:"coverage"`     // 보장 금액 (센트)
+	Premium     int64                 `json:"premium"`      // 납부한 보험료 (센트)
+	PremiumRate float64               `json:"premium_rate"` // 가입 시 적용 요율
+	Status      InsurancePolicyStatus `json:"status" gorm:"type:varchar(20);not null;default:'active';index"`
+	ExpiresAt   time.Time             `json:"expires_at" gorm:"index"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+
+	Milestone Milestone       `json:"milestone,omitempty" gorm:"foreignKey:MilestoneID"`
+	Claim     *InsuranceClaim `json:"claim,omitempty" gorm:"foreignKey:PolicyID"`
+}
+
+// InsuranceClaim 보험금 지급 기록 (보험당 하나)
+type InsuranceClaim struct {
+	ID                uint      `json:"id" gorm:"primaryKey"`
+	PolicyID          uint      `json:"policy_id" gorm:"not null;uniqueIndex"`
+	UserID            uint      `json:"user_id" gorm:"not null;index"`
+	ArbitrationCaseID uint      `json:"arbitration_case_id" gorm:"not null;index"` // 사기 판결이 확정된 사건
+	Requested         int64     `json:"requested"`                                 // 보장 금액
+	Paid              int64     `json:"paid"`                                      // 실제 지급액 (풀 잔액이 부족하면 일부)
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// InsuranceQuote 보험료 견적
+type InsuranceQuote struct {
+	MilestoneID  uint            `json:"milestone_id"`
+	OptionID     string          `json:"option_id"`
+	Category     ProjectCategory `json:"category"`
+	Coverage     int64           `json:"coverage"`
+	MaxCoverage  int64           `json:"max_coverage"` // 보장 가능 한도 (롱 포지션 취득 원가 - 기존 보장)
+	PremiumRate  float64         `json:"premium_rate"`
+	Premium      int64           `json:"premium"`
+	RiskScore    float64         `json:"risk_score"`    // 적용한 마일스톤 위험 점수
+	PoolBalance  int64           `json:"pool_balance"`  // 풀 잔액
+	PoolCoverage int64           `json:"pool_coverage"` // 풀의 기존 보장 합계
+	ExpiresAt    time.Time       `json:"expires_at"`
+}
+
+// InsuranceQuoteRequest 보험료 견적/가입
+type InsuranceQuoteRequest struct {
+	MilestoneID uint   `json:"milestone_id" form:"milestone_id" binding:"required"`
+	OptionID    string `json:"option_id" form:"option_id" binding:"required"`
+	Coverage    int64  `json:"coverage" form:"coverage" binding:"required,min=100"` // 센트 ($1 이상)
+}
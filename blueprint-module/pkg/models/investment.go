@@ -35,23 +35,26 @@ const (
 
 // Order P2P 주문 (폴리마켓 스타일)
 type Order struct {
-	ID          uint        `json:"id" gorm:"primaryKey"`
-	ProjectID   uint        `json:"project_id"`
-	MilestoneID uint        `json:"milestone_id"`
-	OptionID    string      `json:"option_id"`
-	UserID      uint        `json:"user_id"`
-	Type        OrderType   `json:"type"`
-	Side        OrderSide   `json:"side"`
-	Quantity    int64       `json:"quantity"`     // 주문 수량
-	Price       float64     `json:"price"`        // 주문 가격 (0-1 사이)
-	Filled      int64       `json:"filled"`       // 체결된 수량
-	Remaining   int64       `json:"remaining"`    // 남은 수량
-	Status      OrderStatus `json:"status"`
-	ExpiresAt   *time.Time  `json:"expires_at,omitempty"`
-	IPAddress   string      `json:"ip_address,omitempty"`
-	UserAgent   string      `json:"user_agent,omitempty"`
-	CreatedAt   time.Time   `json:"created_at"`
-	UpdatedAt   time.Time   `json:"updated_at"`
+	ID            uint           `json:"id" gorm:"primaryKey"`
+	ProjectID     uint           `json:"project_id"`
+	MilestoneID   uint           `json:"milestone_id"`
+	OptionID      string         `json:"option_id"`
+	UserID        uint           `json:"user_id"`
+	Type          OrderType      `json:"type"`
+	Side          OrderSide      `json:"side"`
+	Quantity      int64          `json:"quantity"`                                                       // 주문 수량
+	Price         float64        `json:"price"`                                                          // 주문 가격 (0-1 사이, 표시용 - PriceTicks에서 계산)
+	PriceTicks    int64          `json:"price_ticks" gorm:"not null;default:0;index"`                    // 주문 가격 (PriceScale 단위 정수)
+	Filled        int64          `json:"filled"`                                                         // 체결된 수량
+	Remaining     int64          `json:"remaining"`                                                      // 남은 수량
+	ShortQuantity int64          `json:"short_quantity" gorm:"not null;default:0"`                       // 접수 시 매도 가능 수량을 넘은 숏 매도 수량 (담보 잠금 대상)
+	QuoteCurrency LedgerCurrency `json:"quote_currency" gorm:"type:varchar(16);not null;default:'usdc'"` // 주문 금액을 잠근 결제 통화 (마켓 결제 통화)
+	Status        OrderStatus    `json:"status"`
+	ExpiresAt     *time.Time     `json:"expires_at,omitempty"`
+	IPAddress     string         `json:"ip_address,omitempty"`
+	UserAgent     string         `json:"user_agent,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
 
 	// 관계
 	User      User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
@@ -61,20 +64,26 @@ type Order struct {
 
 // Trade 거래 내역
 type Trade struct {
-	ID           uint      `json:"id" gorm:"primaryKey"`
-	ProjectID    uint      `json:"project_id"`
-	MilestoneID  uint      `json:"milestone_id"`
-	OptionID     string    `json:"option_id"`
-	BuyOrderID   uint      `json:"buy_order_id"`
-	SellOrderID  uint      `json:"sell_order_id"`
-	BuyerID      uint      `json:"buyer_id"`
-	SellerID     uint      `json:"seller_id"`
-	Quantity     int64     `json:"quantity"`     // 거래 수량
-	Price        float64   `json:"price"`        // 거래 가격
-	TotalAmount  int64     `json:"total_amount"` // 총 거래 금액 (points)
-	BuyerFee     int64     `json:"buyer_fee"`    // 매수자 수수료
-	SellerFee    int64     `json:"seller_fee"`   // 매도자 수수료
-	CreatedAt    time.Time `json:"created_at"`
+	ID            uint           `json:"id" gorm:"primaryKey"`
+	ProjectID     uint           `json:"project_id"`
+	MilestoneID   uint           `json:"milestone_id"`
+	OptionID      string         `json:"option_id"`
+	BuyOrderID    uint           `json:"buy_order_id"`
+	SellOrderID   uint           `json:"sell_order_id"`
+	BuyerID       uint           `json:"buyer_id"`
+	SellerID      uint           `json:"seller_id"`
+	Quantity      int64          `json:"quantity"`                                                       // 거래 수량
+	Price         float64        `json:"price"`                                                          // 거래 가격 (표시용 - PriceTicks에서 계산)
+	PriceTicks    int64          `json:"price_ticks" gorm:"not null;default:0"`                          // 거래 가격 (PriceScale 단위 정수)
+	TotalAmount   int64          `json:"total_amount"`                                                   // 총 거래 금액 (points)
+	BuyerFee      int64          `json:"buyer_fee"`                                                      // 매수자 수수료
+	SellerFee     int64          `json:"seller_fee"`                                                     // 매도자 수수료
+	QuoteCurrency LedgerCurrency `json:"quote_currency" gorm:"type:varchar(16);not null;default:'usdc'"` // 금액/수수료 통화
+	CreatedAt     time.Time      `json:"created_at"`
+
+	// 정산용 (저장하지 않음)
+	BuyerRelease     int64 `json:"-" gorm:"-"` // 이번 체결로 풀리는 매수 주문 잠금액
+	SellerCollateral int64 `json:"-" gorm:"-"` // 이번 체결로 매도 주문 잠금에서 숏 포지션 담보로 옮겨지는 금액
 
 	// 관계
 	BuyOrder  Order     `json:"buy_order,omitempty" gorm:"foreignKey:BuyOrderID"`
@@ -92,11 +101,12 @@ type Position struct {
 	ProjectID   uint      `json:"project_id"`
 	MilestoneID uint      `json:"milestone_id"`
 	OptionID    string    `json:"option_id"`
-	Quantity    int64     `json:"quantity"`      // 보유 수량 (+매수, -매도)
-	AvgPrice    float64   `json:"avg_price"`     // 평균 취득 가격
-	TotalCost   int64     `json:"total_cost"`    // 총 투입 비용
-	Realized    int64     `json:"realized"`      // 실현 손익
-	Unrealized  int64     `json:"unrealized"`    // 미실현 손익
+	Quantity    int64     `json:"quantity"`                             // 보유 수량 (+매수, -매도)
+	AvgPrice    float64   `json:"avg_price"`                            // 평균 취득 가격
+	TotalCost   int64     `json:"total_cost"`                           // 총 투입 비용
+	Realized    int64     `json:"realized"`                             // 실현 손익
+	Unrealized  int64     `json:"unrealized"`                           // 미실현 손익
+	Collateral  int64     `json:"collateral" gorm:"not null;default:0"` // 숏 포지션 담보 (잠긴 USDC 센트, 환매/정산 시 해제)
 	UpdatedAt   time.Time `json:"updated_at"`
 
 	// 관계
@@ -124,6 +134,14 @@ type MarketData struct {
 	MarketCap       int64     `json:"market_cap"`        // 시가총액
 	Liquidity       int64     `json:"liquidity"`         // 유동성
 	LastTradeTime   time.Time `json:"last_trade_time"`   // 마지막 거래 시간
+
+	// 기간 평균 가격 (얇은 호가에서 직전 체결가 조작 대비, 체결 이벤트 소비자가 갱신)
+	TWAP1h           float64    `json:"twap_1h" gorm:"column:twap_1h"`   // 1시간 시간 가중 평균가
+	TWAP24h          float64    `json:"twap_24h" gorm:"column:twap_24h"` // 24시간 시간 가중 평균가
+	VWAP1h           float64    `json:"vwap_1h" gorm:"column:vwap_1h"`   // 1시간 거래량 가중 평균가 (체결 없으면 0)
+	VWAP24h          float64    `json:"vwap_24h" gorm:"column:vwap_24h"` // 24시간 거래량 가중 평균가 (체결 없으면 0)
+	MetricsUpdatedAt *time.Time `json:"metrics_updated_at,omitempty"`
+
 	UpdatedAt       time.Time `json:"updated_at"`
 
 	// 관계
@@ -375,11 +393,18 @@ type OrderResponse struct {
 	Trades []Trade `json:"trades,omitempty"`
 }
 
+// CancelOrderResponse 주문 취소 응답
+type CancelOrderResponse struct {
+	Order          Order `json:"order"`           // 취소 직전까지 반영된 체결 수량 포함
+	ReleasedAmount int64 `json:"released_amount"` // 해제된 매수 잠금액 (센트, 매도 주문은 0)
+}
+
 // OrderBookLevel 호가창 레벨
 type OrderBookLevel struct {
 	Price    float64 `json:"price"`
 	Quantity int64   `json:"quantity"`
 	Count    int     `json:"count"` // 주문 개수
+	Total    int64   `json:"total"` // 최우선 호가부터 이 레벨까지 누적 수량
 }
 
 // OrderBook 호가창
@@ -389,6 +414,9 @@ type OrderBook struct {
 	Bids        []OrderBookLevel `json:"bids"` // 매수 호가 (높은 가격부터)
 	Asks        []OrderBookLevel `json:"asks"` // 매도 호가 (낮은 가격부터)
 	Spread      float64          `json:"spread"`
+	Sequence    uint64           `json:"sequence"`    // 주문장 변경마다 1씩 증가 (SSE 업데이트와 동기화용)
+	Depth       int              `json:"depth"`       // 한쪽 최대 레벨 수
+	Aggregation float64          `json:"aggregation"` // 가격 집계 단위 (0이면 미집계)
 	LastUpdate  time.Time        `json:"last_update"`
 }
 
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// JobStatus 워커 작업 진행 상태
+type JobStatus string
+
+const (
+	JobQueued     JobStatus = "queued"     // 큐에서 대기 (재시도 대기 포함)
+	JobProcessing JobStatus = "processing" // 워커가 처리 중
+	JobSucceeded  JobStatus = "succeeded"  // 처리 완료
+	JobFailed     JobStatus = "failed"     // 재시도를 모두 소진하고 DLQ로 이동 (Error에 사유)
+)
+
+// IsFinished 더 이상 바뀌지 않는 상태 여부
+func (s JobStatus) IsFinished() bool {
+	return s == JobSucceeded || s == JobFailed
+}
+
+// Job 큐로 워커에 넘긴 작업의 진행 상태 (이메일/SMS/파일 처리/검증 등)
+//
+// ID는 추측할 수 없는 임의 값이라 발급받은 클라이언트만 GET /api/v1/jobs/:id 로 조회할 수 있다.
+type Job struct {
+	ID       string    `json:"id" gorm:"primaryKey;size:32"`
+	Queue    string    `json:"queue" gorm:"size:50;not null"`
+	Type     string    `json:"type" gorm:"size:50"`
+	UserID   uint      `json:"-" gorm:"index"` // 작업을 요청한 사용자 (없으면 0)
+	Status   JobStatus `json:"status" gorm:"size:20;not null;default:'queued';index"`
+	Attempts int       `json:"attempts"`                         // 워커가 처리를 시작한 횟수
+	Error    string    `json:"error,omitempty" gorm:"type:text"` // 마지막 실패 사유
+
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
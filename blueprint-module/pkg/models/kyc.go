@@ -0,0 +1,96 @@
+package models
+
+import "time"
+
+// KYCTier 본인 인증 단계 (단계별 거래/출금 한도 적용)
+type KYCTier int
+
+const (
+	KYCTierNone     KYCTier = 0 // 미인증
+	KYCTierBasic    KYCTier = 1 // 신분증 확인
+	KYCTierEnhanced KYCTier = 2 // 신분증 + 주소/자금 출처 확인 (강화된 AML)
+)
+
+// KYCStatus 본인 인증 심사 상태
+type KYCStatus string
+
+const (
+	KYCStatusNone     KYCStatus = "none"     // 신청 전
+	KYCStatusPending  KYCStatus = "pending"  // 심사 중
+	KYCStatusApproved KYCStatus = "approved" // 승인
+	KYCStatusRejected KYCStatus = "rejected" // 거절
+)
+
+// KYCTierLimit 인증 단계별 한도 (센트 단위)
+type KYCTierLimit struct {
+	MaxOrderValue   int64 `json:"max_order_value"`  // 주문 1건당 최대 금액
+	DailyWithdrawal int64 `json:"daily_withdrawal"` // 일일 출금 한도 (0이면 출금 불가)
+}
+
+// KYCTierLimits 단계별 한도 정책
+var KYCTierLimits = map[KYCTier]KYCTierLimit{
+	KYCTierNone:     {MaxOrderValue: 10_000, DailyWithdrawal: 0},             // $100, 출금 불가
+	KYCTierBasic:    {MaxOrderValue: 500_000, DailyWithdrawal: 200_000},      // $5,000 / $2,000
+	KYCTierEnhanced: {MaxOrderValue: 10_000_000, DailyWithdrawal: 5_000_000}, // $100,000 / $50,000
+}
+
+// KYCVerification 사용자 본인 인증 정보 (사용자당 1건, 재신청 시 갱신)
+type KYCVerification struct {
+	ID     uint `json:"id" gorm:"primaryKey"`
+	UserID uint `json:"user_id" gorm:"uniqueIndex;not null"`
+
+	Tier          KYCTier   `json:"tier" gorm:"default:0"`           // 승인된 단계
+	RequestedTier KYCTier   `json:"requested_tier" gorm:"default:0"` // 심사 중인 단계
+	Status        KYCStatus `json:"status" gorm:"size:20;default:'none';index"`
+
+	// 인증 제공업체
+	Provider          string `json:"provider" gorm:"size:30"`
+	ProviderReference string `json:"-" gorm:"size:100;index"`
+
+	// 신청 정보
+	FullName     string `json:"full_name" gorm:"size:200"`
+	DateOfBirth  string `json:"date_of_birth" gorm:"size:10"` // YYYY-MM-DD
+	Country      string `json:"country" gorm:"size:2"`        // ISO 3166-1 alpha-2
+	DocumentType string `json:"document_type" gorm:"size:30"`
+	DocumentPath string `json:"-" gorm:"size:200"`               // 업로드된 신분증 저장 경로 (category/key)
+	DocumentURL  string `json:"document_url,omitempty" gorm:"-"` // 심사자용 서명된 다운로드 URL (응답용)
+
+	// 심사 결과
+	AMLFlagged      bool       `json:"aml_flagged" gorm:"default:false"`
+	RejectionReason string     `json:"rejection_reason,omitempty" gorm:"size:500"`
+	ReviewedBy      *uint      `json:"reviewed_by,omitempty"`
+	SubmittedAt     *time.Time `json:"submitted_at,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// 외래키 참조
+	User User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// SubmitKYCRequest 본인 인증 신청
+// 신분증 이미지는 multipart "document" 필드로 함께 업로드
+type SubmitKYCRequest struct {
+	Tier         KYCTier `json:"tier" form:"tier" binding:"required,oneof=1 2"`
+	FullName     string  `json:"full_name" form:"full_name" binding:"required,max=200"`
+	DateOfBirth  string  `json:"date_of_birth" form:"date_of_birth" binding:"required"` // YYYY-MM-DD
+	Country      string  `json:"country" form:"country" binding:"required,len=2"`
+	DocumentType string  `json:"document_type" form:"document_type" binding:"required,oneof=passport id_card driver_license"`
+}
+
+// ReviewKYCRequest 수동 심사 결과
+type ReviewKYCRequest struct {
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason" binding:"max=500"`
+}
+
+// KYCStatusResponse 내 본인 인증 상태 + 현재/다음 단계 한도
+type KYCStatusResponse struct {
+	Tier            KYCTier       `json:"tier"`
+	Status          KYCStatus     `json:"status"`
+	RequestedTier   KYCTier       `json:"requested_tier,omitempty"`
+	RejectionReason string        `json:"rejection_reason,omitempty"`
+	Limits          KYCTierLimit  `json:"limits"`
+	NextTierLimits  *KYCTierLimit `json:"next_tier_limits,omitempty"`
+}
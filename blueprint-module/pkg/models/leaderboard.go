@@ -0,0 +1,87 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// LeaderboardKind 리더보드 종류
+type LeaderboardKind string
+
+const (
+	LeaderboardTraders    LeaderboardKind = "traders"    // 거래 손익 (센트)
+	LeaderboardValidators LeaderboardKind = "validators" // 검증 정확도 (%)
+	LeaderboardMentors    LeaderboardKind = "mentors"    // 멘토 성과 점수 (0-100)
+)
+
+// LeaderboardKinds 집계 대상 리더보드 목록
+var LeaderboardKinds = []LeaderboardKind{LeaderboardTraders, LeaderboardValidators, LeaderboardMentors}
+
+// IsValid 정의된 리더보드인지 확인
+func (k LeaderboardKind) IsValid() bool {
+	for _, kind := range LeaderboardKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// LeaderboardPeriod 집계 기간
+type LeaderboardPeriod string
+
+const (
+	LeaderboardWeekly  LeaderboardPeriod = "weekly"   // 최근 7일
+	LeaderboardMonthly LeaderboardPeriod = "monthly"  // 최근 1개월
+	LeaderboardAllTime LeaderboardPeriod = "all_time" // 전체 기간
+)
+
+// LeaderboardPeriods 집계 대상 기간 목록
+var LeaderboardPeriods = []LeaderboardPeriod{LeaderboardWeekly, LeaderboardMonthly, LeaderboardAllTime}
+
+// ParseLeaderboardPeriod 쿼리 값 파싱 ("all-time"도 허용)
+func ParseLeaderboardPeriod(value string) (LeaderboardPeriod, bool) {
+	period := LeaderboardPeriod(strings.ReplaceAll(strings.ToLower(value), "-", "_"))
+	for _, p := range LeaderboardPeriods {
+		if period == p {
+			return period, true
+		}
+	}
+	return "", false
+}
+
+// Since 기간 시작 시각 (전체 기간이면 zero time)
+func (p LeaderboardPeriod) Since(now time.Time) time.Time {
+	switch p {
+	case LeaderboardWeekly:
+		return now.AddDate(0, 0, -7)
+	case LeaderboardMonthly:
+		return now.AddDate(0, -1, 0)
+	default:
+		return time.Time{}
+	}
+}
+
+// LeaderboardEntry 리더보드 캐시 (주기적으로 재계산되어 종류/기간별로 통째로 교체됨)
+type LeaderboardEntry struct {
+	ID         uint              `json:"-" gorm:"primaryKey"`
+	Kind       LeaderboardKind   `json:"kind" gorm:"type:varchar(20);not null;uniqueIndex:idx_leaderboard_rank"`
+	Period     LeaderboardPeriod `json:"period" gorm:"type:varchar(20);not null;uniqueIndex:idx_leaderboard_rank"`
+	Rank       int               `json:"rank" gorm:"not null;uniqueIndex:idx_leaderboard_rank"`
+	UserID     uint              `json:"user_id" gorm:"not null;index"`
+	Username   string            `json:"username"`
+	Score      float64           `json:"score"`       // 종류별 정렬 기준 값
+	SampleSize int               `json:"sample_size"` // 거래 수 / 검증 투표 수 / 성과 지표 수
+	ComputedAt time.Time         `json:"computed_at"`
+}
+
+// LeaderboardResponse 리더보드 조회 응답
+type LeaderboardResponse struct {
+	Kind       LeaderboardKind    `json:"kind"`
+	Period     LeaderboardPeriod  `json:"period"`
+	Entries    []LeaderboardEntry `json:"entries"`
+	Total      int64              `json:"total"`
+	Limit      int                `json:"limit"`
+	Offset     int                `json:"offset"`
+	ComputedAt *time.Time         `json:"computed_at,omitempty"`
+}
@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// LedgerCurrency 원장 통화
+//
+// usdc/blueprint는 UserWallet 컬럼에, 설정(WALLET_CURRENCIES)으로 추가한 결제 통화는 WalletAccount 행에 잔액을 둔다.
+// 추가 통화는 USDC처럼 1/100 단위(센트)로 저장한다.
+type LedgerCurrency string
+
+const (
+	LedgerCurrencyUSDC      LedgerCurrency = "usdc"      // 센트 단위
+	LedgerCurrencyBlueprint LedgerCurrency = "blueprint" // Wei 단위
+)
+
+// OrUSDC 비어 있으면 USDC (결제 통화 컬럼 추가 전 주문/체결/마켓)
+func (c LedgerCurrency) OrUSDC() LedgerCurrency {
+	if c == "" {
+		return LedgerCurrencyUSDC
+	}
+	return c
+}
+
+// LedgerEntryType 원장 항목 종류
+type LedgerEntryType string
+
+const (
+	LedgerArbitrationStakeLock   LedgerEntryType = "arbitration_stake_lock"   // 분쟁/항소 스테이킹 잠금 (사용 가능 → 잠금)
+	LedgerArbitrationStakeReturn LedgerEntryType = "arbitration_stake_return" // 분쟁 스테이킹 반환 (잠금 → 사용 가능)
+	LedgerArbitrationFee         LedgerEntryType = "arbitration_fee"          // 분쟁 스테이킹 중 배심원 보상 풀 귀속분
+	LedgerArbitrationAward       LedgerEntryType = "arbitration_award"        // 판결에 따른 당사자 간 배상
+	LedgerJurorReward            LedgerEntryType = "juror_reward"             // 다수 의견 배심원 보상
+	LedgerJurorSlash             LedgerEntryType = "juror_slash"              // 소수 의견/미참여 배심원 스테이킹 차감
+	LedgerMentorReward           LedgerEntryType = "mentor_reward"            // 멘토 풀 보상 청구
+	LedgerStakingReward          LedgerEntryType = "staking_reward"           // 스테이킹 발행 보상 청구
+	LedgerAccountMerge           LedgerEntryType = "account_merge"            // 중복 계정 병합에 따른 잔액 이전
+	LedgerReconcileAdjustment    LedgerEntryType = "reconcile_adjustment"     // 대사로 확인된 잠금액 초과분 해제 (잠금 → 사용 가능)
+	LedgerEscrowLock             LedgerEntryType = "escrow_lock"              // 마일스톤 후원 예치 (사용 가능 → 잠금)
+	LedgerEscrowRelease          LedgerEntryType = "escrow_release"           // 완료 확정으로 후원 잠금 지급 (후원자 잠금 차감)
+	LedgerEscrowPayout           LedgerEntryType = "escrow_payout"            // 완료 확정 후원금 수령 (프로젝트 소유자)
+	LedgerEscrowRefund           LedgerEntryType = "escrow_refund"            // 실패/취소로 후원 반환 (잠금 → 사용 가능)
+	LedgerCreatorPoolShare       LedgerEntryType = "creator_pool_share"       // 분배되지 않은 멘토 풀의 프로젝트 소유자 몫
+	LedgerPayoutHold             LedgerEntryType = "payout_hold"              // 창작자 지급 요청 (사용 가능 → 잠금)
+	LedgerPayoutRelease          LedgerEntryType = "payout_release"           // 지급 반려/실패 (잠금 → 사용 가능)
+	LedgerPayoutDisbursed        LedgerEntryType = "payout_disbursed"         // 지급 계좌로 송금 완료 (잠금 차감)
+	LedgerTransferFeeHold        LedgerEntryType = "transfer_fee_hold"        // 포지션 이전 요청 수수료 (사용 가능 → 잠금)
+	LedgerTransferFeeRelease     LedgerEntryType = "transfer_fee_release"     // 이전 거절/취소/만료로 수수료 반환 (잠금 → 사용 가능)
+	LedgerTransferFee            LedgerEntryType = "transfer_fee"             // 이전 수락으로 수수료 차감 (잠금 차감)
+	LedgerMediationStakeReturn   LedgerEntryType = "mediation_stake_return"   // 조정 합의로 분쟁 제기 스테이킹 반환 (잠금 → 사용 가능)
+	LedgerMediationSettlement    LedgerEntryType = "mediation_settlement"     // 조정 합의에 따른 당사자 간 지급
+	LedgerInsurancePremium       LedgerEntryType = "insurance_premium"        // 마일스톤 보험료 납부 (보험 풀로 이동)
+	LedgerInsuranceClaim         LedgerEntryType = "insurance_claim"          // 사기 판결 확정으로 보험 풀에서 보험금 수령
+	LedgerCurrencyConvertOut     LedgerEntryType = "currency_convert_out"     // 통화 환전으로 보낸 통화 차감
+	LedgerCurrencyConvertIn      LedgerEntryType = "currency_convert_in"      // 통화 환전으로 받은 통화 입금
+	LedgerParlayStake            LedgerEntryType = "parlay_stake"             // 조합 베팅 원금 (사용 가능 → 잠금)
+	LedgerParlaySettlement       LedgerEntryType = "parlay_settlement"        // 조합 정산 (원금 잠금 차감 + 지급액 입금, 하우스 계정은 원금 - 지급액)
+)
+
+// WalletLedgerEntry 지갑 잔액 변동 원장 (잔액을 바꿀 때마다 1행, 수정/삭제 없음)
+type WalletLedgerEntry struct {
+	ID            uint            `json:"id" gorm:"primaryKey"`
+	UserID        uint            `json:"user_id" gorm:"not null;index:idx_ledger_user_created"`
+	Currency      LedgerCurrency  `json:"currency" gorm:"type:varchar(16);not null"`
+	EntryType     LedgerEntryType `json:"entry_type" gorm:"type:varchar(40);not null"`
+	Amount        int64           `json:"amount"`        // 사용 가능 잔액 변동 (음수는 차감)
+	LockedAmount  int64           `json:"locked_amount"` // 잠긴 잔액 변동
+	ReferenceType string          `json:"reference_type" gorm:"type:varchar(40);index:idx_ledger_reference"`
+	ReferenceID   uint            `json:"reference_id" gorm:"index:idx_ledger_reference"`
+	Memo          string          `json:"memo"`
+	CreatedAt     time.Time       `json:"created_at" gorm:"index:idx_ledger_user_created"`
+}
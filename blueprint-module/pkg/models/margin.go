@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// MarginPosition 숏 포지션 증거금 현황 (현재가 기준 환매 비용)
+type MarginPosition struct {
+	PositionID  uint    `json:"position_id"`
+	MilestoneID uint    `json:"milestone_id"`
+	OptionID    string  `json:"option_id"`
+	Quantity    int64   `json:"quantity"`     // 숏 수량 (양수)
+	Collateral  int64   `json:"collateral"`   // 잠긴 담보 (센트)
+	MarkPrice   float64 `json:"mark_price"`   // 평가 가격 (최근 시장가)
+	BuybackCost int64   `json:"buyback_cost"` // 현재가로 환매할 때 드는 금액 (센트)
+	Liquidating bool    `json:"liquidating"`  // 강제 청산 주문이 열려 있음
+}
+
+// MarginSummary 사용자 포트폴리오 증거금 (숏 환매 비용 대비 평가 자산)
+//
+// 평가 자산 = 사용 가능 USDC + 숏 담보 + 매수 포지션 평가액(현재가).
+// 평가 자산이 유지 증거금(숏 환매 비용 합계 + 여유분)보다 적으면 강제 청산 대상이다.
+type MarginSummary struct {
+	UserID         uint             `json:"user_id"`
+	Available      int64            `json:"available"`    // 사용 가능 USDC (센트)
+	Collateral     int64            `json:"collateral"`   // 숏 담보 합계
+	LongValue      int64            `json:"long_value"`   // 매수 포지션 평가액
+	Equity         int64            `json:"equity"`       // 평가 자산 합계
+	Liability      int64            `json:"liability"`    // 숏 환매 비용 합계
+	Maintenance    int64            `json:"maintenance"`  // 유지 증거금 (환매 비용 × (1 + 유지율))
+	MarginRatio    float64          `json:"margin_ratio"` // Equity / Liability (숏이 없으면 0)
+	Insufficient   bool             `json:"insufficient"` // 강제 청산 대상
+	ShortPositions []MarginPosition `json:"short_positions"`
+}
+
+// MarginLiquidationTrigger 강제 청산을 시작한 검사
+type MarginLiquidationTrigger string
+
+const (
+	MarginLiquidationTriggerPriceMove MarginLiquidationTrigger = "price_move" // 체결로 가격이 움직인 마켓 검사
+	MarginLiquidationTriggerSweep     MarginLiquidationTrigger = "sweep"      // 주기 전체 검사
+)
+
+// MarginLiquidationStatus 강제 환매 주문 진행 상태
+type MarginLiquidationStatus string
+
+const (
+	MarginLiquidationOpen      MarginLiquidationStatus = "open"      // 환매 주문 미체결
+	MarginLiquidationFilled    MarginLiquidationStatus = "filled"    // 환매 주문 전량 체결
+	MarginLiquidationCancelled MarginLiquidationStatus = "cancelled" // 일부만 체결되고 주문이 취소/만료됨
+)
+
+// MarginLiquidation 증거금 부족으로 낸 숏 포지션 강제 환매 주문 기록 (체결되면 상태/체결 수량 갱신)
+type MarginLiquidation struct {
+	ID          uint                     `json:"id" gorm:"primaryKey"`
+	UserID      uint                     `json:"user_id" gorm:"not null;index"`
+	PositionID  uint                     `json:"position_id" gorm:"not null;index"`
+	MilestoneID uint                     `json:"milestone_id" gorm:"not null"`
+	OptionID    string                   `json:"option_id" gorm:"not null"`
+	OrderID     uint                     `json:"order_id" gorm:"index"` // 환매 매수 주문
+	Trigger     MarginLiquidationTrigger `json:"trigger" gorm:"not null;default:'sweep'"`
+	Status      MarginLiquidationStatus  `json:"status" gorm:"not null;default:'open';index"`
+	Quantity    int64                    `json:"quantity"`    // 환매 주문 수량
+	Filled      int64                    `json:"filled"`      // 체결된 환매 수량
+	PriceTicks  int64                    `json:"price_ticks"` // 환매 주문 지정가
+	Released    int64                    `json:"released"`    // 환매 자금으로 풀어 준 포지션 담보 (센트)
+	Equity      int64                    `json:"equity"`      // 청산 판단 시점 평가 자산
+	Liability   int64                    `json:"liability"`   // 청산 판단 시점 숏 환매 비용
+	Maintenance int64                    `json:"maintenance"` // 청산 판단 시점 유지 증거금
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+}
@@ -0,0 +1,121 @@
+package models
+
+import "time"
+
+// MediationSource 조정이 시작된 분쟁 종류
+type MediationSource string
+
+const (
+	MediationSourceProofDispute MediationSource = "proof_dispute" // 증거 분쟁 (분쟁 제기자 ↔ 증거 제출자)
+	MediationSourceMentorReport MediationSource = "mentor_report" // 멘토 신고 (신고자 ↔ 멘토)
+)
+
+// MediationStatus 조정 진행 상태
+type MediationStatus string
+
+const (
+	MediationStatusOpen      MediationStatus = "open"      // 협상 중
+	MediationStatusSettled   MediationStatus = "settled"   // 합의 성립 (원 분쟁 종결)
+	MediationStatusEscalated MediationStatus = "escalated" // 결렬 → 배심원 중재 이관
+)
+
+// MediationOutcome 합의안이 원 분쟁에 적용할 결론
+type MediationOutcome string
+
+const (
+	MediationOutcomeApproveProof  MediationOutcome = "approve_proof"  // 증거 승인으로 분쟁 종결
+	MediationOutcomeRejectProof   MediationOutcome = "reject_proof"   // 증거 거절로 분쟁 종결
+	MediationOutcomeDismissReport MediationOutcome = "dismiss_report" // 신고 철회 (슬래싱 없음)
+	MediationOutcomeAcceptSlash   MediationOutcome = "accept_slash"   // 멘토가 신고 내용대로 슬래싱 수용
+)
+
+// Allows 조정 종류별로 선택할 수 있는 결론인지
+func (s MediationSource) Allows(outcome MediationOutcome) bool {
+	switch s {
+	case MediationSourceProofDispute:
+		return outcome == MediationOutcomeApproveProof || outcome == MediationOutcomeRejectProof
+	case MediationSourceMentorReport:
+		return outcome == MediationOutcomeDismissReport || outcome == MediationOutcomeAcceptSlash
+	}
+	return false
+}
+
+// Mediation 배심원 중재 전 당사자 간 조정
+//
+// 증거 분쟁이나 멘토 신고가 접수되면 열리고, 기한 안에 한쪽의 제안을 상대가 수락하면 합의안대로 원 분쟁을 종결한다.
+// 결렬(당사자 선언 또는 기한 경과)되면 배심원 중재로 이관하며, 분쟁 제기 스테이킹은 사건 스테이킹으로 그대로 넘어간다.
+type Mediation struct {
+	ID            uint            `json:"id" gorm:"primaryKey"`
+	SourceType    MediationSource `json:"source_type" gorm:"size:20;not null;uniqueIndex:idx_mediation_source,priority:1"`
+	SourceID      uint            `json:"source_id" gorm:"not null;uniqueIndex:idx_mediation_source,priority:2"` // ProofDispute.ID 또는 MentorSlashEvent.ID
+	ClaimantID    uint            `json:"claimant_id" gorm:"not null;index"`                                     // 분쟁 제기자/신고자
+	RespondentID  uint            `json:"respondent_id" gorm:"not null;index"`                                   // 증거 제출자/멘토 본인
+	MilestoneID   *uint           `json:"milestone_id,omitempty" gorm:"index"`
+	MentorshipID  *uint           `json:"mentorship_id,omitempty"`
+	Title         string          `json:"title" gorm:"not null"`
+	ClaimantStake int64           `json:"claimant_stake"` // 분쟁 제기 시 잠근 BLUEPRINT (합의 시 반환, 이관 시 사건 스테이킹으로 승계)
+
+	Status            MediationStatus `json:"status" gorm:"size:16;index;default:'open'"`
+	Deadline          time.Time       `json:"deadline" gorm:"index"`
+	SettledOfferID    *uint           `json:"settled_offer_id,omitempty"`
+	ArbitrationCaseID *uint           `json:"arbitration_case_id,omitempty" gorm:"index"`
+	EscalationReason  string          `json:"escalation_reason,omitempty" gorm:"type:text"`
+	ResolvedAt        *time.Time      `json:"resolved_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Offers []MediationOffer `json:"offers,omitempty" gorm:"foreignKey:MediationID"`
+}
+
+// IsParty 조정 당사자 여부
+func (m *Mediation) IsParty(userID uint) bool {
+	return userID == m.ClaimantID || userID == m.RespondentID
+}
+
+// Counterparty 상대 당사자
+func (m *Mediation) Counterparty(userID uint) uint {
+	if userID == m.ClaimantID {
+		return m.RespondentID
+	}
+	return m.ClaimantID
+}
+
+// MediationOfferStatus 제안 상태
+type MediationOfferStatus string
+
+const (
+	MediationOfferPending   MediationOfferStatus = "pending"   // 상대 응답 대기
+	MediationOfferAccepted  MediationOfferStatus = "accepted"  // 수락 (합의 성립)
+	MediationOfferRejected  MediationOfferStatus = "rejected"  // 거절
+	MediationOfferCountered MediationOfferStatus = "countered" // 상대가 역제안
+	MediationOfferWithdrawn MediationOfferStatus = "withdrawn" // 제안자가 새 제안으로 대체
+	MediationOfferExpired   MediationOfferStatus = "expired"   // 응답 기한 경과
+)
+
+// MediationOffer 조정 제안/역제안 (대기 중인 제안은 조정당 하나)
+type MediationOffer struct {
+	ID           uint                 `json:"id" gorm:"primaryKey"`
+	MediationID  uint                 `json:"mediation_id" gorm:"not null;index"`
+	ProposerID   uint                 `json:"proposer_id" gorm:"not null"`
+	CounterToID  *uint                `json:"counter_to_id,omitempty"` // 역제안 대상 제안
+	Outcome      MediationOutcome     `json:"outcome" gorm:"size:20;not null"`
+	Compensation int64                `json:"compensation"` // 피신청인 → 신청인 지급 BLUEPRINT (합의 시 이체)
+	Terms        string               `json:"terms" gorm:"type:text"`
+	Status       MediationOfferStatus `json:"status" gorm:"size:16;index;default:'pending'"`
+	ExpiresAt    time.Time            `json:"expires_at"`
+	RespondedAt  *time.Time           `json:"responded_at,omitempty"`
+	CreatedAt    time.Time            `json:"created_at"`
+}
+
+// MediationOfferRequest 제안/역제안
+type MediationOfferRequest struct {
+	Outcome      MediationOutcome `json:"outcome" binding:"required,oneof=approve_proof reject_proof dismiss_report accept_slash"`
+	Compensation int64            `json:"compensation" binding:"min=0"`
+	Terms        string           `json:"terms" binding:"max=2000"`
+}
+
+// EscalateMediationRequest 조정 결렬 선언
+type EscalateMediationRequest struct {
+	Reason string `json:"reason" binding:"max=1000"`
+}
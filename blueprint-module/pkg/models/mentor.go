@@ -51,6 +51,7 @@ type Mentor struct {
 	TotalBettingAmount  int64   `json:"total_betting_amount" gorm:"default:0"`     // 총 베팅 금액 (센트)
 	TotalEarnedAmount   int64   `json:"total_earned_amount" gorm:"default:0"`      // 총 획득 금액 (센트)
 	AverageRating       float64 `json:"average_rating" gorm:"default:0"`           // 평균 평점
+	TotalStaked         int64   `json:"total_staked" gorm:"default:0;index"`       // 활성 스테이킹 합계 (BLUEPRINT)
 
 	// 평판 점수 (온체인 기록용)
 	ReputationScore     int     `json:"reputation_score" gorm:"default:0"`         // 평판 점수
@@ -229,6 +230,7 @@ type MentorPool struct {
 	DistributedAmount     int64     `json:"distributed_amount" gorm:"default:0"`     // 분배된 금액
 	DistributedAt         *time.Time `json:"distributed_at,omitempty"`               // 분배 완료일
 	EligibleMentorsCount  int       `json:"eligible_mentors_count" gorm:"default:0"` // 자격있는 멘토 수
+	CreatorShareAmount    int64     `json:"creator_share_amount" gorm:"default:0"`   // 분배되지 않아 프로젝트 소유자에게 지급된 금액
 
 	// 분배 방식 설정
 	SimpleDistribution    bool    `json:"simple_distribution" gorm:"default:false"`  // 단순 분배 (베팅액 비례)
@@ -245,6 +247,42 @@ type MentorPool struct {
 	Project   Project   `json:"project,omitempty" gorm:"foreignKey:ProjectID"`
 }
 
+// MentorSessionFeedback 멘티의 세션 평가 (세션당 1건, 멘토 성과 지표와 자동 슬래싱 근거)
+type MentorSessionFeedback struct {
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	SessionID   uint   `json:"session_id" gorm:"not null;uniqueIndex"`
+	MentorID    uint   `json:"mentor_id" gorm:"not null;index:idx_feedback_mentor_created"`
+	MenteeID    uint   `json:"mentee_id" gorm:"not null;index"`
+	MilestoneID uint   `json:"milestone_id" gorm:"not null;index"`
+	Rating      int    `json:"rating" gorm:"not null"`               // 1-5
+	Comment     string `json:"comment" gorm:"type:text"`             // 자유 서술 후기
+	NoShow      bool   `json:"no_show" gorm:"default:false;index"` // 멘토 무단 불참 신고
+
+	CreatedAt time.Time `json:"created_at" gorm:"index:idx_feedback_mentor_created"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// 관계
+	Session MentoringSession `json:"-" gorm:"foreignKey:SessionID"`
+	Mentee  User             `json:"mentee,omitempty" gorm:"foreignKey:MenteeID"`
+}
+
+// RateSessionRequest 세션 평가 요청
+type RateSessionRequest struct {
+	Rating  int    `json:"rating" binding:"required,min=1,max=5"`
+	Comment string `json:"comment" binding:"max=2000"`
+	NoShow  bool   `json:"no_show"` // 멘토가 약속된 세션에 나타나지 않음
+}
+
+// MentorFeedbackSummary 멘토 평가 요약
+type MentorFeedbackSummary struct {
+	MentorID      uint                    `json:"mentor_id"`
+	AverageRating float64                 `json:"average_rating"`
+	TotalCount    int64                   `json:"total_count"`
+	NoShowCount   int64                   `json:"no_show_count"`
+	Distribution  map[int]int64           `json:"distribution"` // 별점별 건수
+	Feedbacks     []MentorSessionFeedback `json:"feedbacks"`
+}
+
 // MentorReputation 온체인 평판 기록
 type MentorReputation struct {
 	ID         uint   `json:"id" gorm:"primaryKey"`
@@ -279,6 +317,7 @@ func (MentoringSession) TableName() string { return "mentoring_sessions" }
 func (MentorAction) TableName() string     { return "mentor_actions" }
 func (MentorPool) TableName() string       { return "mentor_pools" }
 func (MentorReputation) TableName() string { return "mentor_reputations" }
+func (MentorSessionFeedback) TableName() string { return "mentor_session_feedbacks" }
 
 // 🚀 Helper 메서드들
 
@@ -271,6 +271,13 @@ func (MentorStakeReward) TableName() string {
 	return "mentor_stake_rewards"
 }
 
+// MentorStakeReward 지급 상태
+const (
+	MentorStakeRewardPending     = "pending"     // 청구 대기
+	MentorStakeRewardDistributed = "distributed" // 지갑 입금 완료
+	MentorStakeRewardForfeited   = "forfeited"   // 몰수
+)
+
 // MentorRewardType 멘토 보상 유형
 type MentorRewardType string
 
@@ -326,6 +333,13 @@ type MentorStakeStatistics struct {
 	StakingRank        int     `json:"staking_rank"`         // 스테이킹 순위
 }
 
+// MentorRewardClaimResponse 멘토 보상 청구 결과
+type MentorRewardClaimResponse struct {
+	ClaimedCount int                 `json:"claimed_count"`
+	TotalAmount  int64               `json:"total_amount"` // 입금된 USDC (센트)
+	Rewards      []MentorStakeReward `json:"rewards"`
+}
+
 // MentorDashboardResponse 멘토 대시보드 응답  
 type MentorDashboardResponse struct {
 	Stakes         []MentorStake           `json:"stakes"`
@@ -0,0 +1,105 @@
+package models
+
+import "time"
+
+// ConversationScope 대화가 열리는 범위
+type ConversationScope string
+
+const (
+	ConversationMentorship  ConversationScope = "mentorship"  // 멘토링 세션 (멘토 ↔ 멘티)
+	ConversationArbitration ConversationScope = "arbitration" // 분쟁 사건 (신청인 ↔ 피신청인)
+)
+
+// Conversation 멘토링 세션 또는 분쟁 사건에 딸린 대화방 (범위당 하나)
+//
+// 참여자는 범위에서 정해진다 (세션의 멘토/멘티, 사건의 당사자). 범위가 끝나면(세션 완료/취소, 사건 종료/기각)
+// 기록은 읽을 수 있지만 새 메시지는 보낼 수 없다.
+type Conversation struct {
+	ID            uint              `json:"id" gorm:"primaryKey"`
+	Scope         ConversationScope `json:"scope" gorm:"size:20;not null;uniqueIndex:idx_conversation_scope,priority:1"`
+	ScopeID       uint              `json:"scope_id" gorm:"not null;uniqueIndex:idx_conversation_scope,priority:2"` // MentoringSession.ID 또는 ArbitrationCase.ID
+	LastMessageAt *time.Time        `json:"last_message_at,omitempty" gorm:"index"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+
+	Participants []ConversationParticipant `json:"participants,omitempty" gorm:"foreignKey:ConversationID"`
+}
+
+// ConversationParticipant 대화 참여자와 읽음 위치
+type ConversationParticipant struct {
+	ConversationID    uint       `json:"conversation_id" gorm:"primaryKey"`
+	UserID            uint       `json:"user_id" gorm:"primaryKey;index"`
+	LastReadMessageID uint       `json:"last_read_message_id"` // 이 메시지까지 읽음 (읽음 표시)
+	LastReadAt        *time.Time `json:"last_read_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// Message 대화 메시지
+//
+// 운영자가 신고를 받아 숨긴 메시지는 본문 대신 빈 문자열과 hidden=true로 내려간다 (원문은 감사용으로 보관).
+type Message struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	ConversationID uint       `json:"conversation_id" gorm:"not null;index:idx_messages_conversation_id,priority:1"`
+	SenderID       uint       `json:"sender_id" gorm:"not null;index"`
+	Body           string     `json:"body" gorm:"type:text;not null"`
+	Hidden         bool       `json:"hidden" gorm:"default:false"`
+	HiddenBy       *uint      `json:"-"`
+	HiddenAt       *time.Time `json:"-"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"index"`
+}
+
+// MessageReportStatus 메시지 신고 처리 상태
+type MessageReportStatus string
+
+const (
+	MessageReportOpen      MessageReportStatus = "open"      // 검토 대기
+	MessageReportDismissed MessageReportStatus = "dismissed" // 문제 없음
+	MessageReportRemoved   MessageReportStatus = "removed"   // 메시지 숨김 처리
+)
+
+// MessageReport 참여자의 메시지 신고 (운영자 검토 대기열)
+type MessageReport struct {
+	ID         uint                `json:"id" gorm:"primaryKey"`
+	MessageID  uint                `json:"message_id" gorm:"not null;uniqueIndex:idx_message_report_reporter,priority:1"`
+	ReporterID uint                `json:"reporter_id" gorm:"not null;uniqueIndex:idx_message_report_reporter,priority:2"`
+	Reason     string              `json:"reason" gorm:"type:text;not null"`
+	Status     MessageReportStatus `json:"status" gorm:"size:16;index;default:'open'"`
+
+	ReviewedBy *uint      `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote string     `json:"review_note,omitempty" gorm:"type:text"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Message *Message `json:"message,omitempty" gorm:"foreignKey:MessageID"`
+}
+
+// ConversationSummary 대화 목록 항목
+type ConversationSummary struct {
+	Conversation
+	ParticipantIDs []uint   `json:"participant_ids"`
+	LastMessage    *Message `json:"last_message,omitempty"`
+	UnreadCount    int64    `json:"unread_count"`
+	Writable       bool     `json:"writable"` // 범위(세션/사건)가 진행 중이라 메시지를 보낼 수 있는지
+}
+
+// SendMessageRequest 메시지 보내기
+type SendMessageRequest struct {
+	Body string `json:"body" binding:"required,max=4000"`
+}
+
+// MarkConversationReadRequest 읽음 표시 (비우면 마지막 메시지까지)
+type MarkConversationReadRequest struct {
+	MessageID uint `json:"message_id"`
+}
+
+// ReportMessageRequest 메시지 신고
+type ReportMessageRequest struct {
+	Reason string `json:"reason" binding:"required,max=1000"`
+}
+
+// ReviewMessageReportRequest 신고 종결/메시지 숨김
+type ReviewMessageReportRequest struct {
+	Note string `json:"note" binding:"max=1000"`
+}
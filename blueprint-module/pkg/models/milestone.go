@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
@@ -53,11 +54,30 @@ type Milestone struct {
 	CurrentTVL        int64      `json:"current_tvl" gorm:"default:0"`    // 현재 총 베팅액 (센트)
 	FundingProgress   float64    `json:"funding_progress" gorm:"default:0"` // 펀딩 진행률 (0-1)
 
+	// 거래 설정
+	PriceTickSize      int64             `json:"price_tick_size" gorm:"default:100"`            // 호가 단위 (PriceScale 틱, 기본 1¢)
+	TradingHaltedUntil *time.Time        `json:"trading_halted_until,omitempty"`                // 서킷브레이커 - 이 시각까지 신규 주문 거부
+	TradingHaltReason  TradingHaltReason `json:"trading_halt_reason,omitempty" gorm:"size:20"` // 서킷브레이커 발동 사유
+	TradingClosesAt    *time.Time        `json:"trading_closes_at,omitempty"`                  // 거래 마감 시각 (비어 있으면 목표일에 마감)
+	TradingClosedAt    *time.Time        `json:"trading_closed_at,omitempty"`                  // 마켓 캘린더가 마감 처리(미체결 주문 만료)한 시각
+	QuoteCurrency      LedgerCurrency    `json:"quote_currency" gorm:"type:varchar(16);default:'usdc'"` // 결제 통화 (주문 잠금/체결/정산 통화, 첫 주문 전까지 변경 가능)
+
 	// 상태 정보 (기본값을 proposal로 변경)
 	Status      MilestoneStatus `json:"status" gorm:"type:varchar(20);default:'proposal'"`
 	IsCompleted bool           `json:"is_completed" gorm:"default:false"`
 
-	// 베팅은 항상 성공/실패 두 옵션만 지원
+	// 결과 옵션 (binary는 success/fail 고정, categorical은 Outcomes에 정의, scalar는 long/short 고정)
+	MarketType      MarketType         `json:"market_type" gorm:"type:varchar(20);default:'binary'"`
+	Outcomes        string             `json:"-" gorm:"type:text"`                         // categorical 결과 옵션 (JSON 배열)
+	OutcomesArray   []MilestoneOutcome `json:"outcomes,omitempty" gorm:"-"`                // API 응답용 배열
+	WinningOptionID string             `json:"winning_option_id,omitempty" gorm:"size:50"` // 판정된 결과 옵션 (무효/미확정이면 빈 값)
+
+	// scalar 마켓 범위와 판정 값 (long은 하한 0 ~ 상한 1, short는 그 반대로 정산)
+	ScalarLower   *float64   `json:"scalar_lower,omitempty"`               // 범위 하한
+	ScalarUpper   *float64   `json:"scalar_upper,omitempty"`               // 범위 상한
+	ScalarUnit    string     `json:"scalar_unit,omitempty" gorm:"size:20"` // 표시 단위 (users, $ 등)
+	ResolvedValue *float64   `json:"resolved_value,omitempty"`             // 판정된 수치 (무효/미확정이면 nil)
+	SettledAt     *time.Time `json:"settled_at,omitempty"`                 // 포지션 정산 완료 시각
 
 	// 응원 (베팅) 관련
 	TotalSupport       int64   `json:"total_support" gorm:"default:0"`
@@ -75,6 +95,7 @@ type Milestone struct {
 	ProofDeadline            *time.Time `json:"proof_deadline,omitempty"`                     // 증거 제출 마감일
 	VerificationDeadline     *time.Time `json:"verification_deadline,omitempty"`              // 검증 완료 마감일
 	VerificationDeadlineDays int       `json:"verification_deadline_days" gorm:"default:3"`   // 검증 마감일 (일수)
+	ResolutionDueAt          *time.Time `json:"resolution_due_at,omitempty"`                  // 판정 확정 예정 시각 (분쟁 제기 기간 종료)
 	MinValidators            int       `json:"min_validators" gorm:"default:3"`               // 최소 검증인 수
 	MinApprovalRate          float64   `json:"min_approval_rate" gorm:"default:0.6"`          // 최소 승인률 (60%)
 
@@ -128,9 +149,8 @@ func (m *Milestone) CalculateFundingProgress() float64 {
 	return progress
 }
 
-// StartFundingPhase 펀딩 단계 시작
+// StartFundingPhase 펀딩 일정 설정 (상태 전환은 MilestoneStateMachine 담당)
 func (m *Milestone) StartFundingPhase() {
-	m.Status = MilestoneStatusFunding
 	now := time.Now()
 	m.FundingStartDate = &now
 	fundingEnd := now.AddDate(0, 0, m.FundingDuration)
@@ -185,32 +205,23 @@ func (m *Milestone) UpdateVerificationStats(approvalVotes, rejectionVotes int) {
 	}
 }
 
-// StartVerificationProcess 검증 프로세스 시작
+// StartVerificationProcess 검증 마감일 설정 (상태 전환은 MilestoneStateMachine 담당)
 func (m *Milestone) StartVerificationProcess() {
-	m.Status = MilestoneStatusUnderVerification
 	if m.VerificationDeadline == nil {
 		deadline := time.Now().Add(72 * time.Hour) // 72시간 후
 		m.VerificationDeadline = &deadline
 	}
 }
 
-// CompleteVerification 검증 완료 처리
+// CompleteVerification 검증 완료 정보 기록 (상태 전환은 MilestoneStateMachine 담당)
 func (m *Milestone) CompleteVerification(approved bool) {
 	if approved {
-		m.Status = MilestoneStatusProofApproved
 		now := time.Now()
 		m.CompletedAt = &now
 		m.IsCompleted = true
-	} else {
-		m.Status = MilestoneStatusProofRejected
 	}
 }
 
-// SetDisputed 분쟁 상태로 변경
-func (m *Milestone) SetDisputed() {
-	m.Status = MilestoneStatusDisputed
-}
-
 // SetProofDeadline 증거 제출 마감일 설정
 func (m *Milestone) SetProofDeadline(days int) {
 	if days > 0 {
@@ -219,8 +230,13 @@ func (m *Milestone) SetProofDeadline(days int) {
 	}
 }
 
-// AfterFind 데이터베이스에서 조회한 후 ProofTypes JSON을 파싱
+// AfterFind 데이터베이스에서 조회한 후 ProofTypes/Outcomes JSON을 파싱
 func (m *Milestone) AfterFind(tx *gorm.DB) error {
+	if m.Outcomes != "" {
+		if err := json.Unmarshal([]byte(m.Outcomes), &m.OutcomesArray); err != nil {
+			return fmt.Errorf("milestone %d outcomes: %w", m.ID, err)
+		}
+	}
 	if m.ProofTypes != "" {
 		if err := json.Unmarshal([]byte(m.ProofTypes), &m.ProofTypesArray); err != nil {
 			// JSON 파싱 실패 시 기본값으로 설정
@@ -232,8 +248,16 @@ func (m *Milestone) AfterFind(tx *gorm.DB) error {
 	return nil
 }
 
-// BeforeSave 저장하기 전에 ProofTypesArray를 JSON으로 변환
+// BeforeSave 저장하기 전에 ProofTypesArray/OutcomesArray를 JSON으로 변환
 func (m *Milestone) BeforeSave(tx *gorm.DB) error {
+	if len(m.OutcomesArray) > 0 {
+		outcomesBytes, err := json.Marshal(m.OutcomesArray)
+		if err != nil {
+			return err
+		}
+		m.Outcomes = string(outcomesBytes)
+	}
+
 	// ProofTypesArray가 설정되어 있고 ProofTypes가 비어있으면 변환
 	if len(m.ProofTypesArray) > 0 {
 		if proofTypesBytes, err := json.Marshal(m.ProofTypesArray); err == nil {
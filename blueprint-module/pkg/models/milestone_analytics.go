@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// OrderBookDepthSnapshot 옵션별 호가 잔량 스냅샷 (orderbook_depth 주기 작업이 열린 마켓마다 기록)
+type OrderBookDepthSnapshot struct {
+	ID          uint      `json:"-" gorm:"primaryKey"`
+	MilestoneID uint      `json:"-" gorm:"not null;index:idx_depth_snapshots_milestone_captured,priority:1"`
+	OptionID    string    `json:"option_id" gorm:"size:100;not null"`
+	BidQuantity int64     `json:"bid_quantity"` // 매수 호가 잔량 합계
+	AskQuantity int64     `json:"ask_quantity"` // 매도 호가 잔량 합계
+	BidLevels   int       `json:"bid_levels"`   // 매수 가격대 수
+	AskLevels   int       `json:"ask_levels"`   // 매도 가격대 수
+	BestBid     float64   `json:"best_bid"`     // 최우선 매수 호가 (없으면 0)
+	BestAsk     float64   `json:"best_ask"`     // 최우선 매도 호가 (없으면 0)
+	CapturedAt  time.Time `json:"captured_at" gorm:"not null;index:idx_depth_snapshots_milestone_captured,priority:2"`
+}
+
+// OptionHolderStats 옵션별 보유자 분포
+type OptionHolderStats struct {
+	OptionID    string  `json:"option_id"`
+	Holders     int     `json:"holders"`      // 보유 수량이 있는 고유 사용자 수
+	TotalShares int64   `json:"total_shares"` // 보유 수량 합계
+	Top10Share  float64 `json:"top10_share"`  // 상위 10명 보유 비중 (0~1)
+	Gini        float64 `json:"gini"`         // 보유 수량 지니 계수 (0 균등 ~ 1 집중)
+}
+
+// DailyVolume UTC 날짜별 체결량
+type DailyVolume struct {
+	Date   string `json:"date"`   // YYYY-MM-DD (UTC)
+	Volume int64  `json:"volume"` // 체결 금액 (센트)
+	Shares int64  `json:"shares"` // 체결 수량
+	Trades int    `json:"trades"` // 체결 건수
+}
+
+// MilestoneAnalytics 마일스톤 마켓 투명성 지표 (보유 집중도, 일별 거래량, 호가 잔량 추이)
+type MilestoneAnalytics struct {
+	MilestoneID  uint                     `json:"milestone_id"`
+	Days         int                      `json:"days"` // 거래량/호가 추이 조회 기간
+	Holders      []OptionHolderStats      `json:"holders"`
+	DailyVolume  []DailyVolume            `json:"daily_volume"`
+	DepthHistory []OrderBookDepthSnapshot `json:"depth_history"`
+	ComputedAt   time.Time                `json:"computed_at"`
+}
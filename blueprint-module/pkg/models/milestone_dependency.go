@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// MilestoneDependency 마일스톤 선후 관계 (MilestoneID는 DependsOnID가 완료된 뒤 시작)
+//
+// 같은 프로젝트 안에서만 연결할 수 있고 순환은 허용하지 않는다.
+type MilestoneDependency struct {
+	ID          uint `json:"id" gorm:"primaryKey"`
+	MilestoneID uint `json:"milestone_id" gorm:"not null;uniqueIndex:idx_milestone_dependency"`
+	DependsOnID uint `json:"depends_on_id" gorm:"not null;uniqueIndex:idx_milestone_dependency;index"`
+	// GapDays 연결 시점의 선행 목표일 → 후행 목표일 간격 (선행이 지연되면 이 간격을 유지하도록 후행 목표일을 미룸)
+	GapDays   int       `json:"gap_days" gorm:"not null;default:0"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (MilestoneDependency) TableName() string {
+	return "milestone_dependencies"
+}
+
+// SetMilestoneDependenciesRequest 선행 마일스톤 목록 교체 요청 (빈 배열이면 모두 해제)
+type SetMilestoneDependenciesRequest struct {
+	DependsOn []uint `json:"depends_on" binding:"max=10"`
+}
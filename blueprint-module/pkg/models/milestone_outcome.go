@@ -0,0 +1,97 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// MarketType 마일스톤 마켓 유형
+type MarketType string
+
+const (
+	MarketTypeBinary      MarketType = "binary"      // success/fail 두 옵션
+	MarketTypeCategorical MarketType = "categorical" // N개 결과 중 하나
+	MarketTypeScalar      MarketType = "scalar"      // 수치 결과를 범위 안에서 비례 정산 (long/short)
+)
+
+// 이진 마켓 옵션
+const (
+	OptionSuccess = "success"
+	OptionFail    = "fail"
+)
+
+// MaxMarketOutcomes categorical 마켓 최대 결과 옵션 수
+const MaxMarketOutcomes = 10
+
+// BinaryOptionIDs 이진 마켓 옵션 목록
+var BinaryOptionIDs = []string{OptionSuccess, OptionFail}
+
+// ErrInvalidOutcomes 잘못된 결과 옵션 정의
+var ErrInvalidOutcomes = errors.New("invalid market outcomes")
+
+var outcomeIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,49}$`)
+
+// MilestoneOutcome categorical 마켓 결과 옵션
+type MilestoneOutcome struct {
+	ID           string  `json:"id" binding:"required"`    // 주문/포지션의 option_id
+	Label        string  `json:"label" binding:"required"` // 표시 이름
+	InitialPrice float64 `json:"initial_price,omitempty"`  // 초기 확률 (생략 시 1/N)
+}
+
+// IsBinary success/fail 이진 마켓 여부 (유형이 비어 있는 기존 마일스톤 포함)
+func (m *Milestone) IsBinary() bool {
+	return !m.IsCategorical() && !m.IsScalar()
+}
+
+// IsCategorical 다중 결과 마켓 여부
+func (m *Milestone) IsCategorical() bool {
+	return m.MarketType == MarketTypeCategorical
+}
+
+// OptionIDs 거래 가능한 결과 옵션 (옵션마다 별도 주문장)
+func (m *Milestone) OptionIDs() []string {
+	if m.IsScalar() {
+		return ScalarOptionIDs
+	}
+	if !m.IsCategorical() {
+		return BinaryOptionIDs
+	}
+	ids := make([]string, len(m.OutcomesArray))
+	for i, outcome := range m.OutcomesArray {
+		ids[i] = outcome.ID
+	}
+	return ids
+}
+
+// HasOption 마켓의 결과 옵션인지 확인
+func (m *Milestone) HasOption(optionID string) bool {
+	for _, id := range m.OptionIDs() {
+		if id == optionID {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateOutcomes categorical 결과 옵션 정의 검증 (2-10개, 고유한 소문자 ID)
+func ValidateOutcomes(outcomes []MilestoneOutcome) error {
+	if len(outcomes) < 2 || len(outcomes) > MaxMarketOutcomes {
+		return fmt.Errorf("%w: need 2-%d outcomes, got %d", ErrInvalidOutcomes, MaxMarketOutcomes, len(outcomes))
+	}
+
+	seen := make(map[string]bool, len(outcomes))
+	for _, outcome := range outcomes {
+		if !outcomeIDPattern.MatchString(outcome.ID) {
+			return fmt.Errorf("%w: outcome id %q must be lowercase letters, digits, '-' or '_'", ErrInvalidOutcomes, outcome.ID)
+		}
+		if seen[outcome.ID] {
+			return fmt.Errorf("%w: duplicate outcome id %q", ErrInvalidOutcomes, outcome.ID)
+		}
+		if outcome.Label == "" {
+			return fmt.Errorf("%w: outcome %q needs a label", ErrInvalidOutcomes, outcome.ID)
+		}
+		seen[outcome.ID] = true
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// scalar 마켓 옵션
+const (
+	OptionLong  = "long"  // 판정 값이 상한에 가까울수록 가치 상승
+	OptionShort = "short" // 판정 값이 하한에 가까울수록 가치 상승
+)
+
+// ScalarOptionIDs scalar 마켓 옵션 목록
+var ScalarOptionIDs = []string{OptionLong, OptionShort}
+
+// ErrInvalidScalarRange 잘못된 scalar 범위 정의
+var ErrInvalidScalarRange = errors.New("invalid scalar range")
+
+// ScalarRange scalar 마켓 생성 요청 ("사용자 1만 명 달성" 같은 수치 마일스톤)
+type ScalarRange struct {
+	Lower        float64  `json:"lower"`                   // 범위 하한 (이하로 판정되면 long 0)
+	Upper        float64  `json:"upper"`                   // 범위 상한 (이상으로 판정되면 long 1)
+	Unit         string   `json:"unit,omitempty"`          // 표시 단위
+	InitialValue *float64 `json:"initial_value,omitempty"` // 초기 예상 값 (생략 시 범위 중간)
+}
+
+// Validate 범위와 초기 예상 값 검증
+func (r ScalarRange) Validate() error {
+	if math.IsNaN(r.Lower) || math.IsInf(r.Lower, 0) || math.IsNaN(r.Upper) || math.IsInf(r.Upper, 0) {
+		return fmt.Errorf("%w: bounds must be finite numbers", ErrInvalidScalarRange)
+	}
+	if r.Lower >= r.Upper {
+		return fmt.Errorf("%w: lower (%g) must be less than upper (%g)", ErrInvalidScalarRange, r.Lower, r.Upper)
+	}
+	if len(r.Unit) > 20 {
+		return fmt.Errorf("%w: unit must be at most 20 characters", ErrInvalidScalarRange)
+	}
+	if r.InitialValue != nil && (*r.InitialValue <= r.Lower || *r.InitialValue >= r.Upper) {
+		return fmt.Errorf("%w: initial_value must be strictly between lower and upper", ErrInvalidScalarRange)
+	}
+	return nil
+}
+
+// IsScalar 수치 범위 마켓 여부
+func (m *Milestone) IsScalar() bool {
+	return m.MarketType == MarketTypeScalar
+}
+
+// ScalarLongFraction 판정 값의 범위 내 위치 (0-1, 범위 밖은 양 끝으로 고정)
+func (m *Milestone) ScalarLongFraction(value float64) float64 {
+	if m.ScalarLower == nil || m.ScalarUpper == nil || *m.ScalarUpper <= *m.ScalarLower {
+		return 0
+	}
+	fraction := (value - *m.ScalarLower) / (*m.ScalarUpper - *m.ScalarLower)
+	return math.Max(0, math.Min(1, fraction))
+}
+
+// ImpliedScalarValue long 가격이 나타내는 시장 예상 값
+func (m *Milestone) ImpliedScalarValue(longPrice float64) (float64, bool) {
+	if !m.IsScalar() || m.ScalarLower == nil || m.ScalarUpper == nil || longPrice <= 0 {
+		return 0, false
+	}
+	return *m.ScalarLower + longPrice*(*m.ScalarUpper-*m.ScalarLower), true
+}
+
+// SettlementTicks 판정 결과에 따른 옵션별 1주당 지급액 (PriceScale 틱)
+//
+// binary/categorical은 승리 옵션 1.0, 나머지 0이다. scalar는 long이 판정 값의 범위 내 위치만큼,
+// short가 나머지를 받는다 (long + short = 1.0). 판정이 없거나 무효면 false.
+func (m *Milestone) SettlementTicks() (map[string]int64, bool) {
+	if m.IsScalar() {
+		if m.ResolvedValue == nil {
+			return nil, false
+		}
+		long := int64(math.Round(m.ScalarLongFraction(*m.ResolvedValue) * float64(PriceScale)))
+		return map[string]int64{OptionLong: long, OptionShort: PriceScale - long}, true
+	}
+
+	if m.WinningOptionID == "" || !m.HasOption(m.WinningOptionID) {
+		return nil, false
+	}
+	ticks := make(map[string]int64)
+	for _, option := range m.OptionIDs() {
+		ticks[option] = 0
+	}
+	ticks[m.WinningOptionID] = PriceScale
+	return ticks, true
+}
@@ -0,0 +1,119 @@
+package models
+
+import "time"
+
+// milestoneTransitions 허용된 마일스톤 상태 전환
+//
+//	proposal → funding → active → proof_submitted → under_verification
+//	  → proof_approved → completed
+//	  → proof_rejected → active(재제출) | failed
+//	  → disputed → proof_approved | proof_rejected | completed | failed
+var milestoneTransitions = map[MilestoneStatus][]MilestoneStatus{
+	MilestoneStatusProposal:          {MilestoneStatusFunding, MilestoneStatusCancelled},
+	MilestoneStatusPending:           {MilestoneStatusFunding, MilestoneStatusActive, MilestoneStatusFailed, MilestoneStatusCancelled},
+	MilestoneStatusFunding:           {MilestoneStatusActive, MilestoneStatusRejected, MilestoneStatusCancelled},
+	MilestoneStatusActive:            {MilestoneStatusProofSubmitted, MilestoneStatusFailed, MilestoneStatusCancelled},
+	MilestoneStatusProofSubmitted:    {MilestoneStatusUnderVerification, MilestoneStatusCancelled},
+	MilestoneStatusUnderVerification: {MilestoneStatusProofApproved, MilestoneStatusProofRejected, MilestoneStatusDisputed},
+	MilestoneStatusProofApproved:     {MilestoneStatusCompleted, MilestoneStatusDisputed},
+	MilestoneStatusProofRejected:     {MilestoneStatusActive, MilestoneStatusFailed, MilestoneStatusDisputed},
+	MilestoneStatusDisputed:          {MilestoneStatusProofApproved, MilestoneStatusProofRejected, MilestoneStatusCompleted, MilestoneStatusFailed},
+}
+
+// TradableMilestoneStatuses 신규 주문을 받을 수 있는 상태 (그 외 상태에서는 마켓 동결)
+var TradableMilestoneStatuses = []MilestoneStatus{
+	MilestoneStatusPending, // 구버전 호환
+	MilestoneStatusFunding,
+	MilestoneStatusActive,
+}
+
+// TradingHaltReason 서킷브레이커(거래 일시 중단) 발동 사유
+type TradingHaltReason string
+
+const (
+	TradingHaltPriceMove    TradingHaltReason = "price_move"    // 짧은 시간 내 급격한 가격 변동
+	TradingHaltStatusChange TradingHaltReason = "status_change" // 펀딩/검증 결과로 상태가 바뀐 직후
+)
+
+// MarketTradingState 마켓 캘린더 기준 거래 상태 (마켓 정보 응답용)
+type MarketTradingState string
+
+const (
+	MarketStateUpcoming MarketTradingState = "upcoming"                   // 제안 단계, 아직 거래 전
+	MarketStateOpen     MarketTradingState = "open"                       // 주문 접수 중
+	MarketStateClosed   MarketTradingState = "closed_awaiting_resolution" // 마감 시각 경과 또는 검증 중, 결과 판정 대기
+	MarketStateResolved MarketTradingState = "resolved"                   // 결과 확정/종료
+)
+
+// CanTransitionTo 다음 상태로 전환 가능 여부
+func (s MilestoneStatus) CanTransitionTo(next MilestoneStatus) bool {
+	for _, allowed := range milestoneTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTerminal 더 이상 전환되지 않는 최종 상태 여부
+func (s MilestoneStatus) IsTerminal() bool {
+	return len(milestoneTransitions[s]) == 0
+}
+
+// IsTradable 마켓 거래 가능 상태 여부
+func (s MilestoneStatus) IsTradable() bool {
+	for _, status := range TradableMilestoneStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// MilestoneStatusHistory 마일스톤 상태 전환 이력
+type MilestoneStatusHistory struct {
+	ID          uint            `json:"id" gorm:"primaryKey"`
+	MilestoneID uint            `json:"milestone_id" gorm:"not null;index"`
+	FromStatus  MilestoneStatus `json:"from_status" gorm:"type:varchar(20);not null"`
+	ToStatus    MilestoneStatus `json:"to_status" gorm:"type:varchar(20);not null"`
+	Reason      string          `json:"reason"`
+	ActorID     *uint           `json:"actor_id,omitempty"` // nil이면 시스템 전환
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+func (MilestoneStatusHistory) TableName() string {
+	return "milestone_status_histories"
+}
+
+// TradingCloseTime 거래 마감 시각 (설정한 마감 시각, 없으면 목표일, 둘 다 없으면 nil)
+func (m *Milestone) TradingCloseTime() *time.Time {
+	if m.TradingClosesAt != nil {
+		return m.TradingClosesAt
+	}
+	return m.TargetDate
+}
+
+// IsTradingClosed 마감 시각이 지나 신규 주문을 받지 않는지 여부
+func (m *Milestone) IsTradingClosed(now time.Time) bool {
+	closesAt := m.TradingCloseTime()
+	return closesAt != nil && !now.Before(*closesAt)
+}
+
+// TradingState 상태와 마감 시각으로 본 거래 상태
+func (m *Milestone) TradingState(now time.Time) MarketTradingState {
+	switch {
+	case m.Status.IsTerminal():
+		return MarketStateResolved
+	case m.Status == MilestoneStatusProposal:
+		return MarketStateUpcoming
+	case m.Status.IsTradable() && !m.IsTradingClosed(now):
+		return MarketStateOpen
+	default:
+		return MarketStateClosed
+	}
+}
+
+// SetMarketCloseTimeRequest 마켓 거래 마감 시각 지정 요청 (closes_at 없으면 목표일에 마감)
+type SetMarketCloseTimeRequest struct {
+	ClosesAt *time.Time `json:"closes_at"`
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"time"
+)
+
+// NotificationType 알림 종류
+type NotificationType string
+
+const (
+	NotificationTypeSystem           NotificationType = "system"            // 시스템 공지
+	NotificationTypeValidatorAlert   NotificationType = "validator_alert"   // 검증 요청 알림
+	NotificationTypeJurorSelected    NotificationType = "juror_selected"    // 배심원 선정 알림
+	NotificationTypeMentorSlashed    NotificationType = "mentor_slashed"    // 멘토 슬래싱 알림
+	NotificationTypeTrade            NotificationType = "trade"             // 거래 체결 알림
+	NotificationTypeProofSubmitted   NotificationType = "proof_submitted"   // 마일스톤 증거 제출 알림
+	NotificationTypeArbitration      NotificationType = "arbitration"       // 분쟁 마감 임박 등
+	NotificationTypeKYC              NotificationType = "kyc"               // 본인 인증(KYC) 결과
+	NotificationTypeVerification     NotificationType = "verification"      // 전문 자격/학력 서류 심사 결과
+	NotificationTypeProjectUpdate    NotificationType = "project_update"    // 팔로우한 프로젝트 소식
+	NotificationTypeMilestone        NotificationType = "milestone"         // 포지션 보유 마일스톤 상태 변경
+	NotificationTypeExport           NotificationType = "export"            // 내보내기 파일 준비 완료
+	NotificationTypeProjectInvite    NotificationType = "project_invite"    // 프로젝트 팀 초대
+	NotificationTypeMarketing        NotificationType = "marketing"         // 마케팅/프로모션
+	NotificationTypePositionTransfer NotificationType = "position_transfer" // 포지션 이전 요청/응답
+	NotificationTypeMediation        NotificationType = "mediation"         // 분쟁 조정 제안/합의/이관
+	NotificationTypeInsurance        NotificationType = "insurance"         // 마일스톤 보험 가입/보험금 지급/만료
+	NotificationTypeCopyTrading      NotificationType = "copy_trading"      // 복사 거래 팔로우/자동 중지/리더 종료
+	NotificationTypeMarginCall       NotificationType = "margin_call"       // 숏 증거금 부족 강제 청산
+)
+
+// NotificationPriority 알림 중요도
+type NotificationPriority string
+
+const (
+	NotificationPriorityLow    NotificationPriority = "low"
+	NotificationPriorityNormal NotificationPriority = "normal"
+	NotificationPriorityHigh   NotificationPriority = "high" // SMS 발송 대상
+)
+
+// Notification 사용자 알림 (인앱 수신함)
+type Notification struct {
+	ID     uint `json:"id" gorm:"primaryKey"`
+	UserID uint `json:"user_id" gorm:"not null;index"`
+
+	// 알림 내용
+	Type     NotificationType     `json:"type" gorm:"not null;index"`
+	Priority NotificationPriority `json:"priority" gorm:"default:'normal'"`
+	Title    string               `json:"title" gorm:"not null"`
+	Message  string               `json:"message" gorm:"type:text"`
+	Link     string               `json:"link,omitempty"`                  // 프론트엔드 이동 경로
+	Data     string               `json:"data,omitempty" gorm:"type:text"` // JSON 형태 추가 데이터
+
+	// 읽음 상태
+	IsRead bool       `json:"is_read" gorm:"default:false;index"`
+	ReadAt *time.Time `json:"read_at,omitempty"`
+
+	// 외부 채널 발송 상태
+	EmailQueued bool `json:"email_queued" gorm:"default:false"`
+	SMSQueued   bool `json:"sms_queued" gorm:"default:false"`
+	PushQueued  bool `json:"push_queued" gorm:"default:false"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// 관계
+	User User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// CreateNotificationRequest 알림 생성 요청 (서비스 내부용)
+type CreateNotificationRequest struct {
+	UserID   uint                   `json:"user_id"`
+	Type     NotificationType       `json:"type"`
+	Priority NotificationPriority   `json:"priority"`
+	Title    string                 `json:"title"`
+	Message  string                 `json:"message"`
+	Link     string                 `json:"link"`
+	Data     map[string]interface{} `json:"data"`
+}
+
+// NotificationListResponse 알림 목록 응답
+type NotificationListResponse struct {
+	Notifications []Notification `json:"notifications"`
+	UnreadCount   int64          `json:"unread_count"`
+	Total         int64          `json:"total"`
+	Limit         int            `json:"limit"`
+	Offset        int            `json:"offset"`
+}
+
+// PushSubscription 브라우저 Web Push 구독 정보 (VAPID)
+type PushSubscription struct {
+	ID     uint `json:"id" gorm:"primaryKey"`
+	UserID uint `json:"user_id" gorm:"not null;index"`
+
+	// PushSubscription.toJSON() 값
+	Endpoint string `json:"endpoint" gorm:"uniqueIndex;not null;size:1024"`
+	P256dh   string `json:"-" gorm:"not null"` // 클라이언트 공개키 (base64url)
+	Auth     string `json:"-" gorm:"not null"` // 인증 시크릿 (base64url)
+
+	UserAgent  string     `json:"user_agent"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// SubscribePushRequest 푸시 구독 등록 요청
+type SubscribePushRequest struct {
+	Endpoint string `json:"endpoint" binding:"required,url"`
+	Keys     struct {
+		P256dh string `json:"p256dh" binding:"required"`
+		Auth   string `json:"auth" binding:"required"`
+	} `json:"keys" binding:"required"`
+}
+
+// UnsubscribePushRequest 푸시 구독 해제 요청
+type UnsubscribePushRequest struct {
+	Endpoint string `json:"endpoint" binding:"required"`
+}
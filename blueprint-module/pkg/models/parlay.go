@@ -0,0 +1,119 @@
+package models
+
+import (
+	"time"
+)
+
+// ParlayStatus 조합 포지션 상태
+type ParlayStatus string
+
+const (
+	ParlayStatusOpen ParlayStatus = "open" // 미정산 레그 존재
+	ParlayStatusWon  ParlayStatus = "won"  // 모든 레그 적중
+	ParlayStatusLost ParlayStatus = "lost" // 하나 이상 미적중
+	ParlayStatusVoid ParlayStatus = "void" // 모든 레그 무효 (원금 환불)
+)
+
+// ParlayKind 조합 포지션 유형
+type ParlayKind string
+
+const (
+	ParlayKindCustom  ParlayKind = "custom"  // 사용자가 레그를 직접 고른 조합
+	ParlayKindRoadmap ParlayKind = "roadmap" // 한 프로젝트의 남은 마일스톤 전체 성공 묶음
+)
+
+// ParlayLegResult 레그 결과
+type ParlayLegResult string
+
+const (
+	ParlayLegPending ParlayLegResult = "pending"
+	ParlayLegWon     ParlayLegResult = "won"
+	ParlayLegLost    ParlayLegResult = "lost"
+	ParlayLegVoid    ParlayLegResult = "void" // 마일스톤 취소/폐기 → 해당 레그 제외 후 재산정
+)
+
+// Parlay 여러 마일스톤 결과를 묶은 조합 포지션 ("A 성공 AND B 성공")
+type Parlay struct {
+	ID     uint `json:"id" gorm:"primaryKey"`
+	UserID uint `json:"user_id" gorm:"not null;index"`
+
+	Kind      ParlayKind `json:"kind" gorm:"type:varchar(10);default:'custom'"`
+	ProjectID *uint      `json:"project_id,omitempty" gorm:"index"` // 로드맵 묶음 대상 프로젝트
+
+	Stake         int64        `json:"stake"`                   // 베팅 원금 (센트)
+	CombinedPrice float64      `json:"combined_price"`          // 조합 확률 가격 (마진 포함)
+	PotentialWin  int64        `json:"potential_win"`           // 적중 시 지급액 (센트)
+	Payout        int64        `json:"payout" gorm:"default:0"` // 실제 지급액 (센트)
+	Status        ParlayStatus `json:"status" gorm:"type:varchar(10);default:'open';index"`
+	SettledAt     *time.Time   `json:"settled_at,omitempty"`
+
+	Legs []ParlayLeg `json:"legs" gorm:"foreignKey:ParlayID"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// 외래키 참조
+	User User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// ParlayLeg 조합 포지션의 개별 마일스톤 레그
+type ParlayLeg struct {
+	ID          uint            `json:"id" gorm:"primaryKey"`
+	ParlayID    uint            `json:"parlay_id" gorm:"not null;index"`
+	ProjectID   uint            `json:"project_id" gorm:"index"`
+	MilestoneID uint            `json:"milestone_id" gorm:"not null;index"`
+	OptionID    string          `json:"option_id" gorm:"size:50;not null"` // 결과 옵션 (이진 마켓은 success | fail)
+	Price       float64         `json:"price"`                             // 접수 시점 시장 가격
+	Result      ParlayLegResult `json:"result" gorm:"type:varchar(10);default:'pending';index"`
+	SettledAt   *time.Time      `json:"settled_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// 외래키 참조
+	Milestone Milestone `json:"milestone,omitempty" gorm:"foreignKey:MilestoneID"`
+}
+
+// ParlayLegRequest 레그 요청
+type ParlayLegRequest struct {
+	MilestoneID uint   `json:"milestone_id" binding:"required"`
+	OptionID    string `json:"option_id" binding:"required,max=50"`
+}
+
+// ParlayQuoteRequest 조합 가격 조회 요청
+type ParlayQuoteRequest struct {
+	Legs  []ParlayLegRequest `json:"legs" binding:"required,min=2,max=5,dive"`
+	Stake int64              `json:"stake" binding:"omitempty,min=0"`
+}
+
+// CreateParlayRequest 조합 포지션 생성 요청
+type CreateParlayRequest struct {
+	Legs     []ParlayLegRequest `json:"legs" binding:"required,min=2,max=5,dive"`
+	Stake    int64              `json:"stake" binding:"required,min=100"`        // 최소 $1
+	MaxPrice float64            `json:"max_price" binding:"omitempty,gt=0,lt=1"` // 슬리피지 보호 (견적 이후 가격 상승 시 거부)
+}
+
+// CreateRoadmapBundleRequest 프로젝트 로드맵 묶음 포지션 생성 요청 (레그는 서버가 구성)
+type CreateRoadmapBundleRequest struct {
+	Stake    int64   `json:"stake" binding:"required,min=100"`
+	MaxPrice float64 `json:"max_price" binding:"omitempty,gt=0,lt=1"`
+}
+
+// ParlayLegQuote 레그별 가격
+type ParlayLegQuote struct {
+	MilestoneID uint    `json:"milestone_id"`
+	ProjectID   uint    `json:"project_id"`
+	OptionID    string  `json:"option_id"`
+	Price       float64 `json:"price"`
+}
+
+// ParlayQuote 조합 가격 견적
+type ParlayQuote struct {
+	Legs             []ParlayLegQuote `json:"legs"`
+	FairProbability  float64          `json:"fair_probability"` // 상관관계 반영 조합 확률
+	CombinedPrice    float64          `json:"combined_price"`   // 마진 포함 가격
+	Odds             float64          `json:"odds"`             // 1 / CombinedPrice
+	Stake            int64            `json:"stake,omitempty"`
+	PotentialWin     int64            `json:"potential_win,omitempty"`
+	CorrelatedGroups int              `json:"correlated_groups"` // 같은 프로젝트로 묶인 레그 그룹 수
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PayoutAccountType 지급 계좌 종류
+type PayoutAccountType string
+
+const (
+	PayoutAccountBank   PayoutAccountType = "bank"   // 은행 계좌
+	PayoutAccountCrypto PayoutAccountType = "crypto" // 암호화폐 지갑 주소
+)
+
+// PayoutAccount 창작자 지급 계좌
+//
+// 계좌번호/지갑 주소 원문은 암호화해 DetailsEncrypted에만 보관하고, 응답에는 마스킹한 값만 내보낸다.
+// 워커가 송금할 때 API 서버와 같은 키(API_KEY_ENCRYPTION_SECRET)로 복호화한다.
+type PayoutAccount struct {
+	ID     uint              `json:"id" gorm:"primaryKey"`
+	UserID uint              `json:"user_id" gorm:"not null;index"`
+	Type   PayoutAccountType `json:"type" gorm:"size:20;not null"`
+	Label  string            `json:"label" gorm:"size:100"`
+
+	BankName      string `json:"bank_name,omitempty" gorm:"size:100"`
+	AccountHolder string `json:"account_holder,omitempty" gorm:"size:100"`
+	CryptoNetwork string `json:"crypto_network,omitempty" gorm:"size:30"`
+
+	MaskedDetails    string `json:"masked_details" gorm:"size:100"` // "****1234", "0x12ab…cd34"
+	DetailsEncrypted string `json:"-" gorm:"type:text;not null"`
+
+	IsDefault  bool       `json:"is_default" gorm:"default:false"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"` // 계좌 확인 (현재는 등록 시 형식 검증만 하는 stub)
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// CreatorPayoutStatus 창작자 지급 요청 상태
+type CreatorPayoutStatus string
+
+const (
+	CreatorPayoutRequested  CreatorPayoutStatus = "requested"  // 승인 대기 (지갑 잠금)
+	CreatorPayoutApproved   CreatorPayoutStatus = "approved"   // 승인됨, 예정 시각에 워커가 송금
+	CreatorPayoutRejected   CreatorPayoutStatus = "rejected"   // 반려 (잠금 해제)
+	CreatorPayoutProcessing CreatorPayoutStatus = "processing" // 워커 송금 중
+	CreatorPayoutPaid       CreatorPayoutStatus = "paid"       // 송금 완료 (잠금 차감)
+	CreatorPayoutFailed     CreatorPayoutStatus = "failed"     // 재시도 초과로 실패 (잠금 해제)
+)
+
+// IsOpen 지급 가능 금액에서 빼야 하는 진행 중 상태인지
+func (s CreatorPayoutStatus) IsOpen() bool {
+	return s == CreatorPayoutRequested || s == CreatorPayoutApproved || s == CreatorPayoutProcessing
+}
+
+// CreatorPayout 창작자 수익 지급 요청
+//
+// 지급 가능 금액은 프로젝트 소유자로 받은 후원금(escrow_payout)과 멘토 풀 소유자 몫(creator_pool_share)
+// 합계에서 이미 지급했거나 진행 중인 요청을 뺀 값이다. 요청 시 지갑에서 잠그고, 관리자가 승인하면
+// 워커가 ScheduledFor 이후 지급 계좌로 송금한다.
+type CreatorPayout struct {
+	ID              uint                `json:"id" gorm:"primaryKey"`
+	UserID          uint                `json:"user_id" gorm:"not null;index"`
+	PayoutAccountID uint                `json:"payout_account_id" gorm:"not null;index"`
+	Amount          int64               `json:"amount" gorm:"not null"` // 센트
+	Status          CreatorPayoutStatus `json:"status" gorm:"size:20;not null;default:'requested';index"`
+
+	ReviewedBy   *uint      `json:"reviewed_by,omitempty"`
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty"`
+	RejectReason string     `json:"reject_reason,omitempty" gorm:"size:500"`
+
+	ScheduledFor      *time.Time `json:"scheduled_for,omitempty" gorm:"index"` // 송금 예정 시각 (승인 시 지정)
+	Attempts          int        `json:"attempts" gorm:"default:0"`
+	ProviderReference string     `json:"provider_reference,omitempty" gorm:"size:100"`
+	FailureReason     string     `json:"failure_reason,omitempty" gorm:"size:500"`
+	PaidAt            *time.Time `json:"paid_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	PayoutAccount *PayoutAccount `json:"payout_account,omitempty" gorm:"foreignKey:PayoutAccountID"`
+}
+
+// RegisterPayoutAccountRequest 지급 계좌 등록 요청
+type RegisterPayoutAccountRequest struct {
+	Type          PayoutAccountType `json:"type" binding:"required,oneof=bank crypto"`
+	Label         string            `json:"label" binding:"max=100"`
+	BankName      string            `json:"bank_name" binding:"max=100"`
+	AccountHolder string            `json:"account_holder" binding:"max=100"`
+	AccountNumber string            `json:"account_number" binding:"max=40"`
+	CryptoNetwork string            `json:"crypto_network" binding:"max=30"`
+	CryptoAddress string            `json:"crypto_address" binding:"max=128"`
+	IsDefault     bool              `json:"is_default"`
+}
+
+// CreateCreatorPayoutRequest 지급 요청 (센트, 계좌 미지정 시 기본 계좌)
+type CreateCreatorPayoutRequest struct {
+	Amount          int64 `json:"amount" binding:"required,min=100"`
+	PayoutAccountID uint  `json:"payout_account_id"`
+}
+
+// ApproveCreatorPayoutRequest 지급 승인 (예정 시각 미지정 시 다음 워커 실행 때 송금)
+type ApproveCreatorPayoutRequest struct {
+	ScheduledFor *time.Time `json:"scheduled_for"`
+}
+
+// RejectCreatorPayoutRequest 지급 반려
+type RejectCreatorPayoutRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
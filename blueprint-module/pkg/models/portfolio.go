@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// PositionSnapshot 사용자 포트폴리오 일별 스냅샷 (자산 곡선용)
+//
+// 금액은 모두 USDC 센트 단위이며 포지션 평가액은 스냅샷 시점의 MarketData 현재가 기준이다.
+type PositionSnapshot struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	UserID       uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_position_snapshot_user_date"`
+	SnapshotDate time.Time `json:"snapshot_date" gorm:"not null;uniqueIndex:idx_position_snapshot_user_date"` // UTC 자정
+
+	CashBalance   int64 `json:"cash_balance"`                                // 사용 가능 + 잠긴 USDC
+	PositionValue int64 `json:"position_value"`                              // 보유 포지션 평가액
+	CostBasis     int64 `json:"cost_basis"`                                  // 보유 포지션 취득 원가
+	RealizedPnL   int64 `json:"realized_pnl" gorm:"column:realized_pnl"`     // 누적 실현 손익
+	UnrealizedPnL int64 `json:"unrealized_pnl" gorm:"column:unrealized_pnl"` // 평가 손익
+	TotalValue    int64 `json:"total_value"`                                 // 현금 + 포지션 평가액
+	OpenPositions int   `json:"open_positions"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PortfolioHistoryResponse 자산 곡선 응답 (일별 스냅샷 + 현재 시점 평가)
+type PortfolioHistoryResponse struct {
+	Snapshots []PositionSnapshot `json:"snapshots"`
+	Current   *PositionSnapshot  `json:"current"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// PositionTransferStatus 포지션 이전 상태
+type PositionTransferStatus string
+
+const (
+	PositionTransferPending   PositionTransferStatus = "pending"   // 받는 사람 확인 대기 (수수료 잠금)
+	PositionTransferAccepted  PositionTransferStatus = "accepted"  // 수락되어 수량 이동 완료
+	PositionTransferDeclined  PositionTransferStatus = "declined"  // 받는 사람이 거절
+	PositionTransferCancelled PositionTransferStatus = "cancelled" // 보낸 사람이 취소
+	PositionTransferExpired   PositionTransferStatus = "expired"   // 기한 내 응답 없음
+)
+
+// PositionTransfer 사용자 간 포지션(마일스톤 옵션 보유 수량) 이전/선물
+//
+// 보낸 사람이 요청하면 수수료가 잠기고, 받는 사람이 수락할 때 수량과 그만큼의 취득 원가가 옮겨지며
+// 수수료가 차감된다. 거절/취소/만료되면 수수료는 반환된다.
+type PositionTransfer struct {
+	ID          uint                   `json:"id" gorm:"primaryKey"`
+	SenderID    uint                   `json:"sender_id" gorm:"not null;index"`
+	RecipientID uint                   `json:"recipient_id" gorm:"not null;index"`
+	ProjectID   uint                   `json:"project_id" gorm:"not null"`
+	MilestoneID uint                   `json:"milestone_id" gorm:"not null;index"`
+	OptionID    string                 `json:"option_id" gorm:"not null"`
+	Quantity    int64                  `json:"quantity" gorm:"not null"`
+	Fee         int64                  `json:"fee"` // 보낸 사람 부담 수수료 (센트)
+	Message     string                 `json:"message,omitempty" gorm:"type:varchar(280)"`
+	Status      PositionTransferStatus `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"`
+
+	// 수락 시점에 옮겨진 취득 원가 (보낸 사람 평균가 기준)
+	AvgPrice  float64 `json:"avg_price"`
+	CostBasis int64   `json:"cost_basis"`
+
+	ExpiresAt   time.Time  `json:"expires_at"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	Milestone Milestone `json:"milestone,omitempty" gorm:"foreignKey:MilestoneID"`
+}
+
+// CreatePositionTransferRequest 포지션 이전 요청 (받는 사람은 사용자명으로 지정)
+type CreatePositionTransferRequest struct {
+	RecipientUsername string `json:"recipient_username" binding:"required"`
+	MilestoneID       uint   `json:"milestone_id" binding:"required"`
+	OptionID          string `json:"option_id" binding:"required"`
+	Quantity          int64  `json:"quantity" binding:"required,min=1"`
+	Message           string `json:"message" binding:"max=280"`
+}
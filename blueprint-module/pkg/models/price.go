@@ -0,0 +1,157 @@
+package models
+
+import (
+	"errors"
+	"math"
+
+	"gorm.io/gorm"
+)
+
+// 💲 고정소수점 가격/금액
+// 가격은 0-1 확률을 PriceScale 배한 정수 틱으로 저장하고, 금액은 센트 정수로만 계산한다.
+
+const (
+	PriceScale           int64 = 10000 // 1.0 = 10000틱 (1틱 = 0.01¢)
+	DefaultPriceTickSize int64 = 100   // 기본 호가 단위 1¢
+	centsPerUnit         int64 = 100   // 결제 1단위(가격 1.0) = 100센트
+	FeeBasisPoints       int64 = 10000
+
+	// MaxTradeFeeBasisPoints 체결 수수료율 상한 (매수 주문은 접수 시 이 요율의 수수료까지 잠근다)
+	MaxTradeFeeBasisPoints int64 = 25
+)
+
+var (
+	ErrPriceOutOfRange = errors.New("가격은 0과 1 사이여야 합니다")
+	ErrPriceOffTick    = errors.New("가격이 마켓 호가 단위에 맞지 않습니다")
+)
+
+// PriceToTicks float 가격을 가장 가까운 틱으로 변환 (API 입력 경계에서만 사용)
+func PriceToTicks(price float64) int64 {
+	return int64(math.Round(price * float64(PriceScale)))
+}
+
+// TicksToPrice 틱을 표시용 float 가격으로 변환
+func TicksToPrice(ticks int64) float64 {
+	return float64(ticks) / float64(PriceScale)
+}
+
+// ValidatePriceTicks 가격 범위(0 < p < 1)와 마켓 호가 단위 검증
+func ValidatePriceTicks(ticks, tickSize int64) error {
+	if ticks <= 0 || ticks >= PriceScale {
+		return ErrPriceOutOfRange
+	}
+	if tickSize <= 0 {
+		tickSize = DefaultPriceTickSize
+	}
+	if ticks%tickSize != 0 {
+		return ErrPriceOffTick
+	}
+	return nil
+}
+
+// NotionalCents 체결 금액 (센트, 내림)
+// 매수자 지불액과 매도자 수령액은 항상 이 값 하나로 계산해 양쪽이 어긋나지 않게 한다
+// 1센트 미만 호가에서도 체결마다 매수 잠금(올림) 해제액을 넘지 않도록 내림한다
+func NotionalCents(quantity, priceTicks int64) int64 {
+	return quantity * priceTicks * centsPerUnit / PriceScale
+}
+
+// ReserveCents 매수 주문 잠금액 (센트, 올림 - 지정가 전량 체결 금액 이상 보장)
+func ReserveCents(quantity, priceTicks int64) int64 {
+	return (quantity*priceTicks*centsPerUnit + PriceScale - 1) / PriceScale
+}
+
+// BuyReserveCents 매수 주문 잠금액 (지정가 전량 체결 금액 + 최대 수수료, 센트 올림)
+// 체결 수수료는 이 잠금에서 나가므로 사용 가능 잔액이 0이어도 체결로 잔액이 음수가 되지 않는다
+func BuyReserveCents(quantity, priceTicks int64) int64 {
+	reserve := ReserveCents(quantity, priceTicks)
+	return reserve + (reserve*MaxTradeFeeBasisPoints+FeeBasisPoints-1)/FeeBasisPoints
+}
+
+// MaxReserveQuantity 잠금액이 cents를 넘지 않는 최대 매수 수량 (ReserveCents의 역)
+func MaxReserveQuantity(cents, priceTicks int64) int64 {
+	if cents <= 0 || priceTicks <= 0 {
+		return 0
+	}
+	return cents * PriceScale / (priceTicks * centsPerUnit)
+}
+
+// ShortCollateralCents 숏 매도 담보 (센트, 올림) - 1주당 최대 손실 (1 - 가격)
+func ShortCollateralCents(quantity, priceTicks int64) int64 {
+	return ReserveCents(quantity, PriceScale-priceTicks)
+}
+
+// FeeCents 수수료 (센트, 내림)
+func FeeCents(amount, basisPoints int64) int64 {
+	return amount * basisPoints / FeeBasisPoints
+}
+
+// TradeSettlement 체결 1건의 정산 금액 (모두 센트)
+// 매수자: 잠금 -BuyerRelease, 잔액 +(BuyerRelease - Notional - BuyerFee) (수수료는 주문 잠금에서 지불)
+// 매도자: 잔액 +(Notional - SellerFee), 수수료 합계는 BuyerFee + SellerFee
+type TradeSettlement struct {
+	Notional     int64
+	BuyerFee     int64
+	SellerFee    int64
+	BuyerRelease int64 // 이번 체결로 풀리는 매수 주문 잠금액 (수수료 잠금 포함)
+}
+
+// SettleFill 체결 정산 계산 (매수/매도 같은 수수료율)
+// 잠금 해제액은 누적 체결량 기준 잠금액의 차이라서 부분 체결을 모두 더하면 주문 잠금액과 정확히 같다
+func SettleFill(quantity, priceTicks, buyLimitTicks, buyFilledBefore, feeBasisPoints int64) TradeSettlement {
+	return SettleFillWithFees(quantity, priceTicks, buyLimitTicks, buyFilledBefore, feeBasisPoints, feeBasisPoints)
+}
+
+// SettleFillWithFees 매수/매도 수수료율을 따로 지정한 체결 정산 계산 (메이커/테이커 분리 수수료)
+func SettleFillWithFees(quantity, priceTicks, buyLimitTicks, buyFilledBefore, buyerFeeBasisPoints, sellerFeeBasisPoints int64) TradeSettlement {
+	notional := NotionalCents(quantity, priceTicks)
+	return TradeSettlement{
+		Notional:     notional,
+		BuyerFee:     FeeCents(notional, buyerFeeBasisPoints),
+		SellerFee:    FeeCents(notional, sellerFeeBasisPoints),
+		BuyerRelease: BuyReserveCents(buyFilledBefore+quantity, buyLimitTicks) - BuyReserveCents(buyFilledBefore, buyLimitTicks),
+	}
+}
+
+// LockedCents 주문에 아직 잠겨 있는 금액 (미체결 수량분, 취소/환불 시 해제액)
+// 매수는 지정가 기준 주문 금액과 최대 수수료, 매도는 보유 수량을 넘는 숏 매도분의 담보
+func (o *Order) LockedCents() int64 {
+	if o.Side != OrderSideBuy {
+		return ShortCollateralCents(o.ShortQuantity, o.PriceTicks) - o.shortCollateralFilled(o.Filled)
+	}
+	return BuyReserveCents(o.Quantity, o.PriceTicks) - BuyReserveCents(o.Filled, o.PriceTicks)
+}
+
+// ShortCollateralFill 매도 주문 체결로 주문 잠금에서 숏 포지션 담보로 옮겨지는 금액
+// 보유분이 먼저 체결되고 숏 매도분은 마지막에 체결되는 것으로 보며, 부분 체결을 모두 더하면 접수 시 담보와 같다
+func (o *Order) ShortCollateralFill(filledBefore, quantity int64) int64 {
+	if o.Side == OrderSideBuy || o.ShortQuantity <= 0 {
+		return 0
+	}
+	return o.shortCollateralFilled(filledBefore+quantity) - o.shortCollateralFilled(filledBefore)
+}
+
+// shortCollateralFilled 누적 체결량 중 숏 매도분의 담보
+func (o *Order) shortCollateralFilled(filled int64) int64 {
+	return ShortCollateralCents(max(filled-(o.Quantity-o.ShortQuantity), 0), o.PriceTicks)
+}
+
+// syncPriceTicks 틱이 비어 있으면 float 가격에서 채우고, 표시용 가격은 항상 틱에서 다시 계산
+func syncPriceTicks(ticks *int64, price *float64) {
+	if *ticks == 0 && *price > 0 {
+		*ticks = PriceToTicks(*price)
+	}
+	*price = TicksToPrice(*ticks)
+}
+
+// BeforeSave 가격 틱과 표시용 가격 동기화
+func (o *Order) BeforeSave(tx *gorm.DB) error {
+	syncPriceTicks(&o.PriceTicks, &o.Price)
+	return nil
+}
+
+// BeforeSave 가격 틱과 표시용 가격 동기화
+func (t *Trade) BeforeSave(tx *gorm.DB) error {
+	syncPriceTicks(&t.PriceTicks, &t.Price)
+	return nil
+}
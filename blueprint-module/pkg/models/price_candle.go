@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// CandleInterval 가격 캔들 간격
+type CandleInterval string
+
+const (
+	CandleInterval1m  CandleInterval = "1m"
+	CandleInterval5m  CandleInterval = "5m"
+	CandleInterval15m CandleInterval = "15m"
+	CandleInterval1h  CandleInterval = "1h"
+	CandleInterval1d  CandleInterval = "1d"
+)
+
+// CandleIntervals 체결마다 갱신하는 캔들 간격 목록
+var CandleIntervals = []CandleInterval{CandleInterval1m, CandleInterval5m, CandleInterval15m, CandleInterval1h, CandleInterval1d}
+
+// Duration 간격 길이 (알 수 없는 값이면 0)
+func (i CandleInterval) Duration() time.Duration {
+	switch i {
+	case CandleInterval1m:
+		return time.Minute
+	case CandleInterval5m:
+		return 5 * time.Minute
+	case CandleInterval15m:
+		return 15 * time.Minute
+	case CandleInterval1h:
+		return time.Hour
+	case CandleInterval1d:
+		return 24 * time.Hour
+	}
+	return 0
+}
+
+// PriceCandle 마켓별 OHLCV 캔들 (체결 이벤트로 갱신하는 조회 전용 모델)
+// 가격은 PriceScale 단위 틱, 거래대금은 체결 금액(센트) 합계
+type PriceCandle struct {
+	ID          uint           `json:"-" gorm:"primaryKey"`
+	MilestoneID uint           `json:"milestone_id" gorm:"not null;uniqueIndex:idx_price_candle_bucket"`
+	OptionID    string         `json:"option_id" gorm:"not null;uniqueIndex:idx_price_candle_bucket"`
+	Interval    CandleInterval `json:"interval" gorm:"column:candle_interval;size:8;not null;uniqueIndex:idx_price_candle_bucket"`
+	BucketStart time.Time      `json:"bucket_start" gorm:"not null;uniqueIndex:idx_price_candle_bucket"`
+	OpenTicks   int64          `json:"open_ticks"`
+	HighTicks   int64          `json:"high_ticks"`
+	LowTicks    int64          `json:"low_ticks"`
+	CloseTicks  int64          `json:"close_ticks"`
+	Quantity    int64          `json:"quantity"`
+	Volume      int64          `json:"volume"`
+	Trades      int            `json:"trades"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// VWAP 구간 거래량 가중 평균가 (거래 금액 센트 / 수량, 체결 없으면 0)
+func (c PriceCandle) VWAP() float64 {
+	if c.Quantity == 0 {
+		return 0
+	}
+	return float64(c.Volume) / float64(c.Quantity) / 100
+}
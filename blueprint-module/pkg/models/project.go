@@ -2,7 +2,11 @@ package models
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
+	"unicode"
 
 	"gorm.io/gorm"
 )
@@ -18,6 +22,19 @@ const (
 	LifeProject     ProjectCategory = "life"
 )
 
+// ProjectCategories 선택 가능한 프로젝트 카테고리
+var ProjectCategories = []ProjectCategory{CareerProject, BusinessProject, EducationProject, PersonalProject, LifeProject}
+
+// IsValid 지원하는 카테고리인지 확인
+func (c ProjectCategory) IsValid() bool {
+	for _, category := range ProjectCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
 // 프로젝트 상태
 type ProjectStatus string
 
@@ -83,6 +100,58 @@ func (Project) TableName() string {
 	return "projects"
 }
 
+// 프로젝트 태그 제한
+const (
+	MaxProjectTags      = 10 // 프로젝트당 최대 태그 수
+	MaxProjectTagLength = 30 // 태그 최대 길이 (문자 수)
+)
+
+var ErrInvalidProjectTag = errors.New("태그가 올바르지 않습니다")
+
+// ProjectTag 태그별 프로젝트 탐색용 인덱스 (Project.Tags JSON과 함께 저장)
+type ProjectTag struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	ProjectID uint      `json:"project_id" gorm:"not null;uniqueIndex:idx_project_tag"`
+	Tag       string    `json:"tag" gorm:"size:30;not null;uniqueIndex:idx_project_tag;index"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (ProjectTag) TableName() string {
+	return "project_tags"
+}
+
+// NormalizeProjectTags 태그 정규화 및 검증 - 소문자, 앞의 #과 공백 제거, 공백은 -로, 중복 제거
+//
+// 글자/숫자/-/_ 만 허용한다.
+func NormalizeProjectTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, raw := range tags {
+		tag := strings.ToLower(strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(raw), "#")))
+		tag = strings.Join(strings.Fields(tag), "-")
+		if tag == "" {
+			continue
+		}
+		if len([]rune(tag)) > MaxProjectTagLength {
+			return nil, fmt.Errorf("%w: '%s'는 %d자를 넘을 수 없습니다", ErrInvalidProjectTag, raw, MaxProjectTagLength)
+		}
+		for _, r := range tag {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' {
+				return nil, fmt.Errorf("%w: '%s'에 사용할 수 없는 문자가 있습니다", ErrInvalidProjectTag, raw)
+			}
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > MaxProjectTags {
+		return nil, fmt.Errorf("%w: 태그는 최대 %d개까지 지정할 수 있습니다", ErrInvalidProjectTag, MaxProjectTags)
+	}
+	return normalized, nil
+}
+
 // 프로젝트 생성 요청
 type CreateProjectRequest struct {
 	Title       string          `json:"title" binding:"required,min=3,max=200"`
@@ -137,6 +206,11 @@ type CreateProjectMilestoneRequest struct {
 	MinValidators             *int     `json:"min_validators,omitempty"`               // 최소 검증인 수
 	MinApprovalRate           *float64 `json:"min_approval_rate,omitempty"`            // 최소 승인률
 	VerificationDeadlineDays  *int     `json:"verification_deadline_days,omitempty"`  // 검증 마감일 (일수)
+
+	// 다중 결과(categorical) 마켓 옵션, 비어 있으면 success/fail 이진 마켓
+	Outcomes []MilestoneOutcome `json:"outcomes,omitempty" binding:"omitempty,max=10,dive"`
+	// 수치(scalar) 마켓 범위, Outcomes와 함께 쓸 수 없음
+	Scalar *ScalarRange `json:"scalar,omitempty"`
 }
 
 // 마일스톤 업데이트 요청
@@ -0,0 +1,85 @@
+package models
+
+import "time"
+
+// ProjectRole 프로젝트 팀 내 역할
+type ProjectRole string
+
+const (
+	ProjectRoleOwner  ProjectRole = "owner"  // 프로젝트 작성자 (Project.UserID) - 삭제, 팀 관리
+	ProjectRoleEditor ProjectRole = "editor" // 프로젝트/마일스톤 수정, 증거 제출
+	ProjectRoleViewer ProjectRole = "viewer" // 비공개 프로젝트 열람
+)
+
+// IsValid 초대/변경 가능한 역할인지 확인 (owner는 작성자 고정)
+func (r ProjectRole) IsValid() bool {
+	return r == ProjectRoleEditor || r == ProjectRoleViewer
+}
+
+// CanEdit 프로젝트/마일스톤 수정 및 증거 제출 권한
+func (r ProjectRole) CanEdit() bool {
+	return r == ProjectRoleOwner || r == ProjectRoleEditor
+}
+
+// CanManage 팀원 초대/역할 변경/제거 및 프로젝트 삭제 권한
+func (r ProjectRole) CanManage() bool {
+	return r == ProjectRoleOwner
+}
+
+// ProjectMember 프로젝트 팀원 (작성자는 Project.UserID로 owner이며 이 테이블에 없음)
+type ProjectMember struct {
+	ID        uint        `json:"id" gorm:"primaryKey"`
+	ProjectID uint        `json:"project_id" gorm:"not null;uniqueIndex:idx_project_member"`
+	UserID    uint        `json:"user_id" gorm:"not null;uniqueIndex:idx_project_member;index"`
+	Role      ProjectRole `json:"role" gorm:"type:varchar(20);not null"`
+	InvitedBy uint        `json:"invited_by"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+
+	User User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+func (ProjectMember) TableName() string {
+	return "project_members"
+}
+
+// ProjectInviteStatus 팀 초대 상태
+type ProjectInviteStatus string
+
+const (
+	ProjectInvitePending  ProjectInviteStatus = "pending"
+	ProjectInviteAccepted ProjectInviteStatus = "accepted"
+	ProjectInviteDeclined ProjectInviteStatus = "declined"
+	ProjectInviteRevoked  ProjectInviteStatus = "revoked" // 작성자가 취소
+)
+
+// ProjectInvite 이메일 기반 팀 초대 (해당 이메일로 로그인한 사용자가 수락)
+type ProjectInvite struct {
+	ID         uint                `json:"id" gorm:"primaryKey"`
+	ProjectID  uint                `json:"project_id" gorm:"not null;index"`
+	Email      string              `json:"email" gorm:"type:varchar(255);not null;index"`
+	Role       ProjectRole         `json:"role" gorm:"type:varchar(20);not null"`
+	Status     ProjectInviteStatus `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"`
+	InvitedBy  uint                `json:"invited_by" gorm:"not null"`
+	ExpiresAt  time.Time           `json:"expires_at"`
+	AcceptedAt *time.Time          `json:"accepted_at,omitempty"`
+	CreatedAt  time.Time           `json:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+
+	Project Project `json:"project,omitempty" gorm:"foreignKey:ProjectID"`
+}
+
+func (ProjectInvite) TableName() string {
+	return "project_invites"
+}
+
+// InviteProjectMemberRequest 팀 초대 요청
+type InviteProjectMemberRequest struct {
+	Email string      `json:"email" binding:"required,email"`
+	Role  ProjectRole `json:"role" binding:"required"`
+}
+
+// UpdateProjectMemberRequest 팀원 역할 변경 요청
+type UpdateProjectMemberRequest struct {
+	Role ProjectRole `json:"role" binding:"required"`
+}
@@ -0,0 +1,86 @@
+package models
+
+import "time"
+
+// MilestoneRiskLevel 실패 위험 구간
+type MilestoneRiskLevel string
+
+const (
+	MilestoneRiskLow    MilestoneRiskLevel = "low"
+	MilestoneRiskMedium MilestoneRiskLevel = "medium"
+	MilestoneRiskHigh   MilestoneRiskLevel = "high"
+)
+
+// MilestoneRiskFactors 위험 점수 입력 신호 (각 0~1, 클수록 위험)
+type MilestoneRiskFactors struct {
+	Market       float64 `json:"market"`        // 1 - 성공 옵션 가격 (가격이 없으면 0.5)
+	Schedule     float64 `json:"schedule"`      // 목표일까지 경과 비율 (증거 없이 목표일을 넘기면 1)
+	ProofHistory float64 `json:"proof_history"` // 거절된 증거 비율
+	Inactivity   float64 `json:"inactivity"`    // 마지막 프로젝트 활동 이후 경과 (30일이면 1)
+}
+
+// MilestoneRiskScore 마일스톤 실패 위험 점수 (모델 기반 신호, 마켓 가격과 함께 노출)
+type MilestoneRiskScore struct {
+	MilestoneID uint                 `json:"milestone_id"`
+	Score       float64              `json:"score"` // 0~1 실패 확률 추정
+	Level       MilestoneRiskLevel   `json:"level"`
+	Factors     MilestoneRiskFactors `json:"factors"`
+	Model       string               `json:"model"` // 계산 모델 버전
+	ComputedAt  time.Time            `json:"computed_at"`
+}
+
+// ProjectStatsCache 프로젝트 단위로 주기적으로 계산해 두는 지표 (조회 API는 계산하지 않고 이 값을 사용)
+type ProjectStatsCache struct {
+	ProjectID      uint                        `json:"project_id" gorm:"primaryKey;autoIncrement:false"`
+	MilestoneRisks map[uint]MilestoneRiskScore `json:"milestone_risks" gorm:"type:text;serializer:json"`
+	MaxRiskScore   float64                     `json:"max_risk_score"` // 진행 중 마일스톤 중 가장 높은 위험
+	RiskComputedAt *time.Time                  `json:"risk_computed_at,omitempty"`
+	CreatedAt      time.Time                   `json:"created_at"`
+	UpdatedAt      time.Time                   `json:"updated_at"`
+}
+
+func (ProjectStatsCache) TableName() string {
+	return "project_stats_caches"
+}
+
+// UserStatsCache 사용자 거래/검증/멘토 지표 (user_stats 주기 작업이 계산, 공개 프로필에는 InvestmentPublic일 때만 노출)
+type UserStatsCache struct {
+	UserID            uint      `json:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	TotalVolume       int64     `json:"total_volume"`       // 체결 금액 합계 (센트)
+	TotalTrades       int       `json:"total_trades"`       // 체결 건수
+	SettledPositions  int       `json:"settled_positions"`  // 결과가 확정된 마켓에서 손익이 난 포지션 수
+	WinRate           float64   `json:"win_rate"`           // 그중 실현 손익이 양수인 비율 (0~1)
+	ResolvedMarkets   int       `json:"resolved_markets"`   // 순매수로 참여한 결과 확정 마켓 수
+	MarketAccuracy    float64   `json:"market_accuracy"`    // 그중 가장 많이 순매수한 옵션이 지급된 비율 (0~1)
+	ValidatorVotes    int       `json:"validator_votes"`    // 검증 참여 수
+	ValidatorAccuracy float64   `json:"validator_accuracy"` // 검증 정확도 (0~1)
+	MentorRating      float64   `json:"mentor_rating"`      // 멘토 평균 평점 (멘토가 아니면 0)
+	ComputedAt        time.Time `json:"computed_at"`
+}
+
+func (UserStatsCache) TableName() string {
+	return "user_stats_caches"
+}
+
+// GlobalStatsCacheID 플랫폼 지표는 한 행만 유지
+const GlobalStatsCacheID = 1
+
+// GlobalStatsCache 플랫폼 전체 지표 (platform_stats 주기 작업이 한 행을 갱신, 공개 API는 이 값을 사용)
+type GlobalStatsCache struct {
+	ID                        uint      `json:"-" gorm:"primaryKey;autoIncrement:false"`
+	TotalVolume               int64     `json:"total_volume"`                // 누적 체결 금액 (센트)
+	Volume24h                 int64     `json:"volume_24h"`                  // 최근 24시간 체결 금액 (센트)
+	OpenMarkets               int64     `json:"open_markets"`                // 거래 가능한 마일스톤 마켓 수
+	ActiveTraders             int64     `json:"active_traders"`              // 최근 30일 체결에 참여한 사용자 수
+	ResolvedMilestones        int64     `json:"resolved_milestones"`         // 결과가 확정(완료/실패)된 마일스톤 수
+	TotalValueLocked          int64     `json:"tvl"`                         // 결과가 확정되지 않은 마일스톤의 총 베팅액 (센트)
+	ArbitrationCases          int64     `json:"arbitration_cases"`           // 접수된 분쟁 사건 수
+	ArbitrationResolved       int64     `json:"arbitration_resolved"`        // 판결/종료된 분쟁 사건 수
+	ArbitrationResolutionRate float64   `json:"arbitration_resolution_rate"` // 판결/종료 비율 (0~1)
+	ComputedAt                time.Time `json:"computed_at"`
+	UpdatedAt                 time.Time `json:"-"`
+}
+
+func (GlobalStatsCache) TableName() string {
+	return "global_stats_caches"
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// ReconciliationCheck 대사 항목
+type ReconciliationCheck string
+
+const (
+	ReconcileBookOrderClosed  ReconciliationCheck = "book_order_closed"  // 주문장에 남았지만 DB에서는 종료된 주문
+	ReconcileBookOrderUnknown ReconciliationCheck = "book_order_unknown" // 주문장에 남았지만 DB에 없는 주문
+	ReconcileBookRemaining    ReconciliationCheck = "book_remaining"     // 주문장과 DB의 남은 수량 불일치
+	ReconcileOrderNotInBook   ReconciliationCheck = "order_not_in_book"  // DB에서 열린 주문이 주문장에 없음
+	ReconcileOrderFill        ReconciliationCheck = "order_fill"         // DB 체결 수량과 체결 내역 합계 불일치
+	ReconcileWalletTrades     ReconciliationCheck = "wallet_trades"      // 지갑 거래 수/수수료 통계와 체결 내역 불일치
+	ReconcileLockedBalance    ReconciliationCheck = "locked_balance"     // 잠긴 USDC와 열린 주문/조합 베팅 잠금액 불일치
+	ReconcileBuyerSettlement  ReconciliationCheck = "buyer_settlement"   // 체결 매수 정산에 필요한 주문 잠금액이 부족해 정산 보류
+)
+
+// ReconciliationDiscrepancy 대사에서 발견된 불일치 1건
+type ReconciliationDiscrepancy struct {
+	Check       ReconciliationCheck `json:"check"`
+	UserID      uint                `json:"user_id,omitempty"`
+	OrderID     uint                `json:"order_id,omitempty"`
+	MilestoneID uint                `json:"milestone_id,omitempty"`
+	OptionID    string              `json:"option_id,omitempty"`
+	Expected    int64               `json:"expected"` // 기준값 (체결 내역/DB 주문 기준)
+	Actual      int64               `json:"actual"`   // 실제 기록된 값
+	Detail      string              `json:"detail"`
+	Corrected   bool                `json:"corrected"` // 자동 보정됨
+}
+
+// ReconciliationReport 주문/체결/지갑 대사 실행 결과
+//
+// 체결 후처리(지갑/포지션)와 주문 상태 반영은 비동기라 유실되면 흔적이 남지 않는다. 주기 대사가 주문장,
+// DB 주문, 체결 내역, 지갑을 서로 맞춰 보고 불일치를 기록한다.
+type ReconciliationReport struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	StartedAt   time.Time  `json:"started_at" gorm:"index"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+	AutoCorrect bool       `json:"auto_correct"`
+
+	OrdersChecked    int `json:"orders_checked"`
+	WalletsChecked   int `json:"wallets_checked"`
+	DiscrepancyCount int `json:"discrepancy_count"`
+	CorrectedCount   int `json:"corrected_count"`
+
+	Discrepancies []ReconciliationDiscrepancy `json:"discrepancies,omitempty" gorm:"type:text;serializer:json"`
+	Error         string                      `json:"error,omitempty" gorm:"type:text"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
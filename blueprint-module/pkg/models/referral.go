@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+const (
+	// ReferralRewardShareBps 추천인이 받는 피추천인 거래 수수료 비율 (2000 = 20%)
+	ReferralRewardShareBps int64 = 2000
+
+	// ReferralRewardWindow 가입 후 수수료 공유 기간
+	ReferralRewardWindow = 90 * 24 * time.Hour
+)
+
+// ReferralCode 사용자별 추천 코드
+type ReferralCode struct {
+	ID        uint      `json:"-" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex"`
+	Code      string    `json:"code" gorm:"type:varchar(16);not null;uniqueIndex"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Referral 추천 관계 (피추천인당 하나, 가입 시점에 귀속)
+type Referral struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	ReferrerID   uint      `json:"referrer_id" gorm:"not null;index"`
+	RefereeID    uint      `json:"referee_id" gorm:"not null;uniqueIndex"`
+	Code         string    `json:"code" gorm:"type:varchar(16);not null"`
+	RewardEndsAt time.Time `json:"reward_ends_at" gorm:"not null"` // 수수료 공유 종료 시각
+	CreatedAt    time.Time `json:"created_at"`
+
+	// 관계
+	Referee User `json:"referee,omitempty" gorm:"foreignKey:RefereeID"`
+}
+
+// ReferralRewardStatus 추천 보상 상태
+type ReferralRewardStatus string
+
+const (
+	ReferralRewardPending ReferralRewardStatus = "pending" // 적립됨 (지급 대기)
+	ReferralRewardPaid    ReferralRewardStatus = "paid"    // 지갑으로 지급 완료
+)
+
+// ReferralReward 추천 보상 원장 (체결 1건의 피추천인 수수료당 1행, 수정 없이 상태만 변경)
+type ReferralReward struct {
+	ID           uint                 `json:"id" gorm:"primaryKey"`
+	ReferralID   uint                 `json:"referral_id" gorm:"not null;index"`
+	ReferrerID   uint                 `json:"referrer_id" gorm:"not null;index:idx_referral_reward_referrer_status"`
+	RefereeID    uint                 `json:"referee_id" gorm:"not null;uniqueIndex:idx_referral_reward_trade"`
+	TradeID      uint                 `json:"trade_id" gorm:"not null;uniqueIndex:idx_referral_reward_trade"`
+	FeeAmount    int64                `json:"fee_amount"`    // 피추천인이 낸 수수료 (센트)
+	RewardAmount int64                `json:"reward_amount"` // 추천인 적립액 (센트)
+	Status       ReferralRewardStatus `json:"status" gorm:"type:varchar(20);default:'pending';index:idx_referral_reward_referrer_status"`
+	PayoutID     *uint                `json:"payout_id,omitempty" gorm:"index"`
+	CreatedAt    time.Time            `json:"created_at"`
+}
+
+// ReferralPayout 적립 보상 지급 내역
+type ReferralPayout struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	ReferrerID  uint      `json:"referrer_id" gorm:"not null;index"`
+	Amount      int64     `json:"amount"`       // 지급액 (센트, USDC 잔액으로 입금)
+	RewardCount int       `json:"reward_count"` // 포함된 적립 건수
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ReferralStatsResponse 내 추천 현황
+type ReferralStatsResponse struct {
+	Code            string  `json:"code"`
+	TotalReferees   int64   `json:"total_referees"`
+	ActiveReferees  int64   `json:"active_referees"` // 수수료 공유 기간 내 피추천인
+	TotalAccrued    int64   `json:"total_accrued"`   // 누적 적립액 (센트)
+	PendingAmount   int64   `json:"pending_amount"`  // 지급 대기액 (센트)
+	PaidAmount      int64   `json:"paid_amount"`     // 지급 완료액 (센트)
+	RewardShareRate float64 `json:"reward_share_rate"`
+	RewardWindowDay int     `json:"reward_window_days"`
+}
@@ -0,0 +1,111 @@
+package models
+
+import "time"
+
+// Role 사용자 역할
+type Role string
+
+const (
+	RoleAdmin     Role = "admin"     // 전체 관리자 (모든 권한)
+	RoleModerator Role = "moderator" // 운영자 (신고/분쟁/슬래싱 처리)
+	RoleValidator Role = "validator" // 마일스톤 증거 검증인
+	RoleJuror     Role = "juror"     // 분쟁 배심원
+	RoleMentor    Role = "mentor"    // 멘토
+)
+
+// ValidRoles 부여 가능한 역할 목록
+var ValidRoles = []Role{RoleAdmin, RoleModerator, RoleValidator, RoleJuror, RoleMentor}
+
+// IsValid 정의된 역할인지 확인
+func (r Role) IsValid() bool {
+	for _, role := range ValidRoles {
+		if role == r {
+			return true
+		}
+	}
+	return false
+}
+
+// Permission 라우트 단위 권한
+type Permission string
+
+const (
+	PermissionManageRoles       Permission = "roles:manage"        // 역할 부여/회수
+	PermissionManageFunding     Permission = "funding:manage"      // 펀딩 단계 강제 전환
+	PermissionProcessSlashing   Permission = "slashing:process"    // 멘토 슬래싱 승인/거부
+	PermissionModerate          Permission = "content:moderate"    // 콘텐츠/사용자 제재
+	PermissionReviewKYC         Permission = "kyc:review"          // 본인 인증(KYC) 수동 심사
+	PermissionReviewCredentials Permission = "credentials:review"  // 전문 자격/학력 서류 심사
+	PermissionValidateProofs    Permission = "proofs:validate"     // 증거 검증 투표
+	PermissionJudgeDisputes     Permission = "disputes:judge"      // 분쟁 배심 투표
+	PermissionMentor            Permission = "mentoring:provide"   // 멘토 활동
+	PermissionManageMarketMaker Permission = "market_maker:manage" // 마켓메이커 봇 시작/중지/설정
+	PermissionResolveMarkets    Permission = "markets:resolve"     // 다중 결과 마켓 승리 옵션 확정
+	PermissionManageFeatures    Permission = "features:manage"     // 기능 플래그 변경
+	PermissionManageTrading     Permission = "trading:manage"      // 점검 모드/마켓 거래 중단
+	PermissionManagePayouts     Permission = "payouts:manage"      // 창작자 지급 승인/반려
+	PermissionSurveillance      Permission = "surveillance:review" // 시장 감시 알림 검토/중재 이관
+	PermissionManageQueues      Permission = "queues:manage"       // 데드레터 큐 조회/재처리/삭제
+	PermissionManageScheduler   Permission = "scheduler:manage"    // 주기 작업 일정 변경/즉시 실행
+)
+
+// AllPermissions 정의된 모든 권한 (admin 권한 목록)
+var AllPermissions = []Permission{
+	PermissionManageRoles, PermissionManageFunding, PermissionProcessSlashing, PermissionModerate,
+	PermissionReviewKYC, PermissionReviewCredentials, PermissionValidateProofs, PermissionJudgeDisputes, PermissionMentor,
+	PermissionManageMarketMaker, PermissionResolveMarkets, PermissionManageFeatures, PermissionManageTrading,
+	PermissionManagePayouts, PermissionSurveillance, PermissionManageQueues, PermissionManageScheduler,
+}
+
+// RolePermissions 역할별 권한 (admin은 모든 권한 보유)
+var RolePermissions = map[Role][]Permission{
+	RoleModerator: {PermissionProcessSlashing, PermissionModerate, PermissionReviewKYC, PermissionReviewCredentials, PermissionSurveillance},
+	RoleValidator: {PermissionValidateProofs},
+	RoleJuror:     {PermissionJudgeDisputes},
+	RoleMentor:    {PermissionMentor},
+}
+
+// Grants 역할이 해당 권한을 포함하는지 확인
+func (r Role) Grants(permission Permission) bool {
+	if r == RoleAdmin {
+		return true
+	}
+	for _, p := range RolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// UserRole 사용자 역할 부여 내역 (회수 시 RevokedAt 기록, 이력 보존)
+type UserRole struct {
+	ID     uint `json:"id" gorm:"primaryKey"`
+	UserID uint `json:"user_id" gorm:"not null;index"`
+	Role   Role `json:"role" gorm:"size:20;not null;index"`
+
+	GrantedBy *uint  `json:"granted_by,omitempty"` // nil이면 시스템(부트스트랩) 부여
+	Reason    string `json:"reason,omitempty" gorm:"size:500"`
+
+	RevokedAt *time.Time `json:"revoked_at,omitempty" gorm:"index"`
+	RevokedBy *uint      `json:"revoked_by,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// 외래키 참조
+	User User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// GrantRoleRequest 역할 부여 요청
+type GrantRoleRequest struct {
+	Role   Role   `json:"role" binding:"required"`
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// UserRolesResponse 사용자 역할/권한 응답
+type UserRolesResponse struct {
+	UserID      uint         `json:"user_id"`
+	Roles       []Role       `json:"roles"`
+	Permissions []Permission `json:"permissions"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// ScheduledJobStatus 주기 작업 실행 상태
+type ScheduledJobStatus string
+
+const (
+	ScheduledJobRunning   ScheduledJobStatus = "running"
+	ScheduledJobSucceeded ScheduledJobStatus = "succeeded"
+	ScheduledJobFailed    ScheduledJobStatus = "failed"
+)
+
+// ScheduledJobTrigger 실행 계기
+type ScheduledJobTrigger string
+
+const (
+	ScheduledJobTriggerSchedule ScheduledJobTrigger = "schedule" // 주기 도래
+	ScheduledJobTriggerManual   ScheduledJobTrigger = "manual"   // 관리자 즉시 실행
+)
+
+// ScheduledJob API 서버 주기 작업의 일정과 마지막 실행 결과
+//
+// 서버가 처음 등록할 때 코드의 기본 주기로 만들고, 이후에는 이 행의 주기/활성 여부를 따른다.
+// 여러 인스턴스 중 Redis 락을 잡은 한 곳만 NextRunAt이 지난 작업을 실행한다.
+type ScheduledJob struct {
+	Name            string     `json:"name" gorm:"primaryKey;size:50"`
+	IntervalSeconds int        `json:"interval_seconds" gorm:"not null"`
+	Enabled         bool       `json:"enabled" gorm:"not null;default:true"`
+	NextRunAt       *time.Time `json:"next_run_at,omitempty" gorm:"index"`
+
+	LastRunAt      *time.Time         `json:"last_run_at,omitempty"`
+	LastStatus     ScheduledJobStatus `json:"last_status,omitempty" gorm:"size:20"`
+	LastError      string             `json:"last_error,omitempty" gorm:"type:text"`
+	LastDurationMs int64              `json:"last_duration_ms"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ScheduledJobRun 주기 작업 실행 이력
+type ScheduledJobRun struct {
+	ID          uint                `json:"id" gorm:"primaryKey"`
+	JobName     string              `json:"job_name" gorm:"size:50;not null;index:idx_scheduled_job_runs_job_started,priority:1"`
+	Trigger     ScheduledJobTrigger `json:"trigger" gorm:"size:20;not null"`
+	TriggeredBy *uint               `json:"triggered_by,omitempty"`   // 즉시 실행한 관리자
+	Instance    string              `json:"instance" gorm:"size:100"` // 실행한 서버 인스턴스
+	Status      ScheduledJobStatus  `json:"status" gorm:"size:20;not null;index"`
+	Affected    int                 `json:"affected"` // 작업이 처리한 건수 (작업마다 의미가 다름)
+	Error       string              `json:"error,omitempty" gorm:"type:text"`
+
+	StartedAt  time.Time  `json:"started_at" gorm:"not null;index:idx_scheduled_job_runs_job_started,priority:2"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	DurationMs int64      `json:"duration_ms"`
+}
+
+// UpdateScheduledJobRequest 주기/활성 여부 변경
+type UpdateScheduledJobRequest struct {
+	IntervalSeconds *int  `json:"interval_seconds" binding:"omitempty,min=10"`
+	Enabled         *bool `json:"enabled"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Session 로그인 세션 (Redis에 저장되며 DB 테이블은 없음)
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     uint      `json:"user_id"`
+	DeviceName string    `json:"device_name"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"` // 현재 요청의 세션 여부 (응답용)
+}
+
+// AuthTokens 로그인/토큰 갱신 응답
+type AuthTokens struct {
+	AccessToken      string `json:"token"`
+	RefreshToken     string `json:"refresh_token,omitempty"`
+	ExpiresIn        int    `json:"expires_in"`                   // 액세스 토큰 유효 시간 (초)
+	RefreshExpiresIn int    `json:"refresh_expires_in,omitempty"` // 리프레시 토큰 유효 시간 (초)
+	SessionID        string `json:"session_id,omitempty"`
+}
+
+// RefreshTokenRequest 토큰 갱신 요청
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
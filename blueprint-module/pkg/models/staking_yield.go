@@ -0,0 +1,78 @@
+package models
+
+import "time"
+
+// StakeKind 스테이킹 보상 대상 종류
+type StakeKind string
+
+const (
+	StakeKindMentor StakeKind = "mentor" // MentorStake
+	StakeKindJuror  StakeKind = "juror"  // JurorQualification.CurrentStake
+)
+
+// StakingEpochRewardStatus 스테이킹 보상 상태
+type StakingEpochRewardStatus string
+
+const (
+	StakingEpochRewardPending StakingEpochRewardStatus = "pending" // 청구 대기
+	StakingEpochRewardClaimed StakingEpochRewardStatus = "claimed" // 지갑 입금 완료
+)
+
+// StakingEpoch 에포크별 BLUEPRINT 발행 기록 (에포크당 1행, 중복 처리 방지)
+type StakingEpoch struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	EpochNumber int64     `json:"epoch_number" gorm:"not null;uniqueIndex"`
+	StartAt     time.Time `json:"start_at" gorm:"not null"`
+	EndAt       time.Time `json:"end_at" gorm:"not null"`
+
+	EmissionAmount    int64 `json:"emission_amount"`    // 스케줄상 발행량
+	DistributedAmount int64 `json:"distributed_amount"` // 실제 적립된 보상 합계 (스테이커가 없으면 0)
+
+	MentorStakeTotal int64   `json:"mentor_stake_total"`
+	JurorStakeTotal  int64   `json:"juror_stake_total"`
+	MentorAPY        float64 `json:"mentor_apy"` // 해당 에포크 기준 연환산 수익률 (%)
+	JurorAPY         float64 `json:"juror_apy"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// StakingEpochReward 에포크별 BLUEPRINT 발행 보상 적립 (스테이킹 1건당 에포크마다 1행)
+// 수수료 수익 분배(StakingReward)와 별개
+type StakingEpochReward struct {
+	ID           uint                     `json:"id" gorm:"primaryKey"`
+	UserID       uint                     `json:"user_id" gorm:"not null;index:idx_staking_epoch_reward_user_status"`
+	EpochID      uint                     `json:"epoch_id" gorm:"not null;uniqueIndex:idx_staking_epoch_reward_epoch_stake"`
+	StakeKind    StakeKind                `json:"stake_kind" gorm:"type:varchar(16);not null;uniqueIndex:idx_staking_epoch_reward_epoch_stake"`
+	StakeRefID   uint                     `json:"stake_ref_id" gorm:"not null;uniqueIndex:idx_staking_epoch_reward_epoch_stake"` // MentorStake.ID 또는 JurorQualification.ID
+	StakedAmount int64                    `json:"staked_amount"`
+	Amount       int64                    `json:"amount"`
+	Status       StakingEpochRewardStatus `json:"status" gorm:"type:varchar(16);default:'pending';index:idx_staking_epoch_reward_user_status"`
+	ClaimedAt    *time.Time               `json:"claimed_at,omitempty"`
+	CreatedAt    time.Time                `json:"created_at"`
+}
+
+func (StakingEpoch) TableName() string       { return "staking_epochs" }
+func (StakingEpochReward) TableName() string { return "staking_epoch_rewards" }
+
+// StakingRewardSummary 내 스테이킹 보상 현황
+type StakingRewardSummary struct {
+	PendingAmount int64                `json:"pending_amount"`
+	PendingCount  int64                `json:"pending_count"`
+	ClaimedAmount int64                `json:"claimed_amount"`
+	CurrentEpoch  int64                `json:"current_epoch"`
+	EpochEmission int64                `json:"epoch_emission"` // 현재 에포크 발행량
+	MentorAPY     float64              `json:"mentor_apy"`     // 최근 처리된 에포크 기준
+	JurorAPY      float64              `json:"juror_apy"`
+	RecentRewards []StakingEpochReward `json:"recent_rewards"`
+}
+
+// StakingRewardClaimResponse 스테이킹 보상 청구 결과
+type StakingRewardClaimResponse struct {
+	ClaimedCount int   `json:"claimed_count"`
+	TotalAmount  int64 `json:"total_amount"`
+}
+
+// UpdateAutoRenewalRequest 자동 갱신 설정 변경 요청
+type UpdateAutoRenewalRequest struct {
+	IsAutoRenewal bool `json:"is_auto_renewal"`
+}
@@ -0,0 +1,93 @@
+package models
+
+import "time"
+
+// SurveillanceAlertType 시장 감시 탐지 유형
+type SurveillanceAlertType string
+
+const (
+	SurveillanceWashTrading      SurveillanceAlertType = "wash_trading"       // 자기 체결 또는 소수 계정 간 주고받기 거래 고리
+	SurveillanceSpoofing         SurveillanceAlertType = "spoofing"           // 체결 의사 없는 주문을 넣었다 바로 취소
+	SurveillancePaintingTheClose SurveillanceAlertType = "painting_the_close" // 마감/검증 기한 직전 소수 계정이 가격을 끌어올리거나 내림
+)
+
+// SurveillanceSeverity 알림 심각도
+type SurveillanceSeverity string
+
+const (
+	SurveillanceSeverityLow    SurveillanceSeverity = "low"
+	SurveillanceSeverityMedium SurveillanceSeverity = "medium"
+	SurveillanceSeverityHigh   SurveillanceSeverity = "high"
+)
+
+// SurveillanceAlertStatus 알림 처리 상태
+type SurveillanceAlertStatus string
+
+const (
+	SurveillanceAlertOpen      SurveillanceAlertStatus = "open"      // 검토 대기 (재탐지 시 증거 갱신)
+	SurveillanceAlertDismissed SurveillanceAlertStatus = "dismissed" // 문제 없음으로 종결
+	SurveillanceAlertEscalated SurveillanceAlertStatus = "escalated" // 배심원 중재로 이관
+)
+
+// SurveillanceEvidence 탐지 근거 (유형별로 채워지는 항목이 다름)
+type SurveillanceEvidence struct {
+	WindowStart time.Time `json:"window_start"`
+	WindowEnd   time.Time `json:"window_end"`
+
+	// wash_trading: 고리 안에서 주고받은 체결
+	TradeIDs     []uint  `json:"trade_ids,omitempty"`
+	RingQuantity int64   `json:"ring_quantity,omitempty"` // 고리를 한 바퀴 도는 수량 (구간별 최소 체결 수량)
+	RingVolume   int64   `json:"ring_volume,omitempty"`   // 고리 안 체결 금액 합계 (센트)
+	MarketShare  float64 `json:"market_share,omitempty"`  // 구간 전체 체결 수량 대비 비율 (painting_the_close는 주도 계정 비중)
+
+	// spoofing: 빠르게 취소된 주문
+	OrderIDs          []uint  `json:"order_ids,omitempty"`
+	OrdersPlaced      int     `json:"orders_placed,omitempty"`
+	QuickCancels      int     `json:"quick_cancels,omitempty"`
+	CancelRatio       float64 `json:"cancel_ratio,omitempty"`
+	CancelledQuantity int64   `json:"cancelled_quantity,omitempty"`
+	OppositeFills     int64   `json:"opposite_fills,omitempty"` // 같은 구간 반대 방향 체결 수량 (허수 주문으로 가격을 띄우고 반대로 체결)
+
+	// painting_the_close: 기한 직전 가격 변동
+	Deadline     *time.Time `json:"deadline,omitempty"`
+	DeadlineKind string     `json:"deadline_kind,omitempty"` // "trading_close", "verification"
+	PriceBefore  float64    `json:"price_before,omitempty"`
+	PriceAfter   float64    `json:"price_after,omitempty"`
+	DriverVolume int64      `json:"driver_volume,omitempty"` // 주도 계정의 가격 방향 체결 수량
+	WindowVolume int64      `json:"window_volume,omitempty"` // 구간 전체 체결 수량
+}
+
+// SurveillanceAlert 시장 감시 알림
+//
+// 감시 작업이 주기적으로 체결/주문 기록을 훑어 조작 의심 패턴을 기록한다. 같은 패턴은 DedupeKey로 묶여
+// 열린 알림이면 증거만 갱신되고, 종결/이관된 알림은 다시 열리지 않는다. 운영자가 검토해 종결하거나
+// 배심원 중재 사건으로 이관한다.
+type SurveillanceAlert struct {
+	ID          uint                    `json:"id" gorm:"primaryKey"`
+	Type        SurveillanceAlertType   `json:"type" gorm:"size:32;index;not null"`
+	Severity    SurveillanceSeverity    `json:"severity" gorm:"size:16;not null"`
+	Status      SurveillanceAlertStatus `json:"status" gorm:"size:16;index;default:'open'"`
+	DedupeKey   string                  `json:"-" gorm:"size:191;uniqueIndex;not null"`
+	MilestoneID uint                    `json:"milestone_id" gorm:"index"`
+	OptionID    string                  `json:"option_id"`
+	UserIDs     []uint                  `json:"user_ids" gorm:"type:text;serializer:json"` // 관련 계정 (첫 번째가 주 대상)
+	Summary     string                  `json:"summary"`
+	Evidence    SurveillanceEvidence    `json:"evidence" gorm:"type:text;serializer:json"`
+
+	DetectedAt        time.Time  `json:"detected_at"`
+	LastSeenAt        time.Time  `json:"last_seen_at"`
+	ReviewedBy        *uint      `json:"reviewed_by,omitempty"`
+	ReviewedAt        *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote        string     `json:"review_note,omitempty" gorm:"type:text"`
+	ArbitrationCaseID *uint      `json:"arbitration_case_id,omitempty" gorm:"index"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Milestone *Milestone `json:"milestone,omitempty" gorm:"foreignKey:MilestoneID"`
+}
+
+// ReviewSurveillanceAlertRequest 알림 종결/이관 요청
+type ReviewSurveillanceAlertRequest struct {
+	Note string `json:"note" binding:"max=1000"`
+}
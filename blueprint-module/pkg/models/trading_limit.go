@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// UserTradingLimit 사용자가 직접 정한 일일 한도와 휴식/자기 배제 (책임 있는 거래)
+//
+// 한도가 0이면 플랫폼 기본값을 따른다. 한도를 낮추면 바로 적용되고, 올리거나 해제하면 Pending 값에 담겨
+// 유예 시간이 지난 뒤 적용된다. 휴식(cooling-off)과 자기 배제는 기한 전에 해제할 수 없다.
+type UserTradingLimit struct {
+	ID     uint `json:"-" gorm:"primaryKey"`
+	UserID uint `json:"-" gorm:"uniqueIndex;not null"`
+
+	DailyDepositLimit       int64 `json:"daily_deposit_limit"`        // 하루 후원 예치 한도 (센트)
+	DailyOrderNotionalLimit int64 `json:"daily_order_notional_limit"` // 하루 주문 금액 한도 (센트, 조합 베팅 원금 포함)
+
+	PendingDailyDepositLimit       *int64     `json:"pending_daily_deposit_limit,omitempty"`
+	PendingDailyOrderNotionalLimit *int64     `json:"pending_daily_order_notional_limit,omitempty"`
+	PendingEffectiveAt             *time.Time `json:"pending_effective_at,omitempty"` // 완화된 한도 적용 시각
+
+	CoolingOffUntil   *time.Time `json:"cooling_off_until,omitempty"`   // 휴식 종료 시각
+	SelfExcludedUntil *time.Time `json:"self_excluded_until,omitempty"` // 자기 배제 종료 시각
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RestrictedUntil 거래가 막혀 있으면 해제 시각 (휴식과 자기 배제 중 늦은 쪽)
+func (l *UserTradingLimit) RestrictedUntil(now time.Time) *time.Time {
+	var until *time.Time
+	for _, t := range []*time.Time{l.CoolingOffUntil, l.SelfExcludedUntil} {
+		if t != nil && t.After(now) && (until == nil || t.After(*until)) {
+			until = t
+		}
+	}
+	return until
+}
+
+// UpdateTradingLimitsRequest 일일 한도 변경 (생략한 항목은 유지, 0이면 플랫폼 기본값으로)
+type UpdateTradingLimitsRequest struct {
+	DailyDepositLimit       *int64 `json:"daily_deposit_limit" binding:"omitempty,min=0"`
+	DailyOrderNotionalLimit *int64 `json:"daily_order_notional_limit" binding:"omitempty,min=0"`
+}
+
+// CoolingOffRequest 휴식 기간 설정 (최대 6주)
+type CoolingOffRequest struct {
+	Hours int `json:"hours" binding:"required,min=1,max=1008"`
+}
+
+// SelfExclusionRequest 자기 배제 설정 (최소 30일, 최대 5년)
+type SelfExclusionRequest struct {
+	Days int `json:"days" binding:"required,min=30,max=1825"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// TradingPauseScope 거래 중단 범위
+type TradingPauseScope string
+
+const (
+	TradingPauseGlobal TradingPauseScope = "global" // 전체 마켓 (점검 모드)
+	TradingPauseMarket TradingPauseScope = "market" // 특정 마일스톤 마켓
+)
+
+// TradingPause 관리자 거래 중단 (점검/마이그레이션)
+//
+// 서킷브레이커와 달리 사람이 걸고 푸는 중단이다. StartsAt이 미래면 예약 점검으로, 시각이 되면 스케줄러가
+// 중단을 적용하고 EndsAt에 자동으로 풀린다. EndsAt이 없으면 해제할 때까지 유지된다.
+// 중단 중에도 주문 취소는 허용한다.
+type TradingPause struct {
+	ID          uint              `json:"id" gorm:"primaryKey"`
+	Scope       TradingPauseScope `json:"scope" gorm:"size:20;not null;index"`
+	MilestoneID *uint             `json:"milestone_id,omitempty" gorm:"index"` // market 범위일 때만
+	Reason      string            `json:"reason" gorm:"size:500"`
+
+	StartsAt time.Time  `json:"starts_at" gorm:"not null;index"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"` // nil이면 해제할 때까지
+
+	CreatedBy *uint      `json:"created_by,omitempty"`
+	LiftedAt  *time.Time `json:"lifted_at,omitempty"` // 수동 해제 시각
+	LiftedBy  *uint      `json:"lifted_by,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ActiveAt 해당 시각에 적용 중인지
+func (p *TradingPause) ActiveAt(now time.Time) bool {
+	if p.LiftedAt != nil || now.Before(p.StartsAt) {
+		return false
+	}
+	return p.EndsAt == nil || now.Before(*p.EndsAt)
+}
+
+// CreateTradingPauseRequest 거래 중단 요청 (MilestoneID가 없으면 전체 중단, StartsAt이 없으면 즉시)
+type CreateTradingPauseRequest struct {
+	MilestoneID *uint      `json:"milestone_id"`
+	Reason      string     `json:"reason" binding:"required,max=500"`
+	StartsAt    *time.Time `json:"starts_at"`
+	EndsAt      *time.Time `json:"ends_at"`
+}
@@ -18,6 +18,13 @@ type User struct {
 	AIUsageCount int `json:"ai_usage_count" gorm:"default:0"` // 사용한 횟수
 	AIUsageLimit int `json:"ai_usage_limit" gorm:"default:5"` // 최대 사용 가능 횟수
 
+	// 신뢰 점수 (검증 신호 가중 합산, 0~100) 🛡️
+	TrustScore          int        `json:"trust_score" gorm:"default:0;index"`
+	TrustScoreUpdatedAt *time.Time `json:"-"` // 마지막 계산 시각 (이후 바뀐 검증 정보가 있으면 재계산)
+
+	// 다른 계정으로 병합되어 비활성화된 경우 병합 대상 (로그인 시 대상 계정으로 연결)
+	MergedIntoID *uint `json:"merged_into_id,omitempty" gorm:"index"`
+
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
@@ -58,6 +65,13 @@ type UserProfile struct {
 	ProfilePublic          bool `json:"profile_public" gorm:"default:true"`
 	InvestmentPublic       bool `json:"investment_public" gorm:"default:false"`
 
+	// 언어 설정 (이메일/알림 템플릿 선택: ko, en)
+	Locale string `json:"locale" gorm:"size:10;default:'ko'"`
+
+	// 알림 요약 메일 (포지션 변동, 마감 임박 마켓, 검증 대기 증거, 분쟁 기한)
+	DigestFrequency DigestFrequency `json:"digest_frequency" gorm:"size:10;default:'weekly'"`
+	DigestSentAt    *time.Time      `json:"digest_sent_at,omitempty"` // 마지막 요약 메일 발송 시각
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
@@ -76,6 +90,16 @@ const (
 	VerificationRejected   VerificationStatus = "rejected"
 )
 
+// DocumentScanStatus 제출 서류 악성코드 검사 상태 (서류가 없으면 빈 값)
+type DocumentScanStatus string
+
+const (
+	DocumentScanPending  DocumentScanStatus = "pending"  // 워커 검사 대기
+	DocumentScanClean    DocumentScanStatus = "clean"    // 검토 가능
+	DocumentScanInfected DocumentScanStatus = "infected" // 악성코드 감지 (격리, 열람 차단)
+	DocumentScanFailed   DocumentScanStatus = "failed"   // 해시 불일치/검사 실패 (열람 차단, 재제출 필요)
+)
+
 type UserVerification struct {
 	ID     uint `json:"id" gorm:"primaryKey"`
 	UserID uint `json:"user_id" gorm:"uniqueIndex;not null"`
@@ -84,6 +108,7 @@ type UserVerification struct {
 	EmailVerified   bool       `json:"email_verified" gorm:"default:false"`
 	EmailVerifiedAt *time.Time `json:"email_verified_at"`
 	PhoneVerified   bool       `json:"phone_verified" gorm:"default:false"`
+	PhoneNumber     string     `json:"-"` // 인증 완료된 휴대폰 번호 (SMS 알림 발송용)
 	PhoneVerifiedAt *time.Time `json:"phone_verified_at"`
 
 	// Level 2 - Social & Career
@@ -110,12 +135,26 @@ type UserVerification struct {
 	ProfessionalStatus   VerificationStatus `json:"professional_status" gorm:"default:'unverified'"`
 	ProfessionalTitle    string             `json:"professional_title" gorm:"size:120"`
 	ProfessionalDocPath  string             `json:"professional_doc_path"`
+	ProfessionalDocSHA256     string             `json:"-"`                                                     // 제출 시 해시 (워커가 저장본과 대조)
+	ProfessionalDocScanStatus DocumentScanStatus `json:"professional_doc_scan_status" gorm:"type:varchar(20)"` // 서류 악성코드 검사 (검토자 확인용)
+	ProfessionalDocScanDetail string             `json:"professional_doc_scan_detail,omitempty"`               // 감지 시그니처/실패 사유
+	ProfessionalDocScannedAt  *time.Time         `json:"professional_doc_scanned_at"`
 	ProfessionalVerifiedAt *time.Time       `json:"professional_verified_at"`
+	ProfessionalReviewedBy      *uint      `json:"professional_reviewed_by,omitempty"` // 승인/거절한 검토자
+	ProfessionalReviewedAt      *time.Time `json:"professional_reviewed_at"`
+	ProfessionalRejectionReason string     `json:"professional_rejection_reason,omitempty" gorm:"size:500"` // 거절 사유 (사용자에게 표시)
 
 	EducationStatus   VerificationStatus `json:"education_status" gorm:"default:'unverified'"`
 	EducationDegree   string             `json:"education_degree" gorm:"size:120"`
 	EducationDocPath  string             `json:"education_doc_path"`
+	EducationDocSHA256     string             `json:"-"`
+	EducationDocScanStatus DocumentScanStatus `json:"education_doc_scan_status" gorm:"type:varchar(20)"`
+	EducationDocScanDetail string             `json:"education_doc_scan_detail,omitempty"`
+	EducationDocScannedAt  *time.Time         `json:"education_doc_scanned_at"`
 	EducationVerifiedAt *time.Time       `json:"education_verified_at"`
+	EducationReviewedBy      *uint      `json:"education_reviewed_by,omitempty"` // 승인/거절한 검토자
+	EducationReviewedAt      *time.Time `json:"education_reviewed_at"`
+	EducationRejectionReason string     `json:"education_rejection_reason,omitempty" gorm:"size:500"` // 거절 사유 (사용자에게 표시)
 
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -124,6 +163,24 @@ type UserVerification struct {
 	User User `json:"-" gorm:"foreignKey:UserID"`
 }
 
+// ReviewVerificationDocRequest 전문 자격/학력 서류 심사 결과
+type ReviewVerificationDocRequest struct {
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason" binding:"max=500"`
+}
+
+// PendingVerificationDoc 심사 대기 서류 (검토자용)
+type PendingVerificationDoc struct {
+	UserID      uint               `json:"user_id"`
+	Username    string             `json:"username"`
+	DocType     string             `json:"doc_type"` // professional | education
+	Claim       string             `json:"claim"`    // 직함 또는 학위
+	ScanStatus  DocumentScanStatus `json:"scan_status"`
+	ScanDetail  string             `json:"scan_detail,omitempty"`
+	DocumentURL string             `json:"document_url,omitempty"` // 검사 통과한 서류만 서명 URL 발급
+	SubmittedAt time.Time          `json:"submitted_at"`
+}
+
 // 사용자 프로필 업데이트를 위한 요청 구조체
 type UpdateProfileRequest struct {
 	DisplayName  string `json:"display_name"`
@@ -138,11 +195,13 @@ type UpdateProfileRequest struct {
 
 // 설정 업데이트 요청
 type UpdatePreferencesRequest struct {
-	EmailNotifications     *bool `json:"email_notifications"`
-	PushNotifications      *bool `json:"push_notifications"`
-	MarketingNotifications *bool `json:"marketing_notifications"`
-	ProfilePublic          *bool `json:"profile_public"`
-	InvestmentPublic       *bool `json:"investment_public"`
+	EmailNotifications     *bool   `json:"email_notifications"`
+	PushNotifications      *bool   `json:"push_notifications"`
+	MarketingNotifications *bool   `json:"marketing_notifications"`
+	ProfilePublic          *bool   `json:"profile_public"`
+	InvestmentPublic       *bool   `json:"investment_public"`
+	Locale                 *string `json:"locale" binding:"omitempty,oneof=ko en"` // 이메일/알림 언어
+	DigestFrequency        *string `json:"digest_frequency" binding:"omitempty,oneof=off daily weekly"` // 알림 요약 메일 주기
 }
 
 // JWT 페이로드에 포함될 사용자 정보
@@ -161,6 +220,13 @@ type MagicLink struct {
 	IsUsed    bool      `json:"is_used" gorm:"default:false"`
 	UserID    *uint     `json:"user_id"` // 연결된 사용자 ID (있다면)
 
+	// 서명 토큰 식별자 해시 (토큰 원문은 이메일로만 전달)
+	TokenHash  string     `json:"-" gorm:"size:64;index"`
+	ConsumedAt *time.Time `json:"consumed_at,omitempty"`
+
+	// 가입 시 입력한 추천 코드 (신규 가입일 때만 사용)
+	ReferralCode string `json:"-" gorm:"type:varchar(16)"`
+
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
@@ -171,12 +237,43 @@ func (MagicLink) TableName() string {
 	return "magic_links"
 }
 
+// MagicLinkEventType 매직링크 감사 기록 종류
+type MagicLinkEventType string
+
+const (
+	MagicLinkEventIssued   MagicLinkEventType = "issued"   // 발급 (이메일 발송 요청)
+	MagicLinkEventConsumed MagicLinkEventType = "consumed" // 로그인에 사용됨
+	MagicLinkEventRejected MagicLinkEventType = "rejected" // 재사용/만료/다른 기기 등으로 거부
+)
+
+// MagicLinkEvent 매직링크 발급/사용 감사 기록
+type MagicLinkEvent struct {
+	ID          uint               `json:"id" gorm:"primaryKey"`
+	MagicLinkID uint               `json:"magic_link_id" gorm:"not null;index"`
+	Email       string             `json:"email" gorm:"not null;index"`
+	Event       MagicLinkEventType `json:"event" gorm:"type:varchar(20);not null"`
+	Reason      string             `json:"reason,omitempty" gorm:"type:varchar(50)"` // 거부 사유 (replayed, expired, device_mismatch)
+	IPAddress   string             `json:"ip_address" gorm:"type:varchar(45)"`
+	UserAgent   string             `json:"user_agent" gorm:"type:varchar(500)"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+// TableName GORM 테이블명 설정
+func (MagicLinkEvent) TableName() string {
+	return "magic_link_events"
+}
+
 // 매직링크 생성 요청
 type CreateMagicLinkRequest struct {
-	Email string `json:"email" binding:"required,email"`
+	Email        string `json:"email" binding:"required,email"`
+	ReferralCode string `json:"referral_code" binding:"omitempty,max=16"` // 신규 가입 시 추천 코드
+	DeviceID     string `json:"device_id" binding:"omitempty,max=128"`    // 기기 바인딩용 클라이언트 식별자 (선택)
 }
 
-// 매직링크 인증 요청
+// 매직링크 인증 요청 (이메일 링크의 token 또는 6자리 code 중 하나)
 type VerifyMagicLinkRequest struct {
-	Code string `json:"code" binding:"required,len=6"`
+	Token    string `json:"token" binding:"required_without=Code,omitempty,max=256"`
+	Code     string `json:"code" binding:"required_without=Token,omitempty,len=6"`
+	Email    string `json:"email" binding:"required_with=Code,omitempty,email"` // 코드는 받은 이메일과 함께만 사용
+	DeviceID string `json:"device_id" binding:"omitempty,max=128"`
 }
@@ -33,6 +33,26 @@ const (
 	ProofStatusDisputed  ProofStatus = "disputed"  // 분쟁 중
 )
 
+// PrescreenStatus AI 사전 검토 상태
+type PrescreenStatus string
+
+const (
+	PrescreenStatusPending   PrescreenStatus = "pending"   // 검토 대기
+	PrescreenStatusCompleted PrescreenStatus = "completed" // 검토 완료 (점수 산출)
+	PrescreenStatusFailed    PrescreenStatus = "failed"    // 검토 실패 (점수 없음)
+)
+
+// ProofFileStatus 업로드 증거 파일 처리 상태 (파일이 없는 증거는 빈 값)
+type ProofFileStatus string
+
+const (
+	ProofFileStatusPending  ProofFileStatus = "pending"  // 워커 처리 대기
+	ProofFileStatusReady    ProofFileStatus = "ready"    // 검사 통과, 미리보기 생성 완료
+	ProofFileStatusRejected ProofFileStatus = "rejected" // 확장자와 내용 형식 불일치 등 허용되지 않는 파일
+	ProofFileStatusInfected ProofFileStatus = "infected" // 악성코드 감지 (파일 격리)
+	ProofFileStatusFailed   ProofFileStatus = "failed"   // 처리 실패
+)
+
 // MilestoneVerificationStatus 마일스톤 검증 상태
 type MilestoneVerificationStatus string
 
@@ -68,6 +88,34 @@ func (pm *ProofMetadata) Scan(value interface{}) error {
 	return json.Unmarshal(bytes, pm)
 }
 
+// StringList 문자열 목록 (JSON 형태로 저장)
+type StringList []string
+
+// Value implements driver.Valuer for database storage
+func (sl StringList) Value() (driver.Value, error) {
+	return json.Marshal(sl)
+}
+
+// Scan implements sql.Scanner for database retrieval
+func (sl *StringList) Scan(value interface{}) error {
+	if value == nil {
+		*sl = nil
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, sl)
+}
+
 // MilestoneProof 마일스톤 증거 제출
 type MilestoneProof struct {
 	ID          uint      `json:"id" gorm:"primaryKey"`
@@ -89,6 +137,20 @@ type MilestoneProof struct {
 	Status       ProofStatus `json:"status" gorm:"default:'submitted'"`
 	SubmittedAt  time.Time   `json:"submitted_at" gorm:"default:CURRENT_TIMESTAMP"`
 	ReviewDeadline time.Time `json:"review_deadline"` // 검증 마감일 (제출 후 72시간)
+
+	// 🤖 AI 사전 검토 (검증인 대기열 우선순위용, 최종 판정에는 사용하지 않음)
+	PrescreenStatus PrescreenStatus `json:"prescreen_status" gorm:"type:varchar(20);default:'pending'"`
+	PrescreenScore  *float64        `json:"prescreen_score,omitempty"`                    // 기계 신뢰도 (0-1)
+	PrescreenReport ProofMetadata   `json:"prescreen_report,omitempty" gorm:"type:jsonb"` // 항목별 점검 결과
+	PrescreenedAt   *time.Time      `json:"prescreened_at,omitempty"`
+
+	// 📎 업로드 파일 처리 결과 (워커: 형식 확인 → 악성코드 검사 → EXIF 제거/썸네일/PDF 미리보기)
+	FileStatus      ProofFileStatus `json:"file_status,omitempty" gorm:"type:varchar(20)"`
+	FileContentType string          `json:"file_content_type,omitempty"` // 내용에서 감지한 형식 (클라이언트 헤더 무시)
+	ThumbnailURL    string          `json:"thumbnail_url,omitempty"`
+	PreviewURLs     StringList      `json:"preview_urls,omitempty" gorm:"type:jsonb"` // PDF 페이지 미리보기 이미지
+	FileReport      ProofMetadata   `json:"file_report,omitempty" gorm:"type:jsonb"`  // 단계별 처리 결과
+	FileProcessedAt *time.Time      `json:"file_processed_at,omitempty"`
 	
 	// 통계
 	TotalValidators int `json:"total_validators" gorm:"default:0"` // 총 검증인 수
@@ -288,6 +350,7 @@ type SubmitProofRequest struct {
 	ProofType   ProofType `json:"proof_type" binding:"required"`
 	Title       string    `json:"title" binding:"required"`
 	Description string    `json:"description"`
+	FileURL     string    `json:"file_url,omitempty"` // POST /verification/upload 응답의 file_url
 	ExternalURL string    `json:"external_url,omitempty"`
 	APIData     ProofMetadata `json:"api_data,omitempty"`
 	Metadata    ProofMetadata `json:"metadata,omitempty"`
@@ -0,0 +1,82 @@
+package models
+
+import "time"
+
+// WalletAccount 설정으로 추가한 결제 통화 잔액 (사용자 × 통화 1행, usdc/blueprint는 UserWallet 컬럼)
+type WalletAccount struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	UserID    uint           `json:"user_id" gorm:"not null;uniqueIndex:idx_wallet_account_user_currency"`
+	Currency  LedgerCurrency `json:"currency" gorm:"type:varchar(16);not null;uniqueIndex:idx_wallet_account_user_currency"`
+	Available int64          `json:"available" gorm:"not null;default:0"` // 사용 가능 잔액 (1/100 단위)
+	Locked    int64          `json:"locked" gorm:"not null;default:0"`    // 주문 등으로 잠긴 잔액
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// ExchangeRate 통화 환율 (1 통화 = Rate USDC, USDC는 항상 1이라 저장하지 않음)
+// 결제 통화는 1/100 단위 금액끼리, BLUEPRINT는 토큰 1개 단위로 환산한다.
+type ExchangeRate struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	Currency  LedgerCurrency `json:"currency" gorm:"type:varchar(16);not null;uniqueIndex"`
+	Rate      float64        `json:"rate" gorm:"not null"`
+	UpdatedBy uint           `json:"updated_by"` // 마지막으로 환율을 지정한 관리자
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// WalletBalance 통화별 잔액 (지갑 계정 목록 응답)
+type WalletBalance struct {
+	Currency  LedgerCurrency `json:"currency"`
+	Available int64          `json:"available"`
+	Locked    int64          `json:"locked"`
+	Tradable  bool           `json:"tradable"` // 마켓 결제 통화로 쓸 수 있음 (blueprint 제외)
+}
+
+// CurrencyConversion 환전 견적/결과 (금액은 각 통화의 잔액 단위)
+type CurrencyConversion struct {
+	SwapID         uint           `json:"swap_id,omitempty"` // 실행된 환전 기록 (견적은 0)
+	From           LedgerCurrency `json:"from"`
+	To             LedgerCurrency `json:"to"`
+	Amount         int64          `json:"amount"`          // 보내는 금액
+	Converted      int64          `json:"converted"`       // 받는 금액 (내림)
+	Rate           float64        `json:"rate"`            // 보내는 통화 1단위당 받는 통화 단위
+	USDCValue      int64          `json:"usdc_value"`      // 보내는 금액의 USDC 환산 (센트, 일일 한도 집계)
+	DailyRemaining int64          `json:"daily_remaining"` // 이 환전 후 오늘 남은 한도 (센트)
+}
+
+// CurrencySwap 지갑 통화 간 환전 기록 (두 원장 항목의 참조, 일일 한도 집계 대상)
+type CurrencySwap struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	UserID    uint           `json:"user_id" gorm:"not null;index:idx_currency_swap_user_created"`
+	From      LedgerCurrency `json:"from" gorm:"column:from_currency;type:varchar(16);not null"`
+	To        LedgerCurrency `json:"to" gorm:"column:to_currency;type:varchar(16);not null"`
+	Amount    int64          `json:"amount" gorm:"not null"`
+	Converted int64          `json:"converted" gorm:"not null"`
+	Rate      float64        `json:"rate" gorm:"not null"`
+	USDCValue int64          `json:"usdc_value" gorm:"not null"` // 보내는 금액의 USDC 환산 (센트)
+	CreatedAt time.Time      `json:"created_at" gorm:"index:idx_currency_swap_user_created"`
+}
+
+// WalletHistoryQuery 지갑 원장 내역 조회 조건
+type WalletHistoryQuery struct {
+	Currency  LedgerCurrency  `form:"currency"`   // 비우면 전체 통화
+	EntryType LedgerEntryType `form:"entry_type"` // 비우면 전체 종류
+	Before    uint            `form:"before"`     // 이 ID보다 오래된 항목 (페이지 넘김)
+	Limit     int             `form:"limit"`      // 기본 50, 최대 200
+}
+
+// ConvertCurrencyRequest 지갑 계정 간 환전 요청
+type ConvertCurrencyRequest struct {
+	From   LedgerCurrency `json:"from" binding:"required"`
+	To     LedgerCurrency `json:"to" binding:"required"`
+	Amount int64          `json:"amount" binding:"required,min=1"`
+}
+
+// SetExchangeRateRequest 관리자 환율 지정 요청
+type SetExchangeRateRequest struct {
+	Rate float64 `json:"rate" binding:"required,gt=0"`
+}
+
+// SetQuoteCurrencyRequest 관리자 마켓 결제 통화 지정 요청
+type SetQuoteCurrencyRequest struct {
+	Currency LedgerCurrency `json:"currency" binding:"required"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// ProjectWatch 프로젝트 팔로우 (관심 목록)
+type ProjectWatch struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_project_watch_user_project"`
+	ProjectID uint      `json:"project_id" gorm:"not null;uniqueIndex:idx_project_watch_user_project;index"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// 관계
+	Project Project `json:"project,omitempty" gorm:"foreignKey:ProjectID"`
+}
+
+// ProjectEventType 팔로워에게 전파되는 프로젝트 이벤트 종류
+type ProjectEventType string
+
+const (
+	ProjectEventProofSubmitted     ProjectEventType = "proof_submitted"     // 마일스톤 증거 제출
+	ProjectEventMarketMoved        ProjectEventType = "market_moved"        // 마켓 가격 10% 이상 변동
+	ProjectEventMilestoneCompleted ProjectEventType = "milestone_completed" // 마일스톤 완료 (검증 승인)
+)
+
+// ProjectEvent 프로젝트 이벤트 (워커가 팔로워 알림함으로 전파)
+type ProjectEvent struct {
+	Type        ProjectEventType       `json:"event"`
+	ProjectID   uint                   `json:"project_id"`
+	MilestoneID uint                   `json:"milestone_id,omitempty"`
+	ActorID     uint                   `json:"actor_id,omitempty"` // 이벤트 발생자 (알림 제외)
+	Title       string                 `json:"title"`
+	Message     string                 `json:"message"`
+	Link        string                 `json:"link"`
+	Data        map[string]interface{} `json:"data,omitempty"`
+}
+
+// ProjectWatchStatus 프로젝트 팔로우 상태 응답
+type ProjectWatchStatus struct {
+	ProjectID     uint  `json:"project_id"`
+	Watching      bool  `json:"watching"`
+	FollowerCount int64 `json:"follower_count"`
+}
+
+// WatchlistResponse 내 관심 프로젝트 목록 응답
+type WatchlistResponse struct {
+	Watches []ProjectWatch `json:"watches"`
+	Total   int            `json:"total"`
+}
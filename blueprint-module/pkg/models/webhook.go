@@ -0,0 +1,126 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// WebhookEventType 외부로 발송되는 플랫폼 이벤트 종류
+type WebhookEventType string
+
+const (
+	WebhookEventTradeExecuted      WebhookEventType = "trade.executed"      // 체결 발생
+	WebhookEventMilestoneResolved  WebhookEventType = "milestone.resolved"  // 마일스톤 완료/실패 확정
+	WebhookEventArbitrationDecided WebhookEventType = "arbitration.decided" // 분쟁 판결
+	WebhookEventSlashApproved      WebhookEventType = "slash.approved"      // 멘토 슬래싱 승인
+	WebhookEventPing               WebhookEventType = "ping"                // 연결 테스트
+)
+
+// ValidWebhookEventTypes 구독 가능한 이벤트 목록
+var ValidWebhookEventTypes = []WebhookEventType{
+	WebhookEventTradeExecuted,
+	WebhookEventMilestoneResolved,
+	WebhookEventArbitrationDecided,
+	WebhookEventSlashApproved,
+}
+
+// WebhookDeliveryStatus 발송 상태
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"   // 발송 대기
+	WebhookDeliveryRetrying  WebhookDeliveryStatus = "retrying"  // 실패 후 재시도 대기
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded" // 2xx 응답 수신
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"    // 재시도 한도 초과
+)
+
+// WebhookEndpoint 외부 웹훅 수신 주소 (사용자 단위 또는 프로젝트 단위)
+//
+// ProjectID가 없으면 구독자 본인이 관련된 이벤트(체결, 보유 마일스톤 확정, 분쟁 당사자, 슬래싱)를,
+// 있으면 해당 프로젝트의 모든 이벤트를 받는다.
+type WebhookEndpoint struct {
+	ID        uint  `json:"id" gorm:"primaryKey"`
+	UserID    uint  `json:"user_id" gorm:"not null;index"`
+	ProjectID *uint `json:"project_id,omitempty" gorm:"index"`
+
+	URL         string `json:"url" gorm:"size:500;not null"`
+	Description string `json:"description" gorm:"size:200"`
+	Events      string `json:"events" gorm:"size:200;not null"` // 쉼표 구분 (trade.executed,milestone.resolved,...)
+
+	// 발송 시 서명에 원문이 필요하므로 서버 키로 암호화하여 보관
+	EncryptedSecret string `json:"-" gorm:"type:text;not null"`
+
+	Active              bool       `json:"active" gorm:"default:true;index"`
+	ConsecutiveFailures int        `json:"consecutive_failures" gorm:"default:0"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"` // 연속 실패로 자동 비활성화된 시각
+	LastDeliveryAt      *time.Time `json:"last_delivery_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// 외래키 참조
+	User User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// HasEvent 이벤트 구독 여부 (ping은 항상 허용)
+func (e *WebhookEndpoint) HasEvent(event WebhookEventType) bool {
+	if event == WebhookEventPing {
+		return true
+	}
+	for _, ev := range strings.Split(e.Events, ",") {
+		if WebhookEventType(strings.TrimSpace(ev)) == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery 웹훅 발송 기록 (재시도마다 갱신)
+type WebhookDelivery struct {
+	ID         uint             `json:"id" gorm:"primaryKey"`
+	EndpointID uint             `json:"endpoint_id" gorm:"not null;index"`
+	EventID    string           `json:"event_id" gorm:"size:64;not null;index"` // 수신측 중복 제거용 (X-Blueprint-Event-ID)
+	EventType  WebhookEventType `json:"event_type" gorm:"size:50;not null"`
+	Payload    string           `json:"payload" gorm:"type:text;not null"`
+
+	Status         WebhookDeliveryStatus `json:"status" gorm:"size:20;not null;default:'pending';index"`
+	Attempts       int                   `json:"attempts" gorm:"default:0"`
+	ResponseStatus int                   `json:"response_status,omitempty"`
+	ResponseBody   string                `json:"response_body,omitempty" gorm:"type:text"` // 앞부분만 저장
+	LastError      string                `json:"last_error,omitempty" gorm:"type:text"`
+	NextRetryAt    *time.Time            `json:"next_retry_at,omitempty"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookPayload 발송 본문 (HMAC 서명 대상)
+type WebhookPayload struct {
+	ID        string                 `json:"id"` // 이벤트 ID (같은 이벤트의 재시도는 동일)
+	Type      WebhookEventType       `json:"type"`
+	CreatedAt time.Time              `json:"created_at"`
+	Data      map[string]interface{} `json:"data"`
+}
+
+// CreateWebhookEndpointRequest 웹훅 등록 요청
+type CreateWebhookEndpointRequest struct {
+	URL         string             `json:"url" binding:"required,url"`
+	ProjectID   *uint              `json:"project_id"` // 비우면 사용자 단위
+	Events      []WebhookEventType `json:"events" binding:"required,min=1"`
+	Description string             `json:"description"`
+}
+
+// UpdateWebhookEndpointRequest 웹훅 수정 요청 (active=true면 자동 비활성화 해제)
+type UpdateWebhookEndpointRequest struct {
+	URL         *string            `json:"url" binding:"omitempty,url"`
+	Events      []WebhookEventType `json:"events"`
+	Description *string            `json:"description"`
+	Active      *bool              `json:"active"`
+}
+
+// WebhookEndpointSecretResponse 서명 secret 응답 (생성/재발급 시 한 번만 반환)
+type WebhookEndpointSecretResponse struct {
+	Endpoint *WebhookEndpoint `json:"endpoint"`
+	Secret   string           `json:"secret"`
+}
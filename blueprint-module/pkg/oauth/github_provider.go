@@ -0,0 +1,164 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"blueprint-module/pkg/config"
+)
+
+// GitHubProvider GitHub OAuth 제공업체
+type GitHubProvider struct {
+	config config.GitHubOAuthConfig
+	client *http.Client
+}
+
+// NewGitHubProvider GitHub 제공업체 생성
+func NewGitHubProvider(cfg config.GitHubOAuthConfig) *GitHubProvider {
+	return &GitHubProvider{
+		config: cfg,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// GetProviderName 제공업체 이름 반환
+func (p *GitHubProvider) GetProviderName() string {
+	return "github"
+}
+
+// ValidateConfig 설정 유효성 검사
+func (p *GitHubProvider) ValidateConfig() error {
+	if p.config.ClientID == "" {
+		return fmt.Errorf("github client_id is required")
+	}
+	if p.config.ClientSecret == "" {
+		return fmt.Errorf("github client_secret is required")
+	}
+	if p.config.RedirectURL == "" {
+		return fmt.Errorf("github redirect_url is required")
+	}
+	return nil
+}
+
+// GetAuthURL 인증 URL 생성
+func (p *GitHubProvider) GetAuthURL(state string) string {
+	scopes := p.config.Scopes
+	if scopes == "" {
+		scopes = "read:user user:email"
+	}
+
+	params := map[string]string{
+		"client_id":    p.config.ClientID,
+		"redirect_uri": p.config.RedirectURL,
+		"scope":        scopes,
+		"state":        state,
+	}
+
+	return BuildURL("https://github.com/login/oauth/authorize", params)
+}
+
+// ExchangeCode authorization code를 access token으로 교환
+func (p *GitHubProvider) ExchangeCode(ctx context.Context, code string) (*TokenResponse, error) {
+	tokenURL := "https://github.com/login/oauth/access_token"
+
+	data := url.Values{}
+	data.Set("code", code)
+	data.Set("redirect_uri", p.config.RedirectURL)
+	data.Set("client_id", p.config.ClientID)
+	data.Set("client_secret", p.config.ClientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("github token exchange failed %d: %s", resp.StatusCode, string(body))
+	}
+
+	// GitHub은 실패 시에도 200과 함께 error 필드를 반환
+	var tokenResp struct {
+		TokenResponse
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if tokenResp.Error != "" {
+		return nil, fmt.Errorf("github token exchange failed: %s (%s)", tokenResp.Error, tokenResp.ErrorDescription)
+	}
+
+	return &tokenResp.TokenResponse, nil
+}
+
+// GetUserProfile access token으로 사용자 프로필 정보 조회
+func (p *GitHubProvider) GetUserProfile(ctx context.Context, accessToken string) (*UserProfile, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.github.com/user", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch profile: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("github user API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	var profile gitHubUser
+	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
+		return nil, fmt.Errorf("failed to parse github profile: %w", err)
+	}
+
+	displayName := profile.Name
+	if displayName == "" {
+		displayName = profile.Login
+	}
+
+	return &UserProfile{
+		ID:          strconv.FormatInt(profile.ID, 10),
+		Email:       profile.Email,
+		DisplayName: displayName,
+		ProfileURL:  profile.HTMLURL,
+		Avatar:      profile.AvatarURL,
+		Provider:    "github",
+		RawData:     map[string]interface{}{"login": profile.Login},
+	}, nil
+}
+
+// GitHub API 응답 구조체
+type gitHubUser struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	HTMLURL   string `json:"html_url"`
+	AvatarURL string `json:"avatar_url"`
+}
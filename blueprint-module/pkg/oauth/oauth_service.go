@@ -50,8 +50,12 @@ func (s *OAuthService) registerProviders() {
 		s.factory.Register("linkedin", linkedinProvider)
 	}
 
+	// GitHub 제공업체 등록 (저장소 웹훅 연동에도 사용)
+	if s.config.GitHub.ClientID != "" {
+		s.factory.Register("github", NewGitHubProvider(s.config.GitHub))
+	}
+
 	// TODO: 다른 제공업체들도 여기에 추가
-	// s.factory.Register("github", NewGitHubProvider(s.config.GitHub))
 	// s.factory.Register("twitter", NewTwitterProvider(s.config.Twitter))
 }
 
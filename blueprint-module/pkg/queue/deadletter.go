@@ -0,0 +1,246 @@
+package queue
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/redis"
+
+	redislib "github.com/redis/go-redis/v9"
+)
+
+// ErrDeadLetterNotFound DLQ에 없는 항목
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// dlqSuffix DeadLetterQueueName이 붙이는 접미사
+const dlqSuffix = ":dlq"
+
+// DeadLetterKind DLQ 항목 종류
+type DeadLetterKind string
+
+const (
+	DeadLetterJob   DeadLetterKind = "job"   // 작업 큐(job_data, 워커 ConsumeJobs*)
+	DeadLetterEvent DeadLetterKind = "event" // 이벤트 큐(event, Consumer)
+)
+
+// DeadLetterQueueInfo 원래 큐별 DLQ 크기
+type DeadLetterQueueInfo struct {
+	Queue        string     `json:"queue"` // 원래 큐 이름 (DLQ 스트림은 Queue + ":dlq")
+	Size         int64      `json:"size"`
+	OldestFailed *time.Time `json:"oldest_failed_at,omitempty"`
+}
+
+// DeadLetter DLQ에 쌓인 실패 항목 1건
+type DeadLetter struct {
+	ID        string          `json:"id"` // DLQ 스트림 메시지 ID
+	Queue     string          `json:"queue"`
+	Kind      DeadLetterKind  `json:"kind"`
+	Type      string          `json:"type,omitempty"`
+	JobID     string          `json:"job_id,omitempty"` // 추적 중인 작업이면 jobs.id
+	Retries   int             `json:"retries"`
+	LastError string          `json:"last_error,omitempty"`
+	FailedAt  time.Time       `json:"failed_at"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// DeadLetterQueues DLQ가 있는 모든 큐와 크기 (비어 있는 DLQ는 스트림이 없으므로 빠진다)
+func DeadLetterQueues() ([]DeadLetterQueueInfo, error) {
+	client := redis.GetClient()
+	if client == nil {
+		return nil, fmt.Errorf("redis client is not available")
+	}
+
+	var streams []string
+	iter := client.Scan(ctx, 0, "*"+dlqSuffix, 100).Iterator()
+	for iter.Next(ctx) {
+		streams = append(streams, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan dead letter queues: %w", err)
+	}
+	sort.Strings(streams)
+
+	infos := make([]DeadLetterQueueInfo, 0, len(streams))
+	for _, stream := range streams {
+		size, err := client.XLen(ctx, stream).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s length: %w", stream, err)
+		}
+		info := DeadLetterQueueInfo{Queue: strings.TrimSuffix(stream, dlqSuffix), Size: size}
+		if oldest, err := client.XRangeN(ctx, stream, "-", "+", 1).Result(); err == nil && len(oldest) == 1 {
+			failedAt := parseDeadLetter(info.Queue, oldest[0]).FailedAt
+			info.OldestFailed = &failedAt
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// ListDeadLetters 큐의 DLQ 항목을 최신순으로 limit개 조회 (before가 있으면 그 ID보다 오래된 것부터)
+func ListDeadLetters(queueName string, limit int64, before string) ([]DeadLetter, error) {
+	client := redis.GetClient()
+	if client == nil {
+		return nil, fmt.Errorf("redis client is not available")
+	}
+
+	end := "+"
+	if before != "" {
+		end = "(" + before
+	}
+	messages, err := client.XRevRangeN(ctx, DeadLetterQueueName(queueName), end, "-", limit).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dead letters of %s: %w", queueName, err)
+	}
+
+	letters := make([]DeadLetter, 0, len(messages))
+	for _, message := range messages {
+		letters = append(letters, parseDeadLetter(queueName, message))
+	}
+	return letters, nil
+}
+
+// GetDeadLetter DLQ 항목 1건
+func GetDeadLetter(queueName, id string) (*DeadLetter, error) {
+	client := redis.GetClient()
+	if client == nil {
+		return nil, fmt.Errorf("redis client is not available")
+	}
+
+	messages, err := client.XRange(ctx, DeadLetterQueueName(queueName), id, id).Result()
+	if err != nil {
+		// 잘못된 형식의 ID도 없는 항목으로 취급
+		if strings.Contains(err.Error(), "Invalid stream ID") {
+			return nil, fmt.Errorf("%w: %s", ErrDeadLetterNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to read dead letter %s: %w", id, err)
+	}
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrDeadLetterNotFound, id)
+	}
+	letter := parseDeadLetter(queueName, messages[0])
+	return &letter, nil
+}
+
+// ReplayDeadLetter DLQ 항목을 재시도 횟수를 초기화해 원래 큐로 다시 발행하고 DLQ에서 제거
+//
+// 추적 중인 작업은 jobs 상태를 queued로 되돌린다. 재발행 후 제거 전에 실패하면 중복 처리될 수 있다.
+func ReplayDeadLetter(queueName, id string) error {
+	letter, err := GetDeadLetter(queueName, id)
+	if err != nil {
+		return err
+	}
+	client := redis.GetClient()
+
+	switch letter.Kind {
+	case DeadLetterJob:
+		var jobData map[string]interface{}
+		if err := json.Unmarshal(letter.Payload, &jobData); err != nil {
+			return fmt.Errorf("failed to decode job payload %s: %w", id, err)
+		}
+		delete(jobData, "retry_count")
+		delete(jobData, "last_error")
+		if letter.JobID != "" {
+			updateJob(letter.JobID, map[string]interface{}{
+				"status":      models.JobQueued,
+				"error":       "",
+				"finished_at": nil,
+			})
+		}
+		if err := PublishJob(queueName, jobData); err != nil {
+			return err
+		}
+	case DeadLetterEvent:
+		var event QueueEvent
+		if err := json.Unmarshal(letter.Payload, &event); err != nil {
+			return fmt.Errorf("failed to decode event payload %s: %w", id, err)
+		}
+		event.Retry = 0
+		eventData, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %w", err)
+		}
+		if err := client.XAdd(ctx, &redislib.XAddArgs{
+			Stream: queueName,
+			Values: map[string]interface{}{"event": string(eventData)},
+		}).Err(); err != nil {
+			return fmt.Errorf("failed to republish event to %s: %w", queueName, err)
+		}
+	}
+
+	return client.XDel(ctx, DeadLetterQueueName(queueName), id).Err()
+}
+
+// PurgeDeadLetters DLQ 항목 삭제 (ids가 비면 큐의 DLQ 전체), 삭제한 개수 반환
+func PurgeDeadLetters(queueName string, ids []string) (int64, error) {
+	client := redis.GetClient()
+	if client == nil {
+		return 0, fmt.Errorf("redis client is not available")
+	}
+
+	stream := DeadLetterQueueName(queueName)
+	if len(ids) > 0 {
+		removed, err := client.XDel(ctx, stream, ids...).Result()
+		if err != nil {
+			return 0, fmt.Errorf("failed to delete dead letters of %s: %w", queueName, err)
+		}
+		return removed, nil
+	}
+
+	size, err := client.XLen(ctx, stream).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s length: %w", stream, err)
+	}
+	if err := client.Del(ctx, stream).Err(); err != nil {
+		return 0, fmt.Errorf("failed to purge %s: %w", stream, err)
+	}
+	return size, nil
+}
+
+// parseDeadLetter 작업 큐(job_data)와 이벤트 큐(event) 양쪽 형식의 DLQ 메시지 해석
+func parseDeadLetter(queueName string, message redislib.XMessage) DeadLetter {
+	letter := DeadLetter{ID: message.ID, Queue: queueName}
+	if failedAt, ok := message.Values["failed_at"].(string); ok {
+		if unix, err := strconv.ParseInt(failedAt, 10, 64); err == nil {
+			letter.FailedAt = time.Unix(unix, 0)
+		}
+	}
+
+	if eventData, ok := message.Values["event"].(string); ok {
+		letter.Kind = DeadLetterEvent
+		letter.Payload = rawPayload(eventData)
+		letter.LastError, _ = message.Values["last_error"].(string)
+		var event QueueEvent
+		if json.Unmarshal([]byte(eventData), &event) == nil {
+			letter.Type = string(event.Type)
+			letter.Retries = event.Retry
+		}
+		return letter
+	}
+
+	jobDataStr, _ := message.Values["job_data"].(string)
+	letter.Kind = DeadLetterJob
+	letter.Payload = rawPayload(jobDataStr)
+	var jobData map[string]interface{}
+	if json.Unmarshal([]byte(jobDataStr), &jobData) == nil {
+		letter.Type = stringField(jobData, "type")
+		letter.JobID = stringField(jobData, JobIDField)
+		letter.LastError = stringField(jobData, "last_error")
+		letter.Retries = int(uintField(jobData, "retry_count"))
+	}
+	return letter
+}
+
+// rawPayload 깨진 본문은 JSON 문자열로 감싸 응답이 실패하지 않게 함
+func rawPayload(data string) json.RawMessage {
+	if json.Valid([]byte(data)) {
+		return json.RawMessage(data)
+	}
+	quoted, _ := json.Marshal(data)
+	return quoted
+}
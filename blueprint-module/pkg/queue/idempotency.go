@@ -0,0 +1,95 @@
+package queue
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	redislib "github.com/redis/go-redis/v9"
+)
+
+// IdempotencyKeyField 작업 데이터의 중복 처리 방지 키 (PublishJob이 없으면 채움, 같은 작업을 여러 번 발행해도 한 번만 처리하려면 호출 측이 지정)
+const IdempotencyKeyField = "idempotency_key"
+
+const (
+	// IdempotencyTTL 처리 완료 기록 보관 기간 (이 기간 안의 재전달/중복 발행은 건너뜀)
+	IdempotencyTTL = 7 * 24 * time.Hour
+	// idempotencyLease 처리 중 표시 유지 시간 (컨슈머가 죽으면 만료 후 다른 컨슈머가 처리)
+	idempotencyLease = 15 * time.Minute
+
+	idempotencyProcessing = "processing"
+	idempotencyDone       = "done"
+)
+
+// errMessageInFlight 같은 메시지를 다른 컨슈머가 처리 중
+var errMessageInFlight = errors.New("message is being processed by another consumer")
+
+// idempotencyRedisKey 컨슈머 그룹별 처리 기록 키 (같은 스트림을 읽는 그룹은 각자 한 번씩 처리)
+func idempotencyRedisKey(queueName, consumerGroup, key string) string {
+	return fmt.Sprintf("idempotency:%s:%s:%s", queueName, consumerGroup, key)
+}
+
+// claimMessage 부수 효과 전에 키를 선점
+//
+// true면 처리 진행, false면 이미 처리된 메시지라 건너뛴다. 다른 컨슈머가 처리 중이면 errMessageInFlight.
+// Redis 오류 시에는 처리를 막지 않는다 (중복 방지보다 유실 방지 우선).
+func claimMessage(client *redislib.Client, queueName, consumerGroup, key string) (bool, error) {
+	redisKey := idempotencyRedisKey(queueName, consumerGroup, key)
+	claimed, err := client.SetNX(ctx, redisKey, idempotencyProcessing, idempotencyLease).Result()
+	if err != nil {
+		log.Printf("⚠️ Idempotency check failed for %s, processing anyway: %v", redisKey, err)
+		return true, nil
+	}
+	if claimed {
+		return true, nil
+	}
+
+	state, err := client.Get(ctx, redisKey).Result()
+	if err == redislib.Nil {
+		// 그 사이 만료/해제됨, 다시 선점
+		return claimMessage(client, queueName, consumerGroup, key)
+	}
+	if err != nil {
+		log.Printf("⚠️ Idempotency check failed for %s, processing anyway: %v", redisKey, err)
+		return true, nil
+	}
+	if state == idempotencyDone {
+		return false, nil
+	}
+	return false, errMessageInFlight
+}
+
+// completeMessage 처리 완료 기록
+func completeMessage(client *redislib.Client, queueName, consumerGroup, key string) {
+	redisKey := idempotencyRedisKey(queueName, consumerGroup, key)
+	if err := client.Set(ctx, redisKey, idempotencyDone, IdempotencyTTL).Err(); err != nil {
+		log.Printf("⚠️ Failed to record processed message %s: %v", redisKey, err)
+	}
+}
+
+// releaseMessage 처리 실패 시 선점 해제 (재시도/DLQ 재발행이 다시 처리할 수 있게)
+func releaseMessage(client *redislib.Client, queueName, consumerGroup, key string) {
+	client.Del(ctx, idempotencyRedisKey(queueName, consumerGroup, key))
+}
+
+// jobIdempotencyKey 작업 데이터의 키 (없으면 스트림 메시지 ID로 같은 메시지의 재전달만 막음)
+func jobIdempotencyKey(jobData map[string]interface{}, messageID string) string {
+	if key := stringField(jobData, IdempotencyKeyField); key != "" {
+		return key
+	}
+	return messageID
+}
+
+// deferJob 다른 컨슈머가 처리 중인 작업을 재시도 횟수 증가 없이 나중에 다시 확인
+func deferJob(client *redislib.Client, queueName string, jobData map[string]interface{}, delay time.Duration) error {
+	jobBytes, err := json.Marshal(jobData)
+	if err != nil {
+		return err
+	}
+	return client.ZAdd(ctx, delayedQueueName(queueName), redislib.Z{
+		Score:  float64(time.Now().Add(delay).Unix()),
+		Member: string(jobBytes),
+	}).Err()
+}
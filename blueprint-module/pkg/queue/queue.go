@@ -68,10 +68,11 @@ type MarketMakeEventData struct {
 
 // UserCreatedEventData 회원가입 완료 이벤트 데이터
 type UserCreatedEventData struct {
-	UserID   uint   `json:"user_id"`
-	Email    string `json:"email"`
-	Username string `json:"username"`
-	Provider string `json:"provider"` // "local", "google"
+	UserID       uint   `json:"user_id"`
+	Email        string `json:"email"`
+	Username     string `json:"username"`
+	Provider     string `json:"provider"`                // "local", "google"
+	ReferralCode string `json:"referral_code,omitempty"` // 가입 시 입력한 추천 코드
 }
 
 // WalletCreateEventData 지갑 생성 이벤트 데이터
@@ -82,9 +83,10 @@ type WalletCreateEventData struct {
 
 // MarketInitEventData 마켓 초기화 이벤트 데이터
 type MarketInitEventData struct {
-	ProjectID   uint     `json:"project_id"`
-	MilestoneID uint     `json:"milestone_id"`
-	Options     []string `json:"options"`
+	ProjectID     uint               `json:"project_id"`
+	MilestoneID   uint               `json:"milestone_id"`
+	Options       []string           `json:"options"`
+	InitialPrices map[string]float64 `json:"initial_prices,omitempty"` // 옵션별 초기 확률 (생략 시 1/N 균등)
 }
 
 // WelcomeUserEventData 웰컴 처리 이벤트 데이터
@@ -125,7 +127,7 @@ func NewPublisher() *Publisher {
 // EnqueueTradeWork 거래 작업을 큐에 추가 (기존 PublishTradeEvent)
 func (p *Publisher) EnqueueTradeWork(milestoneID uint, optionID string, data TradeEventData) error {
 	event := QueueEvent{
-		ID:          fmt.Sprintf("trade_%d_%s_%d", milestoneID, optionID, time.Now().UnixNano()),
+		ID:          fmt.Sprintf("trade_%d", data.TradeID), // 체결당 한 번만 처리
 		Type:        EventTypeTrade,
 		MilestoneID: milestoneID,
 		OptionID:    optionID,
@@ -183,14 +185,15 @@ func (p *Publisher) EnqueueMarketMakeWork(milestoneID uint, optionID string, dat
 // EnqueueUserCreated 사용자 생성 후 처리 작업을 큐에 추가
 func (p *Publisher) EnqueueUserCreated(data UserCreatedEventData) error {
 	event := QueueEvent{
-		ID:       fmt.Sprintf("user_created_%d_%d", data.UserID, time.Now().UnixNano()),
+		ID:       fmt.Sprintf("user_created_%d", data.UserID), // 사용자당 한 번만 처리
 		Type:     EventTypeUserCreated,
 		UserID:   data.UserID,
 		Data: map[string]interface{}{
-			"user_id":  data.UserID,
-			"email":    data.Email,
-			"username": data.Username,
-			"provider": data.Provider,
+			"user_id":       data.UserID,
+			"email":         data.Email,
+			"username":      data.Username,
+			"provider":      data.Provider,
+			"referral_code": data.ReferralCode,
 		},
 		Timestamp: time.Now().Unix(),
 	}
@@ -201,7 +204,7 @@ func (p *Publisher) EnqueueUserCreated(data UserCreatedEventData) error {
 // EnqueueWalletCreate 지갑 생성 작업을 큐에 추가
 func (p *Publisher) EnqueueWalletCreate(data WalletCreateEventData) error {
 	event := QueueEvent{
-		ID:     fmt.Sprintf("wallet_create_%d_%d", data.UserID, time.Now().UnixNano()),
+		ID:     fmt.Sprintf("wallet_create_%d", data.UserID), // 지갑 조회마다 발행돼도 사용자당 한 번만 처리
 		Type:   EventTypeWalletCreate,
 		UserID: data.UserID,
 		Data: map[string]interface{}{
@@ -227,6 +230,9 @@ func (p *Publisher) EnqueueMarketInit(data MarketInitEventData) error {
 		},
 		Timestamp: time.Now().Unix(),
 	}
+	if len(data.InitialPrices) > 0 {
+		event.Data["initial_prices"] = data.InitialPrices
+	}
 
 	return p.publishEvent(QueueMarket, event)
 }
@@ -234,7 +240,7 @@ func (p *Publisher) EnqueueMarketInit(data MarketInitEventData) error {
 // EnqueueWelcomeUser 웰컴 처리 작업을 큐에 추가
 func (p *Publisher) EnqueueWelcomeUser(data WelcomeUserEventData) error {
 	event := QueueEvent{
-		ID:     fmt.Sprintf("welcome_user_%d_%d", data.UserID, time.Now().UnixNano()),
+		ID:     fmt.Sprintf("welcome_user_%d", data.UserID), // 사용자당 한 번만 처리
 		Type:   EventTypeWelcomeUser,
 		UserID: data.UserID,
 		Data: map[string]interface{}{
@@ -251,6 +257,10 @@ func (p *Publisher) EnqueueWelcomeUser(data WelcomeUserEventData) error {
 
 // publishEvent 내부 이벤트 발행 메서드
 func (p *Publisher) publishEvent(queueName string, event QueueEvent) error {
+	if p.client == nil {
+		return fmt.Errorf("failed to publish %s: redis client not connected", event.Type)
+	}
+
 	jsonData, err := json.Marshal(event)
 	if err != nil {
 		return fmt.Errorf("failed to marshal event: %v", err)
@@ -364,21 +374,32 @@ func (c *Consumer) handleMessage(queueName string, message redislib.XMessage, ha
 		return fmt.Errorf("failed to unmarshal event: %v", err)
 	}
 
+	// 재전달/중복 발행된 이벤트는 부수 효과 전에 걸러냄 (이벤트 ID 기준, 다른 컨슈머가 처리 중이면 그쪽에 맡김)
+	proceed, err := claimMessage(c.client, queueName, c.groupName, event.ID)
+	if err != nil || !proceed {
+		return c.client.XAck(ctx, queueName, c.groupName, message.ID).Err()
+	}
+
 	// 이벤트 처리
 	if err := handler(event); err != nil {
 		// log.Printf("❌ Handler error for event %s: %v", event.ID, err) // Original code had this line commented out
+		releaseMessage(c.client, queueName, c.groupName, event.ID)
 
-		// 재시도 로직 (3회까지)
-		if event.Retry < 3 {
+		// 재시도 로직 (공통 재시도 정책)
+		if DefaultRetryPolicy.ShouldRetry(event.Retry) {
 			event.Retry++
 			return c.retryEvent(queueName, event)
 		}
 
-		// 실패한 이벤트는 별도 큐로 이동
-		return c.moveToDeadLetterQueue(queueName, event)
+		// 실패한 이벤트는 별도 큐로 이동 (ACK 후 DLQ 도구로 조회/재처리)
+		if err := c.moveToDeadLetterQueue(queueName, event, err); err != nil {
+			return err
+		}
+		return c.client.XAck(ctx, queueName, c.groupName, message.ID).Err()
 	}
 
 	// 성공적으로 처리된 메시지 확인
+	completeMessage(c.client, queueName, c.groupName, event.ID)
 	return c.client.XAck(ctx, queueName, c.groupName, message.ID).Err()
 }
 
@@ -394,12 +415,18 @@ func (c *Consumer) retryEvent(queueName string, event QueueEvent) error {
 }
 
 // moveToDeadLetterQueue 실패한 이벤트를 데드레터 큐로 이동
-func (c *Consumer) moveToDeadLetterQueue(queueName string, event QueueEvent) error {
-	dlqName := fmt.Sprintf("%s:dlq", queueName)
+func (c *Consumer) moveToDeadLetterQueue(queueName string, event QueueEvent, handlerErr error) error {
+	eventData, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %v", err)
+	}
+
+	dlqName := DeadLetterQueueName(queueName)
 	return c.client.XAdd(ctx, &redislib.XAddArgs{
 		Stream: dlqName,
 		Values: map[string]interface{}{
-			"event":      event,
+			"event":      string(eventData),
+			"last_error": handlerErr.Error(),
 			"failed_at":  time.Now().Unix(),
 			"queue_name": queueName,
 		},
@@ -501,6 +528,20 @@ func PublishJob(queueName string, job map[string]interface{}) error {
 		return fmt.Errorf("redis client is not available")
 	}
 
+	// 중복 처리 방지 키가 없으면 발급 (재전달된 같은 작업은 컨슈머가 한 번만 처리)
+	if _, ok := job[IdempotencyKeyField]; !ok {
+		key, err := newJobID()
+		if err != nil {
+			return fmt.Errorf("failed to generate idempotency key: %w", err)
+		}
+		keyed := make(map[string]interface{}, len(job)+1)
+		for field, value := range job {
+			keyed[field] = value
+		}
+		keyed[IdempotencyKeyField] = key
+		job = keyed
+	}
+
 	// job을 JSON으로 직렬화
 	jobData, err := json.Marshal(job)
 	if err != nil {
@@ -526,6 +567,7 @@ func PublishJob(queueName string, job map[string]interface{}) error {
 
 // ConsumeJobs Redis Stream에서 작업을 소비 (워커용)
 func ConsumeJobs(queueName, consumerGroup, consumerName string, handler func(map[string]interface{}) error) error {
+	policy := DefaultRetryPolicy
 	client := redis.GetClient()
 	if client == nil {
 		return fmt.Errorf("redis client is not available")
@@ -538,6 +580,9 @@ func ConsumeJobs(queueName, consumerGroup, consumerName string, handler func(map
 	}
 
 	for {
+		// 재시도 시각이 된 작업 복귀
+		promoteDelayedJobs(client, queueName)
+
 		// 새로운 메시지 읽기
 		msgs, err := client.XReadGroup(ctx, &redislib.XReadGroupArgs{
 			Group:    consumerGroup,
@@ -554,27 +599,10 @@ func ConsumeJobs(queueName, consumerGroup, consumerName string, handler func(map
 			return fmt.Errorf("failed to read from stream: %w", err)
 		}
 
-		// 메시지 처리
+		// 메시지 처리 (실패 시 재시도 정책 적용)
 		for _, stream := range msgs {
 			for _, msg := range stream.Messages {
-				jobDataStr, ok := msg.Values["job_data"].(string)
-				if !ok {
-					continue
-				}
-
-				var jobData map[string]interface{}
-				if err := json.Unmarshal([]byte(jobDataStr), &jobData); err != nil {
-					continue
-				}
-
-				// 핸들러 실행
-				if err := handler(jobData); err != nil {
-					// 처리 실패 시 로그만 출력하고 계속
-					fmt.Printf("Failed to process job %s: %v\n", msg.ID, err)
-				}
-
-				// 메시지 ACK
-				client.XAck(ctx, queueName, consumerGroup, msg.ID)
+				processJobMessage(client, queueName, consumerGroup, msg, policy, handler)
 			}
 		}
 	}
@@ -597,6 +625,11 @@ func GetQueueLength(queueName string) (int64, error) {
 
 // ConsumeJobsWithContext Redis Stream에서 작업을 소비 (context 지원)
 func ConsumeJobsWithContext(ctx context.Context, queueName, consumerGroup, consumerName string, handler func(map[string]interface{}) error) error {
+	return ConsumeJobsWithPolicy(ctx, queueName, consumerGroup, consumerName, DefaultRetryPolicy, handler)
+}
+
+// ConsumeJobsWithPolicy 재시도 정책을 지정하여 작업 소비 (실패 작업은 지연 재시도 후 DLQ로 이동)
+func ConsumeJobsWithPolicy(ctx context.Context, queueName, consumerGroup, consumerName string, policy RetryPolicy, handler func(map[string]interface{}) error) error {
 	client := redis.GetClient()
 	if client == nil {
 		return fmt.Errorf("redis client is not available")
@@ -616,6 +649,9 @@ func ConsumeJobsWithContext(ctx context.Context, queueName, consumerGroup, consu
 		default:
 		}
 
+		// 재시도 시각이 된 작업 복귀
+		promoteDelayedJobs(client, queueName)
+
 		// 새로운 메시지 읽기
 		msgs, err := client.XReadGroup(ctx, &redislib.XReadGroupArgs{
 			Group:    consumerGroup,
@@ -636,27 +672,10 @@ func ConsumeJobsWithContext(ctx context.Context, queueName, consumerGroup, consu
 			return fmt.Errorf("failed to read from stream: %w", err)
 		}
 
-		// 메시지 처리
+		// 메시지 처리 (실패 시 재시도 정책 적용)
 		for _, stream := range msgs {
 			for _, msg := range stream.Messages {
-				jobDataStr, ok := msg.Values["job_data"].(string)
-				if !ok {
-					continue
-				}
-
-				var jobData map[string]interface{}
-				if err := json.Unmarshal([]byte(jobDataStr), &jobData); err != nil {
-					continue
-				}
-
-				// 핸들러 실행
-				if err := handler(jobData); err != nil {
-					// 처리 실패 시 로그만 출력하고 계속
-					fmt.Printf("Failed to process job %s: %v\n", msg.ID, err)
-				}
-
-				// 메시지 ACK
-				client.XAck(ctx, queueName, consumerGroup, msg.ID)
+				processJobMessage(client, queueName, consumerGroup, msg, policy, handler)
 			}
 		}
 	}
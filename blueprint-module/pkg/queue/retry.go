@@ -0,0 +1,156 @@
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	redislib "github.com/redis/go-redis/v9"
+)
+
+// RetryPolicy 실패 작업 재시도 및 데드레터 정책 (이벤트 큐/작업 큐 공통)
+type RetryPolicy struct {
+	MaxRetries int           // 최대 재시도 횟수 (초과 시 DLQ 이동)
+	BaseDelay  time.Duration // 지수 백오프 기준 지연
+	MaxDelay   time.Duration // 최대 지연
+}
+
+// DefaultRetryPolicy 기본 정책: 3회 재시도 (2s, 4s, 8s) 후 DLQ
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  2 * time.Second,
+	MaxDelay:   5 * time.Minute,
+}
+
+// ShouldRetry attempt번 재시도한 작업을 다시 시도할지 여부
+func (p RetryPolicy) ShouldRetry(attempt int) bool {
+	return attempt < p.MaxRetries
+}
+
+// Backoff attempt번째 재시도 전 대기 시간
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << uint(attempt)
+	if delay <= 0 || delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+// DeadLetterQueueName 데드레터 큐 이름
+func DeadLetterQueueName(queueName string) string {
+	return fmt.Sprintf("%s:dlq", queueName)
+}
+
+// delayedQueueName 지연 재시도 대기열 (sorted set, score = 실행 시각)
+func delayedQueueName(queueName string) string {
+	return fmt.Sprintf("%s:delayed", queueName)
+}
+
+// handleJobFailure 작업 실패 시 정책에 따라 지연 재시도 또는 DLQ 이동
+func handleJobFailure(client *redislib.Client, queueName string, jobData map[string]interface{}, handlerErr error, policy RetryPolicy) error {
+	attempt := 0
+	if retry, ok := jobData["retry_count"].(float64); ok {
+		attempt = int(retry)
+	}
+	jobData["last_error"] = handlerErr.Error()
+
+	if !policy.ShouldRetry(attempt) {
+		trackJobFailed(jobData, handlerErr, true)
+		jobBytes, err := json.Marshal(jobData)
+		if err != nil {
+			return err
+		}
+		log.Printf("☠️ Job moved to DLQ after %d retries (%s): %v", attempt, queueName, handlerErr)
+		return client.XAdd(ctx, &redislib.XAddArgs{
+			Stream: DeadLetterQueueName(queueName),
+			Values: map[string]interface{}{
+				"job_data":   string(jobBytes),
+				"failed_at":  time.Now().Unix(),
+				"queue_name": queueName,
+			},
+		}).Err()
+	}
+
+	trackJobFailed(jobData, handlerErr, false)
+	jobData["retry_count"] = attempt + 1
+	jobBytes, err := json.Marshal(jobData)
+	if err != nil {
+		return err
+	}
+
+	runAt := time.Now().Add(policy.Backoff(attempt))
+	return client.ZAdd(ctx, delayedQueueName(queueName), redislib.Z{
+		Score:  float64(runAt.Unix()),
+		Member: string(jobBytes),
+	}).Err()
+}
+
+// promoteDelayedJobs 실행 시각이 된 재시도 작업을 원래 스트림으로 복귀
+func promoteDelayedJobs(client *redislib.Client, queueName string) {
+	delayed := delayedQueueName(queueName)
+	due, err := client.ZRangeByScore(ctx, delayed, &redislib.ZRangeBy{
+		Min: "-inf",
+		Max: fmt.Sprintf("%d", time.Now().Unix()),
+	}).Result()
+	if err != nil || len(due) == 0 {
+		return
+	}
+
+	for _, job := range due {
+		// 다른 컨슈머가 먼저 가져간 경우 건너뜀
+		removed, err := client.ZRem(ctx, delayed, job).Result()
+		if err != nil || removed == 0 {
+			continue
+		}
+		client.XAdd(ctx, &redislib.XAddArgs{
+			Stream: queueName,
+			Values: map[string]interface{}{
+				"job_data":   job,
+				"created_at": time.Now().Unix(),
+			},
+		})
+	}
+}
+
+// processJobMessage 작업 메시지 1건 처리 (실패 시 재시도 정책 적용 후 ACK)
+func processJobMessage(client *redislib.Client, queueName, consumerGroup string, msg redislib.XMessage, policy RetryPolicy, handler func(map[string]interface{}) error) {
+	defer client.XAck(ctx, queueName, consumerGroup, msg.ID)
+
+	jobDataStr, ok := msg.Values["job_data"].(string)
+	if !ok {
+		return
+	}
+
+	var jobData map[string]interface{}
+	if err := json.Unmarshal([]byte(jobDataStr), &jobData); err != nil {
+		return
+	}
+
+	// 재전달/중복 발행된 작업은 부수 효과 전에 걸러냄 (다른 컨슈머가 처리 중이면 나중에 다시 확인)
+	key := jobIdempotencyKey(jobData, msg.ID)
+	proceed, err := claimMessage(client, queueName, consumerGroup, key)
+	if err != nil {
+		if err := deferJob(client, queueName, jobData, policy.Backoff(0)); err != nil {
+			log.Printf("❌ Failed to defer in-flight job %s: %v", msg.ID, err)
+		}
+		return
+	}
+	if !proceed {
+		log.Printf("⏭️ Skipping already processed job %s (%s)", key, queueName)
+		return
+	}
+
+	// 추적 중인 작업(job_id)은 처리 시작/성공/실패를 jobs 테이블에 반영
+	trackJobProcessing(jobData)
+	if err := handler(jobData); err != nil {
+		releaseMessage(client, queueName, consumerGroup, key)
+		log.Printf("❌ Failed to process job %s: %v", msg.ID, err)
+		if err := handleJobFailure(client, queueName, jobData, err, policy); err != nil {
+			log.Printf("❌ Failed to schedule retry for job %s: %v", msg.ID, err)
+		}
+		return
+	}
+	completeMessage(client, queueName, consumerGroup, key)
+	trackJobSucceeded(jobData)
+}
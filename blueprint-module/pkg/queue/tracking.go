@@ -0,0 +1,149 @@
+package queue
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+
+	"blueprint-module/pkg/database"
+	"blueprint-module/pkg/models"
+)
+
+// JobIDField 추적 중인 작업의 jobs.id 를 싣는 작업 데이터 키
+const JobIDField = "job_id"
+
+// maxJobErrorLength 저장할 실패 사유 최대 길이
+const maxJobErrorLength = 500
+
+// PublishTrackedJob jobs 행을 만든 뒤 작업 발행 (워커가 처리하며 상태를 갱신), 발급된 작업 ID 반환
+//
+// 발행에 실패하면 행을 failed로 남기고 오류를 돌려준다. DB가 없으면 추적 없이 발행만 한다.
+func PublishTrackedJob(queueName string, job map[string]interface{}) (string, error) {
+	db := database.GetDB()
+	if db == nil {
+		return "", PublishJob(queueName, job)
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate job id: %w", err)
+	}
+	record := &models.Job{
+		ID:     id,
+		Queue:  queueName,
+		Type:   stringField(job, "type"),
+		UserID: uintField(job, "user_id"),
+		Status: models.JobQueued,
+	}
+	if err := db.Create(record).Error; err != nil {
+		return "", fmt.Errorf("failed to record job: %w", err)
+	}
+
+	job[JobIDField] = id
+	if err := PublishJob(queueName, job); err != nil {
+		updateJob(id, map[string]interface{}{
+			"status":      models.JobFailed,
+			"error":       truncateJobError(err.Error()),
+			"finished_at": time.Now(),
+		})
+		return id, err
+	}
+	return id, nil
+}
+
+// trackJobProcessing 워커가 처리를 시작함
+func trackJobProcessing(jobData map[string]interface{}) {
+	id := stringField(jobData, JobIDField)
+	if id == "" {
+		return
+	}
+	now := time.Now()
+	updateJob(id, map[string]interface{}{
+		"status":     models.JobProcessing,
+		"attempts":   int(uintField(jobData, "retry_count")) + 1,
+		"started_at": now,
+	})
+}
+
+// trackJobSucceeded 처리 완료
+func trackJobSucceeded(jobData map[string]interface{}) {
+	id := stringField(jobData, JobIDField)
+	if id == "" {
+		return
+	}
+	updateJob(id, map[string]interface{}{
+		"status":      models.JobSucceeded,
+		"error":       "",
+		"finished_at": time.Now(),
+	})
+}
+
+// trackJobFailed 실패 기록 (재시도가 남았으면 queued로 되돌리고, DLQ로 가면 failed로 끝냄)
+func trackJobFailed(jobData map[string]interface{}, handlerErr error, deadLettered bool) {
+	id := stringField(jobData, JobIDField)
+	if id == "" {
+		return
+	}
+	updates := map[string]interface{}{
+		"status": models.JobQueued,
+		"error":  truncateJobError(handlerErr.Error()),
+	}
+	if deadLettered {
+		updates["status"] = models.JobFailed
+		updates["finished_at"] = time.Now()
+	}
+	updateJob(id, updates)
+}
+
+func updateJob(id string, updates map[string]interface{}) {
+	db := database.GetDB()
+	if db == nil {
+		return
+	}
+	if err := db.Model(&models.Job{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		log.Printf("⚠️ Failed to update job %s status: %v", id, err)
+	}
+}
+
+func newJobID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func truncateJobError(reason string) string {
+	if len(reason) > maxJobErrorLength {
+		return reason[:maxJobErrorLength]
+	}
+	return reason
+}
+
+func stringField(data map[string]interface{}, key string) string {
+	value, _ := data[key].(string)
+	return value
+}
+
+// uintField 발행 측(uint/int)과 JSON 디코딩 후(float64) 모두에서 정수 필드 읽기
+func uintField(data map[string]interface{}, key string) uint {
+	switch value := data[key].(type) {
+	case uint:
+		return value
+	case int:
+		if value > 0 {
+			return uint(value)
+		}
+	case int64:
+		if value > 0 {
+			return uint(value)
+		}
+	case float64:
+		if value > 0 {
+			return uint(value)
+		}
+	}
+	return 0
+}
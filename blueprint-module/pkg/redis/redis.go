@@ -107,8 +107,11 @@ func GetRecentTrades(milestoneID uint, optionID string, result interface{}) erro
 
 // 🚀 Real-time Broadcasting
 
-// BroadcastRealtimeUpdate 실시간 업데이트 브로드캐스트 (기존 PublishRealtimeNotification)
+// BroadcastRealtimeUpdate 실시간 업데이트 브로드캐스트 (기존 PublishRealtimeNotification, Redis 미연결 시 무시)
 func BroadcastRealtimeUpdate(channel string, event interface{}) error {
+	if Client == nil {
+		return nil
+	}
 	jsonData, err := json.Marshal(event)
 	if err != nil {
 		return err
@@ -123,8 +126,11 @@ func BroadcastTradeUpdate(milestoneID uint, optionID string, event interface{})
 	return BroadcastRealtimeUpdate(channel, event)
 }
 
-// BroadcastPriceChange 가격 변동 실시간 브로드캐스트 (기존 PublishPriceUpdate)
+// BroadcastPriceChange 가격 변동 실시간 브로드캐스트 (기존 PublishPriceUpdate, Redis 미연결 시 무시)
 func BroadcastPriceChange(milestoneID uint, optionID string, price float64) error {
+	if Client == nil {
+		return nil
+	}
 	channel := fmt.Sprintf("price_updates:%d:%s", milestoneID, optionID)
 	data := map[string]interface{}{
 		"milestone_id": milestoneID,
@@ -141,6 +147,19 @@ func BroadcastPriceChange(milestoneID uint, optionID string, price float64) erro
 	return Client.Publish(ctx, channel, jsonData).Err()
 }
 
+// UserEventsPattern 모든 사용자 비공개 이벤트 채널 (API 인스턴스마다 한 번 패턴 구독)
+const UserEventsPattern = "user_events:*"
+
+// UserEventsChannel 사용자 비공개 이벤트 채널
+func UserEventsChannel(userID uint) string {
+	return fmt.Sprintf("user_events:%d", userID)
+}
+
+// PublishUserEvent 사용자 비공개 이벤트 발행 (해당 사용자가 연결된 인스턴스의 /stream/me로 전달)
+func PublishUserEvent(userID uint, event interface{}) error {
+	return BroadcastRealtimeUpdate(UserEventsChannel(userID), event)
+}
+
 // 💾 Session Management
 
 // SetUserSession 사용자 세션 저장
@@ -171,6 +190,22 @@ func DeleteUserSession(sessionID string) error {
 	return Client.Del(ctx, key).Err()
 }
 
+// RevokeSession 세션 폐기 목록에 추가 (이미 발급된 액세스 토큰이 만료될 때까지 유지)
+func RevokeSession(sessionID string, ttl time.Duration) error {
+	key := fmt.Sprintf("session_revoked:%s", sessionID)
+	return Client.Set(ctx, key, 1, ttl).Err()
+}
+
+// IsSessionRevoked 폐기된 세션인지 확인 (Redis 미연결 시 false)
+func IsSessionRevoked(sessionID string) bool {
+	if Client == nil || sessionID == "" {
+		return false
+	}
+	key := fmt.Sprintf("session_revoked:%s", sessionID)
+	n, err := Client.Exists(ctx, key).Result()
+	return err == nil && n > 0
+}
+
 // 🛡️ Rate Limiting
 
 // CheckRateLimit API 요청 제한 확인
@@ -216,6 +251,188 @@ func GetActiveUsers(milestoneID uint) (int, error) {
 	return Client.Get(ctx, key).Int()
 }
 
+// 🧮 Incremental Stats
+
+// 통계 재계산 대상 범위 (stats:dirty:<scope> 집합에 바뀐 엔티티를 모음)
+const (
+	StatsScopeProjects    = "projects"    // 프로젝트 ID (마일스톤 위험 점수)
+	StatsScopeUsers       = "users"       // 사용자 ID (프로필 거래/검증/멘토 지표)
+	StatsScopeLeaderboard = "leaderboard" // 리더보드 종류 (traders/validators/mentors)
+)
+
+// MarkStatsDirty 통계를 다시 계산해야 하는 엔티티 표시 (Redis 미연결 시 무시, 주기 전체 재계산이 보정)
+func MarkStatsDirty(scope string, members ...interface{}) error {
+	if Client == nil || len(members) == 0 {
+		return nil
+	}
+	return Client.SAdd(ctx, "stats:dirty:"+scope, members...).Err()
+}
+
+// PopStatsDirty 재계산 대상을 최대 count개 꺼냄 (꺼낸 대상은 집합에서 빠지므로 실패 시 다시 표시)
+func PopStatsDirty(scope string, count int64) ([]string, error) {
+	if Client == nil {
+		return nil, nil
+	}
+	return Client.SPopN(ctx, "stats:dirty:"+scope, count).Result()
+}
+
+// 📦 Market View Versioning
+
+// IncrMarketSequence 시장 데이터 변경 시퀀스 증가 (ETag/델타 응답 기준)
+func IncrMarketSequence(milestoneID uint) (int64, error) {
+	key := fmt.Sprintf("market_seq:%d", milestoneID)
+	return Client.Incr(ctx, key).Result()
+}
+
+// GetMarketSequence 현재 시장 데이터 시퀀스 조회 (없으면 0)
+func GetMarketSequence(milestoneID uint) (int64, error) {
+	key := fmt.Sprintf("market_seq:%d", milestoneID)
+	seq, err := Client.Get(ctx, key).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return seq, err
+}
+
+// SetMarketView 현재 시장 뷰 캐싱 (짧은 TTL)
+func SetMarketView(milestoneID uint, data interface{}, ttl time.Duration) error {
+	key := fmt.Sprintf("market_view:%d", milestoneID)
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return Client.Set(ctx, key, jsonData, ttl).Err()
+}
+
+// GetMarketView 캐싱된 시장 뷰 조회
+func GetMarketView(milestoneID uint, result interface{}) error {
+	key := fmt.Sprintf("market_view:%d", milestoneID)
+	val, err := Client.Get(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal([]byte(val), result)
+}
+
+// SetMarketVersion 버전별 시장 스냅샷 저장 (델타 계산 기준)
+func SetMarketVersion(milestoneID uint, version string, data interface{}, ttl time.Duration) error {
+	key := fmt.Sprintf("market_version:%d:%s", milestoneID, version)
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return Client.Set(ctx, key, jsonData, ttl).Err()
+}
+
+// GetMarketVersion 버전별 시장 스냅샷 조회
+func GetMarketVersion(milestoneID uint, version string, result interface{}) error {
+	key := fmt.Sprintf("market_version:%d:%s", milestoneID, version)
+	val, err := Client.Get(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal([]byte(val), result)
+}
+
+// 🌐 Public Page Cache
+
+// SetPublicProject 공개 프로젝트 페이지 캐싱 (짧은 TTL)
+func SetPublicProject(projectID uint, data interface{}, ttl time.Duration) error {
+	key := fmt.Sprintf("public_project:%d", projectID)
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return Client.Set(ctx, key, jsonData, ttl).Err()
+}
+
+// GetPublicProject 캐싱된 공개 프로젝트 페이지 조회
+func GetPublicProject(projectID uint, result interface{}) error {
+	key := fmt.Sprintf("public_project:%d", projectID)
+	val, err := Client.Get(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal([]byte(val), result)
+}
+
+// DeletePublicProject 공개 프로젝트 페이지 캐시 삭제 (프로젝트 수정/삭제 시)
+func DeletePublicProject(projectID uint) error {
+	return Client.Del(ctx, fmt.Sprintf("public_project:%d", projectID)).Err()
+}
+
+// SetPublicProjectList 공개 프로젝트 목록 캐싱 (조회 조건별)
+func SetPublicProjectList(query string, data interface{}, ttl time.Duration) error {
+	key := "public_projects:" + query
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return Client.Set(ctx, key, jsonData, ttl).Err()
+}
+
+// GetPublicProjectList 캐싱된 공개 프로젝트 목록 조회
+func GetPublicProjectList(query string, result interface{}) error {
+	val, err := Client.Get(ctx, "public_projects:"+query).Result()
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal([]byte(val), result)
+}
+
+// SetPlatformStats 플랫폼 지표 캐싱
+func SetPlatformStats(data interface{}, ttl time.Duration) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return Client.Set(ctx, "platform_stats", jsonData, ttl).Err()
+}
+
+// GetPlatformStats 캐싱된 플랫폼 지표 조회
+func GetPlatformStats(result interface{}) error {
+	val, err := Client.Get(ctx, "platform_stats").Result()
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal([]byte(val), result)
+}
+
+// 🚩 Feature Flags
+
+// featureFlagsKey 모든 서버가 공유하는 기능 플래그 스냅샷
+const featureFlagsKey = "feature_flags"
+
+// SetFeatureFlags 기능 플래그 스냅샷 저장 (만료 없음, 변경 시 덮어씀)
+func SetFeatureFlags(data interface{}) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return Client.Set(ctx, featureFlagsKey, jsonData, 0).Err()
+}
+
+// GetFeatureFlags 기능 플래그 스냅샷 조회
+func GetFeatureFlags(result interface{}) error {
+	val, err := Client.Get(ctx, featureFlagsKey).Result()
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal([]byte(val), result)
+}
+
 // 🧹 Utility Functions
 
 // FlushMarketData 특정 시장의 모든 캐시 데이터 삭제
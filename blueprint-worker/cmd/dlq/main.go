@@ -0,0 +1,168 @@
+// dlq 데드레터 큐 운영 도구
+//
+// 재시도를 모두 소진한 작업/이벤트(<queue>:dlq)를 조회하고 원래 큐로 다시 보내거나 지운다.
+//
+//	go run ./cmd/dlq                                       # DLQ가 쌓인 큐와 크기
+//	go run ./cmd/dlq -queue email_queue -limit 20          # 항목 목록 (최신순)
+//	go run ./cmd/dlq -queue email_queue -id 1700000000000-0 # payload 확인
+//	go run ./cmd/dlq -queue email_queue -replay -id 1700000000000-0,1700000000001-0
+//	go run ./cmd/dlq -queue email_queue -replay -all       # 전체 재발행
+//	go run ./cmd/dlq -queue email_queue -purge             # 전체 삭제 (-id로 선택 삭제)
+//
+// 재발행은 재시도 횟수를 초기화하고, 추적 중인 작업(job_id)은 jobs 상태를 queued로 되돌린다.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	moduleConfig "blueprint-module/pkg/config"
+	"blueprint-module/pkg/database"
+	"blueprint-module/pkg/queue"
+	moduleRedis "blueprint-module/pkg/redis"
+	"blueprint-worker/internal/config"
+)
+
+func main() {
+	queueName := flag.String("queue", "", "원래 큐 이름 (비우면 DLQ가 있는 큐 목록)")
+	idsFlag := flag.String("id", "", "DLQ 메시지 ID (쉼표로 여러 개)")
+	limit := flag.Int64("limit", 50, "목록 조회 개수")
+	replay := flag.Bool("replay", false, "선택한 항목을 원래 큐로 재발행")
+	purge := flag.Bool("purge", false, "선택한 항목 삭제 (-id 없으면 전체)")
+	all := flag.Bool("all", false, "-replay 시 DLQ 전체 대상")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	if err := moduleRedis.InitRedis(&moduleConfig.Config{
+		Redis: moduleConfig.RedisConfig{
+			Host:     cfg.Redis.Host,
+			Port:     cfg.Redis.Port,
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		},
+	}); err != nil {
+		log.Fatalf("Failed to initialize Redis: %v", err)
+	}
+	defer moduleRedis.CloseRedis()
+
+	ids := splitIDs(*idsFlag)
+	switch {
+	case *queueName == "":
+		listQueues()
+	case *replay:
+		// 재발행 시 jobs 상태를 되돌리려면 DB가 필요 (연결 실패 시 상태 갱신 없이 진행)
+		if err := database.Connect(&moduleConfig.Config{
+			Database: moduleConfig.DatabaseConfig{
+				Host:     cfg.Database.Host,
+				User:     cfg.Database.User,
+				Password: cfg.Database.Password,
+				Name:     cfg.Database.Name,
+				Port:     cfg.Database.Port,
+				SSLMode:  cfg.Database.SSLMode,
+			},
+		}); err != nil {
+			log.Printf("⚠️ Database unavailable, job status will not be updated: %v", err)
+		}
+		if *all {
+			ids = allIDs(*queueName)
+		}
+		if len(ids) == 0 {
+			log.Fatal("-replay requires -id or -all")
+		}
+		replayed := 0
+		for _, id := range ids {
+			if err := queue.ReplayDeadLetter(*queueName, id); err != nil {
+				log.Printf("❌ %s: %v", id, err)
+				continue
+			}
+			replayed++
+		}
+		log.Printf("🔁 Replayed %d/%d dead letters to %s", replayed, len(ids), *queueName)
+		if replayed < len(ids) {
+			os.Exit(1)
+		}
+	case *purge:
+		removed, err := queue.PurgeDeadLetters(*queueName, ids)
+		if err != nil {
+			log.Fatalf("Failed to purge dead letters: %v", err)
+		}
+		log.Printf("🧹 Removed %d dead letters from %s", removed, *queueName)
+	case len(ids) > 0:
+		for _, id := range ids {
+			letter, err := queue.GetDeadLetter(*queueName, id)
+			if err != nil {
+				log.Fatalf("Failed to read dead letter: %v", err)
+			}
+			encoded, _ := json.MarshalIndent(letter, "", "  ")
+			fmt.Println(string(encoded))
+		}
+	default:
+		letters, err := queue.ListDeadLetters(*queueName, *limit, "")
+		if err != nil {
+			log.Fatalf("Failed to list dead letters: %v", err)
+		}
+		for _, letter := range letters {
+			fmt.Printf("%s\t%s\t%s\t%s\tretries=%d\t%s\n", letter.ID, letter.FailedAt.Format("2006-01-02 15:04:05"),
+				letter.Kind, letter.Type, letter.Retries, letter.LastError)
+		}
+		fmt.Printf("%d dead letters shown\n", len(letters))
+	}
+}
+
+func listQueues() {
+	infos, err := queue.DeadLetterQueues()
+	if err != nil {
+		log.Fatalf("Failed to list dead letter queues: %v", err)
+	}
+	if len(infos) == 0 {
+		fmt.Println("No dead letters")
+		return
+	}
+	for _, info := range infos {
+		oldest := "-"
+		if info.OldestFailed != nil {
+			oldest = info.OldestFailed.Format("2006-01-02 15:04:05")
+		}
+		fmt.Printf("%s\t%d\toldest=%s\n", info.Queue, info.Size, oldest)
+	}
+}
+
+// allIDs DLQ 전체 메시지 ID (오래된 것부터 재발행)
+func allIDs(queueName string) []string {
+	var ids []string
+	before := ""
+	for {
+		letters, err := queue.ListDeadLetters(queueName, 500, before)
+		if err != nil {
+			log.Fatalf("Failed to list dead letters: %v", err)
+		}
+		if len(letters) == 0 {
+			break
+		}
+		for _, letter := range letters {
+			ids = append(ids, letter.ID)
+		}
+		before = letters[len(letters)-1].ID
+	}
+	for i, j := 0, len(ids)-1; i < j; i, j = i+1, j-1 {
+		ids[i], ids[j] = ids[j], ids[i]
+	}
+	return ids
+}
+
+func splitIDs(value string) []string {
+	var ids []string
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
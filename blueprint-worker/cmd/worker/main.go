@@ -11,6 +11,7 @@ import (
 
 	moduleConfig "blueprint-module/pkg/config"
 	"blueprint-module/pkg/database"
+	"blueprint-module/pkg/internalapi"
 	moduleRedis "blueprint-module/pkg/redis"
 	"blueprint-worker/internal/config"
 	"blueprint-worker/internal/handlers"
@@ -54,12 +55,40 @@ func main() {
 	}
 	defer moduleRedis.CloseRedis()
 
+	// API 서버 내부 gRPC (설정된 경우만, 연결 실패는 호출 시 재시도)
+	var internalAPI *internalapi.Client
+	if cfg.InternalAPI.Address != "" {
+		internalAPI, err = internalapi.Dial(cfg.InternalAPI.Address, cfg.InternalAPI.Token)
+		if err != nil {
+			log.Fatalf("Failed to create internal API client: %v", err)
+		}
+		defer internalAPI.Close()
+
+		checkCtx, checkCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := internalAPI.Check(checkCtx); err != nil {
+			log.Printf("⚠️ Internal API %s not healthy yet: %v", cfg.InternalAPI.Address, err)
+		} else {
+			log.Printf("🔌 Connected to internal API %s", cfg.InternalAPI.Address)
+		}
+		checkCancel()
+	}
+
 	// 워커 핸들러 초기화
 	emailHandler := handlers.NewEmailHandler(cfg)
 	smsHandler := handlers.NewSMSHandler(cfg)
 	fileHandler := handlers.NewFileHandler(cfg)
 	verificationHandler := handlers.NewVerificationHandler(cfg)
-	activityHandler := handlers.NewActivityHandler() // 활동 로그 핸들러 추가
+	activityHandler := handlers.NewActivityHandler()             // 활동 로그 핸들러 추가
+	pushHandler := handlers.NewPushHandler(cfg)                  // Web Push 핸들러 추가
+	feedHandler := handlers.NewFeedHandler()                     // 프로젝트 팔로워 피드 핸들러 추가
+	prescreenHandler := handlers.NewPrescreenHandler(cfg)        // 증거 AI 사전 검토 핸들러 추가
+	webhookHandler := handlers.NewWebhookHandler(cfg)            // 외부 웹훅 발송 핸들러 추가
+	exportHandler := handlers.NewExportHandler(cfg)              // 거래 내역 내보내기 핸들러 추가
+	validatorStatsHandler := handlers.NewValidatorStatsHandler() // 검증인 정확도/평판 재계산 핸들러 추가
+	payoutHandler := handlers.NewPayoutHandler(cfg)              // 창작자 지급 송금 핸들러 추가
+	if internalAPI != nil {
+		payoutHandler.SetInternalAPI(internalAPI) // 송금 전 지갑 잠금액 확인
+	}
 
 	// Graceful shutdown을 위한 context 생성
 	ctx, cancel := context.WithCancel(context.Background())
@@ -118,6 +147,76 @@ func main() {
 		}
 	}()
 
+	// Web Push 큐 워커
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		log.Println("🔔 Starting Push Notification Worker...")
+		if err := pushHandler.StartPushWorker(ctx); err != nil {
+			log.Printf("Push worker error: %v", err)
+		}
+	}()
+
+	// 프로젝트 팔로워 피드 워커
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		log.Println("👀 Starting Project Feed Worker...")
+		if err := feedHandler.StartFeedWorker(ctx); err != nil {
+			log.Printf("Feed worker error: %v", err)
+		}
+	}()
+
+	// 증거 AI 사전 검토 워커
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		log.Println("🤖 Starting Proof Prescreen Worker...")
+		if err := prescreenHandler.StartPrescreenWorker(ctx); err != nil {
+			log.Printf("Prescreen worker error: %v", err)
+		}
+	}()
+
+	// 외부 웹훅 발송 워커
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		log.Println("📮 Starting Webhook Worker...")
+		if err := webhookHandler.StartWebhookWorker(ctx); err != nil {
+			log.Printf("Webhook worker error: %v", err)
+		}
+	}()
+
+	// 거래 내역 내보내기 워커
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		log.Println("📤 Starting Export Worker...")
+		if err := exportHandler.StartExportWorker(ctx); err != nil {
+			log.Printf("Export worker error: %v", err)
+		}
+	}()
+
+	// 검증인 정확도/평판 재계산 워커
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		log.Println("🏅 Starting Validator Stats Worker...")
+		if err := validatorStatsHandler.StartValidatorStatsWorker(ctx); err != nil {
+			log.Printf("Validator stats worker error: %v", err)
+		}
+	}()
+
+	// 창작자 지급 송금 워커 (승인된 요청을 예정 시각에 송금)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		log.Println("💸 Starting Payout Worker...")
+		if err := payoutHandler.StartPayoutWorker(ctx); err != nil {
+			log.Printf("Payout worker error: %v", err)
+		}
+	}()
+
 	log.Println("✅ All workers started successfully")
 
 	// Graceful shutdown
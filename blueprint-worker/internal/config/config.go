@@ -1,8 +1,10 @@
 package config
 
 import (
+	"errors"
 	"log"
 	"os"
+	"strconv"
 
 	"github.com/joho/godotenv"
 )
@@ -20,11 +22,32 @@ type Config struct {
 	// SMS 서비스 설정
 	SMS SMSConfig `json:"sms"`
 
+	// Web Push (VAPID) 설정
+	Push PushConfig `json:"push"`
+
 	// 파일 저장소 설정
 	Storage StorageConfig `json:"storage"`
 
 	// 소셜 미디어 API 설정
 	Social SocialConfig `json:"social"`
+
+	// 증거 AI 사전 검토 설정
+	Prescreen PrescreenConfig `json:"prescreen"`
+
+	// 외부 웹훅 발송 설정
+	Webhook WebhookConfig `json:"webhook"`
+
+	// 업로드 증거 파일 처리 (썸네일/PDF 미리보기)
+	Media MediaConfig `json:"media"`
+
+	// 업로드 파일 악성코드 검사
+	Scanner ScannerConfig `json:"scanner"`
+
+	// 창작자 지급 송금
+	Payout PayoutConfig `json:"payout"`
+
+	// API 서버 내부 gRPC API
+	InternalAPI InternalAPIConfig `json:"internal_api"`
 }
 
 type DatabaseConfig struct {
@@ -44,21 +67,38 @@ type RedisConfig struct {
 }
 
 type EmailConfig struct {
-	SMTPHost     string `json:"smtp_host"`
-	SMTPPort     string `json:"smtp_port"`
-	SMTPUsername string `json:"smtp_username"`
-	SMTPPassword string `json:"smtp_password"`
-	FromEmail    string `json:"from_email"`
-	FromName     string `json:"from_name"`
+	Provider       string    `json:"provider"` // "smtp", "ses", "sendgrid"
+	SMTPHost       string    `json:"smtp_host"`
+	SMTPPort       string    `json:"smtp_port"`
+	SMTPUsername   string    `json:"smtp_username"`
+	SMTPPassword   string    `json:"smtp_password"`
+	SES            SESConfig `json:"ses"`
+	SendGridAPIKey string    `json:"sendgrid_api_key"`
+	FromEmail      string    `json:"from_email"`
+	FromName       string    `json:"from_name"`
+	FrontendURL    string    `json:"frontend_url"` // 알림 메일 링크용
+}
+
+type SESConfig struct {
+	Region          string `json:"region"`
+	AccessKeyID     string `json:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key"`
 }
 
 type SMSConfig struct {
-	Provider   string `json:"provider"`   // "twilio", "aligo", "solapi"
-	APIKey     string `json:"api_key"`
-	APISecret  string `json:"api_secret"`
+	Provider   string `json:"provider"`   // "twilio", "aligo", "solapi", "log"(개발용)
+	APIKey     string `json:"api_key"`    // Aligo/Solapi API 키
+	APISecret  string `json:"api_secret"` // Twilio auth token / Solapi API secret
+	AccountID  string `json:"account_id"` // Twilio account SID / Aligo user id
 	FromNumber string `json:"from_number"`
 }
 
+type PushConfig struct {
+	VAPIDPublicKey  string `json:"vapid_public_key"`  // base64url 비압축 공개키
+	VAPIDPrivateKey string `json:"vapid_private_key"` // base64url 개인키
+	Subject         string `json:"subject"`           // mailto: 연락처
+}
+
 type StorageConfig struct {
 	Provider        string `json:"provider"`         // "s3", "r2", "local"
 	Bucket          string `json:"bucket"`
@@ -67,6 +107,7 @@ type StorageConfig struct {
 	SecretAccessKey string `json:"secret_access_key"`
 	Endpoint        string `json:"endpoint"`         // For R2 or custom S3 endpoint
 	LocalPath       string `json:"local_path"`       // For local storage
+	PublicURL       string `json:"public_url"`       // API 서버 파일 다운로드 기준 URL (처리 결과 URL 생성용)
 }
 
 type SocialConfig struct {
@@ -90,6 +131,42 @@ type TwitterConfig struct {
 	APISecret string `json:"api_secret"`
 }
 
+type PrescreenConfig struct {
+	OCRProvider  string `json:"ocr_provider"` // "openai", "none"
+	OpenAIAPIKey string `json:"openai_api_key"`
+	OCRModel     string `json:"ocr_model"`
+}
+
+type WebhookConfig struct {
+	EncryptionSecret string `json:"-"` // 웹훅 secret 복호화 키 (API 서버와 동일해야 함)
+}
+
+type MediaConfig struct {
+	ThumbnailSize int    `json:"thumbnail_size"` // 썸네일 긴 변 (px)
+	PreviewPages  int    `json:"preview_pages"`  // PDF 미리보기로 렌더링할 앞쪽 페이지 수
+	PDFRenderer   string `json:"pdf_renderer"`   // pdftoppm 실행 파일 (없으면 PDF 미리보기 생략)
+	MaxFileSize   int64  `json:"max_file_size"`  // 처리할 최대 파일 크기 (bytes)
+}
+
+type ScannerConfig struct {
+	ClamAVAddress  string `json:"clamav_address"` // clamd 주소 ("tcp://host:3310" 또는 "unix:///run/clamav/clamd.sock"), 비우면 검사 생략
+	TimeoutSeconds int    `json:"timeout_seconds"`
+}
+
+type PayoutConfig struct {
+	Provider         string `json:"provider"`         // "stub" (실제 은행/체인 연동 전)
+	IntervalSeconds  int    `json:"interval_seconds"` // 송금 예정 요청 확인 주기
+	BatchSize        int    `json:"batch_size"`       // 한 번에 송금할 최대 요청 수
+	MaxAttempts      int    `json:"max_attempts"`     // 실패 시 재시도 횟수 (넘으면 failed, 잠금 해제)
+	RetryDelayMinute int    `json:"retry_delay_minute"`
+	EncryptionSecret string `json:"-"` // 계좌 정보 복호화 키 (API 서버의 API_KEY_ENCRYPTION_SECRET과 동일해야 함)
+}
+
+type InternalAPIConfig struct {
+	Address string `json:"address"` // API 서버 내부 gRPC 주소 ("api:9090", 여러 인스턴스면 "dns:///api:9090"), 비우면 사용 안 함
+	Token   string `json:"-"`       // API 서버의 INTERNAL_API_TOKEN과 동일해야 함 (JWT_SECRET과 다른 별도 값)
+}
+
 func LoadConfig() (*Config, error) {
 	// .env 파일 로드 (선택적)
 	if err := godotenv.Load(); err != nil {
@@ -112,19 +189,33 @@ func LoadConfig() (*Config, error) {
 			DB:       0,
 		},
 		Email: EmailConfig{
+			Provider:     getEnv("EMAIL_PROVIDER", "smtp"),
 			SMTPHost:     getEnv("SMTP_HOST", "smtp.gmail.com"),
 			SMTPPort:     getEnv("SMTP_PORT", "587"),
 			SMTPUsername: getEnv("SMTP_USERNAME", ""),
 			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
-			FromEmail:    getEnv("FROM_EMAIL", "noreply@blueprint.io"),
-			FromName:     getEnv("FROM_NAME", "Blueprint"),
+			SES: SESConfig{
+				Region:          getEnv("AWS_SES_REGION", "ap-northeast-2"),
+				AccessKeyID:     getEnv("AWS_ACCESS_KEY_ID", ""),
+				SecretAccessKey: getEnv("AWS_SECRET_ACCESS_KEY", ""),
+			},
+			SendGridAPIKey: getEnv("SENDGRID_API_KEY", ""),
+			FromEmail:      getEnv("FROM_EMAIL", "noreply@blueprint.io"),
+			FromName:       getEnv("FROM_NAME", "Blueprint"),
+			FrontendURL:    getEnv("FRONTEND_URL", "http://localhost:3000"),
 		},
 		SMS: SMSConfig{
 			Provider:   getEnv("SMS_PROVIDER", "aligo"),
 			APIKey:     getEnv("SMS_API_KEY", ""),
 			APISecret:  getEnv("SMS_API_SECRET", ""),
+			AccountID:  getEnv("SMS_ACCOUNT_ID", ""),
 			FromNumber: getEnv("SMS_FROM_NUMBER", ""),
 		},
+		Push: PushConfig{
+			VAPIDPublicKey:  getEnv("VAPID_PUBLIC_KEY", ""),
+			VAPIDPrivateKey: getEnv("VAPID_PRIVATE_KEY", ""),
+			Subject:         getEnv("VAPID_SUBJECT", "mailto:support@blueprint.io"),
+		},
 		Storage: StorageConfig{
 			Provider:        getEnv("STORAGE_PROVIDER", "local"),
 			Bucket:          getEnv("STORAGE_BUCKET", "blueprint-files"),
@@ -133,6 +224,7 @@ func LoadConfig() (*Config, error) {
 			SecretAccessKey: getEnv("STORAGE_SECRET_ACCESS_KEY", ""),
 			Endpoint:        getEnv("STORAGE_ENDPOINT", ""),
 			LocalPath:       getEnv("STORAGE_LOCAL_PATH", "./uploads"),
+			PublicURL:       getEnv("API_PUBLIC_URL", "http://localhost:8080") + "/api/v1/files",
 		},
 		Social: SocialConfig{
 			LinkedIn: LinkedInConfig{
@@ -148,6 +240,45 @@ func LoadConfig() (*Config, error) {
 				APISecret: getEnv("TWITTER_API_SECRET", ""),
 			},
 		},
+		Prescreen: PrescreenConfig{
+			OCRProvider:  getEnv("PRESCREEN_OCR_PROVIDER", "openai"),
+			OpenAIAPIKey: getEnv("OPENAI_API_KEY", ""),
+			OCRModel:     getEnv("PRESCREEN_OCR_MODEL", "gpt-4o-mini"),
+		},
+		Webhook: WebhookConfig{
+			EncryptionSecret: getEnv("API_KEY_ENCRYPTION_SECRET", getEnv("JWT_SECRET", "your-super-secret-jwt-key-change-this-in-production")),
+		},
+		Media: MediaConfig{
+			ThumbnailSize: getEnvAsInt("MEDIA_THUMBNAIL_SIZE", 320),
+			PreviewPages:  getEnvAsInt("MEDIA_PDF_PREVIEW_PAGES", 3),
+			PDFRenderer:   getEnv("MEDIA_PDF_RENDERER", "pdftoppm"),
+			MaxFileSize:   int64(getEnvAsInt("MEDIA_MAX_FILE_SIZE_MB", 25)) << 20,
+		},
+		Scanner: ScannerConfig{
+			ClamAVAddress:  getEnv("CLAMAV_ADDRESS", ""),
+			TimeoutSeconds: getEnvAsInt("CLAMAV_TIMEOUT_SECONDS", 30),
+		},
+		Payout: PayoutConfig{
+			Provider:         getEnv("PAYOUT_PROVIDER", "stub"),
+			IntervalSeconds:  getEnvAsInt("PAYOUT_INTERVAL_SECONDS", 300),
+			BatchSize:        getEnvAsInt("PAYOUT_BATCH_SIZE", 50),
+			MaxAttempts:      getEnvAsInt("PAYOUT_MAX_ATTEMPTS", 3),
+			RetryDelayMinute: getEnvAsInt("PAYOUT_RETRY_DELAY_MINUTES", 30),
+			EncryptionSecret: getEnv("API_KEY_ENCRYPTION_SECRET", getEnv("JWT_SECRET", "your-super-secret-jwt-key-change-this-in-production")),
+		},
+		InternalAPI: InternalAPIConfig{
+			Address: getEnv("INTERNAL_GRPC_ADDR", ""),
+			Token:   getEnv("INTERNAL_API_TOKEN", ""),
+		},
+	}
+
+	if config.InternalAPI.Address != "" {
+		if config.InternalAPI.Token == "" {
+			return nil, errors.New("INTERNAL_API_TOKEN: INTERNAL_GRPC_ADDR를 쓰려면 필수 값입니다")
+		}
+		if config.InternalAPI.Token == os.Getenv("JWT_SECRET") {
+			return nil, errors.New("INTERNAL_API_TOKEN: JWT_SECRET과 다른 값이어야 합니다")
+		}
 	}
 
 	return config, nil
@@ -159,3 +290,12 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvAsInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.Atoi(value); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
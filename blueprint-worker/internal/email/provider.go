@@ -0,0 +1,51 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"blueprint-worker/internal/config"
+)
+
+// Message 발송할 이메일 (HTML + 텍스트 대체 본문)
+type Message struct {
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// Provider 이메일 발송 제공자 (SMTP, SES, SendGrid)
+type Provider interface {
+	Name() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewProvider EMAIL_PROVIDER 설정에 따라 발송 제공자 생성
+func NewProvider(cfg *config.Config) (Provider, error) {
+	switch strings.ToLower(cfg.Email.Provider) {
+	case "", "smtp":
+		return NewSMTPProvider(cfg.Email), nil
+	case "ses":
+		if cfg.Email.SES.AccessKeyID == "" || cfg.Email.SES.SecretAccessKey == "" {
+			return nil, fmt.Errorf("SES credentials are not configured")
+		}
+		return NewSESProvider(cfg.Email), nil
+	case "sendgrid":
+		if cfg.Email.SendGridAPIKey == "" {
+			return nil, fmt.Errorf("SendGrid API key is not configured")
+		}
+		return NewSendGridProvider(cfg.Email), nil
+	default:
+		return nil, fmt.Errorf("unknown email provider: %s", cfg.Email.Provider)
+	}
+}
+
+// fromHeader "Blueprint <noreply@blueprint.io>" 형태의 발신자
+func fromHeader(cfg config.EmailConfig) string {
+	if cfg.FromName == "" {
+		return cfg.FromEmail
+	}
+	return fmt.Sprintf("%s <%s>", cfg.FromName, cfg.FromEmail)
+}
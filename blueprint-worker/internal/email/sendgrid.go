@@ -0,0 +1,68 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"blueprint-worker/internal/config"
+)
+
+const sendGridEndpoint = "https://api.sendgrid.com/v3/mail/send"
+
+// SendGridProvider SendGrid v3 Mail Send API
+type SendGridProvider struct {
+	cfg    config.EmailConfig
+	client *http.Client
+}
+
+func NewSendGridProvider(cfg config.EmailConfig) *SendGridProvider {
+	return &SendGridProvider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (p *SendGridProvider) Name() string { return "sendgrid" }
+
+func (p *SendGridProvider) Send(ctx context.Context, msg Message) error {
+	payload := map[string]interface{}{
+		"personalizations": []map[string]interface{}{
+			{"to": []map[string]string{{"email": msg.To}}},
+		},
+		"from":    map[string]string{"email": p.cfg.FromEmail, "name": p.cfg.FromName},
+		"subject": msg.Subject,
+		"content": []map[string]string{
+			{"type": "text/plain", "value": msg.Text},
+			{"type": "text/html", "value": msg.HTML},
+		},
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", sendGridEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.cfg.SendGridAPIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sendgrid request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("sendgrid returned %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
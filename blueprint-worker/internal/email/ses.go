@@ -0,0 +1,171 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"blueprint-worker/internal/config"
+)
+
+// SESProvider Amazon SES v2 SendEmail API (SigV4 서명)
+type SESProvider struct {
+	cfg    config.EmailConfig
+	client *http.Client
+}
+
+func NewSESProvider(cfg config.EmailConfig) *SESProvider {
+	return &SESProvider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (p *SESProvider) Name() string { return "ses" }
+
+func (p *SESProvider) Send(ctx context.Context, msg Message) error {
+	payload := map[string]interface{}{
+		"FromEmailAddress": fromHeader(p.cfg),
+		"Destination": map[string]interface{}{
+			"ToAddresses": []string{msg.To},
+		},
+		"Content": map[string]interface{}{
+			"Simple": map[string]interface{}{
+				"Subject": map[string]string{"Data": msg.Subject, "Charset": "UTF-8"},
+				"Body": map[string]interface{}{
+					"Text": map[string]string{"Data": msg.Text, "Charset": "UTF-8"},
+					"Html": map[string]string{"Data": msg.HTML, "Charset": "UTF-8"},
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	host := fmt.Sprintf("email.%s.amazonaws.com", p.cfg.SES.Region)
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://"+host+"/v2/email/outbound-emails", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	p.sign(req, host, body, time.Now().UTC())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("ses request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("ses returned %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// sign AWS Signature Version 4 헤더 추가 (본문 해시도 서명)
+func (p *SESProvider) sign(req *http.Request, host string, body []byte, now time.Time) {
+	req.Host = host
+	req.Header.Set("X-Amz-Content-Sha256", sha256Hex(body))
+	signV4(req, body, sigV4Credentials{
+		AccessKeyID:     p.cfg.SES.AccessKeyID,
+		SecretAccessKey: p.cfg.SES.SecretAccessKey,
+		Region:          p.cfg.SES.Region,
+		Service:         "ses",
+	}, []string{"content-type", "host", "x-amz-content-sha256", "x-amz-date"}, now)
+}
+
+// sigV4Credentials 서명 키 도출에 쓰는 자격 증명과 범위
+type sigV4Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	Region          string
+	Service         string
+}
+
+// signV4 X-Amz-Date와 Authorization 헤더 추가 (signedHeaders는 소문자, 정렬된 순서)
+func signV4(req *http.Request, body []byte, creds sigV4Credentials, signedHeaders []string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range signedHeaders {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.Host
+			if value == "" {
+				value = req.URL.Host
+			}
+		}
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, strings.TrimSpace(value))
+	}
+	signedHeaderList := strings.Join(signedHeaders, ";")
+
+	canonicalRequest := fmt.Sprintf("%s\n%s\n%s\n%s\n%s\n%s",
+		req.Method, canonicalPath(req.URL), canonicalQuery(req.URL), canonicalHeaders.String(), signedHeaderList, sha256Hex(body))
+
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", dateStamp, creds.Region, creds.Service)
+	stringToSign := fmt.Sprintf("AWS4-HMAC-SHA256\n%s\n%s\n%s", amzDate, scope, sha256Hex([]byte(canonicalRequest)))
+
+	signingKey := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), dateStamp)
+	signingKey = hmacSHA256(signingKey, creds.Region)
+	signingKey = hmacSHA256(signingKey, creds.Service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaderList, signature))
+}
+
+func canonicalPath(u *url.URL) string {
+	if path := u.EscapedPath(); path != "" {
+		return path
+	}
+	return "/"
+}
+
+// canonicalQuery 키 순으로 정렬하고 공백은 %20으로 인코딩한 쿼리 문자열
+func canonicalQuery(u *url.URL) string {
+	values := u.Query()
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	escape := func(v string) string { return strings.ReplaceAll(url.QueryEscape(v), "+", "%20") }
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		vals := append([]string(nil), values[key]...)
+		sort.Strings(vals)
+		for _, v := range vals {
+			pairs = append(pairs, escape(key)+"="+escape(v))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
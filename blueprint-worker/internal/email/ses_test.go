@@ -0,0 +1,66 @@
+package email
+
+import (
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"blueprint-worker/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSignV4DocumentedExample AWS 문서의 IAM ListUsers 서명 예제와 같은 서명을 만들어야 함
+func TestSignV4DocumentedExample(t *testing.T) {
+	req, err := http.NewRequest("GET", "https://iam.amazonaws.com/?Version=2010-05-08&Action=ListUsers", nil)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	signV4(req, nil, sigV4Credentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		Region:          "us-east-1",
+		Service:         "iam",
+	}, []string{"content-type", "host", "x-amz-date"}, time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, "+
+		"SignedHeaders=content-type;host;x-amz-date, "+
+		"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7",
+		req.Header.Get("Authorization"))
+}
+
+// TestSESSignsPayloadHash SES 요청은 본문 해시를 서명 헤더에 포함
+func TestSESSignsPayloadHash(t *testing.T) {
+	provider := NewSESProvider(config.EmailConfig{SES: config.SESConfig{
+		AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret", Region: "ap-northeast-2",
+	}})
+	body := []byte(`{"FromEmailAddress":"noreply@blueprint.io"}`)
+	req, err := http.NewRequest("POST", "https://email.ap-northeast-2.amazonaws.com/v2/email/outbound-emails", nil)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	provider.sign(req, "email.ap-northeast-2.amazonaws.com", body, time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, sha256Hex(body), req.Header.Get("X-Amz-Content-Sha256"))
+	authorization := req.Header.Get("Authorization")
+	assert.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20261001/ap-northeast-2/ses/aws4_request, "))
+	assert.Contains(t, authorization, "SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date, ")
+
+	// 본문이 바뀌면 서명도 바뀜
+	other := req.Clone(req.Context())
+	provider.sign(other, "email.ap-northeast-2.amazonaws.com", []byte(`{}`), time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC))
+	assert.NotEqual(t, authorization, other.Header.Get("Authorization"))
+}
+
+func TestSigningKeyDerivation(t *testing.T) {
+	// AWS 문서의 서명 키 도출 예제 (20120215/us-east-1/iam)
+	key := hmacSHA256([]byte("AWS4wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"), "20120215")
+	key = hmacSHA256(key, "us-east-1")
+	key = hmacSHA256(key, "iam")
+	key = hmacSHA256(key, "aws4_request")
+	assert.Equal(t, "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d", hex.EncodeToString(key))
+}
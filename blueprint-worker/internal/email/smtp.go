@@ -0,0 +1,111 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/smtp"
+
+	"blueprint-worker/internal/config"
+)
+
+// SMTPProvider SMTP 발송 (465: implicit TLS, 그 외: STARTTLS)
+type SMTPProvider struct {
+	cfg  config.EmailConfig
+	auth smtp.Auth
+}
+
+func NewSMTPProvider(cfg config.EmailConfig) *SMTPProvider {
+	return &SMTPProvider{
+		cfg:  cfg,
+		auth: smtp.PlainAuth("", cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPHost),
+	}
+}
+
+func (p *SMTPProvider) Name() string { return "smtp" }
+
+func (p *SMTPProvider) Send(ctx context.Context, msg Message) error {
+	body, err := p.buildMIME(msg)
+	if err != nil {
+		return err
+	}
+
+	addr := p.cfg.SMTPHost + ":" + p.cfg.SMTPPort
+	tlsConfig := &tls.Config{ServerName: p.cfg.SMTPHost}
+
+	var client *smtp.Client
+	if p.cfg.SMTPPort == "465" {
+		conn, err := tls.Dial("tcp", addr, tlsConfig)
+		if err != nil {
+			return err
+		}
+		client, err = smtp.NewClient(conn, p.cfg.SMTPHost)
+		if err != nil {
+			return err
+		}
+	} else {
+		client, err = smtp.Dial(addr)
+		if err != nil {
+			return err
+		}
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				client.Close()
+				return err
+			}
+		}
+	}
+	defer client.Close()
+
+	if p.cfg.SMTPUsername != "" {
+		if err := client.Auth(p.auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(p.cfg.FromEmail); err != nil {
+		return err
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// buildMIME multipart/alternative (text + html) 메시지 구성
+func (p *SMTPProvider) buildMIME(msg Message) ([]byte, error) {
+	boundaryBytes := make([]byte, 12)
+	if _, err := rand.Read(boundaryBytes); err != nil {
+		return nil, err
+	}
+	boundary := "bp-" + hex.EncodeToString(boundaryBytes)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", mime.QEncoding.Encode("UTF-8", fromHeader(p.cfg)))
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", msg.Subject))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary)
+
+	fmt.Fprintf(&buf, "--%s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n", boundary, msg.Text)
+	fmt.Fprintf(&buf, "--%s\r\nContent-Type: text/html; charset=UTF-8\r\n\r\n%s\r\n", boundary, msg.HTML)
+	fmt.Fprintf(&buf, "--%s--\r\n", boundary)
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,143 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// 지원 언어 (기본: 한국어)
+const (
+	LocaleKorean  = "ko"
+	LocaleEnglish = "en"
+	DefaultLocale = LocaleKorean
+)
+
+// NormalizeLocale "en-US", "EN" 등을 지원 언어로 정규화 (미지원 시 빈 문자열)
+func NormalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	switch {
+	case strings.HasPrefix(locale, LocaleEnglish):
+		return LocaleEnglish
+	case strings.HasPrefix(locale, LocaleKorean):
+		return LocaleKorean
+	default:
+		return ""
+	}
+}
+
+// templateData 템플릿 렌더링 컨텍스트
+type templateData struct {
+	Locale      string
+	Subject     string
+	FrontendURL string
+	Data        map[string]interface{}
+}
+
+// templateSet 언어/템플릿별 파싱 결과 (제목·본문은 text, HTML은 html/template)
+type templateSet struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// Renderer 임베드된 템플릿으로 언어별 이메일 렌더링
+type Renderer struct {
+	frontendURL string
+	files       fs.FS // templates/<언어>/<이름>.html, templates/layout.html
+
+	mu    sync.RWMutex
+	cache map[string]*templateSet
+}
+
+func NewRenderer(frontendURL string) *Renderer {
+	return &Renderer{
+		frontendURL: frontendURL,
+		files:       templateFS,
+		cache:       make(map[string]*templateSet),
+	}
+}
+
+// Render 템플릿을 렌더링하여 메시지 생성 (해당 언어 템플릿이 없으면 한국어로 대체)
+func (r *Renderer) Render(name, locale, to string, data map[string]interface{}) (Message, error) {
+	locale = NormalizeLocale(locale)
+	if locale == "" {
+		locale = DefaultLocale
+	}
+
+	set, err := r.load(locale, name)
+	if err != nil && locale != DefaultLocale {
+		locale = DefaultLocale
+		set, err = r.load(locale, name)
+	}
+	if err != nil {
+		return Message{}, err
+	}
+
+	ctx := templateData{
+		Locale:      locale,
+		FrontendURL: r.frontendURL,
+		Data:        data,
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := set.text.ExecuteTemplate(&subject, "subject", ctx); err != nil {
+		return Message{}, fmt.Errorf("failed to render subject: %w", err)
+	}
+	ctx.Subject = strings.TrimSpace(subject.String())
+
+	if err := set.text.ExecuteTemplate(&text, "text", ctx); err != nil {
+		return Message{}, fmt.Errorf("failed to render text body: %w", err)
+	}
+	if err := set.html.ExecuteTemplate(&html, "layout", ctx); err != nil {
+		return Message{}, fmt.Errorf("failed to render html body: %w", err)
+	}
+
+	return Message{
+		To:      to,
+		Subject: ctx.Subject,
+		Text:    strings.TrimSpace(text.String()),
+		HTML:    html.String(),
+	}, nil
+}
+
+func (r *Renderer) load(locale, name string) (*templateSet, error) {
+	key := locale + "/" + name
+
+	r.mu.RLock()
+	set, ok := r.cache[key]
+	r.mu.RUnlock()
+	if ok {
+		return set, nil
+	}
+
+	path := fmt.Sprintf("templates/%s/%s.html", locale, name)
+	content, err := fs.ReadFile(r.files, path)
+	if err != nil {
+		return nil, fmt.Errorf("unknown email template: %s (%s)", name, locale)
+	}
+
+	textTmpl, err := texttemplate.New(name).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+	htmlTmpl, err := htmltemplate.New(name).ParseFS(r.files, "templates/layout.html", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+
+	set = &templateSet{text: textTmpl, html: htmlTmpl}
+
+	r.mu.Lock()
+	r.cache[key] = set
+	r.mu.Unlock()
+
+	return set, nil
+}
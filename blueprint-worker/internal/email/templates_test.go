@@ -0,0 +1,94 @@
+package email
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"blueprint-worker/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeLocale(t *testing.T) {
+	assert.Equal(t, LocaleEnglish, NormalizeLocale("en-US"))
+	assert.Equal(t, LocaleEnglish, NormalizeLocale(" EN "))
+	assert.Equal(t, LocaleKorean, NormalizeLocale("ko-KR"))
+	assert.Equal(t, "", NormalizeLocale("ja"))
+}
+
+// TestRenderLocaleFallback 미지원 언어와 영어 템플릿이 없는 메일은 한국어로 렌더링
+func TestRenderLocaleFallback(t *testing.T) {
+	layout := `{{define "layout"}}<html lang="{{.Locale}}">{{template "html" .}}</html>{{end}}`
+	renderer := NewRenderer("https://blueprint.io")
+	renderer.files = fstest.MapFS{
+		"templates/layout.html": {Data: []byte(layout)},
+		"templates/ko/welcome.html": {Data: []byte(
+			`{{define "subject"}}환영합니다 {{.Data.name}}{{end}}{{define "text"}}안녕하세요{{end}}{{define "html"}}<p>안녕하세요</p>{{end}}`)},
+		"templates/ko/notice.html": {Data: []byte(
+			`{{define "subject"}}공지{{end}}{{define "text"}}공지{{end}}{{define "html"}}<p>공지</p>{{end}}`)},
+		"templates/en/notice.html": {Data: []byte(
+			`{{define "subject"}}Notice{{end}}{{define "text"}}Notice{{end}}{{define "html"}}<p>Notice</p>{{end}}`)},
+	}
+
+	msg, err := renderer.Render("notice", "en-GB", "a@b.io", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Notice", msg.Subject)
+	assert.Contains(t, msg.HTML, `lang="en"`)
+
+	// 영어 템플릿이 없으면 한국어
+	msg, err = renderer.Render("welcome", "en", "a@b.io", map[string]interface{}{"name": "민수"})
+	require.NoError(t, err)
+	assert.Equal(t, "환영합니다 민수", msg.Subject)
+	assert.Contains(t, msg.HTML, `lang="ko"`)
+
+	// 미지원 언어는 한국어
+	msg, err = renderer.Render("notice", "ja", "a@b.io", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "공지", msg.Subject)
+
+	_, err = renderer.Render("missing", "en", "a@b.io", nil)
+	assert.Error(t, err)
+}
+
+// TestEmbeddedTemplates 임베드된 모든 템플릿이 두 언어로 렌더링됨
+func TestEmbeddedTemplates(t *testing.T) {
+	renderer := NewRenderer("https://blueprint.io")
+	for _, name := range []string{"digest", "email_verification", "magic_link", "notification", "work_email_verification"} {
+		for _, locale := range []string{LocaleKorean, LocaleEnglish} {
+			msg, err := renderer.Render(name, locale, "a@b.io", map[string]interface{}{"title": "t", "message": "m"})
+			require.NoError(t, err, "%s/%s", locale, name)
+			assert.NotEmpty(t, msg.Subject, "%s/%s", locale, name)
+			assert.Contains(t, msg.HTML, `lang="`+locale+`"`)
+		}
+	}
+}
+
+// TestBuildMIME 제목/발신자는 Q 인코딩, 텍스트와 HTML 대체 본문을 같은 경계로 구분
+func TestBuildMIME(t *testing.T) {
+	provider := NewSMTPProvider(config.EmailConfig{FromEmail: "noreply@blueprint.io", FromName: "블루프린트"})
+	body, err := provider.buildMIME(Message{To: "user@example.com", Subject: "인증 코드", Text: "코드: 123456", HTML: "<p>코드: 123456</p>"})
+	require.NoError(t, err)
+	raw := string(body)
+
+	assert.Contains(t, raw, "To: user@example.com\r\n")
+	assert.Contains(t, raw, "Subject: =?UTF-8?q?")
+	assert.NotContains(t, raw, "Subject: 인증 코드")
+	assert.Contains(t, raw, "From: =?UTF-8?q?")
+	assert.Contains(t, raw, "MIME-Version: 1.0\r\n")
+
+	start := strings.Index(raw, `boundary="`) + len(`boundary="`)
+	boundary := raw[start : start+strings.Index(raw[start:], `"`)]
+	assert.True(t, strings.HasPrefix(boundary, "bp-"))
+	parts := strings.Split(raw, "--"+boundary)
+	require.Len(t, parts, 4) // 헤더, 텍스트, HTML, 종료
+	assert.Contains(t, parts[1], "Content-Type: text/plain; charset=UTF-8\r\n\r\n코드: 123456")
+	assert.Contains(t, parts[2], "Content-Type: text/html; charset=UTF-8\r\n\r\n<p>코드: 123456</p>")
+	assert.Equal(t, "--\r\n", parts[3])
+
+	// 메시지마다 다른 경계
+	again, err := provider.buildMIME(Message{To: "user@example.com"})
+	require.NoError(t, err)
+	assert.NotContains(t, string(again), boundary)
+}
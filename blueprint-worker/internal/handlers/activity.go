@@ -104,6 +104,12 @@ func (h *ActivityHandler) createActivityLog(jobData map[string]interface{}) erro
 		}
 	}
 
+	// 발생 시각은 큐에 넣은 시점 기준 (워커 지연과 무관하게 기록)
+	createdAt := time.Now()
+	if ts, ok := jobData["created_at"].(float64); ok && ts > 0 {
+		createdAt = time.Unix(int64(ts), 0)
+	}
+
 	// ActivityLog 인스턴스 생성
 	activityLog := models.ActivityLog{
 		UserID:       uint(userID),
@@ -115,7 +121,7 @@ func (h *ActivityHandler) createActivityLog(jobData map[string]interface{}) erro
 		OrderID:      orderID,
 		TradeID:      tradeID,
 		Metadata:     metadata,
-		CreatedAt:    time.Now(),
+		CreatedAt:    createdAt,
 		UpdatedAt:    time.Now(),
 	}
 
@@ -129,6 +135,13 @@ func (h *ActivityHandler) createActivityLog(jobData map[string]interface{}) erro
 	log.Printf("✅ 활동 로그 저장 성공 (ID: %d, Type: %s, Action: %s, UserID: %d)",
 		activityLog.ID, activityLog.ActivityType, activityLog.Action, activityLog.UserID)
 
+	// 프로젝트 활동은 마일스톤 위험 점수의 비활동 신호에 쓰이므로 재계산 요청
+	if projectID != nil {
+		if err := redis.MarkStatsDirty(redis.StatsScopeProjects, *projectID); err != nil {
+			log.Printf("⚠️ 프로젝트 지표 재계산 요청 실패 (project %d): %v", *projectID, err)
+		}
+	}
+
 	return nil
 }
 
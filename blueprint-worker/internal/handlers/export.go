@@ -0,0 +1,518 @@
+package handlers
+
+import (
+	"blueprint-module/pkg/database"
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/queue"
+	"blueprint-worker/internal/config"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	exportQueue     = "export_queue"
+	exportCategory  = "exports"
+	exportRetention = 7 * 24 * time.Hour // 생성된 파일 보관 기간 (API 서버가 만료 후 삭제)
+)
+
+// exportContentTypes 형식별 다운로드 Content-Type
+var exportContentTypes = map[models.DataExportFormat]string{
+	models.DataExportCSV:  "text/csv; charset=utf-8",
+	models.DataExportXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	models.DataExportJSON: "application/json; charset=utf-8",
+	models.DataExportPDF:  "application/pdf",
+}
+
+// exportFileMeta API 서버 FileService가 읽는 메타데이터 형식 (<key>.json)
+type exportFileMeta struct {
+	Category     string    `json:"category"`
+	Key          string    `json:"key"`
+	OriginalName string    `json:"original_name"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	UploadedAt   time.Time `json:"uploaded_at"`
+}
+
+// ExportHandler 거래/주문/세무 리포트, 수수료 명세서 파일 생성 워커
+type ExportHandler struct {
+	config *config.Config
+}
+
+// NewExportHandler 생성자
+func NewExportHandler(cfg *config.Config) *ExportHandler {
+	return &ExportHandler{
+		config: cfg,
+	}
+}
+
+// StartExportWorker 내보내기 큐 소비 시작
+func (h *ExportHandler) StartExportWorker(ctx context.Context) error {
+	return queue.ConsumeJobsWithContext(ctx, exportQueue, "export_workers", "export_worker_1", h.handleExportJob)
+}
+
+func (h *ExportHandler) handleExportJob(jobData map[string]interface{}) error {
+	jobType, _ := jobData["type"].(string)
+	if jobType != "generate_export" {
+		log.Printf("⚠️ Unknown export job type: %s", jobType)
+		return nil
+	}
+
+	exportID, ok := jobData["export_id"].(float64)
+	if !ok {
+		return fmt.Errorf("invalid export_id")
+	}
+
+	db := database.GetDB()
+	var export models.DataExport
+	if err := db.First(&export, uint(exportID)).Error; err != nil {
+		return fmt.Errorf("failed to load export %d: %w", uint(exportID), err)
+	}
+	if export.Status == models.DataExportCompleted || export.Status == models.DataExportExpired {
+		return nil
+	}
+
+	if err := h.generate(db, &export); err != nil {
+		attempt := 0
+		if v, ok := jobData["retry_count"].(float64); ok {
+			attempt = int(v)
+		}
+		// 마지막 시도까지 실패하면 사용자에게 실패 상태를 남김
+		if !queue.DefaultRetryPolicy.ShouldRetry(attempt) {
+			db.Model(&export).Updates(map[string]interface{}{
+				"status": models.DataExportFailed,
+				"error":  err.Error(),
+			})
+		}
+		return err
+	}
+	return nil
+}
+
+// generate 행 생성 → 파일 저장 → 완료 처리 및 알림
+func (h *ExportHandler) generate(db *gorm.DB, export *models.DataExport) error {
+	if h.config.Storage.Provider != "local" {
+		return fmt.Errorf("export files require local storage (provider: %s)", h.config.Storage.Provider)
+	}
+
+	db.Model(export).Update("status", models.DataExportProcessing)
+
+	content, fileName, rowCount, err := h.render(db, export)
+	if err != nil {
+		return err
+	}
+
+	key, err := h.saveFile(fileName, exportContentTypes[export.Format], content)
+	if err != nil {
+		return err
+	}
+
+	// 수수료 명세서는 회계 보관용이라 만료하지 않음
+	now := time.Now()
+	var expiresAt *time.Time
+	if export.Kind != models.DataExportFeeStatement {
+		expires := now.Add(exportRetention)
+		expiresAt = &expires
+	}
+	if err := db.Model(export).Updates(map[string]interface{}{
+		"status":       models.DataExportCompleted,
+		"file_path":    exportCategory + "/" + key,
+		"file_name":    fileName,
+		"row_count":    rowCount,
+		"error":        "",
+		"completed_at": now,
+		"expires_at":   expiresAt,
+	}).Error; err != nil {
+		return fmt.Errorf("failed to update export: %w", err)
+	}
+
+	message := fmt.Sprintf("%d년 %s 파일(%d건)을 7일 동안 다운로드할 수 있습니다.", export.Year, exportKindLabel(export.Kind), rowCount)
+	switch export.Kind {
+	case models.DataExportPersonalData:
+		message = fmt.Sprintf("%s 파일(%d건)을 7일 동안 다운로드할 수 있습니다.", exportKindLabel(export.Kind), rowCount)
+	case models.DataExportFeeStatement:
+		message = fmt.Sprintf("%d년 %d월 %s(PDF)를 언제든 다운로드할 수 있습니다.", export.Year, export.Month, exportKindLabel(export.Kind))
+	}
+	data, _ := json.Marshal(map[string]interface{}{
+		"export_id":     export.ID,
+		"download_path": fmt.Sprintf("/api/v1/exports/%d/download", export.ID),
+		"expires_at":    expiresAt,
+	})
+	notification := models.Notification{
+		UserID:   export.UserID,
+		Type:     models.NotificationTypeExport,
+		Priority: models.NotificationPriorityNormal,
+		Title:    "내보내기 파일이 준비되었습니다",
+		Message:  message,
+		Link:     fmt.Sprintf("/exports/%d", export.ID),
+		Data:     string(data),
+	}
+	if err := db.Create(&notification).Error; err != nil {
+		log.Printf("Failed to create export notification for user %d: %v", export.UserID, err)
+	}
+
+	log.Printf("📤 Export %d (%s %d, %s) generated with %d rows", export.ID, export.Kind, export.Year, export.Format, rowCount)
+	return nil
+}
+
+// render 종류/형식별 파일 내용 생성 (반환값: 내용, 파일명, 행 수)
+func (h *ExportHandler) render(db *gorm.DB, export *models.DataExport) ([]byte, string, int, error) {
+	if export.Kind == models.DataExportPersonalData {
+		content, count, err := personalDataDocument(db, export.UserID)
+		if err != nil {
+			return nil, "", 0, err
+		}
+		fileName := fmt.Sprintf("blueprint-personal-data-%s.json", time.Now().UTC().Format("20060102"))
+		return content, fileName, count, nil
+	}
+	if export.Kind == models.DataExportFeeStatement {
+		content, count, err := feeStatementDocument(db, export)
+		if err != nil {
+			return nil, "", 0, err
+		}
+		fileName := fmt.Sprintf("blueprint-fee-statement-%d-%02d.pdf", export.Year, export.Month)
+		return content, fileName, count, nil
+	}
+
+	from := time.Date(export.Year, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, 0)
+
+	var header []string
+	var rows [][]string
+	var err error
+	switch export.Kind {
+	case models.DataExportTrades:
+		header, rows, err = h.tradeRows(db, export.UserID, from, to)
+	case models.DataExportOrders:
+		header, rows, err = h.orderRows(db, export.UserID, from, to)
+	case models.DataExportTaxReport:
+		header, rows, err = h.taxReportRows(db, export.UserID, from, to)
+	default:
+		return nil, "", 0, fmt.Errorf("unknown export kind: %s", export.Kind)
+	}
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	var content []byte
+	switch export.Format {
+	case models.DataExportCSV:
+		content, err = encodeCSV(header, rows)
+	case models.DataExportXLSX:
+		content, err = encodeXLSX(string(export.Kind), header, rows)
+	default:
+		return nil, "", 0, fmt.Errorf("unknown export format: %s", export.Format)
+	}
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	fileName := fmt.Sprintf("blueprint-%s-%d.%s", export.Kind, export.Year, export.Format)
+	return content, fileName, len(rows), nil
+}
+
+// saveFile 무작위 저장 키로 파일과 메타데이터 저장
+func (h *ExportHandler) saveFile(fileName, contentType string, content []byte) (string, error) {
+	randBytes := make([]byte, 16)
+	if _, err := rand.Read(randBytes); err != nil {
+		return "", fmt.Errorf("failed to generate storage key: %w", err)
+	}
+	key := hex.EncodeToString(randBytes)
+
+	dir := filepath.Join(h.config.Storage.LocalPath, exportCategory)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, key), content, 0640); err != nil {
+		return "", fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	meta, err := json.Marshal(exportFileMeta{
+		Category:     exportCategory,
+		Key:          key,
+		OriginalName: fileName,
+		ContentType:  contentType,
+		Size:         int64(len(content)),
+		UploadedAt:   time.Now(),
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, key+".json"), meta, 0640); err != nil {
+		return "", fmt.Errorf("failed to write export metadata: %w", err)
+	}
+	return key, nil
+}
+
+// tradeRows 체결 내역 (사용자 기준 매수/매도 방향)
+func (h *ExportHandler) tradeRows(db *gorm.DB, userID uint, from, to time.Time) ([]string, [][]string, error) {
+	var trades []models.Trade
+	if err := db.Where("(buyer_id = ? OR seller_id = ?) AND created_at >= ? AND created_at < ?", userID, userID, from, to).
+		Order("created_at ASC, id ASC").
+		Find(&trades).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to load trades: %w", err)
+	}
+
+	titles := milestoneTitles(db, tradeMilestoneIDs(trades))
+	header := []string{"trade_id", "executed_at", "milestone_id", "milestone", "option", "side", "quantity", "price", "amount", "fee"}
+	rows := make([][]string, 0, len(trades))
+	for _, trade := range trades {
+		if trade.BuyerID == userID {
+			rows = append(rows, tradeRow(trade, titles, "buy", trade.BuyerFee))
+		}
+		if trade.SellerID == userID {
+			rows = append(rows, tradeRow(trade, titles, "sell", trade.SellerFee))
+		}
+	}
+	return header, rows, nil
+}
+
+func tradeRow(trade models.Trade, titles map[uint]string, side string, fee int64) []string {
+	return []string{
+		strconv.FormatUint(uint64(trade.ID), 10),
+		trade.CreatedAt.UTC().Format(time.RFC3339),
+		strconv.FormatUint(uint64(trade.MilestoneID), 10),
+		titles[trade.MilestoneID],
+		trade.OptionID,
+		side,
+		strconv.FormatInt(trade.Quantity, 10),
+		formatPrice(trade.Price),
+		strconv.FormatInt(trade.TotalAmount, 10),
+		strconv.FormatInt(fee, 10),
+	}
+}
+
+// orderRows 주문 내역
+func (h *ExportHandler) orderRows(db *gorm.DB, userID uint, from, to time.Time) ([]string, [][]string, error) {
+	var orders []models.Order
+	if err := db.Where("user_id = ? AND created_at >= ? AND created_at < ?", userID, from, to).
+		Order("created_at ASC, id ASC").
+		Find(&orders).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to load orders: %w", err)
+	}
+
+	ids := make([]uint, 0, len(orders))
+	for _, order := range orders {
+		ids = append(ids, order.MilestoneID)
+	}
+	titles := milestoneTitles(db, ids)
+
+	header := []string{"order_id", "created_at", "milestone_id", "milestone", "option", "type", "side", "quantity", "price", "filled", "remaining", "status"}
+	rows := make([][]string, 0, len(orders))
+	for _, order := range orders {
+		rows = append(rows, []string{
+			strconv.FormatUint(uint64(order.ID), 10),
+			order.CreatedAt.UTC().Format(time.RFC3339),
+			strconv.FormatUint(uint64(order.MilestoneID), 10),
+			titles[order.MilestoneID],
+			order.OptionID,
+			string(order.Type),
+			string(order.Side),
+			strconv.FormatInt(order.Quantity, 10),
+			formatPrice(order.Price),
+			strconv.FormatInt(order.Filled, 10),
+			strconv.FormatInt(order.Remaining, 10),
+			string(order.Status),
+		})
+	}
+	return header, rows, nil
+}
+
+// taxLot 마일스톤/옵션별 보유 수량과 평균 단가 (부호: +롱, -숏)
+type taxLot struct {
+	quantity int64
+	avgPrice float64
+}
+
+// taxReportRows 연간 실현 손익 (평균 단가법, 해당 연도 이전 체결도 원가 계산에 반영)
+//
+// 마일스톤 결과 확정에 따른 정산금은 체결이 아니므로 포함하지 않는다.
+func (h *ExportHandler) taxReportRows(db *gorm.DB, userID uint, from, to time.Time) ([]string, [][]string, error) {
+	var trades []models.Trade
+	if err := db.Where("(buyer_id = ? OR seller_id = ?) AND created_at < ?", userID, userID, to).
+		Order("created_at ASC, id ASC").
+		Find(&trades).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to load trades: %w", err)
+	}
+
+	titles := milestoneTitles(db, tradeMilestoneIDs(trades))
+	header := []string{"closed_at", "milestone_id", "milestone", "option", "position", "quantity", "avg_cost", "close_price", "cost_basis", "proceeds", "realized_pnl", "fee"}
+
+	lots := make(map[string]*taxLot)
+	var rows [][]string
+	var totalPnL, totalFees int64
+
+	apply := func(trade models.Trade, delta int64, fee int64) {
+		key := fmt.Sprintf("%d:%s", trade.MilestoneID, trade.OptionID)
+		lot, ok := lots[key]
+		if !ok {
+			lot = &taxLot{}
+			lots[key] = lot
+		}
+
+		inYear := !trade.CreatedAt.Before(from)
+		if inYear {
+			totalFees += fee
+		}
+
+		// 같은 방향이면 평균 단가만 갱신
+		if lot.quantity == 0 || (lot.quantity > 0) == (delta > 0) {
+			total := math.Abs(float64(lot.quantity)) + math.Abs(float64(delta))
+			lot.avgPrice = (lot.avgPrice*math.Abs(float64(lot.quantity)) + trade.Price*math.Abs(float64(delta))) / total
+			lot.quantity += delta
+			return
+		}
+
+		// 반대 방향: 겹치는 수량만큼 청산
+		closed := delta
+		if abs64(closed) > abs64(lot.quantity) {
+			closed = -lot.quantity
+		}
+		closedQty := abs64(closed)
+		position := "long"
+		pnl := int64(float64(closedQty) * (trade.Price - lot.avgPrice))
+		if lot.quantity < 0 {
+			position = "short"
+			pnl = -pnl
+		}
+
+		if inYear {
+			totalPnL += pnl
+			rows = append(rows, []string{
+				trade.CreatedAt.UTC().Format(time.RFC3339),
+				strconv.FormatUint(uint64(trade.MilestoneID), 10),
+				titles[trade.MilestoneID],
+				trade.OptionID,
+				position,
+				strconv.FormatInt(closedQty, 10),
+				formatPrice(lot.avgPrice),
+				formatPrice(trade.Price),
+				strconv.FormatInt(int64(float64(closedQty)*lot.avgPrice), 10),
+				strconv.FormatInt(int64(float64(closedQty)*trade.Price), 10),
+				strconv.FormatInt(pnl, 10),
+				strconv.FormatInt(fee, 10),
+			})
+		}
+
+		lot.quantity += delta
+		if lot.quantity == 0 {
+			lot.avgPrice = 0
+		} else if (lot.quantity > 0) == (delta > 0) {
+			// 방향 전환: 남은 수량은 이번 체결가로 새로 진입
+			lot.avgPrice = trade.Price
+		}
+	}
+
+	for _, trade := range trades {
+		if trade.BuyerID == userID {
+			apply(trade, trade.Quantity, trade.BuyerFee)
+		}
+		if trade.SellerID == userID {
+			apply(trade, -trade.Quantity, trade.SellerFee)
+		}
+	}
+
+	rows = append(rows, []string{"TOTAL", "", "", "", "", "", "", "", "", "", strconv.FormatInt(totalPnL, 10), strconv.FormatInt(totalFees, 10)})
+	return header, rows, nil
+}
+
+func tradeMilestoneIDs(trades []models.Trade) []uint {
+	ids := make([]uint, 0, len(trades))
+	for _, trade := range trades {
+		ids = append(ids, trade.MilestoneID)
+	}
+	return ids
+}
+
+// milestoneTitles 마일스톤 ID → 제목 (삭제된 마일스톤 포함)
+func milestoneTitles(db *gorm.DB, ids []uint) map[uint]string {
+	titles := make(map[uint]string)
+	if len(ids) == 0 {
+		return titles
+	}
+
+	var milestones []models.Milestone
+	db.Unscoped().Select("id", "title").Where("id IN ?", ids).Find(&milestones)
+	for _, milestone := range milestones {
+		titles[milestone.ID] = milestone.Title
+	}
+	return titles
+}
+
+func exportKindLabel(kind models.DataExportKind) string {
+	switch kind {
+	case models.DataExportTrades:
+		return "체결 내역"
+	case models.DataExportOrders:
+		return "주문 내역"
+	case models.DataExportTaxReport:
+		return "실현 손익 리포트"
+	case models.DataExportPersonalData:
+		return "개인 데이터"
+	case models.DataExportFeeStatement:
+		return "수수료 명세서"
+	default:
+		return string(kind)
+	}
+}
+
+func formatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', -1, 64)
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// encodeCSV UTF-8 BOM 포함 CSV (Excel에서 한글 제목이 깨지지 않도록)
+func encodeCSV(header []string, rows [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+
+	w := csv.NewWriter(&buf)
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if err := w.Write(sanitizeCSVRow(row)); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// sanitizeCSVRow 스프레드시트 수식 주입 방지 (=, +, -, @로 시작하는 텍스트는 작은따옴표 접두)
+func sanitizeCSVRow(row []string) []string {
+	out := make([]string, len(row))
+	for i, cell := range row {
+		if cell != "" && !isNumericCell(cell) {
+			switch cell[0] {
+			case '=', '+', '-', '@', '\t', '\r':
+				cell = "'" + cell
+			}
+		}
+		out[i] = cell
+	}
+	return out
+}
+
+func isNumericCell(cell string) bool {
+	_, err := strconv.ParseFloat(cell, 64)
+	return err == nil
+}
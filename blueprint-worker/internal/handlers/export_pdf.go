@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// 최소 구성 PDF (A4, Courier 고정폭, 텍스트 줄만) - 외부 라이브러리 없이 표 형태 명세서를 출력
+const (
+	pdfPageWidth    = 595
+	pdfPageHeight   = 842
+	pdfMargin       = 50
+	pdfFontSize     = 9
+	pdfLeading      = 12
+	pdfLinesPerPage = (pdfPageHeight - 2*pdfMargin) / pdfLeading
+)
+
+// encodePDF 텍스트 줄을 페이지로 나눠 PDF로 변환 (ASCII만 출력, 그 외 문자는 ?)
+func encodePDF(title string, lines []string) []byte {
+	var pages [][]string
+	for start := 0; start < len(lines) || start == 0; start += pdfLinesPerPage {
+		end := start + pdfLinesPerPage
+		if end > len(lines) {
+			end = len(lines)
+		}
+		pages = append(pages, lines[start:end])
+	}
+
+	// 객체 번호: 1 카탈로그, 2 페이지 목록, 3 글꼴, 4 문서 정보, 이후 페이지마다 (페이지, 내용)
+	const firstPageObject = 5
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"", // 페이지 목록은 페이지 번호가 정해진 뒤 채움
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Title (%s) /Producer (BLUEPRINT) >>", pdfEscape(title)),
+	}
+
+	kids := make([]string, 0, len(pages))
+	for i, page := range pages {
+		pageObject := firstPageObject + i*2
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageObject))
+
+		var content strings.Builder
+		fmt.Fprintf(&content, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", pdfFontSize, pdfLeading, pdfMargin, pdfPageHeight-pdfMargin)
+		for _, line := range page {
+			fmt.Fprintf(&content, "(%s) '\n", pdfEscape(line))
+		}
+		content.WriteString("ET")
+
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+				pdfPageWidth, pdfPageHeight, pageObject+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages))
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 4 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+// pdfEscape 문자열 리터럴 이스케이프 (괄호, 역슬래시) 및 ASCII 밖 문자 치환
+func pdfEscape(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"blueprint-module/pkg/models"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// feeStatementStakeEntries 명세서에 넣는 분쟁 스테이킹 원장 종류 (API 서버 자동 생성 대상과 같음)
+var feeStatementStakeEntries = []models.LedgerEntryType{
+	models.LedgerArbitrationStakeLock,
+	models.LedgerArbitrationStakeReturn,
+	models.LedgerMediationStakeReturn,
+	models.LedgerArbitrationFee,
+	models.LedgerJurorSlash,
+}
+
+// feeTotals 결제 통화별 거래 수수료 합계
+type feeTotals struct {
+	trades    int
+	buyerFee  int64
+	sellerFee int64
+}
+
+// mentorPoolShare 마일스톤별 멘토 풀 적립분 (USDC 센트)
+type mentorPoolShare struct {
+	milestoneID uint
+	fees        int64
+	percentage  float64
+	amount      int64
+}
+
+// feeStatementDocument 월간 수수료 명세서 PDF (반환값: 내용, 반영된 체결/원장 건수)
+//
+// 멘토 풀 적립분은 체결 시점처럼 사용자가 낸 수수료(USDC 환산) × 풀 적립 비율로 다시 계산한다.
+// USDC 외 결제 통화는 명세서 생성 시점 환율을 쓴다.
+func feeStatementDocument(db *gorm.DB, export *models.DataExport) ([]byte, int, error) {
+	from := time.Date(export.Year, time.Month(export.Month), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	userID := export.UserID
+
+	var trades []models.Trade
+	if err := db.Where("(buyer_id = ? OR seller_id = ?) AND created_at >= ? AND created_at < ?", userID, userID, from, to).
+		Order("created_at ASC, id ASC").
+		Find(&trades).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to load trades: %w", err)
+	}
+
+	var stakes []models.WalletLedgerEntry
+	if err := db.Where("user_id = ? AND entry_type IN ? AND created_at >= ? AND created_at < ?", userID, feeStatementStakeEntries, from, to).
+		Order("created_at ASC, id ASC").
+		Find(&stakes).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to load arbitration stakes: %w", err)
+	}
+
+	rates, err := usdcRates(db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 결제 통화별 수수료, 마일스톤별 USDC 환산 수수료
+	totals := make(map[models.LedgerCurrency]*feeTotals)
+	milestoneFees := make(map[uint]int64)
+	for _, trade := range trades {
+		currency := trade.QuoteCurrency.OrUSDC()
+		total, ok := totals[currency]
+		if !ok {
+			total = &feeTotals{}
+			totals[currency] = total
+		}
+		total.trades++
+
+		var fee int64
+		if trade.BuyerID == userID {
+			total.buyerFee += trade.BuyerFee
+			fee += trade.BuyerFee
+		}
+		if trade.SellerID == userID {
+			total.sellerFee += trade.SellerFee
+			fee += trade.SellerFee
+		}
+		milestoneFees[trade.MilestoneID] += int64(math.Floor(float64(fee) * rates[currency]))
+	}
+
+	shares, err := mentorPoolShares(db, milestoneFees)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 분쟁 스테이킹 (BLUEPRINT): 잠금, 반환, 수수료/차감으로 귀속
+	var staked, returned, forfeited int64
+	for _, entry := range stakes {
+		switch entry.EntryType {
+		case models.LedgerArbitrationStakeLock:
+			staked += entry.LockedAmount
+		case models.LedgerArbitrationStakeReturn, models.LedgerMediationStakeReturn:
+			returned += entry.Amount
+		case models.LedgerArbitrationFee:
+			forfeited -= entry.LockedAmount
+		case models.LedgerJurorSlash:
+			forfeited -= entry.Amount
+		}
+	}
+
+	lines := []string{
+		"BLUEPRINT - Monthly Fee Statement",
+		"",
+		fmt.Sprintf("Statement No. : FS-%d%02d-%06d", export.Year, export.Month, export.ID),
+		fmt.Sprintf("Account ID    : %d", userID),
+		fmt.Sprintf("Period (UTC)  : %s - %s", from.Format("2006-01-02"), to.AddDate(0, 0, -1).Format("2006-01-02")),
+		fmt.Sprintf("Issued (UTC)  : %s", time.Now().UTC().Format("2006-01-02 15:04")),
+		"",
+		"1. Trading fees",
+		fmt.Sprintf("   %-10s %8s %18s %18s %18s", "Currency", "Trades", "Buyer fees", "Seller fees", "Total"),
+	}
+	currencies := make([]string, 0, len(totals))
+	for currency := range totals {
+		currencies = append(currencies, string(currency))
+	}
+	sort.Strings(currencies)
+	for _, name := range currencies {
+		currency := models.LedgerCurrency(name)
+		total := totals[currency]
+		lines = append(lines, fmt.Sprintf("   %-10s %8d %18s %18s %18s", strings.ToUpper(name), total.trades,
+			statementAmount(currency, total.buyerFee), statementAmount(currency, total.sellerFee),
+			statementAmount(currency, total.buyerFee+total.sellerFee)))
+	}
+	if len(currencies) == 0 {
+		lines = append(lines, "   No trades in this period.")
+	}
+
+	lines = append(lines,
+		"",
+		"2. Mentor pool contributions (share of your fees, USDC)",
+		fmt.Sprintf("   %-14s %18s %8s %18s", "Milestone", "Fees", "Pool %", "Contribution"),
+	)
+	var poolTotal int64
+	for _, share := range shares {
+		poolTotal += share.amount
+		lines = append(lines, fmt.Sprintf("   %-14s %18s %7.1f%% %18s", fmt.Sprintf("#%d", share.milestoneID),
+			statementAmount(models.LedgerCurrencyUSDC, share.fees), share.percentage,
+			statementAmount(models.LedgerCurrencyUSDC, share.amount)))
+	}
+	if len(shares) == 0 {
+		lines = append(lines, "   No mentor pool contributions in this period.")
+	} else {
+		lines = append(lines, fmt.Sprintf("   %-14s %18s %8s %18s", "Total", "", "", statementAmount(models.LedgerCurrencyUSDC, poolTotal)))
+	}
+
+	lines = append(lines,
+		"",
+		"3. Arbitration stakes (BLUEPRINT)",
+		fmt.Sprintf("   %-32s %18s", "Staked", statementAmount(models.LedgerCurrencyBlueprint, staked)),
+		fmt.Sprintf("   %-32s %18s", "Returned", statementAmount(models.LedgerCurrencyBlueprint, returned)),
+		fmt.Sprintf("   %-32s %18s", "Forfeited (fees and slashes)", statementAmount(models.LedgerCurrencyBlueprint, forfeited)),
+		"",
+		"Amounts are in each account currency. Mentor pool contributions",
+		"for non-USDC markets use the exchange rate at the time of issue.",
+	)
+
+	title := fmt.Sprintf("BLUEPRINT Fee Statement %d-%02d", export.Year, export.Month)
+	return encodePDF(title, lines), len(trades) + len(stakes), nil
+}
+
+// mentorPoolShares 마일스톤별 수수료 중 멘토 풀로 적립된 몫 (풀이 없는 마일스톤은 적립되지 않음)
+func mentorPoolShares(db *gorm.DB, milestoneFees map[uint]int64) ([]mentorPoolShare, error) {
+	if len(milestoneFees) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint, 0, len(milestoneFees))
+	for id := range milestoneFees {
+		ids = append(ids, id)
+	}
+
+	var pools []models.MentorPool
+	if err := db.Unscoped().Where("milestone_id IN ?", ids).Order("milestone_id ASC").Find(&pools).Error; err != nil {
+		return nil, fmt.Errorf("failed to load mentor pools: %w", err)
+	}
+
+	shares := make([]mentorPoolShare, 0, len(pools))
+	for _, pool := range pools {
+		fees := milestoneFees[pool.MilestoneID]
+		if fees <= 0 {
+			continue
+		}
+		shares = append(shares, mentorPoolShare{
+			milestoneID: pool.MilestoneID,
+			fees:        fees,
+			percentage:  pool.FeePercentage,
+			amount:      int64(float64(fees) * pool.FeePercentage / 100),
+		})
+	}
+	return shares, nil
+}
+
+// usdcRates 통화별 1 단위의 USDC 가치 (USDC는 1)
+func usdcRates(db *gorm.DB) (map[models.LedgerCurrency]float64, error) {
+	var rates []models.ExchangeRate
+	if err := db.Find(&rates).Error; err != nil {
+		return nil, fmt.Errorf("failed to load exchange rates: %w", err)
+	}
+
+	result := map[models.LedgerCurrency]float64{models.LedgerCurrencyUSDC: 1}
+	for _, rate := range rates {
+		result[rate.Currency] = rate.Rate
+	}
+	return result, nil
+}
+
+// statementAmount 명세서 금액 표시 (USDC/결제 통화는 소수 둘째 자리, BLUEPRINT는 정수)
+func statementAmount(currency models.LedgerCurrency, amount int64) string {
+	switch currency {
+	case models.LedgerCurrencyUSDC:
+		return fmt.Sprintf("$%.2f", float64(amount)/100)
+	case models.LedgerCurrencyBlueprint:
+		return fmt.Sprintf("%d BLUEPRINT", amount)
+	default:
+		return fmt.Sprintf("%.2f %s", float64(amount)/100, strings.ToUpper(string(currency)))
+	}
+}
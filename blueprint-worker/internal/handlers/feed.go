@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"blueprint-module/pkg/database"
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/queue"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+const feedInsertBatchSize = 500
+
+// FeedHandler 프로젝트 이벤트를 팔로워 알림함으로 전파하는 핸들러
+type FeedHandler struct{}
+
+// NewFeedHandler FeedHandler 인스턴스 생성
+func NewFeedHandler() *FeedHandler {
+	return &FeedHandler{}
+}
+
+// StartFeedWorker 프로젝트 이벤트 큐 소비 시작
+func (h *FeedHandler) StartFeedWorker(ctx context.Context) error {
+	log.Println("👀 Feed worker started")
+
+	return queue.ConsumeJobsWithContext(ctx, "feed_queue", "feed_workers", "feed_worker_1", h.handleFeedJob)
+}
+
+func (h *FeedHandler) handleFeedJob(jobData map[string]interface{}) error {
+	jobType, ok := jobData["type"].(string)
+	if !ok {
+		return fmt.Errorf("missing job type")
+	}
+
+	switch jobType {
+	case "project_event":
+		return h.fanOutProjectEvent(jobData)
+	default:
+		return fmt.Errorf("unknown feed job type: %s", jobType)
+	}
+}
+
+// fanOutProjectEvent 프로젝트 팔로워 전원에게 인앱 알림 생성
+func (h *FeedHandler) fanOutProjectEvent(jobData map[string]interface{}) error {
+	projectID, ok := jobData["project_id"].(float64)
+	if !ok || projectID == 0 {
+		return fmt.Errorf("missing project_id")
+	}
+
+	event, _ := jobData["event"].(string)
+	title, _ := jobData["title"].(string)
+	message, _ := jobData["message"].(string)
+	link, _ := jobData["link"].(string)
+	actorID, _ := jobData["actor_id"].(float64)
+	milestoneID, _ := jobData["milestone_id"].(float64)
+
+	data := map[string]interface{}{
+		"event":      event,
+		"project_id": uint(projectID),
+	}
+	if milestoneID > 0 {
+		data["milestone_id"] = uint(milestoneID)
+	}
+	if extra, ok := jobData["data"].(map[string]interface{}); ok {
+		for k, v := range extra {
+			data[k] = v
+		}
+	}
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event data: %w", err)
+	}
+
+	db := database.GetDB()
+	followers := db.Model(&models.ProjectWatch{}).
+		Where("project_id = ? AND user_id != ?", uint(projectID), uint(actorID))
+
+	// 증거 제출은 포지션 보유자에게 이미 직접 알림이 가므로 중복 제외
+	if models.ProjectEventType(event) == models.ProjectEventProofSubmitted && milestoneID > 0 {
+		followers = followers.Where("user_id NOT IN (?)",
+			db.Model(&models.Position{}).
+				Select("user_id").
+				Where("milestone_id = ? AND quantity > 0", uint(milestoneID)))
+	}
+
+	var followerIDs []uint
+	if err := followers.Pluck("user_id", &followerIDs).Error; err != nil {
+		return fmt.Errorf("failed to load followers: %w", err)
+	}
+	if len(followerIDs) == 0 {
+		return nil
+	}
+
+	notifications := make([]models.Notification, 0, len(followerIDs))
+	for _, userID := range followerIDs {
+		notifications = append(notifications, models.Notification{
+			UserID:   userID,
+			Type:     models.NotificationTypeProjectUpdate,
+			Priority: models.NotificationPriorityLow,
+			Title:    title,
+			Message:  message,
+			Link:     link,
+			Data:     string(dataJSON),
+		})
+	}
+
+	// 재시도 시 일부만 저장되지 않도록 단일 트랜잭션으로 저장
+	if err := db.CreateInBatches(&notifications, feedInsertBatchSize).Error; err != nil {
+		return fmt.Errorf("failed to create follower notifications: %w", err)
+	}
+
+	log.Printf("👀 Project %d %s event delivered to %d followers", uint(projectID), event, len(notifications))
+	return nil
+}
@@ -1,21 +1,37 @@
 package handlers
 
 import (
+	"blueprint-module/pkg/database"
+	"blueprint-module/pkg/models"
 	"blueprint-module/pkg/queue"
 	"blueprint-worker/internal/config"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
+	"time"
 )
 
+// evidencePathPattern API 서버 FileService가 발급한 증거 저장 경로 (category/key)
+var evidencePathPattern = regexp.MustCompile(`^arbitration_evidence/[a-f0-9]{32}$`)
+
+// verificationDocPathPattern API 서버 FileService가 발급한 검증 서류 저장 경로
+var verificationDocPathPattern = regexp.MustCompile(`^verification_docs/[a-f0-9]{32}$`)
+
 type FileHandler struct {
-	config *config.Config
+	config  *config.Config
+	scanner MalwareScanner
 }
 
 func NewFileHandler(cfg *config.Config) *FileHandler {
 	return &FileHandler{
-		config: cfg,
+		config:  cfg,
+		scanner: NewMalwareScanner(cfg.Scanner),
 	}
 }
 
@@ -43,87 +59,86 @@ func (h *FileHandler) handleFileJob(jobData map[string]interface{}) error {
 		return h.uploadVerificationDoc(jobData)
 	case "process_image":
 		return h.processImage(jobData)
+	case "scan_arbitration_evidence":
+		return h.scanArbitrationEvidence(jobData)
+	case "process_proof_file":
+		proofID, ok := jobData["proof_id"].(float64)
+		if !ok || proofID == 0 {
+			return fmt.Errorf("missing proof_id")
+		}
+		return h.processProofFile(uint(proofID))
 	default:
 		return fmt.Errorf("unknown file job type: %s", jobType)
 	}
 }
 
+// uploadVerificationDoc API 서버가 저장한 전문/학력 서류의 무결성(해시) 확인 + 악성코드 검사 후 검사 상태 기록
 func (h *FileHandler) uploadVerificationDoc(jobData map[string]interface{}) error {
-	// 필수 필드 추출
-	userID, ok := jobData["user_id"]
+	userID, ok := jobData["user_id"].(float64)
 	if !ok {
 		return fmt.Errorf("missing user_id")
 	}
 
 	docType, ok := jobData["doc_type"].(string)
-	if !ok {
-		return fmt.Errorf("missing doc_type")
+	if !ok || (docType != "professional" && docType != "education") {
+		return fmt.Errorf("invalid doc_type: %v", jobData["doc_type"])
 	}
 
-	filename, ok := jobData["filename"].(string)
+	// API 서버가 발급한 무작위 저장 키 사용 (사용자 입력 파일명은 경로에 사용하지 않음)
+	storageKey, ok := jobData["storage_key"].(string)
 	if !ok {
-		return fmt.Errorf("missing filename")
+		return fmt.Errorf("missing storage_key")
 	}
 
-	// 파일 저장 경로 생성
-	relativePath := fmt.Sprintf("verification/%v/%s/%s", userID, docType, filename)
-
-	switch h.config.Storage.Provider {
-	case "local":
-		return h.saveToLocal(relativePath, jobData)
-	case "s3":
-		return h.saveToS3(relativePath, jobData)
-	case "r2":
-		return h.saveToR2(relativePath, jobData)
-	default:
-		return fmt.Errorf("unsupported storage provider: %s", h.config.Storage.Provider)
+	db := database.GetDB()
+	var verification models.UserVerification
+	if err := db.Where("user_id = ?", uint(userID)).First(&verification).Error; err != nil {
+		return fmt.Errorf("failed to load verification for user %d: %w", uint(userID), err)
 	}
-}
 
-func (h *FileHandler) saveToLocal(relativePath string, jobData map[string]interface{}) error {
-	// 로컬 파일 시스템에 저장
-	fullPath := filepath.Join(h.config.Storage.LocalPath, relativePath)
-
-	// 디렉토리 생성
-	dir := filepath.Dir(fullPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
+	docPath, docHash, scanStatus := verification.ProfessionalDocPath, verification.ProfessionalDocSHA256, verification.ProfessionalDocScanStatus
+	if docType == "education" {
+		docPath, docHash, scanStatus = verification.EducationDocPath, verification.EducationDocSHA256, verification.EducationDocScanStatus
+	}
+	// 그 사이 서류를 다시 제출했거나 이미 검사한 작업이면 건너뜀
+	if docPath != storageKey || scanStatus != models.DocumentScanPending {
+		return nil
 	}
 
-	// 실제 환경에서는 여기서 multipart form에서 파일 데이터를 읽어와 저장
-	// 지금은 메타데이터만 처리
-	log.Printf("✅ File would be saved to: %s", fullPath)
-
-	// 파일 메타데이터를 데이터베이스에 저장하는 로직 추가 필요
-	// 예: 파일 경로, 크기, 타입 등을 user_verification 테이블에 업데이트
-
-	return nil
-}
-
-func (h *FileHandler) saveToS3(relativePath string, jobData map[string]interface{}) error {
-	// AWS S3에 파일 업로드
-	// 실제 환경에서는 AWS SDK를 사용
-	log.Printf("✅ File would be uploaded to S3: s3://%s/%s", h.config.Storage.Bucket, relativePath)
-
-	// TODO: AWS S3 SDK 구현
-	// - AWS 자격 증명 설정
-	// - S3 클라이언트 생성
-	// - 파일 업로드
-	// - 업로드된 URL 반환
-
-	return nil
-}
-
-func (h *FileHandler) saveToR2(relativePath string, jobData map[string]interface{}) error {
-	// Cloudflare R2에 파일 업로드 (S3 호환 API 사용)
-	log.Printf("✅ File would be uploaded to R2: %s", relativePath)
-
-	// TODO: Cloudflare R2 SDK 구현 (S3 호환)
-	// - R2 자격 증명 설정
-	// - S3 호환 클라이언트 생성
-	// - 파일 업로드
+	status, detail := models.DocumentScanClean, ""
+	if !verificationDocPathPattern.MatchString(docPath) {
+		status, detail = models.DocumentScanFailed, "invalid storage path"
+	} else {
+		fullPath := filepath.Join(h.config.Storage.LocalPath, docPath)
+		hash, err := fileSHA256(fullPath)
+		switch {
+		case err != nil:
+			log.Printf("❌ User %d %s document unreadable: %v", verification.UserID, docType, err)
+			status, detail = models.DocumentScanFailed, "file unreadable"
+		case hash != docHash:
+			log.Printf("❌ User %d %s document hash mismatch", verification.UserID, docType)
+			status, detail = models.DocumentScanFailed, "hash mismatch"
+		default:
+			scan, err := h.scanFile(fullPath)
+			if err != nil {
+				return fmt.Errorf("failed to scan %s document of user %d: %w", docType, verification.UserID, err) // 검사기 장애는 재시도
+			}
+			if scan.Infected {
+				log.Printf("🦠 User %d %s document rejected by virus scan: %s", verification.UserID, docType, scan.Signature)
+				status, detail = models.DocumentScanInfected, scan.Signature
+				if err := h.quarantine(fullPath); err != nil {
+					log.Printf("❌ Failed to quarantine user %d %s document: %v", verification.UserID, docType, err)
+				}
+			}
+		}
+	}
 
-	return nil
+	now := time.Now()
+	return db.Model(&verification).Updates(map[string]interface{}{
+		docType + "_doc_scan_status": status,
+		docType + "_doc_scan_detail": detail,
+		docType + "_doc_scanned_at":  now,
+	}).Error
 }
 
 func (h *FileHandler) processImage(jobData map[string]interface{}) error {
@@ -145,6 +160,68 @@ func (h *FileHandler) processImage(jobData map[string]interface{}) error {
 	return nil
 }
 
+// scanArbitrationEvidence 분쟁 증거 파일 무결성(해시) 확인 + 악성코드 검사 후 열람 허용 여부 기록
+func (h *FileHandler) scanArbitrationEvidence(jobData map[string]interface{}) error {
+	evidenceID, ok := jobData["evidence_id"].(float64)
+	if !ok {
+		return fmt.Errorf("invalid evidence_id")
+	}
+
+	db := database.GetDB()
+	var evidence models.ArbitrationEvidence
+	if err := db.First(&evidence, uint(evidenceID)).Error; err != nil {
+		return fmt.Errorf("failed to load evidence %d: %w", uint(evidenceID), err)
+	}
+	if evidence.ScanStatus != models.EvidenceScanPending {
+		return nil
+	}
+
+	status := models.EvidenceScanClean
+	if !evidencePathPattern.MatchString(evidence.FilePath) {
+		status = models.EvidenceScanFailed
+	} else {
+		fullPath := filepath.Join(h.config.Storage.LocalPath, evidence.FilePath)
+		hash, err := fileSHA256(fullPath)
+		switch {
+		case err != nil:
+			log.Printf("❌ Evidence %d unreadable: %v", evidence.ID, err)
+			status = models.EvidenceScanFailed
+		case hash != evidence.SHA256:
+			log.Printf("❌ Evidence %d hash mismatch", evidence.ID)
+			status = models.EvidenceScanFailed
+		default:
+			scan, err := h.scanFile(fullPath)
+			if err != nil {
+				return fmt.Errorf("failed to scan evidence %d: %w", evidence.ID, err) // 검사기 장애는 재시도
+			}
+			if scan.Infected {
+				log.Printf("🦠 Evidence %d rejected by virus scan: %s", evidence.ID, scan.Signature)
+				status = models.EvidenceScanInfected
+			}
+		}
+	}
+
+	now := time.Now()
+	return db.Model(&evidence).Updates(map[string]interface{}{
+		"scan_status": status,
+		"scanned_at":  now,
+	}).Error
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 // 파일 유효성 검사
 func (h *FileHandler) validateFile(jobData map[string]interface{}) error {
 	contentType, ok := jobData["content_type"].(string)
@@ -177,9 +254,15 @@ func (h *FileHandler) validateFile(jobData map[string]interface{}) error {
 	return nil
 }
 
-// 바이러스 검사 (선택사항)
-func (h *FileHandler) scanForVirus(filePath string) error {
-	// 실제 환경에서는 ClamAV 등을 사용한 바이러스 검사
-	log.Printf("🔍 Virus scan completed for: %s", filePath)
-	return nil
+// scanFile 저장된 파일 악성코드 검사 (CLAMAV_ADDRESS 미설정 시 건너뜀)
+func (h *FileHandler) scanFile(filePath string) (ScanResult, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return ScanResult{}, err
+	}
+	defer f.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	return h.scanner.Scan(ctx, f)
 }
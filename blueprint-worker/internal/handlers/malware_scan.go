@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"blueprint-worker/internal/config"
+)
+
+// clamdChunkSize INSTREAM 전송 단위 (clamd StreamMaxLength보다 작아야 함)
+const clamdChunkSize = 64 << 10
+
+// ScanResult 악성코드 검사 결과
+type ScanResult struct {
+	Infected  bool
+	Signature string // 감지된 시그니처 이름 (감염 시)
+	Skipped   bool   // 검사기가 설정되지 않아 검사하지 않음
+}
+
+// MalwareScanner 업로드 파일 악성코드 검사기
+//
+// 검사기 장애(연결 실패 등)는 error로 돌려주고, 호출한 작업은 재시도 정책에 따라 다시 시도된다.
+type MalwareScanner interface {
+	Scan(ctx context.Context, r io.Reader) (ScanResult, error)
+}
+
+// NewMalwareScanner CLAMAV_ADDRESS가 있으면 clamd 검사기, 없으면 검사를 건너뛰는 검사기
+func NewMalwareScanner(cfg config.ScannerConfig) MalwareScanner {
+	if cfg.ClamAVAddress == "" {
+		return skipScanner{}
+	}
+
+	network, address := "tcp", cfg.ClamAVAddress
+	if scheme, rest, found := strings.Cut(cfg.ClamAVAddress, "://"); found {
+		network, address = scheme, rest
+	}
+	timeout := time.Duration(cfg.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &clamdScanner{network: network, address: address, timeout: timeout}
+}
+
+// skipScanner 검사기 미설정 (개발 환경)
+type skipScanner struct{}
+
+func (skipScanner) Scan(context.Context, io.Reader) (ScanResult, error) {
+	return ScanResult{Skipped: true}, nil
+}
+
+// clamdScanner clamd INSTREAM 프로토콜 검사기
+type clamdScanner struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+func (s *clamdScanner) Scan(ctx context.Context, r io.Reader) (ScanResult, error) {
+	dialer := net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return ScanResult{}, fmt.Errorf("clamd 연결 실패: %w", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(s.timeout))
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return ScanResult{}, fmt.Errorf("clamd 요청 실패: %w", err)
+	}
+
+	// [4바이트 길이][데이터] 청크를 보내고 길이 0으로 끝냄
+	buf := make([]byte, clamdChunkSize)
+	size := make([]byte, 4)
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, err := conn.Write(size); err != nil {
+				return ScanResult{}, fmt.Errorf("clamd 전송 실패: %w", err)
+			}
+			if _, err := conn.Write(buf[:n]); err != nil {
+				return ScanResult{}, fmt.Errorf("clamd 전송 실패: %w", err)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return ScanResult{}, fmt.Errorf("파일 읽기 실패: %w", readErr)
+		}
+	}
+	binary.BigEndian.PutUint32(size, 0)
+	if _, err := conn.Write(size); err != nil {
+		return ScanResult{}, fmt.Errorf("clamd 전송 실패: %w", err)
+	}
+
+	reply, err := io.ReadAll(conn)
+	if err != nil {
+		return ScanResult{}, fmt.Errorf("clamd 응답 읽기 실패: %w", err)
+	}
+	return parseClamdReply(string(reply))
+}
+
+// parseClamdReply "stream: OK" / "stream: <시그니처> FOUND" / "... ERROR" 응답 해석
+func parseClamdReply(reply string) (ScanResult, error) {
+	reply = strings.TrimRight(reply, "\x00\n")
+	_, status, _ := strings.Cut(reply, ": ")
+
+	switch {
+	case status == "OK":
+		return ScanResult{}, nil
+	case strings.HasSuffix(status, " FOUND"):
+		return ScanResult{Infected: true, Signature: strings.TrimSuffix(status, " FOUND")}, nil
+	default:
+		return ScanResult{}, fmt.Errorf("clamd 검사 오류: %s", reply)
+	}
+}
@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"blueprint-module/pkg/database"
+	"blueprint-module/pkg/internalapi"
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/secrets"
+	"blueprint-worker/internal/config"
+	"blueprint-worker/internal/payout"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PayoutHandler 승인된 창작자 지급 요청을 예정 시각에 지급 계좌로 송금
+//
+// 큐 대신 주기적으로 scheduled_for가 지난 approved 요청을 가져와 processing으로 선점한 뒤 송금한다.
+// 성공하면 paid로 바꾸고 잠금을 차감(payout_disbursed), 실패하면 재시도 시각을 미루고, 재시도 횟수를
+// 넘기면 failed로 바꿔 잠금을 풀어 준다(payout_release). processing에 남은 요청은 송금 결과를 알 수
+// 없으므로 자동으로 다시 보내지 않는다.
+type PayoutHandler struct {
+	config        config.PayoutConfig
+	provider      payout.Provider
+	providerErr   error
+	encryptionKey []byte
+	internalAPI   *internalapi.Client // 송금 전 잠금 잔액 확인 (nil이면 생략)
+}
+
+// NewPayoutHandler 생성자
+func NewPayoutHandler(cfg *config.Config) *PayoutHandler {
+	provider, err := payout.NewProvider(cfg.Payout)
+	return &PayoutHandler{
+		config:        cfg.Payout,
+		provider:      provider,
+		providerErr:   err,
+		encryptionKey: secrets.DeriveKey(cfg.Payout.EncryptionSecret),
+	}
+}
+
+// SetInternalAPI API 서버 내부 gRPC 클라이언트 주입 (송금 전 지갑 잠금액 확인)
+func (h *PayoutHandler) SetInternalAPI(client *internalapi.Client) {
+	h.internalAPI = client
+}
+
+// StartPayoutWorker 송금 예정 요청을 주기적으로 처리 (ctx 취소 시 종료)
+func (h *PayoutHandler) StartPayoutWorker(ctx context.Context) error {
+	// 송금 제공자가 없으면 잘못 paid 처리하지 않도록 워커를 띄우지 않음
+	if h.providerErr != nil {
+		return fmt.Errorf("payout provider %q unavailable: %w", h.config.Provider, h.providerErr)
+	}
+
+	interval := time.Duration(h.config.IntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	log.Printf("💸 Payout worker started (provider: %s, every %v)", h.provider.Name(), interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := h.disburseDue(ctx, time.Now()); err != nil {
+			log.Printf("❌ Payout disbursement failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("💸 Payout worker stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// disburseDue 예정 시각이 지난 승인 요청을 오래된 순으로 송금
+func (h *PayoutHandler) disburseDue(ctx context.Context, now time.Time) error {
+	db := database.GetDB()
+	batchSize := h.config.BatchSize
+	if batchSize <= 0 {
+		batchSize = 50
+	}
+
+	var payouts []models.CreatorPayout
+	if err := db.Preload("PayoutAccount").
+		Where("status = ? AND scheduled_for <= ?", models.CreatorPayoutApproved, now).
+		Order("scheduled_for ASC, id ASC").
+		Limit(batchSize).
+		Find(&payouts).Error; err != nil {
+		return fmt.Errorf("failed to load due payouts: %w", err)
+	}
+
+	for i := range payouts {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err := h.disburse(ctx, db, &payouts[i]); err != nil {
+			log.Printf("❌ Payout %d: %v", payouts[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// disburse 요청 한 건을 선점해 송금하고 결과 반영
+func (h *PayoutHandler) disburse(ctx context.Context, db *gorm.DB, p *models.CreatorPayout) error {
+	if err := h.checkLocked(ctx, p); err != nil {
+		return err
+	}
+
+	// 다른 워커 인스턴스나 관리자 반려와 겹치지 않도록 approved일 때만 선점
+	result := db.Model(&models.CreatorPayout{}).
+		Where("id = ? AND status = ?", p.ID, models.CreatorPayoutApproved).
+		Updates(map[string]interface{}{
+			"status":   models.CreatorPayoutProcessing,
+			"attempts": gorm.Expr("attempts + 1"),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to claim payout: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil
+	}
+	p.Attempts++
+
+	reference, err := h.send(ctx, p)
+	if err != nil {
+		return h.recordFailure(db, p, err)
+	}
+
+	now := time.Now()
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.CreatorPayout{}).Where("id = ?", p.ID).
+			Updates(map[string]interface{}{
+				"status":             models.CreatorPayoutPaid,
+				"provider_reference": reference,
+				"failure_reason":     "",
+				"paid_at":            now,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to mark payout paid: %w", err)
+		}
+		if err := postPayoutLedger(tx, p, models.LedgerPayoutDisbursed, 0, -p.Amount, "창작자 지급 송금 완료 ("+reference+")"); err != nil {
+			return err
+		}
+		log.Printf("💸 Payout %d paid: $%.2f to user %d (%s)", p.ID, float64(p.Amount)/100, p.UserID, reference)
+		return nil
+	})
+}
+
+// checkLocked 지급액이 지갑에 잠겨 있는지 API 서버에 확인 (모자라면 선점하지 않고 다음 주기로 미룸)
+func (h *PayoutHandler) checkLocked(ctx context.Context, p *models.CreatorPayout) error {
+	if h.internalAPI == nil {
+		return nil
+	}
+	balance, err := h.internalAPI.GetWalletBalance(ctx, &internalapi.GetWalletBalanceRequest{UserId: uint32(p.UserID)})
+	if err != nil {
+		return fmt.Errorf("failed to check wallet balance: %w", err)
+	}
+	if balance.GetUsdcLockedBalance() < p.Amount {
+		return fmt.Errorf("locked balance $%.2f is below payout amount $%.2f, deferring",
+			float64(balance.GetUsdcLockedBalance())/100, float64(p.Amount)/100)
+	}
+	return nil
+}
+
+func (h *PayoutHandler) send(ctx context.Context, p *models.CreatorPayout) (string, error) {
+	account := p.PayoutAccount
+	if account == nil {
+		return "", fmt.Errorf("payout account %d not found", p.PayoutAccountID)
+	}
+	destination, err := secrets.Decrypt(h.encryptionKey, account.DetailsEncrypted)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt payout account: %w", err)
+	}
+
+	return h.provider.Send(ctx, payout.Transfer{
+		PayoutID:      p.ID,
+		Amount:        p.Amount,
+		AccountType:   string(account.Type),
+		BankName:      account.BankName,
+		AccountHolder: account.AccountHolder,
+		CryptoNetwork: account.CryptoNetwork,
+		Destination:   destination,
+	})
+}
+
+// recordFailure 재시도 가능하면 approved로 되돌려 미루고, 횟수를 넘기면 failed로 바꿔 잠금 해제
+func (h *PayoutHandler) recordFailure(db *gorm.DB, p *models.CreatorPayout, sendErr error) error {
+	reason := sendErr.Error()
+	if len(reason) > 500 {
+		reason = reason[:500]
+	}
+
+	if p.Attempts < h.config.MaxAttempts {
+		retryAt := time.Now().Add(time.Duration(h.config.RetryDelayMinute) * time.Minute)
+		if err := db.Model(&models.CreatorPayout{}).Where("id = ?", p.ID).
+			Updates(map[string]interface{}{
+				"status":         models.CreatorPayoutApproved,
+				"scheduled_for":  retryAt,
+				"failure_reason": reason,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to reschedule payout: %w", err)
+		}
+		log.Printf("⚠️ Payout %d attempt %d failed, retrying at %v: %v", p.ID, p.Attempts, retryAt.Format(time.RFC3339), sendErr)
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.CreatorPayout{}).Where("id = ?", p.ID).
+			Updates(map[string]interface{}{
+				"status":         models.CreatorPayoutFailed,
+				"failure_reason": reason,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to mark payout failed: %w", err)
+		}
+		if err := postPayoutLedger(tx, p, models.LedgerPayoutRelease, p.Amount, -p.Amount, "창작자 지급 실패: "+reason); err != nil {
+			return err
+		}
+		log.Printf("❌ Payout %d failed after %d attempts, released $%.2f to user %d: %v", p.ID, p.Attempts, float64(p.Amount)/100, p.UserID, sendErr)
+		return nil
+	})
+}
+
+// postPayoutLedger 지갑 잔액 변경과 원장 기록 (API 서버 postLedgerEntry와 같은 방식)
+func postPayoutLedger(tx *gorm.DB, p *models.CreatorPayout, entryType models.LedgerEntryType, amount, locked int64, memo string) error {
+	updates := map[string]interface{}{}
+	if amount != 0 {
+		updates["usdc_balance"] = gorm.Expr("usdc_balance + ?", amount)
+	}
+	if locked != 0 {
+		updates["usdc_locked_balance"] = gorm.Expr("usdc_locked_balance + ?", locked)
+	}
+	result := tx.Model(&models.UserWallet{}).Where("user_id = ?", p.UserID).Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update wallet: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("wallet of user %d not found", p.UserID)
+	}
+
+	return tx.Create(&models.WalletLedgerEntry{
+		UserID:        p.UserID,
+		Currency:      models.LedgerCurrencyUSDC,
+		EntryType:     entryType,
+		Amount:        amount,
+		LockedAmount:  locked,
+		ReferenceType: "creator_payout",
+		ReferenceID:   p.ID,
+		Memo:          memo,
+	}).Error
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"blueprint-module/pkg/models"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// personalDataSection 개인 데이터 내보내기의 한 항목 (테이블 단위)
+type personalDataSection struct {
+	name string
+	load func(db *gorm.DB, userID uint) (interface{}, int, error)
+}
+
+// personalDataSections 사용자 ID로 조회되는 개인 데이터 목록
+//
+// 비밀값(비밀번호, 토큰, 암호화된 시크릿 등)은 모델의 json:"-" 태그로 제외된다.
+var personalDataSections = []personalDataSection{
+	{"profile", loadRows[models.UserProfile]("user_id = ?")},
+	{"verification", loadRows[models.UserVerification]("user_id = ?")},
+	{"identities", loadRows[models.UserIdentity]("user_id = ?")},
+	{"kyc", loadRows[models.KYCVerification]("user_id = ?")},
+	{"roles", loadRows[models.UserRole]("user_id = ?")},
+	{"wallet", loadRows[models.UserWallet]("user_id = ?")},
+	{"wallet_ledger", loadRows[models.WalletLedgerEntry]("user_id = ?")},
+	{"orders", loadRows[models.Order]("user_id = ?")},
+	{"trades", loadRows[models.Trade]("buyer_id = ? OR seller_id = ?")},
+	{"positions", loadRows[models.Position]("user_id = ?")},
+	{"parlays", loadRows[models.Parlay]("user_id = ?")},
+	{"projects", loadRows[models.Project]("user_id = ?")},
+	{"project_watches", loadRows[models.ProjectWatch]("user_id = ?")},
+	{"mentor_stakes", loadRows[models.MentorStake]("user_id = ?")},
+	{"staking_pools", loadRows[models.StakingPool]("user_id = ?")},
+	{"juror_qualification", loadRows[models.JurorQualification]("user_id = ?")},
+	{"arbitration_cases", loadRows[models.ArbitrationCase]("plaintiff_id = ? OR defendant_id = ?")},
+	{"arbitration_votes", loadRows[models.ArbitrationVote]("juror_id = ?")},
+	{"referrals", loadRows[models.Referral]("referrer_id = ? OR referee_id = ?")},
+	{"notifications", loadRows[models.Notification]("user_id = ?")},
+	{"activities", loadRows[models.ActivityLog]("user_id = ?")},
+	{"api_keys", loadRows[models.APIKey]("user_id = ?")},
+	{"webhooks", loadRows[models.WebhookEndpoint]("user_id = ?")},
+	{"push_subscriptions", loadRows[models.PushSubscription]("user_id = ?")},
+	{"github_connection", loadRows[models.GitHubConnection]("user_id = ?")},
+	{"account_merges", loadRows[models.AccountMerge]("target_user_id = ? OR source_user_id = ?")},
+	{"account_deletions", loadRows[models.AccountDeletion]("user_id = ?")},
+}
+
+// loadRows 조건의 모든 ? 자리에 사용자 ID를 넣어 조회
+func loadRows[T any](where string) func(db *gorm.DB, userID uint) (interface{}, int, error) {
+	return func(db *gorm.DB, userID uint) (interface{}, int, error) {
+		args := make([]interface{}, 0, 2)
+		for i := 0; i < countPlaceholders(where); i++ {
+			args = append(args, userID)
+		}
+
+		var rows []T
+		if err := db.Where(where, args...).Order("id ASC").Find(&rows).Error; err != nil {
+			return nil, 0, err
+		}
+		return rows, len(rows), nil
+	}
+}
+
+func countPlaceholders(where string) int {
+	count := 0
+	for _, r := range where {
+		if r == '?' {
+			count++
+		}
+	}
+	return count
+}
+
+// personalDataDocument 계정 정보와 모든 항목을 하나의 JSON 문서로 생성 (반환값: 내용, 전체 레코드 수)
+func personalDataDocument(db *gorm.DB, userID uint) ([]byte, int, error) {
+	var user models.User
+	if err := db.First(&user, userID).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to load user %d: %w", userID, err)
+	}
+
+	data := make(map[string]interface{}, len(personalDataSections))
+	total := 1
+	for _, section := range personalDataSections {
+		rows, count, err := section.load(db, userID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to load %s: %w", section.name, err)
+		}
+		data[section.name] = rows
+		total += count
+	}
+
+	content, err := json.MarshalIndent(map[string]interface{}{
+		"generated_at": time.Now().UTC().Format(time.RFC3339),
+		"account":      user,
+		"data":         data,
+	}, "", "  ")
+	if err != nil {
+		return nil, 0, err
+	}
+	return content, total, nil
+}
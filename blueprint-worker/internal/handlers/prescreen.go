@@ -0,0 +1,429 @@
+package handlers
+
+import (
+	"blueprint-module/pkg/database"
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/queue"
+	"blueprint-worker/internal/config"
+	"blueprint-worker/internal/netguard"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+const (
+	prescreenFetchTimeout = 10 * time.Second
+	prescreenMaxImageSize = 10 << 20 // 10MB
+
+	// 항목별 가중치 (해당 항목이 있는 경우만 합산 후 정규화)
+	prescreenWeightURL = 0.40
+	prescreenWeightOCR = 0.35
+	prescreenWeightAPI = 0.25
+
+	// 점검 가능한 증거가 없을 때의 중립 점수
+	prescreenNeutralScore = 0.5
+)
+
+// ProofOCR 이미지에서 텍스트 추출
+type ProofOCR interface {
+	ExtractText(ctx context.Context, image []byte, mimeType string) (string, error)
+}
+
+// PrescreenHandler 제출된 증거를 기계적으로 점검해 신뢰도 점수를 매기는 핸들러
+//
+// 점수는 검증인 대기열 정렬에만 쓰이며 승인/거절 판정은 여전히 검증인 투표로 결정된다.
+type PrescreenHandler struct {
+	config     *config.Config
+	ocr        ProofOCR
+	httpClient *http.Client
+}
+
+// NewPrescreenHandler PrescreenHandler 인스턴스 생성
+func NewPrescreenHandler(cfg *config.Config) *PrescreenHandler {
+	var ocr ProofOCR
+	if cfg.Prescreen.OCRProvider == "openai" && cfg.Prescreen.OpenAIAPIKey != "" {
+		ocr = &openAIVisionOCR{
+			apiKey: cfg.Prescreen.OpenAIAPIKey,
+			model:  cfg.Prescreen.OCRModel,
+			client: &http.Client{Timeout: time.Minute},
+		}
+	}
+
+	return &PrescreenHandler{
+		config:     cfg,
+		ocr:        ocr,
+		httpClient: netguard.NewHTTPClient(prescreenFetchTimeout),
+	}
+}
+
+// StartPrescreenWorker 증거 사전 검토 큐 소비 시작
+func (h *PrescreenHandler) StartPrescreenWorker(ctx context.Context) error {
+	log.Println("🤖 Proof prescreen worker started")
+
+	return queue.ConsumeJobsWithContext(ctx, "proof_prescreen_queue", "prescreen_workers", "prescreen_worker_1", h.handlePrescreenJob)
+}
+
+func (h *PrescreenHandler) handlePrescreenJob(jobData map[string]interface{}) error {
+	jobType, ok := jobData["type"].(string)
+	if !ok {
+		return fmt.Errorf("missing job type")
+	}
+
+	switch jobType {
+	case "prescreen_proof":
+		proofID, ok := jobData["proof_id"].(float64)
+		if !ok || proofID == 0 {
+			return fmt.Errorf("missing proof_id")
+		}
+		return h.prescreenProof(uint(proofID))
+	default:
+		return fmt.Errorf("unknown prescreen job type: %s", jobType)
+	}
+}
+
+// prescreenProof 외부 링크 접근성, 스크린샷 OCR, API 데이터 정합성을 점검해 점수 저장
+func (h *PrescreenHandler) prescreenProof(proofID uint) error {
+	db := database.GetDB()
+
+	var proof models.MilestoneProof
+	if err := db.Preload("Milestone").First(&proof, proofID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("⚠️ Prescreen skipped: proof %d not found", proofID)
+			return nil
+		}
+		return fmt.Errorf("failed to load proof %d: %w", proofID, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	report := models.ProofMetadata{}
+	var weighted, totalWeight float64
+	addCheck := func(name string, weight float64, result map[string]interface{}) {
+		report[name] = result
+		if score, ok := result["score"].(float64); ok {
+			weighted += score * weight
+			totalWeight += weight
+		}
+	}
+
+	if proof.ExternalURL != "" {
+		addCheck("external_url", prescreenWeightURL, h.checkExternalURL(ctx, proof.ExternalURL))
+	}
+	if proof.FileURL != "" && proofFileUsable(&proof) && isImageProof(&proof) {
+		addCheck("screenshot_ocr", prescreenWeightOCR, h.checkScreenshot(ctx, &proof))
+	}
+	if len(proof.APIData) > 0 {
+		addCheck("api_data", prescreenWeightAPI, checkAPIData(proof.APIData, time.Now()))
+	}
+
+	score := prescreenNeutralScore
+	if totalWeight > 0 {
+		score = math.Round(weighted/totalWeight*1000) / 1000
+	} else {
+		report["note"] = "기계적으로 점검할 수 있는 증거가 없습니다"
+	}
+
+	now := time.Now()
+	if err := db.Model(&models.MilestoneProof{}).Where("id = ?", proof.ID).Updates(map[string]interface{}{
+		"prescreen_status": models.PrescreenStatusCompleted,
+		"prescreen_score":  score,
+		"prescreen_report": report,
+		"prescreened_at":   now,
+	}).Error; err != nil {
+		return fmt.Errorf("failed to save prescreen result for proof %d: %w", proof.ID, err)
+	}
+
+	log.Printf("🤖 Prescreened proof %d: confidence %.2f", proof.ID, score)
+	return nil
+}
+
+// checkExternalURL 외부 링크가 열리는지 확인 (HEAD 실패 시 GET 재시도)
+func (h *PrescreenHandler) checkExternalURL(ctx context.Context, rawURL string) map[string]interface{} {
+	result := map[string]interface{}{"url": rawURL}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		result["score"] = 0.0
+		result["error"] = "http(s) URL이 아닙니다"
+		return result
+	}
+
+	resp, err := h.fetch(ctx, http.MethodHead, rawURL)
+	if err != nil || resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented {
+		resp, err = h.fetch(ctx, http.MethodGet, rawURL)
+	}
+	if err != nil {
+		result["score"] = 0.0
+		result["reachable"] = false
+		result["error"] = err.Error()
+		return result
+	}
+	resp.Body.Close()
+
+	result["status_code"] = resp.StatusCode
+	result["content_type"] = resp.Header.Get("Content-Type")
+	result["reachable"] = resp.StatusCode < 400
+	switch {
+	case resp.StatusCode < 300:
+		result["score"] = 1.0
+	case resp.StatusCode < 400:
+		result["score"] = 0.8
+	default:
+		result["score"] = 0.1
+	}
+	return result
+}
+
+func (h *PrescreenHandler) fetch(ctx context.Context, method, rawURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "BlueprintProofPrescreen/1.0")
+	return h.httpClient.Do(req)
+}
+
+// checkScreenshot 스크린샷 텍스트를 추출해 마일스톤/증거 제목 키워드와 비교
+func (h *PrescreenHandler) checkScreenshot(ctx context.Context, proof *models.MilestoneProof) map[string]interface{} {
+	result := map[string]interface{}{}
+	if h.ocr == nil {
+		result["skipped"] = "OCR이 설정되지 않았습니다"
+		return result
+	}
+
+	image, mimeType, err := h.loadProofFile(ctx, proof.FileURL)
+	if err != nil {
+		result["score"] = 0.0
+		result["error"] = err.Error()
+		return result
+	}
+
+	text, err := h.ocr.ExtractText(ctx, image, mimeType)
+	if err != nil {
+		// OCR 장애는 증거 탓이 아니므로 점수에 반영하지 않음
+		result["error"] = err.Error()
+		return result
+	}
+
+	keywords := extractKeywords(proof.Milestone.Title + " " + proof.Title)
+	lowered := strings.ToLower(text)
+	var matched []string
+	for _, keyword := range keywords {
+		if strings.Contains(lowered, keyword) {
+			matched = append(matched, keyword)
+		}
+	}
+
+	result["text_length"] = len([]rune(text))
+	result["keywords"] = keywords
+	result["matched_keywords"] = matched
+	result["excerpt"] = truncateRunes(text, 200)
+
+	switch {
+	case strings.TrimSpace(text) == "":
+		result["score"] = 0.1
+	case len(keywords) == 0:
+		result["score"] = 0.5
+	default:
+		result["score"] = 0.3 + 0.7*float64(len(matched))/float64(len(keywords))
+	}
+	return result
+}
+
+// loadProofFile 업로드 파일 로드 (로컬 저장소면 디스크에서, 아니면 URL로 다운로드)
+func (h *PrescreenHandler) loadProofFile(ctx context.Context, fileURL string) ([]byte, string, error) {
+	parsed, err := url.Parse(fileURL)
+	if err != nil {
+		return nil, "", fmt.Errorf("잘못된 파일 URL: %w", err)
+	}
+
+	if h.config.Storage.Provider == "local" {
+		// 업로드 URL은 .../files/{category}/{key} 형태
+		category, key := path.Base(path.Dir(parsed.Path)), path.Base(parsed.Path)
+		local := filepath.Join(h.config.Storage.LocalPath, category, key)
+		if data, err := os.ReadFile(local); err == nil {
+			if len(data) > prescreenMaxImageSize {
+				return nil, "", fmt.Errorf("이미지가 너무 큽니다")
+			}
+			return data, http.DetectContentType(data), nil
+		}
+	}
+
+	resp, err := h.fetch(ctx, http.MethodGet, fileURL)
+	if err != nil {
+		return nil, "", fmt.Errorf("파일 다운로드 실패: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, "", fmt.Errorf("파일 다운로드 실패 (HTTP %d)", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, prescreenMaxImageSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("파일 다운로드 실패: %w", err)
+	}
+	if len(data) > prescreenMaxImageSize {
+		return nil, "", fmt.Errorf("이미지가 너무 큽니다")
+	}
+	return data, http.DetectContentType(data), nil
+}
+
+// checkAPIData 연동 데이터의 기본 정합성 점검 (음수 수치, 미래 시각, 빈 값)
+func checkAPIData(data models.ProofMetadata, now time.Time) map[string]interface{} {
+	var issues []string
+	var walk func(prefix string, value interface{})
+	walk = func(prefix string, value interface{}) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for k, child := range v {
+				walk(prefix+k+".", child)
+			}
+		case []interface{}:
+			for i, child := range v {
+				walk(fmt.Sprintf("%s%d.", prefix, i), child)
+			}
+		case float64:
+			if v < 0 {
+				issues = append(issues, fmt.Sprintf("%s 값이 음수입니다", strings.TrimSuffix(prefix, ".")))
+			}
+		case string:
+			if ts, err := time.Parse(time.RFC3339, v); err == nil && ts.After(now.Add(time.Hour)) {
+				issues = append(issues, fmt.Sprintf("%s 시각이 미래입니다", strings.TrimSuffix(prefix, ".")))
+			}
+		case nil:
+			issues = append(issues, fmt.Sprintf("%s 값이 비어 있습니다", strings.TrimSuffix(prefix, ".")))
+		}
+	}
+	walk("", map[string]interface{}(data))
+
+	return map[string]interface{}{
+		"fields": len(data),
+		"issues": issues,
+		"score":  math.Max(0, 1-0.25*float64(len(issues))),
+	}
+}
+
+func isImageProof(proof *models.MilestoneProof) bool {
+	if proof.ProofType == models.ProofTypeScreenshot || proof.ProofType == models.ProofTypeCertificate {
+		return true
+	}
+	if strings.HasPrefix(proof.FileContentType, "image/") {
+		return true
+	}
+	switch strings.ToLower(path.Ext(proof.FileURL)) {
+	case ".png", ".jpg", ".jpeg", ".webp", ".gif":
+		return true
+	}
+	return false
+}
+
+// proofFileUsable 파일 처리 파이프라인을 통과했거나 처리 대상이 아닌(이전 제출) 파일인지
+func proofFileUsable(proof *models.MilestoneProof) bool {
+	return proof.FileStatus == "" || proof.FileStatus == models.ProofFileStatusReady
+}
+
+// extractKeywords 제목에서 비교용 키워드 추출 (2글자 이상, 소문자, 중복 제거)
+func extractKeywords(text string) []string {
+	seen := map[string]bool{}
+	var keywords []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(word)) < 2 || seen[word] {
+			continue
+		}
+		seen[word] = true
+		keywords = append(keywords, word)
+	}
+	return keywords
+}
+
+func truncateRunes(text string, max int) string {
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) <= max {
+		return string(runes)
+	}
+	return string(runes[:max]) + "…"
+}
+
+// openAIVisionOCR OpenAI 비전 모델을 이용한 텍스트 추출
+type openAIVisionOCR struct {
+	apiKey string
+	model  string
+	client *http.Client
+}
+
+func (o *openAIVisionOCR) ExtractText(ctx context.Context, image []byte, mimeType string) (string, error) {
+	if !strings.HasPrefix(mimeType, "image/") {
+		return "", fmt.Errorf("이미지 파일이 아닙니다 (%s)", mimeType)
+	}
+
+	body := map[string]interface{}{
+		"model":      o.model,
+		"max_tokens": 1000,
+		"messages": []map[string]interface{}{
+			{
+				"role": "user",
+				"content": []map[string]interface{}{
+					{"type": "text", "text": "이미지에 보이는 모든 텍스트를 설명 없이 그대로 추출해주세요. 텍스트가 없으면 빈 응답을 주세요."},
+					{"type": "image_url", "image_url": map[string]string{
+						"url": "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(image),
+					}},
+				},
+			},
+		},
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/chat/completions", bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+o.apiKey)
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("OCR API 호출 실패: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("OCR API 오류 (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+
+	var result struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("OCR 응답 디코딩 실패: %w", err)
+	}
+	if len(result.Choices) == 0 {
+		return "", nil
+	}
+	return result.Choices[0].Message.Content, nil
+}
@@ -0,0 +1,562 @@
+package handlers
+
+import (
+	"blueprint-module/pkg/database"
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/queue"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	proofFileCategory    = "proofs"
+	quarantineDir        = "quarantine" // 감염 파일 격리 (메타데이터가 없어 다운로드 API로 열리지 않음)
+	proofProcessTimeout  = 2 * time.Minute
+	sanitizedJPEGQuality = 90
+	thumbnailJPEGQuality = 80
+)
+
+// proofPathPattern API 서버 FileService가 발급한 증거 저장 경로 (category/key)
+var proofPathPattern = regexp.MustCompile(`^proofs/[a-f0-9]{32}$`)
+
+// errPDFRendererUnavailable PDF 렌더러(pdftoppm)가 설치되지 않음
+var errPDFRendererUnavailable = errors.New("PDF 렌더러를 찾을 수 없습니다")
+
+// proofExtensionTypes 원본 파일 확장자별로 허용하는 내용 형식 (업로드 API 허용 확장자와 동일)
+var proofExtensionTypes = map[string][]string{
+	".jpg":  {"image/jpeg"},
+	".jpeg": {"image/jpeg"},
+	".png":  {"image/png"},
+	".gif":  {"image/gif"},
+	".pdf":  {"application/pdf"},
+	".txt":  {"text/plain"},
+	".doc":  {"application/msword"},
+	".docx": {"application/zip"},
+	".zip":  {"application/zip"},
+	".rar":  {"application/x-rar-compressed"},
+	".mp4":  {"video/mp4", "video/quicktime"},
+	".mov":  {"video/quicktime", "video/mp4"},
+	".avi":  {"video/avi"},
+}
+
+// storedFileMeta API 서버 FileService 메타데이터 형식 ({category}/{key}.json)
+type storedFileMeta struct {
+	Category     string    `json:"category"`
+	Key          string    `json:"key"`
+	OriginalName string    `json:"original_name"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	UploadedAt   time.Time `json:"uploaded_at"`
+}
+
+// processProofFile 증거 파일 처리: 형식 확인 → 악성코드 검사 → EXIF 제거/썸네일/PDF 미리보기
+// 처리 결과와 관계없이 마지막에 AI 사전 검토를 요청한다 (통과하지 못한 파일은 사전 검토에서 제외됨)
+func (h *FileHandler) processProofFile(proofID uint) error {
+	db := database.GetDB()
+
+	var proof models.MilestoneProof
+	if err := db.First(&proof, proofID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("⚠️ Proof file processing skipped: proof %d not found", proofID)
+			return nil
+		}
+		return fmt.Errorf("failed to load proof %d: %w", proofID, err)
+	}
+	if proof.FileStatus != models.ProofFileStatusPending {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), proofProcessTimeout)
+	defer cancel()
+
+	report := models.ProofMetadata{}
+	updates, err := h.runProofPipeline(ctx, &proof, report)
+	if err != nil {
+		return err // 검사기 장애 등 일시적 오류는 재시도
+	}
+
+	now := time.Now()
+	updates["file_report"] = report
+	updates["file_processed_at"] = now
+	if err := db.Model(&models.MilestoneProof{}).Where("id = ?", proof.ID).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to save file processing result for proof %d: %w", proof.ID, err)
+	}
+	log.Printf("📎 Processed proof %d file: %v", proof.ID, updates["file_status"])
+
+	job := map[string]interface{}{
+		"type":      "prescreen_proof",
+		"proof_id":  proof.ID,
+		"timestamp": now.Unix(),
+	}
+	if err := queue.PublishJob("proof_prescreen_queue", job); err != nil {
+		log.Printf("⚠️ Failed to request prescreen for proof %d: %v", proof.ID, err)
+	}
+	return nil
+}
+
+// runProofPipeline 처리 단계 실행 후 저장할 컬럼 반환 (단계별 결과는 report에 기록)
+func (h *FileHandler) runProofPipeline(ctx context.Context, proof *models.MilestoneProof, report models.ProofMetadata) (map[string]interface{}, error) {
+	finish := func(status models.ProofFileStatus, reason string) map[string]interface{} {
+		if reason != "" {
+			report["error"] = reason
+		}
+		return map[string]interface{}{"file_status": status}
+	}
+
+	relPath, ok := proofStoragePath(proof.FileURL)
+	if !ok {
+		return finish(models.ProofFileStatusRejected, "이 서버에 업로드된 증거 파일이 아닙니다"), nil
+	}
+	fullPath := filepath.Join(h.config.Storage.LocalPath, relPath)
+
+	meta, err := readStoredFileMeta(fullPath)
+	if err != nil {
+		return finish(models.ProofFileStatusFailed, "파일 메타데이터를 읽을 수 없습니다"), nil
+	}
+	data, err := readFileLimited(fullPath, h.config.Media.MaxFileSize)
+	if err != nil {
+		return finish(models.ProofFileStatusRejected, err.Error()), nil
+	}
+
+	// 1. 형식 확인 (클라이언트 Content-Type/확장자가 아닌 내용 기준)
+	contentType := detectProofContentType(data)
+	report["detected_type"] = contentType
+	updates := finish(models.ProofFileStatusReady, "")
+	updates["file_content_type"] = contentType
+	if problem := checkProofFileType(meta.OriginalName, contentType); problem != "" {
+		updates["file_status"] = models.ProofFileStatusRejected
+		report["error"] = problem
+		return updates, nil
+	}
+
+	// 2. 악성코드 검사
+	scan, err := h.scanner.Scan(ctx, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan proof %d: %w", proof.ID, err)
+	}
+	switch {
+	case scan.Infected:
+		report["malware_scan"] = "infected"
+		report["signature"] = scan.Signature
+		if err := h.quarantine(fullPath); err != nil {
+			log.Printf("❌ Failed to quarantine proof %d file: %v", proof.ID, err)
+		}
+		updates["file_status"] = models.ProofFileStatusInfected
+		return updates, nil
+	case scan.Skipped:
+		report["malware_scan"] = "skipped"
+	default:
+		report["malware_scan"] = "clean"
+	}
+
+	// 3. 미리보기 (실패해도 파일 자체는 사용 가능)
+	var thumbSource image.Image
+	switch contentType {
+	case "image/jpeg", "image/png":
+		sanitized, img, err := sanitizeImage(data, contentType)
+		if err != nil {
+			report["exif_stripped"] = false
+			report["preview_error"] = err.Error()
+			break
+		}
+		sanitizedURL, err := h.storeArtifact(sanitized, contentType, meta.OriginalName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store sanitized image for proof %d: %w", proof.ID, err)
+		}
+		updates["file_url"] = sanitizedURL
+		report["exif_stripped"] = true
+		thumbSource = img
+	case "image/gif":
+		if img, err := gif.Decode(bytes.NewReader(data)); err == nil {
+			thumbSource = img
+		}
+	case "application/pdf":
+		pages, err := h.renderPDFPages(ctx, fullPath)
+		if err != nil {
+			report["preview_error"] = err.Error()
+			break
+		}
+		var previewURLs models.StringList
+		for i, page := range pages {
+			pageURL, err := h.storeArtifact(page, "image/png", fmt.Sprintf("page-%d.png", i+1))
+			if err != nil {
+				return nil, fmt.Errorf("failed to store preview page for proof %d: %w", proof.ID, err)
+			}
+			previewURLs = append(previewURLs, pageURL)
+		}
+		updates["preview_urls"] = previewURLs
+		if len(pages) > 0 {
+			thumbSource, _, _ = image.Decode(bytes.NewReader(pages[0]))
+		}
+	}
+
+	if thumbSource != nil {
+		var thumb bytes.Buffer
+		if err := jpeg.Encode(&thumb, thumbnail(thumbSource, h.config.Media.ThumbnailSize), &jpeg.Options{Quality: thumbnailJPEGQuality}); err == nil {
+			thumbURL, err := h.storeArtifact(thumb.Bytes(), "image/jpeg", "thumbnail.jpg")
+			if err != nil {
+				return nil, fmt.Errorf("failed to store thumbnail for proof %d: %w", proof.ID, err)
+			}
+			updates["thumbnail_url"] = thumbURL
+		}
+	}
+
+	// 위치 정보 등이 담긴 원본은 지우고 정리된 사본으로 교체 (결과물을 모두 저장한 뒤)
+	if _, replaced := updates["file_url"]; replaced {
+		os.Remove(fullPath + ".json")
+		os.Remove(fullPath)
+	}
+	return updates, nil
+}
+
+// proofStoragePath 증거 파일 URL(.../files/proofs/{key})에서 저장 경로 추출
+func proofStoragePath(fileURL string) (string, bool) {
+	parsed, err := url.Parse(fileURL)
+	if err != nil {
+		return "", false
+	}
+	relPath := path.Base(path.Dir(parsed.Path)) + "/" + path.Base(parsed.Path)
+	return relPath, proofPathPattern.MatchString(relPath)
+}
+
+func readStoredFileMeta(fullPath string) (*storedFileMeta, error) {
+	raw, err := os.ReadFile(fullPath + ".json")
+	if err != nil {
+		return nil, err
+	}
+	var meta storedFileMeta
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+func readFileLimited(fullPath string, limit int64) ([]byte, error) {
+	f, err := os.Open(fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("파일을 열 수 없습니다")
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("파일을 읽을 수 없습니다")
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("파일이 너무 큽니다 (최대 %dMB)", limit>>20)
+	}
+	return data, nil
+}
+
+// detectProofContentType 내용 기반 형식 감지 (http.DetectContentType이 모르는 문서/실행 파일 보강)
+func detectProofContentType(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("MZ")):
+		return "application/x-msdownload"
+	case bytes.HasPrefix(data, []byte("\x7fELF")):
+		return "application/x-executable"
+	case bytes.HasPrefix(data, []byte("#!")):
+		return "text/x-shellscript"
+	case bytes.HasPrefix(data, []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}):
+		return "application/msword" // OLE 복합 문서 (.doc)
+	case len(data) >= 12 && string(data[4:8]) == "ftyp" && string(data[8:12]) == "qt  ":
+		return "video/quicktime"
+	}
+
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(data))
+	if err != nil {
+		return "application/octet-stream"
+	}
+	return mediaType
+}
+
+// checkProofFileType 원본 확장자와 감지한 형식이 맞는지 확인 (문제가 없으면 빈 문자열)
+func checkProofFileType(originalName, contentType string) string {
+	ext := strings.ToLower(filepath.Ext(originalName))
+	allowed, ok := proofExtensionTypes[ext]
+	if !ok {
+		return fmt.Sprintf("허용되지 않는 확장자입니다 (%s)", ext)
+	}
+	for _, candidate := range allowed {
+		if candidate == contentType {
+			return ""
+		}
+	}
+	return fmt.Sprintf("파일 내용(%s)이 확장자(%s)와 다릅니다", contentType, ext)
+}
+
+// quarantine 감염 파일을 다운로드할 수 없는 격리 디렉토리로 이동
+func (h *FileHandler) quarantine(fullPath string) error {
+	dir := filepath.Join(h.config.Storage.LocalPath, quarantineDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	os.Remove(fullPath + ".json")
+	return os.Rename(fullPath, filepath.Join(dir, filepath.Base(fullPath)))
+}
+
+// storeArtifact 처리 결과물을 API 서버 FileService와 같은 형식으로 저장하고 다운로드 URL 반환
+func (h *FileHandler) storeArtifact(data []byte, contentType, originalName string) (string, error) {
+	randBytes := make([]byte, 16)
+	if _, err := rand.Read(randBytes); err != nil {
+		return "", err
+	}
+	key := hex.EncodeToString(randBytes)
+
+	dir := filepath.Join(h.config.Storage.LocalPath, proofFileCategory)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	dst, err := os.OpenFile(filepath.Join(dir, key), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0640)
+	if err != nil {
+		return "", err
+	}
+	if _, err := dst.Write(data); err != nil {
+		dst.Close()
+		return "", err
+	}
+	if err := dst.Close(); err != nil {
+		return "", err
+	}
+
+	meta, err := json.Marshal(storedFileMeta{
+		Category:     proofFileCategory,
+		Key:          key,
+		OriginalName: originalName,
+		ContentType:  contentType,
+		Size:         int64(len(data)),
+		UploadedAt:   time.Now(),
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, key+".json"), meta, 0640); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/%s/%s", h.config.Storage.PublicURL, proofFileCategory, key), nil
+}
+
+// renderPDFPages 앞쪽 페이지를 PNG로 렌더링 (pdftoppm)
+func (h *FileHandler) renderPDFPages(ctx context.Context, pdfPath string) ([][]byte, error) {
+	renderer, err := exec.LookPath(h.config.Media.PDFRenderer)
+	if err != nil {
+		return nil, errPDFRendererUnavailable
+	}
+
+	dir, err := os.MkdirTemp("", "proof-pdf-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	pages := h.config.Media.PreviewPages
+	if pages <= 0 {
+		pages = 1
+	}
+	cmd := exec.CommandContext(ctx, renderer, "-png", "-r", "72", "-f", "1", "-l", strconv.Itoa(pages), pdfPath, filepath.Join(dir, "page"))
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("PDF 렌더링 실패: %v %s", err, strings.TrimSpace(string(output)))
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "page-*.png"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var rendered [][]byte
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		rendered = append(rendered, data)
+	}
+	return rendered, nil
+}
+
+// sanitizeImage 이미지를 다시 인코딩해 EXIF/텍스트 메타데이터 제거 (JPEG 회전 정보는 픽셀에 반영)
+func sanitizeImage(data []byte, contentType string) ([]byte, image.Image, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("이미지를 해석할 수 없습니다: %w", err)
+	}
+
+	var out bytes.Buffer
+	if contentType == "image/jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+		err = jpeg.Encode(&out, img, &jpeg.Options{Quality: sanitizedJPEGQuality})
+	} else {
+		err = png.Encode(&out, img)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return out.Bytes(), img, nil
+}
+
+// jpegOrientation JPEG APP1(Exif)의 Orientation 태그 (없으면 1)
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 { // 이미지 데이터 시작/끝
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+// exifOrientation TIFF 헤더 + IFD0에서 Orientation(0x0112) 값 읽기
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for n := 0; n < count; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			break
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			if value := int(order.Uint16(tiff[entry+8:])); value >= 1 && value <= 8 {
+				return value
+			}
+			break
+		}
+	}
+	return 1
+}
+
+// applyOrientation EXIF Orientation(2~8)에 맞게 뒤집기/회전
+func applyOrientation(src image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return src
+	}
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			}
+			dst.Set(dx, dy, src.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+// thumbnail 긴 변이 maxSide 이하가 되도록 영역 평균으로 축소 (투명 영역은 흰 배경)
+func thumbnail(src image.Image, maxSide int) image.Image {
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	tw, th := w, h
+	if maxSide > 0 && (w > maxSide || h > maxSide) {
+		if w >= h {
+			tw, th = maxSide, max(1, h*maxSide/w)
+		} else {
+			tw, th = max(1, w*maxSide/h), maxSide
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for ty := 0; ty < th; ty++ {
+		y0, y1 := ty*h/th, max((ty+1)*h/th, ty*h/th+1)
+		for tx := 0; tx < tw; tx++ {
+			x0, x1 := tx*w/tw, max((tx+1)*w/tw, tx*w/tw+1)
+
+			var r, g, b, a, n uint64
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					cr, cg, cb, ca := src.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+					r, g, b, a, n = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca), n+1
+				}
+			}
+			// 알파 premultiplied 값에 흰 배경 합성
+			white := 0xffff - a/n
+			dst.Set(tx, ty, color.RGBA64{
+				R: uint16(r/n + white),
+				G: uint16(g/n + white),
+				B: uint16(b/n + white),
+				A: 0xffff,
+			})
+		}
+	}
+	return dst
+}
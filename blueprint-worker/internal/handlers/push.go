@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"blueprint-module/pkg/database"
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/queue"
+	"blueprint-worker/internal/config"
+	"blueprint-worker/internal/webpush"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// PushHandler Web Push 알림 발송 핸들러
+type PushHandler struct {
+	config *config.Config
+	sender *webpush.Sender
+}
+
+func NewPushHandler(cfg *config.Config) *PushHandler {
+	handler := &PushHandler{config: cfg}
+
+	if cfg.Push.VAPIDPublicKey == "" || cfg.Push.VAPIDPrivateKey == "" {
+		log.Println("⚠️  VAPID keys not configured, web push disabled")
+		return handler
+	}
+
+	sender, err := webpush.NewSender(cfg.Push.VAPIDPublicKey, cfg.Push.VAPIDPrivateKey, cfg.Push.Subject)
+	if err != nil {
+		log.Printf("⚠️  Invalid VAPID keys, web push disabled: %v", err)
+		return handler
+	}
+	handler.sender = sender
+
+	return handler
+}
+
+func (h *PushHandler) StartPushWorker(ctx context.Context) error {
+	log.Println("🔔 Push worker started")
+
+	return queue.ConsumeJobsWithContext(ctx, "push_queue", "push_workers", "push_worker_1", h.handlePushJob)
+}
+
+func (h *PushHandler) handlePushJob(jobData map[string]interface{}) error {
+	jobType, ok := jobData["type"].(string)
+	if !ok {
+		return fmt.Errorf("missing job type")
+	}
+
+	switch jobType {
+	case "send_push":
+		return h.sendPush(jobData)
+	default:
+		return fmt.Errorf("unknown push job type: %s", jobType)
+	}
+}
+
+func (h *PushHandler) sendPush(jobData map[string]interface{}) error {
+	if h.sender == nil {
+		return nil // 설정되지 않은 환경에서는 조용히 무시
+	}
+
+	userID, ok := jobData["user_id"].(float64)
+	if !ok {
+		return fmt.Errorf("missing user_id")
+	}
+
+	title, _ := jobData["title"].(string)
+	body, _ := jobData["body"].(string)
+	link, _ := jobData["url"].(string)
+	notificationID, _ := jobData["notification_id"].(float64)
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"title":           title,
+		"body":            body,
+		"url":             link,
+		"notification_id": uint(notificationID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal push payload: %w", err)
+	}
+
+	db := database.GetDB()
+	var subscriptions []models.PushSubscription
+	if err := db.Where("user_id = ?", uint(userID)).Find(&subscriptions).Error; err != nil {
+		return fmt.Errorf("failed to load push subscriptions: %w", err)
+	}
+
+	sent := 0
+	for _, subscription := range subscriptions {
+		err := h.sender.Send(webpush.Subscription{
+			Endpoint: subscription.Endpoint,
+			P256dh:   subscription.P256dh,
+			Auth:     subscription.Auth,
+		}, payload, 24*time.Hour)
+
+		switch {
+		case errors.Is(err, webpush.ErrSubscriptionGone):
+			// 만료된 구독 정리
+			db.Delete(&subscription)
+			log.Printf("🧹 Removed expired push subscription %d (user %d)", subscription.ID, subscription.UserID)
+		case errors.Is(err, webpush.ErrInvalidEndpoint):
+			// 공인 https 푸시 서비스가 아닌 구독 정리
+			db.Delete(&subscription)
+			log.Printf("🧹 Removed push subscription %d with invalid endpoint (user %d): %v", subscription.ID, subscription.UserID, err)
+		case err != nil:
+			log.Printf("❌ Failed to send push to subscription %d: %v", subscription.ID, err)
+		default:
+			now := time.Now()
+			db.Model(&subscription).Update("last_used_at", &now)
+			sent++
+		}
+	}
+
+	log.Printf("✅ Push sent to %d/%d devices (user %d)", sent, len(subscriptions), uint(userID))
+	return nil
+}
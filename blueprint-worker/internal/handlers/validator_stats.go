@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"blueprint-module/pkg/database"
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/queue"
+	"blueprint-module/pkg/redis"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	validatorStatsQueue = "validator_stats_queue"
+
+	// 최근 투표 중 틀리거나 기권한 투표가 기준 이상이면 검증 참여 제재
+	validatorSuspensionWindow    = 10                  // 제재 판단에 보는 최근 투표 수
+	validatorSuspensionThreshold = 5                   // 제재 기준 (틀린 투표 + 기권)
+	validatorSuspensionPeriod    = 14 * 24 * time.Hour // 제재 기간
+)
+
+// ValidatorStatsHandler 검증 완료 후 참여 검증인의 정확도/합의율/평판 재계산 및 제재 적용
+type ValidatorStatsHandler struct{}
+
+// NewValidatorStatsHandler 생성자
+func NewValidatorStatsHandler() *ValidatorStatsHandler {
+	return &ValidatorStatsHandler{}
+}
+
+// StartValidatorStatsWorker 검증인 통계 큐 소비 시작
+func (h *ValidatorStatsHandler) StartValidatorStatsWorker(ctx context.Context) error {
+	log.Println("🏅 Validator stats worker started")
+
+	return queue.ConsumeJobsWithContext(ctx, validatorStatsQueue, "validator_stats_workers", "validator_stats_worker_1", h.handleValidatorStatsJob)
+}
+
+func (h *ValidatorStatsHandler) handleValidatorStatsJob(jobData map[string]interface{}) error {
+	jobType, ok := jobData["type"].(string)
+	if !ok {
+		return fmt.Errorf("missing job type")
+	}
+
+	switch jobType {
+	case "recalculate_validators":
+		proofID, ok := jobData["proof_id"].(float64)
+		if !ok || proofID == 0 {
+			return fmt.Errorf("missing proof_id")
+		}
+		return h.recalculateProofValidators(uint(proofID))
+	default:
+		return fmt.Errorf("unknown validator stats job type: %s", jobType)
+	}
+}
+
+// validatorVote 완료된 검증의 투표와 최종 결과
+type validatorVote struct {
+	ProofID     uint
+	Vote        string
+	VotedAt     time.Time
+	FinalResult string
+}
+
+// recalculateProofValidators 증거에 투표한 검증인 전원의 통계 재계산
+//
+// 매번 전체 투표 이력으로 다시 계산하므로 같은 작업이 재시도되어도 결과가 같다.
+func (h *ValidatorStatsHandler) recalculateProofValidators(proofID uint) error {
+	db := database.GetDB()
+
+	var userIDs []uint
+	if err := db.Model(&models.ProofValidator{}).
+		Where("proof_id = ?", proofID).
+		Distinct().Pluck("user_id", &userIDs).Error; err != nil {
+		return fmt.Errorf("failed to load proof validators: %w", err)
+	}
+
+	for _, userID := range userIDs {
+		if err := h.recalculateValidator(db, userID); err != nil {
+			return fmt.Errorf("failed to recalculate validator %d: %w", userID, err)
+		}
+		// 프로필 검증 정확도 갱신 요청
+		if err := redis.MarkStatsDirty(redis.StatsScopeUsers, userID); err != nil {
+			log.Printf("⚠️ Failed to mark user stats dirty for validator %d: %v", userID, err)
+		}
+	}
+
+	log.Printf("🏅 Recalculated %d validators for proof %d", len(userIDs), proofID)
+	return nil
+}
+
+// recalculateValidator 검증인의 정확도, 합의율, 평판 점수 갱신 및 반복 오투표/기권 제재
+func (h *ValidatorStatsHandler) recalculateValidator(db *gorm.DB, userID uint) error {
+	var votes []validatorVote
+	if err := db.Table("proof_validators").
+		Select("proof_validators.proof_id, proof_validators.vote, proof_validators.voted_at, milestone_verifications.final_result").
+		Joins("JOIN milestone_verifications ON milestone_verifications.proof_id = proof_validators.proof_id").
+		Where("proof_validators.user_id = ? AND milestone_verifications.final_result IN ?", userID, []string{"approved", "rejected"}).
+		Order("proof_validators.voted_at DESC").
+		Scan(&votes).Error; err != nil {
+		return fmt.Errorf("failed to load votes: %w", err)
+	}
+
+	majorities, err := h.majorityVotes(db, votes)
+	if err != nil {
+		return err
+	}
+
+	var qualification models.ValidatorQualification
+	if err := db.Where("user_id = ?", userID).First(&qualification).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to load qualification: %w", err)
+		}
+		qualification = models.ValidatorQualification{UserID: userID, ReputationScore: 0.5}
+	}
+
+	// 1. 정확도 (최종 결과와 일치) / 합의율 (다수 의견과 일치) - 기권은 분모에서 제외
+	var decided, correct, consensus int
+	for _, vote := range votes {
+		if !isDecidedVote(vote.Vote) {
+			continue
+		}
+		decided++
+		if voteMatchesResult(vote.Vote, vote.FinalResult) {
+			correct++
+		}
+		if majority, ok := majorities[vote.ProofID]; ok && vote.Vote == majority {
+			consensus++
+		}
+	}
+	qualification.TotalVerifications = len(votes)
+	qualification.AccuracyRate = voteRatio(correct, decided)
+	qualification.ConsensusRate = voteRatio(consensus, decided)
+
+	// 2. 평판 점수 - 이력이 없으면 0.5에서 시작하도록 평활화한 정확도/합의율/참여율의 가중 평균
+	smoothed := func(hits, total int) float64 {
+		return float64(hits+1) / float64(total+2)
+	}
+	reputation := 0.5*smoothed(correct, decided) + 0.3*smoothed(consensus, decided) + 0.2*smoothed(decided, len(votes))
+	qualification.ReputationScore = math.Round(reputation*1000) / 1000
+
+	// 3. 제재 - 직전 제재 이후의 최근 투표 중 틀린 투표와 기권이 기준 이상
+	suspended := false
+	if !qualification.IsSuspended {
+		misses := 0
+		recent := 0
+		for _, vote := range votes {
+			if recent == validatorSuspensionWindow {
+				break
+			}
+			if qualification.SuspendedUntil != nil && !vote.VotedAt.After(*qualification.SuspendedUntil) {
+				break
+			}
+			recent++
+			if !isDecidedVote(vote.Vote) || !voteMatchesResult(vote.Vote, vote.FinalResult) {
+				misses++
+			}
+		}
+		if misses >= validatorSuspensionThreshold {
+			until := time.Now().Add(validatorSuspensionPeriod)
+			qualification.IsSuspended = true
+			qualification.SuspendedUntil = &until
+			qualification.SuspensionReason = fmt.Sprintf("최근 검증 %d건 중 %d건 오투표/기권", recent, misses)
+			suspended = true
+		}
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&qualification).Error; err != nil {
+			return fmt.Errorf("failed to save qualification: %w", err)
+		}
+		if !suspended {
+			return nil
+		}
+
+		notification := models.Notification{
+			UserID:   userID,
+			Type:     models.NotificationTypeValidatorAlert,
+			Priority: models.NotificationPriorityHigh,
+			Title:    "검증 참여가 일시 제한되었습니다",
+			Message: fmt.Sprintf("%s. %s까지 새 검증에 참여할 수 없습니다.",
+				qualification.SuspensionReason, qualification.SuspendedUntil.Format("2006-01-02")),
+		}
+		if err := tx.Create(&notification).Error; err != nil {
+			return fmt.Errorf("failed to create suspension notification: %w", err)
+		}
+		log.Printf("⛔ Validator %d suspended until %s: %s", userID, qualification.SuspendedUntil.Format(time.RFC3339), qualification.SuspensionReason)
+		return nil
+	})
+}
+
+// majorityVotes 증거별 다수 의견 (찬반 동수면 제외)
+func (h *ValidatorStatsHandler) majorityVotes(db *gorm.DB, votes []validatorVote) (map[uint]string, error) {
+	proofIDs := make([]uint, 0, len(votes))
+	for _, vote := range votes {
+		proofIDs = append(proofIDs, vote.ProofID)
+	}
+	majorities := make(map[uint]string, len(proofIDs))
+	if len(proofIDs) == 0 {
+		return majorities, nil
+	}
+
+	var tallies []struct {
+		ProofID  uint
+		Approves int
+		Rejects  int
+	}
+	if err := db.Model(&models.ProofValidator{}).
+		Select("proof_id, SUM(CASE WHEN vote = 'approve' THEN 1 ELSE 0 END) AS approves, SUM(CASE WHEN vote = 'reject' THEN 1 ELSE 0 END) AS rejects").
+		Where("proof_id IN ?", proofIDs).
+		Group("proof_id").
+		Scan(&tallies).Error; err != nil {
+		return nil, fmt.Errorf("failed to tally votes: %w", err)
+	}
+
+	for _, tally := range tallies {
+		switch {
+		case tally.Approves > tally.Rejects:
+			majorities[tally.ProofID] = "approve"
+		case tally.Rejects > tally.Approves:
+			majorities[tally.ProofID] = "reject"
+		}
+	}
+	return majorities, nil
+}
+
+func isDecidedVote(vote string) bool {
+	return vote == "approve" || vote == "reject"
+}
+
+func voteMatchesResult(vote, finalResult string) bool {
+	return (vote == "approve" && finalResult == "approved") || (vote == "reject" && finalResult == "rejected")
+}
+
+func voteRatio(hits, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(hits) / float64(total)
+}
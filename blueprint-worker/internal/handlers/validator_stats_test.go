@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"blueprint-module/pkg/database"
+	"blueprint-module/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newValidatorStatsDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&models.ValidatorQualification{}, &models.ProofValidator{}, &models.MilestoneVerification{}, &models.Notification{},
+	))
+
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = previous })
+	return db
+}
+
+// completedProof 검증과 투표 생성 (finalResult가 비어 있으면 진행 중, votes: 검증인 ID -> 투표)
+func completedProof(t *testing.T, db *gorm.DB, proofID uint, finalResult string, votedAt time.Time, votes map[uint]string) {
+	t.Helper()
+	status := models.MilestoneVerificationStatusActive
+	if finalResult != "" {
+		status = models.MilestoneVerificationStatus(finalResult)
+	}
+	require.NoError(t, db.Create(&models.MilestoneVerification{
+		MilestoneID: proofID, ProofID: proofID, Status: status, FinalResult: finalResult,
+	}).Error)
+	for userID, vote := range votes {
+		require.NoError(t, db.Create(&models.ProofValidator{ProofID: proofID, UserID: userID, Vote: vote, VotedAt: votedAt}).Error)
+	}
+}
+
+// TestRecalculateValidatorStats 정확도/합의율은 기권과 미확정 검증을 빼고 계산하며, 재시도해도 같은 결과
+func TestRecalculateValidatorStats(t *testing.T) {
+	db := newValidatorStatsDB(t)
+	handler := NewValidatorStatsHandler()
+	now := time.Now()
+
+	completedProof(t, db, 1, "approved", now.Add(-4*time.Hour), map[uint]string{7: "approve", 8: "approve", 9: "reject"})
+	completedProof(t, db, 2, "rejected", now.Add(-3*time.Hour), map[uint]string{7: "approve", 8: "reject", 9: "reject"})
+	completedProof(t, db, 3, "approved", now.Add(-2*time.Hour), map[uint]string{7: "abstain", 8: "approve"})
+	completedProof(t, db, 4, "", now.Add(-time.Hour), map[uint]string{7: "reject"}) // 아직 확정 전
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, handler.recalculateProofValidators(1))
+
+		var qualification models.ValidatorQualification
+		require.NoError(t, db.Where("user_id = ?", 7).First(&qualification).Error)
+		assert.Equal(t, 3, qualification.TotalVerifications)
+		assert.InDelta(t, 0.5, qualification.AccuracyRate, 1e-9)
+		assert.InDelta(t, 0.5, qualification.ConsensusRate, 1e-9)
+		// 0.5*(2/4) + 0.3*(2/4) + 0.2*(3/5)
+		assert.InDelta(t, 0.52, qualification.ReputationScore, 1e-9)
+		assert.False(t, qualification.IsSuspended)
+	}
+
+	var count int64
+	db.Model(&models.ValidatorQualification{}).Count(&count)
+	assert.Equal(t, int64(3), count, "증거 1에 투표한 검증인만")
+}
+
+// TestValidatorSuspendedAfterRepeatedMisses 최근 투표 중 오투표/기권이 5건 이상이면 제재하고 한 번만 알림
+func TestValidatorSuspendedAfterRepeatedMisses(t *testing.T) {
+	db := newValidatorStatsDB(t)
+	handler := NewValidatorStatsHandler()
+	now := time.Now()
+
+	for i := uint(1); i <= 6; i++ {
+		vote := "reject"
+		if i == 6 {
+			vote = "abstain"
+		}
+		if i == 1 {
+			vote = "approve"
+		}
+		completedProof(t, db, i, "approved", now.Add(-time.Duration(10-i)*time.Hour), map[uint]string{7: vote})
+	}
+
+	require.NoError(t, handler.recalculateProofValidators(6))
+	var qualification models.ValidatorQualification
+	require.NoError(t, db.Where("user_id = ?", 7).First(&qualification).Error)
+	assert.True(t, qualification.IsSuspended)
+	require.NotNil(t, qualification.SuspendedUntil)
+	assert.WithinDuration(t, now.Add(validatorSuspensionPeriod), *qualification.SuspendedUntil, time.Minute)
+	assert.Contains(t, qualification.SuspensionReason, "6건 중 5건")
+
+	require.NoError(t, handler.recalculateProofValidators(6))
+	var notifications int64
+	db.Model(&models.Notification{}).Where("user_id = ? AND type = ?", 7, models.NotificationTypeValidatorAlert).Count(&notifications)
+	assert.Equal(t, int64(1), notifications)
+}
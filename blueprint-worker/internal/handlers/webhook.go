@@ -0,0 +1,327 @@
+package handlers
+
+import (
+	"blueprint-module/pkg/database"
+	"blueprint-module/pkg/models"
+	"blueprint-module/pkg/queue"
+	"blueprint-module/pkg/secrets"
+	"blueprint-worker/internal/config"
+	"blueprint-worker/internal/netguard"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	webhookQueue          = "webhook_queue"
+	webhookTimeout        = 10 * time.Second
+	webhookMaxResponseLog = 1024 // 발송 기록에 남기는 응답 본문 최대 길이
+
+	// 연속 실패가 이 횟수에 도달하면 엔드포인트 자동 비활성화
+	webhookDisableThreshold = 10
+)
+
+// webhookRetryPolicy 발송 재시도 정책: 30s, 1m, 2m, 4m, 8m, 16m 후 실패 확정
+var webhookRetryPolicy = queue.RetryPolicy{
+	MaxRetries: 6,
+	BaseDelay:  30 * time.Second,
+	MaxDelay:   time.Hour,
+}
+
+// WebhookHandler 외부 웹훅 발송 워커
+type WebhookHandler struct {
+	config        *config.Config
+	httpClient    *http.Client
+	encryptionKey []byte
+}
+
+// NewWebhookHandler 생성자
+func NewWebhookHandler(cfg *config.Config) *WebhookHandler {
+	client := netguard.NewHTTPClient(webhookTimeout)
+	// 수신 주소가 리다이렉트로 내부망을 가리키지 않도록 리다이렉트는 따르지 않음
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	return &WebhookHandler{
+		config:        cfg,
+		httpClient:    client,
+		encryptionKey: secrets.DeriveKey(cfg.Webhook.EncryptionSecret),
+	}
+}
+
+// StartWebhookWorker 웹훅 큐 소비 시작
+func (h *WebhookHandler) StartWebhookWorker(ctx context.Context) error {
+	return queue.ConsumeJobsWithPolicy(ctx, webhookQueue, "webhook_workers", "webhook_worker_1", webhookRetryPolicy, h.handleWebhookJob)
+}
+
+func (h *WebhookHandler) handleWebhookJob(jobData map[string]interface{}) error {
+	jobType, _ := jobData["type"].(string)
+
+	switch jobType {
+	case "platform_event":
+		return h.fanOutEvent(jobData)
+	case "deliver_webhook":
+		return h.deliver(jobData)
+	default:
+		log.Printf("⚠️ Unknown webhook job type: %s", jobType)
+		return nil
+	}
+}
+
+// fanOutEvent 플랫폼 이벤트를 구독 중인 엔드포인트별 발송 건으로 분배
+func (h *WebhookHandler) fanOutEvent(jobData map[string]interface{}) error {
+	db := database.GetDB()
+
+	eventID, _ := jobData["event_id"].(string)
+	event, _ := jobData["event"].(string)
+	if eventID == "" || event == "" {
+		return fmt.Errorf("invalid platform event: missing event or event_id")
+	}
+	eventType := models.WebhookEventType(event)
+
+	// 이미 분배된 이벤트면 건너뜀 (재시도 시 중복 발송 방지)
+	var existing int64
+	db.Model(&models.WebhookDelivery{}).Where("event_id = ?", eventID).Count(&existing)
+	if existing > 0 {
+		return nil
+	}
+
+	var projectID uint
+	if v, ok := jobData["project_id"].(float64); ok {
+		projectID = uint(v)
+	}
+
+	userIDs := make([]uint, 0)
+	seen := make(map[uint]bool)
+	if raw, ok := jobData["user_ids"].([]interface{}); ok {
+		for _, v := range raw {
+			id, ok := v.(float64)
+			if !ok || id <= 0 || seen[uint(id)] {
+				continue
+			}
+			seen[uint(id)] = true
+			userIDs = append(userIDs, uint(id))
+		}
+	}
+
+	if len(userIDs) == 0 && projectID == 0 {
+		return nil
+	}
+
+	createdAt := time.Now()
+	if v, ok := jobData["occurred_at"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			createdAt = t
+		}
+	}
+	data, _ := jobData["data"].(map[string]interface{})
+
+	payload, err := json.Marshal(models.WebhookPayload{
+		ID:        eventID,
+		Type:      eventType,
+		CreatedAt: createdAt,
+		Data:      data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	// 사용자 단위 구독은 관련 사용자, 프로젝트 단위 구독은 해당 프로젝트 기준으로 매칭
+	scope := db.Where("project_id IS NULL AND user_id IN ?", userIDs)
+	if projectID > 0 {
+		scope = scope.Or("project_id = ?", projectID)
+	}
+
+	var endpoints []models.WebhookEndpoint
+	if err := db.Where("active = ?", true).Where(scope).Find(&endpoints).Error; err != nil {
+		return fmt.Errorf("failed to load webhook endpoints: %w", err)
+	}
+
+	var deliveries []models.WebhookDelivery
+	for _, endpoint := range endpoints {
+		if !endpoint.HasEvent(eventType) {
+			continue
+		}
+		deliveries = append(deliveries, models.WebhookDelivery{
+			EndpointID: endpoint.ID,
+			EventID:    eventID,
+			EventType:  eventType,
+			Payload:    string(payload),
+			Status:     models.WebhookDeliveryPending,
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	if err := db.Create(&deliveries).Error; err != nil {
+		return fmt.Errorf("failed to create webhook deliveries: %w", err)
+	}
+
+	for _, delivery := range deliveries {
+		job := map[string]interface{}{
+			"type":        "deliver_webhook",
+			"delivery_id": delivery.ID,
+			"timestamp":   time.Now().Unix(),
+		}
+		if err := queue.PublishJob(webhookQueue, job); err != nil {
+			log.Printf("Failed to enqueue webhook delivery %d: %v", delivery.ID, err)
+		}
+	}
+
+	log.Printf("📮 Webhook event %s (%s) fanned out to %d endpoint(s)", eventID, eventType, len(deliveries))
+	return nil
+}
+
+// deliver 발송 건 1개를 서명하여 POST (실패 시 재시도 정책에 따라 에러 반환)
+func (h *WebhookHandler) deliver(jobData map[string]interface{}) error {
+	db := database.GetDB()
+
+	deliveryID, ok := jobData["delivery_id"].(float64)
+	if !ok {
+		return fmt.Errorf("invalid delivery_id")
+	}
+	attempt := 0
+	if v, ok := jobData["retry_count"].(float64); ok {
+		attempt = int(v)
+	}
+
+	var delivery models.WebhookDelivery
+	if err := db.First(&delivery, uint(deliveryID)).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to load webhook delivery: %w", err)
+	}
+	if delivery.Status == models.WebhookDeliverySucceeded {
+		return nil
+	}
+
+	var endpoint models.WebhookEndpoint
+	if err := db.First(&endpoint, delivery.EndpointID).Error; err != nil || !endpoint.Active {
+		h.markDelivery(db, &delivery, map[string]interface{}{
+			"status":        models.WebhookDeliveryFailed,
+			"last_error":    "웹훅이 삭제되었거나 비활성화되었습니다",
+			"next_retry_at": nil,
+		})
+		return nil
+	}
+
+	secret, err := secrets.Decrypt(h.encryptionKey, endpoint.EncryptedSecret)
+	if err != nil {
+		h.markDelivery(db, &delivery, map[string]interface{}{
+			"status":        models.WebhookDeliveryFailed,
+			"last_error":    "서명 secret을 복호화할 수 없습니다",
+			"next_retry_at": nil,
+		})
+		return nil
+	}
+
+	statusCode, body, sendErr := h.send(&endpoint, &delivery, secret)
+	now := time.Now()
+	updates := map[string]interface{}{
+		"attempts":        delivery.Attempts + 1,
+		"response_status": statusCode,
+		"response_body":   body,
+	}
+
+	if sendErr == nil {
+		updates["status"] = models.WebhookDeliverySucceeded
+		updates["delivered_at"] = now
+		updates["last_error"] = ""
+		updates["next_retry_at"] = nil
+		h.markDelivery(db, &delivery, updates)
+
+		db.Model(&endpoint).Updates(map[string]interface{}{
+			"consecutive_failures": 0,
+			"last_delivery_at":     now,
+		})
+		return nil
+	}
+
+	updates["last_error"] = sendErr.Error()
+
+	if webhookRetryPolicy.ShouldRetry(attempt) {
+		updates["status"] = models.WebhookDeliveryRetrying
+		updates["next_retry_at"] = now.Add(webhookRetryPolicy.Backoff(attempt))
+		h.markDelivery(db, &delivery, updates)
+		return fmt.Errorf("webhook delivery %d failed: %w", delivery.ID, sendErr)
+	}
+
+	// 재시도 한도 초과: 실패 확정 후 엔드포인트 연속 실패 집계
+	updates["status"] = models.WebhookDeliveryFailed
+	updates["next_retry_at"] = nil
+	h.markDelivery(db, &delivery, updates)
+
+	failures := endpoint.ConsecutiveFailures + 1
+	endpointUpdates := map[string]interface{}{
+		"consecutive_failures": failures,
+	}
+	if failures >= webhookDisableThreshold {
+		endpointUpdates["active"] = false
+		endpointUpdates["disabled_at"] = now
+		log.Printf("🚫 Webhook endpoint %d disabled after %d consecutive failures", endpoint.ID, failures)
+	}
+	db.Model(&endpoint).Updates(endpointUpdates)
+
+	log.Printf("❌ Webhook delivery %d failed permanently: %v", delivery.ID, sendErr)
+	return nil
+}
+
+// send 서명 헤더를 붙여 POST 전송. 2xx가 아니면 에러 반환
+func (h *WebhookHandler) send(endpoint *models.WebhookEndpoint, delivery *models.WebhookDelivery, secret string) (int, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader([]byte(delivery.Payload)))
+	if err != nil {
+		return 0, "", err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Blueprint-Webhooks/1.0")
+	req.Header.Set("X-Blueprint-Event", string(delivery.EventType))
+	req.Header.Set("X-Blueprint-Event-ID", delivery.EventID)
+	req.Header.Set("X-Blueprint-Delivery", strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set("X-Blueprint-Signature", signWebhookPayload(secret, timestamp, delivery.Payload))
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookMaxResponseLog))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, string(body), fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, string(body), nil
+}
+
+// markDelivery 발송 기록 갱신
+func (h *WebhookHandler) markDelivery(db *gorm.DB, delivery *models.WebhookDelivery, updates map[string]interface{}) {
+	if err := db.Model(delivery).Updates(updates).Error; err != nil {
+		log.Printf("Failed to update webhook delivery %d: %v", delivery.ID, err)
+	}
+}
+
+// signWebhookPayload 서명 헤더 값 생성: t=<unix>,v1=hex(HMAC-SHA256(secret, "<t>.<payload>"))
+func signWebhookPayload(secret, timestamp, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + payload))
+	return fmt.Sprintf("t=%s,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
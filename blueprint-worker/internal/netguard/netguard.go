@@ -0,0 +1,68 @@
+// Package netguard 사용자가 등록한 URL(증거 링크, 웹훅, 푸시 endpoint)로 나가는 요청이 내부망에 닿지 않도록 막는다.
+//
+// 호스트 이름이 내부 주소로 풀리는 경우(DNS 재바인딩 포함)도 막아야 하므로 URL이 아니라
+// 이름 해석 후 실제로 연결하는 주소를 dialer에서 검사한다.
+package netguard
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrBlockedAddress 공인 주소가 아닌 곳으로 연결하려 함
+var ErrBlockedAddress = errors.New("내부 주소로는 접근할 수 없습니다")
+
+// maxRedirects NewHTTPClient가 따르는 최대 리다이렉트 수 (리다이렉트 대상도 같은 dialer로 검사)
+const maxRedirects = 5
+
+// sharedAddressSpace RFC 6598 통신사 NAT 대역 (IsPrivate에 포함되지 않음)
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// IsPublicAddr 루프백/사설/링크 로컬/CGNAT/멀티캐스트/미지정 주소가 아님
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() {
+		return false
+	}
+	return !sharedAddressSpace.Contains(addr)
+}
+
+// Dialer 연결 직전 주소가 공인 주소인지 검사하는 dialer
+func Dialer(timeout time.Duration) *net.Dialer {
+	return &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !IsPublicAddr(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", ErrBlockedAddress, addrPort.Addr())
+			}
+			return nil
+		},
+	}
+}
+
+// NewHTTPClient 공인 주소로만 연결하는 HTTP 클라이언트 (프록시를 쓰지 않고 리다이렉트는 5번까지)
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         Dialer(timeout).DialContext,
+			TLSHandshakeTimeout: timeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return errors.New("리다이렉트가 너무 많습니다")
+			}
+			return nil
+		},
+	}
+}
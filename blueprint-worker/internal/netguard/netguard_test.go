@@ -0,0 +1,41 @@
+package netguard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIsPublicAddr 루프백/사설/링크 로컬/CGNAT/멀티캐스트/미지정 주소는 거부
+func TestIsPublicAddr(t *testing.T) {
+	for _, addr := range []string{"8.8.8.8", "1.1.1.1", "2606:4700::1111", "100.63.255.255", "100.128.0.1"} {
+		assert.True(t, IsPublicAddr(netip.MustParseAddr(addr)), addr)
+	}
+	for _, addr := range []string{
+		"127.0.0.1", "::1", "10.0.0.5", "172.16.0.1", "192.168.1.1", "fd00::1",
+		"169.254.169.254", "fe80::1", "100.64.0.1", "100.127.255.254",
+		"224.0.0.1", "239.255.255.250", "ff02::1", "ff01::1", "ff0e::1",
+		"0.0.0.0", "::", "::ffff:127.0.0.1", "::ffff:100.64.0.1",
+	} {
+		assert.False(t, IsPublicAddr(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+// TestNewHTTPClientRefusesInternalAddress 루프백 서버로는 요청을 보내지 않음
+func TestNewHTTPClientRefusesInternalAddress(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPClient(time.Second).Get(server.URL)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrBlockedAddress)
+	assert.Zero(t, requests)
+}
@@ -0,0 +1,57 @@
+package payout
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"blueprint-worker/internal/config"
+)
+
+// Transfer 지급 계좌로 보낼 송금 한 건
+type Transfer struct {
+	PayoutID      uint   // 제공자 멱등 키로 사용 (같은 요청 재시도 시 중복 송금 방지)
+	Amount        int64  // 센트 (USDC)
+	AccountType   string // "bank", "crypto"
+	BankName      string
+	AccountHolder string
+	CryptoNetwork string
+	Destination   string // 복호화한 계좌번호 또는 지갑 주소
+}
+
+// Provider 창작자 지급 송금 제공자
+type Provider interface {
+	Name() string
+	// Send 송금 후 제공자 거래 참조값 반환
+	Send(ctx context.Context, transfer Transfer) (string, error)
+}
+
+// NewProvider PAYOUT_PROVIDER 설정에 따라 송금 제공자 생성
+func NewProvider(cfg config.PayoutConfig) (Provider, error) {
+	switch strings.ToLower(cfg.Provider) {
+	case "", "stub":
+		return StubProvider{}, nil
+	default:
+		return nil, fmt.Errorf("unknown payout provider: %s", cfg.Provider)
+	}
+}
+
+// StubProvider 실제로 송금하지 않고 성공 처리 (은행/체인 연동 전)
+type StubProvider struct{}
+
+func (StubProvider) Name() string { return "stub" }
+
+func (StubProvider) Send(_ context.Context, transfer Transfer) (string, error) {
+	log.Printf("💸 [payout:stub] payout %d: $%.2f to %s %s", transfer.PayoutID, float64(transfer.Amount)/100, transfer.AccountType, MaskDestination(transfer.Destination))
+	return fmt.Sprintf("stub-%d-%d", transfer.PayoutID, time.Now().Unix()), nil
+}
+
+// MaskDestination 로그용 계좌번호/주소 마스킹 (끝 4자리만 표시)
+func MaskDestination(destination string) string {
+	if len(destination) <= 4 {
+		return "****"
+	}
+	return "****" + destination[len(destination)-4:]
+}
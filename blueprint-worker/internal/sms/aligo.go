@@ -0,0 +1,64 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"blueprint-worker/internal/config"
+)
+
+const aligoEndpoint = "https://apis.aligo.in/send/"
+
+// AligoProvider 알리고 문자 API (국내 번호 형식으로 발송)
+type AligoProvider struct {
+	cfg    config.SMSConfig
+	client *http.Client
+}
+
+func NewAligoProvider(cfg config.SMSConfig) *AligoProvider {
+	return &AligoProvider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (p *AligoProvider) Name() string { return "aligo" }
+
+func (p *AligoProvider) Send(ctx context.Context, to, message string) error {
+	data := url.Values{}
+	data.Set("key", p.cfg.APIKey)
+	data.Set("user_id", p.cfg.AccountID)
+	data.Set("sender", DomesticKR(p.cfg.FromNumber))
+	data.Set("receiver", DomesticKR(to))
+	data.Set("msg", message)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", aligoEndpoint, strings.NewReader(data.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("aligo request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// 알리고는 HTTP 200과 함께 result_code로 성공 여부를 알려준다 (1: 성공)
+	var result struct {
+		ResultCode json.Number `json:"result_code"`
+		Message    string      `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("aligo response parse failed (status %d): %w", resp.StatusCode, err)
+	}
+	if result.ResultCode.String() != "1" {
+		return fmt.Errorf("aligo returned %s: %s", result.ResultCode, result.Message)
+	}
+	return nil
+}
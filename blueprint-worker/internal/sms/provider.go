@@ -0,0 +1,67 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"blueprint-worker/internal/config"
+)
+
+// Provider SMS 발송 제공자 (Twilio, Aligo, Solapi)
+type Provider interface {
+	Name() string
+	Send(ctx context.Context, to, message string) error
+}
+
+// NewProvider SMS_PROVIDER 설정에 따라 발송 제공자 생성
+func NewProvider(cfg config.SMSConfig) (Provider, error) {
+	switch strings.ToLower(cfg.Provider) {
+	case "twilio":
+		if cfg.AccountID == "" || cfg.APISecret == "" {
+			return nil, fmt.Errorf("Twilio account SID / auth token are not configured")
+		}
+		return NewTwilioProvider(cfg), nil
+	case "aligo":
+		if cfg.APIKey == "" || cfg.AccountID == "" {
+			return nil, fmt.Errorf("Aligo API key / user id are not configured")
+		}
+		return NewAligoProvider(cfg), nil
+	case "solapi":
+		if cfg.APIKey == "" || cfg.APISecret == "" {
+			return nil, fmt.Errorf("Solapi API key / secret are not configured")
+		}
+		return NewSolapiProvider(cfg), nil
+	case "", "log":
+		return LogProvider{}, nil
+	default:
+		return nil, fmt.Errorf("unknown SMS provider: %s", cfg.Provider)
+	}
+}
+
+// LogProvider 실제로 보내지 않고 로그만 남김 (개발 환경)
+type LogProvider struct{}
+
+func (LogProvider) Name() string { return "log" }
+
+func (LogProvider) Send(_ context.Context, to, message string) error {
+	log.Printf("📱 [sms:log] to %s: %s", MaskNumber(to), message)
+	return nil
+}
+
+// DomesticKR 국제 형식(+8210...)을 국내 형식(010...)으로 변환 (국내 제공업체용)
+func DomesticKR(number string) string {
+	if rest, found := strings.CutPrefix(number, "+82"); found {
+		return "0" + strings.TrimPrefix(rest, "0")
+	}
+	return number
+}
+
+// MaskNumber 로그용 번호 마스킹 (끝 4자리만 표시)
+func MaskNumber(number string) string {
+	if len(number) <= 4 {
+		return "****"
+	}
+	return strings.Repeat("*", len(number)-4) + number[len(number)-4:]
+}
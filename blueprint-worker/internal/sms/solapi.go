@@ -0,0 +1,86 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"blueprint-worker/internal/config"
+)
+
+const solapiEndpoint = "https://api.solapi.com/messages/v4/send"
+
+// SolapiProvider 솔라피(쿨에스엠에스) 메시지 API (HMAC-SHA256 인증, 국내 번호 형식)
+type SolapiProvider struct {
+	cfg    config.SMSConfig
+	client *http.Client
+}
+
+func NewSolapiProvider(cfg config.SMSConfig) *SolapiProvider {
+	return &SolapiProvider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (p *SolapiProvider) Name() string { return "solapi" }
+
+func (p *SolapiProvider) Send(ctx context.Context, to, message string) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"message": map[string]string{
+			"to":   DomesticKR(to),
+			"from": DomesticKR(p.cfg.FromNumber),
+			"text": message,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	authorization, err := p.authorization(time.Now())
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", solapiEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("solapi request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("solapi returned %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// authorization "HMAC-SHA256 apiKey=..., date=..., salt=..., signature=hex(HMAC(secret, date+salt))"
+func (p *SolapiProvider) authorization(now time.Time) (string, error) {
+	saltBytes := make([]byte, 16)
+	if _, err := rand.Read(saltBytes); err != nil {
+		return "", err
+	}
+	salt := hex.EncodeToString(saltBytes)
+	date := now.UTC().Format(time.RFC3339)
+
+	mac := hmac.New(sha256.New, []byte(p.cfg.APISecret))
+	mac.Write([]byte(date + salt))
+	return fmt.Sprintf("HMAC-SHA256 apiKey=%s, date=%s, salt=%s, signature=%s",
+		p.cfg.APIKey, date, salt, hex.EncodeToString(mac.Sum(nil))), nil
+}
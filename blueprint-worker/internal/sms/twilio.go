@@ -0,0 +1,56 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"blueprint-worker/internal/config"
+)
+
+// TwilioProvider Twilio Programmable Messaging (수신 번호는 E.164 형식)
+type TwilioProvider struct {
+	cfg    config.SMSConfig
+	client *http.Client
+}
+
+func NewTwilioProvider(cfg config.SMSConfig) *TwilioProvider {
+	return &TwilioProvider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (p *TwilioProvider) Name() string { return "twilio" }
+
+func (p *TwilioProvider) Send(ctx context.Context, to, message string) error {
+	apiURL := fmt.Sprintf("https://api.twilio.com/2010-04-01/Accounts/%s/Messages.json", url.PathEscape(p.cfg.AccountID))
+
+	data := url.Values{}
+	data.Set("From", p.cfg.FromNumber)
+	data.Set("To", to)
+	data.Set("Body", message)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(p.cfg.AccountID, p.cfg.APISecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("twilio request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("twilio returned %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+package webpush
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"blueprint-worker/internal/netguard"
+)
+
+var (
+	// ErrSubscriptionGone 구독이 만료/해제됨 (404, 410) - 구독 삭제 필요
+	ErrSubscriptionGone = errors.New("push subscription is no longer valid")
+	// ErrInvalidEndpoint 푸시 서비스로 볼 수 없는 endpoint (https 아님, 내부망 주소) - 구독 삭제 필요
+	ErrInvalidEndpoint = errors.New("push endpoint is not a public https URL")
+)
+
+// Subscription 브라우저 PushSubscription 정보
+type Subscription struct {
+	Endpoint string
+	P256dh   string // base64url 클라이언트 공개키
+	Auth     string // base64url 인증 시크릿
+}
+
+// Sender VAPID 기반 Web Push 발송기 (RFC 8291 aes128gcm + RFC 8292 VAPID)
+type Sender struct {
+	publicKey  string // base64url 비압축 공개키 (65 bytes)
+	privateKey *ecdsa.PrivateKey
+	subject    string // mailto: 또는 https: 연락처
+	client     *http.Client
+}
+
+// NewSender VAPID 키로 발송기 생성 (privateKey는 base64url 32바이트 스칼라)
+func NewSender(publicKey, privateKey, subject string) (*Sender, error) {
+	d, err := decodeBase64(privateKey)
+	if err != nil || len(d) != 32 {
+		return nil, fmt.Errorf("invalid VAPID private key")
+	}
+
+	curve := elliptic.P256()
+	x, y := curve.ScalarBaseMult(d)
+	key := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: curve, X: x, Y: y},
+		D:         new(big.Int).SetBytes(d),
+	}
+
+	return &Sender{
+		publicKey:  publicKey,
+		privateKey: key,
+		subject:    subject,
+		client:     newPushClient(),
+	}, nil
+}
+
+// newPushClient 공인 주소로만 연결하는 HTTP 클라이언트
+//
+// endpoint는 사용자가 등록한 값이라 호스트 이름이 내부망 주소로 풀리는 경우도 막아야 하므로
+// 이름 해석 후 실제 연결하는 주소를 검사한다. 프록시와 리다이렉트는 따르지 않는다.
+func newPushClient() *http.Client {
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext:         netguard.Dialer(5 * time.Second).DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// ValidateEndpoint 푸시 endpoint 형식 검사 (https, 자격 증명 없음, IP 주소면 공인 주소)
+func ValidateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEndpoint, err)
+	}
+	if u.Scheme != "https" || u.Hostname() == "" || u.User != nil {
+		return ErrInvalidEndpoint
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrInvalidEndpoint
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !netguard.IsPublicAddr(addr) {
+		return ErrInvalidEndpoint
+	}
+	return nil
+}
+
+// Send 페이로드를 암호화하여 푸시 서비스로 전송
+func (s *Sender) Send(sub Subscription, payload []byte, ttl time.Duration) error {
+	if err := ValidateEndpoint(sub.Endpoint); err != nil {
+		return err
+	}
+
+	body, err := encrypt(sub, payload)
+	if err != nil {
+		return err
+	}
+
+	token, err := s.vapidToken(sub.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(ttl.Seconds())))
+	req.Header.Set("Urgency", "normal")
+	req.Header.Set("Authorization", fmt.Sprintf("vapid t=%s, k=%s", token, s.publicKey))
+
+	resp, err := s.client.Do(req)
+	if errors.Is(err, netguard.ErrBlockedAddress) {
+		return fmt.Errorf("%w: %v", ErrInvalidEndpoint, err)
+	}
+	if err != nil {
+		return fmt.Errorf("push request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrSubscriptionGone
+	case resp.StatusCode >= 300:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("push service returned %d: %s", resp.StatusCode, string(msg))
+	}
+	return nil
+}
+
+// vapidToken 푸시 서비스 origin 대상 ES256 JWT 생성
+func (s *Sender) vapidToken(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid endpoint: %w", err)
+	}
+
+	header, _ := json.Marshal(map[string]string{"typ": "JWT", "alg": "ES256"})
+	claims, _ := json.Marshal(map[string]interface{}{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": time.Now().Add(12 * time.Hour).Unix(),
+		"sub": s.subject,
+	})
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+
+	r, sig, err := ecdsa.Sign(rand.Reader, s.privateKey, digest[:])
+	if err != nil {
+		return "", err
+	}
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	sig.FillBytes(signature[32:])
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// encrypt RFC 8291 aes128gcm 콘텐츠 암호화 (요청마다 새 서버 키와 salt)
+func encrypt(sub Subscription, payload []byte) ([]byte, error) {
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return encryptWith(sub, payload, asPrivate, salt)
+}
+
+// encryptWith 지정한 서버 키와 salt로 암호화 (RFC 8291 5절 예제 검증용으로 분리)
+func encryptWith(sub Subscription, payload []byte, asPrivate *ecdh.PrivateKey, salt []byte) ([]byte, error) {
+	uaPublicBytes, err := decodeBase64(sub.P256dh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh: %w", err)
+	}
+	authSecret, err := decodeBase64(sub.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth secret: %w", err)
+	}
+
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh: %w", err)
+	}
+	asPublicBytes := asPrivate.PublicKey().Bytes()
+
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	// IKM = HKDF(auth_secret, ecdh_secret, "WebPush: info" || 0x00 || ua_public || as_public)
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublicBytes...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm := hkdf(authSecret, ecdhSecret, keyInfo, 32)
+
+	cek := hkdf(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := hkdf(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// 단일 레코드: payload || 0x02 (마지막 레코드 구분자)
+	plaintext := append(append([]byte{}, payload...), 0x02)
+	ciphertext := gcm.Seal(nil, nonce, plaintext, nil)
+
+	// 헤더: salt(16) || rs(4) || idlen(1) || keyid(as_public)
+	header := make([]byte, 0, 21+len(asPublicBytes))
+	header = append(header, salt...)
+	rs := make([]byte, 4)
+	binary.BigEndian.PutUint32(rs, 4096)
+	header = append(header, rs...)
+	header = append(header, byte(len(asPublicBytes)))
+	header = append(header, asPublicBytes...)
+
+	return append(header, ciphertext...), nil
+}
+
+// hkdf HMAC-SHA256 HKDF (출력 길이 32바이트 이하 전용)
+func hkdf(salt, ikm, info []byte, length int) []byte {
+	extract := hmac.New(sha256.New, salt)
+	extract.Write(ikm)
+	prk := extract.Sum(nil)
+
+	expand := hmac.New(sha256.New, prk)
+	expand.Write(info)
+	expand.Write([]byte{0x01})
+	return expand.Sum(nil)[:length]
+}
+
+// decodeBase64 base64url (패딩 유무 무관) 디코딩
+func decodeBase64(value string) ([]byte, error) {
+	value = strings.TrimRight(value, "=")
+	if decoded, err := base64.RawURLEncoding.DecodeString(value); err == nil {
+		return decoded, nil
+	}
+	return base64.RawStdEncoding.DecodeString(value)
+}